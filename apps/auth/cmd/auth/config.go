@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	sharedconfig "teamflow-shared/config"
+	"teamflow-shared/cors"
+	"teamflow-shared/logging"
+	"teamflow-shared/openapi"
+	"teamflow-shared/server"
+
+	"teamflow-auth/internal/usecase/login"
+)
+
+const (
+	// defaultPort は API の listen ポートの既定値。
+	defaultPort = 8083
+	// defaultTokenTTL は発行する JWT の有効期間の既定値。
+	defaultTokenTTL = time.Hour
+	// defaultIssuer / defaultAudience は発行する JWT の iss / aud の既定値（各サービスの JWT_ISSUER / JWT_AUDIENCE と合わせる）。
+	defaultIssuer   = "teamflow-auth"
+	defaultAudience = "teamflow"
+)
+
+// defaultScopes は OIDC プロバイダに要求するスコープの既定値。
+var defaultScopes = []string{"openid", "email", "profile"}
+
+// config は環境変数から読み込んだ auth サービスの設定。
+type config struct {
+	AppEnv string
+	// LogLevel はログの出力レベル（既定は info）
+	LogLevel slog.Level
+	// Port は API の listen ポート
+	Port int
+	// ShutdownTimeout は SIGINT / SIGTERM を受けてから処理中のリクエストを待つ時間
+	ShutdownTimeout time.Duration
+
+	// TLS はクライアントとの接続の TLS の設定（未設定の場合は平文で待ち受ける）
+	TLS server.TLSOptions
+	// H2C は平文の接続で HTTP/2（h2c）を受け付けるか（TLS を終端したロードバランサー・サービス間の呼び出し用）
+	H2C bool
+
+	// OIDC プロバイダ（IssuerURL の /.well-known/openid-configuration からエンドポイントを取得する）
+	OIDCIssuerURL    string
+	OIDCClientID     string
+	OIDCClientSecret string
+	// OIDCRedirectURL はプロバイダに登録したコールバック（/api/v1/auth/oidc/callback）の URL
+	OIDCRedirectURL string
+	OIDCScopes      []string
+	// OIDCSubjectClaim は TeamFlow の操作者にする ID トークンのクレーム（空の場合は sub）
+	OIDCSubjectClaim string
+	// OIDCWorkspaceClaim はワークスペースにする ID トークンのクレーム（空の場合は既定のワークスペース）
+	OIDCWorkspaceClaim string
+	// LoginTimeout はログインを開始してからコールバックを受け付ける時間
+	LoginTimeout time.Duration
+
+	// 発行する JWT（SigningKeyFile が空の場合は起動時に鍵を生成する。production では必須）
+	SigningKeyFile string
+	TokenIssuer    string
+	TokenAudience  string
+	TokenTTL       time.Duration
+
+	// OpenAPIValidation はリクエスト・レスポンスを OpenAPI の仕様で検証するかどうか（off / log / strict）
+	OpenAPIValidation openapi.Mode
+}
+
+// isProduction は本番環境かどうかを返す。
+func (c config) isProduction() bool {
+	return c.AppEnv == "production"
+}
+
+// addr は API の listen アドレスを返す。
+func (c config) addr() string {
+	return fmt.Sprintf(":%d", c.Port)
+}
+
+// loadConfig は環境変数（と CONFIG_FILE の設定ファイル）から設定を読み込み、検証する。
+// 不正な値はまとめて 1 つのエラーとして返す（起動時にすべて把握できるように）。
+//
+//	CONFIG_FILE             KEY=VALUE 形式の設定ファイル（環境変数に無い値を補う、default: 無し）
+//	APP_ENV                 production の場合は AUTH_SIGNING_KEY_FILE が必須
+//	LOG_LEVEL               ログレベル（debug / info / warn / error、default: info）
+//	PORT                    API の listen ポート（default: 8083）
+//	SHUTDOWN_TIMEOUT        停止時に処理中のリクエストを待つ時間（default: 20s）
+//	TLS_* / H2C             クライアントとの接続の TLS・h2c（server.ParseTLSOptions を参照、default: 平文の HTTP/1.1）
+//	OIDC_ISSUER_URL         OIDC プロバイダの issuer（例: https://accounts.google.com、必須）
+//	OIDC_CLIENT_ID          プロバイダに登録したクライアント ID（必須）
+//	OIDC_CLIENT_SECRET      クライアントシークレット（default: 無し＝公開クライアントとして PKCE のみ）
+//	OIDC_REDIRECT_URL       プロバイダに登録したコールバックの URL（例: https://auth.teamflow.example.com/api/v1/auth/oidc/callback、必須）
+//	OIDC_SCOPES             要求するスコープ（カンマ区切り、openid を含める、default: openid,email,profile）
+//	OIDC_SUBJECT_CLAIM      TeamFlow の操作者（X-User-ID）にする ID トークンのクレーム（default: sub）
+//	OIDC_WORKSPACE_CLAIM    ワークスペース（X-Workspace-ID）にする ID トークンのクレーム（default: 無し＝既定のワークスペース）
+//	OIDC_LOGIN_TIMEOUT      ログインを開始してからコールバックを受け付ける時間（default: 10m）
+//	AUTH_SIGNING_KEY_FILE   JWT の署名鍵（PEM 形式の RSA 秘密鍵）のパス（default: 無し＝起動時に生成、production では必須）
+//	AUTH_ISSUER             発行する JWT の iss（default: teamflow-auth）
+//	AUTH_AUDIENCE           発行する JWT の aud（default: teamflow）
+//	AUTH_TOKEN_TTL          発行する JWT の有効期間（default: 1h）
+//	OPENAPI_VALIDATION      OpenAPI の仕様とのずれの検証（off / log / strict、default: production は off、それ以外は strict）
+func loadConfig(getenv func(string) string) (config, error) {
+	getenv, err := sharedconfig.Load(getenv)
+	if err != nil {
+		return config{}, fmt.Errorf("invalid configuration: %w", err)
+	}
+	p := sharedconfig.NewParser(getenv)
+
+	cfg := config{
+		AppEnv:             p.Get("APP_ENV"),
+		Port:               p.Port("PORT", defaultPort),
+		ShutdownTimeout:    p.Duration("SHUTDOWN_TIMEOUT", server.DefaultShutdownTimeout),
+		OIDCIssuerURL:      p.URL("OIDC_ISSUER_URL"),
+		OIDCClientID:       p.Get("OIDC_CLIENT_ID"),
+		OIDCClientSecret:   p.Get("OIDC_CLIENT_SECRET"),
+		OIDCRedirectURL:    p.URL("OIDC_REDIRECT_URL"),
+		OIDCScopes:         defaultScopes,
+		OIDCSubjectClaim:   p.Get("OIDC_SUBJECT_CLAIM"),
+		OIDCWorkspaceClaim: p.Get("OIDC_WORKSPACE_CLAIM"),
+		LoginTimeout:       p.Duration("OIDC_LOGIN_TIMEOUT", login.DefaultStateTTL),
+		SigningKeyFile:     p.Get("AUTH_SIGNING_KEY_FILE"),
+		TokenIssuer:        p.String("AUTH_ISSUER", defaultIssuer),
+		TokenAudience:      p.String("AUTH_AUDIENCE", defaultAudience),
+		TokenTTL:           p.Duration("AUTH_TOKEN_TTL", defaultTokenTTL),
+	}
+
+	tlsOpts, h2c, err := server.ParseTLSOptions(p.Get)
+	p.Add(err)
+	cfg.TLS, cfg.H2C = tlsOpts, h2c
+
+	// ログインにはプロバイダの設定が欠かせないため、未設定の場合は起動しない
+	const oidcRequired = "the identity provider used for login"
+	if cfg.OIDCIssuerURL == "" {
+		p.Required("OIDC_ISSUER_URL", oidcRequired)
+	}
+	if cfg.OIDCClientID == "" {
+		p.Required("OIDC_CLIENT_ID", oidcRequired)
+	}
+	if cfg.OIDCRedirectURL == "" {
+		p.Required("OIDC_REDIRECT_URL", oidcRequired)
+	}
+	if v := p.Get("OIDC_SCOPES"); v != "" {
+		cfg.OIDCScopes = cors.SplitList(v)
+	}
+	if !slices.Contains(cfg.OIDCScopes, "openid") {
+		p.Errorf("OIDC_SCOPES must include openid, got %q", p.Get("OIDC_SCOPES"))
+	}
+
+	level, err := logging.ParseLevel(p.Get("LOG_LEVEL"))
+	if err != nil {
+		p.Errorf("LOG_LEVEL is invalid: %w", err)
+	}
+	cfg.LogLevel = level
+
+	// 仕様とのずれは開発・テストで早く見つける。本番はレスポンスをバッファしないよう検証しない
+	cfg.OpenAPIValidation = openapi.ModeStrict
+	if cfg.isProduction() {
+		cfg.OpenAPIValidation = openapi.ModeOff
+	}
+	if v := p.Get("OPENAPI_VALIDATION"); v != "" {
+		mode, err := openapi.ParseMode(v)
+		if err != nil {
+			p.Errorf("OPENAPI_VALIDATION %w", err)
+		} else {
+			cfg.OpenAPIValidation = mode
+		}
+	}
+
+	if cfg.SigningKeyFile == "" && cfg.isProduction() {
+		// 起動時に生成した鍵は再起動で変わり、発行済みのトークンが一斉に使えなくなるため、本番では使わない
+		p.Required("AUTH_SIGNING_KEY_FILE", "a generated signing key invalidates issued tokens on restart; set a PEM RSA private key in production")
+	}
+
+	if err := p.Err(); err != nil {
+		return config{}, err
+	}
+	return cfg, nil
+}
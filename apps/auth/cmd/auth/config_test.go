@@ -0,0 +1,133 @@
+package main
+
+import (
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"teamflow-shared/openapi"
+)
+
+func mapEnv(env map[string]string) func(string) string {
+	return func(key string) string { return env[key] }
+}
+
+// requiredEnv はプロバイダの必須の設定。
+func requiredEnv(extra map[string]string) map[string]string {
+	env := map[string]string{
+		"OIDC_ISSUER_URL":   "https://idp.example.com",
+		"OIDC_CLIENT_ID":    "teamflow",
+		"OIDC_REDIRECT_URL": "http://localhost:8083/api/v1/auth/oidc/callback",
+	}
+	for k, v := range extra {
+		env[k] = v
+	}
+	return env
+}
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	cfg, err := loadConfig(mapEnv(requiredEnv(nil)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.addr() != ":8083" || cfg.LogLevel != slog.LevelInfo || cfg.OpenAPIValidation != openapi.ModeStrict {
+		t.Errorf("unexpected defaults: addr=%q level=%v validation=%q", cfg.addr(), cfg.LogLevel, cfg.OpenAPIValidation)
+	}
+	if !reflect.DeepEqual(cfg.OIDCScopes, []string{"openid", "email", "profile"}) {
+		t.Errorf("OIDCScopes = %v", cfg.OIDCScopes)
+	}
+	if cfg.TokenIssuer != "teamflow-auth" || cfg.TokenAudience != "teamflow" || cfg.TokenTTL != time.Hour {
+		t.Errorf("unexpected token defaults: iss=%q aud=%q ttl=%v", cfg.TokenIssuer, cfg.TokenAudience, cfg.TokenTTL)
+	}
+	if cfg.LoginTimeout != 10*time.Minute || cfg.SigningKeyFile != "" {
+		t.Errorf("unexpected defaults: login timeout=%v key file=%q", cfg.LoginTimeout, cfg.SigningKeyFile)
+	}
+}
+
+func TestLoadConfig_Overrides(t *testing.T) {
+	cfg, err := loadConfig(mapEnv(requiredEnv(map[string]string{
+		"OIDC_SCOPES":           "openid, email , groups",
+		"OIDC_WORKSPACE_CLAIM":  "org_id",
+		"AUTH_TOKEN_TTL":        "15m",
+		"AUTH_SIGNING_KEY_FILE": "/run/secrets/jwt.pem",
+		"APP_ENV":               "production",
+	})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.OIDCScopes, []string{"openid", "email", "groups"}) || cfg.OIDCWorkspaceClaim != "org_id" {
+		t.Errorf("unexpected oidc settings: scopes=%v workspace claim=%q", cfg.OIDCScopes, cfg.OIDCWorkspaceClaim)
+	}
+	if cfg.TokenTTL != 15*time.Minute || cfg.OpenAPIValidation != openapi.ModeOff {
+		t.Errorf("unexpected settings: ttl=%v validation=%q", cfg.TokenTTL, cfg.OpenAPIValidation)
+	}
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		wantErrs []string
+	}{
+		{name: "provider is required", env: map[string]string{}, wantErrs: []string{"OIDC_ISSUER_URL", "OIDC_CLIENT_ID", "OIDC_REDIRECT_URL"}},
+		{name: "invalid issuer url", env: requiredEnv(map[string]string{"OIDC_ISSUER_URL": "idp.example.com"}), wantErrs: []string{"OIDC_ISSUER_URL"}},
+		{name: "scopes without openid", env: requiredEnv(map[string]string{"OIDC_SCOPES": "email"}), wantErrs: []string{"OIDC_SCOPES"}},
+		{name: "invalid token ttl", env: requiredEnv(map[string]string{"AUTH_TOKEN_TTL": "forever"}), wantErrs: []string{"AUTH_TOKEN_TTL"}},
+		{name: "production requires signing key", env: requiredEnv(map[string]string{"APP_ENV": "production"}), wantErrs: []string{"AUTH_SIGNING_KEY_FILE must be set"}},
+		{name: "invalid log level", env: requiredEnv(map[string]string{"LOG_LEVEL": "verbose"}), wantErrs: []string{"LOG_LEVEL"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadConfig(mapEnv(tt.env))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected error to mention %q, got %v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadConfig_TLS(t *testing.T) {
+	cfg, err := loadConfig(mapEnv(requiredEnv(nil)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TLS.Enabled() || cfg.H2C {
+		t.Errorf("expected plaintext HTTP/1.1 by default, got %+v h2c=%v", cfg.TLS, cfg.H2C)
+	}
+
+	cfg, err = loadConfig(mapEnv(requiredEnv(map[string]string{
+		"TLS_AUTOCERT_HOSTS":     "api.example.com, www.example.com",
+		"TLS_AUTOCERT_CACHE_DIR": "/var/cache/teamflow/autocert",
+	})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cfg.TLS.AutocertHosts; len(got) != 2 || got[0] != "api.example.com" || got[1] != "www.example.com" {
+		t.Errorf("AutocertHosts = %v, want [api.example.com www.example.com]", got)
+	}
+
+	for name, env := range map[string]map[string]string{
+		"cert without key":     {"TLS_CERT_FILE": "/etc/teamflow/tls.crt"},
+		"autocert without dir": {"TLS_AUTOCERT_HOSTS": "api.example.com"},
+		"h2c with tls":         {"TLS_CERT_FILE": "/etc/teamflow/tls.crt", "TLS_KEY_FILE": "/etc/teamflow/tls.key", "H2C": "true"},
+	} {
+		if _, err := loadConfig(mapEnv(requiredEnv(env))); err == nil || !strings.Contains(err.Error(), "TLS") {
+			t.Errorf("%s: expected TLS error, got %v", name, err)
+		}
+	}
+
+	cfg, err = loadConfig(mapEnv(requiredEnv(map[string]string{"H2C": "true"})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.H2C {
+		t.Error("expected H2C to be enabled")
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"crypto/rsa"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/health"
+	"teamflow-shared/jwt"
+	"teamflow-shared/logging"
+	"teamflow-shared/openapi"
+	"teamflow-shared/server"
+
+	"teamflow-auth/internal/infrastructure/oidc"
+	"teamflow-auth/internal/infrastructure/state"
+	"teamflow-auth/internal/infrastructure/token"
+	httphandler "teamflow-auth/internal/interface/http"
+	"teamflow-auth/internal/usecase/login"
+)
+
+// discoveryTimeout は起動時に OIDC プロバイダのディスカバリ文書を取得する時間の上限。
+const discoveryTimeout = 10 * time.Second
+
+func main() {
+	// ログは JSON 形式で標準出力に出す。レベルは設定を読み込んでから LOG_LEVEL に合わせる
+	var logLevel slog.LevelVar
+	slog.SetDefault(logging.New(os.Stdout, &logLevel))
+
+	cfg, err := loadConfig(os.Getenv)
+	if err != nil {
+		fatal("failed to load configuration", err)
+	}
+	logLevel.Set(cfg.LogLevel)
+
+	// OIDC プロバイダのエンドポイント（認可・トークン・JWKS）は起動時に 1 度だけ取得する
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	ctx, cancel := context.WithTimeout(context.Background(), discoveryTimeout)
+	meta, err := oidc.Discover(ctx, cfg.OIDCIssuerURL, httpClient)
+	cancel()
+	if err != nil {
+		fatal("failed to discover the OIDC provider (check OIDC_ISSUER_URL)", err)
+	}
+	slog.Info("using OIDC provider", "issuer", meta.Issuer, "client_id", cfg.OIDCClientID)
+	provider := oidc.NewProvider(oidc.Config{
+		ClientID:     cfg.OIDCClientID,
+		ClientSecret: cfg.OIDCClientSecret,
+		RedirectURL:  cfg.OIDCRedirectURL,
+		Scopes:       cfg.OIDCScopes,
+	}, meta, httpClient, clock.System)
+
+	// TeamFlow の JWT の署名鍵（AUTH_SIGNING_KEY_FILE が無ければ起動時に生成する）
+	key, err := loadSigningKey(cfg)
+	if err != nil {
+		fatal("failed to load signing key", err)
+	}
+	issuer := token.NewIssuer(key, cfg.TokenIssuer, cfg.TokenAudience, cfg.TokenTTL, clock.System)
+
+	// ユースケース
+	states := state.NewMemoryStore(clock.System)
+	startUC := &login.StartLoginUsecase{Provider: provider, States: states, Clock: clock.System, TTL: cfg.LoginTimeout}
+	completeUC := &login.CompleteLoginUsecase{
+		Provider:       provider,
+		States:         states,
+		Tokens:         issuer,
+		Clock:          clock.System,
+		SubjectClaim:   cfg.OIDCSubjectClaim,
+		WorkspaceClaim: cfg.OIDCWorkspaceClaim,
+	}
+
+	// HTTP ハンドラ（API はすべて /api 配下。パスの振り分けは Router が行う）
+	router := httphandler.NewRouter(httphandler.Handlers{
+		Login:    httphandler.NewLoginHandler(startUC),
+		Callback: httphandler.NewCallbackHandler(completeUC, clock.System),
+	})
+
+	// ヘルスチェック（/livez, /healthz, /readyz）は server.NewMux が登録する。依存先の DB は無い
+	mux := server.NewMux(health.NewChecker(health.DefaultTimeout))
+	mux.Handle(httphandler.APIPrefix+"/", router)
+	// 各サービスは JWKS_URL でここから公開鍵を取得し、発行した JWT を検証する
+	mux.Handle(httphandler.JWKSPath, jwt.Handler(issuer.JWKS()))
+
+	// OpenAPI の仕様（/api/openapi.json）。OPENAPI_VALIDATION が off 以外なら API のリクエスト・レスポンスを仕様で検証する
+	handler, err := withOpenAPI(mux, cfg.OpenAPIValidation)
+	if err != nil {
+		fatal("failed to load OpenAPI spec", err)
+	}
+
+	// TLS_CERT_FILE・TLS_AUTOCERT_HOSTS を設定した場合は TLS で待ち受ける（証明書のファイルは起動時に読み込む）
+	tlsConfig, err := cfg.TLS.Config()
+	if err != nil {
+		fatal("failed to configure TLS (check TLS_CERT_FILE / TLS_KEY_FILE)", err)
+	}
+
+	// リクエスト ID・ログ・セキュリティヘッダ・panic の回復は server.New が順に適用する
+	srv := server.New(handler, server.Options{
+		Addr:      cfg.addr(),
+		Messages:  httphandler.Messages,
+		TLSConfig: tlsConfig,
+		H2C:       cfg.H2C,
+	})
+	slog.Info("auth service listening", "addr", srv.Addr, "tls", tlsConfig != nil, "h2c", cfg.H2C)
+
+	// SIGINT / SIGTERM で graceful shutdown する
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := server.Run(sigCtx, srv, cfg.ShutdownTimeout); err != nil {
+		fatal("http server stopped", err)
+	}
+	slog.Info("auth service stopped")
+}
+
+// loadSigningKey は AUTH_SIGNING_KEY_FILE の署名鍵を読み込む。未設定の場合は鍵を生成する（開発用）。
+func loadSigningKey(cfg config) (*rsa.PrivateKey, error) {
+	if cfg.SigningKeyFile != "" {
+		return token.LoadKey(cfg.SigningKeyFile)
+	}
+	slog.Warn("AUTH_SIGNING_KEY_FILE is not set; generating a signing key (issued tokens become invalid on restart)")
+	return token.GenerateKey()
+}
+
+// withOpenAPI は mux に仕様を返す /api/openapi.json を登録し、mode に応じて仕様で検証するハンドラを返す。
+func withOpenAPI(mux *http.ServeMux, mode openapi.Mode) (http.Handler, error) {
+	spec, err := openapi.Load()
+	if err != nil {
+		return nil, err
+	}
+	specHandler, err := openapi.SpecHandler(spec)
+	if err != nil {
+		return nil, err
+	}
+	mux.Handle(openapi.Path, specHandler)
+	return openapi.Middleware(spec, mode, mux)
+}
+
+// fatal はエラーをログに出力して終了する。
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
@@ -0,0 +1,182 @@
+// Package oidc は OIDC プロバイダ（Google・Okta・Keycloak など）との認可コードフローを実装する。
+//
+// 起動時に Discover で /.well-known/openid-configuration からエンドポイントを取得し、
+// Provider が認可 URL の組み立て・認可コードの交換・ID トークンの検証（プロバイダの JWKS）を行う。
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/jwt"
+
+	"teamflow-auth/internal/usecase/login"
+)
+
+// Metadata はプロバイダのディスカバリ文書（必要な項目のみ）。
+type Metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Discover は issuerURL の /.well-known/openid-configuration を取得する。
+// 文書の issuer が issuerURL と一致しない場合（なりすまし・設定の誤り）はエラーを返す。
+func Discover(ctx context.Context, issuerURL string, httpClient *http.Client) (Metadata, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	issuerURL = strings.TrimSuffix(issuerURL, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuerURL+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return Metadata{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to fetch openid configuration: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return Metadata{}, fmt.Errorf("failed to fetch openid configuration: status %d", res.StatusCode)
+	}
+	var m Metadata
+	if err := json.NewDecoder(res.Body).Decode(&m); err != nil {
+		return Metadata{}, fmt.Errorf("failed to decode openid configuration: %w", err)
+	}
+	if strings.TrimSuffix(m.Issuer, "/") != issuerURL {
+		return Metadata{}, fmt.Errorf("openid configuration issuer %q does not match %q", m.Issuer, issuerURL)
+	}
+	if m.AuthorizationEndpoint == "" || m.TokenEndpoint == "" || m.JWKSURI == "" {
+		return Metadata{}, errors.New("openid configuration lacks authorization_endpoint, token_endpoint or jwks_uri")
+	}
+	return m, nil
+}
+
+// Config はプロバイダに登録したクライアントの設定。
+type Config struct {
+	ClientID string
+	// ClientSecret は任意。空の場合は公開クライアント（PKCE のみ）として client_id だけを送る
+	ClientSecret string
+	// RedirectURL はプロバイダに登録したコールバックの URL
+	RedirectURL string
+	// Scopes は要求するスコープ（openid を含める）
+	Scopes []string
+}
+
+// Provider は login.Provider の実装。
+type Provider struct {
+	cfg        Config
+	meta       Metadata
+	httpClient *http.Client
+	idTokens   *jwt.Verifier
+}
+
+// NewProvider は meta のエンドポイントを使う Provider を生成する。
+// ID トークンは meta.JWKSURI の公開鍵で、iss が meta.Issuer・aud に ClientID を含むことを検証する。
+// httpClient が nil の場合は http.DefaultClient、clk が nil の場合は clock.System。
+func NewProvider(cfg Config, meta Metadata, httpClient *http.Client, clk clock.Clock) *Provider {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Provider{
+		cfg:        cfg,
+		meta:       meta,
+		httpClient: httpClient,
+		idTokens: &jwt.Verifier{
+			Keys:     jwt.NewRemoteKeys(meta.JWKSURI, httpClient, clk, 0),
+			Issuer:   meta.Issuer,
+			Audience: cfg.ClientID,
+			Clock:    clk,
+		},
+	}
+}
+
+// AuthCodeURL は認可エンドポイントの URL（response_type=code、PKCE は S256）を返す。
+func (p *Provider) AuthCodeURL(state, nonce, codeChallenge string) string {
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.cfg.RedirectURL},
+		"scope":                 {strings.Join(p.cfg.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(p.meta.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return p.meta.AuthorizationEndpoint + sep + q.Encode()
+}
+
+// tokenResponse はトークンエンドポイントの応答（必要な項目のみ）。
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange は認可コードと code_verifier をトークンエンドポイントに送り、ID トークンを検証してユーザーを返す。
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (login.Identity, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	if p.cfg.ClientSecret == "" {
+		form.Set("client_id", p.cfg.ClientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return login.Identity{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		// client_secret_basic（RFC 6749 2.3.1 のとおり URL エンコードしてから Basic 認証にする）
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+	res, err := p.httpClient.Do(req)
+	if err != nil {
+		return login.Identity{}, fmt.Errorf("failed to call token endpoint: %w", err)
+	}
+	defer res.Body.Close()
+
+	var body tokenResponse
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return login.Identity{}, fmt.Errorf("failed to decode token response (status %d): %w", res.StatusCode, err)
+	}
+	switch {
+	case res.StatusCode == http.StatusBadRequest && body.Error == "invalid_grant":
+		return login.Identity{}, fmt.Errorf("%w: %s", login.ErrInvalidGrant, body.ErrorDescription)
+	case res.StatusCode != http.StatusOK:
+		return login.Identity{}, fmt.Errorf("token endpoint returned %d: %s %s", res.StatusCode, body.Error, body.ErrorDescription)
+	case body.IDToken == "":
+		return login.Identity{}, fmt.Errorf("%w: token response has no id_token", login.ErrInvalidIdentity)
+	}
+
+	claims, err := p.idTokens.Verify(ctx, body.IDToken)
+	if errors.Is(err, jwt.ErrInvalidToken) {
+		return login.Identity{}, fmt.Errorf("%w: %w", login.ErrInvalidIdentity, err)
+	}
+	if err != nil {
+		return login.Identity{}, err
+	}
+	return login.Identity{
+		Subject: claims.Subject,
+		Email:   claims.Email,
+		Name:    claims.Name,
+		Nonce:   claims.Nonce,
+		Claims:  claims.Raw,
+	}, nil
+}
@@ -0,0 +1,164 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"teamflow-shared/jwt"
+
+	"teamflow-auth/internal/infrastructure/oidc"
+	"teamflow-auth/internal/usecase/login"
+)
+
+// fakeIdP はディスカバリ・JWKS・トークンエンドポイントを持つ OIDC プロバイダ。
+type fakeIdP struct {
+	srv      *httptest.Server
+	key      *rsa.PrivateKey
+	audience string
+	// lastForm はトークンエンドポイントが受け取ったフォーム
+	lastForm url.Values
+	// lastUser / lastPassword は Basic 認証の値
+	lastUser, lastPassword string
+}
+
+func newFakeIdP(t *testing.T) *fakeIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &fakeIdP{key: key, audience: "teamflow-client"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(oidc.Metadata{
+			Issuer:                idp.srv.URL,
+			AuthorizationEndpoint: idp.srv.URL + "/authorize",
+			TokenEndpoint:         idp.srv.URL + "/token",
+			JWKSURI:               idp.srv.URL + "/jwks",
+		})
+	})
+	mux.Handle("/jwks", jwt.Handler(jwt.JWKS{Keys: []jwt.JWK{jwt.NewJWK("idp-key", &key.PublicKey)}}))
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		idp.lastForm = r.PostForm
+		idp.lastUser, idp.lastPassword, _ = r.BasicAuth()
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("code") != "good-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"code expired"}`))
+			return
+		}
+		idToken, err := jwt.Sign(jwt.Claims{
+			Issuer:    idp.srv.URL,
+			Subject:   "idp-user-1",
+			Audience:  jwt.Audience{idp.audience},
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+			Nonce:     "nonce-1",
+			Email:     "taro@example.com",
+		}, key, "idp-key")
+		if err != nil {
+			t.Error(err)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "at", "token_type": "Bearer", "id_token": idToken})
+	})
+	idp.srv = httptest.NewServer(mux)
+	t.Cleanup(idp.srv.Close)
+	return idp
+}
+
+func TestDiscover(t *testing.T) {
+	idp := newFakeIdP(t)
+	meta, err := oidc.Discover(context.Background(), idp.srv.URL+"/", idp.srv.Client())
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+	if meta.TokenEndpoint != idp.srv.URL+"/token" || meta.JWKSURI != idp.srv.URL+"/jwks" {
+		t.Errorf("unexpected metadata %+v", meta)
+	}
+
+	// 文書の issuer と一致しない URL は受け付けない
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, idp.srv.URL+r.URL.Path, http.StatusFound)
+	}))
+	defer other.Close()
+	if _, err := oidc.Discover(context.Background(), other.URL, other.Client()); err == nil {
+		t.Error("expected an issuer mismatch error")
+	}
+}
+
+func TestProvider(t *testing.T) {
+	idp := newFakeIdP(t)
+	meta, err := oidc.Discover(context.Background(), idp.srv.URL, idp.srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := oidc.Config{
+		ClientID:    idp.audience,
+		RedirectURL: "https://auth.teamflow.test/api/v1/auth/oidc/callback",
+		Scopes:      []string{"openid", "email"},
+	}
+	p := oidc.NewProvider(cfg, meta, idp.srv.Client(), nil)
+
+	t.Run("authorization url", func(t *testing.T) {
+		u, err := url.Parse(p.AuthCodeURL("state-1", "nonce-1", "challenge-1"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		q := u.Query()
+		if !strings.HasPrefix(u.String(), idp.srv.URL+"/authorize?") || q.Get("response_type") != "code" ||
+			q.Get("client_id") != idp.audience || q.Get("scope") != "openid email" || q.Get("state") != "state-1" ||
+			q.Get("nonce") != "nonce-1" || q.Get("code_challenge") != "challenge-1" || q.Get("code_challenge_method") != "S256" ||
+			q.Get("redirect_uri") != cfg.RedirectURL {
+			t.Errorf("unexpected authorization url %s", u)
+		}
+	})
+
+	t.Run("public client exchange", func(t *testing.T) {
+		id, err := p.Exchange(context.Background(), "good-code", "verifier-1")
+		if err != nil {
+			t.Fatalf("Exchange: %v", err)
+		}
+		if id.Subject != "idp-user-1" || id.Email != "taro@example.com" || id.Nonce != "nonce-1" || id.Claims["sub"] != "idp-user-1" {
+			t.Errorf("unexpected identity %+v", id)
+		}
+		f := idp.lastForm
+		if f.Get("grant_type") != "authorization_code" || f.Get("code_verifier") != "verifier-1" ||
+			f.Get("client_id") != idp.audience || f.Get("redirect_uri") != cfg.RedirectURL || idp.lastUser != "" {
+			t.Errorf("unexpected token request %v (basic user %q)", f, idp.lastUser)
+		}
+	})
+
+	t.Run("confidential client uses basic auth", func(t *testing.T) {
+		secretCfg := cfg
+		secretCfg.ClientSecret = "s3cr3t"
+		if _, err := oidc.NewProvider(secretCfg, meta, idp.srv.Client(), nil).Exchange(context.Background(), "good-code", "v"); err != nil {
+			t.Fatalf("Exchange: %v", err)
+		}
+		if idp.lastUser != idp.audience || idp.lastPassword != "s3cr3t" || idp.lastForm.Get("client_id") != "" {
+			t.Errorf("unexpected client authentication: user=%q password=%q form=%v", idp.lastUser, idp.lastPassword, idp.lastForm)
+		}
+	})
+
+	t.Run("invalid grant", func(t *testing.T) {
+		if _, err := p.Exchange(context.Background(), "expired-code", "v"); !errors.Is(err, login.ErrInvalidGrant) {
+			t.Errorf("expected ErrInvalidGrant, got %v", err)
+		}
+	})
+
+	t.Run("id token for another client", func(t *testing.T) {
+		otherCfg := cfg
+		otherCfg.ClientID = "another-client"
+		if _, err := oidc.NewProvider(otherCfg, meta, idp.srv.Client(), nil).Exchange(context.Background(), "good-code", "v"); !errors.Is(err, login.ErrInvalidIdentity) {
+			t.Errorf("expected ErrInvalidIdentity, got %v", err)
+		}
+	})
+}
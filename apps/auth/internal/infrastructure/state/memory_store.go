@@ -0,0 +1,55 @@
+// Package state はログインを開始してからコールバックまでの値（state ごとの PendingLogin）を保持する。
+package state
+
+import (
+	"context"
+	"sync"
+
+	"teamflow-shared/clock"
+
+	"teamflow-auth/internal/usecase/login"
+)
+
+// MemoryStore は PendingLogin をメモリに保持する login.StateStore。並行に呼び出してよい。
+// プロセスごとに保持するため、auth サービスを複数台にする場合はコールバックを同じ台に振り分ける必要がある。
+type MemoryStore struct {
+	clock clock.Clock
+
+	mu      sync.Mutex
+	pending map[string]login.PendingLogin
+}
+
+// NewMemoryStore は MemoryStore を生成する。clk が nil の場合は clock.System。
+func NewMemoryStore(clk clock.Clock) *MemoryStore {
+	return &MemoryStore{clock: clock.OrSystem(clk), pending: make(map[string]login.PendingLogin)}
+}
+
+// Save は state の PendingLogin を保存する。保存のたびに期限切れのものを取り除く（完了しないログインで増え続けないように）。
+func (s *MemoryStore) Save(_ context.Context, state string, p login.PendingLogin) error {
+	now := s.clock.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, v := range s.pending {
+		if !now.Before(v.ExpiresAt) {
+			delete(s.pending, k)
+		}
+	}
+	s.pending[state] = p
+	return nil
+}
+
+// Take は state の PendingLogin を取り出して削除する。存在しない・期限切れの場合は login.ErrInvalidState を返す。
+func (s *MemoryStore) Take(_ context.Context, state string) (login.PendingLogin, error) {
+	now := s.clock.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.pending[state]
+	if !ok {
+		return login.PendingLogin{}, login.ErrInvalidState
+	}
+	delete(s.pending, state)
+	if !now.Before(p.ExpiresAt) {
+		return login.PendingLogin{}, login.ErrInvalidState
+	}
+	return p, nil
+}
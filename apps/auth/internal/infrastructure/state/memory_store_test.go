@@ -0,0 +1,41 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"teamflow-shared/clock"
+
+	"teamflow-auth/internal/infrastructure/state"
+	"teamflow-auth/internal/usecase/login"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(now)
+	s := state.NewMemoryStore(clk)
+
+	p := login.PendingLogin{CodeVerifier: "v", Nonce: "n", ExpiresAt: now.Add(time.Minute)}
+	if err := s.Save(ctx, "s1", p); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(ctx, "s2", p); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Take(ctx, "s1")
+	if err != nil || got != p {
+		t.Fatalf("Take() = %+v, %v", got, err)
+	}
+	if _, err := s.Take(ctx, "s1"); !errors.Is(err, login.ErrInvalidState) {
+		t.Errorf("expected ErrInvalidState for a used state, got %v", err)
+	}
+
+	clk.Advance(time.Minute)
+	if _, err := s.Take(ctx, "s2"); !errors.Is(err, login.ErrInvalidState) {
+		t.Errorf("expected ErrInvalidState for an expired state, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+// Package token は TeamFlow の JWT の発行と、検証用の公開鍵（JWKS）を扱う。
+package token
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/jwt"
+)
+
+// keyBits は起動時に生成する署名鍵のビット数。
+const keyBits = 2048
+
+// Issuer は RS256 の署名鍵で TeamFlow の JWT を発行する login.TokenIssuer。
+type Issuer struct {
+	key   *rsa.PrivateKey
+	keyID string
+
+	// Issuer は iss（各サービスの JWT_ISSUER と合わせる）
+	Issuer string
+	// Audience は aud（各サービスの JWT_AUDIENCE と合わせる）
+	Audience string
+	// TTL はトークンの有効期間
+	TTL time.Duration
+	// Clock は任意。nil の場合は clock.System
+	Clock clock.Clock
+}
+
+// NewIssuer は key で署名する Issuer を生成する。kid は公開鍵の JWK Thumbprint。
+func NewIssuer(key *rsa.PrivateKey, issuer, audience string, ttl time.Duration, clk clock.Clock) *Issuer {
+	return &Issuer{key: key, keyID: jwt.KeyID(&key.PublicKey), Issuer: issuer, Audience: audience, TTL: ttl, Clock: clk}
+}
+
+// Issue は claims に iss・aud・iat・exp・jti を設定して署名する。
+func (i *Issuer) Issue(_ context.Context, claims jwt.Claims) (string, time.Time, error) {
+	now := clock.OrSystem(i.Clock).Now()
+	expiresAt := now.Add(i.TTL)
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate jti: %w", err)
+	}
+	claims.Issuer = i.Issuer
+	claims.Audience = jwt.Audience{i.Audience}
+	claims.IssuedAt = now.Unix()
+	claims.NotBefore = now.Unix()
+	claims.ExpiresAt = expiresAt.Unix()
+	claims.ID = base64.RawURLEncoding.EncodeToString(jti)
+	token, err := jwt.Sign(claims, i.key, i.keyID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// JWKS は発行したトークンを検証するための公開鍵の一覧を返す。
+func (i *Issuer) JWKS() jwt.JWKS {
+	return jwt.JWKS{Keys: []jwt.JWK{jwt.NewJWK(i.keyID, &i.key.PublicKey)}}
+}
+
+// GenerateKey は署名鍵を生成する。鍵を保存しないため、再起動すると発行済みのトークンは検証できなくなる（開発用）。
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, keyBits)
+}
+
+// LoadKey は PEM 形式（PKCS #1 の RSA PRIVATE KEY か PKCS #8 の PRIVATE KEY）の RSA 秘密鍵を読み込む。
+func LoadKey(path string) (*rsa.PrivateKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseKey(b)
+}
+
+// ParseKey は PEM 形式の RSA 秘密鍵を読み込む。
+func ParseKey(b []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("expected an RSA private key, got %T", key)
+		}
+		return rsaKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+}
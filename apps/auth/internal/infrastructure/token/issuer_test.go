@@ -0,0 +1,83 @@
+package token_test
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/jwt"
+
+	"teamflow-auth/internal/infrastructure/token"
+)
+
+func TestIssuer(t *testing.T) {
+	key, err := token.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	issuer := token.NewIssuer(key, "teamflow-auth", "teamflow", 15*time.Minute, clock.Fixed(now))
+
+	signed, expiresAt, err := issuer.Issue(context.Background(), jwt.Claims{Subject: "user-1", WorkspaceID: "acme"})
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if !expiresAt.Equal(now.Add(15 * time.Minute)) {
+		t.Errorf("expiresAt = %v", expiresAt)
+	}
+
+	// JWKS で配布する公開鍵で、各サービスと同じ設定（iss / aud）で検証できる
+	jwks := issuer.JWKS()
+	pub, err := jwks.Keys[0].PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := &jwt.Verifier{Keys: jwt.StaticKeys{jwks.Keys[0].Kid: pub}, Issuer: "teamflow-auth", Audience: "teamflow", Clock: clock.Fixed(now)}
+	claims, err := v.Verify(context.Background(), signed)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if claims.Subject != "user-1" || claims.WorkspaceID != "acme" || claims.ID == "" || claims.IssuedAt != now.Unix() {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+}
+
+func TestLoadKey(t *testing.T) {
+	key, err := token.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	for name, block := range map[string]*pem.Block{
+		"pkcs1.pem": {Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)},
+		"pkcs8.pem": {Type: "PRIVATE KEY", Bytes: pkcs8},
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		got, err := token.LoadKey(path)
+		if err != nil {
+			t.Fatalf("LoadKey(%s): %v", name, err)
+		}
+		if !got.Equal(key) {
+			t.Errorf("LoadKey(%s) returned a different key", name)
+		}
+	}
+
+	if _, err := token.ParseKey([]byte("not a pem")); err == nil {
+		t.Error("expected an error for non-PEM input")
+	}
+	if _, err := token.ParseKey(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{1}})); err == nil {
+		t.Error("expected an error for unsupported PEM blocks")
+	}
+}
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/logging"
+
+	"teamflow-auth/internal/usecase/login"
+)
+
+// エラーレスポンスは他のサービスと同じ ErrorResponse / ValidationIssue 形式（teamflow-shared/apierror）で返す。
+
+// writeError は status と ErrorResponse を書き込む。
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	apierror.Write(w, status, apierror.New(code, message))
+}
+
+// writeValidationError は 400 + VALIDATION_ERROR を issues 付きで書き込む。
+func writeValidationError(w http.ResponseWriter, issues ...apierror.ValidationIssue) {
+	apierror.Write(w, http.StatusBadRequest, apierror.New(apierror.CodeValidation, "Invalid request", issues...))
+}
+
+// writeMethodNotAllowed は 405 + METHOD_NOT_ALLOWED を書き込む。
+func writeMethodNotAllowed(w http.ResponseWriter) {
+	writeError(w, http.StatusMethodNotAllowed, apierror.CodeMethodNotAllowed, "method not allowed")
+}
+
+// writeUsecaseError はユースケースのエラーをステータスコードに変換して書き込む。
+//
+//	400  state が存在しない・使用済み・期限切れ（ログインをやり直す）
+//	401  認可コード・ID トークンをプロバイダ・署名の検証が受け付けない
+//	502  その他（プロバイダに問い合わせられないなど）
+func writeUsecaseError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, login.ErrInvalidState):
+		writeValidationError(w, apierror.ValidationIssue{
+			Location: apierror.LocationQuery,
+			Field:    "state",
+			Code:     "INVALID_STATE",
+			Message:  "ログインの有効期限が切れたか、既に完了しています。もう一度ログインしてください。",
+		})
+	case errors.Is(err, login.ErrInvalidGrant),
+		errors.Is(err, login.ErrInvalidIdentity):
+		logging.FromContext(r.Context()).WarnContext(r.Context(), "rejected oidc login", "error", err)
+		writeError(w, http.StatusUnauthorized, apierror.CodeUnauthorized, "failed to verify the login with the identity provider")
+	default:
+		logging.FromContext(r.Context()).ErrorContext(r.Context(), "oidc login failed", "error", err)
+		writeError(w, http.StatusBadGateway, apierror.CodeBadGateway, "failed to complete the login with the identity provider")
+	}
+}
@@ -0,0 +1,98 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/clock"
+
+	"teamflow-auth/internal/usecase/login"
+)
+
+// LoginHandler は GET /auth/oidc/login を処理する HTTP ハンドラ。ユーザーを OIDC プロバイダの認可 URL にリダイレクトする。
+type LoginHandler struct {
+	startUC *login.StartLoginUsecase
+}
+
+// NewLoginHandler は LoginHandler を生成する。
+func NewLoginHandler(startUC *login.StartLoginUsecase) http.Handler {
+	return &LoginHandler{startUC: startUC}
+}
+
+func (h *LoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+	redirectURL, err := h.startUC.Execute(r.Context())
+	if err != nil {
+		writeUsecaseError(w, r, err)
+		return
+	}
+	// 認可 URL の state・nonce はログインごとに異なるため、キャッシュさせない
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, redirectURL, http.StatusFound)
+}
+
+// CallbackHandler は GET /auth/oidc/callback（プロバイダからのリダイレクト）を処理する HTTP ハンドラ。
+// 認可コードを交換して TeamFlow の JWT を返す。
+type CallbackHandler struct {
+	completeUC *login.CompleteLoginUsecase
+	clock      clock.Clock
+}
+
+// NewCallbackHandler は CallbackHandler を生成する。clk が nil の場合は clock.System。
+func NewCallbackHandler(completeUC *login.CompleteLoginUsecase, clk clock.Clock) http.Handler {
+	return &CallbackHandler{completeUC: completeUC, clock: clock.OrSystem(clk)}
+}
+
+// tokenResponse は発行した JWT（OAuth 2.0 のトークンレスポンスと同じ形式を camelCase にしたもの）。
+type tokenResponse struct {
+	AccessToken string `json:"accessToken"`
+	TokenType   string `json:"tokenType"`
+	// ExpiresIn は有効期限までの秒数
+	ExpiresIn int64 `json:"expiresIn"`
+}
+
+func (h *CallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+	q := r.URL.Query()
+	// プロバイダでユーザーが拒否した場合などは code の代わりに error が返る
+	if reason := q.Get("error"); reason != "" {
+		writeError(w, http.StatusUnauthorized, apierror.CodeUnauthorized, "login was rejected by the identity provider: "+reason)
+		return
+	}
+	var issues []apierror.ValidationIssue
+	for _, field := range []string{"code", "state"} {
+		if q.Get(field) == "" {
+			issues = append(issues, apierror.ValidationIssue{
+				Location: apierror.LocationQuery, Field: field, Code: "REQUIRED", Message: field + " は必須です。",
+			})
+		}
+	}
+	if len(issues) > 0 {
+		writeValidationError(w, issues...)
+		return
+	}
+
+	session, err := h.completeUC.Execute(r.Context(), login.CompleteLoginInput{Code: q.Get("code"), State: q.Get("state")})
+	if err != nil {
+		writeUsecaseError(w, r, err)
+		return
+	}
+
+	resp := tokenResponse{
+		AccessToken: session.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(session.ExpiresAt.Sub(h.clock.Now()) / time.Second),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,171 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/clock"
+	"teamflow-shared/jwt"
+
+	"teamflow-auth/internal/infrastructure/state"
+	httpiface "teamflow-auth/internal/interface/http"
+	"teamflow-auth/internal/usecase/login"
+)
+
+var now = time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+// fakeProvider は認可 URL に state を載せ、code が good-code の場合だけログインを受け付ける。
+type fakeProvider struct {
+	nonce string
+	err   error
+}
+
+func (p *fakeProvider) AuthCodeURL(state, nonce, _ string) string {
+	p.nonce = nonce
+	return "https://idp.test/authorize?" + url.Values{"state": {state}}.Encode()
+}
+
+func (p *fakeProvider) Exchange(_ context.Context, code, _ string) (login.Identity, error) {
+	if p.err != nil {
+		return login.Identity{}, p.err
+	}
+	if code != "good-code" {
+		return login.Identity{}, login.ErrInvalidGrant
+	}
+	return login.Identity{Subject: "user-1", Nonce: p.nonce}, nil
+}
+
+type fakeIssuer struct{}
+
+func (fakeIssuer) Issue(_ context.Context, c jwt.Claims) (string, time.Time, error) {
+	return "token-for-" + c.Subject, now.Add(time.Hour), nil
+}
+
+func newRouter(provider *fakeProvider) http.Handler {
+	clk := clock.Fixed(now)
+	states := state.NewMemoryStore(clk)
+	return httpiface.NewRouter(httpiface.Handlers{
+		Login: httpiface.NewLoginHandler(&login.StartLoginUsecase{Provider: provider, States: states, Clock: clk}),
+		Callback: httpiface.NewCallbackHandler(
+			&login.CompleteLoginUsecase{Provider: provider, States: states, Tokens: fakeIssuer{}, Clock: clk}, clk),
+	})
+}
+
+// startLogin は GET /login のリダイレクト先から state を返す。
+func startLogin(t *testing.T, router http.Handler) string {
+	t.Helper()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/login", nil))
+	if w.Code != http.StatusFound {
+		t.Fatalf("status = %d, want 302: %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("Cache-Control = %q", w.Header().Get("Cache-Control"))
+	}
+	u, err := url.Parse(w.Header().Get("Location"))
+	if err != nil || u.Host != "idp.test" {
+		t.Fatalf("unexpected Location %q", w.Header().Get("Location"))
+	}
+	return u.Query().Get("state")
+}
+
+func callback(router http.Handler, query url.Values) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/callback?"+query.Encode(), nil))
+	return w
+}
+
+func TestCallbackHandler(t *testing.T) {
+	router := newRouter(&fakeProvider{})
+	st := startLogin(t, router)
+
+	w := callback(router, url.Values{"code": {"good-code"}, "state": {st}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	var body struct {
+		AccessToken string `json:"accessToken"`
+		TokenType   string `json:"tokenType"`
+		ExpiresIn   int64  `json:"expiresIn"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.AccessToken != "token-for-user-1" || body.TokenType != "Bearer" || body.ExpiresIn != 3600 {
+		t.Errorf("unexpected response %+v", body)
+	}
+}
+
+func TestCallbackHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		providerErr error
+		query       func(state string) url.Values
+		wantStatus  int
+		wantCode    string
+		wantIssue   string
+	}{
+		{name: "missing code", query: func(st string) url.Values { return url.Values{"state": {st}} },
+			wantStatus: http.StatusBadRequest, wantCode: apierror.CodeValidation, wantIssue: "code.REQUIRED"},
+		{name: "unknown state", query: func(string) url.Values { return url.Values{"code": {"good-code"}, "state": {"forged"}} },
+			wantStatus: http.StatusBadRequest, wantCode: apierror.CodeValidation, wantIssue: "state.INVALID_STATE"},
+		{name: "rejected by the user", query: func(st string) url.Values { return url.Values{"error": {"access_denied"}, "state": {st}} },
+			wantStatus: http.StatusUnauthorized, wantCode: apierror.CodeUnauthorized},
+		{name: "invalid code", query: func(st string) url.Values { return url.Values{"code": {"bad-code"}, "state": {st}} },
+			wantStatus: http.StatusUnauthorized, wantCode: apierror.CodeUnauthorized},
+		{name: "provider unavailable", providerErr: errors.New("connection refused"),
+			query:      func(st string) url.Values { return url.Values{"code": {"good-code"}, "state": {st}} },
+			wantStatus: http.StatusBadGateway, wantCode: apierror.CodeBadGateway},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{}
+			router := newRouter(provider)
+			st := startLogin(t, router)
+			provider.err = tt.providerErr
+
+			w := callback(router, tt.query(st))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			var body apierror.ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Error != tt.wantCode {
+				t.Errorf("error = %q, want %q", body.Error, tt.wantCode)
+			}
+			if tt.wantIssue != "" {
+				if body.Details == nil || len(body.Details.Issues) == 0 ||
+					body.Details.Issues[0].Field+"."+body.Details.Issues[0].Code != tt.wantIssue {
+					t.Errorf("unexpected issues %+v, want %s", body.Details, tt.wantIssue)
+				}
+			}
+		})
+	}
+}
+
+func TestRouter(t *testing.T) {
+	router := newRouter(&fakeProvider{})
+	for _, tt := range []struct {
+		method, path string
+		wantStatus   int
+	}{
+		{method: http.MethodGet, path: "/api/auth/oidc/login", wantStatus: http.StatusFound},
+		{method: http.MethodPost, path: "/api/v1/auth/oidc/login", wantStatus: http.StatusMethodNotAllowed},
+		{method: http.MethodGet, path: "/auth/oidc/login", wantStatus: http.StatusNotFound},
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s %s: status = %d, want %d", tt.method, tt.path, w.Code, tt.wantStatus)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package http
+
+import "teamflow-shared/i18n"
+
+// Messages は ValidationIssue の message の翻訳（field.code ごと）。日本語は各ハンドラの文言をそのまま使う。
+// server.Options.Messages に渡し、Accept-Language で英語を求められた場合に使う。
+var Messages = i18n.Merge(i18n.Common, i18n.Catalog{
+	"state.INVALID_STATE": {i18n.English: "The login has expired or was already completed. Please sign in again."},
+})
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"net/http"
+
+	"teamflow-shared/apiversion"
+)
+
+// APIPrefix は auth サービスの API を配置するパス（tasks / projects / users サービスと同じ）。
+const APIPrefix = "/api"
+
+// JWKSPath は発行した JWT の公開鍵（JWKS）を配布するパス。各サービスは JWKS_URL でここを参照する。
+const JWKSPath = "/.well-known/jwks.json"
+
+// Handlers は Router に登録する各エンドポイントのハンドラ。
+type Handlers struct {
+	Login    http.Handler // GET /api/auth/oidc/login
+	Callback http.Handler // GET /api/auth/oidc/callback
+}
+
+// NewRouter は auth サービスの API のルーティングを行うハンドラを返す。
+//
+// API はすべて APIPrefix 配下（/api/v1 と、その別名の /api）に置き、プレフィックスはここで一度だけ取り除く。
+// JWKSPath・/healthz などの運用エンドポイントは含まない。
+func NewRouter(h Handlers) http.Handler {
+	api := http.NewServeMux()
+	api.Handle("/auth/oidc/login", h.Login)
+	api.Handle("/auth/oidc/callback", h.Callback)
+
+	// 正式なパスは /api/v1 配下。バージョン無しの /api 配下は互換のため v1 の別名として扱う
+	return apiversion.Handler(APIPrefix, api)
+}
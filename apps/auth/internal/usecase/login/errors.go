@@ -0,0 +1,18 @@
+// Package login は OIDC プロバイダでのログイン（認可コード + PKCE）と、TeamFlow の JWT の発行を扱う。
+//
+// StartLoginUsecase が state・nonce・PKCE の code_verifier を生成してプロバイダの認可 URL を返し、
+// プロバイダからのリダイレクト（コールバック）で CompleteLoginUsecase が認可コードをトークンに交換する。
+// ID トークンを検証したら、プロバイダのユーザーを操作者（sub）とする TeamFlow の JWT を発行する。
+package login
+
+import "errors"
+
+// Sentinel errors used by login usecases.
+var (
+	// ErrInvalidState は state が存在しない・使用済み・期限切れの場合に返す（HTTP 層で 400）。
+	ErrInvalidState = errors.New("login state is unknown or expired")
+	// ErrInvalidGrant は認可コードをプロバイダが受け付けない（無効・期限切れ・使用済み）場合に返す（HTTP 層で 401）。
+	ErrInvalidGrant = errors.New("authorization code was rejected by the identity provider")
+	// ErrInvalidIdentity は ID トークンが不正（署名・nonce・必要なクレームの欠落など）な場合に返す（HTTP 層で 401）。
+	ErrInvalidIdentity = errors.New("id token from the identity provider is invalid")
+)
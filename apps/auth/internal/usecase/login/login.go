@@ -0,0 +1,181 @@
+package login
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/jwt"
+	"teamflow-shared/workspace"
+)
+
+// DefaultStateTTL はログインを開始してからコールバックを受け付ける時間の既定値。
+const DefaultStateTTL = 10 * time.Minute
+
+// Identity は ID トークンで確認したプロバイダのユーザー。
+type Identity struct {
+	Subject string
+	Email   string
+	Name    string
+	// Nonce は ID トークンの nonce（ログイン開始時の値と一致することを CompleteLoginUsecase が確認する）
+	Nonce string
+	// Claims は ID トークンのすべてのクレーム（SubjectClaim / WorkspaceClaim で読む）
+	Claims map[string]any
+}
+
+// Provider は OIDC プロバイダとのやり取りを担当する抽象。
+type Provider interface {
+	// AuthCodeURL はユーザーをリダイレクトする認可エンドポイントの URL を返す。
+	AuthCodeURL(state, nonce, codeChallenge string) string
+	// Exchange は認可コードをトークンに交換し、ID トークンを検証してユーザーを返す。
+	// コードを受け付けない場合は ErrInvalidGrant、ID トークンが不正な場合は ErrInvalidIdentity を返す。
+	Exchange(ctx context.Context, code, codeVerifier string) (Identity, error)
+}
+
+// PendingLogin はログインを開始してからコールバックまでに保持する値。
+type PendingLogin struct {
+	CodeVerifier string
+	Nonce        string
+	ExpiresAt    time.Time
+}
+
+// StateStore は state ごとの PendingLogin を保持する抽象。
+type StateStore interface {
+	// Save は state の PendingLogin を保存する。
+	Save(ctx context.Context, state string, p PendingLogin) error
+	// Take は state の PendingLogin を取り出して削除する（1 度しか使えない）。存在しない場合は ErrInvalidState を返す。
+	Take(ctx context.Context, state string) (PendingLogin, error)
+}
+
+// TokenIssuer は TeamFlow の JWT を発行する抽象。
+type TokenIssuer interface {
+	// Issue は claims に発行者・対象者・有効期限などを加えて署名し、トークンと有効期限を返す。
+	Issue(ctx context.Context, claims jwt.Claims) (token string, expiresAt time.Time, err error)
+}
+
+// StartLoginUsecase はログインを開始し、プロバイダの認可 URL を返す。
+type StartLoginUsecase struct {
+	Provider Provider
+	States   StateStore
+	// Clock は任意。nil の場合は clock.System
+	Clock clock.Clock
+	// TTL はコールバックを受け付ける時間。0 の場合は DefaultStateTTL
+	TTL time.Duration
+}
+
+// Execute は state・nonce・code_verifier を生成して保存し、ユーザーをリダイレクトする認可 URL を返す。
+func (uc *StartLoginUsecase) Execute(ctx context.Context) (string, error) {
+	state, err := randomString()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return "", err
+	}
+	verifier, err := randomString()
+	if err != nil {
+		return "", err
+	}
+	ttl := uc.TTL
+	if ttl == 0 {
+		ttl = DefaultStateTTL
+	}
+	pending := PendingLogin{
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		ExpiresAt:    clock.OrSystem(uc.Clock).Now().Add(ttl),
+	}
+	if err := uc.States.Save(ctx, state, pending); err != nil {
+		return "", err
+	}
+	return uc.Provider.AuthCodeURL(state, nonce, CodeChallenge(verifier)), nil
+}
+
+// CompleteLoginInput はコールバックのクエリ。
+type CompleteLoginInput struct {
+	Code  string
+	State string
+}
+
+// Session は発行した TeamFlow の JWT。
+type Session struct {
+	AccessToken string
+	ExpiresAt   time.Time
+	UserID      string
+	WorkspaceID string
+}
+
+// CompleteLoginUsecase はコールバックの認可コードをトークンに交換し、TeamFlow の JWT を発行する。
+type CompleteLoginUsecase struct {
+	Provider Provider
+	States   StateStore
+	Tokens   TokenIssuer
+	// Clock は任意。nil の場合は clock.System
+	Clock clock.Clock
+	// SubjectClaim は TeamFlow の操作者（sub）にする ID トークンのクレーム。空の場合は sub
+	SubjectClaim string
+	// WorkspaceClaim はワークスペースにする ID トークンのクレーム。空の場合やクレームが無い場合は既定のワークスペース
+	WorkspaceClaim string
+}
+
+// Execute は state を確認してコードを交換し、ID トークンのユーザーで TeamFlow の JWT を発行する。
+func (uc *CompleteLoginUsecase) Execute(ctx context.Context, in CompleteLoginInput) (*Session, error) {
+	pending, err := uc.States.Take(ctx, in.State)
+	if err != nil {
+		return nil, err
+	}
+	if !clock.OrSystem(uc.Clock).Now().Before(pending.ExpiresAt) {
+		return nil, ErrInvalidState
+	}
+
+	id, err := uc.Provider.Exchange(ctx, in.Code, pending.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+	// 別のログインの ID トークンを差し込まれていないことを確認する
+	if id.Nonce != pending.Nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIdentity)
+	}
+
+	subject := id.Subject
+	if uc.SubjectClaim != "" {
+		subject = stringClaim(id.Claims, uc.SubjectClaim)
+	}
+	if subject == "" {
+		return nil, fmt.Errorf("%w: claim %q is missing", ErrInvalidIdentity, orDefault(uc.SubjectClaim, "sub"))
+	}
+	var workspaceID string
+	if uc.WorkspaceClaim != "" {
+		if v := stringClaim(id.Claims, uc.WorkspaceClaim); v != "" {
+			if workspaceID, err = workspace.Parse(v); err != nil {
+				return nil, fmt.Errorf("%w: claim %q: %w", ErrInvalidIdentity, uc.WorkspaceClaim, err)
+			}
+		}
+	}
+
+	token, expiresAt, err := uc.Tokens.Issue(ctx, jwt.Claims{
+		Subject:     subject,
+		Email:       id.Email,
+		Name:        id.Name,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Session{AccessToken: token, ExpiresAt: expiresAt, UserID: subject, WorkspaceID: workspaceID}, nil
+}
+
+// stringClaim は claims の name が文字列の場合にその値を返す。
+func stringClaim(claims map[string]any, name string) string {
+	s, _ := claims[name].(string)
+	return s
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,192 @@
+package login_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/jwt"
+
+	"teamflow-auth/internal/infrastructure/state"
+	"teamflow-auth/internal/usecase/login"
+)
+
+var now = time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+// fakeProvider は認可 URL のクエリに値を載せ、Exchange で identity を返す。
+type fakeProvider struct {
+	identity      login.Identity
+	err           error
+	gotCode       string
+	gotVerifier   string
+	lastNonce     string
+	lastState     string
+	lastChallenge string
+}
+
+func (p *fakeProvider) AuthCodeURL(state, nonce, challenge string) string {
+	p.lastState, p.lastNonce, p.lastChallenge = state, nonce, challenge
+	return "https://idp.test/authorize?" + url.Values{"state": {state}}.Encode()
+}
+
+func (p *fakeProvider) Exchange(_ context.Context, code, verifier string) (login.Identity, error) {
+	p.gotCode, p.gotVerifier = code, verifier
+	if p.err != nil {
+		return login.Identity{}, p.err
+	}
+	id := p.identity
+	if id.Nonce == "" {
+		id.Nonce = p.lastNonce
+	}
+	return id, nil
+}
+
+// fakeIssuer は受け取ったクレームを記録し、固定のトークンを返す。
+type fakeIssuer struct {
+	claims jwt.Claims
+}
+
+func (i *fakeIssuer) Issue(_ context.Context, c jwt.Claims) (string, time.Time, error) {
+	i.claims = c
+	return "signed-token", now.Add(time.Hour), nil
+}
+
+func setup(provider *fakeProvider) (*login.StartLoginUsecase, *login.CompleteLoginUsecase, *fakeIssuer, *clock.Fake) {
+	clk := clock.NewFake(now)
+	states := state.NewMemoryStore(clk)
+	issuer := &fakeIssuer{}
+	return &login.StartLoginUsecase{Provider: provider, States: states, Clock: clk},
+		&login.CompleteLoginUsecase{Provider: provider, States: states, Tokens: issuer, Clock: clk},
+		issuer, clk
+}
+
+func TestLogin(t *testing.T) {
+	provider := &fakeProvider{identity: login.Identity{Subject: "idp-user-1", Email: "taro@example.com", Name: "Taro"}}
+	start, complete, issuer, _ := setup(provider)
+	ctx := context.Background()
+
+	redirect, err := start.Execute(ctx)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if redirect == "" || provider.lastState == "" || provider.lastNonce == "" || provider.lastState == provider.lastNonce {
+		t.Fatalf("unexpected authorization request: url=%q state=%q nonce=%q", redirect, provider.lastState, provider.lastNonce)
+	}
+
+	session, err := complete.Execute(ctx, login.CompleteLoginInput{Code: "code-1", State: provider.lastState})
+	if err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if provider.gotCode != "code-1" || login.CodeChallenge(provider.gotVerifier) != provider.lastChallenge {
+		t.Errorf("code verifier does not match the challenge: code=%q verifier=%q", provider.gotCode, provider.gotVerifier)
+	}
+	if session.AccessToken != "signed-token" || session.UserID != "idp-user-1" || session.WorkspaceID != "" {
+		t.Errorf("unexpected session %+v", session)
+	}
+	if issuer.claims.Subject != "idp-user-1" || issuer.claims.Email != "taro@example.com" || issuer.claims.Name != "Taro" {
+		t.Errorf("unexpected claims %+v", issuer.claims)
+	}
+
+	// state は 1 度しか使えない
+	if _, err := complete.Execute(ctx, login.CompleteLoginInput{Code: "code-1", State: provider.lastState}); !errors.Is(err, login.ErrInvalidState) {
+		t.Errorf("expected ErrInvalidState on replay, got %v", err)
+	}
+}
+
+func TestCompleteLogin_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unknown state", func(t *testing.T) {
+		_, complete, _, _ := setup(&fakeProvider{})
+		if _, err := complete.Execute(ctx, login.CompleteLoginInput{Code: "c", State: "unknown"}); !errors.Is(err, login.ErrInvalidState) {
+			t.Errorf("expected ErrInvalidState, got %v", err)
+		}
+	})
+
+	t.Run("expired state", func(t *testing.T) {
+		provider := &fakeProvider{identity: login.Identity{Subject: "u"}}
+		start, complete, _, clk := setup(provider)
+		if _, err := start.Execute(ctx); err != nil {
+			t.Fatal(err)
+		}
+		clk.Advance(login.DefaultStateTTL)
+		if _, err := complete.Execute(ctx, login.CompleteLoginInput{Code: "c", State: provider.lastState}); !errors.Is(err, login.ErrInvalidState) {
+			t.Errorf("expected ErrInvalidState, got %v", err)
+		}
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		provider := &fakeProvider{identity: login.Identity{Subject: "u", Nonce: "other-login"}}
+		start, complete, _, _ := setup(provider)
+		if _, err := start.Execute(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := complete.Execute(ctx, login.CompleteLoginInput{Code: "c", State: provider.lastState}); !errors.Is(err, login.ErrInvalidIdentity) {
+			t.Errorf("expected ErrInvalidIdentity, got %v", err)
+		}
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		provider := &fakeProvider{err: login.ErrInvalidGrant}
+		start, complete, _, _ := setup(provider)
+		if _, err := start.Execute(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := complete.Execute(ctx, login.CompleteLoginInput{Code: "c", State: provider.lastState}); !errors.Is(err, login.ErrInvalidGrant) {
+			t.Errorf("expected ErrInvalidGrant, got %v", err)
+		}
+	})
+}
+
+func TestCompleteLogin_Claims(t *testing.T) {
+	ctx := context.Background()
+	claims := map[string]any{"sub": "idp-1", "preferred_username": "taro", "org_id": "acme", "bad_ws": "not a workspace!"}
+
+	tests := []struct {
+		name           string
+		subjectClaim   string
+		workspaceClaim string
+		wantUser       string
+		wantWorkspace  string
+		wantErr        error
+	}{
+		{name: "custom subject claim", subjectClaim: "preferred_username", wantUser: "taro"},
+		{name: "workspace claim", workspaceClaim: "org_id", wantUser: "idp-1", wantWorkspace: "acme"},
+		{name: "missing workspace claim uses default", workspaceClaim: "tenant", wantUser: "idp-1"},
+		{name: "missing subject claim", subjectClaim: "employee_id", wantErr: login.ErrInvalidIdentity},
+		{name: "invalid workspace", workspaceClaim: "bad_ws", wantErr: login.ErrInvalidIdentity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{identity: login.Identity{Subject: "idp-1", Claims: claims}}
+			start, complete, _, _ := setup(provider)
+			complete.SubjectClaim, complete.WorkspaceClaim = tt.subjectClaim, tt.workspaceClaim
+			if _, err := start.Execute(ctx); err != nil {
+				t.Fatal(err)
+			}
+			session, err := complete.Execute(ctx, login.CompleteLoginInput{Code: "c", State: provider.lastState})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if session.UserID != tt.wantUser || session.WorkspaceID != tt.wantWorkspace {
+				t.Errorf("user, workspace = %q, %q, want %q, %q", session.UserID, session.WorkspaceID, tt.wantUser, tt.wantWorkspace)
+			}
+		})
+	}
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// RFC 7636 Appendix B の例
+	if got := login.CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"); got != "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM" {
+		t.Errorf("CodeChallenge() = %q", got)
+	}
+}
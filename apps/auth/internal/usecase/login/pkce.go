@@ -0,0 +1,26 @@
+package login
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+// randomBytes は state・nonce・code_verifier のランダム部分のバイト数（base64url で 43 文字）。
+const randomBytes = 32
+
+// randomString は推測できないランダムな文字列（base64url、パディングなし）を返す。
+func randomString() (string, error) {
+	b := make([]byte, randomBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random value: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CodeChallenge は PKCE の code_verifier から code_challenge（S256）を返す（RFC 7636）。
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -1,6 +1,6 @@
 import { NextRequest, NextResponse } from "next/server";
 
-const PROJECTS_SERVICE_BASE = "http://localhost:8080";
+const PROJECTS_SERVICE_BASE = "http://localhost:8080/api";
 
 export async function POST(req: NextRequest) {
   try {
@@ -59,8 +59,8 @@ export default function DevProjectsPage() {
       const text = await res.text();
       if (!res.ok) throw new Error(text);
 
-      const data = JSON.parse(text) as Project[];
-      setProjects(data);
+      const data = JSON.parse(text) as { projects: Project[] };
+      setProjects(data.projects);
     } catch (err: unknown) {
       const message = err instanceof Error ? err.message : String(err);
       setListError(message);
@@ -7,7 +7,7 @@ import { CreateTaskForm } from "./CreateTaskForm";
 
 // Server-side: use process.env directly (not NEXT_PUBLIC_)
 const PROJECTS_BASE =
-  process.env.PROJECTS_BASE ?? process.env.NEXT_PUBLIC_PROJECTS_BASE ?? "http://localhost:8080";
+  process.env.PROJECTS_BASE ?? process.env.NEXT_PUBLIC_PROJECTS_BASE ?? "http://localhost:8080/api";
 const TASKS_BASE =
   process.env.TASKS_BASE ?? process.env.NEXT_PUBLIC_TASKS_BASE ?? "http://localhost:8081/api";
 
@@ -31,7 +31,8 @@ type Task = {
 };
 
 async function fetchProjects(): Promise<Project[]> {
-  return apiFetch<Project[]>(`${PROJECTS_BASE}/projects`);
+  const data = await apiFetch<{ projects: Project[] }>(`${PROJECTS_BASE}/projects`);
+  return data.projects;
 }
 
 async function fetchTasksByProject(projectId: string): Promise<Task[]> {
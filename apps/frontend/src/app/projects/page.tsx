@@ -11,14 +11,15 @@ type Project = {
 // ここから下は前回のままでOK（fetchProjects などは変更なし）
 
 async function fetchProjects(): Promise<Project[]> {
-  const res = await fetch("http://localhost:8080/projects", {
+  const res = await fetch("http://localhost:8080/api/projects", {
     cache: "no-store",
   });
   if (!res.ok) {
     const text = await res.text();
     throw new Error(`Failed to load projects: ${res.status} ${text}`);
   }
-  return res.json();
+  const data = (await res.json()) as { projects: Project[] };
+  return data.projects;
 }
 
 export default async function ProjectsPage() {
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"time"
+
+	sharedconfig "teamflow-shared/config"
+	"teamflow-shared/logging"
+	"teamflow-shared/server"
+
+	graphqlhandler "teamflow-gateway/internal/interface/graphql"
+)
+
+const (
+	// defaultPort は API の listen ポートの既定値。
+	defaultPort = 8084
+	// defaultUpstreamTimeout は projects / tasks サービスへの 1 回の呼び出しのタイムアウトの既定値。
+	defaultUpstreamTimeout = 10 * time.Second
+	// defaultJWTIssuer / defaultJWTAudience は受け付ける JWT の iss / aud の既定値（auth サービスの AUTH_ISSUER / AUTH_AUDIENCE と合わせる）。
+	defaultJWTIssuer   = "teamflow-auth"
+	defaultJWTAudience = "teamflow"
+)
+
+// config は環境変数から読み込んだ gateway サービスの設定。
+type config struct {
+	// LogLevel はログの出力レベル（既定は info）
+	LogLevel slog.Level
+	// Port は API の listen ポート
+	Port int
+	// ShutdownTimeout は SIGINT / SIGTERM を受けてから処理中のリクエストを待つ時間
+	ShutdownTimeout time.Duration
+
+	// TLS はクライアントとの接続の TLS の設定（未設定の場合は平文で待ち受ける）
+	TLS server.TLSOptions
+	// H2C は平文の接続で HTTP/2（h2c）を受け付けるか（TLS を終端したロードバランサー・サービス間の呼び出し用）
+	H2C bool
+
+	// 呼び出し先のサービス（プロジェクトは REST、タスクは gRPC）
+	ProjectsServiceURL string
+	TasksGRPCAddr      string
+	// ServiceAPIKey は projects / tasks サービスの呼び出しに付けるキー
+	ServiceAPIKey string
+	// UpstreamTimeout は projects / tasks サービスへの 1 回の呼び出しのタイムアウト
+	UpstreamTimeout time.Duration
+
+	// MaxDepth は GraphQL のクエリのネストの深さの上限
+	MaxDepth int
+
+	// JWT（auth サービスが OIDC のログインで発行する）の検証（JWKSURL が空の場合は検証しない）
+	JWKSURL     string
+	JWTIssuer   string
+	JWTAudience string
+}
+
+// addr は API の listen アドレスを返す。
+func (c config) addr() string {
+	return fmt.Sprintf(":%d", c.Port)
+}
+
+// loadConfig は環境変数（と CONFIG_FILE の設定ファイル）から設定を読み込み、検証する。
+// 不正な値はまとめて 1 つのエラーとして返す（起動時にすべて把握できるように）。
+//
+//	CONFIG_FILE             KEY=VALUE 形式の設定ファイル（環境変数に無い値を補う、default: 無し）
+//	LOG_LEVEL               ログレベル（debug / info / warn / error、default: info）
+//	PORT                    API の listen ポート（default: 8084）
+//	SHUTDOWN_TIMEOUT        停止時に処理中のリクエストを待つ時間（default: 20s）
+//	TLS_* / H2C             クライアントとの接続の TLS・h2c（server.ParseTLSOptions を参照、default: 平文の HTTP/1.1）
+//	PROJECTS_SERVICE_URL    projects サービスのベース URL（例: http://projects:8080、必須）
+//	TASKS_GRPC_ADDR         tasks サービスの gRPC の API のアドレス（tasks の GRPC_PORT、例: tasks:9091、必須）
+//	SERVICE_API_KEY         projects / tasks サービスの呼び出しに付けるキー（各サービスの SERVICE_API_KEYS に登録したもの、default: 無し）
+//	UPSTREAM_TIMEOUT        projects / tasks サービスへの 1 回の呼び出しのタイムアウト（default: 10s）
+//	GRAPHQL_MAX_DEPTH       GraphQL のクエリのネストの深さの上限（default: 10）
+//	JWKS_URL                JWT を検証する公開鍵（auth サービスの /.well-known/jwks.json、例: http://auth:8083/.well-known/jwks.json、default: 無し＝検証しない）
+//	JWT_ISSUER              受け付ける JWT の iss（default: teamflow-auth）
+//	JWT_AUDIENCE            受け付ける JWT の aud（default: teamflow）
+func loadConfig(getenv func(string) string) (config, error) {
+	getenv, err := sharedconfig.Load(getenv)
+	if err != nil {
+		return config{}, fmt.Errorf("invalid configuration: %w", err)
+	}
+	p := sharedconfig.NewParser(getenv)
+
+	cfg := config{
+		Port:               p.Port("PORT", defaultPort),
+		ShutdownTimeout:    p.Duration("SHUTDOWN_TIMEOUT", server.DefaultShutdownTimeout),
+		ProjectsServiceURL: p.URL("PROJECTS_SERVICE_URL"),
+		TasksGRPCAddr:      p.Get("TASKS_GRPC_ADDR"),
+		ServiceAPIKey:      p.Get("SERVICE_API_KEY"),
+		UpstreamTimeout:    p.Duration("UPSTREAM_TIMEOUT", defaultUpstreamTimeout),
+		MaxDepth:           p.NonNegativeInt("GRAPHQL_MAX_DEPTH", graphqlhandler.DefaultMaxDepth),
+		JWKSURL:            p.URL("JWKS_URL"),
+		JWTIssuer:          p.String("JWT_ISSUER", defaultJWTIssuer),
+		JWTAudience:        p.String("JWT_AUDIENCE", defaultJWTAudience),
+	}
+
+	tlsOpts, h2c, err := server.ParseTLSOptions(p.Get)
+	p.Add(err)
+	cfg.TLS, cfg.H2C = tlsOpts, h2c
+
+	// プロジェクト・タスクはすべて呼び出し先のサービスから取得するため、未設定の場合は起動しない
+	if cfg.ProjectsServiceURL == "" {
+		p.Required("PROJECTS_SERVICE_URL", "projects are fetched from the projects service")
+	}
+	if cfg.TasksGRPCAddr == "" {
+		p.Required("TASKS_GRPC_ADDR", "tasks are fetched from the tasks service's gRPC API")
+	} else if _, _, err := net.SplitHostPort(cfg.TasksGRPCAddr); err != nil {
+		p.Errorf("TASKS_GRPC_ADDR must be host:port, got %q", cfg.TasksGRPCAddr)
+	}
+	if cfg.MaxDepth == 0 {
+		p.Errorf("GRAPHQL_MAX_DEPTH must be positive")
+	}
+
+	level, err := logging.ParseLevel(p.Get("LOG_LEVEL"))
+	if err != nil {
+		p.Errorf("LOG_LEVEL is invalid: %w", err)
+	}
+	cfg.LogLevel = level
+
+	if err := p.Err(); err != nil {
+		return config{}, err
+	}
+	return cfg, nil
+}
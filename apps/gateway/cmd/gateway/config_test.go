@@ -0,0 +1,117 @@
+package main
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mapEnv(env map[string]string) func(string) string {
+	return func(key string) string { return env[key] }
+}
+
+// requiredEnv は呼び出し先のサービスの必須の設定。
+func requiredEnv(extra map[string]string) map[string]string {
+	env := map[string]string{
+		"PROJECTS_SERVICE_URL": "http://projects:8080",
+		"TASKS_GRPC_ADDR":      "tasks:9091",
+	}
+	for k, v := range extra {
+		env[k] = v
+	}
+	return env
+}
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	cfg, err := loadConfig(mapEnv(requiredEnv(nil)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.addr() != ":8084" || cfg.LogLevel != slog.LevelInfo || cfg.MaxDepth != 10 || cfg.UpstreamTimeout != 10*time.Second {
+		t.Errorf("unexpected defaults: addr=%q level=%v depth=%d timeout=%v", cfg.addr(), cfg.LogLevel, cfg.MaxDepth, cfg.UpstreamTimeout)
+	}
+	if cfg.JWKSURL != "" || cfg.JWTIssuer != "teamflow-auth" || cfg.JWTAudience != "teamflow" {
+		t.Errorf("unexpected jwt defaults: jwks=%q iss=%q aud=%q", cfg.JWKSURL, cfg.JWTIssuer, cfg.JWTAudience)
+	}
+}
+
+func TestLoadConfig_Overrides(t *testing.T) {
+	cfg, err := loadConfig(mapEnv(requiredEnv(map[string]string{
+		"PORT":              "9000",
+		"SERVICE_API_KEY":   "s3cr3t",
+		"UPSTREAM_TIMEOUT":  "3s",
+		"GRAPHQL_MAX_DEPTH": "6",
+		"JWKS_URL":          "http://auth:8083/.well-known/jwks.json",
+	})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.addr() != ":9000" || cfg.ServiceAPIKey != "s3cr3t" || cfg.UpstreamTimeout != 3*time.Second || cfg.MaxDepth != 6 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if cfg.JWKSURL != "http://auth:8083/.well-known/jwks.json" {
+		t.Errorf("JWKSURL = %q", cfg.JWKSURL)
+	}
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"projects service missing", map[string]string{"TASKS_GRPC_ADDR": "tasks:9091"}, "PROJECTS_SERVICE_URL must be set"},
+		{"tasks address missing", map[string]string{"PROJECTS_SERVICE_URL": "http://projects:8080"}, "TASKS_GRPC_ADDR must be set"},
+		{"tasks address without port", requiredEnv(map[string]string{"TASKS_GRPC_ADDR": "tasks"}), "TASKS_GRPC_ADDR must be host:port"},
+		{"zero depth", requiredEnv(map[string]string{"GRAPHQL_MAX_DEPTH": "0"}), "GRAPHQL_MAX_DEPTH must be positive"},
+		{"invalid log level", requiredEnv(map[string]string{"LOG_LEVEL": "verbose"}), "LOG_LEVEL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadConfig(mapEnv(tt.env))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_TLS(t *testing.T) {
+	cfg, err := loadConfig(mapEnv(requiredEnv(nil)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TLS.Enabled() || cfg.H2C {
+		t.Errorf("expected plaintext HTTP/1.1 by default, got %+v h2c=%v", cfg.TLS, cfg.H2C)
+	}
+
+	cfg, err = loadConfig(mapEnv(requiredEnv(map[string]string{
+		"TLS_AUTOCERT_HOSTS":     "api.example.com, www.example.com",
+		"TLS_AUTOCERT_CACHE_DIR": "/var/cache/teamflow/autocert",
+	})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cfg.TLS.AutocertHosts; len(got) != 2 || got[0] != "api.example.com" || got[1] != "www.example.com" {
+		t.Errorf("AutocertHosts = %v, want [api.example.com www.example.com]", got)
+	}
+
+	for name, env := range map[string]map[string]string{
+		"cert without key":     {"TLS_CERT_FILE": "/etc/teamflow/tls.crt"},
+		"autocert without dir": {"TLS_AUTOCERT_HOSTS": "api.example.com"},
+		"h2c with tls":         {"TLS_CERT_FILE": "/etc/teamflow/tls.crt", "TLS_KEY_FILE": "/etc/teamflow/tls.key", "H2C": "true"},
+	} {
+		if _, err := loadConfig(mapEnv(requiredEnv(env))); err == nil || !strings.Contains(err.Error(), "TLS") {
+			t.Errorf("%s: expected TLS error, got %v", name, err)
+		}
+	}
+
+	cfg, err = loadConfig(mapEnv(requiredEnv(map[string]string{"H2C": "true"})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.H2C {
+		t.Error("expected H2C to be enabled")
+	}
+}
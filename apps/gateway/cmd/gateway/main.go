@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"teamflow-shared/health"
+	"teamflow-shared/jwt"
+	"teamflow-shared/logging"
+	tasksv1 "teamflow-shared/proto/tasks/v1"
+	"teamflow-shared/requestid"
+	"teamflow-shared/server"
+	"teamflow-shared/serviceauth"
+	"teamflow-shared/workspace"
+
+	"teamflow-gateway/internal/infrastructure/upstream"
+	graphqlhandler "teamflow-gateway/internal/interface/graphql"
+)
+
+func main() {
+	// ログは JSON 形式で標準出力に出す。レベルは設定を読み込んでから LOG_LEVEL に合わせる
+	var logLevel slog.LevelVar
+	slog.SetDefault(logging.New(os.Stdout, &logLevel))
+
+	cfg, err := loadConfig(os.Getenv)
+	if err != nil {
+		fatal("failed to load configuration", err)
+	}
+	logLevel.Set(cfg.LogLevel)
+
+	// プロジェクトは projects サービスの REST API から取得する（SERVICE_API_KEY で認証される）
+	projects := upstream.NewProjectsClient(cfg.ProjectsServiceURL, &http.Client{
+		Timeout:   cfg.UpstreamTimeout,
+		Transport: &requestid.Transport{Base: &serviceauth.Transport{Key: cfg.ServiceAPIKey}},
+	})
+	// タスクは tasks サービスの gRPC API から取得する（接続は最初の呼び出しで確立する）
+	conn, err := upstream.DialTasks(cfg.TasksGRPCAddr, cfg.ServiceAPIKey,
+		grpc.WithChainUnaryInterceptor(timeoutUnary(cfg.UpstreamTimeout)))
+	if err != nil {
+		fatal("failed to create tasks client (check TASKS_GRPC_ADDR)", err)
+	}
+	defer conn.Close()
+	slog.Info("using upstream services", "projects", cfg.ProjectsServiceURL, "tasks", cfg.TasksGRPCAddr)
+
+	schema, err := graphqlhandler.NewSchema(&graphqlhandler.Resolver{
+		ProjectsService: projects,
+		TasksService:    tasksv1.NewTaskServiceClient(conn),
+	}, cfg.MaxDepth)
+	if err != nil {
+		fatal("failed to parse graphql schema", err)
+	}
+
+	// ヘルスチェック（/livez, /healthz, /readyz）は server.NewMux が登録する。依存先の DB は無い
+	mux := server.NewMux(health.NewChecker(health.DefaultTimeout))
+	// Authorization: Bearer の JWT（auth サービスが OIDC のログインで発行する）を検証する Verifier（JWKS_URL が空なら nil）
+	jwtVerifier := jwt.NewRemoteVerifier(cfg.JWKSURL, cfg.JWTIssuer, cfg.JWTAudience)
+	// クエリはワークスペース（JWT の workspace_id）ごとに分離し、呼び出し先のサービスにそのまま引き継ぐ。
+	// JWT を検証しない場合は、X-User-ID と同じくヘッダをそのまま使う
+	mux.Handle(graphqlhandler.Path, workspace.Middleware(jwtVerifier != nil, nil, graphqlhandler.NewHandler(schema)))
+
+	// JWT を JWKS_URL の公開鍵で検証し、sub を操作者にする。トークンの無いリクエストの X-User-ID / X-Workspace-ID は使わない
+	handler := jwt.Middleware(jwtVerifier, nil, mux)
+
+	// TLS_CERT_FILE・TLS_AUTOCERT_HOSTS を設定した場合は TLS で待ち受ける（証明書のファイルは起動時に読み込む）
+	tlsConfig, err := cfg.TLS.Config()
+	if err != nil {
+		fatal("failed to configure TLS (check TLS_CERT_FILE / TLS_KEY_FILE)", err)
+	}
+
+	// リクエスト ID・ログ・セキュリティヘッダ・panic の回復は server.New が順に適用する
+	srv := server.New(handler, server.Options{
+		Addr:      cfg.addr(),
+		TLSConfig: tlsConfig,
+		H2C:       cfg.H2C,
+	})
+	slog.Info("gateway service listening", "addr", srv.Addr, "tls", tlsConfig != nil, "h2c", cfg.H2C)
+
+	// SIGINT / SIGTERM で graceful shutdown する
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := server.Run(sigCtx, srv, cfg.ShutdownTimeout); err != nil {
+		fatal("http server stopped", err)
+	}
+	slog.Info("gateway service stopped")
+}
+
+// timeoutUnary は tasks サービスへの 1 回の呼び出しに timeout を設定する（HTTP クライアントの Timeout と合わせる）。
+func timeoutUnary(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// fatal はエラーをログに出力して終了する。
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
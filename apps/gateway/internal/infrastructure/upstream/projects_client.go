@@ -0,0 +1,46 @@
+// Package upstream は gateway サービスが呼び出す projects / tasks サービスのクライアントを提供する。
+package upstream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"teamflow-shared/authz"
+	"teamflow-shared/client"
+)
+
+// ProjectsClient は projects サービスの HTTP API クライアント（teamflow-shared/client のラッパー）。
+// 元のリクエストの操作者（authz.ContextWithActor）を X-User-ID で送る。
+type ProjectsClient struct {
+	api *client.Client
+}
+
+// NewProjectsClient は baseURL（例: http://projects:8080）の projects サービスに接続する ProjectsClient を生成する。
+// httpClient が nil の場合は client.New の既定のクライアントを使う。
+func NewProjectsClient(baseURL string, httpClient *http.Client) *ProjectsClient {
+	return &ProjectsClient{api: client.New(baseURL, httpClient)}
+}
+
+// ListProjects はプロジェクトの一覧を 1 ページ取得する。
+func (c *ProjectsClient) ListProjects(ctx context.Context, opts client.ListProjectsOptions, cursor string) (*client.ProjectPage, error) {
+	page, err := c.asActor(ctx).ListProjects(ctx, opts, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("projects client: %w", err)
+	}
+	return page, nil
+}
+
+// GetProject はプロジェクトを取得する。
+func (c *ProjectsClient) GetProject(ctx context.Context, projectID string) (*client.Project, error) {
+	p, err := c.asActor(ctx).GetProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("projects client: %w", err)
+	}
+	return p, nil
+}
+
+// asActor は ctx の操作者を X-User-ID で送る Client を返す。
+func (c *ProjectsClient) asActor(ctx context.Context) *client.Client {
+	return c.api.WithActor(authz.ActorFromContext(ctx))
+}
@@ -0,0 +1,51 @@
+package upstream
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"teamflow-shared/authz"
+	"teamflow-shared/serviceauth"
+	"teamflow-shared/workspace"
+)
+
+// DialTasks は addr（例: tasks:9091）の tasks サービスの gRPC API への接続を生成する（接続は最初の呼び出しで確立する）。
+// すべての呼び出しに、serviceKey（空の場合は送らない）と ctx のワークスペース・操作者をメタデータで付ける。
+// サービス間の通信はクラスタ内のため TLS を使わない。
+func DialTasks(addr, serviceKey string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	m := &tasksMetadata{serviceKey: serviceKey}
+	opts = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(m.unary),
+		grpc.WithChainStreamInterceptor(m.stream),
+	}, opts...)
+	return grpc.NewClient(addr, opts...)
+}
+
+// tasksMetadata は tasks サービスが受け取るメタデータ（HTTP のヘッダ名の小文字）を付ける。
+type tasksMetadata struct {
+	serviceKey string
+}
+
+func (m *tasksMetadata) outgoing(ctx context.Context) context.Context {
+	kv := []string{strings.ToLower(workspace.Header), workspace.FromContext(ctx)}
+	if m.serviceKey != "" {
+		kv = append(kv, strings.ToLower(serviceauth.Header), m.serviceKey)
+	}
+	if actor := authz.ActorFromContext(ctx); actor != "" {
+		kv = append(kv, strings.ToLower(authz.ActorHeader), actor)
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+func (m *tasksMetadata) unary(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(m.outgoing(ctx), method, req, reply, cc, opts...)
+}
+
+func (m *tasksMetadata) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(m.outgoing(ctx), desc, cc, method, opts...)
+}
@@ -0,0 +1,73 @@
+package upstream_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	"teamflow-shared/authz"
+	tasksv1 "teamflow-shared/proto/tasks/v1"
+	"teamflow-shared/workspace"
+
+	"teamflow-gateway/internal/infrastructure/upstream"
+)
+
+// recordingTasks は受け取ったメタデータを記録する TaskServiceServer。
+type recordingTasks struct {
+	tasksv1.UnimplementedTaskServiceServer
+	md metadata.MD
+}
+
+func (s *recordingTasks) BatchListTasks(ctx context.Context, _ *tasksv1.BatchListTasksRequest) (*tasksv1.BatchListTasksResponse, error) {
+	s.md, _ = metadata.FromIncomingContext(ctx)
+	return &tasksv1.BatchListTasksResponse{}, nil
+}
+
+func dial(t *testing.T, serviceKey string) (tasksv1.TaskServiceClient, *recordingTasks) {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	rec := &recordingTasks{}
+	srv := grpc.NewServer()
+	tasksv1.RegisterTaskServiceServer(srv, rec)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := upstream.DialTasks("passthrough:///bufnet", serviceKey,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }))
+	if err != nil {
+		t.Fatalf("DialTasks failed: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return tasksv1.NewTaskServiceClient(conn), rec
+}
+
+func TestDialTasks_ForwardsMetadata(t *testing.T) {
+	client, rec := dial(t, "s3cr3t")
+	ctx := authz.ContextWithActor(workspace.NewContext(context.Background(), "acme"), "user-1")
+	if _, err := client.BatchListTasks(ctx, &tasksv1.BatchListTasksRequest{ProjectIds: []string{"p1"}}); err != nil {
+		t.Fatalf("BatchListTasks failed: %v", err)
+	}
+	for key, want := range map[string]string{"x-service-key": "s3cr3t", "x-workspace-id": "acme", "x-user-id": "user-1"} {
+		if got := rec.md.Get(key); len(got) != 1 || got[0] != want {
+			t.Errorf("metadata %s = %v, want %q", key, got, want)
+		}
+	}
+}
+
+func TestDialTasks_OmitsEmptyValues(t *testing.T) {
+	client, rec := dial(t, "")
+	if _, err := client.BatchListTasks(context.Background(), &tasksv1.BatchListTasksRequest{ProjectIds: []string{"p1"}}); err != nil {
+		t.Fatalf("BatchListTasks failed: %v", err)
+	}
+	if len(rec.md.Get("x-service-key")) != 0 || len(rec.md.Get("x-user-id")) != 0 {
+		t.Errorf("expected no service key / actor, got %v", rec.md)
+	}
+	// ワークスペースは常に送る（未設定の場合は既定のワークスペース）
+	if got := rec.md.Get("x-workspace-id"); len(got) != 1 || got[0] != workspace.DefaultID {
+		t.Errorf("x-workspace-id = %v", got)
+	}
+}
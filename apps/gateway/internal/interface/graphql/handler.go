@@ -0,0 +1,104 @@
+// Package graphql は gateway サービスの GraphQL API（schema.graphql）を提供する。
+//
+// プロジェクトは projects サービスの REST API、タスクは tasks サービスの gRPC API から取得する。
+// 一覧の各プロジェクトのタスクは taskLoader がまとめて取得し、プロジェクトごとの呼び出し（N+1）を避ける。
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/authz"
+	"teamflow-shared/logging"
+)
+
+// Path は GraphQL のエンドポイント。
+const Path = "/graphql"
+
+// maxRequestBytes はリクエスト本文（クエリと変数）の上限。
+const maxRequestBytes = 1 << 20
+
+// DefaultMaxDepth はクエリのネストの深さの既定の上限。
+const DefaultMaxDepth = 10
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// NewSchema は r をルートのリゾルバにしたスキーマを生成する。maxDepth はクエリのネストの深さの上限。
+func NewSchema(r *Resolver, maxDepth int) (*graphql.Schema, error) {
+	return graphql.ParseSchema(schemaSDL, r,
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(maxDepth),
+		graphql.Logger(panicLogger{}),
+	)
+}
+
+// request は GraphQL のリクエスト本文。
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Handler は POST /graphql のハンドラ。
+type Handler struct {
+	schema *graphql.Schema
+}
+
+// NewHandler は schema のクエリを実行する Handler を生成する。
+func NewHandler(schema *graphql.Schema) *Handler {
+	return &Handler{schema: schema}
+}
+
+// ServeHTTP は JSON の本文（query / operationName / variables）のクエリを実行し、結果を返す。
+// クエリの実行時のエラー（呼び出し先のサービスのエラーを含む）は GraphQL の慣例どおり 200 の errors で返す。
+//
+//	400  本文が JSON でない、query が無い（VALIDATION_ERROR）
+//	405  POST 以外（METHOD_NOT_ALLOWED）
+//	413  本文が大きすぎる（VALIDATION_ERROR）
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		apierror.Write(w, http.StatusMethodNotAllowed, apierror.New(apierror.CodeMethodNotAllowed, "use POST"))
+		return
+	}
+
+	var req request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			apierror.Write(w, http.StatusRequestEntityTooLarge, apierror.New(apierror.CodeValidation, "request body is too large"))
+			return
+		}
+		apierror.Write(w, http.StatusBadRequest, apierror.New(apierror.CodeValidation, "request body must be a JSON object"))
+		return
+	}
+	if req.Query == "" {
+		apierror.Write(w, http.StatusBadRequest, apierror.New(apierror.CodeValidation, "query is required"))
+		return
+	}
+
+	// 呼び出し先のサービスには元のリクエストの操作者（X-User-ID）を引き継ぐ
+	ctx := authz.ContextWithActor(r.Context(), r.Header.Get(authz.ActorHeader))
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "failed to write graphql response", "error", err)
+	}
+}
+
+// panicLogger はリゾルバの panic を slog に出力する（graphql-go はフィールドのエラーにして実行を続ける）。
+type panicLogger struct{}
+
+func (panicLogger) LogPanic(ctx context.Context, value any) {
+	slog.ErrorContext(ctx, "panic recovered in graphql resolver", "panic", value, "stack", string(debug.Stack()))
+}
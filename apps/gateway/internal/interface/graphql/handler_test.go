@@ -0,0 +1,303 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"teamflow-shared/authz"
+	"teamflow-shared/client"
+	tasksv1 "teamflow-shared/proto/tasks/v1"
+
+	graphqlhandler "teamflow-gateway/internal/interface/graphql"
+)
+
+// fakeProjects は固定のプロジェクトを返す ProjectsService。
+type fakeProjects struct {
+	projects []client.Project
+
+	mu     sync.Mutex
+	opts   []client.ListProjectsOptions
+	actors []string
+}
+
+func (f *fakeProjects) ListProjects(ctx context.Context, opts client.ListProjectsOptions, _ string) (*client.ProjectPage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.opts = append(f.opts, opts)
+	f.actors = append(f.actors, authz.ActorFromContext(ctx))
+	return &client.ProjectPage{Projects: f.projects}, nil
+}
+
+func (f *fakeProjects) GetProject(_ context.Context, projectID string) (*client.Project, error) {
+	for _, p := range f.projects {
+		if p.ID == projectID {
+			return &p, nil
+		}
+	}
+	return nil, &client.APIError{StatusCode: http.StatusNotFound, Code: "NOT_FOUND", Message: "project not found"}
+}
+
+// fakeTasks はプロジェクトごとの固定のタスクを status で絞り込んで返す TasksService。呼び出しを記録する。
+type fakeTasks struct {
+	tasks map[string][]*tasksv1.Task
+	err   error
+
+	mu    sync.Mutex
+	calls []*tasksv1.BatchListTasksRequest
+}
+
+func (f *fakeTasks) BatchListTasks(_ context.Context, in *tasksv1.BatchListTasksRequest, _ ...grpc.CallOption) (*tasksv1.BatchListTasksResponse, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, in)
+	f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	resp := &tasksv1.BatchListTasksResponse{}
+	for _, id := range in.GetProjectIds() {
+		tasks, ok := f.tasks[id]
+		if !ok {
+			continue
+		}
+		p := &tasksv1.ProjectTasks{ProjectId: id}
+		for _, t := range tasks {
+			if statuses := in.GetFilter().GetStatuses(); len(statuses) == 0 || slices.Contains(statuses, t.GetStatus()) {
+				p.Tasks = append(p.Tasks, t)
+			}
+		}
+		resp.Projects = append(resp.Projects, p)
+	}
+	return resp, nil
+}
+
+func (f *fakeTasks) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.calls)
+}
+
+var created = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newFakes() (*fakeProjects, *fakeTasks) {
+	projects := &fakeProjects{}
+	tasks := &fakeTasks{tasks: map[string][]*tasksv1.Task{}}
+	for _, id := range []string{"p1", "p2", "p3"} {
+		projects.projects = append(projects.projects, client.Project{ID: id, Name: "Project " + id, Status: "active", Visibility: "private", CreatedAt: created, UpdatedAt: created})
+	}
+	assignee := "11111111-1111-1111-1111-111111111111"
+	tasks.tasks["p1"] = []*tasksv1.Task{
+		{Id: "t1", ProjectId: "p1", Number: 1, Title: "設計", Status: "todo", Priority: "high", AssigneeId: &assignee, AssigneeName: "Alice",
+			DueDate: timestamppb.New(created.AddDate(0, 1, 0)), CreatedAt: timestamppb.New(created), UpdatedAt: timestamppb.New(created), LabelIds: []string{"bug"}},
+		{Id: "t2", ProjectId: "p1", Number: 2, Title: "実装", Status: "done", Priority: "low", CreatedAt: timestamppb.New(created), UpdatedAt: timestamppb.New(created)},
+	}
+	tasks.tasks["p2"] = []*tasksv1.Task{
+		{Id: "t3", ProjectId: "p2", Number: 1, Title: "調査", Status: "todo", Priority: "medium", CreatedAt: timestamppb.New(created), UpdatedAt: timestamppb.New(created)},
+	}
+	// p3 は tasks サービスで閲覧できない（結果に含まれない）
+	return projects, tasks
+}
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Path       []any          `json:"path"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+// post は query を POST /graphql で実行する。
+func post(t *testing.T, h http.Handler, query string, variables map[string]any) (int, response) {
+	t.Helper()
+	body, _ := json.Marshal(map[string]any{"query": query, "variables": variables})
+	req := httptest.NewRequest(http.MethodPost, graphqlhandler.Path, strings.NewReader(string(body)))
+	req.Header.Set(authz.ActorHeader, "actor-1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var resp response
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid response %s: %v", rec.Body.String(), err)
+		}
+	}
+	return rec.Code, resp
+}
+
+func newHandler(t *testing.T, projects *fakeProjects, tasks *fakeTasks) http.Handler {
+	t.Helper()
+	schema, err := graphqlhandler.NewSchema(&graphqlhandler.Resolver{ProjectsService: projects, TasksService: tasks}, graphqlhandler.DefaultMaxDepth)
+	if err != nil {
+		t.Fatalf("NewSchema failed: %v", err)
+	}
+	return graphqlhandler.NewHandler(schema)
+}
+
+func TestHandler_ProjectsWithTasksAreBatched(t *testing.T) {
+	projects, tasks := newFakes()
+	h := newHandler(t, projects, tasks)
+
+	code, resp := post(t, h, `query($statuses: [String!]) {
+		projects(q: "web", archived: false, first: 10) {
+			id name createdAt
+			tasks(statuses: $statuses, first: 20) { id number title assigneeId assigneeName dueDate estimate labelIds }
+		}
+	}`, map[string]any{"statuses": []string{"todo"}})
+	if code != http.StatusOK || len(resp.Errors) > 0 {
+		t.Fatalf("unexpected response: %d %+v", code, resp.Errors)
+	}
+
+	var data struct {
+		Projects []struct {
+			ID        string
+			CreatedAt string
+			Tasks     []struct {
+				ID           string
+				Number       int
+				AssigneeID   *string
+				AssigneeName *string
+				DueDate      *string
+				Estimate     *int
+				LabelIDs     []string
+			}
+		}
+	}
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Projects) != 3 || data.Projects[0].CreatedAt != "2025-01-01T12:00:00Z" {
+		t.Fatalf("unexpected projects: %s", resp.Data)
+	}
+	p1 := data.Projects[0].Tasks
+	if len(p1) != 1 || p1[0].ID != "t1" || p1[0].AssigneeName == nil || *p1[0].AssigneeName != "Alice" ||
+		p1[0].DueDate == nil || p1[0].Estimate != nil || len(p1[0].LabelIDs) != 1 {
+		t.Errorf("unexpected tasks of p1: %s", resp.Data)
+	}
+	if len(data.Projects[1].Tasks) != 1 || len(data.Projects[2].Tasks) != 0 {
+		t.Errorf("unexpected tasks of p2 / p3: %s", resp.Data)
+	}
+
+	// 3 つのプロジェクトのタスクを 1 回の呼び出しで取得する
+	if tasks.callCount() != 1 {
+		t.Fatalf("expected 1 BatchListTasks call, got %d", tasks.callCount())
+	}
+	call := tasks.calls[0]
+	if !slices.Equal(call.GetProjectIds(), []string{"p1", "p2", "p3"}) ||
+		!slices.Equal(call.GetFilter().GetStatuses(), []string{"todo"}) || call.GetFilter().GetPageSize() != 20 {
+		t.Errorf("unexpected BatchListTasks request: %v", call)
+	}
+	if o := projects.opts[0]; o.Query != "web" || o.Archived == nil || *o.Archived || o.Limit != 10 {
+		t.Errorf("unexpected ListProjects options: %+v", o)
+	}
+	if projects.actors[0] != "actor-1" {
+		t.Errorf("expected the actor to be forwarded, got %q", projects.actors[0])
+	}
+}
+
+func TestHandler_TasksWithDifferentArgumentsAreBatchedSeparately(t *testing.T) {
+	projects, tasks := newFakes()
+	h := newHandler(t, projects, tasks)
+
+	code, resp := post(t, h, `{
+		projects {
+			id
+			open: tasks(statuses: ["todo"]) { id }
+			done: tasks(statuses: ["done"]) { id }
+			again: tasks(statuses: ["todo"]) { title }
+		}
+	}`, nil)
+	if code != http.StatusOK || len(resp.Errors) > 0 {
+		t.Fatalf("unexpected response: %d %+v", code, resp.Errors)
+	}
+	// 引数ごとに 1 回（同じ引数の again は open の結果を使う）
+	if tasks.callCount() != 2 {
+		t.Fatalf("expected 2 BatchListTasks calls, got %d", tasks.callCount())
+	}
+	var data struct {
+		Projects []struct {
+			Open, Done, Again []struct{ ID, Title string }
+		}
+	}
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	if p1 := data.Projects[0]; len(p1.Open) != 1 || len(p1.Done) != 1 || p1.Done[0].ID != "t2" || len(p1.Again) != 1 || p1.Again[0].Title != "設計" {
+		t.Errorf("unexpected tasks of p1: %s", resp.Data)
+	}
+}
+
+func TestHandler_Project(t *testing.T) {
+	projects, tasks := newFakes()
+	h := newHandler(t, projects, tasks)
+
+	code, resp := post(t, h, `{ project(id: "p2") { name tasks { title } } missing: project(id: "nope") { name } }`, nil)
+	if code != http.StatusOK || len(resp.Errors) > 0 {
+		t.Fatalf("unexpected response: %d %+v", code, resp.Errors)
+	}
+	want := `{"project":{"name":"Project p2","tasks":[{"title":"調査"}]},"missing":null}`
+	if string(resp.Data) != want {
+		t.Errorf("expected %s, got %s", want, resp.Data)
+	}
+	if tasks.callCount() != 1 || !slices.Equal(tasks.calls[0].GetProjectIds(), []string{"p2"}) {
+		t.Errorf("unexpected BatchListTasks calls: %v", tasks.calls)
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	projects, tasks := newFakes()
+	tasks.err = status.Error(codes.InvalidArgument, "invalid status filter")
+	h := newHandler(t, projects, tasks)
+
+	// 呼び出し先の引数のエラーは extensions.code で伝え、プロジェクトは返す
+	code, resp := post(t, h, `{ projects { id tasks(statuses: ["bogus"]) { id } } }`, nil)
+	if code != http.StatusOK || len(resp.Errors) == 0 || resp.Errors[0].Extensions["code"] != "VALIDATION_ERROR" || resp.Errors[0].Message != "invalid status filter" {
+		t.Fatalf("expected VALIDATION_ERROR, got %d %+v", code, resp.Errors)
+	}
+
+	// 呼び出し先の障害は詳細を隠して BAD_GATEWAY
+	tasks.err = status.Error(codes.Unavailable, "connection refused")
+	_, resp = post(t, h, `{ projects { tasks { id } } }`, nil)
+	if len(resp.Errors) == 0 || resp.Errors[0].Extensions["code"] != "BAD_GATEWAY" || strings.Contains(resp.Errors[0].Message, "refused") {
+		t.Fatalf("expected BAD_GATEWAY, got %+v", resp.Errors)
+	}
+
+	_, resp = post(t, h, `{ projects(first: 201) { id } }`, nil)
+	if len(resp.Errors) == 0 || resp.Errors[0].Extensions["code"] != "VALIDATION_ERROR" {
+		t.Fatalf("expected VALIDATION_ERROR for first, got %+v", resp.Errors)
+	}
+
+	// スキーマに無いフィールドは実行せずに errors を返す
+	_, resp = post(t, h, `{ projects { unknown } }`, nil)
+	if len(resp.Errors) == 0 || len(projects.opts) != 2 {
+		t.Fatalf("expected a validation error without calling projects, got %+v (%d calls)", resp.Errors, len(projects.opts))
+	}
+
+	for _, tt := range []struct {
+		name, method, body string
+		want               int
+	}{
+		{"get", http.MethodGet, "", http.StatusMethodNotAllowed},
+		{"not json", http.MethodPost, "query", http.StatusBadRequest},
+		{"no query", http.MethodPost, `{"variables":{}}`, http.StatusBadRequest},
+		{"too large", http.MethodPost, `{"query":"` + strings.Repeat(" ", 1<<20) + `"}`, http.StatusRequestEntityTooLarge},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, graphqlhandler.Path, strings.NewReader(tt.body)))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	tasksv1 "teamflow-shared/proto/tasks/v1"
+)
+
+// taskLoader は 1 回のクエリで取得したプロジェクト（一覧の兄弟）のタスクをまとめて取得する。
+//
+// 最初に tasks を解決したプロジェクトが、同じ引数のすべてのプロジェクトの分を BatchListTasks の 1 回の呼び出しで取得し、
+// 他のプロジェクトはその結果を待って使う。プロジェクトごとに tasks サービスを呼ぶ N+1 を避けるため、
+// リクエストごとに Query のリゾルバが生成する（結果をリクエストをまたいでキャッシュしない）。
+type taskLoader struct {
+	tasks      TasksService
+	projectIDs []string
+
+	mu      sync.Mutex
+	batches map[string]*taskBatch
+}
+
+// taskBatch は 1 つの引数（フィルタ）の取得結果。
+type taskBatch struct {
+	once  sync.Once
+	tasks map[string][]*tasksv1.Task
+	err   error
+}
+
+// newTaskLoader は projectIDs のタスクをまとめて取得する taskLoader を生成する。
+// projectIDs は tasks サービスの BatchListTasks の上限（200 件）以下にする（projects の first の上限と同じ）。
+func newTaskLoader(tasks TasksService, projectIDs []string) *taskLoader {
+	return &taskLoader{tasks: tasks, projectIDs: projectIDs, batches: make(map[string]*taskBatch)}
+}
+
+// load は projectID のタスクを filter で取得する。同じ filter の 2 回目以降は最初の取得結果を返す。
+func (l *taskLoader) load(ctx context.Context, projectID string, filter *tasksv1.ListTasksRequest) ([]*tasksv1.Task, error) {
+	// 同じ引数かどうかはフィルタのメッセージのバイト列で判定する
+	key, err := proto.MarshalOptions{Deterministic: true}.Marshal(filter)
+	if err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	b, ok := l.batches[string(key)]
+	if !ok {
+		b = &taskBatch{}
+		l.batches[string(key)] = b
+	}
+	l.mu.Unlock()
+
+	b.once.Do(func() {
+		b.tasks, b.err = l.fetch(ctx, filter)
+	})
+	if b.err != nil {
+		return nil, b.err
+	}
+	// 存在しない・閲覧できないプロジェクトは tasks サービスが結果から除くため、タスクなしとして扱う
+	return b.tasks[projectID], nil
+}
+
+// fetch はすべてのプロジェクトのタスクを BatchListTasks で取得する。
+func (l *taskLoader) fetch(ctx context.Context, filter *tasksv1.ListTasksRequest) (map[string][]*tasksv1.Task, error) {
+	tasks := make(map[string][]*tasksv1.Task, len(l.projectIDs))
+	if len(l.projectIDs) == 0 {
+		return tasks, nil
+	}
+	resp, err := l.tasks.BatchListTasks(ctx, &tasksv1.BatchListTasksRequest{ProjectIds: l.projectIDs, Filter: filter})
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range resp.GetProjects() {
+		tasks[p.GetProjectId()] = p.GetTasks()
+	}
+	return tasks, nil
+}
@@ -0,0 +1,287 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/client"
+	"teamflow-shared/logging"
+	tasksv1 "teamflow-shared/proto/tasks/v1"
+)
+
+// ProjectsService は projects サービスのプロジェクトの取得（upstream.ProjectsClient が実装する）。
+type ProjectsService interface {
+	ListProjects(ctx context.Context, opts client.ListProjectsOptions, cursor string) (*client.ProjectPage, error)
+	GetProject(ctx context.Context, projectID string) (*client.Project, error)
+}
+
+// TasksService は tasks サービスのタスクの取得（tasksv1.TaskServiceClient の一部）。
+type TasksService interface {
+	BatchListTasks(ctx context.Context, in *tasksv1.BatchListTasksRequest, opts ...grpc.CallOption) (*tasksv1.BatchListTasksResponse, error)
+}
+
+// maxFirst は projects / tasks の first の上限（各サービスの 1 ページの最大件数）。
+const maxFirst = 200
+
+// Resolver はスキーマのルート（Query）のリゾルバ。
+type Resolver struct {
+	ProjectsService ProjectsService
+	TasksService    TasksService
+}
+
+type projectsArgs struct {
+	Q        *string
+	Status   *string
+	Archived *bool
+	Sort     *string
+	First    int32
+}
+
+// Projects はプロジェクトの一覧を返す。各プロジェクトの tasks は 1 つの taskLoader でまとめて取得する。
+func (r *Resolver) Projects(ctx context.Context, args projectsArgs) ([]*projectResolver, error) {
+	first, err := firstArg(args.First)
+	if err != nil {
+		return nil, err
+	}
+	page, err := r.ProjectsService.ListProjects(ctx, client.ListProjectsOptions{
+		Query:    deref(args.Q),
+		Archived: args.Archived,
+		Status:   deref(args.Status),
+		Sort:     deref(args.Sort),
+		Limit:    first,
+	}, "")
+	if err != nil {
+		return nil, upstreamError(ctx, "projects", err)
+	}
+
+	ids := make([]string, len(page.Projects))
+	for i, p := range page.Projects {
+		ids[i] = p.ID
+	}
+	loader := newTaskLoader(r.TasksService, ids)
+	projects := make([]*projectResolver, len(page.Projects))
+	for i := range page.Projects {
+		projects[i] = &projectResolver{p: &page.Projects[i], tasks: loader}
+	}
+	return projects, nil
+}
+
+// Project はプロジェクトを返す。存在しない・閲覧できない場合は null。
+func (r *Resolver) Project(ctx context.Context, args struct{ ID graphql.ID }) (*projectResolver, error) {
+	p, err := r.ProjectsService.GetProject(ctx, string(args.ID))
+	if client.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, upstreamError(ctx, "projects", err)
+	}
+	return &projectResolver{p: p, tasks: newTaskLoader(r.TasksService, []string{p.ID})}, nil
+}
+
+// projectResolver は Project のリゾルバ。
+type projectResolver struct {
+	p     *client.Project
+	tasks *taskLoader
+}
+
+func (r *projectResolver) ID() graphql.ID            { return graphql.ID(r.p.ID) }
+func (r *projectResolver) Key() *string              { return optional(r.p.Key) }
+func (r *projectResolver) Name() string              { return r.p.Name }
+func (r *projectResolver) Description() string       { return r.p.Description }
+func (r *projectResolver) Status() string            { return r.p.Status }
+func (r *projectResolver) Visibility() string        { return r.p.Visibility }
+func (r *projectResolver) CreatedAt() graphql.Time   { return graphql.Time{Time: r.p.CreatedAt} }
+func (r *projectResolver) UpdatedAt() graphql.Time   { return graphql.Time{Time: r.p.UpdatedAt} }
+func (r *projectResolver) ArchivedAt() *graphql.Time { return timeOf(r.p.ArchivedAt) }
+func (r *projectResolver) CreatedBy() *string        { return optional(r.p.CreatedBy) }
+func (r *projectResolver) UpdatedBy() *string        { return optional(r.p.UpdatedBy) }
+
+type tasksArgs struct {
+	Statuses    *[]string
+	Priorities  *[]string
+	AssigneeID  *graphql.ID
+	MilestoneID *graphql.ID
+	SprintID    *graphql.ID
+	EpicID      *graphql.ID
+	DueDateFrom *string
+	DueDateTo   *string
+	Q           *string
+	Sort        *string
+	First       int32
+}
+
+// Tasks はプロジェクトのタスクを返す。同じ引数の一覧の他のプロジェクトの分とまとめて取得する。
+func (r *projectResolver) Tasks(ctx context.Context, args tasksArgs) ([]*taskResolver, error) {
+	first, err := firstArg(args.First)
+	if err != nil {
+		return nil, err
+	}
+	filter := &tasksv1.ListTasksRequest{
+		AssigneeId:  derefID(args.AssigneeID),
+		MilestoneId: derefID(args.MilestoneID),
+		SprintId:    derefID(args.SprintID),
+		EpicId:      derefID(args.EpicID),
+		DueDateFrom: deref(args.DueDateFrom),
+		DueDateTo:   deref(args.DueDateTo),
+		Query:       deref(args.Q),
+		Sort:        deref(args.Sort),
+		PageSize:    int32(first),
+	}
+	if args.Statuses != nil {
+		filter.Statuses = *args.Statuses
+	}
+	if args.Priorities != nil {
+		filter.Priorities = *args.Priorities
+	}
+
+	tasks, err := r.tasks.load(ctx, r.p.ID, filter)
+	if err != nil {
+		return nil, upstreamError(ctx, "tasks", err)
+	}
+	resolvers := make([]*taskResolver, len(tasks))
+	for i, t := range tasks {
+		resolvers[i] = &taskResolver{t: t}
+	}
+	return resolvers, nil
+}
+
+// taskResolver は Task のリゾルバ。
+type taskResolver struct {
+	t *tasksv1.Task
+}
+
+func (r *taskResolver) ID() graphql.ID           { return graphql.ID(r.t.GetId()) }
+func (r *taskResolver) ProjectID() graphql.ID    { return graphql.ID(r.t.GetProjectId()) }
+func (r *taskResolver) Number() int32            { return r.t.GetNumber() }
+func (r *taskResolver) Title() string            { return r.t.GetTitle() }
+func (r *taskResolver) Description() string      { return r.t.GetDescription() }
+func (r *taskResolver) Status() string           { return r.t.GetStatus() }
+func (r *taskResolver) Priority() string         { return r.t.GetPriority() }
+func (r *taskResolver) AssigneeID() *graphql.ID  { return optionalID(r.t.AssigneeId) }
+func (r *taskResolver) AssigneeName() *string    { return optional(r.t.GetAssigneeName()) }
+func (r *taskResolver) DueDate() *graphql.Time   { return timestamp(r.t.GetDueDate()) }
+func (r *taskResolver) StartDate() *graphql.Time { return timestamp(r.t.GetStartDate()) }
+func (r *taskResolver) Estimate() *int32         { return r.t.Estimate }
+func (r *taskResolver) MilestoneID() *graphql.ID { return optionalID(r.t.MilestoneId) }
+func (r *taskResolver) SprintID() *graphql.ID    { return optionalID(r.t.SprintId) }
+func (r *taskResolver) EpicID() *graphql.ID      { return optionalID(r.t.EpicId) }
+func (r *taskResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.t.GetCreatedAt().AsTime()}
+}
+func (r *taskResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.t.GetUpdatedAt().AsTime()}
+}
+func (r *taskResolver) CreatedBy() *string        { return optional(r.t.GetCreatedBy()) }
+func (r *taskResolver) UpdatedBy() *string        { return optional(r.t.GetUpdatedBy()) }
+func (r *taskResolver) ArchivedAt() *graphql.Time { return timestamp(r.t.GetArchivedAt()) }
+func (r *taskResolver) LabelIDs() []graphql.ID {
+	ids := make([]graphql.ID, len(r.t.GetLabelIds()))
+	for i, id := range r.t.GetLabelIds() {
+		ids[i] = graphql.ID(id)
+	}
+	return ids
+}
+
+// Error はクライアントに返すエラー。extensions.code に REST API のエラーコードを設定する。
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Extensions は GraphQL のエラーの extensions（graphql-go が errors[].extensions に設定する）。
+func (e *Error) Extensions() map[string]any {
+	return map[string]any{"code": e.Code}
+}
+
+// upstreamError は呼び出し先のサービス（service）のエラーをクライアントに返すエラーに変換する。
+// 引数の誤り・認証・権限のエラーはそのまま伝え、それ以外は詳細を隠して BAD_GATEWAY にする。
+func upstreamError(ctx context.Context, service string, err error) error {
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode < 500 && apiErr.Code != "" {
+		return &Error{Code: apiErr.Code, Message: apiErr.Message}
+	}
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.InvalidArgument:
+			return &Error{Code: apierror.CodeValidation, Message: st.Message()}
+		case codes.Unauthenticated:
+			return &Error{Code: apierror.CodeUnauthorized, Message: st.Message()}
+		case codes.PermissionDenied:
+			return &Error{Code: apierror.CodeForbidden, Message: st.Message()}
+		case codes.NotFound:
+			return &Error{Code: apierror.CodeNotFound, Message: st.Message()}
+		case codes.DeadlineExceeded:
+			return &Error{Code: apierror.CodeTimeout, Message: service + " service timed out"}
+		}
+	}
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+	logging.FromContext(ctx).ErrorContext(ctx, "upstream request failed", "service", service, "error", err)
+	return &Error{Code: apierror.CodeBadGateway, Message: service + " service is unavailable"}
+}
+
+// firstArg は first の引数（省略時はスキーマの既定値の 50）を検証し、件数を返す。
+func firstArg(first int32) (int, error) {
+	if first < 1 || first > maxFirst {
+		return 0, &Error{Code: apierror.CodeValidation, Message: fmt.Sprintf("first must be between 1 and %d", maxFirst)}
+	}
+	return int(first), nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func derefID(id *graphql.ID) string {
+	if id == nil {
+		return ""
+	}
+	return string(*id)
+}
+
+// optional は空文字を null に変換する。
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func optionalID(s *string) *graphql.ID {
+	if s == nil {
+		return nil
+	}
+	id := graphql.ID(*s)
+	return &id
+}
+
+func timeOf(t *time.Time) *graphql.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *t}
+}
+
+func timestamp(ts *timestamppb.Timestamp) *graphql.Time {
+	if ts == nil {
+		return nil
+	}
+	return &graphql.Time{Time: ts.AsTime()}
+}
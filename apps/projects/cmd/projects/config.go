@@ -0,0 +1,461 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"teamflow-shared/apiversion"
+	"teamflow-shared/authz"
+	"teamflow-shared/bus"
+	sharedconfig "teamflow-shared/config"
+	"teamflow-shared/cors"
+	"teamflow-shared/featureflag"
+	"teamflow-shared/logging"
+	"teamflow-shared/openapi"
+	"teamflow-shared/ratelimit"
+	"teamflow-shared/requestid"
+	"teamflow-shared/server"
+	"teamflow-shared/serviceauth"
+	"teamflow-shared/tracing"
+	"teamflow-shared/uuidpolicy"
+
+	infra "teamflow-projects/internal/infrastructure/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+const (
+	// defaultPort は API の listen ポートの既定値。
+	defaultPort = 8080
+	// defaultAdminPort はメトリクスなどの運用エンドポイントの listen ポートの既定値。
+	defaultAdminPort = 9090
+	// defaultJWTIssuer / defaultJWTAudience は受け付ける JWT の iss / aud の既定値（auth サービスの AUTH_ISSUER / AUTH_AUDIENCE と合わせる）。
+	defaultJWTIssuer   = "teamflow-auth"
+	defaultJWTAudience = "teamflow"
+)
+
+// defaultStatsCacheTTL はタスク集計のキャッシュ期間の既定値。
+const defaultStatsCacheTTL = 30 * time.Second
+
+// defaultRestoreWindow は削除したプロジェクトを復元できる期間の既定値。
+const defaultRestoreWindow = 30 * 24 * time.Hour
+
+// defaultArchivePurgeInterval は保持期間を過ぎたアーカイブ・削除したプロジェクトを確認する間隔の既定値。
+const defaultArchivePurgeInterval = time.Hour
+
+// defaultFlags は projects サービスが参照するフィーチャーフラグの既定値。
+var defaultFlags = featureflag.Set{
+	usecase.FlagUniqueProjectNames: false,
+}
+
+// config は環境変数から読み込んだ projects サービスの設定。
+type config struct {
+	AppEnv string
+	// LogLevel はログの出力レベル（既定は info）
+	LogLevel     slog.Level
+	CursorSecret []byte
+	// Port は API の listen ポート
+	Port int
+	// AdminPort はメトリクス（/metrics）を公開する listen ポート（API とは別）
+	AdminPort int
+	// ShutdownTimeout は SIGINT / SIGTERM を受けてから処理中のリクエストを待つ時間
+	ShutdownTimeout time.Duration
+
+	// TLS はクライアントとの接続の TLS の設定（未設定の場合は平文で待ち受ける）
+	TLS server.TLSOptions
+	// H2C は平文の接続で HTTP/2（h2c）を受け付けるか（TLS を終端したロードバランサー・サービス間の呼び出し用）
+	H2C bool
+	// InvitationSecret は招待リンクのトークン署名用シークレット（未設定の場合は CursorSecret）
+	InvitationSecret []byte
+
+	// EnforceRoles はメンバーのロールによる権限チェックを行うかどうか（操作者は X-User-ID ヘッダで受け取る）
+	EnforceRoles bool
+	// UniqueProjectNames はプロジェクト名の重複を拒否するかどうか（大文字小文字と前後の空白は区別しない）
+	UniqueProjectNames bool
+	// Flags はフィーチャーフラグ（FEATURE_FLAGS / FEATURE_FLAGS_FILE。既定値は defaultFlags）
+	Flags featureflag.Set
+
+	// tasks サービスのベース URL（空の場合はテンプレートのタスクを作成できない）
+	TasksServiceURL string
+	// ServiceAPIKey は tasks / users サービスのサービス間専用のエンドポイントに X-Service-Key で送るキー（空の場合は送らない）
+	ServiceAPIKey string
+	// ServiceAPIKeys は X-Service-Key で認証するサービス間の呼び出しのキー（空の場合は認証しない）。
+	// JWKS_URL を設定した場合、トークンの無いリクエストで X-User-ID / X-Workspace-ID を使えるのはこのキーで認証した呼び出しだけ
+	ServiceAPIKeys []serviceauth.Key
+	// users サービスのベース URL（空の場合は個人用アクセストークンを検証しない）
+	UsersServiceURL string
+	// StatsCacheTTL はプロジェクトのタスク集計のキャッシュ期間（0 の場合はキャッシュしない）
+	StatsCacheTTL time.Duration
+	// SummaryQueryTimeout はサマリー（GET /projects/{id}/summary）の集計 1 件あたりのタイムアウト
+	SummaryQueryTimeout time.Duration
+	// RestoreWindow は削除したプロジェクトを復元できる期間
+	RestoreWindow time.Duration
+	// ArchiveRetentionDays はアーカイブ・削除したプロジェクトを物理削除するまでの日数（0 の場合は保持期間のジョブを実行しない）
+	ArchiveRetentionDays int
+	// ArchivePurgeInterval は保持期間を過ぎたアーカイブ・削除したプロジェクトを確認する間隔
+	ArchivePurgeInterval time.Duration
+	// Operators は管理用の API（/api/admin 配下）を呼び出せる運用者（空の場合は誰も呼び出せない）
+	Operators authz.Operators
+
+	// レート制限（RATE_LIMIT_TIERS。RateLimitTiers が空の場合は制限しない）
+	RateLimitTiers     map[string]int
+	RateLimitUserTiers map[string]string
+	// MaxInFlightRequests は同時に処理する API のリクエスト数の上限。超えた分は 503 で断る（0 の場合は制限しない）
+	MaxInFlightRequests int
+
+	// JWT（auth サービスが OIDC のログインで発行する）の検証（JWKSURL が空の場合は検証しない）
+	JWKSURL     string
+	JWTIssuer   string
+	JWTAudience string
+
+	// CORS はブラウザからの別オリジンのリクエストの許可設定
+	CORS cors.Options
+
+	// トレーシング（OTLPEndpoint が空の場合は記録しない）
+	OTLPEndpoint string
+	// ServiceName はトレースの service.name
+	ServiceName string
+	// TraceSampleRatio は新しいトレースを記録する割合（0〜1）
+	TraceSampleRatio float64
+
+	// OpenAPIValidation はリクエスト・レスポンスを OpenAPI の仕様で検証するかどうか（off / log / strict）
+	OpenAPIValidation openapi.Mode
+
+	// UUIDPolicy は作成するプロジェクトの IDとして受け付ける UUID の形式（any / v4v7）
+	UUIDPolicy uuidpolicy.Policy
+
+	// RepoBackend はリポジトリの実装（REPO_BACKEND。未設定の場合は DBDSN があれば postgres、無ければ memory）
+	RepoBackend sharedconfig.RepoBackend
+
+	// DevSeed は起動時にデモデータ（devseed）を作成するかどうか（ローカル開発用。production では使えない）
+	DevSeed bool
+
+	// DB（RepoBackend が postgres の場合に使う）
+	DBDSN              string
+	DBMaxConns         int32
+	DBMinConns         int32
+	DBStatementTimeout time.Duration
+
+	// EventBus は outbox のリレーがドメインイベントを配信するイベントバス（EVENT_BUS。既定は log）
+	EventBus bus.Config
+	// ConsumeTaskEvents は EventBus のタスクのイベントを購読してプロジェクトのタスク数を更新し、
+	// 一覧のタスク件数（expand=taskCounts）に使うかどうか（EVENT_BUS が nats / kafka の場合のみ）
+	ConsumeTaskEvents bool
+
+	// SlackNotifications は EventBus のタスクのイベントを購読して、Slack 連携を設定したプロジェクトに投稿するかどうか
+	// （EVENT_BUS が nats / kafka の場合のみ）
+	SlackNotifications bool
+	// SlackWebhookHosts は Slack 連携の Webhook URL に指定できるホスト
+	SlackWebhookHosts []string
+	// SlackRetries は Slack への投稿に失敗した場合に再試行する回数
+	SlackRetries int
+}
+
+// useSQL は SQL リポジトリを使うかどうかを返す。
+func (c config) useSQL() bool {
+	return c.RepoBackend == sharedconfig.BackendPostgres
+}
+
+// isProduction は本番環境かどうかを返す。
+func (c config) isProduction() bool {
+	return c.AppEnv == "production"
+}
+
+// addr は API の listen アドレスを返す。
+func (c config) addr() string {
+	return fmt.Sprintf(":%d", c.Port)
+}
+
+// adminAddr は運用エンドポイントの listen アドレスを返す。
+func (c config) adminAddr() string {
+	return fmt.Sprintf(":%d", c.AdminPort)
+}
+
+// loadConfig は環境変数（と CONFIG_FILE の設定ファイル）から設定を読み込み、検証する。
+// 不正な値はまとめて 1 つのエラーとして返す（起動時にすべて把握できるように）。
+//
+//	CONFIG_FILE             KEY=VALUE 形式の設定ファイル（環境変数に無い値を補う、default: 無し）
+//	APP_ENV                 production の場合は REPO_BACKEND=postgres（DB_DSN）と 32 バイト以上の CURSOR_SECRET が必須
+//	LOG_LEVEL               ログレベル（debug / info / warn / error、default: info）
+//	PORT                    API の listen ポート（default: 8080）
+//	ADMIN_PORT              メトリクス（/metrics）の listen ポート（default: 9090、PORT と別にする）
+//	SHUTDOWN_TIMEOUT        停止時に処理中のリクエストを待つ時間（default: 20s）
+//	TLS_* / H2C             クライアントとの接続の TLS・h2c（server.ParseTLSOptions を参照、default: 平文の HTTP/1.1）
+//	CURSOR_SECRET           一覧の cursor 署名用シークレット
+//	INVITATION_SECRET       招待リンクのトークン署名用シークレット（default: CURSOR_SECRET と同じ、production では 32 バイト以上）
+//	ENFORCE_PROJECT_ROLES   true の場合はロールによる権限チェックを行う（default: false）
+//	UNIQUE_PROJECT_NAMES    true の場合は同じ名前のプロジェクトの作成・名前変更を 409 にする（default: false）
+//	FEATURE_FLAGS           フィーチャーフラグ（カンマ区切りの name または name=false、例: unique-project-names、default: 無し）
+//	FEATURE_FLAGS_FILE      {"name": true} 形式のフィーチャーフラグの JSON ファイル（FEATURE_FLAGS が優先、default: 無し）
+//	TASKS_SERVICE_URL       tasks サービスのベース URL（例: http://tasks:8081、default: 無し）
+//	SERVICE_API_KEY         tasks / users サービスの呼び出しに X-Service-Key で付けるキー（各サービスの SERVICE_API_KEYS に登録したもの、default: 無し）
+//	SERVICE_API_KEYS        X-Service-Key で受け付けるキー（カンマ区切りの name:key[:rpm]、例: tasks:s3cr3t。JWKS_URL を設定した場合、トークンの無い呼び出しで X-User-ID を引き継げるのはこのキーで認証した呼び出しだけ、default: 無し）
+//	USERS_SERVICE_URL       users サービスのベース URL。個人用アクセストークンの検証に使う（例: http://users:8082、default: 無し）
+//	STATS_CACHE_TTL         タスク集計のキャッシュ期間（例: 1m、0 でキャッシュしない、default: 30s）
+//	SUMMARY_QUERY_TIMEOUT   サマリー（/projects/{id}/summary）の集計 1 件あたりのタイムアウト（集計は並行して取得する、default: 3s）
+//	PROJECT_RESTORE_WINDOW  削除したプロジェクトを復元できる期間（例: 168h、default: 720h）
+//	ARCHIVE_RETENTION_DAYS  アーカイブ・削除したプロジェクトをタスクとともに物理削除するまでの日数（TASKS_SERVICE_URL が必要、default: 0＝削除しない、管理用の POST /api/admin/projects:purge では都度指定する）
+//	ARCHIVE_PURGE_INTERVAL  保持期間を過ぎたアーカイブ・削除したプロジェクトを確認する間隔（default 1h）
+//	ADMIN_USER_IDS          管理用の API（/api/admin 配下）を呼び出せる運用者のユーザー ID（カンマ区切り、default: 無し＝誰も呼び出せない）
+//	RATE_LIMIT_TIERS        ティアごとの 1 分あたりのリクエスト数の上限（カンマ区切りの tier:rpm、例: anonymous:60,user:600,token:300、0 で無制限、default: 無し＝制限しない）
+//	RATE_LIMIT_USER_TIERS   既定と異なるティアを使う操作者（カンマ区切りの userId:tier、例: ci-bot:premium、default: 無し）
+//	MAX_IN_FLIGHT_REQUESTS  同時に処理するリクエスト数の上限。超えた分は 503 と Retry-After で断る（DB_MAX_CONNS の数倍を目安にする、0 で無制限、default: 0）
+//	JWKS_URL                JWT を検証する公開鍵（auth サービスの /.well-known/jwks.json、例: http://auth:8083/.well-known/jwks.json、default: 無し＝検証しない）
+//	JWT_ISSUER              受け付ける JWT の iss（default: teamflow-auth）
+//	JWT_AUDIENCE            受け付ける JWT の aud（default: teamflow）
+//	CORS_ALLOWED_ORIGINS    ブラウザから呼び出せるオリジン（カンマ区切り、* ですべて、default: http://localhost:3000,http://127.0.0.1:3000）
+//	CORS_ALLOWED_METHODS    プリフライトで許可するメソッド（カンマ区切り、default: GET,POST,PUT,PATCH,DELETE）
+//	CORS_ALLOWED_HEADERS    プリフライトで許可するヘッダ（カンマ区切り、default: Content-Type,Authorization,X-Request-ID,X-User-ID,traceparent）
+//	CORS_ALLOW_CREDENTIALS  Cookie・Authorization 付きのリクエストを許可するか（default: true、* のオリジンとは併用不可）
+//	CORS_MAX_AGE            プリフライトの結果のキャッシュ期間（default: 10m）
+//	OTEL_EXPORTER_OTLP_ENDPOINT  トレースの送信先（OTLP/HTTP、例: http://otel-collector:4318、default: 無し＝記録しない）
+//	OTEL_SERVICE_NAME       トレースの service.name（default: projects）
+//	OTEL_TRACES_SAMPLER_ARG 新しいトレースを記録する割合（0〜1、default: 1）
+//	OPENAPI_VALIDATION      OpenAPI の仕様とのずれの検証（off / log / strict、default: production は off、それ以外は strict）
+//	UUID_POLICY             作成するプロジェクトの IDとして受け付ける UUID（any: バージョンを問わない / v4v7: バージョン 4・7 のみ、default: any）
+//	REPO_BACKEND            リポジトリの実装（memory / postgres、default: DB_DSN があれば postgres、無ければ memory、production では postgres のみ）
+//	DB_DSN                  PostgreSQL の接続文字列（REPO_BACKEND=postgres では必須）
+//	DEV_SEED                起動時にデモ用のプロジェクトを作成するか（ローカル開発用、作成済みのものは作成しない、production では不可、default: false）
+//	DB_MAX_CONNS            プールの最大接続数（default: pgxpool の既定値）
+//	DB_MIN_CONNS            プールの最小接続数（default: 0）
+//	DB_STATEMENT_TIMEOUT    ステートメントタイムアウト（例: 5s、default: 無し）
+//	EVENT_BUS               ドメインイベントの配信先（log / nats / kafka、default: log＝ログに出力するだけ）
+//	NATS_URL                NATS サーバーの URL（例: nats://nats:4222、EVENT_BUS=nats では必須）
+//	NATS_SUBJECT_PREFIX     NATS の subject の接頭辞（default: teamflow.events、例: teamflow.events.project.created）
+//	KAFKA_REST_URL          Kafka REST Proxy のベース URL（例: http://kafka-rest:8082、EVENT_BUS=kafka では必須）
+//	KAFKA_TOPIC             Kafka のトピック（default: teamflow.events）
+//	EVENT_BUS_PUBLISH_RETRIES  1 回の配信で失敗した場合に再試行する回数（default 3、それでも失敗したイベントは outbox から後で再送する）
+//	CONSUME_TASK_EVENTS     タスクのイベントを購読してプロジェクトのタスク数を更新し、一覧の expand=taskCounts に使うか（default: false、EVENT_BUS が nats / kafka の場合のみ）
+//	SLACK_NOTIFICATIONS     タスクのイベントを購読して、Slack 連携を設定したプロジェクトの Webhook に投稿するか（default: false、EVENT_BUS が nats / kafka の場合のみ）
+//	SLACK_WEBHOOK_HOSTS     Slack 連携の Webhook URL に指定できるホスト（カンマ区切り、default: hooks.slack.com）
+//	SLACK_RETRIES           Slack への投稿が失敗（429・5xx・通信エラー）した場合に再試行する回数（default: 3）
+func loadConfig(getenv func(string) string) (config, error) {
+	getenv, err := sharedconfig.Load(getenv)
+	if err != nil {
+		return config{}, fmt.Errorf("invalid configuration: %w", err)
+	}
+	p := sharedconfig.NewParser(getenv)
+
+	cfg := config{
+		AppEnv:               p.Get("APP_ENV"),
+		Port:                 p.Port("PORT", defaultPort),
+		AdminPort:            p.Port("ADMIN_PORT", defaultAdminPort),
+		ShutdownTimeout:      p.Duration("SHUTDOWN_TIMEOUT", server.DefaultShutdownTimeout),
+		EnforceRoles:         p.Bool("ENFORCE_PROJECT_ROLES", false),
+		UniqueProjectNames:   p.Bool("UNIQUE_PROJECT_NAMES", false),
+		TasksServiceURL:      p.URL("TASKS_SERVICE_URL"),
+		ServiceAPIKey:        p.Get("SERVICE_API_KEY"),
+		UsersServiceURL:      p.URL("USERS_SERVICE_URL"),
+		StatsCacheTTL:        p.NonNegativeDuration("STATS_CACHE_TTL", defaultStatsCacheTTL),
+		SummaryQueryTimeout:  p.Duration("SUMMARY_QUERY_TIMEOUT", usecase.DefaultSummaryQueryTimeout),
+		RestoreWindow:        p.Duration("PROJECT_RESTORE_WINDOW", defaultRestoreWindow),
+		ArchiveRetentionDays: p.NonNegativeInt("ARCHIVE_RETENTION_DAYS", 0),
+		ArchivePurgeInterval: p.Duration("ARCHIVE_PURGE_INTERVAL", defaultArchivePurgeInterval),
+		Operators:            authz.ParseOperators(p.Get("ADMIN_USER_IDS")),
+		CORS:                 parseCORS(p),
+		OTLPEndpoint:         p.URL("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		JWKSURL:              p.URL("JWKS_URL"),
+		JWTIssuer:            p.String("JWT_ISSUER", defaultJWTIssuer),
+		JWTAudience:          p.String("JWT_AUDIENCE", defaultJWTAudience),
+		ServiceName:          p.String("OTEL_SERVICE_NAME", "projects"),
+		TraceSampleRatio:     p.Ratio("OTEL_TRACES_SAMPLER_ARG", 1),
+		DBDSN:                p.Get("DB_DSN"),
+		DBMaxConns:           p.PositiveInt32("DB_MAX_CONNS"),
+		DBMinConns:           p.PositiveInt32("DB_MIN_CONNS"),
+		DBStatementTimeout:   p.Duration("DB_STATEMENT_TIMEOUT", 0),
+	}
+
+	tlsOpts, h2c, err := server.ParseTLSOptions(p.Get)
+	p.Add(err)
+	cfg.TLS, cfg.H2C = tlsOpts, h2c
+
+	keys, err := serviceauth.ParseKeys(p.Get("SERVICE_API_KEYS"))
+	if err != nil {
+		p.Errorf("SERVICE_API_KEYS is invalid: %w", err)
+	}
+	cfg.ServiceAPIKeys = keys
+
+	cfg.RateLimitTiers, cfg.RateLimitUserTiers = parseRateLimits(p)
+	cfg.MaxInFlightRequests = p.NonNegativeInt("MAX_IN_FLIGHT_REQUESTS", 0)
+	cfg.EventBus = parseEventBus(p, "projects")
+	cfg.ConsumeTaskEvents = p.Bool("CONSUME_TASK_EVENTS", false)
+	// log はイベントを配信しないため購読できない
+	if cfg.ConsumeTaskEvents && cfg.EventBus.Driver == bus.DriverLog {
+		p.Errorf("CONSUME_TASK_EVENTS requires EVENT_BUS to be nats or kafka")
+	}
+	cfg.SlackNotifications = p.Bool("SLACK_NOTIFICATIONS", false)
+	if cfg.SlackNotifications && cfg.EventBus.Driver == bus.DriverLog {
+		p.Errorf("SLACK_NOTIFICATIONS requires EVENT_BUS to be nats or kafka")
+	}
+	cfg.SlackWebhookHosts = usecase.DefaultSlackWebhookHosts
+	if v := p.Get("SLACK_WEBHOOK_HOSTS"); v != "" {
+		cfg.SlackWebhookHosts = cors.SplitList(strings.ToLower(v))
+	}
+	cfg.SlackRetries = p.NonNegativeInt("SLACK_RETRIES", infra.DefaultSlackRetries)
+	// プロジェクトのタスクは tasks サービスで削除する
+	if cfg.ArchiveRetentionDays > 0 && cfg.TasksServiceURL == "" {
+		p.Required("TASKS_SERVICE_URL", "ARCHIVE_RETENTION_DAYS deletes the tasks of purged projects via the tasks service")
+	}
+
+	flags, err := featureflag.Load(getenv, defaultFlags)
+	p.Add(err)
+	cfg.Flags = flags
+
+	level, err := logging.ParseLevel(p.Get("LOG_LEVEL"))
+	if err != nil {
+		p.Errorf("LOG_LEVEL is invalid: %w", err)
+	}
+	cfg.LogLevel = level
+
+	// 仕様とのずれは開発・テストで早く見つける。本番はレスポンスをバッファしないよう検証しない
+	cfg.OpenAPIValidation = openapi.ModeStrict
+	if cfg.isProduction() {
+		cfg.OpenAPIValidation = openapi.ModeOff
+	}
+	if v := p.Get("OPENAPI_VALIDATION"); v != "" {
+		mode, err := openapi.ParseMode(v)
+		if err != nil {
+			p.Errorf("OPENAPI_VALIDATION %w", err)
+		} else {
+			cfg.OpenAPIValidation = mode
+		}
+	}
+
+	uuidPolicy, err := uuidpolicy.Parse(p.Get("UUID_POLICY"))
+	if err != nil {
+		p.Errorf("UUID_POLICY %w", err)
+	}
+	cfg.UUIDPolicy = uuidPolicy
+
+	if cfg.AdminPort == cfg.Port {
+		p.Errorf("ADMIN_PORT must differ from PORT (%d)", cfg.Port)
+	}
+
+	secret, err := sharedconfig.ResolveCursorSecret(cfg.AppEnv, p.Get("CURSOR_SECRET"))
+	p.Add(err)
+	cfg.CursorSecret = secret
+	cfg.InvitationSecret = secret
+	if v := p.Get("INVITATION_SECRET"); v != "" {
+		if cfg.isProduction() && len(v) < sharedconfig.MinSecretLength {
+			p.Errorf("INVITATION_SECRET must be at least %d bytes in production, got %d (generate one with: openssl rand -base64 32)",
+				sharedconfig.MinSecretLength, len(v))
+		}
+		cfg.InvitationSecret = []byte(v)
+	}
+
+	if cfg.DBMaxConns > 0 && cfg.DBMinConns > cfg.DBMaxConns {
+		p.Errorf("DB_MIN_CONNS (%d) must not exceed DB_MAX_CONNS (%d)", cfg.DBMinConns, cfg.DBMaxConns)
+	}
+
+	cfg.RepoBackend = p.RepoBackend(cfg.DBDSN, cfg.isProduction())
+
+	cfg.DevSeed = p.Bool("DEV_SEED", false)
+	if cfg.DevSeed && cfg.isProduction() {
+		p.Errorf("DEV_SEED cannot be used in production")
+	}
+
+	if err := p.Err(); err != nil {
+		return config{}, err
+	}
+	return cfg, nil
+}
+
+// CORS の既定値（ローカルのフロントエンドの開発サーバーを許可する）。
+var (
+	defaultCORSOrigins = []string{"http://localhost:3000", "http://127.0.0.1:3000"}
+	defaultCORSMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+	defaultCORSHeaders = []string{"Content-Type", "Authorization", requestid.Header, authz.ActorHeader, tracing.TraceparentHeader}
+)
+
+// defaultCORSMaxAge はプリフライトの結果をブラウザがキャッシュする時間の既定値。
+const defaultCORSMaxAge = 10 * time.Minute
+
+// parseEventBus は EVENT_BUS / NATS_* / KAFKA_* の環境変数からイベントバスの設定を読み込む。
+func parseEventBus(p *sharedconfig.Parser, clientName string) bus.Config {
+	cfg := bus.Config{
+		NATSURL:       p.Get("NATS_URL"),
+		SubjectPrefix: p.String("NATS_SUBJECT_PREFIX", bus.DefaultSubjectPrefix),
+		KafkaRESTURL:  p.Get("KAFKA_REST_URL"),
+		Topic:         p.String("KAFKA_TOPIC", bus.DefaultTopic),
+		Retries:       p.NonNegativeInt("EVENT_BUS_PUBLISH_RETRIES", bus.DefaultRetries),
+		ClientName:    clientName,
+	}
+	driver, err := bus.ParseDriver(p.Get("EVENT_BUS"))
+	if err != nil {
+		p.Errorf("EVENT_BUS %w", err)
+		return cfg
+	}
+	cfg.Driver = driver
+	switch {
+	case driver == bus.DriverNATS && cfg.NATSURL == "":
+		p.Required("NATS_URL", "EVENT_BUS=nats publishes domain events to a NATS server")
+	case driver == bus.DriverKafka && cfg.KafkaRESTURL == "":
+		p.Required("KAFKA_REST_URL", "EVENT_BUS=kafka publishes domain events via the Kafka REST Proxy")
+	default:
+		if err := cfg.Validate(); err != nil {
+			p.Errorf("EVENT_BUS is misconfigured: %w", err)
+		}
+	}
+	return cfg
+}
+
+// parseRateLimits は RATE_LIMIT_TIERS / RATE_LIMIT_USER_TIERS を読み込む。
+func parseRateLimits(p *sharedconfig.Parser) (map[string]int, map[string]string) {
+	tiers, err := ratelimit.ParseTiers(p.Get("RATE_LIMIT_TIERS"))
+	if err != nil {
+		p.Errorf("RATE_LIMIT_TIERS is invalid: %w", err)
+		return nil, nil
+	}
+	users, err := ratelimit.ParseUserTiers(p.Get("RATE_LIMIT_USER_TIERS"), tiers)
+	if err != nil {
+		p.Errorf("RATE_LIMIT_USER_TIERS is invalid: %w", err)
+	}
+	return tiers, users
+}
+
+// parseCORS は CORS_* の環境変数から CORS の設定を読み込む。
+func parseCORS(p *sharedconfig.Parser) cors.Options {
+	opts := cors.Options{
+		AllowedOrigins:   defaultCORSOrigins,
+		AllowedMethods:   defaultCORSMethods,
+		AllowedHeaders:   defaultCORSHeaders,
+		ExposedHeaders:   []string{requestid.Header, apiversion.Header, ratelimit.HeaderLimit, ratelimit.HeaderRemaining, ratelimit.HeaderReset, "Retry-After"},
+		AllowCredentials: p.Bool("CORS_ALLOW_CREDENTIALS", true),
+		MaxAge:           p.NonNegativeDuration("CORS_MAX_AGE", defaultCORSMaxAge),
+	}
+	if v := p.Get("CORS_ALLOWED_ORIGINS"); v != "" {
+		opts.AllowedOrigins = cors.SplitList(v)
+	}
+	if v := p.Get("CORS_ALLOWED_METHODS"); v != "" {
+		opts.AllowedMethods = cors.SplitList(v)
+	}
+	if v := p.Get("CORS_ALLOWED_HEADERS"); v != "" {
+		opts.AllowedHeaders = cors.SplitList(v)
+	}
+	if err := opts.Validate(); err != nil {
+		p.Errorf("CORS_ALLOWED_ORIGINS is invalid: %w", err)
+	}
+	return opts
+}
+
+// poolConfig は DB 設定を反映した pgxpool.Config を返す。
+func (c config) poolConfig() (*pgxpool.Config, error) {
+	pc, err := pgxpool.ParseConfig(c.DBDSN)
+	if err != nil {
+		return nil, err
+	}
+	if c.DBMaxConns > 0 {
+		pc.MaxConns = c.DBMaxConns
+	}
+	if c.DBMinConns > 0 {
+		pc.MinConns = c.DBMinConns
+	}
+	if c.DBStatementTimeout > 0 {
+		pc.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(c.DBStatementTimeout.Milliseconds(), 10)
+	}
+	return pc, nil
+}
@@ -1,56 +1,875 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
+	_ "time/tzdata" // プロジェクト設定のタイムゾーン（IANA のタイムゾーン名）をタイムゾーンのデータが無い環境でも検証できるようにする
 
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"teamflow-shared/audit"
+	"teamflow-shared/bus"
+	"teamflow-shared/client"
+	"teamflow-shared/clock"
+	sharedconfig "teamflow-shared/config"
+	"teamflow-shared/events"
+	"teamflow-shared/health"
+	"teamflow-shared/jwt"
+	"teamflow-shared/loadshed"
+	"teamflow-shared/logging"
+	"teamflow-shared/metrics"
+	"teamflow-shared/migrate"
+	"teamflow-shared/openapi"
+	"teamflow-shared/outbox"
+	"teamflow-shared/pat"
+	"teamflow-shared/ratelimit"
+	"teamflow-shared/requestid"
+	"teamflow-shared/server"
+	"teamflow-shared/serviceauth"
+	"teamflow-shared/tracing"
+	"teamflow-shared/tracing/pgxtrace"
+	"teamflow-shared/workspace"
+
+	"teamflow-projects/internal/infrastructure/migration"
 	infra "teamflow-projects/internal/infrastructure/project"
 	httphandler "teamflow-projects/internal/interface/http"
 	usecase "teamflow-projects/internal/usecase/project"
 )
 
 func main() {
-	// インメモリのリポジトリ
-	repo := infra.NewMemoryProjectRepository()
+	// ログは JSON 形式で標準出力に出す。レベルは設定を読み込んでから LOG_LEVEL に合わせる
+	var logLevel slog.LevelVar
+	slog.SetDefault(logging.New(os.Stdout, &logLevel))
+
+	// projects migrate <up|down [steps]|version>
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrate.Run(context.Background(), os.Getenv("DB_DSN"), migration.Config, "projects", os.Args[2:]); err != nil {
+			fatal("migration failed", err)
+		}
+		return
+	}
+
+	cfg, err := loadConfig(os.Getenv)
+	if err != nil {
+		fatal("failed to load configuration", err)
+	}
+	logLevel.Set(cfg.LogLevel)
+
+	// トレーシング（OTEL_EXPORTER_OTLP_ENDPOINT が無ければ記録しない）
+	tracer, err := newTracer(cfg)
+	if err != nil {
+		fatal("failed to initialize tracing", err)
+	}
+	defer shutdownTracer(tracer)
+
+	// readiness で確認する依存先（リポジトリの生成時に登録する）
+	checks := health.NewChecker(health.DefaultTimeout)
+
+	// リポジトリ（REPO_BACKEND=postgres なら PostgreSQL、memory ならインメモリ）
+	repos, closeRepo, err := newRepositories(context.Background(), cfg, tracer, checks)
+	if err != nil {
+		fatal("failed to initialize repositories", err)
+	}
+	defer closeRepo()
+	repo, memberRepo, settingsRepo, templateRepo := repos.projects, repos.members, repos.settings, repos.templates
 
 	// ユースケース
 	createUC := &usecase.CreateProjectUsecase{
-		Repo: repo,
+		Repo:         repo,
+		Members:      memberRepo,
+		EnforceRoles: cfg.EnforceRoles,
+		UniqueNames:  cfg.UniqueProjectNames,
+		Flags:        cfg.Flags,
+		Activity:     repos.activity,
+		Audit:        repos.audit,
+		Tx:           repos.tx,
+		Events:       repos.outbox,
+		UUIDPolicy:   cfg.UUIDPolicy,
+	}
+	// デモデータ（DEV_SEED）。名前の一意制約は確認しない（作成済みかどうかは ID で判定する）
+	if cfg.DevSeed {
+		seedUC := &usecase.CreateProjectUsecase{
+			Repo:       repo,
+			Members:    memberRepo,
+			Activity:   repos.activity,
+			Audit:      repos.audit,
+			Tx:         repos.tx,
+			Events:     repos.outbox,
+			UUIDPolicy: cfg.UUIDPolicy,
+		}
+		if err := seedDemoProjects(context.Background(), seedUC, repo, time.Now()); err != nil {
+			closeRepo()
+			fatal("failed to load demo data", err)
+		}
 	}
 	updateUC := &usecase.UpdateProjectUsecase{
-		Repo: repo,
+		Repo:         repo,
+		Members:      memberRepo,
+		EnforceRoles: cfg.EnforceRoles,
+		UniqueNames:  cfg.UniqueProjectNames,
+		Flags:        cfg.Flags,
+		Activity:     repos.activity,
+		Audit:        repos.audit,
+	}
+	archiveUC := &usecase.ArchiveProjectUsecase{
+		Repo:         repo,
+		Members:      memberRepo,
+		EnforceRoles: cfg.EnforceRoles,
+		Activity:     repos.activity,
+		Audit:        repos.audit,
 	}
 	listUC := &usecase.ListProjectsUsecase{
-		Repo: repo,
+		Repo:            repo,
+		Members:         memberRepo,
+		EnforceRoles:    cfg.EnforceRoles,
+		UseTaskCounters: cfg.ConsumeTaskEvents,
+	}
+	getUC := &usecase.GetProjectUsecase{
+		Repo:         repo,
+		Members:      memberRepo,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	addMemberUC := &usecase.AddMemberUsecase{
+		Projects:     repo,
+		Members:      memberRepo,
+		EnforceRoles: cfg.EnforceRoles,
+		Activity:     repos.activity,
+	}
+	removeMemberUC := &usecase.RemoveMemberUsecase{
+		Members:      memberRepo,
+		EnforceRoles: cfg.EnforceRoles,
+		Activity:     repos.activity,
+	}
+	listMembersUC := &usecase.ListMembersUsecase{
+		Projects:     repo,
+		Members:      memberRepo,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	getMemberUC := &usecase.GetMemberUsecase{
+		Members: memberRepo,
+	}
+	getSettingsUC := &usecase.GetSettingsUsecase{
+		Projects: repo,
+		Settings: settingsRepo,
+	}
+	createTemplateUC := &usecase.CreateTemplateUsecase{
+		Templates:    templateRepo,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	listTemplatesUC := &usecase.ListTemplatesUsecase{
+		Templates: templateRepo,
+	}
+	createFromTemplateUC := &usecase.CreateProjectFromTemplateUsecase{
+		Create:    createUC,
+		Templates: templateRepo,
+		Tx:        repos.tx,
+	}
+	cloneUC := &usecase.CloneProjectUsecase{
+		Create:       createUC,
+		Projects:     repo,
+		Members:      memberRepo,
+		Settings:     settingsRepo,
+		Tx:           repos.tx,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	importUC := &usecase.ImportTasksUsecase{
+		Projects:     repo,
+		Members:      memberRepo,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	listActivityUC := &usecase.ListActivityUsecase{
+		Projects:     repo,
+		Activity:     repos.activity,
+		Members:      memberRepo,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	statsUC := &usecase.GetStatsUsecase{
+		Projects:     repo,
+		Members:      memberRepo,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	summaryUC := &usecase.GetSummaryUsecase{
+		Projects:     repo,
+		Members:      memberRepo,
+		Milestones:   repos.milestones,
+		Epics:        repos.epics,
+		Labels:       repos.labels,
+		EnforceRoles: cfg.EnforceRoles,
+		QueryTimeout: cfg.SummaryQueryTimeout,
+	}
+	createMilestoneUC := &usecase.CreateMilestoneUsecase{
+		Projects:     repo,
+		Members:      memberRepo,
+		Milestones:   repos.milestones,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	updateMilestoneUC := &usecase.UpdateMilestoneUsecase{
+		Members:      memberRepo,
+		Milestones:   repos.milestones,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	deleteMilestoneUC := &usecase.DeleteMilestoneUsecase{
+		Members:      memberRepo,
+		Milestones:   repos.milestones,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	listMilestonesUC := &usecase.ListMilestonesUsecase{
+		Projects:     repo,
+		Milestones:   repos.milestones,
+		Members:      memberRepo,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	getMilestoneUC := &usecase.GetMilestoneUsecase{
+		Milestones: repos.milestones,
+	}
+	milestoneProgressUC := &usecase.GetMilestoneProgressUsecase{
+		Projects:     repo,
+		Milestones:   repos.milestones,
+		Members:      memberRepo,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	createSprintUC := &usecase.CreateSprintUsecase{
+		Projects:     repo,
+		Members:      memberRepo,
+		Sprints:      repos.sprints,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	updateSprintUC := &usecase.UpdateSprintUsecase{
+		Members:      memberRepo,
+		Sprints:      repos.sprints,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	deleteSprintUC := &usecase.DeleteSprintUsecase{
+		Members:      memberRepo,
+		Sprints:      repos.sprints,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	listSprintsUC := &usecase.ListSprintsUsecase{
+		Projects:     repo,
+		Sprints:      repos.sprints,
+		Members:      memberRepo,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	getSprintUC := &usecase.GetSprintUsecase{
+		Sprints: repos.sprints,
+	}
+	startSprintUC := &usecase.StartSprintUsecase{
+		Members:      memberRepo,
+		Sprints:      repos.sprints,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	completeSprintUC := &usecase.CompleteSprintUsecase{
+		Members:      memberRepo,
+		Sprints:      repos.sprints,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	createEpicUC := &usecase.CreateEpicUsecase{
+		Projects:     repo,
+		Members:      memberRepo,
+		Epics:        repos.epics,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	updateEpicUC := &usecase.UpdateEpicUsecase{
+		Members:      memberRepo,
+		Epics:        repos.epics,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	deleteEpicUC := &usecase.DeleteEpicUsecase{
+		Members:      memberRepo,
+		Epics:        repos.epics,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	listEpicsUC := &usecase.ListEpicsUsecase{
+		Projects:     repo,
+		Epics:        repos.epics,
+		Members:      memberRepo,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	getEpicUC := &usecase.GetEpicUsecase{
+		Epics: repos.epics,
+	}
+	epicProgressUC := &usecase.GetEpicProgressUsecase{
+		Projects:     repo,
+		Epics:        repos.epics,
+		Members:      memberRepo,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	createLabelUC := &usecase.CreateLabelUsecase{
+		Projects:     repo,
+		Members:      memberRepo,
+		Labels:       repos.labels,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	updateLabelUC := &usecase.UpdateLabelUsecase{
+		Members:      memberRepo,
+		Labels:       repos.labels,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	deleteLabelUC := &usecase.DeleteLabelUsecase{
+		Members:      memberRepo,
+		Labels:       repos.labels,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	listLabelsUC := &usecase.ListLabelsUsecase{
+		Projects:     repo,
+		Labels:       repos.labels,
+		Members:      memberRepo,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	getLabelUC := &usecase.GetLabelUsecase{
+		Labels: repos.labels,
+	}
+	createInvitationUC := &usecase.CreateInvitationUsecase{
+		Projects:     repo,
+		Members:      memberRepo,
+		Invitations:  repos.invitations,
+		Secret:       cfg.InvitationSecret,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	listInvitationsUC := &usecase.ListInvitationsUsecase{
+		Projects:     repo,
+		Members:      memberRepo,
+		Invitations:  repos.invitations,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	revokeInvitationUC := &usecase.RevokeInvitationUsecase{
+		Members:      memberRepo,
+		Invitations:  repos.invitations,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	getInvitationUC := &usecase.GetInvitationUsecase{
+		Invitations: repos.invitations,
+		Secret:      cfg.InvitationSecret,
+	}
+	acceptInvitationUC := &usecase.AcceptInvitationUsecase{
+		Projects:    repo,
+		Members:     memberRepo,
+		Invitations: repos.invitations,
+		Secret:      cfg.InvitationSecret,
+		Activity:    repos.activity,
+	}
+	deleteUC := &usecase.DeleteProjectUsecase{
+		Repo:         repo,
+		Members:      memberRepo,
+		EnforceRoles: cfg.EnforceRoles,
+		Audit:        repos.audit,
+	}
+	restoreUC := &usecase.RestoreProjectUsecase{
+		Repo:         repo,
+		Members:      memberRepo,
+		Window:       cfg.RestoreWindow,
+		EnforceRoles: cfg.EnforceRoles,
+		Audit:        repos.audit,
+	}
+	purgeUC := &usecase.PurgeProjectsUsecase{
+		Purger:    repos.purger,
+		Tx:        repos.tx,
+		Operators: cfg.Operators,
+		Audit:     repos.audit,
+	}
+	// tasks サービスが指定されていなければ、タスクを含むテンプレートからの作成、
+	// タスクを含む複製、タスクのインポート（dryRun を除く）、タスクの集計（一覧の expand=taskCounts、マイルストーン・エピックの進捗、ラベルの使用数、サマリーを含む）、
+	// プロジェクトの削除・物理削除、スプリントの完了（未完了タスクの持ち越し）はできない（502）
+	if cfg.TasksServiceURL != "" {
+		// tasks サービスのサービス間専用のエンドポイントは SERVICE_API_KEY で認証される
+		tasksClient := infra.NewTasksClient(cfg.TasksServiceURL, &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: tracing.NewTransport(tracer, &requestid.Transport{
+				Base: &serviceauth.Transport{Key: cfg.ServiceAPIKey},
+			}),
+		})
+		createFromTemplateUC.Tasks = tasksClient
+		cloneUC.Tasks = tasksClient
+		importUC.Tasks = tasksClient
+		statsUC.Stats = tasksClient
+		listUC.Stats = tasksClient
+		milestoneProgressUC.Stats = tasksClient
+		epicProgressUC.Stats = tasksClient
+		listLabelsUC.Stats = tasksClient
+		summaryUC.Stats = tasksClient
+		completeSprintUC.Tasks = tasksClient
+		// 削除前のタスクの件数の確認はキャッシュを通さない
+		deleteUC.Stats = tasksClient
+		deleteUC.Tasks = tasksClient
+		restoreUC.Tasks = tasksClient
+		purgeUC.Tasks = tasksClient
+		if cfg.StatsCacheTTL > 0 {
+			statsUC.Stats = infra.NewCachingStatsProvider(tasksClient, cfg.StatsCacheTTL)
+		}
+		slog.Info("using tasks service", "url", cfg.TasksServiceURL)
+	}
+	setFavoriteUC := &usecase.SetFavoriteUsecase{
+		Projects:    repo,
+		Preferences: repos.prefs,
 	}
+	listPreferencesUC := &usecase.ListPreferencesUsecase{
+		Preferences: repos.prefs,
+	}
+	reorderUC := &usecase.ReorderProjectsUsecase{
+		Projects:    repo,
+		Preferences: repos.prefs,
+	}
+	updateSettingsUC := &usecase.UpdateSettingsUsecase{
+		Projects:     repo,
+		Members:      memberRepo,
+		Settings:     settingsRepo,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	// Slack 連携（Webhook URL は SLACK_WEBHOOK_HOSTS のホストのみ）
+	slackPoster := infra.NewSlackWebhookClient(nil, cfg.SlackRetries)
+	getSlackUC := &usecase.GetSlackIntegrationUsecase{
+		Projects:     repo,
+		Integrations: repos.slack,
+	}
+	updateSlackUC := &usecase.UpdateSlackIntegrationUsecase{
+		Projects:     repo,
+		Members:      memberRepo,
+		Integrations: repos.slack,
+		AllowedHosts: cfg.SlackWebhookHosts,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	deleteSlackUC := &usecase.DeleteSlackIntegrationUsecase{
+		Projects:     repo,
+		Members:      memberRepo,
+		Integrations: repos.slack,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+	testSlackUC := &usecase.TestSlackIntegrationUsecase{
+		Projects:     repo,
+		Members:      memberRepo,
+		Integrations: repos.slack,
+		Poster:       slackPoster,
+		AllowedHosts: cfg.SlackWebhookHosts,
+		EnforceRoles: cfg.EnforceRoles,
+	}
+
+	// HTTP ハンドラ（API はすべて /api 配下。パスの振り分けは Router が行う）
+	router := httphandler.NewRouter(httphandler.Handlers{
+		Projects:           httphandler.NewProjectHandler(createUC, listUC, clock.System, cfg.CursorSecret),
+		CreateFromTemplate: httphandler.NewCreateFromTemplateHandler(createFromTemplateUC, clock.System),
+		Templates:          httphandler.NewTemplatesHandler(createTemplateUC, listTemplatesUC, clock.System),
+		Get:                httphandler.NewGetProjectHandler(getUC),
+		Update:             httphandler.NewUpdateProjectHandler(updateUC, clock.System),
+		Delete:             httphandler.NewDeleteProjectHandler(deleteUC, restoreUC, clock.System),
+		Archive:            httphandler.NewArchiveProjectHandler(archiveUC, clock.System),
+		Members:            httphandler.NewMembersHandler(addMemberUC, removeMemberUC, listMembersUC, getMemberUC, clock.System),
+		Settings:           httphandler.NewSettingsHandler(getSettingsUC, updateSettingsUC, clock.System),
+		Clone:              httphandler.NewCloneProjectHandler(cloneUC, clock.System),
+		Import:             httphandler.NewImportHandler(importUC),
+		Stats:              httphandler.NewStatsHandler(statsUC),
+		Summary:            httphandler.NewSummaryHandler(summaryUC),
+		Activity:           httphandler.NewActivityHandler(listActivityUC, clock.System, cfg.CursorSecret),
+		Preferences:        httphandler.NewPreferencesHandler(setFavoriteUC, listPreferencesUC, reorderUC, clock.System),
+		Milestones: httphandler.NewMilestonesHandler(createMilestoneUC, updateMilestoneUC, deleteMilestoneUC,
+			listMilestonesUC, getMilestoneUC, milestoneProgressUC, clock.System),
+		Sprints: httphandler.NewSprintsHandler(createSprintUC, updateSprintUC, deleteSprintUC,
+			listSprintsUC, getSprintUC, startSprintUC, completeSprintUC, clock.System),
+		Epics: httphandler.NewEpicsHandler(createEpicUC, updateEpicUC, deleteEpicUC,
+			listEpicsUC, getEpicUC, epicProgressUC, clock.System),
+		Labels: httphandler.NewLabelsHandler(createLabelUC, updateLabelUC, deleteLabelUC,
+			listLabelsUC, getLabelUC, clock.System),
+		Invitations: httphandler.NewInvitationsHandler(createInvitationUC, listInvitationsUC, revokeInvitationUC,
+			getInvitationUC, acceptInvitationUC, clock.System),
+		Slack:         httphandler.NewSlackHandler(getSlackUC, updateSlackUC, deleteSlackUC, testSlackUC, clock.System),
+		Purge:         httphandler.NewPurgeProjectsHandler(purgeUC, clock.System),
+		ProjectExists: getUC.Exists,
+	})
+
+	// ヘルスチェック（/livez, /healthz, /readyz）は server.NewMux が登録する
+	mux := server.NewMux(checks)
+	// トークン（JWT・PAT）の無いリクエストの X-User-ID / X-Workspace-ID は、サービス API キー（SERVICE_API_KEYS）で認証した呼び出しの場合だけ使う
+	serviceAuth := serviceauth.NewAuthenticator(cfg.ServiceAPIKeys, clock.System)
+	// Authorization: Bearer の個人用アクセストークンは users サービスで検証する（USERS_SERVICE_URL が空なら検証しない）。
+	// tokens:introspect はサービス間専用のエンドポイントのため SERVICE_API_KEY で認証される
+	var tokenVerifier pat.Verifier
+	if cfg.UsersServiceURL != "" {
+		usersClient := client.New(cfg.UsersServiceURL, &http.Client{
+			Timeout: 3 * time.Second,
+			Transport: tracing.NewTransport(tracer, &requestid.Transport{
+				Base: &serviceauth.Transport{Key: cfg.ServiceAPIKey},
+			}),
+		})
+		tokenVerifier = pat.NewIntrospectionVerifier(usersClient)
+		slog.Info("verifying personal access tokens with users service", "url", cfg.UsersServiceURL)
+	}
+	// Authorization: Bearer の JWT（auth サービスが OIDC のログインで発行する）を検証する Verifier（JWKS_URL が空なら nil）
+	jwtVerifier := jwt.NewRemoteVerifier(cfg.JWKSURL, cfg.JWTIssuer, cfg.JWTAudience)
+	// API はワークスペース（X-Workspace-ID。jwt / pat の Middleware がトークンから設定する）ごとに分離する。
+	// 認証（JWT・PAT）を設定していない場合は、X-User-ID と同じくヘッダをそのまま使う
+	mux.Handle(httphandler.APIPrefix+"/", workspace.Middleware(jwtVerifier != nil || tokenVerifier != nil, serviceAuth.Authenticated, router))
 
-	// HTTP ハンドラ
-	projectHandler := httphandler.NewProjectHandler(createUC, listUC, time.Now)
-	updateHandler := httphandler.NewUpdateProjectHandler(updateUC, time.Now)
+	// OpenAPI の仕様（/api/openapi.json）。OPENAPI_VALIDATION が off 以外なら API のリクエスト・レスポンスを仕様で検証する
+	handler, err := withOpenAPI(mux, cfg.OpenAPIValidation)
+	if err != nil {
+		fatal("failed to load OpenAPI spec", err)
+	}
+	if cfg.OpenAPIValidation != openapi.ModeOff {
+		slog.Info("validating requests and responses against the OpenAPI spec", "mode", cfg.OpenAPIValidation)
+	}
+	// 操作者・個人用アクセストークンごとのレート制限（RATE_LIMIT_TIERS）
+	handler = ratelimit.Middleware(ratelimit.Policy{
+		Tiers:     cfg.RateLimitTiers,
+		UserTiers: cfg.RateLimitUserTiers,
+		Clock:     clock.System,
+	}, handler)
+	// Authorization: Bearer の個人用アクセストークンを検証し、持ち主を操作者にする（レート制限・仕様の検証より前に認証する）
+	handler = pat.Middleware(tokenVerifier, serviceAuth.Authenticated, handler)
+	// Authorization: Bearer の JWT（auth サービスが OIDC のログインで発行する）を JWKS_URL の公開鍵で検証し、sub を操作者にする。
+	// トークンの無いリクエストの X-User-ID / X-Workspace-ID は、サービス API キーで認証した呼び出しの場合だけ使う
+	handler = jwt.Middleware(jwtVerifier, serviceAuth.Authenticated, handler)
+	// 処理中のリクエスト数の上限（MAX_IN_FLIGHT_REQUESTS）。DB のプールが埋まる前に超えた分を 503 で断る（認証よりも前に断る）
+	shedder := loadshed.NewLimiter(loadshed.Policy{MaxInFlight: cfg.MaxInFlightRequests})
+	handler = shedder.Middleware(handler)
+	metrics.RegisterLoadShedMetrics(prometheus.DefaultRegisterer, "projects", shedder)
+	if cfg.MaxInFlightRequests > 0 {
+		slog.Info("shedding requests above the in-flight limit", "max_in_flight", cfg.MaxInFlightRequests)
+	}
 
-	mux := http.NewServeMux()
-	mux.Handle("/projects", projectHandler) // POST /projects, GET /projects
-	mux.Handle("/projects/", updateHandler) // PUT /projects/{id}
+	// メトリクス（Prometheus テキスト形式）は API と別の管理用ポートで公開する
+	adminMux := http.NewServeMux()
+	adminMux.Handle("/metrics", promhttp.Handler())
+	stopAdmin, err := server.StartAdmin(cfg.adminAddr(), adminMux)
+	if err != nil {
+		fatal("failed to start admin server", err)
+	}
+	defer stopAdmin()
 
-	// ヘルスチェック
-	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ok"))
+	// outbox に記録したドメインイベントをイベントバス（EVENT_BUS。既定はログに出力するだけ）に配信する
+	publisher, closePublisher, err := bus.New(cfg.EventBus)
+	if err != nil {
+		fatal("failed to initialize event bus", err)
+	}
+	slog.Info("relaying domain events", "event_bus", cfg.EventBus.Driver)
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		(&outbox.Relay{Store: repos.outbox, Publisher: publisher, Clock: clock.System}).Run(relayCtx)
+	}()
+	defer func() {
+		stopRelay()
+		<-relayDone
+		closePublisher()
+	}()
+
+	// タスクのイベントを購読してプロジェクトのタスク数を更新する（CONSUME_TASK_EVENTS）
+	if cfg.ConsumeTaskEvents {
+		consumer, err := bus.NewConsumer(cfg.EventBus, taskCountersGroup)
+		if err != nil {
+			fatal("failed to initialize event bus consumer", err)
+		}
+		slog.Info("consuming task events for project task counts", "event_bus", cfg.EventBus.Driver)
+		stopTaskCounters := startTaskCounters(consumer, &usecase.ApplyTaskEventUsecase{Counters: repos.counters})
+		defer stopTaskCounters()
+	}
+
+	// タスクのイベントを購読して Slack 連携を設定したプロジェクトに投稿する（SLACK_NOTIFICATIONS）
+	if cfg.SlackNotifications {
+		consumer, err := bus.NewConsumer(cfg.EventBus, slackNotificationsGroup)
+		if err != nil {
+			fatal("failed to initialize event bus consumer", err)
+		}
+		slog.Info("posting task events to slack", "event_bus", cfg.EventBus.Driver, "webhook_hosts", cfg.SlackWebhookHosts)
+		stopSlack := startSlackNotifications(consumer, &usecase.NotifySlackUsecase{
+			Projects:     repo,
+			Integrations: repos.slack,
+			Poster:       slackPoster,
+			AllowedHosts: cfg.SlackWebhookHosts,
+		})
+		defer stopSlack()
+	}
+
+	// ARCHIVE_RETENTION_DAYS を過ぎたアーカイブ・削除したプロジェクトを定期的に物理削除する
+	if cfg.ArchiveRetentionDays > 0 {
+		slog.Info("purging archived projects after the retention period", "retention_days", cfg.ArchiveRetentionDays, "interval", cfg.ArchivePurgeInterval.String())
+		stopProjectPurge := startProjectPurge(purgeUC, cfg.ArchiveRetentionDays, cfg.ArchivePurgeInterval)
+		defer stopProjectPurge()
+	}
+
+	// TLS_CERT_FILE・TLS_AUTOCERT_HOSTS を設定した場合は TLS で待ち受ける（証明書のファイルは起動時に読み込む）
+	tlsConfig, err := cfg.TLS.Config()
+	if err != nil {
+		fatal("failed to configure TLS (check TLS_CERT_FILE / TLS_KEY_FILE)", err)
+	}
+
+	// リクエスト ID・トレース・ログ・メトリクス・セキュリティヘッダ・panic の回復・CORS は server.New が順に適用する
+	srv := server.New(handler, server.Options{
+		Addr:      cfg.addr(),
+		Tracer:    tracer,
+		Metrics:   metrics.NewHTTPMetrics(prometheus.DefaultRegisterer, "projects", httphandler.RouteLabel).Middleware,
+		CORS:      cfg.CORS,
+		Messages:  httphandler.Messages,
+		TLSConfig: tlsConfig,
+		H2C:       cfg.H2C,
 	})
+	slog.Info("projects service listening", "addr", srv.Addr, "tls", tlsConfig != nil, "h2c", cfg.H2C, "feature_flags", cfg.Flags.Names())
+
+	// SIGINT / SIGTERM で graceful shutdown し、処理中のリクエストが終わってからプールを閉じる
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := server.Run(ctx, srv, cfg.ShutdownTimeout); err != nil {
+		fatal("http server stopped", err)
+	}
+	slog.Info("projects service stopped")
+}
 
-	addr := ":8080"
-	log.Printf("projects service listening on %s", addr)
+// repositories は main で使うリポジトリ一式。
+type repositories struct {
+	projects    usecase.ProjectRepository
+	members     usecase.MemberRepository
+	settings    usecase.SettingsRepository
+	templates   usecase.TemplateRepository
+	prefs       usecase.PreferenceRepository
+	activity    usecase.ActivityRepository
+	milestones  usecase.MilestoneRepository
+	sprints     usecase.SprintRepository
+	epics       usecase.EpicRepository
+	labels      usecase.LabelRepository
+	invitations usecase.InvitationRepository
+	slack       usecase.SlackIntegrationRepository
+	tx          usecase.TxManager
+	audit       audit.Recorder
+	outbox      outboxStore
+	counters    usecase.TaskCounterRepository
+	purger      usecase.ProjectPurger
+}
+
+// outboxStore はユースケースがドメインイベントを記録し、リレーが読み出す outbox。
+type outboxStore interface {
+	outbox.Writer
+	outbox.Store
+}
 
-	server := &http.Server{
-		Addr:         addr,
-		Handler:      mux,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+// newRepositories は設定に応じてリポジトリ一式を生成する。
+// SQL の場合は起動時に疎通確認を行い、戻り値の close でプールを閉じる。
+// tracer が nil でなければ問い合わせごとのスパンを記録する。プールへの疎通確認を checks に登録する。
+// 監査ログは SQL の場合のみ記録する（インメモリでは audit は nil）。
+// outbox は SQL の場合は outbox テーブル、インメモリの場合はこのプロセスのメモリに記録する。
+func newRepositories(ctx context.Context, cfg config, tracer *tracing.Tracer, checks *health.Checker) (repositories, func(), error) {
+	if !cfg.useSQL() {
+		slog.Info("using in-memory project repository")
+		projects := infra.NewMemoryProjectRepository()
+		return repositories{
+			projects:    projects,
+			members:     infra.NewMemoryMemberRepository(),
+			settings:    infra.NewMemorySettingsRepository(),
+			templates:   infra.NewMemoryTemplateRepository(),
+			prefs:       infra.NewMemoryPreferenceRepository(),
+			activity:    infra.NewMemoryActivityRepository(),
+			milestones:  infra.NewMemoryMilestoneRepository(),
+			sprints:     infra.NewMemorySprintRepository(),
+			epics:       infra.NewMemoryEpicRepository(),
+			labels:      infra.NewMemoryLabelRepository(),
+			invitations: infra.NewMemoryInvitationRepository(),
+			slack:       infra.NewMemorySlackIntegrationRepository(),
+			tx:          infra.NoopTxManager{},
+			outbox:      outbox.NewMemoryStore(),
+			counters:    projects,
+			purger:      projects,
+		}, func() {}, nil
 	}
 
-	if err := server.ListenAndServe(); err != nil {
-		log.Fatal(err)
+	poolCfg, err := cfg.poolConfig()
+	if err != nil {
+		return repositories{}, nil, fmt.Errorf("REPO_BACKEND=postgres: DB_DSN is invalid: %w", err)
 	}
+	if tracer != nil {
+		poolCfg.ConnConfig.Tracer = pgxtrace.NewQueryTracer(tracer.Provider())
+	}
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return repositories{}, nil, fmt.Errorf("failed to create database pool: %w", err)
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := pool.Ping(pingCtx); err != nil {
+		pool.Close()
+		return repositories{}, nil, fmt.Errorf("REPO_BACKEND=postgres: failed to connect database %s (check DB_DSN and that the database is reachable): %w", sharedconfig.DBTarget(poolCfg), err)
+	}
+
+	slog.Info("using postgres project repository", "db", sharedconfig.DBTarget(poolCfg), "max_conns", poolCfg.MaxConns)
+	metrics.RegisterPoolMetrics(prometheus.DefaultRegisterer, "projects", pool)
+	checks.Add("postgres", pool.Ping)
+	projects := infra.NewSQLProjectRepository(pool)
+	return repositories{
+		projects:    infra.NewMeteredProjectRepository(projects),
+		members:     infra.NewSQLMemberRepository(pool),
+		settings:    infra.NewSQLSettingsRepository(pool),
+		templates:   infra.NewSQLTemplateRepository(pool),
+		prefs:       infra.NewSQLPreferenceRepository(pool),
+		activity:    infra.NewSQLActivityRepository(pool),
+		milestones:  infra.NewSQLMilestoneRepository(pool),
+		sprints:     infra.NewSQLSprintRepository(pool),
+		epics:       infra.NewSQLEpicRepository(pool),
+		labels:      infra.NewSQLLabelRepository(pool),
+		invitations: infra.NewSQLInvitationRepository(pool),
+		slack:       infra.NewSQLSlackIntegrationRepository(pool),
+		tx:          infra.NewPgxTxManager(pool),
+		audit:       audit.NewSQLRecorder(pool, infra.TxFromContext),
+		outbox:      outbox.NewSQLOutbox(pool, infra.TxFromContext),
+		counters:    projects,
+		purger:      projects,
+	}, pool.Close, nil
+}
+
+// taskCountersGroup はプロジェクトのタスク数を更新する購読のグループ（projects のレプリカでイベントを分け合う）。
+const taskCountersGroup = "projects-task-counters"
+
+// taskCountersCleanupInterval は処理済みのイベントの記録を削除する間隔。
+const taskCountersCleanupInterval = time.Hour
+
+// startTaskCounters は consumer でタスクのイベントを購読して uc でプロジェクトのタスク数に反映し、
+// 処理済みのイベントの記録を定期的に削除する。戻り値で購読を止め、終わるまで待つ。
+func startTaskCounters(consumer bus.Consumer, uc *usecase.ApplyTaskEventUsecase) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		consumer.Consume(ctx, usecase.TaskCounterEvents, func(ctx context.Context, e events.Event) error {
+			return uc.Execute(ctx, usecase.ApplyTaskEventInput{Event: e, Now: clock.System.Now()})
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(taskCountersCleanupInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				n, err := uc.Cleanup(ctx, clock.System.Now())
+				if err != nil {
+					slog.Warn("failed to delete processed events", "error", err)
+				} else if n > 0 {
+					slog.Info("deleted processed events", "count", n)
+				}
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// startProjectPurge は interval ごとに、アーカイブ・削除してから days 日を過ぎたプロジェクトを uc で物理削除する。
+// 1 回の確認で対象が無くなるまで BatchSize ずつ削除する。操作者は空（監査ログには保持期間のジョブとして残る）。
+// 戻り値で止め、実行中の削除が終わるまで待つ。
+func startProjectPurge(uc *usecase.PurgeProjectsUsecase, days int, interval time.Duration) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			purged := 0
+			for ctx.Err() == nil {
+				out, err := uc.Purge(ctx, usecase.PurgeProjectsInput{OlderThanDays: days, Now: clock.System.Now()})
+				if err != nil {
+					if ctx.Err() == nil {
+						slog.ErrorContext(ctx, "failed to purge archived projects", "error", err)
+					}
+					break
+				}
+				purged += len(out.Projects)
+				if !out.HasMore {
+					break
+				}
+			}
+			if purged > 0 {
+				slog.InfoContext(ctx, "purged archived projects", "count", purged, "retention_days", days)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// slackNotificationsGroup は Slack に投稿する購読のグループ（projects のレプリカでイベントを分け合い、1 度だけ投稿する）。
+const slackNotificationsGroup = "projects-slack-notifications"
+
+// startSlackNotifications は consumer でタスクのイベントを購読して uc で Slack に投稿する。戻り値で購読を止め、終わるまで待つ。
+// 投稿の失敗は SlackWebhookClient が再試行した後のため、ログに出力してイベントを読み飛ばす（購読側で再試行すると重複して投稿しうる）。
+func startSlackNotifications(consumer bus.Consumer, uc *usecase.NotifySlackUsecase) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		consumer.Consume(ctx, usecase.SlackNotificationEvents, func(ctx context.Context, e events.Event) error {
+			err := uc.Execute(ctx, e)
+			if errors.Is(err, usecase.ErrSlackDelivery) {
+				slog.WarnContext(ctx, "failed to post task event to slack",
+					"event_id", e.ID, "project_id", e.ProjectID, "workspace_id", e.WorkspaceID, "error", err)
+				return nil
+			}
+			return err
+		})
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// withOpenAPI は mux に仕様を返す /api/openapi.json を登録し、mode に応じて仕様で検証するハンドラを返す。
+func withOpenAPI(mux *http.ServeMux, mode openapi.Mode) (http.Handler, error) {
+	spec, err := openapi.Load()
+	if err != nil {
+		return nil, err
+	}
+	specHandler, err := openapi.SpecHandler(spec)
+	if err != nil {
+		return nil, err
+	}
+	mux.Handle(openapi.Path, specHandler)
+	return openapi.Middleware(spec, mode, mux)
+}
+
+// newTracer は OTLP の送信先が設定されていれば Tracer を生成する。設定されていなければ nil（記録しない）。
+func newTracer(cfg config) (*tracing.Tracer, error) {
+	if cfg.OTLPEndpoint == "" {
+		return nil, nil
+	}
+	slog.Info("exporting traces", "endpoint", cfg.OTLPEndpoint, "service", cfg.ServiceName, "sample_ratio", cfg.TraceSampleRatio)
+	return tracing.NewTracer(tracing.Options{
+		Endpoint:    cfg.OTLPEndpoint,
+		ServiceName: cfg.ServiceName,
+		SampleRatio: cfg.TraceSampleRatio,
+	})
+}
+
+// shutdownTracer は送信待ちのスパンを送ってから Tracer を停止する。
+func shutdownTracer(tracer *tracing.Tracer) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := tracer.Shutdown(ctx); err != nil {
+		slog.Warn("failed to flush traces", "error", err)
+	}
+}
+
+// fatal はエラーをログに出力して終了する。
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
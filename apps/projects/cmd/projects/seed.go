@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"teamflow-shared/devseed"
+
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// seedDemoProjects は devseed のデモのプロジェクトを create で作成する（DEV_SEED、ローカル開発用）。
+// 作成者は devseed.OwnerID（owner として登録する）。作成済み（削除済みを含む）の ID は作成しないため、
+// 永続化するリポジトリで再起動しても重複しない。キーが他のプロジェクトと重複する場合は作成せずにログに出力する。
+func seedDemoProjects(ctx context.Context, create *usecase.CreateProjectUsecase, repo usecase.ProjectRepository, now time.Time) error {
+	created := 0
+	for _, p := range devseed.Projects {
+		exists, err := projectExists(ctx, repo, p.ID)
+		if err != nil {
+			return fmt.Errorf("demo project %s: %w", p.ID, err)
+		}
+		if exists {
+			continue
+		}
+		_, err = create.Execute(ctx, usecase.CreateProjectInput{
+			ID:          p.ID,
+			Key:         p.Key,
+			Name:        p.Name,
+			Description: p.Description,
+			ActorID:     devseed.OwnerID,
+			Now:         now,
+		})
+		if errors.Is(err, usecase.ErrProjectKeyAlreadyExists) {
+			slog.Warn("skipped demo project: key already in use", "project_id", p.ID, "key", p.Key)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("demo project %s: %w", p.ID, err)
+		}
+		created++
+	}
+	slog.Info("loaded demo projects", "created", created, "total", len(devseed.Projects), "owner_id", devseed.OwnerID)
+	return nil
+}
+
+// projectExists は id のプロジェクト（削除済みを含む）があるかどうかを返す。
+func projectExists(ctx context.Context, repo usecase.ProjectRepository, id string) (bool, error) {
+	if _, err := repo.FindByID(ctx, id); !errors.Is(err, usecase.ErrProjectNotFound) {
+		return err == nil, err
+	}
+	if _, err := repo.FindDeleted(ctx, id); !errors.Is(err, usecase.ErrProjectNotFound) {
+		return err == nil, err
+	}
+	return false, nil
+}
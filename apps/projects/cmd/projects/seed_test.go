@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"teamflow-shared/devseed"
+
+	domain "teamflow-projects/internal/domain/project"
+	infra "teamflow-projects/internal/infrastructure/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+func TestSeedDemoProjects(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	repo := infra.NewMemoryProjectRepository()
+	members := infra.NewMemoryMemberRepository()
+	create := &usecase.CreateProjectUsecase{Repo: repo, Members: members}
+
+	// 2 回目（再起動）は作成済みのプロジェクトを作成しない
+	for i := 0; i < 2; i++ {
+		if err := seedDemoProjects(ctx, create, repo, now); err != nil {
+			t.Fatalf("run %d: unexpected error: %v", i+1, err)
+		}
+	}
+
+	all, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all) != len(devseed.Projects) {
+		t.Fatalf("expected %d projects, got %d", len(devseed.Projects), len(all))
+	}
+	for _, p := range devseed.Projects {
+		m, err := members.FindMember(ctx, p.ID, devseed.OwnerID)
+		if err != nil || m.Role != domain.RoleOwner {
+			t.Errorf("%s: expected %s to be the owner, got %+v, %v", p.ID, devseed.OwnerID, m, err)
+		}
+	}
+}
+
+func TestSeedDemoProjects_KeyInUse(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	repo := infra.NewMemoryProjectRepository()
+	create := &usecase.CreateProjectUsecase{Repo: repo}
+
+	// 利用者が同じキーのプロジェクトを作成済みの場合は、そのデモのプロジェクトだけ作成しない
+	if _, err := create.Execute(ctx, usecase.CreateProjectInput{Key: devseed.Projects[0].Key, Name: "既存", Now: now}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := seedDemoProjects(ctx, create, repo, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	all, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all) != len(devseed.Projects) {
+		t.Errorf("expected %d projects, got %d", len(devseed.Projects), len(all))
+	}
+}
@@ -0,0 +1,164 @@
+package project
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ActivityType はプロジェクトのアクティビティ（変更履歴）の種類を表す。
+type ActivityType string
+
+const (
+	ActivityProjectCreated    ActivityType = "project.created"
+	ActivityProjectUpdated    ActivityType = "project.updated"
+	ActivityProjectArchived   ActivityType = "project.archived"
+	ActivityProjectUnarchived ActivityType = "project.unarchived"
+	ActivityMemberAdded       ActivityType = "member.added"
+	ActivityMemberRemoved     ActivityType = "member.removed"
+)
+
+// ActivityEvent はプロジェクトに対する 1 回の変更の記録。
+type ActivityEvent struct {
+	ID        int64 // 記録時にリポジトリが採番する（記録順に大きくなる）
+	ProjectID string
+	Type      ActivityType
+	ActorID   string // 操作者。空の場合は不明
+	// Data は種類ごとの付加情報。
+	// project.created は name、project.updated は変更したフィールド（カンマ区切りの fields）、
+	// member.added は userId / role（招待で参加した場合は invitationId も）、member.removed は userId を持つ
+	Data      map[string]string
+	CreatedAt time.Time
+}
+
+// NewActivityEvent は新しいアクティビティを生成する。data が nil の場合は空の map を設定する。
+func NewActivityEvent(projectID string, typ ActivityType, actorID string, data map[string]string, now time.Time) *ActivityEvent {
+	if data == nil {
+		data = map[string]string{}
+	}
+	return &ActivityEvent{
+		ProjectID: projectID,
+		Type:      typ,
+		ActorID:   actorID,
+		Data:      data,
+		CreatedAt: now,
+	}
+}
+
+// ChangedFields は before から after で変更されたフィールド（key, name, description, status, visibility の順）を返す。
+// project.updated の fields に使う。
+func ChangedFields(before, after *Project) []string {
+	var fields []string
+	if before.Key != after.Key {
+		fields = append(fields, "key")
+	}
+	if before.Name != after.Name {
+		fields = append(fields, "name")
+	}
+	if before.Description != after.Description {
+		fields = append(fields, "description")
+	}
+	if before.Status != after.Status {
+		fields = append(fields, "status")
+	}
+	if before.Visibility != after.Visibility {
+		fields = append(fields, "visibility")
+	}
+	return fields
+}
+
+const (
+	// DefaultActivityLimit はアクティビティ一覧の limit の既定値。
+	DefaultActivityLimit = 50
+	// MaxActivityLimit はアクティビティ一覧の limit の上限（プロジェクト一覧と揃える）。
+	MaxActivityLimit = MaxListLimit
+)
+
+// activitySort はアクティビティ一覧のソート順（新しい順で固定。同時刻は ID の降順）。
+var activitySort = ProjectSort{Key: SortKeyCreatedAt, Direction: SortDirectionDESC}
+
+// ActivityQuery はプロジェクトのアクティビティ一覧の検索条件を表す Query Object。
+type ActivityQuery struct {
+	ProjectID string
+	Limit     int             // 1-200（default 50）
+	Cursor    *ActivityCursor // cursor デコード結果
+}
+
+// ActivityCursor は前ページ末尾のアクティビティの位置を保持する。
+type ActivityCursor struct {
+	CreatedAt time.Time
+	ID        int64
+	IssuedAt  int64
+}
+
+// NewActivityQuery はアクティビティ一覧の Query Object を構築する。
+// limit が範囲外の場合は ErrLimitOutOfRange、cursor が不正な場合は cursor の各エラーを返す。
+// cursor は発行したプロジェクトでのみ使える（別のプロジェクトの cursor は ErrCursorQueryMismatch）。
+func NewActivityQuery(projectID string, limit int, cursorStr string, secret []byte, now time.Time) (*ActivityQuery, error) {
+	if limit < 1 || limit > MaxActivityLimit {
+		return nil, ErrLimitOutOfRange
+	}
+	q := &ActivityQuery{ProjectID: projectID, Limit: limit}
+	if cursorStr == "" {
+		return q, nil
+	}
+
+	payload, err := DecodeCursor(cursorStr, secret)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateCursorExpiry(payload, now); err != nil {
+		return nil, err
+	}
+	if payload.QV != QHashVersion || payload.QHash != q.ComputeQHash() {
+		return nil, ErrCursorQueryMismatch
+	}
+	if payload.Sort != activitySort.String() {
+		return nil, ErrCursorInvalidFormat
+	}
+	createdAt, err := ParseCursorCreatedAt(payload.Key)
+	if err != nil {
+		return nil, ErrCursorInvalidFormat
+	}
+	id, err := strconv.ParseInt(payload.ID, 10, 64)
+	if err != nil {
+		return nil, ErrCursorInvalidFormat
+	}
+	q.Cursor = &ActivityCursor{CreatedAt: createdAt, ID: id, IssuedAt: payload.IssuedAt}
+	return q, nil
+}
+
+// ComputeQHash は cursor をプロジェクトに結び付けるための qhash を計算する。
+// プロジェクト一覧の qhash と衝突しないよう、対象（activity）を含めて正規化する。
+func (q *ActivityQuery) ComputeQHash() string {
+	canonical := "projectId=" + url.QueryEscape(q.ProjectID) + "&qv=" + strconv.Itoa(QHashVersion) + "&target=activity"
+	hash := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(hash[:8])
+}
+
+// NewCursorPayload は e をページ末尾として次ページの cursor payload を作る。
+func (q *ActivityQuery) NewCursorPayload(e *ActivityEvent, now time.Time) CursorPayload {
+	return CursorPayload{
+		V:        1,
+		Sort:     activitySort.String(),
+		Key:      FormatCursorCreatedAt(e.CreatedAt),
+		ID:       strconv.FormatInt(e.ID, 10),
+		QHash:    q.ComputeQHash(),
+		QV:       QHashVersion,
+		IssuedAt: now.Unix(),
+	}
+}
+
+// After は e が cursor より後ろ（次ページ側）に並ぶかどうかを返す。cursor が無い場合は常に true。
+func (q *ActivityQuery) After(e *ActivityEvent) bool {
+	if q.Cursor == nil {
+		return true
+	}
+	createdAt := e.CreatedAt.Truncate(time.Microsecond)
+	if !createdAt.Equal(q.Cursor.CreatedAt) {
+		return createdAt.Before(q.Cursor.CreatedAt)
+	}
+	return e.ID < q.Cursor.ID
+}
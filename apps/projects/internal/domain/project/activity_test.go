@@ -0,0 +1,95 @@
+package project
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestChangedFields(t *testing.T) {
+	before := &Project{Key: "TF", Name: "TeamFlow", Description: "desc", Status: StatusActive}
+
+	same := *before
+	if fields := ChangedFields(before, &same); len(fields) != 0 {
+		t.Errorf("expected no changed fields, got %v", fields)
+	}
+
+	after := *before
+	after.Name = "TeamFlow 2"
+	after.Status = StatusOnHold
+	after.Visibility = VisibilityPublic
+	if fields := ChangedFields(before, &after); !reflect.DeepEqual(fields, []string{"name", "status", "visibility"}) {
+		t.Errorf("unexpected changed fields: %v", fields)
+	}
+}
+
+func TestNewActivityQuery_Cursor(t *testing.T) {
+	secret := []byte("test-secret")
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	q, err := NewActivityQuery("proj-1", 10, "", secret, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	last := &ActivityEvent{ID: 42, ProjectID: "proj-1", CreatedAt: now.Add(-time.Minute)}
+	cursor, err := EncodeCursor(q.NewCursorPayload(last, now), secret)
+	if err != nil {
+		t.Fatalf("failed to encode cursor: %v", err)
+	}
+
+	next, err := NewActivityQuery("proj-1", 10, cursor, secret, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next.Cursor == nil || next.Cursor.ID != 42 || !next.Cursor.CreatedAt.Equal(last.CreatedAt) {
+		t.Fatalf("unexpected cursor: %+v", next.Cursor)
+	}
+
+	// 同時刻は ID の小さいもの、それ以外は古いものが次ページ側
+	tests := []struct {
+		e    *ActivityEvent
+		want bool
+	}{
+		{e: &ActivityEvent{ID: 41, CreatedAt: last.CreatedAt}, want: true},
+		{e: &ActivityEvent{ID: 43, CreatedAt: last.CreatedAt}, want: false},
+		{e: &ActivityEvent{ID: 50, CreatedAt: last.CreatedAt.Add(-time.Second)}, want: true},
+		{e: &ActivityEvent{ID: 1, CreatedAt: last.CreatedAt.Add(time.Second)}, want: false},
+	}
+	for _, tt := range tests {
+		if got := next.After(tt.e); got != tt.want {
+			t.Errorf("After(%+v) = %v, want %v", tt.e, got, tt.want)
+		}
+	}
+
+	// 別のプロジェクトの cursor は使えない
+	if _, err := NewActivityQuery("proj-2", 10, cursor, secret, now); !errors.Is(err, ErrCursorQueryMismatch) {
+		t.Errorf("expected ErrCursorQueryMismatch, got %v", err)
+	}
+	// プロジェクト一覧の cursor も使えない
+	listQuery, _ := NewProjectQuery()
+	listCursor, _ := EncodeCursor(listQuery.NewCursorPayload(&Project{ID: "proj-1", CreatedAt: now}, now), secret)
+	if _, err := NewActivityQuery("proj-1", 10, listCursor, secret, now); !errors.Is(err, ErrCursorQueryMismatch) {
+		t.Errorf("expected ErrCursorQueryMismatch for list cursor, got %v", err)
+	}
+}
+
+func TestNewActivityQuery_Errors(t *testing.T) {
+	secret := []byte("test-secret")
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, limit := range []int{0, MaxActivityLimit + 1} {
+		if _, err := NewActivityQuery("proj-1", limit, "", secret, now); !errors.Is(err, ErrLimitOutOfRange) {
+			t.Errorf("limit %d: expected ErrLimitOutOfRange, got %v", limit, err)
+		}
+	}
+	if _, err := NewActivityQuery("proj-1", 10, "invalid", secret, now); !errors.Is(err, ErrCursorInvalidFormat) {
+		t.Errorf("expected ErrCursorInvalidFormat, got %v", err)
+	}
+
+	q, _ := NewActivityQuery("proj-1", 10, "", secret, now)
+	cursor, _ := EncodeCursor(q.NewCursorPayload(&ActivityEvent{ID: 1, CreatedAt: now}, now), secret)
+	if _, err := NewActivityQuery("proj-1", 10, cursor, secret, now.Add(25*time.Hour)); !errors.Is(err, ErrCursorExpired) {
+		t.Errorf("expected ErrCursorExpired, got %v", err)
+	}
+}
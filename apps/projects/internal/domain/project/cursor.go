@@ -0,0 +1,95 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// CursorPayload は cursor の payload を表す。
+// Sort / Key はページ末尾のプロジェクトのソートキーの値（name はそのまま、createdAt は RFC3339Nano の micro秒精度）。
+type CursorPayload struct {
+	V        int    `json:"v"`
+	Sort     string `json:"sort"` // "name", "-createdAt" など（ProjectSort.String）
+	Key      string `json:"key"`
+	ID       string `json:"id"`
+	QHash    string `json:"qhash"`
+	QV       int    `json:"qv"` // qhash の正規化仕様バージョン（QHashVersion）
+	IssuedAt int64  `json:"iat"`
+}
+
+// EncodeCursor は cursor をエンコードする。
+// payload(JSON) に signToken で HMAC-SHA256 の署名を付ける（cursor = encodedPayload + "." + sig）。
+func EncodeCursor(payload CursorPayload, secret []byte) (string, error) {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	return signToken(payloadJSON, secret), nil
+}
+
+// MaxCursorLength は受け付ける cursor 文字列の最大長（バイト）。
+// name ソートの cursor はプロジェクト名を含むため tasks より大きめに取り、巨大な入力は base64 デコード前に弾く。
+const MaxCursorLength = 4096
+
+// maxCursorCreatedAtLength は cursor の createdAt 文字列の最大長（RFC3339Nano は最大 35 文字）。
+const maxCursorCreatedAtLength = 64
+
+// DecodeCursor は cursor をデコードし、署名を検証する。
+// エラーは validation error として返す（500にしない）。
+// 元エラーを wrap してデバッグ可能にする。
+//
+// 信頼できない入力を JSON パースしないよう、署名を検証してから payload を解釈する。
+func DecodeCursor(cursorStr string, secret []byte) (*CursorPayload, error) {
+	// 長さチェック（base64 デコード前に巨大な入力を弾く）
+	if len(cursorStr) > MaxCursorLength {
+		return nil, fmt.Errorf("%w: cursor exceeds %d bytes", ErrCursorInvalidFormat, MaxCursorLength)
+	}
+
+	// 署名を検証
+	payloadJSON, err := verifyToken(cursorStr, secret, ErrCursorInvalidFormat, ErrCursorInvalidSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON をパース（encoding/json は不正な UTF-8 を黙って置換するため、事前に弾く）
+	if !utf8.Valid(payloadJSON) {
+		return nil, fmt.Errorf("%w: payload is not valid UTF-8", ErrCursorInvalidFormat)
+	}
+	var payload CursorPayload
+	if err := json.Unmarshal(payloadJSON, &payload); err != nil {
+		return nil, fmt.Errorf("%w: json unmarshal: %v", ErrCursorInvalidFormat, err)
+	}
+
+	return &payload, nil
+}
+
+// ParseCursorCreatedAt は cursor の createdAt 文字列を time.Time に変換し、micro秒に丸める。
+func ParseCursorCreatedAt(createdAtStr string) (time.Time, error) {
+	if len(createdAtStr) > maxCursorCreatedAtLength {
+		return time.Time{}, fmt.Errorf("invalid cursor format: createdAt exceeds %d bytes", maxCursorCreatedAtLength)
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAtStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid cursor format: %w", err)
+	}
+	// micro秒に丸める
+	return t.Truncate(time.Microsecond), nil
+}
+
+// FormatCursorCreatedAt は time.Time を RFC3339Nano 形式の文字列に変換する（micro秒精度）。
+func FormatCursorCreatedAt(t time.Time) string {
+	// micro秒に丸めてからフォーマット
+	return t.Truncate(time.Microsecond).Format(time.RFC3339Nano)
+}
+
+// ValidateCursorExpiry は cursor の有効期限をチェックする（24時間）。
+// 期限切れの場合はエラーを返す。
+func ValidateCursorExpiry(payload *CursorPayload, now time.Time) error {
+	nowUnix := now.Unix()
+	if nowUnix-payload.IssuedAt > 86400 {
+		return ErrCursorExpired
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package project
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testCursorSecret = []byte("test-cursor-secret-32-bytes-long!")
+
+func testCursorPayload() CursorPayload {
+	return CursorPayload{
+		V:        1,
+		Sort:     "-name",
+		Key:      "TeamFlow 開発",
+		ID:       "proj-1",
+		QHash:    "abc",
+		QV:       QHashVersion,
+		IssuedAt: 1735689600,
+	}
+}
+
+// signRaw は任意の payload バイト列に正しい署名を付けた cursor を作る。
+func signRaw(payloadJSON []byte) string {
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payloadJSON)
+	mac := hmac.New(sha256.New, testCursorSecret)
+	mac.Write([]byte(encodedPayload))
+	return encodedPayload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestDecodeCursor_RoundTrip(t *testing.T) {
+	want := testCursorPayload()
+	cursor, err := EncodeCursor(want, testCursorSecret)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := DecodeCursor(cursor, testCursorSecret)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("expected %+v, got %+v", want, *got)
+	}
+}
+
+func TestDecodeCursor_RejectsPathologicalInputs(t *testing.T) {
+	valid, err := EncodeCursor(testCursorPayload(), testCursorSecret)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		cursor  string
+		wantErr error
+	}{
+		{"empty", "", ErrCursorInvalidFormat},
+		{"no separator", "not-a-valid-cursor", ErrCursorInvalidFormat},
+		{"too many separators", valid + ".extra", ErrCursorInvalidFormat},
+		{"invalid base64", "invalid.base64!!!", ErrCursorInvalidFormat},
+		{"huge payload", strings.Repeat("A", MaxCursorLength) + ".sig", ErrCursorInvalidFormat},
+		{"wrong signature", valid[:strings.Index(valid, ".")] + ".AAAA", ErrCursorInvalidSignature},
+		{"invalid utf-8", signRaw([]byte("{\"id\":\"\xff\xfe\"}")), ErrCursorInvalidFormat},
+		{"deeply nested json", signRaw([]byte(strings.Repeat("[", 300) + strings.Repeat("]", 300))), ErrCursorInvalidFormat},
+		{"not an object", signRaw([]byte(`"string"`)), ErrCursorInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeCursor(tt.cursor, testCursorSecret)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseCursorCreatedAt_RejectsTooLong(t *testing.T) {
+	_, err := ParseCursorCreatedAt("2025-01-01T00:00:00Z" + strings.Repeat(" ", 100))
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func FuzzDecodeCursor(f *testing.F) {
+	valid, err := EncodeCursor(testCursorPayload(), testCursorSecret)
+	if err != nil {
+		f.Fatalf("unexpected error: %v", err)
+	}
+	f.Add(valid)
+	f.Add("")
+	f.Add(".")
+	f.Add("not-a-valid-cursor")
+	f.Add("invalid.base64!!!")
+	f.Add(signRaw([]byte(`{}`)))
+	f.Add(signRaw([]byte("{\"id\":\"\xff\"}")))
+	f.Add(signRaw([]byte(strings.Repeat("[", 100))))
+
+	f.Fuzz(func(t *testing.T, cursor string) {
+		payload, err := DecodeCursor(cursor, testCursorSecret)
+		if err != nil {
+			if !errors.Is(err, ErrCursorInvalidFormat) && !errors.Is(err, ErrCursorInvalidSignature) {
+				t.Fatalf("unexpected error kind: %v", err)
+			}
+			return
+		}
+		if len(cursor) > MaxCursorLength {
+			t.Fatalf("accepted cursor longer than %d bytes", MaxCursorLength)
+		}
+
+		// デコードできた payload は再エンコード後も同じ内容で読めること
+		reencoded, err := EncodeCursor(*payload, testCursorSecret)
+		if err != nil {
+			t.Fatalf("re-encode failed: %v", err)
+		}
+		again, err := DecodeCursor(reencoded, testCursorSecret)
+		if err != nil {
+			t.Fatalf("re-decode failed: %v", err)
+		}
+		if !reflect.DeepEqual(payload, again) {
+			t.Fatalf("round trip mismatch: %+v != %+v", payload, again)
+		}
+	})
+}
+
+func FuzzParseCursorCreatedAt(f *testing.F) {
+	f.Add("2025-01-01T00:00:00.123456Z")
+	f.Add("2025-01-01T00:00:00.123456789+09:00")
+	f.Add("2025-01-01T00:00:00Z")
+	f.Add("")
+	f.Add("not-a-time")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		got, err := ParseCursorCreatedAt(s)
+		if err != nil {
+			return
+		}
+		if got.Nanosecond()%int(time.Microsecond) != 0 {
+			t.Fatalf("expected microsecond precision, got %v", got)
+		}
+
+		// パースできた値は FormatCursorCreatedAt 経由で同じ時刻に戻ること
+		again, err := ParseCursorCreatedAt(FormatCursorCreatedAt(got))
+		if err != nil {
+			t.Fatalf("re-parse failed: %v", err)
+		}
+		if !again.Equal(got) {
+			t.Fatalf("round trip mismatch: %v != %v", got, again)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DeletePolicy はプロジェクトを削除するときのタスクの扱い（cascade）。
+type DeletePolicy string
+
+const (
+	// DeletePolicyBlock はタスクが 1 件でもあれば削除しない（既定）。
+	DeletePolicyBlock DeletePolicy = "block"
+	// DeletePolicyArchiveTasks はタスクをアーカイブする。復元するとタスクも戻る。
+	DeletePolicyArchiveTasks DeletePolicy = "archive_tasks"
+	// DeletePolicyDeleteTasks はタスクを削除する。プロジェクトを復元してもタスクは戻らない。
+	DeletePolicyDeleteTasks DeletePolicy = "delete_tasks"
+)
+
+// ParseDeletePolicy は文字列から DeletePolicy を生成する。
+// 前後の空白と大文字小文字は無視し、空の場合は DeletePolicyBlock を返す。
+// 未知の値の場合は ErrInvalidDeletePolicy を返す。
+func ParseDeletePolicy(s string) (DeletePolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "block":
+		return DeletePolicyBlock, nil
+	case "archive_tasks":
+		return DeletePolicyArchiveTasks, nil
+	case "delete_tasks":
+		return DeletePolicyDeleteTasks, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidDeletePolicy, s)
+	}
+}
+
+// IsDeleted はプロジェクトが削除（論理削除）されているかどうかを返す。
+func (p *Project) IsDeleted() bool {
+	return p.DeletedAt != nil
+}
+
+// RestorableUntil は削除されたプロジェクトを復元できる期限（削除日時 + window）を返す。
+// 削除されていない場合はゼロ値を返す。
+func (p *Project) RestorableUntil(window time.Duration) time.Time {
+	if p.DeletedAt == nil {
+		return time.Time{}
+	}
+	return p.DeletedAt.Add(window)
+}
+
+// CanRestore は now の時点で削除されたプロジェクトを復元できるかどうかを返す（期限ちょうどは復元できない）。
+func (p *Project) CanRestore(now time.Time, window time.Duration) bool {
+	return p.IsDeleted() && now.Before(p.RestorableUntil(window))
+}
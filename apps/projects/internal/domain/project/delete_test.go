@@ -0,0 +1,66 @@
+package project
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseDeletePolicy(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    DeletePolicy
+		wantErr bool
+	}{
+		{in: "", want: DeletePolicyBlock},
+		{in: "block", want: DeletePolicyBlock},
+		{in: " Archive_Tasks ", want: DeletePolicyArchiveTasks},
+		{in: "delete_tasks", want: DeletePolicyDeleteTasks},
+		{in: "delete-tasks", wantErr: true},
+		{in: "cascade", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseDeletePolicy(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidDeletePolicy) {
+					t.Fatalf("expected ErrInvalidDeletePolicy, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProject_CanRestore(t *testing.T) {
+	deletedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := 24 * time.Hour
+	deleted := &Project{ID: "proj-1", DeletedAt: &deletedAt}
+
+	tests := []struct {
+		name    string
+		project *Project
+		now     time.Time
+		want    bool
+	}{
+		{name: "within window", project: deleted, now: deletedAt.Add(window - time.Second), want: true},
+		{name: "at the deadline", project: deleted, now: deletedAt.Add(window), want: false},
+		{name: "after window", project: deleted, now: deletedAt.Add(2 * window), want: false},
+		{name: "not deleted", project: &Project{ID: "proj-2"}, now: deletedAt, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.project.CanRestore(tt.now, window); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
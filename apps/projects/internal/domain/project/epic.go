@@ -0,0 +1,105 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EpicStatus はエピックの状態を表す型。
+type EpicStatus string
+
+const (
+	EpicOpen   EpicStatus = "open"
+	EpicClosed EpicStatus = "closed"
+)
+
+// ParseEpicStatus は正規の EpicStatus か検証し、型付きで返す。空の場合は open。
+func ParseEpicStatus(s string) (EpicStatus, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return EpicOpen, nil
+	}
+	switch EpicStatus(s) {
+	case EpicOpen, EpicClosed:
+		return EpicStatus(s), nil
+	default:
+		return "", fmt.Errorf("%w: status must be one of open, closed", ErrInvalidEpic)
+	}
+}
+
+// Epic はプロジェクトのエピック（複数のタスクにまたがる大きな作業単位）を表す。
+// タスクは tasks サービス側で epicId によってエピックに属し、マイルストーン・スプリントとは独立に設定できる。
+type Epic struct {
+	ID          string // プロジェクト内で一意
+	ProjectID   string
+	Name        string
+	Description string // 空は説明なし
+	Status      EpicStatus
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewEpic は新しいエピックを生成する。
+// ID・名前が空、status が不正な場合は ErrInvalidEpic を返す。
+func NewEpic(id, projectID, name, description, status string, now time.Time) (*Epic, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, fmt.Errorf("%w: id must not be empty", ErrInvalidEpic)
+	}
+	if strings.Contains(id, "/") {
+		return nil, fmt.Errorf("%w: id must not contain '/'", ErrInvalidEpic)
+	}
+
+	e := &Epic{
+		ID:        id,
+		ProjectID: projectID,
+		CreatedAt: now,
+	}
+	if err := e.Update(name, description, status, now); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Update は名前・説明・状態を置き換える（PUT）。不正な値の場合は ErrInvalidEpic を返し、e は変更しない。
+func (e *Epic) Update(name, description, status string, now time.Time) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("%w: name must not be empty", ErrInvalidEpic)
+	}
+	s, err := ParseEpicStatus(status)
+	if err != nil {
+		return err
+	}
+
+	e.Name = name
+	e.Description = strings.TrimSpace(description)
+	e.Status = s
+	e.UpdatedAt = now
+	return nil
+}
+
+// EpicTaskCounts は tasks サービスから取得したエピックごとのタスクの件数と見積もりの合計。
+type EpicTaskCounts struct {
+	Total         int // タスク数
+	Done          int // 完了したタスク数
+	EstimateTotal int // 見積もりの合計（見積もりの無いタスクは 0 として数える）
+	EstimateDone  int // 完了したタスクの見積もりの合計
+}
+
+// EpicProgress はエピックとそのタスクの集計。
+type EpicProgress struct {
+	Epic *Epic
+	EpicTaskCounts
+}
+
+// ComputeEpicProgress は epics の並び順のまま、counts（epicID をキーとする）の件数を対応付ける。
+// タスクの無いエピックは 0 件とする。
+func ComputeEpicProgress(epics []*Epic, counts map[string]EpicTaskCounts) []EpicProgress {
+	out := make([]EpicProgress, len(epics))
+	for i, e := range epics {
+		out[i] = EpicProgress{Epic: e, EpicTaskCounts: counts[e.ID]}
+	}
+	return out
+}
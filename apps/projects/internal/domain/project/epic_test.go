@@ -0,0 +1,83 @@
+package project
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewEpic(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	e, err := NewEpic(" login ", "proj-1", " ログイン刷新 ", " SSO 対応 ", "", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.ID != "login" || e.Name != "ログイン刷新" || e.Description != "SSO 対応" || e.Status != EpicOpen {
+		t.Errorf("unexpected epic: %+v", e)
+	}
+	if !e.CreatedAt.Equal(now) || !e.UpdatedAt.Equal(now) {
+		t.Errorf("unexpected timestamps: %+v", e)
+	}
+
+	tests := []struct {
+		name   string
+		id     string
+		ename  string
+		status string
+	}{
+		{name: "empty id", id: "", ename: "e1"},
+		{name: "id with slash", id: "a/b", ename: "e1"},
+		{name: "empty name", id: "e1", ename: "  "},
+		{name: "invalid status", id: "e1", ename: "e1", status: "done"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewEpic(tt.id, "proj-1", tt.ename, "", tt.status, now); !errors.Is(err, ErrInvalidEpic) {
+				t.Errorf("expected ErrInvalidEpic, got %v", err)
+			}
+		})
+	}
+}
+
+func TestEpic_Update(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	e, err := NewEpic("e1", "proj-1", "e1", "", "", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 不正な値の場合は変更しない
+	if err := e.Update("e1'", "", "unknown", now.Add(time.Hour)); !errors.Is(err, ErrInvalidEpic) {
+		t.Fatalf("expected ErrInvalidEpic, got %v", err)
+	}
+	if e.Name != "e1" || !e.UpdatedAt.Equal(now) {
+		t.Errorf("epic must not change on error: %+v", e)
+	}
+
+	if err := e.Update("e1'", "説明", "Closed", now.Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.Name != "e1'" || e.Description != "説明" || e.Status != EpicClosed || !e.UpdatedAt.Equal(now.Add(time.Hour)) || !e.CreatedAt.Equal(now) {
+		t.Errorf("unexpected epic: %+v", e)
+	}
+}
+
+func TestComputeEpicProgress(t *testing.T) {
+	epics := []*Epic{{ID: "e2"}, {ID: "e1"}}
+	counts := map[string]EpicTaskCounts{
+		"e1":      {Total: 3, Done: 1, EstimateTotal: 8, EstimateDone: 3},
+		"deleted": {Total: 5},
+	}
+
+	got := ComputeEpicProgress(epics, counts)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(got))
+	}
+	if got[0].Epic.ID != "e2" || got[0].Total != 0 || got[0].EstimateTotal != 0 {
+		t.Errorf("unexpected first entry: %+v", got[0])
+	}
+	if got[1].Epic.ID != "e1" || got[1].Total != 3 || got[1].Done != 1 || got[1].EstimateTotal != 8 || got[1].EstimateDone != 3 {
+		t.Errorf("unexpected second entry: %+v", got[1])
+	}
+}
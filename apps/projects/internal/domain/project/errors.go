@@ -0,0 +1,152 @@
+package project
+
+import (
+	"errors"
+
+	"teamflow-shared/authz"
+)
+
+// --- Sentinel Errors ---
+// これらは errors.Is で判定可能。HTTP 層で 400 に変換される。
+
+// Project validation errors
+var (
+	// ErrNameRequired は name が空の場合のエラー。
+	ErrNameRequired = errors.New("project name must not be empty")
+
+	// ErrInvalidStatus は status が active / on_hold / completed 以外の場合のエラー。
+	ErrInvalidStatus = errors.New("status must be one of active, on_hold, completed")
+
+	// ErrInvalidKey は key が英大文字で始まる 2〜10 文字の英大文字・数字でない場合のエラー。
+	ErrInvalidKey = errors.New("key must be 2-10 uppercase letters or digits, starting with a letter")
+
+	// ErrInvalidVisibility は visibility が private / public 以外の場合のエラー。
+	ErrInvalidVisibility = authz.ErrInvalidVisibility
+
+	// ErrInvalidID は id が UUID の形式の方針（UUID_POLICY）に合わない場合のエラー。
+	ErrInvalidID = errors.New("id must be a valid UUID")
+
+	// ErrInvalidDeletePolicy は cascade が block / archive_tasks / delete_tasks 以外の場合のエラー。
+	ErrInvalidDeletePolicy = errors.New("cascade must be one of block, archive_tasks, delete_tasks")
+)
+
+// Member validation errors
+var (
+	// ErrInvalidUserID は userId が空の場合のエラー。
+	ErrInvalidUserID = errors.New("userId must not be empty")
+
+	// ErrInvalidMemberRole は role が owner / admin / member 以外の場合のエラー。
+	ErrInvalidMemberRole = errors.New("role must be one of owner, admin, member")
+)
+
+// Settings validation errors
+var (
+	// ErrInvalidSettings はプロジェクト設定の値が不正な場合のエラー。
+	ErrInvalidSettings = errors.New("invalid project settings")
+)
+
+// Preference validation errors
+var (
+	// ErrInvalidProjectOrder は並び順の projectIds が不正（空の ID・重複・件数超過）な場合のエラー。
+	ErrInvalidProjectOrder = errors.New("invalid project order")
+)
+
+// Template validation errors
+var (
+	// ErrInvalidTemplate はプロジェクトテンプレートの値が不正な場合のエラー。
+	ErrInvalidTemplate = errors.New("invalid project template")
+)
+
+// Milestone validation errors
+var (
+	// ErrInvalidMilestone はマイルストーンの値が不正な場合のエラー。
+	ErrInvalidMilestone = errors.New("invalid milestone")
+)
+
+// Sprint validation errors
+var (
+	// ErrInvalidSprint はスプリントの値が不正な場合のエラー。
+	ErrInvalidSprint = errors.New("invalid sprint")
+
+	// ErrSprintStateConflict はスプリントの現在の状態では行えない操作（完了済みの更新、計画中以外の開始など）の場合のエラー。
+	// バリデーションエラーではないため、HTTP 層では 409 に変換される。
+	ErrSprintStateConflict = errors.New("operation is not allowed in the current sprint state")
+)
+
+// Epic validation errors
+var (
+	// ErrInvalidEpic はエピックの値が不正な場合のエラー。
+	ErrInvalidEpic = errors.New("invalid epic")
+)
+
+// Label validation errors
+var (
+	// ErrInvalidLabel はラベルの値が不正な場合のエラー。
+	ErrInvalidLabel = errors.New("invalid label")
+)
+
+// Slack integration validation errors
+var (
+	// ErrInvalidSlackIntegration は Slack 連携の設定（Webhook URL・通知するイベント）が不正な場合のエラー。
+	ErrInvalidSlackIntegration = errors.New("invalid slack integration")
+)
+
+// Import validation errors
+var (
+	// ErrInvalidImport はタスクのインポート元の指定・エクスポートの内容が不正な場合のエラー。
+	ErrInvalidImport = errors.New("invalid import")
+)
+
+// Invitation errors
+var (
+	// ErrInvalidInvitation は招待の値（ロール・有効期間）が不正な場合のエラー。
+	ErrInvalidInvitation = errors.New("invalid invitation")
+
+	// ErrInvalidInvitationToken は招待トークンの形式・署名が不正な場合のエラー。HTTP 層では 404 に変換される。
+	ErrInvalidInvitationToken = errors.New("invalid invitation token")
+
+	// ErrInvitationExpired は招待の有効期限が切れている場合のエラー。HTTP 層では 404 に変換される。
+	ErrInvitationExpired = errors.New("invitation expired")
+
+	// ErrInvitationRevoked は招待が取り消されている場合のエラー。HTTP 層では 404 に変換される。
+	ErrInvitationRevoked = errors.New("invitation revoked")
+)
+
+// Purge validation errors
+var (
+	// ErrInvalidRetentionDays は物理削除の対象の日数（olderThanDays）が 1 未満の場合のエラー。
+	ErrInvalidRetentionDays = errors.New("olderThanDays must be at least 1")
+)
+
+// Query validation errors
+var (
+	// ErrLimitOutOfRange は limit が 1-200 の範囲外の場合のエラー。
+	ErrLimitOutOfRange = errors.New("limit must be between 1 and 200")
+
+	// ErrInvalidSort は sort に未対応のキーが指定された場合のエラー。
+	ErrInvalidSort = errors.New("sort must be one of name, -name, createdAt, -createdAt")
+
+	// ErrInvalidArchived は archived が true / false 以外の場合のエラー。
+	ErrInvalidArchived = errors.New("archived must be true or false")
+
+	// ErrInvalidExpand は expand に未対応の値が指定された場合のエラー。
+	ErrInvalidExpand = errors.New("expand must be taskCounts")
+
+	// ErrSortIncompatibleWithCursor は cursor と sort の併用時のエラー。
+	ErrSortIncompatibleWithCursor = errors.New("sort is incompatible with cursor")
+)
+
+// Cursor validation errors
+var (
+	// ErrCursorInvalidFormat は cursor の形式が不正な場合のエラー。
+	ErrCursorInvalidFormat = errors.New("invalid cursor format")
+
+	// ErrCursorInvalidSignature は cursor の署名が不正な場合のエラー。
+	ErrCursorInvalidSignature = errors.New("invalid cursor signature")
+
+	// ErrCursorExpired は cursor の有効期限が切れている場合のエラー。
+	ErrCursorExpired = errors.New("cursor expired")
+
+	// ErrCursorQueryMismatch は cursor のクエリ条件が一致しない場合のエラー。
+	ErrCursorQueryMismatch = errors.New("cursor query mismatch")
+)
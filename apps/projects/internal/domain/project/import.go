@@ -0,0 +1,344 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ImportSource はタスクのインポート元のサービス。
+type ImportSource string
+
+const (
+	ImportSourceTrello ImportSource = "trello" // Trello のボードのエクスポート（JSON）
+	ImportSourceJira   ImportSource = "jira"   // Jira の課題の検索結果（REST API /rest/api/{2,3}/search の JSON）
+)
+
+// MaxImportTasks は 1 回のインポートで作成できるタスクの最大数（tasks サービスの一括作成の上限と同じ）。
+const MaxImportTasks = 200
+
+// ImportValueMapping はインポート元の値（リスト名・ステータス名・優先度名）と TeamFlow の値の対応。
+type ImportValueMapping struct {
+	From  string
+	To    string // 優先度の場合、空はプロジェクト設定の既定値
+	Count int    // この対応でインポートするタスクの数
+}
+
+// ImportSkip はインポートしない項目（アーカイブ済みのカード、タイトルの無い課題など）。
+type ImportSkip struct {
+	SourceID string // インポート元の ID（Trello のカード ID、Jira の課題キー）
+	Title    string
+	Reason   string
+}
+
+// ImportPlan はエクスポートを TeamFlow のタスクに変換した結果（インポートの内容とマッピングのレポート）。
+type ImportPlan struct {
+	Source ImportSource
+	Tasks  []TaskBlueprint
+	// Statuses / Priorities はインポート元の値ごとの対応（インポート元に現れた順）
+	Statuses   []ImportValueMapping
+	Priorities []ImportValueMapping
+	Skipped    []ImportSkip
+	// Warnings は推測で対応付けた値など、インポートの前に確認した方がよい点
+	Warnings []string
+}
+
+// ParseImport は source のエクスポート（JSON）を TeamFlow のタスクに変換する。
+// source が不明、JSON の形式が不正、タスクが MaxImportTasks を超える場合は ErrInvalidImport を返す。
+func ParseImport(source ImportSource, data []byte) (*ImportPlan, error) {
+	var plan *ImportPlan
+	var err error
+	switch source {
+	case ImportSourceTrello:
+		plan, err = parseTrello(data)
+	case ImportSourceJira:
+		plan, err = parseJira(data)
+	default:
+		return nil, fmt.Errorf("%w: source must be one of trello, jira", ErrInvalidImport)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.Tasks) > MaxImportTasks {
+		return nil, fmt.Errorf("%w: export has %d tasks to import (max %d)", ErrInvalidImport, len(plan.Tasks), MaxImportTasks)
+	}
+	return plan, nil
+}
+
+// add はタスクを追加し、ステータス・優先度の対応を数える（インポート元の値が空の場合は数えない）。
+func (p *ImportPlan) add(bp TaskBlueprint, statusFrom, priorityFrom string) {
+	p.Tasks = append(p.Tasks, bp)
+	if statusFrom != "" {
+		p.Statuses = countMapping(p.Statuses, statusFrom, bp.Status)
+	}
+	if priorityFrom != "" {
+		p.Priorities = countMapping(p.Priorities, priorityFrom, bp.Priority)
+	}
+}
+
+func countMapping(mappings []ImportValueMapping, from, to string) []ImportValueMapping {
+	for i := range mappings {
+		if mappings[i].From == from {
+			mappings[i].Count++
+			return mappings
+		}
+	}
+	return append(mappings, ImportValueMapping{From: from, To: to, Count: 1})
+}
+
+// --- Trello ---
+
+type trelloExport struct {
+	Lists []trelloList  `json:"lists"`
+	Cards *[]trelloCard `json:"cards"`
+}
+
+type trelloList struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Closed bool   `json:"closed"`
+}
+
+type trelloCard struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Desc   string `json:"desc"`
+	IDList string `json:"idList"`
+	Closed bool   `json:"closed"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+// parseTrello はボードのエクスポートを変換する。
+// ステータスはカードのリストの名前から、優先度はラベルの名前から推測する（該当するラベルが無い場合は既定値）。
+// アーカイブ済みのカード・リストのカードはインポートしない。
+func parseTrello(data []byte) (*ImportPlan, error) {
+	var export trelloExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("%w: data is not a trello board export: %w", ErrInvalidImport, err)
+	}
+	if export.Cards == nil {
+		return nil, fmt.Errorf("%w: trello export must have cards", ErrInvalidImport)
+	}
+
+	lists := make(map[string]trelloList, len(export.Lists))
+	for _, l := range export.Lists {
+		lists[l.ID] = l
+	}
+
+	plan := &ImportPlan{Source: ImportSourceTrello}
+	guessed := map[string]bool{}
+	for _, c := range *export.Cards {
+		title := strings.TrimSpace(c.Name)
+		list, ok := lists[c.IDList]
+		switch {
+		case c.Closed:
+			plan.Skipped = append(plan.Skipped, ImportSkip{SourceID: c.ID, Title: title, Reason: "card is archived"})
+			continue
+		case !ok:
+			plan.Skipped = append(plan.Skipped, ImportSkip{SourceID: c.ID, Title: title, Reason: "list not found"})
+			continue
+		case list.Closed:
+			plan.Skipped = append(plan.Skipped, ImportSkip{SourceID: c.ID, Title: title, Reason: "list is archived"})
+			continue
+		case title == "":
+			plan.Skipped = append(plan.Skipped, ImportSkip{SourceID: c.ID, Reason: "title is empty"})
+			continue
+		}
+
+		status, matched := statusFromName(list.Name)
+		if !matched && !guessed[list.Name] {
+			guessed[list.Name] = true
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("list %q does not look like a status; mapped to todo", list.Name))
+		}
+		var priority, priorityFrom string
+		for _, l := range c.Labels {
+			if p, ok := priorityFromName(l.Name); ok {
+				priority, priorityFrom = p, l.Name
+				break
+			}
+		}
+		plan.add(TaskBlueprint{Title: title, Description: c.Desc, Status: status, Priority: priority}, list.Name, priorityFrom)
+	}
+	return plan, nil
+}
+
+// --- Jira ---
+
+type jiraExport struct {
+	Issues *[]jiraIssue `json:"issues"`
+}
+
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string `json:"summary"`
+		// Description は API v2 では文字列、v3 では Atlassian Document Format（ADF）
+		Description json.RawMessage `json:"description"`
+		Status      *struct {
+			Name           string `json:"name"`
+			StatusCategory *struct {
+				Key string `json:"key"`
+			} `json:"statusCategory"`
+		} `json:"status"`
+		Priority *struct {
+			Name string `json:"name"`
+		} `json:"priority"`
+	} `json:"fields"`
+}
+
+// jiraStatusCategories は Jira のステータスカテゴリの TeamFlow のステータスへの対応。
+var jiraStatusCategories = map[string]string{
+	"new":           "todo",
+	"indeterminate": "in_progress",
+	"done":          "done",
+}
+
+// parseJira は課題の検索結果を変換する。
+// ステータスはステータスカテゴリ（無い場合はステータス名）から、優先度は優先度名から対応付ける。
+func parseJira(data []byte) (*ImportPlan, error) {
+	var export jiraExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("%w: data is not a jira search result: %w", ErrInvalidImport, err)
+	}
+	if export.Issues == nil {
+		return nil, fmt.Errorf("%w: jira export must have issues", ErrInvalidImport)
+	}
+
+	plan := &ImportPlan{Source: ImportSourceJira}
+	warned := map[string]bool{}
+	warn := func(key, format string, args ...any) {
+		if !warned[key] {
+			warned[key] = true
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf(format, args...))
+		}
+	}
+	for _, is := range *export.Issues {
+		f := is.Fields
+		title := strings.TrimSpace(f.Summary)
+		if title == "" {
+			plan.Skipped = append(plan.Skipped, ImportSkip{SourceID: is.Key, Reason: "title is empty"})
+			continue
+		}
+
+		statusFrom, status := "", "todo"
+		if f.Status != nil {
+			statusFrom = f.Status.Name
+			mapped := false
+			if f.Status.StatusCategory != nil {
+				status, mapped = jiraStatusCategories[f.Status.StatusCategory.Key]
+			}
+			if !mapped {
+				status, mapped = statusFromName(f.Status.Name)
+			}
+			if !mapped {
+				warn("status:"+f.Status.Name, "status %q does not look like a status category; mapped to todo", f.Status.Name)
+			}
+		}
+
+		var priority, priorityFrom string
+		if f.Priority != nil && f.Priority.Name != "" {
+			priorityFrom = f.Priority.Name
+			var ok bool
+			if priority, ok = priorityFromName(f.Priority.Name); !ok {
+				warn("priority:"+f.Priority.Name, "priority %q is unknown; the project default is used", f.Priority.Name)
+			}
+		}
+
+		bp := TaskBlueprint{Title: title, Description: jiraDescription(f.Description), Status: status, Priority: priority}
+		plan.add(bp, statusFrom, priorityFrom)
+	}
+	return plan, nil
+}
+
+// adfNode は Atlassian Document Format のノード。
+type adfNode struct {
+	Type    string    `json:"type"`
+	Text    string    `json:"text"`
+	Content []adfNode `json:"content"`
+}
+
+// jiraDescription は説明（文字列または ADF）をプレーンテキストにする。ADF はテキストのみを取り出し、段落ごとに改行する。
+func jiraDescription(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var doc adfNode
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return ""
+	}
+	var b strings.Builder
+	writeADFText(&b, doc)
+	return strings.TrimSpace(b.String())
+}
+
+func writeADFText(b *strings.Builder, n adfNode) {
+	switch n.Type {
+	case "text":
+		b.WriteString(n.Text)
+		return
+	case "hardBreak":
+		b.WriteString("\n")
+		return
+	}
+	for _, c := range n.Content {
+		writeADFText(b, c)
+	}
+	switch n.Type {
+	case "paragraph", "heading", "codeBlock", "listItem", "blockquote", "rule":
+		if !strings.HasSuffix(b.String(), "\n") {
+			b.WriteString("\n")
+		}
+	}
+}
+
+// --- 名前からの推測 ---
+
+// statusKeywords はリスト名・ステータス名から TeamFlow のステータスを推測するためのキーワード（前から順に確認する）。
+var statusKeywords = []struct {
+	status   string
+	keywords []string
+}{
+	{"done", []string{"done", "complete", "closed", "finished", "resolved", "完了", "済"}},
+	{"in_progress", []string{"doing", "progress", "review", "testing", "wip", "進行", "作業中", "対応中", "レビュー"}},
+	{"todo", []string{"todo", "to do", "backlog", "open", "new", "未着手", "未対応", "やること"}},
+}
+
+// statusFromName は name から TeamFlow のステータスを推測する。該当しない場合は todo と false を返す。
+func statusFromName(name string) (string, bool) {
+	n := strings.ToLower(strings.TrimSpace(name))
+	for _, s := range statusKeywords {
+		for _, k := range s.keywords {
+			if strings.Contains(n, k) {
+				return s.status, true
+			}
+		}
+	}
+	return "todo", false
+}
+
+// importPriorities はインポート元の優先度名（小文字）の TeamFlow の優先度への対応。
+// Jira の既定の優先度（Highest〜Lowest、Blocker〜Trivial）と、Trello でよく使うラベル名を含む。
+var importPriorities = map[string]string{
+	"highest": "high", "high": "high", "blocker": "high", "critical": "high", "urgent": "high", "高": "high", "緊急": "high",
+	"medium": "medium", "major": "medium", "normal": "medium", "中": "medium",
+	"low": "low", "lowest": "low", "minor": "low", "trivial": "low", "低": "low",
+}
+
+// priorityFromName は name を TeamFlow の優先度に対応付ける。該当しない場合は false を返す。
+// "priority: high" や "優先度: 高" のような接頭辞は取り除いて判定する。
+func priorityFromName(name string) (string, bool) {
+	n := strings.ToLower(strings.TrimSpace(name))
+	for _, prefix := range []string{"priority", "優先度"} {
+		if rest, ok := strings.CutPrefix(n, prefix); ok {
+			n = strings.TrimSpace(strings.TrimLeft(rest, ":：-/ "))
+		}
+	}
+	p, ok := importPriorities[n]
+	return p, ok
+}
@@ -0,0 +1,171 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const trelloExportJSON = `{
+	"name": "開発ボード",
+	"lists": [
+		{"id": "l1", "name": "To Do"},
+		{"id": "l2", "name": "Doing"},
+		{"id": "l3", "name": "完了"},
+		{"id": "l4", "name": "Ideas"},
+		{"id": "l5", "name": "Old", "closed": true}
+	],
+	"cards": [
+		{"id": "c1", "name": " 設計 ", "desc": "画面設計", "idList": "l1", "labels": [{"name": "frontend"}, {"name": "Priority: High"}]},
+		{"id": "c2", "name": "実装", "idList": "l2", "labels": [{"name": "低"}]},
+		{"id": "c3", "name": "リリース", "idList": "l3"},
+		{"id": "c4", "name": "アイデア", "idList": "l4"},
+		{"id": "c5", "name": "古いカード", "idList": "l1", "closed": true},
+		{"id": "c6", "name": "古いリスト", "idList": "l5"},
+		{"id": "c7", "name": "  ", "idList": "l1"},
+		{"id": "c8", "name": "行方不明", "idList": "lx"}
+	]
+}`
+
+func TestParseImport_Trello(t *testing.T) {
+	plan, err := ParseImport(ImportSourceTrello, []byte(trelloExportJSON))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantTasks := []TaskBlueprint{
+		{Title: "設計", Description: "画面設計", Status: "todo", Priority: "high"},
+		{Title: "実装", Status: "in_progress", Priority: "low"},
+		{Title: "リリース", Status: "done"},
+		{Title: "アイデア", Status: "todo"},
+	}
+	if !reflect.DeepEqual(plan.Tasks, wantTasks) {
+		t.Errorf("tasks = %+v, want %+v", plan.Tasks, wantTasks)
+	}
+	wantStatuses := []ImportValueMapping{
+		{From: "To Do", To: "todo", Count: 1},
+		{From: "Doing", To: "in_progress", Count: 1},
+		{From: "完了", To: "done", Count: 1},
+		{From: "Ideas", To: "todo", Count: 1},
+	}
+	if !reflect.DeepEqual(plan.Statuses, wantStatuses) {
+		t.Errorf("statuses = %+v, want %+v", plan.Statuses, wantStatuses)
+	}
+	wantPriorities := []ImportValueMapping{
+		{From: "Priority: High", To: "high", Count: 1},
+		{From: "低", To: "low", Count: 1},
+	}
+	if !reflect.DeepEqual(plan.Priorities, wantPriorities) {
+		t.Errorf("priorities = %+v, want %+v", plan.Priorities, wantPriorities)
+	}
+	wantSkipped := []ImportSkip{
+		{SourceID: "c5", Title: "古いカード", Reason: "card is archived"},
+		{SourceID: "c6", Title: "古いリスト", Reason: "list is archived"},
+		{SourceID: "c7", Reason: "title is empty"},
+		{SourceID: "c8", Title: "行方不明", Reason: "list not found"},
+	}
+	if !reflect.DeepEqual(plan.Skipped, wantSkipped) {
+		t.Errorf("skipped = %+v, want %+v", plan.Skipped, wantSkipped)
+	}
+	if len(plan.Warnings) != 1 || !strings.Contains(plan.Warnings[0], `"Ideas"`) {
+		t.Errorf("expected a warning for the Ideas list, got %v", plan.Warnings)
+	}
+}
+
+const jiraExportJSON = `{
+	"total": 4,
+	"issues": [
+		{"key": "TF-1", "fields": {"summary": "ログイン", "description": "v2 の説明",
+			"status": {"name": "Backlog", "statusCategory": {"key": "new"}}, "priority": {"name": "Highest"}}},
+		{"key": "TF-2", "fields": {"summary": "検索",
+			"description": {"type": "doc", "version": 1, "content": [
+				{"type": "paragraph", "content": [{"type": "text", "text": "1 行目"}, {"type": "hardBreak"}, {"type": "text", "text": "2 行目"}]},
+				{"type": "paragraph", "content": [{"type": "text", "text": "次の段落"}]}
+			]},
+			"status": {"name": "In Review", "statusCategory": {"key": "indeterminate"}}, "priority": {"name": "Major"}}},
+		{"key": "TF-3", "fields": {"summary": "リリース", "status": {"name": "Shipped"}, "priority": {"name": "P1"}}},
+		{"key": "TF-4", "fields": {"summary": "", "status": {"name": "Done"}}}
+	]
+}`
+
+func TestParseImport_Jira(t *testing.T) {
+	plan, err := ParseImport(ImportSourceJira, []byte(jiraExportJSON))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantTasks := []TaskBlueprint{
+		{Title: "ログイン", Description: "v2 の説明", Status: "todo", Priority: "high"},
+		{Title: "検索", Description: "1 行目\n2 行目\n次の段落", Status: "in_progress", Priority: "medium"},
+		{Title: "リリース", Status: "todo"},
+	}
+	if !reflect.DeepEqual(plan.Tasks, wantTasks) {
+		t.Errorf("tasks = %+v, want %+v", plan.Tasks, wantTasks)
+	}
+	wantStatuses := []ImportValueMapping{
+		{From: "Backlog", To: "todo", Count: 1},
+		{From: "In Review", To: "in_progress", Count: 1},
+		{From: "Shipped", To: "todo", Count: 1},
+	}
+	if !reflect.DeepEqual(plan.Statuses, wantStatuses) {
+		t.Errorf("statuses = %+v, want %+v", plan.Statuses, wantStatuses)
+	}
+	wantPriorities := []ImportValueMapping{
+		{From: "Highest", To: "high", Count: 1},
+		{From: "Major", To: "medium", Count: 1},
+		{From: "P1", To: "", Count: 1},
+	}
+	if !reflect.DeepEqual(plan.Priorities, wantPriorities) {
+		t.Errorf("priorities = %+v, want %+v", plan.Priorities, wantPriorities)
+	}
+	if len(plan.Skipped) != 1 || plan.Skipped[0].SourceID != "TF-4" {
+		t.Errorf("expected TF-4 to be skipped, got %+v", plan.Skipped)
+	}
+	if len(plan.Warnings) != 2 {
+		t.Errorf("expected warnings for Shipped and P1, got %v", plan.Warnings)
+	}
+}
+
+func TestParseImport_Invalid(t *testing.T) {
+	var cards []string
+	for i := range MaxImportTasks + 1 {
+		cards = append(cards, fmt.Sprintf(`{"id":"c%d","name":"t","idList":"l1"}`, i))
+	}
+	tooMany := `{"lists":[{"id":"l1","name":"todo"}],"cards":[` + strings.Join(cards, ",") + `]}`
+
+	tests := []struct {
+		name   string
+		source ImportSource
+		data   string
+	}{
+		{name: "unknown source", source: "asana", data: `{}`},
+		{name: "not json", source: ImportSourceTrello, data: `{invalid`},
+		{name: "trello without cards", source: ImportSourceTrello, data: `{"lists":[]}`},
+		{name: "jira without issues", source: ImportSourceJira, data: `{"cards":[]}`},
+		{name: "jira array", source: ImportSourceJira, data: `[]`},
+		{name: "too many tasks", source: ImportSourceTrello, data: tooMany},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseImport(tt.source, []byte(tt.data)); !errors.Is(err, ErrInvalidImport) {
+				t.Errorf("expected ErrInvalidImport, got %v", err)
+			}
+		})
+	}
+}
+
+func TestStatusFromName(t *testing.T) {
+	for name, want := range map[string]string{
+		"Done":        "done",
+		"In Progress": "in_progress",
+		"レビュー待ち":      "in_progress",
+		"Backlog":     "todo",
+		"Someday":     "todo",
+	} {
+		if got, _ := statusFromName(name); got != want {
+			t.Errorf("statusFromName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package project
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultInvitationTTL は招待の有効期間の既定値。
+	DefaultInvitationTTL = 7 * 24 * time.Hour
+	// MaxInvitationTTL は招待の有効期間の上限。
+	MaxInvitationTTL = 30 * 24 * time.Hour
+)
+
+// MaxInvitationTokenLength は受け付ける招待トークンの最大長（バイト）。巨大な入力は base64 デコード前に弾く。
+const MaxInvitationTokenLength = 512
+
+// invitationTokenVersion は招待トークンの payload の仕様バージョン。
+const invitationTokenVersion = 1
+
+// Invitation はプロジェクトへの招待リンクを表す。
+// リンクは有効期限まで何人でも使え、取り消すと使えなくなる。
+// リンクのトークンは保存せず、ID と有効期限を署名したものを発行時に返す。
+type Invitation struct {
+	ID        string
+	ProjectID string
+	Role      MemberRole // 参加したユーザーのロール（admin / member）
+	CreatedBy string     // 発行者。空の場合は不明
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	RevokedAt *time.Time // 取り消し日時。取り消されていない場合は nil
+}
+
+// NewInvitation は新しい招待を生成する。
+// role が空の場合は member、ttl が 0 の場合は DefaultInvitationTTL とする。
+// role が owner、ttl が負または MaxInvitationTTL を超える場合は ErrInvalidInvitation を返す。
+func NewInvitation(id, projectID, role, createdBy string, ttl time.Duration, now time.Time) (*Invitation, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: id must not be empty", ErrInvalidInvitation)
+	}
+
+	r := RoleMember
+	if role != "" {
+		parsed, err := ParseMemberRole(role)
+		if err != nil {
+			return nil, err
+		}
+		r = parsed
+	}
+	if r == RoleOwner {
+		return nil, fmt.Errorf("%w: role must be admin or member", ErrInvalidInvitation)
+	}
+
+	if ttl == 0 {
+		ttl = DefaultInvitationTTL
+	}
+	if ttl < 0 || ttl > MaxInvitationTTL {
+		return nil, fmt.Errorf("%w: expiry must be within %s", ErrInvalidInvitation, MaxInvitationTTL)
+	}
+
+	return &Invitation{
+		ID:        id,
+		ProjectID: projectID,
+		Role:      r,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, nil
+}
+
+// NewInvitationID は招待の ID（128 bit の乱数の 16 進文字列）を生成する。
+func NewInvitationID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:]) // crypto/rand.Read は失敗しない
+	return hex.EncodeToString(b[:])
+}
+
+// Outstanding は now の時点で招待が使えるか（取り消されておらず、期限が切れていない）を返す。
+func (i *Invitation) Outstanding(now time.Time) bool {
+	return i.CheckUsable(now) == nil
+}
+
+// CheckUsable は now の時点で招待が使えるか確認する。
+// 取り消されている場合は ErrInvitationRevoked、期限が切れている場合は ErrInvitationExpired を返す。
+func (i *Invitation) CheckUsable(now time.Time) error {
+	if i.RevokedAt != nil {
+		return ErrInvitationRevoked
+	}
+	if !now.Before(i.ExpiresAt) {
+		return ErrInvitationExpired
+	}
+	return nil
+}
+
+// InvitationTokenPayload は招待トークンの payload を表す。
+type InvitationTokenPayload struct {
+	V            int    `json:"v"`
+	ProjectID    string `json:"pid"`
+	InvitationID string `json:"iid"`
+	ExpiresAt    int64  `json:"exp"` // Unix 秒
+}
+
+// EncodeInvitationToken は招待のトークンを発行する。
+// payload(JSON) に cursor と同じ HMAC-SHA256 の署名を付ける。
+func EncodeInvitationToken(inv *Invitation, secret []byte) (string, error) {
+	payloadJSON, err := json.Marshal(InvitationTokenPayload{
+		V:            invitationTokenVersion,
+		ProjectID:    inv.ProjectID,
+		InvitationID: inv.ID,
+		ExpiresAt:    inv.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	return signToken(payloadJSON, secret), nil
+}
+
+// DecodeInvitationToken はトークンの署名と有効期限を検証し、payload を返す。
+// 形式・署名・バージョンが不正な場合は ErrInvalidInvitationToken、期限が切れている場合は ErrInvitationExpired を返す。
+// 取り消されているかどうかは保存している招待で確認すること。
+func DecodeInvitationToken(token string, secret []byte, now time.Time) (*InvitationTokenPayload, error) {
+	if len(token) > MaxInvitationTokenLength {
+		return nil, fmt.Errorf("%w: token exceeds %d bytes", ErrInvalidInvitationToken, MaxInvitationTokenLength)
+	}
+	payloadJSON, err := verifyToken(token, secret, ErrInvalidInvitationToken, ErrInvalidInvitationToken)
+	if err != nil {
+		return nil, err
+	}
+	if !utf8.Valid(payloadJSON) {
+		return nil, fmt.Errorf("%w: payload is not valid UTF-8", ErrInvalidInvitationToken)
+	}
+
+	var payload InvitationTokenPayload
+	if err := json.Unmarshal(payloadJSON, &payload); err != nil {
+		return nil, fmt.Errorf("%w: json unmarshal: %v", ErrInvalidInvitationToken, err)
+	}
+	if payload.V != invitationTokenVersion || payload.ProjectID == "" || payload.InvitationID == "" {
+		return nil, ErrInvalidInvitationToken
+	}
+	if now.Unix() >= payload.ExpiresAt {
+		return nil, ErrInvitationExpired
+	}
+	return &payload, nil
+}
@@ -0,0 +1,111 @@
+package project
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewInvitation(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	inv, err := NewInvitation("inv-1", "proj-1", "", "user-1", 0, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inv.Role != RoleMember || inv.CreatedBy != "user-1" || !inv.ExpiresAt.Equal(now.Add(DefaultInvitationTTL)) {
+		t.Errorf("unexpected invitation: %+v", inv)
+	}
+
+	inv, err = NewInvitation("inv-2", "proj-1", "Admin", "", time.Hour, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inv.Role != RoleAdmin || !inv.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("unexpected invitation: %+v", inv)
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		role    string
+		ttl     time.Duration
+		wantErr error
+	}{
+		{name: "empty id", role: "member", wantErr: ErrInvalidInvitation},
+		{name: "owner", id: "inv", role: "owner", wantErr: ErrInvalidInvitation},
+		{name: "unknown role", id: "inv", role: "guest", wantErr: ErrInvalidMemberRole},
+		{name: "negative ttl", id: "inv", ttl: -time.Hour, wantErr: ErrInvalidInvitation},
+		{name: "ttl too long", id: "inv", ttl: MaxInvitationTTL + time.Second, wantErr: ErrInvalidInvitation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewInvitation(tt.id, "proj-1", tt.role, "", tt.ttl, now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestInvitation_CheckUsable(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	inv, _ := NewInvitation("inv-1", "proj-1", "member", "", time.Hour, now)
+
+	if err := inv.CheckUsable(now.Add(59 * time.Minute)); err != nil {
+		t.Errorf("expected usable, got %v", err)
+	}
+	if err := inv.CheckUsable(now.Add(time.Hour)); !errors.Is(err, ErrInvitationExpired) {
+		t.Errorf("expected ErrInvitationExpired, got %v", err)
+	}
+
+	revokedAt := now.Add(time.Minute)
+	inv.RevokedAt = &revokedAt
+	if err := inv.CheckUsable(now.Add(2 * time.Minute)); !errors.Is(err, ErrInvitationRevoked) {
+		t.Errorf("expected ErrInvitationRevoked, got %v", err)
+	}
+	if inv.Outstanding(now.Add(2 * time.Minute)) {
+		t.Error("revoked invitation must not be outstanding")
+	}
+}
+
+func TestInvitationToken(t *testing.T) {
+	secret := []byte("test-secret")
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	inv, _ := NewInvitation("inv-1", "proj-1", "member", "", time.Hour, now)
+
+	token, err := EncodeInvitationToken(inv, secret)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	payload, err := DecodeInvitationToken(token, secret, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payload.ProjectID != "proj-1" || payload.InvitationID != "inv-1" {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		secret  []byte
+		now     time.Time
+		wantErr error
+	}{
+		{name: "expired", token: token, secret: secret, now: now.Add(time.Hour), wantErr: ErrInvitationExpired},
+		{name: "wrong secret", token: token, secret: []byte("other"), now: now, wantErr: ErrInvalidInvitationToken},
+		{name: "tampered", token: "x" + token, secret: secret, now: now, wantErr: ErrInvalidInvitationToken},
+		{name: "no signature", token: "abc", secret: secret, now: now, wantErr: ErrInvalidInvitationToken},
+		{name: "too long", token: strings.Repeat("a", MaxInvitationTokenLength+1), secret: secret, now: now, wantErr: ErrInvalidInvitationToken},
+		{name: "cursor is not an invitation", token: signToken([]byte(`{"v":1,"sort":"name"}`), secret), secret: secret, now: now, wantErr: ErrInvalidInvitationToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeInvitationToken(tt.token, tt.secret, tt.now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
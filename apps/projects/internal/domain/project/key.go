@@ -0,0 +1,31 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MinKeyLength / MaxKeyLength はプロジェクトキーの長さの範囲。
+const (
+	MinKeyLength = 2
+	MaxKeyLength = 10
+)
+
+// ParseKey はプロジェクトキー（例: "TFLOW"）を検証し、正規化して返す。
+// 前後の空白は無視し、大文字に揃える。英大文字で始まり、英大文字と数字のみの 2〜10 文字であること。
+// タスクの参照（例: TFLOW-42）に使うため、区切りの "-" は含められない。
+// 不正な場合は ErrInvalidKey を返す。
+func ParseKey(s string) (string, error) {
+	key := strings.ToUpper(strings.TrimSpace(s))
+	if len(key) < MinKeyLength || len(key) > MaxKeyLength {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, s)
+	}
+	for i, c := range key {
+		isLetter := c >= 'A' && c <= 'Z'
+		isDigit := c >= '0' && c <= '9'
+		if !isLetter && !(isDigit && i > 0) {
+			return "", fmt.Errorf("%w: %q", ErrInvalidKey, s)
+		}
+	}
+	return key, nil
+}
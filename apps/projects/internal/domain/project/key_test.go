@@ -0,0 +1,43 @@
+package project
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "TFLOW", want: "TFLOW"},
+		{in: " tflow ", want: "TFLOW"},
+		{in: "Q3", want: "Q3"},
+		{in: "ABCDEFGHIJ", want: "ABCDEFGHIJ"},
+		{in: "A", wantErr: true},
+		{in: "ABCDEFGHIJK", wantErr: true},
+		{in: "3D", wantErr: true},
+		{in: "TF-1", wantErr: true},
+		{in: "TÉAM", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseKey(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidKey) {
+					t.Fatalf("expected ErrInvalidKey, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxLabelNameLength はラベル名の最大文字数。
+const MaxLabelNameLength = 50
+
+// Label はプロジェクトで定義するタスクのラベル（名前と表示色）を表す。
+// タスクは tasks サービス側で labelIds によってラベルを参照し、定義済みのラベルのみ付けられる。
+type Label struct {
+	ID        string // プロジェクト内で一意
+	ProjectID string
+	Name      string // プロジェクト内で一意（大文字・小文字は区別しない）
+	Color     string // #rrggbb（小文字に正規化する）
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewLabel は新しいラベルを生成する。
+// ID・名前が空、名前が長すぎる、色が #RRGGBB 形式でない場合は ErrInvalidLabel を返す。
+func NewLabel(id, projectID, name, color string, now time.Time) (*Label, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, fmt.Errorf("%w: id must not be empty", ErrInvalidLabel)
+	}
+	if strings.Contains(id, "/") {
+		return nil, fmt.Errorf("%w: id must not contain '/'", ErrInvalidLabel)
+	}
+
+	l := &Label{
+		ID:        id,
+		ProjectID: projectID,
+		CreatedAt: now,
+	}
+	if err := l.Update(name, color, now); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Update は名前と色を置き換える。不正な値の場合は ErrInvalidLabel を返し、l は変更しない。
+func (l *Label) Update(name, color string, now time.Time) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("%w: name must not be empty", ErrInvalidLabel)
+	}
+	if utf8.RuneCountInString(name) > MaxLabelNameLength {
+		return fmt.Errorf("%w: name must be at most %d characters", ErrInvalidLabel, MaxLabelNameLength)
+	}
+	c, err := ParseLabelColor(color)
+	if err != nil {
+		return err
+	}
+
+	l.Name = name
+	l.Color = c
+	l.UpdatedAt = now
+	return nil
+}
+
+// ParseLabelColor は色が #RRGGBB 形式か検証し、小文字に正規化して返す。
+func ParseLabelColor(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) != 7 || s[0] != '#' {
+		return "", fmt.Errorf("%w: color must be in #RRGGBB format", ErrInvalidLabel)
+	}
+	for _, c := range s[1:] {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return "", fmt.Errorf("%w: color must be in #RRGGBB format", ErrInvalidLabel)
+		}
+	}
+	return s, nil
+}
+
+// LabelUsage はラベルとそのラベルが付いたタスク数。
+type LabelUsage struct {
+	Label      *Label
+	UsageCount int
+}
+
+// ComputeLabelUsage は labels の並び順のまま、counts（labelID をキーとする）の件数を対応付ける。
+// どのタスクにも付いていないラベルは 0 件とする。
+func ComputeLabelUsage(labels []*Label, counts map[string]int) []LabelUsage {
+	out := make([]LabelUsage, len(labels))
+	for i, l := range labels {
+		out[i] = LabelUsage{Label: l, UsageCount: counts[l.ID]}
+	}
+	return out
+}
@@ -0,0 +1,78 @@
+package project
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewLabel(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	l, err := NewLabel(" bug ", "proj-1", " 不具合 ", " #D73A4A ", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if l.ID != "bug" || l.Name != "不具合" || l.Color != "#d73a4a" {
+		t.Errorf("unexpected label: %+v", l)
+	}
+	if !l.CreatedAt.Equal(now) || !l.UpdatedAt.Equal(now) {
+		t.Errorf("unexpected timestamps: %+v", l)
+	}
+
+	tests := []struct {
+		name  string
+		id    string
+		lname string
+		color string
+	}{
+		{name: "empty id", id: "", lname: "bug", color: "#ffffff"},
+		{name: "id with slash", id: "a/b", lname: "bug", color: "#ffffff"},
+		{name: "empty name", id: "l1", lname: "  ", color: "#ffffff"},
+		{name: "name too long", id: "l1", lname: strings.Repeat("あ", MaxLabelNameLength+1), color: "#ffffff"},
+		{name: "empty color", id: "l1", lname: "bug", color: ""},
+		{name: "short color", id: "l1", lname: "bug", color: "#fff"},
+		{name: "color without hash", id: "l1", lname: "bug", color: "ffffff0"},
+		{name: "non-hex color", id: "l1", lname: "bug", color: "#gggggg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewLabel(tt.id, "proj-1", tt.lname, tt.color, now); !errors.Is(err, ErrInvalidLabel) {
+				t.Errorf("expected ErrInvalidLabel, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLabel_Update(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	l, err := NewLabel("l1", "proj-1", "bug", "#d73a4a", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 不正な値の場合は変更しない
+	if err := l.Update("feature", "red", now.Add(time.Hour)); !errors.Is(err, ErrInvalidLabel) {
+		t.Fatalf("expected ErrInvalidLabel, got %v", err)
+	}
+	if l.Name != "bug" || !l.UpdatedAt.Equal(now) {
+		t.Errorf("label must not change on error: %+v", l)
+	}
+
+	if err := l.Update("feature", "#A2EEEF", now.Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if l.Name != "feature" || l.Color != "#a2eeef" || !l.UpdatedAt.Equal(now.Add(time.Hour)) || !l.CreatedAt.Equal(now) {
+		t.Errorf("unexpected label: %+v", l)
+	}
+}
+
+func TestComputeLabelUsage(t *testing.T) {
+	labels := []*Label{{ID: "l2"}, {ID: "l1"}}
+
+	got := ComputeLabelUsage(labels, map[string]int{"l1": 3, "unknown": 1})
+	if len(got) != 2 || got[0].Label.ID != "l2" || got[0].UsageCount != 0 || got[1].Label.ID != "l1" || got[1].UsageCount != 3 {
+		t.Errorf("unexpected usage: %+v", got)
+	}
+}
@@ -0,0 +1,60 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MemberRole はプロジェクトメンバーの役割を表す。
+type MemberRole string
+
+const (
+	RoleOwner  MemberRole = "owner"
+	RoleAdmin  MemberRole = "admin"
+	RoleMember MemberRole = "member"
+)
+
+// ParseMemberRole は文字列から MemberRole を生成する。
+// 前後の空白と大文字小文字は無視する。未知の値の場合は ErrInvalidMemberRole を返す。
+func ParseMemberRole(s string) (MemberRole, error) {
+	switch r := MemberRole(strings.ToLower(strings.TrimSpace(s))); r {
+	case RoleOwner, RoleAdmin, RoleMember:
+		return r, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidMemberRole, s)
+	}
+}
+
+// Member はプロジェクトに参加しているユーザーを表す。
+type Member struct {
+	ProjectID string
+	UserID    string
+	Role      MemberRole
+	JoinedAt  time.Time
+}
+
+// NewMember は新しいプロジェクトメンバーを生成する。
+// role が空の場合は member とする。userID が空の場合は ErrInvalidUserID を返す。
+func NewMember(projectID, userID, role string, now time.Time) (*Member, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil, ErrInvalidUserID
+	}
+
+	r := RoleMember
+	if role != "" {
+		parsed, err := ParseMemberRole(role)
+		if err != nil {
+			return nil, err
+		}
+		r = parsed
+	}
+
+	return &Member{
+		ProjectID: projectID,
+		UserID:    userID,
+		Role:      r,
+		JoinedAt:  now,
+	}, nil
+}
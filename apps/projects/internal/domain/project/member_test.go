@@ -0,0 +1,58 @@
+package project
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseMemberRole(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    MemberRole
+		wantErr bool
+	}{
+		{in: "owner", want: RoleOwner},
+		{in: " Admin ", want: RoleAdmin},
+		{in: "MEMBER", want: RoleMember},
+		{in: "guest", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseMemberRole(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidMemberRole) {
+					t.Fatalf("expected ErrInvalidMemberRole, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewMember(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	m, err := NewMember("proj-1", " user-1 ", "", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.UserID != "user-1" || m.Role != RoleMember || !m.JoinedAt.Equal(now) {
+		t.Errorf("unexpected member: %+v", m)
+	}
+
+	if _, err := NewMember("proj-1", "  ", "member", now); !errors.Is(err, ErrInvalidUserID) {
+		t.Errorf("expected ErrInvalidUserID, got %v", err)
+	}
+	if _, err := NewMember("proj-1", "user-1", "guest", now); !errors.Is(err, ErrInvalidMemberRole) {
+		t.Errorf("expected ErrInvalidMemberRole, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MilestoneStatus はマイルストーンの状態を表す型。
+type MilestoneStatus string
+
+const (
+	MilestoneOpen   MilestoneStatus = "open"
+	MilestoneClosed MilestoneStatus = "closed"
+)
+
+// ParseMilestoneStatus は正規の MilestoneStatus か検証し、型付きで返す。空の場合は open。
+func ParseMilestoneStatus(s string) (MilestoneStatus, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return MilestoneOpen, nil
+	}
+	switch MilestoneStatus(s) {
+	case MilestoneOpen, MilestoneClosed:
+		return MilestoneStatus(s), nil
+	default:
+		return "", fmt.Errorf("%w: status must be one of open, closed", ErrInvalidMilestone)
+	}
+}
+
+// Milestone はプロジェクトのマイルストーン（リリース等の区切り）を表す。
+// タスクは tasks サービス側で milestoneId によってマイルストーンに属する。
+type Milestone struct {
+	ID        string // プロジェクト内で一意
+	ProjectID string
+	Name      string
+	DueDate   *time.Time // nil は期限なし
+	Status    MilestoneStatus
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewMilestone は新しいマイルストーンを生成する。
+// ID・名前が空、status が不正な場合は ErrInvalidMilestone を返す。
+func NewMilestone(id, projectID, name string, dueDate *time.Time, status string, now time.Time) (*Milestone, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, fmt.Errorf("%w: id must not be empty", ErrInvalidMilestone)
+	}
+	if strings.Contains(id, "/") {
+		return nil, fmt.Errorf("%w: id must not contain '/'", ErrInvalidMilestone)
+	}
+
+	m := &Milestone{
+		ID:        id,
+		ProjectID: projectID,
+		CreatedAt: now,
+	}
+	if err := m.Update(name, dueDate, status, now); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Update は名前・期限・状態を置き換える（PUT）。不正な値の場合は ErrInvalidMilestone を返し、m は変更しない。
+func (m *Milestone) Update(name string, dueDate *time.Time, status string, now time.Time) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("%w: name must not be empty", ErrInvalidMilestone)
+	}
+	s, err := ParseMilestoneStatus(status)
+	if err != nil {
+		return err
+	}
+
+	m.Name = name
+	m.DueDate = dueDate
+	m.Status = s
+	m.UpdatedAt = now
+	return nil
+}
+
+// MilestoneProgress はマイルストーンとそのタスクの集計（未完了・完了の件数）。
+type MilestoneProgress struct {
+	Milestone *Milestone
+	Open      int // 未完了（todo / in_progress）のタスク数
+	Done      int // 完了したタスク数
+}
+
+// MilestoneTaskCounts は tasks サービスから取得したマイルストーンごとのタスクの件数。
+type MilestoneTaskCounts struct {
+	Open int
+	Done int
+}
+
+// ComputeMilestoneProgress は milestones の並び順のまま、counts（milestoneID をキーとする）の件数を対応付ける。
+// タスクの無いマイルストーンは 0 件とする。
+func ComputeMilestoneProgress(milestones []*Milestone, counts map[string]MilestoneTaskCounts) []MilestoneProgress {
+	out := make([]MilestoneProgress, len(milestones))
+	for i, m := range milestones {
+		c := counts[m.ID]
+		out[i] = MilestoneProgress{Milestone: m, Open: c.Open, Done: c.Done}
+	}
+	return out
+}
@@ -0,0 +1,84 @@
+package project
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewMilestone(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	due := now.AddDate(0, 1, 0)
+
+	m, err := NewMilestone(" v1 ", "proj-1", " リリース 1 ", &due, "", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.ID != "v1" || m.Name != "リリース 1" || m.Status != MilestoneOpen || !m.DueDate.Equal(due) {
+		t.Errorf("unexpected milestone: %+v", m)
+	}
+	if !m.CreatedAt.Equal(now) || !m.UpdatedAt.Equal(now) {
+		t.Errorf("unexpected timestamps: %+v", m)
+	}
+
+	tests := []struct {
+		name   string
+		id     string
+		mname  string
+		status string
+	}{
+		{name: "empty id", id: "", mname: "v1"},
+		{name: "id with slash", id: "a/b", mname: "v1"},
+		{name: "empty name", id: "v1", mname: "  "},
+		{name: "invalid status", id: "v1", mname: "v1", status: "done"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMilestone(tt.id, "proj-1", tt.mname, nil, tt.status, now); !errors.Is(err, ErrInvalidMilestone) {
+				t.Errorf("expected ErrInvalidMilestone, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMilestone_Update(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	m, err := NewMilestone("v1", "proj-1", "v1", nil, "", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 不正な値の場合は変更しない
+	if err := m.Update("v1.0", nil, "unknown", now.Add(time.Hour)); !errors.Is(err, ErrInvalidMilestone) {
+		t.Fatalf("expected ErrInvalidMilestone, got %v", err)
+	}
+	if m.Name != "v1" || !m.UpdatedAt.Equal(now) {
+		t.Errorf("milestone must not change on error: %+v", m)
+	}
+
+	if err := m.Update("v1.0", nil, "Closed", now.Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Name != "v1.0" || m.Status != MilestoneClosed || !m.UpdatedAt.Equal(now.Add(time.Hour)) || !m.CreatedAt.Equal(now) {
+		t.Errorf("unexpected milestone: %+v", m)
+	}
+}
+
+func TestComputeMilestoneProgress(t *testing.T) {
+	milestones := []*Milestone{{ID: "v2"}, {ID: "v1"}}
+	counts := map[string]MilestoneTaskCounts{
+		"v1":      {Open: 2, Done: 3},
+		"deleted": {Open: 5},
+	}
+
+	got := ComputeMilestoneProgress(milestones, counts)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(got))
+	}
+	if got[0].Milestone.ID != "v2" || got[0].Open != 0 || got[0].Done != 0 {
+		t.Errorf("unexpected first entry: %+v", got[0])
+	}
+	if got[1].Milestone.ID != "v1" || got[1].Open != 2 || got[1].Done != 3 {
+		t.Errorf("unexpected second entry: %+v", got[1])
+	}
+}
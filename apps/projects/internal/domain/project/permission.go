@@ -0,0 +1,77 @@
+package project
+
+import (
+	"fmt"
+
+	"teamflow-shared/authz"
+)
+
+// Action はプロジェクトに対する操作の種類（権限チェックの単位）。
+type Action string
+
+const (
+	ActionEdit           Action = "edit"            // 名前・説明・ステータスの変更
+	ActionManageMembers  Action = "manage_members"  // メンバーの追加・削除
+	ActionManageOwners   Action = "manage_owners"   // owner の追加・削除
+	ActionManageSettings Action = "manage_settings" // プロジェクト設定の変更
+	ActionArchive        Action = "archive"         // アーカイブ・アーカイブ解除
+	ActionDelete         Action = "delete"          // 削除
+	ActionClone          Action = "clone"           // 複製（複製元プロジェクトに対する権限）
+	ActionImport         Action = "import"          // 他のサービス（Trello / Jira）からのタスクのインポート
+)
+
+// ErrForbidden は権限が不足している場合のエラー。errors.Is で判定し、HTTP 層で 403 に変換する。
+// tasks サービスと同じ値（teamflow-shared/authz）を使う。
+var ErrForbidden = authz.ErrForbidden
+
+// ErrActorRequired はロールの確認が必要な操作で、操作者が特定できない場合のエラー。HTTP 層で 401 に変換する。
+var ErrActorRequired = authz.ErrActorRequired
+
+// InsufficientRoleError は操作者のロールでは action が許可されていない場合のエラー。
+// errors.Is(err, ErrForbidden) が true になる。
+type InsufficientRoleError struct {
+	Action Action
+	Role   MemberRole // 空の場合はメンバーではない
+}
+
+func (e *InsufficientRoleError) Error() string {
+	if e.Role == "" {
+		return fmt.Sprintf("forbidden: %s requires project membership", e.Action)
+	}
+	return fmt.Sprintf("forbidden: role %s cannot %s", e.Role, e.Action)
+}
+
+// Unwrap は ErrForbidden を返す。
+func (e *InsufficientRoleError) Unwrap() error {
+	return ErrForbidden
+}
+
+// rolePermissions はロールごとに許可された操作。
+// owner はすべて、admin は編集・設定変更・複製・インポートと owner 以外のメンバー管理、member は編集・複製のみ。
+var rolePermissions = map[MemberRole][]Action{
+	RoleOwner:  {ActionEdit, ActionManageMembers, ActionManageOwners, ActionManageSettings, ActionArchive, ActionDelete, ActionClone, ActionImport},
+	RoleAdmin:  {ActionEdit, ActionManageMembers, ActionManageSettings, ActionClone, ActionImport},
+	RoleMember: {ActionEdit, ActionClone},
+}
+
+// Can はロール r が action を許可されているかどうかを返す。
+func (r MemberRole) Can(action Action) bool {
+	for _, a := range rolePermissions[r] {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize はメンバー m が action を実行できるか確認する。
+// m が nil（メンバーではない）か、ロールが不足している場合は *InsufficientRoleError を返す。
+func Authorize(m *Member, action Action) error {
+	if m == nil {
+		return &InsufficientRoleError{Action: action}
+	}
+	if !m.Role.Can(action) {
+		return &InsufficientRoleError{Action: action, Role: m.Role}
+	}
+	return nil
+}
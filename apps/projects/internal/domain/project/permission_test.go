@@ -0,0 +1,64 @@
+package project
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		role    MemberRole // 空はメンバーではない
+		action  Action
+		allowed bool
+	}{
+		{RoleOwner, ActionEdit, true},
+		{RoleOwner, ActionManageMembers, true},
+		{RoleOwner, ActionManageOwners, true},
+		{RoleOwner, ActionArchive, true},
+		{RoleOwner, ActionDelete, true},
+		{RoleAdmin, ActionEdit, true},
+		{RoleAdmin, ActionManageMembers, true},
+		{RoleAdmin, ActionManageOwners, false},
+		{RoleAdmin, ActionManageSettings, true},
+		{RoleAdmin, ActionArchive, false},
+		{RoleAdmin, ActionDelete, false},
+		{RoleMember, ActionEdit, true},
+		{RoleMember, ActionManageMembers, false},
+		{RoleMember, ActionManageSettings, false},
+		{RoleMember, ActionArchive, false},
+		{RoleMember, ActionClone, true},
+		{RoleOwner, ActionImport, true},
+		{RoleAdmin, ActionImport, true},
+		{RoleMember, ActionImport, false},
+		{"", ActionEdit, false},
+		{"", ActionClone, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+"/"+string(tt.action), func(t *testing.T) {
+			var m *Member
+			if tt.role != "" {
+				m = &Member{ProjectID: "proj-1", UserID: "user-1", Role: tt.role}
+			}
+
+			err := Authorize(m, tt.action)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("expected allowed, got %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrForbidden) {
+				t.Fatalf("expected ErrForbidden, got %v", err)
+			}
+			var roleErr *InsufficientRoleError
+			if !errors.As(err, &roleErr) {
+				t.Fatalf("expected *InsufficientRoleError, got %T", err)
+			}
+			if roleErr.Action != tt.action || roleErr.Role != tt.role {
+				t.Errorf("unexpected error fields: %+v", roleErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package project
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MaxOrderedProjects は並び順に指定できるプロジェクトの最大数（一覧の最大件数と揃える）。
+const MaxOrderedProjects = MaxListLimit
+
+// Preference はユーザーごとのプロジェクトの表示設定（サイドバーのお気に入りと並び順）。
+type Preference struct {
+	UserID    string
+	ProjectID string
+	Favorite  bool
+	Position  *int // 手動の並び順（0 始まり）。nil の場合は並び順を指定していない
+	UpdatedAt time.Time
+}
+
+// IsEmpty はお気に入りでも並び順の指定も無い（表示に影響しない）かどうかを返す。
+func (p *Preference) IsEmpty() bool {
+	return !p.Favorite && p.Position == nil
+}
+
+// SortPreferences は prefs をサイドバーの表示順に並べ替える。
+// お気に入りを先頭に、それぞれ Position の昇順（未指定は後ろ）、同じ場合は ProjectID の順。
+func SortPreferences(prefs []*Preference) {
+	sort.SliceStable(prefs, func(i, j int) bool {
+		a, b := prefs[i], prefs[j]
+		if a.Favorite != b.Favorite {
+			return a.Favorite
+		}
+		switch {
+		case a.Position != nil && b.Position != nil && *a.Position != *b.Position:
+			return *a.Position < *b.Position
+		case (a.Position == nil) != (b.Position == nil):
+			return a.Position != nil
+		}
+		return a.ProjectID < b.ProjectID
+	})
+}
+
+// ValidateProjectOrder は並び順に指定するプロジェクト ID を検証する。
+// 空の ID・重複・MaxOrderedProjects を超える指定は ErrInvalidProjectOrder を返す。
+func ValidateProjectOrder(projectIDs []string) error {
+	if len(projectIDs) > MaxOrderedProjects {
+		return fmt.Errorf("%w: must not exceed %d projects", ErrInvalidProjectOrder, MaxOrderedProjects)
+	}
+	seen := make(map[string]bool, len(projectIDs))
+	for _, id := range projectIDs {
+		if id == "" {
+			return fmt.Errorf("%w: empty project ID", ErrInvalidProjectOrder)
+		}
+		if seen[id] {
+			return fmt.Errorf("%w: duplicate project ID %q", ErrInvalidProjectOrder, id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
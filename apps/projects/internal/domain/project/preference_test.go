@@ -0,0 +1,56 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSortPreferences(t *testing.T) {
+	pos := func(n int) *int { return &n }
+	prefs := []*Preference{
+		{ProjectID: "c"},
+		{ProjectID: "b", Position: pos(1)},
+		{ProjectID: "f", Favorite: true},
+		{ProjectID: "a", Position: pos(0)},
+		{ProjectID: "e", Favorite: true, Position: pos(2)},
+		{ProjectID: "d", Favorite: true, Position: pos(5)},
+	}
+
+	SortPreferences(prefs)
+
+	want := []string{"e", "d", "f", "a", "b", "c"}
+	for i, p := range prefs {
+		if p.ProjectID != want[i] {
+			t.Fatalf("position %d: expected %s, got %s (want order %v)", i, want[i], p.ProjectID, want)
+		}
+	}
+}
+
+func TestValidateProjectOrder(t *testing.T) {
+	tooMany := make([]string, MaxOrderedProjects+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("proj-%d", i)
+	}
+
+	tests := []struct {
+		name    string
+		ids     []string
+		wantErr bool
+	}{
+		{name: "valid", ids: []string{"proj-1", "proj-2"}},
+		{name: "empty list clears order", ids: nil},
+		{name: "empty id", ids: []string{"proj-1", ""}, wantErr: true},
+		{name: "duplicate", ids: []string{"proj-1", "proj-2", "proj-1"}, wantErr: true},
+		{name: "too many", ids: tooMany, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateProjectOrder(tt.ids)
+			if tt.wantErr != errors.Is(err, ErrInvalidProjectOrder) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -1,31 +1,70 @@
 package project
 
 import (
-	"errors"
 	"time"
+
+	"teamflow-shared/authz"
+)
+
+// Visibility はプロジェクトの公開範囲（private / public）。判定は tasks サービスと共通の authz で行う。
+type Visibility = authz.Visibility
+
+const (
+	// VisibilityPrivate はメンバーだけが閲覧できる（既定）。
+	VisibilityPrivate = authz.VisibilityPrivate
+	// VisibilityPublic はメンバーでなくても閲覧できる。
+	VisibilityPublic = authz.VisibilityPublic
 )
 
 // Project は TeamFlow におけるプロジェクトのドメインモデル。
 type Project struct {
 	ID          string
+	WorkspaceID string // 所属するワークスペース（テナント）。保存時にリポジトリが context のワークスペースを設定する
+	Key         string // 人が読むためのプロジェクトキー（例: TFLOW）。空の場合は未設定。設定されている場合はワークスペース内で一意
 	Name        string
 	Description string
+	Status      ProjectStatus
+	Visibility  Visibility // 公開範囲。NewProject では private
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	CreatedBy   string     // 作成者（X-User-ID）。空は不明
+	UpdatedBy   string     // 最終更新者（X-User-ID）。空は不明
+	ArchivedAt  *time.Time // アーカイブ日時（nil はアーカイブされていない）
+	// DeletedAt は削除（論理削除）日時。nil は削除されていない。削除されたプロジェクトは取得・一覧の対象外
+	DeletedAt *time.Time
+	// DeletePolicy は削除したときのタスクの扱い。削除されていない場合は空
+	DeletePolicy DeletePolicy
+	// OpenTasks / DoneTasks は tasks サービスのタスクのイベントで更新する未完了・完了のタスク数。
+	// イベントを購読していない場合は更新しない。イベントの届く順序によっては一時的に負になることがある
+	OpenTasks int
+	DoneTasks int
 }
 
 // NewProject は新しいプロジェクトを生成する。
-// Name が空の場合はエラーを返す。
+// Name が空の場合は ErrNameRequired を返す。
 func NewProject(id, name, description string, now time.Time) (*Project, error) {
 	if name == "" {
-		return nil, errors.New("project name must not be empty")
+		return nil, ErrNameRequired
 	}
 
 	return &Project{
 		ID:          id,
 		Name:        name,
 		Description: description,
+		Status:      StatusActive,
+		Visibility:  VisibilityPrivate,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}, nil
 }
+
+// IsArchived はプロジェクトがアーカイブされているかどうかを返す。
+func (p *Project) IsArchived() bool {
+	return p.ArchivedAt != nil
+}
+
+// ParseVisibility は文字列から Visibility を生成する。前後の空白と大文字小文字は無視する。
+// 未知の値の場合は ErrInvalidVisibility を返す。
+func ParseVisibility(s string) (Visibility, error) {
+	return authz.ParseVisibility(s)
+}
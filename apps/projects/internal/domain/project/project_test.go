@@ -32,6 +32,10 @@ func TestNewProject_Success(t *testing.T) {
 	if !p.UpdatedAt.Equal(now) {
 		t.Errorf("expected UpdatedAt to equal now, got=%v", p.UpdatedAt)
 	}
+
+	if p.Visibility != VisibilityPrivate {
+		t.Errorf("expected Visibility=private, got=%s", p.Visibility)
+	}
 }
 
 func TestNewProject_InvalidName(t *testing.T) {
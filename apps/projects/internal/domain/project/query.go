@@ -0,0 +1,360 @@
+package project
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultListLimit はプロジェクト一覧の limit の既定値。
+	DefaultListLimit = 200
+	// MaxListLimit はプロジェクト一覧の limit の上限。
+	MaxListLimit = 200
+)
+
+// ソートキー。
+const (
+	SortKeyName      = "name"
+	SortKeyCreatedAt = "createdAt"
+)
+
+const (
+	SortDirectionASC  = "ASC"
+	SortDirectionDESC = "DESC"
+)
+
+// ProjectSort はプロジェクト一覧のソート順を表す。同じキーの値は ID で同じ向きに並べる。
+type ProjectSort struct {
+	Key       string // name, createdAt
+	Direction string // "ASC" or "DESC"
+}
+
+// DefaultSort は sort 未指定時のソート順（作成日時の昇順）。
+var DefaultSort = ProjectSort{Key: SortKeyCreatedAt, Direction: SortDirectionASC}
+
+// String は sort パラメータ形式（"-name" など）を返す。
+func (s ProjectSort) String() string {
+	if s.Direction == SortDirectionDESC {
+		return "-" + s.Key
+	}
+	return s.Key
+}
+
+// ParseSort は sort パラメータ（"name", "-createdAt" など）をパースする。
+func ParseSort(s string) (ProjectSort, error) {
+	key := strings.TrimSpace(s)
+	direction := SortDirectionASC
+	if strings.HasPrefix(key, "-") {
+		key = strings.TrimPrefix(key, "-")
+		direction = SortDirectionDESC
+	}
+	switch key {
+	case SortKeyName, SortKeyCreatedAt:
+		return ProjectSort{Key: key, Direction: direction}, nil
+	default:
+		return ProjectSort{}, ErrInvalidSort
+	}
+}
+
+// ProjectQuery はプロジェクト一覧の検索条件を表すQuery Object。
+// 条件定義のみを担当し、実装詳細（フィルタリング・ソート・リミット処理）はリポジトリ層に委譲する。
+type ProjectQuery struct {
+	// WorkspaceID は検索するワークスペース。絞り込みはリポジトリが context で行い、ここでは qhash に含めるためだけに使う
+	WorkspaceID string
+
+	// Filters
+	Query    *string         // q (name の部分一致、大文字小文字を区別しない)
+	Archived *bool           // archived フィルタ（nil は絞り込まない）
+	Statuses []ProjectStatus // status フィルタ（正規化・重複排除済み）
+
+	// 閲覧権限による絞り込み（ロールの確認が有効な場合にユースケースが設定する。qhash には含めない）
+	ReadableOnly     bool     // true の場合は公開プロジェクトと MemberProjectIDs のプロジェクトだけを返す
+	MemberProjectIDs []string // 閲覧者がメンバーのプロジェクト ID
+
+	// Sorting（nil は DefaultSort。cursor がある場合は cursor のソート順を使う）
+	Sort *ProjectSort
+
+	// Limit
+	Limit int // limit (default 200, max 200, min 1)
+
+	// Cursor
+	Cursor *ProjectCursor // cursor デコード結果
+}
+
+// ProjectCursor は cursor のデコード結果（前ページ末尾のプロジェクトの位置）を保持する。
+// Sort.Key に応じて Name / CreatedAt のどちらかが使われる。
+type ProjectCursor struct {
+	Sort      ProjectSort
+	Name      string
+	CreatedAt time.Time
+	ID        string
+	IssuedAt  int64
+}
+
+// NewProjectQuery はQuery Objectを構築し、正規化を行う。
+// エラーはバリデーションエラーの場合のみ返す。
+func NewProjectQuery(opts ...ProjectQueryOption) (*ProjectQuery, error) {
+	q := &ProjectQuery{
+		Limit: DefaultListLimit,
+	}
+
+	for _, opt := range opts {
+		if err := opt(q); err != nil {
+			return nil, err
+		}
+	}
+
+	// Limit の正規化（1-200にクランプ）
+	if q.Limit < 1 || q.Limit > MaxListLimit {
+		q.Limit = DefaultListLimit
+	}
+
+	return q, nil
+}
+
+// ProjectQueryOption はQuery Objectの構築オプション。
+type ProjectQueryOption func(*ProjectQuery) error
+
+// WithQueryFilter はq（名前検索）フィルタを設定する。
+func WithQueryFilter(queryStr string) ProjectQueryOption {
+	return func(q *ProjectQuery) error {
+		trimmed := strings.TrimSpace(queryStr)
+		if trimmed == "" {
+			return nil
+		}
+		q.Query = &trimmed
+		return nil
+	}
+}
+
+// WithArchivedFilter はarchivedフィルタを設定する（"true" / "false"）。
+func WithArchivedFilter(archivedStr string) ProjectQueryOption {
+	return func(q *ProjectQuery) error {
+		if archivedStr == "" {
+			return nil
+		}
+		archived, err := strconv.ParseBool(archivedStr)
+		if err != nil {
+			return ErrInvalidArchived
+		}
+		q.Archived = &archived
+		return nil
+	}
+}
+
+// WithStatusFilter はstatusフィルタを設定する（カンマ区切り文字列を受け取り、on-hold -> on_hold などを正規化）。
+func WithStatusFilter(statusStr string) ProjectQueryOption {
+	return func(q *ProjectQuery) error {
+		if statusStr == "" {
+			return nil
+		}
+
+		parts := strings.Split(statusStr, ",")
+		statuses := make([]ProjectStatus, 0, len(parts))
+		seen := make(map[ProjectStatus]bool)
+
+		for _, part := range parts {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+
+			status, err := ParseStatus(part)
+			if err != nil {
+				return err
+			}
+
+			// 重複排除
+			if !seen[status] {
+				statuses = append(statuses, status)
+				seen[status] = true
+			}
+		}
+
+		q.Statuses = statuses
+		return nil
+	}
+}
+
+// WithSort はsortパラメータをパースして設定する。
+func WithSort(sortStr string) ProjectQueryOption {
+	return func(q *ProjectQuery) error {
+		if sortStr == "" {
+			return nil
+		}
+		s, err := ParseSort(sortStr)
+		if err != nil {
+			return err
+		}
+		q.Sort = &s
+		return nil
+	}
+}
+
+// WithLimit はlimitを設定する（正規化はNewProjectQuery内で行われる）。
+func WithLimit(limit int) ProjectQueryOption {
+	return func(q *ProjectQuery) error {
+		q.Limit = limit
+		return nil
+	}
+}
+
+// WithWorkspace は検索するワークスペースを設定する。
+// 別のワークスペースで発行された cursor を QUERY_MISMATCH にするため、WithCursor より前に渡すこと。
+func WithWorkspace(workspaceID string) ProjectQueryOption {
+	return func(q *ProjectQuery) error {
+		q.WorkspaceID = workspaceID
+		return nil
+	}
+}
+
+// WithCursor は cursor をデコードし、検証して設定する。
+// qhash をフィルタ条件から計算するため、フィルタのオプションより後に渡すこと。
+func WithCursor(cursorStr string, secret []byte, now time.Time) ProjectQueryOption {
+	return func(q *ProjectQuery) error {
+		if cursorStr == "" {
+			return nil
+		}
+
+		payload, err := DecodeCursor(cursorStr, secret)
+		if err != nil {
+			return err
+		}
+
+		// 有効期限チェック
+		if err := ValidateCursorExpiry(payload, now); err != nil {
+			return err
+		}
+
+		// qhash バージョン・qhash の一致確認（条件が変わった cursor は再取得させる）
+		if payload.QV != QHashVersion || payload.QHash != q.ComputeQHash() {
+			return ErrCursorQueryMismatch
+		}
+
+		s, err := ParseSort(payload.Sort)
+		if err != nil {
+			return ErrCursorInvalidFormat
+		}
+		cursor := &ProjectCursor{
+			Sort:     s,
+			ID:       payload.ID,
+			IssuedAt: payload.IssuedAt,
+		}
+		switch s.Key {
+		case SortKeyName:
+			cursor.Name = payload.Key
+		case SortKeyCreatedAt:
+			createdAt, err := ParseCursorCreatedAt(payload.Key)
+			if err != nil {
+				return ErrCursorInvalidFormat
+			}
+			cursor.CreatedAt = createdAt
+		}
+
+		q.Cursor = cursor
+		return nil
+	}
+}
+
+// Validate はQuery Objectの整合性をチェックする。
+func (q *ProjectQuery) Validate() error {
+	if q.Limit < 1 || q.Limit > MaxListLimit {
+		return ErrLimitOutOfRange
+	}
+
+	// cursor + sort 併用禁止（ソート順は cursor に含まれる）
+	if q.Cursor != nil && q.Sort != nil {
+		return ErrSortIncompatibleWithCursor
+	}
+
+	return nil
+}
+
+// EffectiveSort は実際に適用するソート順を返す（cursor > sort > DefaultSort の優先順）。
+func (q *ProjectQuery) EffectiveSort() ProjectSort {
+	if q.Cursor != nil {
+		return q.Cursor.Sort
+	}
+	if q.Sort != nil {
+		return *q.Sort
+	}
+	return DefaultSort
+}
+
+// NewCursorPayload は p をページ末尾として次ページの cursor payload を作る。
+func (q *ProjectQuery) NewCursorPayload(p *Project, now time.Time) CursorPayload {
+	s := q.EffectiveSort()
+	key := p.Name
+	if s.Key == SortKeyCreatedAt {
+		key = FormatCursorCreatedAt(p.CreatedAt)
+	}
+	return CursorPayload{
+		V:        1,
+		Sort:     s.String(),
+		Key:      key,
+		ID:       p.ID,
+		QHash:    q.ComputeQHash(),
+		QV:       QHashVersion,
+		IssuedAt: now.Unix(),
+	}
+}
+
+// QHashVersion は qhash の正規化仕様のバージョン。
+// CanonicalQuery の出力が変わる変更を入れる場合は必ず上げる。
+//
+// 履歴:
+//   - 1: q / archived
+//   - 2: status を追加
+//   - 3: workspaceId を追加
+const QHashVersion = 3
+
+// CanonicalQuery はフィルタ条件を qhash 用の正規化文字列に変換する。
+// key=value の組をキー名でソートし、値を URL エスケープして "&" で連結する（tasks の v2 と同じ規則）。
+// sort / limit / cursor は「同一クエリの続き」の判定に含めない。
+func (q *ProjectQuery) CanonicalQuery() string {
+	fields := map[string]string{
+		"qv": strconv.Itoa(QHashVersion),
+	}
+	if q.Query != nil {
+		fields["q"] = *q.Query
+	}
+	if q.Archived != nil {
+		fields["archived"] = strconv.FormatBool(*q.Archived)
+	}
+	if len(q.Statuses) > 0 {
+		// 指定順に依存しないようソートして連結する
+		statuses := make([]string, len(q.Statuses))
+		for i, s := range q.Statuses {
+			statuses[i] = string(s)
+		}
+		sort.Strings(statuses)
+		fields["status"] = strings.Join(statuses, ",")
+	}
+	if q.WorkspaceID != "" {
+		fields["workspaceId"] = q.WorkspaceID
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+url.QueryEscape(fields[k]))
+	}
+	return strings.Join(pairs, "&")
+}
+
+// ComputeQHash はフィルタ条件から qhash を計算する。
+// CanonicalQuery の sha256 の先頭 8byte を Base64URL でエンコードした短い文字列を返す。
+func (q *ProjectQuery) ComputeQHash() string {
+	hash := sha256.Sum256([]byte(q.CanonicalQuery()))
+	return base64.RawURLEncoding.EncodeToString(hash[:8])
+}
@@ -0,0 +1,269 @@
+package project
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewProjectQuery_Default(t *testing.T) {
+	q, err := NewProjectQuery()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if q.Limit != DefaultListLimit {
+		t.Errorf("expected default limit=%d, got=%d", DefaultListLimit, q.Limit)
+	}
+	if q.Query != nil || q.Archived != nil || q.Statuses != nil || q.Sort != nil || q.Cursor != nil {
+		t.Errorf("expected no filters, got %+v", q)
+	}
+	if got := q.EffectiveSort(); got != DefaultSort {
+		t.Errorf("expected default sort %+v, got %+v", DefaultSort, got)
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ProjectSort
+		wantErr bool
+	}{
+		{in: "name", want: ProjectSort{Key: SortKeyName, Direction: SortDirectionASC}},
+		{in: "-name", want: ProjectSort{Key: SortKeyName, Direction: SortDirectionDESC}},
+		{in: "createdAt", want: ProjectSort{Key: SortKeyCreatedAt, Direction: SortDirectionASC}},
+		{in: "-createdAt", want: ProjectSort{Key: SortKeyCreatedAt, Direction: SortDirectionDESC}},
+		{in: "updatedAt", wantErr: true},
+		{in: "name,createdAt", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseSort(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSort) {
+					t.Fatalf("expected ErrInvalidSort, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+			if got.String() != tt.in {
+				t.Errorf("expected String()=%q, got %q", tt.in, got.String())
+			}
+		})
+	}
+}
+
+func TestWithArchivedFilter(t *testing.T) {
+	q, err := NewProjectQuery(WithArchivedFilter("false"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.Archived == nil || *q.Archived {
+		t.Errorf("expected archived=false, got %v", q.Archived)
+	}
+
+	if _, err := NewProjectQuery(WithArchivedFilter("yes")); !errors.Is(err, ErrInvalidArchived) {
+		t.Errorf("expected ErrInvalidArchived, got %v", err)
+	}
+}
+
+func TestWithStatusFilter(t *testing.T) {
+	q, err := NewProjectQuery(WithStatusFilter("on-hold, active,on_hold"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []ProjectStatus{StatusOnHold, StatusActive}
+	if len(q.Statuses) != len(want) {
+		t.Fatalf("expected %v, got %v", want, q.Statuses)
+	}
+	for i := range want {
+		if q.Statuses[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, q.Statuses)
+		}
+	}
+
+	if _, err := NewProjectQuery(WithStatusFilter("active,archived")); !errors.Is(err, ErrInvalidStatus) {
+		t.Errorf("expected ErrInvalidStatus, got %v", err)
+	}
+}
+
+func TestWithQueryFilter_TrimsSpaces(t *testing.T) {
+	q, err := NewProjectQuery(WithQueryFilter("  team  "))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.Query == nil || *q.Query != "team" {
+		t.Errorf("expected q=team, got %v", q.Query)
+	}
+
+	q, err = NewProjectQuery(WithQueryFilter("   "))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.Query != nil {
+		t.Errorf("expected blank q to be ignored, got %q", *q.Query)
+	}
+}
+
+func TestCanonicalQuery_IgnoresSortAndLimit(t *testing.T) {
+	a, _ := NewProjectQuery(WithQueryFilter("team"), WithArchivedFilter("true"), WithSort("name"), WithLimit(10))
+	b, _ := NewProjectQuery(WithArchivedFilter("true"), WithQueryFilter("team"))
+
+	if a.ComputeQHash() != b.ComputeQHash() {
+		t.Errorf("expected same qhash, got %q and %q (%q / %q)", a.ComputeQHash(), b.ComputeQHash(), a.CanonicalQuery(), b.CanonicalQuery())
+	}
+
+	c, _ := NewProjectQuery(WithQueryFilter("team"))
+	if a.ComputeQHash() == c.ComputeQHash() {
+		t.Error("expected different qhash when archived filter differs")
+	}
+}
+
+func TestCanonicalQuery_Workspace(t *testing.T) {
+	a, _ := NewProjectQuery(WithWorkspace("ws-1"), WithQueryFilter("team"))
+	b, _ := NewProjectQuery(WithWorkspace("ws-2"), WithQueryFilter("team"))
+	if a.ComputeQHash() == b.ComputeQHash() {
+		t.Errorf("expected different qhash when workspace differs, got %q / %q", a.CanonicalQuery(), b.CanonicalQuery())
+	}
+
+	// 別のワークスペースで発行された cursor は使えない
+	secret := []byte("secret")
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cursor, err := EncodeCursor(a.NewCursorPayload(&Project{ID: "p1", CreatedAt: now}, now), secret)
+	if err != nil {
+		t.Fatalf("failed to encode cursor: %v", err)
+	}
+	if _, err := NewProjectQuery(WithWorkspace("ws-1"), WithQueryFilter("team"), WithCursor(cursor, secret, now)); err != nil {
+		t.Errorf("expected cursor to be accepted in the same workspace, got %v", err)
+	}
+	if _, err := NewProjectQuery(WithWorkspace("ws-2"), WithQueryFilter("team"), WithCursor(cursor, secret, now)); !errors.Is(err, ErrCursorQueryMismatch) {
+		t.Errorf("expected ErrCursorQueryMismatch, got %v", err)
+	}
+}
+
+func TestCanonicalQuery_StatusOrderIndependent(t *testing.T) {
+	a, _ := NewProjectQuery(WithStatusFilter("completed,active"))
+	b, _ := NewProjectQuery(WithStatusFilter("active,completed"))
+	if a.ComputeQHash() != b.ComputeQHash() {
+		t.Errorf("expected same qhash, got %q / %q", a.CanonicalQuery(), b.CanonicalQuery())
+	}
+
+	c, _ := NewProjectQuery(WithStatusFilter("active"))
+	if a.ComputeQHash() == c.ComputeQHash() {
+		t.Error("expected different qhash when status filter differs")
+	}
+}
+
+func TestWithCursor_RoundTrip(t *testing.T) {
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	last := &Project{ID: "proj-2", Name: "Beta", CreatedAt: time.Date(2025, 1, 1, 9, 0, 0, 123456789, time.UTC)}
+
+	for _, sortStr := range []string{"name", "-name", "createdAt", "-createdAt"} {
+		t.Run(sortStr, func(t *testing.T) {
+			first, err := NewProjectQuery(WithQueryFilter("a"), WithSort(sortStr), WithLimit(1))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			cursor, err := EncodeCursor(first.NewCursorPayload(last, now), testCursorSecret)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			next, err := NewProjectQuery(WithQueryFilter("a"), WithCursor(cursor, testCursorSecret, now))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := next.Validate(); err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+
+			if got := next.EffectiveSort(); got != *first.Sort {
+				t.Errorf("expected sort %+v from cursor, got %+v", *first.Sort, got)
+			}
+			if next.Cursor.ID != last.ID {
+				t.Errorf("expected cursor id=%s, got=%s", last.ID, next.Cursor.ID)
+			}
+			switch first.Sort.Key {
+			case SortKeyName:
+				if next.Cursor.Name != last.Name {
+					t.Errorf("expected cursor name=%s, got=%s", last.Name, next.Cursor.Name)
+				}
+			case SortKeyCreatedAt:
+				if want := last.CreatedAt.Truncate(time.Microsecond); !next.Cursor.CreatedAt.Equal(want) {
+					t.Errorf("expected cursor createdAt=%v, got=%v", want, next.Cursor.CreatedAt)
+				}
+			}
+		})
+	}
+}
+
+func TestWithCursor_Errors(t *testing.T) {
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	last := &Project{ID: "proj-1", Name: "Alpha", CreatedAt: now.Add(-time.Hour)}
+
+	q, _ := NewProjectQuery(WithQueryFilter("a"))
+	valid, err := EncodeCursor(q.NewCursorPayload(last, now), testCursorSecret)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		opts    []ProjectQueryOption
+		wantErr error
+	}{
+		{
+			name:    "filter changed",
+			opts:    []ProjectQueryOption{WithQueryFilter("b"), WithCursor(valid, testCursorSecret, now)},
+			wantErr: ErrCursorQueryMismatch,
+		},
+		{
+			name:    "expired",
+			opts:    []ProjectQueryOption{WithQueryFilter("a"), WithCursor(valid, testCursorSecret, now.Add(25*time.Hour))},
+			wantErr: ErrCursorExpired,
+		},
+		{
+			name:    "wrong secret",
+			opts:    []ProjectQueryOption{WithQueryFilter("a"), WithCursor(valid, []byte("other"), now)},
+			wantErr: ErrCursorInvalidSignature,
+		},
+		{
+			name:    "garbage",
+			opts:    []ProjectQueryOption{WithCursor("not-a-valid-cursor", testCursorSecret, now)},
+			wantErr: ErrCursorInvalidFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewProjectQuery(tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidate_SortIncompatibleWithCursor(t *testing.T) {
+	now := time.Now()
+	q, _ := NewProjectQuery()
+	cursor, err := EncodeCursor(q.NewCursorPayload(&Project{ID: "proj-1", CreatedAt: now}, now), testCursorSecret)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	q, err = NewProjectQuery(WithSort("name"), WithCursor(cursor, testCursorSecret, now))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := q.Validate(); !errors.Is(err, ErrSortIncompatibleWithCursor) {
+		t.Errorf("expected ErrSortIncompatibleWithCursor, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package project
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxWIPLimit は WIP 上限に設定できる最大値。
+const MaxWIPLimit = 1000
+
+// Settings はプロジェクトごとの設定（新しいタスクの既定値など）を表す。
+// 空文字・空 map は「未設定」を意味する。
+type Settings struct {
+	ProjectID         string
+	DefaultPriority   string         // タスク作成時に priority が省略された場合の値（low / medium / high）
+	DefaultAssigneeID string         // タスク作成時に担当者が省略された場合の担当者
+	DefaultSort       string         // タスク一覧の既定のソート順（tasks の sort パラメータ形式、例: "-priority,dueDate"）
+	WIPLimits         map[string]int // ステータスごとの WIP 上限（todo / in_progress / done）
+	// FieldLocks はタスクのフィールド（PATCH のフィールド名、例: priority）ごとに変更できるロール。
+	// ロックしていないフィールドはタスクを変更できる操作者なら誰でも変更できる
+	FieldLocks map[string][]MemberRole
+	// Timezone は期日の判定（期限切れのタスク数・期日が近いタスクの通知）に使うタイムゾーン（IANA のタイムゾーン名、例: Asia/Tokyo）。
+	// 空の場合は UTC
+	Timezone  string
+	UpdatedAt time.Time
+}
+
+// DefaultSettings は設定が保存されていないプロジェクトの設定（すべて未設定）を返す。
+func DefaultSettings(projectID string) *Settings {
+	return &Settings{
+		ProjectID:  projectID,
+		WIPLimits:  map[string]int{},
+		FieldLocks: map[string][]MemberRole{},
+	}
+}
+
+// taskPriorities / taskStatuses / taskSortKeys は tasks サービスが受け付ける値。
+var (
+	taskPriorities = map[string]bool{"low": true, "medium": true, "high": true}
+	taskStatuses   = map[string]bool{"todo": true, "in_progress": true, "done": true}
+	taskSortKeys   = map[string]bool{"sortOrder": true, "createdAt": true, "updatedAt": true, "dueDate": true, "priority": true}
+	// taskLockableFields は PATCH /tasks/{id} で変更できるフィールド（FieldLocks のキー）
+	taskLockableFields = map[string]bool{
+		"title": true, "description": true, "status": true, "priority": true, "assigneeId": true, "dueDate": true,
+		"startDate": true, "estimate": true, "milestoneId": true, "sprintId": true, "epicId": true, "labelIds": true,
+	}
+)
+
+// Normalize は前後の空白を取り除き、WIPLimits / FieldLocks が nil の場合は空 map にする。
+// FieldLocks のロールは小文字にし、重複を除いて並べ替える。
+func (s *Settings) Normalize() {
+	s.DefaultPriority = strings.ToLower(strings.TrimSpace(s.DefaultPriority))
+	s.DefaultAssigneeID = strings.TrimSpace(s.DefaultAssigneeID)
+	s.DefaultSort = strings.TrimSpace(s.DefaultSort)
+	s.Timezone = strings.TrimSpace(s.Timezone)
+	if s.WIPLimits == nil {
+		s.WIPLimits = map[string]int{}
+	}
+	locks := make(map[string][]MemberRole, len(s.FieldLocks))
+	for field, roles := range s.FieldLocks {
+		normalized := make([]MemberRole, 0, len(roles))
+		for _, r := range roles {
+			normalized = append(normalized, MemberRole(strings.ToLower(strings.TrimSpace(string(r)))))
+		}
+		slices.Sort(normalized)
+		locks[strings.TrimSpace(field)] = slices.Compact(normalized)
+	}
+	s.FieldLocks = locks
+}
+
+// Validate は設定値を検証する。不正な値がある場合は ErrInvalidSettings を返す。
+func (s *Settings) Validate() error {
+	if s.DefaultPriority != "" && !taskPriorities[s.DefaultPriority] {
+		return fmt.Errorf("%w: defaultPriority must be one of low, medium, high", ErrInvalidSettings)
+	}
+
+	if s.DefaultSort != "" {
+		for _, part := range strings.Split(s.DefaultSort, ",") {
+			key := strings.TrimPrefix(strings.TrimSpace(part), "-")
+			if !taskSortKeys[key] {
+				return fmt.Errorf("%w: defaultSort has unsupported key %q", ErrInvalidSettings, key)
+			}
+		}
+	}
+
+	if s.Timezone != "" {
+		// Local はサーバーのタイムゾーンになるため受け付けない
+		if _, err := time.LoadLocation(s.Timezone); err != nil || s.Timezone == "Local" {
+			return fmt.Errorf("%w: timezone must be an IANA time zone name (e.g. Asia/Tokyo)", ErrInvalidSettings)
+		}
+	}
+
+	for status, limit := range s.WIPLimits {
+		if !taskStatuses[status] {
+			return fmt.Errorf("%w: wipLimits has unknown status %q", ErrInvalidSettings, status)
+		}
+		if limit < 1 || limit > MaxWIPLimit {
+			return fmt.Errorf("%w: wipLimits.%s must be between 1 and %d", ErrInvalidSettings, status, MaxWIPLimit)
+		}
+	}
+
+	for field, roles := range s.FieldLocks {
+		if !taskLockableFields[field] {
+			return fmt.Errorf("%w: fieldLocks has unknown task field %q", ErrInvalidSettings, field)
+		}
+		if len(roles) == 0 {
+			return fmt.Errorf("%w: fieldLocks.%s must allow at least one role", ErrInvalidSettings, field)
+		}
+		for _, r := range roles {
+			if _, err := ParseMemberRole(string(r)); err != nil {
+				return fmt.Errorf("%w: fieldLocks.%s has unknown role %q", ErrInvalidSettings, field, r)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package project
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSettings_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings Settings
+		wantErr  bool
+	}{
+		{name: "empty", settings: Settings{}},
+		{
+			name: "all fields",
+			settings: Settings{
+				DefaultPriority:   "high",
+				DefaultAssigneeID: "user-1",
+				DefaultSort:       "-priority,dueDate",
+				WIPLimits:         map[string]int{"in_progress": 3},
+				FieldLocks:        map[string][]MemberRole{"priority": {RoleOwner, RoleAdmin}, "dueDate": {RoleAdmin}},
+				Timezone:          "Asia/Tokyo",
+			},
+		},
+		{name: "utc timezone", settings: Settings{Timezone: "UTC"}},
+		{name: "invalid priority", settings: Settings{DefaultPriority: "urgent"}, wantErr: true},
+		{name: "invalid sort key", settings: Settings{DefaultSort: "title"}, wantErr: true},
+		{name: "empty sort key", settings: Settings{DefaultSort: "priority,"}, wantErr: true},
+		{name: "unknown status", settings: Settings{WIPLimits: map[string]int{"doing": 3}}, wantErr: true},
+		{name: "zero limit", settings: Settings{WIPLimits: map[string]int{"todo": 0}}, wantErr: true},
+		{name: "limit too large", settings: Settings{WIPLimits: map[string]int{"todo": MaxWIPLimit + 1}}, wantErr: true},
+		{name: "unknown locked field", settings: Settings{FieldLocks: map[string][]MemberRole{"createdAt": {RoleAdmin}}}, wantErr: true},
+		{name: "lock without roles", settings: Settings{FieldLocks: map[string][]MemberRole{"priority": {}}}, wantErr: true},
+		{name: "lock with unknown role", settings: Settings{FieldLocks: map[string][]MemberRole{"priority": {"guest"}}}, wantErr: true},
+		{name: "unknown timezone", settings: Settings{Timezone: "Asia/Atlantis"}, wantErr: true},
+		{name: "offset timezone", settings: Settings{Timezone: "+09:00"}, wantErr: true},
+		{name: "local timezone", settings: Settings{Timezone: "Local"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSettings) {
+					t.Fatalf("expected ErrInvalidSettings, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSettings_Normalize(t *testing.T) {
+	s := Settings{
+		DefaultPriority:   " High ",
+		DefaultAssigneeID: " user-1 ",
+		DefaultSort:       " -priority ",
+		Timezone:          " Asia/Tokyo ",
+		FieldLocks:        map[string][]MemberRole{" priority ": {"Owner", " admin", "owner"}},
+	}
+	s.Normalize()
+
+	if s.DefaultPriority != "high" || s.DefaultAssigneeID != "user-1" || s.DefaultSort != "-priority" || s.Timezone != "Asia/Tokyo" {
+		t.Errorf("unexpected normalized settings: %+v", s)
+	}
+	if s.WIPLimits == nil {
+		t.Error("expected WIPLimits to be non-nil")
+	}
+	if got := s.FieldLocks["priority"]; !slices.Equal(got, []MemberRole{RoleAdmin, RoleOwner}) {
+		t.Errorf("FieldLocks[priority] = %v, want [admin owner]", got)
+	}
+}
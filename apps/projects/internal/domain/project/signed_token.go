@@ -0,0 +1,54 @@
+package project
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// signToken は payload(JSON) に HMAC-SHA256 の署名を付けたトークンを返す。
+// payload → base64.RawURLEncoding（paddingなし） = encodedPayload
+// sig = HMAC-SHA256(secret, encodedPayload) → base64.RawURLEncoding
+// token = encodedPayload + "." + sig
+//
+// cursor と招待トークンで共通に使う。
+func signToken(payloadJSON, secret []byte) string {
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payloadJSON)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(encodedPayload))
+	encodedSig := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+	return encodedPayload + "." + encodedSig
+}
+
+// verifyToken は signToken で作ったトークンの署名を検証し、payload(JSON) を返す。
+// 形式が不正な場合は errFormat、署名が一致しない場合は errSignature でラップしたエラーを返す。
+// 信頼できない入力を解釈しないよう、payload は署名を検証してから返す。
+func verifyToken(token string, secret []byte, errFormat, errSignature error) ([]byte, error) {
+	// フォーマットチェック: "payload.sig" の形式
+	encodedPayload, encodedSig, ok := strings.Cut(token, ".")
+	if !ok || encodedPayload == "" || strings.Contains(encodedSig, ".") {
+		return nil, errFormat
+	}
+
+	// payload / 署名をデコード
+	payloadJSON, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: base64 decode payload: %v", errFormat, err)
+	}
+	expectedSig, err := base64.RawURLEncoding.DecodeString(encodedSig)
+	if err != nil {
+		return nil, fmt.Errorf("%w: base64 decode sig: %v", errFormat, err)
+	}
+
+	// 署名を検証
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(encodedPayload))
+	if !hmac.Equal(expectedSig, mac.Sum(nil)) {
+		return nil, errSignature
+	}
+	return payloadJSON, nil
+}
@@ -0,0 +1,104 @@
+package project
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SlackEvent は Slack に投稿するタスクのイベントの種類。
+type SlackEvent string
+
+const (
+	SlackEventTaskCreated       SlackEvent = "task.created"        // タスクの作成
+	SlackEventTaskStatusChanged SlackEvent = "task.status_changed" // ステータスの変更
+	SlackEventTaskAssigned      SlackEvent = "task.assigned"       // 担当者の設定・変更（担当者を外した場合は含まない）
+)
+
+// AllSlackEvents は通知できるイベントの種類。Events を省略した場合はすべて通知する。
+var AllSlackEvents = []SlackEvent{SlackEventTaskCreated, SlackEventTaskStatusChanged, SlackEventTaskAssigned}
+
+// SlackIntegration はプロジェクトの Slack 連携（Incoming Webhook）の設定を表す。
+type SlackIntegration struct {
+	ProjectID string
+	// WebhookURL は Slack の Incoming Webhook の URL（https）。URL 自体が投稿の権限を持つため API では伏せて返す
+	WebhookURL string
+	// Events は投稿するイベントの種類（重複を除いて AllSlackEvents の順に並べる）
+	Events    []SlackEvent
+	UpdatedAt time.Time
+	UpdatedBy string
+}
+
+// Normalize は前後の空白を取り除き、Events の重複を除いて AllSlackEvents の順に並べる。Events が空の場合はすべてにする。
+func (s *SlackIntegration) Normalize() {
+	s.WebhookURL = strings.TrimSpace(s.WebhookURL)
+	if len(s.Events) == 0 {
+		s.Events = slices.Clone(AllSlackEvents)
+		return
+	}
+	events := make([]SlackEvent, len(s.Events))
+	for i, e := range s.Events {
+		events[i] = SlackEvent(strings.ToLower(strings.TrimSpace(string(e))))
+	}
+	slices.SortFunc(events, func(a, b SlackEvent) int {
+		return slackEventOrder(a) - slackEventOrder(b)
+	})
+	s.Events = slices.Compact(events)
+}
+
+// Validate は設定値を検証する。不正な値がある場合は ErrInvalidSlackIntegration を返す。
+// Webhook の宛先のホストの制限（SSRF 対策）はユースケースで確認する。
+func (s *SlackIntegration) Validate() error {
+	if s.WebhookURL == "" {
+		return fmt.Errorf("%w: webhookUrl is required", ErrInvalidSlackIntegration)
+	}
+	u, err := url.Parse(s.WebhookURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" || u.User != nil {
+		return fmt.Errorf("%w: webhookUrl must be an absolute https URL", ErrInvalidSlackIntegration)
+	}
+	for _, e := range s.Events {
+		if slackEventOrder(e) == len(AllSlackEvents) {
+			return fmt.Errorf("%w: events has unknown event %q", ErrInvalidSlackIntegration, e)
+		}
+	}
+	return nil
+}
+
+// Notifies は e を投稿する設定かどうかを返す。
+func (s *SlackIntegration) Notifies(e SlackEvent) bool {
+	return slices.Contains(s.Events, e)
+}
+
+// WebhookHost は WebhookURL のホスト（ポートを除く、小文字）を返す。
+func (s *SlackIntegration) WebhookHost() string {
+	u, err := url.Parse(s.WebhookURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// MaskedWebhookURL は API で返す WebhookURL（スキーム・ホストと末尾の 4 文字以外を伏せたもの）を返す。
+// 例: https://hooks.slack.com/****WXYZ
+func (s *SlackIntegration) MaskedWebhookURL() string {
+	u, err := url.Parse(s.WebhookURL)
+	if err != nil || u.Host == "" {
+		return "****"
+	}
+	rest := strings.TrimPrefix(s.WebhookURL, u.Scheme+"://"+u.Host)
+	const visible = 4
+	if len(rest) <= visible {
+		return u.Scheme + "://" + u.Host + "/****"
+	}
+	return u.Scheme + "://" + u.Host + "/****" + rest[len(rest)-visible:]
+}
+
+// slackEventOrder は e の AllSlackEvents での位置を返す。知らないイベントは len(AllSlackEvents)。
+func slackEventOrder(e SlackEvent) int {
+	if i := slices.Index(AllSlackEvents, e); i >= 0 {
+		return i
+	}
+	return len(AllSlackEvents)
+}
@@ -0,0 +1,113 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SprintState はスプリントの状態を表す型。
+// planned → active → completed の順にのみ遷移する。
+type SprintState string
+
+const (
+	SprintPlanned   SprintState = "planned"
+	SprintActive    SprintState = "active"
+	SprintCompleted SprintState = "completed"
+)
+
+// Sprint はプロジェクトのスプリント（期間を区切った作業単位）を表す。
+// タスクは tasks サービス側で sprintId によってスプリントに属する。
+type Sprint struct {
+	ID          string // プロジェクト内で一意
+	ProjectID   string
+	Name        string
+	Goal        string // 空はゴールなし
+	StartDate   time.Time
+	EndDate     time.Time
+	State       SprintState
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time // 完了した日時。完了前は nil
+}
+
+// NewSprint は計画中（planned）の新しいスプリントを生成する。
+// ID・名前が空、終了日が開始日より前の場合は ErrInvalidSprint を返す。
+func NewSprint(id, projectID, name, goal string, startDate, endDate time.Time, now time.Time) (*Sprint, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, fmt.Errorf("%w: id must not be empty", ErrInvalidSprint)
+	}
+	if strings.ContainsAny(id, "/:") {
+		return nil, fmt.Errorf("%w: id must not contain '/' or ':'", ErrInvalidSprint)
+	}
+
+	s := &Sprint{
+		ID:        id,
+		ProjectID: projectID,
+		State:     SprintPlanned,
+		CreatedAt: now,
+	}
+	if err := s.Update(name, goal, startDate, endDate, now); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Update は名前・ゴール・期間を置き換える（PUT）。
+// 不正な値の場合は ErrInvalidSprint、完了済みの場合は ErrSprintStateConflict を返し、s は変更しない。
+func (s *Sprint) Update(name, goal string, startDate, endDate time.Time, now time.Time) error {
+	if s.State == SprintCompleted {
+		return fmt.Errorf("%w: completed sprint cannot be updated", ErrSprintStateConflict)
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("%w: name must not be empty", ErrInvalidSprint)
+	}
+	if startDate.IsZero() || endDate.IsZero() {
+		return fmt.Errorf("%w: startDate and endDate are required", ErrInvalidSprint)
+	}
+	if endDate.Before(startDate) {
+		return fmt.Errorf("%w: endDate must not be before startDate", ErrInvalidSprint)
+	}
+
+	s.Name = name
+	s.Goal = strings.TrimSpace(goal)
+	s.StartDate = startDate
+	s.EndDate = endDate
+	s.UpdatedAt = now
+	return nil
+}
+
+// Start は計画中のスプリントを開始（active）にする。計画中でない場合は ErrSprintStateConflict を返す。
+// プロジェクト内で同時に開始できるスプリントは 1 つだけだが、その確認は呼び出し側で行う。
+func (s *Sprint) Start(now time.Time) error {
+	if s.State != SprintPlanned {
+		return fmt.Errorf("%w: only planned sprint can be started (current: %s)", ErrSprintStateConflict, s.State)
+	}
+	s.State = SprintActive
+	s.UpdatedAt = now
+	return nil
+}
+
+// Complete は開始中のスプリントを完了（completed）にする。開始中でない場合は ErrSprintStateConflict を返す。
+func (s *Sprint) Complete(now time.Time) error {
+	if s.State != SprintActive {
+		return fmt.Errorf("%w: only active sprint can be completed (current: %s)", ErrSprintStateConflict, s.State)
+	}
+	s.State = SprintCompleted
+	s.UpdatedAt = now
+	s.CompletedAt = &now
+	return nil
+}
+
+// NextPlannedSprint は sprints（一覧の順）から exceptID 以外で最初の計画中のスプリントを返す。無い場合は nil。
+// スプリントの完了時に、未完了タスクの持ち越し先を決めるのに使う。
+func NextPlannedSprint(sprints []*Sprint, exceptID string) *Sprint {
+	for _, s := range sprints {
+		if s.State == SprintPlanned && s.ID != exceptID {
+			return s
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package project
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewSprint(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	start := now.AddDate(0, 0, 1)
+	end := start.AddDate(0, 0, 14)
+
+	s, err := NewSprint(" s1 ", "proj-1", " スプリント 1 ", " ログイン機能 ", start, end, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.ID != "s1" || s.Name != "スプリント 1" || s.Goal != "ログイン機能" || s.State != SprintPlanned {
+		t.Errorf("unexpected sprint: %+v", s)
+	}
+	if !s.StartDate.Equal(start) || !s.EndDate.Equal(end) || s.CompletedAt != nil {
+		t.Errorf("unexpected dates: %+v", s)
+	}
+
+	tests := []struct {
+		name  string
+		id    string
+		sname string
+		start time.Time
+		end   time.Time
+	}{
+		{name: "empty id", id: "", sname: "s1", start: start, end: end},
+		{name: "id with slash", id: "a/b", sname: "s1", start: start, end: end},
+		{name: "id with colon", id: "s1:start", sname: "s1", start: start, end: end},
+		{name: "empty name", id: "s1", sname: "  ", start: start, end: end},
+		{name: "missing dates", id: "s1", sname: "s1"},
+		{name: "end before start", id: "s1", sname: "s1", start: end, end: start},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSprint(tt.id, "proj-1", tt.sname, "", tt.start, tt.end, now); !errors.Is(err, ErrInvalidSprint) {
+				t.Errorf("expected ErrInvalidSprint, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSprint_Lifecycle(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	s, err := NewSprint("s1", "proj-1", "s1", "", now, now.AddDate(0, 0, 14), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 計画中は完了できない
+	if err := s.Complete(now); !errors.Is(err, ErrSprintStateConflict) {
+		t.Fatalf("expected ErrSprintStateConflict, got %v", err)
+	}
+
+	if err := s.Start(now.Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.State != SprintActive || !s.UpdatedAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("unexpected sprint after start: %+v", s)
+	}
+	if err := s.Start(now); !errors.Is(err, ErrSprintStateConflict) {
+		t.Fatalf("expected ErrSprintStateConflict for second start, got %v", err)
+	}
+
+	done := now.AddDate(0, 0, 14)
+	if err := s.Complete(done); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.State != SprintCompleted || s.CompletedAt == nil || !s.CompletedAt.Equal(done) {
+		t.Errorf("unexpected sprint after complete: %+v", s)
+	}
+
+	// 完了済みは更新できない
+	if err := s.Update("s1'", "", now, done, done); !errors.Is(err, ErrSprintStateConflict) {
+		t.Fatalf("expected ErrSprintStateConflict, got %v", err)
+	}
+	if s.Name != "s1" {
+		t.Errorf("sprint must not change on error: %+v", s)
+	}
+}
+
+func TestNextPlannedSprint(t *testing.T) {
+	sprints := []*Sprint{
+		{ID: "s1", State: SprintCompleted},
+		{ID: "s2", State: SprintActive},
+		{ID: "s3", State: SprintPlanned},
+		{ID: "s4", State: SprintPlanned},
+	}
+	if got := NextPlannedSprint(sprints, "s2"); got == nil || got.ID != "s3" {
+		t.Errorf("expected s3, got %+v", got)
+	}
+	if got := NextPlannedSprint(sprints, "s3"); got == nil || got.ID != "s4" {
+		t.Errorf("expected s4, got %+v", got)
+	}
+	if got := NextPlannedSprint(sprints[:2], "s2"); got != nil {
+		t.Errorf("expected nil, got %+v", got)
+	}
+}
@@ -0,0 +1,41 @@
+package project
+
+import "time"
+
+// Stats はプロジェクトのタスクの集計（ダッシュボードのプロジェクトカード用）。
+// タスクは tasks サービスが管理するため、集計も tasks サービスから取得する。
+type Stats struct {
+	ProjectID      string
+	Open           int        // 未完了（todo / in_progress）のタスク数
+	Done           int        // 完了したタスク数
+	Overdue        int        // 期限を過ぎた未完了のタスク数
+	LastActivityAt *time.Time // タスクの最終更新日時。タスクが無い場合は nil
+}
+
+// Summary はダッシュボード向けのプロジェクトのサマリー（タスクの集計と、マイルストーン・エピック・ラベルごとの集計）。
+type Summary struct {
+	Stats      *Stats
+	Milestones []MilestoneProgress
+	Epics      []EpicProgress
+	Labels     []LabelUsage
+}
+
+// taskStatusDone は tasks サービスの完了のステータス。
+const taskStatusDone = "done"
+
+// TaskCountsDelta はタスクのステータスが previous から status に変わったときの未完了・完了のタスク数の増減を返す。
+// previous が空の場合は作成、status が空の場合は削除として扱う。done 以外のステータスは未完了として数える。
+func TaskCountsDelta(previous, status string) (open, done int) {
+	add := func(s string, n int) {
+		switch s {
+		case "":
+		case taskStatusDone:
+			done += n
+		default:
+			open += n
+		}
+	}
+	add(previous, -1)
+	add(status, 1)
+	return open, done
+}
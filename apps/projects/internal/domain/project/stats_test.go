@@ -0,0 +1,25 @@
+package project
+
+import "testing"
+
+func TestTaskCountsDelta(t *testing.T) {
+	tests := []struct {
+		previous, status string
+		open, done       int
+	}{
+		{"", "todo", 1, 0},
+		{"", "done", 0, 1},
+		{"todo", "in_progress", 0, 0},
+		{"in_progress", "done", -1, 1},
+		{"done", "todo", 1, -1},
+		{"done", "", 0, -1},
+		{"todo", "", -1, 0},
+		{"", "", 0, 0},
+	}
+	for _, tt := range tests {
+		open, done := TaskCountsDelta(tt.previous, tt.status)
+		if open != tt.open || done != tt.done {
+			t.Errorf("TaskCountsDelta(%q, %q) = (%d, %d), want (%d, %d)", tt.previous, tt.status, open, done, tt.open, tt.done)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProjectStatus はプロジェクトの進行状態を表す。
+type ProjectStatus string
+
+const (
+	StatusActive    ProjectStatus = "active"
+	StatusOnHold    ProjectStatus = "on_hold"
+	StatusCompleted ProjectStatus = "completed"
+)
+
+// ParseStatus は文字列から ProjectStatus を生成する。
+// 前後の空白と大文字小文字は無視し、"on-hold" / "onhold" は on_hold に正規化する。
+// 未知の値の場合は ErrInvalidStatus を返す。
+func ParseStatus(s string) (ProjectStatus, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "active":
+		return StatusActive, nil
+	case "on_hold", "on-hold", "onhold":
+		return StatusOnHold, nil
+	case "completed":
+		return StatusCompleted, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidStatus, s)
+	}
+}
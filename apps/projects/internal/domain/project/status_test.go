@@ -0,0 +1,52 @@
+package project
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ProjectStatus
+		wantErr bool
+	}{
+		{in: "active", want: StatusActive},
+		{in: " Active ", want: StatusActive},
+		{in: "on_hold", want: StatusOnHold},
+		{in: "on-hold", want: StatusOnHold},
+		{in: "ONHOLD", want: StatusOnHold},
+		{in: "completed", want: StatusCompleted},
+		{in: "archived", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseStatus(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidStatus) {
+					t.Fatalf("expected ErrInvalidStatus, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewProject_DefaultsToActive(t *testing.T) {
+	p, err := NewProject("proj-1", "Alpha", "", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Status != StatusActive {
+		t.Errorf("expected status=%q, got %q", StatusActive, p.Status)
+	}
+}
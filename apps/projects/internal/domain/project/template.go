@@ -0,0 +1,74 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxTemplateTasks はテンプレートに含められるタスクの最大数。
+const MaxTemplateTasks = 200
+
+// TaskBlueprint はテンプレートからプロジェクトを作成する際に作られるタスクのひな形。
+type TaskBlueprint struct {
+	Title       string
+	Description string
+	Status      string // 空の場合は todo
+	Priority    string // 空の場合はプロジェクト設定の既定値（tasks サービス側で適用）
+}
+
+// Template はプロジェクトテンプレート（名前と定義済みタスク）を表す。
+type Template struct {
+	ID          string
+	Name        string
+	Description string
+	Tasks       []TaskBlueprint
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewTemplate は新しいプロジェクトテンプレートを生成する。
+// ID・名前が空、タスクが多すぎる、タスクの値が不正な場合は ErrInvalidTemplate を返す。
+func NewTemplate(id, name, description string, tasks []TaskBlueprint, now time.Time) (*Template, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, fmt.Errorf("%w: id must not be empty", ErrInvalidTemplate)
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name must not be empty", ErrInvalidTemplate)
+	}
+	if len(tasks) > MaxTemplateTasks {
+		return nil, fmt.Errorf("%w: tasks must not exceed %d", ErrInvalidTemplate, MaxTemplateTasks)
+	}
+
+	blueprints := make([]TaskBlueprint, len(tasks))
+	for i, bp := range tasks {
+		bp.Title = strings.TrimSpace(bp.Title)
+		bp.Status = strings.ToLower(strings.TrimSpace(bp.Status))
+		bp.Priority = strings.ToLower(strings.TrimSpace(bp.Priority))
+		if bp.Status == "" {
+			bp.Status = "todo"
+		}
+
+		if bp.Title == "" {
+			return nil, fmt.Errorf("%w: tasks[%d].title must not be empty", ErrInvalidTemplate, i)
+		}
+		if !taskStatuses[bp.Status] {
+			return nil, fmt.Errorf("%w: tasks[%d].status must be one of todo, in_progress, done", ErrInvalidTemplate, i)
+		}
+		if bp.Priority != "" && !taskPriorities[bp.Priority] {
+			return nil, fmt.Errorf("%w: tasks[%d].priority must be one of low, medium, high", ErrInvalidTemplate, i)
+		}
+		blueprints[i] = bp
+	}
+
+	return &Template{
+		ID:          id,
+		Name:        name,
+		Description: description,
+		Tasks:       blueprints,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package project
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewTemplate(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tmpl, err := NewTemplate("tmpl-1", " スプリント ", "", []TaskBlueprint{
+		{Title: " 計画 ", Priority: "High"},
+		{Title: "振り返り", Status: "done"},
+	}, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tmpl.Name != "スプリント" {
+		t.Errorf("expected trimmed name, got %q", tmpl.Name)
+	}
+	if got := tmpl.Tasks[0]; got.Title != "計画" || got.Status != "todo" || got.Priority != "high" {
+		t.Errorf("unexpected normalized blueprint: %+v", got)
+	}
+	if got := tmpl.Tasks[1]; got.Status != "done" || got.Priority != "" {
+		t.Errorf("unexpected blueprint: %+v", got)
+	}
+}
+
+func TestNewTemplate_Invalid(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name  string
+		id    string
+		tName string
+		tasks []TaskBlueprint
+	}{
+		{name: "empty id", id: " ", tName: "t"},
+		{name: "empty name", tName: " "},
+		{name: "empty task title", tName: "t", tasks: []TaskBlueprint{{Title: ""}}},
+		{name: "invalid status", tName: "t", tasks: []TaskBlueprint{{Title: "a", Status: "doing"}}},
+		{name: "invalid priority", tName: "t", tasks: []TaskBlueprint{{Title: "a", Priority: "urgent"}}},
+		{name: "too many tasks", tName: "t", tasks: make([]TaskBlueprint, MaxTemplateTasks+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := tt.id
+			if id == "" {
+				id = "tmpl-1"
+			}
+			if _, err := NewTemplate(id, tt.tName, "", tt.tasks, now); !errors.Is(err, ErrInvalidTemplate) {
+				t.Fatalf("expected ErrInvalidTemplate, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+// Package migration は projects サービスの SQL マイグレーション（migrations/ に埋め込み）を teamflow-shared/migrate で適用する。
+//
+// 適用済みバージョンは projects_schema_migrations テーブルで管理する
+// （tasks サービスと同じ DB を使う場合でも schema_migrations と衝突しないよう別テーブルにする）。
+package migration
+
+import (
+	"embed"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"teamflow-shared/migrate"
+)
+
+//go:embed migrations/*.sql
+var embedded embed.FS
+
+// Config は projects サービスのマイグレーションの設定。
+var Config = migrate.Config{
+	FS:    embedded,
+	Dir:   "migrations",
+	Table: "projects_schema_migrations",
+	// 複数プロセス（テストパッケージの並列実行など）からの同時適用を直列化するためのロックキー
+	LockKey: 0x70726f6a65637473, // "projects"
+}
+
+// New は埋め込みのマイグレーションを読み込んだ Migrator を生成する。
+func New(db *pgxpool.Pool) (*migrate.Migrator, error) {
+	return migrate.New(db, Config)
+}
@@ -0,0 +1,11 @@
+-- projects テーブル定義
+CREATE TABLE projects (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- インデックス
+CREATE INDEX idx_projects_created_at ON projects(created_at);
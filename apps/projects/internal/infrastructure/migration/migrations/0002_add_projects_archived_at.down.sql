@@ -0,0 +1,4 @@
+CREATE INDEX idx_projects_created_at ON projects(created_at);
+DROP INDEX IF EXISTS idx_projects_created_id;
+DROP INDEX IF EXISTS idx_projects_name_id;
+ALTER TABLE projects DROP COLUMN IF EXISTS archived_at;
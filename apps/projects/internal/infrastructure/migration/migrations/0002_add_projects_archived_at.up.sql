@@ -0,0 +1,7 @@
+-- アーカイブ日時（NULL はアーカイブされていない）
+ALTER TABLE projects ADD COLUMN archived_at TIMESTAMPTZ;
+
+-- 一覧の keyset pagination 用の複合インデックス（name はバイト順で比較する）
+CREATE INDEX idx_projects_name_id ON projects((name COLLATE "C"), id);
+CREATE INDEX idx_projects_created_id ON projects(created_at, id);
+DROP INDEX IF EXISTS idx_projects_created_at;
@@ -0,0 +1,5 @@
+-- プロジェクトの進行状態（既存行は active とする）
+ALTER TABLE projects ADD COLUMN status TEXT NOT NULL DEFAULT 'active'
+    CONSTRAINT projects_status_check CHECK (status IN ('active', 'on_hold', 'completed'));
+
+CREATE INDEX idx_projects_status ON projects(status);
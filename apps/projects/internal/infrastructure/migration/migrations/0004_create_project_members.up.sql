@@ -0,0 +1,12 @@
+-- プロジェクトメンバー（プロジェクト削除時は一緒に削除する）
+CREATE TABLE project_members (
+    project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    role TEXT NOT NULL DEFAULT 'member'
+        CONSTRAINT project_members_role_check CHECK (role IN ('owner', 'admin', 'member')),
+    joined_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (project_id, user_id)
+);
+
+-- ユーザーが参加しているプロジェクトの検索用
+CREATE INDEX idx_project_members_user_id ON project_members(user_id);
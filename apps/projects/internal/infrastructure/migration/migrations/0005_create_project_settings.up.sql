@@ -0,0 +1,9 @@
+-- プロジェクト設定（新しいタスクの既定値・WIP 上限）。空文字は未設定を表す
+CREATE TABLE project_settings (
+    project_id TEXT PRIMARY KEY REFERENCES projects(id) ON DELETE CASCADE,
+    default_priority TEXT NOT NULL DEFAULT '',
+    default_assignee_id TEXT NOT NULL DEFAULT '',
+    default_sort TEXT NOT NULL DEFAULT '',
+    wip_limits JSONB NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,9 @@
+-- プロジェクトテンプレート。tasks はタスクのひな形（title / description / status / priority）の配列
+CREATE TABLE project_templates (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    tasks JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,6 @@
+-- 人が読むためのプロジェクトキー（例: TFLOW）。NULL は未設定（既存行は未設定とする）
+ALTER TABLE projects ADD COLUMN key TEXT
+    CONSTRAINT projects_key_check CHECK (key ~ '^[A-Z][A-Z0-9]{1,9}$');
+
+-- キーは一意（NULL 同士は重複とみなさない）
+CREATE UNIQUE INDEX idx_projects_key ON projects(key);
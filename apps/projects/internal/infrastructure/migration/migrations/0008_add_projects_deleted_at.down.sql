@@ -0,0 +1,2 @@
+ALTER TABLE projects DROP COLUMN IF EXISTS delete_policy;
+ALTER TABLE projects DROP COLUMN IF EXISTS deleted_at;
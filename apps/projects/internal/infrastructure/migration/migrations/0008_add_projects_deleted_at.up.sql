@@ -0,0 +1,5 @@
+-- 削除（論理削除）日時と削除したときのタスクの扱い（cascade）。deleted_at が NULL のプロジェクトのみ取得・一覧の対象
+-- 削除されたプロジェクトも復元できる期間は key を保持する（idx_projects_key の一意性は削除後も有効）
+ALTER TABLE projects ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE projects ADD COLUMN delete_policy TEXT
+    CONSTRAINT projects_delete_policy_check CHECK (delete_policy IN ('block', 'archive_tasks', 'delete_tasks'));
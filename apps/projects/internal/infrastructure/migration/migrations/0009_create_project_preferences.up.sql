@@ -0,0 +1,10 @@
+-- ユーザーごとのプロジェクトの表示設定（サイドバーのお気に入り・並び順）
+CREATE TABLE project_preferences (
+    user_id TEXT NOT NULL,
+    project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    favorite BOOLEAN NOT NULL DEFAULT FALSE,
+    -- 手動の並び順（0 始まり）。NULL は並び順を指定していない
+    position INTEGER,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, project_id)
+);
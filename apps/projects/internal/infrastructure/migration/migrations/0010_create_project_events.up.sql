@@ -0,0 +1,13 @@
+-- プロジェクトのアクティビティ（作成・更新・アーカイブ・メンバーの変更の履歴）
+CREATE TABLE project_events (
+    id BIGSERIAL PRIMARY KEY,
+    project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    -- 操作者。空文字は不明を表す
+    actor_id TEXT NOT NULL DEFAULT '',
+    data JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+-- GET /projects/{id}/activity の keyset ページネーション（新しい順）用
+CREATE INDEX idx_project_events_project_id_created_at ON project_events(project_id, created_at DESC, id DESC);
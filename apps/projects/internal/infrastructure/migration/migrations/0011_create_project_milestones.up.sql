@@ -0,0 +1,13 @@
+-- プロジェクトのマイルストーン。タスクは tasks サービスの tasks.milestone_id で参照する
+CREATE TABLE project_milestones (
+    project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    -- 期限。NULL は期限なし
+    due_date TIMESTAMPTZ,
+    status TEXT NOT NULL DEFAULT 'open'
+        CONSTRAINT project_milestones_status_check CHECK (status IN ('open', 'closed')),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (project_id, id)
+);
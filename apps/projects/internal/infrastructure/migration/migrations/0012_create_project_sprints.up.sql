@@ -0,0 +1,21 @@
+-- プロジェクトのスプリント。タスクは tasks サービスの tasks.sprint_id で参照する
+CREATE TABLE project_sprints (
+    project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    -- ゴール。空文字はゴールなし
+    goal TEXT NOT NULL DEFAULT '',
+    start_date TIMESTAMPTZ NOT NULL,
+    end_date TIMESTAMPTZ NOT NULL,
+    state TEXT NOT NULL DEFAULT 'planned'
+        CONSTRAINT project_sprints_state_check CHECK (state IN ('planned', 'active', 'completed')),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    -- 完了した日時。完了前は NULL
+    completed_at TIMESTAMPTZ,
+    PRIMARY KEY (project_id, id),
+    CONSTRAINT project_sprints_dates_check CHECK (end_date >= start_date)
+);
+
+-- プロジェクト内で開始中（active）のスプリントは 1 つまで
+CREATE UNIQUE INDEX idx_project_sprints_one_active ON project_sprints (project_id) WHERE state = 'active';
@@ -0,0 +1,13 @@
+-- プロジェクトのエピック。タスクは tasks サービスの tasks.epic_id で参照する
+CREATE TABLE project_epics (
+    project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    -- 説明。空文字は説明なし
+    description TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'open'
+        CONSTRAINT project_epics_status_check CHECK (status IN ('open', 'closed')),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (project_id, id)
+);
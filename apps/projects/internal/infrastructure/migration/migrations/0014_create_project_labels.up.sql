@@ -0,0 +1,15 @@
+-- プロジェクトのラベル定義。タスクは tasks サービスの tasks.label_ids で参照する
+CREATE TABLE project_labels (
+    project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    -- 表示色（#rrggbb）
+    color TEXT NOT NULL
+        CONSTRAINT project_labels_color_check CHECK (color ~ '^#[0-9a-f]{6}$'),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (project_id, id)
+);
+
+-- 名前はプロジェクト内で一意（大文字・小文字は区別しない）。一覧の並び順にも使う
+CREATE UNIQUE INDEX idx_project_labels_project_id_name ON project_labels(project_id, lower(name));
@@ -0,0 +1,4 @@
+-- プロジェクトの公開範囲。private はメンバーのみ、public は誰でも閲覧できる
+ALTER TABLE projects
+    ADD COLUMN visibility TEXT NOT NULL DEFAULT 'private'
+        CONSTRAINT projects_visibility_check CHECK (visibility IN ('private', 'public'));
@@ -0,0 +1,14 @@
+-- プロジェクトへの招待リンク。トークンは保存せず、id と有効期限を署名したものを発行時に返す
+CREATE TABLE project_invitations (
+    project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    id TEXT NOT NULL,
+    -- 参加したユーザーのロール（owner は招待できない）
+    role TEXT NOT NULL
+        CONSTRAINT project_invitations_role_check CHECK (role IN ('admin', 'member')),
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    -- 取り消し日時（NULL は有効）
+    revoked_at TIMESTAMPTZ,
+    PRIMARY KEY (project_id, id)
+);
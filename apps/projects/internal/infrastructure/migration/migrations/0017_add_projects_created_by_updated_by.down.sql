@@ -0,0 +1,2 @@
+ALTER TABLE projects DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE projects DROP COLUMN IF EXISTS created_by;
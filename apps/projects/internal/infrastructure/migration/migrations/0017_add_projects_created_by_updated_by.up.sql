@@ -0,0 +1,4 @@
+-- 作成者・最終更新者（X-User-ID）。空文字は不明（既存の行・操作者の無い呼び出し）
+ALTER TABLE projects
+    ADD COLUMN created_by TEXT NOT NULL DEFAULT '',
+    ADD COLUMN updated_by TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_projects_workspace_key;
+CREATE UNIQUE INDEX idx_projects_key ON projects(key);
+ALTER TABLE projects DROP COLUMN IF EXISTS workspace_id;
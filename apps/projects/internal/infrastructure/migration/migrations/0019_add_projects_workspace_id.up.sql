@@ -0,0 +1,6 @@
+-- プロジェクトが属するワークスペース（テナント）。既存の行はワークスペースの導入前のデータとして default に属する
+ALTER TABLE projects ADD COLUMN workspace_id TEXT NOT NULL DEFAULT 'default';
+
+-- キーはワークスペース内で一意（別のワークスペースでは同じキーを使える）
+DROP INDEX IF EXISTS idx_projects_key;
+CREATE UNIQUE INDEX idx_projects_workspace_key ON projects(workspace_id, key);
@@ -0,0 +1 @@
+ALTER TABLE project_settings DROP COLUMN IF EXISTS field_locks;
@@ -0,0 +1,2 @@
+-- タスクのフィールドごとに変更できるロール（例: {"priority": ["owner", "admin"]}）。無いフィールドはロックしない
+ALTER TABLE project_settings ADD COLUMN field_locks JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS processed_events;
+ALTER TABLE projects DROP COLUMN IF EXISTS done_count;
+ALTER TABLE projects DROP COLUMN IF EXISTS open_count;
@@ -0,0 +1,12 @@
+-- tasks サービスのタスクのイベント（CONSUME_TASK_EVENTS）で更新する未完了・完了のタスク数。
+-- 購読を有効にする前に作成したタスクは含まない（有効にした後のイベントから数える）
+ALTER TABLE projects ADD COLUMN open_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE projects ADD COLUMN done_count INTEGER NOT NULL DEFAULT 0;
+
+-- 件数に反映したイベントの ID。同じイベントが複数回届いても 2 回数えないようにする（一定期間の後に削除する）
+CREATE TABLE processed_events (
+    event_id TEXT PRIMARY KEY,
+    processed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_processed_events_processed_at ON processed_events (processed_at);
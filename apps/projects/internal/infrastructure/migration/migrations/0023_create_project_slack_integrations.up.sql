@@ -0,0 +1,8 @@
+-- プロジェクトの Slack 連携（Incoming Webhook）の設定。events は投稿するタスクのイベントの種類
+CREATE TABLE project_slack_integrations (
+    project_id TEXT PRIMARY KEY REFERENCES projects(id) ON DELETE CASCADE,
+    webhook_url TEXT NOT NULL,
+    events TEXT[] NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    updated_by TEXT NOT NULL DEFAULT ''
+);
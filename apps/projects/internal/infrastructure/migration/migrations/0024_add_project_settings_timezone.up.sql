@@ -0,0 +1,2 @@
+-- 期日の判定（期限切れ・期日が近いタスクの通知）に使うタイムゾーン（IANA のタイムゾーン名）。空の場合は UTC
+ALTER TABLE project_settings ADD COLUMN timezone TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,15 @@
+//go:build integration
+// +build integration
+
+package projectinfra
+
+import (
+	"os"
+	"testing"
+
+	"teamflow-projects/internal/testutil"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(testutil.InitTestDB(m))
+}
@@ -0,0 +1,72 @@
+package projectinfra
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// MemoryActivityRepository はメモリ上にプロジェクトのアクティビティを保持する ActivityRepository 実装。
+// 並行に呼び出してよい。保存時と取得時にコピーする。
+// プロジェクトの存在は確認しない（記録するユースケースはプロジェクトを取得・保存した後に呼ぶ）。
+type MemoryActivityRepository struct {
+	mu     sync.RWMutex
+	seq    int64
+	events map[string][]*domain.ActivityEvent // projectID -> 記録順のアクティビティ
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.ActivityRepository = (*MemoryActivityRepository)(nil)
+
+// NewMemoryActivityRepository は空のインメモリリポジトリを生成する。
+func NewMemoryActivityRepository() *MemoryActivityRepository {
+	return &MemoryActivityRepository{
+		events: make(map[string][]*domain.ActivityEvent),
+	}
+}
+
+// Append はアクティビティを記録し、e.ID を採番する。
+func (r *MemoryActivityRepository) Append(_ context.Context, e *domain.ActivityEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	e.ID = r.seq
+	r.events[e.ProjectID] = append(r.events[e.ProjectID], cloneActivityEvent(e))
+	return nil
+}
+
+// FindActivity はプロジェクトのアクティビティを新しい順で limit + 1 件まで返す。
+func (r *MemoryActivityRepository) FindActivity(_ context.Context, query *domain.ActivityQuery) ([]*domain.ActivityEvent, error) {
+	r.mu.RLock()
+	out := make([]*domain.ActivityEvent, 0)
+	for _, e := range r.events[query.ProjectID] {
+		if query.After(e) {
+			out = append(out, cloneActivityEvent(e))
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.After(out[j].CreatedAt)
+		}
+		return out[i].ID > out[j].ID
+	})
+	if len(out) > query.Limit+1 {
+		out = out[:query.Limit+1]
+	}
+	return out, nil
+}
+
+// cloneActivityEvent は e のコピーを返す（Data も共有しない）。
+func cloneActivityEvent(e *domain.ActivityEvent) *domain.ActivityEvent {
+	c := *e
+	c.Data = make(map[string]string, len(e.Data))
+	for k, v := range e.Data {
+		c.Data[k] = v
+	}
+	return &c
+}
@@ -0,0 +1,64 @@
+package projectinfra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+func TestMemoryActivityRepository_Pagination(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryActivityRepository()
+	secret := []byte("test-secret")
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// 3 件目と 4 件目は同時刻（ID の降順で並ぶ）
+	for i, at := range []time.Time{now, now.Add(time.Minute), now.Add(2 * time.Minute), now.Add(2 * time.Minute)} {
+		e := domain.NewActivityEvent("proj-1", domain.ActivityProjectUpdated, "user-1", map[string]string{"fields": "name"}, at)
+		if err := repo.Append(ctx, e); err != nil {
+			t.Fatalf("failed to append: %v", err)
+		}
+		if e.ID != int64(i+1) {
+			t.Fatalf("expected ID %d, got %d", i+1, e.ID)
+		}
+	}
+	if err := repo.Append(ctx, domain.NewActivityEvent("proj-2", domain.ActivityProjectCreated, "", nil, now)); err != nil {
+		t.Fatalf("failed to append: %v", err)
+	}
+
+	var got []int64
+	cursor := ""
+	for page := 0; page < 3; page++ {
+		q, err := domain.NewActivityQuery("proj-1", 3, cursor, secret, now)
+		if err != nil {
+			t.Fatalf("failed to build query: %v", err)
+		}
+		events, err := repo.FindActivity(ctx, q)
+		if err != nil {
+			t.Fatalf("failed to find activity: %v", err)
+		}
+		if len(events) <= q.Limit {
+			for _, e := range events {
+				got = append(got, e.ID)
+			}
+			break
+		}
+		events = events[:q.Limit]
+		for _, e := range events {
+			got = append(got, e.ID)
+		}
+		cursor, _ = domain.EncodeCursor(q.NewCursorPayload(events[len(events)-1], now), secret)
+	}
+
+	want := []int64{4, 3, 2, 1}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
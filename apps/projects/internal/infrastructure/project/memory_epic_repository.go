@@ -0,0 +1,108 @@
+package projectinfra
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// ErrEpicNotFound はエピックが存在しない場合のエラー。
+var ErrEpicNotFound = usecase.ErrEpicNotFound
+
+// ErrEpicAlreadyExists はプロジェクト内に同じ ID のエピックが既に存在する場合のエラー。
+var ErrEpicAlreadyExists = usecase.ErrEpicAlreadyExists
+
+// MemoryEpicRepository はメモリ上にエピックを保持する EpicRepository 実装。
+// 並行に呼び出してよい。保存時と取得時にコピーする。
+// プロジェクトの存在は確認しない（保存するユースケースはプロジェクトを取得した後に呼ぶ）。
+type MemoryEpicRepository struct {
+	mu    sync.RWMutex
+	epics map[string]map[string]*domain.Epic // projectID -> epicID -> エピック
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.EpicRepository = (*MemoryEpicRepository)(nil)
+
+// NewMemoryEpicRepository は空のインメモリリポジトリを生成する。
+func NewMemoryEpicRepository() *MemoryEpicRepository {
+	return &MemoryEpicRepository{
+		epics: make(map[string]map[string]*domain.Epic),
+	}
+}
+
+// SaveEpic はエピックを保存する。プロジェクト内に同じ ID がある場合は ErrEpicAlreadyExists を返す。
+func (r *MemoryEpicRepository) SaveEpic(_ context.Context, e *domain.Epic) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	byID, ok := r.epics[e.ProjectID]
+	if !ok {
+		byID = make(map[string]*domain.Epic)
+		r.epics[e.ProjectID] = byID
+	}
+	if _, ok := byID[e.ID]; ok {
+		return ErrEpicAlreadyExists
+	}
+	byID[e.ID] = cloneEpic(e)
+	return nil
+}
+
+// UpdateEpic はエピックを更新する。存在しない場合は ErrEpicNotFound を返す。
+func (r *MemoryEpicRepository) UpdateEpic(_ context.Context, e *domain.Epic) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.epics[e.ProjectID][e.ID]; !ok {
+		return ErrEpicNotFound
+	}
+	r.epics[e.ProjectID][e.ID] = cloneEpic(e)
+	return nil
+}
+
+// DeleteEpic はエピックを削除する。存在しない場合は ErrEpicNotFound を返す。
+func (r *MemoryEpicRepository) DeleteEpic(_ context.Context, projectID, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.epics[projectID][id]; !ok {
+		return ErrEpicNotFound
+	}
+	delete(r.epics[projectID], id)
+	return nil
+}
+
+// FindEpic はエピックを取得する。存在しない場合は ErrEpicNotFound を返す。
+func (r *MemoryEpicRepository) FindEpic(_ context.Context, projectID, id string) (*domain.Epic, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.epics[projectID][id]
+	if !ok {
+		return nil, ErrEpicNotFound
+	}
+	return cloneEpic(e), nil
+}
+
+// ListEpics はエピックを作成日時・ID の昇順で返す。
+func (r *MemoryEpicRepository) ListEpics(_ context.Context, projectID string) ([]*domain.Epic, error) {
+	r.mu.RLock()
+	out := make([]*domain.Epic, 0, len(r.epics[projectID]))
+	for _, e := range r.epics[projectID] {
+		out = append(out, cloneEpic(e))
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(out, func(a, b *domain.Epic) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return out, nil
+}
+
+// cloneEpic は e のコピーを返す。
+func cloneEpic(e *domain.Epic) *domain.Epic {
+	c := *e
+	return &c
+}
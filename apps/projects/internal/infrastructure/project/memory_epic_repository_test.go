@@ -0,0 +1,75 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+func TestMemoryEpicRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryEpicRepository()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, e := range []*domain.Epic{
+		{ID: "later", ProjectID: "proj-1", Name: "後", Status: domain.EpicOpen, CreatedAt: now.Add(time.Hour)},
+		{ID: "b", ProjectID: "proj-1", Name: "先 B", Status: domain.EpicOpen, CreatedAt: now},
+		{ID: "a", ProjectID: "proj-1", Name: "先 A", Status: domain.EpicOpen, CreatedAt: now},
+		{ID: "later", ProjectID: "proj-2", Name: "別プロジェクト", Status: domain.EpicOpen, CreatedAt: now},
+	} {
+		if err := repo.SaveEpic(ctx, e); err != nil {
+			t.Fatalf("failed to save %s: %v", e.ID, err)
+		}
+	}
+	if err := repo.SaveEpic(ctx, &domain.Epic{ID: "later", ProjectID: "proj-1", Name: "dup"}); !errors.Is(err, ErrEpicAlreadyExists) {
+		t.Errorf("expected ErrEpicAlreadyExists, got %v", err)
+	}
+
+	list, err := repo.ListEpics(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	want := []string{"a", "b", "later"}
+	if len(list) != len(want) {
+		t.Fatalf("expected %d epics, got %d", len(want), len(list))
+	}
+	for i, id := range want {
+		if list[i].ID != id {
+			t.Errorf("index %d: expected %s, got %s", i, id, list[i].ID)
+		}
+	}
+
+	// 取得したものを書き換えても保存内容は変わらない
+	list[0].Name = "changed"
+	got, err := repo.FindEpic(ctx, "proj-1", "a")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.Name != "先 A" {
+		t.Errorf("stored name must not change, got %s", got.Name)
+	}
+
+	got.Status = domain.EpicClosed
+	if err := repo.UpdateEpic(ctx, got); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	if got, _ := repo.FindEpic(ctx, "proj-1", "a"); got.Status != domain.EpicClosed {
+		t.Errorf("expected closed, got %s", got.Status)
+	}
+
+	if err := repo.DeleteEpic(ctx, "proj-1", "a"); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	if _, err := repo.FindEpic(ctx, "proj-1", "a"); !errors.Is(err, ErrEpicNotFound) {
+		t.Errorf("expected ErrEpicNotFound, got %v", err)
+	}
+	if err := repo.DeleteEpic(ctx, "proj-1", "a"); !errors.Is(err, ErrEpicNotFound) {
+		t.Errorf("expected ErrEpicNotFound on second delete, got %v", err)
+	}
+	if err := repo.UpdateEpic(ctx, &domain.Epic{ID: "missing", ProjectID: "proj-1"}); !errors.Is(err, ErrEpicNotFound) {
+		t.Errorf("expected ErrEpicNotFound, got %v", err)
+	}
+}
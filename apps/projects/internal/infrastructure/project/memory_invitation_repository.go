@@ -0,0 +1,100 @@
+package projectinfra
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// ErrInvitationNotFound は招待が存在しない（または取り消し済みの招待を取り消そうとした）場合のエラー。
+var ErrInvitationNotFound = usecase.ErrInvitationNotFound
+
+// MemoryInvitationRepository はメモリ上に招待を保持する InvitationRepository 実装。
+// 並行に呼び出してよい。保存時と取得時にコピーする。
+// プロジェクトの存在は確認しない（保存するユースケースはプロジェクトを取得した後に呼ぶ）。
+type MemoryInvitationRepository struct {
+	mu          sync.RWMutex
+	invitations map[string]map[string]*domain.Invitation // projectID -> invitationID -> 招待
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.InvitationRepository = (*MemoryInvitationRepository)(nil)
+
+// NewMemoryInvitationRepository は空のインメモリリポジトリを生成する。
+func NewMemoryInvitationRepository() *MemoryInvitationRepository {
+	return &MemoryInvitationRepository{
+		invitations: make(map[string]map[string]*domain.Invitation),
+	}
+}
+
+// SaveInvitation は招待を保存する。同じ ID がある場合は上書きする（ID は乱数で生成するため衝突しない）。
+func (r *MemoryInvitationRepository) SaveInvitation(_ context.Context, inv *domain.Invitation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	byID, ok := r.invitations[inv.ProjectID]
+	if !ok {
+		byID = make(map[string]*domain.Invitation)
+		r.invitations[inv.ProjectID] = byID
+	}
+	byID[inv.ID] = cloneInvitation(inv)
+	return nil
+}
+
+// FindInvitation は招待を取得する（取り消し済みを含む）。存在しない場合は ErrInvitationNotFound を返す。
+func (r *MemoryInvitationRepository) FindInvitation(_ context.Context, projectID, id string) (*domain.Invitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	inv, ok := r.invitations[projectID][id]
+	if !ok {
+		return nil, ErrInvitationNotFound
+	}
+	return cloneInvitation(inv), nil
+}
+
+// ListInvitations は取り消されていない招待を発行日時の新しい順（同時刻は ID 順）で返す。
+func (r *MemoryInvitationRepository) ListInvitations(_ context.Context, projectID string) ([]*domain.Invitation, error) {
+	r.mu.RLock()
+	out := make([]*domain.Invitation, 0, len(r.invitations[projectID]))
+	for _, inv := range r.invitations[projectID] {
+		if inv.RevokedAt == nil {
+			out = append(out, cloneInvitation(inv))
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(out, func(a, b *domain.Invitation) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return out, nil
+}
+
+// RevokeInvitation は招待に取り消し日時を設定する。
+// 存在しない、または既に取り消されている場合は ErrInvitationNotFound を返す。
+func (r *MemoryInvitationRepository) RevokeInvitation(_ context.Context, projectID, id string, revokedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	inv, ok := r.invitations[projectID][id]
+	if !ok || inv.RevokedAt != nil {
+		return ErrInvitationNotFound
+	}
+	inv.RevokedAt = &revokedAt
+	return nil
+}
+
+// cloneInvitation は inv のコピーを返す。
+func cloneInvitation(inv *domain.Invitation) *domain.Invitation {
+	c := *inv
+	if inv.RevokedAt != nil {
+		t := *inv.RevokedAt
+		c.RevokedAt = &t
+	}
+	return &c
+}
@@ -0,0 +1,76 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+func TestMemoryInvitationRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryInvitationRepository()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, inv := range []*domain.Invitation{
+		{ID: "i1", ProjectID: "proj-1", Role: domain.RoleMember, CreatedAt: now, ExpiresAt: now.Add(time.Hour)},
+		{ID: "i2", ProjectID: "proj-1", Role: domain.RoleAdmin, CreatedAt: now.Add(time.Minute), ExpiresAt: now.Add(time.Hour)},
+		{ID: "i3", ProjectID: "proj-1", Role: domain.RoleMember, CreatedAt: now, ExpiresAt: now.Add(time.Hour)},
+		{ID: "i1", ProjectID: "proj-2", Role: domain.RoleMember, CreatedAt: now, ExpiresAt: now.Add(time.Hour)},
+	} {
+		if err := repo.SaveInvitation(ctx, inv); err != nil {
+			t.Fatalf("failed to save %s: %v", inv.ID, err)
+		}
+	}
+
+	list, err := repo.ListInvitations(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	want := []string{"i2", "i1", "i3"} // 新しい順、同時刻は ID 順
+	if len(list) != len(want) {
+		t.Fatalf("expected %d invitations, got %d", len(want), len(list))
+	}
+	for i, id := range want {
+		if list[i].ID != id {
+			t.Errorf("index %d: expected %s, got %s", i, id, list[i].ID)
+		}
+	}
+
+	if err := repo.RevokeInvitation(ctx, "proj-1", "i1", now.Add(2*time.Minute)); err != nil {
+		t.Fatalf("failed to revoke: %v", err)
+	}
+	if err := repo.RevokeInvitation(ctx, "proj-1", "i1", now.Add(3*time.Minute)); !errors.Is(err, ErrInvitationNotFound) {
+		t.Errorf("expected ErrInvitationNotFound for already revoked, got %v", err)
+	}
+	if err := repo.RevokeInvitation(ctx, "proj-1", "missing", now); !errors.Is(err, ErrInvitationNotFound) {
+		t.Errorf("expected ErrInvitationNotFound, got %v", err)
+	}
+
+	// 取り消した招待は一覧に含まれないが、取得はできる
+	got, err := repo.FindInvitation(ctx, "proj-1", "i1")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.RevokedAt == nil || !got.RevokedAt.Equal(now.Add(2*time.Minute)) {
+		t.Errorf("unexpected revokedAt: %v", got.RevokedAt)
+	}
+	if list, _ := repo.ListInvitations(ctx, "proj-1"); len(list) != 2 {
+		t.Errorf("expected 2 outstanding invitations, got %d", len(list))
+	}
+	if other, _ := repo.FindInvitation(ctx, "proj-2", "i1"); other == nil || other.RevokedAt != nil {
+		t.Errorf("invitation in other project must not be revoked: %+v", other)
+	}
+
+	// 取得したものを書き換えても保存内容は変わらない
+	*got.RevokedAt = now
+	if again, _ := repo.FindInvitation(ctx, "proj-1", "i1"); !again.RevokedAt.Equal(now.Add(2 * time.Minute)) {
+		t.Errorf("stored revokedAt must not change, got %v", again.RevokedAt)
+	}
+
+	if _, err := repo.FindInvitation(ctx, "proj-1", "missing"); !errors.Is(err, ErrInvitationNotFound) {
+		t.Errorf("expected ErrInvitationNotFound, got %v", err)
+	}
+}
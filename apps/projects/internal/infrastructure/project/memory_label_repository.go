@@ -0,0 +1,130 @@
+package projectinfra
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// ErrLabelNotFound はラベルが存在しない場合のエラー。
+var ErrLabelNotFound = usecase.ErrLabelNotFound
+
+// ErrLabelAlreadyExists はプロジェクト内に同じ ID のラベルが既に存在する場合のエラー。
+var ErrLabelAlreadyExists = usecase.ErrLabelAlreadyExists
+
+// ErrLabelNameAlreadyExists はプロジェクト内に同じ名前のラベルが既に存在する場合のエラー。
+var ErrLabelNameAlreadyExists = usecase.ErrLabelNameAlreadyExists
+
+// MemoryLabelRepository はメモリ上にラベルを保持する LabelRepository 実装。
+// 並行に呼び出してよい。保存時と取得時にコピーする。
+// プロジェクトの存在は確認しない（保存するユースケースはプロジェクトを取得した後に呼ぶ）。
+type MemoryLabelRepository struct {
+	mu     sync.RWMutex
+	labels map[string]map[string]*domain.Label // projectID -> labelID -> ラベル
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.LabelRepository = (*MemoryLabelRepository)(nil)
+
+// NewMemoryLabelRepository は空のインメモリリポジトリを生成する。
+func NewMemoryLabelRepository() *MemoryLabelRepository {
+	return &MemoryLabelRepository{
+		labels: make(map[string]map[string]*domain.Label),
+	}
+}
+
+// SaveLabel はラベルを保存する。
+// プロジェクト内に同じ ID がある場合は ErrLabelAlreadyExists、同じ名前がある場合は ErrLabelNameAlreadyExists を返す。
+func (r *MemoryLabelRepository) SaveLabel(_ context.Context, l *domain.Label) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	byID, ok := r.labels[l.ProjectID]
+	if !ok {
+		byID = make(map[string]*domain.Label)
+		r.labels[l.ProjectID] = byID
+	}
+	if _, ok := byID[l.ID]; ok {
+		return ErrLabelAlreadyExists
+	}
+	if r.nameTaken(l) {
+		return ErrLabelNameAlreadyExists
+	}
+	byID[l.ID] = cloneLabel(l)
+	return nil
+}
+
+// UpdateLabel はラベルを更新する。
+// 存在しない場合は ErrLabelNotFound、名前が他のラベルと重複する場合は ErrLabelNameAlreadyExists を返す。
+func (r *MemoryLabelRepository) UpdateLabel(_ context.Context, l *domain.Label) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.labels[l.ProjectID][l.ID]; !ok {
+		return ErrLabelNotFound
+	}
+	if r.nameTaken(l) {
+		return ErrLabelNameAlreadyExists
+	}
+	r.labels[l.ProjectID][l.ID] = cloneLabel(l)
+	return nil
+}
+
+// nameTaken は l と同じプロジェクトに、l 以外で同じ名前（大文字・小文字は区別しない）のラベルがあるかどうかを返す。
+// 呼び出し側でロックを取得していること。
+func (r *MemoryLabelRepository) nameTaken(l *domain.Label) bool {
+	for id, other := range r.labels[l.ProjectID] {
+		if id != l.ID && strings.EqualFold(other.Name, l.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteLabel はラベルを削除する。存在しない場合は ErrLabelNotFound を返す。
+func (r *MemoryLabelRepository) DeleteLabel(_ context.Context, projectID, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.labels[projectID][id]; !ok {
+		return ErrLabelNotFound
+	}
+	delete(r.labels[projectID], id)
+	return nil
+}
+
+// FindLabel はラベルを取得する。存在しない場合は ErrLabelNotFound を返す。
+func (r *MemoryLabelRepository) FindLabel(_ context.Context, projectID, id string) (*domain.Label, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	l, ok := r.labels[projectID][id]
+	if !ok {
+		return nil, ErrLabelNotFound
+	}
+	return cloneLabel(l), nil
+}
+
+// ListLabels はラベルを名前（大文字・小文字は区別しない）・ID の昇順で返す。
+func (r *MemoryLabelRepository) ListLabels(_ context.Context, projectID string) ([]*domain.Label, error) {
+	r.mu.RLock()
+	out := make([]*domain.Label, 0, len(r.labels[projectID]))
+	for _, l := range r.labels[projectID] {
+		out = append(out, cloneLabel(l))
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(out, func(a, b *domain.Label) int {
+		if c := strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return out, nil
+}
+
+// cloneLabel は l のコピーを返す。
+func cloneLabel(l *domain.Label) *domain.Label {
+	c := *l
+	return &c
+}
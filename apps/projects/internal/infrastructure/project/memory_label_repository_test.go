@@ -0,0 +1,80 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+func TestMemoryLabelRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryLabelRepository()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, l := range []*domain.Label{
+		{ID: "l3", ProjectID: "proj-1", Name: "feature", Color: "#a2eeef", CreatedAt: now},
+		{ID: "l1", ProjectID: "proj-1", Name: "Bug", Color: "#d73a4a", CreatedAt: now.Add(time.Hour)},
+		{ID: "l2", ProjectID: "proj-1", Name: "docs", Color: "#0075ca", CreatedAt: now},
+		{ID: "l1", ProjectID: "proj-2", Name: "bug", Color: "#d73a4a", CreatedAt: now},
+	} {
+		if err := repo.SaveLabel(ctx, l); err != nil {
+			t.Fatalf("failed to save %s: %v", l.ID, err)
+		}
+	}
+	if err := repo.SaveLabel(ctx, &domain.Label{ID: "l1", ProjectID: "proj-1", Name: "dup"}); !errors.Is(err, ErrLabelAlreadyExists) {
+		t.Errorf("expected ErrLabelAlreadyExists, got %v", err)
+	}
+	if err := repo.SaveLabel(ctx, &domain.Label{ID: "l4", ProjectID: "proj-1", Name: "BUG"}); !errors.Is(err, ErrLabelNameAlreadyExists) {
+		t.Errorf("expected ErrLabelNameAlreadyExists, got %v", err)
+	}
+
+	list, err := repo.ListLabels(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	want := []string{"l1", "l2", "l3"} // Bug, docs, feature
+	if len(list) != len(want) {
+		t.Fatalf("expected %d labels, got %d", len(want), len(list))
+	}
+	for i, id := range want {
+		if list[i].ID != id {
+			t.Errorf("index %d: expected %s, got %s", i, id, list[i].ID)
+		}
+	}
+
+	// 取得したものを書き換えても保存内容は変わらない
+	list[0].Name = "changed"
+	got, err := repo.FindLabel(ctx, "proj-1", "l1")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.Name != "Bug" {
+		t.Errorf("stored name must not change, got %s", got.Name)
+	}
+
+	// 自身の名前の大文字・小文字を変えるのは重複ではない
+	got.Name = "bug"
+	if err := repo.UpdateLabel(ctx, got); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	got.Name = "Docs"
+	if err := repo.UpdateLabel(ctx, got); !errors.Is(err, ErrLabelNameAlreadyExists) {
+		t.Errorf("expected ErrLabelNameAlreadyExists, got %v", err)
+	}
+
+	if err := repo.DeleteLabel(ctx, "proj-1", "l1"); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	if _, err := repo.FindLabel(ctx, "proj-1", "l1"); !errors.Is(err, ErrLabelNotFound) {
+		t.Errorf("expected ErrLabelNotFound, got %v", err)
+	}
+	if err := repo.DeleteLabel(ctx, "proj-1", "l1"); !errors.Is(err, ErrLabelNotFound) {
+		t.Errorf("expected ErrLabelNotFound on second delete, got %v", err)
+	}
+	if err := repo.UpdateLabel(ctx, &domain.Label{ID: "missing", ProjectID: "proj-1"}); !errors.Is(err, ErrLabelNotFound) {
+		t.Errorf("expected ErrLabelNotFound, got %v", err)
+	}
+}
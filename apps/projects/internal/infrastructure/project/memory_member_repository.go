@@ -0,0 +1,110 @@
+package projectinfra
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+var (
+	// ErrMemberNotFound は指定したユーザーがプロジェクトのメンバーでない場合のエラー。
+	ErrMemberNotFound = usecase.ErrMemberNotFound
+	// ErrMemberAlreadyExists は既にメンバーとして参加しているユーザーを追加しようとした場合のエラー。
+	ErrMemberAlreadyExists = usecase.ErrMemberAlreadyExists
+)
+
+// memberKey はプロジェクト ID とユーザー ID の組。
+type memberKey struct {
+	projectID string
+	userID    string
+}
+
+// MemoryMemberRepository はメモリ上にプロジェクトメンバーを保持する MemberRepository 実装。
+// 並行に呼び出してよい。保存時と取得時にコピーする。
+type MemoryMemberRepository struct {
+	mu      sync.RWMutex
+	members map[memberKey]*domain.Member
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.MemberRepository = (*MemoryMemberRepository)(nil)
+
+// NewMemoryMemberRepository は空のインメモリリポジトリを生成する。
+func NewMemoryMemberRepository() *MemoryMemberRepository {
+	return &MemoryMemberRepository{
+		members: make(map[memberKey]*domain.Member),
+	}
+}
+
+// AddMember はメンバーを追加する。既に参加している場合は ErrMemberAlreadyExists を返す。
+func (r *MemoryMemberRepository) AddMember(_ context.Context, m *domain.Member) error {
+	key := memberKey{projectID: m.ProjectID, userID: m.UserID}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.members[key]; ok {
+		return ErrMemberAlreadyExists
+	}
+	stored := *m
+	r.members[key] = &stored
+	return nil
+}
+
+// RemoveMember はメンバーを削除する。参加していない場合は ErrMemberNotFound を返す。
+func (r *MemoryMemberRepository) RemoveMember(_ context.Context, projectID, userID string) error {
+	key := memberKey{projectID: projectID, userID: userID}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.members[key]; !ok {
+		return ErrMemberNotFound
+	}
+	delete(r.members, key)
+	return nil
+}
+
+// FindMember はメンバーを 1 件取得する。参加していない場合は ErrMemberNotFound を返す。
+func (r *MemoryMemberRepository) FindMember(_ context.Context, projectID, userID string) (*domain.Member, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	m, ok := r.members[memberKey{projectID: projectID, userID: userID}]
+	if !ok {
+		return nil, ErrMemberNotFound
+	}
+	c := *m
+	return &c, nil
+}
+
+// ListMembers はプロジェクトのメンバーを参加日時順（同時刻は userID 順）で返す。
+func (r *MemoryMemberRepository) ListMembers(_ context.Context, projectID string) ([]*domain.Member, error) {
+	r.mu.RLock()
+	out := make([]*domain.Member, 0)
+	for key, m := range r.members {
+		if key.projectID == projectID {
+			c := *m
+			out = append(out, &c)
+		}
+	}
+	r.mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].JoinedAt.Equal(out[j].JoinedAt) {
+			return out[i].JoinedAt.Before(out[j].JoinedAt)
+		}
+		return out[i].UserID < out[j].UserID
+	})
+	return out, nil
+}
+
+// ListProjectIDsByUser はユーザーがメンバーのプロジェクトの ID を返す（順不同）。
+func (r *MemoryMemberRepository) ListProjectIDsByUser(_ context.Context, userID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]string, 0)
+	for key := range r.members {
+		if key.userID == userID {
+			out = append(out, key.projectID)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,100 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+func TestMemoryMemberRepository(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	repo := NewMemoryMemberRepository()
+
+	for _, m := range []*domain.Member{
+		{ProjectID: "proj-1", UserID: "user-b", Role: domain.RoleMember, JoinedAt: base},
+		{ProjectID: "proj-1", UserID: "user-a", Role: domain.RoleOwner, JoinedAt: base},
+		{ProjectID: "proj-1", UserID: "user-c", Role: domain.RoleAdmin, JoinedAt: base.Add(-time.Hour)},
+		{ProjectID: "proj-2", UserID: "user-a", Role: domain.RoleMember, JoinedAt: base},
+	} {
+		if err := repo.AddMember(ctx, m); err != nil {
+			t.Fatalf("failed to add member: %v", err)
+		}
+	}
+
+	if err := repo.AddMember(ctx, &domain.Member{ProjectID: "proj-1", UserID: "user-a", Role: domain.RoleMember}); !errors.Is(err, ErrMemberAlreadyExists) {
+		t.Errorf("expected ErrMemberAlreadyExists, got %v", err)
+	}
+
+	got, err := repo.ListMembers(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("failed to list members: %v", err)
+	}
+	want := []string{"user-c", "user-a", "user-b"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d members, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i].UserID != want[i] {
+			t.Errorf("expected members[%d]=%s, got %s", i, want[i], got[i].UserID)
+		}
+	}
+
+	ids, err := repo.ListProjectIDsByUser(ctx, "user-a")
+	if err != nil {
+		t.Fatalf("failed to list projects of member: %v", err)
+	}
+	slices.Sort(ids)
+	if !slices.Equal(ids, []string{"proj-1", "proj-2"}) {
+		t.Errorf("unexpected project ids: %v", ids)
+	}
+
+	if err := repo.RemoveMember(ctx, "proj-1", "user-a"); err != nil {
+		t.Fatalf("failed to remove member: %v", err)
+	}
+	if _, err := repo.FindMember(ctx, "proj-1", "user-a"); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("expected ErrMemberNotFound, got %v", err)
+	}
+	if err := repo.RemoveMember(ctx, "proj-1", "user-a"); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("expected ErrMemberNotFound, got %v", err)
+	}
+	if _, err := repo.FindMember(ctx, "proj-2", "user-a"); err != nil {
+		t.Errorf("expected member in other project to remain, got %v", err)
+	}
+}
+
+// TestMemoryMemberRepository_Concurrent は並行な読み書きでデータ競合が起きないことを確認する（go test -race で検出）。
+func TestMemoryMemberRepository_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryMemberRepository()
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			userID := fmt.Sprintf("user-%d", i)
+			_ = repo.AddMember(ctx, &domain.Member{ProjectID: "proj-1", UserID: userID, Role: domain.RoleMember, JoinedAt: time.Now()})
+			if i%2 == 0 {
+				_ = repo.RemoveMember(ctx, "proj-1", userID)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = repo.FindMember(ctx, "proj-1", fmt.Sprintf("user-%d", i))
+			_, _ = repo.ListMembers(ctx, "proj-1")
+		}()
+	}
+	wg.Wait()
+
+	members, _ := repo.ListMembers(ctx, "proj-1")
+	if len(members) != 10 {
+		t.Fatalf("expected 10 members, got %d", len(members))
+	}
+}
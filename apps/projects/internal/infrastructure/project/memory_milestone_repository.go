@@ -0,0 +1,121 @@
+package projectinfra
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// ErrMilestoneNotFound はマイルストーンが存在しない場合のエラー。
+var ErrMilestoneNotFound = usecase.ErrMilestoneNotFound
+
+// ErrMilestoneAlreadyExists はプロジェクト内に同じ ID のマイルストーンが既に存在する場合のエラー。
+var ErrMilestoneAlreadyExists = usecase.ErrMilestoneAlreadyExists
+
+// MemoryMilestoneRepository はメモリ上にマイルストーンを保持する MilestoneRepository 実装。
+// 並行に呼び出してよい。保存時と取得時にコピーする。
+// プロジェクトの存在は確認しない（保存するユースケースはプロジェクトを取得した後に呼ぶ）。
+type MemoryMilestoneRepository struct {
+	mu         sync.RWMutex
+	milestones map[string]map[string]*domain.Milestone // projectID -> milestoneID -> マイルストーン
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.MilestoneRepository = (*MemoryMilestoneRepository)(nil)
+
+// NewMemoryMilestoneRepository は空のインメモリリポジトリを生成する。
+func NewMemoryMilestoneRepository() *MemoryMilestoneRepository {
+	return &MemoryMilestoneRepository{
+		milestones: make(map[string]map[string]*domain.Milestone),
+	}
+}
+
+// SaveMilestone はマイルストーンを保存する。プロジェクト内に同じ ID がある場合は ErrMilestoneAlreadyExists を返す。
+func (r *MemoryMilestoneRepository) SaveMilestone(_ context.Context, m *domain.Milestone) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	byID, ok := r.milestones[m.ProjectID]
+	if !ok {
+		byID = make(map[string]*domain.Milestone)
+		r.milestones[m.ProjectID] = byID
+	}
+	if _, ok := byID[m.ID]; ok {
+		return ErrMilestoneAlreadyExists
+	}
+	byID[m.ID] = cloneMilestone(m)
+	return nil
+}
+
+// UpdateMilestone はマイルストーンを更新する。存在しない場合は ErrMilestoneNotFound を返す。
+func (r *MemoryMilestoneRepository) UpdateMilestone(_ context.Context, m *domain.Milestone) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.milestones[m.ProjectID][m.ID]; !ok {
+		return ErrMilestoneNotFound
+	}
+	r.milestones[m.ProjectID][m.ID] = cloneMilestone(m)
+	return nil
+}
+
+// DeleteMilestone はマイルストーンを削除する。存在しない場合は ErrMilestoneNotFound を返す。
+func (r *MemoryMilestoneRepository) DeleteMilestone(_ context.Context, projectID, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.milestones[projectID][id]; !ok {
+		return ErrMilestoneNotFound
+	}
+	delete(r.milestones[projectID], id)
+	return nil
+}
+
+// FindMilestone はマイルストーンを取得する。存在しない場合は ErrMilestoneNotFound を返す。
+func (r *MemoryMilestoneRepository) FindMilestone(_ context.Context, projectID, id string) (*domain.Milestone, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	m, ok := r.milestones[projectID][id]
+	if !ok {
+		return nil, ErrMilestoneNotFound
+	}
+	return cloneMilestone(m), nil
+}
+
+// ListMilestones はマイルストーンを期限の昇順（期限なしは後ろ、同じ場合は作成日時・ID 順）で返す。
+func (r *MemoryMilestoneRepository) ListMilestones(_ context.Context, projectID string) ([]*domain.Milestone, error) {
+	r.mu.RLock()
+	out := make([]*domain.Milestone, 0, len(r.milestones[projectID]))
+	for _, m := range r.milestones[projectID] {
+		out = append(out, cloneMilestone(m))
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(out, func(a, b *domain.Milestone) int {
+		switch {
+		case a.DueDate != nil && b.DueDate != nil && !a.DueDate.Equal(*b.DueDate):
+			return a.DueDate.Compare(*b.DueDate)
+		case (a.DueDate == nil) != (b.DueDate == nil):
+			if a.DueDate == nil {
+				return 1
+			}
+			return -1
+		}
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return out, nil
+}
+
+// cloneMilestone は m のコピーを返す（DueDate も共有しない）。
+func cloneMilestone(m *domain.Milestone) *domain.Milestone {
+	c := *m
+	if m.DueDate != nil {
+		d := *m.DueDate
+		c.DueDate = &d
+	}
+	return &c
+}
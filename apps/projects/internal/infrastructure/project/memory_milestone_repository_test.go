@@ -0,0 +1,78 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+func TestMemoryMilestoneRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryMilestoneRepository()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	early := now.AddDate(0, 1, 0)
+	late := now.AddDate(0, 2, 0)
+
+	for _, m := range []*domain.Milestone{
+		{ID: "no-due", ProjectID: "proj-1", Name: "期限なし", Status: domain.MilestoneOpen, CreatedAt: now},
+		{ID: "late", ProjectID: "proj-1", Name: "後", DueDate: &late, Status: domain.MilestoneOpen, CreatedAt: now},
+		{ID: "early-b", ProjectID: "proj-1", Name: "先 B", DueDate: &early, Status: domain.MilestoneOpen, CreatedAt: now},
+		{ID: "early-a", ProjectID: "proj-1", Name: "先 A", DueDate: &early, Status: domain.MilestoneOpen, CreatedAt: now},
+		{ID: "late", ProjectID: "proj-2", Name: "別プロジェクト", Status: domain.MilestoneOpen, CreatedAt: now},
+	} {
+		if err := repo.SaveMilestone(ctx, m); err != nil {
+			t.Fatalf("failed to save %s: %v", m.ID, err)
+		}
+	}
+	if err := repo.SaveMilestone(ctx, &domain.Milestone{ID: "late", ProjectID: "proj-1", Name: "dup"}); !errors.Is(err, ErrMilestoneAlreadyExists) {
+		t.Errorf("expected ErrMilestoneAlreadyExists, got %v", err)
+	}
+
+	list, err := repo.ListMilestones(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	want := []string{"early-a", "early-b", "late", "no-due"}
+	if len(list) != len(want) {
+		t.Fatalf("expected %d milestones, got %d", len(want), len(list))
+	}
+	for i, id := range want {
+		if list[i].ID != id {
+			t.Errorf("index %d: expected %s, got %s", i, id, list[i].ID)
+		}
+	}
+
+	// 取得したものを書き換えても保存内容は変わらない
+	*list[0].DueDate = late
+	got, err := repo.FindMilestone(ctx, "proj-1", "early-a")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if !got.DueDate.Equal(early) {
+		t.Errorf("stored due date must not change, got %v", got.DueDate)
+	}
+
+	got.Status = domain.MilestoneClosed
+	if err := repo.UpdateMilestone(ctx, got); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	if got, _ := repo.FindMilestone(ctx, "proj-1", "early-a"); got.Status != domain.MilestoneClosed {
+		t.Errorf("expected closed, got %s", got.Status)
+	}
+
+	if err := repo.DeleteMilestone(ctx, "proj-1", "early-a"); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	if _, err := repo.FindMilestone(ctx, "proj-1", "early-a"); !errors.Is(err, ErrMilestoneNotFound) {
+		t.Errorf("expected ErrMilestoneNotFound, got %v", err)
+	}
+	if err := repo.DeleteMilestone(ctx, "proj-1", "early-a"); !errors.Is(err, ErrMilestoneNotFound) {
+		t.Errorf("expected ErrMilestoneNotFound on second delete, got %v", err)
+	}
+	if err := repo.UpdateMilestone(ctx, &domain.Milestone{ID: "missing", ProjectID: "proj-1"}); !errors.Is(err, ErrMilestoneNotFound) {
+		t.Errorf("expected ErrMilestoneNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package projectinfra
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// MemoryPreferenceRepository はメモリ上にユーザーごとのプロジェクトの表示設定を保持する PreferenceRepository 実装。
+// 並行に呼び出してよい。保存時と取得時にコピーする。
+// プロジェクトの存在は確認しない（ユースケースで確認する）。
+type MemoryPreferenceRepository struct {
+	mu    sync.RWMutex
+	prefs map[string]map[string]*domain.Preference // userID -> projectID -> 表示設定
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.PreferenceRepository = (*MemoryPreferenceRepository)(nil)
+
+// NewMemoryPreferenceRepository は空のインメモリリポジトリを生成する。
+func NewMemoryPreferenceRepository() *MemoryPreferenceRepository {
+	return &MemoryPreferenceRepository{
+		prefs: make(map[string]map[string]*domain.Preference),
+	}
+}
+
+// ListPreferences はユーザーの表示設定をすべて返す。
+func (r *MemoryPreferenceRepository) ListPreferences(_ context.Context, userID string) ([]*domain.Preference, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]*domain.Preference, 0, len(r.prefs[userID]))
+	for _, p := range r.prefs[userID] {
+		out = append(out, clonePreference(p))
+	}
+	return out, nil
+}
+
+// SetFavorite はお気に入りを設定・解除する。
+func (r *MemoryPreferenceRepository) SetFavorite(_ context.Context, userID, projectID string, favorite bool, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p := r.preference(userID, projectID)
+	p.Favorite = favorite
+	p.UpdatedAt = now
+	return nil
+}
+
+// ReplaceOrder はユーザーの並び順を projectIDs の順に置き換える。
+func (r *MemoryPreferenceRepository) ReplaceOrder(_ context.Context, userID string, projectIDs []string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range r.prefs[userID] {
+		if p.Position != nil {
+			p.Position = nil
+			p.UpdatedAt = now
+		}
+	}
+	for i, id := range projectIDs {
+		p := r.preference(userID, id)
+		position := i
+		p.Position = &position
+		p.UpdatedAt = now
+	}
+	return nil
+}
+
+// preference は userID / projectID の表示設定を返す。無ければ作成する。r.mu をロックして呼ぶこと。
+func (r *MemoryPreferenceRepository) preference(userID, projectID string) *domain.Preference {
+	byProject, ok := r.prefs[userID]
+	if !ok {
+		byProject = make(map[string]*domain.Preference)
+		r.prefs[userID] = byProject
+	}
+	p, ok := byProject[projectID]
+	if !ok {
+		p = &domain.Preference{UserID: userID, ProjectID: projectID}
+		byProject[projectID] = p
+	}
+	return p
+}
+
+// clonePreference は p のコピーを返す（Position も共有しない）。
+func clonePreference(p *domain.Preference) *domain.Preference {
+	c := *p
+	if p.Position != nil {
+		position := *p.Position
+		c.Position = &position
+	}
+	return &c
+}
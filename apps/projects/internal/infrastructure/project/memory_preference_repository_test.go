@@ -0,0 +1,58 @@
+package projectinfra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+func TestMemoryPreferenceRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryPreferenceRepository()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	if err := repo.SetFavorite(ctx, "user-1", "proj-1", true, now); err != nil {
+		t.Fatalf("failed to set favorite: %v", err)
+	}
+	if err := repo.ReplaceOrder(ctx, "user-1", []string{"proj-2", "proj-1"}, now); err != nil {
+		t.Fatalf("failed to replace order: %v", err)
+	}
+	// 並び順を置き換えると、含まれないプロジェクトの並び順は解除される（お気に入りはそのまま）
+	if err := repo.ReplaceOrder(ctx, "user-1", []string{"proj-3"}, now.Add(time.Hour)); err != nil {
+		t.Fatalf("failed to replace order: %v", err)
+	}
+
+	prefs, err := repo.ListPreferences(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("failed to list preferences: %v", err)
+	}
+	domain.SortPreferences(prefs)
+	if len(prefs) != 3 {
+		t.Fatalf("expected 3 preferences, got %d", len(prefs))
+	}
+	if p := prefs[0]; p.ProjectID != "proj-1" || !p.Favorite || p.Position != nil {
+		t.Errorf("unexpected preference: %+v", p)
+	}
+	if p := prefs[1]; p.ProjectID != "proj-3" || p.Position == nil || *p.Position != 0 {
+		t.Errorf("unexpected preference: %+v", p)
+	}
+	if p := prefs[2]; p.ProjectID != "proj-2" || !p.IsEmpty() {
+		t.Errorf("expected proj-2 to have no preference left, got %+v", p)
+	}
+
+	// 取得した値を変更してもリポジトリに影響しないこと
+	*prefs[1].Position = 99
+	again, _ := repo.ListPreferences(ctx, "user-1")
+	for _, p := range again {
+		if p.ProjectID == "proj-3" && *p.Position != 0 {
+			t.Errorf("expected stored position to be unchanged, got %d", *p.Position)
+		}
+	}
+
+	// 他のユーザーの表示設定は含まない
+	if others, _ := repo.ListPreferences(ctx, "user-2"); len(others) != 0 {
+		t.Errorf("expected no preferences for user-2, got %+v", others)
+	}
+}
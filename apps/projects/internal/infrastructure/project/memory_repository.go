@@ -2,7 +2,13 @@ package projectinfra
 
 import (
 	"context"
-	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"teamflow-shared/workspace"
 
 	domain "teamflow-projects/internal/domain/project"
 	usecase "teamflow-projects/internal/usecase/project"
@@ -10,15 +16,32 @@ import (
 
 // MemoryProjectRepository はメモリ上にプロジェクトを保持する
 // シンプルな ProjectRepository 実装。
+//
+// 並行に呼び出してよい。保存時と取得時にコピーするため、呼び出し側が
+// 返り値を変更しても Update するまで保存内容には反映されない（SQL 実装と同じ）。
+// SQL 実装と同じく context のワークスペースのプロジェクトだけを扱う。
 type MemoryProjectRepository struct {
+	mu       sync.RWMutex
 	projects map[string]*domain.Project
+	// processed はタスク数に反映したイベントの ID と処理した日時
+	processed map[string]time.Time
 }
 
 // コンパイル時にインターフェース実装を保証する。
-var _ usecase.ProjectRepository = (*MemoryProjectRepository)(nil)
+var (
+	_ usecase.ProjectRepository     = (*MemoryProjectRepository)(nil)
+	_ usecase.TaskCounterRepository = (*MemoryProjectRepository)(nil)
+	_ usecase.ProjectPurger         = (*MemoryProjectRepository)(nil)
+)
 
-// ErrProjectNotFound は指定した ID のプロジェクトが存在しない場合のエラー。
-var ErrProjectNotFound = errors.New("project not found")
+var (
+	// ErrProjectNotFound は指定した ID のプロジェクトが存在しない場合のエラー。
+	ErrProjectNotFound = usecase.ErrProjectNotFound
+	// ErrProjectKeyAlreadyExists は Key が他のプロジェクトと重複する場合のエラー。
+	ErrProjectKeyAlreadyExists = usecase.ErrProjectKeyAlreadyExists
+	// ErrProjectNameAlreadyExists は名前の一意制約が有効で、同じ名前のプロジェクトがある場合のエラー。
+	ErrProjectNameAlreadyExists = usecase.ErrProjectNameAlreadyExists
+)
 
 // NewMemoryProjectRepository は空のインメモリリポジトリを生成する。
 func NewMemoryProjectRepository() *MemoryProjectRepository {
@@ -27,32 +50,276 @@ func NewMemoryProjectRepository() *MemoryProjectRepository {
 	}
 }
 
-// Save はプロジェクトをメモリ上に保存する。
-func (r *MemoryProjectRepository) Save(_ context.Context, p *domain.Project) error {
+// Save はプロジェクトを context のワークスペースに保存し、p.WorkspaceID を設定する。
+// Key が同じワークスペースで重複する場合は ErrProjectKeyAlreadyExists を返す。
+func (r *MemoryProjectRepository) Save(ctx context.Context, p *domain.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	if r.projects == nil {
 		r.projects = make(map[string]*domain.Project)
 	}
-	r.projects[p.ID] = p
+	workspaceID := workspace.FromContext(ctx)
+	if r.keyTaken(workspaceID, p) {
+		return ErrProjectKeyAlreadyExists
+	}
+	p.WorkspaceID = workspaceID
+	r.projects[p.ID] = cloneProject(p)
 	return nil
 }
 
-// FindByID は ID を指定してプロジェクトを取得する。
-func (r *MemoryProjectRepository) FindByID(_ context.Context, id string) (*domain.Project, error) {
-	if r.projects == nil {
+// Update は既存プロジェクト（削除されたプロジェクトを含む）を更新する。存在しない場合は ErrProjectNotFound、
+// Key が重複する場合は ErrProjectKeyAlreadyExists を返す。タスク数（OpenTasks / DoneTasks）は ApplyTaskCounts でのみ更新する。
+func (r *MemoryProjectRepository) Update(ctx context.Context, p *domain.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.projects[p.ID]
+	if !ok || !inWorkspace(ctx, stored) {
+		return ErrProjectNotFound
+	}
+	if r.keyTaken(stored.WorkspaceID, p) {
+		return ErrProjectKeyAlreadyExists
+	}
+	c := cloneProject(p)
+	c.WorkspaceID = stored.WorkspaceID
+	c.OpenTasks, c.DoneTasks = stored.OpenTasks, stored.DoneTasks
+	r.projects[p.ID] = c
+	return nil
+}
+
+// ApplyTaskCounts は eventID を処理済みとして記録し、プロジェクトのタスク数に open / done を加える。
+// eventID が処理済みの場合は何もしない。
+func (r *MemoryProjectRepository) ApplyTaskCounts(ctx context.Context, eventID, projectID string, open, done int, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.processed[eventID]; ok {
+		return nil
+	}
+	if r.processed == nil {
+		r.processed = make(map[string]time.Time)
+	}
+	r.processed[eventID] = now
+	if p, ok := r.projects[projectID]; ok && inWorkspace(ctx, p) {
+		p.OpenTasks += open
+		p.DoneTasks += done
+	}
+	return nil
+}
+
+// DeleteProcessedEvents は before より前に処理したイベントの記録を削除する。
+func (r *MemoryProjectRepository) DeleteProcessedEvents(_ context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var n int64
+	for id, at := range r.processed {
+		if at.Before(before) {
+			delete(r.processed, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// PurgeCandidates は before より前にアーカイブ、または削除されたプロジェクトのコピーを、アーカイブ・削除の早い方の日時と ID の順に
+// 最大 limit 件返す。すべてのワークスペースのプロジェクトを対象にする（SQL 実装と同じ）。
+func (r *MemoryProjectRepository) PurgeCandidates(_ context.Context, before time.Time, limit int) ([]*domain.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]*domain.Project, 0)
+	for _, p := range r.projects {
+		if at := purgeableSince(p); at != nil && at.Before(before) {
+			out = append(out, cloneProject(p))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := purgeableSince(out[i]), purgeableSince(out[j])
+		if !a.Equal(*b) {
+			return a.Before(*b)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out[:min(len(out), limit)], nil
+}
+
+// HardDelete は id のプロジェクトを削除する。ワークスペースでは絞り込まない。存在しない場合は ErrProjectNotFound を返す。
+// インメモリの実装ではメンバー・設定などは別のリポジトリにあるため削除しない。
+func (r *MemoryProjectRepository) HardDelete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.projects[id]; !ok {
+		return ErrProjectNotFound
+	}
+	delete(r.projects, id)
+	return nil
+}
+
+// purgeableSince はアーカイブ・削除の早い方の日時を返す（SQL の LEAST(archived_at, deleted_at)）。どちらも無い場合は nil。
+func purgeableSince(p *domain.Project) *time.Time {
+	switch {
+	case p.ArchivedAt == nil:
+		return p.DeletedAt
+	case p.DeletedAt == nil || p.ArchivedAt.Before(*p.DeletedAt):
+		return p.ArchivedAt
+	default:
+		return p.DeletedAt
+	}
+}
+
+// keyTaken は p の Key が workspaceID の他のプロジェクトで使われているかどうかを返す（SQL の一意インデックスに相当）。
+// 削除されたプロジェクトの Key も使用中として扱う。呼び出し側で r.mu を取得しておくこと。
+func (r *MemoryProjectRepository) keyTaken(workspaceID string, p *domain.Project) bool {
+	if p.Key == "" {
+		return false
+	}
+	for _, other := range r.projects {
+		if other.ID != p.ID && other.WorkspaceID == workspaceID && other.Key == p.Key {
+			return true
+		}
+	}
+	return false
+}
+
+// FindByID は ID を指定してプロジェクトを取得する。削除されたプロジェクトは ErrProjectNotFound を返す。
+func (r *MemoryProjectRepository) FindByID(ctx context.Context, id string) (*domain.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.projects[id]
+	if !ok || !inWorkspace(ctx, p) || p.IsDeleted() {
 		return nil, ErrProjectNotFound
 	}
+	return cloneProject(p), nil
+}
+
+// FindDeleted は ID を指定して削除されたプロジェクトを取得する。
+// 存在しない、または削除されていない場合は ErrProjectNotFound を返す。
+func (r *MemoryProjectRepository) FindDeleted(ctx context.Context, id string) (*domain.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	p, ok := r.projects[id]
-	if !ok {
+	if !ok || !inWorkspace(ctx, p) || !p.IsDeleted() {
 		return nil, ErrProjectNotFound
 	}
-	return p, nil
+	return cloneProject(p), nil
 }
 
-// List はすべてのプロジェクトを返す。
-func (r *MemoryProjectRepository) List(_ context.Context) ([]*domain.Project, error) {
+// FindByName は名前が一致する（前後の空白と大文字小文字を無視する）最も古いプロジェクトを取得する。
+func (r *MemoryProjectRepository) FindByName(ctx context.Context, name string) (*domain.Project, error) {
+	name = strings.TrimSpace(name)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var found *domain.Project
+	for _, p := range r.projects {
+		if p.IsDeleted() || !inWorkspace(ctx, p) || !strings.EqualFold(strings.TrimSpace(p.Name), name) {
+			continue
+		}
+		if found == nil || p.CreatedAt.Before(found.CreatedAt) || (p.CreatedAt.Equal(found.CreatedAt) && p.ID < found.ID) {
+			found = p
+		}
+	}
+	if found == nil {
+		return nil, ErrProjectNotFound
+	}
+	return cloneProject(found), nil
+}
+
+// List はワークスペースの削除されていないすべてのプロジェクトを返す。
+func (r *MemoryProjectRepository) List(ctx context.Context) ([]*domain.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	out := make([]*domain.Project, 0, len(r.projects))
 	for _, p := range r.projects {
-		out = append(out, p)
+		if p.IsDeleted() || !inWorkspace(ctx, p) {
+			continue
+		}
+		out = append(out, cloneProject(p))
+	}
+	return out, nil
+}
+
+// FindWithQuery は Query Object に基づいてプロジェクトを取得する。
+// SQL 実装と同じく、ソートキーが同じ場合は ID で同じ向きに並べ、limit + 1 件まで返す。
+func (r *MemoryProjectRepository) FindWithQuery(ctx context.Context, query *domain.ProjectQuery) ([]*domain.Project, error) {
+	s := query.EffectiveSort()
+
+	r.mu.RLock()
+	out := make([]*domain.Project, 0)
+	for _, p := range r.projects {
+		if !inWorkspace(ctx, p) || !matches(p, query, s) {
+			continue
+		}
+		out = append(out, cloneProject(p))
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		return compareProjects(out[i], out[j], s) < 0
+	})
+
+	if len(out) > query.Limit+1 {
+		out = out[:query.Limit+1]
 	}
 	return out, nil
 }
+
+// inWorkspace は p が context のワークスペースのプロジェクトかどうかを返す。
+func inWorkspace(ctx context.Context, p *domain.Project) bool {
+	return p.WorkspaceID == workspace.FromContext(ctx)
+}
+
+// cloneProject は p のコピーを返す（ArchivedAt / DeletedAt も共有しない）。
+func cloneProject(p *domain.Project) *domain.Project {
+	c := *p
+	if p.ArchivedAt != nil {
+		t := *p.ArchivedAt
+		c.ArchivedAt = &t
+	}
+	if p.DeletedAt != nil {
+		t := *p.DeletedAt
+		c.DeletedAt = &t
+	}
+	return &c
+}
+
+// matches は p が削除されておらず、フィルタ条件と cursor の seek 条件を満たすかどうかを返す。
+func matches(p *domain.Project, query *domain.ProjectQuery, s domain.ProjectSort) bool {
+	if p.IsDeleted() {
+		return false
+	}
+	if query.Query != nil && !strings.Contains(strings.ToLower(p.Name), strings.ToLower(*query.Query)) {
+		return false
+	}
+	if query.Archived != nil && p.IsArchived() != *query.Archived {
+		return false
+	}
+	if len(query.Statuses) > 0 && !slices.Contains(query.Statuses, p.Status) {
+		return false
+	}
+	if query.ReadableOnly && p.Visibility != domain.VisibilityPublic && !slices.Contains(query.MemberProjectIDs, p.ID) {
+		return false
+	}
+	if c := query.Cursor; c != nil {
+		pos := &domain.Project{ID: c.ID, Name: c.Name, CreatedAt: c.CreatedAt}
+		if compareProjects(p, pos, s) <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// compareProjects は s の順で a と b を比較する（同じ値は ID で比較する）。
+// name はバイト順で比較する（SQL 実装の COLLATE "C" と一致させる）。
+func compareProjects(a, b *domain.Project, s domain.ProjectSort) int {
+	var c int
+	switch s.Key {
+	case domain.SortKeyName:
+		c = strings.Compare(a.Name, b.Name)
+	default:
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	}
+	if c == 0 {
+		c = strings.Compare(a.ID, b.ID)
+	}
+	if s.Direction == domain.SortDirectionDESC {
+		c = -c
+	}
+	return c
+}
@@ -2,9 +2,16 @@ package projectinfra
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"teamflow-shared/workspace"
+
+	domain "teamflow-projects/internal/domain/project"
 	usecase "teamflow-projects/internal/usecase/project"
 )
 
@@ -40,7 +47,333 @@ func TestMemoryProjectRepository_SaveStoresProject(t *testing.T) {
 		t.Fatalf("expected project with ID=%s to be stored", p.ID)
 	}
 
-	if stored != p {
-		t.Fatalf("expected stored project pointer to equal returned project")
+	// 呼び出し側とポインタを共有しない（保存時にコピーする）
+	if stored == p {
+		t.Fatalf("expected stored project to be a copy of the saved project")
+	}
+	if *stored != *p {
+		t.Fatalf("expected stored project %+v to equal saved project %+v", stored, p)
+	}
+}
+
+func TestMemoryProjectRepository_UpdateNotFound(t *testing.T) {
+	repo := NewMemoryProjectRepository()
+
+	p, err := domain.NewProject("proj-1", "TeamFlow 開発", "", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := repo.Update(context.Background(), p); !errors.Is(err, ErrProjectNotFound) {
+		t.Fatalf("expected ErrProjectNotFound, got %v", err)
+	}
+
+	if _, ok := repo.projects[p.ID]; ok {
+		t.Fatalf("expected project not to be stored by Update")
+	}
+}
+
+func TestMemoryProjectRepository_KeyUnique(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryProjectRepository()
+
+	newProject := func(id, key string) *domain.Project {
+		p, err := domain.NewProject(id, "TeamFlow 開発", "", time.Now())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Key = key
+		return p
+	}
+
+	if err := repo.Save(ctx, newProject("proj-1", "TFLOW")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// キー未設定のプロジェクトは重複扱いしない
+	if err := repo.Save(ctx, newProject("proj-2", "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.Save(ctx, newProject("proj-3", "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := repo.Save(ctx, newProject("proj-4", "TFLOW")); !errors.Is(err, ErrProjectKeyAlreadyExists) {
+		t.Fatalf("expected ErrProjectKeyAlreadyExists on Save, got %v", err)
+	}
+	if err := repo.Update(ctx, newProject("proj-2", "TFLOW")); !errors.Is(err, ErrProjectKeyAlreadyExists) {
+		t.Fatalf("expected ErrProjectKeyAlreadyExists on Update, got %v", err)
+	}
+	// 自分自身のキーのままの更新は重複ではない
+	if err := repo.Update(ctx, newProject("proj-1", "TFLOW")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMemoryProjectRepository_FindByName(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryProjectRepository()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		id, name  string
+		createdAt time.Time
+	}{
+		{"proj-2", "TeamFlow", now.Add(time.Hour)},
+		{"proj-1", "teamflow ", now},
+		{"proj-3", "Other", now},
+	} {
+		p, err := domain.NewProject(tc.id, tc.name, "", tc.createdAt)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := repo.Save(ctx, p); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// 大文字小文字と前後の空白は区別せず、最も古いものを返す
+	got, err := repo.FindByName(ctx, " TEAMFLOW")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != "proj-1" {
+		t.Errorf("expected proj-1, got %s", got.ID)
+	}
+
+	if _, err := repo.FindByName(ctx, "TeamFlow 2"); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+}
+
+func TestMemoryProjectRepository_DefensiveCopy(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryProjectRepository()
+
+	p, _ := domain.NewProject("proj-1", "TeamFlow", "", time.Now())
+	if err := repo.Save(ctx, p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 保存後に呼び出し側のオブジェクトを変更しても保存内容は変わらない
+	p.Name = "changed"
+	got, _ := repo.FindByID(ctx, "proj-1")
+	if got.Name != "TeamFlow" {
+		t.Fatalf("expected stored name to be unchanged, got %q", got.Name)
 	}
+
+	// 取得したオブジェクトを変更しても Update するまで反映されない
+	archivedAt := time.Now()
+	got.ArchivedAt = &archivedAt
+	again, _ := repo.FindByID(ctx, "proj-1")
+	if again.IsArchived() {
+		t.Fatal("expected stored project not to be archived before Update")
+	}
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	again, _ = repo.FindByID(ctx, "proj-1")
+	if !again.IsArchived() || again.ArchivedAt == got.ArchivedAt {
+		t.Fatalf("expected archived copy, got %+v", again)
+	}
+}
+
+// TestMemoryProjectRepository_Concurrent は並行な読み書きでデータ競合が起きないことを確認する（go test -race で検出）。
+func TestMemoryProjectRepository_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryProjectRepository()
+	now := time.Now()
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			p, _ := domain.NewProject(fmt.Sprintf("proj-%d", i), fmt.Sprintf("Project %d", i), "", now)
+			p.Key = fmt.Sprintf("P%d", i)
+			if err := repo.Save(ctx, p); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			p.Description = "updated"
+			if err := repo.Update(ctx, p); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = repo.FindByID(ctx, fmt.Sprintf("proj-%d", i))
+			_, _ = repo.FindByName(ctx, "Project 0")
+			_, _ = repo.List(ctx)
+			q, _ := domain.NewProjectQuery(domain.WithLimit(200))
+			_, _ = repo.FindWithQuery(ctx, q)
+		}()
+	}
+	wg.Wait()
+
+	list, _ := repo.List(ctx)
+	if len(list) != 20 {
+		t.Fatalf("expected 20 projects, got %d", len(list))
+	}
+}
+
+func TestMemoryProjectRepository_Workspace(t *testing.T) {
+	repo := NewMemoryProjectRepository()
+	ws1 := workspace.NewContext(context.Background(), "ws-1")
+	ws2 := workspace.NewContext(context.Background(), "ws-2")
+
+	newProject := func(id, key string) *domain.Project {
+		p, err := domain.NewProject(id, "TeamFlow 開発", "", time.Now())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Key = key
+		return p
+	}
+
+	p := newProject("proj-1", "TFLOW")
+	if err := repo.Save(ws1, p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.WorkspaceID != "ws-1" {
+		t.Errorf("WorkspaceID = %q, want ws-1", p.WorkspaceID)
+	}
+	// キーはワークスペース内で一意（別のワークスペースでは同じキーを使える）
+	if err := repo.Save(ws2, newProject("proj-2", "TFLOW")); err != nil {
+		t.Fatalf("expected the same key in another workspace to be allowed, got %v", err)
+	}
+
+	// 別のワークスペースからは見えず、更新もできない
+	if _, err := repo.FindByID(ws2, "proj-1"); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("FindByID from ws-2: expected ErrProjectNotFound, got %v", err)
+	}
+	if got, err := repo.FindByName(ws2, "TeamFlow 開発"); err != nil || got.ID != "proj-2" {
+		t.Errorf("FindByName from ws-2 = %v, %v, want proj-2", got, err)
+	}
+	if err := repo.Update(ws2, p); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("Update from ws-2: expected ErrProjectNotFound, got %v", err)
+	}
+	list, _ := repo.List(ws1)
+	if len(list) != 1 || list[0].ID != "proj-1" {
+		t.Errorf("List from ws-1 = %v, want [proj-1]", list)
+	}
+	query, _ := domain.NewProjectQuery()
+	found, _ := repo.FindWithQuery(ws2, query)
+	if len(found) != 1 || found[0].ID != "proj-2" {
+		t.Errorf("FindWithQuery from ws-2 = %v, want [proj-2]", found)
+	}
+}
+
+func TestMemoryProjectRepository_ApplyTaskCounts(t *testing.T) {
+	repo := NewMemoryProjectRepository()
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	p, _ := domain.NewProject("proj-1", "TeamFlow", "", now)
+	if err := repo.Save(ctx, p); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	_ = repo.ApplyTaskCounts(ctx, "e-1", "proj-1", 1, 0, now)
+	_ = repo.ApplyTaskCounts(ctx, "e-1", "proj-1", 1, 0, now) // 重複して届いたイベントは数えない
+	_ = repo.ApplyTaskCounts(ctx, "e-2", "proj-1", -1, 1, now.Add(time.Hour))
+	_ = repo.ApplyTaskCounts(workspace.NewContext(ctx, "other"), "e-3", "proj-1", 1, 0, now)
+
+	// 取得した時点のタスク数で Update しても、その後に反映したタスク数を上書きしない
+	stale, _ := repo.FindByID(ctx, "proj-1")
+	_ = repo.ApplyTaskCounts(ctx, "e-4", "proj-1", 1, 0, now)
+	stale.Name = "Renamed"
+	if err := repo.Update(ctx, stale); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+
+	got, _ := repo.FindByID(ctx, "proj-1")
+	if got.OpenTasks != 1 || got.DoneTasks != 1 {
+		t.Errorf("expected counts (1, 1), got (%d, %d)", got.OpenTasks, got.DoneTasks)
+	}
+
+	if n, err := repo.DeleteProcessedEvents(ctx, now.Add(time.Minute)); err != nil || n != 3 {
+		t.Errorf("DeleteProcessedEvents() = %d, %v, want 3", n, err)
+	}
+}
+
+// purgeRepository は物理削除の共通シナリオ（testPurgeCandidates）を実行するリポジトリ。
+type purgeRepository interface {
+	usecase.ProjectRepository
+	usecase.ProjectPurger
+}
+
+// testPurgeCandidates は保持期間を過ぎたプロジェクトの取り出しと物理削除を確認する（メモリ・SQL 実装で共通）。
+func testPurgeCandidates(t *testing.T, repo purgeRepository) {
+	t.Helper()
+	now := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	daysAgo := func(n int) *time.Time {
+		at := now.AddDate(0, 0, -n)
+		return &at
+	}
+	ws1 := workspace.NewContext(context.Background(), "ws-1")
+	ws2 := workspace.NewContext(context.Background(), "ws-2")
+	for _, tc := range []struct {
+		ctx       context.Context
+		id        string
+		archived  *time.Time
+		deleted   *time.Time
+		policyDel bool
+	}{
+		{ctx: ws1, id: "archived-100", archived: daysAgo(100)},
+		{ctx: ws2, id: "deleted-120", deleted: daysAgo(120), policyDel: true},
+		{ctx: ws1, id: "archived-200-deleted-10", archived: daysAgo(200), deleted: daysAgo(10), policyDel: true},
+		{ctx: ws1, id: "archived-10", archived: daysAgo(10)},
+		{ctx: ws2, id: "active"},
+	} {
+		p, err := domain.NewProject(tc.id, tc.id, "", now.AddDate(-1, 0, 0))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.ArchivedAt, p.DeletedAt = tc.archived, tc.deleted
+		if tc.policyDel {
+			p.DeletePolicy = domain.DeletePolicyDeleteTasks
+		}
+		if err := repo.Save(tc.ctx, p); err != nil {
+			t.Fatalf("failed to save %s: %v", tc.id, err)
+		}
+	}
+
+	// ワークスペースをまたいで、アーカイブ・削除の早い方の日時の順に返す
+	got, err := repo.PurgeCandidates(context.Background(), now.AddDate(0, 0, -90), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids := projectIDs(got); ids != "archived-200-deleted-10,deleted-120,archived-100" {
+		t.Errorf("PurgeCandidates() = %s", ids)
+	}
+	if got[1].WorkspaceID != "ws-2" {
+		t.Errorf("expected the candidate with its workspace, got %q", got[1].WorkspaceID)
+	}
+	if got, _ := repo.PurgeCandidates(context.Background(), now.AddDate(0, 0, -90), 1); projectIDs(got) != "archived-200-deleted-10" {
+		t.Errorf("PurgeCandidates() with limit 1 = %s", projectIDs(got))
+	}
+
+	if err := repo.HardDelete(context.Background(), "deleted-120"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.HardDelete(context.Background(), "deleted-120"); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound for a purged project, got %v", err)
+	}
+	if _, err := repo.FindDeleted(ws2, "deleted-120"); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected the purged project not to be found, got %v", err)
+	}
+	if _, err := repo.FindByID(ws2, "active"); err != nil {
+		t.Errorf("expected the active project to remain, got %v", err)
+	}
+}
+
+func projectIDs(projects []*domain.Project) string {
+	ids := make([]string, len(projects))
+	for i, p := range projects {
+		ids[i] = p.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestMemoryProjectRepository_Purge(t *testing.T) {
+	testPurgeCandidates(t, NewMemoryProjectRepository())
 }
@@ -0,0 +1,63 @@
+package projectinfra
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// ErrSettingsNotFound はプロジェクト設定が保存されていない場合のエラー。
+var ErrSettingsNotFound = usecase.ErrSettingsNotFound
+
+// MemorySettingsRepository はメモリ上にプロジェクト設定を保持する SettingsRepository 実装。
+// 並行に呼び出してよい。保存時と取得時にコピーする。
+type MemorySettingsRepository struct {
+	mu       sync.RWMutex
+	settings map[string]*domain.Settings
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.SettingsRepository = (*MemorySettingsRepository)(nil)
+
+// NewMemorySettingsRepository は空のインメモリリポジトリを生成する。
+func NewMemorySettingsRepository() *MemorySettingsRepository {
+	return &MemorySettingsRepository{
+		settings: make(map[string]*domain.Settings),
+	}
+}
+
+// FindSettings は設定を取得する。保存されていない場合は ErrSettingsNotFound を返す。
+func (r *MemorySettingsRepository) FindSettings(_ context.Context, projectID string) (*domain.Settings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.settings[projectID]
+	if !ok {
+		return nil, ErrSettingsNotFound
+	}
+	return cloneSettings(s), nil
+}
+
+// SaveSettings は設定を保存する。WIPLimits / FieldLocks は呼び出し側の map と共有しないようコピーする。
+func (r *MemorySettingsRepository) SaveSettings(_ context.Context, s *domain.Settings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.settings[s.ProjectID] = cloneSettings(s)
+	return nil
+}
+
+// cloneSettings は s のコピーを返す（WIPLimits / FieldLocks も共有しない）。
+func cloneSettings(s *domain.Settings) *domain.Settings {
+	c := *s
+	c.WIPLimits = maps.Clone(s.WIPLimits)
+	if s.FieldLocks != nil {
+		c.FieldLocks = make(map[string][]domain.MemberRole, len(s.FieldLocks))
+		for field, roles := range s.FieldLocks {
+			c.FieldLocks[field] = slices.Clone(roles)
+		}
+	}
+	return &c
+}
@@ -0,0 +1,35 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+func TestMemorySettingsRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemorySettingsRepository()
+
+	if _, err := repo.FindSettings(ctx, "proj-1"); !errors.Is(err, ErrSettingsNotFound) {
+		t.Fatalf("expected ErrSettingsNotFound, got %v", err)
+	}
+
+	limits := map[string]int{"in_progress": 3}
+	locks := map[string][]domain.MemberRole{"priority": {domain.RoleAdmin}}
+	if err := repo.SaveSettings(ctx, &domain.Settings{ProjectID: "proj-1", DefaultPriority: "high", WIPLimits: limits, FieldLocks: locks}); err != nil {
+		t.Fatalf("failed to save settings: %v", err)
+	}
+	// 保存後に呼び出し側の map を変更しても影響しないこと
+	limits["in_progress"] = 99
+	locks["priority"][0] = domain.RoleMember
+
+	got, err := repo.FindSettings(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("failed to find settings: %v", err)
+	}
+	if got.DefaultPriority != "high" || got.WIPLimits["in_progress"] != 3 || got.FieldLocks["priority"][0] != domain.RoleAdmin {
+		t.Errorf("unexpected settings: %+v", got)
+	}
+}
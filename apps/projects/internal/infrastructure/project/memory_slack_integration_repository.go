@@ -0,0 +1,67 @@
+package projectinfra
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// ErrSlackIntegrationNotFound はプロジェクトに Slack 連携の設定が無い場合のエラー。
+var ErrSlackIntegrationNotFound = usecase.ErrSlackIntegrationNotFound
+
+// MemorySlackIntegrationRepository はメモリ上に Slack 連携の設定を保持する SlackIntegrationRepository 実装。
+// 並行に呼び出してよい。保存時と取得時にコピーする。
+type MemorySlackIntegrationRepository struct {
+	mu           sync.RWMutex
+	integrations map[string]*domain.SlackIntegration
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.SlackIntegrationRepository = (*MemorySlackIntegrationRepository)(nil)
+
+// NewMemorySlackIntegrationRepository は空のインメモリリポジトリを生成する。
+func NewMemorySlackIntegrationRepository() *MemorySlackIntegrationRepository {
+	return &MemorySlackIntegrationRepository{
+		integrations: make(map[string]*domain.SlackIntegration),
+	}
+}
+
+// FindSlackIntegration は設定を取得する。保存されていない場合は ErrSlackIntegrationNotFound を返す。
+func (r *MemorySlackIntegrationRepository) FindSlackIntegration(_ context.Context, projectID string) (*domain.SlackIntegration, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.integrations[projectID]
+	if !ok {
+		return nil, ErrSlackIntegrationNotFound
+	}
+	return cloneSlackIntegration(s), nil
+}
+
+// SaveSlackIntegration は設定を保存する（既存の設定は置き換える）。
+func (r *MemorySlackIntegrationRepository) SaveSlackIntegration(_ context.Context, s *domain.SlackIntegration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.integrations[s.ProjectID] = cloneSlackIntegration(s)
+	return nil
+}
+
+// DeleteSlackIntegration は設定を削除する。保存されていない場合は ErrSlackIntegrationNotFound を返す。
+func (r *MemorySlackIntegrationRepository) DeleteSlackIntegration(_ context.Context, projectID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.integrations[projectID]; !ok {
+		return ErrSlackIntegrationNotFound
+	}
+	delete(r.integrations, projectID)
+	return nil
+}
+
+// cloneSlackIntegration は s のコピーを返す（Events も共有しない）。
+func cloneSlackIntegration(s *domain.SlackIntegration) *domain.SlackIntegration {
+	c := *s
+	c.Events = slices.Clone(s.Events)
+	return &c
+}
@@ -0,0 +1,40 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+func TestMemorySlackIntegrationRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemorySlackIntegrationRepository()
+
+	if _, err := repo.FindSlackIntegration(ctx, "proj-1"); !errors.Is(err, ErrSlackIntegrationNotFound) {
+		t.Fatalf("expected ErrSlackIntegrationNotFound, got %v", err)
+	}
+
+	evs := []domain.SlackEvent{domain.SlackEventTaskCreated}
+	if err := repo.SaveSlackIntegration(ctx, &domain.SlackIntegration{ProjectID: "proj-1", WebhookURL: "https://hooks.slack.com/services/T0", Events: evs}); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	// 保存後に呼び出し側の slice を変更しても影響しないこと
+	evs[0] = domain.SlackEventTaskAssigned
+
+	got, err := repo.FindSlackIntegration(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.WebhookURL != "https://hooks.slack.com/services/T0" || len(got.Events) != 1 || got.Events[0] != domain.SlackEventTaskCreated {
+		t.Errorf("unexpected integration: %+v", got)
+	}
+
+	if err := repo.DeleteSlackIntegration(ctx, "proj-1"); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	if err := repo.DeleteSlackIntegration(ctx, "proj-1"); !errors.Is(err, ErrSlackIntegrationNotFound) {
+		t.Errorf("expected ErrSlackIntegrationNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package projectinfra
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// ErrSprintNotFound はスプリントが存在しない場合のエラー。
+var ErrSprintNotFound = usecase.ErrSprintNotFound
+
+// ErrSprintAlreadyExists はプロジェクト内に同じ ID のスプリントが既に存在する場合のエラー。
+var ErrSprintAlreadyExists = usecase.ErrSprintAlreadyExists
+
+// MemorySprintRepository はメモリ上にスプリントを保持する SprintRepository 実装。
+// 並行に呼び出してよい。保存時と取得時にコピーする。
+// プロジェクトの存在は確認しない（保存するユースケースはプロジェクトを取得した後に呼ぶ）。
+type MemorySprintRepository struct {
+	mu      sync.RWMutex
+	sprints map[string]map[string]*domain.Sprint // projectID -> sprintID -> スプリント
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.SprintRepository = (*MemorySprintRepository)(nil)
+
+// NewMemorySprintRepository は空のインメモリリポジトリを生成する。
+func NewMemorySprintRepository() *MemorySprintRepository {
+	return &MemorySprintRepository{
+		sprints: make(map[string]map[string]*domain.Sprint),
+	}
+}
+
+// SaveSprint はスプリントを保存する。プロジェクト内に同じ ID がある場合は ErrSprintAlreadyExists を返す。
+func (r *MemorySprintRepository) SaveSprint(_ context.Context, s *domain.Sprint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	byID, ok := r.sprints[s.ProjectID]
+	if !ok {
+		byID = make(map[string]*domain.Sprint)
+		r.sprints[s.ProjectID] = byID
+	}
+	if _, ok := byID[s.ID]; ok {
+		return ErrSprintAlreadyExists
+	}
+	byID[s.ID] = cloneSprint(s)
+	return nil
+}
+
+// UpdateSprint はスプリントを更新する。存在しない場合は ErrSprintNotFound を返す。
+func (r *MemorySprintRepository) UpdateSprint(_ context.Context, s *domain.Sprint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.sprints[s.ProjectID][s.ID]; !ok {
+		return ErrSprintNotFound
+	}
+	r.sprints[s.ProjectID][s.ID] = cloneSprint(s)
+	return nil
+}
+
+// DeleteSprint はスプリントを削除する。存在しない場合は ErrSprintNotFound を返す。
+func (r *MemorySprintRepository) DeleteSprint(_ context.Context, projectID, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.sprints[projectID][id]; !ok {
+		return ErrSprintNotFound
+	}
+	delete(r.sprints[projectID], id)
+	return nil
+}
+
+// FindSprint はスプリントを取得する。存在しない場合は ErrSprintNotFound を返す。
+func (r *MemorySprintRepository) FindSprint(_ context.Context, projectID, id string) (*domain.Sprint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.sprints[projectID][id]
+	if !ok {
+		return nil, ErrSprintNotFound
+	}
+	return cloneSprint(s), nil
+}
+
+// ListSprints はスプリントを開始日の昇順（同じ場合は作成日時・ID 順）で返す。
+func (r *MemorySprintRepository) ListSprints(_ context.Context, projectID string) ([]*domain.Sprint, error) {
+	r.mu.RLock()
+	out := make([]*domain.Sprint, 0, len(r.sprints[projectID]))
+	for _, s := range r.sprints[projectID] {
+		out = append(out, cloneSprint(s))
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(out, func(a, b *domain.Sprint) int {
+		if c := a.StartDate.Compare(b.StartDate); c != 0 {
+			return c
+		}
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return out, nil
+}
+
+// cloneSprint は s のコピーを返す（CompletedAt も共有しない）。
+func cloneSprint(s *domain.Sprint) *domain.Sprint {
+	c := *s
+	if s.CompletedAt != nil {
+		t := *s.CompletedAt
+		c.CompletedAt = &t
+	}
+	return &c
+}
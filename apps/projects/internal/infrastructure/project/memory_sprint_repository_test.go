@@ -0,0 +1,82 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+func TestMemorySprintRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemorySprintRepository()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	early := now.AddDate(0, 0, 1)
+	late := now.AddDate(0, 0, 15)
+
+	for _, s := range []*domain.Sprint{
+		{ID: "late", ProjectID: "proj-1", Name: "後", StartDate: late, EndDate: late, State: domain.SprintPlanned, CreatedAt: now},
+		{ID: "early-b", ProjectID: "proj-1", Name: "先 B", StartDate: early, EndDate: late, State: domain.SprintPlanned, CreatedAt: now},
+		{ID: "early-a", ProjectID: "proj-1", Name: "先 A", StartDate: early, EndDate: late, State: domain.SprintPlanned, CreatedAt: now},
+		{ID: "late", ProjectID: "proj-2", Name: "別プロジェクト", StartDate: late, EndDate: late, State: domain.SprintPlanned, CreatedAt: now},
+	} {
+		if err := repo.SaveSprint(ctx, s); err != nil {
+			t.Fatalf("failed to save %s: %v", s.ID, err)
+		}
+	}
+	if err := repo.SaveSprint(ctx, &domain.Sprint{ID: "late", ProjectID: "proj-1", Name: "dup"}); !errors.Is(err, ErrSprintAlreadyExists) {
+		t.Errorf("expected ErrSprintAlreadyExists, got %v", err)
+	}
+
+	list, err := repo.ListSprints(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	want := []string{"early-a", "early-b", "late"}
+	if len(list) != len(want) {
+		t.Fatalf("expected %d sprints, got %d", len(want), len(list))
+	}
+	for i, id := range want {
+		if list[i].ID != id {
+			t.Errorf("index %d: expected %s, got %s", i, id, list[i].ID)
+		}
+	}
+
+	// 取得したものを書き換えても保存内容は変わらない
+	got := list[0]
+	if err := got.Start(now); err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+	if stored, _ := repo.FindSprint(ctx, "proj-1", "early-a"); stored.State != domain.SprintPlanned {
+		t.Errorf("stored state must not change, got %s", stored.State)
+	}
+	if err := got.Complete(now); err != nil {
+		t.Fatalf("failed to complete: %v", err)
+	}
+	if err := repo.UpdateSprint(ctx, got); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	*got.CompletedAt = late
+	stored, err := repo.FindSprint(ctx, "proj-1", "early-a")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if stored.State != domain.SprintCompleted || stored.CompletedAt == nil || !stored.CompletedAt.Equal(now) {
+		t.Errorf("unexpected stored sprint: %+v", stored)
+	}
+
+	if err := repo.DeleteSprint(ctx, "proj-1", "early-a"); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	if _, err := repo.FindSprint(ctx, "proj-1", "early-a"); !errors.Is(err, ErrSprintNotFound) {
+		t.Errorf("expected ErrSprintNotFound, got %v", err)
+	}
+	if err := repo.DeleteSprint(ctx, "proj-1", "early-a"); !errors.Is(err, ErrSprintNotFound) {
+		t.Errorf("expected ErrSprintNotFound on second delete, got %v", err)
+	}
+	if err := repo.UpdateSprint(ctx, &domain.Sprint{ID: "missing", ProjectID: "proj-1"}); !errors.Is(err, ErrSprintNotFound) {
+		t.Errorf("expected ErrSprintNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package projectinfra
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// ErrTemplateNotFound はテンプレートが存在しない場合のエラー。
+var ErrTemplateNotFound = usecase.ErrTemplateNotFound
+
+// ErrTemplateAlreadyExists は同じ ID のテンプレートが既に存在する場合のエラー。
+var ErrTemplateAlreadyExists = usecase.ErrTemplateAlreadyExists
+
+// MemoryTemplateRepository はメモリ上にテンプレートを保持する TemplateRepository 実装。
+// 並行に呼び出してよい。保存時と取得時にコピーする。
+type MemoryTemplateRepository struct {
+	mu        sync.RWMutex
+	templates map[string]*domain.Template
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.TemplateRepository = (*MemoryTemplateRepository)(nil)
+
+// NewMemoryTemplateRepository は空のインメモリリポジトリを生成する。
+func NewMemoryTemplateRepository() *MemoryTemplateRepository {
+	return &MemoryTemplateRepository{
+		templates: make(map[string]*domain.Template),
+	}
+}
+
+// SaveTemplate はテンプレートを保存する。同じ ID が既にある場合は ErrTemplateAlreadyExists を返す。
+// Tasks は呼び出し側のスライスと共有しないようコピーする。
+func (r *MemoryTemplateRepository) SaveTemplate(_ context.Context, t *domain.Template) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.templates[t.ID]; ok {
+		return ErrTemplateAlreadyExists
+	}
+	r.templates[t.ID] = cloneTemplate(t)
+	return nil
+}
+
+// cloneTemplate は t のコピーを返す（Tasks も共有しない）。
+func cloneTemplate(t *domain.Template) *domain.Template {
+	c := *t
+	c.Tasks = slices.Clone(t.Tasks)
+	return &c
+}
+
+// FindTemplate はテンプレートを取得する。存在しない場合は ErrTemplateNotFound を返す。
+func (r *MemoryTemplateRepository) FindTemplate(_ context.Context, id string) (*domain.Template, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.templates[id]
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+	return cloneTemplate(t), nil
+}
+
+// ListTemplates はテンプレートを名前順（同名は ID 順）で返す。
+func (r *MemoryTemplateRepository) ListTemplates(_ context.Context) ([]*domain.Template, error) {
+	r.mu.RLock()
+	out := make([]*domain.Template, 0, len(r.templates))
+	for _, t := range r.templates {
+		out = append(out, cloneTemplate(t))
+	}
+	r.mu.RUnlock()
+	slices.SortFunc(out, func(a, b *domain.Template) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return out, nil
+}
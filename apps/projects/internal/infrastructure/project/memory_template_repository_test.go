@@ -0,0 +1,47 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+func TestMemoryTemplateRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryTemplateRepository()
+
+	if _, err := repo.FindTemplate(ctx, "tmpl-1"); !errors.Is(err, ErrTemplateNotFound) {
+		t.Fatalf("expected ErrTemplateNotFound, got %v", err)
+	}
+
+	tasks := []domain.TaskBlueprint{{Title: "計画", Status: "todo"}}
+	if err := repo.SaveTemplate(ctx, &domain.Template{ID: "tmpl-2", Name: "スプリント", Tasks: tasks}); err != nil {
+		t.Fatalf("failed to save template: %v", err)
+	}
+	if err := repo.SaveTemplate(ctx, &domain.Template{ID: "tmpl-1", Name: "オンボーディング"}); err != nil {
+		t.Fatalf("failed to save template: %v", err)
+	}
+	if err := repo.SaveTemplate(ctx, &domain.Template{ID: "tmpl-1", Name: "duplicate"}); !errors.Is(err, ErrTemplateAlreadyExists) {
+		t.Fatalf("expected ErrTemplateAlreadyExists, got %v", err)
+	}
+	// 保存後に呼び出し側のスライスを変更しても影響しないこと
+	tasks[0].Title = "changed"
+
+	got, err := repo.FindTemplate(ctx, "tmpl-2")
+	if err != nil {
+		t.Fatalf("failed to find template: %v", err)
+	}
+	if got.Tasks[0].Title != "計画" {
+		t.Errorf("expected stored tasks to be isolated, got %+v", got.Tasks)
+	}
+
+	list, err := repo.ListTemplates(ctx)
+	if err != nil {
+		t.Fatalf("failed to list templates: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != "tmpl-1" || list[1].ID != "tmpl-2" {
+		t.Errorf("expected templates ordered by name, got %+v", list)
+	}
+}
@@ -0,0 +1,125 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"teamflow-shared/metrics"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// リポジトリ層のメトリクス（operation ラベルはメソッド名）。
+var (
+	repoQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "projects_repository_query_duration_seconds",
+		Help:    "Duration of project repository operations.",
+		Buckets: metrics.DefaultBuckets,
+	}, []string{"operation"})
+
+	repoRows = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "projects_repository_rows_total",
+		Help: "Number of rows returned or written by project repository operations.",
+	}, []string{"operation"})
+
+	repoErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "projects_repository_errors_total",
+		Help: "Number of failed project repository operations (not found is not counted).",
+	}, []string{"operation"})
+)
+
+// MeteredProjectRepository は処理時間・取得件数・エラー数を計測する ProjectRepository のデコレータ。
+type MeteredProjectRepository struct {
+	inner usecase.ProjectRepository
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.ProjectRepository = (*MeteredProjectRepository)(nil)
+
+// NewMeteredProjectRepository は新しいMeteredProjectRepositoryを生成する。
+func NewMeteredProjectRepository(inner usecase.ProjectRepository) *MeteredProjectRepository {
+	return &MeteredProjectRepository{inner: inner}
+}
+
+// observe は operation の処理時間と結果を記録する。ErrProjectNotFound は正常系として扱う。
+func observe(operation string, start time.Time, rows int, err error) {
+	repoQueryDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if err != nil && !errors.Is(err, ErrProjectNotFound) {
+		repoErrors.WithLabelValues(operation).Inc()
+		return
+	}
+	repoRows.WithLabelValues(operation).Add(float64(rows))
+}
+
+// Save はプロジェクトを保存する。
+func (r *MeteredProjectRepository) Save(ctx context.Context, p *domain.Project) error {
+	start := time.Now()
+	err := r.inner.Save(ctx, p)
+	observe("Save", start, 1, err)
+	return err
+}
+
+// Update は既存プロジェクトを更新する。
+func (r *MeteredProjectRepository) Update(ctx context.Context, p *domain.Project) error {
+	start := time.Now()
+	err := r.inner.Update(ctx, p)
+	observe("Update", start, 1, err)
+	return err
+}
+
+// FindByID はIDを指定してプロジェクトを取得する。
+func (r *MeteredProjectRepository) FindByID(ctx context.Context, id string) (*domain.Project, error) {
+	start := time.Now()
+	p, err := r.inner.FindByID(ctx, id)
+	rows := 0
+	if p != nil {
+		rows = 1
+	}
+	observe("FindByID", start, rows, err)
+	return p, err
+}
+
+// FindDeleted は ID を指定して削除されたプロジェクトを取得する。
+func (r *MeteredProjectRepository) FindDeleted(ctx context.Context, id string) (*domain.Project, error) {
+	start := time.Now()
+	p, err := r.inner.FindDeleted(ctx, id)
+	rows := 0
+	if p != nil {
+		rows = 1
+	}
+	observe("FindDeleted", start, rows, err)
+	return p, err
+}
+
+// FindByName は名前が一致するプロジェクトを取得する。
+func (r *MeteredProjectRepository) FindByName(ctx context.Context, name string) (*domain.Project, error) {
+	start := time.Now()
+	p, err := r.inner.FindByName(ctx, name)
+	rows := 0
+	if p != nil {
+		rows = 1
+	}
+	observe("FindByName", start, rows, err)
+	return p, err
+}
+
+// List はすべてのプロジェクトを返す。
+func (r *MeteredProjectRepository) List(ctx context.Context) ([]*domain.Project, error) {
+	start := time.Now()
+	projects, err := r.inner.List(ctx)
+	observe("List", start, len(projects), err)
+	return projects, err
+}
+
+// FindWithQuery は Query Object に基づいてプロジェクトを取得する。
+func (r *MeteredProjectRepository) FindWithQuery(ctx context.Context, query *domain.ProjectQuery) ([]*domain.Project, error) {
+	start := time.Now()
+	projects, err := r.inner.FindWithQuery(ctx, query)
+	observe("FindWithQuery", start, len(projects), err)
+	return projects, err
+}
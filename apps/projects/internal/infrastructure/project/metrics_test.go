@@ -0,0 +1,63 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// errRepo は常にエラーを返す ProjectRepository（エラー計測の確認用）。
+type errRepo struct{ MemoryProjectRepository }
+
+func (*errRepo) List(context.Context) ([]*domain.Project, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestMeteredProjectRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMeteredProjectRepository(NewMemoryProjectRepository())
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, id := range []string{"proj-1", "proj-2"} {
+		p, err := domain.NewProject(id, id, "", now)
+		if err != nil {
+			t.Fatalf("failed to create project: %v", err)
+		}
+		if err := repo.Save(ctx, p); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+	}
+
+	rowsBefore := testutil.ToFloat64(repoRows.WithLabelValues("List"))
+	errorsBefore := testutil.ToFloat64(repoErrors.WithLabelValues("FindByID"))
+
+	if _, err := repo.List(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := testutil.ToFloat64(repoRows.WithLabelValues("List")) - rowsBefore; got != 2 {
+		t.Errorf("expected rows +2, got +%v", got)
+	}
+
+	// not found はエラーとして数えない
+	if _, err := repo.FindByID(ctx, "non-existent"); !errors.Is(err, ErrProjectNotFound) {
+		t.Fatalf("expected ErrProjectNotFound, got %v", err)
+	}
+	if got := testutil.ToFloat64(repoErrors.WithLabelValues("FindByID")) - errorsBefore; got != 0 {
+		t.Errorf("expected not found not to be counted as error, got +%v", got)
+	}
+
+	// それ以外のエラーは数える
+	failing := NewMeteredProjectRepository(&errRepo{})
+	listErrorsBefore := testutil.ToFloat64(repoErrors.WithLabelValues("List"))
+	if _, err := failing.List(ctx); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := testutil.ToFloat64(repoErrors.WithLabelValues("List")) - listErrorsBefore; got != 1 {
+		t.Errorf("expected errors +1, got +%v", got)
+	}
+}
@@ -0,0 +1,19 @@
+//go:build integration
+// +build integration
+
+package projectinfra
+
+import (
+	"testing"
+
+	"teamflow-projects/internal/testutil"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+func TestSQLProjectRepository_Conformance(t *testing.T) {
+	runConformance(t, func(t *testing.T) usecase.ProjectRepository {
+		db := testutil.SetupTestDB(t)
+		testutil.ResetProjectsTable(t, db)
+		return NewSQLProjectRepository(db)
+	})
+}
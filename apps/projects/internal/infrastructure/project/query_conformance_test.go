@@ -0,0 +1,132 @@
+package projectinfra
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+var conformanceCursorSecret = []byte("conformance-secret")
+
+// conformanceSeed は FindWithQuery の適合テスト用のプロジェクト。
+// name / createdAt の同値を含め、ID による並びの安定性も確認できるようにしている。
+func conformanceSeed() []*domain.Project {
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	archivedAt := base.Add(48 * time.Hour)
+	return []*domain.Project{
+		{ID: "p1", Name: "Alpha", Status: domain.StatusActive, CreatedAt: base, UpdatedAt: base},
+		{ID: "p2", Name: "beta", Status: domain.StatusOnHold, Visibility: domain.VisibilityPublic, CreatedAt: base.Add(time.Hour), UpdatedAt: base},
+		{ID: "p3", Name: "Gamma team", Status: domain.StatusActive, CreatedAt: base.Add(time.Hour), UpdatedAt: base},
+		{ID: "p4", Name: "Alpha", Status: domain.StatusCompleted, CreatedAt: base.Add(2 * time.Hour), UpdatedAt: base},
+		{ID: "p5", Name: "Delta 100%", Status: domain.StatusCompleted, CreatedAt: base.Add(3 * time.Hour), UpdatedAt: base, ArchivedAt: &archivedAt},
+		{ID: "p6", Name: "Team Epsilon", Status: domain.StatusActive, Visibility: domain.VisibilityPublic, CreatedAt: base.Add(4 * time.Hour), UpdatedAt: base, ArchivedAt: &archivedAt},
+	}
+}
+
+// conformanceCases は q / archived / status / sort ごとに、全ページを辿ったときの ID の並び。
+var conformanceCases = []struct {
+	name     string
+	q        string
+	archived string
+	status   string
+	sort     string
+	memberOf []string // nil 以外の場合は閲覧権限で絞り込む（公開プロジェクトは p2, p6）
+	want     []string
+}{
+	{name: "default", want: []string{"p1", "p2", "p3", "p4", "p5", "p6"}},
+	{name: "createdAt desc", sort: "-createdAt", want: []string{"p6", "p5", "p4", "p3", "p2", "p1"}},
+	{name: "name asc (byte order)", sort: "name", want: []string{"p1", "p4", "p5", "p3", "p6", "p2"}},
+	{name: "name desc", sort: "-name", want: []string{"p2", "p6", "p3", "p5", "p4", "p1"}},
+	{name: "q is case insensitive", q: "TEAM", want: []string{"p3", "p6"}},
+	{name: "q treats % as literal", q: "100%", want: []string{"p5"}},
+	{name: "archived only", archived: "true", sort: "-name", want: []string{"p6", "p5"}},
+	{name: "not archived", archived: "false", sort: "name", want: []string{"p1", "p4", "p3", "p2"}},
+	{name: "no match", q: "zeta", want: []string{}},
+	{name: "readable by member", memberOf: []string{"p1", "p4"}, want: []string{"p1", "p2", "p4", "p6"}},
+	{name: "readable anonymously", memberOf: []string{}, sort: "-name", want: []string{"p2", "p6"}},
+}
+
+// runConformance は newRepo のリポジトリに conformanceSeed を入れ、
+// 各ケースを limit=2 で最後のページまで辿った結果を検証する。
+func runConformance(t *testing.T, newRepo func(t *testing.T) usecase.ProjectRepository) {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	repo := newRepo(t)
+	for _, p := range conformanceSeed() {
+		if err := repo.Save(ctx, p); err != nil {
+			t.Fatalf("failed to seed %s: %v", p.ID, err)
+		}
+	}
+
+	for _, tc := range conformanceCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := make([]string, 0)
+			cursor := ""
+			for page := 0; ; page++ {
+				if page > len(tc.want) {
+					t.Fatalf("too many pages: %v", got)
+				}
+
+				opts := []domain.ProjectQueryOption{
+					domain.WithQueryFilter(tc.q),
+					domain.WithArchivedFilter(tc.archived),
+					domain.WithStatusFilter(tc.status),
+					domain.WithLimit(2),
+				}
+				if cursor == "" {
+					opts = append(opts, domain.WithSort(tc.sort))
+				} else {
+					opts = append(opts, domain.WithCursor(cursor, conformanceCursorSecret, now))
+				}
+				query, err := domain.NewProjectQuery(opts...)
+				if err != nil {
+					t.Fatalf("failed to build query: %v", err)
+				}
+				if tc.memberOf != nil {
+					query.ReadableOnly = true
+					query.MemberProjectIDs = tc.memberOf
+				}
+
+				projects, err := repo.FindWithQuery(ctx, query)
+				if err != nil {
+					t.Fatalf("FindWithQuery failed: %v", err)
+				}
+				if len(projects) > query.Limit+1 {
+					t.Fatalf("expected at most limit+1 rows, got %d", len(projects))
+				}
+
+				hasMore := len(projects) > query.Limit
+				if hasMore {
+					projects = projects[:query.Limit]
+				}
+				for _, p := range projects {
+					got = append(got, p.ID)
+				}
+				if !hasMore {
+					break
+				}
+
+				cursor, err = domain.EncodeCursor(query.NewCursorPayload(projects[len(projects)-1], now), conformanceCursorSecret)
+				if err != nil {
+					t.Fatalf("failed to encode cursor: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMemoryProjectRepository_Conformance(t *testing.T) {
+	runConformance(t, func(*testing.T) usecase.ProjectRepository {
+		return NewMemoryProjectRepository()
+	})
+}
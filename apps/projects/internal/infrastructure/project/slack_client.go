@@ -0,0 +1,140 @@
+package projectinfra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// Slack への投稿の既定値。
+const (
+	// DefaultSlackRetries は一時的な失敗（接続エラー・429・5xx）で再試行する回数。
+	DefaultSlackRetries = 3
+	// defaultSlackTimeout は 1 回の投稿のタイムアウト。
+	defaultSlackTimeout = 10 * time.Second
+	// defaultSlackRetryDelay は最初の再試行までの待ち時間（再試行のたびに倍にする）。
+	defaultSlackRetryDelay = 500 * time.Millisecond
+	// maxSlackRetryDelay は待ち時間の上限（429 の Retry-After もこれで打ち切る）。
+	maxSlackRetryDelay = 30 * time.Second
+)
+
+// SlackWebhookError は Slack の Webhook が 2xx 以外を返した場合のエラー。
+type SlackWebhookError struct {
+	StatusCode int
+	// Body は Slack が返したエラーの理由（例: no_service, invalid_payload）
+	Body string
+}
+
+func (e *SlackWebhookError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("slack webhook returned %d", e.StatusCode)
+	}
+	return fmt.Sprintf("slack webhook returned %d: %s", e.StatusCode, e.Body)
+}
+
+// temporary は再試行すれば成功する可能性があるか（429・5xx）を返す。
+func (e *SlackWebhookError) temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// SlackWebhookClient は Slack の Incoming Webhook にメッセージを投稿する SlackPoster 実装。
+// 接続エラー・429・5xx は待ち時間を倍にしながら retries 回まで再試行する（429 は Retry-After に従う）。
+type SlackWebhookClient struct {
+	client    *http.Client
+	retries   int
+	baseDelay time.Duration
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.SlackPoster = (*SlackWebhookClient)(nil)
+
+// NewSlackWebhookClient は SlackWebhookClient を生成する。
+// httpClient が nil の場合はタイムアウト付きで、リダイレクトに従わない（許可したホスト以外に送らない）既定のクライアントを使う。
+func NewSlackWebhookClient(httpClient *http.Client, retries int) *SlackWebhookClient {
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: defaultSlackTimeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+	}
+	return &SlackWebhookClient{client: httpClient, retries: retries, baseDelay: defaultSlackRetryDelay}
+}
+
+// PostSlackMessage は webhookURL に text を投稿する。返すエラーには webhookURL を含めない。
+func (c *SlackWebhookClient) PostSlackMessage(ctx context.Context, webhookURL, text string) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return fmt.Errorf("failed to encode slack message: %w", err)
+	}
+
+	delay := c.baseDelay
+	for attempt := 0; ; attempt++ {
+		wait, err := c.post(ctx, webhookURL, body)
+		if err == nil {
+			return nil
+		}
+		if wait < 0 || attempt >= c.retries {
+			return err
+		}
+		if wait == 0 {
+			wait = delay
+			delay = min(delay*2, maxSlackRetryDelay)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// post は 1 回投稿する。失敗した場合は再試行までの待ち時間（0 は既定の待ち時間、負は再試行しない）とエラーを返す。
+func (c *SlackWebhookClient) post(ctx context.Context, webhookURL string, body []byte) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return -1, errors.New("invalid slack webhook url")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		// *url.Error は URL を含むため、原因のエラーだけを返す
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			err = uerr.Err
+		}
+		if ctx.Err() != nil {
+			return -1, fmt.Errorf("slack webhook request failed: %w", ctx.Err())
+		}
+		return 0, fmt.Errorf("slack webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return 0, nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	werr := &SlackWebhookError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(msg))}
+	if !werr.temporary() {
+		return -1, werr
+	}
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+		return min(time.Duration(secs)*time.Second, maxSlackRetryDelay), werr
+	}
+	return 0, werr
+}
@@ -0,0 +1,98 @@
+package projectinfra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// slackServer は statuses を順に返す Slack の Webhook のテスト用サーバー。最後のステータスは繰り返す。
+func slackServer(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32, *string) {
+	t.Helper()
+	var calls atomic.Int32
+	var text string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1)) - 1
+		var body struct{ Text string }
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request: %v", err)
+		}
+		text = body.Text
+		status := statuses[min(n, len(statuses)-1)]
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(status)
+		if status == http.StatusNotFound {
+			_, _ = w.Write([]byte("no_service"))
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls, &text
+}
+
+func newTestSlackClient(retries int) *SlackWebhookClient {
+	c := NewSlackWebhookClient(nil, retries)
+	c.baseDelay = time.Millisecond
+	return c
+}
+
+func TestSlackWebhookClient_Post(t *testing.T) {
+	srv, calls, text := slackServer(t, http.StatusOK)
+	if err := newTestSlackClient(3).PostSlackMessage(context.Background(), srv.URL+"/services/T0", "hello *world*"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 1 || *text != "hello *world*" {
+		t.Errorf("unexpected request: %d calls, text %q", calls.Load(), *text)
+	}
+}
+
+func TestSlackWebhookClient_RetriesTemporaryErrors(t *testing.T) {
+	srv, calls, _ := slackServer(t, http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusOK)
+	if err := newTestSlackClient(3).PostSlackMessage(context.Background(), srv.URL, "hello"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls.Load())
+	}
+
+	srv, calls, _ = slackServer(t, http.StatusServiceUnavailable)
+	err := newTestSlackClient(2).PostSlackMessage(context.Background(), srv.URL, "hello")
+	var werr *SlackWebhookError
+	if !errors.As(err, &werr) || werr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 error, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 1 attempt and 2 retries, got %d", calls.Load())
+	}
+}
+
+func TestSlackWebhookClient_DoesNotRetryPermanentErrors(t *testing.T) {
+	srv, calls, _ := slackServer(t, http.StatusNotFound)
+	err := newTestSlackClient(3).PostSlackMessage(context.Background(), srv.URL+"/services/secret", "hello")
+	if err == nil || err.Error() != "slack webhook returned 404: no_service" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected no retries, got %d calls", calls.Load())
+	}
+}
+
+func TestSlackWebhookClient_ErrorOmitsURL(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL + "/services/secret"
+	srv.Close()
+
+	err := newTestSlackClient(1).PostSlackMessage(context.Background(), url, "hello")
+	if err == nil || strings.Contains(err.Error(), "secret") {
+		t.Errorf("expected an error without the webhook url, got %v", err)
+	}
+}
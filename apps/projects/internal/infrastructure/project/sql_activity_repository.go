@@ -0,0 +1,99 @@
+package projectinfra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"teamflow-shared/sqlbuilder"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// SQLActivityRepository はPostgreSQLを使用したActivityRepository実装。
+type SQLActivityRepository struct {
+	db *pgxpool.Pool
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.ActivityRepository = (*SQLActivityRepository)(nil)
+
+// NewSQLActivityRepository は新しいSQLActivityRepositoryを生成する。
+func NewSQLActivityRepository(db *pgxpool.Pool) *SQLActivityRepository {
+	return &SQLActivityRepository{
+		db: db,
+	}
+}
+
+// activityColumns は SELECT 時のカラム順。scanActivityEvent の Scan 順と一致させる。
+const activityColumns = "id, project_id, type, actor_id, data, created_at"
+
+// Append はアクティビティを記録し、採番された id を e.ID に設定する。
+// プロジェクトが存在しない場合は ErrProjectNotFound を返す。
+func (r *SQLActivityRepository) Append(ctx context.Context, e *domain.ActivityEvent) error {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode activity data: %w", err)
+	}
+	err = conn(ctx, r.db).QueryRow(ctx, `
+		INSERT INTO project_events (project_id, type, actor_id, data, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, e.ProjectID, string(e.Type), e.ActorID, data, e.CreatedAt).Scan(&e.ID)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrProjectNotFound
+		}
+		return fmt.Errorf("failed to insert project event: %w", err)
+	}
+	return nil
+}
+
+// FindActivity はプロジェクトのアクティビティを新しい順（同時刻は id の降順）で limit + 1 件まで返す。
+func (r *SQLActivityRepository) FindActivity(ctx context.Context, query *domain.ActivityQuery) ([]*domain.ActivityEvent, error) {
+	b := sqlbuilder.NewSelect(activityColumns, "project_events")
+	b.Where("project_id = " + b.Arg(query.ProjectID))
+	if c := query.Cursor; c != nil {
+		// (created_at, id) < (cursor.created_at, cursor.id) の keyset 条件
+		b.Where("(created_at, id) < (" + b.Arg(c.CreatedAt) + ", " + b.Arg(c.ID) + ")")
+	}
+	b.OrderBy("created_at DESC", "id DESC").Limit(query.Limit + 1)
+
+	sql, args := b.Build()
+	rows, err := conn(ctx, r.db).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project events: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]*domain.ActivityEvent, 0)
+	for rows.Next() {
+		e, err := scanActivityEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project event: %w", err)
+		}
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list project events: %w", err)
+	}
+	return out, nil
+}
+
+func scanActivityEvent(row pgx.Row) (*domain.ActivityEvent, error) {
+	var e domain.ActivityEvent
+	var typ string
+	var data []byte
+	if err := row.Scan(&e.ID, &e.ProjectID, &typ, &e.ActorID, &data, &e.CreatedAt); err != nil {
+		return nil, err
+	}
+	e.Type = domain.ActivityType(typ)
+	if err := json.Unmarshal(data, &e.Data); err != nil {
+		return nil, fmt.Errorf("failed to decode activity data: %w", err)
+	}
+	return &e, nil
+}
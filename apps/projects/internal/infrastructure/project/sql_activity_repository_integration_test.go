@@ -0,0 +1,71 @@
+//go:build integration
+// +build integration
+
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	"teamflow-projects/internal/testutil"
+)
+
+func TestSQLActivityRepository(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	projects := NewSQLProjectRepository(db)
+	repo := NewSQLActivityRepository(db)
+	ctx := context.Background()
+	secret := []byte("test-secret")
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	if err := projects.Save(ctx, newTestProject(t, "proj-1", "Project 1", "", now)); err != nil {
+		t.Fatalf("failed to save project: %v", err)
+	}
+
+	// 2 件目と 3 件目は同時刻（id の降順で並ぶ）
+	for _, at := range []time.Time{now, now.Add(time.Minute), now.Add(time.Minute)} {
+		e := domain.NewActivityEvent("proj-1", domain.ActivityMemberAdded, "user-1", map[string]string{"userId": "user-2"}, at)
+		if err := repo.Append(ctx, e); err != nil {
+			t.Fatalf("failed to append: %v", err)
+		}
+		if e.ID == 0 {
+			t.Fatal("expected ID to be assigned")
+		}
+	}
+
+	q, err := domain.NewActivityQuery("proj-1", 2, "", secret, now)
+	if err != nil {
+		t.Fatalf("failed to build query: %v", err)
+	}
+	first, err := repo.FindActivity(ctx, q)
+	if err != nil {
+		t.Fatalf("failed to find activity: %v", err)
+	}
+	if len(first) != 3 {
+		t.Fatalf("expected limit + 1 = 3 events, got %d", len(first))
+	}
+	if first[0].ID <= first[1].ID || first[0].Data["userId"] != "user-2" || first[0].Type != domain.ActivityMemberAdded {
+		t.Errorf("unexpected first event: %+v", first[0])
+	}
+
+	cursor, _ := domain.EncodeCursor(q.NewCursorPayload(first[1], now), secret)
+	next, err := domain.NewActivityQuery("proj-1", 2, cursor, secret, now)
+	if err != nil {
+		t.Fatalf("failed to build query: %v", err)
+	}
+	second, err := repo.FindActivity(ctx, next)
+	if err != nil {
+		t.Fatalf("failed to find activity: %v", err)
+	}
+	if len(second) != 1 || second[0].ID != first[2].ID {
+		t.Errorf("unexpected second page: %+v", second)
+	}
+
+	if err := repo.Append(ctx, domain.NewActivityEvent("non-existent", domain.ActivityProjectCreated, "", nil, now)); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// SQLEpicRepository はPostgreSQLを使用したEpicRepository実装。
+type SQLEpicRepository struct {
+	db *pgxpool.Pool
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.EpicRepository = (*SQLEpicRepository)(nil)
+
+// NewSQLEpicRepository は新しいSQLEpicRepositoryを生成する。
+func NewSQLEpicRepository(db *pgxpool.Pool) *SQLEpicRepository {
+	return &SQLEpicRepository{
+		db: db,
+	}
+}
+
+// epicColumns は SELECT 時のカラム順。scanEpic の Scan 順と一致させる。
+const epicColumns = "project_id, id, name, description, status, created_at, updated_at"
+
+// SaveEpic はエピックを保存する。
+// プロジェクト内に同じ ID がある場合は ErrEpicAlreadyExists、プロジェクトが存在しない場合は ErrProjectNotFound を返す。
+func (r *SQLEpicRepository) SaveEpic(ctx context.Context, e *domain.Epic) error {
+	_, err := conn(ctx, r.db).Exec(ctx,
+		"INSERT INTO project_epics ("+epicColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7)",
+		e.ProjectID, e.ID, e.Name, e.Description, string(e.Status), e.CreatedAt, e.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgUniqueViolation:
+				return ErrEpicAlreadyExists
+			case pgForeignKeyViolation:
+				return ErrProjectNotFound
+			}
+		}
+		return fmt.Errorf("failed to insert project epic: %w", err)
+	}
+	return nil
+}
+
+// UpdateEpic はエピックを更新する。存在しない場合は ErrEpicNotFound を返す。
+func (r *SQLEpicRepository) UpdateEpic(ctx context.Context, e *domain.Epic) error {
+	tag, err := conn(ctx, r.db).Exec(ctx, `
+		UPDATE project_epics SET
+			name = $3,
+			description = $4,
+			status = $5,
+			updated_at = $6
+		WHERE project_id = $1 AND id = $2
+	`, e.ProjectID, e.ID, e.Name, e.Description, string(e.Status), e.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update project epic: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrEpicNotFound
+	}
+	return nil
+}
+
+// DeleteEpic はエピックを削除する。存在しない場合は ErrEpicNotFound を返す。
+func (r *SQLEpicRepository) DeleteEpic(ctx context.Context, projectID, id string) error {
+	tag, err := conn(ctx, r.db).Exec(ctx,
+		"DELETE FROM project_epics WHERE project_id = $1 AND id = $2",
+		projectID, id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete project epic: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrEpicNotFound
+	}
+	return nil
+}
+
+// FindEpic はエピックを取得する。存在しない場合は ErrEpicNotFound を返す。
+func (r *SQLEpicRepository) FindEpic(ctx context.Context, projectID, id string) (*domain.Epic, error) {
+	row := conn(ctx, r.db).QueryRow(ctx,
+		"SELECT "+epicColumns+" FROM project_epics WHERE project_id = $1 AND id = $2",
+		projectID, id,
+	)
+	e, err := scanEpic(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEpicNotFound
+		}
+		return nil, fmt.Errorf("failed to find project epic: %w", err)
+	}
+	return e, nil
+}
+
+// ListEpics はエピックを作成日時・ID の昇順で返す。
+func (r *SQLEpicRepository) ListEpics(ctx context.Context, projectID string) ([]*domain.Epic, error) {
+	rows, err := conn(ctx, r.db).Query(ctx,
+		"SELECT "+epicColumns+" FROM project_epics WHERE project_id = $1 ORDER BY created_at ASC, id ASC",
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project epics: %w", err)
+	}
+	defer rows.Close()
+
+	epics := []*domain.Epic{}
+	for rows.Next() {
+		e, err := scanEpic(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project epic: %w", err)
+		}
+		epics = append(epics, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate project epics: %w", err)
+	}
+	return epics, nil
+}
+
+// scanEpic は epicColumns の順で 1 行を読み取る。
+func scanEpic(row pgx.Row) (*domain.Epic, error) {
+	var e domain.Epic
+	var status string
+	if err := row.Scan(&e.ProjectID, &e.ID, &e.Name, &e.Description, &status, &e.CreatedAt, &e.UpdatedAt); err != nil {
+		return nil, err
+	}
+	e.Status = domain.EpicStatus(status)
+	return &e, nil
+}
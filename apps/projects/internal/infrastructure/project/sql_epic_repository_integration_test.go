@@ -0,0 +1,80 @@
+//go:build integration
+// +build integration
+
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	"teamflow-projects/internal/testutil"
+)
+
+func TestSQLEpicRepository(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	projects := NewSQLProjectRepository(db)
+	repo := NewSQLEpicRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	if err := projects.Save(ctx, newTestProject(t, "proj-1", "Project 1", "", now)); err != nil {
+		t.Fatalf("failed to save project: %v", err)
+	}
+
+	for _, e := range []*domain.Epic{
+		{ID: "search", ProjectID: "proj-1", Name: "検索", Status: domain.EpicOpen, CreatedAt: now.Add(time.Hour), UpdatedAt: now},
+		{ID: "login", ProjectID: "proj-1", Name: "ログイン", Description: "SSO 対応", Status: domain.EpicOpen, CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := repo.SaveEpic(ctx, e); err != nil {
+			t.Fatalf("failed to save %s: %v", e.ID, err)
+		}
+	}
+	if err := repo.SaveEpic(ctx, &domain.Epic{ID: "login", ProjectID: "proj-1", Name: "dup", Status: domain.EpicOpen, CreatedAt: now, UpdatedAt: now}); !errors.Is(err, ErrEpicAlreadyExists) {
+		t.Errorf("expected ErrEpicAlreadyExists, got %v", err)
+	}
+	if err := repo.SaveEpic(ctx, &domain.Epic{ID: "login", ProjectID: "non-existent", Name: "login", Status: domain.EpicOpen, CreatedAt: now, UpdatedAt: now}); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+
+	list, err := repo.ListEpics(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != "login" || list[1].ID != "search" {
+		t.Fatalf("unexpected order: %+v", list)
+	}
+	if list[0].Description != "SSO 対応" || list[1].Description != "" {
+		t.Errorf("unexpected descriptions: %q, %q", list[0].Description, list[1].Description)
+	}
+
+	login := list[0]
+	login.Status = domain.EpicClosed
+	login.UpdatedAt = now.Add(time.Hour)
+	if err := repo.UpdateEpic(ctx, login); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	got, err := repo.FindEpic(ctx, "proj-1", "login")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.Status != domain.EpicClosed || !got.UpdatedAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("unexpected epic: %+v", got)
+	}
+
+	if err := repo.DeleteEpic(ctx, "proj-1", "login"); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	if _, err := repo.FindEpic(ctx, "proj-1", "login"); !errors.Is(err, ErrEpicNotFound) {
+		t.Errorf("expected ErrEpicNotFound, got %v", err)
+	}
+	if err := repo.DeleteEpic(ctx, "proj-1", "login"); !errors.Is(err, ErrEpicNotFound) {
+		t.Errorf("expected ErrEpicNotFound on second delete, got %v", err)
+	}
+	if err := repo.UpdateEpic(ctx, login); !errors.Is(err, ErrEpicNotFound) {
+		t.Errorf("expected ErrEpicNotFound on update, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// SQLInvitationRepository はPostgreSQLを使用したInvitationRepository実装。
+type SQLInvitationRepository struct {
+	db *pgxpool.Pool
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.InvitationRepository = (*SQLInvitationRepository)(nil)
+
+// NewSQLInvitationRepository は新しいSQLInvitationRepositoryを生成する。
+func NewSQLInvitationRepository(db *pgxpool.Pool) *SQLInvitationRepository {
+	return &SQLInvitationRepository{
+		db: db,
+	}
+}
+
+// invitationColumns は SELECT 時のカラム順。scanInvitation の Scan 順と一致させる。
+const invitationColumns = "project_id, id, role, created_by, created_at, expires_at, revoked_at"
+
+// SaveInvitation は招待を保存する。プロジェクトが存在しない場合は ErrProjectNotFound を返す。
+func (r *SQLInvitationRepository) SaveInvitation(ctx context.Context, inv *domain.Invitation) error {
+	_, err := conn(ctx, r.db).Exec(ctx,
+		"INSERT INTO project_invitations ("+invitationColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7)",
+		inv.ProjectID, inv.ID, string(inv.Role), inv.CreatedBy, inv.CreatedAt, inv.ExpiresAt, inv.RevokedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+			return ErrProjectNotFound
+		}
+		return fmt.Errorf("failed to insert project invitation: %w", err)
+	}
+	return nil
+}
+
+// FindInvitation は招待を取得する（取り消し済みを含む）。存在しない場合は ErrInvitationNotFound を返す。
+func (r *SQLInvitationRepository) FindInvitation(ctx context.Context, projectID, id string) (*domain.Invitation, error) {
+	row := conn(ctx, r.db).QueryRow(ctx,
+		"SELECT "+invitationColumns+" FROM project_invitations WHERE project_id = $1 AND id = $2",
+		projectID, id,
+	)
+	inv, err := scanInvitation(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to find project invitation: %w", err)
+	}
+	return inv, nil
+}
+
+// ListInvitations は取り消されていない招待を発行日時の新しい順（同時刻は ID 順）で返す。
+func (r *SQLInvitationRepository) ListInvitations(ctx context.Context, projectID string) ([]*domain.Invitation, error) {
+	rows, err := conn(ctx, r.db).Query(ctx,
+		"SELECT "+invitationColumns+" FROM project_invitations WHERE project_id = $1 AND revoked_at IS NULL ORDER BY created_at DESC, id ASC",
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project invitations: %w", err)
+	}
+	defer rows.Close()
+
+	invitations := []*domain.Invitation{}
+	for rows.Next() {
+		inv, err := scanInvitation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project invitation: %w", err)
+		}
+		invitations = append(invitations, inv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate project invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+// RevokeInvitation は招待に取り消し日時を設定する。
+// 存在しない、または既に取り消されている場合は ErrInvitationNotFound を返す。
+func (r *SQLInvitationRepository) RevokeInvitation(ctx context.Context, projectID, id string, revokedAt time.Time) error {
+	tag, err := conn(ctx, r.db).Exec(ctx,
+		"UPDATE project_invitations SET revoked_at = $3 WHERE project_id = $1 AND id = $2 AND revoked_at IS NULL",
+		projectID, id, revokedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke project invitation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+// scanInvitation は invitationColumns の順で 1 行を読み取る。
+func scanInvitation(row pgx.Row) (*domain.Invitation, error) {
+	var inv domain.Invitation
+	var role string
+	if err := row.Scan(&inv.ProjectID, &inv.ID, &role, &inv.CreatedBy, &inv.CreatedAt, &inv.ExpiresAt, &inv.RevokedAt); err != nil {
+		return nil, err
+	}
+	inv.Role = domain.MemberRole(role)
+	return &inv, nil
+}
@@ -0,0 +1,68 @@
+//go:build integration
+// +build integration
+
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	"teamflow-projects/internal/testutil"
+)
+
+func TestSQLInvitationRepository(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	projects := NewSQLProjectRepository(db)
+	repo := NewSQLInvitationRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	if err := projects.Save(ctx, newTestProject(t, "proj-1", "Project 1", "", now)); err != nil {
+		t.Fatalf("failed to save project: %v", err)
+	}
+
+	for _, inv := range []*domain.Invitation{
+		{ID: "i1", ProjectID: "proj-1", Role: domain.RoleMember, CreatedBy: "user-1", CreatedAt: now, ExpiresAt: now.Add(time.Hour)},
+		{ID: "i2", ProjectID: "proj-1", Role: domain.RoleAdmin, CreatedAt: now.Add(time.Minute), ExpiresAt: now.Add(time.Hour)},
+	} {
+		if err := repo.SaveInvitation(ctx, inv); err != nil {
+			t.Fatalf("failed to save %s: %v", inv.ID, err)
+		}
+	}
+	if err := repo.SaveInvitation(ctx, &domain.Invitation{ID: "i1", ProjectID: "non-existent", Role: domain.RoleMember, CreatedAt: now, ExpiresAt: now}); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+
+	list, err := repo.ListInvitations(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != "i2" || list[1].ID != "i1" {
+		t.Fatalf("unexpected order: %+v", list)
+	}
+
+	if err := repo.RevokeInvitation(ctx, "proj-1", "i1", now.Add(2*time.Minute)); err != nil {
+		t.Fatalf("failed to revoke: %v", err)
+	}
+	if err := repo.RevokeInvitation(ctx, "proj-1", "i1", now.Add(3*time.Minute)); !errors.Is(err, ErrInvitationNotFound) {
+		t.Errorf("expected ErrInvitationNotFound for already revoked, got %v", err)
+	}
+
+	got, err := repo.FindInvitation(ctx, "proj-1", "i1")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.Role != domain.RoleMember || got.CreatedBy != "user-1" || got.RevokedAt == nil || !got.RevokedAt.Equal(now.Add(2*time.Minute)) {
+		t.Errorf("unexpected invitation: %+v", got)
+	}
+	if list, _ := repo.ListInvitations(ctx, "proj-1"); len(list) != 1 || list[0].ID != "i2" {
+		t.Errorf("expected only i2 to be outstanding, got %+v", list)
+	}
+	if _, err := repo.FindInvitation(ctx, "proj-1", "missing"); !errors.Is(err, ErrInvitationNotFound) {
+		t.Errorf("expected ErrInvitationNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// SQLLabelRepository はPostgreSQLを使用したLabelRepository実装。
+type SQLLabelRepository struct {
+	db *pgxpool.Pool
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.LabelRepository = (*SQLLabelRepository)(nil)
+
+// NewSQLLabelRepository は新しいSQLLabelRepositoryを生成する。
+func NewSQLLabelRepository(db *pgxpool.Pool) *SQLLabelRepository {
+	return &SQLLabelRepository{
+		db: db,
+	}
+}
+
+// labelColumns は SELECT 時のカラム順。scanLabel の Scan 順と一致させる。
+const labelColumns = "project_id, id, name, color, created_at, updated_at"
+
+// labelNameIndex はラベル名の一意インデックス名（0014_create_project_labels）。
+const labelNameIndex = "idx_project_labels_project_id_name"
+
+// SaveLabel はラベルを保存する。
+// プロジェクト内に同じ ID がある場合は ErrLabelAlreadyExists、同じ名前がある場合は ErrLabelNameAlreadyExists、
+// プロジェクトが存在しない場合は ErrProjectNotFound を返す。
+func (r *SQLLabelRepository) SaveLabel(ctx context.Context, l *domain.Label) error {
+	_, err := conn(ctx, r.db).Exec(ctx,
+		"INSERT INTO project_labels ("+labelColumns+") VALUES ($1, $2, $3, $4, $5, $6)",
+		l.ProjectID, l.ID, l.Name, l.Color, l.CreatedAt, l.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch {
+			case pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == labelNameIndex:
+				return ErrLabelNameAlreadyExists
+			case pgErr.Code == pgUniqueViolation:
+				return ErrLabelAlreadyExists
+			case pgErr.Code == pgForeignKeyViolation:
+				return ErrProjectNotFound
+			}
+		}
+		return fmt.Errorf("failed to insert project label: %w", err)
+	}
+	return nil
+}
+
+// UpdateLabel はラベルを更新する。
+// 存在しない場合は ErrLabelNotFound、名前が他のラベルと重複する場合は ErrLabelNameAlreadyExists を返す。
+func (r *SQLLabelRepository) UpdateLabel(ctx context.Context, l *domain.Label) error {
+	tag, err := conn(ctx, r.db).Exec(ctx, `
+		UPDATE project_labels SET
+			name = $3,
+			color = $4,
+			updated_at = $5
+		WHERE project_id = $1 AND id = $2
+	`, l.ProjectID, l.ID, l.Name, l.Color, l.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == labelNameIndex {
+			return ErrLabelNameAlreadyExists
+		}
+		return fmt.Errorf("failed to update project label: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrLabelNotFound
+	}
+	return nil
+}
+
+// DeleteLabel はラベルを削除する。存在しない場合は ErrLabelNotFound を返す。
+func (r *SQLLabelRepository) DeleteLabel(ctx context.Context, projectID, id string) error {
+	tag, err := conn(ctx, r.db).Exec(ctx,
+		"DELETE FROM project_labels WHERE project_id = $1 AND id = $2",
+		projectID, id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete project label: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrLabelNotFound
+	}
+	return nil
+}
+
+// FindLabel はラベルを取得する。存在しない場合は ErrLabelNotFound を返す。
+func (r *SQLLabelRepository) FindLabel(ctx context.Context, projectID, id string) (*domain.Label, error) {
+	row := conn(ctx, r.db).QueryRow(ctx,
+		"SELECT "+labelColumns+" FROM project_labels WHERE project_id = $1 AND id = $2",
+		projectID, id,
+	)
+	l, err := scanLabel(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrLabelNotFound
+		}
+		return nil, fmt.Errorf("failed to find project label: %w", err)
+	}
+	return l, nil
+}
+
+// ListLabels はラベルを名前（大文字・小文字は区別しない）・ID の昇順で返す。
+func (r *SQLLabelRepository) ListLabels(ctx context.Context, projectID string) ([]*domain.Label, error) {
+	rows, err := conn(ctx, r.db).Query(ctx,
+		"SELECT "+labelColumns+" FROM project_labels WHERE project_id = $1 ORDER BY lower(name) ASC, id ASC",
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project labels: %w", err)
+	}
+	defer rows.Close()
+
+	labels := []*domain.Label{}
+	for rows.Next() {
+		l, err := scanLabel(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project label: %w", err)
+		}
+		labels = append(labels, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate project labels: %w", err)
+	}
+	return labels, nil
+}
+
+// scanLabel は labelColumns の順で 1 行を読み取る。
+func scanLabel(row pgx.Row) (*domain.Label, error) {
+	var l domain.Label
+	if err := row.Scan(&l.ProjectID, &l.ID, &l.Name, &l.Color, &l.CreatedAt, &l.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
@@ -0,0 +1,85 @@
+//go:build integration
+// +build integration
+
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	"teamflow-projects/internal/testutil"
+)
+
+func TestSQLLabelRepository(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	projects := NewSQLProjectRepository(db)
+	repo := NewSQLLabelRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	if err := projects.Save(ctx, newTestProject(t, "proj-1", "Project 1", "", now)); err != nil {
+		t.Fatalf("failed to save project: %v", err)
+	}
+
+	for _, l := range []*domain.Label{
+		{ID: "l2", ProjectID: "proj-1", Name: "feature", Color: "#a2eeef", CreatedAt: now, UpdatedAt: now},
+		{ID: "l1", ProjectID: "proj-1", Name: "Bug", Color: "#d73a4a", CreatedAt: now.Add(time.Hour), UpdatedAt: now},
+	} {
+		if err := repo.SaveLabel(ctx, l); err != nil {
+			t.Fatalf("failed to save %s: %v", l.ID, err)
+		}
+	}
+	if err := repo.SaveLabel(ctx, &domain.Label{ID: "l1", ProjectID: "proj-1", Name: "dup", Color: "#ffffff", CreatedAt: now, UpdatedAt: now}); !errors.Is(err, ErrLabelAlreadyExists) {
+		t.Errorf("expected ErrLabelAlreadyExists, got %v", err)
+	}
+	if err := repo.SaveLabel(ctx, &domain.Label{ID: "l3", ProjectID: "proj-1", Name: "BUG", Color: "#ffffff", CreatedAt: now, UpdatedAt: now}); !errors.Is(err, ErrLabelNameAlreadyExists) {
+		t.Errorf("expected ErrLabelNameAlreadyExists, got %v", err)
+	}
+	if err := repo.SaveLabel(ctx, &domain.Label{ID: "l1", ProjectID: "non-existent", Name: "bug", Color: "#ffffff", CreatedAt: now, UpdatedAt: now}); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+
+	list, err := repo.ListLabels(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != "l1" || list[1].ID != "l2" {
+		t.Fatalf("unexpected order: %+v", list)
+	}
+
+	bug := list[0]
+	bug.Color = "#0e8a16"
+	bug.UpdatedAt = now.Add(time.Hour)
+	if err := repo.UpdateLabel(ctx, bug); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	got, err := repo.FindLabel(ctx, "proj-1", "l1")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.Color != "#0e8a16" || !got.UpdatedAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("unexpected label: %+v", got)
+	}
+	bug.Name = "Feature"
+	if err := repo.UpdateLabel(ctx, bug); !errors.Is(err, ErrLabelNameAlreadyExists) {
+		t.Errorf("expected ErrLabelNameAlreadyExists on update, got %v", err)
+	}
+
+	if err := repo.DeleteLabel(ctx, "proj-1", "l1"); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	if _, err := repo.FindLabel(ctx, "proj-1", "l1"); !errors.Is(err, ErrLabelNotFound) {
+		t.Errorf("expected ErrLabelNotFound, got %v", err)
+	}
+	if err := repo.DeleteLabel(ctx, "proj-1", "l1"); !errors.Is(err, ErrLabelNotFound) {
+		t.Errorf("expected ErrLabelNotFound on second delete, got %v", err)
+	}
+	bug.Name = "bug"
+	if err := repo.UpdateLabel(ctx, bug); !errors.Is(err, ErrLabelNotFound) {
+		t.Errorf("expected ErrLabelNotFound on update, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// SQLMemberRepository はPostgreSQLを使用したMemberRepository実装。
+type SQLMemberRepository struct {
+	db *pgxpool.Pool
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.MemberRepository = (*SQLMemberRepository)(nil)
+
+// NewSQLMemberRepository は新しいSQLMemberRepositoryを生成する。
+func NewSQLMemberRepository(db *pgxpool.Pool) *SQLMemberRepository {
+	return &SQLMemberRepository{
+		db: db,
+	}
+}
+
+// memberColumns は SELECT 時のカラム順。scanMember の Scan 順と一致させる。
+const memberColumns = "project_id, user_id, role, joined_at"
+
+// PostgreSQL のエラーコード
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+// AddMember はメンバーを追加する。
+// 既に参加している場合は ErrMemberAlreadyExists、プロジェクトが存在しない場合は ErrProjectNotFound を返す。
+func (r *SQLMemberRepository) AddMember(ctx context.Context, m *domain.Member) error {
+	_, err := conn(ctx, r.db).Exec(ctx,
+		"INSERT INTO project_members ("+memberColumns+") VALUES ($1, $2, $3, $4)",
+		m.ProjectID, m.UserID, string(m.Role), m.JoinedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgUniqueViolation:
+				return ErrMemberAlreadyExists
+			case pgForeignKeyViolation:
+				return ErrProjectNotFound
+			}
+		}
+		return fmt.Errorf("failed to insert project member: %w", err)
+	}
+	return nil
+}
+
+// RemoveMember はメンバーを削除する。参加していない場合は ErrMemberNotFound を返す。
+func (r *SQLMemberRepository) RemoveMember(ctx context.Context, projectID, userID string) error {
+	tag, err := conn(ctx, r.db).Exec(ctx,
+		"DELETE FROM project_members WHERE project_id = $1 AND user_id = $2",
+		projectID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete project member: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrMemberNotFound
+	}
+	return nil
+}
+
+// FindMember はメンバーを 1 件取得する。参加していない場合は ErrMemberNotFound を返す。
+func (r *SQLMemberRepository) FindMember(ctx context.Context, projectID, userID string) (*domain.Member, error) {
+	row := conn(ctx, r.db).QueryRow(ctx,
+		"SELECT "+memberColumns+" FROM project_members WHERE project_id = $1 AND user_id = $2",
+		projectID, userID,
+	)
+	m, err := scanMember(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to find project member: %w", err)
+	}
+	return m, nil
+}
+
+// ListMembers はプロジェクトのメンバーを参加日時順（同時刻は user_id 順）で返す。
+func (r *SQLMemberRepository) ListMembers(ctx context.Context, projectID string) ([]*domain.Member, error) {
+	rows, err := conn(ctx, r.db).Query(ctx,
+		"SELECT "+memberColumns+" FROM project_members WHERE project_id = $1 ORDER BY joined_at ASC, user_id ASC",
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project members: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]*domain.Member, 0)
+	for rows.Next() {
+		m, err := scanMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project member: %w", err)
+		}
+		out = append(out, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list project members: %w", err)
+	}
+	return out, nil
+}
+
+// ListProjectIDsByUser はユーザーがメンバーのプロジェクトの ID を返す（順不同）。
+func (r *SQLMemberRepository) ListProjectIDsByUser(ctx context.Context, userID string) ([]string, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, "SELECT project_id FROM project_members WHERE user_id = $1", userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects of member: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects of member: %w", err)
+	}
+	return ids, nil
+}
+
+// isForeignKeyViolation は err が外部キー制約違反かどうかを返す。
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation
+}
+
+func scanMember(row pgx.Row) (*domain.Member, error) {
+	var m domain.Member
+	var role string
+	if err := row.Scan(&m.ProjectID, &m.UserID, &role, &m.JoinedAt); err != nil {
+		return nil, err
+	}
+	m.Role = domain.MemberRole(role)
+	return &m, nil
+}
@@ -0,0 +1,69 @@
+//go:build integration
+// +build integration
+
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	"teamflow-projects/internal/testutil"
+)
+
+func TestSQLMemberRepository(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	projects := NewSQLProjectRepository(db)
+	repo := NewSQLMemberRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	p := newTestProject(t, "proj-1", "TeamFlow 開発", "", now)
+	if err := projects.Save(ctx, p); err != nil {
+		t.Fatalf("failed to save project: %v", err)
+	}
+
+	owner, _ := domain.NewMember(p.ID, "user-a", "owner", now)
+	member, _ := domain.NewMember(p.ID, "user-b", "", now.Add(time.Minute))
+	for _, m := range []*domain.Member{member, owner} {
+		if err := repo.AddMember(ctx, m); err != nil {
+			t.Fatalf("failed to add member: %v", err)
+		}
+	}
+
+	if err := repo.AddMember(ctx, owner); !errors.Is(err, ErrMemberAlreadyExists) {
+		t.Errorf("expected ErrMemberAlreadyExists, got %v", err)
+	}
+	orphan, _ := domain.NewMember("non-existent", "user-a", "", now)
+	if err := repo.AddMember(ctx, orphan); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+
+	got, err := repo.ListMembers(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("failed to list members: %v", err)
+	}
+	if len(got) != 2 || got[0].UserID != "user-a" || got[0].Role != domain.RoleOwner || got[1].UserID != "user-b" {
+		t.Fatalf("unexpected members: %+v, %+v", got[0], got[1])
+	}
+
+	if ids, err := repo.ListProjectIDsByUser(ctx, "user-b"); err != nil || len(ids) != 1 || ids[0] != p.ID {
+		t.Errorf("unexpected project ids: %v (err=%v)", ids, err)
+	}
+	if ids, err := repo.ListProjectIDsByUser(ctx, "stranger"); err != nil || len(ids) != 0 {
+		t.Errorf("expected no project ids, got %v (err=%v)", ids, err)
+	}
+
+	if err := repo.RemoveMember(ctx, p.ID, "user-b"); err != nil {
+		t.Fatalf("failed to remove member: %v", err)
+	}
+	if _, err := repo.FindMember(ctx, p.ID, "user-b"); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("expected ErrMemberNotFound, got %v", err)
+	}
+	if err := repo.RemoveMember(ctx, p.ID, "user-b"); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("expected ErrMemberNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// SQLMilestoneRepository はPostgreSQLを使用したMilestoneRepository実装。
+type SQLMilestoneRepository struct {
+	db *pgxpool.Pool
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.MilestoneRepository = (*SQLMilestoneRepository)(nil)
+
+// NewSQLMilestoneRepository は新しいSQLMilestoneRepositoryを生成する。
+func NewSQLMilestoneRepository(db *pgxpool.Pool) *SQLMilestoneRepository {
+	return &SQLMilestoneRepository{
+		db: db,
+	}
+}
+
+// milestoneColumns は SELECT 時のカラム順。scanMilestone の Scan 順と一致させる。
+const milestoneColumns = "project_id, id, name, due_date, status, created_at, updated_at"
+
+// SaveMilestone はマイルストーンを保存する。
+// プロジェクト内に同じ ID がある場合は ErrMilestoneAlreadyExists、プロジェクトが存在しない場合は ErrProjectNotFound を返す。
+func (r *SQLMilestoneRepository) SaveMilestone(ctx context.Context, m *domain.Milestone) error {
+	_, err := conn(ctx, r.db).Exec(ctx,
+		"INSERT INTO project_milestones ("+milestoneColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7)",
+		m.ProjectID, m.ID, m.Name, m.DueDate, string(m.Status), m.CreatedAt, m.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgUniqueViolation:
+				return ErrMilestoneAlreadyExists
+			case pgForeignKeyViolation:
+				return ErrProjectNotFound
+			}
+		}
+		return fmt.Errorf("failed to insert project milestone: %w", err)
+	}
+	return nil
+}
+
+// UpdateMilestone はマイルストーンを更新する。存在しない場合は ErrMilestoneNotFound を返す。
+func (r *SQLMilestoneRepository) UpdateMilestone(ctx context.Context, m *domain.Milestone) error {
+	tag, err := conn(ctx, r.db).Exec(ctx, `
+		UPDATE project_milestones SET
+			name = $3,
+			due_date = $4,
+			status = $5,
+			updated_at = $6
+		WHERE project_id = $1 AND id = $2
+	`, m.ProjectID, m.ID, m.Name, m.DueDate, string(m.Status), m.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update project milestone: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrMilestoneNotFound
+	}
+	return nil
+}
+
+// DeleteMilestone はマイルストーンを削除する。存在しない場合は ErrMilestoneNotFound を返す。
+func (r *SQLMilestoneRepository) DeleteMilestone(ctx context.Context, projectID, id string) error {
+	tag, err := conn(ctx, r.db).Exec(ctx,
+		"DELETE FROM project_milestones WHERE project_id = $1 AND id = $2",
+		projectID, id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete project milestone: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrMilestoneNotFound
+	}
+	return nil
+}
+
+// FindMilestone はマイルストーンを取得する。存在しない場合は ErrMilestoneNotFound を返す。
+func (r *SQLMilestoneRepository) FindMilestone(ctx context.Context, projectID, id string) (*domain.Milestone, error) {
+	row := conn(ctx, r.db).QueryRow(ctx,
+		"SELECT "+milestoneColumns+" FROM project_milestones WHERE project_id = $1 AND id = $2",
+		projectID, id,
+	)
+	m, err := scanMilestone(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrMilestoneNotFound
+		}
+		return nil, fmt.Errorf("failed to find project milestone: %w", err)
+	}
+	return m, nil
+}
+
+// ListMilestones はマイルストーンを期限の昇順（期限なしは後ろ、同じ場合は作成日時・ID 順）で返す。
+func (r *SQLMilestoneRepository) ListMilestones(ctx context.Context, projectID string) ([]*domain.Milestone, error) {
+	rows, err := conn(ctx, r.db).Query(ctx,
+		"SELECT "+milestoneColumns+" FROM project_milestones WHERE project_id = $1 ORDER BY due_date ASC NULLS LAST, created_at ASC, id ASC",
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project milestones: %w", err)
+	}
+	defer rows.Close()
+
+	milestones := []*domain.Milestone{}
+	for rows.Next() {
+		m, err := scanMilestone(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project milestone: %w", err)
+		}
+		milestones = append(milestones, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate project milestones: %w", err)
+	}
+	return milestones, nil
+}
+
+// scanMilestone は milestoneColumns の順で 1 行を読み取る。
+func scanMilestone(row pgx.Row) (*domain.Milestone, error) {
+	var m domain.Milestone
+	var status string
+	if err := row.Scan(&m.ProjectID, &m.ID, &m.Name, &m.DueDate, &status, &m.CreatedAt, &m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	m.Status = domain.MilestoneStatus(status)
+	return &m, nil
+}
@@ -0,0 +1,81 @@
+//go:build integration
+// +build integration
+
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	"teamflow-projects/internal/testutil"
+)
+
+func TestSQLMilestoneRepository(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	projects := NewSQLProjectRepository(db)
+	repo := NewSQLMilestoneRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	if err := projects.Save(ctx, newTestProject(t, "proj-1", "Project 1", "", now)); err != nil {
+		t.Fatalf("failed to save project: %v", err)
+	}
+
+	due := now.AddDate(0, 1, 0)
+	for _, m := range []*domain.Milestone{
+		{ID: "no-due", ProjectID: "proj-1", Name: "期限なし", Status: domain.MilestoneOpen, CreatedAt: now, UpdatedAt: now},
+		{ID: "v1", ProjectID: "proj-1", Name: "v1", DueDate: &due, Status: domain.MilestoneOpen, CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := repo.SaveMilestone(ctx, m); err != nil {
+			t.Fatalf("failed to save %s: %v", m.ID, err)
+		}
+	}
+	if err := repo.SaveMilestone(ctx, &domain.Milestone{ID: "v1", ProjectID: "proj-1", Name: "dup", Status: domain.MilestoneOpen, CreatedAt: now, UpdatedAt: now}); !errors.Is(err, ErrMilestoneAlreadyExists) {
+		t.Errorf("expected ErrMilestoneAlreadyExists, got %v", err)
+	}
+	if err := repo.SaveMilestone(ctx, &domain.Milestone{ID: "v1", ProjectID: "non-existent", Name: "v1", Status: domain.MilestoneOpen, CreatedAt: now, UpdatedAt: now}); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+
+	list, err := repo.ListMilestones(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != "v1" || list[1].ID != "no-due" {
+		t.Fatalf("unexpected order: %+v", list)
+	}
+	if list[0].DueDate == nil || !list[0].DueDate.Equal(due) || list[1].DueDate != nil {
+		t.Errorf("unexpected due dates: %v, %v", list[0].DueDate, list[1].DueDate)
+	}
+
+	v1 := list[0]
+	v1.Status = domain.MilestoneClosed
+	v1.UpdatedAt = now.Add(time.Hour)
+	if err := repo.UpdateMilestone(ctx, v1); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	got, err := repo.FindMilestone(ctx, "proj-1", "v1")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.Status != domain.MilestoneClosed || !got.UpdatedAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("unexpected milestone: %+v", got)
+	}
+
+	if err := repo.DeleteMilestone(ctx, "proj-1", "v1"); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	if _, err := repo.FindMilestone(ctx, "proj-1", "v1"); !errors.Is(err, ErrMilestoneNotFound) {
+		t.Errorf("expected ErrMilestoneNotFound, got %v", err)
+	}
+	if err := repo.DeleteMilestone(ctx, "proj-1", "v1"); !errors.Is(err, ErrMilestoneNotFound) {
+		t.Errorf("expected ErrMilestoneNotFound on second delete, got %v", err)
+	}
+	if err := repo.UpdateMilestone(ctx, v1); !errors.Is(err, ErrMilestoneNotFound) {
+		t.Errorf("expected ErrMilestoneNotFound on update, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+//go:build integration
+// +build integration
+
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"teamflow-shared/events"
+	"teamflow-shared/outbox"
+
+	"teamflow-projects/internal/testutil"
+)
+
+// TestSQLOutbox は記録がトランザクションに従うこと、読み出したイベントを配信済みにできることを検証する。
+func TestSQLOutbox(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "TRUNCATE TABLE outbox"); err != nil {
+		t.Fatalf("failed to truncate outbox: %v", err)
+	}
+	o := outbox.NewSQLOutbox(db, TxFromContext)
+	txm := NewPgxTxManager(db)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	event := func(id string) events.Event {
+		return events.NewProjectEvent(events.ProjectCreated, "default", events.ProjectData{ID: id, Name: id, Status: "active"}, now)
+	}
+
+	if err := o.Append(ctx, event("proj-1")); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	boom := errors.New("boom")
+	err := txm.WithinTx(ctx, func(ctx context.Context) error {
+		if err := o.Append(ctx, event("proj-2")); err != nil {
+			return err
+		}
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected %v, got %v", boom, err)
+	}
+
+	msgs, err := o.Claim(ctx, now, time.Minute, 10)
+	if err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if len(msgs) != 1 || msgs[0].Event.AggregateID != "proj-1" || msgs[0].Event.Type != events.ProjectCreated {
+		t.Fatalf("expected only proj-1 (rolled back event excluded), got %+v", msgs)
+	}
+	if err := o.MarkPublished(ctx, []int64{msgs[0].Seq}, now); err != nil {
+		t.Fatalf("MarkPublished: %v", err)
+	}
+	if again, err := o.Claim(ctx, now.Add(time.Hour), time.Minute, 10); err != nil || len(again) != 0 {
+		t.Errorf("expected published events not to be claimed again, got %+v, %v", again, err)
+	}
+}
@@ -0,0 +1,97 @@
+package projectinfra
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// SQLPreferenceRepository はPostgreSQLを使用したPreferenceRepository実装。
+type SQLPreferenceRepository struct {
+	db *pgxpool.Pool
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.PreferenceRepository = (*SQLPreferenceRepository)(nil)
+
+// NewSQLPreferenceRepository は新しいSQLPreferenceRepositoryを生成する。
+func NewSQLPreferenceRepository(db *pgxpool.Pool) *SQLPreferenceRepository {
+	return &SQLPreferenceRepository{
+		db: db,
+	}
+}
+
+// ListPreferences はユーザーの表示設定をすべて返す。
+func (r *SQLPreferenceRepository) ListPreferences(ctx context.Context, userID string) ([]*domain.Preference, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, `
+		SELECT user_id, project_id, favorite, position, updated_at
+		FROM project_preferences
+		WHERE user_id = $1
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project preferences: %w", err)
+	}
+	prefs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.Preference, error) {
+		var p domain.Preference
+		err := row.Scan(&p.UserID, &p.ProjectID, &p.Favorite, &p.Position, &p.UpdatedAt)
+		return &p, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// SetFavorite はお気に入りを設定・解除する。
+// プロジェクトが存在しない場合は ErrProjectNotFound を返す。
+func (r *SQLPreferenceRepository) SetFavorite(ctx context.Context, userID, projectID string, favorite bool, now time.Time) error {
+	_, err := conn(ctx, r.db).Exec(ctx, `
+		INSERT INTO project_preferences (user_id, project_id, favorite, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, project_id) DO UPDATE SET
+			favorite = EXCLUDED.favorite,
+			updated_at = EXCLUDED.updated_at
+	`, userID, projectID, favorite, now)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrProjectNotFound
+		}
+		return fmt.Errorf("failed to save project preference: %w", err)
+	}
+	return nil
+}
+
+// ReplaceOrder はユーザーの並び順を projectIDs の順に置き換える。
+// 解除と設定を 1 つの文で行うため、途中の状態が見えることはない。
+// プロジェクトが存在しない場合は ErrProjectNotFound を返す。
+func (r *SQLPreferenceRepository) ReplaceOrder(ctx context.Context, userID string, projectIDs []string, now time.Time) error {
+	if projectIDs == nil {
+		projectIDs = []string{}
+	}
+	_, err := conn(ctx, r.db).Exec(ctx, `
+		WITH cleared AS (
+			UPDATE project_preferences
+			SET position = NULL, updated_at = $3
+			WHERE user_id = $1 AND position IS NOT NULL AND NOT (project_id = ANY($2::text[]))
+		)
+		INSERT INTO project_preferences (user_id, project_id, position, updated_at)
+		SELECT $1, t.project_id, t.ord - 1, $3
+		FROM unnest($2::text[]) WITH ORDINALITY AS t(project_id, ord)
+		ON CONFLICT (user_id, project_id) DO UPDATE SET
+			position = EXCLUDED.position,
+			updated_at = EXCLUDED.updated_at
+	`, userID, projectIDs, now)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrProjectNotFound
+		}
+		return fmt.Errorf("failed to replace project order: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+//go:build integration
+// +build integration
+
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	"teamflow-projects/internal/testutil"
+)
+
+func TestSQLPreferenceRepository(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	projects := NewSQLProjectRepository(db)
+	repo := NewSQLPreferenceRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	for _, id := range []string{"proj-1", "proj-2", "proj-3"} {
+		if err := projects.Save(ctx, newTestProject(t, id, "Project "+id, "", now)); err != nil {
+			t.Fatalf("failed to save project: %v", err)
+		}
+	}
+
+	if err := repo.SetFavorite(ctx, "user-1", "proj-1", true, now); err != nil {
+		t.Fatalf("failed to set favorite: %v", err)
+	}
+	if err := repo.ReplaceOrder(ctx, "user-1", []string{"proj-2", "proj-1"}, now); err != nil {
+		t.Fatalf("failed to replace order: %v", err)
+	}
+	if err := repo.ReplaceOrder(ctx, "user-1", []string{"proj-3", "proj-1"}, now.Add(time.Hour)); err != nil {
+		t.Fatalf("failed to replace order: %v", err)
+	}
+
+	prefs, err := repo.ListPreferences(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("failed to list preferences: %v", err)
+	}
+	domain.SortPreferences(prefs)
+	if len(prefs) != 3 {
+		t.Fatalf("expected 3 preferences, got %d", len(prefs))
+	}
+	if p := prefs[0]; p.ProjectID != "proj-1" || !p.Favorite || p.Position == nil || *p.Position != 1 {
+		t.Errorf("unexpected preference: %+v", p)
+	}
+	if p := prefs[1]; p.ProjectID != "proj-3" || p.Position == nil || *p.Position != 0 || !p.UpdatedAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("unexpected preference: %+v", p)
+	}
+	if p := prefs[2]; p.ProjectID != "proj-2" || !p.IsEmpty() {
+		t.Errorf("expected proj-2 to have no preference left, got %+v", p)
+	}
+
+	if err := repo.SetFavorite(ctx, "user-1", "non-existent", true, now); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+	if err := repo.ReplaceOrder(ctx, "user-1", []string{"non-existent"}, now); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+}
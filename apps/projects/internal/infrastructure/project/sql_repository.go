@@ -0,0 +1,387 @@
+package projectinfra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"teamflow-shared/sqlbuilder"
+	"teamflow-shared/workspace"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// SQLProjectRepository はPostgreSQLを使用したProjectRepository実装。
+//
+// すべての問い合わせを context のワークスペース（workspace.FromContext）で絞り込む。
+// 別のワークスペースのプロジェクトは存在しないものとして扱う。
+type SQLProjectRepository struct {
+	db *pgxpool.Pool
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var (
+	_ usecase.ProjectRepository     = (*SQLProjectRepository)(nil)
+	_ usecase.TaskCounterRepository = (*SQLProjectRepository)(nil)
+	_ usecase.ProjectPurger         = (*SQLProjectRepository)(nil)
+)
+
+// NewSQLProjectRepository は新しいSQLProjectRepositoryを生成する。
+func NewSQLProjectRepository(db *pgxpool.Pool) *SQLProjectRepository {
+	return &SQLProjectRepository{
+		db: db,
+	}
+}
+
+// projectColumns は SELECT 時のカラム順。scanProject の Scan 順と一致させる。
+const projectColumns = "id, key, name, description, status, created_at, updated_at, archived_at, deleted_at, delete_policy, visibility, created_by, updated_by, workspace_id, open_count, done_count"
+
+// projectKeyIndex は key のワークスペース内の一意インデックス名（0019_add_projects_workspace_id）。
+const projectKeyIndex = "idx_projects_workspace_key"
+
+// Save はプロジェクトを context のワークスペースに保存し、p.WorkspaceID を設定する。
+// Key が同じワークスペースで重複する場合は ErrProjectKeyAlreadyExists を返す。
+func (r *SQLProjectRepository) Save(ctx context.Context, p *domain.Project) error {
+	workspaceID := workspace.FromContext(ctx)
+	_, err := conn(ctx, r.db).Exec(ctx,
+		"INSERT INTO projects ("+projectColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)",
+		p.ID, nullIfEmpty(p.Key), p.Name, nullIfEmpty(p.Description), string(p.Status), p.CreatedAt, p.UpdatedAt, p.ArchivedAt,
+		p.DeletedAt, nullIfEmpty(string(p.DeletePolicy)), visibilityOrDefault(p.Visibility), p.CreatedBy, p.UpdatedBy,
+		workspaceID, p.OpenTasks, p.DoneTasks,
+	)
+	if err != nil {
+		if isKeyViolation(err) {
+			return ErrProjectKeyAlreadyExists
+		}
+		return fmt.Errorf("failed to insert project: %w", err)
+	}
+	p.WorkspaceID = workspaceID
+	return nil
+}
+
+// Update は既存プロジェクト（削除されたプロジェクトを含む）を更新する。存在しない場合は ErrProjectNotFound、
+// Key が重複する場合は ErrProjectKeyAlreadyExists を返す。タスク数（OpenTasks / DoneTasks）は ApplyTaskCounts でのみ更新する。
+func (r *SQLProjectRepository) Update(ctx context.Context, p *domain.Project) error {
+	tag, err := conn(ctx, r.db).Exec(ctx, `
+		UPDATE projects SET
+			key = $2,
+			name = $3,
+			description = $4,
+			status = $5,
+			updated_at = $6,
+			archived_at = $7,
+			deleted_at = $8,
+			delete_policy = $9,
+			visibility = $10,
+			updated_by = $11
+		WHERE id = $1 AND workspace_id = $12
+	`,
+		p.ID, nullIfEmpty(p.Key), p.Name, nullIfEmpty(p.Description), string(p.Status), p.UpdatedAt, p.ArchivedAt,
+		p.DeletedAt, nullIfEmpty(string(p.DeletePolicy)), visibilityOrDefault(p.Visibility), p.UpdatedBy,
+		workspace.FromContext(ctx),
+	)
+	if err != nil {
+		if isKeyViolation(err) {
+			return ErrProjectKeyAlreadyExists
+		}
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrProjectNotFound
+	}
+	return nil
+}
+
+// FindByID はIDを指定してプロジェクトを取得する。存在しない（削除された）場合は ErrProjectNotFound を返す。
+func (r *SQLProjectRepository) FindByID(ctx context.Context, id string) (*domain.Project, error) {
+	row := conn(ctx, r.db).QueryRow(ctx, "SELECT "+projectColumns+" FROM projects WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL", id, workspace.FromContext(ctx))
+	p, err := scanProject(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, fmt.Errorf("failed to find project: %w", err)
+	}
+	return p, nil
+}
+
+// FindDeleted は ID を指定して削除されたプロジェクトを取得する。
+// 存在しない、または削除されていない場合は ErrProjectNotFound を返す。
+func (r *SQLProjectRepository) FindDeleted(ctx context.Context, id string) (*domain.Project, error) {
+	row := conn(ctx, r.db).QueryRow(ctx, "SELECT "+projectColumns+" FROM projects WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NOT NULL", id, workspace.FromContext(ctx))
+	p, err := scanProject(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, fmt.Errorf("failed to find deleted project: %w", err)
+	}
+	return p, nil
+}
+
+// FindByName は名前が一致する（前後の空白と大文字小文字を無視する）最も古いプロジェクトを取得する。
+// 存在しない場合は ErrProjectNotFound を返す。
+func (r *SQLProjectRepository) FindByName(ctx context.Context, name string) (*domain.Project, error) {
+	row := conn(ctx, r.db).QueryRow(ctx,
+		"SELECT "+projectColumns+" FROM projects WHERE lower(btrim(name)) = lower(btrim($1)) AND workspace_id = $2 AND deleted_at IS NULL ORDER BY created_at ASC, id ASC LIMIT 1",
+		name, workspace.FromContext(ctx),
+	)
+	p, err := scanProject(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, fmt.Errorf("failed to find project by name: %w", err)
+	}
+	return p, nil
+}
+
+// List はワークスペースの削除されていないすべてのプロジェクトを作成日時順（同時刻は ID 順）で返す。
+func (r *SQLProjectRepository) List(ctx context.Context) ([]*domain.Project, error) {
+	rows, err := conn(ctx, r.db).Query(ctx,
+		"SELECT "+projectColumns+" FROM projects WHERE workspace_id = $1 AND deleted_at IS NULL ORDER BY created_at ASC, id ASC",
+		workspace.FromContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]*domain.Project, 0)
+	for rows.Next() {
+		p, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		out = append(out, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	return out, nil
+}
+
+// FindWithQuery は Query Object に基づいてプロジェクトを取得する。
+// nextCursor 判定のため limit + 1 件まで返す。
+func (r *SQLProjectRepository) FindWithQuery(ctx context.Context, query *domain.ProjectQuery) ([]*domain.Project, error) {
+	querySQL, args := buildFindQuery(workspace.FromContext(ctx), query)
+
+	rows, err := conn(ctx, r.db).Query(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query projects: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]*domain.Project, 0)
+	for rows.Next() {
+		p, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		out = append(out, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return out, nil
+}
+
+// PurgeCandidates は before より前にアーカイブ、または削除されたプロジェクトを、アーカイブ・削除の早い方の日時と ID の順に
+// 最大 limit 件返す。すべてのワークスペースのプロジェクトを対象にする（保持期間を過ぎたプロジェクトの物理削除に使う）。
+func (r *SQLProjectRepository) PurgeCandidates(ctx context.Context, before time.Time, limit int) ([]*domain.Project, error) {
+	rows, err := conn(ctx, r.db).Query(ctx,
+		"SELECT "+projectColumns+" FROM projects WHERE archived_at < $1 OR deleted_at < $1 ORDER BY LEAST(archived_at, deleted_at) ASC, id ASC LIMIT $2",
+		before, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find projects to purge: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]*domain.Project, 0)
+	for rows.Next() {
+		p, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		out = append(out, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find projects to purge: %w", err)
+	}
+	return out, nil
+}
+
+// HardDelete は id のプロジェクトを物理削除する。メンバー・設定・マイルストーンなどは外部キー（ON DELETE CASCADE）で削除される。
+// ワークスペースでは絞り込まない。存在しない場合は ErrProjectNotFound を返す。
+func (r *SQLProjectRepository) HardDelete(ctx context.Context, id string) error {
+	tag, err := conn(ctx, r.db).Exec(ctx, "DELETE FROM projects WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrProjectNotFound
+	}
+	return nil
+}
+
+// applyTaskCountsSQL は processed_events にイベントを記録できた（初めて処理する）場合だけプロジェクトのタスク数を加算する。
+// 1 つの文で行うため、記録と加算の片方だけが反映されることはない。
+const applyTaskCountsSQL = `
+	WITH processed AS (
+		INSERT INTO processed_events (event_id, processed_at) VALUES ($1, $2)
+		ON CONFLICT (event_id) DO NOTHING
+		RETURNING event_id
+	)
+	UPDATE projects SET open_count = open_count + $3, done_count = done_count + $4
+	WHERE id = $5 AND workspace_id = $6 AND EXISTS (SELECT 1 FROM processed)
+`
+
+// ApplyTaskCounts は eventID を処理済みとして記録し、プロジェクトのタスク数に open / done を加える。
+// eventID が処理済みの場合は何もしない。
+func (r *SQLProjectRepository) ApplyTaskCounts(ctx context.Context, eventID, projectID string, open, done int, now time.Time) error {
+	_, err := conn(ctx, r.db).Exec(ctx, applyTaskCountsSQL, eventID, now, open, done, projectID, workspace.FromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to apply task counts: %w", err)
+	}
+	return nil
+}
+
+// DeleteProcessedEvents は before より前に処理したイベントの記録を削除する。
+func (r *SQLProjectRepository) DeleteProcessedEvents(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := conn(ctx, r.db).Exec(ctx, "DELETE FROM processed_events WHERE processed_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete processed events: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// sortColumns はソートキーに対応する SQL の式（ホワイトリスト）。
+// name は memory 実装と同じくバイト順で比較する（idx_projects_name_id と一致させる）。
+var sortColumns = map[string]string{
+	domain.SortKeyName:      `(name COLLATE "C")`,
+	domain.SortKeyCreatedAt: "created_at",
+}
+
+// buildFindQuery は FindWithQuery の SQL とパラメータを組み立てる。workspaceID のプロジェクトだけを対象にする。
+func buildFindQuery(workspaceID string, query *domain.ProjectQuery) (string, []interface{}) {
+	b := sqlbuilder.NewSelect(projectColumns, "projects")
+	b.Where("workspace_id = " + b.Arg(workspaceID))
+	b.Where("deleted_at IS NULL")
+
+	// q に含まれる % / _ はワイルドカードではなく文字として扱う。
+	if query.Query != nil {
+		b.Where("name ILIKE " + b.Arg("%"+sqlbuilder.EscapeLike(*query.Query)+"%"))
+	}
+
+	if query.Archived != nil {
+		if *query.Archived {
+			b.Where("archived_at IS NOT NULL")
+		} else {
+			b.Where("archived_at IS NULL")
+		}
+	}
+
+	if len(query.Statuses) > 0 {
+		values := make([]interface{}, len(query.Statuses))
+		for i, status := range query.Statuses {
+			values[i] = string(status)
+		}
+		b.Where("status IN (" + b.ArgList(values...) + ")")
+	}
+
+	// 閲覧権限: 公開プロジェクトか、閲覧者がメンバーのプロジェクト
+	if query.ReadableOnly {
+		b.Where("(visibility = " + b.Arg(string(domain.VisibilityPublic)) + " OR id = ANY(" + b.Arg(query.MemberProjectIDs) + "))")
+	}
+
+	s := query.EffectiveSort()
+	column := sortColumns[s.Key]
+	op, direction := ">", "ASC"
+	if s.Direction == domain.SortDirectionDESC {
+		op, direction = "<", "DESC"
+	}
+
+	// Cursor がある場合の seek 条件: (key, id) > (cursorKey, cursorID)（DESC は <）
+	if query.Cursor != nil {
+		var key interface{} = query.Cursor.CreatedAt
+		if s.Key == domain.SortKeyName {
+			key = query.Cursor.Name
+		}
+		b.Where("(" + column + ", id) " + op + " (" + b.Arg(key) + ", " + b.Arg(query.Cursor.ID) + ")")
+	}
+
+	b.OrderBy(column+" "+direction, "id "+direction)
+
+	// LIMIT句（nextCursor 判定のため limit + 1 件取得）
+	b.Limit(query.Limit + 1)
+
+	return b.Build()
+}
+
+func scanProject(row pgx.Row) (*domain.Project, error) {
+	var p domain.Project
+	var key, description, deletePolicy sql.NullString
+	var status, visibility string
+
+	err := row.Scan(
+		&p.ID,
+		&key,
+		&p.Name,
+		&description,
+		&status,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+		&p.ArchivedAt,
+		&p.DeletedAt,
+		&deletePolicy,
+		&visibility,
+		&p.CreatedBy,
+		&p.UpdatedBy,
+		&p.WorkspaceID,
+		&p.OpenTasks,
+		&p.DoneTasks,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.Valid {
+		p.Key = key.String
+	}
+	if description.Valid {
+		p.Description = description.String
+	}
+	p.Status = domain.ProjectStatus(status)
+	p.DeletePolicy = domain.DeletePolicy(deletePolicy.String)
+	p.Visibility = domain.Visibility(visibility)
+	return &p, nil
+}
+
+// isKeyViolation は err が key の一意インデックス違反かどうかを返す。
+func isKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == projectKeyIndex
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// visibilityOrDefault は公開範囲が未設定の場合に private を返す（NewProject を経由しないプロジェクト用）。
+func visibilityOrDefault(v domain.Visibility) string {
+	if v == "" {
+		return string(domain.VisibilityPrivate)
+	}
+	return string(v)
+}
@@ -0,0 +1,459 @@
+//go:build integration
+// +build integration
+
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"teamflow-shared/workspace"
+
+	domain "teamflow-projects/internal/domain/project"
+	"teamflow-projects/internal/testutil"
+)
+
+func newTestProject(t *testing.T, id, name, description string, createdAt time.Time) *domain.Project {
+	t.Helper()
+	p, err := domain.NewProject(id, name, description, createdAt)
+	if err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	return p
+}
+
+func TestSQLProjectRepository_SaveAndFindByID(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	repo := NewSQLProjectRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 123456000, time.UTC)
+	p := newTestProject(t, "proj-1", "TeamFlow 開発", "TeamFlow の開発プロジェクト", now)
+	if err := repo.Save(ctx, p); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	got, err := repo.FindByID(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.Name != p.Name || got.Description != p.Description {
+		t.Errorf("expected (%q, %q), got (%q, %q)", p.Name, p.Description, got.Name, got.Description)
+	}
+	if !got.CreatedAt.Equal(now) || !got.UpdatedAt.Equal(now) {
+		t.Errorf("expected timestamps %v, got (%v, %v)", now, got.CreatedAt, got.UpdatedAt)
+	}
+}
+
+func TestSQLProjectRepository_EmptyDescription(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	repo := NewSQLProjectRepository(db)
+	ctx := context.Background()
+
+	p := newTestProject(t, "proj-1", "TeamFlow 開発", "", time.Now().UTC())
+	if err := repo.Save(ctx, p); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	// 空文字は NULL として保存される
+	var isNull bool
+	if err := db.QueryRow(ctx, "SELECT description IS NULL FROM projects WHERE id = $1", p.ID).Scan(&isNull); err != nil {
+		t.Fatalf("failed to query: %v", err)
+	}
+	if !isNull {
+		t.Error("expected empty description to be stored as NULL")
+	}
+
+	got, err := repo.FindByID(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.Description != "" {
+		t.Errorf("expected empty description, got %q", got.Description)
+	}
+}
+
+func TestSQLProjectRepository_SaveDuplicateID(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	repo := NewSQLProjectRepository(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	if err := repo.Save(ctx, newTestProject(t, "proj-1", "A", "", now)); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	if err := repo.Save(ctx, newTestProject(t, "proj-1", "B", "", now)); err == nil {
+		t.Fatal("expected error for duplicate ID, got nil")
+	}
+}
+
+func TestSQLProjectRepository_FindByIDNotFound(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	repo := NewSQLProjectRepository(db)
+
+	if _, err := repo.FindByID(context.Background(), "non-existent"); !errors.Is(err, ErrProjectNotFound) {
+		t.Fatalf("expected ErrProjectNotFound, got %v", err)
+	}
+}
+
+func TestSQLProjectRepository_Update(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	repo := NewSQLProjectRepository(db)
+	ctx := context.Background()
+
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	p := newTestProject(t, "proj-1", "Old Name", "Old Desc", createdAt)
+	p.CreatedBy, p.UpdatedBy = "user-1", "user-1"
+	if err := repo.Save(ctx, p); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	updatedAt := createdAt.Add(time.Hour)
+	p.Name = "New Name"
+	p.Description = "New Desc"
+	p.UpdatedAt = updatedAt
+	p.UpdatedBy = "user-2"
+	if err := repo.Update(ctx, p); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+
+	got, err := repo.FindByID(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.Name != "New Name" || got.Description != "New Desc" {
+		t.Errorf("expected updated fields, got (%q, %q)", got.Name, got.Description)
+	}
+	if !got.CreatedAt.Equal(createdAt) {
+		t.Errorf("expected CreatedAt to remain %v, got %v", createdAt, got.CreatedAt)
+	}
+	if !got.UpdatedAt.Equal(updatedAt) {
+		t.Errorf("expected UpdatedAt %v, got %v", updatedAt, got.UpdatedAt)
+	}
+	if got.CreatedBy != "user-1" || got.UpdatedBy != "user-2" {
+		t.Errorf("expected createdBy=user-1 and updatedBy=user-2, got (%q, %q)", got.CreatedBy, got.UpdatedBy)
+	}
+}
+
+func TestSQLProjectRepository_UpdateNotFound(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	repo := NewSQLProjectRepository(db)
+
+	p := newTestProject(t, "non-existent", "Name", "", time.Now().UTC())
+	if err := repo.Update(context.Background(), p); !errors.Is(err, ErrProjectNotFound) {
+		t.Fatalf("expected ErrProjectNotFound, got %v", err)
+	}
+}
+
+func TestSQLProjectRepository_List(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	repo := NewSQLProjectRepository(db)
+	ctx := context.Background()
+
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	// 作成日時順（同時刻は ID 順）で返ることを確認するため、保存順をずらす
+	for _, p := range []*domain.Project{
+		newTestProject(t, "proj-c", "C", "", base.Add(time.Hour)),
+		newTestProject(t, "proj-b", "B", "", base),
+		newTestProject(t, "proj-a", "A", "", base),
+	} {
+		if err := repo.Save(ctx, p); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+	}
+
+	got, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+
+	want := []string{"proj-a", "proj-b", "proj-c"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d projects, got %d", len(want), len(got))
+	}
+	for i, id := range want {
+		if got[i].ID != id {
+			t.Errorf("index %d: expected %s, got %s", i, id, got[i].ID)
+		}
+	}
+}
+
+func TestSQLProjectRepository_ListEmpty(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	repo := NewSQLProjectRepository(db)
+
+	got, err := repo.List(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if got == nil || len(got) != 0 {
+		t.Fatalf("expected empty non-nil slice, got %v", got)
+	}
+}
+
+func TestSQLProjectRepository_ArchivedAt(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	repo := NewSQLProjectRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	p := newTestProject(t, "proj-1", "TeamFlow 開発", "", now)
+	if err := repo.Save(ctx, p); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	archivedAt := now.Add(time.Hour)
+	p.ArchivedAt = &archivedAt
+	if err := repo.Update(ctx, p); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+
+	got, err := repo.FindByID(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.ArchivedAt == nil || !got.ArchivedAt.Equal(archivedAt) {
+		t.Errorf("expected ArchivedAt %v, got %v", archivedAt, got.ArchivedAt)
+	}
+}
+
+func TestSQLProjectRepository_Status(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	repo := NewSQLProjectRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	p := newTestProject(t, "proj-1", "TeamFlow 開発", "", now)
+	if err := repo.Save(ctx, p); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	got, err := repo.FindByID(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.Status != domain.StatusActive {
+		t.Errorf("expected status %q, got %q", domain.StatusActive, got.Status)
+	}
+
+	p.Status = domain.StatusOnHold
+	if err := repo.Update(ctx, p); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+
+	got, err = repo.FindByID(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.Status != domain.StatusOnHold {
+		t.Errorf("expected status %q, got %q", domain.StatusOnHold, got.Status)
+	}
+}
+
+func TestSQLProjectRepository_Key(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	repo := NewSQLProjectRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	p1 := newTestProject(t, "proj-1", "TeamFlow 開発", "", now)
+	p1.Key = "TFLOW"
+	if err := repo.Save(ctx, p1); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	// キー未設定（NULL）のプロジェクトは複数保存できる
+	p2 := newTestProject(t, "proj-2", "B", "", now)
+	if err := repo.Save(ctx, p2); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	if err := repo.Save(ctx, newTestProject(t, "proj-3", "C", "", now)); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	got, err := repo.FindByID(ctx, p1.ID)
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.Key != "TFLOW" {
+		t.Errorf("expected key TFLOW, got %q", got.Key)
+	}
+
+	dup := newTestProject(t, "proj-4", "D", "", now)
+	dup.Key = "TFLOW"
+	if err := repo.Save(ctx, dup); !errors.Is(err, ErrProjectKeyAlreadyExists) {
+		t.Fatalf("expected ErrProjectKeyAlreadyExists on Save, got %v", err)
+	}
+
+	p2.Key = "TFLOW"
+	if err := repo.Update(ctx, p2); !errors.Is(err, ErrProjectKeyAlreadyExists) {
+		t.Fatalf("expected ErrProjectKeyAlreadyExists on Update, got %v", err)
+	}
+
+	// 主キーの重複はキーの重複として扱わない
+	if err := repo.Save(ctx, newTestProject(t, "proj-1", "E", "", now)); err == nil || errors.Is(err, ErrProjectKeyAlreadyExists) {
+		t.Fatalf("expected non-key error for duplicate ID, got %v", err)
+	}
+}
+
+func TestSQLProjectRepository_FindByName(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	repo := NewSQLProjectRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	for _, p := range []*domain.Project{
+		newTestProject(t, "proj-2", "TeamFlow", "", now.Add(time.Hour)),
+		newTestProject(t, "proj-1", "teamflow ", "", now),
+		newTestProject(t, "proj-3", "Other", "", now),
+	} {
+		if err := repo.Save(ctx, p); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+	}
+
+	// 大文字小文字と前後の空白は区別せず、最も古いものを返す
+	got, err := repo.FindByName(ctx, " TEAMFLOW")
+	if err != nil {
+		t.Fatalf("failed to find by name: %v", err)
+	}
+	if got.ID != "proj-1" {
+		t.Errorf("expected proj-1, got %s", got.ID)
+	}
+
+	if _, err := repo.FindByName(ctx, "TeamFlow 2"); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+}
+
+// TestSQLProjectRepository_WorkspaceIsolation は別のワークスペースのプロジェクトが取得・一覧・更新の対象にならず、
+// キーの一意性がワークスペースごとであることを検証する。
+func TestSQLProjectRepository_WorkspaceIsolation(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	repo := NewSQLProjectRepository(db)
+	ws1 := workspace.NewContext(context.Background(), "ws-1")
+	ws2 := workspace.NewContext(context.Background(), "ws-2")
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	p1 := newTestProject(t, "proj-1", "Alpha", "", now)
+	p1.Key = "ALPHA"
+	if err := repo.Save(ws1, p1); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	p2 := newTestProject(t, "proj-2", "Alpha", "", now.Add(time.Second))
+	p2.Key = "ALPHA"
+	if err := repo.Save(ws2, p2); err != nil {
+		t.Fatalf("expected the same key in another workspace to be allowed, got %v", err)
+	}
+	p3 := newTestProject(t, "proj-3", "Beta", "", now.Add(2*time.Second))
+	p3.Key = "ALPHA"
+	if err := repo.Save(ws1, p3); !errors.Is(err, ErrProjectKeyAlreadyExists) {
+		t.Fatalf("expected ErrProjectKeyAlreadyExists in the same workspace, got %v", err)
+	}
+
+	if _, err := repo.FindByID(ws1, "proj-2"); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("FindByID: expected ErrProjectNotFound, got %v", err)
+	}
+	if got, err := repo.FindByName(ws2, "alpha"); err != nil || got.ID != "proj-2" {
+		t.Errorf("FindByName = %v, %v, want proj-2", got, err)
+	}
+	list, err := repo.List(ws1)
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if len(list) != 1 || list[0].ID != "proj-1" || list[0].WorkspaceID != "ws-1" {
+		t.Errorf("List = %+v, want [proj-1 in ws-1]", list)
+	}
+	query, err := domain.NewProjectQuery()
+	if err != nil {
+		t.Fatalf("failed to create query: %v", err)
+	}
+	found, err := repo.FindWithQuery(ws2, query)
+	if err != nil {
+		t.Fatalf("failed to query: %v", err)
+	}
+	if len(found) != 1 || found[0].ID != "proj-2" {
+		t.Errorf("FindWithQuery = %+v, want [proj-2]", found)
+	}
+
+	p2.Name = "hijacked"
+	if err := repo.Update(ws1, p2); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("Update: expected ErrProjectNotFound, got %v", err)
+	}
+}
+
+// TestSQLProjectRepository_ApplyTaskCounts は同じイベントを 2 回数えないこと、Update がタスク数を上書きしないことを検証する。
+func TestSQLProjectRepository_ApplyTaskCounts(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "TRUNCATE TABLE processed_events"); err != nil {
+		t.Fatalf("failed to truncate processed_events: %v", err)
+	}
+	repo := NewSQLProjectRepository(db)
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	p := newTestProject(t, "proj-1", "TeamFlow", "", now)
+	if err := repo.Save(ctx, p); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	for _, step := range []struct {
+		eventID    string
+		open, done int
+	}{
+		{"e-1", 1, 0},
+		{"e-2", 1, 0},
+		{"e-1", 1, 0}, // 重複して届いたイベントは数えない
+		{"e-3", -1, 1},
+	} {
+		if err := repo.ApplyTaskCounts(ctx, step.eventID, "proj-1", step.open, step.done, now); err != nil {
+			t.Fatalf("ApplyTaskCounts(%s): %v", step.eventID, err)
+		}
+	}
+	// 別のワークスペースのプロジェクトは更新しない
+	if err := repo.ApplyTaskCounts(workspace.NewContext(ctx, "other"), "e-4", "proj-1", 1, 0, now); err != nil {
+		t.Fatalf("ApplyTaskCounts(other workspace): %v", err)
+	}
+
+	got, err := repo.FindByID(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.OpenTasks != 1 || got.DoneTasks != 1 {
+		t.Errorf("expected counts (1, 1), got (%d, %d)", got.OpenTasks, got.DoneTasks)
+	}
+
+	p.Name = "Renamed"
+	if err := repo.Update(ctx, p); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	if got, _ := repo.FindByID(ctx, "proj-1"); got.OpenTasks != 1 || got.DoneTasks != 1 {
+		t.Errorf("expected Update to keep counts, got (%d, %d)", got.OpenTasks, got.DoneTasks)
+	}
+
+	n, err := repo.DeleteProcessedEvents(ctx, now.Add(time.Second))
+	if err != nil || n != 4 {
+		t.Errorf("DeleteProcessedEvents() = %d, %v, want 4", n, err)
+	}
+}
+
+func TestSQLProjectRepository_Purge(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	testPurgeCandidates(t, NewSQLProjectRepository(db))
+}
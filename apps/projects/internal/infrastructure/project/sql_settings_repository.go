@@ -0,0 +1,101 @@
+package projectinfra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// SQLSettingsRepository はPostgreSQLを使用したSettingsRepository実装。
+type SQLSettingsRepository struct {
+	db *pgxpool.Pool
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.SettingsRepository = (*SQLSettingsRepository)(nil)
+
+// NewSQLSettingsRepository は新しいSQLSettingsRepositoryを生成する。
+func NewSQLSettingsRepository(db *pgxpool.Pool) *SQLSettingsRepository {
+	return &SQLSettingsRepository{
+		db: db,
+	}
+}
+
+// FindSettings は設定を取得する。保存されていない場合は ErrSettingsNotFound を返す。
+func (r *SQLSettingsRepository) FindSettings(ctx context.Context, projectID string) (*domain.Settings, error) {
+	var s domain.Settings
+	var wipLimits, fieldLocks []byte
+	err := conn(ctx, r.db).QueryRow(ctx, `
+		SELECT project_id, default_priority, default_assignee_id, default_sort, wip_limits, field_locks, timezone, updated_at
+		FROM project_settings
+		WHERE project_id = $1
+	`, projectID).Scan(&s.ProjectID, &s.DefaultPriority, &s.DefaultAssigneeID, &s.DefaultSort, &wipLimits, &fieldLocks, &s.Timezone, &s.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSettingsNotFound
+		}
+		return nil, fmt.Errorf("failed to find project settings: %w", err)
+	}
+
+	if err := json.Unmarshal(wipLimits, &s.WIPLimits); err != nil {
+		return nil, fmt.Errorf("failed to decode wip_limits: %w", err)
+	}
+	if s.WIPLimits == nil {
+		s.WIPLimits = map[string]int{}
+	}
+	if err := json.Unmarshal(fieldLocks, &s.FieldLocks); err != nil {
+		return nil, fmt.Errorf("failed to decode field_locks: %w", err)
+	}
+	if s.FieldLocks == nil {
+		s.FieldLocks = map[string][]domain.MemberRole{}
+	}
+	return &s, nil
+}
+
+// SaveSettings は設定を保存する（既存の設定は置き換える）。
+// プロジェクトが存在しない場合は ErrProjectNotFound を返す。
+func (r *SQLSettingsRepository) SaveSettings(ctx context.Context, s *domain.Settings) error {
+	wipLimits := s.WIPLimits
+	if wipLimits == nil {
+		wipLimits = map[string]int{}
+	}
+	encoded, err := json.Marshal(wipLimits)
+	if err != nil {
+		return fmt.Errorf("failed to encode wip_limits: %w", err)
+	}
+	fieldLocks := s.FieldLocks
+	if fieldLocks == nil {
+		fieldLocks = map[string][]domain.MemberRole{}
+	}
+	encodedLocks, err := json.Marshal(fieldLocks)
+	if err != nil {
+		return fmt.Errorf("failed to encode field_locks: %w", err)
+	}
+
+	_, err = conn(ctx, r.db).Exec(ctx, `
+		INSERT INTO project_settings (project_id, default_priority, default_assignee_id, default_sort, wip_limits, field_locks, timezone, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (project_id) DO UPDATE SET
+			default_priority = EXCLUDED.default_priority,
+			default_assignee_id = EXCLUDED.default_assignee_id,
+			default_sort = EXCLUDED.default_sort,
+			wip_limits = EXCLUDED.wip_limits,
+			field_locks = EXCLUDED.field_locks,
+			timezone = EXCLUDED.timezone,
+			updated_at = EXCLUDED.updated_at
+	`, s.ProjectID, s.DefaultPriority, s.DefaultAssigneeID, s.DefaultSort, encoded, encodedLocks, s.Timezone, s.UpdatedAt)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrProjectNotFound
+		}
+		return fmt.Errorf("failed to save project settings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+//go:build integration
+// +build integration
+
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	"teamflow-projects/internal/testutil"
+)
+
+func TestSQLSettingsRepository(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	projects := NewSQLProjectRepository(db)
+	repo := NewSQLSettingsRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	p := newTestProject(t, "proj-1", "TeamFlow 開発", "", now)
+	if err := projects.Save(ctx, p); err != nil {
+		t.Fatalf("failed to save project: %v", err)
+	}
+
+	if _, err := repo.FindSettings(ctx, p.ID); !errors.Is(err, ErrSettingsNotFound) {
+		t.Fatalf("expected ErrSettingsNotFound, got %v", err)
+	}
+
+	want := &domain.Settings{
+		ProjectID:         p.ID,
+		DefaultPriority:   "high",
+		DefaultAssigneeID: "user-1",
+		DefaultSort:       "-priority",
+		WIPLimits:         map[string]int{"in_progress": 3},
+		FieldLocks:        map[string][]domain.MemberRole{"priority": {domain.RoleAdmin, domain.RoleOwner}},
+		Timezone:          "Asia/Tokyo",
+		UpdatedAt:         now,
+	}
+	if err := repo.SaveSettings(ctx, want); err != nil {
+		t.Fatalf("failed to save settings: %v", err)
+	}
+	got, err := repo.FindSettings(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("failed to find settings: %v", err)
+	}
+	if !reflect.DeepEqual(got.FieldLocks, want.FieldLocks) {
+		t.Errorf("FieldLocks = %v, want %v", got.FieldLocks, want.FieldLocks)
+	}
+	if got.Timezone != "Asia/Tokyo" {
+		t.Errorf("Timezone = %q, want Asia/Tokyo", got.Timezone)
+	}
+
+	// 置き換え（省略したフィールドは未設定になる）
+	want = &domain.Settings{ProjectID: p.ID, DefaultPriority: "low", UpdatedAt: now.Add(time.Hour)}
+	if err := repo.SaveSettings(ctx, want); err != nil {
+		t.Fatalf("failed to save settings: %v", err)
+	}
+
+	got, err = repo.FindSettings(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("failed to find settings: %v", err)
+	}
+	if got.DefaultPriority != "low" || got.DefaultAssigneeID != "" || len(got.WIPLimits) != 0 || len(got.FieldLocks) != 0 || got.Timezone != "" || !got.UpdatedAt.Equal(want.UpdatedAt) {
+		t.Errorf("unexpected settings: %+v", got)
+	}
+
+	if err := repo.SaveSettings(ctx, &domain.Settings{ProjectID: "non-existent", UpdatedAt: now}); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+}
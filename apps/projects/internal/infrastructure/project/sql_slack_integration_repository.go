@@ -0,0 +1,87 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// SQLSlackIntegrationRepository はPostgreSQLを使用したSlackIntegrationRepository実装。
+type SQLSlackIntegrationRepository struct {
+	db *pgxpool.Pool
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.SlackIntegrationRepository = (*SQLSlackIntegrationRepository)(nil)
+
+// NewSQLSlackIntegrationRepository は新しいSQLSlackIntegrationRepositoryを生成する。
+func NewSQLSlackIntegrationRepository(db *pgxpool.Pool) *SQLSlackIntegrationRepository {
+	return &SQLSlackIntegrationRepository{
+		db: db,
+	}
+}
+
+// FindSlackIntegration は設定を取得する。保存されていない場合は ErrSlackIntegrationNotFound を返す。
+func (r *SQLSlackIntegrationRepository) FindSlackIntegration(ctx context.Context, projectID string) (*domain.SlackIntegration, error) {
+	var s domain.SlackIntegration
+	var evs []string
+	err := conn(ctx, r.db).QueryRow(ctx, `
+		SELECT project_id, webhook_url, events, updated_at, updated_by
+		FROM project_slack_integrations
+		WHERE project_id = $1
+	`, projectID).Scan(&s.ProjectID, &s.WebhookURL, &evs, &s.UpdatedAt, &s.UpdatedBy)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSlackIntegrationNotFound
+		}
+		return nil, fmt.Errorf("failed to find slack integration: %w", err)
+	}
+	s.Events = make([]domain.SlackEvent, len(evs))
+	for i, e := range evs {
+		s.Events[i] = domain.SlackEvent(e)
+	}
+	return &s, nil
+}
+
+// SaveSlackIntegration は設定を保存する（既存の設定は置き換える）。
+// プロジェクトが存在しない場合は ErrProjectNotFound を返す。
+func (r *SQLSlackIntegrationRepository) SaveSlackIntegration(ctx context.Context, s *domain.SlackIntegration) error {
+	evs := make([]string, len(s.Events))
+	for i, e := range s.Events {
+		evs[i] = string(e)
+	}
+	_, err := conn(ctx, r.db).Exec(ctx, `
+		INSERT INTO project_slack_integrations (project_id, webhook_url, events, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (project_id) DO UPDATE SET
+			webhook_url = EXCLUDED.webhook_url,
+			events = EXCLUDED.events,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by
+	`, s.ProjectID, s.WebhookURL, evs, s.UpdatedAt, s.UpdatedBy)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrProjectNotFound
+		}
+		return fmt.Errorf("failed to save slack integration: %w", err)
+	}
+	return nil
+}
+
+// DeleteSlackIntegration は設定を削除する。保存されていない場合は ErrSlackIntegrationNotFound を返す。
+func (r *SQLSlackIntegrationRepository) DeleteSlackIntegration(ctx context.Context, projectID string) error {
+	tag, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM project_slack_integrations WHERE project_id = $1`, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to delete slack integration: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSlackIntegrationNotFound
+	}
+	return nil
+}
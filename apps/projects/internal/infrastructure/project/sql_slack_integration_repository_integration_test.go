@@ -0,0 +1,71 @@
+//go:build integration
+// +build integration
+
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	"teamflow-projects/internal/testutil"
+)
+
+func TestSQLSlackIntegrationRepository(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	projects := NewSQLProjectRepository(db)
+	repo := NewSQLSlackIntegrationRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	p := newTestProject(t, "proj-1", "TeamFlow 開発", "", now)
+	if err := projects.Save(ctx, p); err != nil {
+		t.Fatalf("failed to save project: %v", err)
+	}
+
+	if _, err := repo.FindSlackIntegration(ctx, p.ID); !errors.Is(err, ErrSlackIntegrationNotFound) {
+		t.Fatalf("expected ErrSlackIntegrationNotFound, got %v", err)
+	}
+
+	want := &domain.SlackIntegration{
+		ProjectID:  p.ID,
+		WebhookURL: "https://hooks.slack.com/services/T0/B0/XYZ",
+		Events:     []domain.SlackEvent{domain.SlackEventTaskCreated, domain.SlackEventTaskAssigned},
+		UpdatedAt:  now,
+		UpdatedBy:  "user-1",
+	}
+	if err := repo.SaveSlackIntegration(ctx, want); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	got, err := repo.FindSlackIntegration(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.WebhookURL != want.WebhookURL || !slices.Equal(got.Events, want.Events) || !got.UpdatedAt.Equal(now) || got.UpdatedBy != "user-1" {
+		t.Errorf("unexpected integration: %+v", got)
+	}
+
+	// 置き換え
+	want = &domain.SlackIntegration{ProjectID: p.ID, WebhookURL: "https://hooks.slack.com/services/T1", Events: domain.AllSlackEvents, UpdatedAt: now.Add(time.Hour)}
+	if err := repo.SaveSlackIntegration(ctx, want); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	if got, err := repo.FindSlackIntegration(ctx, p.ID); err != nil || got.WebhookURL != want.WebhookURL || len(got.Events) != 3 || got.UpdatedBy != "" {
+		t.Errorf("unexpected integration: %+v, %v", got, err)
+	}
+
+	if err := repo.SaveSlackIntegration(ctx, &domain.SlackIntegration{ProjectID: "non-existent", WebhookURL: "https://hooks.slack.com/x", UpdatedAt: now}); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+
+	if err := repo.DeleteSlackIntegration(ctx, p.ID); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	if err := repo.DeleteSlackIntegration(ctx, p.ID); !errors.Is(err, ErrSlackIntegrationNotFound) {
+		t.Errorf("expected ErrSlackIntegrationNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// SQLSprintRepository はPostgreSQLを使用したSprintRepository実装。
+type SQLSprintRepository struct {
+	db *pgxpool.Pool
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.SprintRepository = (*SQLSprintRepository)(nil)
+
+// NewSQLSprintRepository は新しいSQLSprintRepositoryを生成する。
+func NewSQLSprintRepository(db *pgxpool.Pool) *SQLSprintRepository {
+	return &SQLSprintRepository{
+		db: db,
+	}
+}
+
+// sprintColumns は SELECT 時のカラム順。scanSprint の Scan 順と一致させる。
+const sprintColumns = "project_id, id, name, goal, start_date, end_date, state, created_at, updated_at, completed_at"
+
+// SaveSprint はスプリントを保存する。
+// プロジェクト内に同じ ID がある場合は ErrSprintAlreadyExists、プロジェクトが存在しない場合は ErrProjectNotFound を返す。
+func (r *SQLSprintRepository) SaveSprint(ctx context.Context, s *domain.Sprint) error {
+	_, err := conn(ctx, r.db).Exec(ctx,
+		"INSERT INTO project_sprints ("+sprintColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+		s.ProjectID, s.ID, s.Name, s.Goal, s.StartDate, s.EndDate, string(s.State), s.CreatedAt, s.UpdatedAt, s.CompletedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgUniqueViolation:
+				return ErrSprintAlreadyExists
+			case pgForeignKeyViolation:
+				return ErrProjectNotFound
+			}
+		}
+		return fmt.Errorf("failed to insert project sprint: %w", err)
+	}
+	return nil
+}
+
+// UpdateSprint はスプリントを更新する。存在しない場合は ErrSprintNotFound、
+// 開始中の別のスプリントがあるプロジェクトで開始しようとした場合は ErrSprintAlreadyActive を返す。
+func (r *SQLSprintRepository) UpdateSprint(ctx context.Context, s *domain.Sprint) error {
+	tag, err := conn(ctx, r.db).Exec(ctx, `
+		UPDATE project_sprints SET
+			name = $3,
+			goal = $4,
+			start_date = $5,
+			end_date = $6,
+			state = $7,
+			updated_at = $8,
+			completed_at = $9
+		WHERE project_id = $1 AND id = $2
+	`, s.ProjectID, s.ID, s.Name, s.Goal, s.StartDate, s.EndDate, string(s.State), s.UpdatedAt, s.CompletedAt)
+	if err != nil {
+		// 開始中のスプリントは 1 つまで（idx_project_sprints_one_active）。同時に開始された場合に発生する
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return usecase.ErrSprintAlreadyActive
+		}
+		return fmt.Errorf("failed to update project sprint: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSprintNotFound
+	}
+	return nil
+}
+
+// DeleteSprint はスプリントを削除する。存在しない場合は ErrSprintNotFound を返す。
+func (r *SQLSprintRepository) DeleteSprint(ctx context.Context, projectID, id string) error {
+	tag, err := conn(ctx, r.db).Exec(ctx,
+		"DELETE FROM project_sprints WHERE project_id = $1 AND id = $2",
+		projectID, id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete project sprint: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSprintNotFound
+	}
+	return nil
+}
+
+// FindSprint はスプリントを取得する。存在しない場合は ErrSprintNotFound を返す。
+func (r *SQLSprintRepository) FindSprint(ctx context.Context, projectID, id string) (*domain.Sprint, error) {
+	row := conn(ctx, r.db).QueryRow(ctx,
+		"SELECT "+sprintColumns+" FROM project_sprints WHERE project_id = $1 AND id = $2",
+		projectID, id,
+	)
+	s, err := scanSprint(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSprintNotFound
+		}
+		return nil, fmt.Errorf("failed to find project sprint: %w", err)
+	}
+	return s, nil
+}
+
+// ListSprints はスプリントを開始日の昇順（同じ場合は作成日時・ID 順）で返す。
+func (r *SQLSprintRepository) ListSprints(ctx context.Context, projectID string) ([]*domain.Sprint, error) {
+	rows, err := conn(ctx, r.db).Query(ctx,
+		"SELECT "+sprintColumns+" FROM project_sprints WHERE project_id = $1 ORDER BY start_date ASC, created_at ASC, id ASC",
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project sprints: %w", err)
+	}
+	defer rows.Close()
+
+	sprints := []*domain.Sprint{}
+	for rows.Next() {
+		s, err := scanSprint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project sprint: %w", err)
+		}
+		sprints = append(sprints, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate project sprints: %w", err)
+	}
+	return sprints, nil
+}
+
+// scanSprint は sprintColumns の順で 1 行を読み取る。
+func scanSprint(row pgx.Row) (*domain.Sprint, error) {
+	var s domain.Sprint
+	var state string
+	if err := row.Scan(&s.ProjectID, &s.ID, &s.Name, &s.Goal, &s.StartDate, &s.EndDate, &state, &s.CreatedAt, &s.UpdatedAt, &s.CompletedAt); err != nil {
+		return nil, err
+	}
+	s.State = domain.SprintState(state)
+	return &s, nil
+}
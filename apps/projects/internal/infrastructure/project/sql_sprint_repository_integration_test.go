@@ -0,0 +1,91 @@
+//go:build integration
+// +build integration
+
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	"teamflow-projects/internal/testutil"
+)
+
+func TestSQLSprintRepository(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetProjectsTable(t, db)
+	projects := NewSQLProjectRepository(db)
+	repo := NewSQLSprintRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	if err := projects.Save(ctx, newTestProject(t, "proj-1", "Project 1", "", now)); err != nil {
+		t.Fatalf("failed to save project: %v", err)
+	}
+
+	first, err := domain.NewSprint("s1", "proj-1", "Sprint 1", "ログイン", now, now.AddDate(0, 0, 14), now)
+	if err != nil {
+		t.Fatalf("failed to create sprint: %v", err)
+	}
+	second, err := domain.NewSprint("s2", "proj-1", "Sprint 2", "", now.AddDate(0, 0, 14), now.AddDate(0, 0, 28), now)
+	if err != nil {
+		t.Fatalf("failed to create sprint: %v", err)
+	}
+	for _, s := range []*domain.Sprint{second, first} {
+		if err := repo.SaveSprint(ctx, s); err != nil {
+			t.Fatalf("failed to save %s: %v", s.ID, err)
+		}
+	}
+	if err := repo.SaveSprint(ctx, first); !errors.Is(err, ErrSprintAlreadyExists) {
+		t.Errorf("expected ErrSprintAlreadyExists, got %v", err)
+	}
+	orphan := *first
+	orphan.ProjectID = "non-existent"
+	if err := repo.SaveSprint(ctx, &orphan); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+
+	list, err := repo.ListSprints(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != "s1" || list[1].ID != "s2" {
+		t.Fatalf("unexpected order: %+v", list)
+	}
+	if list[0].Goal != "ログイン" || list[0].State != domain.SprintPlanned || list[0].CompletedAt != nil {
+		t.Errorf("unexpected sprint: %+v", list[0])
+	}
+
+	s1 := list[0]
+	if err := s1.Start(now.Add(time.Hour)); err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+	if err := s1.Complete(now.Add(2 * time.Hour)); err != nil {
+		t.Fatalf("failed to complete: %v", err)
+	}
+	if err := repo.UpdateSprint(ctx, s1); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	got, err := repo.FindSprint(ctx, "proj-1", "s1")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.State != domain.SprintCompleted || got.CompletedAt == nil || !got.CompletedAt.Equal(now.Add(2*time.Hour)) {
+		t.Errorf("unexpected sprint: %+v", got)
+	}
+
+	if err := repo.DeleteSprint(ctx, "proj-1", "s1"); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	if _, err := repo.FindSprint(ctx, "proj-1", "s1"); !errors.Is(err, ErrSprintNotFound) {
+		t.Errorf("expected ErrSprintNotFound, got %v", err)
+	}
+	if err := repo.DeleteSprint(ctx, "proj-1", "s1"); !errors.Is(err, ErrSprintNotFound) {
+		t.Errorf("expected ErrSprintNotFound on second delete, got %v", err)
+	}
+	if err := repo.UpdateSprint(ctx, s1); !errors.Is(err, ErrSprintNotFound) {
+		t.Errorf("expected ErrSprintNotFound on update, got %v", err)
+	}
+}
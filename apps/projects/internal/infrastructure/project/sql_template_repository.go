@@ -0,0 +1,120 @@
+package projectinfra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// SQLTemplateRepository はPostgreSQLを使用したTemplateRepository実装。
+type SQLTemplateRepository struct {
+	db *pgxpool.Pool
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.TemplateRepository = (*SQLTemplateRepository)(nil)
+
+// NewSQLTemplateRepository は新しいSQLTemplateRepositoryを生成する。
+func NewSQLTemplateRepository(db *pgxpool.Pool) *SQLTemplateRepository {
+	return &SQLTemplateRepository{
+		db: db,
+	}
+}
+
+// templateColumns は SELECT 時のカラム順。scanTemplate の Scan 順と一致させる。
+const templateColumns = "id, name, description, tasks, created_at, updated_at"
+
+// blueprintJSON は tasks カラムに保存するタスクのひな形の JSON 表現。
+type blueprintJSON struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Status      string `json:"status"`
+	Priority    string `json:"priority,omitempty"`
+}
+
+// SaveTemplate はテンプレートを保存する。同じ ID が既にある場合は ErrTemplateAlreadyExists を返す。
+func (r *SQLTemplateRepository) SaveTemplate(ctx context.Context, t *domain.Template) error {
+	blueprints := make([]blueprintJSON, len(t.Tasks))
+	for i, bp := range t.Tasks {
+		blueprints[i] = blueprintJSON(bp)
+	}
+	encoded, err := json.Marshal(blueprints)
+	if err != nil {
+		return fmt.Errorf("failed to encode template tasks: %w", err)
+	}
+
+	_, err = conn(ctx, r.db).Exec(ctx,
+		"INSERT INTO project_templates ("+templateColumns+") VALUES ($1, $2, $3, $4, $5, $6)",
+		t.ID, t.Name, t.Description, encoded, t.CreatedAt, t.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return ErrTemplateAlreadyExists
+		}
+		return fmt.Errorf("failed to save project template: %w", err)
+	}
+	return nil
+}
+
+// FindTemplate はテンプレートを取得する。存在しない場合は ErrTemplateNotFound を返す。
+func (r *SQLTemplateRepository) FindTemplate(ctx context.Context, id string) (*domain.Template, error) {
+	row := conn(ctx, r.db).QueryRow(ctx, "SELECT "+templateColumns+" FROM project_templates WHERE id = $1", id)
+	t, err := scanTemplate(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to find project template: %w", err)
+	}
+	return t, nil
+}
+
+// ListTemplates はテンプレートを名前順（同名は ID 順）で返す。
+func (r *SQLTemplateRepository) ListTemplates(ctx context.Context) ([]*domain.Template, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, "SELECT "+templateColumns+" FROM project_templates ORDER BY name ASC, id ASC")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []*domain.Template{}
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project template: %w", err)
+		}
+		templates = append(templates, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate project templates: %w", err)
+	}
+	return templates, nil
+}
+
+// scanTemplate は templateColumns の順で 1 行を読み取る。
+func scanTemplate(row pgx.Row) (*domain.Template, error) {
+	var t domain.Template
+	var tasks []byte
+	if err := row.Scan(&t.ID, &t.Name, &t.Description, &tasks, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	var blueprints []blueprintJSON
+	if err := json.Unmarshal(tasks, &blueprints); err != nil {
+		return nil, fmt.Errorf("failed to decode template tasks: %w", err)
+	}
+	t.Tasks = make([]domain.TaskBlueprint, len(blueprints))
+	for i, bp := range blueprints {
+		t.Tasks[i] = domain.TaskBlueprint(bp)
+	}
+	return &t, nil
+}
@@ -0,0 +1,59 @@
+//go:build integration
+// +build integration
+
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	"teamflow-projects/internal/testutil"
+)
+
+func TestSQLTemplateRepository(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "TRUNCATE TABLE project_templates"); err != nil {
+		t.Fatalf("failed to reset project_templates: %v", err)
+	}
+	repo := NewSQLTemplateRepository(db)
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	want, err := domain.NewTemplate("tmpl-1", "スプリント", "2 週間", []domain.TaskBlueprint{
+		{Title: "計画", Priority: "high"},
+		{Title: "振り返り", Description: "KPT", Status: "todo"},
+	}, now)
+	if err != nil {
+		t.Fatalf("failed to create template: %v", err)
+	}
+
+	if _, err := repo.FindTemplate(ctx, want.ID); !errors.Is(err, ErrTemplateNotFound) {
+		t.Fatalf("expected ErrTemplateNotFound, got %v", err)
+	}
+	if err := repo.SaveTemplate(ctx, want); err != nil {
+		t.Fatalf("failed to save template: %v", err)
+	}
+	if err := repo.SaveTemplate(ctx, want); !errors.Is(err, ErrTemplateAlreadyExists) {
+		t.Fatalf("expected ErrTemplateAlreadyExists, got %v", err)
+	}
+
+	got, err := repo.FindTemplate(ctx, want.ID)
+	if err != nil {
+		t.Fatalf("failed to find template: %v", err)
+	}
+	if got.Name != want.Name || !reflect.DeepEqual(got.Tasks, want.Tasks) || !got.CreatedAt.Equal(now) {
+		t.Errorf("unexpected template: %+v", got)
+	}
+
+	list, err := repo.ListTemplates(ctx)
+	if err != nil {
+		t.Fatalf("failed to list templates: %v", err)
+	}
+	if len(list) != 1 || list[0].ID != want.ID {
+		t.Errorf("unexpected templates: %+v", list)
+	}
+}
@@ -0,0 +1,78 @@
+package projectinfra
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"teamflow-shared/clock"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// CachingStatsProvider は StatsProvider の結果をプロジェクトごとに ttl の間キャッシュするデコレータ。
+//
+// ダッシュボードはプロジェクトカードごとに集計を取得するため、表示のたびに tasks サービスへ
+// 問い合わせないようにする。エラーはキャッシュしない。期限切れのエントリは次の取得時に破棄する。
+// 呼び出し側が返り値を変更してもキャッシュに影響しないよう、コピーを保持・返却する。
+type CachingStatsProvider struct {
+	inner usecase.StatsProvider
+	ttl   time.Duration
+	clock clock.Clock
+
+	mu      sync.Mutex
+	entries map[string]statsCacheEntry
+}
+
+type statsCacheEntry struct {
+	stats     domain.Stats
+	expiresAt time.Time
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.StatsProvider = (*CachingStatsProvider)(nil)
+
+// NewCachingStatsProvider は CachingStatsProvider を生成する。
+func NewCachingStatsProvider(inner usecase.StatsProvider, ttl time.Duration) *CachingStatsProvider {
+	return &CachingStatsProvider{
+		inner:   inner,
+		ttl:     ttl,
+		clock:   clock.System,
+		entries: make(map[string]statsCacheEntry),
+	}
+}
+
+// ProjectStats はキャッシュが有効であればその値を、無ければ inner から取得した値を返す。
+func (p *CachingStatsProvider) ProjectStats(ctx context.Context, projectID string) (*domain.Stats, error) {
+	if s, ok := p.get(projectID); ok {
+		return s, nil
+	}
+
+	s, err := p.inner.ProjectStats(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.entries[projectID] = statsCacheEntry{stats: *s, expiresAt: p.clock.Now().Add(p.ttl)}
+	p.mu.Unlock()
+
+	copied := *s
+	return &copied, nil
+}
+
+func (p *CachingStatsProvider) get(projectID string) (*domain.Stats, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	entry, ok := p.entries[projectID]
+	if !ok {
+		return nil, false
+	}
+	if !p.clock.Now().Before(entry.expiresAt) {
+		delete(p.entries, projectID)
+		return nil, false
+	}
+	s := entry.stats
+	return &s, true
+}
@@ -0,0 +1,69 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"teamflow-shared/clock"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// countingStatsProvider は呼び出し回数を数える StatsProvider。
+type countingStatsProvider struct {
+	calls int
+	err   error
+}
+
+func (p *countingStatsProvider) ProjectStats(_ context.Context, projectID string) (*domain.Stats, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &domain.Stats{ProjectID: projectID, Open: p.calls}, nil
+}
+
+func TestCachingStatsProvider(t *testing.T) {
+	clk := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	inner := &countingStatsProvider{}
+	cache := NewCachingStatsProvider(inner, 30*time.Second)
+	cache.clock = clk
+	ctx := context.Background()
+
+	first, err := cache.ProjectStats(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first.Open = 100 // 返り値の変更はキャッシュに影響しない
+
+	second, _ := cache.ProjectStats(ctx, "proj-1")
+	if inner.calls != 1 || second.Open != 1 {
+		t.Fatalf("expected cached stats, got calls=%d stats=%+v", inner.calls, second)
+	}
+
+	if _, err := cache.ProjectStats(ctx, "proj-2"); err != nil || inner.calls != 2 {
+		t.Fatalf("expected a separate entry per project, got calls=%d err=%v", inner.calls, err)
+	}
+
+	clk.Advance(30 * time.Second)
+	third, _ := cache.ProjectStats(ctx, "proj-1")
+	if inner.calls != 3 || third.Open != 3 {
+		t.Fatalf("expected expired entry to be refreshed, got calls=%d stats=%+v", inner.calls, third)
+	}
+}
+
+func TestCachingStatsProvider_DoesNotCacheErrors(t *testing.T) {
+	inner := &countingStatsProvider{err: errors.New("unavailable")}
+	cache := NewCachingStatsProvider(inner, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if _, err := cache.ProjectStats(context.Background(), "proj-1"); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	}
+	if inner.calls != 2 {
+		t.Errorf("expected errors not to be cached, got %d calls", inner.calls)
+	}
+}
@@ -0,0 +1,211 @@
+package projectinfra
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"teamflow-shared/authz"
+	"teamflow-shared/client"
+	"teamflow-shared/requestid"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// defaultTasksClientTimeout は tasks サービスへの 1 回のリクエストのタイムアウト。
+const defaultTasksClientTimeout = 10 * time.Second
+
+// TasksClient は tasks サービスの HTTP API クライアント（teamflow-shared/client のラッパー）。
+// TaskSeeder（POST /api/v1/projects/{id}/tasks:batch）、TaskLister（GET /api/v1/projects/{id}/tasks）、
+// StatsProvider（GET /api/v1/projects/{id}/tasks/stats）、BatchStatsProvider（POST /api/v1/tasks:stats）、
+// MilestoneStatsProvider（GET /api/v1/projects/{id}/tasks/stats/milestones）、EpicStatsProvider（GET /api/v1/projects/{id}/tasks/stats/epics）、
+// LabelStatsProvider（GET /api/v1/projects/{id}/tasks/stats/labels）、
+// TaskCascader（POST /api/v1/projects/{id}/tasks:archive|unarchive|delete）と SprintTaskCarrier（POST /api/v1/projects/{id}/tasks:carry-over）を実装する。
+type TasksClient struct {
+	api *client.Client
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var (
+	_ usecase.TaskSeeder             = (*TasksClient)(nil)
+	_ usecase.TaskLister             = (*TasksClient)(nil)
+	_ usecase.StatsProvider          = (*TasksClient)(nil)
+	_ usecase.BatchStatsProvider     = (*TasksClient)(nil)
+	_ usecase.MilestoneStatsProvider = (*TasksClient)(nil)
+	_ usecase.EpicStatsProvider      = (*TasksClient)(nil)
+	_ usecase.LabelStatsProvider     = (*TasksClient)(nil)
+	_ usecase.TaskCascader           = (*TasksClient)(nil)
+	_ usecase.SprintTaskCarrier      = (*TasksClient)(nil)
+)
+
+// openTasksPageSize は未完了タスクの取得で 1 リクエストあたりに取得する件数（tasks サービスの上限）。
+const openTasksPageSize = 200
+
+// NewTasksClient は baseURL（例: http://tasks:8081）の tasks サービスに接続する TasksClient を生成する。
+// httpClient が nil の場合はタイムアウト付きで、リクエスト ID（X-Request-ID）を引き継ぐ既定のクライアントを使う。
+func NewTasksClient(baseURL string, httpClient *http.Client) *TasksClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTasksClientTimeout, Transport: &requestid.Transport{}}
+	}
+	return &TasksClient{api: client.New(baseURL, httpClient)}
+}
+
+// SeedTasks はプロジェクトにタスクを一括作成する。tasks サービス側で 1 トランザクションで作成される。
+func (c *TasksClient) SeedTasks(ctx context.Context, projectID string, tasks []domain.TaskBlueprint) error {
+	reqs := make([]client.CreateTaskRequest, len(tasks))
+	for i, bp := range tasks {
+		reqs[i] = client.CreateTaskRequest{
+			Title:       bp.Title,
+			Description: bp.Description,
+			Status:      bp.Status,
+			Priority:    bp.Priority,
+		}
+	}
+	if _, err := c.asActor(ctx).BatchCreateTasks(ctx, projectID, reqs); err != nil {
+		return fmt.Errorf("tasks client: %w", err)
+	}
+	return nil
+}
+
+// ListOpenTasks はプロジェクトの未完了（todo / in_progress）のタスクを、複製用の雛形として返す。
+// nextCursor をたどってすべてのページを取得する。
+func (c *TasksClient) ListOpenTasks(ctx context.Context, projectID string) ([]domain.TaskBlueprint, error) {
+	// tasks サービスはプロジェクトの閲覧権限を確認するため、元のリクエストの操作者を引き継ぐ
+	api := c.asActor(ctx)
+	opts := client.ListTasksOptions{Status: "todo,in_progress", Limit: openTasksPageSize}
+
+	var tasks []domain.TaskBlueprint
+	for t, err := range api.ProjectTasks(ctx, projectID, opts) {
+		if err != nil {
+			return nil, fmt.Errorf("tasks client: %w", err)
+		}
+		tasks = append(tasks, domain.TaskBlueprint{
+			Title:       t.Title,
+			Description: t.Description,
+			Status:      t.Status,
+			Priority:    t.Priority,
+		})
+	}
+	return tasks, nil
+}
+
+// ProjectStats はプロジェクトのタスクの集計を取得する。
+func (c *TasksClient) ProjectStats(ctx context.Context, projectID string) (*domain.Stats, error) {
+	s, err := c.api.ProjectTaskStats(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("tasks client: %w", err)
+	}
+	stats := toStats(*s)
+	stats.ProjectID = projectID
+	return stats, nil
+}
+
+// ProjectsStats は複数プロジェクトのタスクの集計を 1 回のリクエストで取得する。
+func (c *TasksClient) ProjectsStats(ctx context.Context, projectIDs []string) (map[string]*domain.Stats, error) {
+	list, err := c.api.BatchProjectStats(ctx, projectIDs)
+	if err != nil {
+		return nil, fmt.Errorf("tasks client: %w", err)
+	}
+	stats := make(map[string]*domain.Stats, len(list))
+	for _, s := range list {
+		stats[s.ProjectID] = toStats(s)
+	}
+	return stats, nil
+}
+
+func toStats(s client.ProjectStats) *domain.Stats {
+	return &domain.Stats{
+		ProjectID:      s.ProjectID,
+		Open:           s.Open,
+		Done:           s.Done,
+		Overdue:        s.Overdue,
+		LastActivityAt: s.LastActivityAt,
+	}
+}
+
+// MilestoneStats はプロジェクトのタスクをマイルストーンごとに集計した件数を取得する。
+func (c *TasksClient) MilestoneStats(ctx context.Context, projectID string) (map[string]domain.MilestoneTaskCounts, error) {
+	list, err := c.api.MilestoneTaskStats(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("tasks client: %w", err)
+	}
+	counts := make(map[string]domain.MilestoneTaskCounts, len(list))
+	for _, m := range list {
+		counts[m.MilestoneID] = domain.MilestoneTaskCounts{Open: m.Open, Done: m.Done}
+	}
+	return counts, nil
+}
+
+// EpicStats はプロジェクトのタスクをエピックごとに集計した件数と見積もりの合計を取得する。
+func (c *TasksClient) EpicStats(ctx context.Context, projectID string) (map[string]domain.EpicTaskCounts, error) {
+	list, err := c.api.EpicTaskStats(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("tasks client: %w", err)
+	}
+	counts := make(map[string]domain.EpicTaskCounts, len(list))
+	for _, e := range list {
+		counts[e.EpicID] = domain.EpicTaskCounts{
+			Total:         e.Total,
+			Done:          e.Done,
+			EstimateTotal: e.EstimateTotal,
+			EstimateDone:  e.EstimateDone,
+		}
+	}
+	return counts, nil
+}
+
+// LabelStats はプロジェクトのタスクをラベルごとに数えた件数を取得する。
+func (c *TasksClient) LabelStats(ctx context.Context, projectID string) (map[string]int, error) {
+	list, err := c.api.LabelTaskStats(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("tasks client: %w", err)
+	}
+	counts := make(map[string]int, len(list))
+	for _, l := range list {
+		counts[l.LabelID] = l.Count
+	}
+	return counts, nil
+}
+
+// ArchiveTasks はプロジェクトのタスクをアーカイブする。
+func (c *TasksClient) ArchiveTasks(ctx context.Context, projectID string) error {
+	_, err := c.asActor(ctx).ArchiveTasks(ctx, projectID)
+	return wrapTasksError(err)
+}
+
+// UnarchiveTasks はプロジェクトのアーカイブしたタスクを戻す。
+func (c *TasksClient) UnarchiveTasks(ctx context.Context, projectID string) error {
+	_, err := c.asActor(ctx).UnarchiveTasks(ctx, projectID)
+	return wrapTasksError(err)
+}
+
+// DeleteTasks はプロジェクトのタスクを削除する。
+func (c *TasksClient) DeleteTasks(ctx context.Context, projectID string) error {
+	_, err := c.asActor(ctx).DeleteTasks(ctx, projectID)
+	return wrapTasksError(err)
+}
+
+// CarryOverTasks はスプリントの未完了タスクを toSprintID（空の場合はバックログ）へ移し、移した件数を返す。
+// 移したタスクは fromSprintID に属さなくなるため、失敗した場合は再実行してよい。
+func (c *TasksClient) CarryOverTasks(ctx context.Context, projectID, fromSprintID, toSprintID string) (int, error) {
+	n, err := c.asActor(ctx).CarryOverSprintTasks(ctx, projectID, fromSprintID, toSprintID)
+	if err != nil {
+		return 0, fmt.Errorf("tasks client: %w", err)
+	}
+	return n, nil
+}
+
+// asActor は ctx の操作者（authz.ContextWithActor）を X-User-ID で送る Client を返す。
+// tasks サービスは閲覧権限の確認と、タスクの作成者・更新者・監査ログの記録に使う。
+func (c *TasksClient) asActor(ctx context.Context) *client.Client {
+	return c.api.WithActor(authz.ActorFromContext(ctx))
+}
+
+func wrapTasksError(err error) error {
+	if err != nil {
+		return fmt.Errorf("tasks client: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,349 @@
+package projectinfra
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"teamflow-shared/authz"
+	"teamflow-shared/requestid"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+func TestTasksClient_SeedTasks(t *testing.T) {
+	var gotPath string
+	var gotBody struct {
+		Tasks []map[string]string `json:"tasks"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if r.URL.Path == "/api/v1/projects/broken/tasks:batch" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"validation error"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewTasksClient(srv.URL+"/", nil)
+	tasks := []domain.TaskBlueprint{{Title: "計画", Status: "todo", Priority: "high"}}
+
+	if err := client.SeedTasks(context.Background(), "proj-1", tasks); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPath != "/api/v1/projects/proj-1/tasks:batch" {
+		t.Errorf("unexpected path: %s", gotPath)
+	}
+	if len(gotBody.Tasks) != 1 || gotBody.Tasks[0]["title"] != "計画" || gotBody.Tasks[0]["priority"] != "high" {
+		t.Errorf("unexpected body: %+v", gotBody)
+	}
+
+	if err := client.SeedTasks(context.Background(), "broken", tasks); err == nil {
+		t.Error("expected error for 400 response, got nil")
+	}
+}
+
+func TestTasksClient_ListOpenTasks(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/projects/proj-1/tasks" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		queries = append(queries, r.URL.RawQuery)
+		if got := r.Header.Get(authz.ActorHeader); got != "user-1" {
+			t.Errorf("expected actor header user-1, got %q", got)
+		}
+		q := r.URL.Query()
+		if q.Get("status") != "todo,in_progress" || q.Get("limit") != "200" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		if q.Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"tasks":[{"id":"t1","title":"設計","status":"todo","priority":"high"}],"page":{"nextCursor":"c1","limit":200}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"tasks":[{"id":"t2","title":"実装","description":"API","status":"in_progress","priority":"medium"}],"page":{"limit":200}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewTasksClient(srv.URL, nil)
+
+	ctx := authz.ContextWithActor(context.Background(), "user-1")
+	tasks, err := client.ListOpenTasks(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []domain.TaskBlueprint{
+		{Title: "設計", Status: "todo", Priority: "high"},
+		{Title: "実装", Description: "API", Status: "in_progress", Priority: "medium"},
+	}
+	if len(tasks) != len(want) || tasks[0] != want[0] || tasks[1] != want[1] {
+		t.Errorf("unexpected tasks: %+v", tasks)
+	}
+	if len(queries) != 2 {
+		t.Errorf("expected 2 requests, got %d", len(queries))
+	}
+
+	if _, err := client.ListOpenTasks(ctx, "missing"); err == nil {
+		t.Error("expected error for 404 response, got nil")
+	}
+}
+
+func TestTasksClient_ProjectStats(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/projects/proj-1/tasks/stats" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"projectId":"proj-1","open":3,"done":2,"overdue":1,"lastActivityAt":"2025-01-01T12:00:00Z"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewTasksClient(srv.URL, nil)
+
+	stats, err := client.ProjectStats(context.Background(), "proj-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.ProjectID != "proj-1" || stats.Open != 3 || stats.Done != 2 || stats.Overdue != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if stats.LastActivityAt == nil || stats.LastActivityAt.Year() != 2025 {
+		t.Errorf("unexpected lastActivityAt: %v", stats.LastActivityAt)
+	}
+
+	if _, err := client.ProjectStats(context.Background(), "broken"); err == nil {
+		t.Error("expected error for 500 response, got nil")
+	}
+}
+
+func TestTasksClient_MilestoneStats(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/projects/proj-1/tasks/stats/milestones" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"projectId":"proj-1","milestones":[{"milestoneId":"m-1","open":2,"done":1}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewTasksClient(srv.URL, nil)
+
+	counts, err := client.MilestoneStats(context.Background(), "proj-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(counts) != 1 || counts["m-1"].Open != 2 || counts["m-1"].Done != 1 {
+		t.Errorf("unexpected counts: %+v", counts)
+	}
+
+	if _, err := client.MilestoneStats(context.Background(), "broken"); err == nil {
+		t.Error("expected error for 500 response, got nil")
+	}
+}
+
+func TestTasksClient_EpicStats(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/projects/proj-1/tasks/stats/epics" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"projectId":"proj-1","epics":[{"epicId":"e-1","total":3,"done":1,"estimateTotal":8,"estimateDone":3}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewTasksClient(srv.URL, nil)
+
+	counts, err := client.EpicStats(context.Background(), "proj-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := domain.EpicTaskCounts{Total: 3, Done: 1, EstimateTotal: 8, EstimateDone: 3}
+	if len(counts) != 1 || counts["e-1"] != want {
+		t.Errorf("unexpected counts: %+v", counts)
+	}
+
+	if _, err := client.EpicStats(context.Background(), "broken"); err == nil {
+		t.Error("expected error for 500 response, got nil")
+	}
+}
+
+func TestTasksClient_LabelStats(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/projects/proj-1/tasks/stats/labels" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"projectId":"proj-1","labels":[{"labelId":"l-1","count":3},{"labelId":"l-2","count":1}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewTasksClient(srv.URL, nil)
+
+	counts, err := client.LabelStats(context.Background(), "proj-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(counts) != 2 || counts["l-1"] != 3 || counts["l-2"] != 1 {
+		t.Errorf("unexpected counts: %+v", counts)
+	}
+
+	if _, err := client.LabelStats(context.Background(), "broken"); err == nil {
+		t.Error("expected error for 500 response, got nil")
+	}
+}
+
+func TestTasksClient_CascadeTasks(t *testing.T) {
+	var gotRequests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRequests = append(gotRequests, r.Method+" "+r.URL.Path+" "+r.Header.Get(authz.ActorHeader))
+		if r.URL.Path == "/api/v1/projects/broken/tasks:delete" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"projectId":"proj-1","count":0}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewTasksClient(srv.URL, nil)
+	// 操作者は tasks サービスで監査ログに記録するため X-User-ID で引き継ぐ
+	ctx := authz.ContextWithActor(context.Background(), "user-1")
+
+	if err := client.ArchiveTasks(ctx, "proj-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.UnarchiveTasks(ctx, "proj-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.DeleteTasks(ctx, "proj-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"POST /api/v1/projects/proj-1/tasks:archive user-1",
+		"POST /api/v1/projects/proj-1/tasks:unarchive user-1",
+		"POST /api/v1/projects/proj-1/tasks:delete user-1",
+	}
+	if len(gotRequests) != len(want) {
+		t.Fatalf("expected %v, got %v", want, gotRequests)
+	}
+	for i := range want {
+		if gotRequests[i] != want[i] {
+			t.Errorf("request %d: expected %s, got %s", i, want[i], gotRequests[i])
+		}
+	}
+
+	if err := client.DeleteTasks(ctx, "broken"); err == nil {
+		t.Error("expected error for 500 response, got nil")
+	}
+}
+
+func TestTasksClient_ProjectsStats(t *testing.T) {
+	var gotBody struct {
+		ProjectIDs []string `json:"projectIds"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/tasks:stats" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if len(gotBody.ProjectIDs) > 0 && gotBody.ProjectIDs[0] == "broken" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"stats":[{"projectId":"proj-1","open":3,"done":2,"overdue":1,"lastActivityAt":"2025-01-01T12:00:00Z"},{"projectId":"proj-2","open":0,"done":0,"overdue":0,"lastActivityAt":null}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewTasksClient(srv.URL, nil)
+
+	stats, err := client.ProjectsStats(context.Background(), []string{"proj-1", "proj-2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(gotBody.ProjectIDs) != 2 || gotBody.ProjectIDs[1] != "proj-2" {
+		t.Errorf("unexpected request body: %+v", gotBody)
+	}
+	if s := stats["proj-1"]; s == nil || s.Open != 3 || s.Done != 2 || s.LastActivityAt == nil {
+		t.Errorf("unexpected stats for proj-1: %+v", s)
+	}
+	if s := stats["proj-2"]; s == nil || s.Open != 0 || s.LastActivityAt != nil {
+		t.Errorf("unexpected stats for proj-2: %+v", s)
+	}
+
+	if _, err := client.ProjectsStats(context.Background(), []string{"broken"}); err == nil {
+		t.Error("expected error for 400 response, got nil")
+	}
+}
+
+func TestTasksClient_CarryOverTasks(t *testing.T) {
+	var gotBodies []map[string]*string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/projects/proj-1/tasks:carry-over" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body map[string]*string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		gotBodies = append(gotBodies, body)
+		_, _ = w.Write([]byte(`{"projectId":"proj-1","fromSprintId":"s1","toSprintId":null,"count":2}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewTasksClient(srv.URL, nil)
+	ctx := context.Background()
+
+	count, err := client.CarryOverTasks(ctx, "proj-1", "s1", "s2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2, got %d", count)
+	}
+	// 持ち越し先が空の場合は toSprintId: null（バックログ）を送る
+	if _, err := client.CarryOverTasks(ctx, "proj-1", "s1", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(gotBodies) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(gotBodies))
+	}
+	if b := gotBodies[0]; *b["fromSprintId"] != "s1" || b["toSprintId"] == nil || *b["toSprintId"] != "s2" {
+		t.Errorf("unexpected request body: %v", b)
+	}
+	if to, ok := gotBodies[1]["toSprintId"]; !ok || to != nil {
+		t.Errorf("expected toSprintId null, got %v", gotBodies[1])
+	}
+
+	if _, err := client.CarryOverTasks(ctx, "missing", "s1", ""); err == nil {
+		t.Error("expected error for 404 response, got nil")
+	}
+}
+
+func TestTasksClient_ForwardsRequestID(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(requestid.Header)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewTasksClient(srv.URL, nil)
+	ctx := requestid.NewContext(context.Background(), "req-1")
+	if err := client.SeedTasks(ctx, "proj-1", []domain.TaskBlueprint{{Title: "計画", Status: "todo"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "req-1" {
+		t.Errorf("expected X-Request-ID req-1, got %q", got)
+	}
+}
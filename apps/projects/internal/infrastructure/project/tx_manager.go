@@ -0,0 +1,96 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// querier は pgxpool.Pool と pgx.Tx の共通部分。
+// SQL リポジトリは ctx にトランザクションがあればそれを、無ければ Pool を使う。
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type txKey struct{}
+
+// TxFromContext は ctx に紐づく pgx.Tx（PgxTxManager.WithinTx が設定する）を返す。
+func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	return tx, ok
+}
+
+// conn は ctx にトランザクション（PgxTxManager.WithinTx）があればそれを、無ければ db を返す。
+func conn(ctx context.Context, db *pgxpool.Pool) querier {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	return db
+}
+
+// PgxTxManager は pgx.Tx を使った TxManager 実装。
+type PgxTxManager struct {
+	db *pgxpool.Pool
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.TxManager = (*PgxTxManager)(nil)
+
+// NewPgxTxManager は新しいPgxTxManagerを生成する。
+func NewPgxTxManager(db *pgxpool.Pool) *PgxTxManager {
+	return &PgxTxManager{db: db}
+}
+
+// WithinTx は Begin → fn → Commit を行い、fn がエラーまたは panic の場合は Rollback する。
+// ctx に既にトランザクションがある場合はネストせず、そのトランザクション内で fn を実行する。
+func (m *PgxTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				err = errors.Join(err, fmt.Errorf("failed to rollback transaction: %w", rbErr))
+			}
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// NoopTxManager はメモリリポジトリ用の TxManager 実装。
+// トランザクションを張らずに fn をそのまま実行する（ロールバックはされない）。
+type NoopTxManager struct{}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.TxManager = NoopTxManager{}
+
+// WithinTx は fn をそのまま実行する。
+func (NoopTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
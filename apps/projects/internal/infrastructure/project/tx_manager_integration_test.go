@@ -0,0 +1,54 @@
+//go:build integration
+// +build integration
+
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"teamflow-projects/internal/testutil"
+)
+
+// TestPgxTxManager_WithinTx は fn の成否に応じて Commit / Rollback されることを検証する。
+func TestPgxTxManager_WithinTx(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewSQLProjectRepository(db)
+	txm := NewPgxTxManager(db)
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name      string
+		fnErr     error
+		wantCount int
+	}{
+		{name: "commit on success", fnErr: nil, wantCount: 1},
+		{name: "rollback on error", fnErr: errors.New("boom"), wantCount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.ResetProjectsTable(t, db)
+
+			err := txm.WithinTx(context.Background(), func(ctx context.Context) error {
+				if err := repo.Save(ctx, newTestProject(t, "proj-1", "TeamFlow 開発", "", now)); err != nil {
+					return err
+				}
+				return tt.fnErr
+			})
+			if !errors.Is(err, tt.fnErr) {
+				t.Fatalf("expected error %v, got %v", tt.fnErr, err)
+			}
+
+			projects, err := repo.List(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(projects) != tt.wantCount {
+				t.Errorf("expected %d projects, got %d", tt.wantCount, len(projects))
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/clock"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// ActivityHandler は GET /projects/{id}/activity を処理する HTTP ハンドラ。
+// プロジェクトの作成・更新・アーカイブ・メンバーの変更の履歴を新しい順に返す。
+type ActivityHandler struct {
+	listUC       *usecase.ListActivityUsecase
+	clock        clock.Clock
+	cursorSecret []byte
+}
+
+// NewActivityHandler は ActivityHandler を生成する。
+// cursorSecret は cursor の署名に使う（プロジェクト一覧と同じ鍵）。
+func NewActivityHandler(listUC *usecase.ListActivityUsecase, clk clock.Clock, cursorSecret []byte) http.Handler {
+	return &ActivityHandler{
+		listUC:       listUC,
+		clock:        clk,
+		cursorSecret: cursorSecret,
+	}
+}
+
+type activityEventResponse struct {
+	ID        string            `json:"id"`
+	Type      string            `json:"type"`
+	ActorID   string            `json:"actorId,omitempty"`
+	Data      map[string]string `json:"data"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+// listActivityResponse は GET /projects/{id}/activity のレスポンス。
+type listActivityResponse struct {
+	Events []activityEventResponse `json:"events"`
+	Page   pageInfo                `json:"page"`
+}
+
+// parseActivityPath は /projects/{id}/activity から id を取り出す。
+func parseActivityPath(path string) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/projects/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "activity" {
+		return "", false
+	}
+	return parts[0], true
+}
+
+// IsActivityPath はパスが /projects/{id}/activity かどうかを返す。
+func IsActivityPath(path string) bool {
+	_, ok := parseActivityPath(path)
+	return ok
+}
+
+// ServeHTTP はアクティビティ一覧を返す。
+//
+//	limit   1-200（default 50）
+//	cursor  前ページの page.nextCursor（発行したプロジェクトでのみ有効）
+func (h *ActivityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseActivityPath(r.URL.Path)
+	if !ok {
+		writeNotFound(w, "not found")
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	params := r.URL.Query()
+	limit := domain.DefaultActivityLimit
+	if limitStr := params.Get("limit"); limitStr != "" {
+		v, err := strconv.Atoi(limitStr)
+		if err != nil || v < 1 || v > domain.MaxActivityLimit {
+			issue, _ := toValidationIssue(apierror.LocationQuery, domain.ErrLimitOutOfRange)
+			issue.RejectedValue = &limitStr
+			writeValidationError(w, issue)
+			return
+		}
+		limit = v
+	}
+
+	query, err := domain.NewActivityQuery(projectID, limit, params.Get("cursor"), h.cursorSecret, h.clock.Now())
+	if err != nil {
+		writeQueryError(w, err)
+		return
+	}
+
+	events, err := h.listUC.Execute(r.Context(), query, actorID(r))
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	// repository 層で limit + 1 件取得している。次ページがあれば limit 件目から nextCursor を作る
+	var nextCursor *string
+	if len(events) > query.Limit {
+		events = events[:query.Limit]
+		c, err := domain.EncodeCursor(query.NewCursorPayload(events[len(events)-1], h.clock.Now()), h.cursorSecret)
+		if err != nil {
+			writeInternalError(w)
+			return
+		}
+		nextCursor = &c
+	}
+
+	resp := listActivityResponse{
+		Events: make([]activityEventResponse, 0, len(events)),
+		Page:   pageInfo{NextCursor: nextCursor, Limit: query.Limit},
+	}
+	for _, e := range events {
+		resp.Events = append(resp.Events, activityEventResponse{
+			ID:        strconv.FormatInt(e.ID, 10),
+			Type:      string(e.Type),
+			ActorID:   e.ActorID,
+			Data:      e.Data,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,120 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+type activityBody struct {
+	Events []struct {
+		ID      string            `json:"id"`
+		Type    string            `json:"type"`
+		ActorID string            `json:"actorId"`
+		Data    map[string]string `json:"data"`
+	} `json:"events"`
+	Page struct {
+		NextCursor *string `json:"nextCursor"`
+		Limit      int     `json:"limit"`
+	} `json:"page"`
+}
+
+func doActivityRequest(t *testing.T, handler http.Handler, path string) (int, activityBody) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	var resp activityBody
+	if w.Code == http.StatusOK {
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+	}
+	return w.Code, resp
+}
+
+func TestActivityHandler_ListsChangesNewestFirst(t *testing.T) {
+	ctx := context.Background()
+	projects := infra.NewMemoryProjectRepository()
+	activity := infra.NewMemoryActivityRepository()
+
+	createUC := &usecase.CreateProjectUsecase{Repo: projects, Activity: activity}
+	if _, err := createUC.Execute(ctx, usecase.CreateProjectInput{ID: "proj-1", Name: "TeamFlow", ActorID: "user-1", Now: fixedNow()}); err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	updateUC := &usecase.UpdateProjectUsecase{Repo: projects, Activity: activity}
+	if _, err := updateUC.Execute(ctx, usecase.UpdateProjectInput{ID: "proj-1", Name: "TeamFlow 2", ActorID: "user-1", Now: fixedNow()}); err != nil {
+		t.Fatalf("failed to update project: %v", err)
+	}
+	archiveUC := &usecase.ArchiveProjectUsecase{Repo: projects, Activity: activity}
+	if _, err := archiveUC.Execute(ctx, usecase.ArchiveProjectInput{ID: "proj-1", Archived: true, Now: fixedNow()}); err != nil {
+		t.Fatalf("failed to archive project: %v", err)
+	}
+
+	handler := httpiface.NewActivityHandler(&usecase.ListActivityUsecase{Projects: projects, Activity: activity}, fixedClock, testCursorSecret)
+
+	status, first := doActivityRequest(t, handler, "/projects/proj-1/activity?limit=2")
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if len(first.Events) != 2 || first.Page.NextCursor == nil || first.Page.Limit != 2 {
+		t.Fatalf("unexpected first page: %+v", first)
+	}
+	if first.Events[0].Type != "project.archived" || first.Events[1].Type != "project.updated" {
+		t.Errorf("unexpected order: %+v", first.Events)
+	}
+	if first.Events[1].Data["fields"] != "name" || first.Events[1].ActorID != "user-1" {
+		t.Errorf("unexpected update event: %+v", first.Events[1])
+	}
+
+	status, second := doActivityRequest(t, handler, "/projects/proj-1/activity?limit=2&cursor="+url.QueryEscape(*first.Page.NextCursor))
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if len(second.Events) != 1 || second.Events[0].Type != "project.created" || second.Page.NextCursor != nil {
+		t.Errorf("unexpected second page: %+v", second)
+	}
+
+	// 別のプロジェクトでは cursor を使えない
+	seedProject(projects, "proj-2")
+	if status, _ := doActivityRequest(t, handler, "/projects/proj-2/activity?cursor="+url.QueryEscape(*first.Page.NextCursor)); status != http.StatusBadRequest {
+		t.Errorf("expected status 400 for cursor of another project, got %d", status)
+	}
+}
+
+func TestActivityHandler_Errors(t *testing.T) {
+	projects := infra.NewMemoryProjectRepository()
+	seedProject(projects, "proj-1")
+	handler := httpiface.NewActivityHandler(
+		&usecase.ListActivityUsecase{Projects: projects, Activity: infra.NewMemoryActivityRepository()},
+		fixedClock,
+		testCursorSecret,
+	)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{name: "unknown project", path: "/projects/proj-x/activity", wantStatus: http.StatusNotFound},
+		{name: "limit out of range", path: "/projects/proj-1/activity?limit=0", wantStatus: http.StatusBadRequest},
+		{name: "invalid cursor", path: "/projects/proj-1/activity?cursor=invalid", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status, _ := doActivityRequest(t, handler, tt.path); status != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, status)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"teamflow-shared/apierror"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// ActorHeader は操作者のユーザー ID を受け取るヘッダ（認証済みのゲートウェイが設定する）。
+const ActorHeader = "X-User-ID"
+
+// actorID はリクエストの操作者のユーザー ID を返す。未設定の場合は空文字。
+func actorID(r *http.Request) string {
+	return strings.TrimSpace(r.Header.Get(ActorHeader))
+}
+
+// writeAuthzError は操作者が不明な場合は 401、権限不足の場合は 403 を書き込む。
+// どちらでもない場合は何もせず false を返す。
+func writeAuthzError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrActorRequired):
+		writeError(w, http.StatusUnauthorized, apierror.CodeUnauthorized, "X-User-ID header is required")
+	case errors.Is(err, domain.ErrForbidden):
+		writeError(w, http.StatusForbidden, apierror.CodeForbidden, "the actor is not allowed to perform this action")
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"teamflow-shared/clock"
+
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// ArchiveProjectHandler は POST /projects/{id}/archive と POST /projects/{id}/unarchive を処理する HTTP ハンドラ。
+type ArchiveProjectHandler struct {
+	archiveUC *usecase.ArchiveProjectUsecase
+	clock     clock.Clock
+}
+
+// NewArchiveProjectHandler は ArchiveProjectHandler を生成する。
+func NewArchiveProjectHandler(archiveUC *usecase.ArchiveProjectUsecase, clk clock.Clock) http.Handler {
+	return &ArchiveProjectHandler{
+		archiveUC: archiveUC,
+		clock:     clk,
+	}
+}
+
+// parseArchivePath は /projects/{id}/archive または /projects/{id}/unarchive から id と操作を取り出す。
+func parseArchivePath(path string) (id string, archived bool, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/projects/"), "/")
+	if len(parts) != 2 || parts[0] == "" {
+		return "", false, false
+	}
+	switch parts[1] {
+	case "archive":
+		return parts[0], true, true
+	case "unarchive":
+		return parts[0], false, true
+	default:
+		return "", false, false
+	}
+}
+
+// IsArchivePath はパスが /projects/{id}/archive または /projects/{id}/unarchive かどうかを返す。
+func IsArchivePath(path string) bool {
+	_, _, ok := parseArchivePath(path)
+	return ok
+}
+
+func (h *ArchiveProjectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, archived, ok := parseArchivePath(r.URL.Path)
+	if !ok {
+		writeNotFound(w, "not found")
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	p, err := h.archiveUC.Execute(r.Context(), usecase.ArchiveProjectInput{
+		ID:       id,
+		Archived: archived,
+		ActorID:  actorID(r),
+		Now:      h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := projectResponse{
+		ID:          p.ID,
+		Key:         p.Key,
+		Name:        p.Name,
+		Description: p.Description,
+		Status:      string(p.Status),
+		Visibility:  string(p.Visibility),
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+		ArchivedAt:  p.ArchivedAt,
+		CreatedBy:   p.CreatedBy,
+		UpdatedBy:   p.UpdatedBy,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,81 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// newRoleRepos は proj-1 と、その owner / admin を登録したリポジトリを返す。
+func newRoleRepos(t *testing.T) (*infra.MemoryProjectRepository, *infra.MemoryMemberRepository) {
+	t.Helper()
+	projects := infra.NewMemoryProjectRepository()
+	seedProject(projects, "proj-1")
+	members := infra.NewMemoryMemberRepository()
+	for userID, role := range map[string]string{"owner-1": "owner", "admin-1": "admin"} {
+		m, err := domain.NewMember("proj-1", userID, role, fixedNow())
+		if err != nil {
+			t.Fatalf("failed to create member: %v", err)
+		}
+		_ = members.AddMember(context.Background(), m)
+	}
+	return projects, members
+}
+
+func TestArchiveProjectHandler(t *testing.T) {
+	projects, members := newRoleRepos(t)
+	uc := &usecase.ArchiveProjectUsecase{Repo: projects, Members: members, EnforceRoles: true}
+	handler := httpiface.NewArchiveProjectHandler(uc, fixedClock)
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		actor        string
+		wantStatus   int
+		wantArchived bool
+	}{
+		{name: "no actor", method: http.MethodPost, path: "/projects/proj-1/archive", wantStatus: http.StatusUnauthorized},
+		{name: "admin is forbidden", method: http.MethodPost, path: "/projects/proj-1/archive", actor: "admin-1", wantStatus: http.StatusForbidden},
+		{name: "owner archives", method: http.MethodPost, path: "/projects/proj-1/archive", actor: "owner-1", wantStatus: http.StatusOK, wantArchived: true},
+		{name: "owner unarchives", method: http.MethodPost, path: "/projects/proj-1/unarchive", actor: "owner-1", wantStatus: http.StatusOK},
+		{name: "not found", method: http.MethodPost, path: "/projects/proj-x/archive", actor: "owner-1", wantStatus: http.StatusNotFound},
+		{name: "method not allowed", method: http.MethodGet, path: "/projects/proj-1/archive", actor: "owner-1", wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.actor != "" {
+				req.Header.Set(httpiface.ActorHeader, tt.actor)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			var body struct {
+				ArchivedAt *time.Time `json:"archivedAt"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if (body.ArchivedAt != nil) != tt.wantArchived {
+				t.Errorf("expected archived=%v, got archivedAt=%v", tt.wantArchived, body.ArchivedAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"teamflow-shared/clock"
+
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// CloneProjectHandler は POST /projects/{id}/clone を処理する HTTP ハンドラ。
+type CloneProjectHandler struct {
+	cloneUC *usecase.CloneProjectUsecase
+	clock   clock.Clock
+}
+
+// NewCloneProjectHandler は CloneProjectHandler を生成する。
+func NewCloneProjectHandler(
+	cloneUC *usecase.CloneProjectUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &CloneProjectHandler{
+		cloneUC: cloneUC,
+		clock:   clk,
+	}
+}
+
+type cloneProjectRequest struct {
+	ID           string `json:"id"`
+	Key          string `json:"key"` // 省略時は未設定（キーは複製しない）
+	Name         string `json:"name"`
+	IncludeTasks bool   `json:"includeTasks"`
+}
+
+// cloneSummaryResponse は複製した項目の件数。
+type cloneSummaryResponse struct {
+	Members  int  `json:"members"`
+	Settings bool `json:"settings"`
+	Tasks    int  `json:"tasks"`
+}
+
+type cloneProjectResponse struct {
+	Project projectResponse      `json:"project"`
+	Copied  cloneSummaryResponse `json:"copied"`
+}
+
+// parseClonePath は /projects/{id}/clone から id を取り出す。
+func parseClonePath(path string) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/projects/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "clone" {
+		return "", false
+	}
+	return parts[0], true
+}
+
+// IsClonePath はパスが /projects/{id}/clone かどうかを返す。
+func IsClonePath(path string) bool {
+	_, ok := parseClonePath(path)
+	return ok
+}
+
+func (h *CloneProjectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	sourceID, ok := parseClonePath(r.URL.Path)
+	if !ok {
+		writeNotFound(w, "not found")
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req cloneProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	p, summary, err := h.cloneUC.Execute(r.Context(), usecase.CloneProjectInput{
+		SourceID:     sourceID,
+		ID:           req.ID,
+		Key:          req.Key,
+		Name:         req.Name,
+		IncludeTasks: req.IncludeTasks,
+		ActorID:      actorID(r),
+		Now:          h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := cloneProjectResponse{
+		Project: projectResponse{
+			ID:          p.ID,
+			Key:         p.Key,
+			Name:        p.Name,
+			Description: p.Description,
+			Status:      string(p.Status),
+			Visibility:  string(p.Visibility),
+			CreatedAt:   p.CreatedAt,
+			UpdatedAt:   p.UpdatedAt,
+			ArchivedAt:  p.ArchivedAt,
+			CreatedBy:   p.CreatedBy,
+			UpdatedBy:   p.UpdatedBy,
+		},
+		Copied: cloneSummaryResponse{
+			Members:  summary.Members,
+			Settings: summary.Settings,
+			Tasks:    summary.Tasks,
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,127 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "teamflow-projects/internal/domain/project"
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// stubTaskCopier は TaskCopier のテスト用スタブ。
+type stubTaskCopier struct {
+	stubSeeder
+	open    []domain.TaskBlueprint
+	listErr error
+}
+
+func (s *stubTaskCopier) ListOpenTasks(_ context.Context, _ string) ([]domain.TaskBlueprint, error) {
+	return s.open, s.listErr
+}
+
+type cloneBody struct {
+	Project struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Status string `json:"status"`
+	} `json:"project"`
+	Copied struct {
+		Members  int  `json:"members"`
+		Settings bool `json:"settings"`
+		Tasks    int  `json:"tasks"`
+	} `json:"copied"`
+}
+
+func TestCloneProjectHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		actor       string
+		body        string
+		listErr     error
+		wantStatus  int
+		wantMembers int
+		wantTasks   int
+	}{
+		{name: "clone with tasks", method: http.MethodPost, path: "/projects/proj-1/clone", actor: "owner-1", body: `{"id":"proj-2","includeTasks":true}`, wantStatus: http.StatusCreated, wantMembers: 1, wantTasks: 2},
+		{name: "clone without tasks", method: http.MethodPost, path: "/projects/proj-1/clone", actor: "admin-1", body: `{"id":"proj-2","name":"Copy"}`, wantStatus: http.StatusCreated, wantMembers: 1},
+		{name: "no actor", method: http.MethodPost, path: "/projects/proj-1/clone", body: `{"id":"proj-2"}`, wantStatus: http.StatusUnauthorized},
+		{name: "not a member", method: http.MethodPost, path: "/projects/proj-1/clone", actor: "stranger", body: `{"id":"proj-2"}`, wantStatus: http.StatusForbidden},
+		{name: "source not found", method: http.MethodPost, path: "/projects/proj-x/clone", actor: "owner-1", body: `{"id":"proj-2"}`, wantStatus: http.StatusNotFound},
+		{name: "tasks service fails", method: http.MethodPost, path: "/projects/proj-1/clone", actor: "owner-1", body: `{"id":"proj-2","includeTasks":true}`, listErr: errors.New("unavailable"), wantStatus: http.StatusBadGateway},
+		{name: "invalid json", method: http.MethodPost, path: "/projects/proj-1/clone", actor: "owner-1", body: `{invalid`, wantStatus: http.StatusBadRequest},
+		{name: "method not allowed", method: http.MethodGet, path: "/projects/proj-1/clone", actor: "owner-1", wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projects, members := newRoleRepos(t)
+			copier := &stubTaskCopier{
+				open:    []domain.TaskBlueprint{{Title: "設計", Status: "todo"}, {Title: "実装", Status: "in_progress"}},
+				listErr: tt.listErr,
+			}
+			handler := httpiface.NewCloneProjectHandler(&usecase.CloneProjectUsecase{
+				Create:       &usecase.CreateProjectUsecase{Repo: projects, Members: members},
+				Projects:     projects,
+				Members:      members,
+				Settings:     infra.NewMemorySettingsRepository(),
+				Tasks:        copier,
+				Tx:           infra.NoopTxManager{},
+				EnforceRoles: true,
+			}, fixedClock)
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewReader([]byte(tt.body)))
+			if tt.actor != "" {
+				req.Header.Set(httpiface.ActorHeader, tt.actor)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if w.Code != http.StatusCreated {
+				if _, err := projects.FindByID(context.Background(), "proj-2"); err == nil {
+					t.Error("expected clone not to be created")
+				}
+				return
+			}
+
+			var got cloneBody
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.Project.ID != "proj-2" || got.Project.Status != "active" {
+				t.Errorf("unexpected project: %+v", got.Project)
+			}
+			if got.Copied.Members != tt.wantMembers || got.Copied.Tasks != tt.wantTasks || got.Copied.Settings {
+				t.Errorf("unexpected summary: %+v", got.Copied)
+			}
+			if len(copier.tasks) != tt.wantTasks {
+				t.Errorf("expected %d seeded tasks, got %d", tt.wantTasks, len(copier.tasks))
+			}
+		})
+	}
+}
+
+func TestIsClonePath(t *testing.T) {
+	for path, want := range map[string]bool{
+		"/projects/proj-1/clone":    true,
+		"/projects/proj-1/settings": false,
+		"/projects//clone":          false,
+		"/projects/proj-1/clone/x":  false,
+	} {
+		if got := httpiface.IsClonePath(path); got != want {
+			t.Errorf("IsClonePath(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
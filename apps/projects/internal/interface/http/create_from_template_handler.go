@@ -0,0 +1,82 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"teamflow-shared/clock"
+
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// CreateFromTemplateHandler は POST /projects:from-template を処理する HTTP ハンドラ。
+// テンプレートからプロジェクトを作成し、定義済みのタスクを tasks サービスに作成する。
+type CreateFromTemplateHandler struct {
+	createUC *usecase.CreateProjectFromTemplateUsecase
+	clock    clock.Clock
+}
+
+// NewCreateFromTemplateHandler は CreateFromTemplateHandler を生成する。
+func NewCreateFromTemplateHandler(
+	createUC *usecase.CreateProjectFromTemplateUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &CreateFromTemplateHandler{
+		createUC: createUC,
+		clock:    clk,
+	}
+}
+
+type createFromTemplateRequest struct {
+	TemplateID string `json:"templateId"`
+	createProjectRequest
+}
+
+func (h *CreateFromTemplateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req createFromTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	p, err := h.createUC.Execute(r.Context(), usecase.CreateProjectFromTemplateInput{
+		TemplateID: req.TemplateID,
+		Project: usecase.CreateProjectInput{
+			ID:          req.ID,
+			Key:         req.Key,
+			Name:        req.Name,
+			Description: req.Description,
+			Status:      req.Status,
+			Visibility:  req.Visibility,
+			ActorID:     actorID(r),
+			Now:         h.clock.Now(),
+		},
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := projectResponse{
+		ID:          p.ID,
+		Key:         p.Key,
+		Name:        p.Name,
+		Description: p.Description,
+		Status:      string(p.Status),
+		Visibility:  string(p.Visibility),
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+		ArchivedAt:  p.ArchivedAt,
+		CreatedBy:   p.CreatedBy,
+		UpdatedBy:   p.UpdatedBy,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,74 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "teamflow-projects/internal/domain/project"
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// stubSeeder は TaskSeeder のテスト用スタブ。
+type stubSeeder struct {
+	err   error
+	tasks []domain.TaskBlueprint
+}
+
+func (s *stubSeeder) SeedTasks(_ context.Context, _ string, tasks []domain.TaskBlueprint) error {
+	s.tasks = tasks
+	return s.err
+}
+
+func TestCreateFromTemplateHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		seedErr    error
+		wantStatus int
+		wantSeeded int
+	}{
+		{name: "success", body: `{"templateId":"tmpl-1","id":"proj-1","name":"Sprint 1"}`, wantStatus: http.StatusCreated, wantSeeded: 2},
+		{name: "template not found", body: `{"templateId":"tmpl-x","id":"proj-1","name":"Sprint 1"}`, wantStatus: http.StatusNotFound},
+		{name: "invalid project", body: `{"templateId":"tmpl-1","id":"proj-1","name":""}`, wantStatus: http.StatusBadRequest},
+		{name: "seeding fails", body: `{"templateId":"tmpl-1","id":"proj-1","name":"Sprint 1"}`, seedErr: errors.New("unavailable"), wantStatus: http.StatusBadGateway, wantSeeded: 2},
+		{name: "invalid json", body: `{invalid`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templates := infra.NewMemoryTemplateRepository()
+			tmpl, err := domain.NewTemplate("tmpl-1", "スプリント", "", []domain.TaskBlueprint{{Title: "計画"}, {Title: "振り返り"}}, fixedNow())
+			if err != nil {
+				t.Fatalf("failed to create template: %v", err)
+			}
+			if err := templates.SaveTemplate(context.Background(), tmpl); err != nil {
+				t.Fatalf("failed to save template: %v", err)
+			}
+			seeder := &stubSeeder{err: tt.seedErr}
+			handler := httpiface.NewCreateFromTemplateHandler(&usecase.CreateProjectFromTemplateUsecase{
+				Create:    &usecase.CreateProjectUsecase{Repo: infra.NewMemoryProjectRepository()},
+				Templates: templates,
+				Tasks:     seeder,
+				Tx:        infra.NoopTxManager{},
+			}, fixedClock)
+
+			req := httptest.NewRequest(http.MethodPost, "/projects:from-template", bytes.NewReader([]byte(tt.body)))
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if len(seeder.tasks) != tt.wantSeeded {
+				t.Errorf("expected %d seeded tasks, got %d", tt.wantSeeded, len(seeder.tasks))
+			}
+		})
+	}
+}
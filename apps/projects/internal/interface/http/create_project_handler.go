@@ -1,52 +1,80 @@
 package http
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	"teamflow-shared/apierror"
+	"teamflow-shared/clock"
+	"teamflow-shared/workspace"
+
+	domain "teamflow-projects/internal/domain/project"
 	usecase "teamflow-projects/internal/usecase/project"
 )
 
 // ProjectHandler はプロジェクト関連の HTTP ハンドラを提供する。
 type ProjectHandler struct {
-	createUC *usecase.CreateProjectUsecase
-	listUC   *usecase.ListProjectsUsecase
-	nowFunc  func() time.Time
+	createUC     *usecase.CreateProjectUsecase
+	listUC       *usecase.ListProjectsUsecase
+	clock        clock.Clock
+	cursorSecret []byte
 }
 
 // NewProjectHandler は ProjectHandler を生成する。
+// cursorSecret は一覧の cursor の署名に使う。
 func NewProjectHandler(
 	createUC *usecase.CreateProjectUsecase,
 	listUC *usecase.ListProjectsUsecase,
-	nowFunc func() time.Time,
+	clk clock.Clock,
+	cursorSecret []byte,
 ) http.Handler {
 	return &ProjectHandler{
-		createUC: createUC,
-		listUC:   listUC,
-		nowFunc:  nowFunc,
+		createUC:     createUC,
+		listUC:       listUC,
+		clock:        clk,
+		cursorSecret: cursorSecret,
 	}
 }
 
 type createProjectRequest struct {
 	ID          string `json:"id"`
+	Key         string `json:"key"` // 省略時は未設定
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	Status      string `json:"status"`
+	Visibility  string `json:"visibility"` // 省略時は private
 }
 
 type projectResponse struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID          string     `json:"id"`
+	Key         string     `json:"key,omitempty"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Visibility  string     `json:"visibility"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	ArchivedAt  *time.Time `json:"archivedAt,omitempty"`
+	// CreatedBy / UpdatedBy は作成者・最終更新者（X-User-ID）。不明な場合は省略する
+	CreatedBy string `json:"createdBy,omitempty"`
+	UpdatedBy string `json:"updatedBy,omitempty"`
+	// TaskCounts は一覧で expand=taskCounts を指定した場合のみ設定する
+	TaskCounts *taskCountsResponse `json:"taskCounts,omitempty"`
+}
+
+// taskCountsResponse はプロジェクトのタスクの件数。
+type taskCountsResponse struct {
+	Open int `json:"open"`
+	Done int `json:"done"`
 }
 
 // ServeHTTP は /projects を処理する。
 // - POST: プロジェクト作成
-// - GET : プロジェクト一覧取得
+// - GET : プロジェクト一覧取得（q, archived, status, sort, limit, cursor）
 func (h *ProjectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodPost:
@@ -54,41 +82,46 @@ func (h *ProjectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case http.MethodGet:
 		h.handleList(w, r)
 	default:
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		writeMethodNotAllowed(w)
 	}
 }
 
 func (h *ProjectHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
 	var req createProjectRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		writeInvalidJSON(w)
 		return
 	}
 
 	in := usecase.CreateProjectInput{
 		ID:          req.ID,
+		Key:         req.Key,
 		Name:        req.Name,
 		Description: req.Description,
-		Now:         h.nowFunc(),
+		Status:      req.Status,
+		Visibility:  req.Visibility,
+		ActorID:     actorID(r),
+		Now:         h.clock.Now(),
 	}
 
 	p, err := h.createUC.Execute(r.Context(), in)
 	if err != nil {
-		// バリデーションエラー or その他（簡易判定）
-		if errors.Is(err, context.DeadlineExceeded) {
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-		w.WriteHeader(http.StatusBadRequest)
+		writeUsecaseError(w, err)
 		return
 	}
 
 	resp := projectResponse{
 		ID:          p.ID,
+		Key:         p.Key,
 		Name:        p.Name,
 		Description: p.Description,
+		Status:      string(p.Status),
+		Visibility:  string(p.Visibility),
 		CreatedAt:   p.CreatedAt,
 		UpdatedAt:   p.UpdatedAt,
+		ArchivedAt:  p.ArchivedAt,
+		CreatedBy:   p.CreatedBy,
+		UpdatedBy:   p.UpdatedBy,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -96,30 +129,185 @@ func (h *ProjectHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
+// nameConflictResponse は名前が重複する場合の 409 のレスポンス（ErrorResponse に既存プロジェクトの ID を加えたもの）。
+// UI は conflictingProjectId で既存プロジェクトを開く導線を出せる。
+type nameConflictResponse struct {
+	apierror.ErrorResponse
+	ConflictingProjectID string `json:"conflictingProjectId"`
+}
+
+// writeNameConflict は名前の重複（usecase.DuplicateNameError）の場合に 409 と既存プロジェクトの ID を書き込む。
+// それ以外の場合は何もせず false を返す。
+func writeNameConflict(w http.ResponseWriter, err error) bool {
+	var dup *usecase.DuplicateNameError
+	if !errors.As(err, &dup) {
+		return false
+	}
+	apierror.Write(w, http.StatusConflict, &nameConflictResponse{
+		ErrorResponse:        apierror.New(apierror.CodeConflict, usecase.ErrProjectNameAlreadyExists.Error()),
+		ConflictingProjectID: dup.ProjectID,
+	})
+	return true
+}
+
+// listProjectsResponse は GET /projects のレスポンス。
+type listProjectsResponse struct {
+	Projects []projectResponse `json:"projects"`
+	Page     pageInfo          `json:"page"`
+}
+
+type pageInfo struct {
+	NextCursor *string `json:"nextCursor,omitempty"`
+	Limit      int     `json:"limit"`
+}
+
+// handleList はクエリパラメータから ProjectQuery を構築し、プロジェクト一覧を返す。
+//
+//	q         名前の部分一致（大文字小文字を区別しない）
+//	archived  true / false（未指定は絞り込まない）
+//	status    active / on_hold / completed のカンマ区切り（未指定は絞り込まない）
+//	sort      name, -name, createdAt, -createdAt（default: createdAt）
+//	limit     1-200（default 200）
+//	cursor    前ページの page.nextCursor（sort とは併用不可。ソート順は cursor に含まれる）
+//	expand    taskCounts の場合は各プロジェクトにタスクの件数を含める（tasks サービスを 1 回だけ呼び出す）
 func (h *ProjectHandler) handleList(w http.ResponseWriter, r *http.Request) {
 	if h.listUC == nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		writeInternalError(w)
+		return
+	}
+
+	params := r.URL.Query()
+	cursor := params.Get("cursor")
+	sortStr := params.Get("sort")
+	if cursor != "" && sortStr != "" {
+		issue, _ := toValidationIssue(apierror.LocationQuery, domain.ErrSortIncompatibleWithCursor)
+		writeValidationError(w, issue)
+		return
+	}
+
+	limit := domain.DefaultListLimit
+	if limitStr := params.Get("limit"); limitStr != "" {
+		v, err := strconv.Atoi(limitStr)
+		if err != nil || v < 1 || v > domain.MaxListLimit {
+			issue, _ := toValidationIssue(apierror.LocationQuery, domain.ErrLimitOutOfRange)
+			issue.RejectedValue = &limitStr
+			writeValidationError(w, issue)
+			return
+		}
+		limit = v
+	}
+
+	withTaskCounts, err := parseListExpand(params.Get("expand"))
+	if err != nil {
+		writeQueryError(w, err)
+		return
+	}
+
+	// cursor は qhash をワークスペースとフィルタ条件から計算するため最後に渡す
+	query, err := domain.NewProjectQuery(
+		domain.WithWorkspace(workspace.FromContext(r.Context())),
+		domain.WithQueryFilter(params.Get("q")),
+		domain.WithArchivedFilter(params.Get("archived")),
+		domain.WithStatusFilter(params.Get("status")),
+		domain.WithSort(sortStr),
+		domain.WithLimit(limit),
+		domain.WithCursor(cursor, h.cursorSecret, h.clock.Now()),
+	)
+	if err == nil {
+		err = query.Validate()
+	}
+	if err != nil {
+		writeQueryError(w, err)
 		return
 	}
 
-	projects, err := h.listUC.Execute(r.Context())
+	projects, err := h.listUC.ExecuteWithQuery(r.Context(), query, actorID(r))
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		writeUsecaseError(w, err)
 		return
 	}
 
+	// repository 層で limit + 1 件取得している。次ページがあれば limit 件目から nextCursor を作る
+	var nextCursor *string
+	if len(projects) > query.Limit {
+		projects = projects[:query.Limit]
+		c, err := domain.EncodeCursor(query.NewCursorPayload(projects[len(projects)-1], h.clock.Now()), h.cursorSecret)
+		if err != nil {
+			writeInternalError(w)
+			return
+		}
+		nextCursor = &c
+	}
+
+	var counts map[string]*domain.Stats
+	if withTaskCounts {
+		counts, err = h.listUC.TaskCounts(r.Context(), projects)
+		if err != nil {
+			writeUsecaseError(w, err)
+			return
+		}
+	}
+
 	responses := make([]projectResponse, 0, len(projects))
 	for _, p := range projects {
-		responses = append(responses, projectResponse{
+		resp := projectResponse{
 			ID:          p.ID,
+			Key:         p.Key,
 			Name:        p.Name,
 			Description: p.Description,
+			Status:      string(p.Status),
+			Visibility:  string(p.Visibility),
 			CreatedAt:   p.CreatedAt,
 			UpdatedAt:   p.UpdatedAt,
-		})
+			ArchivedAt:  p.ArchivedAt,
+			CreatedBy:   p.CreatedBy,
+			UpdatedBy:   p.UpdatedBy,
+		}
+		if withTaskCounts {
+			// tasks サービスの結果に無いプロジェクトはタスクが無いものとして扱う
+			resp.TaskCounts = &taskCountsResponse{}
+			if s := counts[p.ID]; s != nil {
+				resp.TaskCounts.Open, resp.TaskCounts.Done = s.Open, s.Done
+			}
+		}
+		responses = append(responses, resp)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	_ = json.NewEncoder(w).Encode(responses)
+	_ = json.NewEncoder(w).Encode(listProjectsResponse{
+		Projects: responses,
+		Page: pageInfo{
+			NextCursor: nextCursor,
+			Limit:      query.Limit,
+		},
+	})
+}
+
+// parseListExpand は一覧の expand（カンマ区切り）を解析し、taskCounts が指定されているかどうかを返す。
+func parseListExpand(v string) (taskCounts bool, err error) {
+	for _, e := range strings.Split(v, ",") {
+		switch strings.TrimSpace(e) {
+		case "":
+		case "taskCounts":
+			taskCounts = true
+		default:
+			return false, domain.ErrInvalidExpand
+		}
+	}
+	return taskCounts, nil
+}
+
+// writeQueryError は一覧のクエリパラメータのエラーを 400 で書き込む。
+func writeQueryError(w http.ResponseWriter, err error) {
+	issue, ok := toValidationIssue(apierror.LocationQuery, err)
+	if !ok {
+		issue = apierror.ValidationIssue{
+			Location: apierror.LocationQuery,
+			Field:    "unknown",
+			Code:     "UNKNOWN",
+			Message:  "クエリパラメータが不正です。入力内容を確認してください。",
+		}
+	}
+	writeValidationError(w, issue)
 }
@@ -9,6 +9,8 @@ import (
 	"testing"
 	"time"
 
+	"teamflow-shared/clock"
+
 	domain "teamflow-projects/internal/domain/project"
 	infra "teamflow-projects/internal/infrastructure/project"
 	httpiface "teamflow-projects/internal/interface/http"
@@ -16,10 +18,15 @@ import (
 )
 
 // テスト用の時刻固定関数
+var testCursorSecret = []byte("test-cursor-secret")
+
 func fixedNow() time.Time {
 	return time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
 }
 
+// fixedClock は fixedNow を返す Clock。ハンドラに渡す。
+var fixedClock = clock.Fixed(fixedNow())
+
 func TestCreateProjectHandler_Success(t *testing.T) {
 	repo := infra.NewMemoryProjectRepository()
 
@@ -30,7 +37,7 @@ func TestCreateProjectHandler_Success(t *testing.T) {
 		Repo: repo,
 	}
 
-	handler := httpiface.NewProjectHandler(createUC, listUC, fixedNow)
+	handler := httpiface.NewProjectHandler(createUC, listUC, fixedClock, testCursorSecret)
 
 	body := map[string]string{
 		"id":          "proj-1",
@@ -45,6 +52,7 @@ func TestCreateProjectHandler_Success(t *testing.T) {
 
 	req := httptest.NewRequest(http.MethodPost, "/projects", bytes.NewReader(b))
 	req = req.WithContext(context.Background())
+	req.Header.Set("X-User-ID", "user-1")
 	w := httptest.NewRecorder()
 
 	handler.ServeHTTP(w, req)
@@ -60,8 +68,11 @@ func TestCreateProjectHandler_Success(t *testing.T) {
 		ID          string    `json:"id"`
 		Name        string    `json:"name"`
 		Description string    `json:"description"`
+		Status      string    `json:"status"`
 		CreatedAt   time.Time `json:"createdAt"`
 		UpdatedAt   time.Time `json:"updatedAt"`
+		CreatedBy   string    `json:"createdBy"`
+		UpdatedBy   string    `json:"updatedBy"`
 	}
 	if err := json.NewDecoder(res.Body).Decode(&respBody); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
@@ -76,6 +87,12 @@ func TestCreateProjectHandler_Success(t *testing.T) {
 	if respBody.Description != body["description"] {
 		t.Errorf("expected description=%s, got=%s", body["description"], respBody.Description)
 	}
+	if respBody.Status != "active" {
+		t.Errorf("expected status=active, got=%s", respBody.Status)
+	}
+	if respBody.CreatedBy != "user-1" || respBody.UpdatedBy != "user-1" {
+		t.Errorf("expected createdBy=updatedBy=user-1, got=%q/%q", respBody.CreatedBy, respBody.UpdatedBy)
+	}
 
 	// メモリリポジトリに保存されていることも確認
 	stored, err := repo.FindByID(context.Background(), "proj-1")
@@ -93,7 +110,7 @@ func TestCreateProjectHandler_InvalidJSON(t *testing.T) {
 	createUC := &usecase.CreateProjectUsecase{Repo: repo}
 	listUC := &usecase.ListProjectsUsecase{Repo: repo}
 
-	handler := httpiface.NewProjectHandler(createUC, listUC, fixedNow)
+	handler := httpiface.NewProjectHandler(createUC, listUC, fixedClock, testCursorSecret)
 
 	req := httptest.NewRequest(http.MethodPost, "/projects", bytes.NewReader([]byte("{invalid")))
 	w := httptest.NewRecorder()
@@ -114,7 +131,7 @@ func TestCreateProjectHandler_ValidationError(t *testing.T) {
 	createUC := &usecase.CreateProjectUsecase{Repo: repo}
 	listUC := &usecase.ListProjectsUsecase{Repo: repo}
 
-	handler := httpiface.NewProjectHandler(createUC, listUC, fixedNow)
+	handler := httpiface.NewProjectHandler(createUC, listUC, fixedClock, testCursorSecret)
 
 	body := map[string]string{
 		"id":          "proj-1",
@@ -136,6 +153,88 @@ func TestCreateProjectHandler_ValidationError(t *testing.T) {
 	}
 }
 
+func TestCreateProjectHandler_Key(t *testing.T) {
+	repo := infra.NewMemoryProjectRepository()
+	handler := httpiface.NewProjectHandler(
+		&usecase.CreateProjectUsecase{Repo: repo},
+		&usecase.ListProjectsUsecase{Repo: repo},
+		fixedClock, testCursorSecret,
+	)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantKey    string
+	}{
+		{name: "created with normalized key", body: `{"id":"proj-1","key":"tflow","name":"TeamFlow 開発"}`, wantStatus: http.StatusCreated, wantKey: "TFLOW"},
+		{name: "duplicate key", body: `{"id":"proj-2","key":"TFLOW","name":"別プロジェクト"}`, wantStatus: http.StatusConflict},
+		{name: "invalid key", body: `{"id":"proj-3","key":"T-1","name":"別プロジェクト"}`, wantStatus: http.StatusBadRequest},
+	}
+
+	// 順に実行する（2 件目は 1 件目のキーと重複する）
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/projects", bytes.NewReader([]byte(tt.body)))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		if w.Code != tt.wantStatus {
+			t.Fatalf("%s: expected status %d, got %d", tt.name, tt.wantStatus, w.Code)
+		}
+		if w.Code != http.StatusCreated {
+			continue
+		}
+		var got struct {
+			Key string `json:"key"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if got.Key != tt.wantKey {
+			t.Errorf("%s: expected key %q, got %q", tt.name, tt.wantKey, got.Key)
+		}
+	}
+}
+
+func TestCreateProjectHandler_DuplicateName(t *testing.T) {
+	repo := infra.NewMemoryProjectRepository()
+	handler := httpiface.NewProjectHandler(
+		&usecase.CreateProjectUsecase{Repo: repo, UniqueNames: true},
+		&usecase.ListProjectsUsecase{Repo: repo},
+		fixedClock, testCursorSecret,
+	)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/projects", bytes.NewReader([]byte(body)))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := post(`{"id":"proj-1","name":"TeamFlow 開発"}`); w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d", http.StatusCreated, w.Code)
+	}
+
+	w := post(`{"id":"proj-2","name":"teamflow 開発"}`)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status %d, got %d", http.StatusConflict, w.Code)
+	}
+	var got struct {
+		Error                string `json:"error"`
+		ConflictingProjectID string `json:"conflictingProjectId"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.ConflictingProjectID != "proj-1" || got.Error == "" {
+		t.Errorf("unexpected response: %+v", got)
+	}
+	if _, err := repo.FindByID(context.Background(), "proj-2"); err == nil {
+		t.Error("expected duplicate project not to be created")
+	}
+}
+
 func TestCreateProjectHandler_InternalError(t *testing.T) {
 	// リポジトリを差し替えて、あえてエラーを起こす
 	repo := &errorRepo{}
@@ -143,7 +242,7 @@ func TestCreateProjectHandler_InternalError(t *testing.T) {
 	createUC := &usecase.CreateProjectUsecase{Repo: repo}
 	listUC := &usecase.ListProjectsUsecase{Repo: repo}
 
-	handler := httpiface.NewProjectHandler(createUC, listUC, fixedNow)
+	handler := httpiface.NewProjectHandler(createUC, listUC, fixedClock, testCursorSecret)
 
 	body := map[string]string{
 		"id":          "proj-1",
@@ -172,10 +271,26 @@ func (r *errorRepo) Save(_ context.Context, _ *domain.Project) error {
 	return context.DeadlineExceeded
 }
 
+func (r *errorRepo) Update(_ context.Context, _ *domain.Project) error {
+	return context.DeadlineExceeded
+}
+
 func (r *errorRepo) FindByID(_ context.Context, _ string) (*domain.Project, error) {
 	return nil, context.DeadlineExceeded
 }
 
+func (r *errorRepo) FindDeleted(_ context.Context, _ string) (*domain.Project, error) {
+	return nil, context.DeadlineExceeded
+}
+
+func (r *errorRepo) FindByName(_ context.Context, _ string) (*domain.Project, error) {
+	return nil, context.DeadlineExceeded
+}
+
 func (r *errorRepo) List(_ context.Context) ([]*domain.Project, error) {
 	return nil, context.DeadlineExceeded
 }
+
+func (r *errorRepo) FindWithQuery(_ context.Context, _ *domain.ProjectQuery) ([]*domain.Project, error) {
+	return nil, context.DeadlineExceeded
+}
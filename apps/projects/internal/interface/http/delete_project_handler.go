@@ -0,0 +1,149 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"teamflow-shared/clock"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// DeleteProjectHandler は DELETE /projects/{id}?cascade= と POST /projects/{id}/restore を処理する HTTP ハンドラ。
+type DeleteProjectHandler struct {
+	deleteUC  *usecase.DeleteProjectUsecase
+	restoreUC *usecase.RestoreProjectUsecase
+	clock     clock.Clock
+}
+
+// NewDeleteProjectHandler は DeleteProjectHandler を生成する。
+func NewDeleteProjectHandler(
+	deleteUC *usecase.DeleteProjectUsecase,
+	restoreUC *usecase.RestoreProjectUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &DeleteProjectHandler{
+		deleteUC:  deleteUC,
+		restoreUC: restoreUC,
+		clock:     clk,
+	}
+}
+
+// deletedProjectResponse は削除したプロジェクトのレスポンス。
+type deletedProjectResponse struct {
+	projectResponse
+	DeletedAt       *time.Time `json:"deletedAt"`
+	DeletePolicy    string     `json:"deletePolicy"`
+	RestorableUntil time.Time  `json:"restorableUntil"` // この日時まで POST /projects/{id}/restore で復元できる
+}
+
+// parseRestorePath は /projects/{id}/restore から id を取り出す。
+func parseRestorePath(path string) (id string, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/projects/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "restore" {
+		return "", false
+	}
+	return parts[0], true
+}
+
+// IsRestorePath はパスが /projects/{id}/restore かどうかを返す。
+func IsRestorePath(path string) bool {
+	_, ok := parseRestorePath(path)
+	return ok
+}
+
+func (h *DeleteProjectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if id, ok := parseRestorePath(r.URL.Path); ok {
+		h.restore(w, r, id)
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/projects/")
+	if id == "" || strings.Contains(id, "/") {
+		writeNotFound(w, "not found")
+		return
+	}
+	if r.Method != http.MethodDelete {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	policy, err := domain.ParseDeletePolicy(r.URL.Query().Get("cascade"))
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	p, err := h.deleteUC.Execute(r.Context(), usecase.DeleteProjectInput{
+		ID:      id,
+		Policy:  policy,
+		ActorID: actorID(r),
+		Now:     h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := deletedProjectResponse{
+		projectResponse: projectResponse{
+			ID:          p.ID,
+			Key:         p.Key,
+			Name:        p.Name,
+			Description: p.Description,
+			Status:      string(p.Status),
+			Visibility:  string(p.Visibility),
+			CreatedAt:   p.CreatedAt,
+			UpdatedAt:   p.UpdatedAt,
+			ArchivedAt:  p.ArchivedAt,
+			CreatedBy:   p.CreatedBy,
+			UpdatedBy:   p.UpdatedBy,
+		},
+		DeletedAt:       p.DeletedAt,
+		DeletePolicy:    string(p.DeletePolicy),
+		RestorableUntil: p.RestorableUntil(h.restoreUC.Window),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// restore は POST /projects/{id}/restore を処理する。
+func (h *DeleteProjectHandler) restore(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	p, err := h.restoreUC.Execute(r.Context(), usecase.RestoreProjectInput{
+		ID:      id,
+		ActorID: actorID(r),
+		Now:     h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := projectResponse{
+		ID:          p.ID,
+		Key:         p.Key,
+		Name:        p.Name,
+		Description: p.Description,
+		Status:      string(p.Status),
+		Visibility:  string(p.Visibility),
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+		ArchivedAt:  p.ArchivedAt,
+		CreatedBy:   p.CreatedBy,
+		UpdatedBy:   p.UpdatedBy,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
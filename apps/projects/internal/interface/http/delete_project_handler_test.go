@@ -0,0 +1,115 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"teamflow-shared/clock"
+
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// stubTaskCascader は TaskCascader のテスト用スタブ。呼び出しを記録する。
+type stubTaskCascader struct {
+	calls []string
+}
+
+func (s *stubTaskCascader) ArchiveTasks(_ context.Context, projectID string) error {
+	s.calls = append(s.calls, "archive:"+projectID)
+	return nil
+}
+
+func (s *stubTaskCascader) UnarchiveTasks(_ context.Context, projectID string) error {
+	s.calls = append(s.calls, "unarchive:"+projectID)
+	return nil
+}
+
+func (s *stubTaskCascader) DeleteTasks(_ context.Context, projectID string) error {
+	s.calls = append(s.calls, "delete:"+projectID)
+	return nil
+}
+
+func TestDeleteProjectHandler(t *testing.T) {
+	projects, members := newRoleRepos(t)
+	tasks := &stubTaskCascader{}
+	window := 24 * time.Hour
+	clk := clock.NewFake(fixedNow())
+	handler := httpiface.NewDeleteProjectHandler(
+		&usecase.DeleteProjectUsecase{Repo: projects, Members: members, Stats: &stubStatsProvider{}, Tasks: tasks, EnforceRoles: true},
+		&usecase.RestoreProjectUsecase{Repo: projects, Members: members, Tasks: tasks, Window: window, EnforceRoles: true},
+		clk,
+	)
+
+	// 順に実行する（前のステップの結果に依存する）
+	steps := []struct {
+		name       string
+		method     string
+		path       string
+		actor      string
+		now        time.Time
+		wantStatus int
+	}{
+		{name: "no actor", method: http.MethodDelete, path: "/projects/proj-1", wantStatus: http.StatusUnauthorized},
+		{name: "admin is forbidden", method: http.MethodDelete, path: "/projects/proj-1", actor: "admin-1", wantStatus: http.StatusForbidden},
+		{name: "invalid cascade", method: http.MethodDelete, path: "/projects/proj-1?cascade=purge", actor: "owner-1", wantStatus: http.StatusBadRequest},
+		{name: "block with tasks", method: http.MethodDelete, path: "/projects/proj-1", actor: "owner-1", wantStatus: http.StatusConflict},
+		{name: "archive tasks", method: http.MethodDelete, path: "/projects/proj-1?cascade=archive_tasks", actor: "owner-1", wantStatus: http.StatusOK},
+		{name: "already deleted", method: http.MethodDelete, path: "/projects/proj-1?cascade=archive_tasks", actor: "owner-1", wantStatus: http.StatusNotFound},
+		{name: "restore after window", method: http.MethodPost, path: "/projects/proj-1/restore", actor: "owner-1", now: fixedNow().Add(window), wantStatus: http.StatusConflict},
+		{name: "restore method not allowed", method: http.MethodGet, path: "/projects/proj-1/restore", actor: "owner-1", wantStatus: http.StatusMethodNotAllowed},
+		{name: "restore", method: http.MethodPost, path: "/projects/proj-1/restore", actor: "owner-1", now: fixedNow().Add(time.Hour), wantStatus: http.StatusOK},
+		{name: "restore not deleted", method: http.MethodPost, path: "/projects/proj-1/restore", actor: "owner-1", wantStatus: http.StatusNotFound},
+	}
+
+	for _, step := range steps {
+		clk.Set(fixedNow())
+		if !step.now.IsZero() {
+			clk.Set(step.now)
+		}
+		req := httptest.NewRequest(step.method, step.path, nil)
+		if step.actor != "" {
+			req.Header.Set(httpiface.ActorHeader, step.actor)
+		}
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		if w.Code != step.wantStatus {
+			t.Fatalf("%s: expected status %d, got %d (%s)", step.name, step.wantStatus, w.Code, w.Body.String())
+		}
+		if w.Code != http.StatusOK {
+			continue
+		}
+
+		var body struct {
+			ID              string     `json:"id"`
+			DeletedAt       *time.Time `json:"deletedAt"`
+			DeletePolicy    string     `json:"deletePolicy"`
+			RestorableUntil *time.Time `json:"restorableUntil"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("%s: failed to decode response: %v", step.name, err)
+		}
+		switch step.name {
+		case "archive tasks":
+			if body.DeletedAt == nil || !body.DeletedAt.Equal(fixedNow()) || body.DeletePolicy != "archive_tasks" ||
+				body.RestorableUntil == nil || !body.RestorableUntil.Equal(fixedNow().Add(window)) {
+				t.Errorf("%s: unexpected response: %+v", step.name, body)
+			}
+		case "restore":
+			if body.ID != "proj-1" || body.DeletedAt != nil {
+				t.Errorf("%s: unexpected response: %+v", step.name, body)
+			}
+		}
+	}
+
+	want := []string{"archive:proj-1", "unarchive:proj-1"}
+	if len(tasks.calls) != len(want) || tasks.calls[0] != want[0] || tasks.calls[1] != want[1] {
+		t.Errorf("expected task calls %v, got %v", want, tasks.calls)
+	}
+}
@@ -0,0 +1,296 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"teamflow-shared/clock"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// EpicsHandler は /projects/{id}/epics 以下を処理する HTTP ハンドラ。
+type EpicsHandler struct {
+	createUC   *usecase.CreateEpicUsecase
+	updateUC   *usecase.UpdateEpicUsecase
+	deleteUC   *usecase.DeleteEpicUsecase
+	listUC     *usecase.ListEpicsUsecase
+	getUC      *usecase.GetEpicUsecase
+	progressUC *usecase.GetEpicProgressUsecase
+	clock      clock.Clock
+}
+
+// NewEpicsHandler は EpicsHandler を生成する。
+func NewEpicsHandler(
+	createUC *usecase.CreateEpicUsecase,
+	updateUC *usecase.UpdateEpicUsecase,
+	deleteUC *usecase.DeleteEpicUsecase,
+	listUC *usecase.ListEpicsUsecase,
+	getUC *usecase.GetEpicUsecase,
+	progressUC *usecase.GetEpicProgressUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &EpicsHandler{
+		createUC:   createUC,
+		updateUC:   updateUC,
+		deleteUC:   deleteUC,
+		listUC:     listUC,
+		getUC:      getUC,
+		progressUC: progressUC,
+		clock:      clk,
+	}
+}
+
+// epicRequest は POST / PUT のリクエスト。PUT では id を無視し、名前・説明・状態を置き換える。
+type epicRequest struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Status      string `json:"status"` // 省略時は open
+}
+
+type epicResponse struct {
+	ID          string    `json:"id"`
+	ProjectID   string    `json:"projectId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type listEpicsResponse struct {
+	Epics []epicResponse `json:"epics"`
+}
+
+// epicProgressResponse は GET /projects/{id}/epics:progress の 1 件分。
+type epicProgressResponse struct {
+	epicResponse
+	Total         int `json:"total"`
+	Done          int `json:"done"`
+	EstimateTotal int `json:"estimateTotal"`
+	EstimateDone  int `json:"estimateDone"`
+}
+
+type listEpicProgressResponse struct {
+	ProjectID string                 `json:"projectId"`
+	Epics     []epicProgressResponse `json:"epics"`
+}
+
+// toEpicProgressResponses はエピックの進捗をレスポンスに変換する（空の場合も [] にする）。
+func toEpicProgressResponses(progress []domain.EpicProgress) []epicProgressResponse {
+	out := make([]epicProgressResponse, 0, len(progress))
+	for _, p := range progress {
+		out = append(out, epicProgressResponse{
+			epicResponse:  toEpicResponse(p.Epic),
+			Total:         p.Total,
+			Done:          p.Done,
+			EstimateTotal: p.EstimateTotal,
+			EstimateDone:  p.EstimateDone,
+		})
+	}
+	return out
+}
+
+func toEpicResponse(e *domain.Epic) epicResponse {
+	return epicResponse{
+		ID:          e.ID,
+		ProjectID:   e.ProjectID,
+		Name:        e.Name,
+		Description: e.Description,
+		Status:      string(e.Status),
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+// epicsProgressSegment は進捗を返すサブリソース名（/projects/{id}/epics:progress）。
+const epicsProgressSegment = "epics:progress"
+
+// parseEpicsPath は /projects/{id}/epics[/{epicId}] と /projects/{id}/epics:progress から
+// projectID と epicID を取り出す。progress は :progress の場合に true。
+func parseEpicsPath(path string) (projectID, epicID string, progress, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/projects/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+		return "", "", false, false
+	}
+	switch {
+	case len(parts) == 2 && parts[1] == epicsProgressSegment:
+		return parts[0], "", true, true
+	case parts[1] != "epics":
+		return "", "", false, false
+	}
+	if len(parts) == 3 {
+		if parts[2] == "" {
+			return "", "", false, false
+		}
+		epicID = parts[2]
+	}
+	return parts[0], epicID, false, true
+}
+
+// IsEpicsPath はパスが /projects/{id}/epics 以下（:progress を含む）かどうかを返す。
+func IsEpicsPath(path string) bool {
+	_, _, _, ok := parseEpicsPath(path)
+	return ok
+}
+
+// ServeHTTP は以下を処理する。
+// - GET    /projects/{id}/epics          : エピック一覧
+// - POST   /projects/{id}/epics          : エピック作成
+// - GET    /projects/{id}/epics:progress : エピックごとのタスクの進捗（件数と見積もりの合計）
+// - GET    /projects/{id}/epics/{epicId} : エピック取得（tasks サービスの存在チェック用）
+// - PUT    /projects/{id}/epics/{epicId} : エピック更新
+// - DELETE /projects/{id}/epics/{epicId} : エピック削除
+//
+// エピックに属するタスクの一覧は tasks サービスの GET /api/projects/{id}/tasks?epicId= で取得する。
+func (h *EpicsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	projectID, epicID, progress, ok := parseEpicsPath(r.URL.Path)
+	if !ok {
+		writeNotFound(w, "not found")
+		return
+	}
+
+	switch {
+	case progress:
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w)
+			return
+		}
+		h.handleProgress(w, r, projectID)
+	case epicID == "":
+		switch r.Method {
+		case http.MethodGet:
+			h.handleList(w, r, projectID)
+		case http.MethodPost:
+			h.handleCreate(w, r, projectID)
+		default:
+			writeMethodNotAllowed(w)
+		}
+	default:
+		switch r.Method {
+		case http.MethodGet:
+			h.handleGet(w, r, projectID, epicID)
+		case http.MethodPut:
+			h.handleUpdate(w, r, projectID, epicID)
+		case http.MethodDelete:
+			h.handleDelete(w, r, projectID, epicID)
+		default:
+			writeMethodNotAllowed(w)
+		}
+	}
+}
+
+func (h *EpicsHandler) handleList(w http.ResponseWriter, r *http.Request, projectID string) {
+	epics, err := h.listUC.Execute(r.Context(), projectID, actorID(r))
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := listEpicsResponse{Epics: make([]epicResponse, 0, len(epics))}
+	for _, e := range epics {
+		resp.Epics = append(resp.Epics, toEpicResponse(e))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *EpicsHandler) handleCreate(w http.ResponseWriter, r *http.Request, projectID string) {
+	var req epicRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	e, err := h.createUC.Execute(r.Context(), usecase.CreateEpicInput{
+		ProjectID:   projectID,
+		ID:          req.ID,
+		Name:        req.Name,
+		Description: req.Description,
+		Status:      req.Status,
+		ActorID:     actorID(r),
+		Now:         h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(toEpicResponse(e))
+}
+
+func (h *EpicsHandler) handleGet(w http.ResponseWriter, r *http.Request, projectID, epicID string) {
+	e, err := h.getUC.Execute(r.Context(), projectID, epicID)
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toEpicResponse(e))
+}
+
+func (h *EpicsHandler) handleUpdate(w http.ResponseWriter, r *http.Request, projectID, epicID string) {
+	var req epicRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	e, err := h.updateUC.Execute(r.Context(), usecase.UpdateEpicInput{
+		ProjectID:   projectID,
+		ID:          epicID,
+		Name:        req.Name,
+		Description: req.Description,
+		Status:      req.Status,
+		ActorID:     actorID(r),
+		Now:         h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toEpicResponse(e))
+}
+
+func (h *EpicsHandler) handleDelete(w http.ResponseWriter, r *http.Request, projectID, epicID string) {
+	err := h.deleteUC.Execute(r.Context(), usecase.DeleteEpicInput{
+		ProjectID: projectID,
+		ID:        epicID,
+		ActorID:   actorID(r),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *EpicsHandler) handleProgress(w http.ResponseWriter, r *http.Request, projectID string) {
+	progress, err := h.progressUC.Execute(r.Context(), projectID, actorID(r))
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := listEpicProgressResponse{
+		ProjectID: projectID,
+		Epics:     toEpicProgressResponses(progress),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
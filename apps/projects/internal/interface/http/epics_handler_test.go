@@ -0,0 +1,151 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	domain "teamflow-projects/internal/domain/project"
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// epicStatsStub は EpicStatsProvider のスタブ。
+type epicStatsStub map[string]domain.EpicTaskCounts
+
+func (s epicStatsStub) EpicStats(context.Context, string) (map[string]domain.EpicTaskCounts, error) {
+	return s, nil
+}
+
+func newEpicsHandler(t *testing.T, stats usecase.EpicStatsProvider) http.Handler {
+	t.Helper()
+	projects := infra.NewMemoryProjectRepository()
+	seedProject(projects, "proj-1")
+	epics := infra.NewMemoryEpicRepository()
+
+	return httpiface.NewEpicsHandler(
+		&usecase.CreateEpicUsecase{Projects: projects, Epics: epics},
+		&usecase.UpdateEpicUsecase{Epics: epics},
+		&usecase.DeleteEpicUsecase{Epics: epics},
+		&usecase.ListEpicsUsecase{Projects: projects, Epics: epics},
+		&usecase.GetEpicUsecase{Epics: epics},
+		&usecase.GetEpicProgressUsecase{Projects: projects, Epics: epics, Stats: stats},
+		fixedClock,
+	)
+}
+
+type epicBody struct {
+	ID          string `json:"id"`
+	ProjectID   string `json:"projectId"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+}
+
+func TestEpicsHandler_Lifecycle(t *testing.T) {
+	handler := newEpicsHandler(t, epicStatsStub{"e1": {Total: 3, Done: 1, EstimateTotal: 8, EstimateDone: 3}})
+
+	w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/epics", map[string]any{"id": "e1", "name": "ログイン", "description": "SSO 対応"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created epicBody
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.ID != "e1" || created.ProjectID != "proj-1" || created.Status != "open" || created.Description != "SSO 対応" {
+		t.Errorf("unexpected epic: %+v", created)
+	}
+
+	if w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/epics", map[string]any{"id": "e1", "name": "dup"}); w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for duplicate epic, got %d", w.Code)
+	}
+	if w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/epics", map[string]any{"id": "e2", "name": "検索"}); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", w.Code)
+	}
+
+	w = doMembersRequest(handler, http.MethodPut, "/projects/proj-1/epics/e2", map[string]any{"name": "全文検索", "status": "closed"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var updated epicBody
+	if err := json.NewDecoder(w.Body).Decode(&updated); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if updated.ID != "e2" || updated.Name != "全文検索" || updated.Status != "closed" {
+		t.Errorf("unexpected epic: %+v", updated)
+	}
+
+	w = doMembersRequest(handler, http.MethodGet, "/projects/proj-1/epics:progress", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var progress struct {
+		ProjectID string `json:"projectId"`
+		Epics     []struct {
+			ID            string `json:"id"`
+			Total         int    `json:"total"`
+			Done          int    `json:"done"`
+			EstimateTotal int    `json:"estimateTotal"`
+			EstimateDone  int    `json:"estimateDone"`
+		} `json:"epics"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&progress); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if progress.ProjectID != "proj-1" || len(progress.Epics) != 2 {
+		t.Fatalf("unexpected progress: %+v", progress)
+	}
+	if e := progress.Epics[0]; e.ID != "e1" || e.Total != 3 || e.Done != 1 || e.EstimateTotal != 8 || e.EstimateDone != 3 {
+		t.Errorf("unexpected e1 progress: %+v", e)
+	}
+	if e := progress.Epics[1]; e.ID != "e2" || e.Total != 0 || e.EstimateTotal != 0 {
+		t.Errorf("unexpected e2 progress: %+v", e)
+	}
+
+	if w := doMembersRequest(handler, http.MethodDelete, "/projects/proj-1/epics/e1", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", w.Code)
+	}
+	if w := doMembersRequest(handler, http.MethodGet, "/projects/proj-1/epics/e1", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 after delete, got %d", w.Code)
+	}
+}
+
+func TestEpicsHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       any
+		wantStatus int
+	}{
+		{name: "empty name", method: http.MethodPost, path: "/projects/proj-1/epics", body: map[string]any{"id": "e1"}, wantStatus: http.StatusBadRequest},
+		{name: "invalid status", method: http.MethodPost, path: "/projects/proj-1/epics", body: map[string]any{"id": "e1", "name": "e1", "status": "done"}, wantStatus: http.StatusBadRequest},
+		{name: "project not found", method: http.MethodPost, path: "/projects/missing/epics", body: map[string]any{"id": "e1", "name": "e1"}, wantStatus: http.StatusNotFound},
+		{name: "update not found", method: http.MethodPut, path: "/projects/proj-1/epics/missing", body: map[string]any{"name": "x"}, wantStatus: http.StatusNotFound},
+		{name: "method not allowed", method: http.MethodPatch, path: "/projects/proj-1/epics", wantStatus: http.StatusMethodNotAllowed},
+		{name: "progress method not allowed", method: http.MethodPost, path: "/projects/proj-1/epics:progress", wantStatus: http.StatusMethodNotAllowed},
+		{name: "nested path", method: http.MethodGet, path: "/projects/proj-1/epics/e1/tasks", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newEpicsHandler(t, nil)
+			if w := doMembersRequest(handler, tt.method, tt.path, tt.body); w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestEpicsHandler_ProgressWithoutTasksService(t *testing.T) {
+	handler := newEpicsHandler(t, nil)
+	if w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/epics", map[string]any{"id": "e1", "name": "e1"}); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", w.Code)
+	}
+	if w := doMembersRequest(handler, http.MethodGet, "/projects/proj-1/epics:progress", nil); w.Code != http.StatusBadGateway {
+		t.Errorf("expected status 502, got %d", w.Code)
+	}
+}
@@ -0,0 +1,192 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/requestid"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// エラーレスポンスは tasks サービスと同じ ErrorResponse / ValidationIssue 形式（teamflow-shared/apierror）で返す。
+
+// writeError は status と ErrorResponse を書き込む。
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	apierror.Write(w, status, apierror.New(code, message))
+}
+
+// writeValidationError は 400 + VALIDATION_ERROR を issues 付きで書き込む。
+func writeValidationError(w http.ResponseWriter, issues ...apierror.ValidationIssue) {
+	apierror.Write(w, http.StatusBadRequest, apierror.New(apierror.CodeValidation, "Invalid request", issues...))
+}
+
+// writeInvalidJSON はリクエストボディを JSON としてデコードできない場合の 400 を書き込む。
+func writeInvalidJSON(w http.ResponseWriter) {
+	writeValidationError(w, apierror.ValidationIssue{
+		Location: apierror.LocationBody,
+		Field:    "body",
+		Code:     "INVALID_FORMAT",
+		Message:  "リクエストボディは JSON で指定してください。",
+	})
+}
+
+// writeInvalidPath はパスのプロジェクト ID などが不正な場合の 400 を書き込む。
+func writeInvalidPath(w http.ResponseWriter, field string) {
+	writeValidationError(w, apierror.ValidationIssue{
+		Location: apierror.LocationPath,
+		Field:    field,
+		Code:     "INVALID_FORMAT",
+		Message:  field + " をパスで指定してください。",
+	})
+}
+
+// writeNotFound は 404 + NOT_FOUND を書き込む。
+func writeNotFound(w http.ResponseWriter, message string) {
+	writeError(w, http.StatusNotFound, apierror.CodeNotFound, message)
+}
+
+// writeMethodNotAllowed は 405 + METHOD_NOT_ALLOWED を書き込む。
+func writeMethodNotAllowed(w http.ResponseWriter) {
+	writeError(w, http.StatusMethodNotAllowed, apierror.CodeMethodNotAllowed, "method not allowed")
+}
+
+// writeInternalError は 500 + INTERNAL_ERROR を書き込む。内部エラーの詳細はレスポンスに含めない。
+func writeInternalError(w http.ResponseWriter) {
+	writeError(w, http.StatusInternalServerError, apierror.CodeInternal, "internal server error")
+}
+
+// writeUsecaseError はユースケース・リポジトリのエラーをステータスコードに変換して書き込む。
+//
+//	401 / 403  操作者が不明 / 権限不足
+//	400        ドメインのバリデーションエラー（ValidationIssue 付き）
+//	404        プロジェクト・メンバー・設定・テンプレート・マイルストーン・スプリント・招待・Slack 連携の設定が存在しない、
+//	           招待のトークンが不正・期限切れ・取り消し済み
+//	409        キー・名前・メンバー・テンプレート ID・マイルストーン ID・スプリント ID の重複（名前の重複は既存プロジェクトの ID 付き）、
+//	           タスクのあるプロジェクトの削除（cascade=block）、復元期間を過ぎたプロジェクトの復元、
+//	           スプリントの状態に合わない操作、開始中のスプリントがあるプロジェクトでの開始
+//	502        tasks サービスの呼び出し（タスクのインポートを含む）・Slack への投稿に失敗した
+//	500        その他（タイムアウトを含む）
+func writeUsecaseError(w http.ResponseWriter, err error) {
+	if writeAuthzError(w, err) || writeNameConflict(w, err) {
+		return
+	}
+	if issue, ok := toValidationIssue(apierror.LocationBody, err); ok {
+		writeValidationError(w, issue)
+		return
+	}
+	switch {
+	case errors.Is(err, usecase.ErrProjectNotFound),
+		errors.Is(err, usecase.ErrMemberNotFound),
+		errors.Is(err, usecase.ErrSettingsNotFound),
+		errors.Is(err, usecase.ErrTemplateNotFound),
+		errors.Is(err, usecase.ErrMilestoneNotFound),
+		errors.Is(err, usecase.ErrSprintNotFound),
+		errors.Is(err, usecase.ErrEpicNotFound),
+		errors.Is(err, usecase.ErrLabelNotFound),
+		errors.Is(err, usecase.ErrInvitationNotFound),
+		errors.Is(err, usecase.ErrSlackIntegrationNotFound),
+		errors.Is(err, domain.ErrInvalidInvitationToken),
+		errors.Is(err, domain.ErrInvitationExpired),
+		errors.Is(err, domain.ErrInvitationRevoked):
+		writeNotFound(w, err.Error())
+	case errors.Is(err, usecase.ErrProjectKeyAlreadyExists),
+		errors.Is(err, usecase.ErrMemberAlreadyExists),
+		errors.Is(err, usecase.ErrTemplateAlreadyExists),
+		errors.Is(err, usecase.ErrMilestoneAlreadyExists),
+		errors.Is(err, usecase.ErrSprintAlreadyExists),
+		errors.Is(err, usecase.ErrSprintAlreadyActive),
+		errors.Is(err, usecase.ErrEpicAlreadyExists),
+		errors.Is(err, usecase.ErrLabelAlreadyExists),
+		errors.Is(err, usecase.ErrLabelNameAlreadyExists),
+		errors.Is(err, domain.ErrSprintStateConflict),
+		errors.Is(err, usecase.ErrProjectHasTasks),
+		errors.Is(err, usecase.ErrRestoreWindowExpired):
+		writeError(w, http.StatusConflict, apierror.CodeConflict, err.Error())
+	case errors.Is(err, usecase.ErrTasksService):
+		writeError(w, http.StatusBadGateway, apierror.CodeBadGateway, usecase.ErrTasksService.Error())
+	case errors.Is(err, usecase.ErrSlackDelivery):
+		// Slack の返したエラーの理由（no_service など）を含める。Webhook の URL は含まない
+		writeError(w, http.StatusBadGateway, apierror.CodeBadGateway, err.Error())
+	default:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			slog.Error("unhandled error", "error", err, "request_id", w.Header().Get(requestid.Header))
+		}
+		writeInternalError(w)
+	}
+}
+
+// toValidationIssue はドメインのバリデーションエラーを ValidationIssue に変換する。
+// location は status のように body / query のどちらでも使われるフィールドに使う。
+// バリデーションエラーでない場合は false を返す。
+func toValidationIssue(location string, err error) (apierror.ValidationIssue, bool) {
+	issue := func(loc, field, code, message string) (apierror.ValidationIssue, bool) {
+		return apierror.ValidationIssue{Location: loc, Field: field, Code: code, Message: message}, true
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrNameRequired):
+		return issue(location, "name", "REQUIRED", "name は必須です。")
+	case errors.Is(err, domain.ErrInvalidStatus):
+		return issue(location, "status", "INVALID_ENUM", "status は 'active','on_hold','completed' のいずれかを指定してください。")
+	case errors.Is(err, domain.ErrInvalidKey):
+		return issue(location, "key", "INVALID_FORMAT", "key は英大文字で始まる 2〜10 文字の英大文字・数字で指定してください（例: TFLOW）。")
+	case errors.Is(err, domain.ErrInvalidID):
+		return issue(location, "id", "INVALID_FORMAT", err.Error())
+	case errors.Is(err, domain.ErrInvalidVisibility):
+		return issue(location, "visibility", "INVALID_ENUM", "visibility は 'private','public' のいずれかを指定してください。")
+	case errors.Is(err, domain.ErrInvalidUserID):
+		return issue(location, "userId", "REQUIRED", "userId は必須です。")
+	case errors.Is(err, domain.ErrInvalidMemberRole):
+		return issue(location, "role", "INVALID_ENUM", "role は 'owner','admin','member' のいずれかを指定してください。")
+	case errors.Is(err, domain.ErrInvalidSettings):
+		return issue(location, "settings", "INVALID_VALUE", err.Error())
+	case errors.Is(err, domain.ErrInvalidTemplate):
+		return issue(location, "template", "INVALID_VALUE", err.Error())
+	case errors.Is(err, domain.ErrInvalidMilestone):
+		return issue(location, "milestone", "INVALID_VALUE", err.Error())
+	case errors.Is(err, domain.ErrInvalidSprint):
+		return issue(location, "sprint", "INVALID_VALUE", err.Error())
+	case errors.Is(err, domain.ErrInvalidEpic):
+		return issue(location, "epic", "INVALID_VALUE", err.Error())
+	case errors.Is(err, domain.ErrInvalidLabel):
+		return issue(location, "label", "INVALID_VALUE", err.Error())
+	case errors.Is(err, domain.ErrInvalidSlackIntegration):
+		return issue(location, "slack", "INVALID_VALUE", err.Error())
+	case errors.Is(err, domain.ErrInvalidImport):
+		return issue(location, "import", "INVALID_VALUE", err.Error())
+	case errors.Is(err, domain.ErrInvalidInvitation):
+		return issue(location, "invitation", "INVALID_VALUE", err.Error())
+	case errors.Is(err, domain.ErrInvalidProjectOrder):
+		return issue(location, "projectIds", "INVALID_VALUE", err.Error())
+	case errors.Is(err, domain.ErrInvalidRetentionDays):
+		return issue(location, "olderThanDays", "INVALID_RANGE", "olderThanDays は 1 以上の整数で指定してください。")
+
+	// 一覧のクエリパラメータ
+	case errors.Is(err, domain.ErrLimitOutOfRange):
+		return issue(apierror.LocationQuery, "limit", "INVALID_RANGE", "limit は 1〜200 の整数で指定してください。")
+	case errors.Is(err, domain.ErrInvalidSort):
+		return issue(apierror.LocationQuery, "sort", "INVALID_ENUM", "sort は 'name','-name','createdAt','-createdAt' のいずれかを指定してください。")
+	case errors.Is(err, domain.ErrInvalidExpand):
+		return issue(apierror.LocationQuery, "expand", "INVALID_ENUM", "expand は 'taskCounts' を指定してください。")
+	case errors.Is(err, domain.ErrInvalidArchived):
+		return issue(apierror.LocationQuery, "archived", "INVALID_ENUM", "archived は true または false で指定してください。")
+	case errors.Is(err, domain.ErrInvalidDeletePolicy):
+		return issue(apierror.LocationQuery, "cascade", "INVALID_ENUM", "cascade は 'block','archive_tasks','delete_tasks' のいずれかを指定してください。")
+	case errors.Is(err, domain.ErrSortIncompatibleWithCursor):
+		return issue(apierror.LocationQuery, "sort", "INCOMPATIBLE_WITH_CURSOR", "cursor を使用する場合、sort は指定できません。")
+	case errors.Is(err, domain.ErrCursorInvalidFormat):
+		return issue(apierror.LocationQuery, "cursor", "INVALID_FORMAT", "cursor の形式が不正です。")
+	case errors.Is(err, domain.ErrCursorInvalidSignature):
+		return issue(apierror.LocationQuery, "cursor", "INVALID_SIGNATURE", "cursor の署名が不正です。")
+	case errors.Is(err, domain.ErrCursorExpired):
+		return issue(apierror.LocationQuery, "cursor", "EXPIRED", "cursor の有効期限が切れています。")
+	case errors.Is(err, domain.ErrCursorQueryMismatch):
+		return issue(apierror.LocationQuery, "cursor", "QUERY_MISMATCH", "cursor のクエリ条件が一致しません。フィルタ等が変更された可能性があります。")
+	}
+	return apierror.ValidationIssue{}, false
+}
@@ -0,0 +1,140 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/uuidpolicy"
+
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// TestErrorResponses はエラー時に tasks サービスと同じ ErrorResponse 形式のボディが返ることを確認する。
+func TestErrorResponses(t *testing.T) {
+	projects, members := newRoleRepos(t)
+	repo := infra.NewMemoryProjectRepository()
+	projectHandler := httpiface.NewProjectHandler(
+		&usecase.CreateProjectUsecase{Repo: repo},
+		&usecase.ListProjectsUsecase{Repo: repo},
+		fixedClock, testCursorSecret,
+	)
+	archiveHandler := httpiface.NewArchiveProjectHandler(
+		&usecase.ArchiveProjectUsecase{Repo: projects, Members: members, EnforceRoles: true}, fixedClock,
+	)
+	getHandler := httpiface.NewGetProjectHandler(&usecase.GetProjectUsecase{Repo: projects})
+	strictIDHandler := httpiface.NewProjectHandler(
+		&usecase.CreateProjectUsecase{Repo: repo, UUIDPolicy: uuidpolicy.V4V7},
+		&usecase.ListProjectsUsecase{Repo: repo},
+		fixedClock, testCursorSecret,
+	)
+
+	tests := []struct {
+		name       string
+		handler    http.Handler
+		method     string
+		path       string
+		actor      string
+		body       string
+		wantStatus int
+		wantCode   string
+		wantIssue  *apierror.ValidationIssue
+	}{
+		{
+			name: "invalid json", handler: projectHandler, method: http.MethodPost, path: "/projects", body: `{invalid`,
+			wantStatus: http.StatusBadRequest, wantCode: apierror.CodeValidation,
+			wantIssue: &apierror.ValidationIssue{Location: apierror.LocationBody, Field: "body", Code: "INVALID_FORMAT"},
+		},
+		{
+			name: "empty name", handler: projectHandler, method: http.MethodPost, path: "/projects", body: `{"id":"proj-1","name":""}`,
+			wantStatus: http.StatusBadRequest, wantCode: apierror.CodeValidation,
+			wantIssue: &apierror.ValidationIssue{Location: apierror.LocationBody, Field: "name", Code: "REQUIRED"},
+		},
+		{
+			name: "invalid key", handler: projectHandler, method: http.MethodPost, path: "/projects", body: `{"id":"proj-1","key":"t-1","name":"TeamFlow"}`,
+			wantStatus: http.StatusBadRequest, wantCode: apierror.CodeValidation,
+			wantIssue: &apierror.ValidationIssue{Location: apierror.LocationBody, Field: "key", Code: "INVALID_FORMAT"},
+		},
+		{
+			name: "id not allowed by uuid policy", handler: strictIDHandler, method: http.MethodPost, path: "/projects",
+			body:       `{"id":"6ba7b810-9dad-11d1-80b4-00c04fd430c8","name":"TeamFlow"}`,
+			wantStatus: http.StatusBadRequest, wantCode: apierror.CodeValidation,
+			wantIssue: &apierror.ValidationIssue{Location: apierror.LocationBody, Field: "id", Code: "INVALID_FORMAT"},
+		},
+		{
+			name: "limit out of range", handler: projectHandler, method: http.MethodGet, path: "/projects?limit=201",
+			wantStatus: http.StatusBadRequest, wantCode: apierror.CodeValidation,
+			wantIssue: &apierror.ValidationIssue{Location: apierror.LocationQuery, Field: "limit", Code: "INVALID_RANGE"},
+		},
+		{
+			name: "invalid sort", handler: projectHandler, method: http.MethodGet, path: "/projects?sort=updatedAt",
+			wantStatus: http.StatusBadRequest, wantCode: apierror.CodeValidation,
+			wantIssue: &apierror.ValidationIssue{Location: apierror.LocationQuery, Field: "sort", Code: "INVALID_ENUM"},
+		},
+		{
+			name: "method not allowed", handler: projectHandler, method: http.MethodDelete, path: "/projects",
+			wantStatus: http.StatusMethodNotAllowed, wantCode: apierror.CodeMethodNotAllowed,
+		},
+		{
+			name: "invalid path", handler: getHandler, method: http.MethodGet, path: "/projects/proj-1/extra",
+			wantStatus: http.StatusBadRequest, wantCode: apierror.CodeValidation,
+			wantIssue: &apierror.ValidationIssue{Location: apierror.LocationPath, Field: "id", Code: "INVALID_FORMAT"},
+		},
+		{
+			name: "not found", handler: getHandler, method: http.MethodGet, path: "/projects/proj-x",
+			wantStatus: http.StatusNotFound, wantCode: apierror.CodeNotFound,
+		},
+		{
+			name: "no actor", handler: archiveHandler, method: http.MethodPost, path: "/projects/proj-1/archive",
+			wantStatus: http.StatusUnauthorized, wantCode: apierror.CodeUnauthorized,
+		},
+		{
+			name: "forbidden", handler: archiveHandler, method: http.MethodPost, path: "/projects/proj-1/archive", actor: "admin-1",
+			wantStatus: http.StatusForbidden, wantCode: apierror.CodeForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.actor != "" {
+				req.Header.Set(httpiface.ActorHeader, tt.actor)
+			}
+			w := httptest.NewRecorder()
+			tt.handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+				t.Errorf("expected JSON content type, got %q", ct)
+			}
+			var body apierror.ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if body.Error != tt.wantCode {
+				t.Errorf("expected error=%s, got=%s", tt.wantCode, body.Error)
+			}
+			if body.Message == "" {
+				t.Errorf("expected message to be set")
+			}
+			if tt.wantIssue == nil {
+				return
+			}
+			if body.Details == nil || len(body.Details.Issues) != 1 {
+				t.Fatalf("expected one issue, got %+v", body.Details)
+			}
+			got := body.Details.Issues[0]
+			if got.Location != tt.wantIssue.Location || got.Field != tt.wantIssue.Field || got.Code != tt.wantIssue.Code {
+				t.Errorf("expected issue %s/%s/%s, got %s/%s/%s",
+					tt.wantIssue.Location, tt.wantIssue.Field, tt.wantIssue.Code, got.Location, got.Field, got.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// GetProjectHandler は GET /projects/{id} を処理する HTTP ハンドラ。
+type GetProjectHandler struct {
+	getUC *usecase.GetProjectUsecase
+}
+
+// NewGetProjectHandler は GetProjectHandler を生成する。
+func NewGetProjectHandler(getUC *usecase.GetProjectUsecase) http.Handler {
+	return &GetProjectHandler{
+		getUC: getUC,
+	}
+}
+
+func (h *GetProjectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	// パスから /projects/{id} の {id} 部分を取り出す
+	path := strings.TrimPrefix(r.URL.Path, "/projects/")
+	if path == "" || strings.Contains(path, "/") {
+		writeInvalidPath(w, "id")
+		return
+	}
+	id := path
+
+	p, err := h.getUC.Execute(r.Context(), id, actorID(r))
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := projectResponse{
+		ID:          p.ID,
+		Key:         p.Key,
+		Name:        p.Name,
+		Description: p.Description,
+		Status:      string(p.Status),
+		Visibility:  string(p.Visibility),
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+		ArchivedAt:  p.ArchivedAt,
+		CreatedBy:   p.CreatedBy,
+		UpdatedBy:   p.UpdatedBy,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
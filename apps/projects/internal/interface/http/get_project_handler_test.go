@@ -0,0 +1,158 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+func TestGetProjectHandler_Success(t *testing.T) {
+	repo := infra.NewMemoryProjectRepository()
+	seed := seedProject(repo, "proj-1")
+
+	handler := httpiface.NewGetProjectHandler(&usecase.GetProjectUsecase{Repo: repo})
+
+	req := httptest.NewRequest(http.MethodGet, "/projects/"+seed.ID, nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", res.StatusCode)
+	}
+
+	var respBody struct {
+		ID          string    `json:"id"`
+		Name        string    `json:"name"`
+		Description string    `json:"description"`
+		CreatedAt   time.Time `json:"createdAt"`
+		UpdatedAt   time.Time `json:"updatedAt"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&respBody); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if respBody.ID != seed.ID {
+		t.Errorf("expected id=%s, got=%s", seed.ID, respBody.ID)
+	}
+	if respBody.Name != seed.Name {
+		t.Errorf("expected name=%s, got=%s", seed.Name, respBody.Name)
+	}
+	if !respBody.CreatedAt.Equal(seed.CreatedAt) {
+		t.Errorf("expected createdAt=%v, got=%v", seed.CreatedAt, respBody.CreatedAt)
+	}
+}
+
+func TestGetProjectHandler_NotFound(t *testing.T) {
+	repo := infra.NewMemoryProjectRepository() // 何も入れていない
+
+	handler := httpiface.NewGetProjectHandler(&usecase.GetProjectUsecase{Repo: repo})
+
+	req := httptest.NewRequest(http.MethodGet, "/projects/unknown", nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", w.Code)
+	}
+}
+
+func TestGetProjectHandler_InvalidPath(t *testing.T) {
+	repo := infra.NewMemoryProjectRepository()
+
+	handler := httpiface.NewGetProjectHandler(&usecase.GetProjectUsecase{Repo: repo})
+
+	req := httptest.NewRequest(http.MethodGet, "/projects/proj-1/extra", nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestGetProjectHandler_InternalError(t *testing.T) {
+	handler := httpiface.NewGetProjectHandler(&usecase.GetProjectUsecase{Repo: &errorRepo{}})
+
+	req := httptest.NewRequest(http.MethodGet, "/projects/proj-1", nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status 500, got %d", w.Code)
+	}
+}
+
+func TestGetProjectHandler_Visibility(t *testing.T) {
+	ctx := context.Background()
+	repo := infra.NewMemoryProjectRepository()
+	members := infra.NewMemoryMemberRepository()
+	seedProject(repo, "private-1")
+	public := seedProject(repo, "public-1")
+	public.Visibility = domain.VisibilityPublic
+	if err := repo.Update(ctx, public); err != nil {
+		t.Fatalf("failed to update project: %v", err)
+	}
+	if err := members.AddMember(ctx, &domain.Member{ProjectID: "private-1", UserID: "member-1", Role: domain.RoleMember}); err != nil {
+		t.Fatalf("failed to add member: %v", err)
+	}
+
+	handler := httpiface.NewGetProjectHandler(&usecase.GetProjectUsecase{Repo: repo, Members: members, EnforceRoles: true})
+
+	tests := []struct {
+		name       string
+		projectID  string
+		actor      string
+		wantStatus int
+	}{
+		{name: "member reads private", projectID: "private-1", actor: "member-1", wantStatus: http.StatusOK},
+		{name: "non-member reads private", projectID: "private-1", actor: "stranger", wantStatus: http.StatusForbidden},
+		{name: "anonymous reads private", projectID: "private-1", wantStatus: http.StatusUnauthorized},
+		{name: "anonymous reads public", projectID: "public-1", wantStatus: http.StatusOK},
+		{name: "non-member reads public", projectID: "public-1", actor: "stranger", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/projects/"+tt.projectID, nil)
+			if tt.actor != "" {
+				req.Header.Set(httpiface.ActorHeader, tt.actor)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			var body struct {
+				Visibility string `json:"visibility"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			want := "private"
+			if tt.projectID == "public-1" {
+				want = "public"
+			}
+			if body.Visibility != want {
+				t.Errorf("expected visibility=%s, got=%s", want, body.Visibility)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"teamflow-shared/apierror"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// maxImportBytes はインポートするエクスポート（リクエストボディ）の最大サイズ。
+const maxImportBytes = 10 << 20
+
+// ImportHandler は POST /projects/{id}/import?source=trello|jira&dryRun= を処理する HTTP ハンドラ。
+// リクエストボディはインポート元のエクスポート（JSON）をそのまま受け取る。
+type ImportHandler struct {
+	importUC *usecase.ImportTasksUsecase
+}
+
+// NewImportHandler は ImportHandler を生成する。
+func NewImportHandler(importUC *usecase.ImportTasksUsecase) http.Handler {
+	return &ImportHandler{importUC: importUC}
+}
+
+// importTaskResponse はインポートする（した）タスク。priority が null の場合はプロジェクト設定の既定値。
+type importTaskResponse struct {
+	Title    string  `json:"title"`
+	Status   string  `json:"status"`
+	Priority *string `json:"priority"`
+}
+
+// importMappingResponse はインポート元の値と TeamFlow の値の対応。
+type importMappingResponse struct {
+	From  string  `json:"from"`
+	To    *string `json:"to"`
+	Count int     `json:"count"`
+}
+
+type importMappingsResponse struct {
+	Statuses   []importMappingResponse `json:"statuses"`
+	Priorities []importMappingResponse `json:"priorities"`
+}
+
+type importSkipResponse struct {
+	SourceID string `json:"sourceId"`
+	Title    string `json:"title,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+// importResponse はインポートの結果（dryRun の場合は作成せずにレポートのみ）。
+type importResponse struct {
+	Source   string                 `json:"source"`
+	DryRun   bool                   `json:"dryRun"`
+	Imported int                    `json:"imported"`
+	Tasks    []importTaskResponse   `json:"tasks"`
+	Mappings importMappingsResponse `json:"mappings"`
+	Skipped  []importSkipResponse   `json:"skipped"`
+	Warnings []string               `json:"warnings"`
+}
+
+func toImportResponse(plan *domain.ImportPlan, dryRun bool) importResponse {
+	optional := func(s string) *string {
+		if s == "" {
+			return nil
+		}
+		return &s
+	}
+	mappings := func(ms []domain.ImportValueMapping) []importMappingResponse {
+		resp := make([]importMappingResponse, len(ms))
+		for i, m := range ms {
+			resp[i] = importMappingResponse{From: m.From, To: optional(m.To), Count: m.Count}
+		}
+		return resp
+	}
+
+	resp := importResponse{
+		Source: string(plan.Source),
+		DryRun: dryRun,
+		Tasks:  make([]importTaskResponse, len(plan.Tasks)),
+		Mappings: importMappingsResponse{
+			Statuses:   mappings(plan.Statuses),
+			Priorities: mappings(plan.Priorities),
+		},
+		Skipped:  make([]importSkipResponse, len(plan.Skipped)),
+		Warnings: append([]string{}, plan.Warnings...),
+	}
+	if !dryRun {
+		resp.Imported = len(plan.Tasks)
+	}
+	for i, t := range plan.Tasks {
+		resp.Tasks[i] = importTaskResponse{Title: t.Title, Status: t.Status, Priority: optional(t.Priority)}
+	}
+	for i, s := range plan.Skipped {
+		resp.Skipped[i] = importSkipResponse{SourceID: s.SourceID, Title: s.Title, Reason: s.Reason}
+	}
+	return resp
+}
+
+// parseImportPath は /projects/{id}/import から id を取り出す。
+func parseImportPath(path string) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/projects/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "import" {
+		return "", false
+	}
+	return parts[0], true
+}
+
+// IsImportPath はパスが /projects/{id}/import かどうかを返す。
+func IsImportPath(path string) bool {
+	_, ok := parseImportPath(path)
+	return ok
+}
+
+// ServeHTTP は Trello / Jira のエクスポートをプロジェクトのタスクとしてインポートする。
+// dryRun=true の場合は 200 でレポートのみ、それ以外は作成して 201 を返す。
+//
+//	400  source・dryRun・エクスポートの内容が不正
+//	413  エクスポートが大きすぎる
+func (h *ImportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseImportPath(r.URL.Path)
+	if !ok {
+		writeNotFound(w, "not found")
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	q := r.URL.Query()
+	source := domain.ImportSource(q.Get("source"))
+	if source != domain.ImportSourceTrello && source != domain.ImportSourceJira {
+		writeValidationError(w, apierror.ValidationIssue{
+			Location: apierror.LocationQuery,
+			Field:    "source",
+			Code:     "INVALID_ENUM",
+			Message:  "source は 'trello','jira' のいずれかを指定してください。",
+		})
+		return
+	}
+	dryRun := false
+	if v := q.Get("dryRun"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			writeValidationError(w, apierror.ValidationIssue{
+				Location: apierror.LocationQuery,
+				Field:    "dryRun",
+				Code:     "INVALID_ENUM",
+				Message:  "dryRun は true または false で指定してください。",
+			})
+			return
+		}
+		dryRun = b
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImportBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, apierror.CodeValidation, "export is too large")
+			return
+		}
+		writeInvalidJSON(w)
+		return
+	}
+
+	plan, err := h.importUC.Execute(r.Context(), usecase.ImportTasksInput{
+		ProjectID: projectID,
+		Source:    source,
+		Data:      data,
+		DryRun:    dryRun,
+		ActorID:   actorID(r),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if dryRun {
+		status = http.StatusOK
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(toImportResponse(plan, dryRun))
+}
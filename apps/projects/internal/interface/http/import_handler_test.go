@@ -0,0 +1,156 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+const importTrelloBoard = `{
+	"lists": [{"id": "l1", "name": "To Do"}, {"id": "l2", "name": "Someday"}],
+	"cards": [
+		{"id": "c1", "name": "設計", "idList": "l1", "labels": [{"name": "High"}]},
+		{"id": "c2", "name": "調査", "idList": "l2"},
+		{"id": "c3", "name": "古いカード", "idList": "l1", "closed": true}
+	]
+}`
+
+type importBody struct {
+	Source   string `json:"source"`
+	DryRun   bool   `json:"dryRun"`
+	Imported int    `json:"imported"`
+	Tasks    []struct {
+		Title    string  `json:"title"`
+		Status   string  `json:"status"`
+		Priority *string `json:"priority"`
+	} `json:"tasks"`
+	Mappings struct {
+		Statuses []struct {
+			From  string  `json:"from"`
+			To    *string `json:"to"`
+			Count int     `json:"count"`
+		} `json:"statuses"`
+		Priorities []struct {
+			From string  `json:"from"`
+			To   *string `json:"to"`
+		} `json:"priorities"`
+	} `json:"mappings"`
+	Skipped []struct {
+		SourceID string `json:"sourceId"`
+		Reason   string `json:"reason"`
+	} `json:"skipped"`
+	Warnings []string `json:"warnings"`
+}
+
+func TestImportHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		actor        string
+		body         string
+		seedErr      error
+		wantStatus   int
+		wantImported int
+		wantSeeded   int
+	}{
+		{name: "import", method: http.MethodPost, path: "/projects/proj-1/import?source=trello", actor: "admin-1", body: importTrelloBoard, wantStatus: http.StatusCreated, wantImported: 2, wantSeeded: 2},
+		{name: "dry run", method: http.MethodPost, path: "/projects/proj-1/import?source=trello&dryRun=true", actor: "owner-1", body: importTrelloBoard, wantStatus: http.StatusOK},
+		{name: "no actor", method: http.MethodPost, path: "/projects/proj-1/import?source=trello", body: importTrelloBoard, wantStatus: http.StatusUnauthorized},
+		{name: "not a member", method: http.MethodPost, path: "/projects/proj-1/import?source=trello", actor: "stranger", body: importTrelloBoard, wantStatus: http.StatusForbidden},
+		{name: "project not found", method: http.MethodPost, path: "/projects/proj-x/import?source=trello", actor: "owner-1", body: importTrelloBoard, wantStatus: http.StatusNotFound},
+		{name: "unknown source", method: http.MethodPost, path: "/projects/proj-1/import?source=asana", actor: "owner-1", body: importTrelloBoard, wantStatus: http.StatusBadRequest},
+		{name: "invalid dryRun", method: http.MethodPost, path: "/projects/proj-1/import?source=trello&dryRun=maybe", actor: "owner-1", body: importTrelloBoard, wantStatus: http.StatusBadRequest},
+		{name: "not an export", method: http.MethodPost, path: "/projects/proj-1/import?source=jira", actor: "owner-1", body: importTrelloBoard, wantStatus: http.StatusBadRequest},
+		{name: "tasks service fails", method: http.MethodPost, path: "/projects/proj-1/import?source=trello", actor: "owner-1", body: importTrelloBoard, seedErr: errors.New("unavailable"), wantStatus: http.StatusBadGateway},
+		{name: "method not allowed", method: http.MethodGet, path: "/projects/proj-1/import?source=trello", actor: "owner-1", wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projects, members := newRoleRepos(t)
+			seeder := &stubSeeder{err: tt.seedErr}
+			handler := httpiface.NewImportHandler(&usecase.ImportTasksUsecase{
+				Projects:     projects,
+				Members:      members,
+				Tasks:        seeder,
+				EnforceRoles: true,
+			})
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewReader([]byte(tt.body)))
+			if tt.actor != "" {
+				req.Header.Set(httpiface.ActorHeader, tt.actor)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.seedErr == nil && len(seeder.tasks) != tt.wantSeeded {
+				t.Errorf("expected %d seeded tasks, got %d", tt.wantSeeded, len(seeder.tasks))
+			}
+			if w.Code != http.StatusOK && w.Code != http.StatusCreated {
+				return
+			}
+
+			var got importBody
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.Source != "trello" || got.DryRun != (w.Code == http.StatusOK) || got.Imported != tt.wantImported {
+				t.Errorf("unexpected summary: source=%s dryRun=%v imported=%d", got.Source, got.DryRun, got.Imported)
+			}
+			if len(got.Tasks) != 2 || got.Tasks[0].Priority == nil || *got.Tasks[0].Priority != "high" || got.Tasks[1].Priority != nil {
+				t.Errorf("unexpected tasks: %+v", got.Tasks)
+			}
+			if len(got.Mappings.Statuses) != 2 || got.Mappings.Statuses[1].From != "Someday" || *got.Mappings.Statuses[1].To != "todo" {
+				t.Errorf("unexpected status mappings: %+v", got.Mappings.Statuses)
+			}
+			if len(got.Mappings.Priorities) != 1 || got.Mappings.Priorities[0].From != "High" {
+				t.Errorf("unexpected priority mappings: %+v", got.Mappings.Priorities)
+			}
+			if len(got.Skipped) != 1 || got.Skipped[0].SourceID != "c3" {
+				t.Errorf("unexpected skipped: %+v", got.Skipped)
+			}
+			if len(got.Warnings) != 1 {
+				t.Errorf("expected a warning for the Someday list, got %v", got.Warnings)
+			}
+		})
+	}
+}
+
+func TestImportHandler_TooLarge(t *testing.T) {
+	projects, members := newRoleRepos(t)
+	handler := httpiface.NewImportHandler(&usecase.ImportTasksUsecase{Projects: projects, Members: members, Tasks: &stubSeeder{}})
+
+	body := `{"cards":[],"padding":"` + strings.Repeat("x", 10<<20) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/projects/proj-1/import?source=trello", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status 413, got %d", w.Code)
+	}
+}
+
+func TestIsImportPath(t *testing.T) {
+	for path, want := range map[string]bool{
+		"/projects/proj-1/import":   true,
+		"/projects/proj-1/clone":    false,
+		"/projects//import":         false,
+		"/projects/proj-1/import/x": false,
+	} {
+		if got := httpiface.IsImportPath(path); got != want {
+			t.Errorf("IsImportPath(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"teamflow-shared/clock"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// InvitationsHandler は /projects/{id}/invitations 以下と /invitations/{token} を処理する HTTP ハンドラ。
+type InvitationsHandler struct {
+	createUC *usecase.CreateInvitationUsecase
+	listUC   *usecase.ListInvitationsUsecase
+	revokeUC *usecase.RevokeInvitationUsecase
+	getUC    *usecase.GetInvitationUsecase
+	acceptUC *usecase.AcceptInvitationUsecase
+	clock    clock.Clock
+}
+
+// NewInvitationsHandler は InvitationsHandler を生成する。
+func NewInvitationsHandler(
+	createUC *usecase.CreateInvitationUsecase,
+	listUC *usecase.ListInvitationsUsecase,
+	revokeUC *usecase.RevokeInvitationUsecase,
+	getUC *usecase.GetInvitationUsecase,
+	acceptUC *usecase.AcceptInvitationUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &InvitationsHandler{
+		createUC: createUC,
+		listUC:   listUC,
+		revokeUC: revokeUC,
+		getUC:    getUC,
+		acceptUC: acceptUC,
+		clock:    clk,
+	}
+}
+
+type createInvitationRequest struct {
+	Role           string `json:"role"`           // 省略時は member
+	ExpiresInHours int    `json:"expiresInHours"` // 省略時は 168（7 日）
+}
+
+type invitationResponse struct {
+	ID        string    `json:"id"`
+	ProjectID string    `json:"projectId"`
+	Role      string    `json:"role"`
+	CreatedBy string    `json:"createdBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	// Token は発行時のみ返す（保存していないため、後から取得できない）
+	Token string `json:"token,omitempty"`
+}
+
+type listInvitationsResponse struct {
+	Invitations []invitationResponse `json:"invitations"`
+}
+
+func toInvitationResponse(inv *domain.Invitation) invitationResponse {
+	return invitationResponse{
+		ID:        inv.ID,
+		ProjectID: inv.ProjectID,
+		Role:      string(inv.Role),
+		CreatedBy: inv.CreatedBy,
+		CreatedAt: inv.CreatedAt,
+		ExpiresAt: inv.ExpiresAt,
+	}
+}
+
+// ServeHTTP は以下を処理する。
+// - GET    /projects/{id}/invitations                : 使える招待の一覧
+// - POST   /projects/{id}/invitations                : 招待リンクの発行
+// - DELETE /projects/{id}/invitations/{invitationId} : 招待の取り消し
+// - GET    /invitations/{token}                      : 招待の確認（参加前）
+// - POST   /invitations/{token}                      : 招待を使って参加する
+func (h *InvitationsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if token, ok := parseInvitationTokenPath(r.URL.Path); ok {
+		switch r.Method {
+		case http.MethodGet:
+			h.handleGet(w, r, token)
+		case http.MethodPost:
+			h.handleAccept(w, r, token)
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	projectID, invitationID, ok := parseInvitationsPath(r.URL.Path)
+	if !ok {
+		writeNotFound(w, "not found")
+		return
+	}
+
+	if invitationID == "" {
+		switch r.Method {
+		case http.MethodGet:
+			h.handleList(w, r, projectID)
+		case http.MethodPost:
+			h.handleCreate(w, r, projectID)
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodDelete:
+		h.handleRevoke(w, r, projectID, invitationID)
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// parseInvitationsPath は /projects/{id}/invitations[/{invitationId}] から projectID と invitationID を取り出す。
+func parseInvitationsPath(path string) (projectID, invitationID string, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/projects/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] != "invitations" {
+		return "", "", false
+	}
+	if len(parts) == 3 {
+		if parts[2] == "" {
+			return "", "", false
+		}
+		invitationID = parts[2]
+	}
+	return parts[0], invitationID, true
+}
+
+// parseInvitationTokenPath は /invitations/{token} からトークンを取り出す。
+func parseInvitationTokenPath(path string) (token string, ok bool) {
+	token, found := strings.CutPrefix(path, "/invitations/")
+	if !found || token == "" || strings.Contains(token, "/") {
+		return "", false
+	}
+	return token, true
+}
+
+// IsInvitationsPath はパスが /projects/{id}/invitations 以下かどうかを返す。
+func IsInvitationsPath(path string) bool {
+	_, _, ok := parseInvitationsPath(path)
+	return ok
+}
+
+func (h *InvitationsHandler) handleList(w http.ResponseWriter, r *http.Request, projectID string) {
+	invitations, err := h.listUC.Execute(r.Context(), projectID, actorID(r), h.clock.Now())
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := listInvitationsResponse{Invitations: make([]invitationResponse, 0, len(invitations))}
+	for _, inv := range invitations {
+		resp.Invitations = append(resp.Invitations, toInvitationResponse(inv))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *InvitationsHandler) handleCreate(w http.ResponseWriter, r *http.Request, projectID string) {
+	var req createInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+	ttl := time.Duration(req.ExpiresInHours) * time.Hour
+	// 大きすぎる値は Duration があふれないよう、上限を超える有効期間として渡してエラーにする
+	if req.ExpiresInHours > int(domain.MaxInvitationTTL/time.Hour) {
+		ttl = domain.MaxInvitationTTL + time.Hour
+	}
+
+	inv, token, err := h.createUC.Execute(r.Context(), usecase.CreateInvitationInput{
+		ProjectID: projectID,
+		Role:      req.Role,
+		TTL:       ttl,
+		ActorID:   actorID(r),
+		Now:       h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := toInvitationResponse(inv)
+	resp.Token = token
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *InvitationsHandler) handleRevoke(w http.ResponseWriter, r *http.Request, projectID, invitationID string) {
+	err := h.revokeUC.Execute(r.Context(), usecase.RevokeInvitationInput{
+		ProjectID: projectID,
+		ID:        invitationID,
+		ActorID:   actorID(r),
+		Now:       h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *InvitationsHandler) handleGet(w http.ResponseWriter, r *http.Request, token string) {
+	inv, err := h.getUC.Execute(r.Context(), token, h.clock.Now())
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toInvitationResponse(inv))
+}
+
+func (h *InvitationsHandler) handleAccept(w http.ResponseWriter, r *http.Request, token string) {
+	m, err := h.acceptUC.Execute(r.Context(), token, actorID(r), h.clock.Now())
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(toMemberResponse(m))
+}
@@ -0,0 +1,156 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"teamflow-shared/clock"
+
+	domain "teamflow-projects/internal/domain/project"
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+func newInvitationsHandler(t *testing.T, clk clock.Clock) (http.Handler, *infra.MemoryMemberRepository) {
+	t.Helper()
+	projects := infra.NewMemoryProjectRepository()
+	seedProject(projects, "proj-1")
+	members := infra.NewMemoryMemberRepository()
+	owner, _ := domain.NewMember("proj-1", "owner", "owner", fixedNow())
+	_ = members.AddMember(context.Background(), owner)
+	invitations := infra.NewMemoryInvitationRepository()
+
+	return httpiface.NewInvitationsHandler(
+		&usecase.CreateInvitationUsecase{Projects: projects, Members: members, Invitations: invitations, Secret: testCursorSecret, EnforceRoles: true},
+		&usecase.ListInvitationsUsecase{Projects: projects, Members: members, Invitations: invitations, EnforceRoles: true},
+		&usecase.RevokeInvitationUsecase{Members: members, Invitations: invitations, EnforceRoles: true},
+		&usecase.GetInvitationUsecase{Invitations: invitations, Secret: testCursorSecret},
+		&usecase.AcceptInvitationUsecase{Projects: projects, Members: members, Invitations: invitations, Secret: testCursorSecret},
+		clk,
+	), members
+}
+
+func doInvitationsRequest(handler http.Handler, method, path, actor string, body any) *httptest.ResponseRecorder {
+	var b []byte
+	if body != nil {
+		b, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(b))
+	if actor != "" {
+		req.Header.Set(httpiface.ActorHeader, actor)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+type invitationBody struct {
+	ID        string    `json:"id"`
+	ProjectID string    `json:"projectId"`
+	Role      string    `json:"role"`
+	CreatedBy string    `json:"createdBy"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Token     string    `json:"token"`
+}
+
+func TestInvitationsHandler_Lifecycle(t *testing.T) {
+	now := fixedNow()
+	handler, members := newInvitationsHandler(t, clock.Fixed(now))
+
+	w := doInvitationsRequest(handler, http.MethodPost, "/projects/proj-1/invitations", "owner", map[string]any{"role": "admin", "expiresInHours": 24})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created invitationBody
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.Role != "admin" || created.CreatedBy != "owner" || created.Token == "" || !created.ExpiresAt.Equal(now.Add(24*time.Hour)) {
+		t.Errorf("unexpected invitation: %+v", created)
+	}
+
+	// 一覧ではトークンを返さない
+	w = doInvitationsRequest(handler, http.MethodGet, "/projects/proj-1/invitations", "owner", nil)
+	var list struct {
+		Invitations []invitationBody `json:"invitations"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(list.Invitations) != 1 || list.Invitations[0].ID != created.ID || list.Invitations[0].Token != "" {
+		t.Errorf("unexpected invitations: %+v", list.Invitations)
+	}
+
+	if w := doInvitationsRequest(handler, http.MethodGet, "/invitations/"+created.Token, "", nil); w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+	if w := doInvitationsRequest(handler, http.MethodPost, "/invitations/"+created.Token, "", nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 without actor, got %d", w.Code)
+	}
+	if w := doInvitationsRequest(handler, http.MethodPost, "/invitations/"+created.Token, "user-1", nil); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if m, err := members.FindMember(context.Background(), "proj-1", "user-1"); err != nil || m.Role != domain.RoleAdmin {
+		t.Errorf("expected user-1 to join as admin, got %+v (err=%v)", m, err)
+	}
+	if w := doInvitationsRequest(handler, http.MethodPost, "/invitations/"+created.Token, "user-1", nil); w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for existing member, got %d", w.Code)
+	}
+
+	if w := doInvitationsRequest(handler, http.MethodDelete, "/projects/proj-1/invitations/"+created.ID, "owner", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := doInvitationsRequest(handler, http.MethodPost, "/invitations/"+created.Token, "user-2", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for revoked invitation, got %d", w.Code)
+	}
+	if w := doInvitationsRequest(handler, http.MethodDelete, "/projects/proj-1/invitations/"+created.ID, "owner", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for revoked invitation, got %d", w.Code)
+	}
+}
+
+func TestInvitationsHandler_Errors(t *testing.T) {
+	clk := clock.NewFake(fixedNow())
+	handler, _ := newInvitationsHandler(t, clk)
+
+	w := doInvitationsRequest(handler, http.MethodPost, "/projects/proj-1/invitations", "owner", map[string]any{"expiresInHours": 1})
+	var created invitationBody
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		actor      string
+		body       any
+		wantStatus int
+	}{
+		{name: "owner role", method: http.MethodPost, path: "/projects/proj-1/invitations", actor: "owner", body: map[string]any{"role": "owner"}, wantStatus: http.StatusBadRequest},
+		{name: "too long", method: http.MethodPost, path: "/projects/proj-1/invitations", actor: "owner", body: map[string]any{"expiresInHours": 24*30 + 1}, wantStatus: http.StatusBadRequest},
+		{name: "overflow", method: http.MethodPost, path: "/projects/proj-1/invitations", actor: "owner", body: map[string]any{"expiresInHours": 1 << 40}, wantStatus: http.StatusBadRequest},
+		{name: "not a member", method: http.MethodPost, path: "/projects/proj-1/invitations", actor: "user-1", body: map[string]any{}, wantStatus: http.StatusForbidden},
+		{name: "project not found", method: http.MethodGet, path: "/projects/missing/invitations", actor: "owner", wantStatus: http.StatusNotFound},
+		{name: "tampered token", method: http.MethodGet, path: "/invitations/" + created.Token + "x", wantStatus: http.StatusNotFound},
+		{name: "method not allowed", method: http.MethodPut, path: "/invitations/" + created.Token, wantStatus: http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := doInvitationsRequest(handler, tt.method, tt.path, tt.actor, tt.body); w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	// 期限が切れたリンクは使えない
+	clk.Advance(time.Hour)
+	if w := doInvitationsRequest(handler, http.MethodPost, "/invitations/"+created.Token, "user-1", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for expired invitation, got %d", w.Code)
+	}
+}
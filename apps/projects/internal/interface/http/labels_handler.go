@@ -0,0 +1,246 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"teamflow-shared/clock"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// LabelsHandler は /projects/{id}/labels 以下を処理する HTTP ハンドラ。
+type LabelsHandler struct {
+	createUC *usecase.CreateLabelUsecase
+	updateUC *usecase.UpdateLabelUsecase
+	deleteUC *usecase.DeleteLabelUsecase
+	listUC   *usecase.ListLabelsUsecase
+	getUC    *usecase.GetLabelUsecase
+	clock    clock.Clock
+}
+
+// NewLabelsHandler は LabelsHandler を生成する。
+func NewLabelsHandler(
+	createUC *usecase.CreateLabelUsecase,
+	updateUC *usecase.UpdateLabelUsecase,
+	deleteUC *usecase.DeleteLabelUsecase,
+	listUC *usecase.ListLabelsUsecase,
+	getUC *usecase.GetLabelUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &LabelsHandler{
+		createUC: createUC,
+		updateUC: updateUC,
+		deleteUC: deleteUC,
+		listUC:   listUC,
+		getUC:    getUC,
+		clock:    clk,
+	}
+}
+
+// createLabelRequest は POST のリクエスト。
+type createLabelRequest struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"` // #RRGGBB
+}
+
+// updateLabelRequest は PATCH のリクエスト。省略したフィールドは変更しない。
+type updateLabelRequest struct {
+	Name  *string `json:"name"`
+	Color *string `json:"color"`
+}
+
+type labelResponse struct {
+	ID        string    `json:"id"`
+	ProjectID string    `json:"projectId"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// labelWithUsageResponse は GET /projects/{id}/labels の 1 件分。
+type labelWithUsageResponse struct {
+	labelResponse
+	UsageCount int `json:"usageCount"` // ラベルが付いたタスク数
+}
+
+type listLabelsResponse struct {
+	Labels []labelWithUsageResponse `json:"labels"`
+}
+
+// toLabelUsageResponses はラベルと使用数をレスポンスに変換する（空の場合も [] にする）。
+func toLabelUsageResponses(labels []domain.LabelUsage) []labelWithUsageResponse {
+	out := make([]labelWithUsageResponse, 0, len(labels))
+	for _, l := range labels {
+		out = append(out, labelWithUsageResponse{
+			labelResponse: toLabelResponse(l.Label),
+			UsageCount:    l.UsageCount,
+		})
+	}
+	return out
+}
+
+func toLabelResponse(l *domain.Label) labelResponse {
+	return labelResponse{
+		ID:        l.ID,
+		ProjectID: l.ProjectID,
+		Name:      l.Name,
+		Color:     l.Color,
+		CreatedAt: l.CreatedAt,
+		UpdatedAt: l.UpdatedAt,
+	}
+}
+
+// parseLabelsPath は /projects/{id}/labels[/{labelId}] から projectID と labelID を取り出す。
+func parseLabelsPath(path string) (projectID, labelID string, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/projects/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] != "labels" {
+		return "", "", false
+	}
+	if len(parts) == 3 {
+		if parts[2] == "" {
+			return "", "", false
+		}
+		labelID = parts[2]
+	}
+	return parts[0], labelID, true
+}
+
+// IsLabelsPath はパスが /projects/{id}/labels 以下かどうかを返す。
+func IsLabelsPath(path string) bool {
+	_, _, ok := parseLabelsPath(path)
+	return ok
+}
+
+// ServeHTTP は以下を処理する。
+// - GET    /projects/{id}/labels           : ラベル一覧（ラベルごとの使用数を含む）
+// - POST   /projects/{id}/labels           : ラベル作成
+// - GET    /projects/{id}/labels/{labelId} : ラベル取得（tasks サービスの定義済みチェック用）
+// - PATCH  /projects/{id}/labels/{labelId} : ラベル更新（名前・色）
+// - DELETE /projects/{id}/labels/{labelId} : ラベル削除
+//
+// タスクへのラベルの付け外しは tasks サービスの PATCH /api/tasks/{id}（labelIds）で行う。
+func (h *LabelsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	projectID, labelID, ok := parseLabelsPath(r.URL.Path)
+	if !ok {
+		writeNotFound(w, "not found")
+		return
+	}
+
+	if labelID == "" {
+		switch r.Method {
+		case http.MethodGet:
+			h.handleList(w, r, projectID)
+		case http.MethodPost:
+			h.handleCreate(w, r, projectID)
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGet(w, r, projectID, labelID)
+	case http.MethodPatch:
+		h.handleUpdate(w, r, projectID, labelID)
+	case http.MethodDelete:
+		h.handleDelete(w, r, projectID, labelID)
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+func (h *LabelsHandler) handleList(w http.ResponseWriter, r *http.Request, projectID string) {
+	labels, err := h.listUC.Execute(r.Context(), projectID, actorID(r))
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := listLabelsResponse{Labels: toLabelUsageResponses(labels)}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *LabelsHandler) handleCreate(w http.ResponseWriter, r *http.Request, projectID string) {
+	var req createLabelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	l, err := h.createUC.Execute(r.Context(), usecase.CreateLabelInput{
+		ProjectID: projectID,
+		ID:        req.ID,
+		Name:      req.Name,
+		Color:     req.Color,
+		ActorID:   actorID(r),
+		Now:       h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(toLabelResponse(l))
+}
+
+func (h *LabelsHandler) handleGet(w http.ResponseWriter, r *http.Request, projectID, labelID string) {
+	l, err := h.getUC.Execute(r.Context(), projectID, labelID)
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toLabelResponse(l))
+}
+
+func (h *LabelsHandler) handleUpdate(w http.ResponseWriter, r *http.Request, projectID, labelID string) {
+	var req updateLabelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	l, err := h.updateUC.Execute(r.Context(), usecase.UpdateLabelInput{
+		ProjectID: projectID,
+		ID:        labelID,
+		Name:      req.Name,
+		Color:     req.Color,
+		ActorID:   actorID(r),
+		Now:       h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toLabelResponse(l))
+}
+
+func (h *LabelsHandler) handleDelete(w http.ResponseWriter, r *http.Request, projectID, labelID string) {
+	err := h.deleteUC.Execute(r.Context(), usecase.DeleteLabelInput{
+		ProjectID: projectID,
+		ID:        labelID,
+		ActorID:   actorID(r),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
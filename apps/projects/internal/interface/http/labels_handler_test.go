@@ -0,0 +1,142 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// labelStatsStub は LabelStatsProvider のスタブ。
+type labelStatsStub map[string]int
+
+func (s labelStatsStub) LabelStats(context.Context, string) (map[string]int, error) {
+	return s, nil
+}
+
+func newLabelsHandler(t *testing.T, stats usecase.LabelStatsProvider) http.Handler {
+	t.Helper()
+	projects := infra.NewMemoryProjectRepository()
+	seedProject(projects, "proj-1")
+	labels := infra.NewMemoryLabelRepository()
+
+	return httpiface.NewLabelsHandler(
+		&usecase.CreateLabelUsecase{Projects: projects, Labels: labels},
+		&usecase.UpdateLabelUsecase{Labels: labels},
+		&usecase.DeleteLabelUsecase{Labels: labels},
+		&usecase.ListLabelsUsecase{Projects: projects, Labels: labels, Stats: stats},
+		&usecase.GetLabelUsecase{Labels: labels},
+		fixedClock,
+	)
+}
+
+type labelBody struct {
+	ID         string `json:"id"`
+	ProjectID  string `json:"projectId"`
+	Name       string `json:"name"`
+	Color      string `json:"color"`
+	UsageCount int    `json:"usageCount"`
+}
+
+func TestLabelsHandler_Lifecycle(t *testing.T) {
+	handler := newLabelsHandler(t, labelStatsStub{"l1": 3})
+
+	w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/labels", map[string]any{"id": "l1", "name": "bug", "color": "#D73A4A"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created labelBody
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.ID != "l1" || created.ProjectID != "proj-1" || created.Name != "bug" || created.Color != "#d73a4a" {
+		t.Errorf("unexpected label: %+v", created)
+	}
+
+	if w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/labels", map[string]any{"id": "l2", "name": "Bug", "color": "#ffffff"}); w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for duplicate name, got %d", w.Code)
+	}
+	if w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/labels", map[string]any{"id": "l2", "name": "feature", "color": "#a2eeef"}); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", w.Code)
+	}
+
+	// 指定したフィールドのみ変更する
+	w = doMembersRequest(handler, http.MethodPatch, "/projects/proj-1/labels/l2", map[string]any{"name": "enhancement"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var updated labelBody
+	if err := json.NewDecoder(w.Body).Decode(&updated); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if updated.Name != "enhancement" || updated.Color != "#a2eeef" {
+		t.Errorf("unexpected label: %+v", updated)
+	}
+
+	w = doMembersRequest(handler, http.MethodGet, "/projects/proj-1/labels", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var list struct {
+		Labels []labelBody `json:"labels"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(list.Labels) != 2 {
+		t.Fatalf("unexpected labels: %+v", list.Labels)
+	}
+	if l := list.Labels[0]; l.ID != "l1" || l.UsageCount != 3 {
+		t.Errorf("unexpected l1: %+v", l)
+	}
+	if l := list.Labels[1]; l.ID != "l2" || l.UsageCount != 0 {
+		t.Errorf("unexpected l2: %+v", l)
+	}
+
+	if w := doMembersRequest(handler, http.MethodDelete, "/projects/proj-1/labels/l1", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", w.Code)
+	}
+	if w := doMembersRequest(handler, http.MethodGet, "/projects/proj-1/labels/l1", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 after delete, got %d", w.Code)
+	}
+}
+
+func TestLabelsHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       any
+		wantStatus int
+	}{
+		{name: "empty name", method: http.MethodPost, path: "/projects/proj-1/labels", body: map[string]any{"id": "l1", "color": "#ffffff"}, wantStatus: http.StatusBadRequest},
+		{name: "invalid color", method: http.MethodPost, path: "/projects/proj-1/labels", body: map[string]any{"id": "l1", "name": "bug", "color": "red"}, wantStatus: http.StatusBadRequest},
+		{name: "project not found", method: http.MethodPost, path: "/projects/missing/labels", body: map[string]any{"id": "l1", "name": "bug", "color": "#ffffff"}, wantStatus: http.StatusNotFound},
+		{name: "update not found", method: http.MethodPatch, path: "/projects/proj-1/labels/missing", body: map[string]any{"name": "x"}, wantStatus: http.StatusNotFound},
+		{name: "method not allowed", method: http.MethodPut, path: "/projects/proj-1/labels/l1", wantStatus: http.StatusMethodNotAllowed},
+		{name: "nested path", method: http.MethodGet, path: "/projects/proj-1/labels/l1/tasks", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newLabelsHandler(t, nil)
+			if w := doMembersRequest(handler, tt.method, tt.path, tt.body); w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestLabelsHandler_ListWithoutTasksService(t *testing.T) {
+	handler := newLabelsHandler(t, nil)
+	if w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/labels", map[string]any{"id": "l1", "name": "bug", "color": "#ffffff"}); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", w.Code)
+	}
+	if w := doMembersRequest(handler, http.MethodGet, "/projects/proj-1/labels", nil); w.Code != http.StatusBadGateway {
+		t.Errorf("expected status 502, got %d", w.Code)
+	}
+}
@@ -0,0 +1,242 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+type listProjectsBody struct {
+	Projects []struct {
+		ID         string `json:"id"`
+		Name       string `json:"name"`
+		TaskCounts *struct {
+			Open int `json:"open"`
+			Done int `json:"done"`
+		} `json:"taskCounts"`
+	} `json:"projects"`
+	Page struct {
+		NextCursor *string `json:"nextCursor"`
+		Limit      int     `json:"limit"`
+	} `json:"page"`
+}
+
+func newListHandler(t *testing.T, names ...string) http.Handler {
+	t.Helper()
+	repo := infra.NewMemoryProjectRepository()
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	for i, name := range names {
+		p, err := domain.NewProject("proj-"+string(rune('a'+i)), name, "", base.Add(time.Duration(i)*time.Minute))
+		if err != nil {
+			t.Fatalf("failed to create project: %v", err)
+		}
+		_ = repo.Save(context.Background(), p)
+	}
+
+	createUC := &usecase.CreateProjectUsecase{Repo: repo}
+	listUC := &usecase.ListProjectsUsecase{Repo: repo}
+	return httpiface.NewProjectHandler(createUC, listUC, fixedClock, testCursorSecret)
+}
+
+func getProjects(t *testing.T, handler http.Handler, params url.Values) (int, listProjectsBody) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/projects?"+params.Encode(), nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	var body listProjectsBody
+	if w.Code == http.StatusOK {
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+	}
+	return w.Code, body
+}
+
+func TestListProjectsHandler_Default(t *testing.T) {
+	handler := newListHandler(t, "Alpha", "Beta")
+
+	status, body := getProjects(t, handler, url.Values{})
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if len(body.Projects) != 2 {
+		t.Fatalf("expected 2 projects, got %d", len(body.Projects))
+	}
+	if body.Page.NextCursor != nil {
+		t.Errorf("expected no nextCursor, got %q", *body.Page.NextCursor)
+	}
+	if body.Page.Limit != domain.DefaultListLimit {
+		t.Errorf("expected limit=%d, got %d", domain.DefaultListLimit, body.Page.Limit)
+	}
+}
+
+func TestListProjectsHandler_CursorPagination(t *testing.T) {
+	handler := newListHandler(t, "Charlie", "alpha", "Bravo", "Delta", "Team Alpha")
+
+	params := url.Values{"q": {"a"}, "sort": {"-name"}, "limit": {"2"}}
+	var got []string
+	for page := 0; page < 5; page++ {
+		status, body := getProjects(t, handler, params)
+		if status != http.StatusOK {
+			t.Fatalf("page %d: expected status 200, got %d", page, status)
+		}
+		for _, p := range body.Projects {
+			got = append(got, p.Name)
+		}
+		if body.Page.NextCursor == nil {
+			break
+		}
+		// 2 ページ目以降は sort を付けない（ソート順は cursor に含まれる）
+		params = url.Values{"q": {"a"}, "limit": {"2"}, "cursor": {*body.Page.NextCursor}}
+	}
+
+	want := []string{"alpha", "Team Alpha", "Delta", "Charlie", "Bravo"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestListProjectsHandler_StatusFilter(t *testing.T) {
+	repo := infra.NewMemoryProjectRepository()
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	for i, status := range []domain.ProjectStatus{domain.StatusActive, domain.StatusOnHold, domain.StatusCompleted} {
+		p, err := domain.NewProject("proj-"+string(rune('a'+i)), string(status), "", base.Add(time.Duration(i)*time.Minute))
+		if err != nil {
+			t.Fatalf("failed to create project: %v", err)
+		}
+		p.Status = status
+		_ = repo.Save(context.Background(), p)
+	}
+	handler := httpiface.NewProjectHandler(&usecase.CreateProjectUsecase{Repo: repo}, &usecase.ListProjectsUsecase{Repo: repo}, fixedClock, testCursorSecret)
+
+	status, body := getProjects(t, handler, url.Values{"status": {"completed,on-hold"}})
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if len(body.Projects) != 2 || body.Projects[0].ID != "proj-b" || body.Projects[1].ID != "proj-c" {
+		t.Errorf("expected [proj-b proj-c], got %+v", body.Projects)
+	}
+}
+
+func TestListProjectsHandler_BadRequest(t *testing.T) {
+	handler := newListHandler(t, "Alpha", "Beta", "Gamma")
+
+	// nextCursor を取得しておく
+	_, first := getProjects(t, handler, url.Values{"limit": {"1"}})
+	if first.Page.NextCursor == nil {
+		t.Fatal("expected nextCursor on first page")
+	}
+	cursor := *first.Page.NextCursor
+
+	tests := []struct {
+		name   string
+		params url.Values
+	}{
+		{name: "invalid limit", params: url.Values{"limit": {"0"}}},
+		{name: "limit too large", params: url.Values{"limit": {"201"}}},
+		{name: "invalid sort", params: url.Values{"sort": {"updatedAt"}}},
+		{name: "invalid archived", params: url.Values{"archived": {"maybe"}}},
+		{name: "invalid expand", params: url.Values{"expand": {"taskCounts,members"}}},
+		{name: "invalid status", params: url.Values{"status": {"active,archived"}}},
+		{name: "invalid cursor", params: url.Values{"cursor": {"not-a-valid-cursor"}}},
+		{name: "sort with cursor", params: url.Values{"cursor": {cursor}, "sort": {"name"}}},
+		{name: "filter changed", params: url.Values{"cursor": {cursor}, "q": {"a"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, _ := getProjects(t, handler, tt.params)
+			if status != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", status)
+			}
+		})
+	}
+}
+
+// stubBatchStatsProvider は BatchStatsProvider のテスト用スタブ。呼び出し回数を記録する。
+type stubBatchStatsProvider struct {
+	err   error
+	calls int
+}
+
+func (s *stubBatchStatsProvider) ProjectsStats(_ context.Context, projectIDs []string) (map[string]*domain.Stats, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	// 先頭のプロジェクトのみタスクがある（他は結果に含めない）
+	return map[string]*domain.Stats{projectIDs[0]: {ProjectID: projectIDs[0], Open: 3, Done: 2}}, nil
+}
+
+func TestListProjectsHandler_ExpandTaskCounts(t *testing.T) {
+	repo := infra.NewMemoryProjectRepository()
+	seedProject(repo, "proj-1")
+	seedProject(repo, "proj-2")
+	tests := []struct {
+		name       string
+		params     url.Values
+		statsErr   error
+		wantStatus int
+		wantCalls  int
+	}{
+		{name: "without expand", params: url.Values{}, wantStatus: http.StatusOK},
+		{name: "task counts", params: url.Values{"expand": {"taskCounts"}}, wantStatus: http.StatusOK, wantCalls: 1},
+		{name: "tasks service fails", params: url.Values{"expand": {"taskCounts"}}, statsErr: errors.New("unavailable"), wantStatus: http.StatusBadGateway, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := &stubBatchStatsProvider{err: tt.statsErr}
+			handler := httpiface.NewProjectHandler(
+				&usecase.CreateProjectUsecase{Repo: repo},
+				&usecase.ListProjectsUsecase{Repo: repo, Stats: stats},
+				fixedClock, testCursorSecret,
+			)
+
+			status, body := getProjects(t, handler, tt.params)
+			if status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, status)
+			}
+			// プロジェクトの件数によらず tasks サービスは 1 回だけ呼び出す
+			if stats.calls != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, stats.calls)
+			}
+			if status != http.StatusOK {
+				return
+			}
+			if len(body.Projects) != 2 {
+				t.Fatalf("expected 2 projects, got %d", len(body.Projects))
+			}
+			if tt.wantCalls == 0 {
+				if body.Projects[0].TaskCounts != nil {
+					t.Errorf("expected no taskCounts, got %+v", body.Projects[0].TaskCounts)
+				}
+				return
+			}
+			first, second := body.Projects[0].TaskCounts, body.Projects[1].TaskCounts
+			if first == nil || first.Open != 3 || first.Done != 2 {
+				t.Errorf("unexpected taskCounts for %s: %+v", body.Projects[0].ID, first)
+			}
+			if second == nil || second.Open != 0 || second.Done != 0 {
+				t.Errorf("expected zero taskCounts for %s, got %+v", body.Projects[1].ID, second)
+			}
+		})
+	}
+}
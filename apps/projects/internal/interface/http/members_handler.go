@@ -0,0 +1,186 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"teamflow-shared/clock"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// MembersHandler は /projects/{id}/members 以下を処理する HTTP ハンドラ。
+type MembersHandler struct {
+	addUC    *usecase.AddMemberUsecase
+	removeUC *usecase.RemoveMemberUsecase
+	listUC   *usecase.ListMembersUsecase
+	getUC    *usecase.GetMemberUsecase
+	clock    clock.Clock
+}
+
+// NewMembersHandler は MembersHandler を生成する。
+func NewMembersHandler(
+	addUC *usecase.AddMemberUsecase,
+	removeUC *usecase.RemoveMemberUsecase,
+	listUC *usecase.ListMembersUsecase,
+	getUC *usecase.GetMemberUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &MembersHandler{
+		addUC:    addUC,
+		removeUC: removeUC,
+		listUC:   listUC,
+		getUC:    getUC,
+		clock:    clk,
+	}
+}
+
+type addMemberRequest struct {
+	UserID string `json:"userId"`
+	Role   string `json:"role"` // 省略時は member
+}
+
+type memberResponse struct {
+	ProjectID string    `json:"projectId"`
+	UserID    string    `json:"userId"`
+	Role      string    `json:"role"`
+	JoinedAt  time.Time `json:"joinedAt"`
+}
+
+type listMembersResponse struct {
+	Members []memberResponse `json:"members"`
+}
+
+func toMemberResponse(m *domain.Member) memberResponse {
+	return memberResponse{
+		ProjectID: m.ProjectID,
+		UserID:    m.UserID,
+		Role:      string(m.Role),
+		JoinedAt:  m.JoinedAt,
+	}
+}
+
+// ServeHTTP は以下を処理する。
+// - GET    /projects/{id}/members          : メンバー一覧
+// - POST   /projects/{id}/members          : メンバー追加
+// - GET    /projects/{id}/members/{userId} : メンバー取得（tasks サービスのメンバーチェック用）
+// - DELETE /projects/{id}/members/{userId} : メンバー削除
+func (h *MembersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	projectID, userID, ok := parseMembersPath(r.URL.Path)
+	if !ok {
+		writeNotFound(w, "not found")
+		return
+	}
+
+	if userID == "" {
+		switch r.Method {
+		case http.MethodGet:
+			h.handleList(w, r, projectID)
+		case http.MethodPost:
+			h.handleAdd(w, r, projectID)
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGet(w, r, projectID, userID)
+	case http.MethodDelete:
+		h.handleRemove(w, r, projectID, userID)
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// parseMembersPath は /projects/{id}/members[/{userId}] から projectID と userID を取り出す。
+func parseMembersPath(path string) (projectID, userID string, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/projects/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] != "members" {
+		return "", "", false
+	}
+	if len(parts) == 3 {
+		if parts[2] == "" {
+			return "", "", false
+		}
+		userID = parts[2]
+	}
+	return parts[0], userID, true
+}
+
+// IsMembersPath はパスが /projects/{id}/members 以下かどうかを返す。
+func IsMembersPath(path string) bool {
+	_, _, ok := parseMembersPath(path)
+	return ok
+}
+
+func (h *MembersHandler) handleList(w http.ResponseWriter, r *http.Request, projectID string) {
+	members, err := h.listUC.Execute(r.Context(), projectID, actorID(r))
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := listMembersResponse{Members: make([]memberResponse, 0, len(members))}
+	for _, m := range members {
+		resp.Members = append(resp.Members, toMemberResponse(m))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *MembersHandler) handleAdd(w http.ResponseWriter, r *http.Request, projectID string) {
+	var req addMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	m, err := h.addUC.Execute(r.Context(), usecase.AddMemberInput{
+		ProjectID: projectID,
+		UserID:    req.UserID,
+		Role:      req.Role,
+		ActorID:   actorID(r),
+		Now:       h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(toMemberResponse(m))
+}
+
+func (h *MembersHandler) handleGet(w http.ResponseWriter, r *http.Request, projectID, userID string) {
+	m, err := h.getUC.Execute(r.Context(), projectID, userID)
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toMemberResponse(m))
+}
+
+func (h *MembersHandler) handleRemove(w http.ResponseWriter, r *http.Request, projectID, userID string) {
+	err := h.removeUC.Execute(r.Context(), usecase.RemoveMemberInput{
+		ProjectID: projectID,
+		UserID:    userID,
+		ActorID:   actorID(r),
+		Now:       h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
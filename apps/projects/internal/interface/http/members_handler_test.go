@@ -0,0 +1,117 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+func newMembersHandler(t *testing.T) http.Handler {
+	t.Helper()
+	projects := infra.NewMemoryProjectRepository()
+	seedProject(projects, "proj-1")
+	members := infra.NewMemoryMemberRepository()
+
+	return httpiface.NewMembersHandler(
+		&usecase.AddMemberUsecase{Projects: projects, Members: members},
+		&usecase.RemoveMemberUsecase{Members: members},
+		&usecase.ListMembersUsecase{Projects: projects, Members: members},
+		&usecase.GetMemberUsecase{Members: members},
+		fixedClock,
+	)
+}
+
+func doMembersRequest(handler http.Handler, method, path string, body interface{}) *httptest.ResponseRecorder {
+	var b []byte
+	if body != nil {
+		b, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(b))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestMembersHandler_Lifecycle(t *testing.T) {
+	handler := newMembersHandler(t)
+
+	w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/members", map[string]string{"userId": "user-1", "role": "admin"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", w.Code)
+	}
+	var created struct {
+		ProjectID string `json:"projectId"`
+		UserID    string `json:"userId"`
+		Role      string `json:"role"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.ProjectID != "proj-1" || created.UserID != "user-1" || created.Role != "admin" {
+		t.Errorf("unexpected member: %+v", created)
+	}
+
+	if w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/members", map[string]string{"userId": "user-1"}); w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for duplicate member, got %d", w.Code)
+	}
+
+	w = doMembersRequest(handler, http.MethodGet, "/projects/proj-1/members", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var list struct {
+		Members []struct {
+			UserID string `json:"userId"`
+		} `json:"members"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(list.Members) != 1 || list.Members[0].UserID != "user-1" {
+		t.Errorf("expected [user-1], got %+v", list.Members)
+	}
+
+	if w := doMembersRequest(handler, http.MethodGet, "/projects/proj-1/members/user-1", nil); w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+	if w := doMembersRequest(handler, http.MethodDelete, "/projects/proj-1/members/user-1", nil); w.Code != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d", w.Code)
+	}
+	if w := doMembersRequest(handler, http.MethodGet, "/projects/proj-1/members/user-1", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 after removal, got %d", w.Code)
+	}
+}
+
+func TestMembersHandler_Errors(t *testing.T) {
+	handler := newMembersHandler(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   interface{}
+		want   int
+	}{
+		{name: "empty userId", method: http.MethodPost, path: "/projects/proj-1/members", body: map[string]string{"userId": ""}, want: http.StatusBadRequest},
+		{name: "invalid role", method: http.MethodPost, path: "/projects/proj-1/members", body: map[string]string{"userId": "user-1", "role": "guest"}, want: http.StatusBadRequest},
+		{name: "unknown project on add", method: http.MethodPost, path: "/projects/proj-x/members", body: map[string]string{"userId": "user-1"}, want: http.StatusNotFound},
+		{name: "unknown project on list", method: http.MethodGet, path: "/projects/proj-x/members", want: http.StatusNotFound},
+		{name: "remove non-member", method: http.MethodDelete, path: "/projects/proj-1/members/user-9", want: http.StatusNotFound},
+		{name: "method not allowed", method: http.MethodPut, path: "/projects/proj-1/members", want: http.StatusMethodNotAllowed},
+		{name: "unknown path", method: http.MethodGet, path: "/projects/proj-1/members/user-1/extra", want: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := doMembersRequest(handler, tt.method, tt.path, tt.body); w.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package http
+
+import "teamflow-shared/i18n"
+
+// Messages は ValidationIssue の message の翻訳（field.code ごと）。日本語は toValidationIssue などの文言をそのまま使う。
+// server.Options.Messages に渡し、Accept-Language で英語を求められた場合に使う。
+// INVALID_VALUE はドメインのエラー（英語）をそのまま message にしているため翻訳しない。
+var Messages = i18n.Merge(i18n.Common, i18n.Catalog{
+	"name.REQUIRED":           {i18n.English: "name is required."},
+	"status.INVALID_ENUM":     {i18n.English: "status must be one of 'active','on_hold','completed'."},
+	"key.INVALID_FORMAT":      {i18n.English: "key must be 2-10 uppercase letters or digits starting with a letter (e.g. TFLOW)."},
+	"visibility.INVALID_ENUM": {i18n.English: "visibility must be one of 'private','public'."},
+	"userId.REQUIRED":         {i18n.English: "userId is required."},
+	"role.INVALID_ENUM":       {i18n.English: "role must be one of 'owner','admin','member'."},
+	"limit.INVALID_RANGE":     {i18n.English: "limit must be an integer between 1 and 200."},
+	"sort.INVALID_ENUM":       {i18n.English: "sort must be one of 'name','-name','createdAt','-createdAt'."},
+	"expand.INVALID_ENUM":     {i18n.English: "expand must be 'taskCounts'."},
+	"archived.INVALID_ENUM":   {i18n.English: "archived must be true or false."},
+	"cascade.INVALID_ENUM":    {i18n.English: "cascade must be one of 'block','archive_tasks','delete_tasks'."},
+})
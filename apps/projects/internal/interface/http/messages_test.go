@@ -0,0 +1,42 @@
+package http
+
+import (
+	"testing"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/i18n"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// toValidationIssue が返す field・code には英語の文言があること（INVALID_VALUE はドメインのエラーをそのまま使う）。
+func TestMessages_CoverValidationIssues(t *testing.T) {
+	errs := []error{
+		domain.ErrNameRequired,
+		domain.ErrInvalidStatus,
+		domain.ErrInvalidKey,
+		domain.ErrInvalidID,
+		domain.ErrInvalidVisibility,
+		domain.ErrInvalidUserID,
+		domain.ErrInvalidMemberRole,
+		domain.ErrLimitOutOfRange,
+		domain.ErrInvalidSort,
+		domain.ErrInvalidExpand,
+		domain.ErrInvalidArchived,
+		domain.ErrInvalidDeletePolicy,
+		domain.ErrSortIncompatibleWithCursor,
+		domain.ErrCursorInvalidFormat,
+		domain.ErrCursorInvalidSignature,
+		domain.ErrCursorExpired,
+		domain.ErrCursorQueryMismatch,
+	}
+	for _, err := range errs {
+		issue, ok := toValidationIssue(apierror.LocationBody, err)
+		if !ok {
+			t.Fatalf("%v is not a validation error", err)
+		}
+		if msg, ok := Messages.Message(i18n.English, issue.Field, issue.Code); !ok || msg == "" {
+			t.Errorf("no English message for %s.%s", issue.Field, issue.Code)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"teamflow-shared/clock"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// MilestonesHandler は /projects/{id}/milestones 以下を処理する HTTP ハンドラ。
+type MilestonesHandler struct {
+	createUC   *usecase.CreateMilestoneUsecase
+	updateUC   *usecase.UpdateMilestoneUsecase
+	deleteUC   *usecase.DeleteMilestoneUsecase
+	listUC     *usecase.ListMilestonesUsecase
+	getUC      *usecase.GetMilestoneUsecase
+	progressUC *usecase.GetMilestoneProgressUsecase
+	clock      clock.Clock
+}
+
+// NewMilestonesHandler は MilestonesHandler を生成する。
+func NewMilestonesHandler(
+	createUC *usecase.CreateMilestoneUsecase,
+	updateUC *usecase.UpdateMilestoneUsecase,
+	deleteUC *usecase.DeleteMilestoneUsecase,
+	listUC *usecase.ListMilestonesUsecase,
+	getUC *usecase.GetMilestoneUsecase,
+	progressUC *usecase.GetMilestoneProgressUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &MilestonesHandler{
+		createUC:   createUC,
+		updateUC:   updateUC,
+		deleteUC:   deleteUC,
+		listUC:     listUC,
+		getUC:      getUC,
+		progressUC: progressUC,
+		clock:      clk,
+	}
+}
+
+// milestoneRequest は POST / PUT のリクエスト。PUT では id を無視し、名前・期限・状態を置き換える。
+type milestoneRequest struct {
+	ID      string     `json:"id"`
+	Name    string     `json:"name"`
+	DueDate *time.Time `json:"dueDate"`
+	Status  string     `json:"status"` // 省略時は open
+}
+
+type milestoneResponse struct {
+	ID        string     `json:"id"`
+	ProjectID string     `json:"projectId"`
+	Name      string     `json:"name"`
+	DueDate   *time.Time `json:"dueDate"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+type listMilestonesResponse struct {
+	Milestones []milestoneResponse `json:"milestones"`
+}
+
+// milestoneProgressResponse は GET /projects/{id}/milestones:progress の 1 件分。
+type milestoneProgressResponse struct {
+	milestoneResponse
+	Open int `json:"open"`
+	Done int `json:"done"`
+}
+
+type listMilestoneProgressResponse struct {
+	ProjectID  string                      `json:"projectId"`
+	Milestones []milestoneProgressResponse `json:"milestones"`
+}
+
+// toMilestoneProgressResponses はマイルストーンの進捗をレスポンスに変換する（空の場合も [] にする）。
+func toMilestoneProgressResponses(progress []domain.MilestoneProgress) []milestoneProgressResponse {
+	out := make([]milestoneProgressResponse, 0, len(progress))
+	for _, p := range progress {
+		out = append(out, milestoneProgressResponse{
+			milestoneResponse: toMilestoneResponse(p.Milestone),
+			Open:              p.Open,
+			Done:              p.Done,
+		})
+	}
+	return out
+}
+
+func toMilestoneResponse(m *domain.Milestone) milestoneResponse {
+	return milestoneResponse{
+		ID:        m.ID,
+		ProjectID: m.ProjectID,
+		Name:      m.Name,
+		DueDate:   m.DueDate,
+		Status:    string(m.Status),
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// milestonesProgressSegment は進捗を返すサブリソース名（/projects/{id}/milestones:progress）。
+const milestonesProgressSegment = "milestones:progress"
+
+// parseMilestonesPath は /projects/{id}/milestones[/{milestoneId}] と /projects/{id}/milestones:progress から
+// projectID と milestoneID を取り出す。progress は :progress の場合に true。
+func parseMilestonesPath(path string) (projectID, milestoneID string, progress, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/projects/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+		return "", "", false, false
+	}
+	switch {
+	case len(parts) == 2 && parts[1] == milestonesProgressSegment:
+		return parts[0], "", true, true
+	case parts[1] != "milestones":
+		return "", "", false, false
+	}
+	if len(parts) == 3 {
+		if parts[2] == "" {
+			return "", "", false, false
+		}
+		milestoneID = parts[2]
+	}
+	return parts[0], milestoneID, false, true
+}
+
+// IsMilestonesPath はパスが /projects/{id}/milestones 以下（:progress を含む）かどうかを返す。
+func IsMilestonesPath(path string) bool {
+	_, _, _, ok := parseMilestonesPath(path)
+	return ok
+}
+
+// ServeHTTP は以下を処理する。
+// - GET    /projects/{id}/milestones                : マイルストーン一覧
+// - POST   /projects/{id}/milestones                : マイルストーン作成
+// - GET    /projects/{id}/milestones:progress       : マイルストーンごとのタスクの進捗
+// - GET    /projects/{id}/milestones/{milestoneId}  : マイルストーン取得（tasks サービスの存在チェック用）
+// - PUT    /projects/{id}/milestones/{milestoneId}  : マイルストーン更新
+// - DELETE /projects/{id}/milestones/{milestoneId}  : マイルストーン削除
+func (h *MilestonesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	projectID, milestoneID, progress, ok := parseMilestonesPath(r.URL.Path)
+	if !ok {
+		writeNotFound(w, "not found")
+		return
+	}
+
+	switch {
+	case progress:
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w)
+			return
+		}
+		h.handleProgress(w, r, projectID)
+	case milestoneID == "":
+		switch r.Method {
+		case http.MethodGet:
+			h.handleList(w, r, projectID)
+		case http.MethodPost:
+			h.handleCreate(w, r, projectID)
+		default:
+			writeMethodNotAllowed(w)
+		}
+	default:
+		switch r.Method {
+		case http.MethodGet:
+			h.handleGet(w, r, projectID, milestoneID)
+		case http.MethodPut:
+			h.handleUpdate(w, r, projectID, milestoneID)
+		case http.MethodDelete:
+			h.handleDelete(w, r, projectID, milestoneID)
+		default:
+			writeMethodNotAllowed(w)
+		}
+	}
+}
+
+func (h *MilestonesHandler) handleList(w http.ResponseWriter, r *http.Request, projectID string) {
+	milestones, err := h.listUC.Execute(r.Context(), projectID, actorID(r))
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := listMilestonesResponse{Milestones: make([]milestoneResponse, 0, len(milestones))}
+	for _, m := range milestones {
+		resp.Milestones = append(resp.Milestones, toMilestoneResponse(m))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *MilestonesHandler) handleCreate(w http.ResponseWriter, r *http.Request, projectID string) {
+	var req milestoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	m, err := h.createUC.Execute(r.Context(), usecase.CreateMilestoneInput{
+		ProjectID: projectID,
+		ID:        req.ID,
+		Name:      req.Name,
+		DueDate:   req.DueDate,
+		Status:    req.Status,
+		ActorID:   actorID(r),
+		Now:       h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(toMilestoneResponse(m))
+}
+
+func (h *MilestonesHandler) handleGet(w http.ResponseWriter, r *http.Request, projectID, milestoneID string) {
+	m, err := h.getUC.Execute(r.Context(), projectID, milestoneID)
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toMilestoneResponse(m))
+}
+
+func (h *MilestonesHandler) handleUpdate(w http.ResponseWriter, r *http.Request, projectID, milestoneID string) {
+	var req milestoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	m, err := h.updateUC.Execute(r.Context(), usecase.UpdateMilestoneInput{
+		ProjectID: projectID,
+		ID:        milestoneID,
+		Name:      req.Name,
+		DueDate:   req.DueDate,
+		Status:    req.Status,
+		ActorID:   actorID(r),
+		Now:       h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toMilestoneResponse(m))
+}
+
+func (h *MilestonesHandler) handleDelete(w http.ResponseWriter, r *http.Request, projectID, milestoneID string) {
+	err := h.deleteUC.Execute(r.Context(), usecase.DeleteMilestoneInput{
+		ProjectID: projectID,
+		ID:        milestoneID,
+		ActorID:   actorID(r),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *MilestonesHandler) handleProgress(w http.ResponseWriter, r *http.Request, projectID string) {
+	progress, err := h.progressUC.Execute(r.Context(), projectID, actorID(r))
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := listMilestoneProgressResponse{
+		ProjectID:  projectID,
+		Milestones: toMilestoneProgressResponses(progress),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
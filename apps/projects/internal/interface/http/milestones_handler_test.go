@@ -0,0 +1,152 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	domain "teamflow-projects/internal/domain/project"
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// milestoneStatsStub は MilestoneStatsProvider のスタブ。
+type milestoneStatsStub map[string]domain.MilestoneTaskCounts
+
+func (s milestoneStatsStub) MilestoneStats(context.Context, string) (map[string]domain.MilestoneTaskCounts, error) {
+	return s, nil
+}
+
+func newMilestonesHandler(t *testing.T, stats usecase.MilestoneStatsProvider) http.Handler {
+	t.Helper()
+	projects := infra.NewMemoryProjectRepository()
+	seedProject(projects, "proj-1")
+	milestones := infra.NewMemoryMilestoneRepository()
+
+	return httpiface.NewMilestonesHandler(
+		&usecase.CreateMilestoneUsecase{Projects: projects, Milestones: milestones},
+		&usecase.UpdateMilestoneUsecase{Milestones: milestones},
+		&usecase.DeleteMilestoneUsecase{Milestones: milestones},
+		&usecase.ListMilestonesUsecase{Projects: projects, Milestones: milestones},
+		&usecase.GetMilestoneUsecase{Milestones: milestones},
+		&usecase.GetMilestoneProgressUsecase{Projects: projects, Milestones: milestones, Stats: stats},
+		fixedClock,
+	)
+}
+
+type milestoneBody struct {
+	ID        string  `json:"id"`
+	ProjectID string  `json:"projectId"`
+	Name      string  `json:"name"`
+	DueDate   *string `json:"dueDate"`
+	Status    string  `json:"status"`
+}
+
+func TestMilestonesHandler_Lifecycle(t *testing.T) {
+	handler := newMilestonesHandler(t, milestoneStatsStub{"v1": {Open: 2, Done: 1}})
+
+	w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/milestones", map[string]any{"id": "v1", "name": "v1", "dueDate": "2025-02-01T00:00:00Z"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created milestoneBody
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.ID != "v1" || created.ProjectID != "proj-1" || created.Status != "open" || created.DueDate == nil || *created.DueDate != "2025-02-01T00:00:00Z" {
+		t.Errorf("unexpected milestone: %+v", created)
+	}
+
+	if w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/milestones", map[string]any{"id": "v1", "name": "dup"}); w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for duplicate milestone, got %d", w.Code)
+	}
+	if w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/milestones", map[string]any{"id": "v2", "name": "v2"}); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", w.Code)
+	}
+
+	w = doMembersRequest(handler, http.MethodPut, "/projects/proj-1/milestones/v2", map[string]any{"name": "v2.0", "status": "closed"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var updated milestoneBody
+	if err := json.NewDecoder(w.Body).Decode(&updated); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if updated.ID != "v2" || updated.Name != "v2.0" || updated.Status != "closed" || updated.DueDate != nil {
+		t.Errorf("unexpected milestone: %+v", updated)
+	}
+
+	w = doMembersRequest(handler, http.MethodGet, "/projects/proj-1/milestones:progress", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var progress struct {
+		ProjectID  string `json:"projectId"`
+		Milestones []struct {
+			ID   string `json:"id"`
+			Open int    `json:"open"`
+			Done int    `json:"done"`
+		} `json:"milestones"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&progress); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	// 期限のある v1 が先、期限なしの v2 は後ろ
+	if progress.ProjectID != "proj-1" || len(progress.Milestones) != 2 {
+		t.Fatalf("unexpected progress: %+v", progress)
+	}
+	if m := progress.Milestones[0]; m.ID != "v1" || m.Open != 2 || m.Done != 1 {
+		t.Errorf("unexpected v1 progress: %+v", m)
+	}
+	if m := progress.Milestones[1]; m.ID != "v2" || m.Open != 0 || m.Done != 0 {
+		t.Errorf("unexpected v2 progress: %+v", m)
+	}
+
+	if w := doMembersRequest(handler, http.MethodDelete, "/projects/proj-1/milestones/v1", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", w.Code)
+	}
+	if w := doMembersRequest(handler, http.MethodGet, "/projects/proj-1/milestones/v1", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 after delete, got %d", w.Code)
+	}
+}
+
+func TestMilestonesHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       any
+		stats      usecase.MilestoneStatsProvider
+		wantStatus int
+	}{
+		{name: "empty name", method: http.MethodPost, path: "/projects/proj-1/milestones", body: map[string]any{"id": "v1"}, wantStatus: http.StatusBadRequest},
+		{name: "invalid status", method: http.MethodPost, path: "/projects/proj-1/milestones", body: map[string]any{"id": "v1", "name": "v1", "status": "done"}, wantStatus: http.StatusBadRequest},
+		{name: "invalid dueDate", method: http.MethodPost, path: "/projects/proj-1/milestones", body: map[string]any{"id": "v1", "name": "v1", "dueDate": "2025-02-01"}, wantStatus: http.StatusBadRequest},
+		{name: "project not found", method: http.MethodPost, path: "/projects/missing/milestones", body: map[string]any{"id": "v1", "name": "v1"}, wantStatus: http.StatusNotFound},
+		{name: "update not found", method: http.MethodPut, path: "/projects/proj-1/milestones/missing", body: map[string]any{"name": "x"}, wantStatus: http.StatusNotFound},
+		{name: "method not allowed", method: http.MethodPatch, path: "/projects/proj-1/milestones", wantStatus: http.StatusMethodNotAllowed},
+		{name: "progress method not allowed", method: http.MethodPost, path: "/projects/proj-1/milestones:progress", wantStatus: http.StatusMethodNotAllowed},
+		{name: "nested path", method: http.MethodGet, path: "/projects/proj-1/milestones/v1/tasks", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newMilestonesHandler(t, tt.stats)
+			if w := doMembersRequest(handler, tt.method, tt.path, tt.body); w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestMilestonesHandler_ProgressWithoutTasksService(t *testing.T) {
+	handler := newMilestonesHandler(t, nil)
+	if w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/milestones", map[string]any{"id": "v1", "name": "v1"}); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", w.Code)
+	}
+	if w := doMembersRequest(handler, http.MethodGet, "/projects/proj-1/milestones:progress", nil); w.Code != http.StatusBadGateway {
+		t.Errorf("expected status 502, got %d", w.Code)
+	}
+}
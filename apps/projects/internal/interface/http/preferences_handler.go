@@ -0,0 +1,161 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"teamflow-shared/clock"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// PreferencesHandler は操作者ごとのプロジェクトの表示設定を扱う HTTP ハンドラ。
+//
+//	POST / DELETE /projects/{id}/favorite  お気に入りに追加 / 解除
+//	GET / PUT     /projects/order          表示設定の取得 / 並び順の置き換え
+type PreferencesHandler struct {
+	favoriteUC *usecase.SetFavoriteUsecase
+	listUC     *usecase.ListPreferencesUsecase
+	reorderUC  *usecase.ReorderProjectsUsecase
+	clock      clock.Clock
+}
+
+// NewPreferencesHandler は PreferencesHandler を生成する。
+func NewPreferencesHandler(
+	favoriteUC *usecase.SetFavoriteUsecase,
+	listUC *usecase.ListPreferencesUsecase,
+	reorderUC *usecase.ReorderProjectsUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &PreferencesHandler{
+		favoriteUC: favoriteUC,
+		listUC:     listUC,
+		reorderUC:  reorderUC,
+		clock:      clk,
+	}
+}
+
+// orderPath は並び順のエンドポイントのパス（/api を除く）。
+const orderPath = "/projects/order"
+
+// reorderRequest は PUT /projects/order のリクエスト（並び順全体を置き換える）。
+type reorderRequest struct {
+	ProjectIDs []string `json:"projectIds"`
+}
+
+// preferenceResponse はプロジェクトの表示設定のレスポンス。並び順が未指定の場合 position は null。
+type preferenceResponse struct {
+	ProjectID string `json:"projectId"`
+	Favorite  bool   `json:"favorite"`
+	Position  *int   `json:"position"`
+}
+
+// preferencesResponse は GET / PUT /projects/order のレスポンス（サイドバーの表示順）。
+type preferencesResponse struct {
+	Projects []preferenceResponse `json:"projects"`
+}
+
+func toPreferencesResponse(prefs []*domain.Preference) preferencesResponse {
+	resp := preferencesResponse{Projects: make([]preferenceResponse, 0, len(prefs))}
+	for _, p := range prefs {
+		resp.Projects = append(resp.Projects, preferenceResponse{
+			ProjectID: p.ProjectID,
+			Favorite:  p.Favorite,
+			Position:  p.Position,
+		})
+	}
+	return resp
+}
+
+// parseFavoritePath は /projects/{id}/favorite から id を取り出す。
+func parseFavoritePath(path string) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/projects/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "favorite" {
+		return "", false
+	}
+	return parts[0], true
+}
+
+// IsFavoritePath はパスが /projects/{id}/favorite かどうかを返す。
+func IsFavoritePath(path string) bool {
+	_, ok := parseFavoritePath(path)
+	return ok
+}
+
+func (h *PreferencesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == orderPath {
+		switch r.Method {
+		case http.MethodGet:
+			h.handleList(w, r)
+		case http.MethodPut:
+			h.handleReorder(w, r)
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	projectID, ok := parseFavoritePath(r.URL.Path)
+	if !ok {
+		writeNotFound(w, "not found")
+		return
+	}
+	switch r.Method {
+	case http.MethodPost:
+		h.handleFavorite(w, r, projectID, true)
+	case http.MethodDelete:
+		h.handleFavorite(w, r, projectID, false)
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+func (h *PreferencesHandler) handleFavorite(w http.ResponseWriter, r *http.Request, projectID string, favorite bool) {
+	err := h.favoriteUC.Execute(r.Context(), usecase.SetFavoriteInput{
+		ProjectID: projectID,
+		UserID:    actorID(r),
+		Favorite:  favorite,
+		Now:       h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PreferencesHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	prefs, err := h.listUC.Execute(r.Context(), actorID(r))
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toPreferencesResponse(prefs))
+}
+
+func (h *PreferencesHandler) handleReorder(w http.ResponseWriter, r *http.Request) {
+	var req reorderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	prefs, err := h.reorderUC.Execute(r.Context(), usecase.ReorderProjectsInput{
+		UserID:     actorID(r),
+		ProjectIDs: req.ProjectIDs,
+		Now:        h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toPreferencesResponse(prefs))
+}
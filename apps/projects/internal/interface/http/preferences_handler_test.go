@@ -0,0 +1,130 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+type preferencesBody struct {
+	Projects []struct {
+		ProjectID string `json:"projectId"`
+		Favorite  bool   `json:"favorite"`
+		Position  *int   `json:"position"`
+	} `json:"projects"`
+}
+
+func newPreferencesHandler(t *testing.T) http.Handler {
+	t.Helper()
+	projects := infra.NewMemoryProjectRepository()
+	for _, id := range []string{"proj-1", "proj-2", "proj-3"} {
+		seedProject(projects, id)
+	}
+	prefs := infra.NewMemoryPreferenceRepository()
+	return httpiface.NewPreferencesHandler(
+		&usecase.SetFavoriteUsecase{Projects: projects, Preferences: prefs},
+		&usecase.ListPreferencesUsecase{Preferences: prefs},
+		&usecase.ReorderProjectsUsecase{Projects: projects, Preferences: prefs},
+		fixedClock,
+	)
+}
+
+func doPreferencesRequest(t *testing.T, handler http.Handler, method, path, actor string, body interface{}) (int, preferencesBody) {
+	t.Helper()
+	var b []byte
+	if body != nil {
+		b, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(b))
+	if actor != "" {
+		req.Header.Set(httpiface.ActorHeader, actor)
+	}
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	var resp preferencesBody
+	if w.Code == http.StatusOK {
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+	}
+	return w.Code, resp
+}
+
+func TestPreferencesHandler_FavoriteAndOrder(t *testing.T) {
+	handler := newPreferencesHandler(t)
+
+	if status, _ := doPreferencesRequest(t, handler, http.MethodPost, "/projects/proj-3/favorite", "user-1", nil); status != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", status)
+	}
+	status, got := doPreferencesRequest(t, handler, http.MethodPut, "/projects/order", "user-1", map[string]interface{}{
+		"projectIds": []string{"proj-2", "proj-1"},
+	})
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	// お気に入りが先頭、その後に並び順
+	want := []string{"proj-3", "proj-2", "proj-1"}
+	if len(got.Projects) != len(want) {
+		t.Fatalf("expected %v, got %+v", want, got.Projects)
+	}
+	for i, p := range got.Projects {
+		if p.ProjectID != want[i] {
+			t.Errorf("position %d: expected %s, got %s", i, want[i], p.ProjectID)
+		}
+	}
+	if got.Projects[0].Position != nil || !got.Projects[0].Favorite {
+		t.Errorf("expected proj-3 to be favorite without position, got %+v", got.Projects[0])
+	}
+
+	// 他のユーザーの表示設定には影響しない
+	if status, got := doPreferencesRequest(t, handler, http.MethodGet, "/projects/order", "user-2", nil); status != http.StatusOK || len(got.Projects) != 0 {
+		t.Errorf("expected empty preferences for user-2, got status %d, %+v", status, got.Projects)
+	}
+
+	// お気に入りを解除すると並び順の指定が無いプロジェクトは含まれない
+	if status, _ := doPreferencesRequest(t, handler, http.MethodDelete, "/projects/proj-3/favorite", "user-1", nil); status != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", status)
+	}
+	status, got = doPreferencesRequest(t, handler, http.MethodGet, "/projects/order", "user-1", nil)
+	if status != http.StatusOK || len(got.Projects) != 2 || got.Projects[0].ProjectID != "proj-2" {
+		t.Errorf("expected [proj-2 proj-1], got status %d, %+v", status, got.Projects)
+	}
+}
+
+func TestPreferencesHandler_Errors(t *testing.T) {
+	handler := newPreferencesHandler(t)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		actor      string
+		body       interface{}
+		wantStatus int
+	}{
+		{name: "favorite without actor", method: http.MethodPost, path: "/projects/proj-1/favorite", wantStatus: http.StatusUnauthorized},
+		{name: "favorite unknown project", method: http.MethodPost, path: "/projects/proj-x/favorite", actor: "user-1", wantStatus: http.StatusNotFound},
+		{name: "favorite wrong method", method: http.MethodGet, path: "/projects/proj-1/favorite", actor: "user-1", wantStatus: http.StatusMethodNotAllowed},
+		{name: "order without actor", method: http.MethodGet, path: "/projects/order", wantStatus: http.StatusUnauthorized},
+		{name: "order duplicate", method: http.MethodPut, path: "/projects/order", actor: "user-1", body: map[string]interface{}{"projectIds": []string{"proj-1", "proj-1"}}, wantStatus: http.StatusBadRequest},
+		{name: "order unknown project", method: http.MethodPut, path: "/projects/order", actor: "user-1", body: map[string]interface{}{"projectIds": []string{"proj-x"}}, wantStatus: http.StatusNotFound},
+		{name: "order invalid json", method: http.MethodPut, path: "/projects/order", actor: "user-1", body: "not an object", wantStatus: http.StatusBadRequest},
+		{name: "order wrong method", method: http.MethodPost, path: "/projects/order", actor: "user-1", wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status, _ := doPreferencesRequest(t, handler, tt.method, tt.path, tt.actor, tt.body); status != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, status)
+			}
+		})
+	}
+}
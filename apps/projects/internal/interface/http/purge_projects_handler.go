@@ -0,0 +1,95 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"teamflow-shared/clock"
+
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// PurgeProjectsHandler は POST /admin/projects:purge を処理する HTTP ハンドラ。
+//
+// 運用者（ADMIN_USER_IDS）が、アーカイブ・削除してから olderThanDays 日を過ぎたプロジェクトを、すべてのワークスペースから
+// タスクとともに物理削除する。1 回の呼び出しで削除するのは最大 usecase.DefaultProjectPurgeBatchSize 件で、
+// hasMore が true の場合は同じリクエストを繰り返す。dryRun が true の場合は削除せずに対象のプロジェクトを返す。
+type PurgeProjectsHandler struct {
+	purgeUC *usecase.PurgeProjectsUsecase
+	clock   clock.Clock
+}
+
+// NewPurgeProjectsHandler は PurgeProjectsHandler を生成する。
+func NewPurgeProjectsHandler(purgeUC *usecase.PurgeProjectsUsecase, clk clock.Clock) http.Handler {
+	return &PurgeProjectsHandler{purgeUC: purgeUC, clock: clk}
+}
+
+type purgeProjectsRequest struct {
+	OlderThanDays int  `json:"olderThanDays"`
+	DryRun        bool `json:"dryRun"`
+}
+
+type purgedProjectResponse struct {
+	ID          string     `json:"id"`
+	WorkspaceID string     `json:"workspaceId"`
+	Key         string     `json:"key,omitempty"`
+	Name        string     `json:"name"`
+	ArchivedAt  *time.Time `json:"archivedAt"`
+	DeletedAt   *time.Time `json:"deletedAt"`
+}
+
+type purgeProjectsResponse struct {
+	DryRun bool `json:"dryRun"`
+	// Before はこの日時より前にアーカイブ・削除されたプロジェクトを対象にしたことを表す
+	Before time.Time `json:"before"`
+	Count  int       `json:"count"`
+	// HasMore は対象のプロジェクトが残っていることを表す（同じリクエストを繰り返す）
+	HasMore  bool                    `json:"hasMore"`
+	Projects []purgedProjectResponse `json:"projects"`
+}
+
+func (h *PurgeProjectsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req purgeProjectsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	out, err := h.purgeUC.Execute(r.Context(), usecase.PurgeProjectsInput{
+		OlderThanDays: req.OlderThanDays,
+		DryRun:        req.DryRun,
+		ActorID:       actorID(r),
+		Now:           h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := purgeProjectsResponse{
+		DryRun:   req.DryRun,
+		Before:   out.Before,
+		Count:    len(out.Projects),
+		HasMore:  out.HasMore,
+		Projects: make([]purgedProjectResponse, 0, len(out.Projects)),
+	}
+	for _, p := range out.Projects {
+		resp.Projects = append(resp.Projects, purgedProjectResponse{
+			ID:          p.ID,
+			WorkspaceID: p.WorkspaceID,
+			Key:         p.Key,
+			Name:        p.Name,
+			ArchivedAt:  p.ArchivedAt,
+			DeletedAt:   p.DeletedAt,
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
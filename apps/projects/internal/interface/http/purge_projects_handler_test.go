@@ -0,0 +1,103 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"teamflow-shared/authz"
+
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+func TestPurgeProjectsHandler(t *testing.T) {
+	ctx := context.Background()
+	projects := infra.NewMemoryProjectRepository()
+	for id, daysAgo := range map[string]int{"proj-1": 100, "proj-2": 10} {
+		p := seedProject(projects, id)
+		archivedAt := fixedNow().AddDate(0, 0, -daysAgo)
+		p.ArchivedAt = &archivedAt
+		_ = projects.Save(ctx, p)
+	}
+	seedProject(projects, "proj-3")
+	tasks := &stubTaskCascader{}
+	handler := httpiface.NewPurgeProjectsHandler(&usecase.PurgeProjectsUsecase{
+		Purger:    projects,
+		Tasks:     tasks,
+		Operators: authz.ParseOperators("ops-1"),
+	}, fixedClock)
+
+	// 順に実行する（前のステップの結果に依存する）
+	steps := []struct {
+		name       string
+		method     string
+		actor      string
+		body       string
+		wantStatus int
+		wantIDs    string
+		wantTasks  string // 実行後までに呼び出した tasks サービス
+	}{
+		{name: "no actor", body: `{"olderThanDays":90}`, wantStatus: http.StatusUnauthorized},
+		{name: "not an operator", actor: "user-1", body: `{"olderThanDays":90}`, wantStatus: http.StatusForbidden},
+		{name: "missing days", actor: "ops-1", body: `{"dryRun":true}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid json", actor: "ops-1", body: `{`, wantStatus: http.StatusBadRequest},
+		{name: "method not allowed", method: http.MethodGet, actor: "ops-1", wantStatus: http.StatusMethodNotAllowed},
+		{name: "dry run", actor: "ops-1", body: `{"olderThanDays":90,"dryRun":true}`, wantStatus: http.StatusOK, wantIDs: "proj-1"},
+		{name: "purge", actor: "ops-1", body: `{"olderThanDays":90}`, wantStatus: http.StatusOK, wantIDs: "proj-1", wantTasks: "delete:proj-1"},
+		{name: "purge again", actor: "ops-1", body: `{"olderThanDays":90}`, wantStatus: http.StatusOK, wantTasks: "delete:proj-1"},
+	}
+	for _, step := range steps {
+		method := step.method
+		if method == "" {
+			method = http.MethodPost
+		}
+		req := httptest.NewRequest(method, "/admin/projects:purge", strings.NewReader(step.body))
+		if step.actor != "" {
+			req.Header.Set(authz.ActorHeader, step.actor)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != step.wantStatus {
+			t.Fatalf("%s: expected status %d, got %d: %s", step.name, step.wantStatus, w.Code, w.Body.String())
+		}
+		if w.Code != http.StatusOK {
+			continue
+		}
+		var got struct {
+			Count    int  `json:"count"`
+			HasMore  bool `json:"hasMore"`
+			Projects []struct {
+				ID         string  `json:"id"`
+				ArchivedAt *string `json:"archivedAt"`
+			} `json:"projects"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("%s: failed to decode response: %v", step.name, err)
+		}
+		ids := make([]string, len(got.Projects))
+		for i, p := range got.Projects {
+			ids[i] = p.ID
+			if p.ArchivedAt == nil {
+				t.Errorf("%s: expected archivedAt for %s", step.name, p.ID)
+			}
+		}
+		if strings.Join(ids, ",") != step.wantIDs || got.Count != len(ids) || got.HasMore {
+			t.Errorf("%s: unexpected response: %s", step.name, w.Body.String())
+		}
+		if calls := strings.Join(tasks.calls, ","); calls != step.wantTasks {
+			t.Errorf("%s: unexpected task calls: %s", step.name, calls)
+		}
+	}
+
+	for id, want := range map[string]bool{"proj-1": false, "proj-2": true, "proj-3": true} {
+		if _, err := projects.FindByID(ctx, id); (err == nil) != want {
+			t.Errorf("project %s remains = %v, want %v", id, err == nil, want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"teamflow-shared/apiversion"
+)
+
+// APIPrefix は projects サービスの API を配置するパス（tasks サービスと同じ）。
+const APIPrefix = "/api"
+
+// Handlers は Router に登録する各エンドポイントのハンドラ。
+type Handlers struct {
+	Projects           http.Handler // POST /api/projects, GET /api/projects?q=&archived=&status=&sort=&limit=&cursor=
+	CreateFromTemplate http.Handler // POST /api/projects:from-template
+	Templates          http.Handler // POST /api/templates, GET /api/templates
+	Get                http.Handler // GET /api/projects/{id}
+	Update             http.Handler // PUT /api/projects/{id}
+	Delete             http.Handler // DELETE /api/projects/{id}?cascade=, POST /api/projects/{id}/restore
+	Archive            http.Handler // POST /api/projects/{id}/archive|unarchive
+	Members            http.Handler // /api/projects/{id}/members[/{userId}]
+	Settings           http.Handler // GET|PUT /api/projects/{id}/settings
+	Clone              http.Handler // POST /api/projects/{id}/clone
+	Import             http.Handler // POST /api/projects/{id}/import?source=trello|jira&dryRun=
+	Stats              http.Handler // GET /api/projects/{id}/stats
+	Summary            http.Handler // GET /api/projects/{id}/summary
+	Activity           http.Handler // GET /api/projects/{id}/activity?limit=&cursor=
+	Preferences        http.Handler // POST|DELETE /api/projects/{id}/favorite, GET|PUT /api/projects/order
+	Milestones         http.Handler // /api/projects/{id}/milestones[/{milestoneId}], GET /api/projects/{id}/milestones:progress
+	Sprints            http.Handler // /api/projects/{id}/sprints[/{sprintId}], POST /api/projects/{id}/sprints/{sprintId}:start|complete
+	Epics              http.Handler // /api/projects/{id}/epics[/{epicId}], GET /api/projects/{id}/epics:progress
+	Labels             http.Handler // /api/projects/{id}/labels[/{labelId}]
+	Invitations        http.Handler // /api/projects/{id}/invitations[/{invitationId}], GET|POST /api/invitations/{token}
+	Slack              http.Handler // GET|PUT|DELETE /api/projects/{id}/integrations/slack, POST /api/projects/{id}/integrations/slack:test
+	Purge              http.Handler // POST /api/admin/projects:purge（運用者のみ）
+
+	// ProjectExists はサブリソース（members, milestones など）の処理の前に、プロジェクトが操作者のワークスペースに
+	// あるかを確認する。存在しない場合は usecase.ErrProjectNotFound を返す。
+	// サブリソースのテーブルはワークスペースを持たないため、別のワークスペースのプロジェクトの ID を指定されても
+	// ここで 404 にする。任意。nil の場合は確認しない。
+	ProjectExists func(ctx context.Context, projectID string) error
+}
+
+// NewRouter は projects サービスの API のルーティングを行うハンドラを返す。
+//
+// API はすべて APIPrefix 配下（/api/v1 と、その別名の /api）に置き、プレフィックスはここで一度だけ取り除く。
+// 各ハンドラは /api（/api/v1）を除いたパス（/projects, /projects/{id}...）を扱う。
+// /healthz, /metrics などの運用エンドポイントは含まない。
+func NewRouter(h Handlers) http.Handler {
+	api := http.NewServeMux()
+	api.Handle("/projects", h.Projects)
+	api.Handle("/projects:from-template", h.CreateFromTemplate)
+	api.Handle("/templates", h.Templates)
+	api.Handle("/projects/order", h.Preferences)
+	api.Handle("/invitations/", h.Invitations)
+	api.HandleFunc("/projects/", h.serveProject)
+	api.Handle("/admin/projects:purge", h.Purge)
+
+	// 正式なパスは /api/v1 配下。バージョン無しの /api 配下は互換のため v1 の別名として扱う
+	return apiversion.Handler(APIPrefix, api)
+}
+
+// serveProject は /projects/{id} 配下をサブリソースごとに振り分ける。
+func (h Handlers) serveProject(w http.ResponseWriter, r *http.Request) {
+	if isProjectSubresourcePath(r.URL.Path) && !h.checkProject(w, r) {
+		return
+	}
+
+	switch p := r.URL.Path; {
+	case IsMembersPath(p):
+		h.Members.ServeHTTP(w, r)
+	case IsSettingsPath(p):
+		h.Settings.ServeHTTP(w, r)
+	case IsStatsPath(p):
+		h.Stats.ServeHTTP(w, r)
+	case IsSummaryPath(p):
+		h.Summary.ServeHTTP(w, r)
+	case IsActivityPath(p):
+		h.Activity.ServeHTTP(w, r)
+	case IsMilestonesPath(p):
+		h.Milestones.ServeHTTP(w, r)
+	case IsSprintsPath(p):
+		h.Sprints.ServeHTTP(w, r)
+	case IsEpicsPath(p):
+		h.Epics.ServeHTTP(w, r)
+	case IsLabelsPath(p):
+		h.Labels.ServeHTTP(w, r)
+	case IsInvitationsPath(p):
+		h.Invitations.ServeHTTP(w, r)
+	case IsSlackPath(p):
+		h.Slack.ServeHTTP(w, r)
+	case IsClonePath(p):
+		h.Clone.ServeHTTP(w, r)
+	case IsImportPath(p):
+		h.Import.ServeHTTP(w, r)
+	case IsArchivePath(p):
+		h.Archive.ServeHTTP(w, r)
+	case IsFavoritePath(p):
+		h.Preferences.ServeHTTP(w, r)
+	case IsRestorePath(p), r.Method == http.MethodDelete:
+		h.Delete.ServeHTTP(w, r)
+	case r.Method == http.MethodGet:
+		h.Get.ServeHTTP(w, r)
+	default:
+		h.Update.ServeHTTP(w, r)
+	}
+}
+
+// isProjectSubresourcePath は path がプロジェクトのサブリソース（プロジェクト自体は別のハンドラが取得する）かどうかを返す。
+// 取得・更新・削除・復元・アーカイブ・複製・インポートはプロジェクトのリポジトリがワークスペースで絞り込むため含めない。
+func isProjectSubresourcePath(p string) bool {
+	return IsMembersPath(p) || IsSettingsPath(p) || IsStatsPath(p) || IsSummaryPath(p) || IsActivityPath(p) ||
+		IsMilestonesPath(p) || IsSprintsPath(p) || IsEpicsPath(p) || IsLabelsPath(p) ||
+		IsInvitationsPath(p) || IsFavoritePath(p) || IsSlackPath(p)
+}
+
+// checkProject は ProjectExists でパスのプロジェクトを確認する。続けて処理してよい場合は true を返す。
+func (h Handlers) checkProject(w http.ResponseWriter, r *http.Request) bool {
+	if h.ProjectExists == nil {
+		return true
+	}
+	projectID, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/projects/"), "/")
+	if err := h.ProjectExists(r.Context(), projectID); err != nil {
+		writeUsecaseError(w, err)
+		return false
+	}
+	return true
+}
+
+// routeSegments は RouteLabel でそのまま残すパスの要素。それ以外（ID など）は {id} に置き換える。
+var routeSegments = map[string]bool{
+	"livez":                  true,
+	"readyz":                 true,
+	"healthz":                true,
+	"openapi.json":           true,
+	"api":                    true,
+	"projects":               true,
+	"projects:from-template": true,
+	"templates":              true,
+	"order":                  true,
+	"invitations":            true,
+	"members":                true,
+	"settings":               true,
+	"stats":                  true,
+	"summary":                true,
+	"activity":               true,
+	"milestones":             true,
+	"milestones:progress":    true,
+	"sprints":                true,
+	"epics":                  true,
+	"epics:progress":         true,
+	"labels":                 true,
+	"clone":                  true,
+	"import":                 true,
+	"archive":                true,
+	"unarchive":              true,
+	"favorite":               true,
+	"integrations":           true,
+	"slack":                  true,
+	"slack:test":             true,
+	"restore":                true,
+	"admin":                  true,
+	"projects:purge":         true,
+}
+
+// routeActions は {id}:action 形式の要素でそのまま残す action。
+var routeActions = map[string]bool{"start": true, "complete": true}
+
+// maxRouteSegments は RouteLabel で扱うパスの要素数の上限（/api/v1 の v1 を除く。これより深いパスは存在しない）。
+const maxRouteSegments = 6
+
+// RouteLabel はメトリクスの route ラベル用に、パスの ID を {id} に置き換えたルートを返す。
+// 例: /api/projects/p-1/sprints/s-1:start → /api/projects/{id}/sprints/{id}:start
+// ラベルの種類が増え続けないよう、ルートに無い深さのパスは "other" にまとめる。
+func RouteLabel(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	// /api/v1 のバージョンはそのまま残す（別名の /api と分けて計測する）
+	versioned := len(parts) > 1 && parts[0] == "api" && parts[1] == apiversion.Current
+	limit := maxRouteSegments
+	if versioned {
+		limit++
+	}
+	if len(parts) > limit {
+		return "other"
+	}
+	for i, p := range parts {
+		if routeSegments[p] || p == "" || (versioned && i == 1) {
+			continue
+		}
+		if j := strings.LastIndex(p, ":"); j >= 0 && routeActions[p[j+1:]] {
+			parts[i] = "{id}:" + p[j+1:]
+			continue
+		}
+		parts[i] = "{id}"
+	}
+	return "/" + strings.Join(parts, "/")
+}
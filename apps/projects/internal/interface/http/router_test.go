@@ -0,0 +1,264 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"teamflow-shared/authz"
+	"teamflow-shared/workspace"
+
+	domain "teamflow-projects/internal/domain/project"
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// stubHandler は呼ばれたハンドラ名とハンドラが受け取ったパスをヘッダで返す。
+func stubHandler(name string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Handler", name)
+		w.Header().Set("X-Path", r.URL.Path)
+	})
+}
+
+// TestRouter は公開している URL と、各ハンドラが受け取るパス（/api を除いたもの）を固定する。
+func TestRouter(t *testing.T) {
+	router := httpiface.NewRouter(httpiface.Handlers{
+		Projects:           stubHandler("projects"),
+		CreateFromTemplate: stubHandler("createFromTemplate"),
+		Templates:          stubHandler("templates"),
+		Get:                stubHandler("get"),
+		Update:             stubHandler("update"),
+		Delete:             stubHandler("delete"),
+		Archive:            stubHandler("archive"),
+		Members:            stubHandler("members"),
+		Settings:           stubHandler("settings"),
+		Clone:              stubHandler("clone"),
+		Import:             stubHandler("import"),
+		Stats:              stubHandler("stats"),
+		Activity:           stubHandler("activity"),
+		Preferences:        stubHandler("preferences"),
+		Milestones:         stubHandler("milestones"),
+		Sprints:            stubHandler("sprints"),
+		Epics:              stubHandler("epics"),
+		Labels:             stubHandler("labels"),
+		Invitations:        stubHandler("invitations"),
+		Slack:              stubHandler("slack"),
+		Purge:              stubHandler("purge"),
+	})
+
+	tests := []struct {
+		method      string
+		path        string
+		wantHandler string
+		wantPath    string
+	}{
+		{method: http.MethodPost, path: "/api/projects", wantHandler: "projects", wantPath: "/projects"},
+		{method: http.MethodGet, path: "/api/projects?limit=10", wantHandler: "projects", wantPath: "/projects"},
+		{method: http.MethodPost, path: "/api/projects:from-template", wantHandler: "createFromTemplate", wantPath: "/projects:from-template"},
+		{method: http.MethodPost, path: "/api/v1/admin/projects:purge", wantHandler: "purge", wantPath: "/admin/projects:purge"},
+		{method: http.MethodGet, path: "/api/templates", wantHandler: "templates", wantPath: "/templates"},
+		{method: http.MethodGet, path: "/api/projects/proj-1", wantHandler: "get", wantPath: "/projects/proj-1"},
+		{method: http.MethodPut, path: "/api/projects/proj-1", wantHandler: "update", wantPath: "/projects/proj-1"},
+		{method: http.MethodDelete, path: "/api/projects/proj-1?cascade=archive_tasks", wantHandler: "delete", wantPath: "/projects/proj-1"},
+		{method: http.MethodPost, path: "/api/projects/proj-1/restore", wantHandler: "delete", wantPath: "/projects/proj-1/restore"},
+		{method: http.MethodPost, path: "/api/projects/proj-1/archive", wantHandler: "archive", wantPath: "/projects/proj-1/archive"},
+		{method: http.MethodPost, path: "/api/projects/proj-1/unarchive", wantHandler: "archive", wantPath: "/projects/proj-1/unarchive"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/members", wantHandler: "members", wantPath: "/projects/proj-1/members"},
+		{method: http.MethodDelete, path: "/api/projects/proj-1/members/user-1", wantHandler: "members", wantPath: "/projects/proj-1/members/user-1"},
+		{method: http.MethodPut, path: "/api/projects/proj-1/settings", wantHandler: "settings", wantPath: "/projects/proj-1/settings"},
+		{method: http.MethodPost, path: "/api/projects/proj-1/clone", wantHandler: "clone", wantPath: "/projects/proj-1/clone"},
+		{method: http.MethodPost, path: "/api/projects/proj-1/import?source=trello", wantHandler: "import", wantPath: "/projects/proj-1/import"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/stats", wantHandler: "stats", wantPath: "/projects/proj-1/stats"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/activity", wantHandler: "activity", wantPath: "/projects/proj-1/activity"},
+		{method: http.MethodPost, path: "/api/projects/proj-1/milestones", wantHandler: "milestones", wantPath: "/projects/proj-1/milestones"},
+		{method: http.MethodPut, path: "/api/projects/proj-1/milestones/v1", wantHandler: "milestones", wantPath: "/projects/proj-1/milestones/v1"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/milestones:progress", wantHandler: "milestones", wantPath: "/projects/proj-1/milestones:progress"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/sprints", wantHandler: "sprints", wantPath: "/projects/proj-1/sprints"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/sprints/s1", wantHandler: "sprints", wantPath: "/projects/proj-1/sprints/s1"},
+		{method: http.MethodPost, path: "/api/projects/proj-1/sprints/s1:complete", wantHandler: "sprints", wantPath: "/projects/proj-1/sprints/s1:complete"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/epics/e1", wantHandler: "epics", wantPath: "/projects/proj-1/epics/e1"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/epics:progress", wantHandler: "epics", wantPath: "/projects/proj-1/epics:progress"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/labels", wantHandler: "labels", wantPath: "/projects/proj-1/labels"},
+		{method: http.MethodPatch, path: "/api/projects/proj-1/labels/l1", wantHandler: "labels", wantPath: "/projects/proj-1/labels/l1"},
+		{method: http.MethodPost, path: "/api/projects/proj-1/invitations", wantHandler: "invitations", wantPath: "/projects/proj-1/invitations"},
+		{method: http.MethodDelete, path: "/api/projects/proj-1/invitations/i1", wantHandler: "invitations", wantPath: "/projects/proj-1/invitations/i1"},
+		{method: http.MethodPost, path: "/api/invitations/tok", wantHandler: "invitations", wantPath: "/invitations/tok"},
+		{method: http.MethodPut, path: "/api/projects/proj-1/integrations/slack", wantHandler: "slack", wantPath: "/projects/proj-1/integrations/slack"},
+		{method: http.MethodPost, path: "/api/projects/proj-1/integrations/slack:test", wantHandler: "slack", wantPath: "/projects/proj-1/integrations/slack:test"},
+		{method: http.MethodPost, path: "/api/projects/proj-1/favorite", wantHandler: "preferences", wantPath: "/projects/proj-1/favorite"},
+		{method: http.MethodDelete, path: "/api/projects/proj-1/favorite", wantHandler: "preferences", wantPath: "/projects/proj-1/favorite"},
+		{method: http.MethodGet, path: "/api/projects/order", wantHandler: "preferences", wantPath: "/projects/order"},
+		{method: http.MethodPut, path: "/api/projects/order", wantHandler: "preferences", wantPath: "/projects/order"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if got := w.Header().Get("X-Handler"); got != tt.wantHandler {
+				t.Fatalf("expected handler %q, got %q (status %d)", tt.wantHandler, got, w.Code)
+			}
+			if got := w.Header().Get("X-Path"); got != tt.wantPath {
+				t.Errorf("expected path %q, got %q", tt.wantPath, got)
+			}
+		})
+	}
+
+	// /api を付けないパス（以前のルート直下の URL）や二重の /api は公開しない
+	for _, path := range []string{"/projects", "/projects/proj-1", "/templates", "/api/api/projects"} {
+		t.Run("not found "+path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+			if w.Code != http.StatusNotFound {
+				t.Errorf("expected status 404, got %d (handler=%q)", w.Code, w.Header().Get("X-Handler"))
+			}
+		})
+	}
+}
+
+// TestRouter_Versioned は正式な /api/v1 配下のパスと、レスポンスの X-API-Version を固定する。
+func TestRouter_Versioned(t *testing.T) {
+	router := httpiface.NewRouter(httpiface.Handlers{
+		Projects:           stubHandler("projects"),
+		CreateFromTemplate: stubHandler("createFromTemplate"),
+		Templates:          stubHandler("templates"),
+		Get:                stubHandler("get"),
+		Update:             stubHandler("update"),
+		Delete:             stubHandler("delete"),
+		Archive:            stubHandler("archive"),
+		Members:            stubHandler("members"),
+		Settings:           stubHandler("settings"),
+		Clone:              stubHandler("clone"),
+		Stats:              stubHandler("stats"),
+		Activity:           stubHandler("activity"),
+		Preferences:        stubHandler("preferences"),
+		Milestones:         stubHandler("milestones"),
+		Sprints:            stubHandler("sprints"),
+		Epics:              stubHandler("epics"),
+		Labels:             stubHandler("labels"),
+		Invitations:        stubHandler("invitations"),
+		Purge:              stubHandler("purge"),
+	})
+
+	tests := []struct {
+		method      string
+		path        string
+		wantHandler string
+		wantPath    string
+	}{
+		{method: http.MethodGet, path: "/api/v1/projects", wantHandler: "projects", wantPath: "/projects"},
+		{method: http.MethodPost, path: "/api/v1/projects/proj-1/sprints/s1:complete", wantHandler: "sprints", wantPath: "/projects/proj-1/sprints/s1:complete"},
+		{method: http.MethodPost, path: "/api/v1/invitations/tok", wantHandler: "invitations", wantPath: "/invitations/tok"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if got := w.Header().Get("X-Handler"); got != tt.wantHandler {
+				t.Fatalf("expected handler %q, got %q (status %d)", tt.wantHandler, got, w.Code)
+			}
+			if got := w.Header().Get("X-Path"); got != tt.wantPath {
+				t.Errorf("expected path %q, got %q", tt.wantPath, got)
+			}
+			if got := w.Header().Get("X-API-Version"); got != "v1" {
+				t.Errorf("expected X-API-Version v1, got %q", got)
+			}
+		})
+	}
+}
+
+// TestRouter_ProjectExists は別のワークスペースのプロジェクトのサブリソースが 404 になることを確認する。
+func TestRouter_ProjectExists(t *testing.T) {
+	repo := infra.NewMemoryProjectRepository()
+	p, err := domain.NewProject("proj-1", "Alpha", "", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	if err := repo.Save(workspace.NewContext(context.Background(), "ws-1"), p); err != nil {
+		t.Fatalf("failed to save project: %v", err)
+	}
+
+	router := workspace.Middleware(true, nil, httpiface.NewRouter(httpiface.Handlers{
+		Projects:           stubHandler("projects"),
+		CreateFromTemplate: stubHandler("createFromTemplate"),
+		Templates:          stubHandler("templates"),
+		Get:                stubHandler("get"),
+		Update:             stubHandler("update"),
+		Delete:             stubHandler("delete"),
+		Archive:            stubHandler("archive"),
+		Members:            stubHandler("members"),
+		Settings:           stubHandler("settings"),
+		Clone:              stubHandler("clone"),
+		Stats:              stubHandler("stats"),
+		Activity:           stubHandler("activity"),
+		Preferences:        stubHandler("preferences"),
+		Milestones:         stubHandler("milestones"),
+		Sprints:            stubHandler("sprints"),
+		Epics:              stubHandler("epics"),
+		Labels:             stubHandler("labels"),
+		Invitations:        stubHandler("invitations"),
+		Purge:              stubHandler("purge"),
+		ProjectExists:      (&usecase.GetProjectUsecase{Repo: repo}).Exists,
+	}))
+
+	tests := []struct {
+		workspace   string
+		path        string
+		wantStatus  int
+		wantHandler string
+	}{
+		{workspace: "ws-1", path: "/api/projects/proj-1/members", wantStatus: http.StatusOK, wantHandler: "members"},
+		{workspace: "ws-1", path: "/api/projects/proj-1/milestones:progress", wantStatus: http.StatusOK, wantHandler: "milestones"},
+		{workspace: "ws-2", path: "/api/projects/proj-1/members", wantStatus: http.StatusNotFound},
+		{workspace: "", path: "/api/projects/proj-1/milestones", wantStatus: http.StatusNotFound},
+		{workspace: "ws-1", path: "/api/projects/missing/members", wantStatus: http.StatusNotFound},
+		// プロジェクト自体の取得はリポジトリが絞り込むため、ここでは確認しない
+		{workspace: "ws-2", path: "/api/projects/proj-1", wantStatus: http.StatusOK, wantHandler: "get"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.workspace+" "+tt.path, func(t *testing.T) {
+			// ワークスペースはトークンを検証したリクエストのヘッダから取る
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil).WithContext(authz.ContextWithVerifiedIdentity(context.Background()))
+			if tt.workspace != "" {
+				req.Header.Set(workspace.Header, tt.workspace)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus || w.Header().Get("X-Handler") != tt.wantHandler {
+				t.Errorf("got status %d handler %q, want %d %q", w.Code, w.Header().Get("X-Handler"), tt.wantStatus, tt.wantHandler)
+			}
+		})
+	}
+}
+
+func TestRouteLabel(t *testing.T) {
+	for _, tt := range []struct {
+		path string
+		want string
+	}{
+		{path: "/api/projects", want: "/api/projects"},
+		{path: "/api/projects/p-1", want: "/api/projects/{id}"},
+		{path: "/api/projects/p-1/members/u-1", want: "/api/projects/{id}/members/{id}"},
+		{path: "/api/projects/p-1/sprints/s-1:start", want: "/api/projects/{id}/sprints/{id}:start"},
+		{path: "/api/projects/p-1/milestones:progress", want: "/api/projects/{id}/milestones:progress"},
+		{path: "/api/projects/p-1/import", want: "/api/projects/{id}/import"},
+		{path: "/api/projects/p-1:unknown", want: "/api/projects/{id}"},
+		{path: "/api/invitations/abc.def", want: "/api/invitations/{id}"},
+		{path: "/api/v1/projects/p-1/integrations/slack:test", want: "/api/v1/projects/{id}/integrations/slack:test"},
+		{path: "/api/v1/projects/p-1/sprints/s-1:start", want: "/api/v1/projects/{id}/sprints/{id}:start"},
+		{path: "/api/projects/p-1/milestones/v1", want: "/api/projects/{id}/milestones/{id}"},
+		{path: "/api/admin/projects:purge", want: "/api/admin/projects:purge"},
+		{path: "/healthz", want: "/healthz"},
+		{path: "/a/b/c/d/e/f/g", want: "other"},
+	} {
+		if got := httpiface.RouteLabel(tt.path); got != tt.want {
+			t.Errorf("RouteLabel(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"teamflow-shared/clock"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// SettingsHandler は GET / PUT /projects/{id}/settings を処理する HTTP ハンドラ。
+type SettingsHandler struct {
+	getUC    *usecase.GetSettingsUsecase
+	updateUC *usecase.UpdateSettingsUsecase
+	clock    clock.Clock
+}
+
+// NewSettingsHandler は SettingsHandler を生成する。
+func NewSettingsHandler(
+	getUC *usecase.GetSettingsUsecase,
+	updateUC *usecase.UpdateSettingsUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &SettingsHandler{
+		getUC:    getUC,
+		updateUC: updateUC,
+		clock:    clk,
+	}
+}
+
+// settingsRequest は PUT /projects/{id}/settings のリクエスト（設定全体を置き換える）。
+type settingsRequest struct {
+	DefaultPriority   string              `json:"defaultPriority"`
+	DefaultAssigneeID string              `json:"defaultAssigneeId"`
+	DefaultSort       string              `json:"defaultSort"`
+	WIPLimits         map[string]int      `json:"wipLimits"`
+	FieldLocks        map[string][]string `json:"fieldLocks"`
+	Timezone          string              `json:"timezone"`
+}
+
+// settingsResponse はプロジェクト設定のレスポンス。未設定の値は null を返す。
+type settingsResponse struct {
+	ProjectID         string              `json:"projectId"`
+	DefaultPriority   *string             `json:"defaultPriority"`
+	DefaultAssigneeID *string             `json:"defaultAssigneeId"`
+	DefaultSort       *string             `json:"defaultSort"`
+	WIPLimits         map[string]int      `json:"wipLimits"`
+	FieldLocks        map[string][]string `json:"fieldLocks"`
+	Timezone          *string             `json:"timezone"`
+	UpdatedAt         *time.Time          `json:"updatedAt"`
+}
+
+func toSettingsResponse(s *domain.Settings) settingsResponse {
+	optional := func(v string) *string {
+		if v == "" {
+			return nil
+		}
+		return &v
+	}
+	resp := settingsResponse{
+		ProjectID:         s.ProjectID,
+		DefaultPriority:   optional(s.DefaultPriority),
+		DefaultAssigneeID: optional(s.DefaultAssigneeID),
+		DefaultSort:       optional(s.DefaultSort),
+		WIPLimits:         s.WIPLimits,
+		FieldLocks:        make(map[string][]string, len(s.FieldLocks)),
+		Timezone:          optional(s.Timezone),
+	}
+	if resp.WIPLimits == nil {
+		resp.WIPLimits = map[string]int{}
+	}
+	for field, roles := range s.FieldLocks {
+		resp.FieldLocks[field] = make([]string, len(roles))
+		for i, r := range roles {
+			resp.FieldLocks[field][i] = string(r)
+		}
+	}
+	if !s.UpdatedAt.IsZero() {
+		updatedAt := s.UpdatedAt
+		resp.UpdatedAt = &updatedAt
+	}
+	return resp
+}
+
+// parseSettingsPath は /projects/{id}/settings から id を取り出す。
+func parseSettingsPath(path string) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/projects/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "settings" {
+		return "", false
+	}
+	return parts[0], true
+}
+
+// IsSettingsPath はパスが /projects/{id}/settings かどうかを返す。
+func IsSettingsPath(path string) bool {
+	_, ok := parseSettingsPath(path)
+	return ok
+}
+
+func (h *SettingsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseSettingsPath(r.URL.Path)
+	if !ok {
+		writeNotFound(w, "not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGet(w, r, projectID)
+	case http.MethodPut:
+		h.handleUpdate(w, r, projectID)
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+func (h *SettingsHandler) handleGet(w http.ResponseWriter, r *http.Request, projectID string) {
+	s, err := h.getUC.Execute(r.Context(), projectID)
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toSettingsResponse(s))
+}
+
+func (h *SettingsHandler) handleUpdate(w http.ResponseWriter, r *http.Request, projectID string) {
+	var req settingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	s, err := h.updateUC.Execute(r.Context(), usecase.UpdateSettingsInput{
+		ProjectID:         projectID,
+		DefaultPriority:   req.DefaultPriority,
+		DefaultAssigneeID: req.DefaultAssigneeID,
+		DefaultSort:       req.DefaultSort,
+		WIPLimits:         req.WIPLimits,
+		FieldLocks:        req.FieldLocks,
+		Timezone:          req.Timezone,
+		ActorID:           actorID(r),
+		Now:               h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toSettingsResponse(s))
+}
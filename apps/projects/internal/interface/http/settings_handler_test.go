@@ -0,0 +1,124 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+type settingsBody struct {
+	ProjectID         string              `json:"projectId"`
+	DefaultPriority   *string             `json:"defaultPriority"`
+	DefaultAssigneeID *string             `json:"defaultAssigneeId"`
+	WIPLimits         map[string]int      `json:"wipLimits"`
+	FieldLocks        map[string][]string `json:"fieldLocks"`
+	Timezone          *string             `json:"timezone"`
+}
+
+func newSettingsHandler(t *testing.T) http.Handler {
+	t.Helper()
+	projects, members := newRoleRepos(t)
+	settings := infra.NewMemorySettingsRepository()
+	return httpiface.NewSettingsHandler(
+		&usecase.GetSettingsUsecase{Projects: projects, Settings: settings},
+		&usecase.UpdateSettingsUsecase{Projects: projects, Members: members, Settings: settings, EnforceRoles: true},
+		fixedClock,
+	)
+}
+
+func doSettingsRequest(t *testing.T, handler http.Handler, method, path, actor string, body interface{}) (int, settingsBody) {
+	t.Helper()
+	var b []byte
+	if body != nil {
+		b, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(b))
+	if actor != "" {
+		req.Header.Set(httpiface.ActorHeader, actor)
+	}
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	var resp settingsBody
+	if w.Code == http.StatusOK {
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+	}
+	return w.Code, resp
+}
+
+func TestSettingsHandler_GetAndPut(t *testing.T) {
+	handler := newSettingsHandler(t)
+
+	status, got := doSettingsRequest(t, handler, http.MethodGet, "/projects/proj-1/settings", "", nil)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if got.DefaultPriority != nil || got.WIPLimits == nil || got.FieldLocks == nil || got.Timezone != nil {
+		t.Errorf("expected unset defaults with empty wipLimits and fieldLocks, got %+v", got)
+	}
+
+	body := map[string]interface{}{
+		"defaultPriority":   "high",
+		"defaultAssigneeId": "admin-1",
+		"wipLimits":         map[string]int{"in_progress": 3},
+		"fieldLocks":        map[string][]string{"priority": {"owner", "admin"}},
+		"timezone":          "Asia/Tokyo",
+	}
+	status, got = doSettingsRequest(t, handler, http.MethodPut, "/projects/proj-1/settings", "owner-1", body)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+
+	status, got = doSettingsRequest(t, handler, http.MethodGet, "/projects/proj-1/settings", "", nil)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if got.DefaultPriority == nil || *got.DefaultPriority != "high" || got.DefaultAssigneeID == nil || *got.DefaultAssigneeID != "admin-1" || got.WIPLimits["in_progress"] != 3 {
+		t.Errorf("unexpected settings: %+v", got)
+	}
+	if want := []string{"admin", "owner"}; !slices.Equal(got.FieldLocks["priority"], want) {
+		t.Errorf("fieldLocks.priority = %v, want %v", got.FieldLocks["priority"], want)
+	}
+	if got.Timezone == nil || *got.Timezone != "Asia/Tokyo" {
+		t.Errorf("timezone = %v, want Asia/Tokyo", got.Timezone)
+	}
+}
+
+func TestSettingsHandler_Errors(t *testing.T) {
+	handler := newSettingsHandler(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		actor  string
+		body   interface{}
+		want   int
+	}{
+		{name: "invalid priority", method: http.MethodPut, path: "/projects/proj-1/settings", actor: "owner-1", body: map[string]string{"defaultPriority": "urgent"}, want: http.StatusBadRequest},
+		{name: "invalid timezone", method: http.MethodPut, path: "/projects/proj-1/settings", actor: "owner-1", body: map[string]string{"timezone": "JST"}, want: http.StatusBadRequest},
+		{name: "assignee is not a member", method: http.MethodPut, path: "/projects/proj-1/settings", actor: "owner-1", body: map[string]string{"defaultAssigneeId": "stranger"}, want: http.StatusBadRequest},
+		{name: "no actor", method: http.MethodPut, path: "/projects/proj-1/settings", body: map[string]string{}, want: http.StatusUnauthorized},
+		{name: "non-member", method: http.MethodPut, path: "/projects/proj-1/settings", actor: "stranger", body: map[string]string{}, want: http.StatusForbidden},
+		{name: "unknown project", method: http.MethodGet, path: "/projects/proj-x/settings", want: http.StatusNotFound},
+		{name: "method not allowed", method: http.MethodPost, path: "/projects/proj-1/settings", want: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status, _ := doSettingsRequest(t, handler, tt.method, tt.path, tt.actor, tt.body); status != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, status)
+			}
+		})
+	}
+}
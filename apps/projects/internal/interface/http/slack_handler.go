@@ -0,0 +1,172 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"teamflow-shared/clock"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// SlackHandler は /projects/{id}/integrations/slack[:test] を処理する HTTP ハンドラ。
+type SlackHandler struct {
+	getUC    *usecase.GetSlackIntegrationUsecase
+	updateUC *usecase.UpdateSlackIntegrationUsecase
+	deleteUC *usecase.DeleteSlackIntegrationUsecase
+	testUC   *usecase.TestSlackIntegrationUsecase
+	clock    clock.Clock
+}
+
+// NewSlackHandler は SlackHandler を生成する。
+func NewSlackHandler(
+	getUC *usecase.GetSlackIntegrationUsecase,
+	updateUC *usecase.UpdateSlackIntegrationUsecase,
+	deleteUC *usecase.DeleteSlackIntegrationUsecase,
+	testUC *usecase.TestSlackIntegrationUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &SlackHandler{
+		getUC:    getUC,
+		updateUC: updateUC,
+		deleteUC: deleteUC,
+		testUC:   testUC,
+		clock:    clk,
+	}
+}
+
+// slackIntegrationRequest は PUT /projects/{id}/integrations/slack のリクエスト（設定全体を置き換える）。
+type slackIntegrationRequest struct {
+	WebhookURL string   `json:"webhookUrl"`
+	Events     []string `json:"events"`
+}
+
+// slackIntegrationResponse は Slack 連携の設定のレスポンス。webhookUrl は末尾以外を伏せて返す。
+type slackIntegrationResponse struct {
+	ProjectID  string    `json:"projectId"`
+	WebhookURL string    `json:"webhookUrl"`
+	Events     []string  `json:"events"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	UpdatedBy  *string   `json:"updatedBy"`
+}
+
+func toSlackIntegrationResponse(s *domain.SlackIntegration) slackIntegrationResponse {
+	resp := slackIntegrationResponse{
+		ProjectID:  s.ProjectID,
+		WebhookURL: s.MaskedWebhookURL(),
+		Events:     make([]string, len(s.Events)),
+		UpdatedAt:  s.UpdatedAt,
+	}
+	for i, e := range s.Events {
+		resp.Events[i] = string(e)
+	}
+	if s.UpdatedBy != "" {
+		updatedBy := s.UpdatedBy
+		resp.UpdatedBy = &updatedBy
+	}
+	return resp
+}
+
+// parseSlackPath は /projects/{id}/integrations/slack[:test] から id と :test かどうかを取り出す。
+func parseSlackPath(path string) (projectID string, test bool, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/projects/"), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] != "integrations" {
+		return "", false, false
+	}
+	switch parts[2] {
+	case "slack":
+		return parts[0], false, true
+	case "slack:test":
+		return parts[0], true, true
+	}
+	return "", false, false
+}
+
+// IsSlackPath はパスが /projects/{id}/integrations/slack[:test] かどうかを返す。
+func IsSlackPath(path string) bool {
+	_, _, ok := parseSlackPath(path)
+	return ok
+}
+
+// ServeHTTP は以下を処理する。
+// - GET    /projects/{id}/integrations/slack      : 設定の取得
+// - PUT    /projects/{id}/integrations/slack      : 設定の保存（置き換え）
+// - DELETE /projects/{id}/integrations/slack      : 設定の削除（通知を止める）
+// - POST   /projects/{id}/integrations/slack:test : 保存した Webhook へのテストメッセージの投稿
+func (h *SlackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	projectID, test, ok := parseSlackPath(r.URL.Path)
+	if !ok {
+		writeNotFound(w, "not found")
+		return
+	}
+
+	switch {
+	case test && r.Method == http.MethodPost:
+		h.handleTest(w, r, projectID)
+	case test:
+		writeMethodNotAllowed(w)
+	case r.Method == http.MethodGet:
+		h.handleGet(w, r, projectID)
+	case r.Method == http.MethodPut:
+		h.handleUpdate(w, r, projectID)
+	case r.Method == http.MethodDelete:
+		h.handleDelete(w, r, projectID)
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+func (h *SlackHandler) handleGet(w http.ResponseWriter, r *http.Request, projectID string) {
+	s, err := h.getUC.Execute(r.Context(), projectID)
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toSlackIntegrationResponse(s))
+}
+
+func (h *SlackHandler) handleUpdate(w http.ResponseWriter, r *http.Request, projectID string) {
+	var req slackIntegrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	s, err := h.updateUC.Execute(r.Context(), usecase.UpdateSlackIntegrationInput{
+		ProjectID:  projectID,
+		WebhookURL: req.WebhookURL,
+		Events:     req.Events,
+		ActorID:    actorID(r),
+		Now:        h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toSlackIntegrationResponse(s))
+}
+
+func (h *SlackHandler) handleDelete(w http.ResponseWriter, r *http.Request, projectID string) {
+	if err := h.deleteUC.Execute(r.Context(), projectID, actorID(r)); err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SlackHandler) handleTest(w http.ResponseWriter, r *http.Request, projectID string) {
+	if err := h.testUC.Execute(r.Context(), projectID, actorID(r)); err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
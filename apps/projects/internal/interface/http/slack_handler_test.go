@@ -0,0 +1,136 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// fakeSlackPoster は投稿したメッセージを記録する SlackPoster。err が設定されていれば失敗する。
+type fakeSlackPoster struct {
+	url, text string
+	err       error
+}
+
+func (p *fakeSlackPoster) PostSlackMessage(_ context.Context, webhookURL, text string) error {
+	p.url, p.text = webhookURL, text
+	return p.err
+}
+
+func newSlackHandler(t *testing.T) (http.Handler, *fakeSlackPoster) {
+	t.Helper()
+	projects, members := newRoleRepos(t)
+	integrations := infra.NewMemorySlackIntegrationRepository()
+	poster := &fakeSlackPoster{}
+	return httpiface.NewSlackHandler(
+		&usecase.GetSlackIntegrationUsecase{Projects: projects, Integrations: integrations},
+		&usecase.UpdateSlackIntegrationUsecase{Projects: projects, Members: members, Integrations: integrations, EnforceRoles: true},
+		&usecase.DeleteSlackIntegrationUsecase{Projects: projects, Members: members, Integrations: integrations, EnforceRoles: true},
+		&usecase.TestSlackIntegrationUsecase{Projects: projects, Members: members, Integrations: integrations, Poster: poster, EnforceRoles: true},
+		fixedClock,
+	), poster
+}
+
+func doSlackRequest(t *testing.T, handler http.Handler, method, path, actor string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	var b []byte
+	if body != nil {
+		b, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(b))
+	if actor != "" {
+		req.Header.Set(httpiface.ActorHeader, actor)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestSlackHandler(t *testing.T) {
+	handler, poster := newSlackHandler(t)
+	const path = "/projects/proj-1/integrations/slack"
+	const webhook = "https://hooks.slack.com/services/T0/B0/abcdWXYZ"
+
+	if w := doSlackRequest(t, handler, http.MethodGet, path, "", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 before saving, got %d", w.Code)
+	}
+	if w := doSlackRequest(t, handler, http.MethodPost, path+":test", "owner-1", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for a test message before saving, got %d", w.Code)
+	}
+
+	body := map[string]any{"webhookUrl": webhook, "events": []string{"task.assigned", "task.created"}}
+	if w := doSlackRequest(t, handler, http.MethodPut, path, "", body); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without an actor, got %d", w.Code)
+	}
+	if w := doSlackRequest(t, handler, http.MethodPut, path, "member-1", body); w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for a non-member, got %d", w.Code)
+	}
+	w := doSlackRequest(t, handler, http.MethodPut, path, "admin-1", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got struct {
+		WebhookURL string   `json:"webhookUrl"`
+		Events     []string `json:"events"`
+		UpdatedBy  *string  `json:"updatedBy"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.WebhookURL != "https://hooks.slack.com/****WXYZ" || strings.Join(got.Events, ",") != "task.created,task.assigned" ||
+		got.UpdatedBy == nil || *got.UpdatedBy != "admin-1" {
+		t.Errorf("unexpected response: %+v", got)
+	}
+	if w := doSlackRequest(t, handler, http.MethodGet, path, "", nil); w.Code != http.StatusOK || strings.Contains(w.Body.String(), "abcd") {
+		t.Errorf("expected the masked webhook url, got %d: %s", w.Code, w.Body)
+	}
+
+	if w := doSlackRequest(t, handler, http.MethodPost, path+":test", "owner-1", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body)
+	}
+	if poster.url != webhook || !strings.Contains(poster.text, "テストメッセージ") {
+		t.Errorf("unexpected test message to %q: %q", poster.url, poster.text)
+	}
+	poster.err = errors.New("slack webhook returned 404: no_service")
+	if w := doSlackRequest(t, handler, http.MethodPost, path+":test", "owner-1", nil); w.Code != http.StatusBadGateway || !strings.Contains(w.Body.String(), "no_service") {
+		t.Errorf("expected 502 with the slack error, got %d: %s", w.Code, w.Body)
+	}
+	if w := doSlackRequest(t, handler, http.MethodGet, path+":test", "owner-1", nil); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", w.Code)
+	}
+
+	if w := doSlackRequest(t, handler, http.MethodDelete, path, "owner-1", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+	if w := doSlackRequest(t, handler, http.MethodDelete, path, "owner-1", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 after deleting, got %d", w.Code)
+	}
+}
+
+func TestSlackHandler_Validation(t *testing.T) {
+	handler, _ := newSlackHandler(t)
+	for name, body := range map[string]map[string]any{
+		"missing url":     {},
+		"http url":        {"webhookUrl": "http://hooks.slack.com/services/T0"},
+		"disallowed host": {"webhookUrl": "https://169.254.169.254/latest"},
+		"unknown event":   {"webhookUrl": "https://hooks.slack.com/services/T0", "events": []string{"task.deleted"}},
+	} {
+		w := doSlackRequest(t, handler, http.MethodPut, "/projects/proj-1/integrations/slack", "owner-1", body)
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `"field":"slack"`) {
+			t.Errorf("%s: expected 400, got %d: %s", name, w.Code, w.Body)
+		}
+	}
+	if w := doSlackRequest(t, handler, http.MethodPut, "/projects/missing/integrations/slack", "owner-1",
+		map[string]any{"webhookUrl": "https://hooks.slack.com/services/T0"}); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a missing project, got %d", w.Code)
+	}
+}
@@ -0,0 +1,327 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"teamflow-shared/clock"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// SprintsHandler は /projects/{id}/sprints 以下を処理する HTTP ハンドラ。
+type SprintsHandler struct {
+	createUC   *usecase.CreateSprintUsecase
+	updateUC   *usecase.UpdateSprintUsecase
+	deleteUC   *usecase.DeleteSprintUsecase
+	listUC     *usecase.ListSprintsUsecase
+	getUC      *usecase.GetSprintUsecase
+	startUC    *usecase.StartSprintUsecase
+	completeUC *usecase.CompleteSprintUsecase
+	clock      clock.Clock
+}
+
+// NewSprintsHandler は SprintsHandler を生成する。
+func NewSprintsHandler(
+	createUC *usecase.CreateSprintUsecase,
+	updateUC *usecase.UpdateSprintUsecase,
+	deleteUC *usecase.DeleteSprintUsecase,
+	listUC *usecase.ListSprintsUsecase,
+	getUC *usecase.GetSprintUsecase,
+	startUC *usecase.StartSprintUsecase,
+	completeUC *usecase.CompleteSprintUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &SprintsHandler{
+		createUC:   createUC,
+		updateUC:   updateUC,
+		deleteUC:   deleteUC,
+		listUC:     listUC,
+		getUC:      getUC,
+		startUC:    startUC,
+		completeUC: completeUC,
+		clock:      clk,
+	}
+}
+
+// sprintRequest は POST / PUT のリクエスト。PUT では id を無視し、名前・ゴール・期間を置き換える。
+type sprintRequest struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Goal      string    `json:"goal"`
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+}
+
+// completeSprintRequest は POST /projects/{id}/sprints/{sprintId}:complete のリクエスト（ボディは省略可）。
+type completeSprintRequest struct {
+	// NextSprintID は未完了タスクの持ち越し先。省略時は次の計画中のスプリント（無ければバックログ）
+	NextSprintID string `json:"nextSprintId"`
+}
+
+type sprintResponse struct {
+	ID          string     `json:"id"`
+	ProjectID   string     `json:"projectId"`
+	Name        string     `json:"name"`
+	Goal        string     `json:"goal"`
+	StartDate   time.Time  `json:"startDate"`
+	EndDate     time.Time  `json:"endDate"`
+	State       string     `json:"state"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	CompletedAt *time.Time `json:"completedAt"`
+}
+
+type listSprintsResponse struct {
+	Sprints []sprintResponse `json:"sprints"`
+}
+
+// completeSprintResponse は :complete のレスポンス。
+type completeSprintResponse struct {
+	Sprint       sprintResponse `json:"sprint"`
+	NextSprintID *string        `json:"nextSprintId"` // null はバックログ
+	CarriedOver  int            `json:"carriedOver"`  // 持ち越したタスクの件数
+}
+
+func toSprintResponse(s *domain.Sprint) sprintResponse {
+	return sprintResponse{
+		ID:          s.ID,
+		ProjectID:   s.ProjectID,
+		Name:        s.Name,
+		Goal:        s.Goal,
+		StartDate:   s.StartDate,
+		EndDate:     s.EndDate,
+		State:       string(s.State),
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+		CompletedAt: s.CompletedAt,
+	}
+}
+
+// parseSprintsPath は /projects/{id}/sprints[/{sprintId}[:start|:complete]] から
+// projectID・sprintID・カスタムメソッド名（start / complete、無ければ空）を取り出す。
+func parseSprintsPath(path string) (projectID, sprintID, action string, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/projects/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] != "sprints" {
+		return "", "", "", false
+	}
+	if len(parts) == 2 {
+		return parts[0], "", "", true
+	}
+
+	sprintID, action, _ = strings.Cut(parts[2], ":")
+	if sprintID == "" {
+		return "", "", "", false
+	}
+	switch action {
+	case "", "start", "complete":
+		return parts[0], sprintID, action, true
+	default:
+		return "", "", "", false
+	}
+}
+
+// IsSprintsPath はパスが /projects/{id}/sprints 以下（:start / :complete を含む）かどうかを返す。
+func IsSprintsPath(path string) bool {
+	_, _, _, ok := parseSprintsPath(path)
+	return ok
+}
+
+// ServeHTTP は以下を処理する。
+// - GET    /projects/{id}/sprints                     : スプリント一覧
+// - POST   /projects/{id}/sprints                     : スプリント作成（計画中）
+// - GET    /projects/{id}/sprints/{sprintId}          : スプリント取得（tasks サービスの存在チェック用）
+// - PUT    /projects/{id}/sprints/{sprintId}          : スプリント更新
+// - DELETE /projects/{id}/sprints/{sprintId}          : スプリント削除
+// - POST   /projects/{id}/sprints/{sprintId}:start    : スプリント開始
+// - POST   /projects/{id}/sprints/{sprintId}:complete : スプリント完了（未完了タスクを持ち越す）
+func (h *SprintsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	projectID, sprintID, action, ok := parseSprintsPath(r.URL.Path)
+	if !ok {
+		writeNotFound(w, "not found")
+		return
+	}
+
+	switch {
+	case action != "":
+		if r.Method != http.MethodPost {
+			writeMethodNotAllowed(w)
+			return
+		}
+		if action == "start" {
+			h.handleStart(w, r, projectID, sprintID)
+		} else {
+			h.handleComplete(w, r, projectID, sprintID)
+		}
+	case sprintID == "":
+		switch r.Method {
+		case http.MethodGet:
+			h.handleList(w, r, projectID)
+		case http.MethodPost:
+			h.handleCreate(w, r, projectID)
+		default:
+			writeMethodNotAllowed(w)
+		}
+	default:
+		switch r.Method {
+		case http.MethodGet:
+			h.handleGet(w, r, projectID, sprintID)
+		case http.MethodPut:
+			h.handleUpdate(w, r, projectID, sprintID)
+		case http.MethodDelete:
+			h.handleDelete(w, r, projectID, sprintID)
+		default:
+			writeMethodNotAllowed(w)
+		}
+	}
+}
+
+func (h *SprintsHandler) handleList(w http.ResponseWriter, r *http.Request, projectID string) {
+	sprints, err := h.listUC.Execute(r.Context(), projectID, actorID(r))
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := listSprintsResponse{Sprints: make([]sprintResponse, 0, len(sprints))}
+	for _, s := range sprints {
+		resp.Sprints = append(resp.Sprints, toSprintResponse(s))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *SprintsHandler) handleCreate(w http.ResponseWriter, r *http.Request, projectID string) {
+	var req sprintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	s, err := h.createUC.Execute(r.Context(), usecase.CreateSprintInput{
+		ProjectID: projectID,
+		ID:        req.ID,
+		Name:      req.Name,
+		Goal:      req.Goal,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		ActorID:   actorID(r),
+		Now:       h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(toSprintResponse(s))
+}
+
+func (h *SprintsHandler) handleGet(w http.ResponseWriter, r *http.Request, projectID, sprintID string) {
+	s, err := h.getUC.Execute(r.Context(), projectID, sprintID)
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toSprintResponse(s))
+}
+
+func (h *SprintsHandler) handleUpdate(w http.ResponseWriter, r *http.Request, projectID, sprintID string) {
+	var req sprintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	s, err := h.updateUC.Execute(r.Context(), usecase.UpdateSprintInput{
+		ProjectID: projectID,
+		ID:        sprintID,
+		Name:      req.Name,
+		Goal:      req.Goal,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		ActorID:   actorID(r),
+		Now:       h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toSprintResponse(s))
+}
+
+func (h *SprintsHandler) handleDelete(w http.ResponseWriter, r *http.Request, projectID, sprintID string) {
+	err := h.deleteUC.Execute(r.Context(), usecase.DeleteSprintInput{
+		ProjectID: projectID,
+		ID:        sprintID,
+		ActorID:   actorID(r),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SprintsHandler) handleStart(w http.ResponseWriter, r *http.Request, projectID, sprintID string) {
+	s, err := h.startUC.Execute(r.Context(), usecase.StartSprintInput{
+		ProjectID: projectID,
+		ID:        sprintID,
+		ActorID:   actorID(r),
+		Now:       h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toSprintResponse(s))
+}
+
+func (h *SprintsHandler) handleComplete(w http.ResponseWriter, r *http.Request, projectID, sprintID string) {
+	var req completeSprintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeInvalidJSON(w)
+		return
+	}
+
+	result, err := h.completeUC.Execute(r.Context(), usecase.CompleteSprintInput{
+		ProjectID:    projectID,
+		ID:           sprintID,
+		NextSprintID: req.NextSprintID,
+		ActorID:      actorID(r),
+		Now:          h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := completeSprintResponse{
+		Sprint:      toSprintResponse(result.Sprint),
+		CarriedOver: result.CarriedOver,
+	}
+	if result.NextSprintID != "" {
+		resp.NextSprintID = &result.NextSprintID
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
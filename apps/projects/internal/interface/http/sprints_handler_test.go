@@ -0,0 +1,185 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// sprintCarrierStub は SprintTaskCarrier のスタブ。常に count 件を持ち越したとして返す。
+type sprintCarrierStub struct {
+	count int
+	to    string
+}
+
+func (s *sprintCarrierStub) CarryOverTasks(_ context.Context, _, _, to string) (int, error) {
+	s.to = to
+	return s.count, nil
+}
+
+func newSprintsHandler(t *testing.T, tasks usecase.SprintTaskCarrier) http.Handler {
+	t.Helper()
+	projects := infra.NewMemoryProjectRepository()
+	seedProject(projects, "proj-1")
+	sprints := infra.NewMemorySprintRepository()
+
+	return httpiface.NewSprintsHandler(
+		&usecase.CreateSprintUsecase{Projects: projects, Sprints: sprints},
+		&usecase.UpdateSprintUsecase{Sprints: sprints},
+		&usecase.DeleteSprintUsecase{Sprints: sprints},
+		&usecase.ListSprintsUsecase{Projects: projects, Sprints: sprints},
+		&usecase.GetSprintUsecase{Sprints: sprints},
+		&usecase.StartSprintUsecase{Sprints: sprints},
+		&usecase.CompleteSprintUsecase{Sprints: sprints, Tasks: tasks},
+		fixedClock,
+	)
+}
+
+type sprintBody struct {
+	ID          string  `json:"id"`
+	ProjectID   string  `json:"projectId"`
+	Name        string  `json:"name"`
+	Goal        string  `json:"goal"`
+	StartDate   string  `json:"startDate"`
+	EndDate     string  `json:"endDate"`
+	State       string  `json:"state"`
+	CompletedAt *string `json:"completedAt"`
+}
+
+func TestSprintsHandler_Lifecycle(t *testing.T) {
+	carrier := &sprintCarrierStub{count: 2}
+	handler := newSprintsHandler(t, carrier)
+
+	for _, body := range []map[string]any{
+		{"id": "s1", "name": "Sprint 1", "goal": "ログイン", "startDate": "2025-01-06T00:00:00Z", "endDate": "2025-01-17T00:00:00Z"},
+		{"id": "s2", "name": "Sprint 2", "startDate": "2025-01-20T00:00:00Z", "endDate": "2025-01-31T00:00:00Z"},
+	} {
+		if w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/sprints", body); w.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+	if w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/sprints", map[string]any{"id": "s1", "name": "dup", "startDate": "2025-01-06T00:00:00Z", "endDate": "2025-01-17T00:00:00Z"}); w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for duplicate sprint, got %d", w.Code)
+	}
+
+	w := doMembersRequest(handler, http.MethodGet, "/projects/proj-1/sprints/s1", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var got sprintBody
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.ID != "s1" || got.ProjectID != "proj-1" || got.Goal != "ログイン" || got.State != "planned" || got.CompletedAt != nil {
+		t.Errorf("unexpected sprint: %+v", got)
+	}
+
+	if w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/sprints/s1:start", nil); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/sprints/s2:start", nil); w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 while another sprint is active, got %d", w.Code)
+	}
+	if w := doMembersRequest(handler, http.MethodDelete, "/projects/proj-1/sprints/s1", nil); w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for deleting active sprint, got %d", w.Code)
+	}
+
+	w = doMembersRequest(handler, http.MethodPost, "/projects/proj-1/sprints/s1:complete", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var completed struct {
+		Sprint       sprintBody `json:"sprint"`
+		NextSprintID *string    `json:"nextSprintId"`
+		CarriedOver  int        `json:"carriedOver"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&completed); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if completed.Sprint.State != "completed" || completed.Sprint.CompletedAt == nil || completed.CarriedOver != 2 {
+		t.Errorf("unexpected result: %+v", completed)
+	}
+	if completed.NextSprintID == nil || *completed.NextSprintID != "s2" || carrier.to != "s2" {
+		t.Errorf("expected carry over to s2, got %v (carrier=%q)", completed.NextSprintID, carrier.to)
+	}
+
+	// 完了済みは更新できない
+	if w := doMembersRequest(handler, http.MethodPut, "/projects/proj-1/sprints/s1", map[string]any{"name": "x", "startDate": "2025-01-06T00:00:00Z", "endDate": "2025-01-17T00:00:00Z"}); w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for updating completed sprint, got %d", w.Code)
+	}
+
+	w = doMembersRequest(handler, http.MethodGet, "/projects/proj-1/sprints", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var list struct {
+		Sprints []sprintBody `json:"sprints"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(list.Sprints) != 2 || list.Sprints[0].ID != "s1" || list.Sprints[1].ID != "s2" {
+		t.Errorf("unexpected list: %+v", list.Sprints)
+	}
+
+	if w := doMembersRequest(handler, http.MethodDelete, "/projects/proj-1/sprints/s1", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", w.Code)
+	}
+	if w := doMembersRequest(handler, http.MethodGet, "/projects/proj-1/sprints/s1", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 after delete, got %d", w.Code)
+	}
+}
+
+func TestSprintsHandler_Errors(t *testing.T) {
+	valid := map[string]any{"id": "s1", "name": "s1", "startDate": "2025-01-06T00:00:00Z", "endDate": "2025-01-17T00:00:00Z"}
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       any
+		wantStatus int
+	}{
+		{name: "empty name", method: http.MethodPost, path: "/projects/proj-1/sprints", body: map[string]any{"id": "s1", "startDate": "2025-01-06T00:00:00Z", "endDate": "2025-01-17T00:00:00Z"}, wantStatus: http.StatusBadRequest},
+		{name: "end before start", method: http.MethodPost, path: "/projects/proj-1/sprints", body: map[string]any{"id": "s1", "name": "s1", "startDate": "2025-01-17T00:00:00Z", "endDate": "2025-01-06T00:00:00Z"}, wantStatus: http.StatusBadRequest},
+		{name: "missing dates", method: http.MethodPost, path: "/projects/proj-1/sprints", body: map[string]any{"id": "s1", "name": "s1"}, wantStatus: http.StatusBadRequest},
+		{name: "project not found", method: http.MethodPost, path: "/projects/missing/sprints", body: valid, wantStatus: http.StatusNotFound},
+		{name: "start not found", method: http.MethodPost, path: "/projects/proj-1/sprints/missing:start", wantStatus: http.StatusNotFound},
+		{name: "complete planned sprint", method: http.MethodPost, path: "/projects/proj-1/sprints/s0:complete", wantStatus: http.StatusConflict},
+		{name: "invalid complete body", method: http.MethodPost, path: "/projects/proj-1/sprints/s0:complete", body: "x", wantStatus: http.StatusBadRequest},
+		{name: "method not allowed", method: http.MethodPatch, path: "/projects/proj-1/sprints", wantStatus: http.StatusMethodNotAllowed},
+		{name: "start method not allowed", method: http.MethodGet, path: "/projects/proj-1/sprints/s0:start", wantStatus: http.StatusMethodNotAllowed},
+		{name: "unknown action", method: http.MethodPost, path: "/projects/proj-1/sprints/s0:close", wantStatus: http.StatusNotFound},
+		{name: "nested path", method: http.MethodGet, path: "/projects/proj-1/sprints/s0/tasks", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newSprintsHandler(t, &sprintCarrierStub{})
+			s0 := map[string]any{"id": "s0", "name": "s0", "startDate": "2025-01-01T00:00:00Z", "endDate": "2025-01-05T00:00:00Z"}
+			if w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/sprints", s0); w.Code != http.StatusCreated {
+				t.Fatalf("expected status 201, got %d", w.Code)
+			}
+			if w := doMembersRequest(handler, tt.method, tt.path, tt.body); w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestSprintsHandler_CompleteWithoutTasksService(t *testing.T) {
+	handler := newSprintsHandler(t, nil)
+	if w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/sprints", map[string]any{"id": "s1", "name": "s1", "startDate": "2025-01-06T00:00:00Z", "endDate": "2025-01-17T00:00:00Z"}); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", w.Code)
+	}
+	if w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/sprints/s1:start", nil); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if w := doMembersRequest(handler, http.MethodPost, "/projects/proj-1/sprints/s1:complete", map[string]any{}); w.Code != http.StatusBadGateway {
+		t.Errorf("expected status 502, got %d", w.Code)
+	}
+}
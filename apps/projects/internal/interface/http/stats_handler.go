@@ -0,0 +1,72 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// StatsHandler は GET /projects/{id}/stats を処理する HTTP ハンドラ。
+// ダッシュボードのプロジェクトカード用に、タスクの集計を 1 回の呼び出しで返す。
+type StatsHandler struct {
+	statsUC *usecase.GetStatsUsecase
+}
+
+// NewStatsHandler は StatsHandler を生成する。
+func NewStatsHandler(statsUC *usecase.GetStatsUsecase) http.Handler {
+	return &StatsHandler{statsUC: statsUC}
+}
+
+type statsResponse struct {
+	ProjectID      string     `json:"projectId"`
+	Open           int        `json:"open"`
+	Done           int        `json:"done"`
+	Overdue        int        `json:"overdue"`
+	LastActivityAt *time.Time `json:"lastActivityAt"`
+}
+
+// parseStatsPath は /projects/{id}/stats から id を取り出す。
+func parseStatsPath(path string) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/projects/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "stats" {
+		return "", false
+	}
+	return parts[0], true
+}
+
+// IsStatsPath はパスが /projects/{id}/stats かどうかを返す。
+func IsStatsPath(path string) bool {
+	_, ok := parseStatsPath(path)
+	return ok
+}
+
+func (h *StatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseStatsPath(r.URL.Path)
+	if !ok {
+		writeNotFound(w, "not found")
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	s, err := h.statsUC.Execute(r.Context(), projectID, actorID(r))
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(statsResponse{
+		ProjectID:      s.ProjectID,
+		Open:           s.Open,
+		Done:           s.Done,
+		Overdue:        s.Overdue,
+		LastActivityAt: s.LastActivityAt,
+	})
+}
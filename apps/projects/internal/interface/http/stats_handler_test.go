@@ -0,0 +1,77 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "teamflow-projects/internal/domain/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// stubStatsProvider は StatsProvider のテスト用スタブ。
+type stubStatsProvider struct {
+	err error
+}
+
+func (s *stubStatsProvider) ProjectStats(_ context.Context, projectID string) (*domain.Stats, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	last := fixedNow()
+	return &domain.Stats{ProjectID: projectID, Open: 4, Done: 6, Overdue: 1, LastActivityAt: &last}, nil
+}
+
+func TestStatsHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		statsErr   error
+		wantStatus int
+	}{
+		{name: "success", method: http.MethodGet, path: "/projects/proj-1/stats", wantStatus: http.StatusOK},
+		{name: "project not found", method: http.MethodGet, path: "/projects/proj-x/stats", wantStatus: http.StatusNotFound},
+		{name: "tasks service fails", method: http.MethodGet, path: "/projects/proj-1/stats", statsErr: errors.New("unavailable"), wantStatus: http.StatusBadGateway},
+		{name: "method not allowed", method: http.MethodPost, path: "/projects/proj-1/stats", wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projects, _ := newRoleRepos(t)
+			handler := httpiface.NewStatsHandler(&usecase.GetStatsUsecase{
+				Projects: projects,
+				Stats:    &stubStatsProvider{err: tt.statsErr},
+			})
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			var got struct {
+				ProjectID      string  `json:"projectId"`
+				Open           int     `json:"open"`
+				Done           int     `json:"done"`
+				Overdue        int     `json:"overdue"`
+				LastActivityAt *string `json:"lastActivityAt"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.ProjectID != "proj-1" || got.Open != 4 || got.Done != 6 || got.Overdue != 1 || got.LastActivityAt == nil {
+				t.Errorf("unexpected response: %+v", got)
+			}
+		})
+	}
+}
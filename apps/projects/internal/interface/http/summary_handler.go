@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// SummaryHandler は GET /projects/{id}/summary を処理する HTTP ハンドラ。
+// ダッシュボード向けに、タスクの集計とマイルストーン・エピック・ラベルごとの集計を 1 回の呼び出しで返す。
+type SummaryHandler struct {
+	summaryUC *usecase.GetSummaryUsecase
+}
+
+// NewSummaryHandler は SummaryHandler を生成する。
+func NewSummaryHandler(summaryUC *usecase.GetSummaryUsecase) http.Handler {
+	return &SummaryHandler{summaryUC: summaryUC}
+}
+
+type summaryResponse struct {
+	ProjectID  string                      `json:"projectId"`
+	Stats      statsResponse               `json:"stats"`
+	Milestones []milestoneProgressResponse `json:"milestones"`
+	Epics      []epicProgressResponse      `json:"epics"`
+	Labels     []labelWithUsageResponse    `json:"labels"`
+}
+
+// parseSummaryPath は /projects/{id}/summary から id を取り出す。
+func parseSummaryPath(path string) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/projects/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "summary" {
+		return "", false
+	}
+	return parts[0], true
+}
+
+// IsSummaryPath はパスが /projects/{id}/summary かどうかを返す。
+func IsSummaryPath(path string) bool {
+	_, ok := parseSummaryPath(path)
+	return ok
+}
+
+func (h *SummaryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseSummaryPath(r.URL.Path)
+	if !ok {
+		writeNotFound(w, "not found")
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	s, err := h.summaryUC.Execute(r.Context(), projectID, actorID(r))
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(summaryResponse{
+		ProjectID: projectID,
+		Stats: statsResponse{
+			ProjectID:      s.Stats.ProjectID,
+			Open:           s.Stats.Open,
+			Done:           s.Stats.Done,
+			Overdue:        s.Stats.Overdue,
+			LastActivityAt: s.Stats.LastActivityAt,
+		},
+		Milestones: toMilestoneProgressResponses(s.Milestones),
+		Epics:      toEpicProgressResponses(s.Epics),
+		Labels:     toLabelUsageResponses(s.Labels),
+	})
+}
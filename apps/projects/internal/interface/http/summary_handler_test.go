@@ -0,0 +1,138 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "teamflow-projects/internal/domain/project"
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// summaryStatsStub は SummaryStatsProvider のスタブ。
+type summaryStatsStub struct {
+	*stubStatsProvider
+	milestoneStatsStub
+	epicStatsStub
+	labelStatsStub
+}
+
+func newSummaryHandler(t *testing.T, statsErr error) http.Handler {
+	t.Helper()
+	ctx := context.Background()
+	projects := infra.NewMemoryProjectRepository()
+	seedProject(projects, "proj-1")
+	milestones := infra.NewMemoryMilestoneRepository()
+	epics := infra.NewMemoryEpicRepository()
+	labels := infra.NewMemoryLabelRepository()
+
+	now := fixedNow()
+	m, err := domain.NewMilestone("v1", "proj-1", "v1.0", nil, "", now)
+	if err != nil {
+		t.Fatalf("failed to create milestone: %v", err)
+	}
+	e, err := domain.NewEpic("e1", "proj-1", "SSO", "", "", now)
+	if err != nil {
+		t.Fatalf("failed to create epic: %v", err)
+	}
+	l, err := domain.NewLabel("l1", "proj-1", "bug", "#ff0000", now)
+	if err != nil {
+		t.Fatalf("failed to create label: %v", err)
+	}
+	if err := milestones.SaveMilestone(ctx, m); err != nil {
+		t.Fatalf("failed to save milestone: %v", err)
+	}
+	if err := epics.SaveEpic(ctx, e); err != nil {
+		t.Fatalf("failed to save epic: %v", err)
+	}
+	if err := labels.SaveLabel(ctx, l); err != nil {
+		t.Fatalf("failed to save label: %v", err)
+	}
+
+	return httpiface.NewSummaryHandler(&usecase.GetSummaryUsecase{
+		Projects:   projects,
+		Milestones: milestones,
+		Epics:      epics,
+		Labels:     labels,
+		Stats: summaryStatsStub{
+			stubStatsProvider:  &stubStatsProvider{err: statsErr},
+			milestoneStatsStub: milestoneStatsStub{"v1": {Open: 2, Done: 1}},
+			epicStatsStub:      epicStatsStub{"e1": {Total: 3, Done: 1, EstimateTotal: 8, EstimateDone: 3}},
+			labelStatsStub:     labelStatsStub{"l1": 5},
+		},
+	})
+}
+
+func TestSummaryHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		statsErr   error
+		wantStatus int
+	}{
+		{name: "success", method: http.MethodGet, path: "/projects/proj-1/summary", wantStatus: http.StatusOK},
+		{name: "project not found", method: http.MethodGet, path: "/projects/proj-x/summary", wantStatus: http.StatusNotFound},
+		{name: "tasks service fails", method: http.MethodGet, path: "/projects/proj-1/summary", statsErr: errors.New("unavailable"), wantStatus: http.StatusBadGateway},
+		{name: "method not allowed", method: http.MethodPost, path: "/projects/proj-1/summary", wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newSummaryHandler(t, tt.statsErr)
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			var got struct {
+				ProjectID string `json:"projectId"`
+				Stats     struct {
+					Open int `json:"open"`
+					Done int `json:"done"`
+				} `json:"stats"`
+				Milestones []struct {
+					ID   string `json:"id"`
+					Open int    `json:"open"`
+					Done int    `json:"done"`
+				} `json:"milestones"`
+				Epics []struct {
+					ID           string `json:"id"`
+					Total        int    `json:"total"`
+					EstimateDone int    `json:"estimateDone"`
+				} `json:"epics"`
+				Labels []struct {
+					ID         string `json:"id"`
+					UsageCount int    `json:"usageCount"`
+				} `json:"labels"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.ProjectID != "proj-1" || got.Stats.Open != 4 || got.Stats.Done != 6 {
+				t.Errorf("unexpected response: %+v", got)
+			}
+			if len(got.Milestones) != 1 || got.Milestones[0].ID != "v1" || got.Milestones[0].Open != 2 {
+				t.Errorf("unexpected milestones: %+v", got.Milestones)
+			}
+			if len(got.Epics) != 1 || got.Epics[0].Total != 3 || got.Epics[0].EstimateDone != 3 {
+				t.Errorf("unexpected epics: %+v", got.Epics)
+			}
+			if len(got.Labels) != 1 || got.Labels[0].UsageCount != 5 {
+				t.Errorf("unexpected labels: %+v", got.Labels)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"teamflow-shared/clock"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// TemplatesHandler は GET / POST /templates（プロジェクトテンプレート）を処理する HTTP ハンドラ。
+type TemplatesHandler struct {
+	createUC *usecase.CreateTemplateUsecase
+	listUC   *usecase.ListTemplatesUsecase
+	clock    clock.Clock
+}
+
+// NewTemplatesHandler は TemplatesHandler を生成する。
+func NewTemplatesHandler(
+	createUC *usecase.CreateTemplateUsecase,
+	listUC *usecase.ListTemplatesUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &TemplatesHandler{
+		createUC: createUC,
+		listUC:   listUC,
+		clock:    clk,
+	}
+}
+
+type taskBlueprintJSON struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	Priority    string `json:"priority,omitempty"`
+}
+
+type createTemplateRequest struct {
+	ID          string              `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Tasks       []taskBlueprintJSON `json:"tasks"`
+}
+
+type templateResponse struct {
+	ID          string              `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Tasks       []taskBlueprintJSON `json:"tasks"`
+	CreatedAt   time.Time           `json:"createdAt"`
+	UpdatedAt   time.Time           `json:"updatedAt"`
+}
+
+type listTemplatesResponse struct {
+	Templates []templateResponse `json:"templates"`
+}
+
+func toTemplateResponse(t *domain.Template) templateResponse {
+	tasks := make([]taskBlueprintJSON, len(t.Tasks))
+	for i, bp := range t.Tasks {
+		tasks[i] = taskBlueprintJSON(bp)
+	}
+	return templateResponse{
+		ID:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+		Tasks:       tasks,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+// ServeHTTP は /templates を処理する。
+// - POST: テンプレート作成
+// - GET : テンプレート一覧取得
+func (h *TemplatesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.handleCreate(w, r)
+	case http.MethodGet:
+		h.handleList(w, r)
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+func (h *TemplatesHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req createTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	tasks := make([]domain.TaskBlueprint, len(req.Tasks))
+	for i, bp := range req.Tasks {
+		tasks[i] = domain.TaskBlueprint(bp)
+	}
+
+	t, err := h.createUC.Execute(r.Context(), usecase.CreateTemplateInput{
+		ID:          req.ID,
+		Name:        req.Name,
+		Description: req.Description,
+		Tasks:       tasks,
+		ActorID:     actorID(r),
+		Now:         h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(toTemplateResponse(t))
+}
+
+func (h *TemplatesHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.listUC.Execute(r.Context())
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := listTemplatesResponse{Templates: make([]templateResponse, 0, len(templates))}
+	for _, t := range templates {
+		resp.Templates = append(resp.Templates, toTemplateResponse(t))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,65 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	infra "teamflow-projects/internal/infrastructure/project"
+	httpiface "teamflow-projects/internal/interface/http"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+func TestTemplatesHandler(t *testing.T) {
+	templates := infra.NewMemoryTemplateRepository()
+	handler := httpiface.NewTemplatesHandler(
+		&usecase.CreateTemplateUsecase{Templates: templates},
+		&usecase.ListTemplatesUsecase{Templates: templates},
+		fixedClock,
+	)
+
+	post := func(body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/templates", bytes.NewReader([]byte(body)))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := post(`{"id":"tmpl-1","name":"スプリント","tasks":[{"title":"計画","priority":"high"},{"title":"振り返り"}]}`); code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", code)
+	}
+	if code := post(`{"id":"tmpl-1","name":"duplicate"}`); code != http.StatusConflict {
+		t.Errorf("expected status 409 for duplicate id, got %d", code)
+	}
+	if code := post(`{"id":"tmpl-2","name":"invalid","tasks":[{"title":""}]}`); code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid task, got %d", code)
+	}
+	if code := post(`{invalid`); code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid json, got %d", code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/templates", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var resp struct {
+		Templates []struct {
+			ID    string `json:"id"`
+			Tasks []struct {
+				Title  string `json:"title"`
+				Status string `json:"status"`
+			} `json:"tasks"`
+		} `json:"templates"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Templates) != 1 || len(resp.Templates[0].Tasks) != 2 || resp.Templates[0].Tasks[1].Status != "todo" {
+		t.Errorf("unexpected templates: %+v", resp.Templates)
+	}
+}
@@ -2,88 +2,86 @@ package http
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strings"
-	"time"
 
-	infra "teamflow-projects/internal/infrastructure/project"
+	"teamflow-shared/clock"
+
 	usecase "teamflow-projects/internal/usecase/project"
 )
 
 type updateProjectRequest struct {
+	Key         string `json:"key"` // 省略時は変更しない
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	Status      string `json:"status"`     // 省略時は変更しない
+	Visibility  string `json:"visibility"` // 省略時は変更しない
 }
 
 // UpdateProjectHandler は PUT /projects/{id} を処理する HTTP ハンドラ。
 type UpdateProjectHandler struct {
 	updateUC *usecase.UpdateProjectUsecase
-	nowFunc  func() time.Time
+	clock    clock.Clock
 }
 
 // NewUpdateProjectHandler は UpdateProjectHandler を生成する。
-func NewUpdateProjectHandler(updateUC *usecase.UpdateProjectUsecase, nowFunc func() time.Time) http.Handler {
+func NewUpdateProjectHandler(updateUC *usecase.UpdateProjectUsecase, clk clock.Clock) http.Handler {
 	return &UpdateProjectHandler{
 		updateUC: updateUC,
-		nowFunc:  nowFunc,
+		clock:    clk,
 	}
 }
 
 func (h *UpdateProjectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPut {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		writeMethodNotAllowed(w)
 		return
 	}
 
 	// パスから /projects/{id} の {id} 部分を取り出す
 	path := strings.TrimPrefix(r.URL.Path, "/projects/")
 	if path == "" || strings.Contains(path, "/") {
-		w.WriteHeader(http.StatusBadRequest)
+		writeInvalidPath(w, "id")
 		return
 	}
 	id := path
 
 	var req updateProjectRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		writeInvalidJSON(w)
 		return
 	}
 
 	in := usecase.UpdateProjectInput{
 		ID:          id,
+		Key:         req.Key,
 		Name:        req.Name,
 		Description: req.Description,
-		Now:         h.nowFunc(),
+		Status:      req.Status,
+		Visibility:  req.Visibility,
+		ActorID:     actorID(r),
+		Now:         h.clock.Now(),
 	}
 
 	p, err := h.updateUC.Execute(r.Context(), in)
 	if err != nil {
-		// name 空などのバリデーションエラー
-		if errors.Is(err, infra.ErrProjectNotFound) {
-			w.WriteHeader(http.StatusNotFound)
-			return
-		}
-
-		// UpdateProjectUsecase 側では name 空の場合は errors.New("project name must not be empty")
-		// としているので、それっぽい文言なら 400 にする。
-		if strings.Contains(err.Error(), "must not be empty") {
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		}
-
-		// その他は内部エラー
-		w.WriteHeader(http.StatusInternalServerError)
+		writeUsecaseError(w, err)
 		return
 	}
 
 	// ここがポイント：createProjectResponse ではなく projectResponse を使う
 	resp := projectResponse{
 		ID:          p.ID,
+		Key:         p.Key,
 		Name:        p.Name,
 		Description: p.Description,
+		Status:      string(p.Status),
+		Visibility:  string(p.Visibility),
 		CreatedAt:   p.CreatedAt,
 		UpdatedAt:   p.UpdatedAt,
+		ArchivedAt:  p.ArchivedAt,
+		CreatedBy:   p.CreatedBy,
+		UpdatedBy:   p.UpdatedBy,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -31,11 +31,12 @@ func TestUpdateProjectHandler_Success(t *testing.T) {
 		Repo: repo,
 	}
 
-	handler := httpiface.NewUpdateProjectHandler(uc, fixedNow)
+	handler := httpiface.NewUpdateProjectHandler(uc, fixedClock)
 
 	body := map[string]string{
 		"name":        "New Name",
 		"description": "New Desc",
+		"status":      "on-hold",
 	}
 	b, _ := json.Marshal(body)
 
@@ -55,6 +56,7 @@ func TestUpdateProjectHandler_Success(t *testing.T) {
 		ID          string    `json:"id"`
 		Name        string    `json:"name"`
 		Description string    `json:"description"`
+		Status      string    `json:"status"`
 		CreatedAt   time.Time `json:"createdAt"`
 		UpdatedAt   time.Time `json:"updatedAt"`
 	}
@@ -71,6 +73,9 @@ func TestUpdateProjectHandler_Success(t *testing.T) {
 	if respBody.Description != "New Desc" {
 		t.Errorf("expected description=New Desc, got=%s", respBody.Description)
 	}
+	if respBody.Status != "on_hold" {
+		t.Errorf("expected status=on_hold, got=%s", respBody.Status)
+	}
 }
 
 func TestUpdateProjectHandler_InvalidJSON(t *testing.T) {
@@ -78,7 +83,7 @@ func TestUpdateProjectHandler_InvalidJSON(t *testing.T) {
 	seedProject(repo, "proj-1")
 
 	uc := &usecase.UpdateProjectUsecase{Repo: repo}
-	handler := httpiface.NewUpdateProjectHandler(uc, fixedNow)
+	handler := httpiface.NewUpdateProjectHandler(uc, fixedClock)
 
 	req := httptest.NewRequest(http.MethodPut, "/projects/proj-1", bytes.NewReader([]byte("{invalid")))
 	w := httptest.NewRecorder()
@@ -98,7 +103,7 @@ func TestUpdateProjectHandler_EmptyName(t *testing.T) {
 	seedProject(repo, "proj-1")
 
 	uc := &usecase.UpdateProjectUsecase{Repo: repo}
-	handler := httpiface.NewUpdateProjectHandler(uc, fixedNow)
+	handler := httpiface.NewUpdateProjectHandler(uc, fixedClock)
 
 	body := map[string]string{
 		"name":        "",
@@ -119,11 +124,78 @@ func TestUpdateProjectHandler_EmptyName(t *testing.T) {
 	}
 }
 
+func TestUpdateProjectHandler_InvalidStatus(t *testing.T) {
+	repo := infra.NewMemoryProjectRepository()
+	seedProject(repo, "proj-1")
+
+	uc := &usecase.UpdateProjectUsecase{Repo: repo}
+	handler := httpiface.NewUpdateProjectHandler(uc, fixedClock)
+
+	body := map[string]string{
+		"name":   "New Name",
+		"status": "archived",
+	}
+	b, _ := json.Marshal(body)
+
+	req := httptest.NewRequest(http.MethodPut, "/projects/proj-1", bytes.NewReader(b))
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", res.StatusCode)
+	}
+}
+
+func TestUpdateProjectHandler_DuplicateKey(t *testing.T) {
+	repo := infra.NewMemoryProjectRepository()
+	seedProject(repo, "proj-1")
+	other := seedProject(repo, "proj-2")
+	other.Key = "TFLOW"
+	_ = repo.Update(context.Background(), other)
+
+	uc := &usecase.UpdateProjectUsecase{Repo: repo}
+	handler := httpiface.NewUpdateProjectHandler(uc, fixedClock)
+
+	b, _ := json.Marshal(map[string]string{"name": "New Name", "key": "tflow"})
+	req := httptest.NewRequest(http.MethodPut, "/projects/proj-1", bytes.NewReader(b))
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d", w.Code)
+	}
+	if p, _ := repo.FindByID(context.Background(), "proj-1"); p.Key != "" || p.Name != "Old Name" {
+		t.Errorf("expected project to be unchanged, got %+v", p)
+	}
+}
+
+func TestUpdateProjectHandler_Forbidden(t *testing.T) {
+	projects, members := newRoleRepos(t)
+	uc := &usecase.UpdateProjectUsecase{Repo: projects, Members: members, EnforceRoles: true}
+	handler := httpiface.NewUpdateProjectHandler(uc, fixedClock)
+
+	b, _ := json.Marshal(map[string]string{"name": "New Name"})
+	req := httptest.NewRequest(http.MethodPut, "/projects/proj-1", bytes.NewReader(b))
+	req.Header.Set(httpiface.ActorHeader, "stranger")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", w.Code)
+	}
+}
+
 func TestUpdateProjectHandler_NotFound(t *testing.T) {
 	repo := infra.NewMemoryProjectRepository() // 何も入れていない
 
 	uc := &usecase.UpdateProjectUsecase{Repo: repo}
-	handler := httpiface.NewUpdateProjectHandler(uc, fixedNow)
+	handler := httpiface.NewUpdateProjectHandler(uc, fixedClock)
 
 	body := map[string]string{
 		"name":        "New Name",
@@ -148,7 +220,7 @@ func TestUpdateProjectHandler_InternalError(t *testing.T) {
 	repo := &errorRepo{} // さっき作った内部エラー用
 
 	uc := &usecase.UpdateProjectUsecase{Repo: repo}
-	handler := httpiface.NewUpdateProjectHandler(uc, fixedNow)
+	handler := httpiface.NewUpdateProjectHandler(uc, fixedClock)
 
 	body := map[string]string{
 		"name":        "New Name",
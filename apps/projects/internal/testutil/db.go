@@ -0,0 +1,32 @@
+//go:build integration
+// +build integration
+
+package testutil
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"teamflow-shared/dbtest"
+
+	"teamflow-projects/internal/infrastructure/migration"
+)
+
+// SetupTestDB returns the integration-test pool.
+// It fails fast if TestMain didn't initialize the pool.
+func SetupTestDB(t *testing.T) *pgxpool.Pool {
+	return dbtest.Setup(t)
+}
+
+// ResetProjectsTable truncates the projects table and the tables that reference it.
+func ResetProjectsTable(t *testing.T, db *pgxpool.Pool) {
+	t.Helper()
+	dbtest.Truncate(t, db, "projects CASCADE")
+}
+
+// InitTestDB connects to DB_TEST_DSN and applies the embedded migrations before running the tests.
+// This should be called from a TestMain function in the test package.
+func InitTestDB(m *testing.M) int {
+	return dbtest.Main(m, migration.New)
+}
@@ -0,0 +1,43 @@
+package project
+
+import (
+	"context"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// ActivityRepository はプロジェクトのアクティビティ（変更履歴）の記録・取得を担当する抽象。
+type ActivityRepository interface {
+	// Append はアクティビティを記録し、採番した ID を e.ID に設定する。
+	// プロジェクトが存在しない場合は ErrProjectNotFound 相当のエラーを返す。
+	Append(ctx context.Context, e *domain.ActivityEvent) error
+	// FindActivity はプロジェクトのアクティビティを新しい順（同時刻は ID の降順）で返す。
+	// nextCursor 判定のため limit + 1 件まで返す。
+	FindActivity(ctx context.Context, query *domain.ActivityQuery) ([]*domain.ActivityEvent, error)
+}
+
+// recordActivity は repo が設定されていればアクティビティを記録する。
+// 変更の記録は任意のため、repo が nil の構成（テスト等）では何もしない。
+func recordActivity(ctx context.Context, repo ActivityRepository, e *domain.ActivityEvent) error {
+	if repo == nil {
+		return nil
+	}
+	return repo.Append(ctx, e)
+}
+
+// ListActivityUsecase はプロジェクトのアクティビティ一覧取得ユースケース。
+type ListActivityUsecase struct {
+	Projects ProjectRepository
+	Members  MemberRepository
+	// EnforceRoles が true の場合は閲覧権限を確認する（公開プロジェクトは誰でも、非公開プロジェクトはメンバーのみ）
+	EnforceRoles bool
+	Activity     ActivityRepository
+}
+
+// Execute はプロジェクトの存在と閲覧権限を確認してからアクティビティを返す（limit + 1 件まで）。
+func (uc *ListActivityUsecase) Execute(ctx context.Context, query *domain.ActivityQuery, actorID string) ([]*domain.ActivityEvent, error) {
+	if _, err := findReadableProject(ctx, uc.Projects, uc.Members, uc.EnforceRoles, query.ProjectID, actorID); err != nil {
+		return nil, err
+	}
+	return uc.Activity.FindActivity(ctx, query)
+}
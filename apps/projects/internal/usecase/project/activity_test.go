@@ -0,0 +1,123 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// fakeActivityRepo は記録したアクティビティを保持する ActivityRepository のフェイク。
+type fakeActivityRepo struct {
+	events []*domain.ActivityEvent
+	err    error
+}
+
+func (r *fakeActivityRepo) Append(_ context.Context, e *domain.ActivityEvent) error {
+	if r.err != nil {
+		return r.err
+	}
+	e.ID = int64(len(r.events) + 1)
+	r.events = append(r.events, e)
+	return nil
+}
+
+func (r *fakeActivityRepo) FindActivity(_ context.Context, _ *domain.ActivityQuery) ([]*domain.ActivityEvent, error) {
+	return r.events, r.err
+}
+
+func (r *fakeActivityRepo) types() []domain.ActivityType {
+	out := make([]domain.ActivityType, len(r.events))
+	for i, e := range r.events {
+		out[i] = e.Type
+	}
+	return out
+}
+
+func TestActivity_RecordsChanges(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	projects := newExistingProjectRepo(t)
+	activity := &fakeActivityRepo{}
+
+	update := &usecase.UpdateProjectUsecase{Repo: projects, Activity: activity}
+	// 値が変わらない更新は記録しない
+	if _, err := update.Execute(ctx, usecase.UpdateProjectInput{ID: "proj-1", Name: "TeamFlow 開発", ActorID: "user-1", Now: now}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := update.Execute(ctx, usecase.UpdateProjectInput{ID: "proj-1", Name: "TeamFlow", Status: "on_hold", ActorID: "user-1", Now: now}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	archive := &usecase.ArchiveProjectUsecase{Repo: projects, Activity: activity}
+	if _, err := archive.Execute(ctx, usecase.ArchiveProjectInput{ID: "proj-1", Archived: true, ActorID: "user-1", Now: now}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 既にアーカイブ済みの場合は記録しない
+	if _, err := archive.Execute(ctx, usecase.ArchiveProjectInput{ID: "proj-1", Archived: true, ActorID: "user-1", Now: now}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	members := &fakeMemberRepo{}
+	add := &usecase.AddMemberUsecase{Projects: projects, Members: members, Activity: activity}
+	if _, err := add.Execute(ctx, usecase.AddMemberInput{ProjectID: "proj-1", UserID: "user-2", Role: "admin", ActorID: "user-1", Now: now}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	remove := &usecase.RemoveMemberUsecase{Members: members, Activity: activity}
+	if err := remove.Execute(ctx, usecase.RemoveMemberInput{ProjectID: "proj-1", UserID: "user-2", ActorID: "user-1", Now: now}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []domain.ActivityType{
+		domain.ActivityProjectUpdated,
+		domain.ActivityProjectArchived,
+		domain.ActivityMemberAdded,
+		domain.ActivityMemberRemoved,
+	}
+	got := activity.types()
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+	if e := activity.events[0]; e.Data["fields"] != "name,status" || e.ActorID != "user-1" {
+		t.Errorf("unexpected update event: %+v", e)
+	}
+	if e := activity.events[2]; e.Data["userId"] != "user-2" || e.Data["role"] != "admin" {
+		t.Errorf("unexpected member event: %+v", e)
+	}
+}
+
+func TestCreateProject_RecordsActivity(t *testing.T) {
+	activity := &fakeActivityRepo{}
+	uc := &usecase.CreateProjectUsecase{Repo: &fakeProjectRepo{}, Activity: activity}
+
+	p, err := uc.Execute(context.Background(), usecase.CreateProjectInput{ID: "proj-1", Name: "TeamFlow", ActorID: "user-1", Now: time.Now()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(activity.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(activity.events))
+	}
+	if e := activity.events[0]; e.Type != domain.ActivityProjectCreated || e.ProjectID != p.ID || e.Data["name"] != "TeamFlow" {
+		t.Errorf("unexpected event: %+v", e)
+	}
+}
+
+func TestListActivity_ProjectNotFound(t *testing.T) {
+	uc := &usecase.ListActivityUsecase{Projects: newPreferenceProjects(), Activity: &fakeActivityRepo{}}
+
+	q, err := domain.NewActivityQuery("non-existent", 10, "", nil, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.Execute(context.Background(), q, ""); !errors.Is(err, usecase.ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package project
+
+import (
+	"context"
+	"time"
+
+	"teamflow-shared/audit"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// ArchiveProjectInput はアーカイブ・アーカイブ解除ユースケースの入力。
+type ArchiveProjectInput struct {
+	ID       string
+	Archived bool   // true でアーカイブ、false でアーカイブ解除
+	ActorID  string // 操作者（UpdatedBy）
+	Now      time.Time
+}
+
+// ArchiveProjectUsecase はプロジェクトのアーカイブ・アーカイブ解除ユースケース。
+type ArchiveProjectUsecase struct {
+	Repo    ProjectRepository
+	Members MemberRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（owner のみ）
+	EnforceRoles bool
+	// Activity はアーカイブ・アーカイブ解除を記録するために使う。任意。nil の場合は記録しない
+	Activity ActivityRepository
+	// Audit はアーカイブ・アーカイブ解除を監査ログに記録するために使う。任意。nil の場合は記録しない
+	Audit audit.Recorder
+}
+
+// Execute はプロジェクトの ArchivedAt を設定または解除する。
+// 既に目的の状態の場合は何もせずに現在のプロジェクトを返す。
+func (uc *ArchiveProjectUsecase) Execute(ctx context.Context, in ArchiveProjectInput) (*domain.Project, error) {
+	existing, err := uc.Repo.FindByID(ctx, in.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, existing.ID, in.ActorID, domain.ActionArchive); err != nil {
+			return nil, err
+		}
+	}
+
+	if existing.IsArchived() == in.Archived {
+		return existing, nil
+	}
+
+	if in.Archived {
+		archivedAt := in.Now
+		existing.ArchivedAt = &archivedAt
+	} else {
+		existing.ArchivedAt = nil
+	}
+	existing.UpdatedAt = in.Now
+	existing.UpdatedBy = in.ActorID
+
+	if err := uc.Repo.Update(ctx, existing); err != nil {
+		return existing, err
+	}
+
+	typ, action := domain.ActivityProjectUnarchived, audit.ActionUnarchive
+	if in.Archived {
+		typ, action = domain.ActivityProjectArchived, audit.ActionArchive
+	}
+	if err := recordAudit(ctx, uc.Audit, existing, action, in.ActorID, nil, in.Now); err != nil {
+		return existing, err
+	}
+	if err := recordActivity(ctx, uc.Activity, domain.NewActivityEvent(existing.ID, typ, in.ActorID, nil, in.Now)); err != nil {
+		return existing, err
+	}
+	return existing, nil
+}
@@ -0,0 +1,34 @@
+package project
+
+import (
+	"context"
+	"time"
+
+	"teamflow-shared/audit"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// auditService / auditEntityProject は監査ログに記録する projects サービスのプロジェクトの識別子。
+const (
+	auditService       = "projects"
+	auditEntityProject = "project"
+)
+
+// recordAudit は rec が設定されていればプロジェクト p への action を監査ログに記録する。
+// 監査ログは任意のため、rec が nil の構成（テスト等）では何もしない。
+func recordAudit(ctx context.Context, rec audit.Recorder, p *domain.Project, action audit.Action, actorID string, fields []string, now time.Time) error {
+	if rec == nil {
+		return nil
+	}
+	return rec.Record(ctx, audit.Entry{
+		Service:    auditService,
+		EntityType: auditEntityProject,
+		EntityID:   p.ID,
+		ProjectID:  p.ID,
+		Action:     action,
+		ActorID:    actorID,
+		Fields:     fields,
+		OccurredAt: now,
+	})
+}
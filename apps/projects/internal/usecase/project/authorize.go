@@ -0,0 +1,58 @@
+package project
+
+import (
+	"context"
+	"errors"
+
+	"teamflow-shared/authz"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// authorize は actorID のプロジェクトでのロールが action を許可しているか確認する。
+// 各ユースケースの EnforceRoles が true の場合に呼ぶ。
+// actorID が空の場合は domain.ErrActorRequired、権限不足の場合は *domain.InsufficientRoleError を返す。
+func authorize(ctx context.Context, members MemberRepository, projectID, actorID string, action domain.Action) error {
+	if actorID == "" {
+		return domain.ErrActorRequired
+	}
+
+	m, err := members.FindMember(ctx, projectID, actorID)
+	if err != nil {
+		if errors.Is(err, ErrMemberNotFound) {
+			return domain.Authorize(nil, action)
+		}
+		return err
+	}
+	return domain.Authorize(m, action)
+}
+
+// authorizeRead は actorID がプロジェクト p を閲覧できるか確認する（公開範囲とメンバーかどうかで判定する）。
+// 各閲覧系ユースケースの EnforceRoles が true の場合に呼ぶ。
+// 非公開プロジェクトで actorID が空の場合は domain.ErrActorRequired、メンバーでない場合は domain.ErrForbidden を返す。
+func authorizeRead(ctx context.Context, members MemberRepository, p *domain.Project, actorID string) error {
+	return authz.AuthorizeRead(p.Visibility, actorID, func() (bool, error) {
+		if _, err := members.FindMember(ctx, p.ID, actorID); err != nil {
+			if errors.Is(err, ErrMemberNotFound) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	})
+}
+
+// findReadableProject はプロジェクトを取得し、enforce が true の場合は actorID が閲覧できるか確認する。
+// プロジェクトが存在しない場合は ErrProjectNotFound を返す。
+func findReadableProject(ctx context.Context, projects ProjectRepository, members MemberRepository, enforce bool, projectID, actorID string) (*domain.Project, error) {
+	p, err := projects.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if enforce {
+		if err := authorizeRead(ctx, members, p, actorID); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,234 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// newRoleMembers は proj-1 に owner / admin / member が 1 人ずつ参加しているフェイクを返す。
+func newRoleMembers() *fakeMemberRepo {
+	return &fakeMemberRepo{
+		members: []*domain.Member{
+			{ProjectID: "proj-1", UserID: "owner-1", Role: domain.RoleOwner},
+			{ProjectID: "proj-1", UserID: "admin-1", Role: domain.RoleAdmin},
+			{ProjectID: "proj-1", UserID: "member-1", Role: domain.RoleMember},
+		},
+	}
+}
+
+func TestUpdateProject_EnforceRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   string
+		wantErr error
+	}{
+		{name: "member can edit", actor: "member-1"},
+		{name: "non-member is forbidden", actor: "stranger", wantErr: domain.ErrForbidden},
+		{name: "actor is required", actor: "", wantErr: domain.ErrActorRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &usecase.UpdateProjectUsecase{Repo: newExistingProjectRepo(t), Members: newRoleMembers(), EnforceRoles: true}
+
+			_, err := uc.Execute(context.Background(), usecase.UpdateProjectInput{ID: "proj-1", Name: "New Name", ActorID: tt.actor, Now: time.Now()})
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestUpdateProject_VisibilityRequiresManageSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		actor      string
+		visibility string
+		wantErr    error
+	}{
+		{name: "admin can change visibility", actor: "admin-1", visibility: "public"},
+		{name: "member cannot change visibility", actor: "member-1", visibility: "public", wantErr: domain.ErrForbidden},
+		{name: "member can keep the same visibility", actor: "member-1", visibility: "private"},
+		{name: "invalid visibility", actor: "admin-1", visibility: "internal", wantErr: domain.ErrInvalidVisibility},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newExistingProjectRepo(t)
+			uc := &usecase.UpdateProjectUsecase{Repo: repo, Members: newRoleMembers(), EnforceRoles: true}
+
+			p, err := uc.Execute(context.Background(), usecase.UpdateProjectInput{
+				ID: "proj-1", Name: "TeamFlow 開発", Visibility: tt.visibility, ActorID: tt.actor, Now: time.Now(),
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(p.Visibility) != tt.visibility {
+				t.Errorf("expected visibility=%s, got=%s", tt.visibility, p.Visibility)
+			}
+		})
+	}
+}
+
+func TestGetProject_EnforceRolesChecksVisibility(t *testing.T) {
+	tests := []struct {
+		name       string
+		visibility domain.Visibility
+		actor      string
+		wantErr    error
+	}{
+		{name: "member reads private", visibility: domain.VisibilityPrivate, actor: "member-1"},
+		{name: "non-member reads private", visibility: domain.VisibilityPrivate, actor: "stranger", wantErr: domain.ErrForbidden},
+		{name: "anonymous reads private", visibility: domain.VisibilityPrivate, wantErr: domain.ErrActorRequired},
+		{name: "anonymous reads public", visibility: domain.VisibilityPublic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newExistingProjectRepo(t)
+			repo.stored.Visibility = tt.visibility
+			uc := &usecase.GetProjectUsecase{Repo: repo, Members: newRoleMembers(), EnforceRoles: true}
+
+			_, err := uc.Execute(context.Background(), "proj-1", tt.actor)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestArchiveProject(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	repo := newExistingProjectRepo(t)
+	uc := &usecase.ArchiveProjectUsecase{Repo: repo, Members: newRoleMembers(), EnforceRoles: true}
+
+	_, err := uc.Execute(ctx, usecase.ArchiveProjectInput{ID: "proj-1", Archived: true, ActorID: "admin-1", Now: now})
+	var roleErr *domain.InsufficientRoleError
+	if !errors.As(err, &roleErr) || roleErr.Role != domain.RoleAdmin || roleErr.Action != domain.ActionArchive {
+		t.Fatalf("expected InsufficientRoleError for admin, got %v", err)
+	}
+
+	p, err := uc.Execute(ctx, usecase.ArchiveProjectInput{ID: "proj-1", Archived: true, ActorID: "owner-1", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.ArchivedAt == nil || !p.ArchivedAt.Equal(now) {
+		t.Errorf("expected ArchivedAt=%v, got %v", now, p.ArchivedAt)
+	}
+
+	p, err = uc.Execute(ctx, usecase.ArchiveProjectInput{ID: "proj-1", Archived: false, ActorID: "owner-1", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.IsArchived() {
+		t.Errorf("expected project to be unarchived")
+	}
+}
+
+func TestCreateProject_RegistersOwner(t *testing.T) {
+	members := &fakeMemberRepo{}
+	uc := &usecase.CreateProjectUsecase{Repo: &fakeProjectRepo{}, Members: members, EnforceRoles: true}
+
+	if _, err := uc.Execute(context.Background(), usecase.CreateProjectInput{ID: "proj-1", Name: "TeamFlow 開発", Now: time.Now()}); !errors.Is(err, domain.ErrActorRequired) {
+		t.Fatalf("expected ErrActorRequired, got %v", err)
+	}
+
+	if _, err := uc.Execute(context.Background(), usecase.CreateProjectInput{ID: "proj-1", Name: "TeamFlow 開発", ActorID: "user-1", Now: time.Now()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(members.members) != 1 || members.members[0].UserID != "user-1" || members.members[0].Role != domain.RoleOwner {
+		t.Fatalf("expected user-1 to be registered as owner, got %+v", members.members)
+	}
+}
+
+func TestMembers_EnforceRoles(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		run     func(members *fakeMemberRepo) error
+		wantErr error
+	}{
+		{
+			name: "admin can add member",
+			run: func(members *fakeMemberRepo) error {
+				uc := &usecase.AddMemberUsecase{Projects: newExistingProjectRepo(t), Members: members, EnforceRoles: true}
+				_, err := uc.Execute(ctx, usecase.AddMemberInput{ProjectID: "proj-1", UserID: "user-9", ActorID: "admin-1", Now: now})
+				return err
+			},
+		},
+		{
+			name: "admin cannot add owner",
+			run: func(members *fakeMemberRepo) error {
+				uc := &usecase.AddMemberUsecase{Projects: newExistingProjectRepo(t), Members: members, EnforceRoles: true}
+				_, err := uc.Execute(ctx, usecase.AddMemberInput{ProjectID: "proj-1", UserID: "user-9", Role: "owner", ActorID: "admin-1", Now: now})
+				return err
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name: "member cannot add member",
+			run: func(members *fakeMemberRepo) error {
+				uc := &usecase.AddMemberUsecase{Projects: newExistingProjectRepo(t), Members: members, EnforceRoles: true}
+				_, err := uc.Execute(ctx, usecase.AddMemberInput{ProjectID: "proj-1", UserID: "user-9", ActorID: "member-1", Now: now})
+				return err
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name: "admin cannot remove owner",
+			run: func(members *fakeMemberRepo) error {
+				uc := &usecase.RemoveMemberUsecase{Members: members, EnforceRoles: true}
+				return uc.Execute(ctx, usecase.RemoveMemberInput{ProjectID: "proj-1", UserID: "owner-1", ActorID: "admin-1"})
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name: "owner can remove admin",
+			run: func(members *fakeMemberRepo) error {
+				uc := &usecase.RemoveMemberUsecase{Members: members, EnforceRoles: true}
+				return uc.Execute(ctx, usecase.RemoveMemberInput{ProjectID: "proj-1", UserID: "admin-1", ActorID: "owner-1"})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run(newRoleMembers())
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"teamflow-shared/authz"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// TaskCopier はタスクの取得と一括作成を行う（プロジェクトの複製で使う）。
+type TaskCopier interface {
+	TaskLister
+	TaskSeeder
+}
+
+// CloneProjectInput はプロジェクト複製ユースケースの入力。
+type CloneProjectInput struct {
+	SourceID     string
+	ID           string // 複製先の ID
+	Key          string // 複製先のキー。空の場合は未設定（キーは一意のため複製しない）
+	Name         string // 空の場合は "<複製元の名前> (copy)"
+	IncludeTasks bool   // true の場合は未完了（todo / in_progress）のタスクも複製する
+	ActorID      string // 操作者。複製先の owner になる
+	Now          time.Time
+}
+
+// CloneSummary は複製した項目の件数。
+type CloneSummary struct {
+	Members  int  // 複製したメンバー数（owner になる操作者を除く）
+	Settings bool // プロジェクト設定を複製したかどうか
+	Tasks    int  // 複製したタスク数
+}
+
+// CloneProjectUsecase はプロジェクトを複製するユースケース。
+// 説明・メンバー・プロジェクト設定を複製し、任意で未完了のタスクを複製する（ステータスは active から始める）。
+type CloneProjectUsecase struct {
+	Create   *CreateProjectUsecase
+	Projects ProjectRepository
+	Members  MemberRepository
+	Settings SettingsRepository
+	Tasks    TaskCopier
+	Tx       TxManager
+	// EnforceRoles が true の場合は操作者が複製元のメンバーであることを確認する
+	EnforceRoles bool
+}
+
+// Execute は複製先のプロジェクトの作成と各項目の複製を 1 トランザクションで行う。
+//
+// 複製元が存在しない場合は ErrProjectNotFound、tasks サービスの呼び出しに失敗した場合は
+// ErrTasksService を返す（いずれの場合も複製先は作成されない）。
+// タスクは担当者・期限を含めずに複製する（担当者はプロジェクト設定の既定値が適用される）。
+func (uc *CloneProjectUsecase) Execute(ctx context.Context, in CloneProjectInput) (*domain.Project, CloneSummary, error) {
+	var summary CloneSummary
+
+	source, err := uc.Projects.FindByID(ctx, in.SourceID)
+	if err != nil {
+		return nil, summary, err
+	}
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, source.ID, in.ActorID, domain.ActionClone); err != nil {
+			return nil, summary, err
+		}
+	}
+
+	// タスクは書き込みの前に取得しておく
+	var tasks []domain.TaskBlueprint
+	if in.IncludeTasks {
+		if uc.Tasks == nil {
+			return nil, summary, fmt.Errorf("%w: tasks service is not configured", ErrTasksService)
+		}
+		// tasks サービスでも操作者の閲覧権限を確認するため、操作者を引き継ぐ
+		tasks, err = uc.Tasks.ListOpenTasks(authz.ContextWithActor(ctx, in.ActorID), source.ID)
+		if err != nil {
+			return nil, summary, fmt.Errorf("%w: %w", ErrTasksService, err)
+		}
+	}
+
+	name := in.Name
+	if name == "" {
+		name = source.Name + " (copy)"
+	}
+
+	var cloned *domain.Project
+	err = withinTx(ctx, uc.Tx, func(ctx context.Context) error {
+		summary = CloneSummary{}
+
+		p, err := uc.Create.Execute(ctx, CreateProjectInput{
+			ID:          in.ID,
+			Key:         in.Key,
+			Name:        name,
+			Description: source.Description,
+			Visibility:  string(source.Visibility),
+			ActorID:     in.ActorID,
+			Now:         in.Now,
+		})
+		if err != nil {
+			return err
+		}
+
+		members, err := uc.Members.ListMembers(ctx, source.ID)
+		if err != nil {
+			return err
+		}
+		for _, m := range members {
+			if m.UserID == in.ActorID {
+				continue // 操作者は CreateProjectUsecase で owner として登録済み
+			}
+			copied := &domain.Member{ProjectID: p.ID, UserID: m.UserID, Role: m.Role, JoinedAt: in.Now}
+			if err := uc.Members.AddMember(ctx, copied); err != nil {
+				return err
+			}
+			summary.Members++
+		}
+
+		s, err := uc.Settings.FindSettings(ctx, source.ID)
+		switch {
+		case err == nil:
+			copied := *s
+			copied.ProjectID = p.ID
+			copied.UpdatedAt = in.Now
+			if err := uc.Settings.SaveSettings(ctx, &copied); err != nil {
+				return err
+			}
+			summary.Settings = true
+		case !errors.Is(err, ErrSettingsNotFound):
+			return err
+		}
+
+		if len(tasks) > 0 {
+			if err := uc.Tasks.SeedTasks(authz.ContextWithActor(ctx, in.ActorID), p.ID, tasks); err != nil {
+				return fmt.Errorf("%w: %w", ErrTasksService, err)
+			}
+			summary.Tasks = len(tasks)
+		}
+
+		cloned = p
+		return nil
+	})
+	if err != nil {
+		return nil, CloneSummary{}, err
+	}
+	return cloned, summary, nil
+}
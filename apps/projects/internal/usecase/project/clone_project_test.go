@@ -0,0 +1,157 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// fakeTaskCopier は TaskCopier のテスト用フェイク実装。
+type fakeTaskCopier struct {
+	open    map[string][]domain.TaskBlueprint
+	listErr error
+	fakeSeeder
+}
+
+func (c *fakeTaskCopier) ListOpenTasks(_ context.Context, projectID string) ([]domain.TaskBlueprint, error) {
+	return c.open[projectID], c.listErr
+}
+
+func newCloneUsecase(t *testing.T, tasks *fakeTaskCopier) (*usecase.CloneProjectUsecase, *fakeMemberRepo, *fakeSettingsRepo, *fakeTxManager) {
+	t.Helper()
+	members := newRoleMembers()
+	settings := &fakeSettingsRepo{stored: map[string]*domain.Settings{
+		"proj-1": {ProjectID: "proj-1", DefaultPriority: "high", WIPLimits: map[string]int{}},
+	}}
+	tx := &fakeTxManager{}
+	return &usecase.CloneProjectUsecase{
+		Create:       &usecase.CreateProjectUsecase{Repo: &fakeProjectRepo{}, Members: members, EnforceRoles: true},
+		Projects:     newExistingProjectRepo(t),
+		Members:      members,
+		Settings:     settings,
+		Tasks:        tasks,
+		Tx:           tx,
+		EnforceRoles: true,
+	}, members, settings, tx
+}
+
+func TestCloneProject_Success(t *testing.T) {
+	copier := &fakeTaskCopier{open: map[string][]domain.TaskBlueprint{
+		"proj-1": {{Title: "計画", Status: "todo"}, {Title: "実装", Status: "in_progress"}},
+	}}
+	uc, members, settings, tx := newCloneUsecase(t, copier)
+
+	p, summary, err := uc.Execute(context.Background(), usecase.CloneProjectInput{
+		SourceID: "proj-1", ID: "proj-2", IncludeTasks: true, ActorID: "member-1", Now: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.ID != "proj-2" || p.Name != "TeamFlow 開発 (copy)" || p.Status != domain.StatusActive {
+		t.Errorf("unexpected project: %+v", p)
+	}
+	if summary != (usecase.CloneSummary{Members: 2, Settings: true, Tasks: 2}) {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+
+	// 操作者は owner、その他のメンバーは元のロールのまま複製される
+	wantRoles := map[string]domain.MemberRole{"member-1": domain.RoleOwner, "owner-1": domain.RoleOwner, "admin-1": domain.RoleAdmin}
+	cloned, _ := members.ListMembers(context.Background(), "proj-2")
+	if len(cloned) != len(wantRoles) {
+		t.Fatalf("expected %d members, got %d", len(wantRoles), len(cloned))
+	}
+	for _, m := range cloned {
+		if wantRoles[m.UserID] != m.Role {
+			t.Errorf("unexpected role for %s: %s", m.UserID, m.Role)
+		}
+	}
+
+	if s := settings.stored["proj-2"]; s == nil || s.DefaultPriority != "high" {
+		t.Errorf("expected settings to be copied, got %+v", s)
+	}
+	if copier.projectID != "proj-2" || len(copier.tasks) != 2 {
+		t.Errorf("unexpected seeding: projectID=%s tasks=%d", copier.projectID, len(copier.tasks))
+	}
+	if !tx.committed {
+		t.Errorf("expected transaction to be committed")
+	}
+}
+
+func TestCloneProject_WithoutTasks(t *testing.T) {
+	copier := &fakeTaskCopier{open: map[string][]domain.TaskBlueprint{"proj-1": {{Title: "計画", Status: "todo"}}}}
+	uc, _, _, _ := newCloneUsecase(t, copier)
+
+	_, summary, err := uc.Execute(context.Background(), usecase.CloneProjectInput{
+		SourceID: "proj-1", ID: "proj-2", Name: "Next", ActorID: "owner-1", Now: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Tasks != 0 || copier.tasks != nil {
+		t.Errorf("expected no tasks to be copied, got summary=%+v seeded=%d", summary, len(copier.tasks))
+	}
+}
+
+func TestCloneProject_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		in           usecase.CloneProjectInput
+		copier       *fakeTaskCopier
+		wantErr      error // nil の場合はエラーの種類を問わない
+		wantRollback bool
+	}{
+		{
+			name:   "source not found",
+			in:     usecase.CloneProjectInput{SourceID: "proj-x", ID: "proj-2", ActorID: "owner-1"},
+			copier: &fakeTaskCopier{},
+		},
+		{
+			name:    "non-member is forbidden",
+			in:      usecase.CloneProjectInput{SourceID: "proj-1", ID: "proj-2", ActorID: "stranger"},
+			copier:  &fakeTaskCopier{},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name:    "listing tasks fails",
+			in:      usecase.CloneProjectInput{SourceID: "proj-1", ID: "proj-2", IncludeTasks: true, ActorID: "owner-1"},
+			copier:  &fakeTaskCopier{listErr: errors.New("unavailable")},
+			wantErr: usecase.ErrTasksService,
+		},
+		{
+			name: "seeding fails",
+			in:   usecase.CloneProjectInput{SourceID: "proj-1", ID: "proj-2", IncludeTasks: true, ActorID: "owner-1"},
+			copier: &fakeTaskCopier{
+				open:       map[string][]domain.TaskBlueprint{"proj-1": {{Title: "計画", Status: "todo"}}},
+				fakeSeeder: fakeSeeder{err: errors.New("unavailable")},
+			},
+			wantErr:      usecase.ErrTasksService,
+			wantRollback: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, _, _, tx := newCloneUsecase(t, tt.copier)
+
+			in := tt.in
+			in.Now = time.Now()
+			p, _, err := uc.Execute(context.Background(), in)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if p != nil {
+				t.Errorf("expected nil project, got %+v", p)
+			}
+			if tx.rolledBack != tt.wantRollback {
+				t.Errorf("rolledBack = %v, want %v", tx.rolledBack, tt.wantRollback)
+			}
+		})
+	}
+}
@@ -2,41 +2,169 @@ package project
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
+	"teamflow-shared/audit"
+	"teamflow-shared/events"
+	"teamflow-shared/featureflag"
+	"teamflow-shared/outbox"
+	"teamflow-shared/uuidpolicy"
+
 	domain "teamflow-projects/internal/domain/project"
 )
 
 // ProjectRepository はプロジェクトの永続化・取得を担当する抽象。
 type ProjectRepository interface {
+	// Save / Update は Key が他のプロジェクトと重複する場合は ErrProjectKeyAlreadyExists 相当のエラーを返す。
 	Save(ctx context.Context, p *domain.Project) error
+	Update(ctx context.Context, p *domain.Project) error
+	// FindByID / FindByName / List / FindWithQuery は削除された（DeletedAt が設定された）プロジェクトを返さない。
 	FindByID(ctx context.Context, id string) (*domain.Project, error)
+	// FindDeleted は削除されたプロジェクトを返す。存在しない、または削除されていない場合は ErrProjectNotFound を返す。
+	FindDeleted(ctx context.Context, id string) (*domain.Project, error)
+	// FindByName は名前が一致する（前後の空白と大文字小文字を無視する）プロジェクトを 1 件返す。
+	// 複数ある場合は最も古いもの、存在しない場合は ErrProjectNotFound を返す。
+	FindByName(ctx context.Context, name string) (*domain.Project, error)
 	List(ctx context.Context) ([]*domain.Project, error)
+	// FindWithQuery は Query Object に基づいてプロジェクトを取得する。
+	// nextCursor 判定のため limit + 1 件まで返す。
+	FindWithQuery(ctx context.Context, query *domain.ProjectQuery) ([]*domain.Project, error)
 }
 
 // CreateProjectInput はプロジェクト作成ユースケースの入力。
 type CreateProjectInput struct {
 	ID          string
+	Key         string // 空の場合は未設定
 	Name        string
 	Description string
+	Status      string // 空の場合は active
+	Visibility  string // 空の場合は private
+	ActorID     string // 作成者（CreatedBy / UpdatedBy）。Members が設定されていれば owner として登録する
 	Now         time.Time
 }
 
 // CreateProjectUsecase はプロジェクト作成ユースケースを表す。
 type CreateProjectUsecase struct {
 	Repo ProjectRepository
+	Tx   TxManager // 任意。nil の場合はトランザクション無しで実行する
+	// Members は作成者を owner として登録するために使う。任意。nil の場合は登録しない
+	Members MemberRepository
+	// EnforceRoles が true の場合は作成者（ActorID）を必須にする
+	EnforceRoles bool
+	// UniqueNames が true の場合は同じ名前のプロジェクトがあれば作成しない
+	UniqueNames bool
+	// Flags は FlagUniqueProjectNames で名前の一意制約を有効にするために使う。任意。nil の場合は UniqueNames のみで判定する
+	Flags featureflag.Provider
+	// Activity は作成を記録するために使う。任意。nil の場合は記録しない
+	Activity ActivityRepository
+	// Audit は作成を監査ログに記録するために使う。任意。nil の場合は記録しない
+	Audit audit.Recorder
+	// Events は project.created を outbox に記録するために使う。任意。nil の場合は記録しない
+	Events outbox.Writer
+	// UUIDPolicy は作成するプロジェクトの ID として受け付ける UUID の形式。任意。空の場合は形式を確認しない
+	UUIDPolicy uuidpolicy.Policy
 }
 
 // Execute は新しいプロジェクトを作成し、リポジトリに保存する。
+// ID が UUIDPolicy で受け付けない形式の場合は domain.ErrInvalidID、
+// Status が不正な場合は domain.ErrInvalidStatus、Key が不正な場合は domain.ErrInvalidKey、
+// Visibility が不正な場合は domain.ErrInvalidVisibility、Key が他のプロジェクトと重複する場合は ErrProjectKeyAlreadyExists、
+// UniqueNames で同じ名前のプロジェクトがある場合は *DuplicateNameError（ErrProjectNameAlreadyExists）を返す。
+// 作成者が分かる場合は owner として登録する。EnforceRoles で作成者が空の場合は domain.ErrActorRequired を返す。
 func (uc *CreateProjectUsecase) Execute(ctx context.Context, in CreateProjectInput) (*domain.Project, error) {
+	if uc.EnforceRoles && in.ActorID == "" {
+		return nil, domain.ErrActorRequired
+	}
+	if uc.UUIDPolicy != "" && in.ID != "" {
+		if err := uc.UUIDPolicy.Validate(in.ID); err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrInvalidID, err)
+		}
+	}
+
 	p, err := domain.NewProject(in.ID, in.Name, in.Description, in.Now)
 	if err != nil {
 		return nil, err
 	}
+	p.CreatedBy = in.ActorID
+	p.UpdatedBy = in.ActorID
+	if in.Status != "" {
+		status, err := domain.ParseStatus(in.Status)
+		if err != nil {
+			return nil, err
+		}
+		p.Status = status
+	}
+	if in.Key != "" {
+		key, err := domain.ParseKey(in.Key)
+		if err != nil {
+			return nil, err
+		}
+		p.Key = key
+	}
+	if in.Visibility != "" {
+		v, err := domain.ParseVisibility(in.Visibility)
+		if err != nil {
+			return nil, err
+		}
+		p.Visibility = v
+	}
 
-	if err := uc.Repo.Save(ctx, p); err != nil {
-		return p, err
+	if uc.UniqueNames || flagEnabled(ctx, uc.Flags, FlagUniqueProjectNames) {
+		if err := checkNameAvailable(ctx, uc.Repo, p.Name, p.ID); err != nil {
+			return nil, err
+		}
 	}
 
+	// 保存と owner の登録、アクティビティ・監査ログ・イベントの記録は 1 トランザクションで行う
+	err = withinTx(ctx, uc.Tx, func(ctx context.Context) error {
+		return uc.save(ctx, p, in)
+	})
+	if err != nil {
+		return p, err
+	}
 	return p, nil
 }
+
+// save はプロジェクトを保存し、作成者を owner として登録して、アクティビティ・監査ログ・project.created を記録する。
+func (uc *CreateProjectUsecase) save(ctx context.Context, p *domain.Project, in CreateProjectInput) error {
+	if err := uc.Repo.Save(ctx, p); err != nil {
+		return err
+	}
+
+	if uc.Members != nil && in.ActorID != "" {
+		owner, err := domain.NewMember(p.ID, in.ActorID, string(domain.RoleOwner), in.Now)
+		if err != nil {
+			return err
+		}
+		if err := uc.Members.AddMember(ctx, owner); err != nil {
+			return err
+		}
+	}
+
+	event := domain.NewActivityEvent(p.ID, domain.ActivityProjectCreated, in.ActorID, map[string]string{"name": p.Name}, in.Now)
+	if err := recordActivity(ctx, uc.Activity, event); err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, uc.Audit, p, audit.ActionCreate, in.ActorID, nil, in.Now); err != nil {
+		return err
+	}
+	return recordEvent(ctx, uc.Events, events.ProjectCreated, p, in.ActorID)
+}
+
+// checkNameAvailable は selfID 以外に name と同じ名前のプロジェクトが無いことを確認する。
+// 一意性はアプリケーションで確認するだけのため、同時に作成された場合は重複し得る。
+func checkNameAvailable(ctx context.Context, repo ProjectRepository, name, selfID string) error {
+	existing, err := repo.FindByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, ErrProjectNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID == selfID {
+		return nil
+	}
+	return &DuplicateNameError{ProjectID: existing.ID}
+}
@@ -6,6 +6,13 @@ import (
 	"testing"
 	"time"
 
+	"teamflow-shared/audit"
+	"teamflow-shared/events"
+	"teamflow-shared/featureflag"
+	"teamflow-shared/outbox"
+	"teamflow-shared/uuidpolicy"
+	"teamflow-shared/workspace"
+
 	domain "teamflow-projects/internal/domain/project"
 	usecase "teamflow-projects/internal/usecase/project"
 )
@@ -22,15 +29,38 @@ func (r *fakeProjectRepo) Save(_ context.Context, p *domain.Project) error {
 	return r.err
 }
 
+func (r *fakeProjectRepo) Update(_ context.Context, p *domain.Project) error {
+	// Create のテストでは未使用なのでダミー
+	return errors.New("not implemented")
+}
+
 func (r *fakeProjectRepo) FindByID(_ context.Context, id string) (*domain.Project, error) {
 	// Create のテストでは未使用なのでダミー
 	return nil, errors.New("not implemented")
 }
 
+func (r *fakeProjectRepo) FindDeleted(_ context.Context, id string) (*domain.Project, error) {
+	// Create のテストでは未使用なのでダミー
+	return nil, errors.New("not implemented")
+}
+
+func (r *fakeProjectRepo) FindByName(_ context.Context, name string) (*domain.Project, error) {
+	for _, p := range r.listOut {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return nil, usecase.ErrProjectNotFound
+}
+
 func (r *fakeProjectRepo) List(_ context.Context) ([]*domain.Project, error) {
 	return r.listOut, nil
 }
 
+func (r *fakeProjectRepo) FindWithQuery(_ context.Context, _ *domain.ProjectQuery) ([]*domain.Project, error) {
+	return r.listOut, nil
+}
+
 func TestNewProject_Success(t *testing.T) {
 	now := time.Now()
 
@@ -111,6 +141,57 @@ func TestCreateProject_Success(t *testing.T) {
 	}
 }
 
+func TestCreateProject_RecordsActor(t *testing.T) {
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	rec := audit.NewMemoryRecorder()
+	uc := &usecase.CreateProjectUsecase{Repo: &fakeProjectRepo{}, Audit: rec}
+
+	p, err := uc.Execute(context.Background(), usecase.CreateProjectInput{ID: "proj-1", Name: "TeamFlow", ActorID: "user-1", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.CreatedBy != "user-1" || p.UpdatedBy != "user-1" {
+		t.Errorf("expected CreatedBy=UpdatedBy=user-1, got %q/%q", p.CreatedBy, p.UpdatedBy)
+	}
+
+	entries := rec.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %d", len(entries))
+	}
+	e := entries[0]
+	if e.Service != "projects" || e.EntityType != "project" || e.EntityID != "proj-1" || e.Action != audit.ActionCreate || e.ActorID != "user-1" || !e.OccurredAt.Equal(now) {
+		t.Errorf("unexpected audit entry: %+v", e)
+	}
+}
+
+func TestCreateProject_RecordsEvent(t *testing.T) {
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	store := outbox.NewMemoryStore()
+	tx := &fakeTxManager{}
+	uc := &usecase.CreateProjectUsecase{Repo: &fakeProjectRepo{}, Tx: tx, Events: store}
+	ctx := workspace.NewContext(context.Background(), "acme")
+
+	if _, err := uc.Execute(ctx, usecase.CreateProjectInput{ID: "proj-1", Key: "TF", Name: "TeamFlow", ActorID: "user-1", Now: now}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !tx.committed {
+		t.Error("expected the save and the event to be committed in one transaction")
+	}
+
+	evs := store.Events()
+	if len(evs) != 1 {
+		t.Fatalf("expected 1 event, got %+v", evs)
+	}
+	e := evs[0]
+	if e.Type != events.ProjectCreated || e.Source != "projects" || e.AggregateID != "proj-1" || e.WorkspaceID != "acme" || !e.OccurredAt.Equal(now) {
+		t.Errorf("unexpected event %+v", e)
+	}
+	var data events.ProjectData
+	if err := e.Decode(&data); err != nil || data.Key != "TF" || data.Name != "TeamFlow" || data.Status != "active" || data.Visibility != "private" || data.ActorID != "user-1" {
+		t.Errorf("unexpected data %+v, %v", data, err)
+	}
+}
+
 func TestCreateProject_EmptyName(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
@@ -140,6 +221,163 @@ func TestCreateProject_EmptyName(t *testing.T) {
 	}
 }
 
+func TestCreateProject_Status(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		status string
+		want   domain.ProjectStatus
+	}{
+		{name: "default", status: "", want: domain.StatusActive},
+		{name: "normalized", status: "On-Hold", want: domain.StatusOnHold},
+		{name: "completed", status: "completed", want: domain.StatusCompleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeProjectRepo{}
+			uc := &usecase.CreateProjectUsecase{Repo: repo}
+
+			p, err := uc.Execute(ctx, usecase.CreateProjectInput{ID: "proj-1", Name: "TeamFlow 開発", Status: tt.status, Now: now})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p.Status != tt.want {
+				t.Errorf("expected Status=%s, got=%s", tt.want, p.Status)
+			}
+		})
+	}
+}
+
+func TestCreateProject_InvalidStatus(t *testing.T) {
+	repo := &fakeProjectRepo{}
+	uc := &usecase.CreateProjectUsecase{Repo: repo}
+
+	_, err := uc.Execute(context.Background(), usecase.CreateProjectInput{ID: "proj-1", Name: "TeamFlow 開発", Status: "archived", Now: time.Now()})
+	if !errors.Is(err, domain.ErrInvalidStatus) {
+		t.Fatalf("expected ErrInvalidStatus, got %v", err)
+	}
+	if repo.saved != nil {
+		t.Fatalf("expected repo.saved to be nil when validation fails")
+	}
+}
+
+func TestCreateProject_UUIDPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  uuidpolicy.Policy
+		id      string
+		wantErr bool
+	}{
+		{name: "not configured", id: "proj-1"},
+		{name: "any rejects free form id", policy: uuidpolicy.Any, id: "proj-1", wantErr: true},
+		{name: "any accepts v1", policy: uuidpolicy.Any, id: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{name: "v4v7 rejects v1", policy: uuidpolicy.V4V7, id: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", wantErr: true},
+		{name: "v4v7 accepts v7", policy: uuidpolicy.V4V7, id: "01890a5d-ac96-774b-bcce-b302099a8057"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeProjectRepo{}
+			uc := &usecase.CreateProjectUsecase{Repo: repo, UUIDPolicy: tt.policy}
+
+			_, err := uc.Execute(context.Background(), usecase.CreateProjectInput{ID: tt.id, Name: "TeamFlow 開発", Now: time.Now()})
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidID) {
+					t.Fatalf("expected ErrInvalidID, got %v", err)
+				}
+				if repo.saved != nil {
+					t.Fatal("expected repo.saved to be nil when validation fails")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateProject_Key(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		want    string
+		wantErr error
+	}{
+		{name: "unset", key: "", want: ""},
+		{name: "normalized", key: "tflow", want: "TFLOW"},
+		{name: "invalid", key: "T-1", wantErr: domain.ErrInvalidKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeProjectRepo{}
+			uc := &usecase.CreateProjectUsecase{Repo: repo}
+
+			p, err := uc.Execute(context.Background(), usecase.CreateProjectInput{ID: "proj-1", Key: tt.key, Name: "TeamFlow 開発", Now: time.Now()})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				if repo.saved != nil {
+					t.Fatalf("expected repo.saved to be nil when validation fails")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p.Key != tt.want {
+				t.Errorf("expected Key=%q, got=%q", tt.want, p.Key)
+			}
+		})
+	}
+}
+
+func TestCreateProject_UniqueNames(t *testing.T) {
+	now := time.Now()
+	existing, _ := domain.NewProject("proj-1", "TeamFlow 開発", "", now)
+
+	t.Run("duplicate", func(t *testing.T) {
+		repo := &fakeProjectRepo{listOut: []*domain.Project{existing}}
+		uc := &usecase.CreateProjectUsecase{Repo: repo, UniqueNames: true}
+
+		_, err := uc.Execute(context.Background(), usecase.CreateProjectInput{ID: "proj-2", Name: "TeamFlow 開発", Now: now})
+		var dup *usecase.DuplicateNameError
+		if !errors.As(err, &dup) || dup.ProjectID != "proj-1" {
+			t.Fatalf("expected DuplicateNameError for proj-1, got %v", err)
+		}
+		if !errors.Is(err, usecase.ErrProjectNameAlreadyExists) {
+			t.Errorf("expected ErrProjectNameAlreadyExists, got %v", err)
+		}
+		if repo.saved != nil {
+			t.Fatalf("expected repo.saved to be nil when the name is taken")
+		}
+	})
+
+	t.Run("enabled by feature flag", func(t *testing.T) {
+		repo := &fakeProjectRepo{listOut: []*domain.Project{existing}}
+		uc := &usecase.CreateProjectUsecase{Repo: repo, Flags: featureflag.Set{usecase.FlagUniqueProjectNames: true}}
+
+		_, err := uc.Execute(context.Background(), usecase.CreateProjectInput{ID: "proj-2", Name: "TeamFlow 開発", Now: now})
+		if !errors.Is(err, usecase.ErrProjectNameAlreadyExists) {
+			t.Fatalf("expected ErrProjectNameAlreadyExists, got %v", err)
+		}
+	})
+
+	t.Run("constraint disabled", func(t *testing.T) {
+		repo := &fakeProjectRepo{listOut: []*domain.Project{existing}}
+		uc := &usecase.CreateProjectUsecase{Repo: repo}
+
+		if _, err := uc.Execute(context.Background(), usecase.CreateProjectInput{ID: "proj-2", Name: "TeamFlow 開発", Now: now}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
 func TestCreateProject_RepositoryError(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
@@ -0,0 +1,191 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"teamflow-shared/audit"
+	"teamflow-shared/authz"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// TaskCascader はプロジェクトの削除・復元に伴ってタスクを一括で操作する（tasks サービスのクライアント）。
+// いずれも再試行してよい（対象のタスクが無い場合も成功する）。
+type TaskCascader interface {
+	ArchiveTasks(ctx context.Context, projectID string) error
+	UnarchiveTasks(ctx context.Context, projectID string) error
+	DeleteTasks(ctx context.Context, projectID string) error
+}
+
+// DeleteProjectInput はプロジェクト削除ユースケースの入力。
+type DeleteProjectInput struct {
+	ID      string
+	Policy  domain.DeletePolicy // タスクの扱い。空の場合は block
+	ActorID string              // 操作者（UpdatedBy）
+	Now     time.Time
+}
+
+// DeleteProjectUsecase はプロジェクトを削除（論理削除）するユースケース。
+//
+// プロジェクトとタスクは別のサービスにあるため、次の順で処理する（saga）。
+//  1. block の場合はタスクの件数を確認し、1 件でもあれば ErrProjectHasTasks を返す
+//  2. プロジェクトに DeletedAt と DeletePolicy を設定する（以降は取得・一覧の対象外）
+//  3. archive_tasks / delete_tasks の場合は tasks サービスでタスクをアーカイブ・削除する
+//  4. 3 が失敗した場合は 2 を取り消し（補償）、ErrTasksService を返す
+//
+// 3 がタイムアウトなどで実際には成功していた場合も 2 は取り消されるが、
+// tasks サービスの操作は再試行してよいため、もう一度削除すればよい。
+type DeleteProjectUsecase struct {
+	Repo    ProjectRepository
+	Members MemberRepository
+	// Stats は block の場合のタスクの件数の確認に使う（キャッシュを通さないこと）
+	Stats StatsProvider
+	// Tasks は archive_tasks / delete_tasks の場合のタスクの操作に使う
+	Tasks TaskCascader
+	// EnforceRoles が true の場合は操作者のロールを確認する（owner のみ）
+	EnforceRoles bool
+	// Audit は削除を監査ログに記録するために使う。任意。nil の場合は記録しない
+	Audit audit.Recorder
+}
+
+// Execute はプロジェクトを削除し、削除したプロジェクトを返す。
+// Stats / Tasks が nil（tasks サービスが設定されていない）場合は ErrTasksService を返す。
+func (uc *DeleteProjectUsecase) Execute(ctx context.Context, in DeleteProjectInput) (*domain.Project, error) {
+	policy := in.Policy
+	if policy == "" {
+		policy = domain.DeletePolicyBlock
+	}
+
+	existing, err := uc.Repo.FindByID(ctx, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, existing.ID, in.ActorID, domain.ActionDelete); err != nil {
+			return nil, err
+		}
+	}
+
+	if policy == domain.DeletePolicyBlock {
+		if err := uc.ensureNoTasks(ctx, existing.ID); err != nil {
+			return nil, err
+		}
+	} else if uc.Tasks == nil {
+		return nil, fmt.Errorf("%w: tasks service is not configured", ErrTasksService)
+	}
+
+	before := *existing
+	deletedAt := in.Now
+	existing.DeletedAt = &deletedAt
+	existing.DeletePolicy = policy
+	existing.UpdatedAt = in.Now
+	existing.UpdatedBy = in.ActorID
+	if err := uc.Repo.Update(ctx, existing); err != nil {
+		return nil, err
+	}
+
+	// tasks サービスでも操作者を記録するため、操作者を引き継ぐ
+	if err := uc.cascade(authz.ContextWithActor(ctx, in.ActorID), existing.ID, policy); err != nil {
+		if cerr := uc.Repo.Update(ctx, &before); cerr != nil {
+			return nil, fmt.Errorf("%w: %w (failed to undo deletion: %w)", ErrTasksService, err, cerr)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrTasksService, err)
+	}
+	if err := recordAudit(ctx, uc.Audit, existing, audit.ActionDelete, in.ActorID, nil, in.Now); err != nil {
+		return existing, err
+	}
+	return existing, nil
+}
+
+// ensureNoTasks はプロジェクトにタスクが無いことを確認する。
+func (uc *DeleteProjectUsecase) ensureNoTasks(ctx context.Context, projectID string) error {
+	if uc.Stats == nil {
+		return fmt.Errorf("%w: tasks service is not configured", ErrTasksService)
+	}
+	stats, err := uc.Stats.ProjectStats(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrTasksService, err)
+	}
+	if n := stats.Open + stats.Done; n > 0 {
+		return fmt.Errorf("%w: %d tasks (use cascade=archive_tasks or delete_tasks)", ErrProjectHasTasks, n)
+	}
+	return nil
+}
+
+// cascade は policy に従ってタスクをアーカイブ・削除する。
+func (uc *DeleteProjectUsecase) cascade(ctx context.Context, projectID string, policy domain.DeletePolicy) error {
+	switch policy {
+	case domain.DeletePolicyArchiveTasks:
+		return uc.Tasks.ArchiveTasks(ctx, projectID)
+	case domain.DeletePolicyDeleteTasks:
+		return uc.Tasks.DeleteTasks(ctx, projectID)
+	default:
+		return nil
+	}
+}
+
+// RestoreProjectInput はプロジェクト復元ユースケースの入力。
+type RestoreProjectInput struct {
+	ID      string
+	ActorID string // 操作者（UpdatedBy）
+	Now     time.Time
+}
+
+// RestoreProjectUsecase は削除したプロジェクトを復元するユースケース。
+//
+// archive_tasks で削除した場合はアーカイブしたタスクも戻す。
+// delete_tasks で削除した場合はタスクを戻せないため、プロジェクトのみ復元する。
+type RestoreProjectUsecase struct {
+	Repo    ProjectRepository
+	Members MemberRepository
+	Tasks   TaskCascader
+	// Window は削除から復元できる期間
+	Window time.Duration
+	// EnforceRoles が true の場合は操作者のロールを確認する（owner のみ）
+	EnforceRoles bool
+	// Audit は復元を監査ログに記録するために使う。任意。nil の場合は記録しない
+	Audit audit.Recorder
+}
+
+// Execute はプロジェクトの DeletedAt を解除し、復元したプロジェクトを返す。
+//
+// 削除されていない場合は ErrProjectNotFound、復元期間を過ぎている場合は ErrRestoreWindowExpired、
+// タスクを戻せなかった場合は ErrTasksService を返す（プロジェクトは削除されたまま。再試行してよい）。
+func (uc *RestoreProjectUsecase) Execute(ctx context.Context, in RestoreProjectInput) (*domain.Project, error) {
+	deleted, err := uc.Repo.FindDeleted(ctx, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, deleted.ID, in.ActorID, domain.ActionDelete); err != nil {
+			return nil, err
+		}
+	}
+	if !deleted.CanRestore(in.Now, uc.Window) {
+		return nil, fmt.Errorf("%w: restorable until %s", ErrRestoreWindowExpired, deleted.RestorableUntil(uc.Window).Format(time.RFC3339))
+	}
+
+	// タスクを先に戻す（プロジェクトの更新に失敗しても、削除されたプロジェクトのタスクは表示されない）
+	if deleted.DeletePolicy == domain.DeletePolicyArchiveTasks {
+		if uc.Tasks == nil {
+			return nil, fmt.Errorf("%w: tasks service is not configured", ErrTasksService)
+		}
+		if err := uc.Tasks.UnarchiveTasks(authz.ContextWithActor(ctx, in.ActorID), deleted.ID); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrTasksService, err)
+		}
+	}
+
+	deleted.DeletedAt = nil
+	deleted.DeletePolicy = ""
+	deleted.UpdatedAt = in.Now
+	deleted.UpdatedBy = in.ActorID
+	if err := uc.Repo.Update(ctx, deleted); err != nil {
+		return nil, err
+	}
+	if err := recordAudit(ctx, uc.Audit, deleted, audit.ActionRestore, in.ActorID, nil, in.Now); err != nil {
+		return deleted, err
+	}
+	return deleted, nil
+}
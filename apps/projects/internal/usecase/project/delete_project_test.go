@@ -0,0 +1,226 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"teamflow-shared/audit"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// fakeTaskCascader は呼び出しを記録する TaskCascader。
+type fakeTaskCascader struct {
+	calls []string
+	err   error
+}
+
+func (c *fakeTaskCascader) ArchiveTasks(_ context.Context, projectID string) error {
+	c.calls = append(c.calls, "archive:"+projectID)
+	return c.err
+}
+
+func (c *fakeTaskCascader) UnarchiveTasks(_ context.Context, projectID string) error {
+	c.calls = append(c.calls, "unarchive:"+projectID)
+	return c.err
+}
+
+func (c *fakeTaskCascader) DeleteTasks(_ context.Context, projectID string) error {
+	c.calls = append(c.calls, "delete:"+projectID)
+	return c.err
+}
+
+func TestDeleteProject(t *testing.T) {
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	cascadeErr := errors.New("tasks service down")
+
+	tests := []struct {
+		name       string
+		policy     domain.DeletePolicy
+		stats      *domain.Stats
+		cascadeErr error
+		wantErr    error
+		wantCalls  []string
+	}{
+		{name: "block without tasks", policy: domain.DeletePolicyBlock, stats: &domain.Stats{}},
+		{name: "empty policy means block", stats: &domain.Stats{}},
+		{name: "block with tasks", policy: domain.DeletePolicyBlock, stats: &domain.Stats{Open: 1, Done: 2}, wantErr: usecase.ErrProjectHasTasks},
+		{name: "archive tasks", policy: domain.DeletePolicyArchiveTasks, wantCalls: []string{"archive:proj-1"}},
+		{name: "delete tasks", policy: domain.DeletePolicyDeleteTasks, wantCalls: []string{"delete:proj-1"}},
+		{name: "cascade failure is compensated", policy: domain.DeletePolicyArchiveTasks, cascadeErr: cascadeErr, wantErr: usecase.ErrTasksService, wantCalls: []string{"archive:proj-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newExistingProjectRepo(t)
+			tasks := &fakeTaskCascader{err: tt.cascadeErr}
+			uc := &usecase.DeleteProjectUsecase{
+				Repo:  repo,
+				Stats: &fakeStatsProvider{stats: tt.stats},
+				Tasks: tasks,
+			}
+
+			p, err := uc.Execute(context.Background(), usecase.DeleteProjectInput{ID: "proj-1", Policy: tt.policy, Now: now})
+			if len(tasks.calls) != len(tt.wantCalls) || (len(tt.wantCalls) > 0 && tasks.calls[0] != tt.wantCalls[0]) {
+				t.Errorf("expected calls %v, got %v", tt.wantCalls, tasks.calls)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				// 失敗した場合はプロジェクトを削除しない（削除した場合は取り消す）
+				if repo.stored.IsDeleted() {
+					t.Errorf("expected project not to be deleted, got DeletedAt=%v", repo.stored.DeletedAt)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			wantPolicy := tt.policy
+			if wantPolicy == "" {
+				wantPolicy = domain.DeletePolicyBlock
+			}
+			if p.DeletedAt == nil || !p.DeletedAt.Equal(now) || p.DeletePolicy != wantPolicy {
+				t.Errorf("expected deleted at %v with %q, got %v / %q", now, wantPolicy, p.DeletedAt, p.DeletePolicy)
+			}
+			if _, err := repo.FindByID(context.Background(), "proj-1"); !errors.Is(err, usecase.ErrProjectNotFound) {
+				t.Errorf("expected deleted project to be hidden, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDeleteProject_TasksServiceNotConfigured(t *testing.T) {
+	for _, policy := range []domain.DeletePolicy{domain.DeletePolicyBlock, domain.DeletePolicyArchiveTasks, domain.DeletePolicyDeleteTasks} {
+		uc := &usecase.DeleteProjectUsecase{Repo: newExistingProjectRepo(t)}
+
+		_, err := uc.Execute(context.Background(), usecase.DeleteProjectInput{ID: "proj-1", Policy: policy, Now: time.Now()})
+		if !errors.Is(err, usecase.ErrTasksService) {
+			t.Errorf("%s: expected ErrTasksService, got %v", policy, err)
+		}
+	}
+}
+
+func TestDeleteProject_EnforceRoles(t *testing.T) {
+	uc := &usecase.DeleteProjectUsecase{
+		Repo:         newExistingProjectRepo(t),
+		Members:      newRoleMembers(),
+		Stats:        &fakeStatsProvider{stats: &domain.Stats{}},
+		EnforceRoles: true,
+	}
+
+	_, err := uc.Execute(context.Background(), usecase.DeleteProjectInput{ID: "proj-1", ActorID: "admin-1", Now: time.Now()})
+	var roleErr *domain.InsufficientRoleError
+	if !errors.As(err, &roleErr) || roleErr.Action != domain.ActionDelete {
+		t.Fatalf("expected InsufficientRoleError for admin, got %v", err)
+	}
+
+	if _, err := uc.Execute(context.Background(), usecase.DeleteProjectInput{ID: "proj-1", ActorID: "owner-1", Now: time.Now()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDeleteProject_RecordsActor(t *testing.T) {
+	repo := newExistingProjectRepo(t)
+	rec := audit.NewMemoryRecorder()
+	del := &usecase.DeleteProjectUsecase{Repo: repo, Stats: &fakeStatsProvider{stats: &domain.Stats{}}, Audit: rec}
+	restore := &usecase.RestoreProjectUsecase{Repo: repo, Window: time.Hour, Audit: rec}
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+
+	p, err := del.Execute(context.Background(), usecase.DeleteProjectInput{ID: "proj-1", ActorID: "user-1", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.UpdatedBy != "user-1" {
+		t.Errorf("expected UpdatedBy=user-1, got %q", p.UpdatedBy)
+	}
+	p, err = restore.Execute(context.Background(), usecase.RestoreProjectInput{ID: "proj-1", ActorID: "user-2", Now: now.Add(time.Minute)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.UpdatedBy != "user-2" {
+		t.Errorf("expected UpdatedBy=user-2, got %q", p.UpdatedBy)
+	}
+
+	entries := rec.Entries()
+	if len(entries) != 2 || entries[0].Action != audit.ActionDelete || entries[0].ActorID != "user-1" ||
+		entries[1].Action != audit.ActionRestore || entries[1].ActorID != "user-2" {
+		t.Errorf("unexpected audit entries: %+v", entries)
+	}
+}
+
+func TestRestoreProject(t *testing.T) {
+	deletedAt := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	window := 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		policy    domain.DeletePolicy
+		now       time.Time
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "within window", policy: domain.DeletePolicyBlock, now: deletedAt.Add(time.Hour)},
+		{name: "archived tasks are unarchived", policy: domain.DeletePolicyArchiveTasks, now: deletedAt.Add(time.Hour), wantCalls: 1},
+		{name: "deleted tasks are not restored", policy: domain.DeletePolicyDeleteTasks, now: deletedAt.Add(time.Hour)},
+		{name: "window expired", policy: domain.DeletePolicyArchiveTasks, now: deletedAt.Add(window), wantErr: usecase.ErrRestoreWindowExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newExistingProjectRepo(t)
+			at := deletedAt
+			repo.stored.DeletedAt = &at
+			repo.stored.DeletePolicy = tt.policy
+			tasks := &fakeTaskCascader{}
+			uc := &usecase.RestoreProjectUsecase{Repo: repo, Tasks: tasks, Window: window}
+
+			p, err := uc.Execute(context.Background(), usecase.RestoreProjectInput{ID: "proj-1", Now: tt.now})
+			if len(tasks.calls) != tt.wantCalls {
+				t.Errorf("expected %d task calls, got %v", tt.wantCalls, tasks.calls)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p.IsDeleted() || p.DeletePolicy != "" || !p.UpdatedAt.Equal(tt.now) {
+				t.Errorf("expected project to be restored, got %+v", p)
+			}
+		})
+	}
+}
+
+func TestRestoreProject_NotDeleted(t *testing.T) {
+	uc := &usecase.RestoreProjectUsecase{Repo: newExistingProjectRepo(t), Window: time.Hour}
+
+	_, err := uc.Execute(context.Background(), usecase.RestoreProjectInput{ID: "proj-1", Now: time.Now()})
+	if !errors.Is(err, usecase.ErrProjectNotFound) {
+		t.Fatalf("expected ErrProjectNotFound, got %v", err)
+	}
+}
+
+func TestRestoreProject_UnarchiveFailure(t *testing.T) {
+	repo := newExistingProjectRepo(t)
+	deletedAt := time.Now()
+	repo.stored.DeletedAt = &deletedAt
+	repo.stored.DeletePolicy = domain.DeletePolicyArchiveTasks
+	uc := &usecase.RestoreProjectUsecase{Repo: repo, Tasks: &fakeTaskCascader{err: errors.New("down")}, Window: time.Hour}
+
+	_, err := uc.Execute(context.Background(), usecase.RestoreProjectInput{ID: "proj-1", Now: deletedAt})
+	if !errors.Is(err, usecase.ErrTasksService) {
+		t.Fatalf("expected ErrTasksService, got %v", err)
+	}
+	if !repo.stored.IsDeleted() {
+		t.Errorf("expected project to remain deleted")
+	}
+}
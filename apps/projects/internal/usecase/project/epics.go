@@ -0,0 +1,204 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// EpicRepository はエピックの永続化・取得を担当する抽象。
+type EpicRepository interface {
+	// SaveEpic はエピックを保存する。
+	// プロジェクト内に同じ ID が既にある場合は ErrEpicAlreadyExists、
+	// プロジェクトが存在しない場合は ErrProjectNotFound 相当のエラーを返す。
+	SaveEpic(ctx context.Context, e *domain.Epic) error
+	// UpdateEpic はエピックを更新する。存在しない場合は ErrEpicNotFound 相当のエラーを返す。
+	UpdateEpic(ctx context.Context, e *domain.Epic) error
+	// DeleteEpic はエピックを削除する。存在しない場合は ErrEpicNotFound 相当のエラーを返す。
+	DeleteEpic(ctx context.Context, projectID, id string) error
+	// FindEpic はエピックを 1 件取得する。存在しない場合は ErrEpicNotFound 相当のエラーを返す。
+	FindEpic(ctx context.Context, projectID, id string) (*domain.Epic, error)
+	// ListEpics はプロジェクトのエピックを作成日時・ID の昇順で返す。
+	ListEpics(ctx context.Context, projectID string) ([]*domain.Epic, error)
+}
+
+// EpicStatsProvider はプロジェクトのタスクをエピックごとに集計する（tasks サービスのクライアント）。
+// 返り値は epicID をキーとし、タスクの無いエピックは含まなくてよい。
+type EpicStatsProvider interface {
+	EpicStats(ctx context.Context, projectID string) (map[string]domain.EpicTaskCounts, error)
+}
+
+// CreateEpicInput はエピック作成ユースケースの入力。
+type CreateEpicInput struct {
+	ProjectID   string
+	ID          string
+	Name        string
+	Description string
+	Status      string // 空の場合は open
+	ActorID     string // 操作者
+	Now         time.Time
+}
+
+// CreateEpicUsecase はエピック作成ユースケース。
+type CreateEpicUsecase struct {
+	Projects ProjectRepository
+	Members  MemberRepository
+	Epics    EpicRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（プロジェクトの編集と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute はプロジェクトの存在と操作者のロールを確認してからエピックを保存する。
+// 不正な値の場合は domain.ErrInvalidEpic を返す。
+func (uc *CreateEpicUsecase) Execute(ctx context.Context, in CreateEpicInput) (*domain.Epic, error) {
+	e, err := domain.NewEpic(in.ID, in.ProjectID, in.Name, in.Description, in.Status, in.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.Projects.FindByID(ctx, in.ProjectID); err != nil {
+		return nil, err
+	}
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, domain.ActionEdit); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Epics.SaveEpic(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// UpdateEpicInput はエピック更新ユースケースの入力。
+// 名前・説明・状態を置き換える（省略した説明は空、状態は open になる）。
+type UpdateEpicInput struct {
+	ProjectID   string
+	ID          string
+	Name        string
+	Description string
+	Status      string
+	ActorID     string // 操作者
+	Now         time.Time
+}
+
+// UpdateEpicUsecase はエピック更新ユースケース。
+type UpdateEpicUsecase struct {
+	Members MemberRepository
+	Epics   EpicRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（プロジェクトの編集と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute は既存のエピックを取得し、値を置き換えて保存する。
+func (uc *UpdateEpicUsecase) Execute(ctx context.Context, in UpdateEpicInput) (*domain.Epic, error) {
+	e, err := uc.Epics.FindEpic(ctx, in.ProjectID, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, domain.ActionEdit); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := e.Update(in.Name, in.Description, in.Status, in.Now); err != nil {
+		return nil, err
+	}
+	if err := uc.Epics.UpdateEpic(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// DeleteEpicInput はエピック削除ユースケースの入力。
+type DeleteEpicInput struct {
+	ProjectID string
+	ID        string
+	ActorID   string // 操作者
+}
+
+// DeleteEpicUsecase はエピック削除ユースケース。
+// エピックに属していたタスクの epicId は tasks サービス側に残る（進捗の集計には含まれなくなる）。
+type DeleteEpicUsecase struct {
+	Members MemberRepository
+	Epics   EpicRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（プロジェクトの編集と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute は操作者のロールを確認してからエピックを削除する。
+func (uc *DeleteEpicUsecase) Execute(ctx context.Context, in DeleteEpicInput) error {
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, domain.ActionEdit); err != nil {
+			return err
+		}
+	}
+	return uc.Epics.DeleteEpic(ctx, in.ProjectID, in.ID)
+}
+
+// ListEpicsUsecase はエピック一覧取得ユースケース。
+type ListEpicsUsecase struct {
+	Projects ProjectRepository
+	Members  MemberRepository
+	// EnforceRoles が true の場合は閲覧権限を確認する（公開プロジェクトは誰でも、非公開プロジェクトはメンバーのみ）
+	EnforceRoles bool
+	Epics        EpicRepository
+}
+
+// Execute はプロジェクトの存在と閲覧権限を確認してからエピックを返す。
+func (uc *ListEpicsUsecase) Execute(ctx context.Context, projectID, actorID string) ([]*domain.Epic, error) {
+	if _, err := findReadableProject(ctx, uc.Projects, uc.Members, uc.EnforceRoles, projectID, actorID); err != nil {
+		return nil, err
+	}
+	return uc.Epics.ListEpics(ctx, projectID)
+}
+
+// GetEpicUsecase はエピック取得ユースケース。
+// tasks サービスがタスクに設定する epicId の存在チェックにも使う。
+type GetEpicUsecase struct {
+	Epics EpicRepository
+}
+
+// Execute はエピックを返す。存在しない場合は ErrEpicNotFound を返す。
+func (uc *GetEpicUsecase) Execute(ctx context.Context, projectID, id string) (*domain.Epic, error) {
+	return uc.Epics.FindEpic(ctx, projectID, id)
+}
+
+// GetEpicProgressUsecase はエピックごとのタスクの進捗（完了・全体の件数と見積もりの合計）を取得するユースケース。
+type GetEpicProgressUsecase struct {
+	Projects ProjectRepository
+	Members  MemberRepository
+	// EnforceRoles が true の場合は閲覧権限を確認する（公開プロジェクトは誰でも、非公開プロジェクトはメンバーのみ）
+	EnforceRoles bool
+	Epics        EpicRepository
+	// Stats は集計の取得に使う。nil の場合は ErrTasksService を返す
+	Stats EpicStatsProvider
+}
+
+// Execute はプロジェクトのエピックを一覧の順で、タスクの件数・見積もりの合計と合わせて返す。
+// 集計の取得に失敗した場合は ErrTasksService でラップしたエラーを返す。
+func (uc *GetEpicProgressUsecase) Execute(ctx context.Context, projectID, actorID string) ([]domain.EpicProgress, error) {
+	if _, err := findReadableProject(ctx, uc.Projects, uc.Members, uc.EnforceRoles, projectID, actorID); err != nil {
+		return nil, err
+	}
+	epics, err := uc.Epics.ListEpics(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(epics) == 0 {
+		return []domain.EpicProgress{}, nil
+	}
+	if uc.Stats == nil {
+		return nil, fmt.Errorf("%w: tasks service is not configured", ErrTasksService)
+	}
+
+	counts, err := uc.Stats.EpicStats(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTasksService, err)
+	}
+	return domain.ComputeEpicProgress(epics, counts), nil
+}
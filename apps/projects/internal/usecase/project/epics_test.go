@@ -0,0 +1,197 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// fakeEpicRepo は EpicRepository のテスト用フェイク実装（登録順に返す）。
+type fakeEpicRepo struct {
+	epics []*domain.Epic
+}
+
+func (r *fakeEpicRepo) index(projectID, id string) int {
+	for i, e := range r.epics {
+		if e.ProjectID == projectID && e.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *fakeEpicRepo) SaveEpic(_ context.Context, e *domain.Epic) error {
+	if r.index(e.ProjectID, e.ID) >= 0 {
+		return usecase.ErrEpicAlreadyExists
+	}
+	r.epics = append(r.epics, e)
+	return nil
+}
+
+func (r *fakeEpicRepo) UpdateEpic(_ context.Context, e *domain.Epic) error {
+	i := r.index(e.ProjectID, e.ID)
+	if i < 0 {
+		return usecase.ErrEpicNotFound
+	}
+	r.epics[i] = e
+	return nil
+}
+
+func (r *fakeEpicRepo) DeleteEpic(_ context.Context, projectID, id string) error {
+	i := r.index(projectID, id)
+	if i < 0 {
+		return usecase.ErrEpicNotFound
+	}
+	r.epics = append(r.epics[:i], r.epics[i+1:]...)
+	return nil
+}
+
+func (r *fakeEpicRepo) FindEpic(_ context.Context, projectID, id string) (*domain.Epic, error) {
+	i := r.index(projectID, id)
+	if i < 0 {
+		return nil, usecase.ErrEpicNotFound
+	}
+	return r.epics[i], nil
+}
+
+func (r *fakeEpicRepo) ListEpics(_ context.Context, projectID string) ([]*domain.Epic, error) {
+	out := make([]*domain.Epic, 0)
+	for _, e := range r.epics {
+		if e.ProjectID == projectID {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// fakeEpicStats は EpicStatsProvider のテスト用フェイク実装。
+type fakeEpicStats struct {
+	counts map[string]domain.EpicTaskCounts
+	err    error
+	calls  int
+}
+
+func (p *fakeEpicStats) EpicStats(_ context.Context, _ string) (map[string]domain.EpicTaskCounts, error) {
+	p.calls++
+	return p.counts, p.err
+}
+
+func TestEpics_CRUD(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	epics := &fakeEpicRepo{}
+	projects := newExistingProjectRepo(t)
+
+	createUC := &usecase.CreateEpicUsecase{Projects: projects, Members: newRoleMembers(), Epics: epics, EnforceRoles: true}
+	if _, err := createUC.Execute(ctx, usecase.CreateEpicInput{ProjectID: "missing", ID: "e1", Name: "e1", ActorID: "member-1", Now: now}); err == nil {
+		t.Fatal("expected error for missing project")
+	}
+	e, err := createUC.Execute(ctx, usecase.CreateEpicInput{ProjectID: "proj-1", ID: "e1", Name: "e1", Description: "SSO 対応", ActorID: "member-1", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.Status != domain.EpicOpen || e.ProjectID != "proj-1" || e.Description != "SSO 対応" {
+		t.Errorf("unexpected epic: %+v", e)
+	}
+
+	tests := []struct {
+		name    string
+		in      usecase.CreateEpicInput
+		wantErr error
+	}{
+		{name: "duplicate id", in: usecase.CreateEpicInput{ProjectID: "proj-1", ID: "e1", Name: "e1", ActorID: "member-1"}, wantErr: usecase.ErrEpicAlreadyExists},
+		{name: "invalid status", in: usecase.CreateEpicInput{ProjectID: "proj-1", ID: "e2", Name: "e2", Status: "done", ActorID: "member-1"}, wantErr: domain.ErrInvalidEpic},
+		{name: "non-member is forbidden", in: usecase.CreateEpicInput{ProjectID: "proj-1", ID: "e2", Name: "e2", ActorID: "stranger"}, wantErr: domain.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.in.Now = now
+			if _, err := createUC.Execute(ctx, tt.in); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	updateUC := &usecase.UpdateEpicUsecase{Members: newRoleMembers(), Epics: epics, EnforceRoles: true}
+	updated, err := updateUC.Execute(ctx, usecase.UpdateEpicInput{ProjectID: "proj-1", ID: "e1", Name: "e1'", Status: "closed", ActorID: "member-1", Now: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Name != "e1'" || updated.Status != domain.EpicClosed {
+		t.Errorf("unexpected epic: %+v", updated)
+	}
+	if _, err := updateUC.Execute(ctx, usecase.UpdateEpicInput{ProjectID: "proj-1", ID: "missing", Name: "x", ActorID: "member-1"}); !errors.Is(err, usecase.ErrEpicNotFound) {
+		t.Errorf("expected ErrEpicNotFound, got %v", err)
+	}
+
+	listUC := &usecase.ListEpicsUsecase{Projects: projects, Epics: epics}
+	list, err := listUC.Execute(ctx, "proj-1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 1 || list[0].Name != "e1'" {
+		t.Errorf("unexpected list: %+v", list)
+	}
+
+	deleteUC := &usecase.DeleteEpicUsecase{Members: newRoleMembers(), Epics: epics, EnforceRoles: true}
+	if err := deleteUC.Execute(ctx, usecase.DeleteEpicInput{ProjectID: "proj-1", ID: "e1", ActorID: "stranger"}); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+	if err := deleteUC.Execute(ctx, usecase.DeleteEpicInput{ProjectID: "proj-1", ID: "e1", ActorID: "member-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	getUC := &usecase.GetEpicUsecase{Epics: epics}
+	if _, err := getUC.Execute(ctx, "proj-1", "e1"); !errors.Is(err, usecase.ErrEpicNotFound) {
+		t.Errorf("expected ErrEpicNotFound, got %v", err)
+	}
+}
+
+func TestGetEpicProgress(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	newUsecase := func(t *testing.T, stats usecase.EpicStatsProvider, ids ...string) *usecase.GetEpicProgressUsecase {
+		t.Helper()
+		epics := &fakeEpicRepo{}
+		for _, id := range ids {
+			e, err := domain.NewEpic(id, "proj-1", id, "", "", now)
+			if err != nil {
+				t.Fatalf("failed to create epic: %v", err)
+			}
+			if err := epics.SaveEpic(ctx, e); err != nil {
+				t.Fatalf("failed to save epic: %v", err)
+			}
+		}
+		return &usecase.GetEpicProgressUsecase{Projects: newExistingProjectRepo(t), Epics: epics, Stats: stats}
+	}
+
+	stats := &fakeEpicStats{counts: map[string]domain.EpicTaskCounts{"e1": {Total: 3, Done: 2, EstimateTotal: 8, EstimateDone: 5}}}
+	got, err := newUsecase(t, stats, "e1", "e2").Execute(ctx, "proj-1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].Total != 3 || got[0].Done != 2 || got[0].EstimateDone != 5 || got[1].Total != 0 {
+		t.Errorf("unexpected progress: %+v", got)
+	}
+
+	// エピックが無い場合は tasks サービスを呼ばない
+	empty := &fakeEpicStats{}
+	got, err = newUsecase(t, empty).Execute(ctx, "proj-1", "")
+	if err != nil || len(got) != 0 || empty.calls != 0 {
+		t.Errorf("unexpected result: %+v, %v (calls=%d)", got, err, empty.calls)
+	}
+
+	if _, err := newUsecase(t, nil, "e1").Execute(ctx, "proj-1", ""); !errors.Is(err, usecase.ErrTasksService) {
+		t.Errorf("expected ErrTasksService when not configured, got %v", err)
+	}
+	if _, err := newUsecase(t, &fakeEpicStats{err: errors.New("boom")}, "e1").Execute(ctx, "proj-1", ""); !errors.Is(err, usecase.ErrTasksService) {
+		t.Errorf("expected ErrTasksService, got %v", err)
+	}
+	if _, err := newUsecase(t, stats, "e1").Execute(ctx, "missing", ""); err == nil || stats.calls != 1 {
+		t.Errorf("expected project lookup error without calling tasks service, got %v (calls=%d)", err, stats.calls)
+	}
+}
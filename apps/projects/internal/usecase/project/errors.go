@@ -0,0 +1,57 @@
+package project
+
+import "errors"
+
+// Sentinel errors used by project usecases.
+// リポジトリ実装はこれらを返す（infrastructure 層では同じ値を別名で公開している）。
+var (
+	ErrProjectNotFound         = errors.New("project not found")
+	ErrProjectKeyAlreadyExists = errors.New("project key already exists")
+	ErrMemberNotFound          = errors.New("member not found")
+	ErrMemberAlreadyExists     = errors.New("member already exists")
+	ErrSettingsNotFound        = errors.New("project settings not found")
+	ErrTemplateNotFound        = errors.New("project template not found")
+	ErrTemplateAlreadyExists   = errors.New("project template already exists")
+	ErrMilestoneNotFound       = errors.New("milestone not found")
+	ErrMilestoneAlreadyExists  = errors.New("milestone already exists")
+	ErrSprintNotFound          = errors.New("sprint not found")
+	ErrSprintAlreadyExists     = errors.New("sprint already exists")
+	ErrEpicNotFound            = errors.New("epic not found")
+	ErrEpicAlreadyExists       = errors.New("epic already exists")
+	ErrLabelNotFound           = errors.New("label not found")
+	ErrLabelAlreadyExists      = errors.New("label already exists")
+	ErrLabelNameAlreadyExists  = errors.New("label name already exists")
+	ErrInvitationNotFound      = errors.New("invitation not found")
+)
+
+// ErrTasksService は tasks サービスの呼び出し（タスクの取得・作成）に失敗した場合に返す。
+// テンプレートからの作成・複製では、プロジェクトの作成は取り消される。
+var ErrTasksService = errors.New("tasks service request failed")
+
+// ErrSprintAlreadyActive はプロジェクトに開始中のスプリントがあるのに、別のスプリントを開始しようとした場合に返す。
+var ErrSprintAlreadyActive = errors.New("another sprint is already active")
+
+// ErrProjectHasTasks は cascade=block の削除で、プロジェクトにタスクがある場合に返す。
+var ErrProjectHasTasks = errors.New("project has tasks")
+
+// ErrRestoreWindowExpired は削除から復元期間（RestoreProjectUsecase.Window）を過ぎたプロジェクトを復元しようとした場合に返す。
+var ErrRestoreWindowExpired = errors.New("restore window has expired")
+
+// ErrProjectNameAlreadyExists は名前の一意制約（UniqueNames）が有効で、同じ名前のプロジェクトが既に存在する場合に返す。
+// 実際には *DuplicateNameError として返すため、errors.As で既存プロジェクトの ID を取り出せる。
+var ErrProjectNameAlreadyExists = errors.New("project name already exists")
+
+// DuplicateNameError は名前が重複する既存プロジェクトを表すエラー。
+type DuplicateNameError struct {
+	// ProjectID は同じ名前を持つ既存プロジェクトの ID
+	ProjectID string
+}
+
+func (e *DuplicateNameError) Error() string {
+	return ErrProjectNameAlreadyExists.Error() + ": " + e.ProjectID
+}
+
+// Unwrap は errors.Is(err, ErrProjectNameAlreadyExists) を成立させる。
+func (e *DuplicateNameError) Unwrap() error {
+	return ErrProjectNameAlreadyExists
+}
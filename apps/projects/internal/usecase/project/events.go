@@ -0,0 +1,28 @@
+package project
+
+import (
+	"context"
+
+	"teamflow-shared/events"
+	"teamflow-shared/outbox"
+	"teamflow-shared/workspace"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// recordEvent は w が設定されていればプロジェクト p の typ のドメインイベントを outbox に記録する。
+// イベントの配信は任意のため、w が nil の構成（テスト等）では何もしない。
+func recordEvent(ctx context.Context, w outbox.Writer, typ events.Type, p *domain.Project, actorID string) error {
+	if w == nil {
+		return nil
+	}
+	data := events.ProjectData{
+		ID:         p.ID,
+		Key:        p.Key,
+		Name:       p.Name,
+		Status:     string(p.Status),
+		Visibility: string(p.Visibility),
+		ActorID:    actorID,
+	}
+	return w.Append(ctx, events.NewProjectEvent(typ, workspace.FromContext(ctx), data, p.UpdatedAt))
+}
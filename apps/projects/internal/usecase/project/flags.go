@@ -0,0 +1,16 @@
+package project
+
+import (
+	"context"
+
+	"teamflow-shared/featureflag"
+)
+
+// FlagUniqueProjectNames はプロジェクト名の一意制約を有効にするフィーチャーフラグ。
+// UNIQUE_PROJECT_NAMES（UniqueNames）を全体で有効にする前に、デプロイ単位で段階的に試すために使う。
+const FlagUniqueProjectNames = "unique-project-names"
+
+// flagEnabled は flags が設定されていて name が有効かどうかを返す。nil の場合は無効。
+func flagEnabled(ctx context.Context, flags featureflag.Provider, name string) bool {
+	return flags != nil && flags.Enabled(ctx, name)
+}
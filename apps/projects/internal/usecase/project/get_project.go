@@ -0,0 +1,28 @@
+package project
+
+import (
+	"context"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// GetProjectUsecase はプロジェクト詳細取得ユースケース。
+type GetProjectUsecase struct {
+	Repo    ProjectRepository
+	Members MemberRepository
+	// EnforceRoles が true の場合は閲覧権限を確認する（公開プロジェクトは誰でも、非公開プロジェクトはメンバーのみ）
+	EnforceRoles bool
+}
+
+// Execute は ID を指定してプロジェクトを 1 件取得する。
+// EnforceRoles で actorID が閲覧できない場合は domain.ErrActorRequired / domain.ErrForbidden を返す。
+func (uc *GetProjectUsecase) Execute(ctx context.Context, id, actorID string) (*domain.Project, error) {
+	return findReadableProject(ctx, uc.Repo, uc.Members, uc.EnforceRoles, id, actorID)
+}
+
+// Exists は context のワークスペースに削除されていない ID のプロジェクトがあるかを確認する（閲覧権限は確認しない）。
+// 存在しない場合は ErrProjectNotFound を返す。
+func (uc *GetProjectUsecase) Exists(ctx context.Context, id string) error {
+	_, err := uc.Repo.FindByID(ctx, id)
+	return err
+}
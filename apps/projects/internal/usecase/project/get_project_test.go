@@ -0,0 +1,45 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+func TestGetProject_Success(t *testing.T) {
+	existing, err := domain.NewProject("proj-1", "TeamFlow 開発", "説明", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error creating existing project: %v", err)
+	}
+
+	repo := &fakeUpdateRepo{stored: existing}
+	uc := &usecase.GetProjectUsecase{Repo: repo}
+
+	p, err := uc.Execute(context.Background(), "proj-1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if p.ID != "proj-1" {
+		t.Errorf("expected ID=proj-1, got=%s", p.ID)
+	}
+}
+
+func TestGetProject_FindError(t *testing.T) {
+	findErr := errors.New("db error")
+	repo := &fakeUpdateRepo{findErr: findErr}
+	uc := &usecase.GetProjectUsecase{Repo: repo}
+
+	p, err := uc.Execute(context.Background(), "proj-1", "")
+	if !errors.Is(err, findErr) {
+		t.Fatalf("expected error %v, got %v", findErr, err)
+	}
+
+	if p != nil {
+		t.Fatalf("expected project to be nil when find fails")
+	}
+}
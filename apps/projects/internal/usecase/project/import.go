@@ -0,0 +1,63 @@
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"teamflow-shared/authz"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// ImportTasksInput はタスクのインポートユースケースの入力。
+type ImportTasksInput struct {
+	ProjectID string
+	Source    domain.ImportSource
+	Data      []byte // インポート元のエクスポート（JSON）
+	// DryRun が true の場合は変換とレポートのみ行い、タスクを作成しない
+	DryRun  bool
+	ActorID string
+}
+
+// ImportTasksUsecase は Trello / Jira のエクスポートをプロジェクトのタスクとしてインポートするユースケース。
+type ImportTasksUsecase struct {
+	Projects ProjectRepository
+	Members  MemberRepository
+	Tasks    TaskSeeder
+	// EnforceRoles が true の場合は操作者が owner / admin であることを確認する
+	EnforceRoles bool
+}
+
+// Execute はエクスポートをタスクに変換し、DryRun でなければ tasks サービスで一括作成する。
+// 戻り値の ImportPlan は作成した（DryRun の場合は作成する）タスクとマッピングのレポート。
+//
+// エクスポートが不正な場合は domain.ErrInvalidImport、プロジェクトが存在しない場合は ErrProjectNotFound、
+// tasks サービスの呼び出しに失敗した場合は ErrTasksService を返す（タスクは 1 件も作成されない）。
+func (uc *ImportTasksUsecase) Execute(ctx context.Context, in ImportTasksInput) (*domain.ImportPlan, error) {
+	p, err := uc.Projects.FindByID(ctx, in.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, p.ID, in.ActorID, domain.ActionImport); err != nil {
+			return nil, err
+		}
+	}
+
+	plan, err := domain.ParseImport(in.Source, in.Data)
+	if err != nil {
+		return nil, err
+	}
+	if in.DryRun || len(plan.Tasks) == 0 {
+		return plan, nil
+	}
+
+	if uc.Tasks == nil {
+		return nil, fmt.Errorf("%w: tasks service is not configured", ErrTasksService)
+	}
+	// tasks サービスでも作成者（createdBy）を記録するため、操作者を引き継ぐ
+	if err := uc.Tasks.SeedTasks(authz.ContextWithActor(ctx, in.ActorID), p.ID, plan.Tasks); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTasksService, err)
+	}
+	return plan, nil
+}
@@ -0,0 +1,103 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+const trelloBoard = `{
+	"lists": [{"id": "l1", "name": "To Do"}, {"id": "l2", "name": "Done"}],
+	"cards": [{"id": "c1", "name": "設計", "idList": "l1"}, {"id": "c2", "name": "要件", "idList": "l2"}]
+}`
+
+func TestImportTasks(t *testing.T) {
+	tests := []struct {
+		name      string
+		actor     string
+		source    domain.ImportSource
+		data      string
+		dryRun    bool
+		seedErr   error
+		wantErr   error
+		wantSeeds int
+	}{
+		{name: "import", actor: "admin-1", source: domain.ImportSourceTrello, data: trelloBoard, wantSeeds: 2},
+		{name: "dry run", actor: "owner-1", source: domain.ImportSourceTrello, data: trelloBoard, dryRun: true},
+		{name: "nothing to import", actor: "owner-1", source: domain.ImportSourceJira, data: `{"issues":[]}`},
+		{name: "member", actor: "member-1", source: domain.ImportSourceTrello, data: trelloBoard, wantErr: domain.ErrForbidden},
+		{name: "no actor", source: domain.ImportSourceTrello, data: trelloBoard, wantErr: domain.ErrActorRequired},
+		{name: "invalid export", actor: "owner-1", source: domain.ImportSourceJira, data: trelloBoard, wantErr: domain.ErrInvalidImport},
+		{name: "tasks service fails", actor: "owner-1", source: domain.ImportSourceTrello, data: trelloBoard, seedErr: errors.New("unavailable"), wantErr: usecase.ErrTasksService},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seeder := &fakeSeeder{err: tt.seedErr}
+			uc := &usecase.ImportTasksUsecase{
+				Projects:     newExistingProjectRepo(t),
+				Members:      newRoleMembers(),
+				Tasks:        seeder,
+				EnforceRoles: true,
+			}
+
+			plan, err := uc.Execute(context.Background(), usecase.ImportTasksInput{
+				ProjectID: "proj-1", Source: tt.source, Data: []byte(tt.data), DryRun: tt.dryRun, ActorID: tt.actor,
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(seeder.tasks) != tt.wantSeeds {
+				t.Errorf("expected %d seeded tasks, got %d", tt.wantSeeds, len(seeder.tasks))
+			}
+			if tt.wantSeeds > 0 && seeder.projectID != "proj-1" {
+				t.Errorf("expected tasks to be seeded into proj-1, got %q", seeder.projectID)
+			}
+			if tt.source == domain.ImportSourceTrello && len(plan.Tasks) != 2 {
+				t.Errorf("expected the plan to have 2 tasks, got %+v", plan.Tasks)
+			}
+		})
+	}
+}
+
+func TestImportTasks_ProjectNotFound(t *testing.T) {
+	seeder := &fakeSeeder{}
+	uc := &usecase.ImportTasksUsecase{
+		Projects:     &fakeUpdateRepo{findErr: usecase.ErrProjectNotFound},
+		Members:      newRoleMembers(),
+		Tasks:        seeder,
+		EnforceRoles: true,
+	}
+	_, err := uc.Execute(context.Background(), usecase.ImportTasksInput{
+		ProjectID: "missing", Source: domain.ImportSourceTrello, Data: []byte(trelloBoard), ActorID: "owner-1",
+	})
+	if !errors.Is(err, usecase.ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+	if seeder.tasks != nil {
+		t.Error("expected no tasks to be seeded")
+	}
+}
+
+func TestImportTasks_TasksServiceNotConfigured(t *testing.T) {
+	uc := &usecase.ImportTasksUsecase{Projects: newExistingProjectRepo(t), Members: newRoleMembers()}
+
+	// dryRun は tasks サービスが無くても実行できる
+	in := usecase.ImportTasksInput{ProjectID: "proj-1", Source: domain.ImportSourceTrello, Data: []byte(trelloBoard), DryRun: true}
+	if _, err := uc.Execute(context.Background(), in); err != nil {
+		t.Fatalf("unexpected error for dry run: %v", err)
+	}
+	in.DryRun = false
+	if _, err := uc.Execute(context.Background(), in); !errors.Is(err, usecase.ErrTasksService) {
+		t.Errorf("expected ErrTasksService, got %v", err)
+	}
+}
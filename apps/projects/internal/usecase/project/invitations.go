@@ -0,0 +1,203 @@
+package project
+
+import (
+	"context"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// InvitationRepository は招待の永続化・取得を担当する抽象。
+type InvitationRepository interface {
+	// SaveInvitation は招待を保存する。プロジェクトが存在しない場合は ErrProjectNotFound 相当のエラーを返す。
+	SaveInvitation(ctx context.Context, inv *domain.Invitation) error
+	// FindInvitation は招待を 1 件取得する（取り消し済みを含む）。存在しない場合は ErrInvitationNotFound 相当のエラーを返す。
+	FindInvitation(ctx context.Context, projectID, id string) (*domain.Invitation, error)
+	// ListInvitations はプロジェクトの取り消されていない招待を発行日時の新しい順（同時刻は ID 順）で返す。
+	// 期限切れの招待も含む。
+	ListInvitations(ctx context.Context, projectID string) ([]*domain.Invitation, error)
+	// RevokeInvitation は取り消されていない招待に取り消し日時を設定する。
+	// 存在しない、または既に取り消されている場合は ErrInvitationNotFound 相当のエラーを返す。
+	RevokeInvitation(ctx context.Context, projectID, id string, revokedAt time.Time) error
+}
+
+// CreateInvitationInput は招待の発行ユースケースの入力。
+type CreateInvitationInput struct {
+	ProjectID string
+	Role      string        // 空の場合は member（owner は指定できない）
+	TTL       time.Duration // 有効期間。0 の場合は domain.DefaultInvitationTTL
+	ActorID   string        // 発行者
+	Now       time.Time
+}
+
+// CreateInvitationUsecase は招待リンクを発行するユースケース。
+type CreateInvitationUsecase struct {
+	Projects    ProjectRepository
+	Members     MemberRepository
+	Invitations InvitationRepository
+	// Secret は招待トークンの署名に使う
+	Secret []byte
+	// EnforceRoles が true の場合は操作者のロールを確認する（メンバーの追加と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute はプロジェクトの存在と操作者のロールを確認してから招待を保存し、招待とトークンを返す。
+// 不正な値の場合は domain.ErrInvalidInvitation を返す。
+func (uc *CreateInvitationUsecase) Execute(ctx context.Context, in CreateInvitationInput) (*domain.Invitation, string, error) {
+	inv, err := domain.NewInvitation(domain.NewInvitationID(), in.ProjectID, in.Role, in.ActorID, in.TTL, in.Now)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if _, err := uc.Projects.FindByID(ctx, in.ProjectID); err != nil {
+		return nil, "", err
+	}
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, domain.ActionManageMembers); err != nil {
+			return nil, "", err
+		}
+	}
+
+	token, err := domain.EncodeInvitationToken(inv, uc.Secret)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := uc.Invitations.SaveInvitation(ctx, inv); err != nil {
+		return nil, "", err
+	}
+	return inv, token, nil
+}
+
+// ListInvitationsUsecase は使える（取り消されておらず、期限が切れていない）招待の一覧取得ユースケース。
+type ListInvitationsUsecase struct {
+	Projects    ProjectRepository
+	Members     MemberRepository
+	Invitations InvitationRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（メンバーの追加と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute はプロジェクトの存在と操作者のロールを確認してから、now の時点で使える招待を返す。
+func (uc *ListInvitationsUsecase) Execute(ctx context.Context, projectID, actorID string, now time.Time) ([]*domain.Invitation, error) {
+	if _, err := uc.Projects.FindByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, projectID, actorID, domain.ActionManageMembers); err != nil {
+			return nil, err
+		}
+	}
+
+	all, err := uc.Invitations.ListInvitations(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Invitation, 0, len(all))
+	for _, inv := range all {
+		if inv.Outstanding(now) {
+			out = append(out, inv)
+		}
+	}
+	return out, nil
+}
+
+// RevokeInvitationInput は招待の取り消しユースケースの入力。
+type RevokeInvitationInput struct {
+	ProjectID string
+	ID        string
+	ActorID   string // 操作者
+	Now       time.Time
+}
+
+// RevokeInvitationUsecase は招待を取り消すユースケース。取り消した招待のリンクは使えなくなる。
+// 既に参加したメンバーはそのまま残る。
+type RevokeInvitationUsecase struct {
+	Members     MemberRepository
+	Invitations InvitationRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（メンバーの追加と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute は操作者のロールを確認してから招待を取り消す。
+// 存在しない、または既に取り消されている場合は ErrInvitationNotFound を返す。
+func (uc *RevokeInvitationUsecase) Execute(ctx context.Context, in RevokeInvitationInput) error {
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, domain.ActionManageMembers); err != nil {
+			return err
+		}
+	}
+	return uc.Invitations.RevokeInvitation(ctx, in.ProjectID, in.ID, in.Now)
+}
+
+// GetInvitationUsecase は招待トークンから招待を取得するユースケース（参加前の確認用）。
+type GetInvitationUsecase struct {
+	Invitations InvitationRepository
+	// Secret は招待トークンの検証に使う（CreateInvitationUsecase と同じ値）
+	Secret []byte
+}
+
+// Execute はトークンを検証し、now の時点で使える招待を返す。
+// トークンが不正、招待が存在しない・取り消されている・期限切れの場合は
+// domain.ErrInvalidInvitationToken / ErrInvitationNotFound / domain.ErrInvitationRevoked / domain.ErrInvitationExpired を返す。
+func (uc *GetInvitationUsecase) Execute(ctx context.Context, token string, now time.Time) (*domain.Invitation, error) {
+	return findUsableInvitation(ctx, uc.Invitations, uc.Secret, token, now)
+}
+
+// AcceptInvitationUsecase は招待トークンを使ってプロジェクトに参加するユースケース。
+type AcceptInvitationUsecase struct {
+	Projects    ProjectRepository
+	Members     MemberRepository
+	Invitations InvitationRepository
+	// Secret は招待トークンの検証に使う（CreateInvitationUsecase と同じ値）
+	Secret []byte
+	// Activity は参加を記録するために使う。任意。nil の場合は記録しない
+	Activity ActivityRepository
+}
+
+// Execute はトークンを検証し、操作者（actorID）を招待のロールでメンバーに追加する。
+// actorID が空の場合は domain.ErrActorRequired、既にメンバーの場合は ErrMemberAlreadyExists を返す。
+// 招待が使えない場合のエラーは GetInvitationUsecase.Execute と同じ。
+func (uc *AcceptInvitationUsecase) Execute(ctx context.Context, token, actorID string, now time.Time) (*domain.Member, error) {
+	if actorID == "" {
+		return nil, domain.ErrActorRequired
+	}
+	inv, err := findUsableInvitation(ctx, uc.Invitations, uc.Secret, token, now)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.Projects.FindByID(ctx, inv.ProjectID); err != nil {
+		return nil, err
+	}
+
+	m, err := domain.NewMember(inv.ProjectID, actorID, string(inv.Role), now)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.Members.AddMember(ctx, m); err != nil {
+		return nil, err
+	}
+
+	event := domain.NewActivityEvent(m.ProjectID, domain.ActivityMemberAdded, actorID,
+		map[string]string{"userId": m.UserID, "role": string(m.Role), "invitationId": inv.ID}, now)
+	if err := recordActivity(ctx, uc.Activity, event); err != nil {
+		return m, err
+	}
+	return m, nil
+}
+
+// findUsableInvitation はトークンの署名と期限を検証し、保存している招待が now の時点で使えるか確認する。
+func findUsableInvitation(ctx context.Context, invitations InvitationRepository, secret []byte, token string, now time.Time) (*domain.Invitation, error) {
+	payload, err := domain.DecodeInvitationToken(token, secret, now)
+	if err != nil {
+		return nil, err
+	}
+	// 署名が正しくても、プロジェクトごと削除された場合などは招待が無い（ErrInvitationNotFound）
+	inv, err := invitations.FindInvitation(ctx, payload.ProjectID, payload.InvitationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := inv.CheckUsable(now); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
@@ -0,0 +1,177 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// fakeInvitationRepo は InvitationRepository のテスト用フェイク実装（登録順に返す）。
+type fakeInvitationRepo struct {
+	invitations []*domain.Invitation
+}
+
+func (r *fakeInvitationRepo) SaveInvitation(_ context.Context, inv *domain.Invitation) error {
+	r.invitations = append(r.invitations, inv)
+	return nil
+}
+
+func (r *fakeInvitationRepo) FindInvitation(_ context.Context, projectID, id string) (*domain.Invitation, error) {
+	for _, inv := range r.invitations {
+		if inv.ProjectID == projectID && inv.ID == id {
+			c := *inv
+			return &c, nil
+		}
+	}
+	return nil, usecase.ErrInvitationNotFound
+}
+
+func (r *fakeInvitationRepo) ListInvitations(_ context.Context, projectID string) ([]*domain.Invitation, error) {
+	out := make([]*domain.Invitation, 0)
+	for _, inv := range r.invitations {
+		if inv.ProjectID == projectID && inv.RevokedAt == nil {
+			out = append(out, inv)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeInvitationRepo) RevokeInvitation(_ context.Context, projectID, id string, revokedAt time.Time) error {
+	for _, inv := range r.invitations {
+		if inv.ProjectID == projectID && inv.ID == id && inv.RevokedAt == nil {
+			inv.RevokedAt = &revokedAt
+			return nil
+		}
+	}
+	return usecase.ErrInvitationNotFound
+}
+
+var invitationSecret = []byte("test-secret")
+
+func TestInvitations_CreateAcceptRevoke(t *testing.T) {
+	ctx := context.Background()
+	projects := newExistingProjectRepo(t)
+	members := &fakeMemberRepo{members: []*domain.Member{{ProjectID: "proj-1", UserID: "owner", Role: domain.RoleOwner}}}
+	invitations := &fakeInvitationRepo{}
+	activity := &fakeActivityRepo{}
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	createUC := &usecase.CreateInvitationUsecase{Projects: projects, Members: members, Invitations: invitations, Secret: invitationSecret, EnforceRoles: true}
+	listUC := &usecase.ListInvitationsUsecase{Projects: projects, Members: members, Invitations: invitations, EnforceRoles: true}
+	revokeUC := &usecase.RevokeInvitationUsecase{Members: members, Invitations: invitations, EnforceRoles: true}
+	getUC := &usecase.GetInvitationUsecase{Invitations: invitations, Secret: invitationSecret}
+	acceptUC := &usecase.AcceptInvitationUsecase{Projects: projects, Members: members, Invitations: invitations, Secret: invitationSecret, Activity: activity}
+
+	inv, token, err := createUC.Execute(ctx, usecase.CreateInvitationInput{ProjectID: "proj-1", Role: "admin", TTL: time.Hour, ActorID: "owner", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inv.Role != domain.RoleAdmin || inv.CreatedBy != "owner" || token == "" {
+		t.Errorf("unexpected invitation: %+v (token=%q)", inv, token)
+	}
+
+	if got, err := getUC.Execute(ctx, token, now); err != nil || got.ID != inv.ID {
+		t.Errorf("expected invitation %s, got %+v (err=%v)", inv.ID, got, err)
+	}
+
+	// 同じリンクで複数人が参加できる
+	for _, userID := range []string{"user-1", "user-2"} {
+		m, err := acceptUC.Execute(ctx, token, userID, now.Add(time.Minute))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", userID, err)
+		}
+		if m.ProjectID != "proj-1" || m.Role != domain.RoleAdmin {
+			t.Errorf("%s: unexpected member: %+v", userID, m)
+		}
+	}
+	if len(activity.events) != 2 || activity.events[0].Data["invitationId"] != inv.ID {
+		t.Errorf("expected member.added with invitationId, got %+v", activity.events)
+	}
+
+	if list, err := listUC.Execute(ctx, "proj-1", "owner", now); err != nil || len(list) != 1 {
+		t.Errorf("expected 1 outstanding invitation, got %d (err=%v)", len(list), err)
+	}
+	if list, _ := listUC.Execute(ctx, "proj-1", "owner", now.Add(time.Hour)); len(list) != 0 {
+		t.Errorf("expired invitation must not be listed, got %d", len(list))
+	}
+
+	if err := revokeUC.Execute(ctx, usecase.RevokeInvitationInput{ProjectID: "proj-1", ID: inv.ID, ActorID: "owner", Now: now.Add(2 * time.Minute)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := acceptUC.Execute(ctx, token, "user-3", now.Add(3*time.Minute)); !errors.Is(err, domain.ErrInvitationRevoked) {
+		t.Errorf("expected ErrInvitationRevoked, got %v", err)
+	}
+	if err := revokeUC.Execute(ctx, usecase.RevokeInvitationInput{ProjectID: "proj-1", ID: inv.ID, ActorID: "owner"}); !errors.Is(err, usecase.ErrInvitationNotFound) {
+		t.Errorf("expected ErrInvitationNotFound, got %v", err)
+	}
+}
+
+func TestCreateInvitation_Authorization(t *testing.T) {
+	members := &fakeMemberRepo{members: []*domain.Member{{ProjectID: "proj-1", UserID: "member", Role: domain.RoleMember}}}
+	invitations := &fakeInvitationRepo{}
+	uc := &usecase.CreateInvitationUsecase{Projects: newExistingProjectRepo(t), Members: members, Invitations: invitations, Secret: invitationSecret, EnforceRoles: true}
+
+	for _, tt := range []struct {
+		actor   string
+		role    string
+		wantErr error
+	}{
+		{actor: "", wantErr: domain.ErrActorRequired},
+		{actor: "member", wantErr: domain.ErrForbidden},
+		{actor: "member", role: "owner", wantErr: domain.ErrInvalidInvitation},
+	} {
+		_, _, err := uc.Execute(context.Background(), usecase.CreateInvitationInput{ProjectID: "proj-1", Role: tt.role, ActorID: tt.actor, Now: time.Now()})
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("actor %q role %q: expected %v, got %v", tt.actor, tt.role, tt.wantErr, err)
+		}
+	}
+	if len(invitations.invitations) != 0 {
+		t.Errorf("expected no invitation to be saved, got %d", len(invitations.invitations))
+	}
+}
+
+func TestAcceptInvitation_Errors(t *testing.T) {
+	ctx := context.Background()
+	projects := newExistingProjectRepo(t)
+	members := &fakeMemberRepo{}
+	invitations := &fakeInvitationRepo{}
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	createUC := &usecase.CreateInvitationUsecase{Projects: projects, Members: members, Invitations: invitations, Secret: invitationSecret}
+	acceptUC := &usecase.AcceptInvitationUsecase{Projects: projects, Members: members, Invitations: invitations, Secret: invitationSecret}
+
+	_, token, err := createUC.Execute(ctx, usecase.CreateInvitationInput{ProjectID: "proj-1", TTL: time.Hour, Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 署名は正しいが保存されていない招待
+	orphan, _ := domain.NewInvitation("orphan", "proj-1", "member", "", time.Hour, now)
+	orphanToken, _ := domain.EncodeInvitationToken(orphan, invitationSecret)
+
+	tests := []struct {
+		name    string
+		token   string
+		actorID string
+		now     time.Time
+		wantErr error
+	}{
+		{name: "no actor", token: token, now: now, wantErr: domain.ErrActorRequired},
+		{name: "tampered", token: token + "x", actorID: "user-1", now: now, wantErr: domain.ErrInvalidInvitationToken},
+		{name: "expired", token: token, actorID: "user-1", now: now.Add(time.Hour), wantErr: domain.ErrInvitationExpired},
+		{name: "not saved", token: orphanToken, actorID: "user-1", now: now, wantErr: usecase.ErrInvitationNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := acceptUC.Execute(ctx, tt.token, tt.actorID, tt.now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+	if len(members.members) != 0 {
+		t.Errorf("expected no member to be added, got %d", len(members.members))
+	}
+}
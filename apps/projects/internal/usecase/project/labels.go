@@ -0,0 +1,194 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// LabelRepository はラベルの永続化・取得を担当する抽象。
+type LabelRepository interface {
+	// SaveLabel はラベルを保存する。
+	// プロジェクト内に同じ ID が既にある場合は ErrLabelAlreadyExists、同じ名前（大文字・小文字は区別しない）が
+	// 既にある場合は ErrLabelNameAlreadyExists、プロジェクトが存在しない場合は ErrProjectNotFound 相当のエラーを返す。
+	SaveLabel(ctx context.Context, l *domain.Label) error
+	// UpdateLabel はラベルを更新する。存在しない場合は ErrLabelNotFound、
+	// 名前が他のラベルと重複する場合は ErrLabelNameAlreadyExists 相当のエラーを返す。
+	UpdateLabel(ctx context.Context, l *domain.Label) error
+	// DeleteLabel はラベルを削除する。存在しない場合は ErrLabelNotFound 相当のエラーを返す。
+	DeleteLabel(ctx context.Context, projectID, id string) error
+	// FindLabel はラベルを 1 件取得する。存在しない場合は ErrLabelNotFound 相当のエラーを返す。
+	FindLabel(ctx context.Context, projectID, id string) (*domain.Label, error)
+	// ListLabels はプロジェクトのラベルを名前（大文字・小文字は区別しない）・ID の昇順で返す。
+	ListLabels(ctx context.Context, projectID string) ([]*domain.Label, error)
+}
+
+// LabelStatsProvider はプロジェクトのタスクをラベルごとに数える（tasks サービスのクライアント）。
+// 返り値は labelID をキーとし、どのタスクにも付いていないラベルは含まなくてよい。
+type LabelStatsProvider interface {
+	LabelStats(ctx context.Context, projectID string) (map[string]int, error)
+}
+
+// CreateLabelInput はラベル作成ユースケースの入力。
+type CreateLabelInput struct {
+	ProjectID string
+	ID        string
+	Name      string
+	Color     string // #RRGGBB
+	ActorID   string // 操作者
+	Now       time.Time
+}
+
+// CreateLabelUsecase はラベル作成ユースケース。
+type CreateLabelUsecase struct {
+	Projects ProjectRepository
+	Members  MemberRepository
+	Labels   LabelRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（プロジェクトの編集と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute はプロジェクトの存在と操作者のロールを確認してからラベルを保存する。
+// 不正な値の場合は domain.ErrInvalidLabel を返す。
+func (uc *CreateLabelUsecase) Execute(ctx context.Context, in CreateLabelInput) (*domain.Label, error) {
+	l, err := domain.NewLabel(in.ID, in.ProjectID, in.Name, in.Color, in.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.Projects.FindByID(ctx, in.ProjectID); err != nil {
+		return nil, err
+	}
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, domain.ActionEdit); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Labels.SaveLabel(ctx, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// UpdateLabelInput はラベル更新ユースケースの入力。
+// nil のフィールドは変更しない（PATCH）。
+type UpdateLabelInput struct {
+	ProjectID string
+	ID        string
+	Name      *string
+	Color     *string
+	ActorID   string // 操作者
+	Now       time.Time
+}
+
+// UpdateLabelUsecase はラベル更新ユースケース。
+// ID は変わらないため、タスクに付いているラベルはそのまま新しい名前・色で表示される。
+type UpdateLabelUsecase struct {
+	Members MemberRepository
+	Labels  LabelRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（プロジェクトの編集と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute は既存のラベルを取得し、指定されたフィールドを更新して保存する。
+func (uc *UpdateLabelUsecase) Execute(ctx context.Context, in UpdateLabelInput) (*domain.Label, error) {
+	l, err := uc.Labels.FindLabel(ctx, in.ProjectID, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, domain.ActionEdit); err != nil {
+			return nil, err
+		}
+	}
+
+	name, color := l.Name, l.Color
+	if in.Name != nil {
+		name = *in.Name
+	}
+	if in.Color != nil {
+		color = *in.Color
+	}
+	if err := l.Update(name, color, in.Now); err != nil {
+		return nil, err
+	}
+	if err := uc.Labels.UpdateLabel(ctx, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// DeleteLabelInput はラベル削除ユースケースの入力。
+type DeleteLabelInput struct {
+	ProjectID string
+	ID        string
+	ActorID   string // 操作者
+}
+
+// DeleteLabelUsecase はラベル削除ユースケース。
+// タスクに付いていたラベルの ID は tasks サービス側に残る（使用数の集計には含まれなくなる）。
+type DeleteLabelUsecase struct {
+	Members MemberRepository
+	Labels  LabelRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（プロジェクトの編集と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute は操作者のロールを確認してからラベルを削除する。
+func (uc *DeleteLabelUsecase) Execute(ctx context.Context, in DeleteLabelInput) error {
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, domain.ActionEdit); err != nil {
+			return err
+		}
+	}
+	return uc.Labels.DeleteLabel(ctx, in.ProjectID, in.ID)
+}
+
+// ListLabelsUsecase はラベル一覧取得ユースケース。
+type ListLabelsUsecase struct {
+	Projects ProjectRepository
+	Members  MemberRepository
+	// EnforceRoles が true の場合は閲覧権限を確認する（公開プロジェクトは誰でも、非公開プロジェクトはメンバーのみ）
+	EnforceRoles bool
+	Labels       LabelRepository
+	// Stats は使用数の取得に使う。nil の場合は ErrTasksService を返す
+	Stats LabelStatsProvider
+}
+
+// Execute はプロジェクトのラベルを一覧の順で、そのラベルが付いたタスク数と合わせて返す。
+// 使用数の取得に失敗した場合は ErrTasksService でラップしたエラーを返す。
+func (uc *ListLabelsUsecase) Execute(ctx context.Context, projectID, actorID string) ([]domain.LabelUsage, error) {
+	if _, err := findReadableProject(ctx, uc.Projects, uc.Members, uc.EnforceRoles, projectID, actorID); err != nil {
+		return nil, err
+	}
+	labels, err := uc.Labels.ListLabels(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(labels) == 0 {
+		return []domain.LabelUsage{}, nil
+	}
+	if uc.Stats == nil {
+		return nil, fmt.Errorf("%w: tasks service is not configured", ErrTasksService)
+	}
+
+	counts, err := uc.Stats.LabelStats(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTasksService, err)
+	}
+	return domain.ComputeLabelUsage(labels, counts), nil
+}
+
+// GetLabelUsecase はラベル取得ユースケース。
+// tasks サービスがタスクに付けるラベルが定義済みかのチェックにも使うため、使用数は含めない。
+type GetLabelUsecase struct {
+	Labels LabelRepository
+}
+
+// Execute はラベルを返す。存在しない場合は ErrLabelNotFound を返す。
+func (uc *GetLabelUsecase) Execute(ctx context.Context, projectID, id string) (*domain.Label, error) {
+	return uc.Labels.FindLabel(ctx, projectID, id)
+}
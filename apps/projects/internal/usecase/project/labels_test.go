@@ -0,0 +1,213 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// fakeLabelRepo は LabelRepository のテスト用フェイク実装（登録順に返す）。
+type fakeLabelRepo struct {
+	labels []*domain.Label
+}
+
+func (r *fakeLabelRepo) index(projectID, id string) int {
+	for i, l := range r.labels {
+		if l.ProjectID == projectID && l.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// nameTaken は projectID に id 以外で同じ名前のラベルがあるかどうかを返す。
+func (r *fakeLabelRepo) nameTaken(l *domain.Label) bool {
+	for _, other := range r.labels {
+		if other.ProjectID == l.ProjectID && other.ID != l.ID && strings.EqualFold(other.Name, l.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *fakeLabelRepo) SaveLabel(_ context.Context, l *domain.Label) error {
+	if r.index(l.ProjectID, l.ID) >= 0 {
+		return usecase.ErrLabelAlreadyExists
+	}
+	if r.nameTaken(l) {
+		return usecase.ErrLabelNameAlreadyExists
+	}
+	r.labels = append(r.labels, l)
+	return nil
+}
+
+func (r *fakeLabelRepo) UpdateLabel(_ context.Context, l *domain.Label) error {
+	i := r.index(l.ProjectID, l.ID)
+	if i < 0 {
+		return usecase.ErrLabelNotFound
+	}
+	if r.nameTaken(l) {
+		return usecase.ErrLabelNameAlreadyExists
+	}
+	r.labels[i] = l
+	return nil
+}
+
+func (r *fakeLabelRepo) DeleteLabel(_ context.Context, projectID, id string) error {
+	i := r.index(projectID, id)
+	if i < 0 {
+		return usecase.ErrLabelNotFound
+	}
+	r.labels = append(r.labels[:i], r.labels[i+1:]...)
+	return nil
+}
+
+func (r *fakeLabelRepo) FindLabel(_ context.Context, projectID, id string) (*domain.Label, error) {
+	i := r.index(projectID, id)
+	if i < 0 {
+		return nil, usecase.ErrLabelNotFound
+	}
+	c := *r.labels[i]
+	return &c, nil
+}
+
+func (r *fakeLabelRepo) ListLabels(_ context.Context, projectID string) ([]*domain.Label, error) {
+	out := make([]*domain.Label, 0)
+	for _, l := range r.labels {
+		if l.ProjectID == projectID {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+// fakeLabelStats は LabelStatsProvider のテスト用フェイク実装。
+type fakeLabelStats struct {
+	counts map[string]int
+	err    error
+	calls  int
+}
+
+func (p *fakeLabelStats) LabelStats(_ context.Context, _ string) (map[string]int, error) {
+	p.calls++
+	return p.counts, p.err
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestLabels_CRUD(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	labels := &fakeLabelRepo{}
+	projects := newExistingProjectRepo(t)
+
+	createUC := &usecase.CreateLabelUsecase{Projects: projects, Members: newRoleMembers(), Labels: labels, EnforceRoles: true}
+	if _, err := createUC.Execute(ctx, usecase.CreateLabelInput{ProjectID: "missing", ID: "l1", Name: "bug", Color: "#d73a4a", ActorID: "member-1", Now: now}); err == nil {
+		t.Fatal("expected error for missing project")
+	}
+	l, err := createUC.Execute(ctx, usecase.CreateLabelInput{ProjectID: "proj-1", ID: "l1", Name: "bug", Color: "#D73A4A", ActorID: "member-1", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if l.Color != "#d73a4a" || l.ProjectID != "proj-1" {
+		t.Errorf("unexpected label: %+v", l)
+	}
+
+	tests := []struct {
+		name    string
+		in      usecase.CreateLabelInput
+		wantErr error
+	}{
+		{name: "duplicate id", in: usecase.CreateLabelInput{ProjectID: "proj-1", ID: "l1", Name: "feature", Color: "#a2eeef", ActorID: "member-1"}, wantErr: usecase.ErrLabelAlreadyExists},
+		{name: "duplicate name", in: usecase.CreateLabelInput{ProjectID: "proj-1", ID: "l2", Name: "BUG", Color: "#a2eeef", ActorID: "member-1"}, wantErr: usecase.ErrLabelNameAlreadyExists},
+		{name: "invalid color", in: usecase.CreateLabelInput{ProjectID: "proj-1", ID: "l2", Name: "feature", Color: "blue", ActorID: "member-1"}, wantErr: domain.ErrInvalidLabel},
+		{name: "non-member is forbidden", in: usecase.CreateLabelInput{ProjectID: "proj-1", ID: "l2", Name: "feature", Color: "#a2eeef", ActorID: "stranger"}, wantErr: domain.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.in.Now = now
+			if _, err := createUC.Execute(ctx, tt.in); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	// 指定したフィールドのみ変更する
+	updateUC := &usecase.UpdateLabelUsecase{Members: newRoleMembers(), Labels: labels, EnforceRoles: true}
+	updated, err := updateUC.Execute(ctx, usecase.UpdateLabelInput{ProjectID: "proj-1", ID: "l1", Color: strPtr("#0e8a16"), ActorID: "member-1", Now: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Name != "bug" || updated.Color != "#0e8a16" {
+		t.Errorf("unexpected label: %+v", updated)
+	}
+	if _, err := updateUC.Execute(ctx, usecase.UpdateLabelInput{ProjectID: "proj-1", ID: "l1", Name: strPtr(" "), ActorID: "member-1"}); !errors.Is(err, domain.ErrInvalidLabel) {
+		t.Errorf("expected ErrInvalidLabel, got %v", err)
+	}
+	if _, err := updateUC.Execute(ctx, usecase.UpdateLabelInput{ProjectID: "proj-1", ID: "missing", Name: strPtr("x"), ActorID: "member-1"}); !errors.Is(err, usecase.ErrLabelNotFound) {
+		t.Errorf("expected ErrLabelNotFound, got %v", err)
+	}
+
+	deleteUC := &usecase.DeleteLabelUsecase{Members: newRoleMembers(), Labels: labels, EnforceRoles: true}
+	if err := deleteUC.Execute(ctx, usecase.DeleteLabelInput{ProjectID: "proj-1", ID: "l1", ActorID: "stranger"}); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+	if err := deleteUC.Execute(ctx, usecase.DeleteLabelInput{ProjectID: "proj-1", ID: "l1", ActorID: "member-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	getUC := &usecase.GetLabelUsecase{Labels: labels}
+	if _, err := getUC.Execute(ctx, "proj-1", "l1"); !errors.Is(err, usecase.ErrLabelNotFound) {
+		t.Errorf("expected ErrLabelNotFound, got %v", err)
+	}
+}
+
+func TestListLabels(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	newUsecase := func(t *testing.T, stats usecase.LabelStatsProvider, ids ...string) *usecase.ListLabelsUsecase {
+		t.Helper()
+		labels := &fakeLabelRepo{}
+		for _, id := range ids {
+			l, err := domain.NewLabel(id, "proj-1", id, "#ffffff", now)
+			if err != nil {
+				t.Fatalf("failed to create label: %v", err)
+			}
+			if err := labels.SaveLabel(ctx, l); err != nil {
+				t.Fatalf("failed to save label: %v", err)
+			}
+		}
+		return &usecase.ListLabelsUsecase{Projects: newExistingProjectRepo(t), Labels: labels, Stats: stats}
+	}
+
+	stats := &fakeLabelStats{counts: map[string]int{"l1": 3}}
+	got, err := newUsecase(t, stats, "l1", "l2").Execute(ctx, "proj-1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].Label.ID != "l1" || got[0].UsageCount != 3 || got[1].UsageCount != 0 {
+		t.Errorf("unexpected labels: %+v", got)
+	}
+
+	// ラベルが無い場合は tasks サービスを呼ばない
+	empty := &fakeLabelStats{}
+	got, err = newUsecase(t, empty).Execute(ctx, "proj-1", "")
+	if err != nil || len(got) != 0 || empty.calls != 0 {
+		t.Errorf("unexpected result: %+v, %v (calls=%d)", got, err, empty.calls)
+	}
+
+	if _, err := newUsecase(t, nil, "l1").Execute(ctx, "proj-1", ""); !errors.Is(err, usecase.ErrTasksService) {
+		t.Errorf("expected ErrTasksService when not configured, got %v", err)
+	}
+	if _, err := newUsecase(t, &fakeLabelStats{err: errors.New("boom")}, "l1").Execute(ctx, "proj-1", ""); !errors.Is(err, usecase.ErrTasksService) {
+		t.Errorf("expected ErrTasksService, got %v", err)
+	}
+	if _, err := newUsecase(t, stats, "l1").Execute(ctx, "missing", ""); err == nil || stats.calls != 1 {
+		t.Errorf("expected project lookup error without calling tasks service, got %v (calls=%d)", err, stats.calls)
+	}
+}
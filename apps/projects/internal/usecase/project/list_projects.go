@@ -2,16 +2,77 @@ package project
 
 import (
 	"context"
+	"fmt"
 
 	domain "teamflow-projects/internal/domain/project"
 )
 
 // ListProjectsUsecase はプロジェクト一覧取得ユースケース。
 type ListProjectsUsecase struct {
-	Repo ProjectRepository
+	Repo    ProjectRepository
+	Members MemberRepository
+	// EnforceRoles が true の場合は閲覧できるプロジェクト（公開プロジェクトと操作者がメンバーのプロジェクト）だけを返す
+	EnforceRoles bool
+	// Stats は一覧のタスク件数（expand=taskCounts）の取得に使う。nil の場合は ErrTasksService を返す
+	Stats BatchStatsProvider
+	// UseTaskCounters が true の場合は一覧のタスク件数にプロジェクトのタスク数（タスクのイベントで更新する
+	// Project.OpenTasks / DoneTasks）を使い、tasks サービスを呼び出さない
+	UseTaskCounters bool
 }
 
 // Execute はすべてのプロジェクトを取得する。
 func (uc *ListProjectsUsecase) Execute(ctx context.Context) ([]*domain.Project, error) {
 	return uc.Repo.List(ctx)
 }
+
+// ExecuteWithQuery は Query Object に基づいてプロジェクトを取得する（limit + 1 件まで）。
+// EnforceRoles の場合は actorID が閲覧できるプロジェクトに絞り込む（actorID が空の場合は公開プロジェクトのみ）。
+func (uc *ListProjectsUsecase) ExecuteWithQuery(ctx context.Context, query *domain.ProjectQuery, actorID string) ([]*domain.Project, error) {
+	if !uc.EnforceRoles {
+		return uc.Repo.FindWithQuery(ctx, query)
+	}
+
+	memberOf := []string{}
+	if actorID != "" {
+		ids, err := uc.Members.ListProjectIDsByUser(ctx, actorID)
+		if err != nil {
+			return nil, err
+		}
+		memberOf = ids
+	}
+	// 呼び出し側の Query Object は変更しない
+	q := *query
+	q.ReadableOnly = true
+	q.MemberProjectIDs = memberOf
+	return uc.Repo.FindWithQuery(ctx, &q)
+}
+
+// TaskCounts は projects のタスクの集計（Open / Done）をプロジェクト ID ごとに返す。
+// UseTaskCounters の場合はプロジェクトのタスク数を使う。それ以外はプロジェクトごとに問い合わせず、tasks サービスを 1 回だけ呼び出す。
+// 集計の取得に失敗した場合は ErrTasksService でラップしたエラーを返す。
+func (uc *ListProjectsUsecase) TaskCounts(ctx context.Context, projects []*domain.Project) (map[string]*domain.Stats, error) {
+	if len(projects) == 0 {
+		return map[string]*domain.Stats{}, nil
+	}
+	if uc.UseTaskCounters {
+		counts := make(map[string]*domain.Stats, len(projects))
+		for _, p := range projects {
+			// イベントの届く順序で一時的に負になった件数は 0 として返す
+			counts[p.ID] = &domain.Stats{ProjectID: p.ID, Open: max(p.OpenTasks, 0), Done: max(p.DoneTasks, 0)}
+		}
+		return counts, nil
+	}
+	if uc.Stats == nil {
+		return nil, fmt.Errorf("%w: tasks service is not configured", ErrTasksService)
+	}
+
+	ids := make([]string, len(projects))
+	for i, p := range projects {
+		ids[i] = p.ID
+	}
+	stats, err := uc.Stats.ProjectsStats(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTasksService, err)
+	}
+	return stats, nil
+}
@@ -2,6 +2,8 @@ package project_test
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"testing"
 	"time"
 
@@ -11,12 +13,24 @@ import (
 
 // List 用の簡単なフェイク
 type listRepo struct {
-	out []*domain.Project
+	out   []*domain.Project
+	query *domain.ProjectQuery // 最後に FindWithQuery に渡された Query Object
 }
 
 func (r *listRepo) Save(context.Context, *domain.Project) error               { return nil }
+func (r *listRepo) Update(context.Context, *domain.Project) error             { return nil }
 func (r *listRepo) FindByID(context.Context, string) (*domain.Project, error) { return nil, nil }
-func (r *listRepo) List(context.Context) ([]*domain.Project, error)           { return r.out, nil }
+func (r *listRepo) FindDeleted(context.Context, string) (*domain.Project, error) {
+	return nil, usecase.ErrProjectNotFound
+}
+func (r *listRepo) FindByName(context.Context, string) (*domain.Project, error) {
+	return nil, usecase.ErrProjectNotFound
+}
+func (r *listRepo) List(context.Context) ([]*domain.Project, error) { return r.out, nil }
+func (r *listRepo) FindWithQuery(_ context.Context, q *domain.ProjectQuery) ([]*domain.Project, error) {
+	r.query = q
+	return r.out, nil
+}
 
 func TestListProjects_Success(t *testing.T) {
 	now := time.Now()
@@ -40,3 +54,127 @@ func TestListProjects_Success(t *testing.T) {
 		t.Fatalf("expected 2 projects, got %d", len(got))
 	}
 }
+
+func TestListProjects_EnforceRolesRestrictsToReadable(t *testing.T) {
+	members := &fakeMemberRepo{members: []*domain.Member{
+		{ProjectID: "proj-1", UserID: "user-1", Role: domain.RoleMember},
+		{ProjectID: "proj-2", UserID: "user-2", Role: domain.RoleMember},
+	}}
+
+	tests := []struct {
+		name         string
+		enforce      bool
+		actor        string
+		wantReadable bool
+		wantMemberOf []string
+	}{
+		{name: "not enforced", enforce: false, actor: "user-1"},
+		{name: "member", enforce: true, actor: "user-1", wantReadable: true, wantMemberOf: []string{"proj-1"}},
+		{name: "anonymous sees public only", enforce: true, wantReadable: true, wantMemberOf: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &listRepo{}
+			uc := &usecase.ListProjectsUsecase{Repo: repo, Members: members, EnforceRoles: tt.enforce}
+			query, err := domain.NewProjectQuery()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if _, err := uc.ExecuteWithQuery(context.Background(), query, tt.actor); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if repo.query.ReadableOnly != tt.wantReadable {
+				t.Fatalf("expected ReadableOnly=%v, got %v", tt.wantReadable, repo.query.ReadableOnly)
+			}
+			if tt.wantReadable && !reflect.DeepEqual(repo.query.MemberProjectIDs, tt.wantMemberOf) {
+				t.Errorf("expected MemberProjectIDs=%v, got %v", tt.wantMemberOf, repo.query.MemberProjectIDs)
+			}
+			if query.ReadableOnly {
+				t.Error("caller's query must not be modified")
+			}
+		})
+	}
+}
+
+// fakeBatchStatsProvider は BatchStatsProvider のフェイク。呼び出しごとの projectIDs を記録する。
+type fakeBatchStatsProvider struct {
+	err   error
+	calls [][]string
+}
+
+func (p *fakeBatchStatsProvider) ProjectsStats(_ context.Context, projectIDs []string) (map[string]*domain.Stats, error) {
+	p.calls = append(p.calls, projectIDs)
+	if p.err != nil {
+		return nil, p.err
+	}
+	out := make(map[string]*domain.Stats, len(projectIDs))
+	for i, id := range projectIDs {
+		out[id] = &domain.Stats{ProjectID: id, Open: i + 1, Done: i}
+	}
+	return out, nil
+}
+
+func TestListProjects_TaskCounts(t *testing.T) {
+	now := time.Now()
+	p1, _ := domain.NewProject("proj-1", "P1", "", now)
+	p2, _ := domain.NewProject("proj-2", "P2", "", now)
+	stats := &fakeBatchStatsProvider{}
+	uc := &usecase.ListProjectsUsecase{Repo: &listRepo{}, Stats: stats}
+
+	got, err := uc.TaskCounts(context.Background(), []*domain.Project{p1, p2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// プロジェクトごとではなく 1 回で取得する
+	if len(stats.calls) != 1 || len(stats.calls[0]) != 2 {
+		t.Fatalf("expected a single batched call, got %v", stats.calls)
+	}
+	if got["proj-2"] == nil || got["proj-2"].Open != 2 || got["proj-2"].Done != 1 {
+		t.Errorf("unexpected stats: %+v", got["proj-2"])
+	}
+
+	// 一覧が空の場合は呼び出さない
+	if _, err := uc.TaskCounts(context.Background(), nil); err != nil || len(stats.calls) != 1 {
+		t.Errorf("expected no call for empty list, got err=%v calls=%v", err, stats.calls)
+	}
+}
+
+func TestListProjects_TaskCounts_UseTaskCounters(t *testing.T) {
+	p1, _ := domain.NewProject("proj-1", "P1", "", time.Now())
+	p1.OpenTasks, p1.DoneTasks = 3, 2
+	p2, _ := domain.NewProject("proj-2", "P2", "", time.Now())
+	p2.OpenTasks, p2.DoneTasks = -1, 1
+	stats := &fakeBatchStatsProvider{}
+	uc := &usecase.ListProjectsUsecase{Repo: &listRepo{}, Stats: stats, UseTaskCounters: true}
+
+	got, err := uc.TaskCounts(context.Background(), []*domain.Project{p1, p2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// tasks サービスは呼び出さない
+	if len(stats.calls) != 0 {
+		t.Errorf("expected no tasks service call, got %v", stats.calls)
+	}
+	if got["proj-1"].Open != 3 || got["proj-1"].Done != 2 {
+		t.Errorf("unexpected counts for proj-1: %+v", got["proj-1"])
+	}
+	// 一時的に負になった件数は 0 として返す
+	if got["proj-2"].Open != 0 || got["proj-2"].Done != 1 {
+		t.Errorf("unexpected counts for proj-2: %+v", got["proj-2"])
+	}
+}
+
+func TestListProjects_TaskCounts_TasksServiceError(t *testing.T) {
+	p1, _ := domain.NewProject("proj-1", "P1", "", time.Now())
+
+	for name, stats := range map[string]usecase.BatchStatsProvider{
+		"not configured": nil,
+		"request fails":  &fakeBatchStatsProvider{err: errors.New("unavailable")},
+	} {
+		uc := &usecase.ListProjectsUsecase{Repo: &listRepo{}, Stats: stats}
+		if _, err := uc.TaskCounts(context.Background(), []*domain.Project{p1}); !errors.Is(err, usecase.ErrTasksService) {
+			t.Errorf("%s: expected ErrTasksService, got %v", name, err)
+		}
+	}
+}
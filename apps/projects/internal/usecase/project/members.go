@@ -0,0 +1,142 @@
+package project
+
+import (
+	"context"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// MemberRepository はプロジェクトメンバーの永続化・取得を担当する抽象。
+type MemberRepository interface {
+	// AddMember はメンバーを追加する。既に参加している場合は ErrMemberAlreadyExists 相当のエラーを返す。
+	AddMember(ctx context.Context, m *domain.Member) error
+	// RemoveMember はメンバーを削除する。参加していない場合は ErrMemberNotFound 相当のエラーを返す。
+	RemoveMember(ctx context.Context, projectID, userID string) error
+	// FindMember はメンバーを 1 件取得する。参加していない場合は ErrMemberNotFound 相当のエラーを返す。
+	FindMember(ctx context.Context, projectID, userID string) (*domain.Member, error)
+	// ListMembers はプロジェクトのメンバーを参加日時順（同時刻は userID 順）で返す。
+	ListMembers(ctx context.Context, projectID string) ([]*domain.Member, error)
+	// ListProjectIDsByUser はユーザーがメンバーのプロジェクトの ID を返す（順不同）。
+	ListProjectIDsByUser(ctx context.Context, userID string) ([]string, error)
+}
+
+// AddMemberInput はメンバー追加ユースケースの入力。
+type AddMemberInput struct {
+	ProjectID string
+	UserID    string
+	Role      string // 空の場合は member
+	ActorID   string // 操作者
+	Now       time.Time
+}
+
+// AddMemberUsecase はプロジェクトにメンバーを追加するユースケース。
+type AddMemberUsecase struct {
+	Projects ProjectRepository
+	Members  MemberRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（owner / admin のみ追加でき、owner の追加は owner のみ）
+	EnforceRoles bool
+	// Activity は追加を記録するために使う。任意。nil の場合は記録しない
+	Activity ActivityRepository
+}
+
+// Execute はプロジェクトの存在と操作者のロールを確認してからメンバーを追加する。
+func (uc *AddMemberUsecase) Execute(ctx context.Context, in AddMemberInput) (*domain.Member, error) {
+	m, err := domain.NewMember(in.ProjectID, in.UserID, in.Role, in.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.Projects.FindByID(ctx, in.ProjectID); err != nil {
+		return nil, err
+	}
+
+	if uc.EnforceRoles {
+		action := domain.ActionManageMembers
+		if m.Role == domain.RoleOwner {
+			action = domain.ActionManageOwners
+		}
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, action); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Members.AddMember(ctx, m); err != nil {
+		return nil, err
+	}
+
+	event := domain.NewActivityEvent(m.ProjectID, domain.ActivityMemberAdded, in.ActorID,
+		map[string]string{"userId": m.UserID, "role": string(m.Role)}, in.Now)
+	if err := recordActivity(ctx, uc.Activity, event); err != nil {
+		return m, err
+	}
+	return m, nil
+}
+
+// RemoveMemberInput はメンバー削除ユースケースの入力。
+type RemoveMemberInput struct {
+	ProjectID string
+	UserID    string
+	ActorID   string // 操作者
+	Now       time.Time
+}
+
+// RemoveMemberUsecase はプロジェクトからメンバーを削除するユースケース。
+type RemoveMemberUsecase struct {
+	Members MemberRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（owner / admin のみ削除でき、owner の削除は owner のみ）
+	EnforceRoles bool
+	// Activity は削除を記録するために使う。任意。nil の場合は記録しない
+	Activity ActivityRepository
+}
+
+// Execute は操作者のロールを確認してからメンバーを削除する。
+func (uc *RemoveMemberUsecase) Execute(ctx context.Context, in RemoveMemberInput) error {
+	if uc.EnforceRoles {
+		target, err := uc.Members.FindMember(ctx, in.ProjectID, in.UserID)
+		if err != nil {
+			return err
+		}
+		action := domain.ActionManageMembers
+		if target.Role == domain.RoleOwner {
+			action = domain.ActionManageOwners
+		}
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, action); err != nil {
+			return err
+		}
+	}
+	if err := uc.Members.RemoveMember(ctx, in.ProjectID, in.UserID); err != nil {
+		return err
+	}
+
+	event := domain.NewActivityEvent(in.ProjectID, domain.ActivityMemberRemoved, in.ActorID,
+		map[string]string{"userId": in.UserID}, in.Now)
+	return recordActivity(ctx, uc.Activity, event)
+}
+
+// ListMembersUsecase はプロジェクトのメンバー一覧取得ユースケース。
+type ListMembersUsecase struct {
+	Projects ProjectRepository
+	Members  MemberRepository
+	// EnforceRoles が true の場合は閲覧権限を確認する（公開プロジェクトは誰でも、非公開プロジェクトはメンバーのみ）
+	EnforceRoles bool
+}
+
+// Execute はプロジェクトの存在と閲覧権限を確認してからメンバー一覧を返す。
+func (uc *ListMembersUsecase) Execute(ctx context.Context, projectID, actorID string) ([]*domain.Member, error) {
+	if _, err := findReadableProject(ctx, uc.Projects, uc.Members, uc.EnforceRoles, projectID, actorID); err != nil {
+		return nil, err
+	}
+	return uc.Members.ListMembers(ctx, projectID)
+}
+
+// GetMemberUsecase はメンバー 1 件の取得ユースケース。
+// tasks サービスが担当者のメンバーチェックに使う。
+type GetMemberUsecase struct {
+	Members MemberRepository
+}
+
+// Execute は projectID と userID を指定してメンバーを取得する。
+func (uc *GetMemberUsecase) Execute(ctx context.Context, projectID, userID string) (*domain.Member, error) {
+	return uc.Members.FindMember(ctx, projectID, userID)
+}
@@ -0,0 +1,149 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// fakeMemberRepo は MemberRepository のテスト用フェイク実装。
+type fakeMemberRepo struct {
+	members []*domain.Member
+	err     error
+}
+
+func (r *fakeMemberRepo) AddMember(_ context.Context, m *domain.Member) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.members = append(r.members, m)
+	return nil
+}
+
+func (r *fakeMemberRepo) RemoveMember(_ context.Context, projectID, userID string) error {
+	for i, m := range r.members {
+		if m.ProjectID == projectID && m.UserID == userID {
+			r.members = append(r.members[:i], r.members[i+1:]...)
+			return nil
+		}
+	}
+	return usecase.ErrMemberNotFound
+}
+
+func (r *fakeMemberRepo) FindMember(_ context.Context, projectID, userID string) (*domain.Member, error) {
+	for _, m := range r.members {
+		if m.ProjectID == projectID && m.UserID == userID {
+			return m, nil
+		}
+	}
+	return nil, usecase.ErrMemberNotFound
+}
+
+func (r *fakeMemberRepo) ListMembers(_ context.Context, projectID string) ([]*domain.Member, error) {
+	out := make([]*domain.Member, 0)
+	for _, m := range r.members {
+		if m.ProjectID == projectID {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeMemberRepo) ListProjectIDsByUser(_ context.Context, userID string) ([]string, error) {
+	out := make([]string, 0)
+	for _, m := range r.members {
+		if m.UserID == userID {
+			out = append(out, m.ProjectID)
+		}
+	}
+	return out, nil
+}
+
+func newExistingProjectRepo(t *testing.T) *fakeUpdateRepo {
+	t.Helper()
+	existing, err := domain.NewProject("proj-1", "TeamFlow 開発", "", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error creating existing project: %v", err)
+	}
+	return &fakeUpdateRepo{stored: existing}
+}
+
+func TestAddMember_Success(t *testing.T) {
+	members := &fakeMemberRepo{}
+	uc := &usecase.AddMemberUsecase{Projects: newExistingProjectRepo(t), Members: members}
+
+	now := time.Now()
+	m, err := uc.Execute(context.Background(), usecase.AddMemberInput{ProjectID: "proj-1", UserID: "user-1", Role: "admin", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Role != domain.RoleAdmin || !m.JoinedAt.Equal(now) {
+		t.Errorf("unexpected member: %+v", m)
+	}
+	if len(members.members) != 1 {
+		t.Fatalf("expected member to be saved, got %d", len(members.members))
+	}
+}
+
+func TestAddMember_InvalidRole(t *testing.T) {
+	members := &fakeMemberRepo{}
+	uc := &usecase.AddMemberUsecase{Projects: newExistingProjectRepo(t), Members: members}
+
+	_, err := uc.Execute(context.Background(), usecase.AddMemberInput{ProjectID: "proj-1", UserID: "user-1", Role: "guest", Now: time.Now()})
+	if !errors.Is(err, domain.ErrInvalidMemberRole) {
+		t.Fatalf("expected ErrInvalidMemberRole, got %v", err)
+	}
+	if len(members.members) != 0 {
+		t.Fatalf("expected no member to be saved")
+	}
+}
+
+func TestAddMember_ProjectNotFound(t *testing.T) {
+	findErr := errors.New("project not found")
+	members := &fakeMemberRepo{}
+	uc := &usecase.AddMemberUsecase{Projects: &fakeUpdateRepo{findErr: findErr}, Members: members}
+
+	_, err := uc.Execute(context.Background(), usecase.AddMemberInput{ProjectID: "proj-x", UserID: "user-1", Now: time.Now()})
+	if !errors.Is(err, findErr) {
+		t.Fatalf("expected %v, got %v", findErr, err)
+	}
+	if len(members.members) != 0 {
+		t.Fatalf("expected no member to be saved")
+	}
+}
+
+func TestListMembers(t *testing.T) {
+	members := &fakeMemberRepo{members: []*domain.Member{
+		{ProjectID: "proj-1", UserID: "user-1", Role: domain.RoleOwner},
+		{ProjectID: "proj-2", UserID: "user-2", Role: domain.RoleMember},
+	}}
+	uc := &usecase.ListMembersUsecase{Projects: newExistingProjectRepo(t), Members: members}
+
+	got, err := uc.Execute(context.Background(), "proj-1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].UserID != "user-1" {
+		t.Errorf("expected [user-1], got %+v", got)
+	}
+}
+
+func TestRemoveAndGetMember(t *testing.T) {
+	members := &fakeMemberRepo{members: []*domain.Member{{ProjectID: "proj-1", UserID: "user-1", Role: domain.RoleMember}}}
+	getUC := &usecase.GetMemberUsecase{Members: members}
+	removeUC := &usecase.RemoveMemberUsecase{Members: members}
+
+	if _, err := getUC.Execute(context.Background(), "proj-1", "user-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := removeUC.Execute(context.Background(), usecase.RemoveMemberInput{ProjectID: "proj-1", UserID: "user-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := getUC.Execute(context.Background(), "proj-1", "user-1"); !errors.Is(err, usecase.ErrMemberNotFound) {
+		t.Fatalf("expected ErrMemberNotFound, got %v", err)
+	}
+}
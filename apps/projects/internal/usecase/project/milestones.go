@@ -0,0 +1,204 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// MilestoneRepository はマイルストーンの永続化・取得を担当する抽象。
+type MilestoneRepository interface {
+	// SaveMilestone はマイルストーンを保存する。
+	// プロジェクト内に同じ ID が既にある場合は ErrMilestoneAlreadyExists、
+	// プロジェクトが存在しない場合は ErrProjectNotFound 相当のエラーを返す。
+	SaveMilestone(ctx context.Context, m *domain.Milestone) error
+	// UpdateMilestone はマイルストーンを更新する。存在しない場合は ErrMilestoneNotFound 相当のエラーを返す。
+	UpdateMilestone(ctx context.Context, m *domain.Milestone) error
+	// DeleteMilestone はマイルストーンを削除する。存在しない場合は ErrMilestoneNotFound 相当のエラーを返す。
+	DeleteMilestone(ctx context.Context, projectID, id string) error
+	// FindMilestone はマイルストーンを 1 件取得する。存在しない場合は ErrMilestoneNotFound 相当のエラーを返す。
+	FindMilestone(ctx context.Context, projectID, id string) (*domain.Milestone, error)
+	// ListMilestones はプロジェクトのマイルストーンを期限の昇順（期限なしは後ろ、同じ場合は作成日時・ID 順）で返す。
+	ListMilestones(ctx context.Context, projectID string) ([]*domain.Milestone, error)
+}
+
+// MilestoneStatsProvider はプロジェクトのタスクをマイルストーンごとに集計する（tasks サービスのクライアント）。
+// 返り値は milestoneID をキーとし、タスクの無いマイルストーンは含まなくてよい。
+type MilestoneStatsProvider interface {
+	MilestoneStats(ctx context.Context, projectID string) (map[string]domain.MilestoneTaskCounts, error)
+}
+
+// CreateMilestoneInput はマイルストーン作成ユースケースの入力。
+type CreateMilestoneInput struct {
+	ProjectID string
+	ID        string
+	Name      string
+	DueDate   *time.Time
+	Status    string // 空の場合は open
+	ActorID   string // 操作者
+	Now       time.Time
+}
+
+// CreateMilestoneUsecase はマイルストーン作成ユースケース。
+type CreateMilestoneUsecase struct {
+	Projects   ProjectRepository
+	Members    MemberRepository
+	Milestones MilestoneRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（プロジェクトの編集と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute はプロジェクトの存在と操作者のロールを確認してからマイルストーンを保存する。
+// 不正な値の場合は domain.ErrInvalidMilestone を返す。
+func (uc *CreateMilestoneUsecase) Execute(ctx context.Context, in CreateMilestoneInput) (*domain.Milestone, error) {
+	m, err := domain.NewMilestone(in.ID, in.ProjectID, in.Name, in.DueDate, in.Status, in.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.Projects.FindByID(ctx, in.ProjectID); err != nil {
+		return nil, err
+	}
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, domain.ActionEdit); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Milestones.SaveMilestone(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UpdateMilestoneInput はマイルストーン更新ユースケースの入力。
+// 名前・期限・状態を置き換える（省略した期限は未設定、状態は open になる）。
+type UpdateMilestoneInput struct {
+	ProjectID string
+	ID        string
+	Name      string
+	DueDate   *time.Time
+	Status    string
+	ActorID   string // 操作者
+	Now       time.Time
+}
+
+// UpdateMilestoneUsecase はマイルストーン更新ユースケース。
+type UpdateMilestoneUsecase struct {
+	Members    MemberRepository
+	Milestones MilestoneRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（プロジェクトの編集と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute は既存のマイルストーンを取得し、値を置き換えて保存する。
+func (uc *UpdateMilestoneUsecase) Execute(ctx context.Context, in UpdateMilestoneInput) (*domain.Milestone, error) {
+	m, err := uc.Milestones.FindMilestone(ctx, in.ProjectID, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, domain.ActionEdit); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.Update(in.Name, in.DueDate, in.Status, in.Now); err != nil {
+		return nil, err
+	}
+	if err := uc.Milestones.UpdateMilestone(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DeleteMilestoneInput はマイルストーン削除ユースケースの入力。
+type DeleteMilestoneInput struct {
+	ProjectID string
+	ID        string
+	ActorID   string // 操作者
+}
+
+// DeleteMilestoneUsecase はマイルストーン削除ユースケース。
+// マイルストーンに属していたタスクの milestoneId は tasks サービス側に残る（進捗の集計には含まれなくなる）。
+type DeleteMilestoneUsecase struct {
+	Members    MemberRepository
+	Milestones MilestoneRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（プロジェクトの編集と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute は操作者のロールを確認してからマイルストーンを削除する。
+func (uc *DeleteMilestoneUsecase) Execute(ctx context.Context, in DeleteMilestoneInput) error {
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, domain.ActionEdit); err != nil {
+			return err
+		}
+	}
+	return uc.Milestones.DeleteMilestone(ctx, in.ProjectID, in.ID)
+}
+
+// ListMilestonesUsecase はマイルストーン一覧取得ユースケース。
+type ListMilestonesUsecase struct {
+	Projects ProjectRepository
+	Members  MemberRepository
+	// EnforceRoles が true の場合は閲覧権限を確認する（公開プロジェクトは誰でも、非公開プロジェクトはメンバーのみ）
+	EnforceRoles bool
+	Milestones   MilestoneRepository
+}
+
+// Execute はプロジェクトの存在と閲覧権限を確認してからマイルストーンを返す。
+func (uc *ListMilestonesUsecase) Execute(ctx context.Context, projectID, actorID string) ([]*domain.Milestone, error) {
+	if _, err := findReadableProject(ctx, uc.Projects, uc.Members, uc.EnforceRoles, projectID, actorID); err != nil {
+		return nil, err
+	}
+	return uc.Milestones.ListMilestones(ctx, projectID)
+}
+
+// GetMilestoneUsecase はマイルストーン取得ユースケース。
+// tasks サービスがタスクに設定する milestoneId の存在チェックにも使う。
+type GetMilestoneUsecase struct {
+	Milestones MilestoneRepository
+}
+
+// Execute はマイルストーンを返す。存在しない場合は ErrMilestoneNotFound を返す。
+func (uc *GetMilestoneUsecase) Execute(ctx context.Context, projectID, id string) (*domain.Milestone, error) {
+	return uc.Milestones.FindMilestone(ctx, projectID, id)
+}
+
+// GetMilestoneProgressUsecase はマイルストーンごとのタスクの進捗（未完了・完了の件数）を取得するユースケース。
+type GetMilestoneProgressUsecase struct {
+	Projects ProjectRepository
+	Members  MemberRepository
+	// EnforceRoles が true の場合は閲覧権限を確認する（公開プロジェクトは誰でも、非公開プロジェクトはメンバーのみ）
+	EnforceRoles bool
+	Milestones   MilestoneRepository
+	// Stats は集計の取得に使う。nil の場合は ErrTasksService を返す
+	Stats MilestoneStatsProvider
+}
+
+// Execute はプロジェクトのマイルストーンを一覧の順で、タスクの件数と合わせて返す。
+// 集計の取得に失敗した場合は ErrTasksService でラップしたエラーを返す。
+func (uc *GetMilestoneProgressUsecase) Execute(ctx context.Context, projectID, actorID string) ([]domain.MilestoneProgress, error) {
+	if _, err := findReadableProject(ctx, uc.Projects, uc.Members, uc.EnforceRoles, projectID, actorID); err != nil {
+		return nil, err
+	}
+	milestones, err := uc.Milestones.ListMilestones(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(milestones) == 0 {
+		return []domain.MilestoneProgress{}, nil
+	}
+	if uc.Stats == nil {
+		return nil, fmt.Errorf("%w: tasks service is not configured", ErrTasksService)
+	}
+
+	counts, err := uc.Stats.MilestoneStats(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTasksService, err)
+	}
+	return domain.ComputeMilestoneProgress(milestones, counts), nil
+}
@@ -0,0 +1,197 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// fakeMilestoneRepo は MilestoneRepository のテスト用フェイク実装（登録順に返す）。
+type fakeMilestoneRepo struct {
+	milestones []*domain.Milestone
+}
+
+func (r *fakeMilestoneRepo) index(projectID, id string) int {
+	for i, m := range r.milestones {
+		if m.ProjectID == projectID && m.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *fakeMilestoneRepo) SaveMilestone(_ context.Context, m *domain.Milestone) error {
+	if r.index(m.ProjectID, m.ID) >= 0 {
+		return usecase.ErrMilestoneAlreadyExists
+	}
+	r.milestones = append(r.milestones, m)
+	return nil
+}
+
+func (r *fakeMilestoneRepo) UpdateMilestone(_ context.Context, m *domain.Milestone) error {
+	i := r.index(m.ProjectID, m.ID)
+	if i < 0 {
+		return usecase.ErrMilestoneNotFound
+	}
+	r.milestones[i] = m
+	return nil
+}
+
+func (r *fakeMilestoneRepo) DeleteMilestone(_ context.Context, projectID, id string) error {
+	i := r.index(projectID, id)
+	if i < 0 {
+		return usecase.ErrMilestoneNotFound
+	}
+	r.milestones = append(r.milestones[:i], r.milestones[i+1:]...)
+	return nil
+}
+
+func (r *fakeMilestoneRepo) FindMilestone(_ context.Context, projectID, id string) (*domain.Milestone, error) {
+	i := r.index(projectID, id)
+	if i < 0 {
+		return nil, usecase.ErrMilestoneNotFound
+	}
+	return r.milestones[i], nil
+}
+
+func (r *fakeMilestoneRepo) ListMilestones(_ context.Context, projectID string) ([]*domain.Milestone, error) {
+	out := make([]*domain.Milestone, 0)
+	for _, m := range r.milestones {
+		if m.ProjectID == projectID {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+// fakeMilestoneStats は MilestoneStatsProvider のテスト用フェイク実装。
+type fakeMilestoneStats struct {
+	counts map[string]domain.MilestoneTaskCounts
+	err    error
+	calls  int
+}
+
+func (p *fakeMilestoneStats) MilestoneStats(_ context.Context, _ string) (map[string]domain.MilestoneTaskCounts, error) {
+	p.calls++
+	return p.counts, p.err
+}
+
+func TestMilestones_CRUD(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	milestones := &fakeMilestoneRepo{}
+	projects := newExistingProjectRepo(t)
+
+	createUC := &usecase.CreateMilestoneUsecase{Projects: projects, Members: newRoleMembers(), Milestones: milestones, EnforceRoles: true}
+	if _, err := createUC.Execute(ctx, usecase.CreateMilestoneInput{ProjectID: "missing", ID: "v1", Name: "v1", ActorID: "member-1", Now: now}); err == nil {
+		t.Fatal("expected error for missing project")
+	}
+	m, err := createUC.Execute(ctx, usecase.CreateMilestoneInput{ProjectID: "proj-1", ID: "v1", Name: "v1", ActorID: "member-1", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Status != domain.MilestoneOpen || m.ProjectID != "proj-1" {
+		t.Errorf("unexpected milestone: %+v", m)
+	}
+
+	tests := []struct {
+		name    string
+		in      usecase.CreateMilestoneInput
+		wantErr error
+	}{
+		{name: "duplicate id", in: usecase.CreateMilestoneInput{ProjectID: "proj-1", ID: "v1", Name: "v1", ActorID: "member-1"}, wantErr: usecase.ErrMilestoneAlreadyExists},
+		{name: "invalid status", in: usecase.CreateMilestoneInput{ProjectID: "proj-1", ID: "v2", Name: "v2", Status: "done", ActorID: "member-1"}, wantErr: domain.ErrInvalidMilestone},
+		{name: "non-member is forbidden", in: usecase.CreateMilestoneInput{ProjectID: "proj-1", ID: "v2", Name: "v2", ActorID: "stranger"}, wantErr: domain.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.in.Now = now
+			if _, err := createUC.Execute(ctx, tt.in); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	updateUC := &usecase.UpdateMilestoneUsecase{Members: newRoleMembers(), Milestones: milestones, EnforceRoles: true}
+	updated, err := updateUC.Execute(ctx, usecase.UpdateMilestoneInput{ProjectID: "proj-1", ID: "v1", Name: "v1.0", Status: "closed", ActorID: "member-1", Now: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Name != "v1.0" || updated.Status != domain.MilestoneClosed {
+		t.Errorf("unexpected milestone: %+v", updated)
+	}
+	if _, err := updateUC.Execute(ctx, usecase.UpdateMilestoneInput{ProjectID: "proj-1", ID: "missing", Name: "x", ActorID: "member-1"}); !errors.Is(err, usecase.ErrMilestoneNotFound) {
+		t.Errorf("expected ErrMilestoneNotFound, got %v", err)
+	}
+
+	listUC := &usecase.ListMilestonesUsecase{Projects: projects, Milestones: milestones}
+	list, err := listUC.Execute(ctx, "proj-1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 1 || list[0].Name != "v1.0" {
+		t.Errorf("unexpected list: %+v", list)
+	}
+
+	deleteUC := &usecase.DeleteMilestoneUsecase{Members: newRoleMembers(), Milestones: milestones, EnforceRoles: true}
+	if err := deleteUC.Execute(ctx, usecase.DeleteMilestoneInput{ProjectID: "proj-1", ID: "v1", ActorID: "stranger"}); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+	if err := deleteUC.Execute(ctx, usecase.DeleteMilestoneInput{ProjectID: "proj-1", ID: "v1", ActorID: "member-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	getUC := &usecase.GetMilestoneUsecase{Milestones: milestones}
+	if _, err := getUC.Execute(ctx, "proj-1", "v1"); !errors.Is(err, usecase.ErrMilestoneNotFound) {
+		t.Errorf("expected ErrMilestoneNotFound, got %v", err)
+	}
+}
+
+func TestGetMilestoneProgress(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	newUsecase := func(t *testing.T, stats usecase.MilestoneStatsProvider, ids ...string) *usecase.GetMilestoneProgressUsecase {
+		t.Helper()
+		milestones := &fakeMilestoneRepo{}
+		for _, id := range ids {
+			m, err := domain.NewMilestone(id, "proj-1", id, nil, "", now)
+			if err != nil {
+				t.Fatalf("failed to create milestone: %v", err)
+			}
+			if err := milestones.SaveMilestone(ctx, m); err != nil {
+				t.Fatalf("failed to save milestone: %v", err)
+			}
+		}
+		return &usecase.GetMilestoneProgressUsecase{Projects: newExistingProjectRepo(t), Milestones: milestones, Stats: stats}
+	}
+
+	stats := &fakeMilestoneStats{counts: map[string]domain.MilestoneTaskCounts{"v1": {Open: 1, Done: 2}}}
+	got, err := newUsecase(t, stats, "v1", "v2").Execute(ctx, "proj-1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].Open != 1 || got[0].Done != 2 || got[1].Open != 0 {
+		t.Errorf("unexpected progress: %+v", got)
+	}
+
+	// マイルストーンが無い場合は tasks サービスを呼ばない
+	empty := &fakeMilestoneStats{}
+	got, err = newUsecase(t, empty).Execute(ctx, "proj-1", "")
+	if err != nil || len(got) != 0 || empty.calls != 0 {
+		t.Errorf("unexpected result: %+v, %v (calls=%d)", got, err, empty.calls)
+	}
+
+	if _, err := newUsecase(t, nil, "v1").Execute(ctx, "proj-1", ""); !errors.Is(err, usecase.ErrTasksService) {
+		t.Errorf("expected ErrTasksService when not configured, got %v", err)
+	}
+	if _, err := newUsecase(t, &fakeMilestoneStats{err: errors.New("boom")}, "v1").Execute(ctx, "proj-1", ""); !errors.Is(err, usecase.ErrTasksService) {
+		t.Errorf("expected ErrTasksService, got %v", err)
+	}
+	if _, err := newUsecase(t, stats, "v1").Execute(ctx, "missing", ""); err == nil || stats.calls != 1 {
+		t.Errorf("expected project lookup error without calling tasks service, got %v (calls=%d)", err, stats.calls)
+	}
+}
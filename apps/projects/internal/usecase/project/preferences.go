@@ -0,0 +1,106 @@
+package project
+
+import (
+	"context"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// PreferenceRepository はユーザーごとのプロジェクトの表示設定（お気に入り・並び順）の永続化・取得を担当する抽象。
+type PreferenceRepository interface {
+	// ListPreferences はユーザーの表示設定をすべて返す（順序は問わない）。
+	ListPreferences(ctx context.Context, userID string) ([]*domain.Preference, error)
+	// SetFavorite はお気に入りを設定・解除する（表示設定が無ければ作成する）。
+	// プロジェクトが存在しない場合は ErrProjectNotFound 相当のエラーを返す。
+	SetFavorite(ctx context.Context, userID, projectID string, favorite bool, now time.Time) error
+	// ReplaceOrder はユーザーの並び順を projectIDs の順（0 始まり）に置き換える。
+	// projectIDs に含まれないプロジェクトの並び順は解除する（お気に入りはそのまま）。
+	ReplaceOrder(ctx context.Context, userID string, projectIDs []string, now time.Time) error
+}
+
+// SetFavoriteInput はお気に入り設定ユースケースの入力。
+type SetFavoriteInput struct {
+	ProjectID string
+	UserID    string // 操作者（表示設定の持ち主）
+	Favorite  bool
+	Now       time.Time
+}
+
+// SetFavoriteUsecase はプロジェクトをお気に入りに追加・解除するユースケース。
+type SetFavoriteUsecase struct {
+	Projects    ProjectRepository
+	Preferences PreferenceRepository
+}
+
+// Execute はプロジェクトの存在を確認してからお気に入りを設定する。
+// 既にお気に入り（解除済み）の場合もエラーにしない。操作者が空の場合は domain.ErrActorRequired を返す。
+func (uc *SetFavoriteUsecase) Execute(ctx context.Context, in SetFavoriteInput) error {
+	if in.UserID == "" {
+		return domain.ErrActorRequired
+	}
+	if _, err := uc.Projects.FindByID(ctx, in.ProjectID); err != nil {
+		return err
+	}
+	return uc.Preferences.SetFavorite(ctx, in.UserID, in.ProjectID, in.Favorite, in.Now)
+}
+
+// ListPreferencesUsecase はユーザーのプロジェクトの表示設定を取得するユースケース。
+type ListPreferencesUsecase struct {
+	Preferences PreferenceRepository
+}
+
+// Execute はユーザーの表示設定をサイドバーの表示順（domain.SortPreferences）で返す。
+// お気に入りでも並び順の指定も無いものは含めない。操作者が空の場合は domain.ErrActorRequired を返す。
+func (uc *ListPreferencesUsecase) Execute(ctx context.Context, userID string) ([]*domain.Preference, error) {
+	if userID == "" {
+		return nil, domain.ErrActorRequired
+	}
+	prefs, err := uc.Preferences.ListPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]*domain.Preference, 0, len(prefs))
+	for _, p := range prefs {
+		if !p.IsEmpty() {
+			visible = append(visible, p)
+		}
+	}
+	domain.SortPreferences(visible)
+	return visible, nil
+}
+
+// ReorderProjectsInput は並び順更新ユースケースの入力。
+type ReorderProjectsInput struct {
+	UserID     string   // 操作者（表示設定の持ち主）
+	ProjectIDs []string // 表示順のプロジェクト ID。空の場合は並び順をすべて解除する
+	Now        time.Time
+}
+
+// ReorderProjectsUsecase はユーザーのプロジェクトの並び順を置き換えるユースケース。
+type ReorderProjectsUsecase struct {
+	Projects    ProjectRepository
+	Preferences PreferenceRepository
+}
+
+// Execute は projectIDs を検証し、並び順を置き換えてから表示設定を返す（ListPreferencesUsecase と同じ順）。
+// projectIDs が不正な場合は domain.ErrInvalidProjectOrder、存在しないプロジェクトを含む場合は ErrProjectNotFound を返す。
+func (uc *ReorderProjectsUsecase) Execute(ctx context.Context, in ReorderProjectsInput) ([]*domain.Preference, error) {
+	if in.UserID == "" {
+		return nil, domain.ErrActorRequired
+	}
+	if err := domain.ValidateProjectOrder(in.ProjectIDs); err != nil {
+		return nil, err
+	}
+	for _, id := range in.ProjectIDs {
+		if _, err := uc.Projects.FindByID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Preferences.ReplaceOrder(ctx, in.UserID, in.ProjectIDs, in.Now); err != nil {
+		return nil, err
+	}
+	return (&ListPreferencesUsecase{Preferences: uc.Preferences}).Execute(ctx, in.UserID)
+}
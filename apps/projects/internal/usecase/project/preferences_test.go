@@ -0,0 +1,179 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// fakePreferenceRepo は 1 ユーザー分の表示設定を保持する PreferenceRepository のフェイク。
+type fakePreferenceRepo struct {
+	prefs map[string]*domain.Preference // projectID -> 表示設定（単一ユーザー分）
+	err   error
+}
+
+func (r *fakePreferenceRepo) ListPreferences(_ context.Context, _ string) ([]*domain.Preference, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	out := make([]*domain.Preference, 0, len(r.prefs))
+	for _, p := range r.prefs {
+		c := *p
+		out = append(out, &c)
+	}
+	return out, nil
+}
+
+func (r *fakePreferenceRepo) SetFavorite(_ context.Context, userID, projectID string, favorite bool, now time.Time) error {
+	if r.err != nil {
+		return r.err
+	}
+	p := r.get(userID, projectID)
+	p.Favorite, p.UpdatedAt = favorite, now
+	return nil
+}
+
+func (r *fakePreferenceRepo) ReplaceOrder(_ context.Context, userID string, projectIDs []string, now time.Time) error {
+	if r.err != nil {
+		return r.err
+	}
+	for _, p := range r.prefs {
+		p.Position = nil
+	}
+	for i, id := range projectIDs {
+		p := r.get(userID, id)
+		position := i
+		p.Position, p.UpdatedAt = &position, now
+	}
+	return nil
+}
+
+func (r *fakePreferenceRepo) get(userID, projectID string) *domain.Preference {
+	if r.prefs == nil {
+		r.prefs = make(map[string]*domain.Preference)
+	}
+	if _, ok := r.prefs[projectID]; !ok {
+		r.prefs[projectID] = &domain.Preference{UserID: userID, ProjectID: projectID}
+	}
+	return r.prefs[projectID]
+}
+
+// newPreferenceProjects は proj-1 〜 proj-3 を返す ProjectRepository を返す。
+func newPreferenceProjects() *listRepoByID {
+	return &listRepoByID{ids: map[string]bool{"proj-1": true, "proj-2": true, "proj-3": true}}
+}
+
+// listRepoByID は ids に含まれるプロジェクトのみ FindByID で返す ProjectRepository のフェイク。
+type listRepoByID struct {
+	listRepo
+	ids map[string]bool
+}
+
+func (r *listRepoByID) FindByID(_ context.Context, id string) (*domain.Project, error) {
+	if !r.ids[id] {
+		return nil, usecase.ErrProjectNotFound
+	}
+	return &domain.Project{ID: id}, nil
+}
+
+func TestSetFavorite(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	prefs := &fakePreferenceRepo{}
+	uc := &usecase.SetFavoriteUsecase{Projects: newPreferenceProjects(), Preferences: prefs}
+
+	tests := []struct {
+		name    string
+		in      usecase.SetFavoriteInput
+		wantErr error
+	}{
+		{name: "favorite", in: usecase.SetFavoriteInput{ProjectID: "proj-1", UserID: "user-1", Favorite: true, Now: now}},
+		{name: "favorite again", in: usecase.SetFavoriteInput{ProjectID: "proj-1", UserID: "user-1", Favorite: true, Now: now}},
+		{name: "no actor", in: usecase.SetFavoriteInput{ProjectID: "proj-1", Favorite: true}, wantErr: domain.ErrActorRequired},
+		{name: "project not found", in: usecase.SetFavoriteInput{ProjectID: "proj-x", UserID: "user-1", Favorite: true}, wantErr: usecase.ErrProjectNotFound},
+	}
+
+	for _, tt := range tests {
+		err := uc.Execute(context.Background(), tt.in)
+		if tt.wantErr == nil && err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+			t.Fatalf("%s: expected %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+	if p := prefs.prefs["proj-1"]; p == nil || !p.Favorite || p.UserID != "user-1" {
+		t.Errorf("expected proj-1 to be favorite, got %+v", p)
+	}
+	if _, ok := prefs.prefs["proj-x"]; ok {
+		t.Errorf("expected no preference for unknown project")
+	}
+}
+
+func TestReorderProjects(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	prefs := &fakePreferenceRepo{}
+	_ = prefs.SetFavorite(context.Background(), "user-1", "proj-3", true, now)
+	uc := &usecase.ReorderProjectsUsecase{Projects: newPreferenceProjects(), Preferences: prefs}
+
+	got, err := uc.Execute(context.Background(), usecase.ReorderProjectsInput{UserID: "user-1", ProjectIDs: []string{"proj-2", "proj-1"}, Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// お気に入りが先頭、その後に並び順
+	want := []string{"proj-3", "proj-2", "proj-1"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %+v", want, got)
+	}
+	for i, p := range got {
+		if p.ProjectID != want[i] {
+			t.Errorf("position %d: expected %s, got %s", i, want[i], p.ProjectID)
+		}
+	}
+}
+
+func TestReorderProjects_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      usecase.ReorderProjectsInput
+		wantErr error
+	}{
+		{name: "no actor", in: usecase.ReorderProjectsInput{ProjectIDs: []string{"proj-1"}}, wantErr: domain.ErrActorRequired},
+		{name: "duplicate", in: usecase.ReorderProjectsInput{UserID: "user-1", ProjectIDs: []string{"proj-1", "proj-1"}}, wantErr: domain.ErrInvalidProjectOrder},
+		{name: "project not found", in: usecase.ReorderProjectsInput{UserID: "user-1", ProjectIDs: []string{"proj-1", "proj-x"}}, wantErr: usecase.ErrProjectNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefs := &fakePreferenceRepo{}
+			uc := &usecase.ReorderProjectsUsecase{Projects: newPreferenceProjects(), Preferences: prefs}
+
+			if _, err := uc.Execute(context.Background(), tt.in); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			// 失敗した場合は並び順を変更しない
+			if len(prefs.prefs) != 0 {
+				t.Errorf("expected no preferences to be saved, got %+v", prefs.prefs)
+			}
+		})
+	}
+}
+
+func TestListPreferences_SkipsEmpty(t *testing.T) {
+	prefs := &fakePreferenceRepo{prefs: map[string]*domain.Preference{
+		"proj-1": {UserID: "user-1", ProjectID: "proj-1"},
+		"proj-2": {UserID: "user-1", ProjectID: "proj-2", Favorite: true},
+	}}
+	uc := &usecase.ListPreferencesUsecase{Preferences: prefs}
+
+	got, err := uc.Execute(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].ProjectID != "proj-2" {
+		t.Errorf("expected only proj-2, got %+v", got)
+	}
+}
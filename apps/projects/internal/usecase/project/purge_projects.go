@@ -0,0 +1,127 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"teamflow-shared/audit"
+	"teamflow-shared/authz"
+	"teamflow-shared/workspace"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// ProjectPurger はアーカイブ・削除してから保持期間を過ぎたプロジェクトを取り出し、物理削除する。
+// いずれもすべてのワークスペースのプロジェクトを対象にする（運用者・保持期間のジョブがまとめて扱うため）。
+type ProjectPurger interface {
+	// PurgeCandidates は before より前にアーカイブ、または削除されたプロジェクトを、
+	// アーカイブ・削除の早い方の日時と ID の順に最大 limit 件返す。
+	PurgeCandidates(ctx context.Context, before time.Time, limit int) ([]*domain.Project, error)
+	// HardDelete は id のプロジェクトを物理削除する（メンバー・設定などもあわせて削除する）。
+	// 存在しない場合は ErrProjectNotFound を返す。
+	HardDelete(ctx context.Context, id string) error
+}
+
+// DefaultProjectPurgeBatchSize は 1 回の呼び出しで物理削除するプロジェクトの最大数の既定値。
+// プロジェクトごとに tasks サービスを呼び出すため、タスクの物理削除より小さくする。
+const DefaultProjectPurgeBatchSize = 50
+
+// PurgeProjectsInput はプロジェクトの物理削除の入力。
+type PurgeProjectsInput struct {
+	// OlderThanDays はアーカイブ・削除してからの日数。これより前にアーカイブ・削除されたプロジェクトを対象にする（1 以上）
+	OlderThanDays int
+	// DryRun が true の場合は削除せずに対象のプロジェクトを返す
+	DryRun  bool
+	ActorID string // 操作者（監査ログに記録する）。保持期間のジョブの場合は空
+	Now     time.Time
+}
+
+// PurgeProjectsOutput はプロジェクトの物理削除の結果。
+type PurgeProjectsOutput struct {
+	// Before はこの日時より前にアーカイブ・削除されたプロジェクトを対象にしたことを表す
+	Before time.Time
+	// Projects は削除したプロジェクト（DryRun の場合は削除するプロジェクト）
+	Projects []*domain.Project
+	// HasMore は対象のプロジェクトが BatchSize を超えて残っていることを表す（続きはもう一度呼び出す）
+	HasMore bool
+}
+
+// PurgeProjectsUsecase はアーカイブ・削除してから指定した日数を過ぎたプロジェクトを物理削除するユースケース。
+//
+// プロジェクトとタスクは別のサービスにあるため、プロジェクトごとに次の順で処理する。
+//  1. tasks サービスでプロジェクトのタスクを削除する
+//  2. プロジェクトを物理削除し、監査ログに記録する
+//
+// 1 の後に 2 が失敗した場合も、タスクの削除は再試行してよいため、もう一度呼び出せばよい。
+// 削除したプロジェクトは戻せない（復元期間も過ぎたものとして扱う）。
+type PurgeProjectsUsecase struct {
+	Purger ProjectPurger
+	Tx     TxManager // 任意。nil の場合はトランザクション無しで実行する
+	// Tasks はプロジェクトのタスクの削除に使う
+	Tasks TaskCascader
+	// Operators は Execute（管理用 API）を呼び出せる運用者（ADMIN_USER_IDS）。空の場合は誰も呼び出せない
+	Operators authz.Operators
+	// Audit は削除したプロジェクトを監査ログに記録するために使う。任意。nil の場合は記録しない
+	Audit audit.Recorder
+	// BatchSize は 1 回の呼び出しで削除する最大数。任意。0 の場合は DefaultProjectPurgeBatchSize
+	BatchSize int
+}
+
+// Execute は操作者が運用者であることを確認してから Purge を実行する（管理用 API）。
+func (uc *PurgeProjectsUsecase) Execute(ctx context.Context, in PurgeProjectsInput) (*PurgeProjectsOutput, error) {
+	if err := uc.Operators.Authorize(in.ActorID); err != nil {
+		return nil, err
+	}
+	return uc.Purge(ctx, in)
+}
+
+// Purge は in.OlderThanDays 日より前にアーカイブ・削除されたプロジェクトを最大 BatchSize 件、タスクとともに物理削除し、
+// 削除したプロジェクトを監査ログに記録する。DryRun の場合は削除・記録をせずに対象のプロジェクトを返す。
+//
+// OlderThanDays が 1 未満の場合は domain.ErrInvalidRetentionDays、Tasks が nil（tasks サービスが設定されていない）か
+// タスクの削除に失敗した場合は ErrTasksService を返す（それまでに削除したプロジェクトは削除されたまま）。
+func (uc *PurgeProjectsUsecase) Purge(ctx context.Context, in PurgeProjectsInput) (*PurgeProjectsOutput, error) {
+	if in.OlderThanDays < 1 {
+		return nil, domain.ErrInvalidRetentionDays
+	}
+	out := &PurgeProjectsOutput{Before: in.Now.AddDate(0, 0, -in.OlderThanDays)}
+	limit := uc.BatchSize
+	if limit <= 0 {
+		limit = DefaultProjectPurgeBatchSize
+	}
+
+	projects, err := uc.Purger.PurgeCandidates(ctx, out.Before, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	out.HasMore = len(projects) > limit
+	projects = projects[:min(len(projects), limit)]
+	if in.DryRun {
+		out.Projects = projects
+		return out, nil
+	}
+	if len(projects) > 0 && uc.Tasks == nil {
+		return nil, fmt.Errorf("%w: tasks service is not configured", ErrTasksService)
+	}
+
+	out.Projects = make([]*domain.Project, 0, len(projects))
+	for _, p := range projects {
+		// tasks サービス・監査ログはプロジェクトのワークスペースで扱う
+		pctx := workspace.NewContext(ctx, p.WorkspaceID)
+		if err := uc.Tasks.DeleteTasks(authz.ContextWithActor(pctx, in.ActorID), p.ID); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrTasksService, err)
+		}
+		err := withinTx(pctx, uc.Tx, func(ctx context.Context) error {
+			if err := uc.Purger.HardDelete(ctx, p.ID); err != nil {
+				return err
+			}
+			return recordAudit(ctx, uc.Audit, p, audit.ActionPurge, in.ActorID, nil, in.Now)
+		})
+		if err != nil {
+			return nil, err
+		}
+		out.Projects = append(out.Projects, p)
+	}
+	return out, nil
+}
@@ -0,0 +1,144 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"teamflow-shared/audit"
+	"teamflow-shared/authz"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// fakeProjectPurger はアーカイブ日時（ArchivedAt）だけで対象を判定する usecase.ProjectPurger。
+type fakeProjectPurger struct {
+	projects []*domain.Project
+	deleted  []string
+}
+
+func (f *fakeProjectPurger) PurgeCandidates(_ context.Context, before time.Time, limit int) ([]*domain.Project, error) {
+	var out []*domain.Project
+	for _, p := range f.projects {
+		if p.ArchivedAt != nil && p.ArchivedAt.Before(before) {
+			out = append(out, p)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ArchivedAt.Before(*out[j].ArchivedAt) })
+	return out[:min(len(out), limit)], nil
+}
+
+func (f *fakeProjectPurger) HardDelete(_ context.Context, id string) error {
+	for i, p := range f.projects {
+		if p.ID == id {
+			f.projects = append(f.projects[:i], f.projects[i+1:]...)
+			f.deleted = append(f.deleted, id)
+			return nil
+		}
+	}
+	return usecase.ErrProjectNotFound
+}
+
+func newPurgeFixture(t *testing.T, now time.Time) *fakeProjectPurger {
+	t.Helper()
+	purger := &fakeProjectPurger{}
+	for _, tc := range []struct {
+		id      string
+		daysAgo int
+	}{{"proj-1", 120}, {"proj-2", 200}, {"proj-3", 30}} {
+		p, err := domain.NewProject(tc.id, tc.id, "", now.AddDate(-1, 0, 0))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		archivedAt := now.AddDate(0, 0, -tc.daysAgo)
+		p.ArchivedAt = &archivedAt
+		p.WorkspaceID = "ws-1"
+		purger.projects = append(purger.projects, p)
+	}
+	return purger
+}
+
+func TestPurgeProjects(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	purger := newPurgeFixture(t, now)
+	tasks := &fakeTaskCascader{}
+	rec := audit.NewMemoryRecorder()
+	uc := &usecase.PurgeProjectsUsecase{
+		Purger:    purger,
+		Tasks:     tasks,
+		Operators: authz.ParseOperators("admin-1"),
+		Audit:     rec,
+		BatchSize: 1,
+	}
+	ctx := context.Background()
+
+	// dry-run はタスク・プロジェクトを削除しない
+	out, err := uc.Execute(ctx, usecase.PurgeProjectsInput{OlderThanDays: 90, DryRun: true, ActorID: "admin-1", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Projects) != 1 || out.Projects[0].ID != "proj-2" || !out.HasMore {
+		t.Errorf("unexpected dry-run result: %+v", out)
+	}
+	if len(tasks.calls) != 0 || len(purger.deleted) != 0 || len(rec.Entries()) != 0 {
+		t.Fatalf("dry-run must not delete anything: calls=%v deleted=%v", tasks.calls, purger.deleted)
+	}
+
+	// タスクを削除してからプロジェクトを削除する
+	for _, want := range []struct {
+		id      string
+		hasMore bool
+	}{{"proj-2", true}, {"proj-1", false}} {
+		out, err := uc.Execute(ctx, usecase.PurgeProjectsInput{OlderThanDays: 90, ActorID: "admin-1", Now: now})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(out.Projects) != 1 || out.Projects[0].ID != want.id || out.HasMore != want.hasMore {
+			t.Errorf("expected %s (hasMore=%v), got %+v", want.id, want.hasMore, out)
+		}
+	}
+	if got := tasks.calls; len(got) != 2 || got[0] != "delete:proj-2" || got[1] != "delete:proj-1" {
+		t.Errorf("unexpected task calls: %v", got)
+	}
+	if len(purger.projects) != 1 || purger.projects[0].ID != "proj-3" {
+		t.Errorf("expected only the recently archived project to remain, got %v", purger.projects)
+	}
+	entries := rec.Entries()
+	if len(entries) != 2 || entries[0].Action != audit.ActionPurge || entries[0].EntityID != "proj-2" || entries[0].ActorID != "admin-1" {
+		t.Errorf("unexpected audit entries: %+v", entries)
+	}
+}
+
+func TestPurgeProjects_Errors(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		tasks usecase.TaskCascader
+		in    usecase.PurgeProjectsInput
+		want  error
+	}{
+		{name: "no actor", tasks: &fakeTaskCascader{}, in: usecase.PurgeProjectsInput{OlderThanDays: 90, Now: now}, want: domain.ErrActorRequired},
+		{name: "not an operator", tasks: &fakeTaskCascader{}, in: usecase.PurgeProjectsInput{OlderThanDays: 90, ActorID: "user-1", Now: now}, want: domain.ErrForbidden},
+		{name: "zero days", tasks: &fakeTaskCascader{}, in: usecase.PurgeProjectsInput{ActorID: "admin-1", Now: now}, want: domain.ErrInvalidRetentionDays},
+		{name: "tasks service not configured", in: usecase.PurgeProjectsInput{OlderThanDays: 90, ActorID: "admin-1", Now: now}, want: usecase.ErrTasksService},
+		{name: "tasks service failure", tasks: &fakeTaskCascader{err: errors.New("down")}, in: usecase.PurgeProjectsInput{OlderThanDays: 90, ActorID: "admin-1", Now: now}, want: usecase.ErrTasksService},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purger := newPurgeFixture(t, now)
+			uc := &usecase.PurgeProjectsUsecase{Purger: purger, Tasks: tt.tasks, Operators: authz.ParseOperators("admin-1")}
+			if _, err := uc.Execute(ctx, tt.in); !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+			// タスクを削除できなかったプロジェクトは削除しない
+			if len(purger.deleted) != 0 {
+				t.Errorf("expected no projects to be deleted, got %v", purger.deleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// SettingsRepository はプロジェクト設定の永続化・取得を担当する抽象。
+type SettingsRepository interface {
+	// FindSettings は設定を取得する。保存されていない場合は ErrSettingsNotFound 相当のエラーを返す。
+	FindSettings(ctx context.Context, projectID string) (*domain.Settings, error)
+	// SaveSettings は設定を保存する（既存の設定は置き換える）。
+	SaveSettings(ctx context.Context, s *domain.Settings) error
+}
+
+// GetSettingsUsecase はプロジェクト設定の取得ユースケース。
+type GetSettingsUsecase struct {
+	Projects ProjectRepository
+	Settings SettingsRepository
+}
+
+// Execute はプロジェクトの存在を確認してから設定を返す。
+// 設定が保存されていない場合は domain.DefaultSettings を返す。
+func (uc *GetSettingsUsecase) Execute(ctx context.Context, projectID string) (*domain.Settings, error) {
+	if _, err := uc.Projects.FindByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	s, err := uc.Settings.FindSettings(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, ErrSettingsNotFound) {
+			return domain.DefaultSettings(projectID), nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// UpdateSettingsInput はプロジェクト設定更新ユースケースの入力。
+// 設定全体を置き換える（省略したフィールドは未設定になる）。
+type UpdateSettingsInput struct {
+	ProjectID         string
+	DefaultPriority   string
+	DefaultAssigneeID string
+	DefaultSort       string
+	WIPLimits         map[string]int
+	FieldLocks        map[string][]string // タスクのフィールドごとに変更できるロール
+	Timezone          string              // 期日の判定に使うタイムゾーン（IANA のタイムゾーン名）
+	ActorID           string              // 操作者
+	Now               time.Time
+}
+
+// UpdateSettingsUsecase はプロジェクト設定の更新ユースケース。
+type UpdateSettingsUsecase struct {
+	Projects ProjectRepository
+	Members  MemberRepository
+	Settings SettingsRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（owner / admin のみ）
+	EnforceRoles bool
+}
+
+// Execute は設定を検証して保存する。
+// 値が不正な場合や既定の担当者がプロジェクトのメンバーでない場合は domain.ErrInvalidSettings を返す。
+func (uc *UpdateSettingsUsecase) Execute(ctx context.Context, in UpdateSettingsInput) (*domain.Settings, error) {
+	s := &domain.Settings{
+		ProjectID:         in.ProjectID,
+		DefaultPriority:   in.DefaultPriority,
+		DefaultAssigneeID: in.DefaultAssigneeID,
+		DefaultSort:       in.DefaultSort,
+		WIPLimits:         in.WIPLimits,
+		FieldLocks:        make(map[string][]domain.MemberRole, len(in.FieldLocks)),
+		Timezone:          in.Timezone,
+		UpdatedAt:         in.Now,
+	}
+	for field, roles := range in.FieldLocks {
+		for _, r := range roles {
+			s.FieldLocks[field] = append(s.FieldLocks[field], domain.MemberRole(r))
+		}
+	}
+	s.Normalize()
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.Projects.FindByID(ctx, in.ProjectID); err != nil {
+		return nil, err
+	}
+
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, domain.ActionManageSettings); err != nil {
+			return nil, err
+		}
+	}
+
+	// 既定の担当者はプロジェクトのメンバーに限る（tasks 側の担当者チェックと揃える）
+	if s.DefaultAssigneeID != "" {
+		if _, err := uc.Members.FindMember(ctx, in.ProjectID, s.DefaultAssigneeID); err != nil {
+			if errors.Is(err, ErrMemberNotFound) {
+				return nil, fmt.Errorf("%w: defaultAssigneeId must be a project member", domain.ErrInvalidSettings)
+			}
+			return nil, err
+		}
+	}
+
+	if err := uc.Settings.SaveSettings(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,125 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// fakeSettingsRepo は SettingsRepository のテスト用フェイク実装。
+type fakeSettingsRepo struct {
+	stored map[string]*domain.Settings
+}
+
+func (r *fakeSettingsRepo) FindSettings(_ context.Context, projectID string) (*domain.Settings, error) {
+	s, ok := r.stored[projectID]
+	if !ok {
+		return nil, usecase.ErrSettingsNotFound
+	}
+	return s, nil
+}
+
+func (r *fakeSettingsRepo) SaveSettings(_ context.Context, s *domain.Settings) error {
+	if r.stored == nil {
+		r.stored = make(map[string]*domain.Settings)
+	}
+	r.stored[s.ProjectID] = s
+	return nil
+}
+
+func TestGetSettings_DefaultsWhenNotSaved(t *testing.T) {
+	uc := &usecase.GetSettingsUsecase{Projects: newExistingProjectRepo(t), Settings: &fakeSettingsRepo{}}
+
+	s, err := uc.Execute(context.Background(), "proj-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.ProjectID != "proj-1" || s.DefaultPriority != "" || len(s.WIPLimits) != 0 {
+		t.Errorf("expected empty default settings, got %+v", s)
+	}
+}
+
+func TestUpdateSettings(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		in      usecase.UpdateSettingsInput
+		wantErr error
+	}{
+		{
+			name: "success",
+			in: usecase.UpdateSettingsInput{
+				DefaultPriority:   "High",
+				DefaultAssigneeID: "member-1",
+				DefaultSort:       "-priority",
+				WIPLimits:         map[string]int{"in_progress": 3},
+				FieldLocks:        map[string][]string{"priority": {"Admin", "owner"}},
+				ActorID:           "admin-1",
+			},
+		},
+		{
+			name:    "invalid priority",
+			in:      usecase.UpdateSettingsInput{DefaultPriority: "urgent", ActorID: "admin-1"},
+			wantErr: domain.ErrInvalidSettings,
+		},
+		{
+			name:    "unknown locked field",
+			in:      usecase.UpdateSettingsInput{FieldLocks: map[string][]string{"createdAt": {"admin"}}, ActorID: "admin-1"},
+			wantErr: domain.ErrInvalidSettings,
+		},
+		{
+			name:    "assignee is not a member",
+			in:      usecase.UpdateSettingsInput{DefaultAssigneeID: "stranger", ActorID: "admin-1"},
+			wantErr: domain.ErrInvalidSettings,
+		},
+		{
+			name:    "member cannot change settings",
+			in:      usecase.UpdateSettingsInput{DefaultPriority: "low", ActorID: "member-1"},
+			wantErr: domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &fakeSettingsRepo{}
+			uc := &usecase.UpdateSettingsUsecase{
+				Projects:     newExistingProjectRepo(t),
+				Members:      newRoleMembers(),
+				Settings:     settings,
+				EnforceRoles: true,
+			}
+
+			in := tt.in
+			in.ProjectID = "proj-1"
+			in.Now = now
+			s, err := uc.Execute(context.Background(), in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				if len(settings.stored) != 0 {
+					t.Fatalf("expected settings not to be saved")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if s.DefaultPriority != "high" || !s.UpdatedAt.Equal(now) {
+				t.Errorf("unexpected settings: %+v", s)
+			}
+			if got := s.FieldLocks["priority"]; !slices.Equal(got, []domain.MemberRole{domain.RoleAdmin, domain.RoleOwner}) {
+				t.Errorf("FieldLocks[priority] = %v, want [admin owner]", got)
+			}
+			if settings.stored["proj-1"] != s {
+				t.Errorf("expected settings to be saved")
+			}
+		})
+	}
+}
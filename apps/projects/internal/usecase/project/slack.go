@@ -0,0 +1,291 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"teamflow-shared/events"
+	"teamflow-shared/workspace"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// SlackIntegrationRepository はプロジェクトの Slack 連携の設定の永続化・取得を担当する抽象。
+type SlackIntegrationRepository interface {
+	// FindSlackIntegration は設定を取得する。保存されていない場合は ErrSlackIntegrationNotFound 相当のエラーを返す。
+	FindSlackIntegration(ctx context.Context, projectID string) (*domain.SlackIntegration, error)
+	// SaveSlackIntegration は設定を保存する（既存の設定は置き換える）。
+	// プロジェクトが存在しない場合は ErrProjectNotFound 相当のエラーを返す。
+	SaveSlackIntegration(ctx context.Context, s *domain.SlackIntegration) error
+	// DeleteSlackIntegration は設定を削除する。保存されていない場合は ErrSlackIntegrationNotFound 相当のエラーを返す。
+	DeleteSlackIntegration(ctx context.Context, projectID string) error
+}
+
+// SlackPoster は Slack の Incoming Webhook にメッセージを投稿する（一時的な失敗の再試行は実装が行う）。
+type SlackPoster interface {
+	// PostSlackMessage は webhookURL に text（Slack の mrkdwn）を投稿する。
+	// 返すエラーに webhookURL を含めない（URL 自体が投稿の権限を持つため）。
+	PostSlackMessage(ctx context.Context, webhookURL, text string) error
+}
+
+// ErrSlackIntegrationNotFound はプロジェクトに Slack 連携の設定が無い場合に返す。
+var ErrSlackIntegrationNotFound = errors.New("slack integration not found")
+
+// ErrSlackDelivery は Slack への投稿に（再試行しても）失敗した場合に返す。
+var ErrSlackDelivery = errors.New("failed to post to slack")
+
+// DefaultSlackWebhookHosts は Webhook URL に指定できるホストの既定値。
+var DefaultSlackWebhookHosts = []string{"hooks.slack.com"}
+
+// allowedSlackWebhook は s の Webhook URL のホストが hosts（空の場合は DefaultSlackWebhookHosts）に含まれるかを返す。
+// サーバーから任意の宛先にリクエストを送れないようにする（SSRF 対策）。
+func allowedSlackWebhook(hosts []string, s *domain.SlackIntegration) bool {
+	if len(hosts) == 0 {
+		hosts = DefaultSlackWebhookHosts
+	}
+	return slices.Contains(hosts, s.WebhookHost())
+}
+
+// GetSlackIntegrationUsecase は Slack 連携の設定の取得ユースケース。
+type GetSlackIntegrationUsecase struct {
+	Projects     ProjectRepository
+	Integrations SlackIntegrationRepository
+}
+
+// Execute はプロジェクトの存在を確認してから設定を返す。設定が無い場合は ErrSlackIntegrationNotFound を返す。
+func (uc *GetSlackIntegrationUsecase) Execute(ctx context.Context, projectID string) (*domain.SlackIntegration, error) {
+	if _, err := uc.Projects.FindByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+	return uc.Integrations.FindSlackIntegration(ctx, projectID)
+}
+
+// UpdateSlackIntegrationInput は Slack 連携の設定の更新ユースケースの入力（設定全体を置き換える）。
+type UpdateSlackIntegrationInput struct {
+	ProjectID  string
+	WebhookURL string
+	Events     []string // 空の場合はすべてのイベントを投稿する
+	ActorID    string   // 操作者
+	Now        time.Time
+}
+
+// UpdateSlackIntegrationUsecase は Slack 連携の設定の更新ユースケース。
+type UpdateSlackIntegrationUsecase struct {
+	Projects     ProjectRepository
+	Members      MemberRepository
+	Integrations SlackIntegrationRepository
+	// AllowedHosts は Webhook URL に指定できるホスト。任意。空の場合は DefaultSlackWebhookHosts
+	AllowedHosts []string
+	// EnforceRoles が true の場合は操作者のロールを確認する（プロジェクト設定の変更と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute は設定を検証して保存する。値が不正な場合や許可していないホストの場合は domain.ErrInvalidSlackIntegration を返す。
+func (uc *UpdateSlackIntegrationUsecase) Execute(ctx context.Context, in UpdateSlackIntegrationInput) (*domain.SlackIntegration, error) {
+	s := &domain.SlackIntegration{
+		ProjectID:  in.ProjectID,
+		WebhookURL: in.WebhookURL,
+		UpdatedAt:  in.Now,
+		UpdatedBy:  in.ActorID,
+	}
+	for _, e := range in.Events {
+		s.Events = append(s.Events, domain.SlackEvent(e))
+	}
+	s.Normalize()
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	if !allowedSlackWebhook(uc.AllowedHosts, s) {
+		return nil, fmt.Errorf("%w: webhookUrl host %q is not allowed", domain.ErrInvalidSlackIntegration, s.WebhookHost())
+	}
+
+	if _, err := uc.Projects.FindByID(ctx, in.ProjectID); err != nil {
+		return nil, err
+	}
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, domain.ActionManageSettings); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Integrations.SaveSlackIntegration(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// DeleteSlackIntegrationUsecase は Slack 連携の設定の削除ユースケース。
+type DeleteSlackIntegrationUsecase struct {
+	Projects     ProjectRepository
+	Members      MemberRepository
+	Integrations SlackIntegrationRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（プロジェクト設定の変更と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute はプロジェクトの存在と操作者のロールを確認してから設定を削除する。
+func (uc *DeleteSlackIntegrationUsecase) Execute(ctx context.Context, projectID, actorID string) error {
+	if _, err := uc.Projects.FindByID(ctx, projectID); err != nil {
+		return err
+	}
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, projectID, actorID, domain.ActionManageSettings); err != nil {
+			return err
+		}
+	}
+	return uc.Integrations.DeleteSlackIntegration(ctx, projectID)
+}
+
+// TestSlackIntegrationUsecase は保存した Webhook にテストメッセージを投稿するユースケース（設定の確認用）。
+type TestSlackIntegrationUsecase struct {
+	Projects     ProjectRepository
+	Members      MemberRepository
+	Integrations SlackIntegrationRepository
+	Poster       SlackPoster
+	// AllowedHosts は投稿できる Webhook のホスト。任意。空の場合は DefaultSlackWebhookHosts
+	AllowedHosts []string
+	// EnforceRoles が true の場合は操作者のロールを確認する（プロジェクト設定の変更と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute はテストメッセージを投稿する。設定が無い場合は ErrSlackIntegrationNotFound、
+// 投稿に失敗した場合は ErrSlackDelivery を返す。
+func (uc *TestSlackIntegrationUsecase) Execute(ctx context.Context, projectID, actorID string) error {
+	p, err := uc.Projects.FindByID(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, projectID, actorID, domain.ActionManageSettings); err != nil {
+			return err
+		}
+	}
+	s, err := uc.Integrations.FindSlackIntegration(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if !allowedSlackWebhook(uc.AllowedHosts, s) {
+		return fmt.Errorf("%w: webhook host %q is not allowed", ErrSlackDelivery, s.WebhookHost())
+	}
+	text := fmt.Sprintf("*%s*: TeamFlow からのテストメッセージです。このチャンネルにタスクの通知を投稿します。", slackEscape(p.Name))
+	if err := uc.Poster.PostSlackMessage(ctx, s.WebhookURL, text); err != nil {
+		return fmt.Errorf("%w: %w", ErrSlackDelivery, err)
+	}
+	return nil
+}
+
+// SlackNotificationEvents は NotifySlackUsecase が購読するタスクのイベントの種類。
+var SlackNotificationEvents = []events.Type{events.TaskCreated, events.TaskUpdated}
+
+// NotifySlackUsecase はタスクのイベントを、プロジェクトの Slack 連携の設定に従って Slack に投稿するユースケース。
+// 作成・ステータスの変更・担当者の設定のうち、設定で選んだものを 1 つのメッセージにまとめて投稿する。
+type NotifySlackUsecase struct {
+	Projects     ProjectRepository
+	Integrations SlackIntegrationRepository
+	Poster       SlackPoster
+	// AllowedHosts は投稿できる Webhook のホスト。任意。空の場合は DefaultSlackWebhookHosts
+	AllowedHosts []string
+}
+
+// Execute はイベントのワークスペースのプロジェクトの設定を確認し、投稿するイベントであれば投稿する。
+// 設定の無いプロジェクト・存在しないプロジェクト・投稿しないイベントでは何もしない。
+// 投稿に失敗した場合は ErrSlackDelivery を返す（Poster が再試行した後のため、呼び出し側は再試行しなくてよい）。
+func (uc *NotifySlackUsecase) Execute(ctx context.Context, e events.Event) error {
+	var data events.TaskData
+	if err := e.Decode(&data); err != nil {
+		return fmt.Errorf("invalid task event %s: %w", e.ID, err)
+	}
+	kinds := slackEventsOf(e.Type, data)
+	if len(kinds) == 0 {
+		return nil
+	}
+
+	ctx = workspace.NewContext(ctx, e.WorkspaceID)
+	s, err := uc.Integrations.FindSlackIntegration(ctx, data.ProjectID)
+	if errors.Is(err, ErrSlackIntegrationNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	kinds = slices.DeleteFunc(kinds, func(k domain.SlackEvent) bool { return !s.Notifies(k) })
+	if len(kinds) == 0 {
+		return nil
+	}
+	if !allowedSlackWebhook(uc.AllowedHosts, s) {
+		return fmt.Errorf("%w: webhook host %q is not allowed", ErrSlackDelivery, s.WebhookHost())
+	}
+
+	// 設定はワークスペースを持たないため、プロジェクトがイベントのワークスペースにあることを確認する
+	p, err := uc.Projects.FindByID(ctx, data.ProjectID)
+	if errors.Is(err, ErrProjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := uc.Poster.PostSlackMessage(ctx, s.WebhookURL, slackMessage(p, data, kinds)); err != nil {
+		return fmt.Errorf("%w: %w", ErrSlackDelivery, err)
+	}
+	return nil
+}
+
+// slackEventsOf はイベントが当てはまる Slack のイベントの種類を返す。
+func slackEventsOf(typ events.Type, data events.TaskData) []domain.SlackEvent {
+	var kinds []domain.SlackEvent
+	switch typ {
+	case events.TaskCreated:
+		kinds = append(kinds, domain.SlackEventTaskCreated)
+		if data.AssigneeID != "" && data.AssigneeID != data.ActorID {
+			kinds = append(kinds, domain.SlackEventTaskAssigned)
+		}
+	case events.TaskUpdated:
+		if data.PreviousStatus != "" {
+			kinds = append(kinds, domain.SlackEventTaskStatusChanged)
+		}
+		if data.AssigneeChanged && data.AssigneeID != "" {
+			kinds = append(kinds, domain.SlackEventTaskAssigned)
+		}
+	}
+	return kinds
+}
+
+// slackMessage は Slack に投稿するメッセージ（mrkdwn）を組み立てる。1 行目はプロジェクト名と操作者。
+// 例: *TeamFlow*（操作者: user-1）
+//
+//	タスク TF-12「ログイン画面の修正」のステータスを todo から done に変更しました
+func slackMessage(p *domain.Project, data events.TaskData, kinds []domain.SlackEvent) string {
+	label := "「" + slackEscape(data.Title) + "」"
+	switch {
+	case data.Number > 0 && p.Key != "":
+		label = fmt.Sprintf("%s-%d%s", p.Key, data.Number, label)
+	case data.Number > 0:
+		label = fmt.Sprintf("#%d%s", data.Number, label)
+	}
+
+	lines := []string{"*" + slackEscape(p.Name) + "*"}
+	if data.ActorID != "" {
+		lines[0] += "（操作者: " + slackEscape(data.ActorID) + "）"
+	}
+	for _, k := range kinds {
+		switch k {
+		case domain.SlackEventTaskCreated:
+			lines = append(lines, fmt.Sprintf("タスク %sを作成しました", label))
+		case domain.SlackEventTaskStatusChanged:
+			lines = append(lines, fmt.Sprintf("タスク %sのステータスを %s から %s に変更しました", label, data.PreviousStatus, data.Status))
+		case domain.SlackEventTaskAssigned:
+			lines = append(lines, fmt.Sprintf("タスク %sの担当者を %s にしました", label, slackEscape(data.AssigneeID)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// slackEscape は Slack の mrkdwn で制御文字として扱われる &, <, > をエスケープする。
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,216 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"teamflow-shared/events"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// fakeSlackIntegrations は SlackIntegrationRepository のテスト用フェイク実装。
+type fakeSlackIntegrations struct {
+	stored map[string]*domain.SlackIntegration
+}
+
+func (r *fakeSlackIntegrations) FindSlackIntegration(_ context.Context, projectID string) (*domain.SlackIntegration, error) {
+	s, ok := r.stored[projectID]
+	if !ok {
+		return nil, usecase.ErrSlackIntegrationNotFound
+	}
+	return s, nil
+}
+
+func (r *fakeSlackIntegrations) SaveSlackIntegration(_ context.Context, s *domain.SlackIntegration) error {
+	if r.stored == nil {
+		r.stored = make(map[string]*domain.SlackIntegration)
+	}
+	r.stored[s.ProjectID] = s
+	return nil
+}
+
+// seed は正規化した設定（Events が空の場合はすべてのイベント）を保存する。
+func (r *fakeSlackIntegrations) seed(s *domain.SlackIntegration) *fakeSlackIntegrations {
+	s.Normalize()
+	_ = r.SaveSlackIntegration(context.Background(), s)
+	return r
+}
+
+func (r *fakeSlackIntegrations) DeleteSlackIntegration(_ context.Context, projectID string) error {
+	if _, ok := r.stored[projectID]; !ok {
+		return usecase.ErrSlackIntegrationNotFound
+	}
+	delete(r.stored, projectID)
+	return nil
+}
+
+// fakeSlackPoster は投稿したメッセージを記録する SlackPoster。
+type fakeSlackPoster struct {
+	posted []string
+	err    error
+}
+
+func (p *fakeSlackPoster) PostSlackMessage(_ context.Context, _, text string) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.posted = append(p.posted, text)
+	return nil
+}
+
+func TestNotifySlack(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	task := func(typ events.Type, data events.TaskData) events.Event {
+		data.ID, data.ProjectID, data.Number, data.ActorID = "t-1", "proj-1", 12, "user-1"
+		if data.Title == "" {
+			data.Title = "ログイン画面の修正"
+		}
+		return events.NewTaskEvent(typ, "acme", data, now)
+	}
+
+	tests := []struct {
+		name   string
+		events []domain.SlackEvent
+		event  events.Event
+		want   []string // 空の場合は投稿しない
+	}{
+		{
+			name:  "created",
+			event: task(events.TaskCreated, events.TaskData{Status: "todo"}),
+			want:  []string{"*TeamFlow 開発*（操作者: user-1）", "タスク TF-12「ログイン画面の修正」を作成しました"},
+		},
+		{
+			name:  "created and assigned to another user",
+			event: task(events.TaskCreated, events.TaskData{Status: "todo", AssigneeID: "user-2"}),
+			want:  []string{"を作成しました", "タスク TF-12「ログイン画面の修正」の担当者を user-2 にしました"},
+		},
+		{
+			name:  "status changed",
+			event: task(events.TaskUpdated, events.TaskData{Status: "done", PreviousStatus: "in_progress"}),
+			want:  []string{"タスク TF-12「ログイン画面の修正」のステータスを in_progress から done に変更しました"},
+		},
+		{
+			name:  "assigned",
+			event: task(events.TaskUpdated, events.TaskData{AssigneeID: "user-3", AssigneeChanged: true}),
+			want:  []string{"の担当者を user-3 にしました"},
+		},
+		{
+			name:  "unassigned",
+			event: task(events.TaskUpdated, events.TaskData{AssigneeChanged: true}),
+		},
+		{
+			name:  "other fields",
+			event: task(events.TaskUpdated, events.TaskData{Fields: []string{"title"}}),
+		},
+		{
+			name:   "event not selected",
+			events: []domain.SlackEvent{domain.SlackEventTaskAssigned},
+			event:  task(events.TaskUpdated, events.TaskData{Status: "done", PreviousStatus: "todo"}),
+		},
+		{
+			name:  "deleted",
+			event: task(events.TaskDeleted, events.TaskData{}),
+		},
+		{
+			name:  "escapes mrkdwn",
+			event: task(events.TaskCreated, events.TaskData{Title: "<!channel> & more"}),
+			want:  []string{"「&lt;!channel&gt; &amp; more」"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projects := newExistingProjectRepo(t)
+			projects.stored.Key = "TF"
+			integrations := (&fakeSlackIntegrations{}).seed(&domain.SlackIntegration{
+				ProjectID: "proj-1", WebhookURL: "https://hooks.slack.com/services/T0/B0/x", Events: tt.events,
+			})
+			poster := &fakeSlackPoster{}
+			uc := &usecase.NotifySlackUsecase{Projects: projects, Integrations: integrations, Poster: poster}
+
+			if err := uc.Execute(context.Background(), tt.event); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(tt.want) == 0 {
+				if len(poster.posted) != 0 {
+					t.Errorf("expected no message, got %q", poster.posted)
+				}
+				return
+			}
+			if len(poster.posted) != 1 {
+				t.Fatalf("expected 1 message, got %q", poster.posted)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(poster.posted[0], want) {
+					t.Errorf("expected message to contain %q, got %q", want, poster.posted[0])
+				}
+			}
+		})
+	}
+}
+
+func TestNotifySlack_NotConfigured(t *testing.T) {
+	poster := &fakeSlackPoster{}
+	uc := &usecase.NotifySlackUsecase{Projects: newExistingProjectRepo(t), Integrations: &fakeSlackIntegrations{}, Poster: poster}
+
+	event := events.NewTaskEvent(events.TaskCreated, "acme", events.TaskData{ID: "t-1", ProjectID: "proj-1"}, time.Now())
+	if err := uc.Execute(context.Background(), event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(poster.posted) != 0 {
+		t.Errorf("expected no message, got %q", poster.posted)
+	}
+}
+
+func TestNotifySlack_DeliveryError(t *testing.T) {
+	integrations := (&fakeSlackIntegrations{}).seed(&domain.SlackIntegration{
+		ProjectID: "proj-1", WebhookURL: "https://hooks.slack.com/services/T0/B0/x",
+	})
+	event := events.NewTaskEvent(events.TaskCreated, "acme", events.TaskData{ID: "t-1", ProjectID: "proj-1"}, time.Now())
+
+	uc := &usecase.NotifySlackUsecase{
+		Projects: newExistingProjectRepo(t), Integrations: integrations, Poster: &fakeSlackPoster{err: errors.New("503")},
+	}
+	if err := uc.Execute(context.Background(), event); !errors.Is(err, usecase.ErrSlackDelivery) {
+		t.Errorf("expected ErrSlackDelivery, got %v", err)
+	}
+
+	// 許可していないホストには投稿しない
+	uc.Poster = &fakeSlackPoster{}
+	uc.AllowedHosts = []string{"slack.example.com"}
+	if err := uc.Execute(context.Background(), event); !errors.Is(err, usecase.ErrSlackDelivery) {
+		t.Errorf("expected ErrSlackDelivery for a disallowed host, got %v", err)
+	}
+}
+
+func TestUpdateSlackIntegration(t *testing.T) {
+	integrations := &fakeSlackIntegrations{}
+	uc := &usecase.UpdateSlackIntegrationUsecase{Projects: newExistingProjectRepo(t), Members: &fakeMemberRepo{}, Integrations: integrations}
+	now := time.Now()
+
+	s, err := uc.Execute(context.Background(), usecase.UpdateSlackIntegrationInput{
+		ProjectID: "proj-1", WebhookURL: " https://hooks.slack.com/services/T0/B0/x ", Events: []string{"TASK.ASSIGNED", "task.created", "task.created"},
+		ActorID: "user-1", Now: now,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.WebhookURL != "https://hooks.slack.com/services/T0/B0/x" || len(s.Events) != 2 ||
+		s.Events[0] != domain.SlackEventTaskCreated || s.UpdatedBy != "user-1" || !s.UpdatedAt.Equal(now) {
+		t.Errorf("unexpected integration: %+v", s)
+	}
+	if integrations.stored["proj-1"] == nil {
+		t.Error("expected the integration to be saved")
+	}
+
+	_, err = uc.Execute(context.Background(), usecase.UpdateSlackIntegrationInput{
+		ProjectID: "proj-1", WebhookURL: "https://example.com/hook", Now: now,
+	})
+	if !errors.Is(err, domain.ErrInvalidSlackIntegration) {
+		t.Errorf("expected ErrInvalidSlackIntegration for a disallowed host, got %v", err)
+	}
+}
@@ -0,0 +1,333 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"teamflow-shared/authz"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// SprintRepository はスプリントの永続化・取得を担当する抽象。
+type SprintRepository interface {
+	// SaveSprint はスプリントを保存する。
+	// プロジェクト内に同じ ID が既にある場合は ErrSprintAlreadyExists、
+	// プロジェクトが存在しない場合は ErrProjectNotFound 相当のエラーを返す。
+	SaveSprint(ctx context.Context, s *domain.Sprint) error
+	// UpdateSprint はスプリントを更新する。存在しない場合は ErrSprintNotFound 相当のエラーを返す。
+	UpdateSprint(ctx context.Context, s *domain.Sprint) error
+	// DeleteSprint はスプリントを削除する。存在しない場合は ErrSprintNotFound 相当のエラーを返す。
+	DeleteSprint(ctx context.Context, projectID, id string) error
+	// FindSprint はスプリントを 1 件取得する。存在しない場合は ErrSprintNotFound 相当のエラーを返す。
+	FindSprint(ctx context.Context, projectID, id string) (*domain.Sprint, error)
+	// ListSprints はプロジェクトのスプリントを開始日の昇順（同じ場合は作成日時・ID 順）で返す。
+	ListSprints(ctx context.Context, projectID string) ([]*domain.Sprint, error)
+}
+
+// SprintTaskCarrier はスプリントの未完了タスクを別のスプリントへ移す（tasks サービスのクライアント）。
+// toSprintID が空の場合はバックログ（スプリントなし）へ移す。戻り値は移したタスクの件数。
+type SprintTaskCarrier interface {
+	CarryOverTasks(ctx context.Context, projectID, fromSprintID, toSprintID string) (int, error)
+}
+
+// CreateSprintInput はスプリント作成ユースケースの入力。
+type CreateSprintInput struct {
+	ProjectID string
+	ID        string
+	Name      string
+	Goal      string
+	StartDate time.Time
+	EndDate   time.Time
+	ActorID   string // 操作者
+	Now       time.Time
+}
+
+// CreateSprintUsecase はスプリント作成ユースケース。作成したスプリントは計画中（planned）になる。
+type CreateSprintUsecase struct {
+	Projects ProjectRepository
+	Members  MemberRepository
+	Sprints  SprintRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（プロジェクトの編集と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute はプロジェクトの存在と操作者のロールを確認してからスプリントを保存する。
+// 不正な値の場合は domain.ErrInvalidSprint を返す。
+func (uc *CreateSprintUsecase) Execute(ctx context.Context, in CreateSprintInput) (*domain.Sprint, error) {
+	s, err := domain.NewSprint(in.ID, in.ProjectID, in.Name, in.Goal, in.StartDate, in.EndDate, in.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.Projects.FindByID(ctx, in.ProjectID); err != nil {
+		return nil, err
+	}
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, domain.ActionEdit); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.Sprints.SaveSprint(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// UpdateSprintInput はスプリント更新ユースケースの入力。名前・ゴール・期間を置き換える。
+type UpdateSprintInput struct {
+	ProjectID string
+	ID        string
+	Name      string
+	Goal      string
+	StartDate time.Time
+	EndDate   time.Time
+	ActorID   string // 操作者
+	Now       time.Time
+}
+
+// UpdateSprintUsecase はスプリント更新ユースケース。状態は変更しない（開始・完了は専用のユースケースで行う）。
+type UpdateSprintUsecase struct {
+	Members MemberRepository
+	Sprints SprintRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（プロジェクトの編集と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute は既存のスプリントを取得し、値を置き換えて保存する。
+// 完了済みのスプリントの場合は domain.ErrSprintStateConflict を返す。
+func (uc *UpdateSprintUsecase) Execute(ctx context.Context, in UpdateSprintInput) (*domain.Sprint, error) {
+	s, err := uc.Sprints.FindSprint(ctx, in.ProjectID, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, domain.ActionEdit); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.Update(in.Name, in.Goal, in.StartDate, in.EndDate, in.Now); err != nil {
+		return nil, err
+	}
+	if err := uc.Sprints.UpdateSprint(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// DeleteSprintInput はスプリント削除ユースケースの入力。
+type DeleteSprintInput struct {
+	ProjectID string
+	ID        string
+	ActorID   string // 操作者
+}
+
+// DeleteSprintUsecase はスプリント削除ユースケース。
+// 開始中のスプリントは削除できない（先に完了させ、未完了タスクを持ち越す）。
+// 計画中・完了済みのスプリントに属していたタスクの sprintId は tasks サービス側に残る。
+type DeleteSprintUsecase struct {
+	Members MemberRepository
+	Sprints SprintRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（プロジェクトの編集と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute は操作者のロールを確認してからスプリントを削除する。
+// 開始中のスプリントの場合は domain.ErrSprintStateConflict を返す。
+func (uc *DeleteSprintUsecase) Execute(ctx context.Context, in DeleteSprintInput) error {
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, domain.ActionEdit); err != nil {
+			return err
+		}
+	}
+	s, err := uc.Sprints.FindSprint(ctx, in.ProjectID, in.ID)
+	if err != nil {
+		return err
+	}
+	if s.State == domain.SprintActive {
+		return fmt.Errorf("%w: active sprint cannot be deleted", domain.ErrSprintStateConflict)
+	}
+	return uc.Sprints.DeleteSprint(ctx, in.ProjectID, in.ID)
+}
+
+// ListSprintsUsecase はスプリント一覧取得ユースケース。
+type ListSprintsUsecase struct {
+	Projects ProjectRepository
+	Members  MemberRepository
+	// EnforceRoles が true の場合は閲覧権限を確認する（公開プロジェクトは誰でも、非公開プロジェクトはメンバーのみ）
+	EnforceRoles bool
+	Sprints      SprintRepository
+}
+
+// Execute はプロジェクトの存在と閲覧権限を確認してからスプリントを返す。
+func (uc *ListSprintsUsecase) Execute(ctx context.Context, projectID, actorID string) ([]*domain.Sprint, error) {
+	if _, err := findReadableProject(ctx, uc.Projects, uc.Members, uc.EnforceRoles, projectID, actorID); err != nil {
+		return nil, err
+	}
+	return uc.Sprints.ListSprints(ctx, projectID)
+}
+
+// GetSprintUsecase はスプリント取得ユースケース。
+// tasks サービスがタスクに設定する sprintId の存在チェックにも使う。
+type GetSprintUsecase struct {
+	Sprints SprintRepository
+}
+
+// Execute はスプリントを返す。存在しない場合は ErrSprintNotFound を返す。
+func (uc *GetSprintUsecase) Execute(ctx context.Context, projectID, id string) (*domain.Sprint, error) {
+	return uc.Sprints.FindSprint(ctx, projectID, id)
+}
+
+// StartSprintInput はスプリント開始ユースケースの入力。
+type StartSprintInput struct {
+	ProjectID string
+	ID        string
+	ActorID   string // 操作者
+	Now       time.Time
+}
+
+// StartSprintUsecase はスプリント開始ユースケース。
+type StartSprintUsecase struct {
+	Members MemberRepository
+	Sprints SprintRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（プロジェクトの編集と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute は計画中のスプリントを開始する。
+// プロジェクトに開始中の別のスプリントがある場合は ErrSprintAlreadyActive、
+// 計画中でない場合は domain.ErrSprintStateConflict を返す。
+func (uc *StartSprintUsecase) Execute(ctx context.Context, in StartSprintInput) (*domain.Sprint, error) {
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, domain.ActionEdit); err != nil {
+			return nil, err
+		}
+	}
+	sprints, err := uc.Sprints.ListSprints(ctx, in.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var target, active *domain.Sprint
+	for _, s := range sprints {
+		switch {
+		case s.ID == in.ID:
+			target = s
+		case s.State == domain.SprintActive:
+			active = s
+		}
+	}
+	if target == nil {
+		return nil, ErrSprintNotFound
+	}
+	if active != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSprintAlreadyActive, active.ID)
+	}
+
+	if err := target.Start(in.Now); err != nil {
+		return nil, err
+	}
+	if err := uc.Sprints.UpdateSprint(ctx, target); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// CompleteSprintInput はスプリント完了ユースケースの入力。
+type CompleteSprintInput struct {
+	ProjectID string
+	ID        string
+	// NextSprintID は未完了タスクの持ち越し先。空の場合は次の計画中のスプリント（無ければバックログ）
+	NextSprintID string
+	ActorID      string // 操作者
+	Now          time.Time
+}
+
+// CompleteSprintResult はスプリント完了ユースケースの結果。
+type CompleteSprintResult struct {
+	Sprint *domain.Sprint
+	// NextSprintID は未完了タスクの持ち越し先。空はバックログ
+	NextSprintID string
+	// CarriedOver は持ち越したタスクの件数
+	CarriedOver int
+}
+
+// CompleteSprintUsecase はスプリント完了ユースケース。
+// 完了したスプリントの未完了タスクは tasks サービスで次のスプリント（またはバックログ）へ持ち越す。
+type CompleteSprintUsecase struct {
+	Members MemberRepository
+	Sprints SprintRepository
+	// Tasks は未完了タスクの持ち越しに使う。nil の場合は ErrTasksService を返す
+	Tasks SprintTaskCarrier
+	// EnforceRoles が true の場合は操作者のロールを確認する（プロジェクトの編集と同じ権限）
+	EnforceRoles bool
+}
+
+// Execute は開始中のスプリントの未完了タスクを持ち越してから、スプリントを完了にする。
+// 持ち越しを先に行うため、tasks サービスの呼び出しに失敗した場合（ErrTasksService）はスプリントは開始中のまま残り、
+// 再実行できる。持ち越し先に計画中以外のスプリントを指定した場合は domain.ErrSprintStateConflict を返す。
+func (uc *CompleteSprintUsecase) Execute(ctx context.Context, in CompleteSprintInput) (*CompleteSprintResult, error) {
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, in.ProjectID, in.ActorID, domain.ActionEdit); err != nil {
+			return nil, err
+		}
+	}
+	s, err := uc.Sprints.FindSprint(ctx, in.ProjectID, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	if s.State != domain.SprintActive {
+		return nil, fmt.Errorf("%w: only active sprint can be completed (current: %s)", domain.ErrSprintStateConflict, s.State)
+	}
+
+	nextID, err := uc.nextSprintID(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	if uc.Tasks == nil {
+		return nil, fmt.Errorf("%w: tasks service is not configured", ErrTasksService)
+	}
+	// tasks サービスでも操作者（updatedBy）を記録するため、操作者を引き継ぐ
+	carried, err := uc.Tasks.CarryOverTasks(authz.ContextWithActor(ctx, in.ActorID), in.ProjectID, s.ID, nextID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTasksService, err)
+	}
+
+	if err := s.Complete(in.Now); err != nil {
+		return nil, err
+	}
+	if err := uc.Sprints.UpdateSprint(ctx, s); err != nil {
+		return nil, err
+	}
+	return &CompleteSprintResult{Sprint: s, NextSprintID: nextID, CarriedOver: carried}, nil
+}
+
+// nextSprintID は持ち越し先のスプリント ID を決める。指定された場合は計画中であることを確認する。
+func (uc *CompleteSprintUsecase) nextSprintID(ctx context.Context, in CompleteSprintInput) (string, error) {
+	if in.NextSprintID != "" {
+		if in.NextSprintID == in.ID {
+			return "", fmt.Errorf("%w: nextSprintId must differ from the completed sprint", domain.ErrInvalidSprint)
+		}
+		next, err := uc.Sprints.FindSprint(ctx, in.ProjectID, in.NextSprintID)
+		if err != nil {
+			return "", err
+		}
+		if next.State != domain.SprintPlanned {
+			return "", fmt.Errorf("%w: next sprint must be planned (current: %s)", domain.ErrSprintStateConflict, next.State)
+		}
+		return next.ID, nil
+	}
+
+	sprints, err := uc.Sprints.ListSprints(ctx, in.ProjectID)
+	if err != nil {
+		return "", err
+	}
+	if next := domain.NextPlannedSprint(sprints, in.ID); next != nil {
+		return next.ID, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,311 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// fakeSprintRepo は SprintRepository のテスト用フェイク実装（登録順に返す）。
+type fakeSprintRepo struct {
+	sprints []*domain.Sprint
+}
+
+func (r *fakeSprintRepo) index(projectID, id string) int {
+	for i, s := range r.sprints {
+		if s.ProjectID == projectID && s.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *fakeSprintRepo) SaveSprint(_ context.Context, s *domain.Sprint) error {
+	if r.index(s.ProjectID, s.ID) >= 0 {
+		return usecase.ErrSprintAlreadyExists
+	}
+	r.sprints = append(r.sprints, s)
+	return nil
+}
+
+func (r *fakeSprintRepo) UpdateSprint(_ context.Context, s *domain.Sprint) error {
+	i := r.index(s.ProjectID, s.ID)
+	if i < 0 {
+		return usecase.ErrSprintNotFound
+	}
+	r.sprints[i] = s
+	return nil
+}
+
+func (r *fakeSprintRepo) DeleteSprint(_ context.Context, projectID, id string) error {
+	i := r.index(projectID, id)
+	if i < 0 {
+		return usecase.ErrSprintNotFound
+	}
+	r.sprints = append(r.sprints[:i], r.sprints[i+1:]...)
+	return nil
+}
+
+func (r *fakeSprintRepo) FindSprint(_ context.Context, projectID, id string) (*domain.Sprint, error) {
+	i := r.index(projectID, id)
+	if i < 0 {
+		return nil, usecase.ErrSprintNotFound
+	}
+	return r.sprints[i], nil
+}
+
+func (r *fakeSprintRepo) ListSprints(_ context.Context, projectID string) ([]*domain.Sprint, error) {
+	out := make([]*domain.Sprint, 0)
+	for _, s := range r.sprints {
+		if s.ProjectID == projectID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// fakeSprintCarrier は SprintTaskCarrier のテスト用フェイク実装。
+type fakeSprintCarrier struct {
+	count int
+	err   error
+	from  string
+	to    string
+	calls int
+}
+
+func (c *fakeSprintCarrier) CarryOverTasks(_ context.Context, _, from, to string) (int, error) {
+	c.calls++
+	c.from, c.to = from, to
+	return c.count, c.err
+}
+
+// newSprintRepo は proj-1 に ids のスプリント（計画中、ids の順に開始日が 2 週間ずつ後ろ）を登録したリポジトリを返す。
+func newSprintRepo(t *testing.T, now time.Time, ids ...string) *fakeSprintRepo {
+	t.Helper()
+	repo := &fakeSprintRepo{}
+	for i, id := range ids {
+		start := now.AddDate(0, 0, 14*i)
+		s, err := domain.NewSprint(id, "proj-1", id, "", start, start.AddDate(0, 0, 14), now)
+		if err != nil {
+			t.Fatalf("failed to create sprint: %v", err)
+		}
+		if err := repo.SaveSprint(context.Background(), s); err != nil {
+			t.Fatalf("failed to save sprint: %v", err)
+		}
+	}
+	return repo
+}
+
+func TestSprints_CRUD(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	end := now.AddDate(0, 0, 14)
+	sprints := &fakeSprintRepo{}
+	projects := newExistingProjectRepo(t)
+
+	createUC := &usecase.CreateSprintUsecase{Projects: projects, Members: newRoleMembers(), Sprints: sprints, EnforceRoles: true}
+	if _, err := createUC.Execute(ctx, usecase.CreateSprintInput{ProjectID: "missing", ID: "s1", Name: "s1", StartDate: now, EndDate: end, ActorID: "member-1", Now: now}); err == nil {
+		t.Fatal("expected error for missing project")
+	}
+	s, err := createUC.Execute(ctx, usecase.CreateSprintInput{ProjectID: "proj-1", ID: "s1", Name: "s1", Goal: "ログイン", StartDate: now, EndDate: end, ActorID: "member-1", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.State != domain.SprintPlanned || s.ProjectID != "proj-1" || s.Goal != "ログイン" {
+		t.Errorf("unexpected sprint: %+v", s)
+	}
+
+	tests := []struct {
+		name    string
+		in      usecase.CreateSprintInput
+		wantErr error
+	}{
+		{name: "duplicate id", in: usecase.CreateSprintInput{ProjectID: "proj-1", ID: "s1", Name: "s1", StartDate: now, EndDate: end, ActorID: "member-1"}, wantErr: usecase.ErrSprintAlreadyExists},
+		{name: "end before start", in: usecase.CreateSprintInput{ProjectID: "proj-1", ID: "s2", Name: "s2", StartDate: end, EndDate: now, ActorID: "member-1"}, wantErr: domain.ErrInvalidSprint},
+		{name: "non-member is forbidden", in: usecase.CreateSprintInput{ProjectID: "proj-1", ID: "s2", Name: "s2", StartDate: now, EndDate: end, ActorID: "stranger"}, wantErr: domain.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.in.Now = now
+			if _, err := createUC.Execute(ctx, tt.in); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	updateUC := &usecase.UpdateSprintUsecase{Members: newRoleMembers(), Sprints: sprints, EnforceRoles: true}
+	updated, err := updateUC.Execute(ctx, usecase.UpdateSprintInput{ProjectID: "proj-1", ID: "s1", Name: "Sprint 1", StartDate: now, EndDate: end.AddDate(0, 0, 7), ActorID: "member-1", Now: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Name != "Sprint 1" || updated.Goal != "" || !updated.EndDate.Equal(end.AddDate(0, 0, 7)) {
+		t.Errorf("unexpected sprint: %+v", updated)
+	}
+	if _, err := updateUC.Execute(ctx, usecase.UpdateSprintInput{ProjectID: "proj-1", ID: "missing", Name: "x", StartDate: now, EndDate: end, ActorID: "member-1"}); !errors.Is(err, usecase.ErrSprintNotFound) {
+		t.Errorf("expected ErrSprintNotFound, got %v", err)
+	}
+
+	listUC := &usecase.ListSprintsUsecase{Projects: projects, Sprints: sprints}
+	list, err := listUC.Execute(ctx, "proj-1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 1 || list[0].Name != "Sprint 1" {
+		t.Errorf("unexpected list: %+v", list)
+	}
+
+	// 開始中のスプリントは削除できない
+	deleteUC := &usecase.DeleteSprintUsecase{Members: newRoleMembers(), Sprints: sprints, EnforceRoles: true}
+	sprints.sprints[0].State = domain.SprintActive
+	if err := deleteUC.Execute(ctx, usecase.DeleteSprintInput{ProjectID: "proj-1", ID: "s1", ActorID: "member-1"}); !errors.Is(err, domain.ErrSprintStateConflict) {
+		t.Errorf("expected ErrSprintStateConflict, got %v", err)
+	}
+	sprints.sprints[0].State = domain.SprintPlanned
+	if err := deleteUC.Execute(ctx, usecase.DeleteSprintInput{ProjectID: "proj-1", ID: "s1", ActorID: "stranger"}); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+	if err := deleteUC.Execute(ctx, usecase.DeleteSprintInput{ProjectID: "proj-1", ID: "s1", ActorID: "member-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	getUC := &usecase.GetSprintUsecase{Sprints: sprints}
+	if _, err := getUC.Execute(ctx, "proj-1", "s1"); !errors.Is(err, usecase.ErrSprintNotFound) {
+		t.Errorf("expected ErrSprintNotFound, got %v", err)
+	}
+}
+
+func TestStartSprint(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	sprints := newSprintRepo(t, now, "s1", "s2")
+	uc := &usecase.StartSprintUsecase{Members: newRoleMembers(), Sprints: sprints, EnforceRoles: true}
+
+	if _, err := uc.Execute(ctx, usecase.StartSprintInput{ProjectID: "proj-1", ID: "s1", ActorID: "stranger", Now: now}); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+	s, err := uc.Execute(ctx, usecase.StartSprintInput{ProjectID: "proj-1", ID: "s1", ActorID: "member-1", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.State != domain.SprintActive {
+		t.Errorf("expected active, got %s", s.State)
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		wantErr error
+	}{
+		{name: "another sprint is active", id: "s2", wantErr: usecase.ErrSprintAlreadyActive},
+		{name: "already active", id: "s1", wantErr: domain.ErrSprintStateConflict},
+		{name: "missing", id: "missing", wantErr: usecase.ErrSprintNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := uc.Execute(ctx, usecase.StartSprintInput{ProjectID: "proj-1", ID: tt.id, ActorID: "member-1", Now: now}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := uc.Execute(ctx, usecase.StartSprintInput{ProjectID: "proj-2", ID: "s1", ActorID: "owner-1", Now: now}); err == nil {
+		t.Error("expected error for sprint of another project")
+	}
+}
+
+func TestCompleteSprint(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	// s1 を開始した状態のユースケースを返す
+	newUsecase := func(t *testing.T, tasks usecase.SprintTaskCarrier, ids ...string) (*usecase.CompleteSprintUsecase, *fakeSprintRepo) {
+		t.Helper()
+		sprints := newSprintRepo(t, now, ids...)
+		sprints.sprints[0].State = domain.SprintActive
+		return &usecase.CompleteSprintUsecase{Members: newRoleMembers(), Sprints: sprints, Tasks: tasks, EnforceRoles: true}, sprints
+	}
+
+	t.Run("carries over to next planned sprint", func(t *testing.T) {
+		carrier := &fakeSprintCarrier{count: 3}
+		uc, sprints := newUsecase(t, carrier, "s1", "s2", "s3")
+		sprints.sprints[1].State = domain.SprintCompleted
+		got, err := uc.Execute(ctx, usecase.CompleteSprintInput{ProjectID: "proj-1", ID: "s1", ActorID: "member-1", Now: now})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Sprint.State != domain.SprintCompleted || got.NextSprintID != "s3" || got.CarriedOver != 3 {
+			t.Errorf("unexpected result: %+v", got)
+		}
+		if carrier.from != "s1" || carrier.to != "s3" {
+			t.Errorf("unexpected carry over: %s -> %s", carrier.from, carrier.to)
+		}
+	})
+
+	t.Run("explicit next sprint", func(t *testing.T) {
+		carrier := &fakeSprintCarrier{}
+		uc, _ := newUsecase(t, carrier, "s1", "s2", "s3")
+		got, err := uc.Execute(ctx, usecase.CompleteSprintInput{ProjectID: "proj-1", ID: "s1", NextSprintID: "s3", ActorID: "member-1", Now: now})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.NextSprintID != "s3" || carrier.to != "s3" {
+			t.Errorf("unexpected result: %+v", got)
+		}
+	})
+
+	t.Run("backlog when no planned sprint", func(t *testing.T) {
+		carrier := &fakeSprintCarrier{count: 1}
+		uc, _ := newUsecase(t, carrier, "s1")
+		got, err := uc.Execute(ctx, usecase.CompleteSprintInput{ProjectID: "proj-1", ID: "s1", ActorID: "member-1", Now: now})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.NextSprintID != "" || carrier.calls != 1 || carrier.to != "" {
+			t.Errorf("unexpected result: %+v (calls=%d)", got, carrier.calls)
+		}
+	})
+
+	t.Run("tasks service failure keeps sprint active", func(t *testing.T) {
+		uc, sprints := newUsecase(t, &fakeSprintCarrier{err: errors.New("boom")}, "s1")
+		if _, err := uc.Execute(ctx, usecase.CompleteSprintInput{ProjectID: "proj-1", ID: "s1", ActorID: "member-1", Now: now}); !errors.Is(err, usecase.ErrTasksService) {
+			t.Fatalf("expected ErrTasksService, got %v", err)
+		}
+		if sprints.sprints[0].State != domain.SprintActive {
+			t.Errorf("sprint must stay active, got %s", sprints.sprints[0].State)
+		}
+
+		uc, _ = newUsecase(t, nil, "s1")
+		if _, err := uc.Execute(ctx, usecase.CompleteSprintInput{ProjectID: "proj-1", ID: "s1", ActorID: "member-1", Now: now}); !errors.Is(err, usecase.ErrTasksService) {
+			t.Errorf("expected ErrTasksService when not configured, got %v", err)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		in      usecase.CompleteSprintInput
+		wantErr error
+	}{
+		{name: "not active", in: usecase.CompleteSprintInput{ID: "s2", ActorID: "member-1"}, wantErr: domain.ErrSprintStateConflict},
+		{name: "next is itself", in: usecase.CompleteSprintInput{ID: "s1", NextSprintID: "s1", ActorID: "member-1"}, wantErr: domain.ErrInvalidSprint},
+		{name: "next is missing", in: usecase.CompleteSprintInput{ID: "s1", NextSprintID: "missing", ActorID: "member-1"}, wantErr: usecase.ErrSprintNotFound},
+		{name: "missing", in: usecase.CompleteSprintInput{ID: "missing", ActorID: "member-1"}, wantErr: usecase.ErrSprintNotFound},
+		{name: "non-member is forbidden", in: usecase.CompleteSprintInput{ID: "s1", ActorID: "stranger"}, wantErr: domain.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			carrier := &fakeSprintCarrier{}
+			uc, _ := newUsecase(t, carrier, "s1", "s2")
+			tt.in.ProjectID = "proj-1"
+			tt.in.Now = now
+			if _, err := uc.Execute(ctx, tt.in); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+			if carrier.calls != 0 {
+				t.Errorf("tasks service must not be called, got %d calls", carrier.calls)
+			}
+		})
+	}
+}
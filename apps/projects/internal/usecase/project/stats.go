@@ -0,0 +1,46 @@
+package project
+
+import (
+	"context"
+	"fmt"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// StatsProvider はプロジェクトのタスクの集計を取得する（tasks サービスのクライアント）。
+type StatsProvider interface {
+	ProjectStats(ctx context.Context, projectID string) (*domain.Stats, error)
+}
+
+// BatchStatsProvider は複数プロジェクトのタスクの集計を 1 回の呼び出しで取得する（tasks サービスのクライアント）。
+// 返り値は projectIDs の ID をキーとし、タスクが無いプロジェクトの集計も含む。
+type BatchStatsProvider interface {
+	ProjectsStats(ctx context.Context, projectIDs []string) (map[string]*domain.Stats, error)
+}
+
+// GetStatsUsecase はプロジェクトのタスクの集計を取得するユースケース。
+type GetStatsUsecase struct {
+	Projects ProjectRepository
+	Members  MemberRepository
+	// EnforceRoles が true の場合は閲覧権限を確認する（公開プロジェクトは誰でも、非公開プロジェクトはメンバーのみ）
+	EnforceRoles bool
+	// Stats は集計の取得に使う。nil の場合は ErrTasksService を返す
+	Stats StatsProvider
+}
+
+// Execute はプロジェクトの存在と閲覧権限を確認してから集計を返す。
+// 集計の取得に失敗した場合は ErrTasksService でラップしたエラーを返す。
+func (uc *GetStatsUsecase) Execute(ctx context.Context, projectID, actorID string) (*domain.Stats, error) {
+	if _, err := findReadableProject(ctx, uc.Projects, uc.Members, uc.EnforceRoles, projectID, actorID); err != nil {
+		return nil, err
+	}
+	if uc.Stats == nil {
+		return nil, fmt.Errorf("%w: tasks service is not configured", ErrTasksService)
+	}
+
+	stats, err := uc.Stats.ProjectStats(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTasksService, err)
+	}
+	return stats, nil
+}
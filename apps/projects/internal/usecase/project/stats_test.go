@@ -0,0 +1,87 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// fakeStatsProvider は StatsProvider のテスト用フェイク実装。
+type fakeStatsProvider struct {
+	stats *domain.Stats
+	err   error
+	calls int
+}
+
+func (p *fakeStatsProvider) ProjectStats(_ context.Context, _ string) (*domain.Stats, error) {
+	p.calls++
+	return p.stats, p.err
+}
+
+func TestGetStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		projectID string
+		provider  *fakeStatsProvider
+		wantErr   error
+		wantAny   bool // エラーの種類を問わない
+		wantCalls int
+	}{
+		{
+			name:      "success",
+			projectID: "proj-1",
+			provider:  &fakeStatsProvider{stats: &domain.Stats{ProjectID: "proj-1", Open: 3, Done: 2, Overdue: 1}},
+			wantCalls: 1,
+		},
+		{
+			name:      "project not found",
+			projectID: "proj-x",
+			provider:  &fakeStatsProvider{},
+			wantAny:   true,
+		},
+		{
+			name:      "tasks service fails",
+			projectID: "proj-1",
+			provider:  &fakeStatsProvider{err: errors.New("unavailable")},
+			wantErr:   usecase.ErrTasksService,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &usecase.GetStatsUsecase{Projects: newExistingProjectRepo(t), Stats: tt.provider}
+
+			stats, err := uc.Execute(context.Background(), tt.projectID, "")
+			if tt.provider.calls != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, tt.provider.calls)
+			}
+			if tt.wantErr != nil || tt.wantAny {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if stats.Open != 3 || stats.Done != 2 || stats.Overdue != 1 {
+				t.Errorf("unexpected stats: %+v", stats)
+			}
+		})
+	}
+}
+
+func TestGetStats_NotConfigured(t *testing.T) {
+	uc := &usecase.GetStatsUsecase{Projects: newExistingProjectRepo(t)}
+
+	if _, err := uc.Execute(context.Background(), "proj-1", ""); !errors.Is(err, usecase.ErrTasksService) {
+		t.Fatalf("expected ErrTasksService, got %v", err)
+	}
+}
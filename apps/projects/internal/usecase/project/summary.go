@@ -0,0 +1,119 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// DefaultSummaryQueryTimeout はサマリーの集計 1 件あたりのタイムアウトの既定値。
+const DefaultSummaryQueryTimeout = 3 * time.Second
+
+// SummaryStatsProvider はサマリーに使うタスクの集計（全体・マイルストーン・エピック・ラベルごと）を取得する（tasks サービスのクライアント）。
+type SummaryStatsProvider interface {
+	StatsProvider
+	MilestoneStatsProvider
+	EpicStatsProvider
+	LabelStatsProvider
+}
+
+// GetSummaryUsecase はダッシュボード向けのプロジェクトのサマリーを取得するユースケース。
+// 集計ごとの取得（tasks サービスの呼び出しと、マイルストーンなどの一覧）は並行して行い、
+// 集計が増えてもレスポンスの時間が最も遅い 1 件で決まるようにする。
+type GetSummaryUsecase struct {
+	Projects ProjectRepository
+	Members  MemberRepository
+	// EnforceRoles が true の場合は閲覧権限を確認する（公開プロジェクトは誰でも、非公開プロジェクトはメンバーのみ）
+	EnforceRoles bool
+	Milestones   MilestoneRepository
+	Epics        EpicRepository
+	Labels       LabelRepository
+	// Stats は集計の取得に使う。nil の場合は ErrTasksService を返す
+	Stats SummaryStatsProvider
+	// QueryTimeout は集計 1 件あたりのタイムアウト。任意。0 の場合は DefaultSummaryQueryTimeout
+	QueryTimeout time.Duration
+}
+
+// Execute はプロジェクトの存在と閲覧権限を確認してから、サマリーの集計を並行して取得する。
+// いずれかの集計が失敗・タイムアウトした場合は残りを取り消し、tasks サービスのエラーは ErrTasksService でラップして返す。
+func (uc *GetSummaryUsecase) Execute(ctx context.Context, projectID, actorID string) (*domain.Summary, error) {
+	if _, err := findReadableProject(ctx, uc.Projects, uc.Members, uc.EnforceRoles, projectID, actorID); err != nil {
+		return nil, err
+	}
+	if uc.Stats == nil {
+		return nil, fmt.Errorf("%w: tasks service is not configured", ErrTasksService)
+	}
+
+	timeout := uc.QueryTimeout
+	if timeout <= 0 {
+		timeout = DefaultSummaryQueryTimeout
+	}
+	g, gctx := errgroup.WithContext(ctx)
+	// run は集計 1 件を timeout の中で実行する
+	run := func(f func(ctx context.Context) error) {
+		g.Go(func() error {
+			ctx, cancel := context.WithTimeout(gctx, timeout)
+			defer cancel()
+			return f(ctx)
+		})
+	}
+
+	summary := &domain.Summary{
+		Milestones: []domain.MilestoneProgress{},
+		Epics:      []domain.EpicProgress{},
+		Labels:     []domain.LabelUsage{},
+	}
+	run(func(ctx context.Context) error {
+		stats, err := uc.Stats.ProjectStats(ctx, projectID)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrTasksService, err)
+		}
+		summary.Stats = stats
+		return nil
+	})
+	run(func(ctx context.Context) error {
+		milestones, err := uc.Milestones.ListMilestones(ctx, projectID)
+		if err != nil || len(milestones) == 0 {
+			return err
+		}
+		counts, err := uc.Stats.MilestoneStats(ctx, projectID)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrTasksService, err)
+		}
+		summary.Milestones = domain.ComputeMilestoneProgress(milestones, counts)
+		return nil
+	})
+	run(func(ctx context.Context) error {
+		epics, err := uc.Epics.ListEpics(ctx, projectID)
+		if err != nil || len(epics) == 0 {
+			return err
+		}
+		counts, err := uc.Stats.EpicStats(ctx, projectID)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrTasksService, err)
+		}
+		summary.Epics = domain.ComputeEpicProgress(epics, counts)
+		return nil
+	})
+	run(func(ctx context.Context) error {
+		labels, err := uc.Labels.ListLabels(ctx, projectID)
+		if err != nil || len(labels) == 0 {
+			return err
+		}
+		counts, err := uc.Stats.LabelStats(ctx, projectID)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrTasksService, err)
+		}
+		summary.Labels = domain.ComputeLabelUsage(labels, counts)
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
@@ -0,0 +1,149 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// fakeSummaryStats は SummaryStatsProvider のテスト用フェイク実装。
+type fakeSummaryStats struct {
+	*fakeStatsProvider
+	*fakeMilestoneStats
+	*fakeEpicStats
+	*fakeLabelStats
+}
+
+func newFakeSummaryStats() *fakeSummaryStats {
+	return &fakeSummaryStats{
+		fakeStatsProvider: &fakeStatsProvider{stats: &domain.Stats{ProjectID: "proj-1", Open: 3, Done: 2}},
+		fakeMilestoneStats: &fakeMilestoneStats{counts: map[string]domain.MilestoneTaskCounts{
+			"m1": {Open: 2, Done: 1},
+		}},
+		fakeEpicStats: &fakeEpicStats{counts: map[string]domain.EpicTaskCounts{
+			"e1": {Total: 4, Done: 1, EstimateTotal: 8, EstimateDone: 3},
+		}},
+		fakeLabelStats: &fakeLabelStats{counts: map[string]int{"l1": 5}},
+	}
+}
+
+// slowSummaryStats は ctx が終わるまで全体の集計を返さない SummaryStatsProvider。
+type slowSummaryStats struct {
+	*fakeSummaryStats
+}
+
+func (p *slowSummaryStats) ProjectStats(ctx context.Context, _ string) (*domain.Stats, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// newSummaryUsecase はマイルストーン・エピック・ラベルを 1 件ずつ持つプロジェクトのサマリーのユースケースを返す。
+func newSummaryUsecase(t *testing.T, stats usecase.SummaryStatsProvider) *usecase.GetSummaryUsecase {
+	t.Helper()
+	now := time.Now()
+	m, err := domain.NewMilestone("m1", "proj-1", "v1.0", nil, "", now)
+	if err != nil {
+		t.Fatalf("failed to create milestone: %v", err)
+	}
+	e, err := domain.NewEpic("e1", "proj-1", "SSO", "", "", now)
+	if err != nil {
+		t.Fatalf("failed to create epic: %v", err)
+	}
+	l, err := domain.NewLabel("l1", "proj-1", "bug", "#ff0000", now)
+	if err != nil {
+		t.Fatalf("failed to create label: %v", err)
+	}
+	return &usecase.GetSummaryUsecase{
+		Projects:   newExistingProjectRepo(t),
+		Milestones: &fakeMilestoneRepo{milestones: []*domain.Milestone{m}},
+		Epics:      &fakeEpicRepo{epics: []*domain.Epic{e}},
+		Labels:     &fakeLabelRepo{labels: []*domain.Label{l}},
+		Stats:      stats,
+	}
+}
+
+func TestGetSummary(t *testing.T) {
+	stats := newFakeSummaryStats()
+	uc := newSummaryUsecase(t, stats)
+
+	summary, err := uc.Execute(context.Background(), "proj-1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Stats == nil || summary.Stats.Open != 3 || summary.Stats.Done != 2 {
+		t.Errorf("unexpected stats: %+v", summary.Stats)
+	}
+	if len(summary.Milestones) != 1 || summary.Milestones[0].Open != 2 || summary.Milestones[0].Done != 1 {
+		t.Errorf("unexpected milestones: %+v", summary.Milestones)
+	}
+	if len(summary.Epics) != 1 || summary.Epics[0].Total != 4 || summary.Epics[0].EstimateDone != 3 {
+		t.Errorf("unexpected epics: %+v", summary.Epics)
+	}
+	if len(summary.Labels) != 1 || summary.Labels[0].UsageCount != 5 {
+		t.Errorf("unexpected labels: %+v", summary.Labels)
+	}
+	if stats.fakeStatsProvider.calls != 1 || stats.fakeMilestoneStats.calls != 1 || stats.fakeEpicStats.calls != 1 || stats.fakeLabelStats.calls != 1 {
+		t.Errorf("expected each aggregate to be fetched once, got %+v", stats)
+	}
+}
+
+func TestGetSummary_EmptyListsSkipStats(t *testing.T) {
+	stats := newFakeSummaryStats()
+	uc := &usecase.GetSummaryUsecase{
+		Projects:   newExistingProjectRepo(t),
+		Milestones: &fakeMilestoneRepo{},
+		Epics:      &fakeEpicRepo{},
+		Labels:     &fakeLabelRepo{},
+		Stats:      stats,
+	}
+
+	summary, err := uc.Execute(context.Background(), "proj-1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Milestones == nil || summary.Epics == nil || summary.Labels == nil {
+		t.Errorf("expected empty (non-nil) lists, got %+v", summary)
+	}
+	if stats.fakeMilestoneStats.calls != 0 || stats.fakeEpicStats.calls != 0 || stats.fakeLabelStats.calls != 0 {
+		t.Errorf("expected no per-dimension stats calls for empty lists, got %+v", stats)
+	}
+}
+
+func TestGetSummary_QueryTimeout(t *testing.T) {
+	uc := newSummaryUsecase(t, &slowSummaryStats{newFakeSummaryStats()})
+	uc.QueryTimeout = 20 * time.Millisecond
+
+	start := time.Now()
+	_, err := uc.Execute(context.Background(), "proj-1", "")
+	if !errors.Is(err, usecase.ErrTasksService) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected ErrTasksService wrapping DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the summary to give up after the query timeout, took %v", elapsed)
+	}
+}
+
+func TestGetSummary_TasksServiceFails(t *testing.T) {
+	stats := newFakeSummaryStats()
+	stats.fakeLabelStats.err = errors.New("unavailable")
+	uc := newSummaryUsecase(t, stats)
+
+	if _, err := uc.Execute(context.Background(), "proj-1", ""); !errors.Is(err, usecase.ErrTasksService) {
+		t.Fatalf("expected ErrTasksService, got %v", err)
+	}
+}
+
+func TestGetSummary_NotConfigured(t *testing.T) {
+	uc := &usecase.GetSummaryUsecase{Projects: newExistingProjectRepo(t)}
+
+	if _, err := uc.Execute(context.Background(), "proj-1", ""); !errors.Is(err, usecase.ErrTasksService) {
+		t.Fatalf("expected ErrTasksService, got %v", err)
+	}
+	if _, err := uc.Execute(context.Background(), "proj-x", ""); err == nil || errors.Is(err, usecase.ErrTasksService) {
+		t.Fatalf("expected the project lookup to fail first, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"teamflow-shared/events"
+	"teamflow-shared/workspace"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// TaskCounterRepository はタスクのイベントから更新するプロジェクトのタスク数（Project.OpenTasks / DoneTasks）の永続化を担当する抽象。
+type TaskCounterRepository interface {
+	// ApplyTaskCounts は eventID を処理済みとして記録し、context のワークスペースのプロジェクト projectID の
+	// 未完了・完了のタスク数に open / done を加える。記録と加算はまとめて行う（片方だけ反映されることはない）。
+	// eventID が処理済みの場合は何もしない。プロジェクトが存在しない場合も処理済みとして記録する。
+	ApplyTaskCounts(ctx context.Context, eventID, projectID string, open, done int, now time.Time) error
+	// DeleteProcessedEvents は before より前に処理したイベントの記録を削除し、削除した件数を返す。
+	DeleteProcessedEvents(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ProcessedEventRetention は処理済みのイベントの ID を覚えておく期間。
+// これより後に同じイベントが届いた場合は重複を排除できない（outbox のリレーの再試行はこれより十分短い）。
+const ProcessedEventRetention = 7 * 24 * time.Hour
+
+// TaskCounterEvents は ApplyTaskEventUsecase が購読するタスクのイベントの種類。
+var TaskCounterEvents = []events.Type{events.TaskCreated, events.TaskUpdated, events.TaskDeleted}
+
+// ApplyTaskEventInput はタスクのイベントの反映ユースケースの入力。
+type ApplyTaskEventInput struct {
+	Event events.Event
+	Now   time.Time // 処理した日時（重複の排除の記録に使う）
+}
+
+// ApplyTaskEventUsecase は tasks サービスのタスクのイベントをプロジェクトのタスク数に反映するユースケース。
+// プロジェクト一覧で tasks サービスを呼び出さずにタスク数を返すために使う。
+//
+// 件数は増減（domain.TaskCountsDelta）で更新するため、イベントの届く順序が前後しても最終的な件数は変わらない。
+// 同じイベントが複数回届いた場合はイベント ID で重複を排除する。
+type ApplyTaskEventUsecase struct {
+	Counters TaskCounterRepository
+}
+
+// Execute はイベントのステータスの変化を、イベントのワークスペースのプロジェクトのタスク数に反映する。
+// ステータスが変わらない更新（アーカイブ・スプリントの持ち越しなど）と、ステータスが分からない削除
+// （プロジェクトごと削除する一括削除）は件数を変えないため何もしない。
+func (uc *ApplyTaskEventUsecase) Execute(ctx context.Context, in ApplyTaskEventInput) error {
+	var data events.TaskData
+	if err := in.Event.Decode(&data); err != nil {
+		return fmt.Errorf("invalid task event %s: %w", in.Event.ID, err)
+	}
+
+	var open, done int
+	switch in.Event.Type {
+	case events.TaskCreated:
+		open, done = domain.TaskCountsDelta("", data.Status)
+	case events.TaskUpdated:
+		if data.PreviousStatus != "" {
+			open, done = domain.TaskCountsDelta(data.PreviousStatus, data.Status)
+		}
+	case events.TaskDeleted:
+		open, done = domain.TaskCountsDelta(data.Status, "")
+	}
+	if open == 0 && done == 0 {
+		return nil
+	}
+
+	ctx = workspace.NewContext(ctx, in.Event.WorkspaceID)
+	return uc.Counters.ApplyTaskCounts(ctx, in.Event.ID, data.ProjectID, open, done, in.Now)
+}
+
+// Cleanup は ProcessedEventRetention より前に処理したイベントの記録を削除し、削除した件数を返す。
+func (uc *ApplyTaskEventUsecase) Cleanup(ctx context.Context, now time.Time) (int64, error) {
+	return uc.Counters.DeleteProcessedEvents(ctx, now.Add(-ProcessedEventRetention))
+}
@@ -0,0 +1,96 @@
+package project_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"teamflow-shared/events"
+	"teamflow-shared/workspace"
+
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// applied は fakeTaskCounters.ApplyTaskCounts の呼び出し。
+type applied struct {
+	workspaceID, eventID, projectID string
+	open, done                      int
+}
+
+// fakeTaskCounters は TaskCounterRepository のテスト用フェイク実装。
+type fakeTaskCounters struct {
+	calls  []applied
+	before time.Time
+}
+
+func (f *fakeTaskCounters) ApplyTaskCounts(ctx context.Context, eventID, projectID string, open, done int, _ time.Time) error {
+	f.calls = append(f.calls, applied{workspace.FromContext(ctx), eventID, projectID, open, done})
+	return nil
+}
+
+func (f *fakeTaskCounters) DeleteProcessedEvents(_ context.Context, before time.Time) (int64, error) {
+	f.before = before
+	return 0, nil
+}
+
+func TestApplyTaskEvent(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	task := func(typ events.Type, status, previous string) events.Event {
+		return events.NewTaskEvent(typ, "acme", events.TaskData{ID: "t-1", ProjectID: "p-1", Status: status, PreviousStatus: previous}, now)
+	}
+
+	tests := []struct {
+		name       string
+		event      events.Event
+		open, done int
+		skipped    bool
+	}{
+		{name: "created", event: task(events.TaskCreated, "todo", ""), open: 1},
+		{name: "created done", event: task(events.TaskCreated, "done", ""), done: 1},
+		{name: "completed", event: task(events.TaskUpdated, "done", "in_progress"), open: -1, done: 1},
+		{name: "reopened", event: task(events.TaskUpdated, "todo", "done"), open: 1, done: -1},
+		{name: "started", event: task(events.TaskUpdated, "in_progress", "todo"), skipped: true},
+		{name: "status unchanged", event: task(events.TaskUpdated, "", ""), skipped: true},
+		{name: "deleted with status", event: task(events.TaskDeleted, "done", ""), done: -1},
+		{name: "deleted without status", event: task(events.TaskDeleted, "", ""), skipped: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counters := &fakeTaskCounters{}
+			uc := &usecase.ApplyTaskEventUsecase{Counters: counters}
+			if err := uc.Execute(context.Background(), usecase.ApplyTaskEventInput{Event: tt.event, Now: now}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.skipped {
+				if len(counters.calls) != 0 {
+					t.Errorf("expected no update, got %+v", counters.calls)
+				}
+				return
+			}
+			want := applied{"acme", tt.event.ID, "p-1", tt.open, tt.done}
+			if len(counters.calls) != 1 || counters.calls[0] != want {
+				t.Errorf("got %+v, want %+v", counters.calls, want)
+			}
+		})
+	}
+}
+
+func TestApplyTaskEvent_InvalidData(t *testing.T) {
+	uc := &usecase.ApplyTaskEventUsecase{Counters: &fakeTaskCounters{}}
+	e := events.Event{ID: "e-1", Type: events.TaskCreated, Data: []byte(`"not an object"`)}
+	if err := uc.Execute(context.Background(), usecase.ApplyTaskEventInput{Event: e, Now: time.Now()}); err == nil {
+		t.Error("expected an error for invalid event data")
+	}
+}
+
+func TestApplyTaskEvent_Cleanup(t *testing.T) {
+	counters := &fakeTaskCounters{}
+	uc := &usecase.ApplyTaskEventUsecase{Counters: counters}
+	now := time.Date(2026, 4, 8, 9, 0, 0, 0, time.UTC)
+	if _, err := uc.Cleanup(context.Background(), now); err != nil {
+		t.Fatal(err)
+	}
+	if want := now.Add(-usecase.ProcessedEventRetention); !counters.before.Equal(want) {
+		t.Errorf("DeleteProcessedEvents(before=%v), want %v", counters.before, want)
+	}
+}
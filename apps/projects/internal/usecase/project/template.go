@@ -0,0 +1,130 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"teamflow-shared/authz"
+
+	domain "teamflow-projects/internal/domain/project"
+)
+
+// TemplateRepository はプロジェクトテンプレートの永続化・取得を担当する抽象。
+type TemplateRepository interface {
+	// SaveTemplate はテンプレートを保存する。同じ ID が既にある場合は ErrTemplateAlreadyExists 相当のエラーを返す。
+	SaveTemplate(ctx context.Context, t *domain.Template) error
+	// FindTemplate はテンプレートを取得する。存在しない場合は ErrTemplateNotFound 相当のエラーを返す。
+	FindTemplate(ctx context.Context, id string) (*domain.Template, error)
+	ListTemplates(ctx context.Context) ([]*domain.Template, error)
+}
+
+// TaskSeeder はプロジェクトにタスクを一括作成する（tasks サービスの内部クライアント）。
+// すべて作成するか、1 件も作成しないかのどちらかでなければならない。
+type TaskSeeder interface {
+	SeedTasks(ctx context.Context, projectID string, tasks []domain.TaskBlueprint) error
+}
+
+// TaskLister はプロジェクトの未完了タスクをひな形として取得する（tasks サービスの内部クライアント）。
+type TaskLister interface {
+	ListOpenTasks(ctx context.Context, projectID string) ([]domain.TaskBlueprint, error)
+}
+
+// CreateTemplateInput はテンプレート作成ユースケースの入力。
+type CreateTemplateInput struct {
+	ID          string
+	Name        string
+	Description string
+	Tasks       []domain.TaskBlueprint
+	ActorID     string // 作成者
+	Now         time.Time
+}
+
+// CreateTemplateUsecase はテンプレート作成ユースケース。
+type CreateTemplateUsecase struct {
+	Templates TemplateRepository
+	// EnforceRoles が true の場合は作成者（ActorID）を必須にする
+	EnforceRoles bool
+}
+
+// Execute はテンプレートを検証して保存する。不正な値の場合は domain.ErrInvalidTemplate を返す。
+func (uc *CreateTemplateUsecase) Execute(ctx context.Context, in CreateTemplateInput) (*domain.Template, error) {
+	if uc.EnforceRoles && in.ActorID == "" {
+		return nil, domain.ErrActorRequired
+	}
+
+	t, err := domain.NewTemplate(in.ID, in.Name, in.Description, in.Tasks, in.Now)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.Templates.SaveTemplate(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ListTemplatesUsecase はテンプレート一覧の取得ユースケース。
+type ListTemplatesUsecase struct {
+	Templates TemplateRepository
+}
+
+// Execute はテンプレートの一覧を返す。
+func (uc *ListTemplatesUsecase) Execute(ctx context.Context) ([]*domain.Template, error) {
+	return uc.Templates.ListTemplates(ctx)
+}
+
+// CreateProjectFromTemplateInput はテンプレートからのプロジェクト作成ユースケースの入力。
+type CreateProjectFromTemplateInput struct {
+	TemplateID string
+	Project    CreateProjectInput
+}
+
+// CreateProjectFromTemplateUsecase はテンプレートからプロジェクトを作成し、定義済みのタスクを作成する。
+type CreateProjectFromTemplateUsecase struct {
+	Create    *CreateProjectUsecase
+	Templates TemplateRepository
+	Tasks     TaskSeeder
+	Tx        TxManager
+}
+
+// Execute はプロジェクトの作成（作成者の owner 登録を含む）とタスクの作成を 1 トランザクションで行う。
+//
+// タスクの作成はトランザクション内で tasks サービスを呼び出して行い、失敗した場合は
+// プロジェクトの作成をロールバックして ErrTasksService を返す。
+// テンプレートが存在しない場合は ErrTemplateNotFound を返す。
+func (uc *CreateProjectFromTemplateUsecase) Execute(ctx context.Context, in CreateProjectFromTemplateInput) (*domain.Project, error) {
+	tmpl, err := uc.Templates.FindTemplate(ctx, in.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	project := in.Project
+	if project.Description == "" {
+		project.Description = tmpl.Description
+	}
+
+	var created *domain.Project
+	err = withinTx(ctx, uc.Tx, func(ctx context.Context) error {
+		p, err := uc.Create.Execute(ctx, project)
+		if err != nil {
+			return err
+		}
+
+		if len(tmpl.Tasks) > 0 {
+			if uc.Tasks == nil {
+				return fmt.Errorf("%w: tasks service is not configured", ErrTasksService)
+			}
+			// tasks サービスでも作成者（createdBy）を記録するため、操作者を引き継ぐ
+			if err := uc.Tasks.SeedTasks(authz.ContextWithActor(ctx, project.ActorID), p.ID, tmpl.Tasks); err != nil {
+				return fmt.Errorf("%w: %w", ErrTasksService, err)
+			}
+		}
+
+		created = p
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
@@ -0,0 +1,178 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-projects/internal/domain/project"
+	usecase "teamflow-projects/internal/usecase/project"
+)
+
+// fakeTemplateRepo は TemplateRepository のテスト用フェイク実装。
+type fakeTemplateRepo struct {
+	templates map[string]*domain.Template
+}
+
+func (r *fakeTemplateRepo) SaveTemplate(_ context.Context, t *domain.Template) error {
+	if r.templates == nil {
+		r.templates = map[string]*domain.Template{}
+	}
+	r.templates[t.ID] = t
+	return nil
+}
+
+func (r *fakeTemplateRepo) FindTemplate(_ context.Context, id string) (*domain.Template, error) {
+	t, ok := r.templates[id]
+	if !ok {
+		return nil, usecase.ErrTemplateNotFound
+	}
+	return t, nil
+}
+
+func (r *fakeTemplateRepo) ListTemplates(_ context.Context) ([]*domain.Template, error) {
+	out := make([]*domain.Template, 0, len(r.templates))
+	for _, t := range r.templates {
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// fakeSeeder は TaskSeeder のテスト用フェイク実装。
+type fakeSeeder struct {
+	err       error
+	projectID string
+	tasks     []domain.TaskBlueprint
+}
+
+func (s *fakeSeeder) SeedTasks(_ context.Context, projectID string, tasks []domain.TaskBlueprint) error {
+	s.projectID, s.tasks = projectID, tasks
+	return s.err
+}
+
+// fakeTxManager は WithinTx の結果（コミット / ロールバック）を記録する。
+type fakeTxManager struct {
+	committed  bool
+	rolledBack bool
+}
+
+func (m *fakeTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		m.rolledBack = true
+		return err
+	}
+	m.committed = true
+	return nil
+}
+
+func newTemplateRepo(t *testing.T) *fakeTemplateRepo {
+	t.Helper()
+	tmpl, err := domain.NewTemplate("tmpl-1", "スプリント", "2 週間のスプリント", []domain.TaskBlueprint{
+		{Title: "計画"},
+		{Title: "振り返り", Priority: "low"},
+	}, time.Now())
+	if err != nil {
+		t.Fatalf("failed to create template: %v", err)
+	}
+	return &fakeTemplateRepo{templates: map[string]*domain.Template{tmpl.ID: tmpl}}
+}
+
+func TestCreateProjectFromTemplate_Success(t *testing.T) {
+	projects := &fakeProjectRepo{}
+	seeder := &fakeSeeder{}
+	tx := &fakeTxManager{}
+	uc := &usecase.CreateProjectFromTemplateUsecase{
+		Create:    &usecase.CreateProjectUsecase{Repo: projects},
+		Templates: newTemplateRepo(t),
+		Tasks:     seeder,
+		Tx:        tx,
+	}
+
+	p, err := uc.Execute(context.Background(), usecase.CreateProjectFromTemplateInput{
+		TemplateID: "tmpl-1",
+		Project:    usecase.CreateProjectInput{ID: "proj-1", Name: "Sprint 1", Now: time.Now()},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Description != "2 週間のスプリント" {
+		t.Errorf("expected template description to be used, got %q", p.Description)
+	}
+	if projects.saved != p {
+		t.Errorf("expected project to be saved")
+	}
+	if seeder.projectID != "proj-1" || len(seeder.tasks) != 2 {
+		t.Errorf("unexpected seeding: projectID=%s tasks=%d", seeder.projectID, len(seeder.tasks))
+	}
+	if !tx.committed {
+		t.Errorf("expected transaction to be committed")
+	}
+}
+
+func TestCreateProjectFromTemplate_Errors(t *testing.T) {
+	seedErr := errors.New("tasks service unavailable")
+
+	tests := []struct {
+		name         string
+		templateID   string
+		seeder       usecase.TaskSeeder
+		wantErr      error
+		wantRollback bool
+	}{
+		{name: "template not found", templateID: "tmpl-x", seeder: &fakeSeeder{}, wantErr: usecase.ErrTemplateNotFound},
+		{name: "seeding fails", templateID: "tmpl-1", seeder: &fakeSeeder{err: seedErr}, wantErr: seedErr, wantRollback: true},
+		{name: "tasks service not configured", templateID: "tmpl-1", wantErr: usecase.ErrTasksService, wantRollback: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &fakeTxManager{}
+			uc := &usecase.CreateProjectFromTemplateUsecase{
+				Create:    &usecase.CreateProjectUsecase{Repo: &fakeProjectRepo{}},
+				Templates: newTemplateRepo(t),
+				Tasks:     tt.seeder,
+				Tx:        tx,
+			}
+
+			p, err := uc.Execute(context.Background(), usecase.CreateProjectFromTemplateInput{
+				TemplateID: tt.templateID,
+				Project:    usecase.CreateProjectInput{ID: "proj-1", Name: "Sprint 1", Now: time.Now()},
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantRollback && !errors.Is(err, usecase.ErrTasksService) {
+				t.Errorf("expected ErrTasksService, got %v", err)
+			}
+			if p != nil {
+				t.Errorf("expected nil project, got %+v", p)
+			}
+			if tx.rolledBack != tt.wantRollback {
+				t.Errorf("rolledBack = %v, want %v", tx.rolledBack, tt.wantRollback)
+			}
+		})
+	}
+}
+
+func TestCreateTemplate(t *testing.T) {
+	templates := &fakeTemplateRepo{}
+	uc := &usecase.CreateTemplateUsecase{Templates: templates, EnforceRoles: true}
+
+	if _, err := uc.Execute(context.Background(), usecase.CreateTemplateInput{ID: "tmpl-1", Name: "スプリント"}); !errors.Is(err, domain.ErrActorRequired) {
+		t.Fatalf("expected ErrActorRequired, got %v", err)
+	}
+	if _, err := uc.Execute(context.Background(), usecase.CreateTemplateInput{ID: "tmpl-1", Name: "", ActorID: "user-1"}); !errors.Is(err, domain.ErrInvalidTemplate) {
+		t.Fatalf("expected ErrInvalidTemplate, got %v", err)
+	}
+
+	tmpl, err := uc.Execute(context.Background(), usecase.CreateTemplateInput{
+		ID: "tmpl-1", Name: "スプリント", Tasks: []domain.TaskBlueprint{{Title: "計画"}}, ActorID: "user-1", Now: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if templates.templates["tmpl-1"] != tmpl {
+		t.Errorf("expected template to be saved")
+	}
+}
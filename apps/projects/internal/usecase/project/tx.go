@@ -0,0 +1,21 @@
+package project
+
+import "context"
+
+// TxManager は複数の書き込みを 1 トランザクション（Unit of Work）として実行する抽象。
+//
+// fn に渡される ctx にはトランザクションが紐づいており、
+// リポジトリはその ctx を使うことで同一トランザクション内で処理される。
+// fn がエラーを返した場合はロールバックし、そのエラーを返す。
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// withinTx は tx が nil の場合はトランザクション無しで fn を実行する。
+// TxManager を注入していない構成（テスト等）との互換のため。
+func withinTx(ctx context.Context, tx TxManager, fn func(ctx context.Context) error) error {
+	if tx == nil {
+		return fn(ctx)
+	}
+	return tx.WithinTx(ctx, fn)
+}
@@ -2,29 +2,78 @@ package project
 
 import (
 	"context"
-	"errors"
+	"strings"
 	"time"
 
+	"teamflow-shared/audit"
+	"teamflow-shared/featureflag"
+
 	domain "teamflow-projects/internal/domain/project"
 )
 
 // UpdateProjectInput はプロジェクト更新ユースケースの入力。
 type UpdateProjectInput struct {
 	ID          string
+	Key         string // 空の場合は変更しない
 	Name        string
 	Description string
+	Status      string // 空の場合は変更しない
+	Visibility  string // 空の場合は変更しない。変更には設定の変更権限（owner / admin）が必要
+	ActorID     string // 操作者（UpdatedBy）
 	Now         time.Time
 }
 
 // UpdateProjectUsecase はプロジェクト更新ユースケースを表す。
 type UpdateProjectUsecase struct {
-	Repo ProjectRepository
+	Repo    ProjectRepository
+	Members MemberRepository
+	// EnforceRoles が true の場合は操作者のロールを確認する（member 以上が編集でき、公開範囲の変更は owner / admin のみ）
+	EnforceRoles bool
+	// UniqueNames が true の場合は他のプロジェクトと同じ名前への変更を拒否する
+	UniqueNames bool
+	// Flags は FlagUniqueProjectNames で名前の一意制約を有効にするために使う。任意。nil の場合は UniqueNames のみで判定する
+	Flags featureflag.Provider
+	// Activity は変更を記録するために使う。任意。nil の場合は記録しない
+	Activity ActivityRepository
+	// Audit は更新を監査ログに記録するために使う。任意。nil の場合は記録しない
+	Audit audit.Recorder
 }
 
-// Execute は既存プロジェクトを取得し、名前・説明・UpdatedAt を更新する。
+// Execute は既存プロジェクトを取得し、キー・名前・説明・ステータス・UpdatedAt を更新する。
+// Status が不正な場合は domain.ErrInvalidStatus、Key が不正な場合は domain.ErrInvalidKey、
+// Visibility が不正な場合は domain.ErrInvalidVisibility、Key が他のプロジェクトと重複する場合は ErrProjectKeyAlreadyExists、
+// UniqueNames で他のプロジェクトと名前が重複する場合は *DuplicateNameError、編集権限が無い場合は domain.ErrForbidden を返す。
+// 値が変わったフィールドがある場合のみ、変更したフィールドをアクティビティに記録する。
 func (uc *UpdateProjectUsecase) Execute(ctx context.Context, in UpdateProjectInput) (*domain.Project, error) {
 	if in.Name == "" {
-		return nil, errors.New("project name must not be empty")
+		return nil, domain.ErrNameRequired
+	}
+
+	var status domain.ProjectStatus
+	if in.Status != "" {
+		s, err := domain.ParseStatus(in.Status)
+		if err != nil {
+			return nil, err
+		}
+		status = s
+	}
+
+	var visibility domain.Visibility
+	if in.Visibility != "" {
+		v, err := domain.ParseVisibility(in.Visibility)
+		if err != nil {
+			return nil, err
+		}
+		visibility = v
+	}
+
+	var key string
+	if in.Key != "" {
+		k, err := domain.ParseKey(in.Key)
+		if err != nil {
+			return nil, err
+		}
+		key = k
 	}
 
 	// 既存プロジェクトを取得
@@ -33,13 +82,55 @@ func (uc *UpdateProjectUsecase) Execute(ctx context.Context, in UpdateProjectInp
 		return nil, err
 	}
 
-	existing.Name = in.Name
-	existing.Description = in.Description
-	existing.UpdatedAt = in.Now
+	if uc.EnforceRoles {
+		if err := authorize(ctx, uc.Members, existing.ID, in.ActorID, domain.ActionEdit); err != nil {
+			return nil, err
+		}
+		// 公開範囲は値が変わる場合だけ、設定の変更権限を確認する
+		if visibility != "" && visibility != existing.Visibility {
+			if err := authorize(ctx, uc.Members, existing.ID, in.ActorID, domain.ActionManageSettings); err != nil {
+				return nil, err
+			}
+		}
+	}
 
-	if err := uc.Repo.Save(ctx, existing); err != nil {
-		return existing, err
+	if uc.UniqueNames || flagEnabled(ctx, uc.Flags, FlagUniqueProjectNames) {
+		if err := checkNameAvailable(ctx, uc.Repo, in.Name, existing.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 保存に失敗した場合（キーの重複など）に取得した値を変更しないよう、コピーを更新する
+	updated := *existing
+	updated.Name = in.Name
+	updated.Description = in.Description
+	if status != "" {
+		updated.Status = status
+	}
+	if key != "" {
+		updated.Key = key
+	}
+	if visibility != "" {
+		updated.Visibility = visibility
+	}
+	updated.UpdatedAt = in.Now
+	updated.UpdatedBy = in.ActorID
+
+	if err := uc.Repo.Update(ctx, &updated); err != nil {
+		return &updated, err
+	}
+
+	fields := domain.ChangedFields(existing, &updated)
+	if err := recordAudit(ctx, uc.Audit, &updated, audit.ActionUpdate, in.ActorID, fields, in.Now); err != nil {
+		return &updated, err
+	}
+	if len(fields) > 0 {
+		event := domain.NewActivityEvent(updated.ID, domain.ActivityProjectUpdated, in.ActorID,
+			map[string]string{"fields": strings.Join(fields, ",")}, in.Now)
+		if err := recordActivity(ctx, uc.Activity, event); err != nil {
+			return &updated, err
+		}
 	}
 
-	return existing, nil
+	return &updated, nil
 }
@@ -6,19 +6,28 @@ import (
 	"testing"
 	"time"
 
+	"teamflow-shared/audit"
+
 	domain "teamflow-projects/internal/domain/project"
 	usecase "teamflow-projects/internal/usecase/project"
 )
 
 type fakeUpdateRepo struct {
-	stored  *domain.Project
-	findErr error
-	saveErr error
+	stored    *domain.Project
+	others    []*domain.Project // 名前の重複チェック用の他のプロジェクト
+	findErr   error
+	updateErr error
 }
 
+// Save は Update のテストでは使わないのでダミーで OK
 func (r *fakeUpdateRepo) Save(_ context.Context, p *domain.Project) error {
 	r.stored = p
-	return r.saveErr
+	return nil
+}
+
+func (r *fakeUpdateRepo) Update(_ context.Context, p *domain.Project) error {
+	r.stored = p
+	return r.updateErr
 }
 
 func (r *fakeUpdateRepo) FindByID(_ context.Context, id string) (*domain.Project, error) {
@@ -31,9 +40,28 @@ func (r *fakeUpdateRepo) FindByID(_ context.Context, id string) (*domain.Project
 	if r.stored.ID != id {
 		return nil, errors.New("not found")
 	}
+	if r.stored.IsDeleted() {
+		return nil, usecase.ErrProjectNotFound
+	}
 	return r.stored, nil
 }
 
+func (r *fakeUpdateRepo) FindDeleted(_ context.Context, id string) (*domain.Project, error) {
+	if r.stored == nil || r.stored.ID != id || !r.stored.IsDeleted() {
+		return nil, usecase.ErrProjectNotFound
+	}
+	return r.stored, nil
+}
+
+func (r *fakeUpdateRepo) FindByName(_ context.Context, name string) (*domain.Project, error) {
+	for _, p := range append([]*domain.Project{r.stored}, r.others...) {
+		if p != nil && p.Name == name {
+			return p, nil
+		}
+	}
+	return nil, usecase.ErrProjectNotFound
+}
+
 // List は Update のテストでは使わないのでダミーで OK
 func (r *fakeUpdateRepo) List(_ context.Context) ([]*domain.Project, error) {
 	if r.stored == nil {
@@ -42,6 +70,11 @@ func (r *fakeUpdateRepo) List(_ context.Context) ([]*domain.Project, error) {
 	return []*domain.Project{r.stored}, nil
 }
 
+// FindWithQuery は Update のテストでは使わないのでダミーで OK
+func (r *fakeUpdateRepo) FindWithQuery(ctx context.Context, _ *domain.ProjectQuery) ([]*domain.Project, error) {
+	return r.List(ctx)
+}
+
 func TestUpdateProject_Success(t *testing.T) {
 	ctx := context.Background()
 
@@ -95,6 +128,37 @@ func TestUpdateProject_Success(t *testing.T) {
 	}
 }
 
+func TestUpdateProject_RecordsActor(t *testing.T) {
+	createdAt := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	now := createdAt.Add(time.Hour)
+	existing, err := domain.NewProject("proj-1", "Old Name", "Desc", createdAt)
+	if err != nil {
+		t.Fatalf("unexpected error creating existing project: %v", err)
+	}
+	existing.CreatedBy = "user-1"
+	existing.UpdatedBy = "user-1"
+
+	rec := audit.NewMemoryRecorder()
+	uc := &usecase.UpdateProjectUsecase{Repo: &fakeUpdateRepo{stored: existing}, Audit: rec}
+
+	p, err := uc.Execute(context.Background(), usecase.UpdateProjectInput{ID: "proj-1", Name: "New Name", Description: "Desc", ActorID: "user-2", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.CreatedBy != "user-1" || p.UpdatedBy != "user-2" {
+		t.Errorf("expected CreatedBy=user-1, UpdatedBy=user-2, got %q/%q", p.CreatedBy, p.UpdatedBy)
+	}
+
+	entries := rec.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %d", len(entries))
+	}
+	e := entries[0]
+	if e.Action != audit.ActionUpdate || e.ActorID != "user-2" || len(e.Fields) != 1 || e.Fields[0] != "name" {
+		t.Errorf("unexpected audit entry: %+v", e)
+	}
+}
+
 func TestUpdateProject_EmptyName(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
@@ -129,6 +193,136 @@ func TestUpdateProject_EmptyName(t *testing.T) {
 	}
 }
 
+func TestUpdateProject_Status(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		status string
+		want   domain.ProjectStatus
+	}{
+		{name: "unchanged when empty", status: "", want: domain.StatusActive},
+		{name: "changed", status: "on_hold", want: domain.StatusOnHold},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing, err := domain.NewProject("proj-1", "Old Name", "", now.Add(-time.Hour))
+			if err != nil {
+				t.Fatalf("unexpected error creating existing project: %v", err)
+			}
+			uc := &usecase.UpdateProjectUsecase{Repo: &fakeUpdateRepo{stored: existing}}
+
+			p, err := uc.Execute(ctx, usecase.UpdateProjectInput{ID: "proj-1", Name: "Old Name", Status: tt.status, Now: now})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p.Status != tt.want {
+				t.Errorf("expected Status=%s, got=%s", tt.want, p.Status)
+			}
+		})
+	}
+}
+
+func TestUpdateProject_InvalidStatus(t *testing.T) {
+	now := time.Now()
+	existing, err := domain.NewProject("proj-1", "Old Name", "", now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error creating existing project: %v", err)
+	}
+	uc := &usecase.UpdateProjectUsecase{Repo: &fakeUpdateRepo{stored: existing}}
+
+	_, err = uc.Execute(context.Background(), usecase.UpdateProjectInput{ID: "proj-1", Name: "New Name", Status: "paused", Now: now})
+	if !errors.Is(err, domain.ErrInvalidStatus) {
+		t.Fatalf("expected ErrInvalidStatus, got %v", err)
+	}
+	if existing.Name != "Old Name" {
+		t.Errorf("expected existing project to be untouched, got Name=%s", existing.Name)
+	}
+}
+
+func TestUpdateProject_Key(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		want    string
+		wantErr error
+	}{
+		{name: "unchanged when empty", key: "", want: "OLD"},
+		{name: "changed", key: "new1", want: "NEW1"},
+		{name: "invalid", key: "1NEW", wantErr: domain.ErrInvalidKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			existing, err := domain.NewProject("proj-1", "Old Name", "", now.Add(-time.Hour))
+			if err != nil {
+				t.Fatalf("unexpected error creating existing project: %v", err)
+			}
+			existing.Key = "OLD"
+			uc := &usecase.UpdateProjectUsecase{Repo: &fakeUpdateRepo{stored: existing}}
+
+			p, err := uc.Execute(context.Background(), usecase.UpdateProjectInput{ID: "proj-1", Key: tt.key, Name: "Old Name", Now: now})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				if existing.Key != "OLD" {
+					t.Errorf("expected existing project to be untouched, got Key=%s", existing.Key)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p.Key != tt.want {
+				t.Errorf("expected Key=%q, got=%q", tt.want, p.Key)
+			}
+		})
+	}
+}
+
+func TestUpdateProject_UniqueNames(t *testing.T) {
+	tests := []struct {
+		name      string
+		newName   string
+		unique    bool
+		wantOther string
+	}{
+		{name: "same name as itself", newName: "Old Name", unique: true},
+		{name: "new name", newName: "New Name", unique: true},
+		{name: "other project's name", newName: "Other", unique: true, wantOther: "proj-2"},
+		{name: "other project's name without constraint", newName: "Other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			existing, _ := domain.NewProject("proj-1", "Old Name", "", now.Add(-time.Hour))
+			other, _ := domain.NewProject("proj-2", "Other", "", now.Add(-time.Hour))
+			repo := &fakeUpdateRepo{stored: existing, others: []*domain.Project{other}}
+			uc := &usecase.UpdateProjectUsecase{Repo: repo, UniqueNames: tt.unique}
+
+			_, err := uc.Execute(context.Background(), usecase.UpdateProjectInput{ID: "proj-1", Name: tt.newName, Now: now})
+			if tt.wantOther == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var dup *usecase.DuplicateNameError
+			if !errors.As(err, &dup) || dup.ProjectID != tt.wantOther {
+				t.Fatalf("expected DuplicateNameError for %s, got %v", tt.wantOther, err)
+			}
+			if repo.stored.Name != "Old Name" {
+				t.Errorf("expected project to be unchanged, got Name=%s", repo.stored.Name)
+			}
+		})
+	}
+}
+
 func TestUpdateProject_FindError(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
@@ -163,7 +357,7 @@ func TestUpdateProject_FindError(t *testing.T) {
 	}
 }
 
-func TestUpdateProject_SaveError(t *testing.T) {
+func TestUpdateProject_UpdateError(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
 
@@ -172,10 +366,10 @@ func TestUpdateProject_SaveError(t *testing.T) {
 		t.Fatalf("unexpected error creating existing project: %v", err)
 	}
 
-	saveErr := errors.New("db error")
+	updateErr := errors.New("db error")
 	repo := &fakeUpdateRepo{
-		stored:  existing,
-		saveErr: saveErr,
+		stored:    existing,
+		updateErr: updateErr,
 	}
 
 	uc := &usecase.UpdateProjectUsecase{
@@ -191,14 +385,14 @@ func TestUpdateProject_SaveError(t *testing.T) {
 
 	p, err := uc.Execute(ctx, in)
 	if err == nil {
-		t.Fatalf("expected error from Save, got nil")
+		t.Fatalf("expected error from Update, got nil")
 	}
 
-	if !errors.Is(err, saveErr) {
-		t.Fatalf("expected error %v, got %v", saveErr, err)
+	if !errors.Is(err, updateErr) {
+		t.Fatalf("expected error %v, got %v", updateErr, err)
 	}
 
 	if p == nil {
-		t.Fatalf("expected project to be returned even when Save fails")
+		t.Fatalf("expected project to be returned even when Update fails")
 	}
 }
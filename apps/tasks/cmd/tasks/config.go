@@ -0,0 +1,535 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"teamflow-shared/apiversion"
+	"teamflow-shared/authz"
+	"teamflow-shared/bus"
+	sharedconfig "teamflow-shared/config"
+	"teamflow-shared/cors"
+	"teamflow-shared/featureflag"
+	"teamflow-shared/logging"
+	"teamflow-shared/mail"
+	"teamflow-shared/openapi"
+	"teamflow-shared/ratelimit"
+	"teamflow-shared/requestid"
+	"teamflow-shared/server"
+	"teamflow-shared/serviceauth"
+	"teamflow-shared/tracing"
+	"teamflow-shared/uuidpolicy"
+
+	domain "teamflow-tasks/internal/domain/task"
+	httphandler "teamflow-tasks/internal/interface/http"
+	"teamflow-tasks/internal/usecase/notification"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+const (
+	// defaultPort は API の listen ポートの既定値。
+	defaultPort = 8081
+	// defaultAdminPort はメトリクスなどの運用エンドポイントの listen ポートの既定値。
+	defaultAdminPort = 9091
+	// defaultJWTIssuer / defaultJWTAudience は受け付ける JWT の iss / aud の既定値（auth サービスの AUTH_ISSUER / AUTH_AUDIENCE と合わせる）。
+	defaultJWTIssuer   = "teamflow-auth"
+	defaultJWTAudience = "teamflow"
+
+	// serverWriteTimeout は HTTP サーバーの WriteTimeout。
+	serverWriteTimeout = server.DefaultWriteTimeout
+	// defaultDBQueryTimeout は 1 回の問い合わせのタイムアウトの既定値（WriteTimeout より短くする）。
+	defaultDBQueryTimeout = 10 * time.Second
+
+	defaultTaskCacheSize = 1000
+	defaultTaskCacheTTL  = 30 * time.Second
+
+	defaultMembershipCacheSize = 10000
+	defaultMembershipCacheTTL  = 30 * time.Second
+
+	// defaultArchivePurgeInterval は保持期間を過ぎたアーカイブしたタスクを確認する間隔の既定値。
+	defaultArchivePurgeInterval = time.Hour
+)
+
+// defaultFlags は tasks サービスが参照するフィーチャーフラグの既定値。
+var defaultFlags = featureflag.Set{
+	httphandler.FlagTaskEvents: true,
+}
+
+// config は環境変数から読み込んだ tasks サービスの設定。
+type config struct {
+	AppEnv string
+	// LogLevel はログの出力レベル（既定は info）
+	LogLevel     slog.Level
+	Port         int
+	CursorSecret []byte
+	// AdminPort はメトリクス（/metrics）を公開する listen ポート（API とは別）
+	AdminPort int
+	// GRPCPort は gRPC の API（サービス間の呼び出し用）の listen ポート。0 の場合は起動しない
+	GRPCPort int
+
+	// SIGINT / SIGTERM を受けてから処理中のリクエストを待つ時間
+	ShutdownTimeout time.Duration
+
+	// TLS はクライアントとの接続の TLS の設定（未設定の場合は平文で待ち受ける）
+	TLS server.TLSOptions
+	// H2C は平文の接続で HTTP/2（h2c）を受け付けるか（TLS を終端したロードバランサー・サービス間の呼び出し用）
+	H2C bool
+
+	// CORS はブラウザからの別オリジンのリクエストの許可設定
+	CORS cors.Options
+
+	// トレーシング（OTLPEndpoint が空の場合は記録しない）
+	OTLPEndpoint string
+	// ServiceName はトレースの service.name
+	ServiceName string
+	// TraceSampleRatio は新しいトレースを記録する割合（0〜1）
+	TraceSampleRatio float64
+
+	// OpenAPIValidation はリクエスト・レスポンスを OpenAPI の仕様で検証するかどうか（off / log / strict）
+	OpenAPIValidation openapi.Mode
+
+	// UUIDPolicy は作成するタスクの ID と担当者 IDとして受け付ける UUID の形式（any / v4v7）
+	UUIDPolicy uuidpolicy.Policy
+
+	// RepoBackend はリポジトリの実装（REPO_BACKEND。未設定の場合は DBDSN があれば postgres、無ければ memory）
+	RepoBackend sharedconfig.RepoBackend
+
+	// DevSeed は起動時にデモデータ（devseed）を作成するかどうか（ローカル開発用。production では使えない）
+	DevSeed bool
+
+	// DB（RepoBackend が postgres の場合に使う）
+	DBDSN              string
+	DBMaxConns         int32
+	DBMinConns         int32
+	DBStatementTimeout time.Duration
+	DBQueryTimeout     time.Duration
+	// DBSlowQueryThreshold を超えた問い合わせをログに出力する（0 なら出力しない）
+	DBSlowQueryThreshold time.Duration
+
+	// 一覧（HTTP の limit、gRPC の page_size）の既定値と上限
+	ListPageLimits domain.PageLimits
+
+	// タスク詳細（FindByID）のキャッシュ（SQL のみ。TaskCacheSize が 0 なら無効）
+	TaskCacheSize int
+	TaskCacheTTL  time.Duration
+
+	// SyncTombstoneRetention は差分同期のために削除したタスクの記録を残す期間（これより古い同期トークンは拒否する）
+	SyncTombstoneRetention time.Duration
+
+	// ArchiveRetentionDays はアーカイブしたタスクを物理削除するまでの日数（0 の場合は保持期間のジョブを実行しない）
+	ArchiveRetentionDays int
+	// ArchivePurgeInterval は保持期間を過ぎたアーカイブしたタスクを確認する間隔
+	ArchivePurgeInterval time.Duration
+
+	// Flags はフィーチャーフラグ（FEATURE_FLAGS / FEATURE_FLAGS_FILE。既定値は defaultFlags）
+	Flags featureflag.Set
+
+	// ServiceAPIKeys はサービス間専用のエンドポイントで受け付けるキー（空の場合は認証しない）
+	ServiceAPIKeys []serviceauth.Key
+
+	// Operators は管理用の API（/api/admin 配下）を呼び出せる運用者（空の場合は誰も呼び出せない）
+	Operators authz.Operators
+
+	// レート制限（RATE_LIMIT_TIERS。RateLimitTiers が空の場合は制限しない）
+	RateLimitTiers     map[string]int
+	RateLimitUserTiers map[string]string
+	// MaxInFlightRequests は同時に処理する API のリクエスト数の上限。超えた分は 503 で断る（0 の場合は制限しない）
+	MaxInFlightRequests int
+
+	// JWT（auth サービスが OIDC のログインで発行する）の検証（JWKSURL が空の場合は検証しない）
+	JWKSURL     string
+	JWTIssuer   string
+	JWTAudience string
+
+	// projects サービスのベース URL（空の場合はプロジェクト設定の既定値・担当者のメンバーチェックを使わない）
+	ProjectsServiceURL string
+	// EnforceMembership はタスクの閲覧・変更を操作者がプロジェクトのメンバーの場合に限るかどうか（PROJECTS_SERVICE_URL が必要）
+	EnforceMembership bool
+	// projects サービスへのメンバーシップの問い合わせのキャッシュ（MembershipCacheSize が 0 なら無効）
+	MembershipCacheSize int
+	MembershipCacheTTL  time.Duration
+
+	// users サービスのベース URL（空の場合は担当者の存在チェック・担当者名の表示・個人用アクセストークンの検証を使わない）
+	UsersServiceURL string
+	// ServiceAPIKey は users サービスのサービス間専用のエンドポイントに X-Service-Key で送るキー（空の場合は送らない）
+	ServiceAPIKey string
+
+	// EventBus は outbox のリレーがドメインイベントを配信するイベントバス（EVENT_BUS。既定は log）
+	EventBus bus.Config
+
+	// Notify は担当者へのメールの通知の送信方法（NOTIFY_EMAIL。NotifyEnabled が false の場合は通知しない）
+	NotifyEnabled bool
+	Notify        mail.Config
+	// NotifyDueSoonDays は期日の何日前から担当者に通知するか
+	NotifyDueSoonDays int
+	// NotifyDueSoonInterval は期日が近いタスクを確認する間隔
+	NotifyDueSoonInterval time.Duration
+}
+
+// useSQL は SQL リポジトリを使うかどうかを返す。
+func (c config) useSQL() bool {
+	return c.RepoBackend == sharedconfig.BackendPostgres
+}
+
+// addr は listen アドレスを返す。
+func (c config) addr() string {
+	return fmt.Sprintf(":%d", c.Port)
+}
+
+// adminAddr は運用エンドポイントの listen アドレスを返す。
+func (c config) adminAddr() string {
+	return fmt.Sprintf(":%d", c.AdminPort)
+}
+
+// grpcAddr は gRPC の API の listen アドレスを返す。
+func (c config) grpcAddr() string {
+	return fmt.Sprintf(":%d", c.GRPCPort)
+}
+
+// loadConfig は環境変数（と CONFIG_FILE の設定ファイル）から設定を読み込み、検証する。
+// 不正な値はまとめて 1 つのエラーとして返す（起動時にすべて把握できるように）。
+//
+//	CONFIG_FILE             KEY=VALUE 形式の設定ファイル（環境変数に無い値を補う、default: 無し）
+//	APP_ENV                 production の場合は REPO_BACKEND=postgres（DB_DSN）と 32 バイト以上の CURSOR_SECRET が必須
+//	LOG_LEVEL               ログレベル（debug / info / warn / error、default: info）
+//	PORT                    listen ポート（default 8081）
+//	ADMIN_PORT              メトリクス（/metrics）の listen ポート（default 9091、PORT と別にする）
+//	GRPC_PORT               gRPC の API（サービス間の呼び出し用）の listen ポート（default: 無し＝起動しない、PORT・ADMIN_PORT と別にする）
+//	SHUTDOWN_TIMEOUT        停止時に処理中のリクエストを待つ時間（default 20s）
+//	TLS_* / H2C             クライアントとの接続の TLS・h2c（server.ParseTLSOptions を参照、default: 平文の HTTP/1.1）
+//	CURSOR_SECRET           cursor 署名用シークレット
+//	CORS_ALLOWED_ORIGINS    ブラウザから呼び出せるオリジン（カンマ区切り、* ですべて、default: http://localhost:3000,http://127.0.0.1:3000）
+//	CORS_ALLOWED_METHODS    プリフライトで許可するメソッド（カンマ区切り、default: GET,POST,PUT,PATCH,DELETE）
+//	CORS_ALLOWED_HEADERS    プリフライトで許可するヘッダ（カンマ区切り、default: Content-Type,Authorization,X-Request-ID,X-User-ID,traceparent）
+//	CORS_ALLOW_CREDENTIALS  Cookie・Authorization 付きのリクエストを許可するか（default: true、* のオリジンとは併用不可）
+//	CORS_MAX_AGE            プリフライトの結果のキャッシュ期間（default: 10m）
+//	OTEL_EXPORTER_OTLP_ENDPOINT  トレースの送信先（OTLP/HTTP、例: http://otel-collector:4318、default: 無し＝記録しない）
+//	OTEL_SERVICE_NAME       トレースの service.name（default: tasks）
+//	OTEL_TRACES_SAMPLER_ARG 新しいトレースを記録する割合（0〜1、default: 1）
+//	OPENAPI_VALIDATION      OpenAPI の仕様とのずれの検証（off / log / strict、default: production は off、それ以外は strict）
+//	UUID_POLICY             作成するタスクの ID と担当者 IDとして受け付ける UUID（any: バージョンを問わない / v4v7: バージョン 4・7 のみ、default: any）
+//	REPO_BACKEND            リポジトリの実装（memory / postgres、default: DB_DSN があれば postgres、無ければ memory、production では postgres のみ）
+//	DB_DSN                  PostgreSQL の接続文字列（REPO_BACKEND=postgres では必須）
+//	DEV_SEED                起動時にデモ用のタスクを作成するか（ローカル開発用、作成済みのものは作成しない、production では不可、default: false）
+//	DB_MAX_CONNS            プールの最大接続数（default: pgxpool の既定値）
+//	DB_MIN_CONNS            プールの最小接続数（default: 0）
+//	DB_STATEMENT_TIMEOUT    ステートメントタイムアウト（例: 5s、default: 無し）
+//	DB_QUERY_TIMEOUT        リポジトリでの 1 回の問い合わせのタイムアウト（default 10s、WriteTimeout 未満）
+//	DB_SLOW_QUERY_THRESHOLD  これより時間のかかった問い合わせを SQL の形（値は含めない）とともにログに出力する（例: 200ms、default: 0＝出力しない）
+//	LIST_MAX_LIMIT          一覧の limit（gRPC の page_size）の上限（default 200、超える値は上限に正規化する）
+//	LIST_DEFAULT_LIMIT      一覧の limit を省略した場合の件数（default: 200 と LIST_MAX_LIMIT の小さい方）
+//	TASK_CACHE_SIZE         タスク詳細キャッシュの最大件数（default 1000、0 で無効）
+//	TASK_CACHE_TTL          タスク詳細キャッシュの有効期間（default 30s）
+//	SYNC_TOMBSTONE_RETENTION  差分同期（/projects/{id}/sync）のために削除したタスクの記録を残す期間（default 720h）
+//	ARCHIVE_RETENTION_DAYS  アーカイブしたタスクを物理削除するまでの日数（default: 0＝削除しない、管理用の POST /api/admin/purge では都度指定する）
+//	ARCHIVE_PURGE_INTERVAL  保持期間を過ぎたアーカイブしたタスクを確認する間隔（default 1h）
+//	PROJECTS_SERVICE_URL    projects サービスのベース URL（例: http://projects:8080、default: 無し）
+//	ENFORCE_MEMBERSHIP      タスクの閲覧・変更をプロジェクトのメンバーに限るか（default: false、PROJECTS_SERVICE_URL が必要）
+//	MEMBERSHIP_CACHE_SIZE   メンバーシップのキャッシュの最大件数（default 10000、0 で無効）
+//	MEMBERSHIP_CACHE_TTL    メンバーシップのキャッシュの有効期間（default 30s）
+//	USERS_SERVICE_URL       users サービスのベース URL（例: http://users:8082、default: 無し）
+//	SERVICE_API_KEY         users サービスの呼び出しに X-Service-Key で付けるキー（users の SERVICE_API_KEYS に登録したもの、default: 無し）
+//	SERVICE_API_KEYS        サービス間専用のエンドポイントで受け付けるキー（カンマ区切りの name:key[:rpm]、例: projects:s3cr3t:600、default: 無し＝認証しない）
+//	ADMIN_USER_IDS          管理用の API（/api/admin 配下）を呼び出せる運用者のユーザー ID（カンマ区切り、default: 無し＝誰も呼び出せない）
+//	RATE_LIMIT_TIERS        ティアごとの 1 分あたりのリクエスト数の上限（カンマ区切りの tier:rpm、例: anonymous:60,user:600,token:300、0 で無制限、default: 無し＝制限しない）
+//	RATE_LIMIT_USER_TIERS   既定と異なるティアを使う操作者（カンマ区切りの userId:tier、例: ci-bot:premium、default: 無し）
+//	MAX_IN_FLIGHT_REQUESTS  同時に処理するリクエスト数の上限。超えた分は 503 と Retry-After で断る（DB_MAX_CONNS の数倍を目安にする、0 で無制限、default: 0）
+//	JWKS_URL                JWT を検証する公開鍵（auth サービスの /.well-known/jwks.json、例: http://auth:8083/.well-known/jwks.json、default: 無し＝検証しない）
+//	JWT_ISSUER              受け付ける JWT の iss（default: teamflow-auth）
+//	JWT_AUDIENCE            受け付ける JWT の aud（default: teamflow）
+//	FEATURE_FLAGS           フィーチャーフラグ（カンマ区切りの name または name=false、例: task-events=false、default: 無し）
+//	FEATURE_FLAGS_FILE      {"name": true} 形式のフィーチャーフラグの JSON ファイル（FEATURE_FLAGS が優先、default: 無し）
+//	EVENT_BUS               ドメインイベントの配信先（log / nats / kafka、default: log＝ログに出力するだけ）
+//	NATS_URL                NATS サーバーの URL（例: nats://nats:4222、EVENT_BUS=nats では必須）
+//	NATS_SUBJECT_PREFIX     NATS の subject の接頭辞（default: teamflow.events、例: teamflow.events.task.created）
+//	KAFKA_REST_URL          Kafka REST Proxy のベース URL（例: http://kafka-rest:8082、EVENT_BUS=kafka では必須）
+//	KAFKA_TOPIC             Kafka のトピック（default: teamflow.events）
+//	EVENT_BUS_PUBLISH_RETRIES  1 回の配信で失敗した場合に再試行する回数（default 3、それでも失敗したイベントは outbox から後で再送する）
+//	NOTIFY_EMAIL            担当者へのメールの通知の送信方法（log / smtp / sendgrid、default: 無し＝通知しない、USERS_SERVICE_URL が必要）
+//	NOTIFY_FROM             通知メールの送信元（例: TeamFlow <noreply@example.com>、NOTIFY_EMAIL=smtp / sendgrid では必須）
+//	SMTP_ADDR               SMTP サーバーの host:port（例: smtp.example.com:587、NOTIFY_EMAIL=smtp では必須）
+//	SMTP_USERNAME           SMTP の認証のユーザー名（default: 無し＝認証しない）
+//	SMTP_PASSWORD           SMTP の認証のパスワード（default: 無し）
+//	SENDGRID_API_KEY        SendGrid の API キー（NOTIFY_EMAIL=sendgrid では必須）
+//	SENDGRID_URL            SendGrid の API のベース URL（default: https://api.sendgrid.com）
+//	NOTIFY_DUE_SOON_DAYS    期日の何日前から担当者に通知するか（default 1＝前日と当日、1 以上）
+//	NOTIFY_DUE_SOON_INTERVAL  期日が近いタスクを確認する間隔（default 1h）
+func loadConfig(getenv func(string) string) (config, error) {
+	getenv, err := sharedconfig.Load(getenv)
+	if err != nil {
+		return config{}, fmt.Errorf("invalid configuration: %w", err)
+	}
+	p := sharedconfig.NewParser(getenv)
+
+	cfg := config{
+		AppEnv:                 p.Get("APP_ENV"),
+		Port:                   p.Port("PORT", defaultPort),
+		AdminPort:              p.Port("ADMIN_PORT", defaultAdminPort),
+		GRPCPort:               p.Port("GRPC_PORT", 0),
+		ShutdownTimeout:        p.Duration("SHUTDOWN_TIMEOUT", server.DefaultShutdownTimeout),
+		CORS:                   parseCORS(p),
+		OTLPEndpoint:           p.URL("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		JWKSURL:                p.URL("JWKS_URL"),
+		JWTIssuer:              p.String("JWT_ISSUER", defaultJWTIssuer),
+		JWTAudience:            p.String("JWT_AUDIENCE", defaultJWTAudience),
+		ServiceName:            p.String("OTEL_SERVICE_NAME", "tasks"),
+		TraceSampleRatio:       p.Ratio("OTEL_TRACES_SAMPLER_ARG", 1),
+		DBDSN:                  p.Get("DB_DSN"),
+		DBMaxConns:             p.PositiveInt32("DB_MAX_CONNS"),
+		DBMinConns:             p.PositiveInt32("DB_MIN_CONNS"),
+		DBStatementTimeout:     p.Duration("DB_STATEMENT_TIMEOUT", 0),
+		DBQueryTimeout:         p.Duration("DB_QUERY_TIMEOUT", defaultDBQueryTimeout),
+		DBSlowQueryThreshold:   p.NonNegativeDuration("DB_SLOW_QUERY_THRESHOLD", 0),
+		TaskCacheSize:          p.NonNegativeInt("TASK_CACHE_SIZE", defaultTaskCacheSize),
+		TaskCacheTTL:           p.Duration("TASK_CACHE_TTL", defaultTaskCacheTTL),
+		SyncTombstoneRetention: p.Duration("SYNC_TOMBSTONE_RETENTION", usecase.DefaultTombstoneRetention),
+		ArchiveRetentionDays:   p.NonNegativeInt("ARCHIVE_RETENTION_DAYS", 0),
+		ArchivePurgeInterval:   p.Duration("ARCHIVE_PURGE_INTERVAL", defaultArchivePurgeInterval),
+		ProjectsServiceURL:     p.URL("PROJECTS_SERVICE_URL"),
+		UsersServiceURL:        p.URL("USERS_SERVICE_URL"),
+		ServiceAPIKey:          p.Get("SERVICE_API_KEY"),
+
+		EnforceMembership:   p.Bool("ENFORCE_MEMBERSHIP", false),
+		MembershipCacheSize: p.NonNegativeInt("MEMBERSHIP_CACHE_SIZE", defaultMembershipCacheSize),
+		MembershipCacheTTL:  p.Duration("MEMBERSHIP_CACHE_TTL", defaultMembershipCacheTTL),
+	}
+
+	tlsOpts, h2c, err := server.ParseTLSOptions(p.Get)
+	p.Add(err)
+	cfg.TLS, cfg.H2C = tlsOpts, h2c
+
+	cfg.RateLimitTiers, cfg.RateLimitUserTiers = parseRateLimits(p)
+	cfg.MaxInFlightRequests = p.NonNegativeInt("MAX_IN_FLIGHT_REQUESTS", 0)
+	cfg.EventBus = parseEventBus(p, "tasks")
+	parseNotify(p, &cfg)
+
+	if cfg.AdminPort == cfg.Port {
+		p.Errorf("ADMIN_PORT must differ from PORT (%d)", cfg.Port)
+	}
+	if cfg.GRPCPort != 0 && (cfg.GRPCPort == cfg.Port || cfg.GRPCPort == cfg.AdminPort) {
+		p.Errorf("GRPC_PORT must differ from PORT (%d) and ADMIN_PORT (%d)", cfg.Port, cfg.AdminPort)
+	}
+
+	// メンバーかどうかは projects サービスに問い合わせる
+	if cfg.EnforceMembership && cfg.ProjectsServiceURL == "" {
+		p.Required("PROJECTS_SERVICE_URL", "ENFORCE_MEMBERSHIP checks project membership via the projects service")
+	}
+
+	keys, err := serviceauth.ParseKeys(p.Get("SERVICE_API_KEYS"))
+	if err != nil {
+		p.Errorf("SERVICE_API_KEYS is invalid: %w", err)
+	}
+	cfg.ServiceAPIKeys = keys
+	cfg.Operators = authz.ParseOperators(p.Get("ADMIN_USER_IDS"))
+
+	flags, err := featureflag.Load(getenv, defaultFlags)
+	p.Add(err)
+	cfg.Flags = flags
+
+	level, err := logging.ParseLevel(p.Get("LOG_LEVEL"))
+	if err != nil {
+		p.Errorf("LOG_LEVEL is invalid: %w", err)
+	}
+	cfg.LogLevel = level
+
+	// 仕様とのずれは開発・テストで早く見つける。本番はレスポンスをバッファしないよう検証しない
+	cfg.OpenAPIValidation = openapi.ModeStrict
+	if cfg.AppEnv == "production" {
+		cfg.OpenAPIValidation = openapi.ModeOff
+	}
+	if v := p.Get("OPENAPI_VALIDATION"); v != "" {
+		mode, err := openapi.ParseMode(v)
+		if err != nil {
+			p.Errorf("OPENAPI_VALIDATION %w", err)
+		} else {
+			cfg.OpenAPIValidation = mode
+		}
+	}
+
+	uuidPolicy, err := uuidpolicy.Parse(p.Get("UUID_POLICY"))
+	if err != nil {
+		p.Errorf("UUID_POLICY %w", err)
+	}
+	cfg.UUIDPolicy = uuidPolicy
+
+	maxLimit := p.NonNegativeInt("LIST_MAX_LIMIT", domain.DefaultPageLimits.Max)
+	cfg.ListPageLimits = domain.PageLimits{
+		Default: p.NonNegativeInt("LIST_DEFAULT_LIMIT", min(domain.DefaultPageLimits.Default, maxLimit)),
+		Max:     maxLimit,
+	}
+	if err := cfg.ListPageLimits.Validate(); err != nil {
+		p.Errorf("LIST_DEFAULT_LIMIT / LIST_MAX_LIMIT is invalid: %w", err)
+	}
+
+	secret, err := sharedconfig.ResolveCursorSecret(cfg.AppEnv, p.Get("CURSOR_SECRET"))
+	p.Add(err)
+	cfg.CursorSecret = secret
+
+	if cfg.DBMaxConns > 0 && cfg.DBMinConns > cfg.DBMaxConns {
+		p.Errorf("DB_MIN_CONNS (%d) must not exceed DB_MAX_CONNS (%d)", cfg.DBMinConns, cfg.DBMaxConns)
+	}
+
+	// リトライを含めてレスポンスを返せるよう、WriteTimeout より短くする
+	if cfg.DBQueryTimeout >= serverWriteTimeout {
+		p.Errorf("DB_QUERY_TIMEOUT must be a positive duration less than %s, got %q", serverWriteTimeout, p.Get("DB_QUERY_TIMEOUT"))
+		cfg.DBQueryTimeout = defaultDBQueryTimeout
+	}
+
+	cfg.RepoBackend = p.RepoBackend(cfg.DBDSN, cfg.AppEnv == "production")
+
+	cfg.DevSeed = p.Bool("DEV_SEED", false)
+	if cfg.DevSeed && cfg.AppEnv == "production" {
+		p.Errorf("DEV_SEED cannot be used in production")
+	}
+
+	if err := p.Err(); err != nil {
+		return config{}, err
+	}
+	return cfg, nil
+}
+
+// CORS の既定値（ローカルのフロントエンドの開発サーバーを許可する）。
+var (
+	defaultCORSOrigins = []string{"http://localhost:3000", "http://127.0.0.1:3000"}
+	defaultCORSMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+	defaultCORSHeaders = []string{"Content-Type", "Authorization", requestid.Header, authz.ActorHeader, tracing.TraceparentHeader}
+)
+
+// defaultCORSMaxAge はプリフライトの結果をブラウザがキャッシュする時間の既定値。
+const defaultCORSMaxAge = 10 * time.Minute
+
+// parseRateLimits は RATE_LIMIT_TIERS / RATE_LIMIT_USER_TIERS を読み込む。
+func parseRateLimits(p *sharedconfig.Parser) (map[string]int, map[string]string) {
+	tiers, err := ratelimit.ParseTiers(p.Get("RATE_LIMIT_TIERS"))
+	if err != nil {
+		p.Errorf("RATE_LIMIT_TIERS is invalid: %w", err)
+		return nil, nil
+	}
+	users, err := ratelimit.ParseUserTiers(p.Get("RATE_LIMIT_USER_TIERS"), tiers)
+	if err != nil {
+		p.Errorf("RATE_LIMIT_USER_TIERS is invalid: %w", err)
+	}
+	return tiers, users
+}
+
+// parseEventBus は EVENT_BUS / NATS_* / KAFKA_* の環境変数からイベントバスの設定を読み込む。
+func parseEventBus(p *sharedconfig.Parser, clientName string) bus.Config {
+	cfg := bus.Config{
+		NATSURL:       p.Get("NATS_URL"),
+		SubjectPrefix: p.String("NATS_SUBJECT_PREFIX", bus.DefaultSubjectPrefix),
+		KafkaRESTURL:  p.Get("KAFKA_REST_URL"),
+		Topic:         p.String("KAFKA_TOPIC", bus.DefaultTopic),
+		Retries:       p.NonNegativeInt("EVENT_BUS_PUBLISH_RETRIES", bus.DefaultRetries),
+		ClientName:    clientName,
+	}
+	driver, err := bus.ParseDriver(p.Get("EVENT_BUS"))
+	if err != nil {
+		p.Errorf("EVENT_BUS %w", err)
+		return cfg
+	}
+	cfg.Driver = driver
+	switch {
+	case driver == bus.DriverNATS && cfg.NATSURL == "":
+		p.Required("NATS_URL", "EVENT_BUS=nats publishes domain events to a NATS server")
+	case driver == bus.DriverKafka && cfg.KafkaRESTURL == "":
+		p.Required("KAFKA_REST_URL", "EVENT_BUS=kafka publishes domain events via the Kafka REST Proxy")
+	default:
+		if err := cfg.Validate(); err != nil {
+			p.Errorf("EVENT_BUS is misconfigured: %w", err)
+		}
+	}
+	return cfg
+}
+
+// parseNotify は NOTIFY_* / SMTP_* / SENDGRID_* の環境変数からメールの通知の設定を読み込む。
+func parseNotify(p *sharedconfig.Parser, cfg *config) {
+	cfg.NotifyDueSoonDays = p.NonNegativeInt("NOTIFY_DUE_SOON_DAYS", notification.DefaultDueSoonDays)
+	if cfg.NotifyDueSoonDays == 0 {
+		p.Errorf("NOTIFY_DUE_SOON_DAYS must be at least 1")
+		cfg.NotifyDueSoonDays = notification.DefaultDueSoonDays
+	}
+	cfg.NotifyDueSoonInterval = p.Duration("NOTIFY_DUE_SOON_INTERVAL", notification.DefaultDueReminderInterval)
+
+	v := p.Get("NOTIFY_EMAIL")
+	if v == "" {
+		return
+	}
+	driver, err := mail.ParseDriver(v)
+	if err != nil {
+		p.Errorf("NOTIFY_EMAIL %w", err)
+		return
+	}
+	cfg.NotifyEnabled = true
+	cfg.Notify = mail.Config{
+		Driver:         driver,
+		From:           p.Get("NOTIFY_FROM"),
+		SMTPAddr:       p.Get("SMTP_ADDR"),
+		SMTPUsername:   p.Get("SMTP_USERNAME"),
+		SMTPPassword:   p.Get("SMTP_PASSWORD"),
+		SendGridAPIKey: p.Get("SENDGRID_API_KEY"),
+		SendGridURL:    p.URL("SENDGRID_URL"),
+	}
+	// 宛先のメールアドレスと通知の設定は users サービスに問い合わせる
+	if cfg.UsersServiceURL == "" {
+		p.Required("USERS_SERVICE_URL", "NOTIFY_EMAIL looks up assignees' email addresses and notification preferences via the users service")
+	}
+	switch {
+	case driver != mail.DriverLog && cfg.Notify.From == "":
+		p.Required("NOTIFY_FROM", "NOTIFY_EMAIL="+string(driver)+" needs a sender address")
+	case driver == mail.DriverSMTP && cfg.Notify.SMTPAddr == "":
+		p.Required("SMTP_ADDR", "NOTIFY_EMAIL=smtp sends notifications via an SMTP server")
+	case driver == mail.DriverSendGrid && cfg.Notify.SendGridAPIKey == "":
+		p.Required("SENDGRID_API_KEY", "NOTIFY_EMAIL=sendgrid sends notifications via the SendGrid API")
+	default:
+		if err := cfg.Notify.Validate(); err != nil {
+			p.Errorf("NOTIFY_EMAIL is misconfigured: %w", err)
+		}
+	}
+}
+
+// parseCORS は CORS_* の環境変数から CORS の設定を読み込む。
+func parseCORS(p *sharedconfig.Parser) cors.Options {
+	opts := cors.Options{
+		AllowedOrigins:   defaultCORSOrigins,
+		AllowedMethods:   defaultCORSMethods,
+		AllowedHeaders:   defaultCORSHeaders,
+		ExposedHeaders:   []string{requestid.Header, apiversion.Header, ratelimit.HeaderLimit, ratelimit.HeaderRemaining, ratelimit.HeaderReset, "Retry-After"},
+		AllowCredentials: p.Bool("CORS_ALLOW_CREDENTIALS", true),
+		MaxAge:           p.NonNegativeDuration("CORS_MAX_AGE", defaultCORSMaxAge),
+	}
+	if v := p.Get("CORS_ALLOWED_ORIGINS"); v != "" {
+		opts.AllowedOrigins = cors.SplitList(v)
+	}
+	if v := p.Get("CORS_ALLOWED_METHODS"); v != "" {
+		opts.AllowedMethods = cors.SplitList(v)
+	}
+	if v := p.Get("CORS_ALLOWED_HEADERS"); v != "" {
+		opts.AllowedHeaders = cors.SplitList(v)
+	}
+	if err := opts.Validate(); err != nil {
+		p.Errorf("CORS_ALLOWED_ORIGINS is invalid: %w", err)
+	}
+	return opts
+}
+
+// poolConfig は DB 設定を反映した pgxpool.Config を返す。
+func (c config) poolConfig() (*pgxpool.Config, error) {
+	pc, err := pgxpool.ParseConfig(c.DBDSN)
+	if err != nil {
+		return nil, err
+	}
+	if c.DBMaxConns > 0 {
+		pc.MaxConns = c.DBMaxConns
+	}
+	if c.DBMinConns > 0 {
+		pc.MinConns = c.DBMinConns
+	}
+	if c.DBStatementTimeout > 0 {
+		pc.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(c.DBStatementTimeout.Milliseconds(), 10)
+	}
+	return pc, nil
+}
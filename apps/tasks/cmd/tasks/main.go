@@ -1,168 +1,671 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
-	"io"
-	"log"
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
-	"strings"
+	"os/signal"
+	"syscall"
 	"time"
+	_ "time/tzdata" // プロジェクトのタイムゾーン（IANA のタイムゾーン名）をタイムゾーンのデータが無い環境でも扱えるようにする
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/multitracer"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+
+	"teamflow-shared/audit"
+	"teamflow-shared/bus"
+	"teamflow-shared/client"
+	"teamflow-shared/clock"
+	sharedconfig "teamflow-shared/config"
+	"teamflow-shared/featureflag"
+	"teamflow-shared/health"
+	"teamflow-shared/jwt"
+	"teamflow-shared/loadshed"
+	"teamflow-shared/logging"
+	"teamflow-shared/mail"
+	"teamflow-shared/metrics"
+	"teamflow-shared/migrate"
+	"teamflow-shared/openapi"
+	"teamflow-shared/outbox"
+	"teamflow-shared/pat"
+	"teamflow-shared/ratelimit"
+	"teamflow-shared/requestid"
+	"teamflow-shared/server"
+	"teamflow-shared/serviceauth"
+	"teamflow-shared/tracing"
+	"teamflow-shared/tracing/pgxtrace"
+	"teamflow-shared/workspace"
+
+	"teamflow-tasks/internal/broadcast"
+	"teamflow-tasks/internal/infrastructure/migration"
+	projectinfra "teamflow-tasks/internal/infrastructure/project"
 	infra "teamflow-tasks/internal/infrastructure/task"
+	userinfra "teamflow-tasks/internal/infrastructure/user"
+	grpchandler "teamflow-tasks/internal/interface/grpc"
 	httphandler "teamflow-tasks/internal/interface/http"
+	"teamflow-tasks/internal/usecase/notification"
 	usecase "teamflow-tasks/internal/usecase/task"
 )
 
 func main() {
-	// インメモリのタスクリポジトリ
-	repo := infra.NewMemoryTaskRepository()
+	// ログは JSON 形式で標準出力に出す。レベルは設定を読み込んでから LOG_LEVEL に合わせる
+	var logLevel slog.LevelVar
+	slog.SetDefault(logging.New(os.Stdout, &logLevel))
+
+	// tasks migrate <up|down [steps]|version>
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrate.Run(context.Background(), os.Getenv("DB_DSN"), migration.Config, "tasks", os.Args[2:]); err != nil {
+			fatal("migration failed", err)
+		}
+		return
+	}
+
+	cfg, err := loadConfig(os.Getenv)
+	if err != nil {
+		fatal("failed to load configuration", err)
+	}
+	logLevel.Set(cfg.LogLevel)
+
+	// トレーシング（OTEL_EXPORTER_OTLP_ENDPOINT が無ければ記録しない）
+	tracer, err := newTracer(cfg)
+	if err != nil {
+		fatal("failed to initialize tracing", err)
+	}
+
+	// タスク変更イベントの配信（SSE）
+	broker := broadcast.NewBroker()
+
+	// readiness で確認する依存先（リポジトリの生成時に登録する）
+	checks := health.NewChecker(health.DefaultTimeout)
+
+	// タスクリポジトリ（REPO_BACKEND=postgres なら PostgreSQL、memory ならインメモリ）
+	repo, txManager, auditRecorder, eventOutbox, dueTasks, changeFeed, archivedTasks, closeRepo, err := newTaskRepository(context.Background(), cfg, broker.Publish, tracer, checks)
+	if err != nil {
+		fatal("failed to initialize repository", err)
+	}
 
 	// ユースケース
 	createUC := &usecase.CreateTaskUsecase{
-		Repo: repo,
+		Repo:       repo,
+		Tx:         txManager,
+		Audit:      auditRecorder,
+		Events:     eventOutbox,
+		UUIDPolicy: cfg.UUIDPolicy,
+	}
+	// デモデータ（DEV_SEED）。projects サービスの既定値・メンバーチェックを通さずに作成する（projects の起動を待たないように）
+	if cfg.DevSeed {
+		seedUC := &usecase.CreateTaskUsecase{Repo: repo, Tx: txManager, Audit: auditRecorder, Events: eventOutbox, UUIDPolicy: cfg.UUIDPolicy}
+		if err := seedDemoTasks(context.Background(), seedUC, repo, time.Now()); err != nil {
+			closeRepo()
+			fatal("failed to load demo data", err)
+		}
 	}
 	listUC := &usecase.ListTasksByProjectUsecase{
 		Repo: repo,
 	}
 	updateUC := &usecase.UpdateTaskUsecase{
+		Repo:       repo,
+		Tx:         txManager,
+		Clock:      clock.System,
+		Audit:      auditRecorder,
+		Events:     eventOutbox,
+		UUIDPolicy: cfg.UUIDPolicy,
+	}
+	statsUC := &usecase.GetProjectStatsUsecase{
+		Repo: repo,
+	}
+	getByNumberUC := &usecase.GetTaskByNumberUsecase{
+		Repo: repo,
+	}
+	calendarUC := &usecase.ListCalendarTasksUsecase{
 		Repo: repo,
 	}
+	exportUC := &usecase.ExportTasksUsecase{
+		Repo: repo,
+	}
+	syncUC := &usecase.SyncTasksUsecase{
+		Feed:               changeFeed,
+		Secret:             cfg.CursorSecret,
+		TombstoneRetention: cfg.SyncTombstoneRetention,
+	}
+	purgeUC := &usecase.PurgeArchivedTasksUsecase{
+		Purger:    archivedTasks,
+		Tx:        txManager,
+		Operators: cfg.Operators,
+		Audit:     auditRecorder,
+		Events:    eventOutbox,
+	}
+	cascadeUC := &usecase.CascadeProjectTasksUsecase{
+		Repo:   repo,
+		Tx:     txManager,
+		Audit:  auditRecorder,
+		Events: eventOutbox,
+	}
+	carryOverUC := &usecase.CarryOverSprintTasksUsecase{
+		Repo:   repo,
+		Tx:     txManager,
+		Audit:  auditRecorder,
+		Events: eventOutbox,
+	}
+	createBatchUC := &usecase.CreateTasksUsecase{
+		Create: createUC,
+		Tx:     txManager,
+	}
+	// projects サービスが指定されていれば、プロジェクト設定の既定値、担当者のメンバーチェック、
+	// マイルストーン・スプリント・エピックの存在チェック、ラベルが定義済みかのチェック、フィールドのロックの確認、
+	// 一覧・番号での取得・イベント購読でのプロジェクトの閲覧権限のチェックと、期日の判定に使うプロジェクトのタイムゾーンを使う
+	var access usecase.ProjectAccessChecker
+	var timezones usecase.ProjectTimezoneProvider
+	if cfg.ProjectsServiceURL != "" {
+		projectsClient := projectinfra.NewClient(cfg.ProjectsServiceURL, &http.Client{
+			Timeout:   3 * time.Second,
+			Transport: tracing.NewTransport(tracer, &requestid.Transport{}),
+		})
+		// メンバーかどうかの問い合わせ（担当者のチェックとメンバーシップの確認）は MEMBERSHIP_CACHE_SIZE 件までキャッシュする
+		var members usecase.MembershipChecker = projectsClient
+		if cfg.MembershipCacheSize > 0 {
+			members = projectinfra.NewCachingMembershipChecker(projectsClient, cfg.MembershipCacheSize, cfg.MembershipCacheTTL)
+		}
+		access = projectsClient
+		listUC.Access = projectsClient
+		getByNumberUC.Access = projectsClient
+		calendarUC.Access = projectsClient
+		syncUC.Access = projectsClient
+		exportUC.Access = projectsClient
+		// ENFORCE_MEMBERSHIP なら、公開プロジェクトも含めてタスクの閲覧・変更をメンバーに限る（非メンバーは 404）
+		if cfg.EnforceMembership {
+			policy := &usecase.MembershipPolicy{Members: members}
+			access = policy
+			listUC.Access = policy
+			getByNumberUC.Access = policy
+			calendarUC.Access = policy
+			syncUC.Access = policy
+			exportUC.Access = policy
+			createUC.Authorizer = policy
+			updateUC.Authorizer = policy
+			slog.Info("enforcing project membership for tasks", "cache_size", cfg.MembershipCacheSize, "cache_ttl", cfg.MembershipCacheTTL)
+		}
+		createUC.Defaults = projectsClient
+		createUC.Members = members
+		updateUC.Members = members
+		createUC.Milestones = projectsClient
+		updateUC.Milestones = projectsClient
+		createUC.Sprints = projectsClient
+		updateUC.Sprints = projectsClient
+		createUC.Epics = projectsClient
+		updateUC.Epics = projectsClient
+		createUC.Labels = projectsClient
+		updateUC.Labels = projectsClient
+		// プロジェクト設定でロックされたフィールド（fieldLocks）は許可されたロールの操作者だけが変更できる
+		updateUC.FieldLocks = projectsClient
+		// 期限切れのタスク数・カレンダー・期日の通知はプロジェクト設定のタイムゾーン（未設定の場合は UTC）で日付を判定する
+		timezones = projectsClient
+		statsUC.Timezones = projectsClient
+		calendarUC.Timezones = projectsClient
+		slog.Info("using projects service", "url", cfg.ProjectsServiceURL)
+	}
+	// users サービスが指定されていれば、担当者のユーザーの存在チェックと一覧での担当者名の表示、
+	// 個人用アクセストークン（Authorization: Bearer tfp_...）の検証に使う。
+	// users:lookup と tokens:introspect はサービス間専用のエンドポイントのため SERVICE_API_KEY で認証される
+	var tokenVerifier pat.Verifier
+	var recipients notification.RecipientDirectory
+	if cfg.UsersServiceURL != "" {
+		usersHTTPClient := &http.Client{
+			Timeout: 3 * time.Second,
+			Transport: tracing.NewTransport(tracer, &requestid.Transport{
+				Base: &serviceauth.Transport{Key: cfg.ServiceAPIKey},
+			}),
+		}
+		usersClient := userinfra.NewClient(cfg.UsersServiceURL, usersHTTPClient)
+		createUC.Users = usersClient
+		updateUC.Users = usersClient
+		listUC.Users = usersClient
+		recipients = usersClient
+		tokenVerifier = pat.NewIntrospectionVerifier(client.New(cfg.UsersServiceURL, usersHTTPClient))
+		slog.Info("using users service", "url", cfg.UsersServiceURL)
+	}
+
+	// NOTIFY_EMAIL が設定されていれば、担当者にタスクの割り当てと期日が近いことをメールで通知する。
+	// 宛先のメールアドレスと通知の設定（users サービスの notification-preferences）は users サービスから取得する
+	notifier := &notification.Notifier{}
+	var dueReminder *notification.DueReminder
+	if cfg.NotifyEnabled {
+		sender, err := mail.New(cfg.Notify)
+		if err != nil {
+			fatal("failed to initialize email sender", err)
+		}
+		notifier = &notification.Notifier{Recipients: recipients, Sender: sender}
+		createUC.Notifier = notifier
+		updateUC.Notifier = notifier
+		dueReminder = &notification.DueReminder{
+			Tasks:      dueTasks,
+			Recipients: recipients,
+			Sender:     sender,
+			Timezones:  timezones,
+			Days:       cfg.NotifyDueSoonDays,
+			Interval:   cfg.NotifyDueSoonInterval,
+			Clock:      clock.System,
+		}
+		slog.Info("sending email notifications", "driver", cfg.Notify.Driver, "due_soon_days", cfg.NotifyDueSoonDays)
+	}
+	cursorSecret := cfg.CursorSecret
+
+	// サービス間専用のエンドポイント（projects サービスからの集計・一括作成・一括操作・持ち越し）は
+	// SERVICE_API_KEYS が設定されていれば X-Service-Key で認証する
+	serviceAuth := serviceauth.NewAuthenticator(cfg.ServiceAPIKeys, clock.System)
+	if serviceAuth.Enabled() {
+		slog.Info("requiring service API keys for service endpoints", "keys", len(cfg.ServiceAPIKeys))
+	}
 
-	// cursor secret（環境変数から取得、環境に応じて検証）
-	appEnv := os.Getenv("APP_ENV")
-	rawSecret := os.Getenv("CURSOR_SECRET")
+	// graceful shutdown の開始を SSE・gRPC の WatchTasks に知らせ、再接続を促してからストリームを閉じさせる
+	// （drain の期間を待たずに停止でき、クライアントは書き込みの途中で切断されない）
+	drain := server.NewDrain()
 
-	cursorSecret, err := resolveCursorSecret(appEnv, rawSecret)
+	// HTTP ハンドラ（API はすべて /api 配下。パスの振り分けは Router が行う）
+	router := httphandler.NewRouter(httphandler.Handlers{
+		Create:         httphandler.NewCreateTaskHandler(createUC, clock.System),
+		List:           httphandler.NewListTaskHandler(listUC, clock.System, cursorSecret, httphandler.WithListPageLimits(cfg.ListPageLimits)),
+		Update:         httphandler.NewUpdateTaskHandler(updateUC),
+		Events:         featureflag.Require(cfg.Flags, httphandler.FlagTaskEvents, httphandler.NewTaskEventsHandler(broker, access, httphandler.WithEventsShutdown(drain.Done()))),
+		BatchCreate:    serviceAuth.Require(httphandler.NewBatchCreateTasksHandler(createBatchUC, clock.System)),
+		Stats:          serviceAuth.Require(httphandler.NewProjectStatsHandler(statsUC, clock.System)),
+		BatchStats:     serviceAuth.Require(httphandler.NewBatchProjectStatsHandler(statsUC, clock.System)),
+		MilestoneStats: serviceAuth.Require(httphandler.NewMilestoneStatsHandler(statsUC)),
+		EpicStats:      serviceAuth.Require(httphandler.NewEpicStatsHandler(statsUC)),
+		LabelStats:     serviceAuth.Require(httphandler.NewLabelStatsHandler(statsUC)),
+		GetByNumber:    httphandler.NewGetTaskByNumberHandler(getByNumberUC),
+		Cascade:        serviceAuth.Require(httphandler.NewCascadeProjectTasksHandler(cascadeUC, clock.System)),
+		CarryOver:      serviceAuth.Require(httphandler.NewCarryOverSprintTasksHandler(carryOverUC, clock.System)),
+		Calendar:       httphandler.NewCalendarHandler(calendarUC, clock.System),
+		Sync:           httphandler.NewSyncTasksHandler(syncUC, clock.System),
+		Export:         httphandler.NewExportTasksHandler(exportUC),
+		InspectCursor: httphandler.NewInspectCursorHandler(&usecase.InspectCursorUsecase{
+			Operators: cfg.Operators,
+			Secret:    cursorSecret,
+		}, clock.System),
+		Purge: httphandler.NewPurgeArchivedTasksHandler(purgeUC, clock.System),
+	})
+
+	// ヘルスチェック（/livez, /healthz, /readyz）は server.NewMux が登録する
+	mux := server.NewMux(checks)
+	// Authorization: Bearer の JWT（auth サービスが OIDC のログインで発行する）を検証する Verifier（JWKS_URL が空なら nil）
+	jwtVerifier := jwt.NewRemoteVerifier(cfg.JWKSURL, cfg.JWTIssuer, cfg.JWTAudience)
+	// API はワークスペース（X-Workspace-ID。jwt / pat の Middleware がトークンから、サービス間の呼び出しは呼び出し元が設定する）ごとに分離する。
+	// 認証（JWT・PAT）を設定していない場合は、X-User-ID と同じくヘッダをそのまま使う
+	mux.Handle(httphandler.APIPrefix+"/", workspace.Middleware(jwtVerifier != nil || tokenVerifier != nil, serviceAuth.Authenticated, router))
+
+	// OpenAPI の仕様（/api/openapi.json）。OPENAPI_VALIDATION が off 以外なら API のリクエスト・レスポンスを仕様で検証する
+	handler, err := withOpenAPI(mux, cfg.OpenAPIValidation)
+	if err != nil {
+		closeRepo()
+		fatal("failed to load OpenAPI spec", err)
+	}
+	if cfg.OpenAPIValidation != openapi.ModeOff {
+		slog.Info("validating requests and responses against the OpenAPI spec", "mode", cfg.OpenAPIValidation)
+	}
+	// 操作者・個人用アクセストークンごとのレート制限（RATE_LIMIT_TIERS）。サービス API キーでの呼び出しはキーごとに制限するため外す
+	handler = ratelimit.Middleware(ratelimit.Policy{
+		Tiers:     cfg.RateLimitTiers,
+		UserTiers: cfg.RateLimitUserTiers,
+		Skip:      serviceAuth.Authenticated,
+		Clock:     clock.System,
+	}, handler)
+	// Authorization: Bearer の個人用アクセストークンを検証し、持ち主を操作者にする（レート制限・仕様の検証より前に認証する）。
+	// トークンの無いリクエストの X-User-ID / X-Workspace-ID は、JWT を検証した場合とサービス API キーで認証した呼び出しの場合だけ使う
+	handler = pat.Middleware(tokenVerifier, serviceAuth.Authenticated, handler)
+	// カレンダーのフィード（tasks.ics）はカレンダーアプリが Authorization を送れないため、クエリの token の PAT も受け付ける
+	handler = pat.QueryToken(httphandler.IsCalendarPath, handler)
+	// Authorization: Bearer の JWT（auth サービスが OIDC のログインで発行する）を JWKS_URL の公開鍵で検証し、sub を操作者にする。
+	// トークンの無いリクエストの X-User-ID / X-Workspace-ID は、サービス API キーで認証した呼び出しの場合だけ使う
+	handler = jwt.Middleware(jwtVerifier, serviceAuth.Authenticated, handler)
+	// 処理中のリクエスト数の上限（MAX_IN_FLIGHT_REQUESTS）。DB のプールが埋まる前に超えた分を 503 で断る（認証よりも前に断る）。
+	// SSE（タスクのイベント）は接続を保ち続けるため数えない
+	shedder := loadshed.NewLimiter(loadshed.Policy{
+		MaxInFlight: cfg.MaxInFlightRequests,
+		Skip:        func(r *http.Request) bool { return httphandler.IsEventsPath(r.URL.Path) },
+	})
+	handler = shedder.Middleware(handler)
+	metrics.RegisterLoadShedMetrics(prometheus.DefaultRegisterer, "tasks", shedder)
+	if cfg.MaxInFlightRequests > 0 {
+		slog.Info("shedding requests above the in-flight limit", "max_in_flight", cfg.MaxInFlightRequests)
+	}
+
+	// メトリクス（Prometheus テキスト形式）は API と別の管理用ポートで公開する
+	adminMux := http.NewServeMux()
+	adminMux.Handle("/metrics", promhttp.Handler())
+	stopAdmin, err := server.StartAdmin(cfg.adminAddr(), adminMux)
 	if err != nil {
-		log.Fatal(err)
+		closeRepo()
+		fatal("failed to start admin server", err)
 	}
 
-	// HTTP ハンドラ
-	createHandler := httphandler.NewCreateTaskHandler(createUC, time.Now)
-	listHandler := httphandler.NewListTaskHandler(listUC, time.Now, cursorSecret)
-	updateHandler := httphandler.NewUpdateTaskHandler(updateUC)
+	// TLS_CERT_FILE・TLS_AUTOCERT_HOSTS を設定した場合は TLS で待ち受ける（証明書のファイルは起動時に読み込む）
+	tlsConfig, err := cfg.TLS.Config()
+	if err != nil {
+		fatal("failed to configure TLS (check TLS_CERT_FILE / TLS_KEY_FILE)", err)
+	}
 
-	// /api/tasks の統合ハンドラ（POST と GET の両方を処理）
-	tasksHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodPost:
-			createHandler.ServeHTTP(w, r)
-		case http.MethodGet:
-			listHandler.ServeHTTP(w, r)
-		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
-		}
+	// リクエスト ID・トレース・ログ・メトリクス・セキュリティヘッダ・panic の回復・CORS は server.New が順に適用する
+	srv := server.New(handler, server.Options{
+		Addr:         cfg.addr(),
+		Tracer:       tracer,
+		Metrics:      metrics.NewHTTPMetrics(prometheus.DefaultRegisterer, "tasks", httphandler.RouteLabel).Middleware,
+		CORS:         cfg.CORS,
+		Messages:     httphandler.Messages,
+		WriteTimeout: serverWriteTimeout,
+		Drain:        drain,
+		TLSConfig:    tlsConfig,
+		H2C:          cfg.H2C,
 	})
+	slog.Info("tasks service listening", "addr", srv.Addr, "tls", tlsConfig != nil, "h2c", cfg.H2C, "feature_flags", cfg.Flags.Names())
 
-	// /api/projects/{projectId}/tasks の統合ハンドラ（GET と POST の両方を処理）
-	projectTasksHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// パスから projectId を抽出: /api/projects/{projectId}/tasks
-		path := strings.TrimPrefix(r.URL.Path, "/api/projects/")
-		parts := strings.Split(path, "/")
+	// gRPC の API（GRPC_PORT。サービス間の呼び出し用）は HTTP と同じユースケースを呼び出す。
+	// サービス API キー（SERVICE_API_KEYS）はサービス間専用のエンドポイントと同じものを受け付ける
+	stopGRPC := func() {}
+	if cfg.GRPCPort != 0 {
+		grpcSrv := grpchandler.NewServer(&grpchandler.TaskService{
+			Create:       createUC,
+			List:         listUC,
+			Update:       updateUC,
+			GetByNumber:  getByNumberUC,
+			Broker:       broker,
+			Access:       access,
+			Shutdown:     drain.Done(),
+			Clock:        clock.System,
+			CursorSecret: cursorSecret,
+			PageLimits:   cfg.ListPageLimits,
+		}, serviceAuth)
+		stopGRPC, err = startGRPC(cfg.grpcAddr(), grpcSrv, cfg.ShutdownTimeout)
+		if err != nil {
+			stopAdmin()
+			closeRepo()
+			fatal("failed to start grpc server", err)
+		}
+	}
 
-		if len(parts) < 2 || parts[1] != "tasks" {
-			w.WriteHeader(http.StatusNotFound)
-			return
+	// outbox に記録したドメインイベントをイベントバス（EVENT_BUS。既定はログに出力するだけ）に配信する
+	publisher, closePublisher, err := bus.New(cfg.EventBus)
+	if err != nil {
+		fatal("failed to initialize event bus", err)
+	}
+	slog.Info("relaying domain events", "event_bus", cfg.EventBus.Driver)
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		(&outbox.Relay{Store: eventOutbox, Publisher: publisher, Clock: clock.System}).Run(relayCtx)
+	}()
+	// 期日が近いタスクの担当者への通知（NOTIFY_EMAIL が無ければ起動しない）
+	stopReminder := func() {}
+	if dueReminder != nil {
+		reminderCtx, cancel := context.WithCancel(context.Background())
+		reminderDone := make(chan struct{})
+		go func() {
+			defer close(reminderDone)
+			dueReminder.Run(reminderCtx)
+		}()
+		stopReminder = func() {
+			cancel()
+			<-reminderDone
 		}
+	}
 
-		projectID := parts[0]
+	// 保持期間（SYNC_TOMBSTONE_RETENTION）を過ぎた差分同期の削除の記録を定期的に消す
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	purgeDone := make(chan struct{})
+	go func() {
+		defer close(purgeDone)
+		runTombstonePurge(purgeCtx, syncUC, tombstonePurgeInterval)
+	}()
 
-		switch r.Method {
-		case http.MethodGet:
-			// GET /api/projects/{projectId}/tasks
-			listHandler.ServeHTTP(w, r)
-		case http.MethodPost:
-			// POST /api/projects/{projectId}/tasks
-			// パスから取得した projectId を body に追加して CreateTaskHandler に渡す
-			body, err := io.ReadAll(r.Body)
-			if err != nil {
-				w.WriteHeader(http.StatusBadRequest)
-				return
-			}
-			r.Body.Close()
+	// ARCHIVE_RETENTION_DAYS を過ぎたアーカイブしたタスクを定期的に物理削除する
+	archivePurgeDone := make(chan struct{})
+	go func() {
+		defer close(archivePurgeDone)
+		if cfg.ArchiveRetentionDays > 0 {
+			slog.Info("purging archived tasks after the retention period", "retention_days", cfg.ArchiveRetentionDays, "interval", cfg.ArchivePurgeInterval.String())
+			runArchivePurge(purgeCtx, purgeUC, cfg.ArchiveRetentionDays, cfg.ArchivePurgeInterval)
+		}
+	}()
 
-			// JSON を map にデコードして projectId を追加
-			var reqMap map[string]interface{}
-			if err := json.Unmarshal(body, &reqMap); err != nil {
-				w.WriteHeader(http.StatusBadRequest)
-				return
-			}
+	// SIGINT / SIGTERM で graceful shutdown し、処理中のリクエストが終わってからリレーと期日の通知・削除の記録の掃除を止め、
+	// 送信中のメールを待ってからプールを閉じる
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err = server.Run(ctx, srv, cfg.ShutdownTimeout)
+	stopGRPC()
+	stopAdmin()
+	stopRelay()
+	<-relayDone
+	stopReminder()
+	stopPurge()
+	<-purgeDone
+	<-archivePurgeDone
+	notifier.Wait()
+	closePublisher()
+	closeRepo()
+	shutdownTracer(tracer)
+	if err != nil {
+		fatal("http server stopped", err)
+	}
+	slog.Info("tasks service stopped")
+}
 
-			// projectId を追加（上書き）
-			reqMap["projectId"] = projectID
+// tombstonePurgeInterval は差分同期の削除の記録を掃除する間隔。
+const tombstonePurgeInterval = time.Hour
+
+// runTombstonePurge は ctx が終わるまで interval ごとに保持期間を過ぎた削除の記録を消す。
+// 複数のレプリカで同時に実行しても、同じ記録を消すだけで結果は変わらない。
+func runTombstonePurge(ctx context.Context, uc *usecase.SyncTasksUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		n, err := uc.PurgeTombstones(ctx, clock.System.Now())
+		switch {
+		case err != nil && ctx.Err() == nil:
+			slog.ErrorContext(ctx, "failed to purge task tombstones", "error", err)
+		case n > 0:
+			slog.InfoContext(ctx, "purged task tombstones", "count", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
 
-			// 新しい body を作成
-			newBody, err := json.Marshal(reqMap)
+// runArchivePurge は ctx が終わるまで interval ごとに、アーカイブしてから days 日を過ぎたタスクを物理削除する。
+// 1 回の確認で対象が無くなるまで BatchSize ずつ削除する。操作者は空（監査ログには保持期間のジョブとして残る）。
+// 複数のレプリカで同時に実行しても、同じタスクを二重に削除することはない（削除できたものだけを記録する）。
+func runArchivePurge(ctx context.Context, uc *usecase.PurgeArchivedTasksUsecase, days int, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		purged := 0
+		for ctx.Err() == nil {
+			out, err := uc.Purge(ctx, usecase.PurgeArchivedTasksInput{OlderThanDays: days, Now: clock.System.Now()})
 			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				return
+				if ctx.Err() == nil {
+					slog.ErrorContext(ctx, "failed to purge archived tasks", "error", err)
+				}
+				break
+			}
+			purged += len(out.Tasks)
+			if !out.HasMore {
+				break
 			}
+		}
+		if purged > 0 {
+			slog.InfoContext(ctx, "purged archived tasks", "count", purged, "retention_days", days)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
 
-			// リクエストボディを差し替え
-			r.Body = io.NopCloser(bytes.NewReader(newBody))
-			r.ContentLength = int64(len(newBody))
+// outboxStore はユースケースがドメインイベントを記録し、リレーが読み出す outbox。
+type outboxStore interface {
+	outbox.Writer
+	outbox.Store
+}
 
-			createHandler.ServeHTTP(w, r)
-		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
-		}
-	})
+// newTaskRepository は設定に応じて TaskRepository と TxManager、監査ログの Recorder、ドメインイベントの outbox、
+// 期日が近いタスクの取り出し（通知済みの記録）を生成する。
+// SQL の場合は起動時に疎通確認を行い、戻り値の close でプールを閉じる。
+// 監査ログは SQL の場合のみ audit_log テーブルに記録する（インメモリの場合は nil で記録しない）。
+// outbox は SQL の場合は outbox テーブル、インメモリの場合はこのプロセスのメモリに記録する。
+// 期日の通知済みの記録も同様に、SQL の場合は task_due_reminders テーブル、インメモリの場合はこのプロセスのメモリに記録する。
+// 差分同期の変更は、SQL の場合は tasks.change_seq と task_tombstones テーブル、インメモリの場合はリポジトリが記録したものから取り出す。
+// アーカイブしたタスクの物理削除はワークスペースをまたいで行うため、キャッシュなどを挟まないリポジトリを返す
+// （SQL の場合のキャッシュは削除の NOTIFY で破棄される）。
+// タスクの変更は publish に渡す（SQL は NOTIFY 経由で全レプリカ、インメモリはこのプロセスのみ）。
+// tracer が nil でなければ問い合わせごとのスパンを記録する。DB_SLOW_QUERY_THRESHOLD を超えた問い合わせはログに出力する。プールへの疎通確認を checks に登録する。
+func newTaskRepository(ctx context.Context, cfg config, publish func(broadcast.Event), tracer *tracing.Tracer, checks *health.Checker) (usecase.TaskRepository, usecase.TxManager, audit.Recorder, outboxStore, notification.DueTaskClaimer, usecase.TaskChangeFeed, usecase.ArchivedTaskPurger, func(), error) {
+	if !cfg.useSQL() {
+		slog.Info("using in-memory task repository")
+		mem := infra.NewMemoryTaskRepository()
+		mem.Clock = clock.System
+		repo := infra.NewNotifyingTaskRepository(mem, publish)
+		return repo, infra.NoopTxManager{}, nil, outbox.NewMemoryStore(), infra.NewMemoryDueReminders(mem), infra.NewMemoryTaskChanges(mem), mem, func() {}, nil
+	}
 
-	mux := http.NewServeMux()
+	poolCfg, err := cfg.poolConfig()
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("REPO_BACKEND=postgres: DB_DSN is invalid: %w", err)
+	}
+	// 接続の取得の待ち時間と遅い問い合わせは常に記録し、トレースは有効な場合のみ記録する
+	queryTracers := []pgx.QueryTracer{infra.NewQueryObserver(cfg.DBSlowQueryThreshold)}
+	if tracer != nil {
+		queryTracers = append(queryTracers, pgxtrace.NewQueryTracer(tracer.Provider()))
+	}
+	poolCfg.ConnConfig.Tracer = multitracer.New(queryTracers...)
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("failed to create database pool: %w", err)
+	}
 
-	// API はすべて /api 配下
-	// POST /api/tasks と GET /api/tasks?projectId=xxx (旧API)
-	mux.Handle("/api/tasks", tasksHandler)
-	// GET /api/projects/{projectId}/tasks と POST /api/projects/{projectId}/tasks (OpenAPI準拠)
-	mux.Handle("/api/projects/", projectTasksHandler)
-	// PATCH /api/tasks/{id}
-	mux.Handle("/api/tasks/", updateHandler)
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := pool.Ping(pingCtx); err != nil {
+		pool.Close()
+		return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("REPO_BACKEND=postgres: failed to connect database %s (check DB_DSN and that the database is reachable): %w", sharedconfig.DBTarget(poolCfg), err)
+	}
 
-	// ヘルスチェック
-	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ok"))
-	})
+	slog.Info("using postgres task repository", "db", sharedconfig.DBTarget(poolCfg), "max_conns", poolCfg.MaxConns, "slow_query_threshold", cfg.DBSlowQueryThreshold.String())
+	metrics.RegisterPoolMetrics(prometheus.DefaultRegisterer, "tasks", pool)
+	checks.Add("postgres", pool.Ping)
+	// 一時的なエラー（シリアライズ失敗・接続断など）はリポジトリ層でリトライする。
+	// タイムアウトは試行ごとに適用し、メトリクスはリトライを含めた 1 回の呼び出し単位で計測する
+	// アーカイブしたタスクの物理削除（管理用 API・保持期間のジョブ）も同じデコレータを通す
+	decorated := infra.NewMeteredTaskRepository(
+		infra.NewRetryingTaskRepository(
+			infra.NewTimeoutTaskRepository(infra.NewSQLTaskRepository(pool), cfg.DBQueryTimeout),
+			infra.DefaultRetryPolicy,
+		),
+	)
+	var repo usecase.TaskRepository = decorated
 
-	// CORS ミドルウェア
-	corsHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		allowedOrigins := map[string]bool{
-			"http://localhost:3000": true,
-			"http://127.0.0.1:3000": true,
+	// タスク詳細のキャッシュ。他のレプリカでの更新は NOTIFY を受けて破棄する
+	if cfg.TaskCacheSize > 0 {
+		cache := infra.NewCachingTaskRepository(repo, cfg.TaskCacheSize, cfg.TaskCacheTTL)
+		repo = cache
+		next := publish
+		publish = func(e broadcast.Event) {
+			cache.Invalidate(e.TaskID)
+			next(e)
 		}
+	}
 
-		origin := r.Header.Get("Origin")
-		if allowedOrigins[origin] {
-			w.Header().Set("Access-Control-Allow-Origin", origin)
-			w.Header().Set("Access-Control-Allow-Credentials", "true")
-			w.Header().Set("Vary", "Origin")
-		}
+	// 他のレプリカを含むタスク変更を LISTEN し、SSE の購読者に配信する
+	listenCtx, stopListener := context.WithCancel(context.Background())
+	go infra.NewTaskChangeListener(poolCfg.ConnConfig, publish).Run(listenCtx)
 
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PATCH, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	closeRepo := func() {
+		stopListener()
+		pool.Close()
+	}
+	return repo, infra.NewPgxTxManager(pool), audit.NewSQLRecorder(pool, infra.TxFromContext), outbox.NewSQLOutbox(pool, infra.TxFromContext), infra.NewSQLDueReminders(pool), infra.NewSQLTaskChanges(pool), decorated, closeRepo, nil
+}
 
-		if r.Method == http.MethodOptions {
-			w.WriteHeader(http.StatusNoContent)
-			return
+// startGRPC は addr で gRPC サーバーを起動する。
+// 戻り値の stop は処理中の呼び出しを timeout まで待ってから停止する（WatchTasks の購読は終わらないため、過ぎたら切断する）。
+func startGRPC(addr string, srv *grpc.Server, timeout time.Duration) (stop func(), err error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen grpc port: %w", err)
+	}
+	go func() {
+		if err := srv.Serve(ln); err != nil {
+			slog.Error("grpc server stopped", "error", err)
 		}
+	}()
+	slog.Info("grpc server listening", "addr", ln.Addr().String())
+	return func() {
+		done := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(done)
+		}()
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case <-done:
+		case <-timer.C:
+			srv.Stop()
+			<-done
+		}
+	}, nil
+}
 
-		mux.ServeHTTP(w, r)
-	})
-
-	addr := ":8081"
-	log.Printf("tasks service listening on %s", addr)
+// withOpenAPI は mux に仕様を返す /api/openapi.json を登録し、mode に応じて仕様で検証するハンドラを返す。
+func withOpenAPI(mux *http.ServeMux, mode openapi.Mode) (http.Handler, error) {
+	spec, err := openapi.Load()
+	if err != nil {
+		return nil, err
+	}
+	specHandler, err := openapi.SpecHandler(spec)
+	if err != nil {
+		return nil, err
+	}
+	mux.Handle(openapi.Path, specHandler)
+	return openapi.Middleware(spec, mode, mux)
+}
 
-	server := &http.Server{
-		Addr:         addr,
-		Handler:      corsHandler,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+// newTracer は OTLP の送信先が設定されていれば Tracer を生成する。設定されていなければ nil（記録しない）。
+func newTracer(cfg config) (*tracing.Tracer, error) {
+	if cfg.OTLPEndpoint == "" {
+		return nil, nil
 	}
+	slog.Info("exporting traces", "endpoint", cfg.OTLPEndpoint, "service", cfg.ServiceName, "sample_ratio", cfg.TraceSampleRatio)
+	return tracing.NewTracer(tracing.Options{
+		Endpoint:    cfg.OTLPEndpoint,
+		ServiceName: cfg.ServiceName,
+		SampleRatio: cfg.TraceSampleRatio,
+	})
+}
 
-	if err := server.ListenAndServe(); err != nil {
-		log.Fatal(err)
+// shutdownTracer は送信待ちのスパンを送ってから Tracer を停止する。
+func shutdownTracer(tracer *tracing.Tracer) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := tracer.Shutdown(ctx); err != nil {
+		slog.Warn("failed to flush traces", "error", err)
 	}
 }
+
+// fatal はエラーをログに出力して終了する。
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"teamflow-shared/devseed"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// seedDemoTasks は devseed のデモのタスクを create で作成する（DEV_SEED、ローカル開発用）。
+// 作成済みの ID は作成しないため、永続化するリポジトリで再起動しても重複しない。期日は now からの日数で決める。
+func seedDemoTasks(ctx context.Context, create *usecase.CreateTaskUsecase, repo usecase.TaskRepository, now time.Time) error {
+	created := 0
+	for _, t := range devseed.Tasks {
+		if _, err := repo.FindByID(ctx, t.ID); err == nil {
+			continue
+		} else if !errors.Is(err, usecase.ErrTaskNotFound) {
+			return fmt.Errorf("demo task %s: %w", t.ID, err)
+		}
+		_, err := create.Execute(ctx, usecase.CreateTaskInput{
+			ID:          t.ID,
+			ProjectID:   t.ProjectID,
+			Title:       t.Title,
+			Description: t.Description,
+			Status:      domain.TaskStatus(t.Status),
+			Priority:    domain.TaskPriority(t.Priority),
+			DueDate:     t.DueDate(now),
+			ActorID:     devseed.OwnerID,
+			Now:         now,
+		})
+		if err != nil {
+			return fmt.Errorf("demo task %s: %w", t.ID, err)
+		}
+		created++
+	}
+	slog.Info("loaded demo tasks", "created", created, "total", len(devseed.Tasks))
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"teamflow-shared/devseed"
+
+	infra "teamflow-tasks/internal/infrastructure/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+func TestSeedDemoTasks(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	repo := infra.NewMemoryTaskRepository()
+	create := &usecase.CreateTaskUsecase{Repo: repo}
+
+	// 2 回目（再起動）は作成済みのタスクを作成しない
+	for i := 0; i < 2; i++ {
+		if err := seedDemoTasks(ctx, create, repo, now); err != nil {
+			t.Fatalf("run %d: unexpected error: %v", i+1, err)
+		}
+	}
+
+	total := 0
+	for _, p := range devseed.Projects {
+		tasks, err := repo.ListByProject(ctx, p.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		total += len(tasks)
+	}
+	if total != len(devseed.Tasks) {
+		t.Fatalf("expected %d tasks, got %d", len(devseed.Tasks), total)
+	}
+	for _, want := range devseed.Tasks {
+		got, err := repo.FindByID(ctx, want.ID)
+		if err != nil {
+			t.Fatalf("%s: %v", want.ID, err)
+		}
+		if got.ProjectID != want.ProjectID || string(got.Status) != want.Status || string(got.Priority) != want.Priority {
+			t.Errorf("%s: got project=%s status=%s priority=%s", want.ID, got.ProjectID, got.Status, got.Priority)
+		}
+		if (got.DueDate == nil) != (want.DueInDays == nil) {
+			t.Errorf("%s: DueDate = %v, DueInDays = %v", want.ID, got.DueDate, want.DueInDays)
+		}
+	}
+}
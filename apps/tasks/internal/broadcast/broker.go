@@ -0,0 +1,84 @@
+// Package broadcast はタスクの変更イベントを購読者（SSE 接続など）に配信する。
+//
+// イベントの発生源はリポジトリ構成によって異なる。
+//   - PostgreSQL: tasks テーブルのトリガーが NOTIFY し、TaskChangeListener が Publish する（全レプリカに届く）
+//   - インメモリ: NotifyingTaskRepository が保存成功時に Publish する（単一プロセスのみ）
+package broadcast
+
+import "sync"
+
+// イベント種別。
+const (
+	EventTaskCreated = "task.created"
+	EventTaskUpdated = "task.updated"
+	EventTaskDeleted = "task.deleted"
+)
+
+// subscriberBuffer は購読者ごとのバッファ。溢れたイベントは捨てる（遅い購読者で Publish を止めないため）。
+const subscriberBuffer = 16
+
+// Event はタスクの変更イベント。購読者はこれを受けて対象タスクを取得し直す。
+type Event struct {
+	Type        string `json:"type"`
+	WorkspaceID string `json:"workspaceId"`
+	ProjectID   string `json:"projectId"`
+	TaskID      string `json:"taskId"`
+}
+
+// topic は購読の単位（ワークスペース内のプロジェクト）。
+// プロジェクト ID だけで振り分けると、別のワークスペースの同じ ID のプロジェクトにイベントが届くため組にする。
+type topic struct {
+	workspaceID string
+	projectID   string
+}
+
+// Broker はワークスペース内のプロジェクト単位でイベントを購読者に配信する。
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[topic]map[chan Event]struct{}
+}
+
+// NewBroker は新しいBrokerを生成する。
+func NewBroker() *Broker {
+	return &Broker{subscribers: make(map[topic]map[chan Event]struct{})}
+}
+
+// Subscribe は workspaceID の projectID のイベントを購読する。
+// 返り値の cancel を呼ぶと購読を解除し、チャネルを閉じる。
+func (b *Broker) Subscribe(workspaceID, projectID string) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+	key := topic{workspaceID: workspaceID, projectID: projectID}
+
+	b.mu.Lock()
+	if b.subscribers[key] == nil {
+		b.subscribers[key] = make(map[chan Event]struct{})
+	}
+	b.subscribers[key][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers[key], ch)
+			if len(b.subscribers[key]) == 0 {
+				delete(b.subscribers, key)
+			}
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, cancel
+}
+
+// Publish は e.WorkspaceID の e.ProjectID の購読者に e を配信する。ブロックしない。
+func (b *Broker) Publish(e Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for ch := range b.subscribers[topic{workspaceID: e.WorkspaceID, projectID: e.ProjectID}] {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
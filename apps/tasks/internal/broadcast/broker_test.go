@@ -0,0 +1,63 @@
+package broadcast
+
+import "testing"
+
+func TestBroker_PublishToProjectSubscribers(t *testing.T) {
+	b := NewBroker()
+	proj1, cancel1 := b.Subscribe("ws-1", "proj-1")
+	defer cancel1()
+	proj2, cancel2 := b.Subscribe("ws-1", "proj-2")
+	defer cancel2()
+	otherWorkspace, cancel3 := b.Subscribe("ws-2", "proj-1")
+	defer cancel3()
+
+	want := Event{Type: EventTaskCreated, WorkspaceID: "ws-1", ProjectID: "proj-1", TaskID: "task-1"}
+	b.Publish(want)
+
+	select {
+	case got := <-proj1:
+		if got != want {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	default:
+		t.Fatal("expected proj-1 subscriber to receive the event")
+	}
+
+	select {
+	case got := <-proj2:
+		t.Errorf("expected no event for proj-2, got %+v", got)
+	default:
+	}
+
+	select {
+	case got := <-otherWorkspace:
+		t.Errorf("expected no event for proj-1 in another workspace, got %+v", got)
+	default:
+	}
+}
+
+func TestBroker_CancelClosesChannel(t *testing.T) {
+	b := NewBroker()
+	ch, cancel := b.Subscribe("ws-1", "proj-1")
+	cancel()
+	cancel() // 2 回目は何もしない
+
+	if _, ok := <-ch; ok {
+		t.Error("expected channel to be closed")
+	}
+	// 購読解除後の Publish は panic しない
+	b.Publish(Event{Type: EventTaskUpdated, WorkspaceID: "ws-1", ProjectID: "proj-1", TaskID: "task-1"})
+}
+
+func TestBroker_SlowSubscriberDoesNotBlock(t *testing.T) {
+	b := NewBroker()
+	ch, cancel := b.Subscribe("ws-1", "proj-1")
+	defer cancel()
+
+	for i := 0; i < subscriberBuffer*2; i++ {
+		b.Publish(Event{Type: EventTaskUpdated, WorkspaceID: "ws-1", ProjectID: "proj-1", TaskID: "task-1"})
+	}
+	if len(ch) != subscriberBuffer {
+		t.Errorf("expected %d buffered events, got %d", subscriberBuffer, len(ch))
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // CursorPayload は cursor の payload を表す。
@@ -17,9 +18,16 @@ type CursorPayload struct {
 	ID        string `json:"id"`
 	ProjectID string `json:"projectId"`
 	QHash     string `json:"qhash"`
+	QV        int    `json:"qv"` // qhash の正規化仕様バージョン（QHashVersion）
 	IssuedAt  int64  `json:"iat"`
+	// Dir は keyset の向き。CursorDirectionDesc は created_at DESC, id DESC（新しい順）。
+	// 任意。空の場合は created_at ASC, id ASC（向きを持たない従来の cursor と互換）
+	Dir string `json:"dir,omitempty"`
 }
 
+// CursorDirectionDesc は新しい順（created_at DESC, id DESC）の cursor の Dir。
+const CursorDirectionDesc = "desc"
+
 // EncodeCursor は cursor をエンコードする。
 // payload(JSON) → base64.RawURLEncoding（paddingなし） = encodedPayload
 // sig = HMAC-SHA256(secret, encodedPayload) → base64.RawURLEncoding
@@ -34,62 +42,76 @@ func EncodeCursor(payload CursorPayload, secret []byte) (string, error) {
 	// base64.RawURLEncoding でエンコード（paddingなし）
 	encodedPayload := base64.RawURLEncoding.EncodeToString(payloadJSON)
 
-	// HMAC-SHA256 で署名
-	mac := hmac.New(sha256.New, secret)
-	mac.Write([]byte(encodedPayload))
-	sig := mac.Sum(nil)
-
-	// 署名を base64.RawURLEncoding でエンコード
-	encodedSig := base64.RawURLEncoding.EncodeToString(sig)
+	// HMAC-SHA256 で署名し、base64.RawURLEncoding でエンコード
+	encodedSig := base64.RawURLEncoding.EncodeToString(signPayload(encodedPayload, secret))
 
 	// cursor = encodedPayload + "." + sig
 	return encodedPayload + "." + encodedSig, nil
 }
 
+// signPayload は base64 エンコード済みの payload の HMAC-SHA256 署名を返す（cursor と同期トークンで共通）。
+func signPayload(encodedPayload string, secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(encodedPayload))
+	return mac.Sum(nil)
+}
+
+// MaxCursorLength は受け付ける cursor 文字列の最大長（バイト）。
+// 正規の cursor は 400 バイト程度のため、十分な余裕を持たせつつ巨大な入力を base64 デコード前に弾く。
+const MaxCursorLength = 1024
+
+// maxCursorCreatedAtLength は cursor の createdAt 文字列の最大長（RFC3339Nano は最大 35 文字）。
+const maxCursorCreatedAtLength = 64
+
 // DecodeCursor は cursor をデコードし、署名を検証する。
 // エラーは validation error として返す（500にしない）。
 // 元エラーを wrap してデバッグ可能にする。
+//
+// 信頼できない入力を JSON パースしないよう、署名を検証してから payload を解釈する。
 func DecodeCursor(cursorStr string, secret []byte) (*CursorPayload, error) {
+	// 長さチェック（base64 デコード前に巨大な入力を弾く）
+	if len(cursorStr) > MaxCursorLength {
+		return nil, fmt.Errorf("%w: cursor exceeds %d bytes", ErrCursorInvalidFormat, MaxCursorLength)
+	}
+
 	// フォーマットチェック: "payload.sig" の形式
-	parts := strings.Split(cursorStr, ".")
-	if len(parts) != 2 {
+	encodedPayload, encodedSig, ok := strings.Cut(cursorStr, ".")
+	if !ok || encodedPayload == "" || strings.Contains(encodedSig, ".") {
 		return nil, ErrCursorInvalidFormat
 	}
 
-	encodedPayload := parts[0]
-	encodedSig := parts[1]
-
-	// payload をデコード
+	// payload / 署名をデコード
 	payloadJSON, err := base64.RawURLEncoding.DecodeString(encodedPayload)
 	if err != nil {
 		return nil, fmt.Errorf("%w: base64 decode payload: %v", ErrCursorInvalidFormat, err)
 	}
-
-	// JSON をパース
-	var payload CursorPayload
-	if err := json.Unmarshal(payloadJSON, &payload); err != nil {
-		return nil, fmt.Errorf("%w: json unmarshal: %v", ErrCursorInvalidFormat, err)
-	}
-
-	// 署名を検証
 	expectedSig, err := base64.RawURLEncoding.DecodeString(encodedSig)
 	if err != nil {
 		return nil, fmt.Errorf("%w: base64 decode sig: %v", ErrCursorInvalidFormat, err)
 	}
 
-	mac := hmac.New(sha256.New, secret)
-	mac.Write([]byte(encodedPayload))
-	computedSig := mac.Sum(nil)
-
-	if !hmac.Equal(expectedSig, computedSig) {
+	// 署名を検証
+	if !hmac.Equal(expectedSig, signPayload(encodedPayload, secret)) {
 		return nil, ErrCursorInvalidSignature
 	}
 
+	// JSON をパース（encoding/json は不正な UTF-8 を黙って置換するため、事前に弾く）
+	if !utf8.Valid(payloadJSON) {
+		return nil, fmt.Errorf("%w: payload is not valid UTF-8", ErrCursorInvalidFormat)
+	}
+	var payload CursorPayload
+	if err := json.Unmarshal(payloadJSON, &payload); err != nil {
+		return nil, fmt.Errorf("%w: json unmarshal: %v", ErrCursorInvalidFormat, err)
+	}
+
 	return &payload, nil
 }
 
 // ParseCursorCreatedAt は cursor の createdAt 文字列を time.Time に変換し、micro秒に丸める。
 func ParseCursorCreatedAt(createdAtStr string) (time.Time, error) {
+	if len(createdAtStr) > maxCursorCreatedAtLength {
+		return time.Time{}, fmt.Errorf("invalid cursor format: createdAt exceeds %d bytes", maxCursorCreatedAtLength)
+	}
 	t, err := time.Parse(time.RFC3339Nano, createdAtStr)
 	if err != nil {
 		return time.Time{}, fmt.Errorf("invalid cursor format: %w", err)
@@ -104,12 +126,63 @@ func FormatCursorCreatedAt(t time.Time) string {
 	return t.Truncate(time.Microsecond).Format(time.RFC3339Nano)
 }
 
-// ValidateCursorExpiry は cursor の有効期限をチェックする（24時間）。
+// CursorTTL は cursor の有効期限（発行日時 iat からの期間）。
+const CursorTTL = 24 * time.Hour
+
+// ValidateCursorExpiry は cursor の有効期限をチェックする（CursorTTL）。
 // 期限切れの場合はエラーを返す。
 func ValidateCursorExpiry(payload *CursorPayload, now time.Time) error {
 	nowUnix := now.Unix()
-	if nowUnix-payload.IssuedAt > 86400 {
+	if nowUnix-payload.IssuedAt > int64(CursorTTL/time.Second) {
 		return ErrCursorExpired
 	}
 	return nil
 }
+
+// CursorInspection は InspectCursor の結果。
+type CursorInspection struct {
+	// Payload は署名を検証せずに取り出した payload
+	Payload CursorPayload
+	// SignatureValid は secret で署名を検証できたかどうか
+	SignatureValid bool
+	IssuedAt       time.Time
+	ExpiresAt      time.Time
+	// Expired は ValidateCursorExpiry で期限切れになるかどうか
+	Expired bool
+}
+
+// InspectCursor は運用者の調査用に、署名を信頼せずに cursor の payload を取り出し、署名と有効期限を確認する。
+// DecodeCursor と異なり、署名が不正・期限切れでもエラーにしない。形式が不正な場合だけ ErrCursorInvalidFormat を返す。
+// 取り出した payload は表示にだけ使い、検索に使ってはならない。
+func InspectCursor(cursorStr string, secret []byte, now time.Time) (*CursorInspection, error) {
+	if len(cursorStr) > MaxCursorLength {
+		return nil, fmt.Errorf("%w: cursor exceeds %d bytes", ErrCursorInvalidFormat, MaxCursorLength)
+	}
+	encodedPayload, encodedSig, ok := strings.Cut(cursorStr, ".")
+	if !ok || encodedPayload == "" || strings.Contains(encodedSig, ".") {
+		return nil, ErrCursorInvalidFormat
+	}
+	payloadJSON, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: base64 decode payload: %v", ErrCursorInvalidFormat, err)
+	}
+	if !utf8.Valid(payloadJSON) {
+		return nil, fmt.Errorf("%w: payload is not valid UTF-8", ErrCursorInvalidFormat)
+	}
+	var payload CursorPayload
+	if err := json.Unmarshal(payloadJSON, &payload); err != nil {
+		return nil, fmt.Errorf("%w: json unmarshal: %v", ErrCursorInvalidFormat, err)
+	}
+
+	// 署名の形式が不正な場合も、検証できなかったものとして payload は返す
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSig)
+	issuedAt := time.Unix(payload.IssuedAt, 0).UTC()
+
+	return &CursorInspection{
+		Payload:        payload,
+		SignatureValid: err == nil && hmac.Equal(sig, signPayload(encodedPayload, secret)),
+		IssuedAt:       issuedAt,
+		ExpiresAt:      issuedAt.Add(CursorTTL),
+		Expired:        ValidateCursorExpiry(&payload, now) != nil,
+	}, nil
+}
@@ -0,0 +1,31 @@
+package task
+
+import "time"
+
+// ParseDueDate は期日を YYYY-MM-DD か RFC3339 で受け取り、日付（DueDateOf）に正規化して返す。
+//
+// 期日は日付のみを持つ（DB の due_date は DATE）。RFC3339 は指定されたオフセットでの日付を使う
+// （2026-01-10T00:00:00+09:00 は 2026-01-10。UTC に変換すると前日になるため変換しない）。
+// どちらの形式でもない場合は INVALID_FORMAT の *ValidationError を返す。
+func ParseDueDate(s string) (time.Time, error) {
+	if d, err := time.Parse(time.DateOnly, s); err == nil {
+		return d, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, NewInvalidFormat("dueDate", err, &s)
+	}
+	return DueDateOf(t), nil
+}
+
+// DueDateOf は t のタイムゾーンでの日付を、UTC の 0 時の時刻で返す。
+// 期日はこの形で保持し、DB の DATE・レスポンスの YYYY-MM-DD との間で日付がずれないようにする。
+func DueDateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// IsOverdue は期日 due が now の日付（now のタイムゾーンでの日付）より前かどうかを返す。期日の当日は期限切れにしない。
+// now はプロジェクトのタイムゾーンの時刻で渡す（UTC で渡すと UTC の 0 時で日付が変わる）。
+func IsOverdue(due, now time.Time) bool {
+	return DueDateOf(due).Before(DueDateOf(now))
+}
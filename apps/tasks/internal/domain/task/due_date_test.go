@@ -0,0 +1,71 @@
+package task
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseDueDate(t *testing.T) {
+	want := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	for _, in := range []string{
+		"2026-01-10",
+		"2026-01-10T00:00:00Z",
+		"2026-01-10T23:59:59Z",
+		"2026-01-10T00:30:00+09:00", // UTC では 2026-01-09
+		"2026-01-10T20:00:00-08:00", // UTC では 2026-01-11
+	} {
+		got, err := ParseDueDate(in)
+		if err != nil {
+			t.Fatalf("ParseDueDate(%q): unexpected error: %v", in, err)
+		}
+		if !got.Equal(want) || got.Location() != time.UTC {
+			t.Errorf("ParseDueDate(%q) = %v, want %v", in, got, want)
+		}
+	}
+
+	for _, in := range []string{"", "2026/01/10", "2026-1-10", "2026-01-10T00:00:00"} {
+		_, err := ParseDueDate(in)
+		var ve *ValidationError
+		if !errors.As(err, &ve) || ve.Field != "dueDate" || ve.Code != "INVALID_FORMAT" {
+			t.Errorf("ParseDueDate(%q): expected dueDate INVALID_FORMAT, got %v", in, err)
+		}
+	}
+}
+
+func TestApplyPatch_NormalizesDueDate(t *testing.T) {
+	task, err := NewTask("task-1", "proj-1", "画面設計", "", StatusTodo, PriorityMedium, nil, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	jst := time.FixedZone("JST", 9*60*60)
+	if err := task.ApplyPatch(TaskPatch{DueDate: Set(time.Date(2026, 1, 10, 8, 0, 0, 0, jst))}, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC); task.DueDate == nil || !task.DueDate.Equal(want) {
+		t.Errorf("expected due date %v, got %v", want, task.DueDate)
+	}
+}
+
+func TestIsOverdue(t *testing.T) {
+	due := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	jst := time.FixedZone("JST", 9*60*60)
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{name: "due date itself", now: time.Date(2026, 1, 10, 23, 59, 0, 0, time.UTC), want: false},
+		{name: "next day", now: time.Date(2026, 1, 11, 0, 0, 0, 0, time.UTC), want: true},
+		// UTC では 1/10 の夜だが、JST では 1/11
+		{name: "next day in the project timezone", now: time.Date(2026, 1, 10, 20, 0, 0, 0, time.UTC).In(jst), want: true},
+		{name: "previous day", now: time.Date(2026, 1, 9, 12, 0, 0, 0, time.UTC), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsOverdue(due, tt.now); got != tt.want {
+				t.Errorf("IsOverdue(%v, %v) = %v, want %v", due, tt.now, got, tt.want)
+			}
+		})
+	}
+}
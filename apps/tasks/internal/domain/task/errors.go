@@ -1,17 +1,11 @@
 package task
 
-import (
-	"errors"
-	"fmt"
-)
-
-// ErrInvalidPatch は patch 適用時のエラーを生成する。
-func ErrInvalidPatch(reason string) error {
-	return fmt.Errorf("invalid patch: %s", reason)
-}
+import "errors"
 
 // --- Sentinel Errors ---
 // これらは errors.Is で判定可能。HTTP 層で ValidationIssue に変換される。
+// フィールド単位の入力エラー（NewTask / ParseStatus / ApplyPatch 等）は
+// validation_error.go の *ValidationError で返す。
 //
 // 使用例:
 //   if errors.Is(err, ErrDueDateFromAfterTo) {
@@ -24,9 +18,9 @@ var (
 	// HTTP 層: field=dueDateFrom, code=CONSTRAINT_VIOLATION
 	ErrDueDateFromAfterTo = errors.New("dueDateFrom must not be after dueDateTo")
 
-	// ErrLimitOutOfRange は limit が 1-200 の範囲外の場合のエラー。
+	// ErrLimitOutOfRange は limit が 1〜上限（PageLimits.Max）の範囲外の場合のエラー。
 	// HTTP 層: field=limit, code=INVALID_RANGE
-	ErrLimitOutOfRange = errors.New("limit must be between 1 and 200")
+	ErrLimitOutOfRange = errors.New("limit must be between 1 and the maximum page size")
 
 	// ErrSortIncompatibleWithCursor は cursor と sort の併用時のエラー。
 	// HTTP 層: field=sort, code=INCOMPATIBLE_WITH_CURSOR
@@ -51,3 +45,21 @@ var (
 	// HTTP 層: field=cursor, code=QUERY_MISMATCH
 	ErrCursorQueryMismatch = errors.New("cursor query mismatch")
 )
+
+// Sync token validation errors
+var (
+	// ErrSyncTokenInvalid は同期トークンの形式・署名が不正、または別のプロジェクトのトークンの場合のエラー。
+	// HTTP 層: field=since, code=INVALID_FORMAT
+	ErrSyncTokenInvalid = errors.New("invalid sync token")
+
+	// ErrSyncTokenExpired は同期トークンが削除の記録の保持期間より古い場合のエラー。
+	// HTTP 層: field=since, code=EXPIRED（クライアントは since を付けずに全件を同期し直す）
+	ErrSyncTokenExpired = errors.New("sync token expired")
+)
+
+// Version conflict errors
+var (
+	// ErrVersionConflict は If-Match の版数より後にタスクが変更されている場合のエラー（*VersionConflictError でも返す）。
+	// HTTP 層: 409 CONFLICT
+	ErrVersionConflict = errors.New("task was modified after the given version")
+)
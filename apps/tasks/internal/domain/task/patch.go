@@ -1,5 +1,7 @@
 package task
 
+// Patch は部分更新（PATCH）における 1 フィールド分の指定を表す。
+// 未指定 / null / 値あり の 3 状態を区別する。
 type Patch[T any] struct {
 	IsSet  bool // 未指定=false
 	IsNull bool // null=true
@@ -10,3 +12,19 @@ func Unset[T any]() Patch[T]      { return Patch[T]{IsSet: false} }
 func Null[T any]() Patch[T]       { return Patch[T]{IsSet: true, IsNull: true} }
 func Set[T any](v T) Patch[T]     { return Patch[T]{IsSet: true, Value: v} }
 func (p Patch[T]) HasValue() bool { return p.IsSet && !p.IsNull }
+
+// MapPatch は値ありの場合のみ f で値を変換する。未指定 / null の状態はそのまま引き継ぐ。
+// 文字列で受け取った status や日時を型付きの Patch に変換する用途で使う。
+func MapPatch[T, U any](p Patch[T], f func(T) (U, error)) (Patch[U], error) {
+	if !p.IsSet {
+		return Unset[U](), nil
+	}
+	if p.IsNull {
+		return Null[U](), nil
+	}
+	v, err := f(p.Value)
+	if err != nil {
+		return Patch[U]{}, err
+	}
+	return Set(v), nil
+}
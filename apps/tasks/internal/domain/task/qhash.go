@@ -0,0 +1,139 @@
+package task
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// QHashVersion は qhash の正規化仕様のバージョン。
+// CanonicalQuery の出力が変わる変更（キー追加・値の正規化ルール変更など）を入れる場合は必ず上げる。
+// cursor の payload.qv と一致しない場合は QUERY_MISMATCH として扱う。
+//
+// 履歴:
+//   - 1: "projectId:xxx|status:a,b|..." 形式（バージョン無し、区切り文字のエスケープ無し）
+//   - 2: key=value を key でソートし、値を URL エスケープして "&" で連結
+//   - 3: milestoneId を追加
+//   - 4: sprintId を追加
+//   - 5: epicId を追加
+//   - 6: workspaceId を追加
+const QHashVersion = 6
+
+// CanonicalQuery はクエリ条件を qhash 用の正規化文字列に変換する。
+//
+// 正規化ルール（v2 以降）:
+//   - key=value の組をキー名でソートして "&" で連結する（指定順序の差を吸収）
+//   - 複数値（status/priority）は値をソートして "," で連結する
+//   - 日付は YYYY-MM-DD に揃える
+//   - 値は URL エスケープする（q に区切り文字が含まれても衝突しない）
+//   - 未指定のフィルタはキーごと出力しない
+//   - 先頭に qv=<QHashVersion> を含める
+//
+// sort / limit / cursor は「同一クエリの続き」の判定に含めない。
+func (q *TaskQuery) CanonicalQuery(projectID string) string {
+	fields := map[string]string{
+		"qv":        strconv.Itoa(QHashVersion),
+		"projectId": projectID,
+	}
+
+	if len(q.Statuses) > 0 {
+		values := make([]string, len(q.Statuses))
+		for i, s := range q.Statuses {
+			values[i] = string(s)
+		}
+		sort.Strings(values)
+		fields["status"] = strings.Join(values, ",")
+	}
+
+	if len(q.Priorities) > 0 {
+		values := make([]string, len(q.Priorities))
+		for i, p := range q.Priorities {
+			values[i] = string(p)
+		}
+		sort.Strings(values)
+		fields["priority"] = strings.Join(values, ",")
+	}
+
+	if q.AssigneeID != nil {
+		fields["assigneeId"] = *q.AssigneeID
+	}
+
+	if q.MilestoneID != nil {
+		fields["milestoneId"] = *q.MilestoneID
+	}
+
+	if q.SprintID != nil {
+		fields["sprintId"] = *q.SprintID
+	}
+
+	if q.EpicID != nil {
+		fields["epicId"] = *q.EpicID
+	}
+
+	if q.DueDateFrom != nil {
+		fields["dueDateFrom"] = q.DueDateFrom.Format("2006-01-02")
+	}
+
+	if q.DueDateTo != nil {
+		fields["dueDateTo"] = q.DueDateTo.Format("2006-01-02")
+	}
+
+	if q.Query != nil {
+		fields["q"] = *q.Query
+	}
+
+	if q.WorkspaceID != "" {
+		fields["workspaceId"] = q.WorkspaceID
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+url.QueryEscape(fields[k]))
+	}
+	return strings.Join(pairs, "&")
+}
+
+// ComputeQHash はクエリ条件から qhash を計算する。
+// CanonicalQuery の sha256 の先頭 8byte を Base64URL でエンコードした短い文字列を返す。
+func (q *TaskQuery) ComputeQHash(projectID string) string {
+	hash := sha256.Sum256([]byte(q.CanonicalQuery(projectID)))
+	return base64.RawURLEncoding.EncodeToString(hash[:8])
+}
+
+// CursorQueryMatch は cursor を発行したクエリと、指定したクエリの比較結果（運用者の調査用）。
+type CursorQueryMatch struct {
+	// CanonicalQuery / QHash は指定したクエリの正規化文字列と qhash
+	CanonicalQuery string
+	QHash          string
+	// WithCursor と同じ順で確認する項目。すべて true の場合だけ cursor を受け付ける
+	ProjectIDMatches bool
+	QVMatches        bool
+	QHashMatches     bool
+}
+
+// Matches はすべての項目が一致するかどうかを返す。
+func (m CursorQueryMatch) Matches() bool {
+	return m.ProjectIDMatches && m.QVMatches && m.QHashMatches
+}
+
+// MatchCursor は payload を発行したクエリが q（projectID のプロジェクトの一覧）と一致するかを、
+// WithCursor と同じ条件で比較する。一致しない項目がある cursor は QUERY_MISMATCH になる。
+func (q *TaskQuery) MatchCursor(payload CursorPayload, projectID string) CursorQueryMatch {
+	qhash := q.ComputeQHash(projectID)
+	return CursorQueryMatch{
+		CanonicalQuery:   q.CanonicalQuery(projectID),
+		QHash:            qhash,
+		ProjectIDMatches: payload.ProjectID == projectID,
+		QVMatches:        payload.QV == QHashVersion,
+		QHashMatches:     payload.QHash == qhash,
+	}
+}
@@ -1,19 +1,51 @@
 package task
 
 import (
-	"crypto/sha256"
-	"encoding/base64"
-	"sort"
+	"fmt"
 	"strings"
 	"time"
 )
 
+// PageLimits は一覧の 1 ページの件数（limit）の既定値と上限。
+type PageLimits struct {
+	Default int // limit を指定しない（1 未満の）場合の件数
+	Max     int // limit の上限
+}
+
+// DefaultPageLimits は limit の既定値と上限の既定値（いずれも 200）。
+var DefaultPageLimits = PageLimits{Default: 200, Max: 200}
+
+// Validate は 1 <= Default <= Max であることを確認する。
+func (l PageLimits) Validate() error {
+	if l.Max < 1 {
+		return fmt.Errorf("max page size must be at least 1, got %d", l.Max)
+	}
+	if l.Default < 1 || l.Default > l.Max {
+		return fmt.Errorf("default page size must be between 1 and %d, got %d", l.Max, l.Default)
+	}
+	return nil
+}
+
+// Clamp は limit を 1〜Max に正規化する。1 未満（未指定を含む）は Default、Max を超える値は Max にする。
+func (l PageLimits) Clamp(limit int) int {
+	if limit < 1 {
+		return l.Default
+	}
+	return min(limit, l.Max)
+}
+
 // TaskQuery はタスク検索条件を表すQuery Object。
 // 条件定義のみを担当し、実装詳細（フィルタリング・ソート・リミット処理）はリポジトリ層に委譲する。
 type TaskQuery struct {
+	// WorkspaceID は検索するワークスペース。絞り込みはリポジトリが context で行い、ここでは qhash に含めるためだけに使う
+	WorkspaceID string
+
 	// Filters
 	Statuses    []TaskStatus   // status フィルタ（doing -> in_progress 正規化済み）
 	AssigneeID  *string        // assigneeId フィルタ
+	MilestoneID *string        // milestoneId フィルタ
+	SprintID    *string        // sprintId フィルタ
+	EpicID      *string        // epicId フィルタ
 	Priorities  []TaskPriority // priority フィルタ
 	DueDateFrom *time.Time     // dueDateFrom
 	DueDateTo   *time.Time     // dueDateTo
@@ -23,10 +55,13 @@ type TaskQuery struct {
 	SortOrders []SortOrder // sort パラメータからパース済み
 
 	// Limit
-	Limit int // limit (default 200, max 200, min 1)
+	Limit int // limit（1〜上限。既定値・上限は WithPageLimits、省略時は DefaultPageLimits）
 
 	// Cursor
 	Cursor *TaskCursor // cursor デコード結果
+
+	// limits は Limit の既定値と上限。ゼロ値の場合は DefaultPageLimits を使う
+	limits PageLimits
 }
 
 // TaskCursor は cursor のデコード結果を保持する。
@@ -36,6 +71,15 @@ type TaskCursor struct {
 	ProjectID string
 	QHash     string
 	IssuedAt  int64
+	// Descending は created_at DESC, id DESC（新しい順）の keyset で続きを取得するかどうか（payload の dir）
+	Descending bool
+}
+
+// ProjectVersion はプロジェクトのタスク一覧（アーカイブされていないタスク）のバージョン。
+// 作成・更新で UpdatedAt が、削除・アーカイブ・アーカイブの解除で Count が変わるため、一覧の ETag に使う。
+type ProjectVersion struct {
+	UpdatedAt time.Time // 最も新しい updated_at。タスクが無い場合はゼロ値
+	Count     int
 }
 
 // SortOrder はソート順を表す。
@@ -52,9 +96,7 @@ const (
 // NewTaskQuery はQuery Objectを構築し、正規化を行う。
 // エラーはバリデーションエラーの場合のみ返す。
 func NewTaskQuery(opts ...TaskQueryOption) (*TaskQuery, error) {
-	q := &TaskQuery{
-		Limit: 200, // default
-	}
+	q := &TaskQuery{}
 
 	for _, opt := range opts {
 		if err := opt(q); err != nil {
@@ -62,13 +104,8 @@ func NewTaskQuery(opts ...TaskQueryOption) (*TaskQuery, error) {
 		}
 	}
 
-	// Limit の正規化（1-200にクランプ）
-	if q.Limit < 1 {
-		q.Limit = 200
-	}
-	if q.Limit > 200 {
-		q.Limit = 200
-	}
+	// Limit の正規化（未指定は既定値、上限を超える値は上限にする）
+	q.Limit = q.PageLimits().Clamp(q.Limit)
 
 	return q, nil
 }
@@ -157,6 +194,39 @@ func WithAssigneeIDFilter(assigneeID string) TaskQueryOption {
 	}
 }
 
+// WithMilestoneIDFilter はmilestoneIdフィルタを設定する。
+func WithMilestoneIDFilter(milestoneID string) TaskQueryOption {
+	return func(q *TaskQuery) error {
+		if milestoneID == "" {
+			return nil
+		}
+		q.MilestoneID = &milestoneID
+		return nil
+	}
+}
+
+// WithSprintIDFilter はsprintIdフィルタを設定する。
+func WithSprintIDFilter(sprintID string) TaskQueryOption {
+	return func(q *TaskQuery) error {
+		if sprintID == "" {
+			return nil
+		}
+		q.SprintID = &sprintID
+		return nil
+	}
+}
+
+// WithEpicIDFilter はepicIdフィルタを設定する。
+func WithEpicIDFilter(epicID string) TaskQueryOption {
+	return func(q *TaskQuery) error {
+		if epicID == "" {
+			return nil
+		}
+		q.EpicID = &epicID
+		return nil
+	}
+}
+
 // WithDueDateRangeFilter はdueDateFrom/Toフィルタを設定する（YYYY-MM-DD形式）。
 func WithDueDateRangeFilter(dueDateFromStr, dueDateToStr string) TaskQueryOption {
 	return func(q *TaskQuery) error {
@@ -247,6 +317,22 @@ func WithSort(sortStr string) TaskQueryOption {
 	}
 }
 
+// WithPageLimits は limit の既定値と上限を設定する（省略時は DefaultPageLimits）。
+func WithPageLimits(limits PageLimits) TaskQueryOption {
+	return func(q *TaskQuery) error {
+		q.limits = limits
+		return nil
+	}
+}
+
+// PageLimits は limit の既定値と上限を返す。
+func (q *TaskQuery) PageLimits() PageLimits {
+	if q.limits == (PageLimits{}) {
+		return DefaultPageLimits
+	}
+	return q.limits
+}
+
 // WithLimit はlimitを設定する（正規化はNewTaskQuery内で行われる）。
 func WithLimit(limit int) TaskQueryOption {
 	return func(q *TaskQuery) error {
@@ -257,8 +343,8 @@ func WithLimit(limit int) TaskQueryOption {
 
 // Validate はQuery Objectの整合性をチェックする。
 func (q *TaskQuery) Validate() error {
-	if q.Limit < 1 || q.Limit > 200 {
-		return ErrLimitOutOfRange
+	if maxLimit := q.PageLimits().Max; q.Limit < 1 || q.Limit > maxLimit {
+		return fmt.Errorf("%w (max %d, got %d)", ErrLimitOutOfRange, maxLimit, q.Limit)
 	}
 
 	if q.DueDateFrom != nil && q.DueDateTo != nil {
@@ -275,61 +361,13 @@ func (q *TaskQuery) Validate() error {
 	return nil
 }
 
-// ComputeQHash はクエリ条件から qhash を計算する。
-// projectId と filter/search 等のパラメータを正規化してハッシュ化した短い文字列を返す。
-func (q *TaskQuery) ComputeQHash(projectID string) string {
-	// 正規化: 複数値（status/priority 等）はソートして join（順序差を吸収）
-	parts := []string{}
-
-	// projectID
-	parts = append(parts, "projectId:"+projectID)
-
-	// statuses（ソート済み）
-	if len(q.Statuses) > 0 {
-		statusStrs := make([]string, len(q.Statuses))
-		for i, s := range q.Statuses {
-			statusStrs[i] = string(s)
-		}
-		sort.Strings(statusStrs)
-		parts = append(parts, "status:"+strings.Join(statusStrs, ","))
-	}
-
-	// priorities（ソート済み）
-	if len(q.Priorities) > 0 {
-		priorityStrs := make([]string, len(q.Priorities))
-		for i, p := range q.Priorities {
-			priorityStrs[i] = string(p)
-		}
-		sort.Strings(priorityStrs)
-		parts = append(parts, "priority:"+strings.Join(priorityStrs, ","))
-	}
-
-	// assigneeId
-	if q.AssigneeID != nil {
-		parts = append(parts, "assigneeId:"+*q.AssigneeID)
-	}
-
-	// dueDateFrom
-	if q.DueDateFrom != nil {
-		parts = append(parts, "dueDateFrom:"+q.DueDateFrom.Format("2006-01-02"))
-	}
-
-	// dueDateTo
-	if q.DueDateTo != nil {
-		parts = append(parts, "dueDateTo:"+q.DueDateTo.Format("2006-01-02"))
-	}
-
-	// q (title検索)
-	if q.Query != nil {
-		parts = append(parts, "q:"+*q.Query)
+// WithWorkspace は検索するワークスペースを設定する。
+// 別のワークスペースで発行された cursor を QUERY_MISMATCH にするため、WithCursor より前に指定する。
+func WithWorkspace(workspaceID string) TaskQueryOption {
+	return func(q *TaskQuery) error {
+		q.WorkspaceID = workspaceID
+		return nil
 	}
-
-	// ソート済みの parts を join
-	normalized := strings.Join(parts, "|")
-
-	// sha256 の先頭 8byte を Base64URL でエンコード
-	hash := sha256.Sum256([]byte(normalized))
-	return base64.RawURLEncoding.EncodeToString(hash[:8])
 }
 
 // WithCursor は cursor をデコードし、検証して設定する。
@@ -346,6 +384,11 @@ func WithCursor(cursorStr string, projectID string, secret []byte, now time.Time
 			return err
 		}
 
+		// 向きは空（昇順）か desc のみ
+		if payload.Dir != "" && payload.Dir != CursorDirectionDesc {
+			return ErrCursorInvalidFormat
+		}
+
 		// createdAt をパース（micro秒丸め）
 		createdAt, err := ParseCursorCreatedAt(payload.CreatedAt)
 		if err != nil {
@@ -362,6 +405,11 @@ func WithCursor(cursorStr string, projectID string, secret []byte, now time.Time
 			return ErrCursorQueryMismatch
 		}
 
+		// qhash バージョンの一致確認（正規化仕様が変わった cursor は再取得させる）
+		if payload.QV != QHashVersion {
+			return ErrCursorQueryMismatch
+		}
+
 		// qhash の一致確認
 		computedQHash := q.ComputeQHash(projectID)
 		if computedQHash != payload.QHash {
@@ -370,13 +418,40 @@ func WithCursor(cursorStr string, projectID string, secret []byte, now time.Time
 
 		// TaskCursor を設定
 		q.Cursor = &TaskCursor{
-			CreatedAt: createdAt,
-			ID:        payload.ID,
-			ProjectID: payload.ProjectID,
-			QHash:     payload.QHash,
-			IssuedAt:  payload.IssuedAt,
+			CreatedAt:  createdAt,
+			ID:         payload.ID,
+			ProjectID:  payload.ProjectID,
+			QHash:      payload.QHash,
+			IssuedAt:   payload.IssuedAt,
+			Descending: payload.Dir == CursorDirectionDesc,
 		}
 
 		return nil
 	}
 }
+
+// KeysetDescending は created_at DESC, id DESC（新しい順）の keyset で取得するかどうかを返す。
+// cursor がある場合は cursor の向きに従い、無い場合は sort=-createdAt だけを指定したときに true を返す。
+func (q *TaskQuery) KeysetDescending() bool {
+	if q.Cursor != nil {
+		return q.Cursor.Descending
+	}
+	return len(q.SortOrders) == 1 && q.SortOrders[0] == SortOrder{Key: "createdAt", Direction: SortDirectionDESC}
+}
+
+// NextCursorPayload は last（ページの最後のタスク）の次から同じ条件・同じ向きで続きを取得する cursor の payload を返す。
+func (q *TaskQuery) NextCursorPayload(projectID string, last *Task, now time.Time) CursorPayload {
+	payload := CursorPayload{
+		V:         1,
+		CreatedAt: FormatCursorCreatedAt(last.CreatedAt),
+		ID:        last.ID,
+		ProjectID: projectID,
+		QHash:     q.ComputeQHash(projectID),
+		QV:        QHashVersion,
+		IssuedAt:  now.Unix(),
+	}
+	if q.KeysetDescending() {
+		payload.Dir = CursorDirectionDesc
+	}
+	return payload
+}
@@ -0,0 +1,48 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+// BenchmarkNewTaskQuery は一覧のリクエストごとに行う TaskQuery の組み立て（フィルタの正規化・cursor の検証）を計測する。
+func BenchmarkNewTaskQuery(b *testing.B) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	filters := func() []TaskQueryOption {
+		return []TaskQueryOption{
+			WithWorkspace("ws-1"),
+			WithStatusFilter("todo,doing"),
+			WithPriorityFilter("high,medium"),
+			WithAssigneeIDFilter("user-1"),
+			WithDueDateRangeFilter("2025-01-01", "2025-03-31"),
+			WithQueryFilter("bug"),
+			WithSort("-priority,dueDate"),
+			WithLimit(50),
+		}
+	}
+	first, err := NewTaskQuery(filters()...)
+	if err != nil {
+		b.Fatalf("failed to create query: %v", err)
+	}
+	cursor, err := EncodeCursor(first.NextCursorPayload("proj-1", &Task{ID: "task-1", CreatedAt: now}, now), testCursorSecret)
+	if err != nil {
+		b.Fatalf("failed to encode cursor: %v", err)
+	}
+
+	b.Run("filters", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := NewTaskQuery(filters()...); err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+	b.Run("filters_with_cursor", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := NewTaskQuery(append(filters(), WithCursor(cursor, "proj-1", testCursorSecret, now))...); err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+}
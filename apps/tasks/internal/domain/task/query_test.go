@@ -1,6 +1,7 @@
 package task
 
 import (
+	"errors"
 	"testing"
 	"time"
 )
@@ -208,6 +209,61 @@ func TestNewTaskQuery_Limit(t *testing.T) {
 	}
 }
 
+func TestNewTaskQuery_PageLimits(t *testing.T) {
+	limits := PageLimits{Default: 50, Max: 100}
+	tests := []struct {
+		name  string
+		input int
+		want  int
+	}{
+		{name: "omitted", input: 0, want: 50},
+		{name: "within max", input: 80, want: 80},
+		{name: "over max", input: 150, want: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := NewTaskQuery(WithLimit(tt.input), WithPageLimits(limits))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if q.Limit != tt.want {
+				t.Errorf("Limit = %d, want %d", q.Limit, tt.want)
+			}
+			if err := q.Validate(); err != nil {
+				t.Errorf("unexpected validation error: %v", err)
+			}
+		})
+	}
+
+	// 上限を超える Limit を直接設定した場合は Validate で拒否する
+	q, _ := NewTaskQuery(WithPageLimits(limits))
+	q.Limit = 101
+	if err := q.Validate(); !errors.Is(err, ErrLimitOutOfRange) {
+		t.Errorf("expected ErrLimitOutOfRange, got %v", err)
+	}
+}
+
+func TestPageLimits_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  PageLimits
+		wantErr bool
+	}{
+		{name: "default", limits: DefaultPageLimits},
+		{name: "default equals max", limits: PageLimits{Default: 500, Max: 500}},
+		{name: "zero max", limits: PageLimits{Default: 0, Max: 0}, wantErr: true},
+		{name: "zero default", limits: PageLimits{Default: 0, Max: 100}, wantErr: true},
+		{name: "default over max", limits: PageLimits{Default: 200, Max: 100}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.limits.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestNewTaskQuery_Sort(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -363,3 +419,189 @@ func TestNewTaskQuery_DueDateRange(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskQuery_ComputeQHash_Canonical(t *testing.T) {
+	q1, err := NewTaskQuery(WithStatusFilter("todo,done"), WithPriorityFilter("high,low"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	q2, err := NewTaskQuery(WithPriorityFilter("low,high"), WithStatusFilter("done,todo"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if q1.ComputeQHash("proj-1") != q2.ComputeQHash("proj-1") {
+		t.Errorf("expected same qhash regardless of value/option order")
+	}
+	if q1.ComputeQHash("proj-1") == q1.ComputeQHash("proj-2") {
+		t.Errorf("expected different qhash for different projectId")
+	}
+
+	// 区切り文字を含む q が別条件と衝突しないこと
+	q3, err := NewTaskQuery(WithQueryFilter("a&status=todo"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	q4, err := NewTaskQuery(WithQueryFilter("a"), WithStatusFilter("todo"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q3.CanonicalQuery("proj-1") == q4.CanonicalQuery("proj-1") {
+		t.Errorf("expected escaped canonical form, got collision: %s", q3.CanonicalQuery("proj-1"))
+	}
+
+	want := "priority=high%2Clow&projectId=proj-1&qv=6&status=done%2Ctodo"
+	if got := q1.CanonicalQuery("proj-1"); got != want {
+		t.Errorf("CanonicalQuery() = %s, want %s", got, want)
+	}
+}
+
+func TestComputeQHash_Workspace(t *testing.T) {
+	ws1, err := NewTaskQuery(WithWorkspace("ws-1"), WithStatusFilter("todo"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ws2, err := NewTaskQuery(WithWorkspace("ws-2"), WithStatusFilter("todo"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ws1.ComputeQHash("proj-1") == ws2.ComputeQHash("proj-1") {
+		t.Error("expected different qhash for different workspaceId")
+	}
+
+	// 別のワークスペースで発行された cursor は使えない
+	secret := []byte("test-secret")
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	cursor, err := EncodeCursor(CursorPayload{
+		V:         1,
+		CreatedAt: FormatCursorCreatedAt(now),
+		ID:        "task-1",
+		ProjectID: "proj-1",
+		QHash:     ws1.ComputeQHash("proj-1"),
+		QV:        QHashVersion,
+		IssuedAt:  now.Unix(),
+	}, secret)
+	if err != nil {
+		t.Fatalf("failed to encode cursor: %v", err)
+	}
+	if _, err := NewTaskQuery(WithWorkspace("ws-1"), WithStatusFilter("todo"), WithCursor(cursor, "proj-1", secret, now)); err != nil {
+		t.Errorf("expected cursor to be accepted in the same workspace, got %v", err)
+	}
+	if _, err := NewTaskQuery(WithWorkspace("ws-2"), WithStatusFilter("todo"), WithCursor(cursor, "proj-1", secret, now)); !errors.Is(err, ErrCursorQueryMismatch) {
+		t.Errorf("expected ErrCursorQueryMismatch for another workspace, got %v", err)
+	}
+}
+
+func TestWithCursor_QHashVersionMismatch(t *testing.T) {
+	secret := []byte("test-secret")
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	base, err := NewTaskQuery()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	newCursor := func(qv int) string {
+		c, err := EncodeCursor(CursorPayload{
+			V:         1,
+			CreatedAt: FormatCursorCreatedAt(now),
+			ID:        "task-1",
+			ProjectID: "proj-1",
+			QHash:     base.ComputeQHash("proj-1"),
+			QV:        qv,
+			IssuedAt:  now.Unix(),
+		}, secret)
+		if err != nil {
+			t.Fatalf("failed to encode cursor: %v", err)
+		}
+		return c
+	}
+
+	if _, err := NewTaskQuery(WithCursor(newCursor(QHashVersion), "proj-1", secret, now)); err != nil {
+		t.Fatalf("expected current qv to be accepted, got %v", err)
+	}
+
+	for _, qv := range []int{0, QHashVersion - 1, QHashVersion + 1} {
+		_, err := NewTaskQuery(WithCursor(newCursor(qv), "proj-1", secret, now))
+		if !errors.Is(err, ErrCursorQueryMismatch) {
+			t.Errorf("qv=%d: expected ErrCursorQueryMismatch, got %v", qv, err)
+		}
+	}
+}
+
+func TestWithCursor_Direction(t *testing.T) {
+	secret := []byte("test-secret")
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	last := &Task{ID: "task-1", CreatedAt: now.Add(-time.Hour)}
+
+	// sort=-createdAt の 1 ページ目から作った cursor は新しい順の続きを指す
+	first, err := NewTaskQuery(WithSort("-createdAt"), WithWorkspace("ws-1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !first.KeysetDescending() {
+		t.Fatal("expected sort=-createdAt to use the descending keyset")
+	}
+	payload := first.NextCursorPayload("proj-1", last, now)
+	if payload.Dir != CursorDirectionDesc || payload.ID != "task-1" || payload.IssuedAt != now.Unix() {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+	cursor, err := EncodeCursor(payload, secret)
+	if err != nil {
+		t.Fatalf("failed to encode cursor: %v", err)
+	}
+	next, err := NewTaskQuery(WithWorkspace("ws-1"), WithCursor(cursor, "proj-1", secret, now))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !next.Cursor.Descending || !next.KeysetDescending() {
+		t.Errorf("expected the cursor to keep the descending order, got %+v", next.Cursor)
+	}
+	if p := next.NextCursorPayload("proj-1", last, now); p.Dir != CursorDirectionDesc {
+		t.Errorf("expected the next cursor to stay descending, got %q", p.Dir)
+	}
+
+	// 複数キーや他のキーの sort、sort なしは昇順の cursor
+	for _, sortStr := range []string{"", "createdAt", "-createdAt,priority", "-updatedAt"} {
+		q, _ := NewTaskQuery(WithSort(sortStr))
+		if q.KeysetDescending() || q.NextCursorPayload("proj-1", last, now).Dir != "" {
+			t.Errorf("sort=%q: expected an ascending cursor", sortStr)
+		}
+	}
+
+	// 不明な向きは形式不正
+	payload.Dir = "sideways"
+	invalid, _ := EncodeCursor(payload, secret)
+	if _, err := NewTaskQuery(WithWorkspace("ws-1"), WithCursor(invalid, "proj-1", secret, now)); !errors.Is(err, ErrCursorInvalidFormat) {
+		t.Errorf("expected ErrCursorInvalidFormat, got %v", err)
+	}
+}
+
+func TestTaskQuery_MatchCursor(t *testing.T) {
+	q, err := NewTaskQuery(WithWorkspace("ws-1"), WithStatusFilter("todo"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	payload := CursorPayload{ProjectID: "proj-1", QHash: q.ComputeQHash("proj-1"), QV: QHashVersion}
+
+	if m := q.MatchCursor(payload, "proj-1"); !m.Matches() || m.CanonicalQuery != q.CanonicalQuery("proj-1") {
+		t.Errorf("expected the same query to match, got %+v", m)
+	}
+	if m := q.MatchCursor(payload, "proj-2"); m.ProjectIDMatches || m.QHashMatches || !m.QVMatches || m.Matches() {
+		t.Errorf("expected projectId and qhash mismatch, got %+v", m)
+	}
+
+	other, err := NewTaskQuery(WithWorkspace("ws-1"), WithStatusFilter("done"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m := other.MatchCursor(payload, "proj-1"); !m.ProjectIDMatches || m.QHashMatches {
+		t.Errorf("expected qhash mismatch for another filter, got %+v", m)
+	}
+
+	old := payload
+	old.QV = QHashVersion - 1
+	if m := q.MatchCursor(old, "proj-1"); m.QVMatches || !m.QHashMatches {
+		t.Errorf("expected qv mismatch, got %+v", m)
+	}
+}
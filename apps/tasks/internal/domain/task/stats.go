@@ -0,0 +1,135 @@
+package task
+
+import (
+	"sort"
+	"time"
+)
+
+// ProjectStats はプロジェクトのタスクの集計（ダッシュボードのプロジェクトカード用）。
+type ProjectStats struct {
+	Open           int        // 未完了（todo / in_progress）のタスク数
+	Done           int        // 完了したタスク数
+	Overdue        int        // 期限を過ぎた未完了のタスク数
+	LastActivityAt *time.Time // タスクの最終更新日時。タスクが無い場合は nil
+}
+
+// ComputeProjectStats は tasks を now 時点で集計する。期限切れは now のタイムゾーンでの日付で判定する（IsOverdue）。
+func ComputeProjectStats(tasks []*Task, now time.Time) ProjectStats {
+	var stats ProjectStats
+	for _, t := range tasks {
+		if t.Status == StatusDone {
+			stats.Done++
+		} else {
+			stats.Open++
+			if t.DueDate != nil && IsOverdue(*t.DueDate, now) {
+				stats.Overdue++
+			}
+		}
+		if stats.LastActivityAt == nil || t.UpdatedAt.After(*stats.LastActivityAt) {
+			updatedAt := t.UpdatedAt
+			stats.LastActivityAt = &updatedAt
+		}
+	}
+	return stats
+}
+
+// MilestoneStats はマイルストーンに属するタスクの集計（マイルストーンの進捗表示用）。
+type MilestoneStats struct {
+	MilestoneID string
+	Open        int // 未完了（todo / in_progress）のタスク数
+	Done        int // 完了したタスク数
+}
+
+// ComputeMilestoneStats は tasks をマイルストーンごとに集計し、MilestoneID 順で返す。
+// マイルストーンが設定されていないタスクは含めない。
+func ComputeMilestoneStats(tasks []*Task) []MilestoneStats {
+	byID := make(map[string]*MilestoneStats)
+	for _, t := range tasks {
+		if t.MilestoneID == nil {
+			continue
+		}
+		s, ok := byID[*t.MilestoneID]
+		if !ok {
+			s = &MilestoneStats{MilestoneID: *t.MilestoneID}
+			byID[*t.MilestoneID] = s
+		}
+		if t.Status == StatusDone {
+			s.Done++
+		} else {
+			s.Open++
+		}
+	}
+
+	out := make([]MilestoneStats, 0, len(byID))
+	for _, s := range byID {
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].MilestoneID < out[j].MilestoneID })
+	return out
+}
+
+// EpicStats はエピックに属するタスクの集計（エピックの進捗表示用）。
+type EpicStats struct {
+	EpicID        string
+	Total         int // タスク数
+	Done          int // 完了したタスク数
+	EstimateTotal int // 見積もりの合計（未見積もりのタスクは 0 として扱う）
+	EstimateDone  int // 完了したタスクの見積もりの合計
+}
+
+// ComputeEpicStats は tasks をエピックごとに集計し、EpicID 順で返す。
+// エピックが設定されていないタスクは含めない。
+func ComputeEpicStats(tasks []*Task) []EpicStats {
+	byID := make(map[string]*EpicStats)
+	for _, t := range tasks {
+		if t.EpicID == nil {
+			continue
+		}
+		s, ok := byID[*t.EpicID]
+		if !ok {
+			s = &EpicStats{EpicID: *t.EpicID}
+			byID[*t.EpicID] = s
+		}
+		estimate := 0
+		if t.Estimate != nil {
+			estimate = *t.Estimate
+		}
+		s.Total++
+		s.EstimateTotal += estimate
+		if t.Status == StatusDone {
+			s.Done++
+			s.EstimateDone += estimate
+		}
+	}
+
+	out := make([]EpicStats, 0, len(byID))
+	for _, s := range byID {
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].EpicID < out[j].EpicID })
+	return out
+}
+
+// LabelStats はラベルごとのタスク数（ラベルの使用数の表示用）。
+type LabelStats struct {
+	LabelID string
+	Count   int // ラベルが付いたタスク数
+}
+
+// ComputeLabelStats は tasks をラベルごとに数え、LabelID 順で返す。
+// ラベルが 1 つも付いていないタスクは含めない。
+func ComputeLabelStats(tasks []*Task) []LabelStats {
+	counts := make(map[string]int)
+	for _, t := range tasks {
+		for _, id := range t.LabelIDs {
+			counts[id]++
+		}
+	}
+
+	out := make([]LabelStats, 0, len(counts))
+	for id, n := range counts {
+		out = append(out, LabelStats{LabelID: id, Count: n})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].LabelID < out[j].LabelID })
+	return out
+}
@@ -0,0 +1,104 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeProjectStats(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-24 * time.Hour)
+	future := now.Add(24 * time.Hour)
+	lastUpdate := now.Add(-time.Hour)
+
+	tasks := []*Task{
+		{ID: "t1", Status: StatusTodo, DueDate: &past, UpdatedAt: now.Add(-3 * time.Hour)},
+		{ID: "t2", Status: StatusInProgress, DueDate: &future, UpdatedAt: lastUpdate},
+		{ID: "t3", Status: StatusDone, DueDate: &past, UpdatedAt: now.Add(-2 * time.Hour)},
+		{ID: "t4", Status: StatusTodo, UpdatedAt: now.Add(-5 * time.Hour)},
+	}
+
+	got := ComputeProjectStats(tasks, now)
+	if got.Open != 3 || got.Done != 1 || got.Overdue != 1 {
+		t.Errorf("unexpected counts: %+v", got)
+	}
+	if got.LastActivityAt == nil || !got.LastActivityAt.Equal(lastUpdate) {
+		t.Errorf("expected lastActivityAt=%v, got %v", lastUpdate, got.LastActivityAt)
+	}
+}
+
+func TestComputeProjectStats_Empty(t *testing.T) {
+	got := ComputeProjectStats(nil, time.Now())
+	if got.Open != 0 || got.Done != 0 || got.Overdue != 0 || got.LastActivityAt != nil {
+		t.Errorf("expected zero stats, got %+v", got)
+	}
+}
+
+func TestComputeMilestoneStats(t *testing.T) {
+	m1, m2 := "m-1", "m-2"
+	tasks := []*Task{
+		{ID: "t1", Status: StatusTodo, MilestoneID: &m2},
+		{ID: "t2", Status: StatusDone, MilestoneID: &m2},
+		{ID: "t3", Status: StatusInProgress, MilestoneID: &m1},
+		{ID: "t4", Status: StatusDone},
+	}
+
+	got := ComputeMilestoneStats(tasks)
+	want := []MilestoneStats{
+		{MilestoneID: "m-1", Open: 1, Done: 0},
+		{MilestoneID: "m-2", Open: 1, Done: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d milestones, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("stats[%d]: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestComputeEpicStats(t *testing.T) {
+	e1, e2 := "e-1", "e-2"
+	three, five := 3, 5
+	tasks := []*Task{
+		{ID: "t1", Status: StatusTodo, EpicID: &e2, Estimate: &three},
+		{ID: "t2", Status: StatusDone, EpicID: &e2, Estimate: &five},
+		{ID: "t3", Status: StatusDone, EpicID: &e2},
+		{ID: "t4", Status: StatusInProgress, EpicID: &e1, Estimate: &five},
+		{ID: "t5", Status: StatusDone, Estimate: &three},
+	}
+
+	got := ComputeEpicStats(tasks)
+	want := []EpicStats{
+		{EpicID: "e-1", Total: 1, Done: 0, EstimateTotal: 5, EstimateDone: 0},
+		{EpicID: "e-2", Total: 3, Done: 2, EstimateTotal: 8, EstimateDone: 5},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d epics, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("stats[%d]: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestComputeLabelStats(t *testing.T) {
+	tasks := []*Task{
+		{ID: "t1", LabelIDs: []string{"l-2", "l-1"}},
+		{ID: "t2", LabelIDs: []string{"l-2"}},
+		{ID: "t3"},
+	}
+
+	got := ComputeLabelStats(tasks)
+	want := []LabelStats{{LabelID: "l-1", Count: 1}, {LabelID: "l-2", Count: 2}}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d labels, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("stats[%d]: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package task
+
+import (
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// SyncTokenVersion は同期トークンの payload の形式のバージョン。
+const SyncTokenVersion = 1
+
+// SyncPosition は差分同期の位置。(Seq, TaskID) 以前の変更は同期済みを表す。
+// TaskID が空の場合は Seq 未満の変更をすべて同期済みとする（Seq 以上の変更から返す）。
+type SyncPosition struct {
+	Seq    int64
+	TaskID string
+}
+
+// SyncTokenPayload は同期トークンの payload を表す。cursor と同じく base64url(payload).base64url(HMAC) の形式にする。
+type SyncTokenPayload struct {
+	V         int    `json:"v"`
+	ProjectID string `json:"projectId"`
+	Seq       int64  `json:"seq"`
+	// TaskID と Until は変更の途中（hasMore）のトークンの場合のみ設定する。Until はその同期で返す変更の上限（未満）
+	TaskID   string `json:"taskId,omitempty"`
+	Until    int64  `json:"until,omitempty"`
+	IssuedAt int64  `json:"iat"`
+}
+
+// Position は payload の同期の位置を返す。
+func (p *SyncTokenPayload) Position() SyncPosition {
+	return SyncPosition{Seq: p.Seq, TaskID: p.TaskID}
+}
+
+// TaskChange は差分同期で返すタスクの変更。
+type TaskChange struct {
+	// Seq は変更の順序（大きいほど新しい）
+	Seq    int64
+	TaskID string
+	// Task は変更後のタスク。削除されたタスクは nil
+	Task *Task
+	// DeletedAt は Task が nil の場合の削除日時
+	DeletedAt time.Time
+}
+
+// SortTaskChanges は changes を変更の順序（Seq, TaskID）に並べる。
+func SortTaskChanges(changes []TaskChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Seq != changes[j].Seq {
+			return changes[i].Seq < changes[j].Seq
+		}
+		return changes[i].TaskID < changes[j].TaskID
+	})
+}
+
+// syncTokenSignature は同期トークンの署名を返す。一覧の cursor と同じ secret を使うため、
+// 署名する値に接頭辞を付けて、cursor を同期トークンとして（またはその逆に）受け付けないようにする。
+func syncTokenSignature(encodedPayload string, secret []byte) []byte {
+	return signPayload("sync."+encodedPayload, secret)
+}
+
+// EncodeSyncToken は同期トークンをエンコードする（EncodeCursor と同じ形式）。
+func EncodeSyncToken(payload SyncTokenPayload, secret []byte) (string, error) {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal sync token: %w", err)
+	}
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payloadJSON)
+	return encodedPayload + "." + base64.RawURLEncoding.EncodeToString(syncTokenSignature(encodedPayload, secret)), nil
+}
+
+// DecodeSyncToken は同期トークンをデコードし、署名を検証する。
+// 形式・署名・バージョンが不正な場合は ErrSyncTokenInvalid を返す。
+func DecodeSyncToken(token string, secret []byte) (*SyncTokenPayload, error) {
+	if len(token) > MaxCursorLength {
+		return nil, fmt.Errorf("%w: token exceeds %d bytes", ErrSyncTokenInvalid, MaxCursorLength)
+	}
+	encodedPayload, encodedSig, ok := strings.Cut(token, ".")
+	if !ok || encodedPayload == "" || strings.Contains(encodedSig, ".") {
+		return nil, ErrSyncTokenInvalid
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSig)
+	if err != nil || !hmac.Equal(sig, syncTokenSignature(encodedPayload, secret)) {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrSyncTokenInvalid)
+	}
+	payloadJSON, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil || !utf8.Valid(payloadJSON) {
+		return nil, fmt.Errorf("%w: malformed payload", ErrSyncTokenInvalid)
+	}
+	var payload SyncTokenPayload
+	if err := json.Unmarshal(payloadJSON, &payload); err != nil {
+		return nil, fmt.Errorf("%w: json unmarshal: %v", ErrSyncTokenInvalid, err)
+	}
+	if payload.V != SyncTokenVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrSyncTokenInvalid, payload.V)
+	}
+	return &payload, nil
+}
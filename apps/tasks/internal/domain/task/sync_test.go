@@ -0,0 +1,71 @@
+package task
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecodeSyncToken_RoundTrip(t *testing.T) {
+	want := SyncTokenPayload{V: SyncTokenVersion, ProjectID: "proj-1", Seq: 42, TaskID: "task-1", Until: 50, IssuedAt: 1735689600}
+	token, err := EncodeSyncToken(want, testCursorSecret)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := DecodeSyncToken(token, testCursorSecret)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *got != want {
+		t.Errorf("expected %+v, got %+v", want, *got)
+	}
+	if pos := got.Position(); pos != (SyncPosition{Seq: 42, TaskID: "task-1"}) {
+		t.Errorf("unexpected position: %+v", pos)
+	}
+}
+
+func TestDecodeSyncToken_Invalid(t *testing.T) {
+	valid, err := EncodeSyncToken(SyncTokenPayload{V: SyncTokenVersion, ProjectID: "proj-1", Seq: 1}, testCursorSecret)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	otherVersion, err := EncodeSyncToken(SyncTokenPayload{V: SyncTokenVersion + 1, ProjectID: "proj-1", Seq: 1}, testCursorSecret)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 一覧の cursor は同じ形式だが、同期トークンとしては受け付けない
+	cursor, err := EncodeCursor(testCursorPayload(), testCursorSecret)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := map[string]struct {
+		token  string
+		secret []byte
+	}{
+		"empty":          {token: "", secret: testCursorSecret},
+		"no signature":   {token: "eyJ2IjoxfQ", secret: testCursorSecret},
+		"too many parts": {token: valid + ".x", secret: testCursorSecret},
+		"other secret":   {token: valid, secret: []byte("other-secret")},
+		"tampered":       {token: "x" + valid, secret: testCursorSecret},
+		"other version":  {token: otherVersion, secret: testCursorSecret},
+		"list cursor":    {token: cursor, secret: testCursorSecret},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := DecodeSyncToken(tt.token, tt.secret); !errors.Is(err, ErrSyncTokenInvalid) {
+				t.Errorf("expected ErrSyncTokenInvalid, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSortTaskChanges(t *testing.T) {
+	changes := []TaskChange{{Seq: 3, TaskID: "a"}, {Seq: 1, TaskID: "b"}, {Seq: 1, TaskID: "a"}, {Seq: 2, TaskID: "c"}}
+	SortTaskChanges(changes)
+	want := []TaskChange{{Seq: 1, TaskID: "a"}, {Seq: 1, TaskID: "b"}, {Seq: 2, TaskID: "c"}, {Seq: 3, TaskID: "a"}}
+	for i := range want {
+		if changes[i].Seq != want[i].Seq || changes[i].TaskID != want[i].TaskID {
+			t.Fatalf("unexpected order: %+v", changes)
+		}
+	}
+}
@@ -1,8 +1,7 @@
 package task
 
 import (
-	"errors"
-	"fmt"
+	"strconv"
 	"time"
 )
 
@@ -26,7 +25,7 @@ func ParseStatus(s string) (TaskStatus, error) {
 	case StatusTodo, StatusInProgress, StatusDone:
 		return TaskStatus(s), nil
 	default:
-		return "", fmt.Errorf("invalid task status: %s", input)
+		return "", NewInvalidEnum("status", nil, &input)
 	}
 }
 
@@ -45,7 +44,7 @@ func ParsePriority(p string) (TaskPriority, error) {
 	case PriorityLow, PriorityMedium, PriorityHigh:
 		return TaskPriority(p), nil
 	default:
-		return "", fmt.Errorf("invalid task priority: %s", p)
+		return "", NewInvalidEnum("priority", nil, &p)
 	}
 }
 
@@ -70,18 +69,36 @@ func (p TaskPriority) CompareTo(other TaskPriority) int {
 // Task は TeamFlow におけるタスクのドメインモデル。
 type Task struct {
 	ID          string
+	WorkspaceID string // 所属するワークスペース（テナント）。保存時にリポジトリが context のワークスペースを設定する
 	ProjectID   string
+	Number      int // プロジェクト内の連番（TFLOW-123 の 123）。保存時にリポジトリが採番する。0 は未採番
 	Title       string
 	Description string
 	Status      TaskStatus
 	Priority    TaskPriority
 	AssigneeID  *string
-	DueDate     *time.Time
+	DueDate     *time.Time // 期日（日付のみ、DueDateOf で UTC の 0 時に正規化する）。nil は期日なし
+	StartDate   *time.Time
+	Estimate    *int     // 見積もり（ポイント等、単位はクライアント定義）。nil は未見積もり
+	MilestoneID *string  // 所属するマイルストーン（projects サービスで管理）。nil はマイルストーンなし
+	SprintID    *string  // 所属するスプリント（projects サービスで管理）。nil はバックログ
+	EpicID      *string  // 所属するエピック（projects サービスで管理）。nil はエピックなし
+	LabelIDs    []string // 付けられたラベル（projects サービスで管理）。空はラベルなし
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	CreatedBy   string // 作成者（X-User-ID）。空は不明
+	UpdatedBy   string // 最終更新者（X-User-ID）。空は不明
+	// ArchivedAt はプロジェクトの削除に伴ってアーカイブされた日時。nil はアーカイブされていない。
+	// アーカイブされたタスクは一覧・集計に含めない
+	ArchivedAt *time.Time
+	// Version はタスクの版数。作成時は 1 で、フィールドを変更するたびに 1 ずつ増える（PATCH の If-Match で指定する）
+	Version int
+	// FieldVersions はフィールド（API の JSON 名）ごとに最後に変更した Version。フィールド単位のマージ（CheckVersion）に使う
+	FieldVersions map[string]int
 }
 
 // NewTask は新しいタスクを生成する。
+// 入力が不正な場合は *ValidationError を返す。
 func NewTask(
 	id string,
 	projectID string,
@@ -93,7 +110,7 @@ func NewTask(
 	now time.Time,
 ) (*Task, error) {
 	if title == "" {
-		return nil, errors.New("task title must not be empty")
+		return nil, NewRequired("title", nil)
 	}
 
 	if err := validateStatus(status); err != nil {
@@ -104,6 +121,11 @@ func NewTask(
 		return nil, err
 	}
 
+	if dueDate != nil {
+		d := DueDateOf(*dueDate)
+		dueDate = &d
+	}
+
 	return &Task{
 		ID:          id,
 		ProjectID:   projectID,
@@ -114,23 +136,49 @@ func NewTask(
 		DueDate:     dueDate,
 		CreatedAt:   now,
 		UpdatedAt:   now,
+		Version:     1,
 	}, nil
 }
 
-func validateStatus(s TaskStatus) error {
-	if _, err := ParseStatus(string(s)); err != nil {
-		return errors.New("invalid task status")
+// MaxLabelsPerTask は 1 つのタスクに付けられるラベルの上限。
+const MaxLabelsPerTask = 20
+
+// NormalizeLabelIDs はラベル ID の一覧を検証し、重複を取り除いて返す（順序は最初の出現順）。
+// 空の ID を含む場合や上限を超える場合は *ValidationError を返す。空の一覧は nil を返す。
+func NormalizeLabelIDs(ids []string) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	seen := make(map[string]bool, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return nil, NewRequired("labelIds", nil)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	if len(out) > MaxLabelsPerTask {
+		rejected := strconv.Itoa(len(out))
+		return nil, NewInvalidRange("labelIds", nil, &rejected)
 	}
-	return nil
+	return out, nil
+}
+
+func validateStatus(s TaskStatus) error {
+	_, err := ParseStatus(string(s))
+	return err
 }
 
 func validatePriority(p TaskPriority) error {
-	if _, err := ParsePriority(string(p)); err != nil {
-		return errors.New("invalid task priority")
-	}
-	return nil
+	_, err := ParsePriority(string(p))
+	return err
 }
 
-func (t *Task) TouchUpdatedAt() {
-	t.UpdatedAt = time.Now()
+// TouchUpdatedAt は UpdatedAt を now にする。
+func (t *Task) TouchUpdatedAt(now time.Time) {
+	t.UpdatedAt = now
 }
@@ -1,7 +1,11 @@
 package task
 
-import "time"
+import (
+	"strconv"
+	"time"
+)
 
+// TaskPatch は Task の部分更新内容。変更可能なフィールドはすべて Patch[T] で表す。
 type TaskPatch struct {
 	Title       Patch[string]
 	Description Patch[string]
@@ -9,9 +13,40 @@ type TaskPatch struct {
 	Priority    Patch[TaskPriority]
 	AssigneeID  Patch[string]
 	DueDate     Patch[time.Time]
+	StartDate   Patch[time.Time]
+	Estimate    Patch[int]
+	MilestoneID Patch[string]
+	SprintID    Patch[string]
+	EpicID      Patch[string]
+	LabelIDs    Patch[[]string]
 }
 
-func (t *Task) ApplyPatch(p TaskPatch) error {
+// Fields は指定された（未指定以外の）フィールドを API の JSON 名で返す。監査ログの変更フィールドに使う。
+func (p TaskPatch) Fields() []string {
+	var fields []string
+	add := func(name string, isSet bool) {
+		if isSet {
+			fields = append(fields, name)
+		}
+	}
+	add("title", p.Title.IsSet)
+	add("description", p.Description.IsSet)
+	add("status", p.Status.IsSet)
+	add("priority", p.Priority.IsSet)
+	add("assigneeId", p.AssigneeID.IsSet)
+	add("dueDate", p.DueDate.IsSet)
+	add("startDate", p.StartDate.IsSet)
+	add("estimate", p.Estimate.IsSet)
+	add("milestoneId", p.MilestoneID.IsSet)
+	add("sprintId", p.SprintID.IsSet)
+	add("epicId", p.EpicID.IsSet)
+	add("labelIds", p.LabelIDs.IsSet)
+	return fields
+}
+
+// ApplyPatch は指定されたフィールドのみを検証・反映し、版数を進めて UpdatedAt を now にする。
+// いずれかのフィールドが不正な場合は *ValidationError を返す。
+func (t *Task) ApplyPatch(p TaskPatch, now time.Time) error {
 	if err := t.applyStatusPatch(p.Status); err != nil {
 		return err
 	}
@@ -30,7 +65,26 @@ func (t *Task) ApplyPatch(p TaskPatch) error {
 	if err := t.applyDueDatePatch(p.DueDate); err != nil {
 		return err
 	}
-	t.TouchUpdatedAt()
+	if err := t.applyStartDatePatch(p.StartDate); err != nil {
+		return err
+	}
+	if err := t.applyEstimatePatch(p.Estimate); err != nil {
+		return err
+	}
+	if err := t.applyMilestoneIDPatch(p.MilestoneID); err != nil {
+		return err
+	}
+	if err := t.applySprintIDPatch(p.SprintID); err != nil {
+		return err
+	}
+	if err := t.applyEpicIDPatch(p.EpicID); err != nil {
+		return err
+	}
+	if err := t.applyLabelIDsPatch(p.LabelIDs); err != nil {
+		return err
+	}
+	t.RecordChange(p.Fields()...)
+	t.TouchUpdatedAt(now)
 	return nil
 }
 
@@ -39,10 +93,10 @@ func (t *Task) applyStatusPatch(p Patch[TaskStatus]) error {
 		return nil
 	}
 	if p.IsNull {
-		return ErrInvalidPatch("status cannot be null")
+		return NewRequired("status", nil)
 	}
 	if err := validateStatus(p.Value); err != nil {
-		return ErrInvalidPatch(err.Error())
+		return err
 	}
 	t.Status = p.Value
 	return nil
@@ -53,21 +107,21 @@ func (t *Task) applyPriorityPatch(p Patch[TaskPriority]) error {
 		return nil
 	}
 	if p.IsNull {
-		return ErrInvalidPatch("priority cannot be null")
+		return NewRequired("priority", nil)
 	}
 	if err := validatePriority(p.Value); err != nil {
-		return ErrInvalidPatch(err.Error())
+		return err
 	}
 	t.Priority = p.Value
 	return nil
 }
 
 func (t *Task) applyTitlePatch(p Patch[string]) error {
-	if !p.IsSet || !p.HasValue() {
+	if !p.IsSet {
 		return nil
 	}
-	if p.Value == "" {
-		return ErrInvalidPatch("task title must not be empty")
+	if p.IsNull || p.Value == "" {
+		return NewRequired("title", nil)
 	}
 	t.Title = p.Value
 	return nil
@@ -104,7 +158,98 @@ func (t *Task) applyDueDatePatch(p Patch[time.Time]) error {
 	if p.IsNull {
 		t.DueDate = nil
 	} else {
-		t.DueDate = &p.Value
+		d := DueDateOf(p.Value)
+		t.DueDate = &d
+	}
+	return nil
+}
+
+func (t *Task) applyStartDatePatch(p Patch[time.Time]) error {
+	if !p.IsSet {
+		return nil
+	}
+	if p.IsNull {
+		t.StartDate = nil
+	} else {
+		t.StartDate = &p.Value
+	}
+	return nil
+}
+
+func (t *Task) applyEstimatePatch(p Patch[int]) error {
+	if !p.IsSet {
+		return nil
+	}
+	if p.IsNull {
+		t.Estimate = nil
+		return nil
+	}
+	if p.Value < 0 {
+		rejected := strconv.Itoa(p.Value)
+		return NewInvalidRange("estimate", nil, &rejected)
+	}
+	t.Estimate = &p.Value
+	return nil
+}
+
+func (t *Task) applyMilestoneIDPatch(p Patch[string]) error {
+	if !p.IsSet {
+		return nil
+	}
+	if p.IsNull {
+		t.MilestoneID = nil
+		return nil
+	}
+	if p.Value == "" {
+		return NewRequired("milestoneId", nil)
+	}
+	t.MilestoneID = &p.Value
+	return nil
+}
+
+func (t *Task) applySprintIDPatch(p Patch[string]) error {
+	if !p.IsSet {
+		return nil
+	}
+	if p.IsNull {
+		t.SprintID = nil
+		return nil
+	}
+	if p.Value == "" {
+		return NewRequired("sprintId", nil)
+	}
+	t.SprintID = &p.Value
+	return nil
+}
+
+func (t *Task) applyEpicIDPatch(p Patch[string]) error {
+	if !p.IsSet {
+		return nil
+	}
+	if p.IsNull {
+		t.EpicID = nil
+		return nil
+	}
+	if p.Value == "" {
+		return NewRequired("epicId", nil)
+	}
+	t.EpicID = &p.Value
+	return nil
+}
+
+// applyLabelIDsPatch はラベルを指定された一覧で置き換える。null と空配列はどちらもラベルを外す。
+func (t *Task) applyLabelIDsPatch(p Patch[[]string]) error {
+	if !p.IsSet {
+		return nil
+	}
+	if p.IsNull {
+		t.LabelIDs = nil
+		return nil
+	}
+	ids, err := NormalizeLabelIDs(p.Value)
+	if err != nil {
+		return err
 	}
+	t.LabelIDs = ids
 	return nil
 }
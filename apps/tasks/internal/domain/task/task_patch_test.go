@@ -0,0 +1,320 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newPatchTestTask(t *testing.T) *Task {
+	t.Helper()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	task, err := NewTask("task-1", "proj-1", "title", "desc", StatusTodo, PriorityMedium, nil, now)
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	return task
+}
+
+// patchNow は ApplyPatch に渡す更新時刻。
+var patchNow = time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+
+func TestTask_ApplyPatch(t *testing.T) {
+	startDate := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	dueDate := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		patch     TaskPatch
+		check     func(t *testing.T, task *Task)
+		wantField string
+		wantCode  string
+	}{
+		{
+			name:  "all unset keeps values",
+			patch: TaskPatch{},
+			check: func(t *testing.T, task *Task) {
+				if task.Title != "title" || task.Description != "desc" || task.Status != StatusTodo || task.Priority != PriorityMedium {
+					t.Errorf("unexpected change: %+v", task)
+				}
+			},
+		},
+		{
+			name:  "title value",
+			patch: TaskPatch{Title: Set("new title")},
+			check: func(t *testing.T, task *Task) {
+				if task.Title != "new title" {
+					t.Errorf("Title = %q, want %q", task.Title, "new title")
+				}
+			},
+		},
+		{
+			name:      "title null is rejected",
+			patch:     TaskPatch{Title: Null[string]()},
+			wantField: "title",
+			wantCode:  "REQUIRED",
+		},
+		{
+			name:  "description null clears",
+			patch: TaskPatch{Description: Null[string]()},
+			check: func(t *testing.T, task *Task) {
+				if task.Description != "" {
+					t.Errorf("Description = %q, want empty", task.Description)
+				}
+			},
+		},
+		{
+			name:  "status value",
+			patch: TaskPatch{Status: Set(StatusDone)},
+			check: func(t *testing.T, task *Task) {
+				if task.Status != StatusDone {
+					t.Errorf("Status = %q, want %q", task.Status, StatusDone)
+				}
+			},
+		},
+		{
+			name:      "status null is rejected",
+			patch:     TaskPatch{Status: Null[TaskStatus]()},
+			wantField: "status",
+			wantCode:  "REQUIRED",
+		},
+		{
+			name:      "priority null is rejected",
+			patch:     TaskPatch{Priority: Null[TaskPriority]()},
+			wantField: "priority",
+			wantCode:  "REQUIRED",
+		},
+		{
+			name:  "assigneeId value",
+			patch: TaskPatch{AssigneeID: Set("user-1")},
+			check: func(t *testing.T, task *Task) {
+				if task.AssigneeID == nil || *task.AssigneeID != "user-1" {
+					t.Errorf("AssigneeID = %v, want user-1", task.AssigneeID)
+				}
+			},
+		},
+		{
+			name:  "dueDate value",
+			patch: TaskPatch{DueDate: Set(dueDate)},
+			check: func(t *testing.T, task *Task) {
+				if task.DueDate == nil || !task.DueDate.Equal(dueDate) {
+					t.Errorf("DueDate = %v, want %v", task.DueDate, dueDate)
+				}
+			},
+		},
+		{
+			name:  "startDate value",
+			patch: TaskPatch{StartDate: Set(startDate)},
+			check: func(t *testing.T, task *Task) {
+				if task.StartDate == nil || !task.StartDate.Equal(startDate) {
+					t.Errorf("StartDate = %v, want %v", task.StartDate, startDate)
+				}
+			},
+		},
+		{
+			name:  "estimate value",
+			patch: TaskPatch{Estimate: Set(3)},
+			check: func(t *testing.T, task *Task) {
+				if task.Estimate == nil || *task.Estimate != 3 {
+					t.Errorf("Estimate = %v, want 3", task.Estimate)
+				}
+			},
+		},
+		{
+			name:  "estimate zero is allowed",
+			patch: TaskPatch{Estimate: Set(0)},
+			check: func(t *testing.T, task *Task) {
+				if task.Estimate == nil || *task.Estimate != 0 {
+					t.Errorf("Estimate = %v, want 0", task.Estimate)
+				}
+			},
+		},
+		{
+			name:      "estimate negative is rejected",
+			patch:     TaskPatch{Estimate: Set(-1)},
+			wantField: "estimate",
+			wantCode:  "INVALID_RANGE",
+		},
+		{
+			name:  "milestoneId value",
+			patch: TaskPatch{MilestoneID: Set("m-1")},
+			check: func(t *testing.T, task *Task) {
+				if task.MilestoneID == nil || *task.MilestoneID != "m-1" {
+					t.Errorf("MilestoneID = %v, want m-1", task.MilestoneID)
+				}
+			},
+		},
+		{
+			name:      "milestoneId empty is rejected",
+			patch:     TaskPatch{MilestoneID: Set("")},
+			wantField: "milestoneId",
+			wantCode:  "REQUIRED",
+		},
+		{
+			name:  "sprintId value",
+			patch: TaskPatch{SprintID: Set("s-1")},
+			check: func(t *testing.T, task *Task) {
+				if task.SprintID == nil || *task.SprintID != "s-1" {
+					t.Errorf("SprintID = %v, want s-1", task.SprintID)
+				}
+			},
+		},
+		{
+			name:      "sprintId empty is rejected",
+			patch:     TaskPatch{SprintID: Set("")},
+			wantField: "sprintId",
+			wantCode:  "REQUIRED",
+		},
+		{
+			name:  "epicId value",
+			patch: TaskPatch{EpicID: Set("e-1")},
+			check: func(t *testing.T, task *Task) {
+				if task.EpicID == nil || *task.EpicID != "e-1" {
+					t.Errorf("EpicID = %v, want e-1", task.EpicID)
+				}
+			},
+		},
+		{
+			name:      "epicId empty is rejected",
+			patch:     TaskPatch{EpicID: Set("")},
+			wantField: "epicId",
+			wantCode:  "REQUIRED",
+		},
+		{
+			name:  "labelIds are deduplicated",
+			patch: TaskPatch{LabelIDs: Set([]string{"l-2", "l-1", "l-2"})},
+			check: func(t *testing.T, task *Task) {
+				if strings.Join(task.LabelIDs, ",") != "l-2,l-1" {
+					t.Errorf("LabelIDs = %v, want [l-2 l-1]", task.LabelIDs)
+				}
+			},
+		},
+		{
+			name:      "labelIds empty id is rejected",
+			patch:     TaskPatch{LabelIDs: Set([]string{"l-1", ""})},
+			wantField: "labelIds",
+			wantCode:  "REQUIRED",
+		},
+		{
+			name:      "labelIds over limit is rejected",
+			patch:     TaskPatch{LabelIDs: Set(manyLabelIDs(MaxLabelsPerTask + 1))},
+			wantField: "labelIds",
+			wantCode:  "INVALID_RANGE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := newPatchTestTask(t)
+
+			err := task.ApplyPatch(tt.patch, patchNow)
+
+			if tt.wantCode != "" {
+				var ve *ValidationError
+				if !errors.As(err, &ve) {
+					t.Fatalf("expected *ValidationError, got %v", err)
+				}
+				if ve.Field != tt.wantField || ve.Code != tt.wantCode {
+					t.Errorf("got field=%q code=%q, want field=%q code=%q", ve.Field, ve.Code, tt.wantField, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !task.UpdatedAt.Equal(patchNow) {
+				t.Errorf("UpdatedAt = %s, want %s", task.UpdatedAt, patchNow)
+			}
+			tt.check(t, task)
+		})
+	}
+}
+
+func TestTask_ApplyPatch_NullClearsOptionalFields(t *testing.T) {
+	task := newPatchTestTask(t)
+	date := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	if err := task.ApplyPatch(TaskPatch{
+		AssigneeID:  Set("user-1"),
+		DueDate:     Set(date),
+		StartDate:   Set(date),
+		Estimate:    Set(5),
+		MilestoneID: Set("m-1"),
+		SprintID:    Set("s-1"),
+		EpicID:      Set("e-1"),
+		LabelIDs:    Set([]string{"l-1"}),
+	}, patchNow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := task.ApplyPatch(TaskPatch{
+		AssigneeID:  Null[string](),
+		DueDate:     Null[time.Time](),
+		StartDate:   Null[time.Time](),
+		Estimate:    Null[int](),
+		MilestoneID: Null[string](),
+		SprintID:    Null[string](),
+		EpicID:      Null[string](),
+		LabelIDs:    Null[[]string](),
+	}, patchNow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if task.AssigneeID != nil || task.DueDate != nil || task.StartDate != nil || task.Estimate != nil || task.MilestoneID != nil || task.SprintID != nil || task.EpicID != nil || task.LabelIDs != nil {
+		t.Errorf("expected optional fields to be cleared, got assignee=%v due=%v start=%v estimate=%v milestone=%v",
+			task.AssigneeID, task.DueDate, task.StartDate, task.Estimate, task.MilestoneID)
+	}
+}
+
+func TestTaskPatch_Fields(t *testing.T) {
+	if got := (TaskPatch{}).Fields(); got != nil {
+		t.Errorf("expected nil for an empty patch, got %v", got)
+	}
+	p := TaskPatch{Title: Set("t"), AssigneeID: Null[string](), LabelIDs: Set([]string{"l-1"})}
+	if got := strings.Join(p.Fields(), ","); got != "title,assigneeId,labelIds" {
+		t.Errorf("Fields() = %q", got)
+	}
+}
+
+func TestMapPatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      Patch[string]
+		want    Patch[int]
+		wantErr bool
+	}{
+		{name: "unset", in: Unset[string](), want: Unset[int]()},
+		{name: "null", in: Null[string](), want: Null[int]()},
+		{name: "value", in: Set("42"), want: Set(42)},
+		{name: "value error", in: Set("abc"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MapPatch(tt.in, strconv.Atoi)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// manyLabelIDs は n 個の異なるラベル ID を返す。
+func manyLabelIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("l-%d", i)
+	}
+	return ids
+}
@@ -1,6 +1,7 @@
 package task
 
 import (
+	"errors"
 	"testing"
 	"time"
 )
@@ -63,6 +64,14 @@ func TestNewTask_EmptyTitle(t *testing.T) {
 	if err == nil {
 		t.Fatalf("expected error for empty title, got nil")
 	}
+
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected *ValidationError, got %T", err)
+	}
+	if ve.Field != "title" || ve.Code != "REQUIRED" {
+		t.Errorf("expected title/REQUIRED, got %s/%s", ve.Field, ve.Code)
+	}
 }
 
 func TestNewTask_InvalidStatus(t *testing.T) {
@@ -81,6 +90,17 @@ func TestNewTask_InvalidStatus(t *testing.T) {
 	if err == nil {
 		t.Fatalf("expected error for invalid status, got nil")
 	}
+
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected *ValidationError, got %T", err)
+	}
+	if ve.Field != "status" || ve.Code != "INVALID_ENUM" {
+		t.Errorf("expected status/INVALID_ENUM, got %s/%s", ve.Field, ve.Code)
+	}
+	if ve.RejectedValue == nil || *ve.RejectedValue != "invalid-status" {
+		t.Errorf("expected rejectedValue=invalid-status, got %v", ve.RejectedValue)
+	}
 }
 
 func TestParseStatus(t *testing.T) {
@@ -127,8 +147,13 @@ func TestParsePriority(t *testing.T) {
 	})
 
 	t.Run("invalid", func(t *testing.T) {
-		if _, err := ParsePriority("urgent"); err == nil {
-			t.Fatalf("expected error for invalid priority")
+		_, err := ParsePriority("urgent")
+		var ve *ValidationError
+		if !errors.As(err, &ve) {
+			t.Fatalf("expected *ValidationError for invalid priority, got %v", err)
+		}
+		if ve.Field != "priority" || ve.Code != "INVALID_ENUM" {
+			t.Errorf("expected priority/INVALID_ENUM, got %s/%s", ve.Field, ve.Code)
 		}
 	})
 }
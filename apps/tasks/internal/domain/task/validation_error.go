@@ -5,8 +5,8 @@ import "fmt"
 // ValidationError は検証エラーを表す typed error。
 // HTTP 層で errors.As を使って field/code/rejectedValue を取り出せる。
 type ValidationError struct {
-	Field         string  // status, priority, sort, dueDateFrom, dueDateTo
-	Code          string  // INVALID_ENUM, INVALID_FORMAT
+	Field         string  // title, status, priority, estimate, sort, dueDateFrom, dueDateTo
+	Code          string  // REQUIRED, INVALID_ENUM, INVALID_FORMAT, INVALID_RANGE, FIELD_FORBIDDEN
 	RejectedValue *string // 不正だった値（nil の場合もある）
 	cause         error   // 元のエラー（Unwrap 用）
 }
@@ -51,3 +51,40 @@ func NewInvalidFormat(field string, cause error, rejected *string) *ValidationEr
 		cause:         cause,
 	}
 }
+
+// NewRequired は REQUIRED エラーを生成する。
+// 必須フィールドが空、または null を許容しないフィールドに null が指定された場合に使う。
+// field: title, status, priority など
+// cause: 元のエラー（nil 可）
+func NewRequired(field string, cause error) *ValidationError {
+	return &ValidationError{
+		Field: field,
+		Code:  "REQUIRED",
+		cause: cause,
+	}
+}
+
+// NewInvalidRange は INVALID_RANGE エラーを生成する。
+// field: estimate など
+// cause: 元のエラー（nil 可）
+// rejected: 不正だった値（nil 可）
+func NewInvalidRange(field string, cause error, rejected *string) *ValidationError {
+	return &ValidationError{
+		Field:         field,
+		Code:          "INVALID_RANGE",
+		RejectedValue: rejected,
+		cause:         cause,
+	}
+}
+
+// NewFieldForbidden は FIELD_FORBIDDEN エラーを生成する。
+// プロジェクト設定でロックされたフィールドを、変更を許可されていないロールの操作者が変更しようとした場合に使う。
+// field: priority, dueDate など（PATCH のフィールド名）
+// cause: 元のエラー（nil 可）
+func NewFieldForbidden(field string, cause error) *ValidationError {
+	return &ValidationError{
+		Field: field,
+		Code:  "FIELD_FORBIDDEN",
+		cause: cause,
+	}
+}
@@ -0,0 +1,64 @@
+package task
+
+import (
+	"fmt"
+	"maps"
+)
+
+// VersionConflictError は更新の前提にした版数より後に、タスクが変更されていたことを表す。
+// errors.Is(err, ErrVersionConflict) で判定できる。
+type VersionConflictError struct {
+	Current int      // タスクの現在の版数
+	Fields  []string // 前提にした版数より後に変更されたフィールド（フィールド単位のマージの場合のみ）
+}
+
+// Error は error インターフェースを満たす。
+func (e *VersionConflictError) Error() string {
+	if len(e.Fields) > 0 {
+		return fmt.Sprintf("%s: %v changed (current version %d)", ErrVersionConflict, e.Fields, e.Current)
+	}
+	return fmt.Sprintf("%s (current version %d)", ErrVersionConflict, e.Current)
+}
+
+// Unwrap は ErrVersionConflict を返す。
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
+// CheckVersion は版数 base のタスクを元にした fields の更新を、現在のタスクに適用できるかを確認する。
+//
+// merge が false の場合は版数が一致しなければ *VersionConflictError を返す。
+// merge が true（フィールド単位のマージ）の場合は版数が異なっても、base より後に fields のいずれかが
+// 変更されていなければ適用できる。変更されていたフィールドは VersionConflictError.Fields で返す。
+func (t *Task) CheckVersion(base int, fields []string, merge bool) error {
+	if base == t.Version {
+		return nil
+	}
+	if !merge {
+		return &VersionConflictError{Current: t.Version}
+	}
+	var changed []string
+	for _, f := range fields {
+		if t.FieldVersions[f] > base {
+			changed = append(changed, f)
+		}
+	}
+	if len(changed) > 0 {
+		return &VersionConflictError{Current: t.Version, Fields: changed}
+	}
+	return nil
+}
+
+// RecordChange は版数を 1 進め、fields（API の JSON 名）を新しい版数で変更したものとして記録する。
+// FieldVersions はキャッシュなどと共有されうるため、書き換えずにコピーして置き換える。
+func (t *Task) RecordChange(fields ...string) {
+	t.Version++
+	versions := maps.Clone(t.FieldVersions)
+	if versions == nil {
+		versions = make(map[string]int, len(fields))
+	}
+	for _, f := range fields {
+		versions[f] = t.Version
+	}
+	t.FieldVersions = versions
+}
@@ -0,0 +1,69 @@
+package task
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestTask_CheckVersion(t *testing.T) {
+	task := newPatchTestTask(t)
+	// 版数 2 で title、版数 3 で status を変更した
+	if err := task.ApplyPatch(TaskPatch{Title: Set("renamed")}, patchNow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := task.ApplyPatch(TaskPatch{Status: Set(StatusDone)}, patchNow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.Version != 3 || task.FieldVersions["title"] != 2 || task.FieldVersions["status"] != 3 {
+		t.Fatalf("unexpected versions: version=%d fields=%v", task.Version, task.FieldVersions)
+	}
+
+	tests := []struct {
+		name       string
+		base       int
+		fields     []string
+		merge      bool
+		wantFields []string // 衝突したフィールド（nil は衝突なし）
+		wantErr    bool
+	}{
+		{name: "same version", base: 3, fields: []string{"title"}},
+		{name: "stale version", base: 2, fields: []string{"priority"}, wantErr: true},
+		{name: "merge unrelated field", base: 1, fields: []string{"priority", "description"}, merge: true},
+		{name: "merge field changed later", base: 2, fields: []string{"priority", "status"}, merge: true, wantErr: true, wantFields: []string{"status"}},
+		{name: "merge fields changed later", base: 1, fields: []string{"title", "status"}, merge: true, wantErr: true, wantFields: []string{"title", "status"}},
+		{name: "merge field changed before base", base: 2, fields: []string{"title"}, merge: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := task.CheckVersion(tt.base, tt.fields, tt.merge)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var conflict *VersionConflictError
+			if !errors.As(err, &conflict) || !errors.Is(err, ErrVersionConflict) {
+				t.Fatalf("expected VersionConflictError, got %v", err)
+			}
+			if conflict.Current != 3 || !slices.Equal(conflict.Fields, tt.wantFields) {
+				t.Errorf("unexpected conflict: %+v", conflict)
+			}
+		})
+	}
+}
+
+func TestTask_RecordChange_CopiesFieldVersions(t *testing.T) {
+	task := newPatchTestTask(t)
+	task.RecordChange("title")
+	shared := *task // キャッシュのコピーなどと同じく FieldVersions を共有する
+
+	task.RecordChange("status")
+	if _, ok := shared.FieldVersions["status"]; ok || shared.Version != 2 {
+		t.Errorf("RecordChange must not modify the shared copy: %+v", shared)
+	}
+	if task.Version != 3 || task.FieldVersions["title"] != 2 || task.FieldVersions["status"] != 3 {
+		t.Errorf("unexpected versions: version=%d fields=%v", task.Version, task.FieldVersions)
+	}
+}
@@ -0,0 +1,29 @@
+// Package migration は tasks サービスの SQL マイグレーション（migrations/ に埋め込み）を teamflow-shared/migrate で適用する。
+//
+// 適用済みバージョンは schema_migrations テーブルで管理する。
+package migration
+
+import (
+	"embed"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"teamflow-shared/migrate"
+)
+
+//go:embed migrations/*.sql
+var embedded embed.FS
+
+// Config は tasks サービスのマイグレーションの設定。
+var Config = migrate.Config{
+	FS:    embedded,
+	Dir:   "migrations",
+	Table: "schema_migrations",
+	// 複数プロセス（テストパッケージの並列実行など）からの同時適用を直列化するためのロックキー
+	LockKey: 0x7465616d666c6f77, // "teamflow"
+}
+
+// New は埋め込みのマイグレーションを読み込んだ Migrator を生成する。
+func New(db *pgxpool.Pool) (*migrate.Migrator, error) {
+	return migrate.New(db, Config)
+}
@@ -21,4 +21,3 @@ CREATE INDEX idx_tasks_due_date ON tasks(due_date);
 CREATE INDEX idx_tasks_created_at ON tasks(created_at);
 -- Cursor pagination 用の複合インデックス（v1）
 CREATE INDEX idx_tasks_project_created_id ON tasks(project_id, created_at ASC, id ASC);
-
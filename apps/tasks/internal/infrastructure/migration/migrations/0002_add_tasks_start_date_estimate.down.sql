@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS estimate;
+ALTER TABLE tasks DROP COLUMN IF EXISTS start_date;
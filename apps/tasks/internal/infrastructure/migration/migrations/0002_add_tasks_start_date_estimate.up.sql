@@ -0,0 +1,3 @@
+-- 開始日と見積もり（PATCH で更新可能）
+ALTER TABLE tasks ADD COLUMN start_date DATE;
+ALTER TABLE tasks ADD COLUMN estimate INTEGER CHECK (estimate >= 0);
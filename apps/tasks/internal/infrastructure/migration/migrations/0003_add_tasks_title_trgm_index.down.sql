@@ -0,0 +1,2 @@
+-- pg_trgm 拡張は他で使われている可能性があるため残す
+DROP INDEX IF EXISTS idx_tasks_title_trgm;
@@ -0,0 +1,4 @@
+-- q（title の部分一致検索）用の trigram インデックス
+-- ILIKE '%...%' は B-tree を使えないため、pg_trgm の GIN インデックスで seq scan を避ける
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX idx_tasks_title_trgm ON tasks USING gin (title gin_trgm_ops);
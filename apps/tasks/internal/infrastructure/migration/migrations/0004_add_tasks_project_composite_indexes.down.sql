@@ -0,0 +1,5 @@
+CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks(project_id);
+
+DROP INDEX IF EXISTS idx_tasks_project_due_date;
+DROP INDEX IF EXISTS idx_tasks_project_assignee;
+DROP INDEX IF EXISTS idx_tasks_project_status;
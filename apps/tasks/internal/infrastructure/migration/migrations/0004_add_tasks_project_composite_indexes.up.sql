@@ -0,0 +1,8 @@
+-- フィルタ / ソートは常に project_id で絞るため、project_id を先頭にした複合インデックスを用意する
+-- （keyset pagination 用の (project_id, created_at, id) は 0001 の idx_tasks_project_created_id）
+CREATE INDEX idx_tasks_project_status ON tasks(project_id, status);
+CREATE INDEX idx_tasks_project_assignee ON tasks(project_id, assignee_id);
+CREATE INDEX idx_tasks_project_due_date ON tasks(project_id, due_date);
+
+-- project_id 単独のインデックスは複合インデックスの先頭列で代替できるため削除
+DROP INDEX IF EXISTS idx_tasks_project_id;
@@ -0,0 +1,16 @@
+-- タスクの作成・更新を task_changes チャネルに NOTIFY する（複数レプリカ間のライブ更新用）
+-- NOTIFY はトランザクションのコミット時に配信される
+CREATE OR REPLACE FUNCTION notify_task_change() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('task_changes', json_build_object(
+        'type', CASE TG_OP WHEN 'INSERT' THEN 'task.created' ELSE 'task.updated' END,
+        'projectId', NEW.project_id,
+        'taskId', NEW.id
+    )::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tasks_notify_change
+    AFTER INSERT OR UPDATE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION notify_task_change();
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS tasks_assign_number ON tasks;
+DROP FUNCTION IF EXISTS assign_task_number();
+DROP TABLE IF EXISTS task_number_counters;
+DROP INDEX IF EXISTS idx_tasks_project_number;
+ALTER TABLE tasks DROP COLUMN IF EXISTS number;
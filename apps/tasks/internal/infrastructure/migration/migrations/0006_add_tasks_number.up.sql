@@ -0,0 +1,38 @@
+-- プロジェクトごとのタスク番号（TFLOW-123 の 123）。作成時に採番し、変更しない
+ALTER TABLE tasks ADD COLUMN number INTEGER;
+
+-- 既存のタスクは作成順に採番する
+UPDATE tasks SET number = numbered.n
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY project_id ORDER BY created_at, id) AS n
+    FROM tasks
+) AS numbered
+WHERE tasks.id = numbered.id;
+
+ALTER TABLE tasks ALTER COLUMN number SET NOT NULL;
+CREATE UNIQUE INDEX idx_tasks_project_number ON tasks(project_id, number);
+
+-- プロジェクトごとの最後に採番した番号
+CREATE TABLE task_number_counters (
+    project_id TEXT PRIMARY KEY,
+    last_number INTEGER NOT NULL
+);
+
+INSERT INTO task_number_counters (project_id, last_number)
+SELECT project_id, MAX(number) FROM tasks GROUP BY project_id;
+
+-- INSERT 時にカウンタを進めて採番する。カウンタ行のロックはトランザクションの終了まで保持されるため、
+-- 同じプロジェクトへの同時作成でも番号は重複しない（ロールバックされた番号は欠番になる）
+CREATE OR REPLACE FUNCTION assign_task_number() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO task_number_counters (project_id, last_number)
+    VALUES (NEW.project_id, 1)
+    ON CONFLICT (project_id) DO UPDATE SET last_number = task_number_counters.last_number + 1
+    RETURNING last_number INTO NEW.number;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tasks_assign_number
+    BEFORE INSERT ON tasks
+    FOR EACH ROW EXECUTE FUNCTION assign_task_number();
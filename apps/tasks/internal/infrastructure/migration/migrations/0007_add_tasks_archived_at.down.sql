@@ -0,0 +1,18 @@
+DROP TRIGGER IF EXISTS tasks_notify_change ON tasks;
+
+CREATE OR REPLACE FUNCTION notify_task_change() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('task_changes', json_build_object(
+        'type', CASE TG_OP WHEN 'INSERT' THEN 'task.created' ELSE 'task.updated' END,
+        'projectId', NEW.project_id,
+        'taskId', NEW.id
+    )::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tasks_notify_change
+    AFTER INSERT OR UPDATE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION notify_task_change();
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS archived_at;
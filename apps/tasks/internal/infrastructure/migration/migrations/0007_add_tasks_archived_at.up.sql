@@ -0,0 +1,27 @@
+-- プロジェクトの削除（cascade=archive_tasks）でアーカイブされた日時。NULL はアーカイブされていない
+-- アーカイブされたタスクは一覧・集計に含めない（ID・番号での取得は可能）
+ALTER TABLE tasks ADD COLUMN archived_at TIMESTAMPTZ;
+
+-- タスクの削除（cascade=delete_tasks）も task_changes チャネルに NOTIFY する（他のレプリカのキャッシュの破棄用）
+CREATE OR REPLACE FUNCTION notify_task_change() RETURNS trigger AS $$
+DECLARE
+    t tasks;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        t := OLD;
+    ELSE
+        t := NEW;
+    END IF;
+    PERFORM pg_notify('task_changes', json_build_object(
+        'type', CASE TG_OP WHEN 'INSERT' THEN 'task.created' WHEN 'DELETE' THEN 'task.deleted' ELSE 'task.updated' END,
+        'projectId', t.project_id,
+        'taskId', t.id
+    )::text);
+    RETURN t;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tasks_notify_change ON tasks;
+CREATE TRIGGER tasks_notify_change
+    AFTER INSERT OR UPDATE OR DELETE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION notify_task_change();
@@ -0,0 +1,6 @@
+-- 所属するマイルストーン（projects サービスの project_milestones.id）。NULL はマイルストーンなし
+-- サービスをまたぐため外部キーは張らない（存在チェックはタスクの作成・更新時に projects サービスへ問い合わせる）
+ALTER TABLE tasks ADD COLUMN milestone_id TEXT;
+
+-- milestoneId フィルタ・マイルストーンごとの集計用
+CREATE INDEX idx_tasks_project_id_milestone_id ON tasks(project_id, milestone_id) WHERE milestone_id IS NOT NULL;
@@ -0,0 +1,6 @@
+-- 所属するスプリント（projects サービスの project_sprints.id）。NULL はバックログ
+-- サービスをまたぐため外部キーは張らない（存在チェックはタスクの作成・更新時に projects サービスへ問い合わせる）
+ALTER TABLE tasks ADD COLUMN sprint_id TEXT;
+
+-- sprintId フィルタ・スプリントの完了時の持ち越し用
+CREATE INDEX idx_tasks_project_id_sprint_id ON tasks(project_id, sprint_id) WHERE sprint_id IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tasks_project_id_epic_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS epic_id;
@@ -0,0 +1,6 @@
+-- 所属するエピック（projects サービスの project_epics.id）。NULL はエピックなし
+-- サービスをまたぐため外部キーは張らない（存在チェックはタスクの作成・更新時に projects サービスへ問い合わせる）
+ALTER TABLE tasks ADD COLUMN epic_id TEXT;
+
+-- epicId フィルタ・エピックの進捗集計用
+CREATE INDEX idx_tasks_project_id_epic_id ON tasks(project_id, epic_id) WHERE epic_id IS NOT NULL;
@@ -0,0 +1,3 @@
+-- 付けられたラベル（projects サービスの project_labels.id）。空配列はラベルなし
+-- サービスをまたぐため外部キーは張らない（定義済みかのチェックはタスクの作成・更新時に projects サービスへ問い合わせる）
+ALTER TABLE tasks ADD COLUMN label_ids TEXT[] NOT NULL DEFAULT '{}';
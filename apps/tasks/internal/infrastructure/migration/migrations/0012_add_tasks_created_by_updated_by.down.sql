@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE tasks DROP COLUMN IF EXISTS created_by;
@@ -0,0 +1,29 @@
+-- 監査ログ（teamflow-shared/audit）。projects / tasks サービスで同じ定義のため、同じデータベースを使う構成でも作成できるようにする
+-- 追記専用: UPDATE / DELETE はトリガーで拒否する（TRUNCATE はテストの後始末のため許可する）
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    service TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id TEXT NOT NULL,
+    project_id TEXT NOT NULL,
+    action TEXT NOT NULL,
+    -- 操作者。空文字は不明
+    actor_id TEXT NOT NULL DEFAULT '',
+    -- 変更したフィールド（update のみ）
+    fields TEXT[] NOT NULL DEFAULT '{}',
+    occurred_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log (entity_type, entity_id, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_project ON audit_log (project_id, occurred_at);
+
+CREATE OR REPLACE FUNCTION audit_log_reject_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_append_only ON audit_log;
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_reject_change();
@@ -0,0 +1,19 @@
+CREATE OR REPLACE FUNCTION notify_task_change() RETURNS trigger AS $$
+DECLARE
+    t tasks;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        t := OLD;
+    ELSE
+        t := NEW;
+    END IF;
+    PERFORM pg_notify('task_changes', json_build_object(
+        'type', CASE TG_OP WHEN 'INSERT' THEN 'task.created' WHEN 'DELETE' THEN 'task.deleted' ELSE 'task.updated' END,
+        'projectId', t.project_id,
+        'taskId', t.id
+    )::text);
+    RETURN t;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS workspace_id;
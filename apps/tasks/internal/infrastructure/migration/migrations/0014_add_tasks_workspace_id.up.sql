@@ -0,0 +1,23 @@
+-- タスクが属するワークスペース（テナント）。既存の行はワークスペースの導入前のデータとして default に属する
+-- 問い合わせは常に project_id でも絞り込むため、インデックスは project_id を先頭にした既存のものを使う
+ALTER TABLE tasks ADD COLUMN workspace_id TEXT NOT NULL DEFAULT 'default';
+
+-- 変更の通知にもワークスペースを含める（購読はワークスペースとプロジェクトの組で行う）
+CREATE OR REPLACE FUNCTION notify_task_change() RETURNS trigger AS $$
+DECLARE
+    t tasks;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        t := OLD;
+    ELSE
+        t := NEW;
+    END IF;
+    PERFORM pg_notify('task_changes', json_build_object(
+        'type', CASE TG_OP WHEN 'INSERT' THEN 'task.created' WHEN 'DELETE' THEN 'task.deleted' ELSE 'task.updated' END,
+        'workspaceId', t.workspace_id,
+        'projectId', t.project_id,
+        'taskId', t.id
+    )::text);
+    RETURN t;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,22 @@
+-- ドメインイベントの outbox（teamflow-shared/outbox）。projects / tasks サービスで同じ定義のため、同じデータベースを使う構成でも作成できるようにする
+-- イベントはタスクの書き込みと同じトランザクションで追加し、リレーが配信して published_at を設定する
+CREATE TABLE IF NOT EXISTS outbox (
+    seq BIGSERIAL PRIMARY KEY,
+    event_id TEXT NOT NULL UNIQUE,
+    event_type TEXT NOT NULL,
+    source TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    project_id TEXT NOT NULL,
+    workspace_id TEXT NOT NULL,
+    data JSONB NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    -- 配信に失敗した回数と、次に配信を試みる日時（リレーが読み出した間は他のレプリカから隠すためにも延ばす）
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    -- 配信済みの日時。NULL は未配信
+    published_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (seq) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_published ON outbox (published_at) WHERE published_at IS NOT NULL;
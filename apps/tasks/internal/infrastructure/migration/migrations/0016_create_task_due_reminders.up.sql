@@ -0,0 +1,8 @@
+-- 期日が近いことを担当者に通知したタスク（notification.DueReminder）。同じタスクの同じ期日は 1 度だけ通知する
+-- 期日を変更したタスクは新しい期日で再び通知する
+CREATE TABLE task_due_reminders (
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    due_date DATE NOT NULL,
+    reminded_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (task_id, due_date)
+);
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS tasks_record_tombstone ON tasks;
+DROP FUNCTION IF EXISTS record_task_tombstone();
+DROP TABLE IF EXISTS task_tombstones;
+DROP TRIGGER IF EXISTS tasks_assign_change_seq ON tasks;
+DROP FUNCTION IF EXISTS assign_task_change_seq();
+DROP INDEX IF EXISTS idx_tasks_project_change_seq;
+ALTER TABLE tasks DROP COLUMN IF EXISTS change_seq;
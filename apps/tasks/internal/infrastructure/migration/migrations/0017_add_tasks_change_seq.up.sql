@@ -0,0 +1,43 @@
+-- 差分同期（GET /projects/{id}/sync）用の変更の順序。作成・更新したトランザクションの ID（xid8）を記録する
+-- 同期は pg_snapshot_xmin（実行中の最も古いトランザクション）未満の変更だけを返すため、
+-- コミットの順序が前後しても、同期済みの位置より前に変更が後から現れることはない
+ALTER TABLE tasks ADD COLUMN change_seq BIGINT NOT NULL DEFAULT 0;
+CREATE INDEX idx_tasks_project_change_seq ON tasks(project_id, change_seq, id);
+
+CREATE OR REPLACE FUNCTION assign_task_change_seq() RETURNS trigger AS $$
+BEGIN
+    NEW.change_seq := pg_current_xact_id()::text::bigint;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tasks_assign_change_seq
+    BEFORE INSERT OR UPDATE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION assign_task_change_seq();
+
+-- 既存のタスクはこのマイグレーションのトランザクションで変更したものとして扱う
+UPDATE tasks SET change_seq = pg_current_xact_id()::text::bigint;
+
+-- 削除したタスクの記録（Tombstone）。SYNC_TOMBSTONE_RETENTION を過ぎたものは定期的に消す
+CREATE TABLE task_tombstones (
+    task_id TEXT PRIMARY KEY,
+    project_id TEXT NOT NULL,
+    workspace_id TEXT NOT NULL,
+    change_seq BIGINT NOT NULL,
+    deleted_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_task_tombstones_project_change_seq ON task_tombstones(project_id, change_seq, task_id);
+CREATE INDEX idx_task_tombstones_deleted_at ON task_tombstones(deleted_at);
+
+CREATE OR REPLACE FUNCTION record_task_tombstone() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO task_tombstones (task_id, project_id, workspace_id, change_seq, deleted_at)
+    VALUES (OLD.id, OLD.project_id, OLD.workspace_id, pg_current_xact_id()::text::bigint, clock_timestamp())
+    ON CONFLICT (task_id) DO UPDATE SET change_seq = EXCLUDED.change_seq, deleted_at = EXCLUDED.deleted_at;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tasks_record_tombstone
+    AFTER DELETE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION record_task_tombstone();
@@ -0,0 +1,3 @@
+-- 保持期間を過ぎたアーカイブしたタスクの物理削除（POST /api/admin/purge・ARCHIVE_RETENTION_DAYS）用。
+-- ワークスペースをまたいでアーカイブ日時の古い順に取り出すため、アーカイブしたタスクだけを索引にする
+CREATE INDEX idx_tasks_archived_at ON tasks(archived_at, id) WHERE archived_at IS NOT NULL;
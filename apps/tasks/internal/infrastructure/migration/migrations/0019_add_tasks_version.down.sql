@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS field_versions;
+ALTER TABLE tasks DROP COLUMN IF EXISTS version;
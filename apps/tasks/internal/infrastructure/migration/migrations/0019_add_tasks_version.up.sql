@@ -0,0 +1,4 @@
+-- タスクの版数（PATCH の If-Match）と、フィールドごとに最後に変更した版数（フィールド単位のマージ）。
+-- 既存のタスクは版数 1 とし、フィールドの変更はこれから記録する
+ALTER TABLE tasks ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE tasks ADD COLUMN field_versions JSONB NOT NULL DEFAULT '{}';
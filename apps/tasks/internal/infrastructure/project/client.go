@@ -0,0 +1,174 @@
+package projectinfra
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"teamflow-shared/client"
+	"teamflow-shared/requestid"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// defaultClientTimeout は projects サービスへの 1 回のリクエストのタイムアウト。
+const defaultClientTimeout = 3 * time.Second
+
+// Client は projects サービスの HTTP API クライアント（teamflow-shared/client のラッパー）。
+// ProjectDefaultsProvider・ProjectTimezoneProvider（GET /api/v1/projects/{id}/settings）、
+// MembershipChecker（GET /api/v1/projects/{id}/members/{userId}）、
+// FieldLockPolicy（GET /api/v1/projects/{id}/settings と GET /api/v1/projects/{id}/members/{userId}）、
+// MilestoneChecker（GET /api/v1/projects/{id}/milestones/{milestoneId}）、
+// SprintChecker（GET /api/v1/projects/{id}/sprints/{sprintId}）、
+// EpicChecker（GET /api/v1/projects/{id}/epics/{epicId}）、
+// LabelChecker（GET /api/v1/projects/{id}/labels/{labelId}）と
+// ProjectAccessChecker（GET /api/v1/projects/{id}）を実装する。
+type Client struct {
+	api *client.Client
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var (
+	_ usecase.ProjectDefaultsProvider = (*Client)(nil)
+	_ usecase.ProjectTimezoneProvider = (*Client)(nil)
+	_ usecase.MembershipChecker       = (*Client)(nil)
+	_ usecase.FieldLockPolicy         = (*Client)(nil)
+	_ usecase.MilestoneChecker        = (*Client)(nil)
+	_ usecase.SprintChecker           = (*Client)(nil)
+	_ usecase.EpicChecker             = (*Client)(nil)
+	_ usecase.LabelChecker            = (*Client)(nil)
+	_ usecase.ProjectAccessChecker    = (*Client)(nil)
+)
+
+// NewClient は baseURL（例: http://projects:8080）の projects サービスに接続する Client を生成する。
+// httpClient が nil の場合はタイムアウト付きで、リクエスト ID（X-Request-ID）を引き継ぐ既定のクライアントを使う。
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultClientTimeout, Transport: &requestid.Transport{}}
+	}
+	return &Client{api: client.New(baseURL, httpClient)}
+}
+
+// ProjectDefaults はプロジェクト設定から新規タスクの既定値を取得する。
+// プロジェクトが存在しない場合は既定値なしとして扱う（projectId の検証は tasks の責務ではないため）。
+func (c *Client) ProjectDefaults(ctx context.Context, projectID string) (usecase.ProjectDefaults, error) {
+	settings, err := c.api.GetProjectSettings(ctx, projectID)
+	if found, err := exists(err); err != nil || !found {
+		return usecase.ProjectDefaults{}, err
+	}
+
+	var defaults usecase.ProjectDefaults
+	if settings.DefaultPriority != nil {
+		// projects 側で検証済みだが、未知の値は既定値なしとして扱う
+		if p, err := domain.ParsePriority(*settings.DefaultPriority); err == nil {
+			defaults.Priority = p
+		}
+	}
+	if settings.DefaultAssigneeID != nil {
+		defaults.AssigneeID = *settings.DefaultAssigneeID
+	}
+	return defaults, nil
+}
+
+// ProjectLocation はプロジェクト設定から期日の判定に使うタイムゾーンを取得する。
+// プロジェクトが存在しない場合・タイムゾーンが設定されていない場合は UTC を返す。
+func (c *Client) ProjectLocation(ctx context.Context, projectID string) (*time.Location, error) {
+	settings, err := c.api.GetProjectSettings(ctx, projectID)
+	if found, err := exists(err); err != nil || !found {
+		if err != nil {
+			return nil, err
+		}
+		return time.UTC, nil
+	}
+	if settings.Timezone == nil || *settings.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(*settings.Timezone)
+	if err != nil {
+		// projects 側で検証済みだが、このサービスのタイムゾーンのデータに無い名前は UTC として扱う
+		return time.UTC, nil
+	}
+	return loc, nil
+}
+
+// IsMember はユーザーがプロジェクトのメンバーかどうかを返す。
+func (c *Client) IsMember(ctx context.Context, projectID, userID string) (bool, error) {
+	_, err := c.api.GetMember(ctx, projectID, userID)
+	return exists(err)
+}
+
+// FieldLocks はプロジェクト設定からフィールドごとに変更できるロールを取得する。
+// プロジェクトが存在しない場合はロック無しとして扱う。
+func (c *Client) FieldLocks(ctx context.Context, projectID string) (map[string][]string, error) {
+	settings, err := c.api.GetProjectSettings(ctx, projectID)
+	if found, err := exists(err); err != nil || !found {
+		return nil, err
+	}
+	return settings.FieldLocks, nil
+}
+
+// MemberRole はユーザーのプロジェクトでのロールを返す。メンバーでない場合は空文字。
+func (c *Client) MemberRole(ctx context.Context, projectID, userID string) (string, error) {
+	m, err := c.api.GetMember(ctx, projectID, userID)
+	if found, err := exists(err); err != nil || !found {
+		return "", err
+	}
+	return m.Role, nil
+}
+
+// MilestoneExists はマイルストーンがプロジェクトに存在するかどうかを返す。
+func (c *Client) MilestoneExists(ctx context.Context, projectID, milestoneID string) (bool, error) {
+	_, err := c.api.GetMilestone(ctx, projectID, milestoneID)
+	return exists(err)
+}
+
+// SprintExists はスプリントがプロジェクトに存在するかどうかを返す。
+func (c *Client) SprintExists(ctx context.Context, projectID, sprintID string) (bool, error) {
+	_, err := c.api.GetSprint(ctx, projectID, sprintID)
+	return exists(err)
+}
+
+// EpicExists はエピックがプロジェクトに存在するかどうかを返す。
+func (c *Client) EpicExists(ctx context.Context, projectID, epicID string) (bool, error) {
+	_, err := c.api.GetEpic(ctx, projectID, epicID)
+	return exists(err)
+}
+
+// LabelExists はラベルがプロジェクトに定義されているかどうかを返す。
+func (c *Client) LabelExists(ctx context.Context, projectID, labelID string) (bool, error) {
+	_, err := c.api.GetLabel(ctx, projectID, labelID)
+	return exists(err)
+}
+
+// AuthorizeRead は actorID がプロジェクトを閲覧できるかを、操作者を引き継いで projects サービスに問い合わせる。
+// 401 / 403 の場合は ErrActorRequired / ErrForbidden を返す。
+// プロジェクトが存在しない場合は閲覧できるものとして扱う（projectId の検証は tasks の責務ではないため）。
+func (c *Client) AuthorizeRead(ctx context.Context, projectID, actorID string) error {
+	_, err := c.api.WithActor(actorID).GetProject(ctx, projectID)
+	switch client.StatusCode(err) {
+	case http.StatusNotFound:
+		return nil
+	case http.StatusUnauthorized:
+		return usecase.ErrActorRequired
+	case http.StatusForbidden:
+		return fmt.Errorf("%w: %s is not readable by the actor", usecase.ErrForbidden, projectID)
+	}
+	if err != nil {
+		return fmt.Errorf("projects client: %w", err)
+	}
+	return nil
+}
+
+// exists は取得の結果を、存在するかどうかに変換する。404 の場合は存在しないとしてエラーにしない。
+func exists(err error) (bool, error) {
+	switch {
+	case err == nil:
+		return true, nil
+	case client.IsNotFound(err):
+		return false, nil
+	default:
+		return false, fmt.Errorf("projects client: %w", err)
+	}
+}
@@ -0,0 +1,280 @@
+package projectinfra_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"teamflow-shared/authz"
+	"teamflow-shared/requestid"
+
+	domain "teamflow-tasks/internal/domain/task"
+	projectinfra "teamflow-tasks/internal/infrastructure/project"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+func newProjectsServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/projects/proj-1/settings", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"projectId":"proj-1","defaultPriority":"high","defaultAssigneeId":"user-1","wipLimits":{},"fieldLocks":{"priority":["admin","owner"]},"timezone":"Asia/Tokyo"}`))
+	})
+	mux.HandleFunc("/api/v1/projects/proj-2/settings", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"projectId":"proj-2","defaultPriority":null,"defaultAssigneeId":null,"wipLimits":{}}`))
+	})
+	mux.HandleFunc("/api/v1/projects/proj-1/members/user-1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"projectId":"proj-1","userId":"user-1","role":"member"}`))
+	})
+	mux.HandleFunc("/api/v1/projects/proj-1/milestones/m-1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"m-1","projectId":"proj-1","name":"v1.0","status":"open"}`))
+	})
+	mux.HandleFunc("/api/v1/projects/proj-1/sprints/s-1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"s-1","projectId":"proj-1","name":"Sprint 1","state":"planned"}`))
+	})
+	mux.HandleFunc("/api/v1/projects/proj-1/epics/e-1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"e-1","projectId":"proj-1","name":"Login","status":"open"}`))
+	})
+	mux.HandleFunc("/api/v1/projects/proj-1/labels/l-1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"l-1","projectId":"proj-1","name":"bug","color":"#d73a4a","usageCount":0}`))
+	})
+	mux.HandleFunc("/api/v1/projects/proj-1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get(authz.ActorHeader) {
+		case "user-1":
+			_, _ = w.Write([]byte(`{"id":"proj-1","visibility":"private"}`))
+		case "":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	})
+	mux.HandleFunc("/api/v1/projects/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	mux.HandleFunc("/api/v1/projects/broken/settings", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClient_ProjectDefaults(t *testing.T) {
+	client := projectinfra.NewClient(newProjectsServer(t).URL+"/", nil)
+	ctx := context.Background()
+
+	got, err := client.ProjectDefaults(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Priority != domain.PriorityHigh || got.AssigneeID != "user-1" {
+		t.Errorf("unexpected defaults: %+v", got)
+	}
+
+	for _, projectID := range []string{"proj-2", "unknown"} {
+		got, err := client.ProjectDefaults(ctx, projectID)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", projectID, err)
+		}
+		if got.Priority != "" || got.AssigneeID != "" {
+			t.Errorf("%s: expected empty defaults, got %+v", projectID, got)
+		}
+	}
+
+	if _, err := client.ProjectDefaults(ctx, "broken"); err == nil {
+		t.Error("expected error for 500 response, got nil")
+	}
+}
+
+func TestClient_ProjectLocation(t *testing.T) {
+	client := projectinfra.NewClient(newProjectsServer(t).URL, nil)
+	ctx := context.Background()
+
+	if loc, err := client.ProjectLocation(ctx, "proj-1"); err != nil || loc.String() != "Asia/Tokyo" {
+		t.Errorf("expected Asia/Tokyo, got %v err=%v", loc, err)
+	}
+	for _, projectID := range []string{"proj-2", "unknown"} {
+		if loc, err := client.ProjectLocation(ctx, projectID); err != nil || loc != time.UTC {
+			t.Errorf("%s: expected UTC, got %v err=%v", projectID, loc, err)
+		}
+	}
+	if _, err := client.ProjectLocation(ctx, "broken"); err == nil {
+		t.Error("expected error for 500 response, got nil")
+	}
+}
+
+func TestClient_IsMember(t *testing.T) {
+	client := projectinfra.NewClient(newProjectsServer(t).URL, nil)
+	ctx := context.Background()
+
+	if ok, err := client.IsMember(ctx, "proj-1", "user-1"); err != nil || !ok {
+		t.Errorf("expected member, got ok=%v err=%v", ok, err)
+	}
+	if ok, err := client.IsMember(ctx, "proj-1", "user-2"); err != nil || ok {
+		t.Errorf("expected non-member, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestClient_FieldLocks(t *testing.T) {
+	client := projectinfra.NewClient(newProjectsServer(t).URL, nil)
+	ctx := context.Background()
+
+	locks, err := client.FieldLocks(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string][]string{"priority": {"admin", "owner"}}; !reflect.DeepEqual(locks, want) {
+		t.Errorf("FieldLocks() = %v, want %v", locks, want)
+	}
+	if locks, err := client.FieldLocks(ctx, "unknown"); err != nil || len(locks) != 0 {
+		t.Errorf("expected no locks for unknown project, got %v err=%v", locks, err)
+	}
+	if _, err := client.FieldLocks(ctx, "broken"); err == nil {
+		t.Error("expected error for 500 response, got nil")
+	}
+
+	if role, err := client.MemberRole(ctx, "proj-1", "user-1"); err != nil || role != "member" {
+		t.Errorf("expected member role, got %q err=%v", role, err)
+	}
+	if role, err := client.MemberRole(ctx, "proj-1", "user-2"); err != nil || role != "" {
+		t.Errorf("expected no role for non-member, got %q err=%v", role, err)
+	}
+}
+
+func TestClient_MilestoneExists(t *testing.T) {
+	client := projectinfra.NewClient(newProjectsServer(t).URL, nil)
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		projectID, milestoneID string
+		want                   bool
+	}{
+		{"proj-1", "m-1", true},
+		{"proj-1", "m-2", false},
+		{"proj-2", "m-1", false},
+	} {
+		got, err := client.MilestoneExists(ctx, tt.projectID, tt.milestoneID)
+		if err != nil {
+			t.Fatalf("%s/%s: unexpected error: %v", tt.projectID, tt.milestoneID, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s/%s: expected %v, got %v", tt.projectID, tt.milestoneID, tt.want, got)
+		}
+	}
+}
+
+func TestClient_SprintExists(t *testing.T) {
+	client := projectinfra.NewClient(newProjectsServer(t).URL, nil)
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		projectID, sprintID string
+		want                bool
+	}{
+		{"proj-1", "s-1", true},
+		{"proj-1", "s-2", false},
+		{"proj-2", "s-1", false},
+	} {
+		got, err := client.SprintExists(ctx, tt.projectID, tt.sprintID)
+		if err != nil {
+			t.Fatalf("%s/%s: unexpected error: %v", tt.projectID, tt.sprintID, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s/%s: expected %v, got %v", tt.projectID, tt.sprintID, tt.want, got)
+		}
+	}
+}
+
+func TestClient_EpicExists(t *testing.T) {
+	client := projectinfra.NewClient(newProjectsServer(t).URL, nil)
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		projectID, epicID string
+		want              bool
+	}{
+		{"proj-1", "e-1", true},
+		{"proj-1", "e-2", false},
+		{"proj-2", "e-1", false},
+	} {
+		got, err := client.EpicExists(ctx, tt.projectID, tt.epicID)
+		if err != nil {
+			t.Fatalf("%s/%s: unexpected error: %v", tt.projectID, tt.epicID, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s/%s: expected %v, got %v", tt.projectID, tt.epicID, tt.want, got)
+		}
+	}
+}
+
+func TestClient_LabelExists(t *testing.T) {
+	client := projectinfra.NewClient(newProjectsServer(t).URL, nil)
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		projectID, labelID string
+		want               bool
+	}{
+		{"proj-1", "l-1", true},
+		{"proj-1", "l-2", false},
+		{"proj-2", "l-1", false},
+	} {
+		got, err := client.LabelExists(ctx, tt.projectID, tt.labelID)
+		if err != nil {
+			t.Fatalf("%s/%s: unexpected error: %v", tt.projectID, tt.labelID, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s/%s: expected %v, got %v", tt.projectID, tt.labelID, tt.want, got)
+		}
+	}
+}
+
+func TestClient_AuthorizeRead(t *testing.T) {
+	client := projectinfra.NewClient(newProjectsServer(t).URL, nil)
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		projectID, actorID string
+		want               error
+	}{
+		{"proj-1", "user-1", nil},
+		{"proj-1", "", usecase.ErrActorRequired},
+		{"proj-1", "user-2", usecase.ErrForbidden},
+		{"unknown", "user-1", nil},
+	} {
+		err := client.AuthorizeRead(ctx, tt.projectID, tt.actorID)
+		if tt.want == nil {
+			if err != nil {
+				t.Errorf("%s/%q: unexpected error: %v", tt.projectID, tt.actorID, err)
+			}
+			continue
+		}
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s/%q: expected %v, got %v", tt.projectID, tt.actorID, tt.want, err)
+		}
+	}
+
+	if err := client.AuthorizeRead(ctx, "broken", "user-1"); err == nil {
+		t.Error("expected error for 500 response, got nil")
+	}
+}
+
+func TestClient_ForwardsRequestID(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(requestid.Header)
+		_, _ = w.Write([]byte(`{"projectId":"proj-1","userId":"user-1","role":"member"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := projectinfra.NewClient(srv.URL, nil)
+	if _, err := client.IsMember(requestid.NewContext(context.Background(), "req-1"), "proj-1", "user-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "req-1" {
+		t.Errorf("expected X-Request-ID req-1, got %q", got)
+	}
+}
@@ -0,0 +1,118 @@
+package projectinfra
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/workspace"
+
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// membershipCacheRequests はメンバーシップのキャッシュのヒット / ミス数（result = hit | miss）。
+var membershipCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "tasks_membership_cache_requests_total",
+	Help: "Number of project membership cache lookups by result.",
+}, []string{"result"})
+
+// CachingMembershipChecker は IsMember の結果をプロセス内の LRU にキャッシュする MembershipChecker のデコレータ。
+//
+// タスクの閲覧・変更のたびに projects サービスへ問い合わせないためのもの。
+//   - メンバーである / ない のどちらの結果もキャッシュし、エラーはキャッシュしない
+//   - エントリはワークスペース・プロジェクト・ユーザーで引く
+//   - メンバーの追加・削除は ttl が過ぎるまで反映されない
+type CachingMembershipChecker struct {
+	inner usecase.MembershipChecker
+	size  int
+	ttl   time.Duration
+	clock clock.Clock
+
+	mu      sync.Mutex
+	entries map[membershipKey]*list.Element
+	lru     *list.List // 先頭が最近使われたエントリ
+}
+
+type membershipKey struct {
+	workspaceID string
+	projectID   string
+	userID      string
+}
+
+type membershipEntry struct {
+	key       membershipKey
+	member    bool
+	expiresAt time.Time
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.MembershipChecker = (*CachingMembershipChecker)(nil)
+
+// NewCachingMembershipChecker は新しい CachingMembershipChecker を生成する。
+// size は最大エントリ数、ttl はエントリの有効期間（<= 0 の場合は期限なし）。
+func NewCachingMembershipChecker(inner usecase.MembershipChecker, size int, ttl time.Duration) *CachingMembershipChecker {
+	return &CachingMembershipChecker{
+		inner:   inner,
+		size:    size,
+		ttl:     ttl,
+		clock:   clock.System,
+		entries: make(map[membershipKey]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// IsMember はユーザーがプロジェクトのメンバーかどうかを返す。キャッシュに無ければ inner に問い合わせる。
+func (c *CachingMembershipChecker) IsMember(ctx context.Context, projectID, userID string) (bool, error) {
+	key := membershipKey{workspaceID: workspace.FromContext(ctx), projectID: projectID, userID: userID}
+	if member, ok := c.get(key); ok {
+		membershipCacheRequests.WithLabelValues("hit").Inc()
+		return member, nil
+	}
+	membershipCacheRequests.WithLabelValues("miss").Inc()
+
+	member, err := c.inner.IsMember(ctx, projectID, userID)
+	if err != nil {
+		return false, err
+	}
+	c.put(key, member)
+	return member, nil
+}
+
+func (c *CachingMembershipChecker) get(key membershipKey) (bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return false, false
+	}
+	entry := el.Value.(*membershipEntry)
+	if c.ttl > 0 && !c.clock.Now().Before(entry.expiresAt) {
+		c.lru.Remove(el)
+		delete(c.entries, key)
+		return false, false
+	}
+	c.lru.MoveToFront(el)
+	return entry.member, true
+}
+
+func (c *CachingMembershipChecker) put(key membershipKey, member bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &membershipEntry{key: key, member: member, expiresAt: c.clock.Now().Add(c.ttl)}
+	if el, ok := c.entries[key]; ok {
+		el.Value = entry
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*membershipEntry).key)
+	}
+}
@@ -0,0 +1,115 @@
+package projectinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/workspace"
+)
+
+// countingMembers は members に含まれる "projectID/userID" をメンバーとみなし、呼び出し回数を数える MembershipChecker。
+type countingMembers struct {
+	members map[string]bool
+	err     error
+	calls   int
+}
+
+func (m *countingMembers) IsMember(_ context.Context, projectID, userID string) (bool, error) {
+	m.calls++
+	if m.err != nil {
+		return false, m.err
+	}
+	return m.members[projectID+"/"+userID], nil
+}
+
+func TestCachingMembershipChecker(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	setup := func(size int, ttl time.Duration) (*CachingMembershipChecker, *countingMembers, *clock.Fake) {
+		inner := &countingMembers{members: map[string]bool{"proj-1/user-1": true}}
+		c := NewCachingMembershipChecker(inner, size, ttl)
+		clk := clock.NewFake(now)
+		c.clock = clk
+		return c, inner, clk
+	}
+	isMember := func(t *testing.T, c *CachingMembershipChecker, ctx context.Context, projectID, userID string) bool {
+		t.Helper()
+		ok, err := c.IsMember(ctx, projectID, userID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return ok
+	}
+
+	t.Run("caches members and non-members", func(t *testing.T) {
+		ctx := context.Background()
+		c, inner, _ := setup(10, time.Minute)
+		for range 2 {
+			if !isMember(t, c, ctx, "proj-1", "user-1") {
+				t.Error("expected user-1 to be a member")
+			}
+			if isMember(t, c, ctx, "proj-1", "user-2") {
+				t.Error("expected user-2 not to be a member")
+			}
+		}
+		if inner.calls != 2 {
+			t.Errorf("expected 2 calls, got %d", inner.calls)
+		}
+	})
+
+	t.Run("expires after ttl", func(t *testing.T) {
+		ctx := context.Background()
+		c, inner, clk := setup(10, time.Minute)
+		isMember(t, c, ctx, "proj-1", "user-1")
+		clk.Advance(time.Minute)
+		isMember(t, c, ctx, "proj-1", "user-1")
+		if inner.calls != 2 {
+			t.Errorf("expected the expired entry to be refreshed, got %d calls", inner.calls)
+		}
+	})
+
+	t.Run("evicts the least recently used entry", func(t *testing.T) {
+		ctx := context.Background()
+		c, inner, _ := setup(2, time.Minute)
+		isMember(t, c, ctx, "proj-1", "user-1")
+		isMember(t, c, ctx, "proj-1", "user-2")
+		isMember(t, c, ctx, "proj-1", "user-1")
+		isMember(t, c, ctx, "proj-1", "user-3") // user-2 を追い出す
+		isMember(t, c, ctx, "proj-1", "user-1")
+		if inner.calls != 3 {
+			t.Errorf("expected 3 calls, got %d", inner.calls)
+		}
+		isMember(t, c, ctx, "proj-1", "user-2")
+		if inner.calls != 4 {
+			t.Errorf("expected the evicted entry to be fetched again, got %d calls", inner.calls)
+		}
+	})
+
+	t.Run("separates workspaces", func(t *testing.T) {
+		c, inner, _ := setup(10, time.Minute)
+		isMember(t, c, context.Background(), "proj-1", "user-1")
+		isMember(t, c, workspace.NewContext(context.Background(), "ws-2"), "proj-1", "user-1")
+		if inner.calls != 2 {
+			t.Errorf("expected a lookup per workspace, got %d calls", inner.calls)
+		}
+	})
+
+	t.Run("does not cache errors", func(t *testing.T) {
+		ctx := context.Background()
+		c, inner, _ := setup(10, time.Minute)
+		inner.err = errors.New("unavailable")
+		if _, err := c.IsMember(ctx, "proj-1", "user-1"); err == nil {
+			t.Fatal("expected error")
+		}
+		inner.err = nil
+		if !isMember(t, c, ctx, "proj-1", "user-1") {
+			t.Error("expected user-1 to be a member after recovery")
+		}
+		if inner.calls != 2 {
+			t.Errorf("expected 2 calls, got %d", inner.calls)
+		}
+	})
+}
@@ -0,0 +1,204 @@
+package taskinfra
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/workspace"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// repoCacheRequests は FindByID キャッシュのヒット / ミス数（result = hit | miss）。
+var repoCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "tasks_repository_cache_requests_total",
+	Help: "Number of task detail cache lookups by result.",
+}, []string{"result"})
+
+// CachingTaskRepository は FindByID の結果をプロセス内の LRU にキャッシュする TaskRepository のデコレータ。
+//
+// タスク詳細のポーリングによる DB 負荷を下げるためのもので、一覧系はキャッシュしない。
+//   - Save / Update で該当タスクのエントリを破棄する
+//   - 他のレプリカでの更新は Invalidate（TaskChangeListener の通知）で破棄する
+//   - トランザクション内の FindByID は読み取り後に更新されるため、キャッシュを使わない
+//   - エントリはタスク ID で引くため、ヒットしても context のワークスペースと異なるタスクは返さない
+//
+// 呼び出し側が返り値を変更してもキャッシュに影響しないよう、コピーを保持・返却する。
+type CachingTaskRepository struct {
+	inner usecase.TaskRepository
+	size  int
+	ttl   time.Duration
+	clock clock.Clock
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // 先頭が最近使われたエントリ
+}
+
+type cacheEntry struct {
+	task      domain.Task
+	expiresAt time.Time
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.TaskRepository = (*CachingTaskRepository)(nil)
+
+// NewCachingTaskRepository は新しいCachingTaskRepositoryを生成する。
+// size は最大エントリ数、ttl はエントリの有効期間（<= 0 の場合は期限なし）。
+func NewCachingTaskRepository(inner usecase.TaskRepository, size int, ttl time.Duration) *CachingTaskRepository {
+	return &CachingTaskRepository{
+		inner:   inner,
+		size:    size,
+		ttl:     ttl,
+		clock:   clock.System,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Invalidate は id のエントリを破棄する。
+func (r *CachingTaskRepository) Invalidate(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if el, ok := r.entries[id]; ok {
+		r.lru.Remove(el)
+		delete(r.entries, id)
+	}
+}
+
+func (r *CachingTaskRepository) get(id string) (*domain.Task, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	el, ok := r.entries[id]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if r.ttl > 0 && !r.clock.Now().Before(entry.expiresAt) {
+		r.lru.Remove(el)
+		delete(r.entries, id)
+		return nil, false
+	}
+	r.lru.MoveToFront(el)
+	t := entry.task
+	return &t, true
+}
+
+func (r *CachingTaskRepository) put(t *domain.Task) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry := &cacheEntry{task: *t, expiresAt: r.clock.Now().Add(r.ttl)}
+	if el, ok := r.entries[t.ID]; ok {
+		el.Value = entry
+		r.lru.MoveToFront(el)
+		return
+	}
+	r.entries[t.ID] = r.lru.PushFront(entry)
+	for r.lru.Len() > r.size {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.entries, oldest.Value.(*cacheEntry).task.ID)
+	}
+}
+
+// Save はタスクを保存する。
+func (r *CachingTaskRepository) Save(ctx context.Context, t *domain.Task) error {
+	defer r.Invalidate(t.ID)
+	return r.inner.Save(ctx, t)
+}
+
+// Update は既存タスクを更新する。
+func (r *CachingTaskRepository) Update(ctx context.Context, t *domain.Task) error {
+	defer r.Invalidate(t.ID)
+	return r.inner.Update(ctx, t)
+}
+
+// FindByID はIDを指定してタスクを取得する。存在しない場合はキャッシュしない。
+func (r *CachingTaskRepository) FindByID(ctx context.Context, id string) (*domain.Task, error) {
+	if _, ok := TxFromContext(ctx); ok {
+		return r.inner.FindByID(ctx, id)
+	}
+	if t, ok := r.get(id); ok {
+		repoCacheRequests.WithLabelValues("hit").Inc()
+		if t.WorkspaceID != workspace.FromContext(ctx) {
+			return nil, usecase.ErrTaskNotFound
+		}
+		return t, nil
+	}
+	repoCacheRequests.WithLabelValues("miss").Inc()
+
+	t, err := r.inner.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.put(t)
+	return t, nil
+}
+
+// FindByNumber はプロジェクト内のタスク番号を指定してタスクを取得する（キャッシュしない）。
+func (r *CachingTaskRepository) FindByNumber(ctx context.Context, projectID string, number int) (*domain.Task, error) {
+	return r.inner.FindByNumber(ctx, projectID, number)
+}
+
+// ListByProject は指定されたprojectIDのタスク一覧を返す。
+func (r *CachingTaskRepository) ListByProject(ctx context.Context, projectID string) ([]*domain.Task, error) {
+	return r.inner.ListByProject(ctx, projectID)
+}
+
+// FindByProjectID は指定されたprojectIDとQuery Objectに基づいてタスクを取得する。
+func (r *CachingTaskRepository) FindByProjectID(ctx context.Context, projectID string, query *domain.TaskQuery) ([]*domain.Task, error) {
+	return r.inner.FindByProjectID(ctx, projectID, query)
+}
+
+// ProjectVersion はプロジェクトのタスク一覧のバージョンを返す（変更を検知するためキャッシュしない）。
+func (r *CachingTaskRepository) ProjectVersion(ctx context.Context, projectID string) (domain.ProjectVersion, error) {
+	return r.inner.ProjectVersion(ctx, projectID)
+}
+
+// ArchiveByProject は projectID のタスクをアーカイブし、対象のタスクのエントリを破棄する。
+func (r *CachingTaskRepository) ArchiveByProject(ctx context.Context, projectID string, archivedAt time.Time) ([]string, error) {
+	ids, err := r.inner.ArchiveByProject(ctx, projectID, archivedAt)
+	r.invalidateProject(projectID)
+	return ids, err
+}
+
+// UnarchiveByProject は projectID のアーカイブされたタスクを戻し、対象のタスクのエントリを破棄する。
+func (r *CachingTaskRepository) UnarchiveByProject(ctx context.Context, projectID string) ([]string, error) {
+	ids, err := r.inner.UnarchiveByProject(ctx, projectID)
+	r.invalidateProject(projectID)
+	return ids, err
+}
+
+// DeleteByProject は projectID のタスクを削除し、対象のタスクのエントリを破棄する。
+func (r *CachingTaskRepository) DeleteByProject(ctx context.Context, projectID string) ([]string, error) {
+	ids, err := r.inner.DeleteByProject(ctx, projectID)
+	r.invalidateProject(projectID)
+	return ids, err
+}
+
+// MoveIncompleteSprintTasks はスプリントの未完了のタスクを移し、プロジェクトのタスクのエントリを破棄する。
+func (r *CachingTaskRepository) MoveIncompleteSprintTasks(ctx context.Context, projectID, fromSprintID string, toSprintID *string, actorID string, now time.Time) ([]string, error) {
+	ids, err := r.inner.MoveIncompleteSprintTasks(ctx, projectID, fromSprintID, toSprintID, actorID, now)
+	r.invalidateProject(projectID)
+	return ids, err
+}
+
+// invalidateProject は projectID のタスクのエントリをすべて破棄する。
+// 失敗時（一部だけ反映された可能性がある）も破棄するため、返された ID ではなくキャッシュ側のタスクで判定する。
+func (r *CachingTaskRepository) invalidateProject(projectID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, el := range r.entries {
+		if el.Value.(*cacheEntry).task.ProjectID == projectID {
+			r.lru.Remove(el)
+			delete(r.entries, id)
+		}
+	}
+}
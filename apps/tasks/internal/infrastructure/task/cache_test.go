@@ -0,0 +1,183 @@
+package taskinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/workspace"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// countingRepo は FindByID の呼び出し回数を数える TaskRepository（キャッシュの確認用）。
+type countingRepo struct {
+	*MemoryTaskRepository
+	finds int
+}
+
+func (r *countingRepo) FindByID(ctx context.Context, id string) (*domain.Task, error) {
+	r.finds++
+	return r.MemoryTaskRepository.FindByID(ctx, id)
+}
+
+func TestCachingTaskRepository(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	newTask := func(t *testing.T, id string) *domain.Task {
+		t.Helper()
+		task, err := domain.NewTask(id, "proj-1", "title "+id, "", domain.StatusTodo, domain.PriorityMedium, nil, now)
+		if err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+		return task
+	}
+
+	setup := func(t *testing.T, size int, ttl time.Duration, ids ...string) (*CachingTaskRepository, *countingRepo, *clock.Fake) {
+		t.Helper()
+		inner := &countingRepo{MemoryTaskRepository: NewMemoryTaskRepository()}
+		for _, id := range ids {
+			if err := inner.Save(ctx, newTask(t, id)); err != nil {
+				t.Fatalf("failed to save: %v", err)
+			}
+		}
+		clk := clock.NewFake(now)
+		repo := NewCachingTaskRepository(inner, size, ttl)
+		repo.clock = clk
+		return repo, inner, clk
+	}
+
+	find := func(t *testing.T, repo *CachingTaskRepository, id string) *domain.Task {
+		t.Helper()
+		task, err := repo.FindByID(ctx, id)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return task
+	}
+
+	t.Run("hit after miss", func(t *testing.T) {
+		repo, inner, _ := setup(t, 10, time.Minute, "task-1")
+		hits, misses := testutil.ToFloat64(repoCacheRequests.WithLabelValues("hit")), testutil.ToFloat64(repoCacheRequests.WithLabelValues("miss"))
+
+		find(t, repo, "task-1")
+		find(t, repo, "task-1")
+
+		if inner.finds != 1 {
+			t.Errorf("expected 1 inner FindByID, got %d", inner.finds)
+		}
+		if got := testutil.ToFloat64(repoCacheRequests.WithLabelValues("hit")) - hits; got != 1 {
+			t.Errorf("expected 1 hit, got %v", got)
+		}
+		if got := testutil.ToFloat64(repoCacheRequests.WithLabelValues("miss")) - misses; got != 1 {
+			t.Errorf("expected 1 miss, got %v", got)
+		}
+	})
+
+	t.Run("returned task is a copy", func(t *testing.T) {
+		repo, _, _ := setup(t, 10, time.Minute, "task-1")
+		find(t, repo, "task-1").Title = "changed"
+
+		if got := find(t, repo, "task-1").Title; got != "title task-1" {
+			t.Errorf("cached task was modified: %q", got)
+		}
+	})
+
+	t.Run("update invalidates", func(t *testing.T) {
+		repo, inner, _ := setup(t, 10, time.Minute, "task-1")
+		task := find(t, repo, "task-1")
+		task.Title = "updated"
+		task.RecordChange("title")
+		if err := repo.Update(ctx, task); err != nil {
+			t.Fatalf("failed to update: %v", err)
+		}
+
+		if got := find(t, repo, "task-1").Title; got != "updated" {
+			t.Errorf("expected updated title, got %q", got)
+		}
+		if inner.finds != 2 {
+			t.Errorf("expected 2 inner FindByID, got %d", inner.finds)
+		}
+	})
+
+	t.Run("archive by project invalidates", func(t *testing.T) {
+		repo, _, _ := setup(t, 10, time.Minute, "task-1", "task-2")
+		find(t, repo, "task-1")
+		find(t, repo, "task-2")
+		if _, err := repo.ArchiveByProject(ctx, "proj-1", now); err != nil {
+			t.Fatalf("failed to archive: %v", err)
+		}
+
+		for _, id := range []string{"task-1", "task-2"} {
+			if got := find(t, repo, id).ArchivedAt; got == nil || !got.Equal(now) {
+				t.Errorf("expected %s archived at %v, got %v", id, now, got)
+			}
+		}
+	})
+
+	t.Run("delete by project invalidates", func(t *testing.T) {
+		repo, _, _ := setup(t, 10, time.Minute, "task-1")
+		find(t, repo, "task-1")
+		if _, err := repo.DeleteByProject(ctx, "proj-1"); err != nil {
+			t.Fatalf("failed to delete: %v", err)
+		}
+
+		if _, err := repo.FindByID(ctx, "task-1"); !errors.Is(err, ErrTaskNotFound) {
+			t.Errorf("expected ErrTaskNotFound, got %v", err)
+		}
+	})
+
+	t.Run("expires after ttl", func(t *testing.T) {
+		repo, inner, clk := setup(t, 10, time.Minute, "task-1")
+		find(t, repo, "task-1")
+		clk.Advance(time.Minute)
+		find(t, repo, "task-1")
+
+		if inner.finds != 2 {
+			t.Errorf("expected 2 inner FindByID, got %d", inner.finds)
+		}
+	})
+
+	t.Run("evicts least recently used", func(t *testing.T) {
+		repo, inner, _ := setup(t, 2, time.Minute, "task-1", "task-2", "task-3")
+		find(t, repo, "task-1")
+		find(t, repo, "task-2")
+		find(t, repo, "task-1") // task-2 が最も古くなる
+		find(t, repo, "task-3") // task-2 を追い出す
+		inner.finds = 0
+
+		find(t, repo, "task-1")
+		find(t, repo, "task-2")
+		if inner.finds != 1 {
+			t.Errorf("expected only task-2 to miss, got %d inner FindByID", inner.finds)
+		}
+	})
+
+	t.Run("not found is not cached", func(t *testing.T) {
+		repo, inner, _ := setup(t, 10, time.Minute)
+		for i := 0; i < 2; i++ {
+			if _, err := repo.FindByID(ctx, "missing"); !errors.Is(err, ErrTaskNotFound) {
+				t.Fatalf("expected ErrTaskNotFound, got %v", err)
+			}
+		}
+		if inner.finds != 2 {
+			t.Errorf("expected 2 inner FindByID, got %d", inner.finds)
+		}
+	})
+
+	t.Run("hit from another workspace is not found", func(t *testing.T) {
+		repo, _, _ := setup(t, 10, time.Minute, "task-1")
+		find(t, repo, "task-1")
+
+		other := workspace.NewContext(ctx, "ws-2")
+		if _, err := repo.FindByID(other, "task-1"); !errors.Is(err, ErrTaskNotFound) {
+			t.Fatalf("expected ErrTaskNotFound for another workspace, got %v", err)
+		}
+		find(t, repo, "task-1")
+	})
+}
@@ -0,0 +1,20 @@
+//go:build integration
+// +build integration
+
+package taskinfra
+
+import (
+	"testing"
+
+	"teamflow-tasks/internal/testutil"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// TestSQLTaskRepository_Conformance は MemoryTaskRepository と同じ適合テストを SQL 実装で実行する。
+func TestSQLTaskRepository_Conformance(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	runConformance(t, func(t *testing.T) usecase.TaskRepository {
+		testutil.ResetTasksTable(t, db)
+		return NewSQLTaskRepository(db)
+	})
+}
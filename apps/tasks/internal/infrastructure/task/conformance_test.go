@@ -0,0 +1,363 @@
+package taskinfra
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"teamflow-shared/workspace"
+
+	domain "teamflow-tasks/internal/domain/task"
+	"teamflow-tasks/internal/testutil"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// リポジトリ実装（MemoryTaskRepository / SQLTaskRepository）の共通適合テスト。
+// 同じシナリオを両方の実装で実行し、フィルタ・ソート（null の順序、同値時の id による安定化）・
+// ページング（limit + 1 件、cursor の seek 条件）の挙動がずれないようにする。
+//
+// SQL 側は conformance_integration_test.go で実行する。
+
+var conformanceBase = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// conformanceSeed は適合テストの初期データ。
+// created_at / updated_at / due_date / priority に同値を含め、id による安定化を確認できるようにする。
+func conformanceSeed() []*domain.Task {
+	hours := func(h int) time.Time { return conformanceBase.Add(time.Duration(h) * time.Hour) }
+	date := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	b := testutil.NewTaskBuilder()
+
+	return []*domain.Task{
+		b.WithID("a1").WithTitle("Design API").WithPriority(domain.PriorityHigh).WithAssigneeID("u1").WithDueDate(date("2025-02-01")).WithSprintID("s1").WithCreatedBy("user-1").WithCreatedAt(hours(1)).WithUpdatedAt(hours(5)).Build(),
+		b.WithID("a2").WithTitle("Write docs").WithStatus(domain.StatusInProgress).WithAssigneeID("u2").WithSprintID("s1").WithLabelIDs("l2", "l1").WithCreatedAt(hours(1)).WithUpdatedAt(hours(2)).Build(),
+		b.WithID("a3").WithTitle("Fix 100% bug").WithStatus(domain.StatusDone).WithPriority(domain.PriorityLow).WithDueDate(date("2025-01-15")).WithSprintID("s1").WithEpicID("e1").WithCreatedAt(hours(2)).Build(),
+		b.WithID("a4").WithTitle("design review").WithAssigneeID("u1").WithEpicID("e1").WithDueDate(date("2025-02-01")).WithCreatedAt(hours(3)).WithUpdatedAt(hours(1)).Build(),
+		b.WithID("a5").WithTitle("Deploy_v2").WithPriority(domain.PriorityLow).WithCreatedAt(hours(4)).Build(),
+		b.WithID("b1").WithProjectID("proj-2").WithTitle("Design API").WithPriority(domain.PriorityHigh).WithAssigneeID("u1").WithCreatedAt(hours(0)).Build(),
+	}
+}
+
+// conformanceCase は FindByProjectID の 1 シナリオ。
+type conformanceCase struct {
+	name        string
+	projectID   string // 空の場合は proj-1
+	opts        []domain.TaskQueryOption
+	cursorAfter string   // 指定した ID のタスクを cursor（created_at, id）として使う
+	cursorDesc  bool     // cursor を新しい順（created_at DESC, id DESC）にする
+	want        []string // 期待する ID（順序込み）
+}
+
+var conformanceCases = []conformanceCase{
+	// デフォルト / フィルタ
+	{name: "default order is createdAt then id", want: []string{"a1", "a2", "a3", "a4", "a5"}},
+	{name: "other project is not included", projectID: "proj-2", want: []string{"b1"}},
+	{name: "unknown project is empty", projectID: "proj-x", want: []string{}},
+	{name: "status", opts: []domain.TaskQueryOption{domain.WithStatusFilter("todo")}, want: []string{"a1", "a4", "a5"}},
+	{name: "multiple statuses with doing", opts: []domain.TaskQueryOption{domain.WithStatusFilter("todo,doing")}, want: []string{"a1", "a2", "a4", "a5"}},
+	{name: "priorities", opts: []domain.TaskQueryOption{domain.WithPriorityFilter("high,low")}, want: []string{"a1", "a3", "a5"}},
+	{name: "assignee", opts: []domain.TaskQueryOption{domain.WithAssigneeIDFilter("u1")}, want: []string{"a1", "a4"}},
+	{name: "sprint", opts: []domain.TaskQueryOption{domain.WithSprintIDFilter("s1")}, want: []string{"a1", "a2", "a3"}},
+	{name: "epic", opts: []domain.TaskQueryOption{domain.WithEpicIDFilter("e1")}, want: []string{"a3", "a4"}},
+	{name: "dueDate range is inclusive and excludes null", opts: []domain.TaskQueryOption{domain.WithDueDateRangeFilter("2025-01-15", "2025-02-01")}, want: []string{"a1", "a3", "a4"}},
+	{name: "dueDateFrom only", opts: []domain.TaskQueryOption{domain.WithDueDateRangeFilter("2025-01-16", "")}, want: []string{"a1", "a4"}},
+	{name: "q is case insensitive", opts: []domain.TaskQueryOption{domain.WithQueryFilter("DESIGN")}, want: []string{"a1", "a4"}},
+	{name: "q percent is literal", opts: []domain.TaskQueryOption{domain.WithQueryFilter("100%")}, want: []string{"a3"}},
+	{name: "q underscore is literal", opts: []domain.TaskQueryOption{domain.WithQueryFilter("_")}, want: []string{"a5"}},
+
+	// ソート
+	{name: "dueDate asc nulls last", opts: []domain.TaskQueryOption{domain.WithSort("dueDate")}, want: []string{"a3", "a1", "a4", "a2", "a5"}},
+	{name: "dueDate desc nulls first", opts: []domain.TaskQueryOption{domain.WithSort("-dueDate")}, want: []string{"a2", "a5", "a1", "a4", "a3"}},
+	{name: "priority desc ties by id", opts: []domain.TaskQueryOption{domain.WithSort("-priority")}, want: []string{"a1", "a2", "a4", "a3", "a5"}},
+	{name: "priority then createdAt desc", opts: []domain.TaskQueryOption{domain.WithSort("priority,-createdAt")}, want: []string{"a5", "a3", "a4", "a2", "a1"}},
+	{name: "updatedAt desc ties by id", opts: []domain.TaskQueryOption{domain.WithSort("-updatedAt")}, want: []string{"a1", "a5", "a2", "a3", "a4"}},
+	{name: "sortOrder only falls back to default", opts: []domain.TaskQueryOption{domain.WithSort("sortOrder")}, want: []string{"a1", "a2", "a3", "a4", "a5"}},
+	{name: "filters with sort", opts: []domain.TaskQueryOption{domain.WithStatusFilter("todo"), domain.WithAssigneeIDFilter("u1"), domain.WithSort("-createdAt")}, want: []string{"a4", "a1"}},
+
+	// ページング
+	{name: "limit returns limit + 1", opts: []domain.TaskQueryOption{domain.WithLimit(2)}, want: []string{"a1", "a2", "a3"}},
+	{name: "cursor seeks past same createdAt by id", cursorAfter: "a2", want: []string{"a3", "a4", "a5"}},
+	{name: "cursor with filter", cursorAfter: "a1", opts: []domain.TaskQueryOption{domain.WithStatusFilter("todo")}, want: []string{"a4", "a5"}},
+	{name: "cursor with limit", cursorAfter: "a2", opts: []domain.TaskQueryOption{domain.WithLimit(1)}, want: []string{"a3", "a4"}},
+	{name: "createdAt desc ties by id desc", opts: []domain.TaskQueryOption{domain.WithSort("-createdAt")}, want: []string{"a5", "a4", "a3", "a2", "a1"}},
+	{name: "desc cursor seeks past same createdAt by id", cursorAfter: "a2", cursorDesc: true, want: []string{"a1"}},
+	{name: "desc cursor with filter and limit", cursorAfter: "a5", cursorDesc: true, opts: []domain.TaskQueryOption{domain.WithStatusFilter("todo,done"), domain.WithLimit(1)}, want: []string{"a4", "a3"}},
+}
+
+// runConformance は newRepo で生成した空のリポジトリに conformanceSeed を保存し、全シナリオを実行する。
+func runConformance(t *testing.T, newRepo func(t *testing.T) usecase.TaskRepository) {
+	ctx := context.Background()
+	repo := newRepo(t)
+	seed := conformanceSeed()
+	byID := make(map[string]*domain.Task, len(seed))
+	for _, task := range seed {
+		if err := repo.Save(ctx, task); err != nil {
+			t.Fatalf("failed to save %s: %v", task.ID, err)
+		}
+		byID[task.ID] = task
+	}
+
+	for _, tc := range conformanceCases {
+		t.Run(tc.name, func(t *testing.T) {
+			projectID := tc.projectID
+			if projectID == "" {
+				projectID = "proj-1"
+			}
+			query, err := domain.NewTaskQuery(tc.opts...)
+			if err != nil {
+				t.Fatalf("failed to build query: %v", err)
+			}
+			if tc.cursorAfter != "" {
+				after := byID[tc.cursorAfter]
+				query.Cursor = &domain.TaskCursor{CreatedAt: after.CreatedAt, ID: after.ID, ProjectID: projectID, Descending: tc.cursorDesc}
+			}
+
+			tasks, err := repo.FindByProjectID(ctx, projectID, query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertOrderedIDs(t, tasks, tc.want)
+		})
+	}
+
+	t.Run("ListByProject uses default order", func(t *testing.T) {
+		tasks, err := repo.ListByProject(ctx, "proj-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertOrderedIDs(t, tasks, []string{"a1", "a2", "a3", "a4", "a5"})
+	})
+
+	t.Run("label ids round trip", func(t *testing.T) {
+		a2, err := repo.FindByID(ctx, "a2")
+		if err != nil || !slices.Equal(a2.LabelIDs, []string{"l2", "l1"}) {
+			t.Fatalf("expected a2 labels [l2 l1], got %+v (err=%v)", a2, err)
+		}
+		// ラベルが無いタスクは nil で返す（SQL の空配列も nil にそろえる）
+		if a1, err := repo.FindByID(ctx, "a1"); err != nil || a1.LabelIDs != nil {
+			t.Fatalf("expected a1 without labels, got %+v (err=%v)", a1, err)
+		}
+	})
+
+	t.Run("createdBy and updatedBy round trip", func(t *testing.T) {
+		a1, err := repo.FindByID(ctx, "a1")
+		if err != nil || a1.CreatedBy != "user-1" || a1.UpdatedBy != "user-1" {
+			t.Fatalf("expected a1 created and updated by user-1, got %+v (err=%v)", a1, err)
+		}
+		if a2, err := repo.FindByID(ctx, "a2"); err != nil || a2.CreatedBy != "" || a2.UpdatedBy != "" {
+			t.Fatalf("expected a2 without actors, got %+v (err=%v)", a2, err)
+		}
+	})
+
+	t.Run("project version", func(t *testing.T) {
+		v, err := repo.ProjectVersion(ctx, "proj-1")
+		if err != nil || v.Count != 5 || !v.UpdatedAt.Equal(conformanceBase.Add(5*time.Hour)) {
+			t.Fatalf("expected 5 tasks updated at +5h, got %+v (err=%v)", v, err)
+		}
+		if v, err := repo.ProjectVersion(ctx, "proj-x"); err != nil || v.Count != 0 || !v.UpdatedAt.IsZero() {
+			t.Fatalf("expected an empty version, got %+v (err=%v)", v, err)
+		}
+	})
+
+	// 以降はデータを変更するため最後に実行する
+	t.Run("version and field versions", func(t *testing.T) {
+		a5, err := repo.FindByID(ctx, "a5")
+		if err != nil || a5.Version != 1 || a5.FieldVersions != nil {
+			t.Fatalf("expected a5 at version 1 without field versions, got %+v (err=%v)", a5, err)
+		}
+		stale := cloneTask(a5)
+		if err := a5.ApplyPatch(domain.TaskPatch{Title: domain.Set("Deploy v2")}, conformanceBase); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := repo.Update(ctx, a5); err != nil {
+			t.Fatalf("failed to update: %v", err)
+		}
+		got, err := repo.FindByID(ctx, "a5")
+		if err != nil || got.Version != 2 || !maps.Equal(got.FieldVersions, map[string]int{"title": 2}) {
+			t.Fatalf("expected a5 at version 2 with title changed, got %+v (err=%v)", got, err)
+		}
+
+		// 読み取った後に他で更新された（版数が進んだ）タスクは上書きしない
+		if err := stale.ApplyPatch(domain.TaskPatch{Priority: domain.Set(domain.PriorityHigh)}, conformanceBase); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var conflict *domain.VersionConflictError
+		if err := repo.Update(ctx, stale); !errors.As(err, &conflict) || conflict.Current != 2 {
+			t.Fatalf("expected VersionConflictError (current 2), got %v", err)
+		}
+	})
+
+	t.Run("move incomplete sprint tasks", func(t *testing.T) {
+		movedAt := conformanceBase.Add(24 * time.Hour)
+		next := "s2"
+		ids, err := repo.MoveIncompleteSprintTasks(ctx, "proj-1", "s1", &next, "user-2", movedAt)
+		if err != nil || len(ids) != 2 {
+			t.Fatalf("expected 2 moved tasks, got %v (err=%v)", ids, err)
+		}
+		a1, err := repo.FindByID(ctx, "a1")
+		if err != nil || a1.SprintID == nil || *a1.SprintID != "s2" || !a1.UpdatedAt.Equal(movedAt) {
+			t.Fatalf("expected a1 moved to s2 at %v, got %+v (err=%v)", movedAt, a1, err)
+		}
+		if a1.CreatedBy != "user-1" || a1.UpdatedBy != "user-2" {
+			t.Fatalf("expected a1 created by user-1 and updated by user-2, got %q / %q", a1.CreatedBy, a1.UpdatedBy)
+		}
+		if a1.Version != 2 || a1.FieldVersions["sprintId"] != 2 {
+			t.Fatalf("expected a1 sprintId changed at version 2, got version=%d fields=%v", a1.Version, a1.FieldVersions)
+		}
+		// 完了したタスクは完了したスプリントに残す
+		a3, err := repo.FindByID(ctx, "a3")
+		if err != nil || a3.SprintID == nil || *a3.SprintID != "s1" {
+			t.Fatalf("expected a3 to stay in s1, got %+v (err=%v)", a3, err)
+		}
+		if ids, err := repo.MoveIncompleteSprintTasks(ctx, "proj-1", "s1", &next, "user-2", movedAt); err != nil || len(ids) != 0 {
+			t.Fatalf("expected no tasks on retry, got %v (err=%v)", ids, err)
+		}
+
+		// nil はバックログに戻す
+		if ids, err := repo.MoveIncompleteSprintTasks(ctx, "proj-1", "s2", nil, "user-2", movedAt); err != nil || len(ids) != 2 {
+			t.Fatalf("expected 2 tasks moved to backlog, got %v (err=%v)", ids, err)
+		}
+		if a1, _ := repo.FindByID(ctx, "a1"); a1.SprintID != nil {
+			t.Errorf("expected a1 in backlog, got %v", *a1.SprintID)
+		}
+	})
+
+	t.Run("archive, unarchive and delete by project", func(t *testing.T) {
+		archivedAt := conformanceBase.Add(48 * time.Hour)
+		ids, err := repo.ArchiveByProject(ctx, "proj-1", archivedAt)
+		if err != nil || len(ids) != 5 {
+			t.Fatalf("expected 5 archived tasks, got %v (err=%v)", ids, err)
+		}
+		if ids, err := repo.ArchiveByProject(ctx, "proj-1", archivedAt.Add(time.Hour)); err != nil || len(ids) != 0 {
+			t.Fatalf("expected archived tasks to be skipped, got %v (err=%v)", ids, err)
+		}
+
+		// アーカイブしたタスクは一覧に含めないが、ID・番号では取得できる
+		listed, err := repo.ListByProject(ctx, "proj-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertOrderedIDs(t, listed, []string{})
+		query, _ := domain.NewTaskQuery()
+		found, err := repo.FindByProjectID(ctx, "proj-1", query)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertOrderedIDs(t, found, []string{})
+		a1, err := repo.FindByID(ctx, "a1")
+		if err != nil || a1.ArchivedAt == nil || !a1.ArchivedAt.Equal(archivedAt) {
+			t.Fatalf("expected a1 archived at %v, got %+v (err=%v)", archivedAt, a1, err)
+		}
+		if _, err := repo.FindByNumber(ctx, "proj-1", a1.Number); err != nil {
+			t.Fatalf("expected archived task to be found by number: %v", err)
+		}
+		other, err := repo.ListByProject(ctx, "proj-2")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertOrderedIDs(t, other, []string{"b1"})
+
+		if ids, err := repo.UnarchiveByProject(ctx, "proj-1"); err != nil || len(ids) != 5 {
+			t.Fatalf("expected 5 unarchived tasks, got %v (err=%v)", ids, err)
+		}
+		listed, err = repo.ListByProject(ctx, "proj-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertOrderedIDs(t, listed, []string{"a1", "a2", "a3", "a4", "a5"})
+
+		if ids, err := repo.DeleteByProject(ctx, "proj-1"); err != nil || len(ids) != 5 {
+			t.Fatalf("expected 5 deleted tasks, got %v (err=%v)", ids, err)
+		}
+		if _, err := repo.FindByID(ctx, "a1"); !errors.Is(err, ErrTaskNotFound) {
+			t.Fatalf("expected ErrTaskNotFound after delete, got %v", err)
+		}
+
+		// 削除後もタスク番号は再利用しない
+		next := testutil.NewTaskBuilder().WithID("a6").WithCreatedAt(conformanceBase).Build()
+		if err := repo.Save(ctx, next); err != nil {
+			t.Fatalf("failed to save task: %v", err)
+		}
+		if next.Number <= a1.Number || next.Number <= 5 {
+			t.Errorf("expected a new number after deleted tasks, got %d", next.Number)
+		}
+	})
+
+	t.Run("purge archived tasks across workspaces", func(t *testing.T) {
+		purger, ok := repo.(usecase.ArchivedTaskPurger)
+		if !ok {
+			t.Fatalf("%T does not implement ArchivedTaskPurger", repo)
+		}
+		otherCtx := workspace.NewContext(ctx, "other")
+		c1 := testutil.NewTaskBuilder().WithID("c1").WithProjectID("proj-3").WithCreatedAt(conformanceBase).Build()
+		if err := repo.Save(otherCtx, c1); err != nil {
+			t.Fatalf("failed to save task: %v", err)
+		}
+		if _, err := repo.ArchiveByProject(ctx, "proj-2", conformanceBase.Add(48*time.Hour)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := repo.ArchiveByProject(otherCtx, "proj-3", conformanceBase.Add(24*time.Hour)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// アーカイブ日時の古い順。before と同じ日時のものは含めない
+		before := conformanceBase.Add(48 * time.Hour)
+		found, err := purger.ArchivedBefore(ctx, before, 10)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertOrderedIDs(t, found, []string{"c1"})
+		found, err = purger.ArchivedBefore(ctx, before.Add(time.Second), 10)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertOrderedIDs(t, found, []string{"c1", "b1"})
+
+		deleted, err := purger.DeleteArchivedBefore(ctx, before.Add(time.Second), 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertOrderedIDs(t, deleted, []string{"c1"})
+		if deleted[0].WorkspaceID != "other" || deleted[0].ArchivedAt == nil {
+			t.Errorf("expected the deleted task with its workspace and archivedAt, got %+v", deleted[0])
+		}
+		if _, err := repo.FindByID(otherCtx, "c1"); !errors.Is(err, ErrTaskNotFound) {
+			t.Fatalf("expected ErrTaskNotFound after purge, got %v", err)
+		}
+		deleted, err = purger.DeleteArchivedBefore(ctx, before.Add(time.Second), 10)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertOrderedIDs(t, deleted, []string{"b1"})
+		if _, err := repo.FindByID(ctx, "a6"); err != nil {
+			t.Fatalf("expected the unarchived task to remain: %v", err)
+		}
+	})
+}
+
+func assertOrderedIDs(t *testing.T, tasks []*domain.Task, want []string) {
+	t.Helper()
+	got := make([]string, len(tasks))
+	for i, task := range tasks {
+		got[i] = task.ID
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestMemoryTaskRepository_Conformance(t *testing.T) {
+	runConformance(t, func(t *testing.T) usecase.TaskRepository {
+		return NewMemoryTaskRepository()
+	})
+}
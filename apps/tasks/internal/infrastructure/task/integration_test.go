@@ -6,6 +6,8 @@ import (
 	"os"
 	"testing"
 
+	"teamflow-shared/dbtest"
+
 	"teamflow-tasks/internal/testutil"
 )
 
@@ -16,6 +18,6 @@ func TestMain(m *testing.M) {
 		os.Exit(code)
 	}
 	// Set testPool to the initialized pool
-	testPool = testutil.TestPool
+	testPool = dbtest.TestPool
 	os.Exit(code)
 }
@@ -0,0 +1,97 @@
+package taskinfra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"teamflow-shared/workspace"
+
+	"teamflow-tasks/internal/broadcast"
+)
+
+// TaskChangesChannel はタスク変更を通知する NOTIFY チャネル名（マイグレーション 0005 のトリガーと一致させる）。
+const TaskChangesChannel = "task_changes"
+
+// TaskChangeListener は task_changes チャネルを LISTEN し、受け取った変更を publish に渡す。
+//
+// LISTEN はセッション単位のため、プールとは別の専用接続を使う。
+// 接続が切れた場合はバックオフしながら再接続する（切断中の通知は失われる）。
+type TaskChangeListener struct {
+	connConfig *pgx.ConnConfig
+	publish    func(broadcast.Event)
+}
+
+// NewTaskChangeListener は新しいTaskChangeListenerを生成する。
+func NewTaskChangeListener(connConfig *pgx.ConnConfig, publish func(broadcast.Event)) *TaskChangeListener {
+	return &TaskChangeListener{connConfig: connConfig, publish: publish}
+}
+
+// Run は ctx がキャンセルされるまで通知を待ち受ける。
+func (l *TaskChangeListener) Run(ctx context.Context) {
+	delay := time.Second
+	for {
+		err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		slog.Warn("task change listener disconnected", "error", err, "retry_in", delay.String())
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if delay *= 2; delay > 30*time.Second {
+			delay = 30 * time.Second
+		}
+	}
+}
+
+// listen は接続して LISTEN し、エラーになるまで通知を publish する。
+func (l *TaskChangeListener) listen(ctx context.Context) error {
+	conn, err := pgx.ConnectConfig(ctx, l.connConfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+TaskChangesChannel); err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		e, err := parseTaskChange(n.Payload)
+		if err != nil {
+			slog.Warn("task change listener: invalid payload", "error", err)
+			continue
+		}
+		l.publish(e)
+	}
+}
+
+// parseTaskChange は NOTIFY のペイロード（notify_task_change() が生成する JSON）を Event に変換する。
+// workspaceId が無いペイロード（0014 より前のトリガー）は既定のワークスペースとして扱う。
+func parseTaskChange(payload string) (broadcast.Event, error) {
+	var e broadcast.Event
+	if err := json.Unmarshal([]byte(payload), &e); err != nil {
+		return broadcast.Event{}, fmt.Errorf("invalid payload %q: %w", payload, err)
+	}
+	if e.Type == "" || e.ProjectID == "" || e.TaskID == "" {
+		return broadcast.Event{}, fmt.Errorf("invalid payload %q: type, projectId and taskId are required", payload)
+	}
+	if e.WorkspaceID == "" {
+		e.WorkspaceID = workspace.DefaultID
+	}
+	return e, nil
+}
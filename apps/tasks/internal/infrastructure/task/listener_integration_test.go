@@ -0,0 +1,56 @@
+//go:build integration
+// +build integration
+
+package taskinfra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"teamflow-tasks/internal/broadcast"
+	domain "teamflow-tasks/internal/domain/task"
+	"teamflow-tasks/internal/testutil"
+)
+
+// TestTaskChangeListener はトリガーの NOTIFY が Listener 経由で publish されることを検証する。
+func TestTaskChangeListener(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetTasksTable(t, db)
+	repo := NewSQLTaskRepository(db)
+
+	events := make(chan broadcast.Event, 4)
+	listener := NewTaskChangeListener(db.Config().ConnConfig, func(e broadcast.Event) { events <- e })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go listener.Run(ctx)
+
+	now := time.Now().UTC()
+	task, err := domain.NewTask("task-1", "proj-1", "title", "", domain.StatusTodo, domain.PriorityMedium, nil, now)
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+
+	// LISTEN の開始前に NOTIFY すると取りこぼすため、最初のイベントを受け取るまで Update を繰り返す
+	if err := repo.Save(ctx, task); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	deadline := time.After(5 * time.Second)
+	for {
+		task.RecordChange()
+		if err := repo.Update(ctx, task); err != nil {
+			t.Fatalf("failed to update: %v", err)
+		}
+		select {
+		case e := <-events:
+			if e.ProjectID != "proj-1" || e.TaskID != "task-1" {
+				t.Fatalf("unexpected event: %+v", e)
+			}
+			return
+		case <-time.After(100 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("timed out waiting for notification")
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package taskinfra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"teamflow-tasks/internal/broadcast"
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+func TestParseTaskChange(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    broadcast.Event
+		wantErr bool
+	}{
+		{
+			name:    "created",
+			payload: `{"type" : "task.created", "workspaceId" : "ws-1", "projectId" : "proj-1", "taskId" : "task-1"}`,
+			want:    broadcast.Event{Type: broadcast.EventTaskCreated, WorkspaceID: "ws-1", ProjectID: "proj-1", TaskID: "task-1"},
+		},
+		{
+			name:    "missing workspaceId falls back to default",
+			payload: `{"type":"task.updated","projectId":"proj-1","taskId":"task-1"}`,
+			want:    broadcast.Event{Type: broadcast.EventTaskUpdated, WorkspaceID: "default", ProjectID: "proj-1", TaskID: "task-1"},
+		},
+		{name: "invalid json", payload: `task-1`, wantErr: true},
+		{name: "missing taskId", payload: `{"type":"task.updated","projectId":"proj-1"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTaskChange(tt.payload)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTaskChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseTaskChange() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotifyingTaskRepository(t *testing.T) {
+	ctx := context.Background()
+	var got []broadcast.Event
+	repo := NewNotifyingTaskRepository(NewMemoryTaskRepository(), func(e broadcast.Event) {
+		got = append(got, e)
+	})
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	task, err := domain.NewTask("task-1", "proj-1", "title", "", domain.StatusTodo, domain.PriorityMedium, nil, now)
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	if err := repo.Save(ctx, task); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	task.RecordChange()
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	// 失敗した更新は通知しない
+	missing, _ := domain.NewTask("missing", "proj-1", "title", "", domain.StatusTodo, domain.PriorityMedium, nil, now)
+	if err := repo.Update(ctx, missing); err == nil {
+		t.Fatal("expected error for missing task")
+	}
+
+	want := []broadcast.Event{
+		{Type: broadcast.EventTaskCreated, WorkspaceID: "default", ProjectID: "proj-1", TaskID: "task-1"},
+		{Type: broadcast.EventTaskUpdated, WorkspaceID: "default", ProjectID: "proj-1", TaskID: "task-1"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package taskinfra
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	domain "teamflow-tasks/internal/domain/task"
+	"teamflow-tasks/internal/usecase/notification"
+)
+
+// MemoryDueReminders は MemoryTaskRepository のタスクから期日が近いタスクを取り出す notification.DueTaskClaimer 実装。
+// 通知済みのタスク（タスクの ID と期日）はこのプロセスのメモリに記録する。
+type MemoryDueReminders struct {
+	repo *MemoryTaskRepository
+
+	mu       sync.Mutex
+	reminded map[dueReminderKey]bool
+}
+
+// dueReminderKey は通知済みのタスクと期日。
+type dueReminderKey struct {
+	taskID  string
+	dueDate string
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ notification.DueTaskClaimer = (*MemoryDueReminders)(nil)
+
+// NewMemoryDueReminders は repo のタスクを対象にする MemoryDueReminders を生成する。
+func NewMemoryDueReminders(repo *MemoryTaskRepository) *MemoryDueReminders {
+	return &MemoryDueReminders{repo: repo, reminded: make(map[dueReminderKey]bool)}
+}
+
+// DueTasks は期日が from から to の通知していないタスクを、期日・ID の順で after より後から最大 limit 件返す。
+func (r *MemoryDueReminders) DueTasks(_ context.Context, from, to time.Time, after *domain.Task, limit int) ([]*domain.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fromDate, toDate := from.Format(time.DateOnly), to.Format(time.DateOnly)
+
+	r.repo.mu.RLock()
+	var due []*domain.Task
+	for _, t := range r.repo.tasks {
+		if t.DueDate == nil || t.AssigneeID == nil || t.ArchivedAt != nil || t.Status == domain.StatusDone {
+			continue
+		}
+		d := t.DueDate.Format(time.DateOnly)
+		if d < fromDate || d > toDate || r.reminded[dueReminderKey{t.ID, d}] {
+			continue
+		}
+		if after != nil && !dueTaskLess(after, t) {
+			continue
+		}
+		due = append(due, cloneTask(t))
+	}
+	r.repo.mu.RUnlock()
+
+	sort.Slice(due, func(i, j int) bool { return dueTaskLess(due[i], due[j]) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// ClaimDueTask は t を今の期日で通知済みとして記録する。記録済みの場合は false を返す。
+func (r *MemoryDueReminders) ClaimDueTask(_ context.Context, t *domain.Task, _ time.Time) (bool, error) {
+	if t.DueDate == nil {
+		return false, nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := dueReminderKey{t.ID, t.DueDate.Format(time.DateOnly)}
+	if r.reminded[key] {
+		return false, nil
+	}
+	r.reminded[key] = true
+	return true, nil
+}
+
+// dueTaskLess は期日・ID の順で a が b より前かどうかを返す。
+func dueTaskLess(a, b *domain.Task) bool {
+	if !a.DueDate.Equal(*b.DueDate) {
+		return a.DueDate.Before(*b.DueDate)
+	}
+	return a.ID < b.ID
+}
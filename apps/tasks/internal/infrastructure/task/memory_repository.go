@@ -2,20 +2,53 @@ package taskinfra
 
 import (
 	"context"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"time"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/workspace"
 
 	domain "teamflow-tasks/internal/domain/task"
 	usecase "teamflow-tasks/internal/usecase/task"
 )
 
 // MemoryTaskRepository はメモリ上にタスクを保持するシンプルな実装。
+//
+// 並行に呼び出してよい。保存時と取得時にコピーするため、呼び出し側が
+// 返り値を変更しても Update するまで保存内容には反映されない（SQL 実装と同じ）。
+// SQL 実装と同じく context のワークスペースのタスクだけを扱う。
 type MemoryTaskRepository struct {
+	mu    sync.RWMutex
 	tasks map[string]*domain.Task
+	// lastNumbers はプロジェクトごとの最後に採番したタスク番号
+	lastNumbers map[string]int
+	// lastSeq は最後に記録した変更の順序。changeSeqs はタスクごとの最後の変更の順序、
+	// tombstones は削除したタスクの記録（MemoryTaskChanges が差分同期に使う）
+	lastSeq    int64
+	changeSeqs map[string]int64
+	tombstones map[string]memoryTombstone
+
+	// Clock は削除の記録の日時に使う。任意。nil の場合は clock.System
+	Clock clock.Clock
+}
+
+// memoryTombstone は削除したタスクの記録。
+type memoryTombstone struct {
+	projectID   string
+	workspaceID string
+	seq         int64
+	deletedAt   time.Time
 }
 
 // コンパイル時にインターフェース実装を保証する。
-var _ usecase.TaskRepository = (*MemoryTaskRepository)(nil)
+var (
+	_ usecase.TaskRepository     = (*MemoryTaskRepository)(nil)
+	_ usecase.ArchivedTaskPurger = (*MemoryTaskRepository)(nil)
+)
 
 // ErrTaskNotFound は指定 ID のタスクが存在しない場合に返す。
 var ErrTaskNotFound = usecase.ErrTaskNotFound
@@ -23,78 +56,91 @@ var ErrTaskNotFound = usecase.ErrTaskNotFound
 // NewMemoryTaskRepository は空のインメモリリポジトリを生成する。
 func NewMemoryTaskRepository() *MemoryTaskRepository {
 	return &MemoryTaskRepository{
-		tasks: make(map[string]*domain.Task),
+		tasks:       make(map[string]*domain.Task),
+		lastNumbers: make(map[string]int),
+		changeSeqs:  make(map[string]int64),
+		tombstones:  make(map[string]memoryTombstone),
 	}
 }
 
-// Save はタスクを保存する。
+// Save はタスクを context のワークスペースに保存し、プロジェクト内のタスク番号を採番して t.Number に設定する。
 // タスク ID をキーにして複数タスクを独立して保存できる状態にする。
-func (r *MemoryTaskRepository) Save(_ context.Context, t *domain.Task) error {
+func (r *MemoryTaskRepository) Save(ctx context.Context, t *domain.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	if r.tasks == nil {
 		r.tasks = make(map[string]*domain.Task)
 	}
-	r.tasks[t.ID] = t // ★ これが非常に重要（taskID をキーにする）
+	if r.lastNumbers == nil {
+		r.lastNumbers = make(map[string]int)
+	}
+	r.lastNumbers[t.ProjectID]++
+	t.Number = r.lastNumbers[t.ProjectID]
+	t.WorkspaceID = workspace.FromContext(ctx)
+	t.Version, t.FieldVersions = 1, nil
+	r.tasks[t.ID] = cloneTask(t) // ★ これが非常に重要（taskID をキーにする）
+	r.recordChange(t.ID)
 	return nil
 }
 
-// Update は既存タスクを上書き保存する。
-func (r *MemoryTaskRepository) Update(_ context.Context, t *domain.Task) error {
-	if r.tasks == nil {
+// Update は既存タスクを上書き保存する。保存されている版数が t.Version-1 でない場合は *domain.VersionConflictError を返す。
+func (r *MemoryTaskRepository) Update(ctx context.Context, t *domain.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.tasks[t.ID]
+	if !ok || !inWorkspace(ctx, stored) {
 		return ErrTaskNotFound
 	}
-	if _, ok := r.tasks[t.ID]; !ok {
-		return ErrTaskNotFound
+	if stored.Version != t.Version-1 {
+		return &domain.VersionConflictError{Current: stored.Version}
 	}
-	r.tasks[t.ID] = t
+	c := cloneTask(t)
+	c.WorkspaceID = stored.WorkspaceID
+	r.tasks[t.ID] = c
+	r.recordChange(t.ID)
 	return nil
 }
 
 // FindByID は ID を指定してタスクを取得する。
-func (r *MemoryTaskRepository) FindByID(_ context.Context, id string) (*domain.Task, error) {
-	if r.tasks == nil {
-		return nil, ErrTaskNotFound
-	}
+func (r *MemoryTaskRepository) FindByID(ctx context.Context, id string) (*domain.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	task, ok := r.tasks[id]
-	if !ok {
+	if !ok || !inWorkspace(ctx, task) {
 		return nil, ErrTaskNotFound
 	}
-	return task, nil
+	return cloneTask(task), nil
 }
 
-// ListByProject は指定された projectID のタスク一覧を返す（後方互換性のため残す）。
-func (r *MemoryTaskRepository) ListByProject(_ context.Context, projectID string) ([]*domain.Task, error) {
-	if r.tasks == nil {
-		return []*domain.Task{}, nil
-	}
-
-	out := make([]*domain.Task, 0)
+// FindByNumber はプロジェクト内のタスク番号を指定してタスクを取得する。
+func (r *MemoryTaskRepository) FindByNumber(ctx context.Context, projectID string, number int) (*domain.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	for _, t := range r.tasks {
-		if t.ProjectID == projectID {
-			out = append(out, t)
+		if t.ProjectID == projectID && t.Number == number && inWorkspace(ctx, t) {
+			return cloneTask(t), nil
 		}
 	}
+	return nil, ErrTaskNotFound
+}
+
+// ListByProject は指定された projectID のタスク一覧を返す（後方互換性のため残す）。
+func (r *MemoryTaskRepository) ListByProject(ctx context.Context, projectID string) ([]*domain.Task, error) {
+	out := r.tasksInProject(ctx, projectID)
 
+	// SQL 実装と同じく created_at ASC, id ASC
 	sort.Slice(out, func(i, j int) bool {
-		return out[i].CreatedAt.Before(out[j].CreatedAt)
+		return r.compareTasks(out[i], out[j], &domain.TaskQuery{})
 	})
 	return out, nil
 }
 
 // FindByProjectID は指定された projectID と Query Object に基づいてタスクを取得する。
-func (r *MemoryTaskRepository) FindByProjectID(_ context.Context, projectID string, query *domain.TaskQuery) ([]*domain.Task, error) {
-	if r.tasks == nil {
-		return []*domain.Task{}, nil
-	}
-
+func (r *MemoryTaskRepository) FindByProjectID(ctx context.Context, projectID string, query *domain.TaskQuery) ([]*domain.Task, error) {
 	// まず projectID でフィルタ
-	candidates := make([]*domain.Task, 0)
-	for _, t := range r.tasks {
-		if t.ProjectID == projectID {
-			candidates = append(candidates, t)
-		}
-	}
+	candidates := r.tasksInProject(ctx, projectID)
 
-	// Query Object のフィルタを適用
+	// Query Object のフィルタを適用（cursor の seek 条件を含む）
 	filtered := r.filterTasks(candidates, query)
 
 	// Query Object のソートを適用
@@ -106,6 +152,193 @@ func (r *MemoryTaskRepository) FindByProjectID(_ context.Context, projectID stri
 	return result, nil
 }
 
+// ProjectVersion は projectID のアーカイブされていないタスクの件数と最新の updated_at を返す。
+func (r *MemoryTaskRepository) ProjectVersion(ctx context.Context, projectID string) (domain.ProjectVersion, error) {
+	var v domain.ProjectVersion
+	for _, t := range r.tasksInProject(ctx, projectID) {
+		v.Count++
+		if t.UpdatedAt.After(v.UpdatedAt) {
+			v.UpdatedAt = t.UpdatedAt
+		}
+	}
+	return v, nil
+}
+
+// ArchiveByProject は projectID のアーカイブされていないタスクを archivedAt でアーカイブし、対象のタスク ID を返す。
+func (r *MemoryTaskRepository) ArchiveByProject(ctx context.Context, projectID string, archivedAt time.Time) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ids := make([]string, 0)
+	for _, t := range r.tasks {
+		if t.ProjectID == projectID && inWorkspace(ctx, t) && t.ArchivedAt == nil {
+			at := archivedAt
+			t.ArchivedAt = &at
+			r.recordChange(t.ID)
+			ids = append(ids, t.ID)
+		}
+	}
+	return ids, nil
+}
+
+// UnarchiveByProject は projectID のアーカイブされたタスクを戻し、対象のタスク ID を返す。
+func (r *MemoryTaskRepository) UnarchiveByProject(ctx context.Context, projectID string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ids := make([]string, 0)
+	for _, t := range r.tasks {
+		if t.ProjectID == projectID && inWorkspace(ctx, t) && t.ArchivedAt != nil {
+			t.ArchivedAt = nil
+			r.recordChange(t.ID)
+			ids = append(ids, t.ID)
+		}
+	}
+	return ids, nil
+}
+
+// DeleteByProject は projectID のタスクを削除し、削除したタスク ID を返す。
+// 採番（lastNumbers）は残すため、同じプロジェクトで番号は再利用されない（SQL 実装と同じ）。
+func (r *MemoryTaskRepository) DeleteByProject(ctx context.Context, projectID string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ids := make([]string, 0)
+	for id, t := range r.tasks {
+		if t.ProjectID == projectID && inWorkspace(ctx, t) {
+			delete(r.tasks, id)
+			r.recordDeletion(t)
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// ArchivedBefore は before より前にアーカイブされたタスクのコピーを、アーカイブ日時・ID の順に最大 limit 件返す。
+// すべてのワークスペースのタスクを対象にする（SQL 実装と同じ）。
+func (r *MemoryTaskRepository) ArchivedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tasks := r.archivedBefore(before, limit)
+	for i, t := range tasks {
+		tasks[i] = cloneTask(t)
+	}
+	return tasks, nil
+}
+
+// DeleteArchivedBefore は before より前にアーカイブされたタスクを、アーカイブ日時・ID の順に最大 limit 件削除し、
+// 削除したタスクを返す。すべてのワークスペースのタスクを対象にする（SQL 実装と同じ）。
+func (r *MemoryTaskRepository) DeleteArchivedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tasks := r.archivedBefore(before, limit)
+	for _, t := range tasks {
+		delete(r.tasks, t.ID)
+		r.recordDeletion(t)
+	}
+	return tasks, nil
+}
+
+// archivedBefore は before より前にアーカイブされたタスクを、アーカイブ日時・ID の順に最大 limit 件返す。r.mu をロックして呼ぶ。
+func (r *MemoryTaskRepository) archivedBefore(before time.Time, limit int) []*domain.Task {
+	out := make([]*domain.Task, 0)
+	for _, t := range r.tasks {
+		if t.ArchivedAt != nil && t.ArchivedAt.Before(before) {
+			out = append(out, t)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].ArchivedAt.Equal(*out[j].ArchivedAt) {
+			return out[i].ArchivedAt.Before(*out[j].ArchivedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out[:min(len(out), limit)]
+}
+
+// MoveIncompleteSprintTasks は fromSprintID の未完了でアーカイブされていないタスクを toSprintID（nil はバックログ）に移し、
+// 対象のタスク ID を返す。
+func (r *MemoryTaskRepository) MoveIncompleteSprintTasks(ctx context.Context, projectID, fromSprintID string, toSprintID *string, actorID string, now time.Time) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ids := make([]string, 0)
+	for _, t := range r.tasks {
+		if t.ProjectID != projectID || !inWorkspace(ctx, t) || t.ArchivedAt != nil || t.Status == domain.StatusDone {
+			continue
+		}
+		if t.SprintID == nil || *t.SprintID != fromSprintID {
+			continue
+		}
+		t.SprintID = clonePtr(toSprintID)
+		t.RecordChange("sprintId")
+		t.UpdatedAt = now
+		t.UpdatedBy = actorID
+		r.recordChange(t.ID)
+		ids = append(ids, t.ID)
+	}
+	return ids, nil
+}
+
+// recordChange は id のタスクの変更の順序を進める。r.mu をロックして呼ぶ。
+func (r *MemoryTaskRepository) recordChange(id string) {
+	if r.changeSeqs == nil {
+		r.changeSeqs = make(map[string]int64)
+	}
+	r.lastSeq++
+	r.changeSeqs[id] = r.lastSeq
+}
+
+// recordDeletion は削除した t の記録を残す。r.mu をロックして呼ぶ。
+func (r *MemoryTaskRepository) recordDeletion(t *domain.Task) {
+	if r.tombstones == nil {
+		r.tombstones = make(map[string]memoryTombstone)
+	}
+	r.lastSeq++
+	delete(r.changeSeqs, t.ID)
+	r.tombstones[t.ID] = memoryTombstone{projectID: t.ProjectID, workspaceID: t.WorkspaceID, seq: r.lastSeq, deletedAt: clock.OrSystem(r.Clock).Now()}
+}
+
+// tasksInProject は context のワークスペースにある projectID のアーカイブされていないタスクのコピーを返す（順序は不定）。
+// フィルタ・ソートはコピーに対して行い、ロックを保持する時間を短くする。
+func (r *MemoryTaskRepository) tasksInProject(ctx context.Context, projectID string) []*domain.Task {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]*domain.Task, 0)
+	for _, t := range r.tasks {
+		if t.ProjectID == projectID && inWorkspace(ctx, t) && t.ArchivedAt == nil {
+			out = append(out, cloneTask(t))
+		}
+	}
+	return out
+}
+
+// inWorkspace は t が context のワークスペースのタスクかどうかを返す。
+func inWorkspace(ctx context.Context, t *domain.Task) bool {
+	return t.WorkspaceID == workspace.FromContext(ctx)
+}
+
+// cloneTask は t のコピーを返す（ポインタのフィールドも共有しない）。
+func cloneTask(t *domain.Task) *domain.Task {
+	c := *t
+	c.AssigneeID = clonePtr(t.AssigneeID)
+	c.DueDate = clonePtr(t.DueDate)
+	c.StartDate = clonePtr(t.StartDate)
+	c.Estimate = clonePtr(t.Estimate)
+	c.MilestoneID = clonePtr(t.MilestoneID)
+	c.SprintID = clonePtr(t.SprintID)
+	c.EpicID = clonePtr(t.EpicID)
+	c.LabelIDs = slices.Clone(t.LabelIDs)
+	c.ArchivedAt = clonePtr(t.ArchivedAt)
+	c.FieldVersions = maps.Clone(t.FieldVersions)
+	return &c
+}
+
+// clonePtr は p が指す値のコピーへのポインタを返す（nil はそのまま）。
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
 // filterTasks はタスクのスライスをフィルタする（メモリリポジトリ用）。
 func (r *MemoryTaskRepository) filterTasks(tasks []*domain.Task, query *domain.TaskQuery) []*domain.Task {
 	var result []*domain.Task
@@ -142,6 +375,27 @@ func (r *MemoryTaskRepository) matches(t *domain.Task, query *domain.TaskQuery)
 		}
 	}
 
+	// MilestoneID filter
+	if query.MilestoneID != nil {
+		if t.MilestoneID == nil || *t.MilestoneID != *query.MilestoneID {
+			return false
+		}
+	}
+
+	// SprintID filter
+	if query.SprintID != nil {
+		if t.SprintID == nil || *t.SprintID != *query.SprintID {
+			return false
+		}
+	}
+
+	// EpicID filter
+	if query.EpicID != nil {
+		if t.EpicID == nil || *t.EpicID != *query.EpicID {
+			return false
+		}
+	}
+
 	// Priority filter
 	if len(query.Priorities) > 0 {
 		found := false
@@ -175,6 +429,18 @@ func (r *MemoryTaskRepository) matches(t *domain.Task, query *domain.TaskQuery)
 		}
 	}
 
+	// Cursor の seek 条件: (created_at > X) OR (created_at = X AND id > Y)。desc の cursor は不等号を反転する
+	if query.Cursor != nil {
+		c := query.Cursor
+		if c.Descending {
+			if t.CreatedAt.After(c.CreatedAt) || (t.CreatedAt.Equal(c.CreatedAt) && t.ID >= c.ID) {
+				return false
+			}
+		} else if t.CreatedAt.Before(c.CreatedAt) || (t.CreatedAt.Equal(c.CreatedAt) && t.ID <= c.ID) {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -185,16 +451,39 @@ func (r *MemoryTaskRepository) sortTasks(tasks []*domain.Task, query *domain.Tas
 	})
 }
 
+// defaultSortOrders は sort 未指定時（および cursor 使用時）の並び順。
+var defaultSortOrders = []domain.SortOrder{{Key: "createdAt", Direction: domain.SortDirectionASC}}
+
+// descendingSortOrders は新しい順の keyset（sort=-createdAt または desc の cursor）の並び順。
+var descendingSortOrders = []domain.SortOrder{{Key: "createdAt", Direction: domain.SortDirectionDESC}}
+
+// effectiveSortOrders は SQL 実装（buildQuery）と同じ規則で実際に使うソート条件を返す。
+//   - 新しい順の keyset は createdAt DESC、それ以外で cursor がある場合は createdAt ASC に固定
+//   - sortOrder は未対応のため無視し、有効なキーが残らなければ createdAt ASC
+func (r *MemoryTaskRepository) effectiveSortOrders(query *domain.TaskQuery) []domain.SortOrder {
+	if query.KeysetDescending() {
+		return descendingSortOrders
+	}
+	if query.Cursor != nil {
+		return defaultSortOrders
+	}
+	orders := make([]domain.SortOrder, 0, len(query.SortOrders))
+	for _, order := range query.SortOrders {
+		if order.Key != "sortOrder" {
+			orders = append(orders, order)
+		}
+	}
+	if len(orders) == 0 {
+		return defaultSortOrders
+	}
+	return orders
+}
+
 // compareTasks はTaskQueryのソート条件に従ってタスクを比較する。
 // sort.Slice の比較関数として使用可能。
 func (r *MemoryTaskRepository) compareTasks(t1, t2 *domain.Task, query *domain.TaskQuery) bool {
-	if len(query.SortOrders) == 0 {
-		// デフォルトソート: createdAt ASC
-		return t1.CreatedAt.Before(t2.CreatedAt)
-	}
-
-	for _, order := range query.SortOrders {
-		cmp := r.compareByKey(t1, t2, order.Key, order.Direction)
+	for _, order := range r.effectiveSortOrders(query) {
+		cmp := r.compareByKey(t1, t2, order.Key)
 		if cmp != 0 {
 			if order.Direction == domain.SortDirectionDESC {
 				return cmp > 0
@@ -203,17 +492,19 @@ func (r *MemoryTaskRepository) compareTasks(t1, t2 *domain.Task, query *domain.T
 		}
 	}
 
-	// すべてのソートキーで等しい場合はIDで安定ソート
+	// すべてのソートキーで等しい場合はIDで安定ソート（新しい順の keyset は id DESC）
+	if query.KeysetDescending() {
+		return t1.ID > t2.ID
+	}
 	return t1.ID < t2.ID
 }
 
-// compareByKey は指定されたキーで2つのタスクを比較する。
-// direction はASC/DESCを指定し、dueDateのnull値処理に使用される。
+// compareByKey は指定されたキーで2つのタスクを比較する（昇順基準。DESC の反転は compareTasks で行う）。
 // 戻り値: <0 (t1 < t2), 0 (t1 == t2), >0 (t1 > t2)
-// dueDate の null は以下の順序で扱う:
+// dueDate の null は PostgreSQL と同じく最大値として扱うため、以下の順序になる:
 // ASC: null last (SQL: NULLS LAST)
 // DESC: null first (SQL: NULLS FIRST)
-func (r *MemoryTaskRepository) compareByKey(t1, t2 *domain.Task, key string, direction string) int {
+func (r *MemoryTaskRepository) compareByKey(t1, t2 *domain.Task, key string) int {
 	switch key {
 	case "sortOrder":
 		// sortOrder は現在Taskエンティティにないため、0を返す（将来対応）
@@ -238,23 +529,15 @@ func (r *MemoryTaskRepository) compareByKey(t1, t2 *domain.Task, key string, dir
 		return 0
 
 	case "dueDate":
-		// dueDate の null は以下の順序で扱う:
-		// ASC: null last (SQL: NULLS LAST)
-		// DESC: null first (SQL: NULLS FIRST)
+		// null は最大値（ASC で最後、DESC で先頭）
 		if t1.DueDate == nil && t2.DueDate == nil {
 			return 0
 		}
 		if t1.DueDate == nil {
-			if direction == domain.SortDirectionDESC {
-				return -1 // DESC時はnullを先頭（t1 < t2）
-			}
-			return 1 // ASC時はnullを最後（t1 > t2）
+			return 1
 		}
 		if t2.DueDate == nil {
-			if direction == domain.SortDirectionDESC {
-				return 1 // DESC時はnullを先頭（t1 > t2）
-			}
-			return -1 // ASC時はnullを最後（t1 < t2）
+			return -1
 		}
 		if t1.DueDate.Before(*t2.DueDate) {
 			return -1
@@ -273,9 +556,10 @@ func (r *MemoryTaskRepository) compareByKey(t1, t2 *domain.Task, key string, dir
 }
 
 // applyLimit はタスクのスライスをリミットする。
+// SQL 実装と同じく、nextCursor 判定のため limit + 1 件まで返す。
 func (r *MemoryTaskRepository) applyLimit(tasks []*domain.Task, query *domain.TaskQuery) []*domain.Task {
-	if len(tasks) <= query.Limit {
+	if len(tasks) <= query.Limit+1 {
 		return tasks
 	}
-	return tasks[:query.Limit]
+	return tasks[:query.Limit+1]
 }
@@ -142,6 +142,39 @@ func TestMemoryTaskRepository_FindByProjectID_AssigneeIDFilter(t *testing.T) {
 	}
 }
 
+func TestMemoryTaskRepository_FindByProjectID_MilestoneIDFilter(t *testing.T) {
+	repo := NewMemoryTaskRepository()
+	now := time.Now()
+
+	milestone1 := "m-1"
+	milestone2 := "m-2"
+
+	t1, _ := domain.NewTask("task-1", "proj-1", "T1", "", domain.StatusTodo, domain.PriorityMedium, nil, now)
+	t1.MilestoneID = &milestone1
+	t2, _ := domain.NewTask("task-2", "proj-1", "T2", "", domain.StatusTodo, domain.PriorityMedium, nil, now)
+	t2.MilestoneID = &milestone2
+	t3, _ := domain.NewTask("task-3", "proj-1", "T3", "", domain.StatusTodo, domain.PriorityMedium, nil, now)
+
+	repo.Save(context.Background(), t1)
+	repo.Save(context.Background(), t2)
+	repo.Save(context.Background(), t3)
+
+	// milestoneId=m-1 でフィルタ
+	query, _ := domain.NewTaskQuery(domain.WithMilestoneIDFilter("m-1"))
+	tasks, err := repo.FindByProjectID(context.Background(), "proj-1", query)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tasks) != 1 {
+		t.Fatalf("expected 1 task, got %d", len(tasks))
+	}
+
+	if tasks[0].ID != "task-1" || tasks[0].MilestoneID == nil || *tasks[0].MilestoneID != "m-1" {
+		t.Errorf("expected task-1 in m-1, got %+v", tasks[0])
+	}
+}
+
 func TestMemoryTaskRepository_FindByProjectID_SortByPriority(t *testing.T) {
 	repo := NewMemoryTaskRepository()
 	now := time.Now()
@@ -233,15 +266,15 @@ func TestMemoryTaskRepository_FindByProjectID_Limit(t *testing.T) {
 		repo.Save(context.Background(), task)
 	}
 
-	// limit=5 でリミット
+	// limit=5 でリミット（nextCursor 判定のため limit + 1 件まで返す）
 	query, _ := domain.NewTaskQuery(domain.WithLimit(5))
 	tasks, err := repo.FindByProjectID(context.Background(), "proj-1", query)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if len(tasks) != 5 {
-		t.Fatalf("expected 5 tasks, got %d", len(tasks))
+	if len(tasks) != 6 {
+		t.Fatalf("expected 6 tasks, got %d", len(tasks))
 	}
 }
 
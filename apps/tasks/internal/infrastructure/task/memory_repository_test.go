@@ -2,9 +2,13 @@ package taskinfra_test
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
+	"teamflow-shared/workspace"
+
 	domain "teamflow-tasks/internal/domain/task"
 	infra "teamflow-tasks/internal/infrastructure/task"
 	usecase "teamflow-tasks/internal/usecase/task"
@@ -77,3 +81,165 @@ func TestMemoryTaskRepository_SaveAndListByProject(t *testing.T) {
 		}
 	}
 }
+
+func TestMemoryTaskRepository_Number(t *testing.T) {
+	repo := infra.NewMemoryTaskRepository()
+	ctx := context.Background()
+	now := time.Now()
+
+	tasks := []*domain.Task{
+		{ID: "task-1", ProjectID: "proj-1", Title: "設計", Status: domain.StatusTodo, Priority: domain.PriorityMedium, CreatedAt: now, UpdatedAt: now},
+		{ID: "task-2", ProjectID: "proj-2", Title: "別", Status: domain.StatusTodo, Priority: domain.PriorityMedium, CreatedAt: now, UpdatedAt: now},
+		{ID: "task-3", ProjectID: "proj-1", Title: "実装", Status: domain.StatusTodo, Priority: domain.PriorityMedium, CreatedAt: now, UpdatedAt: now},
+	}
+	for _, task := range tasks {
+		if err := repo.Save(ctx, task); err != nil {
+			t.Fatalf("failed to save task %s: %v", task.ID, err)
+		}
+	}
+
+	// プロジェクトごとに 1 から採番される
+	for id, want := range map[string]int{"task-1": 1, "task-2": 1, "task-3": 2} {
+		got, err := repo.FindByID(ctx, id)
+		if err != nil {
+			t.Fatalf("failed to find task %s: %v", id, err)
+		}
+		if got.Number != want {
+			t.Errorf("%s: expected number %d, got %d", id, want, got.Number)
+		}
+	}
+
+	got, err := repo.FindByNumber(ctx, "proj-1", 2)
+	if err != nil {
+		t.Fatalf("failed to find task by number: %v", err)
+	}
+	if got.ID != "task-3" {
+		t.Errorf("expected task-3, got %s", got.ID)
+	}
+	if _, err := repo.FindByNumber(ctx, "proj-2", 2); err != infra.ErrTaskNotFound {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
+
+func TestMemoryTaskRepository_DefensiveCopy(t *testing.T) {
+	repo := infra.NewMemoryTaskRepository()
+	ctx := context.Background()
+	now := time.Now()
+	assignee := "user-1"
+
+	task := &domain.Task{ID: "task-1", ProjectID: "proj-1", Title: "設計", Status: domain.StatusTodo, Priority: domain.PriorityMedium, AssigneeID: &assignee, CreatedAt: now, UpdatedAt: now}
+	if err := repo.Save(ctx, task); err != nil {
+		t.Fatalf("failed to save task: %v", err)
+	}
+	if task.Number != 1 {
+		t.Fatalf("expected Save to set Number on the given task, got %d", task.Number)
+	}
+
+	// 保存後に呼び出し側のオブジェクト（ポインタの先を含む）を変更しても保存内容は変わらない
+	task.Title = "changed"
+	assignee = "user-2"
+
+	got, _ := repo.FindByID(ctx, "task-1")
+	if got.Title != "設計" || *got.AssigneeID != "user-1" {
+		t.Fatalf("expected stored task to be unchanged, got title=%q assignee=%q", got.Title, *got.AssigneeID)
+	}
+
+	// 取得したオブジェクトを変更しても Update するまで反映されない
+	got.Status = domain.StatusDone
+	list, _ := repo.ListByProject(ctx, "proj-1")
+	if list[0].Status != domain.StatusTodo {
+		t.Fatalf("expected stored status to be unchanged before Update, got %s", list[0].Status)
+	}
+}
+
+// TestMemoryTaskRepository_Concurrent は並行な読み書きでデータ競合が起きず、
+// タスク番号が重複しないことを確認する（データ競合は go test -race で検出）。
+func TestMemoryTaskRepository_Concurrent(t *testing.T) {
+	repo := infra.NewMemoryTaskRepository()
+	ctx := context.Background()
+	now := time.Now()
+	query := &domain.TaskQuery{Limit: 200}
+
+	const n = 50
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			task := &domain.Task{ID: fmt.Sprintf("task-%d", i), ProjectID: "proj-1", Title: "設計", Status: domain.StatusTodo, Priority: domain.PriorityMedium, CreatedAt: now, UpdatedAt: now}
+			if err := repo.Save(ctx, task); err != nil {
+				t.Errorf("failed to save task: %v", err)
+				return
+			}
+			task.Status = domain.StatusInProgress
+			task.RecordChange("status")
+			if err := repo.Update(ctx, task); err != nil {
+				t.Errorf("failed to update task: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = repo.FindByID(ctx, fmt.Sprintf("task-%d", i))
+			_, _ = repo.FindByNumber(ctx, "proj-1", 1)
+			_, _ = repo.ListByProject(ctx, "proj-1")
+			_, _ = repo.FindByProjectID(ctx, "proj-1", query)
+		}()
+	}
+	wg.Wait()
+
+	tasks, _ := repo.ListByProject(ctx, "proj-1")
+	if len(tasks) != n {
+		t.Fatalf("expected %d tasks, got %d", n, len(tasks))
+	}
+	seen := make(map[int]bool)
+	for _, task := range tasks {
+		if task.Number < 1 || task.Number > n || seen[task.Number] {
+			t.Fatalf("expected unique numbers in 1..%d, got duplicate or out of range %d", n, task.Number)
+		}
+		seen[task.Number] = true
+	}
+}
+
+func TestMemoryTaskRepository_Workspace(t *testing.T) {
+	repo := infra.NewMemoryTaskRepository()
+	ws1 := workspace.NewContext(context.Background(), "ws-1")
+	ws2 := workspace.NewContext(context.Background(), "ws-2")
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	task, err := domain.NewTask("task-1", "proj-1", "title", "", domain.StatusTodo, domain.PriorityMedium, nil, now)
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	if err := repo.Save(ws1, task); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	if task.WorkspaceID != "ws-1" {
+		t.Errorf("WorkspaceID = %q, want ws-1", task.WorkspaceID)
+	}
+
+	// 別のワークスペースからは見えず、更新・一括操作の対象にもならない
+	if _, err := repo.FindByID(ws2, "task-1"); err != infra.ErrTaskNotFound {
+		t.Errorf("FindByID from ws-2: expected ErrTaskNotFound, got %v", err)
+	}
+	if _, err := repo.FindByNumber(ws2, "proj-1", task.Number); err != infra.ErrTaskNotFound {
+		t.Errorf("FindByNumber from ws-2: expected ErrTaskNotFound, got %v", err)
+	}
+	if err := repo.Update(ws2, task); err != infra.ErrTaskNotFound {
+		t.Errorf("Update from ws-2: expected ErrTaskNotFound, got %v", err)
+	}
+	query, _ := domain.NewTaskQuery()
+	if got, _ := repo.FindByProjectID(ws2, "proj-1", query); len(got) != 0 {
+		t.Errorf("FindByProjectID from ws-2: expected no tasks, got %d", len(got))
+	}
+	if ids, _ := repo.DeleteByProject(ws2, "proj-1"); len(ids) != 0 {
+		t.Errorf("DeleteByProject from ws-2: expected no tasks, got %v", ids)
+	}
+
+	got, err := repo.FindByID(ws1, "task-1")
+	if err != nil {
+		t.Fatalf("FindByID from ws-1: %v", err)
+	}
+	if got.WorkspaceID != "ws-1" {
+		t.Errorf("WorkspaceID = %q, want ws-1", got.WorkspaceID)
+	}
+}
@@ -0,0 +1,74 @@
+package taskinfra
+
+import (
+	"context"
+	"time"
+
+	"teamflow-shared/workspace"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// MemoryTaskChanges は MemoryTaskRepository が記録した変更の順序と削除したタスクから差分同期の変更を返す
+// usecase.TaskChangeFeed 実装。変更はロックの中で記録するため、上限は常に最後の変更の次にする。
+type MemoryTaskChanges struct {
+	repo *MemoryTaskRepository
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.TaskChangeFeed = (*MemoryTaskChanges)(nil)
+
+// NewMemoryTaskChanges は repo のタスクを対象にする MemoryTaskChanges を生成する。
+func NewMemoryTaskChanges(repo *MemoryTaskRepository) *MemoryTaskChanges {
+	return &MemoryTaskChanges{repo: repo}
+}
+
+// Changes は projectID のタスクの変更を (Seq, TaskID) の順に最大 limit 件返す。
+func (c *MemoryTaskChanges) Changes(ctx context.Context, projectID string, after domain.SyncPosition, until int64, limit int) ([]domain.TaskChange, int64, error) {
+	r := c.repo
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if until == 0 {
+		until = r.lastSeq + 1
+	}
+	inRange := func(seq int64, id string) bool {
+		return seq < until && (seq > after.Seq || (seq == after.Seq && id > after.TaskID))
+	}
+
+	changes := []domain.TaskChange{}
+	for id, seq := range r.changeSeqs {
+		t, ok := r.tasks[id]
+		if !ok || t.ProjectID != projectID || !inWorkspace(ctx, t) || !inRange(seq, id) {
+			continue
+		}
+		changes = append(changes, domain.TaskChange{Seq: seq, TaskID: id, Task: cloneTask(t)})
+	}
+	for id, ts := range r.tombstones {
+		if ts.projectID != projectID || ts.workspaceID != workspace.FromContext(ctx) || !inRange(ts.seq, id) {
+			continue
+		}
+		changes = append(changes, domain.TaskChange{Seq: ts.seq, TaskID: id, DeletedAt: ts.deletedAt})
+	}
+
+	domain.SortTaskChanges(changes)
+	if len(changes) > limit {
+		changes = changes[:limit]
+	}
+	return changes, until, nil
+}
+
+// PurgeTombstones は before より前に削除されたタスクの記録を消す。
+func (c *MemoryTaskChanges) PurgeTombstones(_ context.Context, before time.Time) (int64, error) {
+	r := c.repo
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var n int64
+	for id, ts := range r.tombstones {
+		if ts.deletedAt.Before(before) {
+			delete(r.tombstones, id)
+			n++
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,127 @@
+package taskinfra_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/workspace"
+
+	domain "teamflow-tasks/internal/domain/task"
+	infra "teamflow-tasks/internal/infrastructure/task"
+)
+
+// changeIDs は変更のタスク ID を順に返す（削除は "-" を付ける）。
+func changeIDs(changes []domain.TaskChange) []string {
+	ids := make([]string, 0, len(changes))
+	for _, c := range changes {
+		if c.Task == nil {
+			ids = append(ids, "-"+c.TaskID)
+			continue
+		}
+		ids = append(ids, c.TaskID)
+	}
+	return ids
+}
+
+func TestMemoryTaskChanges(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := infra.NewMemoryTaskRepository()
+	repo.Clock = clock.Fixed(now)
+	feed := infra.NewMemoryTaskChanges(repo)
+
+	save := func(id, projectID string) *domain.Task {
+		t.Helper()
+		task, err := domain.NewTask(id, projectID, "title", "", domain.StatusTodo, domain.PriorityMedium, nil, now)
+		if err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+		if err := repo.Save(ctx, task); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+		return task
+	}
+	t1 := save("t1", "proj-1")
+	save("t2", "proj-1")
+	save("other", "proj-2")
+
+	all, until, err := feed.Changes(ctx, "proj-1", domain.SyncPosition{}, 0, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := changeIDs(all); len(got) != 2 || got[0] != "t1" || got[1] != "t2" {
+		t.Fatalf("expected t1, t2, got %v", got)
+	}
+
+	// 更新したタスクは最新の変更として 1 件だけ返す
+	t1.Title = "updated"
+	t1.RecordChange("title")
+	if err := repo.Update(ctx, t1); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	changes, next, err := feed.Changes(ctx, "proj-1", domain.SyncPosition{Seq: until}, 0, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := changeIDs(changes); len(got) != 1 || got[0] != "t1" || changes[0].Task.Title != "updated" {
+		t.Fatalf("expected the updated t1, got %v", got)
+	}
+	if next <= until {
+		t.Errorf("expected the horizon to advance, got %d after %d", next, until)
+	}
+
+	// 上限を指定した場合は上限以降の変更を返さない
+	if changes, _, _ := feed.Changes(ctx, "proj-1", domain.SyncPosition{}, until, 10); len(changes) != 1 || changes[0].TaskID != "t2" {
+		t.Errorf("expected only t2 below the horizon, got %v", changeIDs(changes))
+	}
+
+	// アーカイブはタスクの変更、削除は Tombstone として返す
+	if _, err := repo.ArchiveByProject(ctx, "proj-1", now); err != nil {
+		t.Fatalf("failed to archive: %v", err)
+	}
+	changes, _, _ = feed.Changes(ctx, "proj-1", domain.SyncPosition{Seq: next}, 0, 10)
+	for _, c := range changes {
+		if c.Task == nil || c.Task.ArchivedAt == nil {
+			t.Errorf("expected archived tasks, got %+v", c)
+		}
+	}
+	if _, err := repo.DeleteByProject(ctx, "proj-1"); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	changes, _, _ = feed.Changes(ctx, "proj-1", domain.SyncPosition{Seq: next}, 0, 10)
+	if got := changeIDs(changes); len(got) != 2 || !slices.Contains(got, "-t1") || !slices.Contains(got, "-t2") {
+		t.Fatalf("expected tombstones of t1 and t2, got %v", got)
+	}
+	// 削除の日時はリポジトリの Clock から取る
+	for _, c := range changes {
+		if !c.DeletedAt.Equal(now) {
+			t.Errorf("expected %s deleted at %v, got %v", c.TaskID, now, c.DeletedAt)
+		}
+	}
+
+	// limit と位置で続きを取り出せる
+	first, _, _ := feed.Changes(ctx, "proj-1", domain.SyncPosition{}, 0, 1)
+	rest, _, _ := feed.Changes(ctx, "proj-1", domain.SyncPosition{Seq: first[0].Seq, TaskID: first[0].TaskID}, 0, 10)
+	if len(first) != 1 || len(rest) != 1 || first[0].TaskID == rest[0].TaskID {
+		t.Errorf("unexpected pages: %v, %v", changeIDs(first), changeIDs(rest))
+	}
+
+	// 別のワークスペースの変更は返さない
+	if changes, _, _ := feed.Changes(workspace.NewContext(ctx, "ws-2"), "proj-1", domain.SyncPosition{}, 0, 10); len(changes) != 0 {
+		t.Errorf("expected no changes from ws-2, got %v", changeIDs(changes))
+	}
+
+	// 保持期間を過ぎた Tombstone を消す
+	if n, err := feed.PurgeTombstones(ctx, now); err != nil || n != 0 {
+		t.Errorf("expected no tombstones to purge, got %d, %v", n, err)
+	}
+	if n, err := feed.PurgeTombstones(ctx, now.Add(time.Second)); err != nil || n != 2 {
+		t.Errorf("expected 2 tombstones to purge, got %d, %v", n, err)
+	}
+	if changes, _, _ := feed.Changes(ctx, "proj-1", domain.SyncPosition{}, 0, 10); len(changes) != 0 {
+		t.Errorf("expected no changes after purge, got %v", changeIDs(changes))
+	}
+}
@@ -0,0 +1,158 @@
+package taskinfra
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"teamflow-shared/metrics"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// リポジトリ層のメトリクス（operation ラベルはメソッド名）。
+var (
+	repoQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "tasks_repository_query_duration_seconds",
+		Help:    "Duration of task repository operations.",
+		Buckets: metrics.DefaultBuckets,
+	}, []string{"operation"})
+
+	repoRows = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tasks_repository_rows_total",
+		Help: "Number of rows returned or written by task repository operations.",
+	}, []string{"operation"})
+
+	repoErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tasks_repository_errors_total",
+		Help: "Number of failed task repository operations (not found is not counted).",
+	}, []string{"operation"})
+
+	repoRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tasks_repository_retries_total",
+		Help: "Number of retries caused by transient Postgres errors.",
+	}, []string{"operation"})
+)
+
+// MeteredTaskRepository は処理時間・取得件数・エラー数を計測する TaskRepository のデコレータ。
+type MeteredTaskRepository struct {
+	inner usecase.TaskRepository
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.TaskRepository = (*MeteredTaskRepository)(nil)
+
+// NewMeteredTaskRepository は新しいMeteredTaskRepositoryを生成する。
+func NewMeteredTaskRepository(inner usecase.TaskRepository) *MeteredTaskRepository {
+	return &MeteredTaskRepository{inner: inner}
+}
+
+// observe は operation の処理時間と結果を記録する。ErrTaskNotFound は正常系として扱う。
+func observe(operation string, start time.Time, rows int, err error) {
+	repoQueryDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if err != nil && !errors.Is(err, ErrTaskNotFound) {
+		repoErrors.WithLabelValues(operation).Inc()
+		return
+	}
+	repoRows.WithLabelValues(operation).Add(float64(rows))
+}
+
+// Save はタスクを保存する。
+func (r *MeteredTaskRepository) Save(ctx context.Context, t *domain.Task) error {
+	start := time.Now()
+	err := r.inner.Save(ctx, t)
+	observe("Save", start, 1, err)
+	return err
+}
+
+// Update は既存タスクを更新する。
+func (r *MeteredTaskRepository) Update(ctx context.Context, t *domain.Task) error {
+	start := time.Now()
+	err := r.inner.Update(ctx, t)
+	observe("Update", start, 1, err)
+	return err
+}
+
+// FindByID はIDを指定してタスクを取得する。
+func (r *MeteredTaskRepository) FindByID(ctx context.Context, id string) (*domain.Task, error) {
+	start := time.Now()
+	t, err := r.inner.FindByID(ctx, id)
+	rows := 0
+	if t != nil {
+		rows = 1
+	}
+	observe("FindByID", start, rows, err)
+	return t, err
+}
+
+// FindByNumber はプロジェクト内のタスク番号を指定してタスクを取得する。
+func (r *MeteredTaskRepository) FindByNumber(ctx context.Context, projectID string, number int) (*domain.Task, error) {
+	start := time.Now()
+	t, err := r.inner.FindByNumber(ctx, projectID, number)
+	rows := 0
+	if t != nil {
+		rows = 1
+	}
+	observe("FindByNumber", start, rows, err)
+	return t, err
+}
+
+// ListByProject は指定されたprojectIDのタスク一覧を返す。
+func (r *MeteredTaskRepository) ListByProject(ctx context.Context, projectID string) ([]*domain.Task, error) {
+	start := time.Now()
+	tasks, err := r.inner.ListByProject(ctx, projectID)
+	observe("ListByProject", start, len(tasks), err)
+	return tasks, err
+}
+
+// FindByProjectID は指定されたprojectIDとQuery Objectに基づいてタスクを取得する。
+func (r *MeteredTaskRepository) FindByProjectID(ctx context.Context, projectID string, query *domain.TaskQuery) ([]*domain.Task, error) {
+	start := time.Now()
+	tasks, err := r.inner.FindByProjectID(ctx, projectID, query)
+	observe("FindByProjectID", start, len(tasks), err)
+	return tasks, err
+}
+
+// ProjectVersion はプロジェクトのタスク一覧のバージョンを返す。
+func (r *MeteredTaskRepository) ProjectVersion(ctx context.Context, projectID string) (domain.ProjectVersion, error) {
+	start := time.Now()
+	v, err := r.inner.ProjectVersion(ctx, projectID)
+	observe("ProjectVersion", start, 1, err)
+	return v, err
+}
+
+// ArchiveByProject は projectID のタスクをアーカイブする。
+func (r *MeteredTaskRepository) ArchiveByProject(ctx context.Context, projectID string, archivedAt time.Time) ([]string, error) {
+	start := time.Now()
+	ids, err := r.inner.ArchiveByProject(ctx, projectID, archivedAt)
+	observe("ArchiveByProject", start, len(ids), err)
+	return ids, err
+}
+
+// UnarchiveByProject は projectID のアーカイブされたタスクを戻す。
+func (r *MeteredTaskRepository) UnarchiveByProject(ctx context.Context, projectID string) ([]string, error) {
+	start := time.Now()
+	ids, err := r.inner.UnarchiveByProject(ctx, projectID)
+	observe("UnarchiveByProject", start, len(ids), err)
+	return ids, err
+}
+
+// DeleteByProject は projectID のタスクを削除する。
+func (r *MeteredTaskRepository) DeleteByProject(ctx context.Context, projectID string) ([]string, error) {
+	start := time.Now()
+	ids, err := r.inner.DeleteByProject(ctx, projectID)
+	observe("DeleteByProject", start, len(ids), err)
+	return ids, err
+}
+
+// MoveIncompleteSprintTasks はスプリントの未完了のタスクを移す。
+func (r *MeteredTaskRepository) MoveIncompleteSprintTasks(ctx context.Context, projectID, fromSprintID string, toSprintID *string, actorID string, now time.Time) ([]string, error) {
+	start := time.Now()
+	ids, err := r.inner.MoveIncompleteSprintTasks(ctx, projectID, fromSprintID, toSprintID, actorID, now)
+	observe("MoveIncompleteSprintTasks", start, len(ids), err)
+	return ids, err
+}
@@ -0,0 +1,64 @@
+package taskinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// errRepo は常にエラーを返す TaskRepository（エラー計測の確認用）。
+type errRepo struct{ MemoryTaskRepository }
+
+func (*errRepo) FindByProjectID(context.Context, string, *domain.TaskQuery) ([]*domain.Task, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestMeteredTaskRepository(t *testing.T) {
+	ctx := context.Background()
+	mem := NewMemoryTaskRepository()
+	repo := NewMeteredTaskRepository(mem)
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, id := range []string{"task-1", "task-2"} {
+		task, err := domain.NewTask(id, "proj-1", id, "", domain.StatusTodo, domain.PriorityMedium, nil, now)
+		if err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+		if err := repo.Save(ctx, task); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+	}
+
+	rowsBefore := testutil.ToFloat64(repoRows.WithLabelValues("ListByProject"))
+	errorsBefore := testutil.ToFloat64(repoErrors.WithLabelValues("FindByID"))
+
+	if _, err := repo.ListByProject(ctx, "proj-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := testutil.ToFloat64(repoRows.WithLabelValues("ListByProject")) - rowsBefore; got != 2 {
+		t.Errorf("expected rows +2, got +%v", got)
+	}
+
+	// not found はエラーとして数えない
+	if _, err := repo.FindByID(ctx, "non-existent"); !errors.Is(err, ErrTaskNotFound) {
+		t.Fatalf("expected ErrTaskNotFound, got %v", err)
+	}
+	if got := testutil.ToFloat64(repoErrors.WithLabelValues("FindByID")) - errorsBefore; got != 0 {
+		t.Errorf("expected not found not to be counted as error, got +%v", got)
+	}
+
+	// それ以外のエラーは数える
+	failing := NewMeteredTaskRepository(&errRepo{})
+	findErrorsBefore := testutil.ToFloat64(repoErrors.WithLabelValues("FindByProjectID"))
+	if _, err := failing.FindByProjectID(ctx, "proj-1", &domain.TaskQuery{}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := testutil.ToFloat64(repoErrors.WithLabelValues("FindByProjectID")) - findErrorsBefore; got != 1 {
+		t.Errorf("expected errors +1, got +%v", got)
+	}
+}
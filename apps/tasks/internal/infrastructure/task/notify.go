@@ -0,0 +1,75 @@
+package taskinfra
+
+import (
+	"context"
+	"time"
+
+	"teamflow-shared/workspace"
+
+	"teamflow-tasks/internal/broadcast"
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// NotifyingTaskRepository は保存に成功したタスクの変更を publish に渡す TaskRepository のデコレータ。
+//
+// インメモリリポジトリ用（単一プロセス内でのみ配信される）。
+// PostgreSQL ではトリガーの NOTIFY と TaskChangeListener で全レプリカに配信するため使わない。
+type NotifyingTaskRepository struct {
+	usecase.TaskRepository
+	publish func(broadcast.Event)
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.TaskRepository = (*NotifyingTaskRepository)(nil)
+
+// NewNotifyingTaskRepository は新しいNotifyingTaskRepositoryを生成する。
+func NewNotifyingTaskRepository(inner usecase.TaskRepository, publish func(broadcast.Event)) *NotifyingTaskRepository {
+	return &NotifyingTaskRepository{TaskRepository: inner, publish: publish}
+}
+
+// Save はタスクを保存し、task.created を通知する。
+func (r *NotifyingTaskRepository) Save(ctx context.Context, t *domain.Task) error {
+	if err := r.TaskRepository.Save(ctx, t); err != nil {
+		return err
+	}
+	r.publish(broadcast.Event{Type: broadcast.EventTaskCreated, WorkspaceID: workspace.FromContext(ctx), ProjectID: t.ProjectID, TaskID: t.ID})
+	return nil
+}
+
+// Update は既存タスクを更新し、task.updated を通知する。
+func (r *NotifyingTaskRepository) Update(ctx context.Context, t *domain.Task) error {
+	if err := r.TaskRepository.Update(ctx, t); err != nil {
+		return err
+	}
+	r.publish(broadcast.Event{Type: broadcast.EventTaskUpdated, WorkspaceID: workspace.FromContext(ctx), ProjectID: t.ProjectID, TaskID: t.ID})
+	return nil
+}
+
+// ArchiveByProject は projectID のタスクをアーカイブし、対象のタスクごとに task.updated を通知する。
+func (r *NotifyingTaskRepository) ArchiveByProject(ctx context.Context, projectID string, archivedAt time.Time) ([]string, error) {
+	ids, err := r.TaskRepository.ArchiveByProject(ctx, projectID, archivedAt)
+	r.publishAll(ctx, broadcast.EventTaskUpdated, projectID, ids)
+	return ids, err
+}
+
+// UnarchiveByProject は projectID のアーカイブされたタスクを戻し、対象のタスクごとに task.updated を通知する。
+func (r *NotifyingTaskRepository) UnarchiveByProject(ctx context.Context, projectID string) ([]string, error) {
+	ids, err := r.TaskRepository.UnarchiveByProject(ctx, projectID)
+	r.publishAll(ctx, broadcast.EventTaskUpdated, projectID, ids)
+	return ids, err
+}
+
+// DeleteByProject は projectID のタスクを削除し、削除したタスクごとに task.deleted を通知する。
+func (r *NotifyingTaskRepository) DeleteByProject(ctx context.Context, projectID string) ([]string, error) {
+	ids, err := r.TaskRepository.DeleteByProject(ctx, projectID)
+	r.publishAll(ctx, broadcast.EventTaskDeleted, projectID, ids)
+	return ids, err
+}
+
+func (r *NotifyingTaskRepository) publishAll(ctx context.Context, eventType, projectID string, taskIDs []string) {
+	workspaceID := workspace.FromContext(ctx)
+	for _, id := range taskIDs {
+		r.publish(broadcast.Event{Type: eventType, WorkspaceID: workspaceID, ProjectID: projectID, TaskID: id})
+	}
+}
@@ -0,0 +1,114 @@
+package taskinfra
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// errPurgeUnsupported は内側のリポジトリが usecase.ArchivedTaskPurger を実装していない場合のエラー。
+var errPurgeUnsupported = errors.New("task repository does not support purging archived tasks")
+
+// コンパイル時に、デコレータがアーカイブしたタスクの物理削除も同じ順で包めることを保証する。
+var (
+	_ usecase.ArchivedTaskPurger = (*TimeoutTaskRepository)(nil)
+	_ usecase.ArchivedTaskPurger = (*RetryingTaskRepository)(nil)
+	_ usecase.ArchivedTaskPurger = (*MeteredTaskRepository)(nil)
+)
+
+// purgerOf は inner の usecase.ArchivedTaskPurger を返す。実装していない場合は errPurgeUnsupported を返す。
+func purgerOf(inner usecase.TaskRepository) (usecase.ArchivedTaskPurger, error) {
+	p, ok := inner.(usecase.ArchivedTaskPurger)
+	if !ok {
+		return nil, errPurgeUnsupported
+	}
+	return p, nil
+}
+
+// ArchivedBefore は before より前にアーカイブされたタスクを返す。
+func (r *TimeoutTaskRepository) ArchivedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Task, error) {
+	p, err := purgerOf(r.inner)
+	if err != nil {
+		return nil, err
+	}
+	var out []*domain.Task
+	err = r.do(ctx, func(ctx context.Context) error {
+		var err error
+		out, err = p.ArchivedBefore(ctx, before, limit)
+		return err
+	})
+	return out, err
+}
+
+// DeleteArchivedBefore は before より前にアーカイブされたタスクを物理削除する。
+func (r *TimeoutTaskRepository) DeleteArchivedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Task, error) {
+	p, err := purgerOf(r.inner)
+	if err != nil {
+		return nil, err
+	}
+	var out []*domain.Task
+	err = r.do(ctx, func(ctx context.Context) error {
+		var err error
+		out, err = p.DeleteArchivedBefore(ctx, before, limit)
+		return err
+	})
+	return out, err
+}
+
+// ArchivedBefore は before より前にアーカイブされたタスクを返す。
+func (r *RetryingTaskRepository) ArchivedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Task, error) {
+	p, err := purgerOf(r.inner)
+	if err != nil {
+		return nil, err
+	}
+	var out []*domain.Task
+	err = r.do(ctx, "ArchivedBefore", true, func(ctx context.Context) error {
+		var err error
+		out, err = p.ArchivedBefore(ctx, before, limit)
+		return err
+	})
+	return out, err
+}
+
+// DeleteArchivedBefore は before より前にアーカイブされたタスクを物理削除する
+// （削除が反映された後の接続エラーで再試行すると、削除したタスクを返せないため再試行しない）。
+func (r *RetryingTaskRepository) DeleteArchivedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Task, error) {
+	p, err := purgerOf(r.inner)
+	if err != nil {
+		return nil, err
+	}
+	var out []*domain.Task
+	err = r.do(ctx, "DeleteArchivedBefore", false, func(ctx context.Context) error {
+		var err error
+		out, err = p.DeleteArchivedBefore(ctx, before, limit)
+		return err
+	})
+	return out, err
+}
+
+// ArchivedBefore は before より前にアーカイブされたタスクを返す。
+func (r *MeteredTaskRepository) ArchivedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Task, error) {
+	p, err := purgerOf(r.inner)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	out, err := p.ArchivedBefore(ctx, before, limit)
+	observe("ArchivedBefore", start, len(out), err)
+	return out, err
+}
+
+// DeleteArchivedBefore は before より前にアーカイブされたタスクを物理削除する。
+func (r *MeteredTaskRepository) DeleteArchivedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Task, error) {
+	p, err := purgerOf(r.inner)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	out, err := p.DeleteArchivedBefore(ctx, before, limit)
+	observe("DeleteArchivedBefore", start, len(out), err)
+	return out, err
+}
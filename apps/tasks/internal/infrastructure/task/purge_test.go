@@ -0,0 +1,74 @@
+package taskinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// slowPurger は ctx が終わるまで DeleteArchivedBefore をブロックする（タイムアウトの確認用）。
+type slowPurger struct{ MemoryTaskRepository }
+
+func (*slowPurger) DeleteArchivedBefore(ctx context.Context, _ time.Time, _ int) ([]*domain.Task, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// noPurgeRepo は ArchivedTaskPurger を実装しない TaskRepository。
+type noPurgeRepo struct{ usecase.TaskRepository }
+
+func TestDecoratedPurger(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("purges through the decorator chain", func(t *testing.T) {
+		mem := NewMemoryTaskRepository()
+		for _, id := range []string{"task-1", "task-2"} {
+			task, err := domain.NewTask(id, "proj-1", id, "", domain.StatusTodo, domain.PriorityMedium, nil, now)
+			if err != nil {
+				t.Fatalf("failed to create task: %v", err)
+			}
+			if err := mem.Save(ctx, task); err != nil {
+				t.Fatalf("failed to save: %v", err)
+			}
+		}
+		if _, err := mem.ArchiveByProject(ctx, "proj-1", now); err != nil {
+			t.Fatalf("failed to archive: %v", err)
+		}
+		// main と同じ順に包む
+		repo := NewMeteredTaskRepository(NewRetryingTaskRepository(NewTimeoutTaskRepository(mem, time.Second), DefaultRetryPolicy))
+
+		rowsBefore := testutil.ToFloat64(repoRows.WithLabelValues("DeleteArchivedBefore"))
+		candidates, err := repo.ArchivedBefore(ctx, now.Add(time.Hour), 10)
+		if err != nil || len(candidates) != 2 {
+			t.Fatalf("expected 2 candidates, got %d (err=%v)", len(candidates), err)
+		}
+		deleted, err := repo.DeleteArchivedBefore(ctx, now.Add(time.Hour), 1)
+		if err != nil || len(deleted) != 1 {
+			t.Fatalf("expected 1 deleted task, got %d (err=%v)", len(deleted), err)
+		}
+		if got := testutil.ToFloat64(repoRows.WithLabelValues("DeleteArchivedBefore")) - rowsBefore; got != 1 {
+			t.Errorf("expected rows +1, got +%v", got)
+		}
+	})
+
+	t.Run("slow delete returns ErrTimeout", func(t *testing.T) {
+		repo := NewTimeoutTaskRepository(&slowPurger{}, 10*time.Millisecond)
+		if _, err := repo.DeleteArchivedBefore(ctx, now, 10); !errors.Is(err, usecase.ErrTimeout) {
+			t.Fatalf("expected ErrTimeout, got %v", err)
+		}
+	})
+
+	t.Run("inner repository without purge", func(t *testing.T) {
+		repo := NewMeteredTaskRepository(noPurgeRepo{})
+		if _, err := repo.ArchivedBefore(ctx, now, 10); !errors.Is(err, errPurgeUnsupported) {
+			t.Fatalf("expected errPurgeUnsupported, got %v", err)
+		}
+	})
+}
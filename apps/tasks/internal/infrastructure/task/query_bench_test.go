@@ -0,0 +1,127 @@
+package taskinfra
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// 一覧のベンチマーク。フィルタ・ソートを追加したら benchQueries にも追加し、変更前後の make bench-go の結果を benchstat で比べる。
+//
+//	make bench-go && cp bench_output.txt old.txt   # 変更前
+//	make bench-go && benchstat old.txt bench_output.txt
+
+// benchTaskCount はベンチマークで 1 プロジェクトに用意するタスクの件数。
+const benchTaskCount = 10_000
+
+// benchQueries は一覧の代表的なクエリ（HTTP のクエリパラメータと同じオプションで組み立てる）。
+var benchQueries = []struct {
+	name string
+	opts []domain.TaskQueryOption
+}{
+	{name: "default"},
+	{name: "status", opts: []domain.TaskQueryOption{domain.WithStatusFilter("todo,doing")}},
+	{name: "all_filters", opts: []domain.TaskQueryOption{
+		domain.WithStatusFilter("todo,doing"),
+		domain.WithPriorityFilter("high,medium"),
+		domain.WithAssigneeIDFilter("user-3"),
+		domain.WithDueDateRangeFilter("2025-01-01", "2025-03-31"),
+		domain.WithQueryFilter("bug"),
+	}},
+	{name: "q", opts: []domain.TaskQueryOption{domain.WithQueryFilter("bug")}},
+	{name: "sort_priority", opts: []domain.TaskQueryOption{domain.WithSort("-priority,dueDate")}},
+	{name: "newest_first", opts: []domain.TaskQueryOption{domain.WithSort("-createdAt"), domain.WithLimit(50)}},
+}
+
+// newBenchQuery は benchQueries の 1 件から TaskQuery を組み立てる。
+func newBenchQuery(b *testing.B, opts []domain.TaskQueryOption) *domain.TaskQuery {
+	b.Helper()
+	q, err := domain.NewTaskQuery(opts...)
+	if err != nil {
+		b.Fatalf("failed to create query: %v", err)
+	}
+	return q
+}
+
+// seedBenchTasks は状態・優先度・担当者・期日・タイトルを散らした benchTaskCount 件のタスクを保存する。
+func seedBenchTasks(b *testing.B, repo *MemoryTaskRepository, base time.Time) {
+	b.Helper()
+	statuses := []domain.TaskStatus{domain.StatusTodo, domain.StatusInProgress, domain.StatusDone}
+	priorities := []domain.TaskPriority{domain.PriorityLow, domain.PriorityMedium, domain.PriorityHigh}
+	for i := 0; i < benchTaskCount; i++ {
+		title := fmt.Sprintf("task %d", i)
+		if i%10 == 0 {
+			title = fmt.Sprintf("fix bug %d", i)
+		}
+		var due *time.Time
+		if i%3 != 0 {
+			d := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, i%180)
+			due = &d
+		}
+		task, err := domain.NewTask(fmt.Sprintf("task-%05d", i), "proj-bench", title, "", statuses[i%len(statuses)], priorities[i%len(priorities)], due, base.Add(time.Duration(i)*time.Second))
+		if err != nil {
+			b.Fatalf("failed to create task: %v", err)
+		}
+		assignee := fmt.Sprintf("user-%d", i%7)
+		task.AssigneeID = &assignee
+		if err := repo.Save(context.Background(), task); err != nil {
+			b.Fatalf("failed to save task: %v", err)
+		}
+	}
+}
+
+// BenchmarkMemoryTaskRepository_FindByProjectID は 10,000 件のプロジェクトの一覧（フィルタ・ソート・ページング）を計測する。
+func BenchmarkMemoryTaskRepository_FindByProjectID(b *testing.B) {
+	repo := NewMemoryTaskRepository()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	seedBenchTasks(b, repo, base)
+	ctx := context.Background()
+
+	for _, bq := range benchQueries {
+		b.Run(bq.name, func(b *testing.B) {
+			q := newBenchQuery(b, bq.opts)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.FindByProjectID(ctx, "proj-bench", q); err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+
+	// プロジェクトの中ほどの cursor から次のページを取り出す
+	b.Run("cursor", func(b *testing.B) {
+		q := newBenchQuery(b, []domain.TaskQueryOption{domain.WithLimit(50)})
+		q.Cursor = &domain.TaskCursor{CreatedAt: base.Add(benchTaskCount / 2 * time.Second), ID: fmt.Sprintf("task-%05d", benchTaskCount/2)}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			tasks, err := repo.FindByProjectID(ctx, "proj-bench", q)
+			if err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+			if len(tasks) != 51 {
+				b.Fatalf("expected 51 tasks (limit + 1), got %d", len(tasks))
+			}
+		}
+	})
+}
+
+// BenchmarkSQLTaskRepository_BuildQuery は一覧の SQL の組み立てを計測する（DB には接続しない）。
+func BenchmarkSQLTaskRepository_BuildQuery(b *testing.B) {
+	repo := &SQLTaskRepository{}
+	for _, bq := range benchQueries {
+		b.Run(bq.name, func(b *testing.B) {
+			q := newBenchQuery(b, bq.opts)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				repo.buildQuery("ws-bench", "proj-bench", q)
+			}
+		})
+	}
+}
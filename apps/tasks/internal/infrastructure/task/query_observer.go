@@ -0,0 +1,109 @@
+package taskinfra
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"teamflow-shared/metrics"
+	"teamflow-shared/tracing/pgxtrace"
+)
+
+// プールと問い合わせのメトリクス。
+var (
+	poolAcquireDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "tasks_db_pool_acquire_duration_seconds",
+		Help:    "Time spent waiting to acquire a connection from the database pool.",
+		Buckets: metrics.DefaultBuckets,
+	})
+
+	slowQueries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tasks_db_slow_queries_total",
+		Help: "Number of queries that took longer than the slow query threshold.",
+	}, []string{"operation"})
+)
+
+type queryStartKey struct{}
+
+// queryStart は TraceQueryEnd に渡すための問い合わせの開始時刻と SQL。
+type queryStart struct {
+	at   time.Time
+	sql  string
+	args int
+}
+
+type acquireStartKey struct{}
+
+// QueryObserver はプールからの接続の取得にかかった時間を計測し、閾値を超えた問い合わせをログに出力する
+// pgx.QueryTracer（pgxpool.AcquireTracer も実装する）。pgxpool.Config の ConnConfig.Tracer に設定する。
+// ログには SQL（値はプレースホルダのため、条件の形だけが残る）と引数の数を出し、引数の値は出さない。
+type QueryObserver struct {
+	slowThreshold time.Duration
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var (
+	_ pgx.QueryTracer       = (*QueryObserver)(nil)
+	_ pgxpool.AcquireTracer = (*QueryObserver)(nil)
+)
+
+// NewQueryObserver は新しいQueryObserverを生成する。slowThreshold が 0 の場合は問い合わせのログを出さない。
+func NewQueryObserver(slowThreshold time.Duration) *QueryObserver {
+	return &QueryObserver{slowThreshold: slowThreshold}
+}
+
+// TraceAcquireStart は接続の取得の開始時刻を記録する。
+func (o *QueryObserver) TraceAcquireStart(ctx context.Context, _ *pgxpool.Pool, _ pgxpool.TraceAcquireStartData) context.Context {
+	return context.WithValue(ctx, acquireStartKey{}, time.Now())
+}
+
+// TraceAcquireEnd は接続の取得にかかった時間を記録する。
+func (o *QueryObserver) TraceAcquireEnd(ctx context.Context, _ *pgxpool.Pool, _ pgxpool.TraceAcquireEndData) {
+	if start, ok := ctx.Value(acquireStartKey{}).(time.Time); ok {
+		poolAcquireDuration.Observe(time.Since(start).Seconds())
+	}
+}
+
+// TraceQueryStart は問い合わせの開始時刻を記録する。
+func (o *QueryObserver) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if o.slowThreshold <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, queryStartKey{}, queryStart{at: time.Now(), sql: data.SQL, args: len(data.Args)})
+}
+
+// TraceQueryEnd は閾値を超えた問い合わせをログに出力する。
+func (o *QueryObserver) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(start.at)
+	if elapsed < o.slowThreshold {
+		return
+	}
+	op := pgxtrace.Operation(start.sql)
+	slowQueries.WithLabelValues(op).Inc()
+	attrs := []any{
+		"operation", op,
+		"statement", queryShape(start.sql),
+		"args", start.args,
+		"duration_ms", elapsed.Milliseconds(),
+		"rows", data.CommandTag.RowsAffected(),
+	}
+	if data.Err != nil {
+		attrs = append(attrs, "error", data.Err)
+	}
+	slog.WarnContext(ctx, "slow query", attrs...)
+}
+
+// queryShape は SQL の空白（改行・インデント）を 1 つの空白にまとめる。
+func queryShape(sql string) string {
+	return strings.Join(strings.Fields(sql), " ")
+}
@@ -0,0 +1,97 @@
+package taskinfra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// captureSlowQueries は既定のロガーを差し替え、"slow query" のログを返す関数を返す。
+func captureSlowQueries(t *testing.T) func() []map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return func() []map[string]any {
+		var logs []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			var entry map[string]any
+			if json.Unmarshal([]byte(line), &entry) == nil && entry["msg"] == "slow query" {
+				logs = append(logs, entry)
+			}
+		}
+		return logs
+	}
+}
+
+func TestQueryObserver_SlowQuery(t *testing.T) {
+	logs := captureSlowQueries(t)
+	o := NewQueryObserver(time.Millisecond)
+	sql := "SELECT id FROM tasks\n\t\tWHERE project_id = $1 AND status = $2"
+
+	// 閾値未満の問い合わせは出力しない
+	ctx := o.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	o.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+
+	before := testutil.ToFloat64(slowQueries.WithLabelValues("SELECT"))
+	ctx = o.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: sql, Args: []any{"proj-secret", "todo"}})
+	time.Sleep(2 * time.Millisecond)
+	o.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 3")})
+
+	got := logs()
+	if len(got) != 1 {
+		t.Fatalf("expected 1 slow query log, got %d: %v", len(got), got)
+	}
+	entry := got[0]
+	if entry["statement"] != "SELECT id FROM tasks WHERE project_id = $1 AND status = $2" || entry["args"] != float64(2) || entry["rows"] != float64(3) {
+		t.Errorf("unexpected slow query log: %v", entry)
+	}
+	if line, _ := json.Marshal(entry); strings.Contains(string(line), "proj-secret") {
+		t.Errorf("expected argument values not to be logged, got %s", line)
+	}
+	if n := testutil.ToFloat64(slowQueries.WithLabelValues("SELECT")) - before; n != 1 {
+		t.Errorf("expected the slow query counter to increase by 1, got %v", n)
+	}
+}
+
+func TestQueryObserver_Disabled(t *testing.T) {
+	logs := captureSlowQueries(t)
+	o := NewQueryObserver(0)
+	ctx := o.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	time.Sleep(time.Millisecond)
+	o.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+	if got := logs(); len(got) != 0 {
+		t.Errorf("expected no logs when the threshold is 0, got %v", got)
+	}
+}
+
+// acquireCount は tasks_db_pool_acquire_duration_seconds の観測回数を返す。
+func acquireCount(t *testing.T) uint64 {
+	t.Helper()
+	var m dto.Metric
+	if err := poolAcquireDuration.Write(&m); err != nil {
+		t.Fatalf("failed to read histogram: %v", err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestQueryObserver_Acquire(t *testing.T) {
+	o := NewQueryObserver(0)
+	before := acquireCount(t)
+	ctx := o.TraceAcquireStart(context.Background(), nil, pgxpool.TraceAcquireStartData{})
+	o.TraceAcquireEnd(ctx, nil, pgxpool.TraceAcquireEndData{})
+	if n := acquireCount(t) - before; n != 1 {
+		t.Errorf("expected 1 acquire to be observed, got %d", n)
+	}
+}
@@ -0,0 +1,226 @@
+package taskinfra
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// RetryPolicy は一時的な Postgres エラーに対するリトライ方針。
+type RetryPolicy struct {
+	MaxAttempts int           // 初回を含む最大試行回数
+	BaseDelay   time.Duration // 1 回目のリトライまでの待ち時間（以降は倍々）
+	MaxDelay    time.Duration // 待ち時間の上限
+}
+
+// DefaultRetryPolicy は既定のリトライ方針（最大 3 回、50ms → 100ms）。
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   50 * time.Millisecond,
+	MaxDelay:    time.Second,
+}
+
+// Do は fn を実行し、一時的なエラーの場合はバックオフしながら再試行する。
+// idempotent が false（INSERT など）の場合、サーバー側で実行されたか不明な接続エラーは再試行しない。
+// ctx がキャンセルされた場合は待機を打ち切り、直前のエラーを返す。
+// リトライ回数は tasks_repository_retries_total{operation} に記録する。
+func (p RetryPolicy) Do(ctx context.Context, operation string, idempotent bool, fn func(ctx context.Context) error) error {
+	delay := p.BaseDelay
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn(ctx)
+		if err == nil || attempt >= p.MaxAttempts || !isTransientError(err, idempotent) {
+			return err
+		}
+
+		repoRetries.WithLabelValues(operation).Inc()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		delay *= 2
+		if p.MaxDelay > 0 && delay > p.MaxDelay {
+			delay = p.MaxDelay
+		}
+	}
+}
+
+// isTransientError はリトライで回復しうるエラーかどうかを返す。
+//
+// 対象:
+//   - 40001 serialization_failure（サーバー側でロールバック済み）
+//   - 40P01 deadlock_detected（サーバー側でロールバック済み）
+//   - 08xxx connection_exception（接続の切断など。idempotent な操作のみ）
+//   - 送信前に失敗したことが確実なエラー（pgconn.SafeToRetry）
+func isTransientError(err error, idempotent bool) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == "40001", pgErr.Code == "40P01":
+			return true
+		case len(pgErr.Code) == 5 && pgErr.Code[:2] == "08":
+			return idempotent
+		}
+		return false
+	}
+
+	if pgconn.SafeToRetry(err) {
+		return true
+	}
+	// 接続リセット等のネットワークエラーは、読み取りなど再実行しても結果が変わらない操作のみ再試行する
+	var netErr net.Error
+	return idempotent && errors.As(err, &netErr)
+}
+
+// RetryingTaskRepository は一時的なエラーをリトライする TaskRepository のデコレータ。
+//
+// トランザクション内（PgxTxManager.WithinTx の ctx）では、失敗したトランザクションは
+// 文単位で再試行できないためリトライしない。
+type RetryingTaskRepository struct {
+	inner  usecase.TaskRepository
+	policy RetryPolicy
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.TaskRepository = (*RetryingTaskRepository)(nil)
+
+// NewRetryingTaskRepository は新しいRetryingTaskRepositoryを生成する。
+func NewRetryingTaskRepository(inner usecase.TaskRepository, policy RetryPolicy) *RetryingTaskRepository {
+	return &RetryingTaskRepository{inner: inner, policy: policy}
+}
+
+func (r *RetryingTaskRepository) do(ctx context.Context, operation string, idempotent bool, fn func(ctx context.Context) error) error {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+	return r.policy.Do(ctx, operation, idempotent, fn)
+}
+
+// Save はタスクを保存する（INSERT は重複の恐れがあるため、接続エラーでは再試行しない）。
+func (r *RetryingTaskRepository) Save(ctx context.Context, t *domain.Task) error {
+	return r.do(ctx, "Save", false, func(ctx context.Context) error {
+		return r.inner.Save(ctx, t)
+	})
+}
+
+// Update は既存タスクを更新する。
+func (r *RetryingTaskRepository) Update(ctx context.Context, t *domain.Task) error {
+	return r.do(ctx, "Update", true, func(ctx context.Context) error {
+		return r.inner.Update(ctx, t)
+	})
+}
+
+// FindByID はIDを指定してタスクを取得する。
+func (r *RetryingTaskRepository) FindByID(ctx context.Context, id string) (*domain.Task, error) {
+	var out *domain.Task
+	err := r.do(ctx, "FindByID", true, func(ctx context.Context) error {
+		var err error
+		out, err = r.inner.FindByID(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// FindByNumber はプロジェクト内のタスク番号を指定してタスクを取得する。
+func (r *RetryingTaskRepository) FindByNumber(ctx context.Context, projectID string, number int) (*domain.Task, error) {
+	var out *domain.Task
+	err := r.do(ctx, "FindByNumber", true, func(ctx context.Context) error {
+		var err error
+		out, err = r.inner.FindByNumber(ctx, projectID, number)
+		return err
+	})
+	return out, err
+}
+
+// ListByProject は指定されたprojectIDのタスク一覧を返す。
+func (r *RetryingTaskRepository) ListByProject(ctx context.Context, projectID string) ([]*domain.Task, error) {
+	var out []*domain.Task
+	err := r.do(ctx, "ListByProject", true, func(ctx context.Context) error {
+		var err error
+		out, err = r.inner.ListByProject(ctx, projectID)
+		return err
+	})
+	return out, err
+}
+
+// FindByProjectID は指定されたprojectIDとQuery Objectに基づいてタスクを取得する。
+func (r *RetryingTaskRepository) FindByProjectID(ctx context.Context, projectID string, query *domain.TaskQuery) ([]*domain.Task, error) {
+	var out []*domain.Task
+	err := r.do(ctx, "FindByProjectID", true, func(ctx context.Context) error {
+		var err error
+		out, err = r.inner.FindByProjectID(ctx, projectID, query)
+		return err
+	})
+	return out, err
+}
+
+// ProjectVersion はプロジェクトのタスク一覧のバージョンを返す。
+func (r *RetryingTaskRepository) ProjectVersion(ctx context.Context, projectID string) (domain.ProjectVersion, error) {
+	var out domain.ProjectVersion
+	err := r.do(ctx, "ProjectVersion", true, func(ctx context.Context) error {
+		var err error
+		out, err = r.inner.ProjectVersion(ctx, projectID)
+		return err
+	})
+	return out, err
+}
+
+// ArchiveByProject は projectID のタスクをアーカイブする（アーカイブ済みは対象外のため再実行しても結果は変わらない）。
+func (r *RetryingTaskRepository) ArchiveByProject(ctx context.Context, projectID string, archivedAt time.Time) ([]string, error) {
+	var out []string
+	err := r.do(ctx, "ArchiveByProject", true, func(ctx context.Context) error {
+		var err error
+		out, err = r.inner.ArchiveByProject(ctx, projectID, archivedAt)
+		return err
+	})
+	return out, err
+}
+
+// UnarchiveByProject は projectID のアーカイブされたタスクを戻す。
+func (r *RetryingTaskRepository) UnarchiveByProject(ctx context.Context, projectID string) ([]string, error) {
+	var out []string
+	err := r.do(ctx, "UnarchiveByProject", true, func(ctx context.Context) error {
+		var err error
+		out, err = r.inner.UnarchiveByProject(ctx, projectID)
+		return err
+	})
+	return out, err
+}
+
+// DeleteByProject は projectID のタスクを削除する。
+func (r *RetryingTaskRepository) DeleteByProject(ctx context.Context, projectID string) ([]string, error) {
+	var out []string
+	err := r.do(ctx, "DeleteByProject", true, func(ctx context.Context) error {
+		var err error
+		out, err = r.inner.DeleteByProject(ctx, projectID)
+		return err
+	})
+	return out, err
+}
+
+// MoveIncompleteSprintTasks はスプリントの未完了のタスクを移す。
+// 移したタスクは fromSprintID に属さなくなるため、再実行しても二重に移さない。
+func (r *RetryingTaskRepository) MoveIncompleteSprintTasks(ctx context.Context, projectID, fromSprintID string, toSprintID *string, actorID string, now time.Time) ([]string, error) {
+	var out []string
+	err := r.do(ctx, "MoveIncompleteSprintTasks", true, func(ctx context.Context) error {
+		var err error
+		out, err = r.inner.MoveIncompleteSprintTasks(ctx, projectID, fromSprintID, toSprintID, actorID, now)
+		return err
+	})
+	return out, err
+}
@@ -0,0 +1,100 @@
+package taskinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		idempotent bool
+		want       bool
+	}{
+		{name: "serialization failure", err: &pgconn.PgError{Code: "40001"}, want: true},
+		{name: "deadlock", err: &pgconn.PgError{Code: "40P01"}, want: true},
+		{name: "connection exception (idempotent)", err: &pgconn.PgError{Code: "08006"}, idempotent: true, want: true},
+		{name: "connection exception (not idempotent)", err: &pgconn.PgError{Code: "08006"}, idempotent: false, want: false},
+		{name: "unique violation", err: &pgconn.PgError{Code: "23505"}, idempotent: true, want: false},
+		{name: "wrapped serialization failure", err: errors.Join(errors.New("query failed"), &pgconn.PgError{Code: "40001"}), want: true},
+		{name: "context canceled", err: context.Canceled, idempotent: true, want: false},
+		{name: "not found", err: ErrTaskNotFound, idempotent: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientError(tt.err, tt.idempotent); got != tt.want {
+				t.Errorf("isTransientError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Do(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3}
+	transient := &pgconn.PgError{Code: "40001"}
+
+	tests := []struct {
+		name         string
+		errs         []error // 試行ごとに返すエラー（足りない分は nil）
+		wantErr      error
+		wantAttempts int
+		wantRetries  float64
+	}{
+		{name: "success on first attempt", errs: nil, wantAttempts: 1, wantRetries: 0},
+		{name: "success after retry", errs: []error{transient}, wantAttempts: 2, wantRetries: 1},
+		{name: "gives up after max attempts", errs: []error{transient, transient, transient, transient}, wantErr: transient, wantAttempts: 3, wantRetries: 2},
+		{name: "permanent error is not retried", errs: []error{ErrTaskNotFound}, wantErr: ErrTaskNotFound, wantAttempts: 1, wantRetries: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := testutil.ToFloat64(repoRetries.WithLabelValues("test"))
+			attempts := 0
+
+			err := policy.Do(context.Background(), "test", true, func(context.Context) error {
+				attempts++
+				if attempts <= len(tt.errs) {
+					return tt.errs[attempts-1]
+				}
+				return nil
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.wantAttempts, attempts)
+			}
+			if got := testutil.ToFloat64(repoRetries.WithLabelValues("test")) - before; got != tt.wantRetries {
+				t.Errorf("expected retry count +%v, got +%v", tt.wantRetries, got)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Do_StopsOnContextCancel(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour}
+	ctx, cancel := context.WithCancel(context.Background())
+	transient := &pgconn.PgError{Code: "40001"}
+
+	attempts := 0
+	err := policy.Do(ctx, "test", true, func(context.Context) error {
+		attempts++
+		cancel()
+		return transient
+	})
+
+	if !errors.Is(err, transient) {
+		t.Errorf("expected transient error, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
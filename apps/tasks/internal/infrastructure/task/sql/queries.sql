@@ -13,10 +13,74 @@ SELECT
     priority,
     assignee_id,
     due_date,
+    start_date,
+    estimate,
+    milestone_id,
+    sprint_id,
+    epic_id,
+    label_ids,
     created_at,
-    updated_at
+    updated_at,
+    created_by,
+    updated_by,
+    number,
+    archived_at
 FROM tasks
-WHERE project_id = $1
+WHERE project_id = $1 AND archived_at IS NULL
 ORDER BY created_at ASC, id ASC
 LIMIT $2;
 
+
+-- name: FindTaskByNumber :one
+-- FindByNumber はプロジェクト内のタスク番号でタスクを取得する。
+SELECT
+    id,
+    project_id,
+    title,
+    description,
+    status,
+    priority,
+    assignee_id,
+    due_date,
+    start_date,
+    estimate,
+    milestone_id,
+    sprint_id,
+    epic_id,
+    label_ids,
+    created_at,
+    updated_at,
+    created_by,
+    updated_by,
+    number,
+    archived_at
+FROM tasks
+WHERE project_id = $1 AND number = $2;
+
+
+-- name: ArchiveTasksByProject :many
+-- ArchiveByProject はプロジェクトの削除（cascade=archive_tasks）でタスクをアーカイブする。
+UPDATE tasks SET archived_at = $2
+WHERE project_id = $1 AND archived_at IS NULL
+RETURNING id;
+
+
+-- name: UnarchiveTasksByProject :many
+-- UnarchiveByProject はプロジェクトの復元でアーカイブしたタスクを戻す。
+UPDATE tasks SET archived_at = NULL
+WHERE project_id = $1 AND archived_at IS NOT NULL
+RETURNING id;
+
+
+-- name: DeleteTasksByProject :many
+-- DeleteByProject はプロジェクトの削除（cascade=delete_tasks）でタスクを削除する。
+DELETE FROM tasks
+WHERE project_id = $1
+RETURNING id;
+
+
+-- name: MoveIncompleteSprintTasks :many
+-- MoveIncompleteSprintTasks はスプリントの完了で未完了のタスクを次のスプリント（NULL はバックログ）に移す。
+UPDATE tasks SET sprint_id = $3, updated_at = $4, updated_by = $5
+WHERE project_id = $1 AND sprint_id = $2 AND status <> 'done' AND archived_at IS NULL
+RETURNING id;
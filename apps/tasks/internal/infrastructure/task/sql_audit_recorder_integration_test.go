@@ -0,0 +1,63 @@
+//go:build integration
+// +build integration
+
+package taskinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"teamflow-shared/audit"
+
+	"teamflow-tasks/internal/testutil"
+)
+
+// TestSQLAuditRecorder は記録がトランザクションに従うこと、記録した行を変更・削除できないことを検証する。
+func TestSQLAuditRecorder(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "TRUNCATE TABLE audit_log"); err != nil {
+		t.Fatalf("failed to truncate audit_log: %v", err)
+	}
+	rec := audit.NewSQLRecorder(db, TxFromContext)
+	txm := NewPgxTxManager(db)
+	now := time.Now().UTC()
+
+	entry := func(id string) audit.Entry {
+		return audit.Entry{
+			Service: "tasks", EntityType: "task", EntityID: id, ProjectID: "proj-1",
+			Action: audit.ActionUpdate, ActorID: "user-1", Fields: []string{"title"}, OccurredAt: now,
+		}
+	}
+
+	if err := rec.Record(ctx, entry("task-1"), entry("task-2")); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	boom := errors.New("boom")
+	err := txm.WithinTx(ctx, func(ctx context.Context) error {
+		if err := rec.Record(ctx, entry("task-3")); err != nil {
+			return err
+		}
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected %v, got %v", boom, err)
+	}
+
+	var count int
+	if err := db.QueryRow(ctx, "SELECT count(*) FROM audit_log WHERE actor_id = 'user-1' AND fields = '{title}'").Scan(&count); err != nil {
+		t.Fatalf("failed to count audit_log: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 entries (rolled back entry excluded), got %d", count)
+	}
+
+	if _, err := db.Exec(ctx, "UPDATE audit_log SET actor_id = 'someone-else'"); err == nil {
+		t.Error("expected UPDATE on audit_log to be rejected")
+	}
+	if _, err := db.Exec(ctx, "DELETE FROM audit_log"); err == nil {
+		t.Error("expected DELETE on audit_log to be rejected")
+	}
+}
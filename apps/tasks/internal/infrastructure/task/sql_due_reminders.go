@@ -0,0 +1,83 @@
+package taskinfra
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "teamflow-tasks/internal/domain/task"
+	"teamflow-tasks/internal/usecase/notification"
+)
+
+// SQLDueReminders は task_due_reminders テーブルに通知済みのタスクを記録する notification.DueTaskClaimer 実装。
+// すべてのワークスペースのタスクを対象にする（バックグラウンドの処理から呼ぶため context のワークスペースで絞り込まない）。
+type SQLDueReminders struct {
+	db *pgxpool.Pool
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ notification.DueTaskClaimer = (*SQLDueReminders)(nil)
+
+// NewSQLDueReminders は新しいSQLDueRemindersを生成する。
+func NewSQLDueReminders(db *pgxpool.Pool) *SQLDueReminders {
+	return &SQLDueReminders{db: db}
+}
+
+// dueTasksSQL は期日が $1〜$2 の通知していないタスクを、期日・ID の順で（$3, $4）より後から最大 $5 件返す。
+// $3 が NULL の場合は先頭から返す。
+const dueTasksSQL = `
+	SELECT ` + taskColumns + ` FROM tasks t
+	WHERE t.due_date BETWEEN $1 AND $2
+		AND t.status <> 'done'
+		AND t.assignee_id IS NOT NULL
+		AND t.archived_at IS NULL
+		AND ($3::date IS NULL OR (t.due_date, t.id) > ($3::date, $4::text))
+		AND NOT EXISTS (SELECT 1 FROM task_due_reminders r WHERE r.task_id = t.id AND r.due_date = t.due_date)
+	ORDER BY t.due_date, t.id
+	LIMIT $5`
+
+// DueTasks は期日が from から to の通知していないタスクを、期日・ID の順で after より後から最大 limit 件返す。
+func (r *SQLDueReminders) DueTasks(ctx context.Context, from, to time.Time, after *domain.Task, limit int) ([]*domain.Task, error) {
+	var afterDue *time.Time
+	var afterID string
+	if after != nil {
+		afterDue, afterID = after.DueDate, after.ID
+	}
+	rows, err := r.db.Query(ctx, dueTasksSQL, from, to, afterDue, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := []*domain.Task{}
+	for rows.Next() {
+		t, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate due tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+// ClaimDueTask は t を今の期日で reminded_at = now の通知済みとして記録する。
+// 複数のレプリカが同時に記録しても、主キーの ON CONFLICT DO NOTHING で記録できた 1 つのレプリカだけが true を返す。
+func (r *SQLDueReminders) ClaimDueTask(ctx context.Context, t *domain.Task, now time.Time) (bool, error) {
+	if t.DueDate == nil {
+		return false, nil
+	}
+	tag, err := r.db.Exec(ctx, `
+		INSERT INTO task_due_reminders (task_id, due_date, reminded_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`, t.ID, *t.DueDate, now)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim due task: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
@@ -0,0 +1,97 @@
+//go:build integration
+// +build integration
+
+package taskinfra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"teamflow-shared/workspace"
+
+	domain "teamflow-tasks/internal/domain/task"
+	"teamflow-tasks/internal/testutil"
+)
+
+// TestSQLDueReminders は期日が近いタスクを期日・ID の順に取り出すこと、1 度だけ記録できること、期日の変更で再び取り出すことを検証する。
+func TestSQLDueReminders(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetTasksTable(t, db)
+	repo := NewSQLTaskRepository(db)
+	claimer := NewSQLDueReminders(db)
+
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	day := func(offset int) *time.Time {
+		d := time.Date(2025, 3, 10+offset, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	assignee := "user-1"
+	save := func(ctx context.Context, id string, due *time.Time, status domain.TaskStatus, assigned bool) *domain.Task {
+		t.Helper()
+		task, err := domain.NewTask(id, "proj-1", id, "", status, domain.PriorityMedium, due, now)
+		if err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+		if assigned {
+			task.AssigneeID = &assignee
+		}
+		if err := repo.Save(ctx, task); err != nil {
+			t.Fatalf("failed to save task: %v", err)
+		}
+		return task
+	}
+	// ワークスペースに関わらず取り出す
+	save(context.Background(), "today", day(0), domain.StatusTodo, true)
+	save(workspace.NewContext(context.Background(), "acme"), "tomorrow", day(1), domain.StatusInProgress, true)
+	save(context.Background(), "later", day(2), domain.StatusTodo, true)
+	save(context.Background(), "done", day(0), domain.StatusDone, true)
+	save(context.Background(), "unassigned", day(0), domain.StatusTodo, false)
+
+	ctx := context.Background()
+	got, err := claimer.DueTasks(ctx, *day(0), *day(1), nil, 1)
+	if err != nil {
+		t.Fatalf("DueTasks: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "today" {
+		t.Fatalf("expected [today], got %v", taskIDs(got))
+	}
+	// after より後のタスクから返す
+	next, err := claimer.DueTasks(ctx, *day(0), *day(1), got[0], 10)
+	if err != nil || len(next) != 1 || next[0].ID != "tomorrow" || next[0].WorkspaceID != "acme" {
+		t.Fatalf("expected [tomorrow], got %v, %v", taskIDs(next), err)
+	}
+
+	// 同じタスクの同じ期日は 1 度だけ記録できる
+	if ok, err := claimer.ClaimDueTask(ctx, got[0], now); err != nil || !ok {
+		t.Fatalf("expected the first claim to succeed, got %v, %v", ok, err)
+	}
+	if ok, err := claimer.ClaimDueTask(ctx, got[0], now); err != nil || ok {
+		t.Errorf("expected the second claim to fail, got %v, %v", ok, err)
+	}
+	if got, err := claimer.DueTasks(ctx, *day(0), *day(1), nil, 10); err != nil || len(got) != 1 || got[0].ID != "tomorrow" {
+		t.Errorf("expected claimed tasks to be skipped, got %v, %v", taskIDs(got), err)
+	}
+
+	// 期日を変更したタスクは新しい期日で再び返す
+	task, err := repo.FindByID(ctx, "today")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	task.DueDate = day(1)
+	task.RecordChange("dueDate")
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got, err := claimer.DueTasks(ctx, *day(0), *day(1), nil, 10); err != nil || len(got) != 2 || got[0].ID != "today" {
+		t.Errorf("expected [today tomorrow] after the due date changed, got %v, %v", taskIDs(got), err)
+	}
+}
+
+func taskIDs(tasks []*domain.Task) []string {
+	ids := make([]string, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	return ids
+}
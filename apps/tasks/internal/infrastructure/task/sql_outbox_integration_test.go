@@ -0,0 +1,77 @@
+//go:build integration
+// +build integration
+
+package taskinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"teamflow-shared/events"
+	"teamflow-shared/outbox"
+
+	"teamflow-tasks/internal/testutil"
+)
+
+// TestSQLOutbox は記録がトランザクションに従うこと、読み出し・配信済み・失敗の記録・削除を検証する。
+func TestSQLOutbox(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "TRUNCATE TABLE outbox"); err != nil {
+		t.Fatalf("failed to truncate outbox: %v", err)
+	}
+	o := outbox.NewSQLOutbox(db, TxFromContext)
+	txm := NewPgxTxManager(db)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	event := func(id string) events.Event {
+		return events.NewTaskEvent(events.TaskCreated, "default", events.TaskData{ID: id, ProjectID: "proj-1", Status: "todo"}, now)
+	}
+
+	if err := o.Append(ctx, event("task-1"), event("task-2")); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	boom := errors.New("boom")
+	err := txm.WithinTx(ctx, func(ctx context.Context) error {
+		if err := o.Append(ctx, event("task-3")); err != nil {
+			return err
+		}
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected %v, got %v", boom, err)
+	}
+
+	msgs, err := o.Claim(ctx, now, time.Minute, 10)
+	if err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if len(msgs) != 2 {
+		t.Fatalf("expected 2 events (rolled back event excluded), got %d", len(msgs))
+	}
+	var data events.TaskData
+	if err := msgs[0].Event.Decode(&data); err != nil || data.Status != "todo" || msgs[0].Event.Type != events.TaskCreated {
+		t.Errorf("unexpected event %+v (%+v, %v)", msgs[0].Event, data, err)
+	}
+	// リースの間は読み出さない
+	if again, err := o.Claim(ctx, now, time.Minute, 10); err != nil || len(again) != 0 {
+		t.Errorf("expected claimed events to be hidden, got %d, %v", len(again), err)
+	}
+
+	if err := o.MarkPublished(ctx, []int64{msgs[0].Seq}, now); err != nil {
+		t.Fatalf("MarkPublished: %v", err)
+	}
+	if err := o.MarkFailed(ctx, msgs[1].Seq, 1, now, "bus unavailable"); err != nil {
+		t.Fatalf("MarkFailed: %v", err)
+	}
+	retried, err := o.Claim(ctx, now, time.Minute, 10)
+	if err != nil || len(retried) != 1 || retried[0].Seq != msgs[1].Seq || retried[0].Attempts != 1 {
+		t.Fatalf("expected only the failed event to be retried, got %+v, %v", retried, err)
+	}
+
+	if n, err := o.DeletePublished(ctx, now.Add(time.Second)); err != nil || n != 1 {
+		t.Errorf("DeletePublished() = %d, %v", n, err)
+	}
+}
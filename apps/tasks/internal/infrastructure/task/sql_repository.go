@@ -3,23 +3,33 @@ package taskinfra
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"teamflow-shared/sqlbuilder"
+	"teamflow-shared/workspace"
+
 	domain "teamflow-tasks/internal/domain/task"
 	usecase "teamflow-tasks/internal/usecase/task"
 )
 
 // SQLTaskRepository はPostgreSQLを使用したTaskRepository実装。
+//
+// すべての問い合わせを context のワークスペース（workspace.FromContext）で絞り込む。
+// 別のワークスペースのタスクは存在しないものとして扱う。
 type SQLTaskRepository struct {
 	db *pgxpool.Pool
 }
 
 // コンパイル時にインターフェース実装を保証する。
-var _ usecase.TaskRepository = (*SQLTaskRepository)(nil)
+var (
+	_ usecase.TaskRepository     = (*SQLTaskRepository)(nil)
+	_ usecase.ArchivedTaskPurger = (*SQLTaskRepository)(nil)
+)
 
 // NewSQLTaskRepository は新しいSQLTaskRepositoryを生成する。
 func NewSQLTaskRepository(db *pgxpool.Pool) *SQLTaskRepository {
@@ -28,32 +38,254 @@ func NewSQLTaskRepository(db *pgxpool.Pool) *SQLTaskRepository {
 	}
 }
 
-// Save はタスクを保存する（後回し）。
-func (r *SQLTaskRepository) Save(_ context.Context, _ *domain.Task) error {
-	return fmt.Errorf("not implemented yet")
+// conn は ctx にトランザクション（PgxTxManager.WithinTx）があればそれを、無ければ Pool を返す。
+func (r *SQLTaskRepository) conn(ctx context.Context) querier {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	return r.db
 }
 
-// Update は既存タスクを更新する（後回し）。
-func (r *SQLTaskRepository) Update(_ context.Context, _ *domain.Task) error {
-	return fmt.Errorf("not implemented yet")
+// taskInsertColumns は INSERT 時のカラム順。number はトリガー（tasks_assign_number）が採番するため含めない。
+const taskInsertColumns = "id, project_id, title, description, status, priority, assignee_id, due_date, start_date, estimate, milestone_id, sprint_id, epic_id, label_ids, created_at, updated_at, created_by, updated_by, workspace_id"
+
+// taskColumns は SELECT 時のカラム順。scanTask の Scan 順と一致させる。
+const taskColumns = taskInsertColumns + ", number, archived_at, version, field_versions"
+
+// Save はタスクを context のワークスペースに保存し、採番されたタスク番号を t.Number、ワークスペースを t.WorkspaceID、
+// 版数（1）を t.Version に設定する。
+func (r *SQLTaskRepository) Save(ctx context.Context, t *domain.Task) error {
+	workspaceID := workspace.FromContext(ctx)
+	err := r.conn(ctx).QueryRow(ctx,
+		"INSERT INTO tasks ("+taskInsertColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19) RETURNING number, version",
+		t.ID, t.ProjectID, t.Title, nullIfEmpty(t.Description), string(t.Status), string(t.Priority),
+		t.AssigneeID, t.DueDate, t.StartDate, t.Estimate, t.MilestoneID, t.SprintID, t.EpicID, labelIDsArray(t.LabelIDs), t.CreatedAt, t.UpdatedAt,
+		t.CreatedBy, t.UpdatedBy, workspaceID,
+	).Scan(&t.Number, &t.Version)
+	if err != nil {
+		return fmt.Errorf("failed to insert task: %w", err)
+	}
+	t.WorkspaceID = workspaceID
+	t.FieldVersions = nil
+	return nil
 }
 
-// FindByID はIDを指定してタスクを取得する（後回し）。
-func (r *SQLTaskRepository) FindByID(_ context.Context, _ string) (*domain.Task, error) {
-	return nil, fmt.Errorf("not implemented yet")
+// Update は既存タスクを更新する。存在しない場合は ErrTaskNotFound、保存されている版数が t.Version-1 でない場合
+// （読み取った後に他で更新された）は *domain.VersionConflictError を返す。
+func (r *SQLTaskRepository) Update(ctx context.Context, t *domain.Task) error {
+	tag, err := r.conn(ctx).Exec(ctx, `
+		UPDATE tasks SET
+			title = $2,
+			description = $3,
+			status = $4,
+			priority = $5,
+			assignee_id = $6,
+			due_date = $7,
+			start_date = $8,
+			estimate = $9,
+			milestone_id = $10,
+			sprint_id = $11,
+			epic_id = $12,
+			label_ids = $13,
+			updated_at = $14,
+			updated_by = $15,
+			version = $17,
+			field_versions = $18
+		WHERE id = $1 AND workspace_id = $16 AND version = $17 - 1
+	`,
+		t.ID, t.Title, nullIfEmpty(t.Description), string(t.Status), string(t.Priority),
+		t.AssigneeID, t.DueDate, t.StartDate, t.Estimate, t.MilestoneID, t.SprintID, t.EpicID, labelIDsArray(t.LabelIDs), t.UpdatedAt,
+		t.UpdatedBy, workspace.FromContext(ctx), t.Version, fieldVersionsMap(t.FieldVersions),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+
+	// 更新できなかった理由（存在しない・版数が異なる）を確かめる
+	var current int
+	err = r.conn(ctx).QueryRow(ctx, "SELECT version FROM tasks WHERE id = $1 AND workspace_id = $2", t.ID, workspace.FromContext(ctx)).Scan(&current)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrTaskNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find task version: %w", err)
+	}
+	return &domain.VersionConflictError{Current: current}
 }
 
-// ListByProject は指定されたprojectIDのタスク一覧を返す（後方互換性のため残す、後回し）。
-func (r *SQLTaskRepository) ListByProject(_ context.Context, _ string) ([]*domain.Task, error) {
-	return nil, fmt.Errorf("not implemented yet")
+// FindByID はIDを指定してタスクを取得する。存在しない場合は ErrTaskNotFound を返す。
+func (r *SQLTaskRepository) FindByID(ctx context.Context, id string) (*domain.Task, error) {
+	row := r.conn(ctx).QueryRow(ctx, "SELECT "+taskColumns+" FROM tasks WHERE id = $1 AND workspace_id = $2", id, workspace.FromContext(ctx))
+	t, err := scanTask(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+	return t, nil
+}
+
+// FindByNumber はプロジェクト内のタスク番号を指定してタスクを取得する。存在しない場合は ErrTaskNotFound を返す。
+func (r *SQLTaskRepository) FindByNumber(ctx context.Context, projectID string, number int) (*domain.Task, error) {
+	row := r.conn(ctx).QueryRow(ctx, "SELECT "+taskColumns+" FROM tasks WHERE project_id = $1 AND number = $2 AND workspace_id = $3", projectID, number, workspace.FromContext(ctx))
+	t, err := scanTask(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("failed to find task by number: %w", err)
+	}
+	return t, nil
+}
+
+// ArchiveByProject は projectID のアーカイブされていないタスクを archivedAt でアーカイブし、対象のタスク ID を返す。
+// updated_at は変更しない（タスク自体の編集ではないため）。
+func (r *SQLTaskRepository) ArchiveByProject(ctx context.Context, projectID string, archivedAt time.Time) ([]string, error) {
+	ids, err := r.queryIDs(ctx,
+		"UPDATE tasks SET archived_at = $2 WHERE project_id = $1 AND workspace_id = $3 AND archived_at IS NULL RETURNING id",
+		projectID, archivedAt, workspace.FromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive tasks: %w", err)
+	}
+	return ids, nil
+}
+
+// UnarchiveByProject は projectID のアーカイブされたタスクを戻し、対象のタスク ID を返す。
+func (r *SQLTaskRepository) UnarchiveByProject(ctx context.Context, projectID string) ([]string, error) {
+	ids, err := r.queryIDs(ctx,
+		"UPDATE tasks SET archived_at = NULL WHERE project_id = $1 AND workspace_id = $2 AND archived_at IS NOT NULL RETURNING id",
+		projectID, workspace.FromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unarchive tasks: %w", err)
+	}
+	return ids, nil
+}
+
+// DeleteByProject は projectID のタスクを削除し、削除したタスク ID を返す。
+// タスク番号のカウンタ（task_number_counters）は残すため、同じプロジェクトで番号は再利用されない。
+func (r *SQLTaskRepository) DeleteByProject(ctx context.Context, projectID string) ([]string, error) {
+	ids, err := r.queryIDs(ctx, "DELETE FROM tasks WHERE project_id = $1 AND workspace_id = $2 RETURNING id", projectID, workspace.FromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete tasks: %w", err)
+	}
+	return ids, nil
+}
+
+// ArchivedBefore は before より前にアーカイブされたタスクを、アーカイブ日時・ID の順に最大 limit 件返す。
+// すべてのワークスペースのタスクを対象にする（保持期間を過ぎたタスクの物理削除に使う）。
+func (r *SQLTaskRepository) ArchivedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Task, error) {
+	tasks, err := r.queryTasks(ctx,
+		"SELECT "+taskColumns+" FROM tasks WHERE archived_at < $1 ORDER BY archived_at, id LIMIT $2",
+		before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find archived tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+// DeleteArchivedBefore は before より前にアーカイブされたタスクを、アーカイブ日時・ID の順に最大 limit 件物理削除し、
+// 削除したタスクを返す。すべてのワークスペースのタスクを対象にする。
+// 削除はトリガーで Tombstone（task_tombstones）に記録され、キャッシュの無効化も通知される（DeleteByProject と同じ）。
+func (r *SQLTaskRepository) DeleteArchivedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Task, error) {
+	tasks, err := r.queryTasks(ctx, `
+		DELETE FROM tasks WHERE id IN (
+			SELECT id FROM tasks WHERE archived_at < $1 ORDER BY archived_at, id LIMIT $2
+		)
+		RETURNING `+taskColumns,
+		before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete archived tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+// MoveIncompleteSprintTasks は fromSprintID の未完了でアーカイブされていないタスクを toSprintID（nil はバックログ）に移し、
+// 対象のタスク ID を返す。
+func (r *SQLTaskRepository) MoveIncompleteSprintTasks(ctx context.Context, projectID, fromSprintID string, toSprintID *string, actorID string, now time.Time) ([]string, error) {
+	ids, err := r.queryIDs(ctx, `
+		UPDATE tasks SET sprint_id = $3, updated_at = $4, updated_by = $5,
+			version = version + 1, field_versions = field_versions || jsonb_build_object('sprintId', version + 1)
+		WHERE project_id = $1 AND workspace_id = $6 AND sprint_id = $2 AND status <> 'done' AND archived_at IS NULL
+		RETURNING id
+	`, projectID, fromSprintID, toSprintID, now, actorID, workspace.FromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to move sprint tasks: %w", err)
+	}
+	return ids, nil
+}
+
+// queryIDs は id を 1 列返すクエリ（RETURNING id）を実行し、ID の一覧を返す。
+func (r *SQLTaskRepository) queryIDs(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := r.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// queryTasks は taskColumns を返すクエリを実行し、タスクの一覧を返す。
+func (r *SQLTaskRepository) queryTasks(ctx context.Context, query string, args ...interface{}) ([]*domain.Task, error) {
+	rows, err := r.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := make([]*domain.Task, 0)
+	for rows.Next() {
+		t, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, rows.Err()
+}
+
+// ListByProject は指定されたprojectIDのタスク一覧を返す（後方互換性のため残す）。
+// デフォルトの Query（created_at ASC, id ASC）で FindByProjectID を keyset で繰り返し呼び、全件を返す。
+func (r *SQLTaskRepository) ListByProject(ctx context.Context, projectID string) ([]*domain.Task, error) {
+	query, err := domain.NewTaskQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*domain.Task, 0)
+	for {
+		page, err := r.FindByProjectID(ctx, projectID, query)
+		if err != nil {
+			return nil, err
+		}
+
+		// FindByProjectID は nextCursor 判定のため limit + 1 件まで返す
+		hasMore := len(page) > query.Limit
+		if hasMore {
+			page = page[:query.Limit]
+		}
+		out = append(out, page...)
+		if !hasMore {
+			return out, nil
+		}
+
+		last := page[len(page)-1]
+		query.Cursor = &domain.TaskCursor{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+			ProjectID: projectID,
+		}
+	}
 }
 
 // FindByProjectID は指定されたprojectIDとQuery Objectに基づいてタスクを取得する。
 func (r *SQLTaskRepository) FindByProjectID(ctx context.Context, projectID string, query *domain.TaskQuery) ([]*domain.Task, error) {
 	// SQLクエリを動的に構築
-	querySQL, args := r.buildQuery(projectID, query)
+	querySQL, args := r.buildQuery(workspace.FromContext(ctx), projectID, query)
 
-	rows, err := r.db.Query(ctx, querySQL, args...)
+	rows, err := r.conn(ctx).Query(ctx, querySQL, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query tasks: %w", err)
 	}
@@ -61,34 +293,11 @@ func (r *SQLTaskRepository) FindByProjectID(ctx context.Context, projectID strin
 
 	var tasks []*domain.Task
 	for rows.Next() {
-		var t domain.Task
-		var assigneeID *string
-		var dueDate *time.Time
-		var description sql.NullString // ← ここは database/sql を使う
-
-		err := rows.Scan(
-			&t.ID,
-			&t.ProjectID,
-			&t.Title,
-			&description,
-			&t.Status,
-			&t.Priority,
-			&assigneeID,
-			&dueDate,
-			&t.CreatedAt,
-			&t.UpdatedAt,
-		)
+		t, err := scanTask(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan task: %w", err)
 		}
-
-		t.AssigneeID = assigneeID
-		t.DueDate = dueDate
-		if description.Valid {
-			t.Description = description.String
-		}
-
-		tasks = append(tasks, &t)
+		tasks = append(tasks, t)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -98,126 +307,125 @@ func (r *SQLTaskRepository) FindByProjectID(ctx context.Context, projectID strin
 	return tasks, nil
 }
 
+// ProjectVersion は projectID のアーカイブされていないタスクの件数と最新の updated_at を返す。
+// プロジェクトの索引（idx_tasks_project_created_id）の範囲で集計し、行を返さないため一覧の取得より軽い。
+func (r *SQLTaskRepository) ProjectVersion(ctx context.Context, projectID string) (domain.ProjectVersion, error) {
+	var (
+		v         domain.ProjectVersion
+		updatedAt *time.Time
+	)
+	err := r.conn(ctx).QueryRow(ctx,
+		"SELECT COUNT(*), MAX(updated_at) FROM tasks WHERE project_id = $1 AND workspace_id = $2 AND archived_at IS NULL",
+		projectID, workspace.FromContext(ctx),
+	).Scan(&v.Count, &updatedAt)
+	if err != nil {
+		return domain.ProjectVersion{}, fmt.Errorf("failed to query project version: %w", err)
+	}
+	if updatedAt != nil {
+		v.UpdatedAt = *updatedAt
+	}
+	return v, nil
+}
+
 // buildQuery はFindByProjectID用のSQLクエリを構築する。
 // 戻り値: (SQL文字列, パラメータ配列)
-func (r *SQLTaskRepository) buildQuery(projectID string, query *domain.TaskQuery) (string, []interface{}) {
-	var whereParts []string
-	var args []interface{}
-	argIndex := 1
+func (r *SQLTaskRepository) buildQuery(workspaceID, projectID string, query *domain.TaskQuery) (string, []interface{}) {
+	b := sqlbuilder.NewSelect(taskColumns, "tasks")
 
-	// projectIDは必ず絞る
-	whereParts = append(whereParts, fmt.Sprintf("project_id = $%d", argIndex))
-	args = append(args, projectID)
-	argIndex++
+	// projectID とワークスペースは必ず絞る。アーカイブされたタスク（プロジェクトの削除）は含めない
+	b.Where("project_id = " + b.Arg(projectID))
+	b.Where("workspace_id = " + b.Arg(workspaceID))
+	b.Where("archived_at IS NULL")
 
 	// Status filter
 	if len(query.Statuses) > 0 {
-		placeholders := make([]string, len(query.Statuses))
+		values := make([]interface{}, len(query.Statuses))
 		for i, status := range query.Statuses {
-			placeholders[i] = fmt.Sprintf("$%d", argIndex)
-			args = append(args, string(status))
-			argIndex++
+			values[i] = string(status)
 		}
-		whereParts = append(whereParts, fmt.Sprintf("status IN (%s)", strings.Join(placeholders, ", ")))
+		b.Where("status IN (" + b.ArgList(values...) + ")")
 	}
 
 	// Priority filter
 	if len(query.Priorities) > 0 {
-		placeholders := make([]string, len(query.Priorities))
+		values := make([]interface{}, len(query.Priorities))
 		for i, priority := range query.Priorities {
-			placeholders[i] = fmt.Sprintf("$%d", argIndex)
-			args = append(args, string(priority))
-			argIndex++
+			values[i] = string(priority)
 		}
-		whereParts = append(whereParts, fmt.Sprintf("priority IN (%s)", strings.Join(placeholders, ", ")))
+		b.Where("priority IN (" + b.ArgList(values...) + ")")
 	}
 
 	// AssigneeID filter
 	if query.AssigneeID != nil && *query.AssigneeID != "" {
-		whereParts = append(whereParts, fmt.Sprintf("assignee_id = $%d", argIndex))
-		args = append(args, *query.AssigneeID)
-		argIndex++
+		b.Where("assignee_id = " + b.Arg(*query.AssigneeID))
+	}
+
+	// MilestoneID filter
+	if query.MilestoneID != nil && *query.MilestoneID != "" {
+		b.Where("milestone_id = " + b.Arg(*query.MilestoneID))
+	}
+
+	// SprintID filter
+	if query.SprintID != nil && *query.SprintID != "" {
+		b.Where("sprint_id = " + b.Arg(*query.SprintID))
+	}
+
+	// EpicID filter
+	if query.EpicID != nil && *query.EpicID != "" {
+		b.Where("epic_id = " + b.Arg(*query.EpicID))
 	}
 
 	// DueDate range filter
 	if query.DueDateFrom != nil {
-		whereParts = append(whereParts, fmt.Sprintf("due_date >= $%d::date", argIndex))
-		args = append(args, query.DueDateFrom.Format("2006-01-02"))
-		argIndex++
+		b.Where("due_date >= " + b.Arg(query.DueDateFrom.Format("2006-01-02")) + "::date")
 	}
 	if query.DueDateTo != nil {
-		whereParts = append(whereParts, fmt.Sprintf("due_date <= $%d::date", argIndex))
-		args = append(args, query.DueDateTo.Format("2006-01-02"))
-		argIndex++
+		b.Where("due_date <= " + b.Arg(query.DueDateTo.Format("2006-01-02")) + "::date")
 	}
 
 	// Query filter (title ILIKE)
+	// idx_tasks_title_trgm（pg_trgm の GIN インデックス）で部分一致でも seq scan を避ける。
+	// q に含まれる % / _ はワイルドカードではなく文字として扱う。
 	if query.Query != nil {
-		whereParts = append(whereParts, fmt.Sprintf("title ILIKE $%d", argIndex))
-		args = append(args, "%"+*query.Query+"%")
-		argIndex++
+		b.Where("title ILIKE " + b.Arg("%"+sqlbuilder.EscapeLike(*query.Query)+"%"))
 	}
 
 	// Cursor がある場合の seek 条件
 	if query.Cursor != nil {
 		// WHERE: (created_at > $X) OR (created_at = $X AND id > $Y)
-		seekCondition := fmt.Sprintf("(created_at > $%d) OR (created_at = $%d AND id > $%d)", argIndex, argIndex, argIndex+1)
-		whereParts = append(whereParts, seekCondition)
-		args = append(args, query.Cursor.CreatedAt, query.Cursor.ID)
-		argIndex += 2
-	}
-
-	// WHERE句を組み立て
-	whereClause := ""
-	if len(whereParts) > 0 {
-		whereClause = "WHERE " + strings.Join(whereParts, " AND ")
+		// 新しい順（desc）の cursor は不等号を反転する: (created_at < $X) OR (created_at = $X AND id < $Y)
+		// 他の条件と AND で連結するため、全体を括弧で囲む
+		cmp := " > "
+		if query.Cursor.Descending {
+			cmp = " < "
+		}
+		createdAt := b.Arg(query.Cursor.CreatedAt)
+		id := b.Arg(query.Cursor.ID)
+		b.Where("((created_at" + cmp + createdAt + ") OR (created_at = " + createdAt + " AND id" + cmp + id + "))")
 	}
 
 	// ORDER BY句を組み立て
-	// cursor がある場合は created_at ASC, id ASC に固定（v1 の制限）
-	var orderByClause string
-	if query.Cursor != nil {
-		// cursor 使用時は created_at ASC, id ASC に固定
-		orderByClause = "ORDER BY created_at ASC, id ASC"
+	// 新しい順の keyset（sort=-createdAt または desc の cursor）は created_at DESC, id DESC、
+	// それ以外で cursor がある場合は created_at ASC, id ASC に固定（v1 の制限）
+	if query.KeysetDescending() {
+		b.OrderBy("created_at DESC", "id DESC")
+	} else if query.Cursor != nil {
+		b.OrderBy("created_at ASC", "id ASC")
 	} else {
-		// cursor がない場合は既存のロジック
 		orderByParts := r.buildOrderBy(query)
-		if len(orderByParts) > 0 {
-			orderByClause = "ORDER BY " + strings.Join(orderByParts, ", ")
-		} else {
+		if len(orderByParts) == 0 {
 			// デフォルトソート: createdAt ASC
-			orderByClause = "ORDER BY created_at ASC"
+			orderByParts = []string{"created_at ASC"}
 		}
 		// 安定化のため、最後にid ASCを追加
-		orderByClause += ", id ASC"
+		b.OrderBy(orderByParts...).OrderBy("id ASC")
 	}
 
 	// LIMIT句（nextCursor 判定のため limit + 1 件取得）
 	// 1ページ目（cursor が nil）でも limit + 1 件取得して nextCursor 判定を行う
-	limitValue := query.Limit + 1
-	limitClause := fmt.Sprintf("LIMIT $%d", argIndex)
-	args = append(args, limitValue)
-
-	// 最終的なSQL
-	sql := fmt.Sprintf(`
-		SELECT
-			id,
-			project_id,
-			title,
-			description,
-			status,
-			priority,
-			assignee_id,
-			due_date,
-			created_at,
-			updated_at
-		FROM tasks
-		%s
-		%s
-		%s
-	`, whereClause, orderByClause, limitClause)
-
-	return sql, args
+	b.Limit(query.Limit + 1)
+
+	return b.Build()
 }
 
 // buildOrderBy はORDER BY句を構築する（ホワイトリストで安全に）。
@@ -273,3 +481,73 @@ func (r *SQLTaskRepository) buildOrderBy(query *domain.TaskQuery) []string {
 
 	return orderByParts
 }
+
+// scanTask は taskColumns の順で 1 行を読み取る。
+func scanTask(row pgx.Row) (*domain.Task, error) {
+	var t domain.Task
+	var description sql.NullString // ← ここは database/sql を使う
+
+	err := row.Scan(
+		&t.ID,
+		&t.ProjectID,
+		&t.Title,
+		&description,
+		&t.Status,
+		&t.Priority,
+		&t.AssigneeID,
+		&t.DueDate,
+		&t.StartDate,
+		&t.Estimate,
+		&t.MilestoneID,
+		&t.SprintID,
+		&t.EpicID,
+		&t.LabelIDs,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+		&t.CreatedBy,
+		&t.UpdatedBy,
+		&t.WorkspaceID,
+		&t.Number,
+		&t.ArchivedAt,
+		&t.Version,
+		&t.FieldVersions,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if description.Valid {
+		t.Description = description.String
+	}
+	if len(t.LabelIDs) == 0 {
+		t.LabelIDs = nil
+	}
+	if len(t.FieldVersions) == 0 {
+		t.FieldVersions = nil
+	}
+	return &t, nil
+}
+
+// labelIDsArray は label_ids カラムに書き込む値を返す（NOT NULL のため nil は空配列にする）。
+func labelIDsArray(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
+
+// fieldVersionsMap は field_versions カラムに書き込む値を返す（NOT NULL のため nil は空のオブジェクトにする）。
+func fieldVersionsMap(versions map[string]int) map[string]int {
+	if versions == nil {
+		return map[string]int{}
+	}
+	return versions
+}
+
+// nullIfEmpty は空文字を NULL として保存するために nil を返す。
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
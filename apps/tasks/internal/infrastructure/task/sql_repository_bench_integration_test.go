@@ -0,0 +1,88 @@
+//go:build integration
+// +build integration
+
+package taskinfra
+
+import (
+	"context"
+	"testing"
+
+	"teamflow-shared/dbtest"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// BenchmarkSQLTaskRepository_FindByProjectID_Query は q（title 部分一致）検索を
+// trigram インデックス無し（seq scan 相当）と有りで比較する。
+//
+//	DB_TEST_DSN=... go test -tags=integration -run '^$' -bench FindByProjectID_Query ./internal/infrastructure/task/
+func BenchmarkSQLTaskRepository_FindByProjectID_Query(b *testing.B) {
+	db := dbtest.TestPool
+	if db == nil {
+		b.Skip("DB_TEST_DSN is not set")
+	}
+	ctx := context.Background()
+
+	// 大きめのプロジェクト（50,000 件）を用意し、タイトルに一致するのはごく一部にする
+	if _, err := db.Exec(ctx, "TRUNCATE TABLE tasks"); err != nil {
+		b.Fatalf("failed to truncate tasks: %v", err)
+	}
+	if _, err := db.Exec(ctx, `
+		INSERT INTO tasks (id, project_id, title, status, priority, created_at, updated_at)
+		SELECT
+			'task-' || i,
+			'proj-bench',
+			CASE WHEN i % 1000 = 0 THEN 'needle task ' || i ELSE 'haystack task ' || md5(i::text) END,
+			'todo',
+			'medium',
+			now() - (i || ' seconds')::interval,
+			now()
+		FROM generate_series(1, 50000) AS i
+	`); err != nil {
+		b.Fatalf("failed to seed tasks: %v", err)
+	}
+	if _, err := db.Exec(ctx, "ANALYZE tasks"); err != nil {
+		b.Fatalf("failed to analyze tasks: %v", err)
+	}
+	b.Cleanup(func() { _, _ = db.Exec(ctx, "TRUNCATE TABLE tasks") })
+
+	repo := NewSQLTaskRepository(db)
+	txm := NewPgxTxManager(db)
+	query, err := domain.NewTaskQuery(domain.WithQueryFilter("needle"), domain.WithLimit(50))
+	if err != nil {
+		b.Fatalf("failed to create query: %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		settings []string // SET LOCAL でプランナの選択肢を絞る
+	}{
+		{name: "without_trgm_index", settings: []string{"SET LOCAL enable_bitmapscan = off", "SET LOCAL enable_indexscan = off"}},
+		{name: "with_trgm_index", settings: []string{"SET LOCAL enable_seqscan = off"}},
+	}
+
+	for _, c := range cases {
+		b.Run(c.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				err := txm.WithinTx(ctx, func(ctx context.Context) error {
+					for _, s := range c.settings {
+						if _, err := repo.conn(ctx).Exec(ctx, s); err != nil {
+							return err
+						}
+					}
+					tasks, err := repo.FindByProjectID(ctx, "proj-bench", query)
+					if err != nil {
+						return err
+					}
+					if len(tasks) != 50 {
+						b.Fatalf("expected 50 tasks, got %d", len(tasks))
+					}
+					return nil
+				})
+				if err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
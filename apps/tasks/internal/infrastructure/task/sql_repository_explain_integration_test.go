@@ -0,0 +1,106 @@
+//go:build integration
+// +build integration
+
+package taskinfra
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	domain "teamflow-tasks/internal/domain/task"
+	"teamflow-tasks/internal/testutil"
+)
+
+// TestSQLTaskRepository_BuildQuery_UsesIndexes は生成したクエリの実行計画を EXPLAIN し、
+// 想定したインデックスが使われることを検証する（インデックス削除・クエリ変更の回帰防止）。
+//
+// テストデータが少ないとプランナは seq scan を選ぶため、enable_seqscan = off で
+// 「使えるインデックスがあるか」を確認する。
+func TestSQLTaskRepository_BuildQuery_UsesIndexes(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewSQLTaskRepository(db)
+	txm := NewPgxTxManager(db)
+	testutil.ResetTasksTable(t, db)
+	ctx := context.Background()
+
+	if _, err := db.Exec(ctx, `
+		INSERT INTO tasks (id, project_id, title, status, priority, assignee_id, due_date, created_at, updated_at)
+		SELECT
+			'task-' || i,
+			'proj-' || (i % 10),
+			'task ' || i,
+			(ARRAY['todo', 'in_progress', 'done'])[i % 3 + 1],
+			'medium',
+			'user-' || (i % 20),
+			DATE '2025-01-01' + (i % 60),
+			now() - (i || ' seconds')::interval,
+			now()
+		FROM generate_series(1, 2000) AS i
+	`); err != nil {
+		t.Fatalf("failed to seed tasks: %v", err)
+	}
+	if _, err := db.Exec(ctx, "ANALYZE tasks"); err != nil {
+		t.Fatalf("failed to analyze tasks: %v", err)
+	}
+
+	assignee := "user-1"
+	tests := []struct {
+		name      string
+		query     *domain.TaskQuery
+		wantIndex string
+	}{
+		{
+			name: "keyset pagination",
+			query: &domain.TaskQuery{
+				Limit:  50,
+				Cursor: &domain.TaskCursor{CreatedAt: time.Now(), ID: "task-100"},
+			},
+			wantIndex: "idx_tasks_project_created_id",
+		},
+		{
+			name:      "default first page",
+			query:     &domain.TaskQuery{Limit: 50},
+			wantIndex: "idx_tasks_project_created_id",
+		},
+		{
+			name:      "assignee filter",
+			query:     &domain.TaskQuery{Limit: 50, AssigneeID: &assignee},
+			wantIndex: "idx_tasks_project_assignee",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := repo.buildQuery("ws-1", "proj-1", tt.query)
+
+			var plan strings.Builder
+			err := txm.WithinTx(ctx, func(ctx context.Context) error {
+				if _, err := repo.conn(ctx).Exec(ctx, "SET LOCAL enable_seqscan = off"); err != nil {
+					return err
+				}
+				rows, err := repo.conn(ctx).Query(ctx, "EXPLAIN "+sql, args...)
+				if err != nil {
+					return err
+				}
+				defer rows.Close()
+				for rows.Next() {
+					var line string
+					if err := rows.Scan(&line); err != nil {
+						return err
+					}
+					plan.WriteString(line + "\n")
+				}
+				return rows.Err()
+			})
+			if err != nil {
+				t.Fatalf("failed to explain query: %v", err)
+			}
+
+			if !strings.Contains(plan.String(), tt.wantIndex) {
+				t.Errorf("expected plan to use %s, got:\n%s", tt.wantIndex, plan.String())
+			}
+		})
+	}
+}
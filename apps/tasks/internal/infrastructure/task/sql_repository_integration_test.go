@@ -6,12 +6,15 @@ package taskinfra
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"teamflow-shared/workspace"
+
 	domain "teamflow-tasks/internal/domain/task"
 	"teamflow-tasks/internal/testutil"
 )
@@ -85,11 +88,11 @@ func TestSQLTaskRepository_FindByProjectID_SortByPriority(t *testing.T) {
 
 	now := time.Now().UTC()
 
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "task-high", ProjectID: "proj-1", Title: "T1", Status: "todo", Priority: "high", CreatedAt: now.Add(-3 * time.Hour), UpdatedAt: now.Add(-3 * time.Hour)},
-		{ID: "task-medium", ProjectID: "proj-1", Title: "T2", Status: "todo", Priority: "medium", CreatedAt: now.Add(-2 * time.Hour), UpdatedAt: now.Add(-2 * time.Hour)},
-		{ID: "task-low", ProjectID: "proj-1", Title: "T3", Status: "todo", Priority: "low", CreatedAt: now.Add(-1 * time.Hour), UpdatedAt: now.Add(-1 * time.Hour)},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("task-high").WithTitle("T1").WithPriority(domain.PriorityHigh).WithCreatedAt(now.Add(-3*time.Hour)).Build(),
+		testutil.NewTaskBuilder().WithID("task-medium").WithTitle("T2").WithCreatedAt(now.Add(-2*time.Hour)).Build(),
+		testutil.NewTaskBuilder().WithID("task-low").WithTitle("T3").WithPriority(domain.PriorityLow).WithCreatedAt(now.Add(-1*time.Hour)).Build(),
+	)
 
 	// priority DESC でソート（high > medium > low）
 	query, err := domain.NewTaskQuery(domain.WithSort("-priority"))
@@ -128,11 +131,11 @@ func TestSQLTaskRepository_FindByProjectID_SortByDueDate_NullHandling(t *testing
 	d1 := testutil.DateYMD(2026, 1, 10)
 	d2 := testutil.DateYMD(2026, 1, 20)
 
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "task-null", ProjectID: "proj-1", Title: "NULL due", Status: "todo", Priority: "medium", DueDate: nil, CreatedAt: now.Add(-3 * time.Hour), UpdatedAt: now.Add(-3 * time.Hour)},
-		{ID: "task-d1", ProjectID: "proj-1", Title: "due 1", Status: "todo", Priority: "medium", DueDate: &d1, CreatedAt: now.Add(-2 * time.Hour), UpdatedAt: now.Add(-2 * time.Hour)},
-		{ID: "task-d2", ProjectID: "proj-1", Title: "due 2", Status: "todo", Priority: "medium", DueDate: &d2, CreatedAt: now.Add(-1 * time.Hour), UpdatedAt: now.Add(-1 * time.Hour)},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("task-null").WithTitle("NULL due").WithCreatedAt(now.Add(-3*time.Hour)).Build(),
+		testutil.NewTaskBuilder().WithID("task-d1").WithTitle("due 1").WithDueDate(d1).WithCreatedAt(now.Add(-2*time.Hour)).Build(),
+		testutil.NewTaskBuilder().WithID("task-d2").WithTitle("due 2").WithDueDate(d2).WithCreatedAt(now.Add(-1*time.Hour)).Build(),
+	)
 
 	// dueDate ASC は NULLS LAST が期待（ASC のとき null は last）
 	qAsc, err := domain.NewTaskQuery(domain.WithSort("dueDate"))
@@ -177,11 +180,11 @@ func TestSQLTaskRepository_FindByProjectID_SortByCreatedAt(t *testing.T) {
 
 	base := time.Now().UTC().Add(-10 * time.Minute)
 
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "task-1", ProjectID: "proj-1", Title: "old", Status: "todo", Priority: "low", CreatedAt: base.Add(-2 * time.Minute), UpdatedAt: base.Add(-2 * time.Minute)},
-		{ID: "task-2", ProjectID: "proj-1", Title: "mid", Status: "todo", Priority: "low", CreatedAt: base.Add(-1 * time.Minute), UpdatedAt: base.Add(-1 * time.Minute)},
-		{ID: "task-3", ProjectID: "proj-1", Title: "new", Status: "todo", Priority: "low", CreatedAt: base, UpdatedAt: base},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("task-1").WithTitle("old").WithPriority(domain.PriorityLow).WithCreatedAt(base.Add(-2*time.Minute)).Build(),
+		testutil.NewTaskBuilder().WithID("task-2").WithTitle("mid").WithPriority(domain.PriorityLow).WithCreatedAt(base.Add(-1*time.Minute)).Build(),
+		testutil.NewTaskBuilder().WithID("task-3").WithTitle("new").WithPriority(domain.PriorityLow).WithCreatedAt(base).Build(),
+	)
 
 	qDesc, err := domain.NewTaskQuery(domain.WithSort("-createdAt"))
 	if err != nil {
@@ -210,11 +213,11 @@ func TestSQLTaskRepository_FindByProjectID_MultipleSortKeys(t *testing.T) {
 	base := time.Now().UTC().Add(-1 * time.Hour)
 
 	// 同 priority の中で createdAt で並ぶことを確認する（-priority,createdAt）
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "task-a", ProjectID: "proj-1", Title: "A", Status: "todo", Priority: "high", CreatedAt: base.Add(10 * time.Minute), UpdatedAt: base.Add(10 * time.Minute)},
-		{ID: "task-b", ProjectID: "proj-1", Title: "B", Status: "todo", Priority: "high", CreatedAt: base.Add(0 * time.Minute), UpdatedAt: base.Add(0 * time.Minute)},
-		{ID: "task-c", ProjectID: "proj-1", Title: "C", Status: "todo", Priority: "medium", CreatedAt: base.Add(5 * time.Minute), UpdatedAt: base.Add(5 * time.Minute)},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("task-a").WithTitle("A").WithPriority(domain.PriorityHigh).WithCreatedAt(base.Add(10*time.Minute)).Build(),
+		testutil.NewTaskBuilder().WithID("task-b").WithTitle("B").WithPriority(domain.PriorityHigh).WithCreatedAt(base.Add(0*time.Minute)).Build(),
+		testutil.NewTaskBuilder().WithID("task-c").WithTitle("C").WithCreatedAt(base.Add(5*time.Minute)).Build(),
+	)
 
 	q, err := domain.NewTaskQuery(domain.WithSort("-priority,createdAt"))
 	if err != nil {
@@ -253,15 +256,15 @@ func TestSQLTaskRepository_FindByProjectID_Filter_Status_Single(t *testing.T) {
 	user1 := "user-1"
 	user2 := "user-2"
 
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
+	testutil.InsertMany(t, db,
 		// proj-1: todo, in_progress, done を混在
-		{ID: "proj1-todo", ProjectID: "proj-1", Title: "alpha", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-inprogress", ProjectID: "proj-1", Title: "beta", Status: "in_progress", Priority: "medium", AssigneeID: &user2, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-done", ProjectID: "proj-1", Title: "gamma", Status: "done", Priority: "low", AssigneeID: nil, CreatedAt: now, UpdatedAt: now},
+		testutil.NewTaskBuilder().WithID("proj1-todo").WithTitle("alpha").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-inprogress").WithTitle("beta").WithStatus(domain.StatusInProgress).WithAssigneeID(user2).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-done").WithTitle("gamma").WithStatus(domain.StatusDone).WithPriority(domain.PriorityLow).WithCreatedAt(now).Build(),
 		// proj-2: 混入防止のため
-		{ID: "proj2-todo", ProjectID: "proj-2", Title: "delta", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj2-done", ProjectID: "proj-2", Title: "epsilon", Status: "done", Priority: "medium", AssigneeID: &user2, CreatedAt: now, UpdatedAt: now},
-	})
+		testutil.NewTaskBuilder().WithID("proj2-todo").WithProjectID("proj-2").WithTitle("delta").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj2-done").WithProjectID("proj-2").WithTitle("epsilon").WithStatus(domain.StatusDone).WithAssigneeID(user2).WithCreatedAt(now).Build(),
+	)
 
 	query, err := domain.NewTaskQuery(domain.WithStatusFilter("todo"), domain.WithLimit(10))
 	if err != nil {
@@ -287,13 +290,13 @@ func TestSQLTaskRepository_FindByProjectID_Filter_Status_Multiple(t *testing.T)
 	now := time.Now().UTC()
 	user1 := "user-1"
 
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "proj1-todo", ProjectID: "proj-1", Title: "alpha", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-inprogress", ProjectID: "proj-1", Title: "beta", Status: "in_progress", Priority: "medium", AssigneeID: nil, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-done", ProjectID: "proj-1", Title: "gamma", Status: "done", Priority: "low", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj2-todo", ProjectID: "proj-2", Title: "delta", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj2-done", ProjectID: "proj-2", Title: "epsilon", Status: "done", Priority: "medium", AssigneeID: nil, CreatedAt: now, UpdatedAt: now},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("proj1-todo").WithTitle("alpha").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-inprogress").WithTitle("beta").WithStatus(domain.StatusInProgress).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-done").WithTitle("gamma").WithStatus(domain.StatusDone).WithPriority(domain.PriorityLow).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj2-todo").WithProjectID("proj-2").WithTitle("delta").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj2-done").WithProjectID("proj-2").WithTitle("epsilon").WithStatus(domain.StatusDone).WithCreatedAt(now).Build(),
+	)
 
 	query, err := domain.NewTaskQuery(domain.WithStatusFilter("todo,done"), domain.WithLimit(10))
 	if err != nil {
@@ -350,12 +353,12 @@ func TestSQLTaskRepository_FindByProjectID_Filter_Priority_Single(t *testing.T)
 	now := time.Now().UTC()
 	user1 := "user-1"
 
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "proj1-high", ProjectID: "proj-1", Title: "alpha", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-medium", ProjectID: "proj-1", Title: "beta", Status: "todo", Priority: "medium", AssigneeID: nil, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-low", ProjectID: "proj-1", Title: "gamma", Status: "todo", Priority: "low", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj2-high", ProjectID: "proj-2", Title: "delta", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("proj1-high").WithTitle("alpha").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-medium").WithTitle("beta").WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-low").WithTitle("gamma").WithPriority(domain.PriorityLow).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj2-high").WithProjectID("proj-2").WithTitle("delta").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+	)
 
 	query, err := domain.NewTaskQuery(domain.WithPriorityFilter("high"), domain.WithLimit(10))
 	if err != nil {
@@ -380,12 +383,12 @@ func TestSQLTaskRepository_FindByProjectID_Filter_Priority_Multiple(t *testing.T
 	now := time.Now().UTC()
 	user1 := "user-1"
 
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "proj1-high", ProjectID: "proj-1", Title: "alpha", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-medium", ProjectID: "proj-1", Title: "beta", Status: "todo", Priority: "medium", AssigneeID: nil, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-low", ProjectID: "proj-1", Title: "gamma", Status: "todo", Priority: "low", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj2-high", ProjectID: "proj-2", Title: "delta", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("proj1-high").WithTitle("alpha").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-medium").WithTitle("beta").WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-low").WithTitle("gamma").WithPriority(domain.PriorityLow).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj2-high").WithProjectID("proj-2").WithTitle("delta").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+	)
 
 	query, err := domain.NewTaskQuery(domain.WithPriorityFilter("high,low"), domain.WithLimit(10))
 	if err != nil {
@@ -443,12 +446,12 @@ func TestSQLTaskRepository_FindByProjectID_Filter_AssigneeID(t *testing.T) {
 	user1 := "user-1"
 	user2 := "user-2"
 
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "proj1-user1", ProjectID: "proj-1", Title: "alpha", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-user2", ProjectID: "proj-1", Title: "beta", Status: "todo", Priority: "medium", AssigneeID: &user2, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-null", ProjectID: "proj-1", Title: "gamma", Status: "todo", Priority: "low", AssigneeID: nil, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj2-user1", ProjectID: "proj-2", Title: "delta", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("proj1-user1").WithTitle("alpha").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-user2").WithTitle("beta").WithAssigneeID(user2).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-null").WithTitle("gamma").WithPriority(domain.PriorityLow).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj2-user1").WithProjectID("proj-2").WithTitle("delta").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+	)
 
 	query, err := domain.NewTaskQuery(domain.WithAssigneeIDFilter("user-1"), domain.WithLimit(10))
 	if err != nil {
@@ -474,12 +477,12 @@ func TestSQLTaskRepository_FindByProjectID_Filter_AssigneeID_NilOrEmptyIgnored(t
 	user1 := "user-1"
 	user2 := "user-2"
 
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "proj1-user1", ProjectID: "proj-1", Title: "alpha", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-user2", ProjectID: "proj-1", Title: "beta", Status: "todo", Priority: "medium", AssigneeID: &user2, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-null", ProjectID: "proj-1", Title: "gamma", Status: "todo", Priority: "low", AssigneeID: nil, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj2-user1", ProjectID: "proj-2", Title: "delta", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("proj1-user1").WithTitle("alpha").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-user2").WithTitle("beta").WithAssigneeID(user2).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-null").WithTitle("gamma").WithPriority(domain.PriorityLow).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj2-user1").WithProjectID("proj-2").WithTitle("delta").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+	)
 
 	// nil の時は全件（project scope内）を返す
 	query1, err := domain.NewTaskQuery(domain.WithLimit(10))
@@ -524,17 +527,17 @@ func TestSQLTaskRepository_FindByProjectID_Filter_Combined(t *testing.T) {
 	user1 := "user-1"
 	user2 := "user-2"
 
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
+	testutil.InsertMany(t, db,
 		// proj-1: 条件に合うもの
-		{ID: "proj1-match", ProjectID: "proj-1", Title: "alpha", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-done-high-user1", ProjectID: "proj-1", Title: "beta", Status: "done", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
+		testutil.NewTaskBuilder().WithID("proj1-match").WithTitle("alpha").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-done-high-user1").WithTitle("beta").WithStatus(domain.StatusDone).WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
 		// proj-1: 条件に合わないもの
-		{ID: "proj1-todo-medium-user1", ProjectID: "proj-1", Title: "gamma", Status: "todo", Priority: "medium", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-todo-high-user2", ProjectID: "proj-1", Title: "delta", Status: "todo", Priority: "high", AssigneeID: &user2, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-inprogress-high-user1", ProjectID: "proj-1", Title: "epsilon", Status: "in_progress", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
+		testutil.NewTaskBuilder().WithID("proj1-todo-medium-user1").WithTitle("gamma").WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-todo-high-user2").WithTitle("delta").WithPriority(domain.PriorityHigh).WithAssigneeID(user2).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-inprogress-high-user1").WithTitle("epsilon").WithStatus(domain.StatusInProgress).WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
 		// proj-2: 混入防止
-		{ID: "proj2-match", ProjectID: "proj-2", Title: "zeta", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-	})
+		testutil.NewTaskBuilder().WithID("proj2-match").WithProjectID("proj-2").WithTitle("zeta").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+	)
 
 	query, err := domain.NewTaskQuery(
 		domain.WithStatusFilter("todo,done"),
@@ -566,11 +569,11 @@ func TestSQLTaskRepository_FindByProjectID_Filter_NoHit(t *testing.T) {
 	user1 := "user-1"
 	user2 := "user-2"
 
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "proj1-todo-medium-user1", ProjectID: "proj-1", Title: "alpha", Status: "todo", Priority: "medium", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-done-low-user2", ProjectID: "proj-1", Title: "beta", Status: "done", Priority: "low", AssigneeID: &user2, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj2-todo-high-user1", ProjectID: "proj-2", Title: "gamma", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("proj1-todo-medium-user1").WithTitle("alpha").WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-done-low-user2").WithTitle("beta").WithStatus(domain.StatusDone).WithPriority(domain.PriorityLow).WithAssigneeID(user2).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj2-todo-high-user1").WithProjectID("proj-2").WithTitle("gamma").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+	)
 
 	query, err := domain.NewTaskQuery(
 		domain.WithStatusFilter("todo"),
@@ -607,12 +610,12 @@ func TestSQLTaskRepository_FindByProjectID_Search_Title_Partial(t *testing.T) {
 	now := time.Now().UTC()
 	user1 := "user-1"
 
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "proj1-alpha", ProjectID: "proj-1", Title: "alpha", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-ALPHA", ProjectID: "proj-1", Title: "ALPHA", Status: "todo", Priority: "medium", AssigneeID: nil, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-beta", ProjectID: "proj-1", Title: "beta", Status: "todo", Priority: "low", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj2-alpha", ProjectID: "proj-2", Title: "alpha", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("proj1-alpha").WithTitle("alpha").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-ALPHA").WithTitle("ALPHA").WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-beta").WithTitle("beta").WithPriority(domain.PriorityLow).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj2-alpha").WithProjectID("proj-2").WithTitle("alpha").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+	)
 
 	query, err := domain.NewTaskQuery(domain.WithQueryFilter("alp"), domain.WithLimit(10))
 	if err != nil {
@@ -638,11 +641,11 @@ func TestSQLTaskRepository_FindByProjectID_Search_MinLength_1(t *testing.T) {
 	now := time.Now().UTC()
 	user1 := "user-1"
 
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "proj1-alpha", ProjectID: "proj-1", Title: "alpha", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-beta", ProjectID: "proj-1", Title: "beta", Status: "todo", Priority: "medium", AssigneeID: nil, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj2-alpha", ProjectID: "proj-2", Title: "alpha", Status: "todo", Priority: "low", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("proj1-alpha").WithTitle("alpha").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-beta").WithTitle("beta").WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj2-alpha").WithProjectID("proj-2").WithTitle("alpha").WithPriority(domain.PriorityLow).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+	)
 
 	query, err := domain.NewTaskQuery(domain.WithQueryFilter("a"), domain.WithLimit(10))
 	if err != nil {
@@ -669,11 +672,11 @@ func TestSQLTaskRepository_FindByProjectID_Search_ScopedToProject(t *testing.T)
 	now := time.Now().UTC()
 	user1 := "user-1"
 
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "proj1-other", ProjectID: "proj-1", Title: "other", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj2-alpha", ProjectID: "proj-2", Title: "alpha", Status: "todo", Priority: "medium", AssigneeID: nil, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj2-alpha2", ProjectID: "proj-2", Title: "alpha task", Status: "todo", Priority: "low", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("proj1-other").WithTitle("other").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj2-alpha").WithProjectID("proj-2").WithTitle("alpha").WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj2-alpha2").WithProjectID("proj-2").WithTitle("alpha task").WithPriority(domain.PriorityLow).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+	)
 
 	query, err := domain.NewTaskQuery(domain.WithQueryFilter("alpha"), domain.WithLimit(10))
 	if err != nil {
@@ -701,11 +704,11 @@ func TestSQLTaskRepository_FindByProjectID_Search_SpecialCharacters(t *testing.T
 	now := time.Now().UTC()
 	user1 := "user-1"
 
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "proj1-special1", ProjectID: "proj-1", Title: "x'y", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-special2", ProjectID: "proj-1", Title: "100% legit", Status: "todo", Priority: "medium", AssigneeID: nil, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj2-special", ProjectID: "proj-2", Title: "x'y", Status: "todo", Priority: "low", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("proj1-special1").WithTitle("x'y").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-special2").WithTitle("100% legit").WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj2-special").WithProjectID("proj-2").WithTitle("x'y").WithPriority(domain.PriorityLow).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+	)
 
 	// "x'" で検索（特殊文字が含まれる）
 	query1, err := domain.NewTaskQuery(domain.WithQueryFilter("x'"), domain.WithLimit(10))
@@ -751,12 +754,12 @@ func TestSQLTaskRepository_FindByProjectID_Limit_1(t *testing.T) {
 	user1 := "user-1"
 
 	// createdAt を 3件で差が出るようにする（now, now+1s, now+2s）
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "proj1-1", ProjectID: "proj-1", Title: "alpha", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: base, UpdatedAt: base},
-		{ID: "proj1-2", ProjectID: "proj-1", Title: "beta", Status: "todo", Priority: "medium", AssigneeID: nil, CreatedAt: base.Add(1 * time.Second), UpdatedAt: base.Add(1 * time.Second)},
-		{ID: "proj1-3", ProjectID: "proj-1", Title: "gamma", Status: "todo", Priority: "low", AssigneeID: &user1, CreatedAt: base.Add(2 * time.Second), UpdatedAt: base.Add(2 * time.Second)},
-		{ID: "proj2-1", ProjectID: "proj-2", Title: "delta", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: base, UpdatedAt: base},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("proj1-1").WithTitle("alpha").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(base).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-2").WithTitle("beta").WithCreatedAt(base.Add(1*time.Second)).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-3").WithTitle("gamma").WithPriority(domain.PriorityLow).WithAssigneeID(user1).WithCreatedAt(base.Add(2*time.Second)).Build(),
+		testutil.NewTaskBuilder().WithID("proj2-1").WithProjectID("proj-2").WithTitle("delta").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(base).Build(),
+	)
 
 	// createdAt,asc でソートして決定的にする
 	query, err := domain.NewTaskQuery(domain.WithSort("createdAt"), domain.WithLimit(1))
@@ -788,12 +791,12 @@ func TestSQLTaskRepository_FindByProjectID_Limit_ExactCount(t *testing.T) {
 	now := time.Now().UTC()
 	user1 := "user-1"
 
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "proj1-1", ProjectID: "proj-1", Title: "alpha", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-2", ProjectID: "proj-1", Title: "beta", Status: "todo", Priority: "medium", AssigneeID: nil, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-3", ProjectID: "proj-1", Title: "gamma", Status: "todo", Priority: "low", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj2-1", ProjectID: "proj-2", Title: "delta", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("proj1-1").WithTitle("alpha").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-2").WithTitle("beta").WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-3").WithTitle("gamma").WithPriority(domain.PriorityLow).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj2-1").WithProjectID("proj-2").WithTitle("delta").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+	)
 
 	query, err := domain.NewTaskQuery(domain.WithLimit(3))
 	if err != nil {
@@ -852,12 +855,12 @@ func TestSQLTaskRepository_FindByProjectID_Security_SQLi_InQuery_DoesNotBypassFi
 	now := time.Now().UTC()
 	user1 := "user-1"
 
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "proj1-normal", ProjectID: "proj-1", Title: "normal task", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-inject", ProjectID: "proj-1", Title: "100% legit", Status: "todo", Priority: "medium", AssigneeID: nil, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj1-special", ProjectID: "proj-1", Title: "x'y", Status: "todo", Priority: "low", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj2-secret", ProjectID: "proj-2", Title: "secret", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("proj1-normal").WithTitle("normal task").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-inject").WithTitle("100% legit").WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj1-special").WithTitle("x'y").WithPriority(domain.PriorityLow).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj2-secret").WithProjectID("proj-2").WithTitle("secret").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+	)
 
 	// SQLi 攻撃文字列
 	query, err := domain.NewTaskQuery(domain.WithQueryFilter("%' OR 1=1 --"), domain.WithLimit(10))
@@ -889,10 +892,10 @@ func TestSQLTaskRepository_FindByProjectID_Security_SQLi_InAssigneeID_DoesNotByp
 	now := time.Now().UTC()
 	user1 := "user-1"
 
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "proj1-user1", ProjectID: "proj-1", Title: "alpha", Status: "todo", Priority: "high", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-		{ID: "proj2-secret", ProjectID: "proj-2", Title: "secret", Status: "todo", Priority: "medium", AssigneeID: &user1, CreatedAt: now, UpdatedAt: now},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("proj1-user1").WithTitle("alpha").WithPriority(domain.PriorityHigh).WithAssigneeID(user1).WithCreatedAt(now).Build(),
+		testutil.NewTaskBuilder().WithID("proj2-secret").WithProjectID("proj-2").WithTitle("secret").WithAssigneeID(user1).WithCreatedAt(now).Build(),
+	)
 
 	// SQLi 攻撃文字列
 	maliciousAssigneeID := "user-1' OR '1'='1"
@@ -964,13 +967,13 @@ func TestSQLTaskRepository_FindByProjectID_CursorPagination_Normal(t *testing.T)
 	secret := []byte("test-secret-key")
 
 	// 5件のタスクを作成（micro秒単位で差をつける）
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "task-001", ProjectID: "proj-1", Title: "T1", Status: "todo", Priority: "high", CreatedAt: base.Add(1 * time.Microsecond), UpdatedAt: base.Add(1 * time.Microsecond)},
-		{ID: "task-002", ProjectID: "proj-1", Title: "T2", Status: "todo", Priority: "medium", CreatedAt: base.Add(2 * time.Microsecond), UpdatedAt: base.Add(2 * time.Microsecond)},
-		{ID: "task-003", ProjectID: "proj-1", Title: "T3", Status: "todo", Priority: "low", CreatedAt: base.Add(3 * time.Microsecond), UpdatedAt: base.Add(3 * time.Microsecond)},
-		{ID: "task-004", ProjectID: "proj-1", Title: "T4", Status: "todo", Priority: "high", CreatedAt: base.Add(4 * time.Microsecond), UpdatedAt: base.Add(4 * time.Microsecond)},
-		{ID: "task-005", ProjectID: "proj-1", Title: "T5", Status: "todo", Priority: "medium", CreatedAt: base.Add(5 * time.Microsecond), UpdatedAt: base.Add(5 * time.Microsecond)},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("task-001").WithTitle("T1").WithPriority(domain.PriorityHigh).WithCreatedAt(base.Add(1*time.Microsecond)).Build(),
+		testutil.NewTaskBuilder().WithID("task-002").WithTitle("T2").WithCreatedAt(base.Add(2*time.Microsecond)).Build(),
+		testutil.NewTaskBuilder().WithID("task-003").WithTitle("T3").WithPriority(domain.PriorityLow).WithCreatedAt(base.Add(3*time.Microsecond)).Build(),
+		testutil.NewTaskBuilder().WithID("task-004").WithTitle("T4").WithPriority(domain.PriorityHigh).WithCreatedAt(base.Add(4*time.Microsecond)).Build(),
+		testutil.NewTaskBuilder().WithID("task-005").WithTitle("T5").WithCreatedAt(base.Add(5*time.Microsecond)).Build(),
+	)
 
 	// 1ページ目取得（limit=2）
 	query1, err := domain.NewTaskQuery(domain.WithLimit(2))
@@ -997,6 +1000,7 @@ func TestSQLTaskRepository_FindByProjectID_CursorPagination_Normal(t *testing.T)
 		ID:        lastTask1.ID,
 		ProjectID: "proj-1",
 		QHash:     query1.ComputeQHash("proj-1"),
+		QV:        domain.QHashVersion,
 		IssuedAt:  time.Now().Unix(),
 	}
 	cursor1, err := domain.EncodeCursor(payload1, secret)
@@ -1065,11 +1069,11 @@ func TestSQLTaskRepository_FindByProjectID_CursorPagination_TieBreaker(t *testin
 	secret := []byte("test-secret-key")
 
 	// 同じ createdAt のタスクを複数作成（id で順序が決まる）
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "task-aaa", ProjectID: "proj-1", Title: "T1", Status: "todo", Priority: "high", CreatedAt: base, UpdatedAt: base},
-		{ID: "task-bbb", ProjectID: "proj-1", Title: "T2", Status: "todo", Priority: "medium", CreatedAt: base, UpdatedAt: base},
-		{ID: "task-ccc", ProjectID: "proj-1", Title: "T3", Status: "todo", Priority: "low", CreatedAt: base, UpdatedAt: base},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("task-aaa").WithTitle("T1").WithPriority(domain.PriorityHigh).WithCreatedAt(base).Build(),
+		testutil.NewTaskBuilder().WithID("task-bbb").WithTitle("T2").WithCreatedAt(base).Build(),
+		testutil.NewTaskBuilder().WithID("task-ccc").WithTitle("T3").WithPriority(domain.PriorityLow).WithCreatedAt(base).Build(),
+	)
 
 	// 1ページ目取得（limit=2）
 	query1, err := domain.NewTaskQuery(domain.WithLimit(2))
@@ -1101,6 +1105,7 @@ func TestSQLTaskRepository_FindByProjectID_CursorPagination_TieBreaker(t *testin
 		ID:        lastTask1.ID,
 		ProjectID: "proj-1",
 		QHash:     query1.ComputeQHash("proj-1"),
+		QV:        domain.QHashVersion,
 		IssuedAt:  time.Now().Unix(),
 	}
 	cursor1, err := domain.EncodeCursor(payload1, secret)
@@ -1296,9 +1301,9 @@ func TestSQLTaskRepository_FindByProjectID_CursorPagination_Error_QueryMismatch(
 	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
 
 	// タスクを作成
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "task-001", ProjectID: "proj-1", Title: "T1", Status: "todo", Priority: "high", CreatedAt: base, UpdatedAt: base},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("task-001").WithTitle("T1").WithPriority(domain.PriorityHigh).WithCreatedAt(base).Build(),
+	)
 
 	// フィルタなしで cursor を生成
 	query1, err := domain.NewTaskQuery(domain.WithLimit(2))
@@ -1318,6 +1323,7 @@ func TestSQLTaskRepository_FindByProjectID_CursorPagination_Error_QueryMismatch(
 		ID:        lastTask1.ID,
 		ProjectID: "proj-1",
 		QHash:     query1.ComputeQHash("proj-1"), // フィルタなしの qhash
+		QV:        domain.QHashVersion,
 		IssuedAt:  time.Now().Unix(),
 	}
 	cursor1, err := domain.EncodeCursor(payload1, secret)
@@ -1352,3 +1358,211 @@ func TestSQLTaskRepository_FindByProjectID_CursorPagination_Error_QueryMismatch(
 		t.Errorf("expected error message to contain 'cursor query mismatch', got: %v", err)
 	}
 }
+
+// TestSQLTaskRepository_ListByProject はページサイズを超える件数でも全件を created_at ASC, id ASC で返すことを検証する。
+func TestSQLTaskRepository_ListByProject(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewSQLTaskRepository(db)
+	testutil.ResetTasksTable(t, db)
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// デフォルト limit（200）を超える件数 + 他プロジェクトのタスク
+	const total = 205
+	seeds := make([]*domain.Task, 0, total+1)
+	expectedIDs := make([]string, 0, total)
+	for i := 0; i < total; i++ {
+		id := fmt.Sprintf("task-%03d", i)
+		seeds = append(seeds, testutil.NewTaskBuilder().WithID(id).WithCreatedAt(base.Add(time.Duration(i)*time.Minute)).Build())
+		expectedIDs = append(expectedIDs, id)
+	}
+	seeds = append(seeds, testutil.NewTaskBuilder().WithID("other-task").WithProjectID("proj-2").WithCreatedAt(base).Build())
+	testutil.InsertMany(t, db, seeds...)
+
+	tasks, err := repo.ListByProject(context.Background(), "proj-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNoProjectLeakage(t, tasks, "proj-1")
+	assertTaskIDs(t, tasks, expectedIDs)
+
+	// ページ境界をまたいでも順序が保たれること
+	for i, task := range tasks {
+		if i < len(expectedIDs) && task.ID != expectedIDs[i] {
+			t.Fatalf("order mismatch at index %d: expected %s, got %s", i, expectedIDs[i], task.ID)
+		}
+	}
+}
+
+// TestSQLTaskRepository_SaveFindUpdate は Save → FindByID → Update の往復を検証する。
+func TestSQLTaskRepository_SaveFindUpdate(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewSQLTaskRepository(db)
+	testutil.ResetTasksTable(t, db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	task, err := domain.NewTask("task-1", "proj-1", "title", "", domain.StatusTodo, domain.PriorityMedium, nil, now)
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	if err := repo.Save(ctx, task); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	got, err := repo.FindByID(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.Title != "title" || got.Description != "" || got.Status != domain.StatusTodo {
+		t.Errorf("unexpected task: %+v", got)
+	}
+
+	estimate := 3
+	due := testutil.DateYMD(2025, 2, 1)
+	got.Title = "updated"
+	got.Estimate = &estimate
+	got.DueDate = &due
+	got.RecordChange("title", "estimate", "dueDate")
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+
+	updated, err := repo.FindByID(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if updated.Title != "updated" || updated.Estimate == nil || *updated.Estimate != 3 || updated.DueDate == nil || !updated.DueDate.Equal(due) {
+		t.Errorf("unexpected updated task: %+v", updated)
+	}
+	if updated.Version != 2 || updated.FieldVersions["title"] != 2 || updated.FieldVersions["status"] != 0 {
+		t.Errorf("unexpected versions: version=%d fields=%v", updated.Version, updated.FieldVersions)
+	}
+
+	// 読み取った後に他で更新された（版数が進んだ）タスクは上書きしない
+	stale := *updated
+	updated.RecordChange("title")
+	if err := repo.Update(ctx, updated); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	stale.RecordChange("status")
+	var conflict *domain.VersionConflictError
+	if err := repo.Update(ctx, &stale); !errors.As(err, &conflict) || conflict.Current != 3 {
+		t.Errorf("expected VersionConflictError (current 3), got %v", err)
+	}
+
+	if _, err := repo.FindByID(ctx, "non-existent"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+	got.ID = "non-existent"
+	if err := repo.Update(ctx, got); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound on update, got %v", err)
+	}
+}
+
+// TestSQLTaskRepository_Number はプロジェクトごとの採番と FindByNumber を検証する。
+func TestSQLTaskRepository_Number(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewSQLTaskRepository(db)
+	testutil.ResetTasksTable(t, db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	want := map[string]int{"task-1": 1, "task-2": 1, "task-3": 2}
+	for _, tc := range []struct{ id, projectID string }{{"task-1", "proj-1"}, {"task-2", "proj-2"}, {"task-3", "proj-1"}} {
+		task, err := domain.NewTask(tc.id, tc.projectID, "title", "", domain.StatusTodo, domain.PriorityMedium, nil, now)
+		if err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+		if err := repo.Save(ctx, task); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+		if task.Number != want[tc.id] {
+			t.Errorf("%s: expected number %d, got %d", tc.id, want[tc.id], task.Number)
+		}
+	}
+
+	got, err := repo.FindByNumber(ctx, "proj-1", 2)
+	if err != nil {
+		t.Fatalf("failed to find by number: %v", err)
+	}
+	if got.ID != "task-3" || got.Number != 2 {
+		t.Errorf("unexpected task: %+v", got)
+	}
+	if _, err := repo.FindByNumber(ctx, "proj-2", 2); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
+
+// TestSQLTaskRepository_WorkspaceIsolation は別のワークスペースのタスクが取得・更新・一括操作の対象にならないことを検証する。
+func TestSQLTaskRepository_WorkspaceIsolation(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewSQLTaskRepository(db)
+	testutil.ResetTasksTable(t, db)
+	ws1 := workspace.NewContext(context.Background(), "ws-1")
+	ws2 := workspace.NewContext(context.Background(), "ws-2")
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		ctx context.Context
+		id  string
+	}{{ws1, "task-1"}, {ws2, "task-2"}} {
+		task, err := domain.NewTask(tc.id, "proj-1", "title", "", domain.StatusTodo, domain.PriorityMedium, nil, now)
+		if err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+		if err := repo.Save(tc.ctx, task); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+	}
+
+	// 一覧は自分のワークスペースのタスクだけ
+	query, err := domain.NewTaskQuery()
+	if err != nil {
+		t.Fatalf("failed to create query: %v", err)
+	}
+	got, err := repo.FindByProjectID(ws1, "proj-1", query)
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	assertTaskIDs(t, got, []string{"task-1"})
+	if got[0].WorkspaceID != "ws-1" {
+		t.Errorf("WorkspaceID = %q, want ws-1", got[0].WorkspaceID)
+	}
+
+	// 別のワークスペースのタスクは存在しないものとして扱う
+	if _, err := repo.FindByID(ws1, "task-2"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("FindByID: expected ErrTaskNotFound, got %v", err)
+	}
+	if _, err := repo.FindByNumber(ws2, "proj-1", 1); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("FindByNumber: expected ErrTaskNotFound, got %v", err)
+	}
+	other, err := repo.FindByID(ws2, "task-2")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	other.Title = "hijacked"
+	if err := repo.Update(ws1, other); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Update: expected ErrTaskNotFound, got %v", err)
+	}
+
+	// プロジェクト単位の一括操作も自分のワークスペースだけ
+	ids, err := repo.ArchiveByProject(ws1, "proj-1", now)
+	if err != nil {
+		t.Fatalf("failed to archive: %v", err)
+	}
+	if len(ids) != 1 || ids[0] != "task-1" {
+		t.Errorf("ArchiveByProject = %v, want [task-1]", ids)
+	}
+	ids, err = repo.DeleteByProject(ws2, "proj-1")
+	if err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	if len(ids) != 1 || ids[0] != "task-2" {
+		t.Errorf("DeleteByProject = %v, want [task-2]", ids)
+	}
+	if _, err := repo.FindByID(ws1, "task-1"); err != nil {
+		t.Errorf("expected task-1 to remain, got %v", err)
+	}
+}
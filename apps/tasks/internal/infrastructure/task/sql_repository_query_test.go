@@ -0,0 +1,161 @@
+package taskinfra
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+const selectTasks = "SELECT " + taskColumns + " FROM tasks"
+
+func TestSQLTaskRepository_BuildQuery(t *testing.T) {
+	repo := &SQLTaskRepository{}
+	cursorAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	assignee := "user-1"
+	q := "bug"
+	percent := "50%_off"
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		query    *domain.TaskQuery
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "no filters uses default sort",
+			query:    &domain.TaskQuery{Limit: 200},
+			wantSQL:  selectTasks + " WHERE project_id = $1 AND workspace_id = $2 AND archived_at IS NULL ORDER BY created_at ASC, id ASC LIMIT $3",
+			wantArgs: []interface{}{"proj-1", "ws-1", 201},
+		},
+		{
+			name:     "status filter",
+			query:    &domain.TaskQuery{Limit: 10, Statuses: []domain.TaskStatus{domain.StatusTodo, domain.StatusDone}},
+			wantSQL:  selectTasks + " WHERE project_id = $1 AND workspace_id = $2 AND archived_at IS NULL AND status IN ($3, $4) ORDER BY created_at ASC, id ASC LIMIT $5",
+			wantArgs: []interface{}{"proj-1", "ws-1", "todo", "done", 11},
+		},
+		{
+			name:     "priority filter",
+			query:    &domain.TaskQuery{Limit: 10, Priorities: []domain.TaskPriority{domain.PriorityHigh}},
+			wantSQL:  selectTasks + " WHERE project_id = $1 AND workspace_id = $2 AND archived_at IS NULL AND priority IN ($3) ORDER BY created_at ASC, id ASC LIMIT $4",
+			wantArgs: []interface{}{"proj-1", "ws-1", "high", 11},
+		},
+		{
+			name:     "assignee filter",
+			query:    &domain.TaskQuery{Limit: 10, AssigneeID: &assignee},
+			wantSQL:  selectTasks + " WHERE project_id = $1 AND workspace_id = $2 AND archived_at IS NULL AND assignee_id = $3 ORDER BY created_at ASC, id ASC LIMIT $4",
+			wantArgs: []interface{}{"proj-1", "ws-1", "user-1", 11},
+		},
+		{
+			name:     "dueDate range filter",
+			query:    &domain.TaskQuery{Limit: 10, DueDateFrom: &from, DueDateTo: &to},
+			wantSQL:  selectTasks + " WHERE project_id = $1 AND workspace_id = $2 AND archived_at IS NULL AND due_date >= $3::date AND due_date <= $4::date ORDER BY created_at ASC, id ASC LIMIT $5",
+			wantArgs: []interface{}{"proj-1", "ws-1", "2025-01-01", "2025-01-31", 11},
+		},
+		{
+			name:     "q filter",
+			query:    &domain.TaskQuery{Limit: 10, Query: &q},
+			wantSQL:  selectTasks + " WHERE project_id = $1 AND workspace_id = $2 AND archived_at IS NULL AND title ILIKE $3 ORDER BY created_at ASC, id ASC LIMIT $4",
+			wantArgs: []interface{}{"proj-1", "ws-1", "%bug%", 11},
+		},
+		{
+			name:     "q filter escapes wildcards",
+			query:    &domain.TaskQuery{Limit: 10, Query: &percent},
+			wantSQL:  selectTasks + " WHERE project_id = $1 AND workspace_id = $2 AND archived_at IS NULL AND title ILIKE $3 ORDER BY created_at ASC, id ASC LIMIT $4",
+			wantArgs: []interface{}{"proj-1", "ws-1", `%50\%\_off%`, 11},
+		},
+		{
+			name:     "cursor seek reuses created_at placeholder and fixes order",
+			query:    &domain.TaskQuery{Limit: 10, Cursor: &domain.TaskCursor{CreatedAt: cursorAt, ID: "task-9"}},
+			wantSQL:  selectTasks + " WHERE project_id = $1 AND workspace_id = $2 AND archived_at IS NULL AND ((created_at > $3) OR (created_at = $3 AND id > $4)) ORDER BY created_at ASC, id ASC LIMIT $5",
+			wantArgs: []interface{}{"proj-1", "ws-1", cursorAt, "task-9", 11},
+		},
+		{
+			name:     "desc cursor mirrors seek and order",
+			query:    &domain.TaskQuery{Limit: 10, Cursor: &domain.TaskCursor{CreatedAt: cursorAt, ID: "task-9", Descending: true}},
+			wantSQL:  selectTasks + " WHERE project_id = $1 AND workspace_id = $2 AND archived_at IS NULL AND ((created_at < $3) OR (created_at = $3 AND id < $4)) ORDER BY created_at DESC, id DESC LIMIT $5",
+			wantArgs: []interface{}{"proj-1", "ws-1", cursorAt, "task-9", 11},
+		},
+		{
+			name:     "createdAt desc only uses desc keyset order",
+			query:    &domain.TaskQuery{Limit: 10, SortOrders: []domain.SortOrder{{Key: "createdAt", Direction: domain.SortDirectionDESC}}},
+			wantSQL:  selectTasks + " WHERE project_id = $1 AND workspace_id = $2 AND archived_at IS NULL ORDER BY created_at DESC, id DESC LIMIT $3",
+			wantArgs: []interface{}{"proj-1", "ws-1", 11},
+		},
+		{
+			name: "cursor ignores sort",
+			query: &domain.TaskQuery{
+				Limit:      10,
+				Cursor:     &domain.TaskCursor{CreatedAt: cursorAt, ID: "task-9"},
+				SortOrders: []domain.SortOrder{{Key: "priority", Direction: domain.SortDirectionDESC}},
+			},
+			wantSQL:  selectTasks + " WHERE project_id = $1 AND workspace_id = $2 AND archived_at IS NULL AND ((created_at > $3) OR (created_at = $3 AND id > $4)) ORDER BY created_at ASC, id ASC LIMIT $5",
+			wantArgs: []interface{}{"proj-1", "ws-1", cursorAt, "task-9", 11},
+		},
+		{
+			name: "multiple sort keys",
+			query: &domain.TaskQuery{
+				Limit: 10,
+				SortOrders: []domain.SortOrder{
+					{Key: "priority", Direction: domain.SortDirectionDESC},
+					{Key: "dueDate", Direction: domain.SortDirectionASC},
+					{Key: "updatedAt", Direction: domain.SortDirectionDESC},
+				},
+			},
+			wantSQL: selectTasks + " WHERE project_id = $1 AND workspace_id = $2 AND archived_at IS NULL ORDER BY " +
+				"CASE priority WHEN 'high' THEN 3 WHEN 'medium' THEN 2 WHEN 'low' THEN 1 ELSE 0 END DESC, " +
+				"due_date ASC NULLS LAST, updated_at DESC, id ASC LIMIT $3",
+			wantArgs: []interface{}{"proj-1", "ws-1", 11},
+		},
+		{
+			name: "dueDate DESC puts nulls first",
+			query: &domain.TaskQuery{
+				Limit:      10,
+				SortOrders: []domain.SortOrder{{Key: "dueDate", Direction: domain.SortDirectionDESC}},
+			},
+			wantSQL:  selectTasks + " WHERE project_id = $1 AND workspace_id = $2 AND archived_at IS NULL ORDER BY due_date DESC NULLS FIRST, id ASC LIMIT $3",
+			wantArgs: []interface{}{"proj-1", "ws-1", 11},
+		},
+		{
+			name: "sortOrder only falls back to default",
+			query: &domain.TaskQuery{
+				Limit:      10,
+				SortOrders: []domain.SortOrder{{Key: "sortOrder", Direction: domain.SortDirectionASC}},
+			},
+			wantSQL:  selectTasks + " WHERE project_id = $1 AND workspace_id = $2 AND archived_at IS NULL ORDER BY created_at ASC, id ASC LIMIT $3",
+			wantArgs: []interface{}{"proj-1", "ws-1", 11},
+		},
+		{
+			name: "all filters with cursor",
+			query: &domain.TaskQuery{
+				Limit:       5,
+				Statuses:    []domain.TaskStatus{domain.StatusInProgress},
+				Priorities:  []domain.TaskPriority{domain.PriorityLow, domain.PriorityMedium},
+				AssigneeID:  &assignee,
+				DueDateFrom: &from,
+				DueDateTo:   &to,
+				Query:       &q,
+				Cursor:      &domain.TaskCursor{CreatedAt: cursorAt, ID: "task-9"},
+			},
+			wantSQL: selectTasks + " WHERE project_id = $1 AND workspace_id = $2 AND archived_at IS NULL AND status IN ($3) AND priority IN ($4, $5) AND assignee_id = $6" +
+				" AND due_date >= $7::date AND due_date <= $8::date AND title ILIKE $9" +
+				" AND ((created_at > $10) OR (created_at = $10 AND id > $11)) ORDER BY created_at ASC, id ASC LIMIT $12",
+			wantArgs: []interface{}{"proj-1", "ws-1", "in_progress", "low", "medium", "user-1", "2025-01-01", "2025-01-31", "%bug%", cursorAt, "task-9", 6},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs := repo.buildQuery("ws-1", "proj-1", tt.query)
+			if gotSQL != tt.wantSQL {
+				t.Errorf("SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("args mismatch:\n got: %v\nwant: %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
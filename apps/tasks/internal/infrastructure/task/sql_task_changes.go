@@ -0,0 +1,109 @@
+package taskinfra
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"teamflow-shared/workspace"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// SQLTaskChanges は tasks.change_seq と task_tombstones テーブルから差分同期の変更を返す usecase.TaskChangeFeed 実装。
+//
+// change_seq は変更したトランザクションの ID（トリガーが設定する）。上限には pg_snapshot_xmin を使い、
+// 実行中のトランザクションの変更を上限以上にする（コミットが上限より前の位置に後から現れないようにする）。
+type SQLTaskChanges struct {
+	db *pgxpool.Pool
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.TaskChangeFeed = (*SQLTaskChanges)(nil)
+
+// NewSQLTaskChanges は新しいSQLTaskChangesを生成する。
+func NewSQLTaskChanges(db *pgxpool.Pool) *SQLTaskChanges {
+	return &SQLTaskChanges{db: db}
+}
+
+// Changes は projectID のタスクの変更を (Seq, TaskID) の順に最大 limit 件返す。
+// タスクと削除の記録をそれぞれ最大 limit 件取り出し、合わせて並べ直す。
+func (r *SQLTaskChanges) Changes(ctx context.Context, projectID string, after domain.SyncPosition, until int64, limit int) ([]domain.TaskChange, int64, error) {
+	if until == 0 {
+		if err := r.db.QueryRow(ctx, "SELECT pg_snapshot_xmin(pg_current_snapshot())::text::bigint").Scan(&until); err != nil {
+			return nil, 0, fmt.Errorf("failed to get sync horizon: %w", err)
+		}
+	}
+	workspaceID := workspace.FromContext(ctx)
+
+	rows, err := r.db.Query(ctx, `
+		SELECT `+taskColumns+`, change_seq FROM tasks
+		WHERE project_id = $1 AND workspace_id = $2 AND (change_seq, id) > ($3, $4) AND change_seq < $5
+		ORDER BY change_seq, id
+		LIMIT $6
+	`, projectID, workspaceID, after.Seq, after.TaskID, until, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query task changes: %w", err)
+	}
+	changes := []domain.TaskChange{}
+	for rows.Next() {
+		var seq int64
+		t, err := scanTask(seqRow{Row: rows, seq: &seq})
+		if err != nil {
+			rows.Close()
+			return nil, 0, fmt.Errorf("failed to scan task: %w", err)
+		}
+		changes = append(changes, domain.TaskChange{Seq: seq, TaskID: t.ID, Task: t})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate task changes: %w", err)
+	}
+
+	rows, err = r.db.Query(ctx, `
+		SELECT change_seq, task_id, deleted_at FROM task_tombstones
+		WHERE project_id = $1 AND workspace_id = $2 AND (change_seq, task_id) > ($3, $4) AND change_seq < $5
+		ORDER BY change_seq, task_id
+		LIMIT $6
+	`, projectID, workspaceID, after.Seq, after.TaskID, until, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query task tombstones: %w", err)
+	}
+	deleted, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.TaskChange, error) {
+		var c domain.TaskChange
+		err := row.Scan(&c.Seq, &c.TaskID, &c.DeletedAt)
+		return c, err
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to scan task tombstones: %w", err)
+	}
+
+	changes = append(changes, deleted...)
+	domain.SortTaskChanges(changes)
+	if len(changes) > limit {
+		changes = changes[:limit]
+	}
+	return changes, until, nil
+}
+
+// PurgeTombstones は before より前に削除されたタスクの記録を消す。
+func (r *SQLTaskChanges) PurgeTombstones(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, "DELETE FROM task_tombstones WHERE deleted_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge task tombstones: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// seqRow は scanTask の列（taskColumns）の後に続く change_seq を seq に読み込む。
+type seqRow struct {
+	pgx.Row
+	seq *int64
+}
+
+func (r seqRow) Scan(dest ...any) error {
+	return r.Row.Scan(append(dest, r.seq)...)
+}
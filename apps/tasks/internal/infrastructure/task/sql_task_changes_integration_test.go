@@ -0,0 +1,102 @@
+//go:build integration
+// +build integration
+
+package taskinfra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"teamflow-shared/workspace"
+
+	domain "teamflow-tasks/internal/domain/task"
+	"teamflow-tasks/internal/testutil"
+)
+
+// TestSQLTaskChanges は作成・更新・削除がトランザクションの順序で取り出せること、
+// 上限より前に変更が後から現れないこと、Tombstone を掃除できることを検証する。
+func TestSQLTaskChanges(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetTasksTable(t, db)
+	repo := NewSQLTaskRepository(db)
+	feed := NewSQLTaskChanges(db)
+	ctx := context.Background()
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	save := func(ctx context.Context, id, projectID string) *domain.Task {
+		t.Helper()
+		task, err := domain.NewTask(id, projectID, id, "", domain.StatusTodo, domain.PriorityMedium, nil, now)
+		if err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+		if err := repo.Save(ctx, task); err != nil {
+			t.Fatalf("failed to save task: %v", err)
+		}
+		return task
+	}
+	t1 := save(ctx, "t1", "proj-1")
+	save(ctx, "t2", "proj-1")
+	save(ctx, "t3", "proj-2")
+	save(workspace.NewContext(ctx, "acme"), "t4", "proj-1")
+
+	all, until, err := feed.Changes(ctx, "proj-1", domain.SyncPosition{}, 0, 10)
+	if err != nil {
+		t.Fatalf("Changes: %v", err)
+	}
+	if len(all) != 2 || all[0].TaskID != "t1" || all[1].TaskID != "t2" || all[0].Task == nil {
+		t.Fatalf("expected [t1 t2], got %+v", all)
+	}
+	if until <= all[1].Seq {
+		t.Errorf("expected the horizon %d to be after the last change %d", until, all[1].Seq)
+	}
+
+	// 実行中のトランザクションの変更は上限以上になるため、コミット後に上限から取り出せる
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	if _, err := tx.Exec(ctx, "UPDATE tasks SET title = 'in flight' WHERE id = 't2'"); err != nil {
+		t.Fatalf("failed to update in tx: %v", err)
+	}
+	t1.Title = "updated"
+	t1.RecordChange("title")
+	if err := repo.Update(ctx, t1); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	changes, blocked, err := feed.Changes(ctx, "proj-1", domain.SyncPosition{Seq: until}, 0, 10)
+	if err != nil {
+		t.Fatalf("Changes: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no changes while an older transaction is in flight, got %+v", changes)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	changes, _, err = feed.Changes(ctx, "proj-1", domain.SyncPosition{Seq: blocked}, 0, 10)
+	if err != nil {
+		t.Fatalf("Changes: %v", err)
+	}
+	if len(changes) != 2 || changes[0].TaskID != "t2" || changes[1].TaskID != "t1" || changes[1].Task.Title != "updated" {
+		t.Fatalf("expected [t2 t1] in transaction order, got %+v", changes)
+	}
+
+	// 削除は Tombstone として返し、保持期間を過ぎたものは消す
+	if _, err := repo.DeleteByProject(ctx, "proj-1"); err != nil {
+		t.Fatalf("DeleteByProject: %v", err)
+	}
+	changes, _, err = feed.Changes(ctx, "proj-1", domain.SyncPosition{Seq: blocked}, 0, 10)
+	if err != nil {
+		t.Fatalf("Changes: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Task != nil || changes[1].Task != nil || changes[0].DeletedAt.IsZero() {
+		t.Fatalf("expected 2 tombstones, got %+v", changes)
+	}
+	if n, err := feed.PurgeTombstones(ctx, time.Now().Add(-time.Hour)); err != nil || n != 0 {
+		t.Errorf("expected no tombstones to purge, got %d, %v", n, err)
+	}
+	if n, err := feed.PurgeTombstones(ctx, time.Now().Add(time.Hour)); err != nil || n != 2 {
+		t.Errorf("expected 2 tombstones to purge, got %d, %v", n, err)
+	}
+}
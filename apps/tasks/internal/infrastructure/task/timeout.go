@@ -0,0 +1,171 @@
+package taskinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// TimeoutTaskRepository は 1 回の問い合わせごとに ctx のタイムアウトを設定する TaskRepository のデコレータ。
+//
+// 遅いフィルタ・検索がサーバーの WriteTimeout を超えてハンドラを占有しないよう、
+// タイムアウトした場合は usecase.ErrTimeout を返す（HTTP では 504）。
+// リトライと組み合わせる場合は RetryingTaskRepository の内側に置き、試行ごとに適用する。
+type TimeoutTaskRepository struct {
+	inner   usecase.TaskRepository
+	timeout time.Duration
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.TaskRepository = (*TimeoutTaskRepository)(nil)
+
+// NewTimeoutTaskRepository は新しいTimeoutTaskRepositoryを生成する。timeout <= 0 の場合はタイムアウトを設定しない。
+func NewTimeoutTaskRepository(inner usecase.TaskRepository, timeout time.Duration) *TimeoutTaskRepository {
+	return &TimeoutTaskRepository{inner: inner, timeout: timeout}
+}
+
+func (r *TimeoutTaskRepository) do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if r.timeout <= 0 {
+		return fn(ctx)
+	}
+	qctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return translateTimeout(ctx, fn(qctx))
+}
+
+// translateTimeout はタイムアウトによる失敗を usecase.ErrTimeout でラップする。
+//
+// 対象:
+//   - context.DeadlineExceeded（ctx のタイムアウト）
+//   - 57014 query_canceled（statement_timeout、またはタイムアウトによるキャンセル要求）
+//
+// 呼び出し元の ctx がキャンセルされた場合（クライアント切断など）はタイムアウトとして扱わない。
+func translateTimeout(parent context.Context, err error) error {
+	if err == nil || errors.Is(parent.Err(), context.Canceled) {
+		return err
+	}
+	var pgErr *pgconn.PgError
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &pgErr) && pgErr.Code == "57014") {
+		return fmt.Errorf("%w: %w", usecase.ErrTimeout, err)
+	}
+	return err
+}
+
+// Save はタスクを保存する。
+func (r *TimeoutTaskRepository) Save(ctx context.Context, t *domain.Task) error {
+	return r.do(ctx, func(ctx context.Context) error {
+		return r.inner.Save(ctx, t)
+	})
+}
+
+// Update は既存タスクを更新する。
+func (r *TimeoutTaskRepository) Update(ctx context.Context, t *domain.Task) error {
+	return r.do(ctx, func(ctx context.Context) error {
+		return r.inner.Update(ctx, t)
+	})
+}
+
+// FindByID はIDを指定してタスクを取得する。
+func (r *TimeoutTaskRepository) FindByID(ctx context.Context, id string) (*domain.Task, error) {
+	var out *domain.Task
+	err := r.do(ctx, func(ctx context.Context) error {
+		var err error
+		out, err = r.inner.FindByID(ctx, id)
+		return err
+	})
+	return out, err
+}
+
+// FindByNumber はプロジェクト内のタスク番号を指定してタスクを取得する。
+func (r *TimeoutTaskRepository) FindByNumber(ctx context.Context, projectID string, number int) (*domain.Task, error) {
+	var out *domain.Task
+	err := r.do(ctx, func(ctx context.Context) error {
+		var err error
+		out, err = r.inner.FindByNumber(ctx, projectID, number)
+		return err
+	})
+	return out, err
+}
+
+// ListByProject は指定されたprojectIDのタスク一覧を返す。
+func (r *TimeoutTaskRepository) ListByProject(ctx context.Context, projectID string) ([]*domain.Task, error) {
+	var out []*domain.Task
+	err := r.do(ctx, func(ctx context.Context) error {
+		var err error
+		out, err = r.inner.ListByProject(ctx, projectID)
+		return err
+	})
+	return out, err
+}
+
+// FindByProjectID は指定されたprojectIDとQuery Objectに基づいてタスクを取得する。
+func (r *TimeoutTaskRepository) FindByProjectID(ctx context.Context, projectID string, query *domain.TaskQuery) ([]*domain.Task, error) {
+	var out []*domain.Task
+	err := r.do(ctx, func(ctx context.Context) error {
+		var err error
+		out, err = r.inner.FindByProjectID(ctx, projectID, query)
+		return err
+	})
+	return out, err
+}
+
+// ProjectVersion はプロジェクトのタスク一覧のバージョンを返す。
+func (r *TimeoutTaskRepository) ProjectVersion(ctx context.Context, projectID string) (domain.ProjectVersion, error) {
+	var out domain.ProjectVersion
+	err := r.do(ctx, func(ctx context.Context) error {
+		var err error
+		out, err = r.inner.ProjectVersion(ctx, projectID)
+		return err
+	})
+	return out, err
+}
+
+// ArchiveByProject は projectID のタスクをアーカイブする。
+func (r *TimeoutTaskRepository) ArchiveByProject(ctx context.Context, projectID string, archivedAt time.Time) ([]string, error) {
+	var out []string
+	err := r.do(ctx, func(ctx context.Context) error {
+		var err error
+		out, err = r.inner.ArchiveByProject(ctx, projectID, archivedAt)
+		return err
+	})
+	return out, err
+}
+
+// UnarchiveByProject は projectID のアーカイブされたタスクを戻す。
+func (r *TimeoutTaskRepository) UnarchiveByProject(ctx context.Context, projectID string) ([]string, error) {
+	var out []string
+	err := r.do(ctx, func(ctx context.Context) error {
+		var err error
+		out, err = r.inner.UnarchiveByProject(ctx, projectID)
+		return err
+	})
+	return out, err
+}
+
+// DeleteByProject は projectID のタスクを削除する。
+func (r *TimeoutTaskRepository) DeleteByProject(ctx context.Context, projectID string) ([]string, error) {
+	var out []string
+	err := r.do(ctx, func(ctx context.Context) error {
+		var err error
+		out, err = r.inner.DeleteByProject(ctx, projectID)
+		return err
+	})
+	return out, err
+}
+
+// MoveIncompleteSprintTasks はスプリントの未完了のタスクを移す。
+func (r *TimeoutTaskRepository) MoveIncompleteSprintTasks(ctx context.Context, projectID, fromSprintID string, toSprintID *string, actorID string, now time.Time) ([]string, error) {
+	var out []string
+	err := r.do(ctx, func(ctx context.Context) error {
+		var err error
+		out, err = r.inner.MoveIncompleteSprintTasks(ctx, projectID, fromSprintID, toSprintID, actorID, now)
+		return err
+	})
+	return out, err
+}
@@ -0,0 +1,76 @@
+package taskinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// slowRepo は ctx が終わるまで FindByProjectID をブロックする TaskRepository（タイムアウトの確認用）。
+type slowRepo struct{ MemoryTaskRepository }
+
+func (*slowRepo) FindByProjectID(ctx context.Context, _ string, _ *domain.TaskQuery) ([]*domain.Task, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestTranslateTimeout(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name        string
+		parent      context.Context
+		err         error
+		wantTimeout bool
+	}{
+		{name: "nil", parent: context.Background(), err: nil, wantTimeout: false},
+		{name: "deadline exceeded", parent: context.Background(), err: context.DeadlineExceeded, wantTimeout: true},
+		{name: "statement timeout", parent: context.Background(), err: &pgconn.PgError{Code: "57014"}, wantTimeout: true},
+		{name: "other pg error", parent: context.Background(), err: &pgconn.PgError{Code: "23505"}, wantTimeout: false},
+		{name: "client canceled", parent: canceled, err: &pgconn.PgError{Code: "57014"}, wantTimeout: false},
+		{name: "not found", parent: context.Background(), err: ErrTaskNotFound, wantTimeout: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := translateTimeout(tt.parent, tt.err)
+			if errors.Is(got, usecase.ErrTimeout) != tt.wantTimeout {
+				t.Errorf("translateTimeout() = %v, want timeout=%v", got, tt.wantTimeout)
+			}
+			if tt.err != nil && !errors.Is(got, tt.err) {
+				t.Errorf("expected original error to be preserved, got %v", got)
+			}
+		})
+	}
+}
+
+func TestTimeoutTaskRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("slow query returns ErrTimeout", func(t *testing.T) {
+		repo := NewTimeoutTaskRepository(&slowRepo{}, 10*time.Millisecond)
+
+		start := time.Now()
+		_, err := repo.FindByProjectID(ctx, "proj-1", nil)
+		if !errors.Is(err, usecase.ErrTimeout) {
+			t.Fatalf("expected ErrTimeout, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("expected query to be aborted quickly, took %v", elapsed)
+		}
+	})
+
+	t.Run("zero timeout is disabled", func(t *testing.T) {
+		repo := NewTimeoutTaskRepository(NewMemoryTaskRepository(), 0)
+		if _, err := repo.FindByID(ctx, "missing"); !errors.Is(err, ErrTaskNotFound) {
+			t.Errorf("expected ErrTaskNotFound, got %v", err)
+		}
+	})
+}
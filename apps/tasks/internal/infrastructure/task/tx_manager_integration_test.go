@@ -0,0 +1,61 @@
+//go:build integration
+// +build integration
+
+package taskinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"teamflow-tasks/internal/testutil"
+)
+
+// TestPgxTxManager_WithinTx は fn の成否に応じて Commit / Rollback されることを検証する。
+func TestPgxTxManager_WithinTx(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewSQLTaskRepository(db)
+	txm := NewPgxTxManager(db)
+	now := time.Now().UTC()
+
+	insert := func(ctx context.Context, id string) error {
+		_, err := repo.conn(ctx).Exec(ctx,
+			`INSERT INTO tasks (id, project_id, title, status, priority, created_at, updated_at) VALUES ($1, 'proj-1', $1, 'todo', 'medium', $2, $2)`,
+			id, now)
+		return err
+	}
+
+	tests := []struct {
+		name      string
+		fnErr     error
+		wantCount int
+	}{
+		{name: "commit on success", fnErr: nil, wantCount: 1},
+		{name: "rollback on error", fnErr: errors.New("boom"), wantCount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.ResetTasksTable(t, db)
+
+			err := txm.WithinTx(context.Background(), func(ctx context.Context) error {
+				if err := insert(ctx, "task-1"); err != nil {
+					return err
+				}
+				return tt.fnErr
+			})
+			if !errors.Is(err, tt.fnErr) {
+				t.Fatalf("expected error %v, got %v", tt.fnErr, err)
+			}
+
+			tasks, err := repo.ListByProject(context.Background(), "proj-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(tasks) != tt.wantCount {
+				t.Errorf("expected %d tasks, got %d", tt.wantCount, len(tasks))
+			}
+		})
+	}
+}
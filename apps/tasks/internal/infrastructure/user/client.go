@@ -0,0 +1,76 @@
+package userinfra
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"teamflow-shared/client"
+	"teamflow-shared/requestid"
+
+	"teamflow-tasks/internal/usecase/notification"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// defaultClientTimeout は users サービスへの 1 回のリクエストのタイムアウト。
+const defaultClientTimeout = 3 * time.Second
+
+// Client は users サービスの HTTP API クライアント（teamflow-shared/client のラッパー）。
+// UserDirectory と notification.RecipientDirectory（POST /api/v1/users:lookup）を実装する。
+type Client struct {
+	api *client.Client
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var (
+	_ usecase.UserDirectory           = (*Client)(nil)
+	_ notification.RecipientDirectory = (*Client)(nil)
+)
+
+// NewClient は baseURL（例: http://users:8082）の users サービスに接続する Client を生成する。
+// httpClient が nil の場合はタイムアウト付きで、リクエスト ID（X-Request-ID）を引き継ぐ既定のクライアントを使う。
+// users:lookup はサービス間専用のため、users サービスが SERVICE_API_KEYS を設定している場合は
+// httpClient の Transport に serviceauth.Transport を含めること。
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultClientTimeout, Transport: &requestid.Transport{}}
+	}
+	return &Client{api: client.New(baseURL, httpClient)}
+}
+
+// LookupUsers は ids のユーザーをまとめて取得する。存在しない ID は戻り値に含めない。
+func (c *Client) LookupUsers(ctx context.Context, ids []string) (map[string]usecase.UserProfile, error) {
+	res, err := c.api.LookupUsers(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("users client: %w", err)
+	}
+	users := make(map[string]usecase.UserProfile, len(res.Users))
+	for _, u := range res.Users {
+		p := usecase.UserProfile{ID: u.ID, Name: u.Name}
+		if u.AvatarURL != nil {
+			p.AvatarURL = *u.AvatarURL
+		}
+		users[u.ID] = p
+	}
+	return users, nil
+}
+
+// LookupRecipients は ids のユーザーのメールアドレスと通知の設定をまとめて取得する。存在しない ID は戻り値に含めない。
+func (c *Client) LookupRecipients(ctx context.Context, ids []string) (map[string]notification.Recipient, error) {
+	res, err := c.api.LookupUsersWithPreferences(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("users client: %w", err)
+	}
+	recipients := make(map[string]notification.Recipient, len(res.Users))
+	for _, u := range res.Users {
+		// 通知の設定が無い場合（古い users サービス）はすべて受け取る既定の設定として扱う
+		r := notification.Recipient{ID: u.ID, Name: u.Name, Email: u.Email, EmailOnAssignment: true, EmailOnDueSoon: true}
+		if p := u.NotificationPreferences; p != nil {
+			r.EmailOnAssignment = p.EmailOnAssignment
+			r.EmailOnDueSoon = p.EmailOnDueSoon
+		}
+		recipients[u.ID] = r
+	}
+	return recipients, nil
+}
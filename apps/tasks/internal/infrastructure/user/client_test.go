@@ -0,0 +1,77 @@
+package userinfra_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"teamflow-shared/serviceauth"
+
+	userinfra "teamflow-tasks/internal/infrastructure/user"
+)
+
+func TestClient_LookupUsers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/users:lookup" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get(serviceauth.Header); got != "s3cr3t" {
+			t.Errorf("%s = %q, want s3cr3t", serviceauth.Header, got)
+		}
+		var req struct {
+			IDs []string `json:"ids"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if len(req.IDs) != 2 {
+			t.Errorf("ids = %v", req.IDs)
+		}
+		_, _ = w.Write([]byte(`{"users":[{"id":"user-1","name":"Taro","email":"taro@example.com","avatarUrl":"https://example.com/a.png"}],"missingIds":["user-2"]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := userinfra.NewClient(srv.URL, &http.Client{Transport: &serviceauth.Transport{Key: "s3cr3t"}})
+	got, err := client.LookupUsers(context.Background(), []string{"user-1", "user-2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got["user-1"].Name != "Taro" || got["user-1"].AvatarURL != "https://example.com/a.png" {
+		t.Errorf("unexpected users: %+v", got)
+	}
+}
+
+func TestClient_LookupUsersError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(srv.Close)
+
+	if _, err := userinfra.NewClient(srv.URL, nil).LookupUsers(context.Background(), []string{"user-1"}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestClient_LookupRecipients(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/users:lookup" || r.URL.Query().Get("expand") != "notificationPreferences" {
+			t.Errorf("request = %s %s", r.Method, r.URL)
+		}
+		_, _ = w.Write([]byte(`{"users":[` +
+			`{"id":"user-1","name":"Taro","email":"taro@example.com","avatarUrl":null,"notificationPreferences":{"emailOnAssignment":false,"emailOnDueSoon":true,"updatedAt":null}},` +
+			`{"id":"user-2","name":"Jiro","email":"jiro@example.com","avatarUrl":null}],"missingIds":[]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	got, err := userinfra.NewClient(srv.URL, nil).LookupRecipients(context.Background(), []string{"user-1", "user-2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r := got["user-1"]; r.Email != "taro@example.com" || r.EmailOnAssignment || !r.EmailOnDueSoon {
+		t.Errorf("unexpected recipient: %+v", r)
+	}
+	// 通知の設定が無い場合はすべて受け取る
+	if r := got["user-2"]; r.Name != "Jiro" || !r.EmailOnAssignment || !r.EmailOnDueSoon {
+		t.Errorf("unexpected recipient: %+v", r)
+	}
+}
@@ -0,0 +1,135 @@
+// Package grpc は tasks サービスの gRPC API（teamflow-shared/proto/tasks/v1 の TaskService）を提供する。
+//
+// サービス間の呼び出し用に、HTTP の API（internal/interface/http）と同じユースケースを gRPC で公開する。
+// 呼び出し元の認証・ワークスペース・操作者は HTTP のヘッダと同じ値をメタデータで受け取る。
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"teamflow-shared/authz"
+	tasksv1 "teamflow-shared/proto/tasks/v1"
+	"teamflow-shared/serviceauth"
+	"teamflow-shared/workspace"
+)
+
+// メタデータのキー（HTTP のヘッダ名の小文字）。
+var (
+	metadataServiceKey = strings.ToLower(serviceauth.Header)
+	metadataWorkspace  = strings.ToLower(workspace.Header)
+	metadataActor      = strings.ToLower(authz.ActorHeader)
+)
+
+// NewServer は svc を登録した gRPC サーバーを生成する。
+//
+// すべての呼び出しで次を行ってから svc を呼ぶ。
+//   - auth にキーが設定されていれば x-service-key を検証する（無い・一致しない場合は UNAUTHENTICATED、
+//     レート制限を超えた場合は RESOURCE_EXHAUSTED）
+//   - x-workspace-id を context のワークスペースにする（形式が不正な場合は INVALID_ARGUMENT）
+//   - panic を回復して INTERNAL を返す
+func NewServer(svc *TaskService, auth *serviceauth.Authenticator) *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(recoverUnary, authenticateUnary(auth)),
+		grpc.ChainStreamInterceptor(recoverStream, authenticateStream(auth)),
+	)
+	tasksv1.RegisterTaskServiceServer(srv, svc)
+	return srv
+}
+
+// authenticate はメタデータのサービス API キーを検証し、呼び出し元のサービスとワークスペースを設定した context を返す。
+func authenticate(ctx context.Context, auth *serviceauth.Authenticator) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	name, err := auth.Authenticate(firstValue(md, metadataServiceKey))
+	switch {
+	case errors.Is(err, serviceauth.ErrRateLimited):
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Unauthenticated, metadataServiceKey+" metadata is missing or invalid")
+	}
+	if name != "" {
+		ctx = serviceauth.ContextWithService(ctx, name)
+	}
+
+	id, err := workspace.Parse(firstValue(md, metadataWorkspace))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, metadataWorkspace+" metadata is invalid")
+	}
+	return workspace.NewContext(ctx, id), nil
+}
+
+func authenticateUnary(auth *serviceauth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, auth)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func authenticateStream(auth *serviceauth.Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), auth)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// serverStream は Context を差し替えた grpc.ServerStream。
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// recoverUnary はハンドラの panic をログに出力して INTERNAL を返す（server.New の panic の回復と同じ）。
+func recoverUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = recovered(ctx, info.FullMethod, v)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func recoverStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = recovered(ss.Context(), info.FullMethod, v)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+func recovered(ctx context.Context, method string, v any) error {
+	slog.ErrorContext(ctx, "panic recovered", "method", method, "panic", v, "stack", string(debug.Stack()))
+	return status.Error(codes.Internal, "internal error")
+}
+
+// actorID は呼び出しの操作者（x-user-id メタデータ）を返す。未設定の場合は空文字。
+func actorID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return strings.TrimSpace(firstValue(md, metadataActor))
+}
+
+// firstValue はメタデータの key の最初の値を返す。無い場合は空文字。
+func firstValue(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
@@ -0,0 +1,483 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"teamflow-shared/clock"
+	tasksv1 "teamflow-shared/proto/tasks/v1"
+	"teamflow-shared/uuidpolicy"
+	"teamflow-shared/workspace"
+
+	"teamflow-tasks/internal/broadcast"
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// TaskService は tasksv1.TaskServiceServer の実装。HTTP のハンドラと同じユースケースを呼び出す。
+type TaskService struct {
+	tasksv1.UnimplementedTaskServiceServer
+
+	Create      *usecase.CreateTaskUsecase
+	List        *usecase.ListTasksByProjectUsecase
+	Update      *usecase.UpdateTaskUsecase
+	GetByNumber *usecase.GetTaskByNumberUsecase
+	// Broker は WatchTasks で購読するタスクの変更イベントの配信元
+	Broker *broadcast.Broker
+	// Access は WatchTasks で購読の前に操作者がプロジェクトを閲覧できるかの確認に使う。任意。nil の場合は確認しない
+	Access usecase.ProjectAccessChecker
+	// Shutdown はサーバーの停止（graceful shutdown の開始）で閉じるチャネル（server.Drain の Done）。任意。
+	// 閉じると WatchTasks は UNAVAILABLE で終わり、呼び出し元に別のインスタンスへの再接続を促す。nil の場合は呼び出し元がキャンセルするまで送る
+	Shutdown <-chan struct{}
+	Clock    clock.Clock
+	// CursorSecret は ListTasks の page_token（HTTP の cursor と同じ形式）の署名に使う
+	CursorSecret []byte
+	// PageLimits は ListTasks の page_size の既定値と上限。任意。ゼロ値の場合は domain.DefaultPageLimits
+	PageLimits domain.PageLimits
+}
+
+// CreateTask はタスクを作成する。
+func (s *TaskService) CreateTask(ctx context.Context, req *tasksv1.CreateTaskRequest) (*tasksv1.Task, error) {
+	taskStatus, err := domain.ParseStatus(req.GetStatus())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// priority の省略はプロジェクト設定の既定値に任せる（既定値も無ければ Usecase のバリデーションで INVALID_ARGUMENT）
+	var priority domain.TaskPriority
+	if req.GetPriority() != "" {
+		priority, err = domain.ParsePriority(req.GetPriority())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	taskID := req.GetId()
+	if taskID == "" {
+		taskID = uuid.New().String()
+	}
+
+	t, err := s.Create.Execute(ctx, usecase.CreateTaskInput{
+		ID:          taskID,
+		ProjectID:   req.GetProjectId(),
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		Status:      taskStatus,
+		Priority:    priority,
+		AssigneeID:  req.GetAssigneeId(),
+		MilestoneID: req.GetMilestoneId(),
+		SprintID:    req.GetSprintId(),
+		EpicID:      req.GetEpicId(),
+		LabelIDs:    req.GetLabelIds(),
+		ActorID:     actorID(ctx),
+		Now:         s.Clock.Now(),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toTask(t, ""), nil
+}
+
+// GetTaskByNumber はプロジェクト内のタスク番号でタスクを取得する。
+func (s *TaskService) GetTaskByNumber(ctx context.Context, req *tasksv1.GetTaskByNumberRequest) (*tasksv1.Task, error) {
+	t, err := s.GetByNumber.Execute(ctx, req.GetProjectId(), int(req.GetNumber()), actorID(ctx))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toTask(t, ""), nil
+}
+
+// ListTasks はプロジェクトのタスクを絞り込んで取得する。次のページがあれば next_page_token を返す。
+func (s *TaskService) ListTasks(ctx context.Context, req *tasksv1.ListTasksRequest) (*tasksv1.ListTasksResponse, error) {
+	projectID := req.GetProjectId()
+	if projectID == "" {
+		return nil, status.Error(codes.InvalidArgument, "project_id is required")
+	}
+	query, err := s.taskQuery(ctx, projectID, req)
+	if err != nil {
+		return nil, err
+	}
+	tasks, token, err := s.listTasks(ctx, projectID, query)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &tasksv1.ListTasksResponse{Tasks: s.toTasks(ctx, tasks), NextPageToken: token}, nil
+}
+
+// maxBatchListProjects は BatchListTasks で一度に取得できるプロジェクトの最大数（projects サービスの一覧の最大件数と合わせる）。
+const maxBatchListProjects = usecase.MaxBatchStatsProjects
+
+// BatchListTasks は複数のプロジェクトのタスクを同じ条件で取得する。
+// 存在しない・操作者が閲覧できないプロジェクトはエラーにせず結果から除く（一覧の一部が見えないだけで全体を失敗させない）。
+func (s *TaskService) BatchListTasks(ctx context.Context, req *tasksv1.BatchListTasksRequest) (*tasksv1.BatchListTasksResponse, error) {
+	projectIDs := req.GetProjectIds()
+	if len(projectIDs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "project_ids is required")
+	}
+	if len(projectIDs) > maxBatchListProjects {
+		return nil, status.Errorf(codes.InvalidArgument, "project_ids must not exceed %d", maxBatchListProjects)
+	}
+	filter := req.GetFilter()
+	if filter.GetPageToken() != "" {
+		return nil, status.Error(codes.InvalidArgument, "filter.page_token is not supported; use ListTasks for the following pages")
+	}
+
+	type projectTasks struct {
+		projectID string
+		tasks     []*domain.Task
+		token     string
+	}
+	results := make([]projectTasks, 0, len(projectIDs))
+	var all []*domain.Task
+	seen := make(map[string]bool, len(projectIDs))
+	for _, projectID := range projectIDs {
+		if projectID == "" {
+			return nil, status.Error(codes.InvalidArgument, "project_ids must not contain empty values")
+		}
+		if seen[projectID] {
+			continue
+		}
+		seen[projectID] = true
+
+		// フィルタは全プロジェクトで同じだが、qhash（page_token の検証）がプロジェクトごとに異なるため毎回組み立てる
+		query, err := s.taskQuery(ctx, projectID, filter)
+		if err != nil {
+			return nil, err
+		}
+		tasks, token, err := s.listTasks(ctx, projectID, query)
+		switch {
+		case errors.Is(err, usecase.ErrProjectNotFound), errors.Is(err, usecase.ErrForbidden):
+			continue
+		case err != nil:
+			return nil, toStatus(ctx, err)
+		}
+		results = append(results, projectTasks{projectID: projectID, tasks: tasks, token: token})
+		all = append(all, tasks...)
+	}
+
+	// 担当者名はすべてのプロジェクトのタスクについて 1 回で取得する
+	names := s.assigneeNames(ctx, all)
+	resp := &tasksv1.BatchListTasksResponse{Projects: make([]*tasksv1.ProjectTasks, 0, len(results))}
+	for _, r := range results {
+		resp.Projects = append(resp.Projects, &tasksv1.ProjectTasks{
+			ProjectId:     r.projectID,
+			Tasks:         toTasksWithNames(r.tasks, names),
+			NextPageToken: r.token,
+		})
+	}
+	return resp, nil
+}
+
+// taskQuery は ListTasksRequest のフィルタから projectID のタスクの検索条件を組み立てる。
+// 不正な値は INVALID_ARGUMENT のステータスを返す。
+func (s *TaskService) taskQuery(ctx context.Context, projectID string, req *tasksv1.ListTasksRequest) (*domain.TaskQuery, error) {
+	if req.GetPageToken() != "" && req.GetSort() != "" {
+		return nil, status.Error(codes.InvalidArgument, domain.ErrSortIncompatibleWithCursor.Error())
+	}
+
+	opts := []domain.TaskQueryOption{}
+	if len(req.GetStatuses()) > 0 {
+		opts = append(opts, domain.WithStatusFilter(strings.Join(req.GetStatuses(), ",")))
+	}
+	if len(req.GetPriorities()) > 0 {
+		opts = append(opts, domain.WithPriorityFilter(strings.Join(req.GetPriorities(), ",")))
+	}
+	if assigneeID := req.GetAssigneeId(); assigneeID != "" {
+		if !uuidpolicy.Any.Valid(assigneeID) {
+			return nil, status.Error(codes.InvalidArgument, "assignee_id must be a valid UUID")
+		}
+		opts = append(opts, domain.WithAssigneeIDFilter(assigneeID))
+	}
+	if milestoneID := req.GetMilestoneId(); milestoneID != "" {
+		opts = append(opts, domain.WithMilestoneIDFilter(milestoneID))
+	}
+	if sprintID := req.GetSprintId(); sprintID != "" {
+		opts = append(opts, domain.WithSprintIDFilter(sprintID))
+	}
+	if epicID := req.GetEpicId(); epicID != "" {
+		opts = append(opts, domain.WithEpicIDFilter(epicID))
+	}
+	if req.GetDueDateFrom() != "" || req.GetDueDateTo() != "" {
+		opts = append(opts, domain.WithDueDateRangeFilter(req.GetDueDateFrom(), req.GetDueDateTo()))
+	}
+	if q := req.GetQuery(); q != "" {
+		opts = append(opts, domain.WithQueryFilter(q))
+	}
+	if sort := req.GetSort(); sort != "" {
+		opts = append(opts, domain.WithSort(sort))
+	}
+	// ワークスペース（qhash に含めるため page_token より前に指定する）
+	opts = append(opts, domain.WithWorkspace(workspace.FromContext(ctx)))
+	if token := req.GetPageToken(); token != "" {
+		opts = append(opts, domain.WithCursor(token, projectID, s.CursorSecret, s.Clock.Now()))
+	}
+	opts = append(opts, domain.WithPageLimits(s.PageLimits), domain.WithLimit(int(req.GetPageSize())))
+
+	query, err := domain.NewTaskQuery(opts...)
+	if err == nil {
+		err = query.Validate()
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return query, nil
+}
+
+// listTasks は projectID のタスクを query で取得し、次のページがあればそのトークンも返す。
+func (s *TaskService) listTasks(ctx context.Context, projectID string, query *domain.TaskQuery) ([]*domain.Task, string, error) {
+	tasks, err := s.List.ExecuteWithQuery(ctx, usecase.ListTasksByProjectWithQueryInput{
+		ProjectID: projectID,
+		Query:     query,
+		ActorID:   actorID(ctx),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	// リポジトリは limit + 1 件取得する。limit + 1 件あれば limit 件目から次のページのトークンを作る
+	if len(tasks) <= query.Limit {
+		return tasks, "", nil
+	}
+	token, err := domain.EncodeCursor(query.NextCursorPayload(projectID, tasks[query.Limit-1], s.Clock.Now()), s.CursorSecret)
+	if err != nil {
+		return nil, "", err
+	}
+	return tasks[:query.Limit], token, nil
+}
+
+// toTasks は担当者名を付けてタスクを gRPC のメッセージに変換する。
+func (s *TaskService) toTasks(ctx context.Context, tasks []*domain.Task) []*tasksv1.Task {
+	return toTasksWithNames(tasks, s.assigneeNames(ctx, tasks))
+}
+
+// assigneeNames はタスクの担当者の表示名を返す。
+// 担当者名は表示用の補足のため、取得できない場合は警告を記録して名前なしで返す。
+func (s *TaskService) assigneeNames(ctx context.Context, tasks []*domain.Task) map[string]string {
+	names, err := s.List.AssigneeNames(ctx, tasks)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to look up assignee names", "error", err)
+	}
+	return names
+}
+
+// toTasksWithNames はタスクを gRPC のメッセージに変換する。names は担当者 ID から表示名への対応。
+func toTasksWithNames(tasks []*domain.Task, names map[string]string) []*tasksv1.Task {
+	msgs := make([]*tasksv1.Task, 0, len(tasks))
+	for _, t := range tasks {
+		var name string
+		if t.AssigneeID != nil {
+			name = names[*t.AssigneeID]
+		}
+		msgs = append(msgs, toTask(t, name))
+	}
+	return msgs
+}
+
+// UpdateTask は update_mask のフィールドを更新する。
+// optional のフィールド（assignee_id, due_date など）は update_mask に含めて値を設定しない場合に外す。
+func (s *TaskService) UpdateTask(ctx context.Context, req *tasksv1.UpdateTaskRequest) (*tasksv1.Task, error) {
+	t := req.GetTask()
+	if t.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "task.id is required")
+	}
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "update_mask must contain at least one field")
+	}
+
+	in := usecase.UpdateTaskInput{ID: t.GetId(), ProjectID: t.GetProjectId(), ActorID: actorID(ctx)}
+	var err error
+	for _, path := range paths {
+		switch path {
+		case "title":
+			title := strings.TrimSpace(t.GetTitle())
+			if title == "" {
+				return nil, status.Error(codes.InvalidArgument, "task title must not be empty")
+			}
+			in.Title = domain.Set(title)
+		case "description":
+			in.Description = domain.Set(t.GetDescription())
+		case "status":
+			// Status / Priority は Usecase 層で Parse する
+			in.Status = domain.Set(t.GetStatus())
+		case "priority":
+			in.Priority = domain.Set(t.GetPriority())
+		case "assignee_id":
+			if t.AssigneeId != nil && !uuidpolicy.Any.Valid(t.GetAssigneeId()) {
+				return nil, status.Error(codes.InvalidArgument, "assignee_id must be a valid UUID")
+			}
+			in.AssigneeID = optionalPatch(t.AssigneeId)
+		case "due_date":
+			in.DueDate = timePatch(t.GetDueDate())
+		case "start_date":
+			in.StartDate = timePatch(t.GetStartDate())
+		case "estimate":
+			in.Estimate = domain.Null[int]()
+			if t.Estimate != nil {
+				in.Estimate = domain.Set(int(t.GetEstimate()))
+			}
+		case "milestone_id":
+			if in.MilestoneID, err = idPatch(path, t.MilestoneId); err != nil {
+				return nil, err
+			}
+		case "sprint_id":
+			if in.SprintID, err = idPatch(path, t.SprintId); err != nil {
+				return nil, err
+			}
+		case "epic_id":
+			if in.EpicID, err = idPatch(path, t.EpicId); err != nil {
+				return nil, err
+			}
+		case "label_ids":
+			in.LabelIDs = domain.Null[[]string]()
+			if len(t.GetLabelIds()) > 0 {
+				in.LabelIDs = domain.Set(t.GetLabelIds())
+			}
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "update_mask contains an unknown field %q", path)
+		}
+	}
+
+	updated, err := s.Update.Execute(ctx, in)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toTask(updated, ""), nil
+}
+
+// WatchTasks はプロジェクトのタスクの変更イベントを、呼び出し元がキャンセルするまで送る。
+// サーバーの停止時（Shutdown）は UNAVAILABLE を返し、GracefulStop がストリームの終了を待ち続けないようにする。
+func (s *TaskService) WatchTasks(req *tasksv1.WatchTasksRequest, stream grpc.ServerStreamingServer[tasksv1.TaskEvent]) error {
+	ctx := stream.Context()
+	projectID := req.GetProjectId()
+	if projectID == "" {
+		return status.Error(codes.InvalidArgument, "project_id is required")
+	}
+	if s.Access != nil {
+		if err := s.Access.AuthorizeRead(ctx, projectID, actorID(ctx)); err != nil {
+			return toStatus(ctx, err)
+		}
+	}
+
+	events, cancel := s.Broker.Subscribe(workspace.FromContext(ctx), projectID)
+	defer cancel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.Shutdown:
+			return status.Error(codes.Unavailable, "server is shutting down, reconnect")
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(&tasksv1.TaskEvent{
+				Type:        e.Type,
+				WorkspaceId: e.WorkspaceID,
+				ProjectId:   e.ProjectID,
+				TaskId:      e.TaskID,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// toStatus はユースケースのエラーを gRPC のステータスに変換する（HTTP のステータスコードと対応させる）。
+func toStatus(ctx context.Context, err error) error {
+	var ve *domain.ValidationError
+	switch {
+	case errors.As(err, &ve) && ve.Code == "FIELD_FORBIDDEN":
+		return status.Error(codes.PermissionDenied, "the actor's role is not allowed to change "+ve.Field)
+	case errors.As(err, &ve), errors.Is(err, usecase.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, usecase.ErrTaskNotFound):
+		return status.Error(codes.NotFound, "task not found")
+	case errors.Is(err, usecase.ErrProjectNotFound):
+		// メンバーでない場合もプロジェクトの有無を明かさないよう NOT_FOUND にする
+		return status.Error(codes.NotFound, "project not found")
+	case errors.Is(err, usecase.ErrActorRequired):
+		return status.Error(codes.Unauthenticated, metadataActor+" metadata is required")
+	case errors.Is(err, usecase.ErrForbidden):
+		return status.Error(codes.PermissionDenied, "the actor is not allowed to access this project")
+	case errors.Is(err, usecase.ErrTimeout):
+		return status.Error(codes.DeadlineExceeded, "the query took too long")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		slog.ErrorContext(ctx, "grpc request failed", "error", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+// toTask はタスクを gRPC のメッセージに変換する。
+func toTask(t *domain.Task, assigneeName string) *tasksv1.Task {
+	msg := &tasksv1.Task{
+		Id:           t.ID,
+		ProjectId:    t.ProjectID,
+		Number:       int32(t.Number),
+		Title:        t.Title,
+		Description:  t.Description,
+		Status:       string(t.Status),
+		Priority:     string(t.Priority),
+		AssigneeId:   t.AssigneeID,
+		AssigneeName: assigneeName,
+		DueDate:      timestamp(t.DueDate),
+		StartDate:    timestamp(t.StartDate),
+		MilestoneId:  t.MilestoneID,
+		SprintId:     t.SprintID,
+		EpicId:       t.EpicID,
+		LabelIds:     t.LabelIDs,
+		CreatedAt:    timestamppb.New(t.CreatedAt),
+		UpdatedAt:    timestamppb.New(t.UpdatedAt),
+		CreatedBy:    t.CreatedBy,
+		UpdatedBy:    t.UpdatedBy,
+		ArchivedAt:   timestamp(t.ArchivedAt),
+	}
+	if t.Estimate != nil {
+		estimate := int32(*t.Estimate)
+		msg.Estimate = &estimate
+	}
+	return msg
+}
+
+// timestamp は nil を nil のまま Timestamp に変換する。
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// optionalPatch は optional のフィールドを、値が無ければ null（外す）、あれば値を設定する Patch に変換する。
+func optionalPatch(v *string) domain.Patch[string] {
+	if v == nil {
+		return domain.Null[string]()
+	}
+	return domain.Set(*v)
+}
+
+// idPatch はマイルストーン・スプリント・エピックの ID を Patch に変換する。
+// 値が無ければ null（外す）にする。空文字は外す指定と紛らわしいため INVALID_ARGUMENT を返す。
+func idPatch(field string, v *string) (domain.Patch[string], error) {
+	if v != nil && strings.TrimSpace(*v) == "" {
+		return domain.Patch[string]{}, status.Error(codes.InvalidArgument, field+" must not be empty (leave it unset to clear)")
+	}
+	return optionalPatch(v), nil
+}
+
+// timePatch は Timestamp を、nil なら null（外す）、あれば値を設定する Patch に変換する。
+func timePatch(ts *timestamppb.Timestamp) domain.Patch[time.Time] {
+	if ts == nil {
+		return domain.Null[time.Time]()
+	}
+	return domain.Set(ts.AsTime())
+}
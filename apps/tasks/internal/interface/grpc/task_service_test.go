@@ -0,0 +1,370 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"teamflow-shared/clock"
+	tasksv1 "teamflow-shared/proto/tasks/v1"
+	"teamflow-shared/serviceauth"
+
+	"teamflow-tasks/internal/broadcast"
+	taskinfra "teamflow-tasks/internal/infrastructure/task"
+	grpciface "teamflow-tasks/internal/interface/grpc"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+const (
+	testServiceKey = "s3cr3t"
+	testActor      = "11111111-1111-1111-1111-111111111111"
+)
+
+// denyProject は projectID の閲覧を ErrForbidden で拒否する ProjectAccessChecker。
+type denyProject struct{ projectID string }
+
+func (d denyProject) AuthorizeRead(_ context.Context, projectID, _ string) error {
+	if projectID == d.projectID {
+		return usecase.ErrForbidden
+	}
+	return nil
+}
+
+// newTestClient はインメモリのリポジトリで TaskService を起動し、クライアントを返す。opts で TaskService の設定を変える。
+func newTestClient(t *testing.T, clk *clock.Fake, opts ...func(*grpciface.TaskService)) tasksv1.TaskServiceClient {
+	t.Helper()
+	broker := broadcast.NewBroker()
+	repo := taskinfra.NewNotifyingTaskRepository(taskinfra.NewMemoryTaskRepository(), broker.Publish)
+	svc := &grpciface.TaskService{
+		Create:       &usecase.CreateTaskUsecase{Repo: repo},
+		List:         &usecase.ListTasksByProjectUsecase{Repo: repo, Access: denyProject{projectID: "private"}},
+		Update:       &usecase.UpdateTaskUsecase{Repo: repo, Clock: clk},
+		GetByNumber:  &usecase.GetTaskByNumberUsecase{Repo: repo},
+		Broker:       broker,
+		Access:       denyProject{projectID: "private"},
+		Clock:        clk,
+		CursorSecret: []byte("test-secret"),
+	}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	auth := serviceauth.NewAuthenticator([]serviceauth.Key{{Name: "projects", Secret: testServiceKey}}, clk)
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpciface.NewServer(svc, auth)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return tasksv1.NewTaskServiceClient(conn)
+}
+
+// callContext はサービス API キー・ワークスペース・操作者をメタデータに設定した context を返す。
+func callContext(workspaceID string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(),
+		"x-service-key", testServiceKey,
+		"x-workspace-id", workspaceID,
+		"x-user-id", testActor,
+	)
+}
+
+func wantCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != want {
+		t.Fatalf("expected %s, got %s (%v)", want, got, err)
+	}
+}
+
+func TestTaskService_CreateGetUpdate(t *testing.T) {
+	clk := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	client := newTestClient(t, clk)
+	ctx := callContext("acme")
+
+	created, err := client.CreateTask(ctx, &tasksv1.CreateTaskRequest{
+		ProjectId: "proj-1", Title: "設計", Status: "doing", Priority: "high", LabelIds: []string{"bug"},
+	})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	if created.GetId() == "" || created.GetNumber() != 1 || created.GetStatus() != "in_progress" || created.GetCreatedBy() != testActor {
+		t.Fatalf("unexpected task: %v", created)
+	}
+	if !created.GetCreatedAt().AsTime().Equal(clk.Now()) {
+		t.Errorf("expected created_at %v, got %v", clk.Now(), created.GetCreatedAt().AsTime())
+	}
+
+	got, err := client.GetTaskByNumber(ctx, &tasksv1.GetTaskByNumberRequest{ProjectId: "proj-1", Number: 1})
+	if err != nil || got.GetId() != created.GetId() {
+		t.Fatalf("GetTaskByNumber: expected %s, got %v, %v", created.GetId(), got, err)
+	}
+	// 別のワークスペースのタスクは存在しないものとして扱う
+	_, err = client.GetTaskByNumber(callContext("other"), &tasksv1.GetTaskByNumberRequest{ProjectId: "proj-1", Number: 1})
+	wantCode(t, err, codes.NotFound)
+
+	due := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	estimate := int32(3)
+	updated, err := client.UpdateTask(ctx, &tasksv1.UpdateTaskRequest{
+		Task: &tasksv1.Task{Id: created.GetId(), Title: " 詳細設計 ", Status: "done", DueDate: timestamppb.New(due), Estimate: &estimate},
+		// label_ids は値を設定せずに含めてすべて外す。priority はマスクに無いため変わらない
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title", "status", "due_date", "estimate", "label_ids"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateTask failed: %v", err)
+	}
+	if updated.GetTitle() != "詳細設計" || updated.GetStatus() != "done" || updated.GetPriority() != "high" ||
+		!updated.GetDueDate().AsTime().Equal(due) || updated.GetEstimate() != 3 || len(updated.GetLabelIds()) != 0 {
+		t.Fatalf("unexpected updated task: %v", updated)
+	}
+
+	// 値を設定しない optional のフィールドは外す
+	cleared, err := client.UpdateTask(ctx, &tasksv1.UpdateTaskRequest{
+		Task:       &tasksv1.Task{Id: created.GetId()},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"due_date", "estimate"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateTask failed: %v", err)
+	}
+	if cleared.DueDate != nil || cleared.Estimate != nil {
+		t.Errorf("expected due_date and estimate to be cleared, got %v", cleared)
+	}
+}
+
+func TestTaskService_Errors(t *testing.T) {
+	clk := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	client := newTestClient(t, clk)
+	ctx := callContext("")
+
+	tests := []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{name: "invalid status", want: codes.InvalidArgument, call: func() error {
+			_, err := client.CreateTask(ctx, &tasksv1.CreateTaskRequest{ProjectId: "proj-1", Title: "a", Status: "unknown"})
+			return err
+		}},
+		{name: "empty title", want: codes.InvalidArgument, call: func() error {
+			_, err := client.CreateTask(ctx, &tasksv1.CreateTaskRequest{ProjectId: "proj-1", Status: "todo", Priority: "low"})
+			return err
+		}},
+		{name: "number zero", want: codes.InvalidArgument, call: func() error {
+			_, err := client.GetTaskByNumber(ctx, &tasksv1.GetTaskByNumberRequest{ProjectId: "proj-1"})
+			return err
+		}},
+		{name: "update without mask", want: codes.InvalidArgument, call: func() error {
+			_, err := client.UpdateTask(ctx, &tasksv1.UpdateTaskRequest{Task: &tasksv1.Task{Id: "t1"}})
+			return err
+		}},
+		{name: "update unknown field", want: codes.InvalidArgument, call: func() error {
+			_, err := client.UpdateTask(ctx, &tasksv1.UpdateTaskRequest{Task: &tasksv1.Task{Id: "t1"}, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"number"}}})
+			return err
+		}},
+		{name: "update missing task", want: codes.NotFound, call: func() error {
+			_, err := client.UpdateTask(ctx, &tasksv1.UpdateTaskRequest{Task: &tasksv1.Task{Id: "missing", Title: "a"}, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}}})
+			return err
+		}},
+		{name: "list invalid assignee", want: codes.InvalidArgument, call: func() error {
+			_, err := client.ListTasks(ctx, &tasksv1.ListTasksRequest{ProjectId: "proj-1", AssigneeId: "bob"})
+			return err
+		}},
+		{name: "list token with sort", want: codes.InvalidArgument, call: func() error {
+			_, err := client.ListTasks(ctx, &tasksv1.ListTasksRequest{ProjectId: "proj-1", PageToken: "x", Sort: "createdAt"})
+			return err
+		}},
+		{name: "list invalid token", want: codes.InvalidArgument, call: func() error {
+			_, err := client.ListTasks(ctx, &tasksv1.ListTasksRequest{ProjectId: "proj-1", PageToken: "not-a-cursor"})
+			return err
+		}},
+		{name: "missing service key", want: codes.Unauthenticated, call: func() error {
+			_, err := client.ListTasks(context.Background(), &tasksv1.ListTasksRequest{ProjectId: "proj-1"})
+			return err
+		}},
+		{name: "invalid workspace", want: codes.InvalidArgument, call: func() error {
+			_, err := client.ListTasks(callContext("-bad"), &tasksv1.ListTasksRequest{ProjectId: "proj-1"})
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wantCode(t, tt.call(), tt.want)
+		})
+	}
+}
+
+func TestTaskService_ListTasks(t *testing.T) {
+	clk := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	client := newTestClient(t, clk)
+	ctx := callContext("")
+
+	for i, title := range []string{"a", "b", "c"} {
+		status := "todo"
+		if i == 1 {
+			status = "done"
+		}
+		if _, err := client.CreateTask(ctx, &tasksv1.CreateTaskRequest{ProjectId: "proj-1", Title: title, Status: status, Priority: "low"}); err != nil {
+			t.Fatalf("CreateTask failed: %v", err)
+		}
+		clk.Advance(time.Minute)
+	}
+
+	var titles []string
+	req := &tasksv1.ListTasksRequest{ProjectId: "proj-1", PageSize: 2}
+	for page := 0; ; page++ {
+		resp, err := client.ListTasks(ctx, req)
+		if err != nil {
+			t.Fatalf("ListTasks failed: %v", err)
+		}
+		for _, task := range resp.GetTasks() {
+			titles = append(titles, task.GetTitle())
+		}
+		if resp.GetNextPageToken() == "" {
+			break
+		}
+		if page > 2 {
+			t.Fatal("pagination did not terminate")
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+	if len(titles) != 3 || titles[0] != "a" || titles[1] != "b" || titles[2] != "c" {
+		t.Fatalf("expected tasks a, b, c across pages, got %v", titles)
+	}
+
+	resp, err := client.ListTasks(ctx, &tasksv1.ListTasksRequest{ProjectId: "proj-1", Statuses: []string{"todo"}})
+	if err != nil {
+		t.Fatalf("ListTasks failed: %v", err)
+	}
+	if len(resp.GetTasks()) != 2 || resp.GetNextPageToken() != "" {
+		t.Errorf("expected 2 todo tasks on one page, got %v", resp)
+	}
+}
+
+func TestTaskService_BatchListTasks(t *testing.T) {
+	clk := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	client := newTestClient(t, clk)
+	ctx := callContext("")
+
+	for _, task := range []struct{ project, title, status string }{
+		{"proj-1", "a", "todo"}, {"proj-1", "b", "todo"}, {"proj-1", "c", "done"},
+		{"proj-2", "d", "todo"}, {"private", "e", "todo"},
+	} {
+		if _, err := client.CreateTask(ctx, &tasksv1.CreateTaskRequest{ProjectId: task.project, Title: task.title, Status: task.status, Priority: "low"}); err != nil {
+			t.Fatalf("CreateTask failed: %v", err)
+		}
+		clk.Advance(time.Minute)
+	}
+
+	resp, err := client.BatchListTasks(ctx, &tasksv1.BatchListTasksRequest{
+		// 重複は 1 つにまとめ、閲覧できない private・タスクの無い proj-3 も含める
+		ProjectIds: []string{"proj-2", "proj-1", "private", "proj-1", "proj-3"},
+		Filter:     &tasksv1.ListTasksRequest{Statuses: []string{"todo"}, PageSize: 1},
+	})
+	if err != nil {
+		t.Fatalf("BatchListTasks failed: %v", err)
+	}
+	got := map[string][]string{}
+	var order []string
+	for _, p := range resp.GetProjects() {
+		order = append(order, p.GetProjectId())
+		for _, task := range p.GetTasks() {
+			got[p.GetProjectId()] = append(got[p.GetProjectId()], task.GetTitle())
+		}
+		// proj-1 は todo が 2 件あるため 2 ページ目がある
+		if (p.GetNextPageToken() != "") != (p.GetProjectId() == "proj-1") {
+			t.Errorf("project %s: unexpected next_page_token %q", p.GetProjectId(), p.GetNextPageToken())
+		}
+	}
+	if len(order) != 3 || order[0] != "proj-2" || order[1] != "proj-1" || order[2] != "proj-3" {
+		t.Fatalf("expected projects proj-2, proj-1, proj-3, got %v", order)
+	}
+	if len(got["proj-1"]) != 1 || got["proj-1"][0] != "a" || len(got["proj-2"]) != 1 || len(got["proj-3"]) != 0 {
+		t.Fatalf("unexpected tasks: %v", got)
+	}
+
+	// 2 ページ目以降は ListTasks で取得する
+	next, err := client.ListTasks(ctx, &tasksv1.ListTasksRequest{ProjectId: "proj-1", Statuses: []string{"todo"}, PageSize: 1, PageToken: resp.GetProjects()[1].GetNextPageToken()})
+	if err != nil || len(next.GetTasks()) != 1 || next.GetTasks()[0].GetTitle() != "b" {
+		t.Fatalf("expected task b on the next page, got %v, %v", next, err)
+	}
+
+	_, err = client.BatchListTasks(ctx, &tasksv1.BatchListTasksRequest{})
+	wantCode(t, err, codes.InvalidArgument)
+	_, err = client.BatchListTasks(ctx, &tasksv1.BatchListTasksRequest{ProjectIds: make([]string, 201)})
+	wantCode(t, err, codes.InvalidArgument)
+	_, err = client.BatchListTasks(ctx, &tasksv1.BatchListTasksRequest{ProjectIds: []string{"proj-1"}, Filter: &tasksv1.ListTasksRequest{PageToken: "x"}})
+	wantCode(t, err, codes.InvalidArgument)
+	_, err = client.BatchListTasks(ctx, &tasksv1.BatchListTasksRequest{ProjectIds: []string{"proj-1"}, Filter: &tasksv1.ListTasksRequest{Statuses: []string{"unknown"}}})
+	wantCode(t, err, codes.InvalidArgument)
+}
+
+func TestTaskService_WatchTasks(t *testing.T) {
+	clk := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	client := newTestClient(t, clk)
+	ctx, cancel := context.WithTimeout(callContext("acme"), 5*time.Second)
+	defer cancel()
+
+	// 閲覧できないプロジェクトは購読できない
+	denied, err := client.WatchTasks(ctx, &tasksv1.WatchTasksRequest{ProjectId: "private"})
+	if err == nil {
+		_, err = denied.Recv()
+	}
+	wantCode(t, err, codes.PermissionDenied)
+
+	stream, err := client.WatchTasks(ctx, &tasksv1.WatchTasksRequest{ProjectId: "proj-1"})
+	if err != nil {
+		t.Fatalf("WatchTasks failed: %v", err)
+	}
+	events := make(chan *tasksv1.TaskEvent, 1)
+	go func() {
+		if e, err := stream.Recv(); err == nil {
+			events <- e
+		}
+	}()
+	// 購読の開始前のイベントは届かないため、イベントが届くまでタスクを作成する
+	for deadline := time.Now().Add(3 * time.Second); time.Now().Before(deadline); {
+		if _, err := client.CreateTask(ctx, &tasksv1.CreateTaskRequest{ProjectId: "proj-1", Title: "a", Status: "todo", Priority: "low"}); err != nil {
+			t.Fatalf("CreateTask failed: %v", err)
+		}
+		select {
+		case e := <-events:
+			if e.GetType() != broadcast.EventTaskCreated || e.GetWorkspaceId() != "acme" || e.GetProjectId() != "proj-1" || e.GetTaskId() == "" {
+				t.Fatalf("unexpected event: %v", e)
+			}
+			return
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+	t.Fatal("expected a task.created event")
+}
+
+func TestTaskService_WatchTasksShutdown(t *testing.T) {
+	clk := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	shutdown := make(chan struct{})
+	client := newTestClient(t, clk, func(s *grpciface.TaskService) { s.Shutdown = shutdown })
+	ctx, cancel := context.WithTimeout(callContext("acme"), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.WatchTasks(ctx, &tasksv1.WatchTasksRequest{ProjectId: "proj-1"})
+	if err != nil {
+		t.Fatalf("WatchTasks failed: %v", err)
+	}
+	close(shutdown)
+	_, err = stream.Recv()
+	wantCode(t, err, codes.Unavailable)
+}
@@ -0,0 +1,135 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"teamflow-shared/clock"
+
+	"github.com/google/uuid"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// BatchCreateTasksHandler は POST /api/projects/{projectId}/tasks:batch を処理する HTTP ハンドラ。
+//
+// projects サービスがテンプレートからプロジェクトを作成する際に、定義済みのタスクを
+// まとめて作成するために使う（すべて作成されるか、1 件も作成されない）。
+type BatchCreateTasksHandler struct {
+	createUC *usecase.CreateTasksUsecase
+	clock    clock.Clock
+}
+
+// NewBatchCreateTasksHandler は BatchCreateTasksHandler を生成する。
+func NewBatchCreateTasksHandler(
+	createUC *usecase.CreateTasksUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &BatchCreateTasksHandler{
+		createUC: createUC,
+		clock:    clk,
+	}
+}
+
+type batchCreateTasksRequest struct {
+	Tasks []createTaskRequest `json:"tasks"`
+}
+
+type batchCreateTasksResponse struct {
+	Tasks []taskResponse `json:"tasks"`
+}
+
+func (h *BatchCreateTasksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectID, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/projects/"), "/tasks:batch")
+	if !ok || projectID == "" || strings.Contains(projectID, "/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var req batchCreateTasksRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "invalid json", err.Error())
+		return
+	}
+
+	now := h.clock.Now()
+	inputs := make([]usecase.CreateTaskInput, len(req.Tasks))
+	for i, t := range req.Tasks {
+		status, err := domain.ParseStatus(t.Status)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "invalid status", err.Error())
+			return
+		}
+		// priority の省略はプロジェクト設定の既定値に任せる
+		var priority domain.TaskPriority
+		if t.Priority != "" {
+			priority, err = domain.ParsePriority(t.Priority)
+			if err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, "invalid priority", err.Error())
+				return
+			}
+		}
+
+		dueDate, err := parseDueDate(t.DueDate)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "validation error", err.Error())
+			return
+		}
+
+		taskID := t.ID
+		if taskID == "" {
+			taskID = uuid.New().String()
+		}
+		inputs[i] = usecase.CreateTaskInput{
+			ID:          taskID,
+			Title:       t.Title,
+			Description: t.Description,
+			Status:      status,
+			Priority:    priority,
+			AssigneeID:  t.AssigneeID,
+			DueDate:     dueDate,
+			MilestoneID: t.MilestoneID,
+			SprintID:    t.SprintID,
+			EpicID:      t.EpicID,
+			LabelIDs:    t.LabelIDs,
+			ActorID:     actorID(r),
+			Now:         now,
+		}
+	}
+
+	tasks, err := h.createUC.Execute(r.Context(), projectID, inputs)
+	if err != nil {
+		var ve *domain.ValidationError
+		if errors.As(err, &ve) {
+			writeErrorResponse(w, http.StatusBadRequest, "validation error", err.Error())
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			writeErrorResponse(w, http.StatusBadRequest, "invalid input", err.Error())
+			return
+		}
+		if errors.Is(err, usecase.ErrTimeout) {
+			writeTimeoutResponse(w)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	resp := batchCreateTasksResponse{Tasks: make([]taskResponse, 0, len(tasks))}
+	for _, t := range tasks {
+		resp.Tasks = append(resp.Tasks, toTaskResponse(t))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(resp)
+}
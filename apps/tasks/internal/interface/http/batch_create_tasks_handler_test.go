@@ -0,0 +1,98 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	taskinfra "teamflow-tasks/internal/infrastructure/task"
+	httpiface "teamflow-tasks/internal/interface/http"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+func TestBatchCreateTasksHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		body      string
+		wantCode  int
+		wantSaved int
+	}{
+		{
+			name:      "creates all tasks",
+			path:      "/projects/proj-1/tasks:batch",
+			body:      `{"tasks":[{"title":"計画","status":"todo","priority":"high"},{"title":"振り返り","status":"todo","priority":"low"}]}`,
+			wantCode:  http.StatusCreated,
+			wantSaved: 2,
+		},
+		{
+			name:     "invalid task creates nothing",
+			path:     "/projects/proj-1/tasks:batch",
+			body:     `{"tasks":[{"title":"計画","status":"todo","priority":"high"},{"title":"","status":"todo","priority":"low"}]}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid status",
+			path:     "/projects/proj-1/tasks:batch",
+			body:     `{"tasks":[{"title":"計画","status":"blocked","priority":"high"}]}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid json",
+			path:     "/projects/proj-1/tasks:batch",
+			body:     `{invalid`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "missing project id",
+			path:     "/projects//tasks:batch",
+			body:     `{"tasks":[]}`,
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := taskinfra.NewMemoryTaskRepository()
+			uc := &usecase.CreateTasksUsecase{
+				Create: &usecase.CreateTaskUsecase{Repo: repo},
+				Tx:     taskinfra.NoopTxManager{},
+			}
+			handler := httpiface.NewBatchCreateTasksHandler(uc, fixedClock)
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewReader([]byte(tt.body)))
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if tt.wantCode == http.StatusCreated {
+				var resp struct {
+					Tasks []struct {
+						ID        string `json:"id"`
+						ProjectID string `json:"projectId"`
+					} `json:"tasks"`
+				}
+				if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if len(resp.Tasks) != tt.wantSaved || resp.Tasks[0].ID == "" || resp.Tasks[0].ProjectID != "proj-1" {
+					t.Errorf("unexpected response: %+v", resp)
+				}
+			}
+
+			saved, err := repo.ListByProject(context.Background(), "proj-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(saved) != tt.wantSaved {
+				t.Errorf("expected %d saved tasks, got %d", tt.wantSaved, len(saved))
+			}
+		})
+	}
+}
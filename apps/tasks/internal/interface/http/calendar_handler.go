@@ -0,0 +1,158 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/clock"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// calendarSuffix はカレンダーのフィードのパスの末尾。
+const calendarSuffix = "/tasks.ics"
+
+// IsCalendarPath は path（/api または /api/v1 を含むパス）がカレンダーのフィードかどうかを返す。
+// pat.QueryToken でクエリの token を受け付けるパスの判定に使う。
+func IsCalendarPath(path string) bool {
+	return strings.HasPrefix(path, APIPrefix+"/") && strings.HasSuffix(path, calendarSuffix)
+}
+
+// CalendarHandler は GET /api/projects/{projectId}/tasks.ics を処理する HTTP ハンドラ。
+//
+// 期日のあるタスクを終日の予定（VEVENT）にした iCalendar（RFC 5545）を返す。カレンダーアプリは
+// Authorization ヘッダを送れないため、URL のクエリ token に個人用アクセストークン（read スコープ）を付けて購読する
+// （pat.QueryToken が Authorization に移す）。操作者の無いリクエストは 401 にする。
+type CalendarHandler struct {
+	listUC *usecase.ListCalendarTasksUsecase
+	clock  clock.Clock
+}
+
+// NewCalendarHandler は CalendarHandler を生成する。
+func NewCalendarHandler(listUC *usecase.ListCalendarTasksUsecase, clk clock.Clock) http.Handler {
+	return &CalendarHandler{listUC: listUC, clock: clk}
+}
+
+func (h *CalendarHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	// /projects/{projectId}/tasks.ics（/api を除いたパス）から projectId を抽出
+	projectID, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/projects/"), calendarSuffix)
+	if !ok || projectID == "" || strings.Contains(projectID, "/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	actor := actorID(r)
+	if actor == "" {
+		apierror.Write(w, http.StatusUnauthorized, apierror.New(apierror.CodeUnauthorized,
+			"a personal access token is required (token query parameter or Authorization header)"))
+		return
+	}
+
+	now := h.clock.Now()
+	tasks, err := h.listUC.Execute(r.Context(), usecase.ListCalendarTasksInput{ProjectID: projectID, ActorID: actor, Now: now})
+	if err != nil {
+		if writeAuthzError(w, err) {
+			return
+		}
+		if errors.Is(err, usecase.ErrTimeout) {
+			writeTimeoutResponse(w)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, _ = w.Write(encodeCalendar(tasks, now))
+}
+
+// encodeCalendar はタスクを iCalendar に変換する。各タスクは期日の終日の予定にし、UID はタスク ID で固定する
+// （購読し直しても同じ予定として更新される）。完了したタスクは件名の先頭に [完了] を付ける。
+func encodeCalendar(tasks []*domain.Task, now time.Time) []byte {
+	var b bytes.Buffer
+	line := func(name, value string) { writeFolded(&b, name+":"+value) }
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//TeamFlow//Tasks//JA")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", "TeamFlow タスクの期日")
+	// 購読したカレンダーアプリに 1 時間ごとの再取得を促す
+	line("REFRESH-INTERVAL;VALUE=DURATION", "PT1H")
+	line("X-PUBLISHED-TTL", "PT1H")
+	for _, t := range tasks {
+		if t.DueDate == nil {
+			continue
+		}
+		due := t.DueDate.UTC()
+		summary := t.Title
+		if t.Number > 0 {
+			summary = fmt.Sprintf("#%d %s", t.Number, summary)
+		}
+		if t.Status == domain.StatusDone {
+			summary = "[完了] " + summary
+		}
+		description := fmt.Sprintf("ステータス: %s\n優先度: %s", t.Status, t.Priority)
+		if t.Description != "" {
+			description += "\n\n" + t.Description
+		}
+
+		line("BEGIN", "VEVENT")
+		line("UID", t.ID+"@teamflow")
+		line("DTSTAMP", now.UTC().Format("20060102T150405Z"))
+		line("LAST-MODIFIED", t.UpdatedAt.UTC().Format("20060102T150405Z"))
+		line("DTSTART;VALUE=DATE", due.Format("20060102"))
+		line("DTEND;VALUE=DATE", due.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY", escapeText(summary))
+		line("DESCRIPTION", escapeText(description))
+		// 終日の予定が空き時間を塞がないようにする
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return b.Bytes()
+}
+
+// escapeText は iCalendar の TEXT の値をエスケープする（\ ; , と改行）。
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+// maxLineOctets は iCalendar の 1 行の最大オクテット数（改行を除く）。
+const maxLineOctets = 75
+
+// writeFolded は s を 75 オクテットごとに折り返して（続きの行は空白で始める）CRLF で終わる行として書き込む。
+// UTF-8 の文字の途中では折り返さない。
+func writeFolded(b *bytes.Buffer, s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		// 続きの行は先頭の空白の分だけ短くする
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,131 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	domain "teamflow-tasks/internal/domain/task"
+	taskinfra "teamflow-tasks/internal/infrastructure/task"
+	httpiface "teamflow-tasks/internal/interface/http"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+func TestCalendarHandler(t *testing.T) {
+	now := fixedNow()
+	date := func(y int, m time.Month, d int) *time.Time {
+		t := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	repo := taskinfra.NewMemoryTaskRepository()
+	for _, task := range []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", Title: "設計, 仕様; レビュー", Description: "画面\nAPI", Status: domain.StatusTodo,
+			Priority: domain.PriorityHigh, DueDate: date(2025, 1, 10), CreatedAt: now, UpdatedAt: now},
+		{ID: "t2", ProjectID: "proj-1", Title: "リリース", Status: domain.StatusDone, Priority: domain.PriorityLow,
+			DueDate: date(2024, 12, 20), CreatedAt: now, UpdatedAt: now},
+		{ID: "t3", ProjectID: "proj-1", Title: "期日なし", Status: domain.StatusTodo, Priority: domain.PriorityLow, CreatedAt: now, UpdatedAt: now},
+		{ID: "t4", ProjectID: "proj-1", Title: "昔のタスク", Status: domain.StatusTodo, Priority: domain.PriorityLow,
+			DueDate: date(2024, 6, 1), CreatedAt: now, UpdatedAt: now},
+		{ID: "t5", ProjectID: "proj-2", Title: "別のプロジェクト", Status: domain.StatusTodo, Priority: domain.PriorityLow,
+			DueDate: date(2025, 1, 5), CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := repo.Save(context.Background(), task); err != nil {
+			t.Fatalf("failed to save task: %v", err)
+		}
+	}
+	handler := httpiface.NewCalendarHandler(&usecase.ListCalendarTasksUsecase{
+		Repo:   repo,
+		Access: privateProjectAccess{private: "proj-1", member: "user-1"},
+	}, fixedClock)
+
+	req := httptest.NewRequest(http.MethodGet, "/projects/proj-1/tasks.ics", nil)
+	req.Header.Set("X-User-ID", "user-1")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/calendar") {
+		t.Fatalf("expected 200 text/calendar, got %d %q: %s", w.Code, w.Header().Get("Content-Type"), w.Body)
+	}
+	body := w.Body.String()
+	if !strings.HasPrefix(body, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n") || !strings.HasSuffix(body, "END:VCALENDAR\r\n") {
+		t.Errorf("unexpected calendar envelope: %q", body)
+	}
+	if got := strings.Count(body, "BEGIN:VEVENT"); got != 2 {
+		t.Errorf("expected 2 events (with due dates in range), got %d: %s", got, body)
+	}
+	// 期日の昇順
+	if strings.Index(body, "UID:t2@teamflow") > strings.Index(body, "UID:t1@teamflow") {
+		t.Errorf("expected events ordered by due date: %s", body)
+	}
+	for _, want := range []string{
+		"DTSTART;VALUE=DATE:20250110\r\nDTEND;VALUE=DATE:20250111\r\n",
+		`SUMMARY:#1 設計\, 仕様\; レビュー`,
+		`DESCRIPTION:ステータス: todo\n優先度: high\n\n画面\nAPI`,
+		"SUMMARY:[完了] #2 リリース",
+		"DTSTAMP:20250101T120000Z",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected calendar to contain %q: %s", want, body)
+		}
+	}
+	for _, line := range strings.Split(body, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("expected lines to be folded at 75 octets, got %d: %q", len(line), line)
+		}
+	}
+}
+
+func TestCalendarHandler_Errors(t *testing.T) {
+	handler := httpiface.NewCalendarHandler(&usecase.ListCalendarTasksUsecase{
+		Repo:   taskinfra.NewMemoryTaskRepository(),
+		Access: privateProjectAccess{private: "proj-1", member: "user-1"},
+	}, fixedClock)
+
+	for _, tt := range []struct {
+		name, method, path, actor string
+		want                      int
+	}{
+		{name: "no token", method: http.MethodGet, path: "/projects/proj-1/tasks.ics", want: http.StatusUnauthorized},
+		{name: "not a member", method: http.MethodGet, path: "/projects/proj-1/tasks.ics", actor: "user-2", want: http.StatusForbidden},
+		{name: "method not allowed", method: http.MethodPost, path: "/projects/proj-1/tasks.ics", actor: "user-1", want: http.StatusMethodNotAllowed},
+		{name: "empty calendar", method: http.MethodGet, path: "/projects/proj-2/tasks.ics", actor: "user-2", want: http.StatusOK},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.actor != "" {
+				req.Header.Set("X-User-ID", tt.actor)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, w.Code, w.Body)
+			}
+		})
+	}
+}
+
+func TestCalendarHandler_FoldsLongLines(t *testing.T) {
+	now := fixedNow()
+	due := now.AddDate(0, 0, 3)
+	repo := taskinfra.NewMemoryTaskRepository()
+	title := strings.Repeat("長いタイトル", 20)
+	if err := repo.Save(context.Background(), &domain.Task{ID: "t1", ProjectID: "proj-1", Title: title,
+		Status: domain.StatusTodo, Priority: domain.PriorityLow, DueDate: &due, CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	handler := httpiface.NewCalendarHandler(&usecase.ListCalendarTasksUsecase{Repo: repo}, fixedClock)
+
+	req := httptest.NewRequest(http.MethodGet, "/projects/proj-1/tasks.ics", nil)
+	req.Header.Set("X-User-ID", "user-1")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// 折り返した行（CRLF + 空白）をつなぐと元の件名に戻る
+	unfolded := strings.ReplaceAll(w.Body.String(), "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:#1 "+title+"\r\n") {
+		t.Errorf("expected the folded summary to unfold to the title: %q", w.Body.String())
+	}
+}
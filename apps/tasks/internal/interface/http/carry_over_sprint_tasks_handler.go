@@ -0,0 +1,101 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"teamflow-shared/clock"
+
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// carryOverSuffix は未完了タスクの持ち越しのカスタムメソッド（/projects/{projectId}/tasks:carry-over）。
+const carryOverSuffix = "/tasks:carry-over"
+
+// CarryOverSprintTasksHandler は POST /api/projects/{projectId}/tasks:carry-over を処理する HTTP ハンドラ。
+//
+// projects サービスがスプリントを完了するときに呼ぶ。fromSprintId の未完了のタスクを toSprintId
+// （null・省略時はバックログ）に移す。移したタスクは fromSprintId に属さなくなるため、projects サービスは失敗時に再試行してよい。
+type CarryOverSprintTasksHandler struct {
+	carryOverUC *usecase.CarryOverSprintTasksUsecase
+	clock       clock.Clock
+}
+
+// NewCarryOverSprintTasksHandler は CarryOverSprintTasksHandler を生成する。
+func NewCarryOverSprintTasksHandler(
+	carryOverUC *usecase.CarryOverSprintTasksUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &CarryOverSprintTasksHandler{
+		carryOverUC: carryOverUC,
+		clock:       clk,
+	}
+}
+
+type carryOverSprintTasksRequest struct {
+	FromSprintID string  `json:"fromSprintId"`
+	ToSprintID   *string `json:"toSprintId"` // null・省略時はバックログ
+}
+
+type carryOverSprintTasksResponse struct {
+	ProjectID    string  `json:"projectId"`
+	FromSprintID string  `json:"fromSprintId"`
+	ToSprintID   *string `json:"toSprintId"`
+	Count        int     `json:"count"` // 移したタスクの件数
+}
+
+func (h *CarryOverSprintTasksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectID, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/projects/"), carryOverSuffix)
+	if !ok || projectID == "" || strings.Contains(projectID, "/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var req carryOverSprintTasksRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "invalid json", err.Error())
+		return
+	}
+	toSprintID := ""
+	if req.ToSprintID != nil {
+		toSprintID = *req.ToSprintID
+	}
+
+	count, err := h.carryOverUC.Execute(r.Context(), usecase.CarryOverSprintTasksInput{
+		ProjectID:    projectID,
+		FromSprintID: req.FromSprintID,
+		ToSprintID:   toSprintID,
+		ActorID:      actorID(r),
+		Now:          h.clock.Now(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidInput):
+			writeErrorResponse(w, http.StatusBadRequest, "validation error", err.Error())
+		case errors.Is(err, usecase.ErrTimeout):
+			writeTimeoutResponse(w)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	resp := carryOverSprintTasksResponse{
+		ProjectID:    projectID,
+		FromSprintID: req.FromSprintID,
+		Count:        count,
+	}
+	if toSprintID != "" {
+		resp.ToSprintID = &toSprintID
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,79 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	domain "teamflow-tasks/internal/domain/task"
+	taskinfra "teamflow-tasks/internal/infrastructure/task"
+	httpiface "teamflow-tasks/internal/interface/http"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+func TestCarryOverSprintTasksHandler(t *testing.T) {
+	now := fixedNow()
+	s1 := "s-1"
+	repo := taskinfra.NewMemoryTaskRepository()
+	for _, task := range []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", Title: "設計", Status: domain.StatusTodo, Priority: domain.PriorityHigh, SprintID: &s1, CreatedAt: now, UpdatedAt: now},
+		{ID: "t2", ProjectID: "proj-1", Title: "実装", Status: domain.StatusDone, Priority: domain.PriorityLow, SprintID: &s1, CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := repo.Save(context.Background(), task); err != nil {
+			t.Fatalf("failed to save task: %v", err)
+		}
+	}
+	handler := httpiface.NewCarryOverSprintTasksHandler(&usecase.CarryOverSprintTasksUsecase{Repo: repo}, fixedClock)
+
+	// 順に実行する（前のステップの結果に依存する）
+	steps := []struct {
+		name      string
+		method    string
+		path      string
+		body      string
+		wantCode  int
+		wantCount int
+	}{
+		{name: "carry over", method: http.MethodPost, path: "/projects/proj-1/tasks:carry-over", body: `{"fromSprintId":"s-1","toSprintId":"s-2"}`, wantCode: http.StatusOK, wantCount: 1},
+		{name: "carry over again", method: http.MethodPost, path: "/projects/proj-1/tasks:carry-over", body: `{"fromSprintId":"s-1","toSprintId":"s-2"}`, wantCode: http.StatusOK, wantCount: 0},
+		{name: "to backlog", method: http.MethodPost, path: "/projects/proj-1/tasks:carry-over", body: `{"fromSprintId":"s-2","toSprintId":null}`, wantCode: http.StatusOK, wantCount: 1},
+		{name: "missing fromSprintId", method: http.MethodPost, path: "/projects/proj-1/tasks:carry-over", body: `{}`, wantCode: http.StatusBadRequest},
+		{name: "invalid json", method: http.MethodPost, path: "/projects/proj-1/tasks:carry-over", body: `{`, wantCode: http.StatusBadRequest},
+		{name: "missing project id", method: http.MethodPost, path: "/projects//tasks:carry-over", body: `{"fromSprintId":"s-1"}`, wantCode: http.StatusNotFound},
+		{name: "method not allowed", method: http.MethodGet, path: "/projects/proj-1/tasks:carry-over", wantCode: http.StatusMethodNotAllowed},
+	}
+
+	for _, step := range steps {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(step.method, step.path, strings.NewReader(step.body)))
+
+		if w.Code != step.wantCode {
+			t.Fatalf("%s: expected status %d, got %d", step.name, step.wantCode, w.Code)
+		}
+		if w.Code != http.StatusOK {
+			continue
+		}
+		var got struct {
+			ProjectID string `json:"projectId"`
+			Count     int    `json:"count"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+			t.Fatalf("%s: failed to decode response: %v", step.name, err)
+		}
+		if got.ProjectID != "proj-1" || got.Count != step.wantCount {
+			t.Errorf("%s: expected count %d, got %+v", step.name, step.wantCount, got)
+		}
+	}
+
+	t1, err := repo.FindByID(context.Background(), "t1")
+	if err != nil || t1.SprintID != nil {
+		t.Errorf("expected t1 in backlog, got %+v (err=%v)", t1, err)
+	}
+	t2, err := repo.FindByID(context.Background(), "t2")
+	if err != nil || t2.SprintID == nil || *t2.SprintID != "s-1" {
+		t.Errorf("expected t2 to stay in s-1, got %+v (err=%v)", t2, err)
+	}
+}
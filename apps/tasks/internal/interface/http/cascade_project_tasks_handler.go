@@ -0,0 +1,81 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"teamflow-shared/clock"
+
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// CascadeProjectTasksHandler は POST /api/projects/{projectId}/tasks:archive|unarchive|delete を処理する HTTP ハンドラ。
+//
+// projects サービスがプロジェクトの削除（cascade=archive_tasks / delete_tasks）と復元のときに呼ぶ。
+// 対象のタスクが無い場合も 200（count: 0）を返すため、projects サービスは失敗時に再試行してよい。
+type CascadeProjectTasksHandler struct {
+	cascadeUC *usecase.CascadeProjectTasksUsecase
+	clock     clock.Clock
+}
+
+// NewCascadeProjectTasksHandler は CascadeProjectTasksHandler を生成する。
+func NewCascadeProjectTasksHandler(
+	cascadeUC *usecase.CascadeProjectTasksUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &CascadeProjectTasksHandler{
+		cascadeUC: cascadeUC,
+		clock:     clk,
+	}
+}
+
+type cascadeProjectTasksResponse struct {
+	ProjectID string `json:"projectId"`
+	Action    string `json:"action"`
+	Count     int    `json:"count"` // 対象になったタスクの件数
+}
+
+func (h *CascadeProjectTasksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	// /projects/{projectId}/tasks:{action}
+	projectID, action, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/projects/"), "/tasks:")
+	if !ok || projectID == "" || strings.Contains(projectID, "/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch usecase.CascadeAction(action) {
+	case usecase.CascadeArchive, usecase.CascadeUnarchive, usecase.CascadeDelete:
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	count, err := h.cascadeUC.Execute(r.Context(), usecase.CascadeProjectTasksInput{
+		ProjectID: projectID,
+		Action:    usecase.CascadeAction(action),
+		ActorID:   actorID(r),
+		Now:       h.clock.Now(),
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrTimeout) {
+			writeTimeoutResponse(w)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(cascadeProjectTasksResponse{
+		ProjectID: projectID,
+		Action:    action,
+		Count:     count,
+	})
+}
@@ -0,0 +1,83 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "teamflow-tasks/internal/domain/task"
+	taskinfra "teamflow-tasks/internal/infrastructure/task"
+	httpiface "teamflow-tasks/internal/interface/http"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+func TestCascadeProjectTasksHandler(t *testing.T) {
+	now := fixedNow()
+	repo := taskinfra.NewMemoryTaskRepository()
+	for _, task := range []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", Title: "設計", Status: domain.StatusTodo, Priority: domain.PriorityHigh, CreatedAt: now, UpdatedAt: now},
+		{ID: "t2", ProjectID: "proj-1", Title: "実装", Status: domain.StatusDone, Priority: domain.PriorityLow, CreatedAt: now, UpdatedAt: now},
+		{ID: "t3", ProjectID: "proj-2", Title: "別", Status: domain.StatusTodo, Priority: domain.PriorityLow, CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := repo.Save(context.Background(), task); err != nil {
+			t.Fatalf("failed to save task: %v", err)
+		}
+	}
+	handler := httpiface.NewCascadeProjectTasksHandler(&usecase.CascadeProjectTasksUsecase{Repo: repo}, fixedClock)
+	listUC := &usecase.ListTasksByProjectUsecase{Repo: repo}
+
+	// 順に実行する（前のステップの結果に依存する）
+	steps := []struct {
+		name       string
+		method     string
+		path       string
+		wantCode   int
+		wantCount  int
+		wantListed int // 実行後に一覧に含まれる proj-1 のタスク数
+	}{
+		{name: "archive", method: http.MethodPost, path: "/projects/proj-1/tasks:archive", wantCode: http.StatusOK, wantCount: 2, wantListed: 0},
+		{name: "archive again", method: http.MethodPost, path: "/projects/proj-1/tasks:archive", wantCode: http.StatusOK, wantCount: 0, wantListed: 0},
+		{name: "unarchive", method: http.MethodPost, path: "/projects/proj-1/tasks:unarchive", wantCode: http.StatusOK, wantCount: 2, wantListed: 2},
+		{name: "delete", method: http.MethodPost, path: "/projects/proj-1/tasks:delete", wantCode: http.StatusOK, wantCount: 2, wantListed: 0},
+		{name: "unknown action", method: http.MethodPost, path: "/projects/proj-1/tasks:purge", wantCode: http.StatusNotFound},
+		{name: "missing project id", method: http.MethodPost, path: "/projects//tasks:archive", wantCode: http.StatusNotFound},
+		{name: "method not allowed", method: http.MethodGet, path: "/projects/proj-1/tasks:archive", wantCode: http.StatusMethodNotAllowed},
+	}
+
+	for _, step := range steps {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(step.method, step.path, nil))
+
+		if w.Code != step.wantCode {
+			t.Fatalf("%s: expected status %d, got %d", step.name, step.wantCode, w.Code)
+		}
+		if w.Code != http.StatusOK {
+			continue
+		}
+		var got struct {
+			ProjectID string `json:"projectId"`
+			Count     int    `json:"count"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+			t.Fatalf("%s: failed to decode response: %v", step.name, err)
+		}
+		if got.ProjectID != "proj-1" || got.Count != step.wantCount {
+			t.Errorf("%s: unexpected response: %+v", step.name, got)
+		}
+
+		listed, err := listUC.Execute(context.Background(), usecase.ListTasksByProjectInput{ProjectID: "proj-1"})
+		if err != nil {
+			t.Fatalf("%s: failed to list tasks: %v", step.name, err)
+		}
+		if len(listed) != step.wantListed {
+			t.Errorf("%s: expected %d listed tasks, got %d", step.name, step.wantListed, len(listed))
+		}
+	}
+
+	// 他のプロジェクトのタスクは変更しない
+	if got, err := repo.FindByID(context.Background(), "t3"); err != nil || got.ArchivedAt != nil {
+		t.Errorf("expected t3 untouched, got %+v (err=%v)", got, err)
+	}
+}
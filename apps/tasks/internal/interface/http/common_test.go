@@ -1,9 +1,20 @@
 package http_test
 
-import "time"
+import (
+	"time"
+
+	"teamflow-shared/clock"
+)
 
 // fixedNow はテスト用の固定時刻を返すヘルパー関数。
 // すべてのテストで一貫した時刻を使用することで、テストの再現性を確保する。
 func fixedNow() time.Time {
 	return time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
 }
+
+// fixedClock は fixedNow を返す Clock。ハンドラに渡す。
+var fixedClock = clock.Fixed(fixedNow())
+
+// strPtr / intPtr は期待値の nil 許容フィールドを書くためのヘルパー関数。
+func strPtr(s string) *string { return &s }
+func intPtr(i int) *int       { return &i }
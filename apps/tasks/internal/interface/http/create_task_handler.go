@@ -2,8 +2,10 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
-	"time"
+
+	"teamflow-shared/clock"
 
 	"github.com/google/uuid"
 
@@ -20,29 +22,20 @@ import (
 //   - 作成されたタスクをJSONレスポンスとして返す
 type CreateTaskHandler struct {
 	createUC *usecase.CreateTaskUsecase
-	nowFunc  func() time.Time
+	clock    clock.Clock
 }
 
 // NewCreateTaskHandler は CreateTaskHandler を生成する。
 func NewCreateTaskHandler(
 	createUC *usecase.CreateTaskUsecase,
-	nowFunc func() time.Time,
+	clk clock.Clock,
 ) http.Handler {
 	return &CreateTaskHandler{
 		createUC: createUC,
-		nowFunc:  nowFunc,
+		clock:    clk,
 	}
 }
 
-type createTaskRequest struct {
-	ID          string `json:"id"`
-	ProjectID   string `json:"projectId"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
-	Priority    string `json:"priority"`
-}
-
 func (h *CreateTaskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		w.WriteHeader(http.StatusMethodNotAllowed)
@@ -64,9 +57,19 @@ func (h *CreateTaskHandler) handleCreate(w http.ResponseWriter, r *http.Request)
 		writeErrorResponse(w, http.StatusBadRequest, "invalid status", err.Error())
 		return
 	}
-	priority, err := domain.ParsePriority(req.Priority)
+	// priority の省略はプロジェクト設定の既定値に任せる（既定値も無ければ Usecase のバリデーションで 400）
+	var priority domain.TaskPriority
+	if req.Priority != "" {
+		priority, err = domain.ParsePriority(req.Priority)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "invalid priority", err.Error())
+			return
+		}
+	}
+
+	dueDate, err := parseDueDate(req.DueDate)
 	if err != nil {
-		writeErrorResponse(w, http.StatusBadRequest, "invalid priority", err.Error())
+		writeErrorResponse(w, http.StatusBadRequest, "validation error", err.Error())
 		return
 	}
 
@@ -83,30 +86,40 @@ func (h *CreateTaskHandler) handleCreate(w http.ResponseWriter, r *http.Request)
 		Description: req.Description,
 		Status:      status,
 		Priority:    priority,
-		Now:         h.nowFunc(),
+		AssigneeID:  req.AssigneeID,
+		DueDate:     dueDate,
+		MilestoneID: req.MilestoneID,
+		SprintID:    req.SprintID,
+		EpicID:      req.EpicID,
+		LabelIDs:    req.LabelIDs,
+		ActorID:     actorID(r),
+		Now:         h.clock.Now(),
 	}
 
 	t, err := h.createUC.Execute(r.Context(), in)
 	if err != nil {
-		// バリデーションエラーなどは 400 として扱う（簡易実装）
-		writeErrorResponse(w, http.StatusBadRequest, "validation error", err.Error())
+		// ドメインのバリデーションエラーのみ 400、それ以外（リポジトリ障害等）は 500
+		var ve *domain.ValidationError
+		if errors.As(err, &ve) {
+			writeErrorResponse(w, http.StatusBadRequest, "validation error", err.Error())
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			writeErrorResponse(w, http.StatusBadRequest, "invalid input", err.Error())
+			return
+		}
+		if writeAuthzError(w, err) {
+			return
+		}
+		if errors.Is(err, usecase.ErrTimeout) {
+			writeTimeoutResponse(w)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	resp := taskResponse{
-		ID:          t.ID,
-		ProjectID:   t.ProjectID,
-		Title:       t.Title,
-		Description: t.Description,
-		Status:      string(t.Status),   // ★ TaskStatus → string
-		Priority:    string(t.Priority), // ★ TaskPriority → string
-		AssigneeID:  t.AssigneeID,
-		DueDate:     t.DueDate,
-		CreatedAt:   t.CreatedAt,
-		UpdatedAt:   t.UpdatedAt,
-	}
-
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	_ = json.NewEncoder(w).Encode(resp)
+	_ = json.NewEncoder(w).Encode(toTaskResponse(t))
 }
@@ -9,6 +9,8 @@ import (
 	"testing"
 	"time"
 
+	"teamflow-shared/authz"
+
 	domain "teamflow-tasks/internal/domain/task"
 	taskinfra "teamflow-tasks/internal/infrastructure/task"
 	httpiface "teamflow-tasks/internal/interface/http"
@@ -20,7 +22,7 @@ func TestCreateTaskHandler_Success(t *testing.T) {
 
 	createUC := &usecase.CreateTaskUsecase{Repo: repo}
 
-	handler := httpiface.NewCreateTaskHandler(createUC, fixedNow)
+	handler := httpiface.NewCreateTaskHandler(createUC, fixedClock)
 
 	body := map[string]string{
 		"id":          "task-1",
@@ -38,6 +40,7 @@ func TestCreateTaskHandler_Success(t *testing.T) {
 
 	req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(b))
 	req = req.WithContext(context.Background())
+	req.Header.Set(authz.ActorHeader, "user-1")
 	w := httptest.NewRecorder()
 
 	handler.ServeHTTP(w, req)
@@ -58,6 +61,8 @@ func TestCreateTaskHandler_Success(t *testing.T) {
 		Priority    string    `json:"priority"`
 		CreatedAt   time.Time `json:"createdAt"`
 		UpdatedAt   time.Time `json:"updatedAt"`
+		CreatedBy   string    `json:"createdBy"`
+		UpdatedBy   string    `json:"updatedBy"`
 	}
 	if err := json.NewDecoder(res.Body).Decode(&respBody); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
@@ -78,6 +83,9 @@ func TestCreateTaskHandler_Success(t *testing.T) {
 	if respBody.Priority != body["priority"] {
 		t.Errorf("expected priority=%s, got=%s", body["priority"], respBody.Priority)
 	}
+	if respBody.CreatedBy != "user-1" || respBody.UpdatedBy != "user-1" {
+		t.Errorf("expected createdBy/updatedBy=user-1 (X-User-ID), got=%s/%s", respBody.CreatedBy, respBody.UpdatedBy)
+	}
 }
 
 func TestCreateTaskHandler_StatusDoingNormalized(t *testing.T) {
@@ -85,7 +93,7 @@ func TestCreateTaskHandler_StatusDoingNormalized(t *testing.T) {
 
 	createUC := &usecase.CreateTaskUsecase{Repo: repo}
 
-	handler := httpiface.NewCreateTaskHandler(createUC, fixedNow)
+	handler := httpiface.NewCreateTaskHandler(createUC, fixedClock)
 
 	body := map[string]string{
 		"id":          "task-1",
@@ -138,7 +146,7 @@ func TestCreateTaskHandler_InvalidJSON(t *testing.T) {
 	repo := taskinfra.NewMemoryTaskRepository()
 	createUC := &usecase.CreateTaskUsecase{Repo: repo}
 
-	handler := httpiface.NewCreateTaskHandler(createUC, fixedNow)
+	handler := httpiface.NewCreateTaskHandler(createUC, fixedClock)
 
 	req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader([]byte("{invalid")))
 	w := httptest.NewRecorder()
@@ -157,7 +165,7 @@ func TestCreateTaskHandler_ValidationError(t *testing.T) {
 	repo := taskinfra.NewMemoryTaskRepository()
 	createUC := &usecase.CreateTaskUsecase{Repo: repo}
 
-	handler := httpiface.NewCreateTaskHandler(createUC, fixedNow)
+	handler := httpiface.NewCreateTaskHandler(createUC, fixedClock)
 
 	// title を空にしてバリデーションエラーを引き起こす
 	body := map[string]string{
@@ -182,3 +190,158 @@ func TestCreateTaskHandler_ValidationError(t *testing.T) {
 		t.Fatalf("expected status 400, got %d", res.StatusCode)
 	}
 }
+
+// staticDefaults は固定の既定値を返す ProjectDefaultsProvider。
+type staticDefaults usecase.ProjectDefaults
+
+func (d staticDefaults) ProjectDefaults(_ context.Context, _ string) (usecase.ProjectDefaults, error) {
+	return usecase.ProjectDefaults(d), nil
+}
+
+func TestCreateTaskHandler_ProjectDefaults(t *testing.T) {
+	tests := []struct {
+		name         string
+		defaults     usecase.ProjectDefaultsProvider
+		wantStatus   int
+		wantPriority string
+		wantAssignee string
+	}{
+		{
+			name:         "omitted fields use project defaults",
+			defaults:     staticDefaults{Priority: domain.PriorityHigh, AssigneeID: "user-1"},
+			wantStatus:   http.StatusCreated,
+			wantPriority: "high",
+			wantAssignee: "user-1",
+		},
+		{
+			name:       "omitted priority without defaults",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			createUC := &usecase.CreateTaskUsecase{Repo: taskinfra.NewMemoryTaskRepository(), Defaults: tt.defaults}
+			handler := httpiface.NewCreateTaskHandler(createUC, fixedClock)
+
+			b, _ := json.Marshal(map[string]string{
+				"projectId": "proj-1",
+				"title":     "画面設計",
+				"status":    string(domain.StatusTodo),
+			})
+			req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(b))
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+			var respBody struct {
+				Priority   string  `json:"priority"`
+				AssigneeID *string `json:"assigneeId"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&respBody); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if respBody.Priority != tt.wantPriority {
+				t.Errorf("expected priority=%s, got=%s", tt.wantPriority, respBody.Priority)
+			}
+			if respBody.AssigneeID == nil || *respBody.AssigneeID != tt.wantAssignee {
+				t.Errorf("expected assigneeId=%s, got=%v", tt.wantAssignee, respBody.AssigneeID)
+			}
+		})
+	}
+}
+
+// staticMembers は "projectID/userID" のキーに含まれるユーザーをメンバーとみなす MembershipChecker。
+type staticMembers map[string]bool
+
+func (m staticMembers) IsMember(_ context.Context, projectID, userID string) (bool, error) {
+	return m[projectID+"/"+userID], nil
+}
+
+func TestCreateTaskHandler_Membership(t *testing.T) {
+	policy := &usecase.MembershipPolicy{Members: staticMembers{"proj-1/user-1": true}}
+
+	tests := []struct {
+		name       string
+		projectID  string
+		actorID    string
+		wantStatus int
+	}{
+		{name: "member", projectID: "proj-1", actorID: "user-1", wantStatus: http.StatusCreated},
+		{name: "no actor", projectID: "proj-1", actorID: "", wantStatus: http.StatusUnauthorized},
+		{name: "not a member", projectID: "proj-1", actorID: "user-2", wantStatus: http.StatusNotFound},
+		// 存在しないプロジェクトも、メンバーでないプロジェクトと同じ 404 にする
+		{name: "missing project", projectID: "missing", actorID: "user-1", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			createUC := &usecase.CreateTaskUsecase{Repo: taskinfra.NewMemoryTaskRepository(), Authorizer: policy}
+			handler := httpiface.NewCreateTaskHandler(createUC, fixedClock)
+
+			b, _ := json.Marshal(map[string]string{
+				"projectId": tt.projectID,
+				"title":     "画面設計",
+				"status":    string(domain.StatusTodo),
+				"priority":  string(domain.PriorityMedium),
+			})
+			req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(b))
+			if tt.actorID != "" {
+				req.Header.Set(authz.ActorHeader, tt.actorID)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCreateTaskHandler_DueDate(t *testing.T) {
+	tests := []struct {
+		name       string
+		dueDate    any
+		wantStatus int
+		want       *string
+	}{
+		{name: "omitted", dueDate: nil, wantStatus: http.StatusCreated},
+		{name: "date only", dueDate: "2026-01-10", wantStatus: http.StatusCreated, want: ptr("2026-01-10")},
+		{name: "rfc3339 with offset", dueDate: "2026-01-10T08:00:00+09:00", wantStatus: http.StatusCreated, want: ptr("2026-01-10")},
+		{name: "invalid format", dueDate: "10/01/2026", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := httpiface.NewCreateTaskHandler(&usecase.CreateTaskUsecase{Repo: taskinfra.NewMemoryTaskRepository()}, fixedClock)
+
+			b, _ := json.Marshal(map[string]any{
+				"id": "task-1", "projectId": "proj-1", "title": "画面設計", "status": "todo", "priority": "medium", "dueDate": tt.dueDate,
+			})
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(b)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+			var resp struct {
+				DueDate *string `json:"dueDate"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if (resp.DueDate == nil) != (tt.want == nil) || (resp.DueDate != nil && *resp.DueDate != *tt.want) {
+				t.Errorf("expected dueDate %v, got %v", tt.want, resp.DueDate)
+			}
+		})
+	}
+}
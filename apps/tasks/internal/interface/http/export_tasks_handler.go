@@ -0,0 +1,147 @@
+package http
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"teamflow-shared/apierror"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// exportSuffix はタスクのエクスポートのパスの末尾。
+const exportSuffix = "/tasks:export"
+
+// ExportFormatNDJSON はエクスポートの形式（1 行 1 件の JSON）。
+const ExportFormatNDJSON = "ndjson"
+
+// exportFlushRows はエクスポートの書き出しをフラッシュする間隔（行数）。
+const exportFlushRows = 100
+
+// ExportTasksHandler は GET /api/projects/{projectId}/tasks:export を処理する HTTP ハンドラ。
+//
+// 大きなプロジェクト向けに、一覧と同じフィルタ（status, priority, assigneeId, milestoneId, sprintId, epicId,
+// dueDateFrom, dueDateTo, q）に一致するタスクを件数の上限無しで、作成日時の昇順に format=ndjson（1 行 1 件の JSON、
+// 一覧の要素と同じ形式）で返す。すべてのタスクをメモリに保持せず、取得したページから 1 行ずつ書き出して
+// exportFlushRows 行ごとにフラッシュする。クライアントが切断した（リクエストの context が取り消された）場合は途中で終了する。
+//
+// 書き出しを始めた後はステータスを変えられないため、途中で失敗した場合はログに記録して接続を切る
+// （クライアントには不完全なレスポンスではなく、読み込みのエラーとして伝わる）。
+type ExportTasksHandler struct {
+	exportUC *usecase.ExportTasksUsecase
+}
+
+// NewExportTasksHandler は ExportTasksHandler を生成する。
+func NewExportTasksHandler(exportUC *usecase.ExportTasksUsecase) http.Handler {
+	return &ExportTasksHandler{exportUC: exportUC}
+}
+
+func (h *ExportTasksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	// /projects/{projectId}/tasks:export（/api を除いたパス）から projectId を抽出
+	projectID, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/projects/"), exportSuffix)
+	if !ok || projectID == "" || strings.Contains(projectID, "/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	values := r.URL.Query()
+	if format := values.Get("format"); format != "" && format != ExportFormatNDJSON {
+		apierror.Write(w, http.StatusBadRequest, NewValidationErrorResponse(ValidationIssue{
+			Location:      "query",
+			Field:         "format",
+			Code:          "INVALID_ENUM",
+			Message:       "format は ndjson で指定してください。",
+			RejectedValue: &format,
+		}))
+		return
+	}
+	opts, err := listFilterOptions(values)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "validation error", err.Error())
+		return
+	}
+	query, err := domain.NewTaskQuery(opts...)
+	if err == nil {
+		err = query.Validate()
+	}
+	if err != nil {
+		apierror.Write(w, http.StatusBadRequest, NewValidationErrorResponse(toValidationIssue(err)))
+		return
+	}
+
+	tasks, err := h.exportUC.Execute(r.Context(), usecase.ExportTasksInput{
+		ProjectID: projectID,
+		Query:     query,
+		ActorID:   actorID(r),
+	})
+	if err != nil {
+		if writeAuthzError(w, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to export tasks", "project_id", projectID, "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// 大きなプロジェクトは書き出しに時間がかかるため、サーバーの WriteTimeout を解除する
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	n := 0
+	for t, err := range tasks {
+		if err == nil {
+			err = enc.Encode(toTaskResponse(t))
+		}
+		if err == nil {
+			if n++; n%exportFlushRows == 0 {
+				err = flushExport(bw, rc)
+			}
+		}
+		if err != nil {
+			abortExport(r, projectID, n, err)
+		}
+	}
+	if err := flushExport(bw, rc); err != nil {
+		abortExport(r, projectID, n, err)
+	}
+}
+
+// flushExport はバッファした行をクライアントに送る。
+func flushExport(bw *bufio.Writer, rc *http.ResponseController) error {
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// abortExport は書き出しの途中の失敗を記録し、接続を切る（200 のまま正常に終わったように見せない）。
+// クライアントの切断による取り消しはエラーとして記録しない。
+func abortExport(r *http.Request, projectID string, rows int, err error) {
+	if r.Context().Err() != nil {
+		slog.InfoContext(r.Context(), "task export canceled by the client", "project_id", projectID, "rows", rows)
+	} else {
+		slog.ErrorContext(r.Context(), "failed to export tasks", "project_id", projectID, "rows", rows, "error", err)
+	}
+	panic(http.ErrAbortHandler)
+}
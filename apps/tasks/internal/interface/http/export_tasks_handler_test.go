@@ -0,0 +1,148 @@
+package http_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	domain "teamflow-tasks/internal/domain/task"
+	taskinfra "teamflow-tasks/internal/infrastructure/task"
+	httpiface "teamflow-tasks/internal/interface/http"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// flushRecorder は Flush の回数を数え、Flush のたびに onFlush を呼ぶ ResponseRecorder。
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+	flushes int
+	onFlush func()
+}
+
+func (r *flushRecorder) Flush() {
+	r.flushes++
+	r.ResponseRecorder.Flush()
+	if r.onFlush != nil {
+		r.onFlush()
+	}
+}
+
+// newExportHandler は 1 ページ（ExportPageSize）に収まらない件数のタスクを持つプロジェクトのエクスポートのハンドラを返す。
+func newExportHandler(t *testing.T, count int) http.Handler {
+	t.Helper()
+	now := fixedNow()
+	repo := taskinfra.NewMemoryTaskRepository()
+	for i := range count {
+		created := now.Add(time.Duration(i) * time.Second)
+		task := &domain.Task{ID: fmt.Sprintf("t%03d", i), ProjectID: "proj-1", Title: fmt.Sprintf("タスク %d", i), Status: domain.StatusTodo, Priority: domain.PriorityLow, CreatedAt: created, UpdatedAt: created}
+		if err := repo.Save(context.Background(), task); err != nil {
+			t.Fatalf("failed to save task: %v", err)
+		}
+	}
+	return httpiface.NewExportTasksHandler(&usecase.ExportTasksUsecase{
+		Repo:   repo,
+		Access: privateProjectAccess{private: "proj-1", member: "user-1"},
+	})
+}
+
+func TestExportTasksHandler_StreamsNDJSON(t *testing.T) {
+	count := usecase.ExportPageSize + 50
+	handler := newExportHandler(t, count)
+
+	req := httptest.NewRequest(http.MethodGet, "/projects/proj-1/tasks:export?format=ndjson", nil)
+	req.Header.Set("X-User-ID", "user-1")
+	w := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("expected Content-Type application/x-ndjson, got %q", ct)
+	}
+	// 全件を書き出すまで待たず、途中でもフラッシュする
+	if w.flushes < 2 {
+		t.Errorf("expected periodic flushes, got %d", w.flushes)
+	}
+
+	sc := bufio.NewScanner(w.Body)
+	n := 0
+	for sc.Scan() {
+		var row struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(sc.Bytes(), &row); err != nil {
+			t.Fatalf("line %d is not JSON: %v", n+1, err)
+		}
+		if want := fmt.Sprintf("t%03d", n); row.ID != want {
+			t.Fatalf("line %d: expected %s, got %s", n+1, want, row.ID)
+		}
+		n++
+	}
+	if n != count {
+		t.Errorf("expected %d rows, got %d", count, n)
+	}
+}
+
+func TestExportTasksHandler_StopsOnClientCancel(t *testing.T) {
+	count := usecase.ExportPageSize + 50
+	handler := newExportHandler(t, count)
+
+	// 最初のフラッシュの後にクライアントが切断する
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/projects/proj-1/tasks:export", nil).WithContext(ctx)
+	req.Header.Set("X-User-ID", "user-1")
+	w := &flushRecorder{ResponseRecorder: httptest.NewRecorder(), onFlush: cancel}
+
+	func() {
+		defer func() {
+			// 途中で終わったことを 200 の正常な終了に見せないよう、接続を切る
+			if rec := recover(); rec != http.ErrAbortHandler {
+				t.Errorf("expected panic with http.ErrAbortHandler, got %v", rec)
+			}
+		}()
+		handler.ServeHTTP(w, req)
+	}()
+
+	rows := 0
+	sc := bufio.NewScanner(w.Body)
+	for sc.Scan() {
+		rows++
+	}
+	if rows == 0 || rows >= count {
+		t.Errorf("expected the export to stop early, got %d of %d rows", rows, count)
+	}
+}
+
+func TestExportTasksHandler_Errors(t *testing.T) {
+	handler := newExportHandler(t, 1)
+	tests := []struct {
+		name     string
+		target   string
+		actor    string
+		wantCode int
+	}{
+		{name: "unsupported format", target: "/projects/proj-1/tasks:export?format=csv", actor: "user-1", wantCode: http.StatusBadRequest},
+		{name: "invalid filter", target: "/projects/proj-1/tasks:export?dueDateFrom=tomorrow", actor: "user-1", wantCode: http.StatusBadRequest},
+		{name: "no actor", target: "/projects/proj-1/tasks:export", wantCode: http.StatusUnauthorized},
+		{name: "not a member", target: "/projects/proj-1/tasks:export", actor: "user-2", wantCode: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.actor != "" {
+				req.Header.Set("X-User-ID", tt.actor)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code != tt.wantCode {
+				t.Errorf("expected %d, got %d: %s", tt.wantCode, w.Code, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// GetTaskByNumberHandler は GET /api/projects/{projectId}/tasks/number/{n} を処理する HTTP ハンドラ。
+// タスク ID の代わりにプロジェクト内のタスク番号（TFLOW-123 の 123）でタスクを取得する。
+type GetTaskByNumberHandler struct {
+	getUC *usecase.GetTaskByNumberUsecase
+}
+
+// NewGetTaskByNumberHandler は GetTaskByNumberHandler を生成する。
+func NewGetTaskByNumberHandler(getUC *usecase.GetTaskByNumberUsecase) http.Handler {
+	return &GetTaskByNumberHandler{getUC: getUC}
+}
+
+func (h *GetTaskByNumberHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectID, numberStr, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/projects/"), "/tasks/number/")
+	if !ok || projectID == "" || strings.Contains(projectID, "/") || numberStr == "" || strings.Contains(numberStr, "/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	number, err := strconv.Atoi(numberStr)
+	if err != nil || number < 1 {
+		writeErrorResponse(w, http.StatusBadRequest, "validation error", "number must be a positive integer")
+		return
+	}
+
+	t, err := h.getUC.Execute(r.Context(), projectID, number, actorID(r))
+	if err != nil {
+		if errors.Is(err, usecase.ErrTaskNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if writeAuthzError(w, err) {
+			return
+		}
+		if errors.Is(err, usecase.ErrTimeout) {
+			writeTimeoutResponse(w)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toTaskResponse(t))
+}
@@ -0,0 +1,117 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "teamflow-tasks/internal/domain/task"
+	taskinfra "teamflow-tasks/internal/infrastructure/task"
+	httpiface "teamflow-tasks/internal/interface/http"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+func TestGetTaskByNumberHandler(t *testing.T) {
+	now := fixedNow()
+	repo := taskinfra.NewMemoryTaskRepository()
+	for _, task := range []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", Title: "設計", Status: domain.StatusTodo, Priority: domain.PriorityHigh, CreatedAt: now, UpdatedAt: now},
+		{ID: "t2", ProjectID: "proj-1", Title: "実装", Status: domain.StatusTodo, Priority: domain.PriorityLow, CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := repo.Save(context.Background(), task); err != nil {
+			t.Fatalf("failed to save task: %v", err)
+		}
+	}
+	handler := httpiface.NewGetTaskByNumberHandler(&usecase.GetTaskByNumberUsecase{Repo: repo})
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		wantCode int
+		wantID   string
+	}{
+		{name: "found", method: http.MethodGet, path: "/projects/proj-1/tasks/number/2", wantCode: http.StatusOK, wantID: "t2"},
+		{name: "not found", method: http.MethodGet, path: "/projects/proj-1/tasks/number/3", wantCode: http.StatusNotFound},
+		{name: "other project", method: http.MethodGet, path: "/projects/proj-2/tasks/number/1", wantCode: http.StatusNotFound},
+		{name: "not a number", method: http.MethodGet, path: "/projects/proj-1/tasks/number/abc", wantCode: http.StatusBadRequest},
+		{name: "zero", method: http.MethodGet, path: "/projects/proj-1/tasks/number/0", wantCode: http.StatusBadRequest},
+		{name: "method not allowed", method: http.MethodPatch, path: "/projects/proj-1/tasks/number/1", wantCode: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d", tt.wantCode, w.Code)
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			var got struct {
+				ID     string `json:"id"`
+				Number int    `json:"number"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.ID != tt.wantID || got.Number != 2 {
+				t.Errorf("unexpected task: %+v", got)
+			}
+		})
+	}
+}
+
+// privateProjectAccess は private のプロジェクトを member だけが閲覧できるとみなす ProjectAccessChecker。
+type privateProjectAccess struct {
+	private, member string
+}
+
+func (a privateProjectAccess) AuthorizeRead(_ context.Context, projectID, actorID string) error {
+	switch {
+	case projectID != a.private:
+		return nil
+	case actorID == "":
+		return usecase.ErrActorRequired
+	case actorID != a.member:
+		return usecase.ErrForbidden
+	}
+	return nil
+}
+
+func TestGetTaskByNumberHandler_Access(t *testing.T) {
+	now := fixedNow()
+	repo := taskinfra.NewMemoryTaskRepository()
+	if err := repo.Save(context.Background(), &domain.Task{ID: "t1", ProjectID: "proj-1", Title: "設計", Status: domain.StatusTodo, Priority: domain.PriorityHigh, CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("failed to save task: %v", err)
+	}
+	handler := httpiface.NewGetTaskByNumberHandler(&usecase.GetTaskByNumberUsecase{
+		Repo:   repo,
+		Access: privateProjectAccess{private: "proj-1", member: "user-1"},
+	})
+
+	for _, tt := range []struct {
+		actor    string
+		wantCode int
+	}{
+		{actor: "user-1", wantCode: http.StatusOK},
+		{actor: "user-2", wantCode: http.StatusForbidden},
+		{actor: "", wantCode: http.StatusUnauthorized},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/projects/proj-1/tasks/number/1", nil)
+		if tt.actor != "" {
+			req.Header.Set("X-User-ID", tt.actor)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != tt.wantCode {
+			t.Errorf("actor %q: expected status %d, got %d", tt.actor, tt.wantCode, w.Code)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/clock"
+	"teamflow-shared/workspace"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// InspectCursorHandler は POST /api/admin/cursor/inspect を処理する HTTP ハンドラ。
+//
+// 運用者（ADMIN_USER_IDS）がページネーションの不具合を調査するために、一覧の cursor を署名を信頼せずにデコードし、
+// payload・署名・有効期限と、指定したクエリ（projectId と一覧のクエリ文字列）の qhash との一致を返す。
+// qhash にはワークスペースが含まれるため、cursor を使ったリクエストと同じ X-Workspace-ID で呼び出す。
+type InspectCursorHandler struct {
+	inspectUC *usecase.InspectCursorUsecase
+	clock     clock.Clock
+}
+
+// NewInspectCursorHandler は InspectCursorHandler を生成する。
+func NewInspectCursorHandler(inspectUC *usecase.InspectCursorUsecase, clk clock.Clock) http.Handler {
+	return &InspectCursorHandler{inspectUC: inspectUC, clock: clk}
+}
+
+type inspectCursorRequest struct {
+	Cursor string `json:"cursor"`
+	// ProjectID は cursor を使った一覧のプロジェクト。任意。空の場合はクエリと比較しない
+	ProjectID string `json:"projectId"`
+	// Query は cursor を使った一覧のクエリ文字列（例: status=todo&q=設計）。sort / cursor / limit は無視する
+	Query string `json:"query"`
+}
+
+type cursorPayloadResponse struct {
+	V         int    `json:"v"`
+	CreatedAt string `json:"createdAt"`
+	ID        string `json:"id"`
+	ProjectID string `json:"projectId"`
+	QHash     string `json:"qhash"`
+	QV        int    `json:"qv"`
+	IssuedAt  int64  `json:"iat"`
+	Dir       string `json:"dir,omitempty"`
+}
+
+type cursorQueryMatchResponse struct {
+	ProjectID        string `json:"projectId"`
+	CanonicalQuery   string `json:"canonicalQuery"`
+	QHash            string `json:"qhash"`
+	ProjectIDMatches bool   `json:"projectIdMatches"`
+	QVMatches        bool   `json:"qvMatches"`
+	QHashMatches     bool   `json:"qhashMatches"`
+	// Matches は一覧でこの cursor を受け付けるかどうか（false の場合は QUERY_MISMATCH）
+	Matches bool `json:"matches"`
+}
+
+type inspectCursorResponse struct {
+	Payload        cursorPayloadResponse `json:"payload"`
+	SignatureValid bool                  `json:"signatureValid"`
+	IssuedAt       time.Time             `json:"issuedAt"`
+	ExpiresAt      time.Time             `json:"expiresAt"`
+	Expired        bool                  `json:"expired"`
+	// CurrentQV はサービスの qhash の正規化仕様のバージョン（payload.qv と異なる cursor は QUERY_MISMATCH）
+	CurrentQV int `json:"currentQv"`
+	// Query はクエリとの比較結果。projectId を指定しなかった場合は null
+	Query *cursorQueryMatchResponse `json:"query"`
+}
+
+func (h *InspectCursorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req inspectCursorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "invalid json", err.Error())
+		return
+	}
+	if req.Cursor == "" {
+		writeBodyValidationError(w, ValidationIssue{Field: "cursor", Code: "REQUIRED", Message: "cursor は必須です。"})
+		return
+	}
+
+	// 一覧（handleListByProjectWithQuery）と同じ手順で Query Object を組み立てる
+	var query *domain.TaskQuery
+	if req.ProjectID != "" {
+		values, err := url.ParseQuery(req.Query)
+		if err != nil {
+			writeBodyValidationError(w, ValidationIssue{Field: "query", Code: "INVALID_FORMAT", Message: "query は一覧のクエリ文字列（例: status=todo&q=設計）で指定してください。"})
+			return
+		}
+		opts, err := listFilterOptions(values)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "validation error", err.Error())
+			return
+		}
+		opts = append(opts, domain.WithWorkspace(workspace.FromContext(r.Context())))
+		query, err = domain.NewTaskQuery(opts...)
+		if err != nil {
+			writeBodyValidationError(w, toValidationIssue(err))
+			return
+		}
+	}
+
+	out, err := h.inspectUC.Execute(r.Context(), usecase.InspectCursorInput{
+		Cursor:    req.Cursor,
+		ProjectID: req.ProjectID,
+		Query:     query,
+		ActorID:   actorID(r),
+		Now:       h.clock.Now(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrActorRequired):
+			apierror.Write(w, http.StatusUnauthorized, apierror.New(apierror.CodeUnauthorized, "X-User-ID header is required"))
+		case errors.Is(err, usecase.ErrForbidden):
+			apierror.Write(w, http.StatusForbidden, apierror.New(apierror.CodeForbidden, "the actor is not an operator"))
+		case errors.Is(err, domain.ErrCursorInvalidFormat):
+			writeBodyValidationError(w, toValidationIssue(err))
+		default:
+			slog.ErrorContext(r.Context(), "failed to inspect cursor", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	p := out.Cursor.Payload
+	resp := inspectCursorResponse{
+		Payload: cursorPayloadResponse{
+			V: p.V, CreatedAt: p.CreatedAt, ID: p.ID, ProjectID: p.ProjectID, QHash: p.QHash, QV: p.QV, IssuedAt: p.IssuedAt, Dir: p.Dir,
+		},
+		SignatureValid: out.Cursor.SignatureValid,
+		IssuedAt:       out.Cursor.IssuedAt,
+		ExpiresAt:      out.Cursor.ExpiresAt,
+		Expired:        out.Cursor.Expired,
+		CurrentQV:      domain.QHashVersion,
+	}
+	if m := out.Query; m != nil {
+		resp.Query = &cursorQueryMatchResponse{
+			ProjectID:        req.ProjectID,
+			CanonicalQuery:   m.CanonicalQuery,
+			QHash:            m.QHash,
+			ProjectIDMatches: m.ProjectIDMatches,
+			QVMatches:        m.QVMatches,
+			QHashMatches:     m.QHashMatches,
+			Matches:          m.Matches(),
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// writeBodyValidationError はリクエストボディの項目の誤りを 400 + VALIDATION_ERROR で書き込む。
+func writeBodyValidationError(w http.ResponseWriter, issue ValidationIssue) {
+	issue.Location = apierror.LocationBody
+	apierror.Write(w, http.StatusBadRequest, apierror.New(apierror.CodeValidation, "Invalid request body", issue))
+}
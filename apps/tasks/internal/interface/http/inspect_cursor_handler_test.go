@@ -0,0 +1,100 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"teamflow-shared/authz"
+	"teamflow-shared/workspace"
+
+	domain "teamflow-tasks/internal/domain/task"
+	httpiface "teamflow-tasks/internal/interface/http"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+func TestInspectCursorHandler(t *testing.T) {
+	secret := []byte("test-secret")
+	issued, err := domain.NewTaskQuery(domain.WithStatusFilter("todo,in_progress"), domain.WithWorkspace(workspace.DefaultID))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cursor, err := domain.EncodeCursor(domain.CursorPayload{
+		V: 1, CreatedAt: "2025-01-01T00:00:00Z", ID: "task-1", ProjectID: "proj-1",
+		QHash: issued.ComputeQHash("proj-1"), QV: domain.QHashVersion, IssuedAt: fixedNow().Add(-25 * time.Hour).Unix(),
+	}, secret)
+	if err != nil {
+		t.Fatalf("failed to encode cursor: %v", err)
+	}
+	handler := httpiface.NewInspectCursorHandler(&usecase.InspectCursorUsecase{
+		Operators: authz.ParseOperators("ops-1"),
+		Secret:    secret,
+	}, fixedClock)
+
+	tests := []struct {
+		name        string
+		actor       string
+		body        string
+		wantStatus  int
+		wantMatches *bool
+	}{
+		{name: "same query", actor: "ops-1", body: `{"cursor":"` + cursor + `","projectId":"proj-1","query":"status=in_progress,todo&sort=-createdAt"}`, wantStatus: http.StatusOK, wantMatches: ptr(true)},
+		{name: "other filter", actor: "ops-1", body: `{"cursor":"` + cursor + `","projectId":"proj-1","query":"status=todo"}`, wantStatus: http.StatusOK, wantMatches: ptr(false)},
+		{name: "without query", actor: "ops-1", body: `{"cursor":"` + cursor + `"}`, wantStatus: http.StatusOK},
+		{name: "no actor", body: `{"cursor":"` + cursor + `"}`, wantStatus: http.StatusUnauthorized},
+		{name: "not an operator", actor: "user-1", body: `{"cursor":"` + cursor + `"}`, wantStatus: http.StatusForbidden},
+		{name: "missing cursor", actor: "ops-1", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid cursor", actor: "ops-1", body: `{"cursor":"garbage"}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid filter", actor: "ops-1", body: `{"cursor":"` + cursor + `","projectId":"proj-1","query":"status=unknown"}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid json", actor: "ops-1", body: `{`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/admin/cursor/inspect", strings.NewReader(tt.body))
+			if tt.actor != "" {
+				req.Header.Set(authz.ActorHeader, tt.actor)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			var got struct {
+				Payload struct {
+					ID string `json:"id"`
+				} `json:"payload"`
+				SignatureValid bool `json:"signatureValid"`
+				Expired        bool `json:"expired"`
+				Query          *struct {
+					ProjectIDMatches bool `json:"projectIdMatches"`
+					QHashMatches     bool `json:"qhashMatches"`
+					Matches          bool `json:"matches"`
+				} `json:"query"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.Payload.ID != "task-1" || !got.SignatureValid || !got.Expired {
+				t.Errorf("unexpected inspection: %+v", got)
+			}
+			if tt.wantMatches == nil {
+				if got.Query != nil {
+					t.Errorf("expected no query comparison, got %+v", got.Query)
+				}
+				return
+			}
+			if got.Query == nil || got.Query.Matches != *tt.wantMatches || got.Query.QHashMatches != *tt.wantMatches || !got.Query.ProjectIDMatches {
+				t.Errorf("unexpected query comparison: %+v", got.Query)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -1,11 +1,22 @@
 package http
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
+	"teamflow-shared/apierror"
+	"teamflow-shared/clock"
+	"teamflow-shared/uuidpolicy"
+	"teamflow-shared/workspace"
+
 	domain "teamflow-tasks/internal/domain/task"
 	usecase "teamflow-tasks/internal/usecase/task"
 )
@@ -15,27 +26,45 @@ import (
 // 責務:
 //   - GET /api/tasks?projectId=xxx エンドポイントのリクエストを受け付ける（旧API、後方互換性のため）
 //   - GET /api/projects/{projectId}/tasks エンドポイントのリクエストを受け付ける（新API）
-//   - クエリパラメータ（status, priority, assigneeId, dueDateFrom, dueDateTo, q, sort, cursor, limit）をパースし、TaskQueryを構築する
+//   - クエリパラメータ（status, priority, assigneeId, milestoneId, dueDateFrom, dueDateTo, q, sort, cursor, limit）をパースし、TaskQueryを構築する
 //   - ListTasksByProjectUsecaseを呼び出してタスク一覧を取得する
 //   - カーソルページネーションの場合はnextCursorを計算してレスポンスに含める
+//   - プロジェクトの一覧のバージョンから ETag を計算し、If-None-Match が一致すれば一覧を取得せずに 304 を返す
 //   - 取得したタスク一覧をJSONレスポンスとして返す
 type ListTaskHandler struct {
 	listUC       *usecase.ListTasksByProjectUsecase
-	nowFunc      func() time.Time
+	clock        clock.Clock
 	cursorSecret []byte
+	pageLimits   domain.PageLimits
+}
+
+// ListTaskHandlerOption は ListTaskHandler の任意の設定。
+type ListTaskHandlerOption func(*ListTaskHandler)
+
+// WithListPageLimits は limit の既定値と上限を設定する。省略時は domain.DefaultPageLimits。
+func WithListPageLimits(limits domain.PageLimits) ListTaskHandlerOption {
+	return func(h *ListTaskHandler) {
+		h.pageLimits = limits
+	}
 }
 
 // NewListTaskHandler は ListTaskHandler を生成する。
 func NewListTaskHandler(
 	listUC *usecase.ListTasksByProjectUsecase,
-	nowFunc func() time.Time,
+	clk clock.Clock,
 	cursorSecret []byte,
+	opts ...ListTaskHandlerOption,
 ) http.Handler {
-	return &ListTaskHandler{
+	h := &ListTaskHandler{
 		listUC:       listUC,
-		nowFunc:      nowFunc,
+		clock:        clk,
 		cursorSecret: cursorSecret,
+		pageLimits:   domain.DefaultPageLimits,
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
 }
 
 func (h *ListTaskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -44,19 +73,19 @@ func (h *ListTaskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// /api/projects/{projectId}/tasks の処理
-	if strings.HasPrefix(r.URL.Path, "/api/projects/") && strings.HasSuffix(r.URL.Path, "/tasks") {
-		// /api/projects/{projectId}/tasks から projectId を抽出
-		path := strings.TrimPrefix(r.URL.Path, "/api/projects/")
+	// パスは Router で /api を除いたもの
+	// /projects/{projectId}/tasks の処理
+	if strings.HasPrefix(r.URL.Path, "/projects/") && strings.HasSuffix(r.URL.Path, "/tasks") {
+		// /projects/{projectId}/tasks から projectId を抽出
+		path := strings.TrimPrefix(r.URL.Path, "/projects/")
 		path = strings.TrimSuffix(path, "/tasks")
 		projectID := path
 		h.handleListByProjectWithQuery(w, r, projectID)
 		return
 	}
 
-	// /api/tasks?projectId=xxx の処理（旧API、後方互換性のため残す）
-	// /tasks も後方互換性のためサポート
-	if r.URL.Path == "/api/tasks" || r.URL.Path == "/tasks" {
+	// /tasks?projectId=xxx の処理（旧API、後方互換性のため残す）
+	if r.URL.Path == "/tasks" {
 		h.handleListByProject(w, r)
 		return
 	}
@@ -82,26 +111,26 @@ func (h *ListTaskHandler) handleListByProject(w http.ResponseWriter, r *http.Req
 		ProjectID:  projectID,
 		Status:     status,
 		AssigneeID: assigneeId,
+		ActorID:    actorID(r),
 	})
 	if err != nil {
+		if errors.Is(err, usecase.ErrTimeout) {
+			writeTimeoutResponse(w)
+			return
+		}
+		if writeAuthzError(w, err) {
+			return
+		}
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
+	names := h.assigneeNames(r, tasks)
 	responses := make([]taskResponse, 0, len(tasks))
 	for _, t := range tasks {
-		responses = append(responses, taskResponse{
-			ID:          t.ID,
-			ProjectID:   t.ProjectID,
-			Title:       t.Title,
-			Description: t.Description,
-			Status:      string(t.Status),   // ★ ここも string に変換
-			Priority:    string(t.Priority), // ★
-			AssigneeID:  t.AssigneeID,
-			DueDate:     t.DueDate,
-			CreatedAt:   t.CreatedAt,
-			UpdatedAt:   t.UpdatedAt,
-		})
+		resp := toTaskResponse(t)
+		resp.AssigneeName = assigneeName(names, t.AssigneeID)
+		responses = append(responses, resp)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -110,50 +139,75 @@ func (h *ListTaskHandler) handleListByProject(w http.ResponseWriter, r *http.Req
 }
 
 // handleListByProjectWithQuery は /projects/{projectId}/tasks を処理する（Query Objectを使用）。
-func (h *ListTaskHandler) handleListByProjectWithQuery(w http.ResponseWriter, r *http.Request, projectID string) {
-	if h.listUC == nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	if projectID == "" {
-		writeErrorResponse(w, http.StatusBadRequest, "validation error", "projectId is required")
-		return
-	}
-
-	// Query Object を構築
+// listFilterOptions は一覧のクエリパラメータのうち、qhash に含めるフィルタの Query Object のオプションを返す。
+// sort / cursor / limit / ワークスペースは含めない。assigneeId が UUID でない場合はエラーを返す。
+func listFilterOptions(values url.Values) ([]domain.TaskQueryOption, error) {
 	opts := []domain.TaskQueryOption{}
 
 	// status フィルタ（カンマ区切り）
-	if statusStr := r.URL.Query().Get("status"); statusStr != "" {
+	if statusStr := values.Get("status"); statusStr != "" {
 		opts = append(opts, domain.WithStatusFilter(statusStr))
 	}
 
 	// priority フィルタ（カンマ区切り）
-	if priorityStr := r.URL.Query().Get("priority"); priorityStr != "" {
+	if priorityStr := values.Get("priority"); priorityStr != "" {
 		opts = append(opts, domain.WithPriorityFilter(priorityStr))
 	}
 
 	// assigneeId フィルタ
-	if assigneeID := r.URL.Query().Get("assigneeId"); assigneeID != "" {
-		if !isValidUUID(assigneeID) {
-			writeErrorResponse(w, http.StatusBadRequest, "validation error", "assigneeId must be a valid UUID")
-			return
+	if assigneeID := values.Get("assigneeId"); assigneeID != "" {
+		if !uuidpolicy.Any.Valid(assigneeID) {
+			return nil, errors.New("assigneeId must be a valid UUID")
 		}
 		opts = append(opts, domain.WithAssigneeIDFilter(assigneeID))
 	}
 
+	// milestoneId フィルタ
+	if milestoneID := values.Get("milestoneId"); milestoneID != "" {
+		opts = append(opts, domain.WithMilestoneIDFilter(milestoneID))
+	}
+
+	// sprintId フィルタ
+	if sprintID := values.Get("sprintId"); sprintID != "" {
+		opts = append(opts, domain.WithSprintIDFilter(sprintID))
+	}
+
+	// epicId フィルタ（エピックに属するタスクをプロジェクト全体から取得する）
+	if epicID := values.Get("epicId"); epicID != "" {
+		opts = append(opts, domain.WithEpicIDFilter(epicID))
+	}
+
 	// dueDateFrom / dueDateTo フィルタ
-	dueDateFrom := r.URL.Query().Get("dueDateFrom")
-	dueDateTo := r.URL.Query().Get("dueDateTo")
+	dueDateFrom := values.Get("dueDateFrom")
+	dueDateTo := values.Get("dueDateTo")
 	if dueDateFrom != "" || dueDateTo != "" {
 		opts = append(opts, domain.WithDueDateRangeFilter(dueDateFrom, dueDateTo))
 	}
 
 	// q フィルタ（タイトル検索）
-	if queryStr := r.URL.Query().Get("q"); queryStr != "" {
+	if queryStr := values.Get("q"); queryStr != "" {
 		opts = append(opts, domain.WithQueryFilter(queryStr))
 	}
+	return opts, nil
+}
+
+func (h *ListTaskHandler) handleListByProjectWithQuery(w http.ResponseWriter, r *http.Request, projectID string) {
+	if h.listUC == nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if projectID == "" {
+		writeErrorResponse(w, http.StatusBadRequest, "validation error", "projectId is required")
+		return
+	}
+
+	// Query Object を構築
+	opts, err := listFilterOptions(r.URL.Query())
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "validation error", err.Error())
+		return
+	}
 
 	// cursor と sort の併用チェック（cursor がある場合、sort は指定不可）
 	cursor := r.URL.Query().Get("cursor")
@@ -167,10 +221,7 @@ func (h *ListTaskHandler) handleListByProjectWithQuery(w http.ResponseWriter, r
 			Message:       "cursor を使用する場合、sort は指定できません。",
 			RejectedValue: &rejected,
 		}
-		resp := NewValidationErrorResponse(issue)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(resp)
+		apierror.Write(w, http.StatusBadRequest, NewValidationErrorResponse(issue))
 		return
 	}
 
@@ -179,46 +230,53 @@ func (h *ListTaskHandler) handleListByProjectWithQuery(w http.ResponseWriter, r
 		opts = append(opts, domain.WithSort(sortStr))
 	}
 
+	// ワークスペース（qhash に含めるため cursor より前に指定する）
+	opts = append(opts, domain.WithWorkspace(workspace.FromContext(r.Context())))
+
 	// cursor（cursor がある場合）
 	if cursor != "" {
-		opts = append(opts, domain.WithCursor(cursor, projectID, h.cursorSecret, h.nowFunc()))
+		opts = append(opts, domain.WithCursor(cursor, projectID, h.cursorSecret, h.clock.Now()))
 	}
 
-	// limit の default=200 を HTTP 層で明示
-	limit := 200
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		v, err := ParseLimit(limitStr)
-		if err != nil {
-			issue := toValidationIssue(err)
-			resp := NewValidationErrorResponse(issue)
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_ = json.NewEncoder(w).Encode(resp)
-			return
-		}
-		// ParseLimit 成功時は v>0 のはず
-		limit = v
+	// limit（未指定・1 未満は既定値、上限を超える値は上限に NewTaskQuery で正規化する）
+	limit, err := ParseLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		issue := toValidationIssue(err)
+		apierror.Write(w, http.StatusBadRequest, NewValidationErrorResponse(issue))
+		return
 	}
-	opts = append(opts, domain.WithLimit(limit))
+	opts = append(opts, domain.WithPageLimits(h.pageLimits), domain.WithLimit(limit))
 
 	// Query Object を作成
 	query, err := domain.NewTaskQuery(opts...)
 	if err != nil {
 		issue := toValidationIssue(err)
-		resp := NewValidationErrorResponse(issue)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(resp)
+		apierror.Write(w, http.StatusBadRequest, NewValidationErrorResponse(issue))
 		return
 	}
 
 	// Query Object のバリデーション
 	if err := query.Validate(); err != nil {
 		issue := toValidationIssue(err)
-		resp := NewValidationErrorResponse(issue)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(resp)
+		apierror.Write(w, http.StatusBadRequest, NewValidationErrorResponse(issue))
+		return
+	}
+
+	// ボードのポーリング向けに、一覧のバージョン（件数と最新の updatedAt）だけを先に取得して ETag を計算し、
+	// If-None-Match が一致すれば一覧を取得せずに 304 を返す。一覧より先に取得するため、間に変更があっても次の取得で反映される
+	version, err := h.listUC.ProjectVersion(r.Context(), usecase.ProjectVersionInput{
+		ProjectID: projectID,
+		ActorID:   actorID(r),
+	})
+	if err != nil {
+		writeListError(w, err)
+		return
+	}
+	etag := listETag(workspace.FromContext(r.Context()), projectID, r.URL.RawQuery, version, h.clock.Now())
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
 		return
 	}
 
@@ -226,9 +284,10 @@ func (h *ListTaskHandler) handleListByProjectWithQuery(w http.ResponseWriter, r
 	tasks, err := h.listUC.ExecuteWithQuery(r.Context(), usecase.ListTasksByProjectWithQueryInput{
 		ProjectID: projectID,
 		Query:     query,
+		ActorID:   actorID(r),
 	})
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		writeListError(w, err)
 		return
 	}
 
@@ -243,20 +302,12 @@ func (h *ListTaskHandler) handleListByProjectWithQuery(w http.ResponseWriter, r
 		Page  *pageInfo      `json:"page,omitempty"`
 	}
 
+	names := h.assigneeNames(r, tasks)
 	responses := make([]taskResponse, 0, len(tasks))
 	for _, t := range tasks {
-		responses = append(responses, taskResponse{
-			ID:          t.ID,
-			ProjectID:   t.ProjectID,
-			Title:       t.Title,
-			Description: t.Description,
-			Status:      string(t.Status),
-			Priority:    string(t.Priority),
-			AssigneeID:  t.AssigneeID,
-			DueDate:     t.DueDate,
-			CreatedAt:   t.CreatedAt,
-			UpdatedAt:   t.UpdatedAt,
-		})
+		resp := toTaskResponse(t)
+		resp.AssigneeName = assigneeName(names, t.AssigneeID)
+		responses = append(responses, resp)
 	}
 
 	// nextCursor の計算
@@ -266,15 +317,8 @@ func (h *ListTaskHandler) handleListByProjectWithQuery(w http.ResponseWriter, r
 	// 1ページ目（cursor なし）でも次ページがあれば nextCursor を返す
 	if len(tasks) > query.Limit {
 		// limit 件目（インデックス query.Limit-1）を使って nextCursor を生成
-		lastTask := tasks[query.Limit-1]
-		payload := domain.CursorPayload{
-			V:         1,
-			CreatedAt: domain.FormatCursorCreatedAt(lastTask.CreatedAt),
-			ID:        lastTask.ID,
-			ProjectID: projectID,
-			QHash:     query.ComputeQHash(projectID),
-			IssuedAt:  h.nowFunc().Unix(),
-		}
+		// sort=-createdAt（または desc の cursor）の場合は新しい順の続きを指す cursor になる
+		payload := query.NextCursorPayload(projectID, tasks[query.Limit-1], h.clock.Now())
 		cursor, err := domain.EncodeCursor(payload, h.cursorSecret)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -299,3 +343,59 @@ func (h *ListTaskHandler) handleListByProjectWithQuery(w http.ResponseWriter, r
 		Page:  page,
 	})
 }
+
+// assigneeNames は tasks の担当者の表示名を取得する。
+// 名前は表示用の補足のため、users サービスから取得できない場合は警告を記録して名前なしで返す。
+func (h *ListTaskHandler) assigneeNames(r *http.Request, tasks []*domain.Task) map[string]string {
+	names, err := h.listUC.AssigneeNames(r.Context(), tasks)
+	if err != nil {
+		slog.WarnContext(r.Context(), "failed to look up assignee names", "error", err)
+		return nil
+	}
+	return names
+}
+
+// assigneeName は担当者 ID の表示名を返す。担当者がいない場合や名前が不明な場合は空文字。
+func assigneeName(names map[string]string, assigneeID *string) string {
+	if assigneeID == nil {
+		return ""
+	}
+	return names[*assigneeID]
+}
+
+// writeListError は一覧の取得のエラーを返す（タイムアウトは 504、権限は writeAuthzError、それ以外は 500）。
+func writeListError(w http.ResponseWriter, err error) {
+	if errors.Is(err, usecase.ErrTimeout) {
+		writeTimeoutResponse(w)
+		return
+	}
+	if writeAuthzError(w, err) {
+		return
+	}
+	w.WriteHeader(http.StatusInternalServerError)
+}
+
+// listETag は一覧のレスポンスの ETag を返す。
+// プロジェクトの一覧のバージョンに、ワークスペースとクエリ文字列（フィルタ・sort・cursor・limit）を加えて計算する。
+// 担当者名（users サービス）の変更は反映しないため弱い ETag にする。また、キャッシュされた nextCursor が
+// 期限切れにならないよう、cursor の有効期限（domain.CursorTTL）の半分ごとに変える。
+func listETag(workspaceID, projectID, rawQuery string, v domain.ProjectVersion, now time.Time) string {
+	epoch := now.Unix() / int64(domain.CursorTTL/2/time.Second)
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%s\x00%d\x00%d\x00%d",
+		workspaceID, projectID, rawQuery, v.Count, v.UpdatedAt.UnixMicro(), epoch)))
+	return `W/"` + base64.RawURLEncoding.EncodeToString(sum[:12]) + `"`
+}
+
+// etagMatches は If-None-Match（カンマ区切り、または *）に etag が含まれるかを弱い比較（W/ を無視）で判定する。
+func etagMatches(ifNoneMatch, etag string) bool {
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == want {
+			return true
+		}
+	}
+	return false
+}
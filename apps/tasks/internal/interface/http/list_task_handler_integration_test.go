@@ -12,6 +12,8 @@ import (
 	"testing"
 	"time"
 
+	"teamflow-shared/clock"
+
 	domain "teamflow-tasks/internal/domain/task"
 	taskinfra "teamflow-tasks/internal/infrastructure/task"
 	"teamflow-tasks/internal/testutil"
@@ -30,22 +32,22 @@ func TestTaskHandler_CursorPagination_FirstPageReturnsNextCursor(t *testing.T) {
 	// Setup handler with real dependencies
 	repo := taskinfra.NewSQLTaskRepository(db)
 	listUC := &usecase.ListTasksByProjectUsecase{Repo: repo}
-	nowFunc := func() time.Time { return time.Now().UTC() }
+	clk := clock.Func(func() time.Time { return time.Now().UTC() })
 	cursorSecret := []byte("test-secret")
-	handler := NewListTaskHandler(listUC, nowFunc, cursorSecret)
+	handler := NewListTaskHandler(listUC, clk, cursorSecret)
 
 	// Seed: 5件以上、limit=2で複数ページになる数
 	// createdAt が同一の行を最低2件含める（tie-breaker: id）
 	// テスト間でIDが重複しないように、一意のIDを使用
 	testID := "first-page-next-cursor"
 	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: testID + "-001", ProjectID: "proj-1", Title: "T1", Status: "todo", Priority: "high", CreatedAt: base.Add(1 * time.Microsecond), UpdatedAt: base.Add(1 * time.Microsecond)},
-		{ID: testID + "-002", ProjectID: "proj-1", Title: "T2", Status: "todo", Priority: "medium", CreatedAt: base.Add(2 * time.Microsecond), UpdatedAt: base.Add(2 * time.Microsecond)},
-		{ID: testID + "-003", ProjectID: "proj-1", Title: "T3", Status: "todo", Priority: "low", CreatedAt: base, UpdatedAt: base},  // 同じcreatedAt
-		{ID: testID + "-004", ProjectID: "proj-1", Title: "T4", Status: "todo", Priority: "high", CreatedAt: base, UpdatedAt: base}, // 同じcreatedAt
-		{ID: testID + "-005", ProjectID: "proj-1", Title: "T5", Status: "todo", Priority: "medium", CreatedAt: base.Add(3 * time.Microsecond), UpdatedAt: base.Add(3 * time.Microsecond)},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID(testID+"-001").WithTitle("T1").WithPriority(domain.PriorityHigh).WithCreatedAt(base.Add(1*time.Microsecond)).Build(),
+		testutil.NewTaskBuilder().WithID(testID+"-002").WithTitle("T2").WithCreatedAt(base.Add(2*time.Microsecond)).Build(),
+		testutil.NewTaskBuilder().WithID(testID+"-003").WithTitle("T3").WithPriority(domain.PriorityLow).WithCreatedAt(base).Build(),  // 同じcreatedAt
+		testutil.NewTaskBuilder().WithID(testID+"-004").WithTitle("T4").WithPriority(domain.PriorityHigh).WithCreatedAt(base).Build(), // 同じcreatedAt
+		testutil.NewTaskBuilder().WithID(testID+"-005").WithTitle("T5").WithCreatedAt(base.Add(3*time.Microsecond)).Build(),
+	)
 
 	// 1ページ目: GET /api/projects/{projectId}/tasks?limit=2
 	req1 := httptest.NewRequest(http.MethodGet, "/projects/proj-1/tasks?limit=2", nil)
@@ -142,6 +144,62 @@ func TestTaskHandler_CursorPagination_FirstPageReturnsNextCursor(t *testing.T) {
 	// (上記のループで nextCursor が nil になった時点で終了しているので、これは既に検証済み)
 }
 
+// TestTaskHandler_CursorPagination_NewestFirst は sort=-createdAt の nextCursor で新しい順の続きを取得できることを検証する。
+func TestTaskHandler_CursorPagination_NewestFirst(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	testutil.ResetTasksTable(t, db)
+
+	repo := taskinfra.NewSQLTaskRepository(db)
+	listUC := &usecase.ListTasksByProjectUsecase{Repo: repo}
+	clk := clock.Func(func() time.Time { return time.Now().UTC() })
+	handler := NewListTaskHandler(listUC, clk, []byte("test-secret"))
+
+	// createdAt が同一の行を含める（tie-breaker: id DESC）
+	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("newest-001").WithCreatedAt(base).Build(),
+		testutil.NewTaskBuilder().WithID("newest-002").WithCreatedAt(base.Add(time.Microsecond)).Build(),
+		testutil.NewTaskBuilder().WithID("newest-003").WithCreatedAt(base.Add(time.Microsecond)).Build(),
+		testutil.NewTaskBuilder().WithID("newest-004").WithCreatedAt(base.Add(2*time.Microsecond)).Build(),
+		testutil.NewTaskBuilder().WithID("newest-005").WithCreatedAt(base.Add(3*time.Microsecond)).Build(),
+	)
+
+	// 2 ページ目以降は sort を付けずに cursor だけを指定する
+	var got []string
+	path := "/projects/proj-1/tasks?limit=2&sort=-createdAt"
+	for page := 1; ; page++ {
+		if page > 10 {
+			t.Fatalf("too many pages, possible infinite loop")
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("page %d: expected status 200, got %d, body: %s", page, w.Code, w.Body.String())
+		}
+		var resp listTasksResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("page %d: failed to decode response: %v", page, err)
+		}
+		for _, task := range resp.Tasks {
+			got = append(got, task.ID)
+		}
+		if resp.Page.NextCursor == nil {
+			break
+		}
+		path = "/projects/proj-1/tasks?limit=2&cursor=" + *resp.Page.NextCursor
+	}
+
+	want := []string{"newest-005", "newest-004", "newest-003", "newest-002", "newest-001"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
 // TestTaskHandler_CursorPagination_Error_INCOMPATIBLE_WITH_CURSOR は cursor + sort の併用エラーを検証する。
 func TestTaskHandler_CursorPagination_Error_INCOMPATIBLE_WITH_CURSOR(t *testing.T) {
 	db := testutil.SetupTestDB(t)
@@ -149,9 +207,9 @@ func TestTaskHandler_CursorPagination_Error_INCOMPATIBLE_WITH_CURSOR(t *testing.
 
 	repo := taskinfra.NewSQLTaskRepository(db)
 	listUC := &usecase.ListTasksByProjectUsecase{Repo: repo}
-	nowFunc := func() time.Time { return time.Now().UTC() }
+	clk := clock.Func(func() time.Time { return time.Now().UTC() })
 	cursorSecret := []byte("test-secret")
-	handler := NewListTaskHandler(listUC, nowFunc, cursorSecret)
+	handler := NewListTaskHandler(listUC, clk, cursorSecret)
 
 	// 有効な cursor を生成
 	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
@@ -205,9 +263,9 @@ func TestTaskHandler_CursorPagination_Error_INVALID_FORMAT(t *testing.T) {
 
 	repo := taskinfra.NewSQLTaskRepository(db)
 	listUC := &usecase.ListTasksByProjectUsecase{Repo: repo}
-	nowFunc := func() time.Time { return time.Now().UTC() }
+	clk := clock.Func(func() time.Time { return time.Now().UTC() })
 	cursorSecret := []byte("test-secret")
-	handler := NewListTaskHandler(listUC, nowFunc, cursorSecret)
+	handler := NewListTaskHandler(listUC, clk, cursorSecret)
 
 	// 形式不正な cursor（ドットなし）
 	req1 := httptest.NewRequest(http.MethodGet, "/projects/proj-1/tasks?limit=2&cursor=not-a-valid-cursor", nil)
@@ -255,9 +313,9 @@ func TestTaskHandler_CursorPagination_Error_INVALID_SIGNATURE(t *testing.T) {
 
 	repo := taskinfra.NewSQLTaskRepository(db)
 	listUC := &usecase.ListTasksByProjectUsecase{Repo: repo}
-	nowFunc := func() time.Time { return time.Now().UTC() }
+	clk := clock.Func(func() time.Time { return time.Now().UTC() })
 	cursorSecret := []byte("test-secret")
-	handler := NewListTaskHandler(listUC, nowFunc, cursorSecret)
+	handler := NewListTaskHandler(listUC, clk, cursorSecret)
 
 	// 正しい cursor を生成（qhash を計算するために query を作成）
 	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
@@ -271,6 +329,7 @@ func TestTaskHandler_CursorPagination_Error_INVALID_SIGNATURE(t *testing.T) {
 		ID:        "task-001",
 		ProjectID: "proj-1",
 		QHash:     query.ComputeQHash("proj-1"),
+		QV:        domain.QHashVersion,
 		IssuedAt:  time.Now().Unix(),
 	}
 	validCursor, err := domain.EncodeCursor(payload, cursorSecret)
@@ -317,9 +376,9 @@ func TestTaskHandler_CursorPagination_Error_EXPIRED(t *testing.T) {
 
 	repo := taskinfra.NewSQLTaskRepository(db)
 	listUC := &usecase.ListTasksByProjectUsecase{Repo: repo}
-	nowFunc := func() time.Time { return time.Now().UTC() }
+	clk := clock.Func(func() time.Time { return time.Now().UTC() })
 	cursorSecret := []byte("test-secret")
-	handler := NewListTaskHandler(listUC, nowFunc, cursorSecret)
+	handler := NewListTaskHandler(listUC, clk, cursorSecret)
 
 	// 過去の iat で cursor を生成（24時間以上前）
 	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
@@ -372,15 +431,15 @@ func TestTaskHandler_CursorPagination_Error_QUERY_MISMATCH(t *testing.T) {
 
 	repo := taskinfra.NewSQLTaskRepository(db)
 	listUC := &usecase.ListTasksByProjectUsecase{Repo: repo}
-	nowFunc := func() time.Time { return time.Now().UTC() }
+	clk := clock.Func(func() time.Time { return time.Now().UTC() })
 	cursorSecret := []byte("test-secret")
-	handler := NewListTaskHandler(listUC, nowFunc, cursorSecret)
+	handler := NewListTaskHandler(listUC, clk, cursorSecret)
 
 	// フィルタなしで cursor を生成
 	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
-	testutil.InsertTasks(t, db, []testutil.SeedTask{
-		{ID: "task-001", ProjectID: "proj-1", Title: "T1", Status: "todo", Priority: "high", CreatedAt: base, UpdatedAt: base},
-	})
+	testutil.InsertMany(t, db,
+		testutil.NewTaskBuilder().WithID("task-001").WithTitle("T1").WithPriority(domain.PriorityHigh).WithCreatedAt(base).Build(),
+	)
 
 	query1, err := domain.NewTaskQuery(domain.WithLimit(2))
 	if err != nil {
@@ -404,6 +463,7 @@ func TestTaskHandler_CursorPagination_Error_QUERY_MISMATCH(t *testing.T) {
 		ID:        lastTask1.ID,
 		ProjectID: "proj-1",
 		QHash:     query1.ComputeQHash("proj-1"), // フィルタなしの qhash
+		QV:        domain.QHashVersion,
 		IssuedAt:  time.Now().Unix(),
 	}
 	cursor1, err := domain.EncodeCursor(payload1, cursorSecret)
@@ -3,9 +3,11 @@ package http_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	domain "teamflow-tasks/internal/domain/task"
 	taskinfra "teamflow-tasks/internal/infrastructure/task"
@@ -59,7 +61,7 @@ func TestListTasksByProjectHandler_Success(t *testing.T) {
 		}
 	}
 
-	handler := httpiface.NewListTaskHandler(listUC, fixedNow, []byte("test-secret"))
+	handler := httpiface.NewListTaskHandler(listUC, fixedClock, []byte("test-secret"))
 
 	req := httptest.NewRequest(http.MethodGet, "/tasks?projectId=proj-1", nil)
 	w := httptest.NewRecorder()
@@ -99,3 +101,259 @@ func TestListTasksByProjectHandler_Success(t *testing.T) {
 		}
 	}
 }
+
+// timeoutRepo は一覧取得でタイムアウトを返す TaskRepository。
+type timeoutRepo struct {
+	*taskinfra.MemoryTaskRepository
+}
+
+func (timeoutRepo) ListByProject(context.Context, string) ([]*domain.Task, error) {
+	return nil, fmt.Errorf("%w: context deadline exceeded", usecase.ErrTimeout)
+}
+
+func (timeoutRepo) FindByProjectID(context.Context, string, *domain.TaskQuery) ([]*domain.Task, error) {
+	return nil, fmt.Errorf("%w: context deadline exceeded", usecase.ErrTimeout)
+}
+
+func TestListTasksByProjectHandler_Timeout(t *testing.T) {
+	listUC := &usecase.ListTasksByProjectUsecase{Repo: timeoutRepo{taskinfra.NewMemoryTaskRepository()}}
+	handler := httpiface.NewListTaskHandler(listUC, fixedClock, []byte("test-secret"))
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{name: "legacy", url: "/tasks?projectId=proj-1"},
+		{name: "with query", url: "/projects/proj-1/tasks?q=design"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			if w.Code != http.StatusGatewayTimeout {
+				t.Fatalf("expected status 504, got %d", w.Code)
+			}
+			var resp httpiface.ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Error != "TIMEOUT" {
+				t.Errorf("expected error TIMEOUT, got %q", resp.Error)
+			}
+		})
+	}
+}
+
+// countingRepo は FindByProjectID（一覧の取得）の呼び出し回数を数える。
+type countingRepo struct {
+	*taskinfra.MemoryTaskRepository
+	finds int
+}
+
+func (r *countingRepo) FindByProjectID(ctx context.Context, projectID string, query *domain.TaskQuery) ([]*domain.Task, error) {
+	r.finds++
+	return r.MemoryTaskRepository.FindByProjectID(ctx, projectID, query)
+}
+
+func TestListTasksByProjectHandler_ETag(t *testing.T) {
+	ctx := context.Background()
+	now := fixedNow()
+	repo := &countingRepo{MemoryTaskRepository: taskinfra.NewMemoryTaskRepository()}
+	for _, id := range []string{"task-1", "task-2"} {
+		task := &domain.Task{ID: id, ProjectID: "proj-1", Title: id, Status: domain.StatusTodo, Priority: domain.PriorityMedium, CreatedAt: now, UpdatedAt: now}
+		if err := repo.Save(ctx, task); err != nil {
+			t.Fatalf("failed to save task: %v", err)
+		}
+	}
+	handler := httpiface.NewListTaskHandler(&usecase.ListTasksByProjectUsecase{
+		Repo:   repo,
+		Access: privateProjectAccess{private: "proj-1", member: "user-1"},
+	}, fixedClock, []byte("test-secret"))
+	get := func(url, ifNoneMatch, actor string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("X-User-ID", actor)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	first := get("/projects/proj-1/tasks", "", "user-1")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || first.Header().Get("Cache-Control") != "private, no-cache" {
+		t.Fatalf("expected 200 with an ETag, got %d %v", first.Code, first.Header())
+	}
+
+	// 変更が無ければ一覧を取得せずに 304 を返す
+	finds := repo.finds
+	if w := get("/projects/proj-1/tasks", etag, "user-1"); w.Code != http.StatusNotModified || w.Body.Len() != 0 || w.Header().Get("ETag") != etag {
+		t.Fatalf("expected 304 with the same ETag, got %d %q", w.Code, w.Header().Get("ETag"))
+	}
+	if w := get("/projects/proj-1/tasks", `"other", `+etag, "user-1"); w.Code != http.StatusNotModified {
+		t.Errorf("expected 304 for a list of ETags, got %d", w.Code)
+	}
+	if repo.finds != finds {
+		t.Errorf("expected no list query for 304, got %d", repo.finds-finds)
+	}
+
+	// 権限の確認は 304 より先に行う
+	if w := get("/projects/proj-1/tasks", etag, "user-2"); w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for a non-member, got %d", w.Code)
+	}
+
+	// クエリが異なる一覧は別の ETag
+	if w := get("/projects/proj-1/tasks?status=todo", etag, "user-1"); w.Code != http.StatusOK || w.Header().Get("ETag") == etag {
+		t.Errorf("expected 200 with another ETag for a filtered list, got %d", w.Code)
+	}
+
+	// 更新・削除で ETag が変わる
+	task, err := repo.FindByID(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("failed to find task: %v", err)
+	}
+	task.UpdatedAt = now.Add(time.Minute)
+	task.RecordChange()
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("failed to update task: %v", err)
+	}
+	updated := get("/projects/proj-1/tasks", etag, "user-1")
+	if updated.Code != http.StatusOK || updated.Header().Get("ETag") == etag {
+		t.Fatalf("expected 200 with a new ETag after the update, got %d", updated.Code)
+	}
+	if _, err := repo.DeleteByProject(ctx, "proj-1"); err != nil {
+		t.Fatalf("failed to delete tasks: %v", err)
+	}
+	if w := get("/projects/proj-1/tasks", updated.Header().Get("ETag"), "user-1"); w.Code != http.StatusOK {
+		t.Errorf("expected 200 after the delete, got %d", w.Code)
+	}
+}
+
+// fakeUserDirectory は users で名前を解決する UserDirectory。err があれば常にそれを返す。
+type fakeUserDirectory struct {
+	users map[string]string
+	err   error
+}
+
+func (d fakeUserDirectory) LookupUsers(_ context.Context, ids []string) (map[string]usecase.UserProfile, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	found := make(map[string]usecase.UserProfile)
+	for _, id := range ids {
+		if name, ok := d.users[id]; ok {
+			found[id] = usecase.UserProfile{ID: id, Name: name}
+		}
+	}
+	return found, nil
+}
+
+func TestListTasksByProjectHandler_AssigneeNames(t *testing.T) {
+	repo := taskinfra.NewMemoryTaskRepository()
+	createUC := &usecase.CreateTaskUsecase{Repo: repo}
+	for i, assignee := range []string{"user-1", "user-2", ""} {
+		in := usecase.CreateTaskInput{
+			ID: fmt.Sprintf("task-%d", i+1), ProjectID: "proj-1", Title: "画面設計",
+			Status: domain.StatusTodo, Priority: domain.PriorityMedium, AssigneeID: assignee, Now: fixedNow(),
+		}
+		if _, err := createUC.Execute(context.Background(), in); err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		users fakeUserDirectory
+		want  map[string]string // タスク ID → assigneeName
+	}{
+		{
+			name:  "names are resolved",
+			users: fakeUserDirectory{users: map[string]string{"user-1": "Taro"}},
+			want:  map[string]string{"task-1": "Taro", "task-2": "", "task-3": ""},
+		},
+		{
+			name:  "lookup failure omits names",
+			users: fakeUserDirectory{err: fmt.Errorf("users service unavailable")},
+			want:  map[string]string{"task-1": "", "task-2": "", "task-3": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listUC := &usecase.ListTasksByProjectUsecase{Repo: repo, Users: tt.users}
+			handler := httpiface.NewListTaskHandler(listUC, fixedClock, []byte("test-secret"))
+
+			req := httptest.NewRequest(http.MethodGet, "/projects/proj-1/tasks", nil)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var resp struct {
+				Tasks []struct {
+					ID           string `json:"id"`
+					AssigneeName string `json:"assigneeName"`
+				} `json:"tasks"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp.Tasks) != len(tt.want) {
+				t.Fatalf("expected %d tasks, got %d", len(tt.want), len(resp.Tasks))
+			}
+			for _, task := range resp.Tasks {
+				if task.AssigneeName != tt.want[task.ID] {
+					t.Errorf("%s: assigneeName = %q, want %q", task.ID, task.AssigneeName, tt.want[task.ID])
+				}
+			}
+		})
+	}
+}
+
+func TestListTasksByProjectHandler_PageLimits(t *testing.T) {
+	ctx := context.Background()
+	now := fixedNow()
+	repo := taskinfra.NewMemoryTaskRepository()
+	for i := 1; i <= 5; i++ {
+		task := &domain.Task{ID: fmt.Sprintf("task-%d", i), ProjectID: "proj-1", Title: "t", Status: domain.StatusTodo, Priority: domain.PriorityMedium, CreatedAt: now.Add(time.Duration(i) * time.Second), UpdatedAt: now}
+		if err := repo.Save(ctx, task); err != nil {
+			t.Fatalf("failed to save task: %v", err)
+		}
+	}
+	handler := httpiface.NewListTaskHandler(&usecase.ListTasksByProjectUsecase{Repo: repo}, fixedClock, []byte("test-secret"),
+		httpiface.WithListPageLimits(domain.PageLimits{Default: 2, Max: 3}))
+
+	for _, tt := range []struct {
+		name string
+		path string
+		want int
+	}{
+		{name: "omitted uses the default", path: "/projects/proj-1/tasks", want: 2},
+		{name: "within the maximum", path: "/projects/proj-1/tasks?limit=3", want: 3},
+		{name: "over the maximum is clamped", path: "/projects/proj-1/tasks?limit=200", want: 3},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+			}
+			var resp struct {
+				Tasks []json.RawMessage `json:"tasks"`
+				Page  struct {
+					NextCursor *string `json:"nextCursor"`
+				} `json:"page"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp.Tasks) != tt.want || resp.Page.NextCursor == nil {
+				t.Errorf("expected %d tasks with a next cursor, got %d (nextCursor=%v)", tt.want, len(resp.Tasks), resp.Page.NextCursor)
+			}
+		})
+	}
+}
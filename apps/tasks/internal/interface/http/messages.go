@@ -0,0 +1,19 @@
+package http
+
+import "teamflow-shared/i18n"
+
+// Messages は ValidationIssue の message の翻訳（field.code ごと）。日本語は toValidationIssue などの文言をそのまま使う。
+// server.Options.Messages に渡し、Accept-Language で英語を求められた場合に使う。
+var Messages = i18n.Merge(i18n.Common, i18n.Catalog{
+	"title.REQUIRED":                   {i18n.English: "title is required and must not be empty or blank."},
+	"status.INVALID_ENUM":              {i18n.English: "status must be a comma-separated list of 'todo','doing','in_progress','done' (e.g. status=todo,in_progress)."},
+	"priority.INVALID_ENUM":            {i18n.English: "priority must be a comma-separated list of 'high','medium','low' (e.g. priority=high,medium)."},
+	"dueDateFrom.INVALID_FORMAT":       {i18n.English: "dueDateFrom must be in YYYY-MM-DD format (e.g. dueDateFrom=2026-01-10)."},
+	"dueDateTo.INVALID_FORMAT":         {i18n.English: "dueDateTo must be in YYYY-MM-DD format (e.g. dueDateTo=2026-01-10)."},
+	"dueDateFrom.CONSTRAINT_VIOLATION": {i18n.English: "dueDateFrom must be on or before dueDateTo (e.g. dueDateFrom=2026-01-01&dueDateTo=2026-01-10)."},
+	"sort.INVALID_ENUM":                {i18n.English: "sort accepts only 'sortOrder','createdAt','updatedAt','dueDate','priority' (e.g. sort=-priority,createdAt)."},
+	"limit.INVALID_FORMAT":             {i18n.English: "limit must be an integer (e.g. limit=50)."},
+	"limit.INVALID_RANGE":              {i18n.English: "limit must be an integer between 1 and the maximum page size (200 by default; omitted or values below 1 are normalized to the default, larger values to the maximum)."},
+	"*.FIELD_FORBIDDEN":                {i18n.English: "{field} is locked by the project settings and cannot be changed with your role."},
+	"*.CHANGED":                        {i18n.English: "{field} was changed after the version in If-Match. Fetch the task again before changing it."},
+})
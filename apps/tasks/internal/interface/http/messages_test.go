@@ -0,0 +1,42 @@
+package http
+
+import (
+	"testing"
+
+	"teamflow-shared/i18n"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// toValidationIssue が返す field・code には英語の文言があること（field 固有の文言が無い場合は code の文言）。
+func TestMessages_CoverValidationIssues(t *testing.T) {
+	errs := []error{
+		&InvalidLimitError{RejectedValue: "abc"},
+		domain.NewRequired("title", nil),
+		domain.NewInvalidEnum("status", nil, nil),
+		domain.NewInvalidEnum("priority", nil, nil),
+		domain.NewInvalidEnum("sort", nil, nil),
+		domain.NewInvalidFormat("dueDateFrom", nil, nil),
+		domain.NewInvalidFormat("dueDateTo", nil, nil),
+		domain.NewInvalidRange("estimate", nil, nil),
+		domain.ErrDueDateFromAfterTo,
+		domain.ErrLimitOutOfRange,
+		domain.ErrSortIncompatibleWithCursor,
+		domain.ErrCursorInvalidFormat,
+		domain.ErrCursorInvalidSignature,
+		domain.ErrCursorExpired,
+		domain.ErrCursorQueryMismatch,
+		nil,
+	}
+	for _, err := range errs {
+		issue := toValidationIssue(err)
+		msg, ok := Messages.Message(i18n.English, issue.Field, issue.Code)
+		if !ok || msg == "" {
+			t.Errorf("no English message for %s.%s", issue.Field, issue.Code)
+		}
+	}
+
+	if got, _ := Messages.Message(i18n.English, "limit", "INVALID_RANGE"); got != "limit must be an integer between 1 and the maximum page size (200 by default; omitted or values below 1 are normalized to the default, larger values to the maximum)." {
+		t.Errorf("limit.INVALID_RANGE = %q", got)
+	}
+}
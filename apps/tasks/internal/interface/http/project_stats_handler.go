@@ -0,0 +1,334 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"teamflow-shared/clock"
+
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// ProjectStatsHandler は GET /api/projects/{projectId}/tasks/stats を処理する HTTP ハンドラ。
+//
+// projects サービスがプロジェクトカード用の集計（未完了・完了・期限切れの件数と最終更新日時）を
+// 取得するために使う。
+type ProjectStatsHandler struct {
+	statsUC *usecase.GetProjectStatsUsecase
+	clock   clock.Clock
+}
+
+// NewProjectStatsHandler は ProjectStatsHandler を生成する。
+func NewProjectStatsHandler(
+	statsUC *usecase.GetProjectStatsUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &ProjectStatsHandler{
+		statsUC: statsUC,
+		clock:   clk,
+	}
+}
+
+type projectStatsResponse struct {
+	ProjectID      string     `json:"projectId"`
+	Open           int        `json:"open"`
+	Done           int        `json:"done"`
+	Overdue        int        `json:"overdue"`
+	LastActivityAt *time.Time `json:"lastActivityAt"`
+}
+
+func (h *ProjectStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectID, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/projects/"), "/tasks/stats")
+	if !ok || projectID == "" || strings.Contains(projectID, "/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	stats, err := h.statsUC.Execute(r.Context(), projectID, h.clock.Now())
+	if err != nil {
+		if errors.Is(err, usecase.ErrTimeout) {
+			writeTimeoutResponse(w)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(projectStatsResponse{
+		ProjectID:      projectID,
+		Open:           stats.Open,
+		Done:           stats.Done,
+		Overdue:        stats.Overdue,
+		LastActivityAt: stats.LastActivityAt,
+	})
+}
+
+// MilestoneStatsHandler は GET /api/projects/{projectId}/tasks/stats/milestones を処理する HTTP ハンドラ。
+//
+// projects サービスがマイルストーンの進捗（未完了・完了のタスク数）を取得するために使う。
+type MilestoneStatsHandler struct {
+	statsUC *usecase.GetProjectStatsUsecase
+}
+
+// NewMilestoneStatsHandler は MilestoneStatsHandler を生成する。
+func NewMilestoneStatsHandler(statsUC *usecase.GetProjectStatsUsecase) http.Handler {
+	return &MilestoneStatsHandler{statsUC: statsUC}
+}
+
+type milestoneStatsResponse struct {
+	MilestoneID string `json:"milestoneId"`
+	Open        int    `json:"open"`
+	Done        int    `json:"done"`
+}
+
+type projectMilestoneStatsResponse struct {
+	ProjectID  string                   `json:"projectId"`
+	Milestones []milestoneStatsResponse `json:"milestones"` // タスクのあるマイルストーンのみ（milestoneId 順）
+}
+
+func (h *MilestoneStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectID, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/projects/"), "/tasks/stats/milestones")
+	if !ok || projectID == "" || strings.Contains(projectID, "/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	stats, err := h.statsUC.ExecuteByMilestone(r.Context(), projectID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrTimeout) {
+			writeTimeoutResponse(w)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	resp := projectMilestoneStatsResponse{
+		ProjectID:  projectID,
+		Milestones: make([]milestoneStatsResponse, 0, len(stats)),
+	}
+	for _, s := range stats {
+		resp.Milestones = append(resp.Milestones, milestoneStatsResponse{
+			MilestoneID: s.MilestoneID,
+			Open:        s.Open,
+			Done:        s.Done,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// EpicStatsHandler は GET /api/projects/{projectId}/tasks/stats/epics を処理する HTTP ハンドラ。
+//
+// projects サービスがエピックの進捗（完了・全体のタスク数と見積もりの合計）を取得するために使う。
+type EpicStatsHandler struct {
+	statsUC *usecase.GetProjectStatsUsecase
+}
+
+// NewEpicStatsHandler は EpicStatsHandler を生成する。
+func NewEpicStatsHandler(statsUC *usecase.GetProjectStatsUsecase) http.Handler {
+	return &EpicStatsHandler{statsUC: statsUC}
+}
+
+type epicStatsResponse struct {
+	EpicID        string `json:"epicId"`
+	Total         int    `json:"total"`
+	Done          int    `json:"done"`
+	EstimateTotal int    `json:"estimateTotal"`
+	EstimateDone  int    `json:"estimateDone"`
+}
+
+type projectEpicStatsResponse struct {
+	ProjectID string              `json:"projectId"`
+	Epics     []epicStatsResponse `json:"epics"` // タスクのあるエピックのみ（epicId 順）
+}
+
+func (h *EpicStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectID, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/projects/"), "/tasks/stats/epics")
+	if !ok || projectID == "" || strings.Contains(projectID, "/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	stats, err := h.statsUC.ExecuteByEpic(r.Context(), projectID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrTimeout) {
+			writeTimeoutResponse(w)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	resp := projectEpicStatsResponse{
+		ProjectID: projectID,
+		Epics:     make([]epicStatsResponse, 0, len(stats)),
+	}
+	for _, s := range stats {
+		resp.Epics = append(resp.Epics, epicStatsResponse{
+			EpicID:        s.EpicID,
+			Total:         s.Total,
+			Done:          s.Done,
+			EstimateTotal: s.EstimateTotal,
+			EstimateDone:  s.EstimateDone,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// LabelStatsHandler は GET /api/projects/{projectId}/tasks/stats/labels を処理する HTTP ハンドラ。
+//
+// projects サービスがラベル一覧の使用数（ラベルが付いたタスク数）を取得するために使う。
+type LabelStatsHandler struct {
+	statsUC *usecase.GetProjectStatsUsecase
+}
+
+// NewLabelStatsHandler は LabelStatsHandler を生成する。
+func NewLabelStatsHandler(statsUC *usecase.GetProjectStatsUsecase) http.Handler {
+	return &LabelStatsHandler{statsUC: statsUC}
+}
+
+type labelStatsResponse struct {
+	LabelID string `json:"labelId"`
+	Count   int    `json:"count"`
+}
+
+type projectLabelStatsResponse struct {
+	ProjectID string               `json:"projectId"`
+	Labels    []labelStatsResponse `json:"labels"` // タスクに付いているラベルのみ（labelId 順）
+}
+
+func (h *LabelStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectID, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/projects/"), "/tasks/stats/labels")
+	if !ok || projectID == "" || strings.Contains(projectID, "/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	stats, err := h.statsUC.ExecuteByLabel(r.Context(), projectID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrTimeout) {
+			writeTimeoutResponse(w)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	resp := projectLabelStatsResponse{
+		ProjectID: projectID,
+		Labels:    make([]labelStatsResponse, 0, len(stats)),
+	}
+	for _, s := range stats {
+		resp.Labels = append(resp.Labels, labelStatsResponse{LabelID: s.LabelID, Count: s.Count})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// BatchProjectStatsHandler は POST /api/tasks:stats を処理する HTTP ハンドラ。
+//
+// projects サービスがプロジェクト一覧（expand=taskCounts）の集計を、プロジェクトごとに
+// 問い合わせずに 1 回で取得するために使う。
+type BatchProjectStatsHandler struct {
+	statsUC *usecase.GetProjectStatsUsecase
+	clock   clock.Clock
+}
+
+// NewBatchProjectStatsHandler は BatchProjectStatsHandler を生成する。
+func NewBatchProjectStatsHandler(
+	statsUC *usecase.GetProjectStatsUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &BatchProjectStatsHandler{
+		statsUC: statsUC,
+		clock:   clk,
+	}
+}
+
+type batchProjectStatsRequest struct {
+	ProjectIDs []string `json:"projectIds"`
+}
+
+type batchProjectStatsResponse struct {
+	Stats []projectStatsResponse `json:"stats"` // 重複を除いた projectIds の順
+}
+
+func (h *BatchProjectStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req batchProjectStatsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "invalid json", err.Error())
+		return
+	}
+
+	stats, err := h.statsUC.ExecuteBatch(r.Context(), req.ProjectIDs, h.clock.Now())
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			writeErrorResponse(w, http.StatusBadRequest, "invalid input", err.Error())
+			return
+		}
+		if errors.Is(err, usecase.ErrTimeout) {
+			writeTimeoutResponse(w)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	resp := batchProjectStatsResponse{Stats: make([]projectStatsResponse, 0, len(stats))}
+	for _, id := range req.ProjectIDs {
+		s, ok := stats[id]
+		if !ok {
+			continue
+		}
+		delete(stats, id)
+		resp.Stats = append(resp.Stats, projectStatsResponse{
+			ProjectID:      id,
+			Open:           s.Open,
+			Done:           s.Done,
+			Overdue:        s.Overdue,
+			LastActivityAt: s.LastActivityAt,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
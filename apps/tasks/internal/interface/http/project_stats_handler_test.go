@@ -0,0 +1,324 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	domain "teamflow-tasks/internal/domain/task"
+	taskinfra "teamflow-tasks/internal/infrastructure/task"
+	httpiface "teamflow-tasks/internal/interface/http"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+func TestProjectStatsHandler(t *testing.T) {
+	now := fixedNow()
+	overdue := now.AddDate(0, 0, -1)
+	repo := taskinfra.NewMemoryTaskRepository()
+	for _, task := range []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", Title: "設計", Status: domain.StatusTodo, Priority: domain.PriorityHigh, DueDate: &overdue, CreatedAt: now, UpdatedAt: now},
+		{ID: "t2", ProjectID: "proj-1", Title: "実装", Status: domain.StatusDone, Priority: domain.PriorityLow, CreatedAt: now, UpdatedAt: now},
+		{ID: "t3", ProjectID: "proj-2", Title: "別", Status: domain.StatusTodo, Priority: domain.PriorityLow, CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := repo.Save(context.Background(), task); err != nil {
+			t.Fatalf("failed to save task: %v", err)
+		}
+	}
+	handler := httpiface.NewProjectStatsHandler(&usecase.GetProjectStatsUsecase{Repo: repo}, fixedClock)
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		wantCode    int
+		wantOpen    int
+		wantDone    int
+		wantOverdue int
+	}{
+		{name: "stats", method: http.MethodGet, path: "/projects/proj-1/tasks/stats", wantCode: http.StatusOK, wantOpen: 1, wantDone: 1, wantOverdue: 1},
+		{name: "no tasks", method: http.MethodGet, path: "/projects/proj-x/tasks/stats", wantCode: http.StatusOK},
+		{name: "missing project id", method: http.MethodGet, path: "/projects//tasks/stats", wantCode: http.StatusNotFound},
+		{name: "method not allowed", method: http.MethodPost, path: "/projects/proj-1/tasks/stats", wantCode: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d", tt.wantCode, w.Code)
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			var got struct {
+				Open           int     `json:"open"`
+				Done           int     `json:"done"`
+				Overdue        int     `json:"overdue"`
+				LastActivityAt *string `json:"lastActivityAt"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.Open != tt.wantOpen || got.Done != tt.wantDone || got.Overdue != tt.wantOverdue {
+				t.Errorf("unexpected stats: %+v", got)
+			}
+			if (got.LastActivityAt != nil) != (tt.wantOpen+tt.wantDone > 0) {
+				t.Errorf("unexpected lastActivityAt: %v", got.LastActivityAt)
+			}
+		})
+	}
+}
+
+func TestMilestoneStatsHandler(t *testing.T) {
+	now := fixedNow()
+	m1, m2 := "m-1", "m-2"
+	repo := taskinfra.NewMemoryTaskRepository()
+	for _, task := range []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", Title: "設計", Status: domain.StatusTodo, Priority: domain.PriorityHigh, MilestoneID: &m2, CreatedAt: now, UpdatedAt: now},
+		{ID: "t2", ProjectID: "proj-1", Title: "実装", Status: domain.StatusDone, Priority: domain.PriorityLow, MilestoneID: &m1, CreatedAt: now, UpdatedAt: now},
+		{ID: "t3", ProjectID: "proj-1", Title: "未分類", Status: domain.StatusTodo, Priority: domain.PriorityLow, CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := repo.Save(context.Background(), task); err != nil {
+			t.Fatalf("failed to save task: %v", err)
+		}
+	}
+	handler := httpiface.NewMilestoneStatsHandler(&usecase.GetProjectStatsUsecase{Repo: repo})
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		wantCode int
+		want     []string // milestoneId:open:done
+	}{
+		{name: "stats", method: http.MethodGet, path: "/projects/proj-1/tasks/stats/milestones", wantCode: http.StatusOK, want: []string{"m-1:0:1", "m-2:1:0"}},
+		{name: "no tasks", method: http.MethodGet, path: "/projects/proj-x/tasks/stats/milestones", wantCode: http.StatusOK, want: []string{}},
+		{name: "method not allowed", method: http.MethodPost, path: "/projects/proj-1/tasks/stats/milestones", wantCode: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d", tt.wantCode, w.Code)
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			var got struct {
+				Milestones []struct {
+					MilestoneID string `json:"milestoneId"`
+					Open        int    `json:"open"`
+					Done        int    `json:"done"`
+				} `json:"milestones"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			gotKeys := make([]string, 0, len(got.Milestones))
+			for _, m := range got.Milestones {
+				gotKeys = append(gotKeys, fmt.Sprintf("%s:%d:%d", m.MilestoneID, m.Open, m.Done))
+			}
+			if strings.Join(gotKeys, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v, got %v", tt.want, gotKeys)
+			}
+		})
+	}
+}
+
+func TestEpicStatsHandler(t *testing.T) {
+	now := fixedNow()
+	e1 := "e-1"
+	three, five := 3, 5
+	repo := taskinfra.NewMemoryTaskRepository()
+	for _, task := range []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", Title: "設計", Status: domain.StatusDone, Priority: domain.PriorityHigh, EpicID: &e1, Estimate: &three, CreatedAt: now, UpdatedAt: now},
+		{ID: "t2", ProjectID: "proj-1", Title: "実装", Status: domain.StatusInProgress, Priority: domain.PriorityLow, EpicID: &e1, Estimate: &five, CreatedAt: now, UpdatedAt: now},
+		{ID: "t3", ProjectID: "proj-1", Title: "未分類", Status: domain.StatusTodo, Priority: domain.PriorityLow, CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := repo.Save(context.Background(), task); err != nil {
+			t.Fatalf("failed to save task: %v", err)
+		}
+	}
+	handler := httpiface.NewEpicStatsHandler(&usecase.GetProjectStatsUsecase{Repo: repo})
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		wantCode int
+		want     []string // epicId:done/total:estimateDone/estimateTotal
+	}{
+		{name: "stats", method: http.MethodGet, path: "/projects/proj-1/tasks/stats/epics", wantCode: http.StatusOK, want: []string{"e-1:1/2:3/8"}},
+		{name: "no tasks", method: http.MethodGet, path: "/projects/proj-x/tasks/stats/epics", wantCode: http.StatusOK, want: []string{}},
+		{name: "method not allowed", method: http.MethodPost, path: "/projects/proj-1/tasks/stats/epics", wantCode: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d", tt.wantCode, w.Code)
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			var got struct {
+				Epics []struct {
+					EpicID        string `json:"epicId"`
+					Total         int    `json:"total"`
+					Done          int    `json:"done"`
+					EstimateTotal int    `json:"estimateTotal"`
+					EstimateDone  int    `json:"estimateDone"`
+				} `json:"epics"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			gotKeys := make([]string, 0, len(got.Epics))
+			for _, e := range got.Epics {
+				gotKeys = append(gotKeys, fmt.Sprintf("%s:%d/%d:%d/%d", e.EpicID, e.Done, e.Total, e.EstimateDone, e.EstimateTotal))
+			}
+			if strings.Join(gotKeys, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v, got %v", tt.want, gotKeys)
+			}
+		})
+	}
+}
+
+func TestLabelStatsHandler(t *testing.T) {
+	now := fixedNow()
+	repo := taskinfra.NewMemoryTaskRepository()
+	for _, task := range []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", Title: "設計", Status: domain.StatusDone, Priority: domain.PriorityHigh, LabelIDs: []string{"l-2", "l-1"}, CreatedAt: now, UpdatedAt: now},
+		{ID: "t2", ProjectID: "proj-1", Title: "実装", Status: domain.StatusTodo, Priority: domain.PriorityLow, LabelIDs: []string{"l-2"}, CreatedAt: now, UpdatedAt: now},
+		{ID: "t3", ProjectID: "proj-1", Title: "未分類", Status: domain.StatusTodo, Priority: domain.PriorityLow, CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := repo.Save(context.Background(), task); err != nil {
+			t.Fatalf("failed to save task: %v", err)
+		}
+	}
+	handler := httpiface.NewLabelStatsHandler(&usecase.GetProjectStatsUsecase{Repo: repo})
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		wantCode int
+		want     []string // labelId:count
+	}{
+		{name: "stats", method: http.MethodGet, path: "/projects/proj-1/tasks/stats/labels", wantCode: http.StatusOK, want: []string{"l-1:1", "l-2:2"}},
+		{name: "no tasks", method: http.MethodGet, path: "/projects/proj-x/tasks/stats/labels", wantCode: http.StatusOK, want: []string{}},
+		{name: "method not allowed", method: http.MethodPost, path: "/projects/proj-1/tasks/stats/labels", wantCode: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d", tt.wantCode, w.Code)
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			var got struct {
+				Labels []struct {
+					LabelID string `json:"labelId"`
+					Count   int    `json:"count"`
+				} `json:"labels"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			gotKeys := make([]string, 0, len(got.Labels))
+			for _, l := range got.Labels {
+				gotKeys = append(gotKeys, fmt.Sprintf("%s:%d", l.LabelID, l.Count))
+			}
+			if strings.Join(gotKeys, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v, got %v", tt.want, gotKeys)
+			}
+		})
+	}
+}
+
+func TestBatchProjectStatsHandler(t *testing.T) {
+	now := fixedNow()
+	repo := taskinfra.NewMemoryTaskRepository()
+	for _, task := range []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", Title: "設計", Status: domain.StatusTodo, Priority: domain.PriorityHigh, CreatedAt: now, UpdatedAt: now},
+		{ID: "t2", ProjectID: "proj-1", Title: "実装", Status: domain.StatusDone, Priority: domain.PriorityLow, CreatedAt: now, UpdatedAt: now},
+		{ID: "t3", ProjectID: "proj-2", Title: "別", Status: domain.StatusTodo, Priority: domain.PriorityLow, CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := repo.Save(context.Background(), task); err != nil {
+			t.Fatalf("failed to save task: %v", err)
+		}
+	}
+	handler := httpiface.NewBatchProjectStatsHandler(&usecase.GetProjectStatsUsecase{Repo: repo}, fixedClock)
+
+	tests := []struct {
+		name     string
+		method   string
+		body     string
+		wantCode int
+		want     []string // projectId:open:done
+	}{
+		{name: "stats", method: http.MethodPost, body: `{"projectIds":["proj-2","proj-1","proj-x","proj-1"]}`, wantCode: http.StatusOK, want: []string{"proj-2:1:0", "proj-1:1:1", "proj-x:0:0"}},
+		{name: "empty", method: http.MethodPost, body: `{"projectIds":[]}`, wantCode: http.StatusBadRequest},
+		{name: "invalid json", method: http.MethodPost, body: `{`, wantCode: http.StatusBadRequest},
+		{name: "method not allowed", method: http.MethodGet, wantCode: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/tasks:stats", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d", tt.wantCode, w.Code)
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			var got struct {
+				Stats []struct {
+					ProjectID string `json:"projectId"`
+					Open      int    `json:"open"`
+					Done      int    `json:"done"`
+				} `json:"stats"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(got.Stats) != len(tt.want) {
+				t.Fatalf("expected %v, got %+v", tt.want, got.Stats)
+			}
+			for i, s := range got.Stats {
+				if g := fmt.Sprintf("%s:%d:%d", s.ProjectID, s.Open, s.Done); g != tt.want[i] {
+					t.Errorf("stats[%d]: expected %s, got %s", i, tt.want[i], g)
+				}
+			}
+		})
+	}
+}
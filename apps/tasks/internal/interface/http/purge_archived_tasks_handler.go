@@ -0,0 +1,105 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/clock"
+
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// PurgeArchivedTasksHandler は POST /api/admin/purge を処理する HTTP ハンドラ。
+//
+// 運用者（ADMIN_USER_IDS）が、アーカイブしてから olderThanDays 日を過ぎたタスクをすべてのワークスペースから物理削除する。
+// 1 回の呼び出しで削除するのは最大 usecase.DefaultPurgeBatchSize 件で、hasMore が true の場合は同じリクエストを繰り返す。
+// dryRun が true の場合は削除せずに対象のタスクを返す。
+type PurgeArchivedTasksHandler struct {
+	purgeUC *usecase.PurgeArchivedTasksUsecase
+	clock   clock.Clock
+}
+
+// NewPurgeArchivedTasksHandler は PurgeArchivedTasksHandler を生成する。
+func NewPurgeArchivedTasksHandler(purgeUC *usecase.PurgeArchivedTasksUsecase, clk clock.Clock) http.Handler {
+	return &PurgeArchivedTasksHandler{purgeUC: purgeUC, clock: clk}
+}
+
+type purgeArchivedTasksRequest struct {
+	OlderThanDays int  `json:"olderThanDays"`
+	DryRun        bool `json:"dryRun"`
+}
+
+type purgedTaskResponse struct {
+	ID          string    `json:"id"`
+	ProjectID   string    `json:"projectId"`
+	WorkspaceID string    `json:"workspaceId"`
+	Title       string    `json:"title"`
+	ArchivedAt  time.Time `json:"archivedAt"`
+}
+
+type purgeArchivedTasksResponse struct {
+	DryRun bool `json:"dryRun"`
+	// Before はこの日時より前にアーカイブされたタスクを対象にしたことを表す
+	Before time.Time `json:"before"`
+	Count  int       `json:"count"`
+	// HasMore は対象のタスクが残っていることを表す（同じリクエストを繰り返す）
+	HasMore bool                 `json:"hasMore"`
+	Tasks   []purgedTaskResponse `json:"tasks"`
+}
+
+func (h *PurgeArchivedTasksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req purgeArchivedTasksRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "invalid json", err.Error())
+		return
+	}
+	if req.OlderThanDays < 1 {
+		writeBodyValidationError(w, ValidationIssue{Field: "olderThanDays", Code: "INVALID_RANGE", Message: "olderThanDays は 1 以上で指定してください。"})
+		return
+	}
+
+	out, err := h.purgeUC.Execute(r.Context(), usecase.PurgeArchivedTasksInput{
+		OlderThanDays: req.OlderThanDays,
+		DryRun:        req.DryRun,
+		ActorID:       actorID(r),
+		Now:           h.clock.Now(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrActorRequired):
+			apierror.Write(w, http.StatusUnauthorized, apierror.New(apierror.CodeUnauthorized, "X-User-ID header is required"))
+		case errors.Is(err, usecase.ErrForbidden):
+			apierror.Write(w, http.StatusForbidden, apierror.New(apierror.CodeForbidden, "the actor is not an operator"))
+		default:
+			slog.ErrorContext(r.Context(), "failed to purge archived tasks", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	resp := purgeArchivedTasksResponse{
+		DryRun:  req.DryRun,
+		Before:  out.Before,
+		Count:   len(out.Tasks),
+		HasMore: out.HasMore,
+		Tasks:   make([]purgedTaskResponse, 0, len(out.Tasks)),
+	}
+	for _, t := range out.Tasks {
+		item := purgedTaskResponse{ID: t.ID, ProjectID: t.ProjectID, WorkspaceID: t.WorkspaceID, Title: t.Title}
+		if t.ArchivedAt != nil {
+			item.ArchivedAt = *t.ArchivedAt
+		}
+		resp.Tasks = append(resp.Tasks, item)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,109 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"teamflow-shared/authz"
+
+	domain "teamflow-tasks/internal/domain/task"
+	taskinfra "teamflow-tasks/internal/infrastructure/task"
+	httpiface "teamflow-tasks/internal/interface/http"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+func TestPurgeArchivedTasksHandler(t *testing.T) {
+	now := fixedNow()
+	ctx := context.Background()
+	repo := taskinfra.NewMemoryTaskRepository()
+	for _, task := range []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", Title: "設計", Status: domain.StatusTodo, Priority: domain.PriorityHigh, CreatedAt: now, UpdatedAt: now},
+		{ID: "t2", ProjectID: "proj-1", Title: "実装", Status: domain.StatusDone, Priority: domain.PriorityLow, CreatedAt: now, UpdatedAt: now},
+		{ID: "t3", ProjectID: "proj-2", Title: "最近アーカイブ", Status: domain.StatusTodo, Priority: domain.PriorityLow, CreatedAt: now, UpdatedAt: now},
+		{ID: "t4", ProjectID: "proj-3", Title: "アーカイブしていない", Status: domain.StatusTodo, Priority: domain.PriorityLow, CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := repo.Save(ctx, task); err != nil {
+			t.Fatalf("failed to save task: %v", err)
+		}
+	}
+	if _, err := repo.ArchiveByProject(ctx, "proj-1", now.AddDate(0, 0, -100)); err != nil {
+		t.Fatalf("failed to archive tasks: %v", err)
+	}
+	if _, err := repo.ArchiveByProject(ctx, "proj-2", now.AddDate(0, 0, -10)); err != nil {
+		t.Fatalf("failed to archive tasks: %v", err)
+	}
+	handler := httpiface.NewPurgeArchivedTasksHandler(&usecase.PurgeArchivedTasksUsecase{
+		Purger:    repo,
+		Operators: authz.ParseOperators("ops-1"),
+	}, fixedClock)
+
+	// 順に実行する（前のステップの結果に依存する）
+	steps := []struct {
+		name       string
+		actor      string
+		body       string
+		wantStatus int
+		wantCode   string // 400 の場合の ValidationIssue の code
+		wantIDs    string
+		wantRemain []string // 実行後に残っているタスク
+	}{
+		{name: "no actor", body: `{"olderThanDays":90}`, wantStatus: http.StatusUnauthorized},
+		{name: "not an operator", actor: "user-1", body: `{"olderThanDays":90}`, wantStatus: http.StatusForbidden},
+		{name: "missing days", actor: "ops-1", body: `{"dryRun":true}`, wantStatus: http.StatusBadRequest, wantCode: "INVALID_RANGE"},
+		{name: "invalid json", actor: "ops-1", body: `{`, wantStatus: http.StatusBadRequest},
+		{name: "dry run", actor: "ops-1", body: `{"olderThanDays":90,"dryRun":true}`, wantStatus: http.StatusOK, wantIDs: "t1,t2", wantRemain: []string{"t1", "t2", "t3", "t4"}},
+		{name: "purge", actor: "ops-1", body: `{"olderThanDays":90}`, wantStatus: http.StatusOK, wantIDs: "t1,t2", wantRemain: []string{"t3", "t4"}},
+		{name: "purge again", actor: "ops-1", body: `{"olderThanDays":90}`, wantStatus: http.StatusOK, wantIDs: "", wantRemain: []string{"t3", "t4"}},
+	}
+	for _, step := range steps {
+		req := httptest.NewRequest(http.MethodPost, "/admin/purge", strings.NewReader(step.body))
+		if step.actor != "" {
+			req.Header.Set(authz.ActorHeader, step.actor)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != step.wantStatus {
+			t.Fatalf("%s: expected status %d, got %d: %s", step.name, step.wantStatus, w.Code, w.Body.String())
+		}
+		if step.wantCode != "" && !strings.Contains(w.Body.String(), `"code":"`+step.wantCode+`"`) {
+			t.Errorf("%s: expected code %s, got %s", step.name, step.wantCode, w.Body.String())
+		}
+		if w.Code != http.StatusOK {
+			continue
+		}
+		var got struct {
+			DryRun  bool `json:"dryRun"`
+			Count   int  `json:"count"`
+			HasMore bool `json:"hasMore"`
+			Tasks   []struct {
+				ID         string `json:"id"`
+				ArchivedAt string `json:"archivedAt"`
+			} `json:"tasks"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("%s: failed to decode response: %v", step.name, err)
+		}
+		ids := make([]string, len(got.Tasks))
+		for i, task := range got.Tasks {
+			ids[i] = task.ID
+			if task.ArchivedAt == "" {
+				t.Errorf("%s: expected archivedAt for %s", step.name, task.ID)
+			}
+		}
+		if strings.Join(ids, ",") != step.wantIDs || got.Count != len(ids) || got.HasMore {
+			t.Errorf("%s: unexpected response: %s", step.name, w.Body.String())
+		}
+		for _, id := range []string{"t1", "t2", "t3", "t4"} {
+			_, err := repo.FindByID(ctx, id)
+			if remain := slices.Contains(step.wantRemain, id); remain != (err == nil) {
+				t.Errorf("%s: task %s remains = %v, want %v", step.name, id, err == nil, remain)
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"teamflow-shared/authz"
+
+	domain "teamflow-tasks/internal/domain/task"
+	"teamflow-tasks/internal/interface/httpjson"
+)
+
+// createTaskRequest は POST /api/tasks・POST /api/projects/{projectId}/tasks のリクエストボディ（tasks:batch の 1 件も同じ）。
+type createTaskRequest struct {
+	ID          string   `json:"id"`
+	ProjectID   string   `json:"projectId"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Priority    string   `json:"priority"`
+	AssigneeID  string   `json:"assigneeId"`
+	DueDate     *string  `json:"dueDate"` // YYYY-MM-DD か RFC3339（オフセットでの日付を使う）
+	MilestoneID string   `json:"milestoneId"`
+	SprintID    string   `json:"sprintId"`
+	EpicID      string   `json:"epicId"`
+	LabelIDs    []string `json:"labelIds"` // projects サービスで定義済みのラベルのみ指定できる
+}
+
+// PatchTaskRequest は PATCH /api/tasks/{id} のリクエストボディ。
+// すべてのフィールドを httpjson.Nullable で受け取り、未指定 / null / 値あり を区別する。
+type PatchTaskRequest struct {
+	Title       httpjson.Nullable[string]   `json:"title"`
+	Description httpjson.Nullable[string]   `json:"description"`
+	Status      httpjson.Nullable[string]   `json:"status"`
+	Priority    httpjson.Nullable[string]   `json:"priority"`
+	AssigneeID  httpjson.Nullable[string]   `json:"assigneeId"`
+	DueDate     httpjson.Nullable[string]   `json:"dueDate"`
+	StartDate   httpjson.Nullable[string]   `json:"startDate"`
+	Estimate    httpjson.Nullable[int]      `json:"estimate"`
+	MilestoneID httpjson.Nullable[string]   `json:"milestoneId"`
+	SprintID    httpjson.Nullable[string]   `json:"sprintId"`
+	EpicID      httpjson.Nullable[string]   `json:"epicId"`
+	LabelIDs    httpjson.Nullable[[]string] `json:"labelIds"` // 指定した一覧で置き換える。null はすべて外す
+}
+
+// isEmpty は全フィールドが未指定かどうかを返す。
+func (req *PatchTaskRequest) isEmpty() bool {
+	return !req.Title.Set &&
+		!req.Description.Set &&
+		!req.Status.Set &&
+		!req.Priority.Set &&
+		!req.AssigneeID.Set &&
+		!req.DueDate.Set &&
+		!req.StartDate.Set &&
+		!req.Estimate.Set &&
+		!req.MilestoneID.Set &&
+		!req.SprintID.Set &&
+		!req.EpicID.Set &&
+		!req.LabelIDs.Set
+}
+
+// toPatch は httpjson.Nullable を domain.Patch に変換する。
+func toPatch[T any](n httpjson.Nullable[T]) domain.Patch[T] {
+	switch {
+	case !n.Set:
+		return domain.Unset[T]()
+	case !n.Valid:
+		return domain.Null[T]()
+	default:
+		return domain.Set(n.Val)
+	}
+}
+
+// parseDueDate はリクエストの dueDate（YYYY-MM-DD か RFC3339）を日付に変換する。省略・null の場合は nil（期日なし）。
+func parseDueDate(v *string) (*time.Time, error) {
+	if v == nil {
+		return nil, nil
+	}
+	d, err := domain.ParseDueDate(*v)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// parseRFC3339 は RFC3339 形式の日時文字列をパースする関数を返す（エラー文言に field 名を含める）。
+func parseRFC3339(field string) func(string) (time.Time, error) {
+	return func(v string) (time.Time, error) {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, errors.New(field + " must be RFC3339")
+		}
+		return parsed, nil
+	}
+}
+
+// actorID はリクエストの操作者（X-User-ID ヘッダ）を返す。未設定の場合は空文字。
+func actorID(r *http.Request) string {
+	return strings.TrimSpace(r.Header.Get(authz.ActorHeader))
+}
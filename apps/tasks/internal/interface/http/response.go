@@ -0,0 +1,165 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/requestid"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// taskResponse はタスクのレスポンス用構造体。
+type taskResponse struct {
+	ID          string  `json:"id"`
+	ProjectID   string  `json:"projectId"`
+	Number      int     `json:"number"` // プロジェクト内のタスク番号（TFLOW-123 の 123）
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Status      string  `json:"status"`
+	Priority    string  `json:"priority"`
+	AssigneeID  *string `json:"assigneeId"`
+	// AssigneeName は担当者の表示名（一覧のみ。users サービスを使わない場合や取得できない場合は省略する）
+	AssigneeName string     `json:"assigneeName,omitempty"`
+	DueDate      *string    `json:"dueDate"` // 期日（YYYY-MM-DD）
+	StartDate    *time.Time `json:"startDate"`
+	Estimate     *int       `json:"estimate"`
+	MilestoneID  *string    `json:"milestoneId"`
+	SprintID     *string    `json:"sprintId"`
+	EpicID       *string    `json:"epicId"`
+	LabelIDs     []string   `json:"labelIds"` // ラベルが無い場合も空配列
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+	CreatedBy    string     `json:"createdBy,omitempty"`  // 作成者（X-User-ID）。不明な場合は省略する
+	UpdatedBy    string     `json:"updatedBy,omitempty"`  // 最終更新者（X-User-ID）。不明な場合は省略する
+	ArchivedAt   *time.Time `json:"archivedAt,omitempty"` // プロジェクトの削除に伴ってアーカイブされた日時
+	Version      int        `json:"version"`              // 版数（PATCH の If-Match に指定する）
+}
+
+// toTaskResponse はタスクをレスポンスに変換する。AssigneeName（一覧のみ）は呼び出し側で設定する。
+func toTaskResponse(t *domain.Task) taskResponse {
+	return taskResponse{
+		ID:          t.ID,
+		ProjectID:   t.ProjectID,
+		Number:      t.Number,
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      string(t.Status),
+		Priority:    string(t.Priority),
+		AssigneeID:  t.AssigneeID,
+		DueDate:     dueDateResponse(t.DueDate),
+		StartDate:   t.StartDate,
+		Estimate:    t.Estimate,
+		MilestoneID: t.MilestoneID,
+		SprintID:    t.SprintID,
+		EpicID:      t.EpicID,
+		LabelIDs:    labelIDsResponse(t.LabelIDs),
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+		CreatedBy:   t.CreatedBy,
+		UpdatedBy:   t.UpdatedBy,
+		ArchivedAt:  t.ArchivedAt,
+		Version:     t.Version,
+	}
+}
+
+// dueDateResponse はレスポンス用の期日（YYYY-MM-DD）を返す。期日が無い場合は nil（null）。
+func dueDateResponse(d *time.Time) *string {
+	if d == nil {
+		return nil
+	}
+	s := d.Format(time.DateOnly)
+	return &s
+}
+
+// labelIDsResponse はレスポンス用のラベル ID の一覧を返す（nil は空配列にする）。
+func labelIDsResponse(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
+
+type errorResponse struct {
+	Error     string `json:"error"`
+	Detail    string `json:"detail"`
+	RequestID string `json:"requestId,omitempty"` // X-Request-ID（apierror.Write と同じ）
+}
+
+// writeErrorResponse はエラーレスポンスを書き込む。
+func writeErrorResponse(w http.ResponseWriter, statusCode int, errorMsg, detail string) {
+	resp := errorResponse{
+		Error:     errorMsg,
+		Detail:    detail,
+		RequestID: w.Header().Get(requestid.Header),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// writeTimeoutResponse はリポジトリへの問い合わせのタイムアウトを 504 + TIMEOUT で返す。
+func writeTimeoutResponse(w http.ResponseWriter) {
+	apierror.Write(w, http.StatusGatewayTimeout, apierror.New(
+		apierror.CodeTimeout,
+		"The query took too long. Narrow down the filters and try again.",
+	))
+}
+
+// writeAuthzError はプロジェクトの権限のエラーを 401 / 403 / 404 で書き込み、書き込んだかどうかを返す。
+// メンバーでない場合（ErrProjectNotFound）は、プロジェクトの有無を明かさないよう存在しない場合と同じ 404 にする。
+func writeAuthzError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, usecase.ErrActorRequired):
+		apierror.Write(w, http.StatusUnauthorized, apierror.New(apierror.CodeUnauthorized, "X-User-ID header is required"))
+	case errors.Is(err, usecase.ErrForbidden):
+		apierror.Write(w, http.StatusForbidden, apierror.New(apierror.CodeForbidden, "the actor is not allowed to access this project"))
+	case errors.Is(err, usecase.ErrProjectNotFound):
+		apierror.Write(w, http.StatusNotFound, apierror.New(apierror.CodeNotFound, "project not found"))
+	default:
+		return false
+	}
+	return true
+}
+
+// writeFieldForbidden はプロジェクト設定でロックされたフィールドの変更（FIELD_FORBIDDEN）を
+// 403 と details.issues で書き込み、書き込んだかどうかを返す。
+func writeFieldForbidden(w http.ResponseWriter, err error) bool {
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) || ve.Code != "FIELD_FORBIDDEN" {
+		return false
+	}
+	apierror.Write(w, http.StatusForbidden, apierror.New(apierror.CodeForbidden, "the actor's role is not allowed to change "+ve.Field,
+		ValidationIssue{
+			Location: apierror.LocationBody,
+			Field:    ve.Field,
+			Code:     ve.Code,
+			Message:  ve.Field + " はプロジェクト設定でロックされているため、操作者のロールでは変更できません。",
+		}))
+	return true
+}
+
+// writeVersionConflict は If-Match の版数より後にタスクが変更されていたこと（domain.ErrVersionConflict）を
+// 409 と details.issues（変更されていたフィールド）で書き込み、書き込んだかどうかを返す。ETag には現在の版数を返す。
+func writeVersionConflict(w http.ResponseWriter, err error) bool {
+	var conflict *domain.VersionConflictError
+	if !errors.As(err, &conflict) {
+		return false
+	}
+	issues := make([]ValidationIssue, 0, len(conflict.Fields))
+	for _, f := range conflict.Fields {
+		issues = append(issues, ValidationIssue{
+			Location: apierror.LocationBody,
+			Field:    f,
+			Code:     "CHANGED",
+			Message:  f + " は If-Match の版数より後に変更されています。タスクを取得し直してから変更してください。",
+		})
+	}
+	w.Header().Set("ETag", taskETag(conflict.Current))
+	apierror.Write(w, http.StatusConflict, apierror.New(apierror.CodeConflict, "the task was modified after the version in If-Match", issues...))
+	return true
+}
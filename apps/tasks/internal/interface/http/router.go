@@ -0,0 +1,162 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"teamflow-shared/apiversion"
+)
+
+// APIPrefix は tasks サービスの API を配置するパス。
+const APIPrefix = "/api"
+
+// Handlers は Router に登録する各エンドポイントのハンドラ。
+type Handlers struct {
+	Create         http.Handler // POST /api/tasks, POST /api/projects/{projectId}/tasks
+	List           http.Handler // GET /api/tasks?projectId=, GET /api/projects/{projectId}/tasks
+	Update         http.Handler // PATCH /api/tasks/{id}, PATCH /api/projects/{projectId}/tasks/{id}
+	Events         http.Handler // GET /api/projects/{projectId}/tasks/events
+	BatchCreate    http.Handler // POST /api/projects/{projectId}/tasks:batch
+	Stats          http.Handler // GET /api/projects/{projectId}/tasks/stats
+	MilestoneStats http.Handler // GET /api/projects/{projectId}/tasks/stats/milestones
+	EpicStats      http.Handler // GET /api/projects/{projectId}/tasks/stats/epics
+	LabelStats     http.Handler // GET /api/projects/{projectId}/tasks/stats/labels
+	BatchStats     http.Handler // POST /api/tasks:stats
+	GetByNumber    http.Handler // GET /api/projects/{projectId}/tasks/number/{n}
+	Cascade        http.Handler // POST /api/projects/{projectId}/tasks:archive|unarchive|delete
+	CarryOver      http.Handler // POST /api/projects/{projectId}/tasks:carry-over
+	Calendar       http.Handler // GET /api/projects/{projectId}/tasks.ics
+	Sync           http.Handler // GET /api/projects/{projectId}/sync
+	Export         http.Handler // GET /api/projects/{projectId}/tasks:export
+	InspectCursor  http.Handler // POST /api/admin/cursor/inspect（運用者のみ）
+	Purge          http.Handler // POST /api/admin/purge（運用者のみ）
+}
+
+// route は API のルーティングテーブルの 1 行。
+type route struct {
+	// pattern は http.ServeMux のパターン（/api（/api/v1）を除いたパス。メソッドを省略したルートはハンドラがメソッドを確認する）
+	pattern string
+	handler http.Handler
+}
+
+// routes は tasks サービスの API のルーティングテーブル。
+// 固定のパス（tasks/events など）は {taskId} などのワイルドカードより優先される。
+func (h Handlers) routes() []route {
+	return []route{
+		{"POST /tasks", h.Create},
+		{"GET /tasks", h.List},
+		{"/tasks:stats", h.BatchStats},             // projects サービスのプロジェクト一覧（expand=taskCounts）用
+		{"/tasks/{taskId}", h.Update},              // projectId を指定しない更新
+		{"/admin/cursor/inspect", h.InspectCursor}, // 運用者のみ
+		{"/admin/purge", h.Purge},                  // 運用者のみ
+		{"GET /projects/{projectId}/tasks", h.List},
+		{"POST /projects/{projectId}/tasks", http.HandlerFunc(h.createInProject)},
+		{"/projects/{projectId}/tasks/{taskId}", h.Update},                 // タスクが projectId に属さない場合は 404
+		{"/projects/{projectId}/tasks/events", h.Events},                   // SSE
+		{"/projects/{projectId}/tasks/stats", h.Stats},                     // projects サービスのプロジェクトカード用
+		{"/projects/{projectId}/tasks/stats/milestones", h.MilestoneStats}, // projects サービスのマイルストーンの進捗用
+		{"/projects/{projectId}/tasks/stats/epics", h.EpicStats},           // projects サービスのエピックの進捗用
+		{"/projects/{projectId}/tasks/stats/labels", h.LabelStats},         // projects サービスのラベルの使用数用
+		{"/projects/{projectId}/tasks/number/{number}", h.GetByNumber},     // プロジェクト内のタスク番号で取得
+		{"/projects/{projectId}/tasks:batch", h.BatchCreate},               // テンプレートからのプロジェクト作成用
+		{"/projects/{projectId}/tasks:carry-over", h.CarryOver},            // スプリントの完了時の持ち越し用
+		{"/projects/{projectId}/tasks:archive", h.Cascade},                 // プロジェクトの削除（cascade=archive_tasks）用
+		{"/projects/{projectId}/tasks:unarchive", h.Cascade},               // プロジェクトの復元用
+		{"/projects/{projectId}/tasks:delete", h.Cascade},                  // プロジェクトの削除（cascade=delete_tasks）用
+		{"/projects/{projectId}/tasks.ics", h.Calendar},                    // カレンダーアプリの購読用の iCalendar
+		{"/projects/{projectId}/sync", h.Sync},                             // オフライン対応のクライアントの差分同期
+		{"/projects/{projectId}/tasks:export", h.Export},                   // 大きなプロジェクトのエクスポート（NDJSON）
+	}
+}
+
+// NewRouter は tasks サービスの API のルーティングを行うハンドラを返す。ルートは routes で定義する。
+//
+// API はすべて APIPrefix 配下（/api/v1 と、その別名の /api）に置き、プレフィックスはここで一度だけ取り除く。
+// 各ハンドラは /api（/api/v1）を除いたパス（/tasks, /projects/{projectId}/tasks...）を扱う。
+// ルートに無いパスは 404、メソッドを指定したルートの他のメソッドは 405 を返す。
+// /healthz, /metrics などの運用エンドポイントは含まない。
+func NewRouter(h Handlers) http.Handler {
+	api := http.NewServeMux()
+	for _, rt := range h.routes() {
+		api.Handle(rt.pattern, rt.handler)
+	}
+
+	// 正式なパスは /api/v1 配下。バージョン無しの /api 配下は互換のため v1 の別名として扱う
+	return apiversion.Handler(APIPrefix, api)
+}
+
+// createInProject はパスの projectId をボディに設定して Create に渡す。
+func (h Handlers) createInProject(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("projectId")
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.Body.Close()
+
+	var reqMap map[string]interface{}
+	if err := json.Unmarshal(body, &reqMap); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "invalid json", err.Error())
+		return
+	}
+	// パスの projectId を優先する（ボディの値は上書き）
+	reqMap["projectId"] = projectID
+
+	newBody, err := json.Marshal(reqMap)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(newBody))
+	r.ContentLength = int64(len(newBody))
+
+	h.Create.ServeHTTP(w, r)
+}
+
+// routeSegments は RouteLabel でそのまま残すパスの要素（運用エンドポイントと routes の固定の要素）。
+// それ以外（ID など）は {id} に置き換える。
+var routeSegments = func() map[string]bool {
+	segments := map[string]bool{"livez": true, "readyz": true, "healthz": true, "openapi.json": true, "api": true}
+	for _, rt := range (Handlers{}).routes() {
+		path := rt.pattern
+		if _, p, ok := strings.Cut(path, " "); ok {
+			path = p
+		}
+		for _, seg := range strings.Split(strings.Trim(path, "/"), "/") {
+			if !strings.HasPrefix(seg, "{") {
+				segments[seg] = true
+			}
+		}
+	}
+	return segments
+}()
+
+// maxRouteSegments は RouteLabel で扱うパスの要素数の上限（/api/v1 の v1 を除く。これより深いパスは存在しない）。
+const maxRouteSegments = 6
+
+// RouteLabel はメトリクスの route ラベル用に、パスの ID を {id} に置き換えたルートを返す。
+// 例: /api/projects/p-1/tasks/number/3 → /api/projects/{id}/tasks/number/{id}
+// ラベルの種類が増え続けないよう、ルートに無い深さのパスは "other" にまとめる。
+func RouteLabel(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	// /api/v1 のバージョンはそのまま残す（別名の /api と分けて計測する）
+	versioned := len(parts) > 1 && parts[0] == "api" && parts[1] == apiversion.Current
+	limit := maxRouteSegments
+	if versioned {
+		limit++
+	}
+	if len(parts) > limit {
+		return "other"
+	}
+	for i, p := range parts {
+		if routeSegments[p] || p == "" || (versioned && i == 1) {
+			continue
+		}
+		parts[i] = "{id}"
+	}
+	return "/" + strings.Join(parts, "/")
+}
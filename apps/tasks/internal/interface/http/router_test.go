@@ -0,0 +1,191 @@
+package http_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpiface "teamflow-tasks/internal/interface/http"
+)
+
+// stubHandler は呼ばれたハンドラ名と、ハンドラが受け取ったパス・ボディを返す。
+func stubHandler(name string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Handler", name)
+		w.Header().Set("X-Path", r.URL.Path)
+		_, _ = w.Write(body)
+	})
+}
+
+func newStubRouter() http.Handler {
+	return httpiface.NewRouter(httpiface.Handlers{
+		Create:         stubHandler("create"),
+		List:           stubHandler("list"),
+		Update:         stubHandler("update"),
+		Events:         stubHandler("events"),
+		BatchCreate:    stubHandler("batchCreate"),
+		Stats:          stubHandler("stats"),
+		BatchStats:     stubHandler("batchStats"),
+		MilestoneStats: stubHandler("milestoneStats"),
+		EpicStats:      stubHandler("epicStats"),
+		LabelStats:     stubHandler("labelStats"),
+		GetByNumber:    stubHandler("getByNumber"),
+		Cascade:        stubHandler("cascade"),
+		CarryOver:      stubHandler("carryOver"),
+		Calendar:       stubHandler("calendar"),
+		Sync:           stubHandler("sync"),
+		Export:         stubHandler("export"),
+		InspectCursor:  stubHandler("inspectCursor"),
+		Purge:          stubHandler("purge"),
+	})
+}
+
+// TestRouter は公開している URL と、各ハンドラが受け取るパス（/api を除いたもの）を固定する。
+func TestRouter(t *testing.T) {
+	router := newStubRouter()
+
+	tests := []struct {
+		method      string
+		path        string
+		wantHandler string
+		wantPath    string
+		wantStatus  int
+	}{
+		{method: http.MethodPost, path: "/api/tasks", wantHandler: "create", wantPath: "/tasks"},
+		{method: http.MethodGet, path: "/api/tasks?projectId=proj-1", wantHandler: "list", wantPath: "/tasks"},
+		{method: http.MethodPatch, path: "/api/tasks/task-1", wantHandler: "update", wantPath: "/tasks/task-1"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/tasks", wantHandler: "list", wantPath: "/projects/proj-1/tasks"},
+		{method: http.MethodPost, path: "/api/projects/proj-1/tasks", wantHandler: "create", wantPath: "/projects/proj-1/tasks"},
+		{method: http.MethodPatch, path: "/api/projects/proj-1/tasks/task-1", wantHandler: "update", wantPath: "/projects/proj-1/tasks/task-1"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/tasks/events", wantHandler: "events", wantPath: "/projects/proj-1/tasks/events"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/tasks/stats", wantHandler: "stats", wantPath: "/projects/proj-1/tasks/stats"},
+		{method: http.MethodPost, path: "/api/tasks:stats", wantHandler: "batchStats", wantPath: "/tasks:stats"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/tasks/stats/milestones", wantHandler: "milestoneStats", wantPath: "/projects/proj-1/tasks/stats/milestones"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/tasks/stats/epics", wantHandler: "epicStats", wantPath: "/projects/proj-1/tasks/stats/epics"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/tasks/stats/labels", wantHandler: "labelStats", wantPath: "/projects/proj-1/tasks/stats/labels"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/tasks/number/3", wantHandler: "getByNumber", wantPath: "/projects/proj-1/tasks/number/3"},
+		{method: http.MethodPost, path: "/api/projects/proj-1/tasks:carry-over", wantHandler: "carryOver", wantPath: "/projects/proj-1/tasks:carry-over"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/tasks.ics", wantHandler: "calendar", wantPath: "/projects/proj-1/tasks.ics"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/sync", wantHandler: "sync", wantPath: "/projects/proj-1/sync"},
+		{method: http.MethodGet, path: "/api/projects/proj-1/tasks:export?format=ndjson", wantHandler: "export", wantPath: "/projects/proj-1/tasks:export"},
+		{method: http.MethodPost, path: "/api/projects/proj-1/tasks:batch", wantHandler: "batchCreate", wantPath: "/projects/proj-1/tasks:batch"},
+		{method: http.MethodPost, path: "/api/projects/proj-1/tasks:archive", wantHandler: "cascade", wantPath: "/projects/proj-1/tasks:archive"},
+		{method: http.MethodPost, path: "/api/projects/proj-1/tasks:unarchive", wantHandler: "cascade", wantPath: "/projects/proj-1/tasks:unarchive"},
+		{method: http.MethodPost, path: "/api/projects/proj-1/tasks:delete", wantHandler: "cascade", wantPath: "/projects/proj-1/tasks:delete"},
+		{method: http.MethodPost, path: "/api/admin/cursor/inspect", wantHandler: "inspectCursor", wantPath: "/admin/cursor/inspect"},
+		{method: http.MethodPost, path: "/api/admin/purge", wantHandler: "purge", wantPath: "/admin/purge"},
+
+		{method: http.MethodDelete, path: "/api/tasks", wantStatus: http.StatusMethodNotAllowed},
+		{method: http.MethodDelete, path: "/api/projects/proj-1/tasks", wantStatus: http.StatusMethodNotAllowed},
+		{method: http.MethodGet, path: "/api/projects/proj-1", wantStatus: http.StatusNotFound},
+		{method: http.MethodGet, path: "/api/projects/proj-1/tasks/task-1/extra/more", wantStatus: http.StatusNotFound},
+		// /api を付けないパスや二重の /api は公開しない
+		{method: http.MethodGet, path: "/tasks?projectId=proj-1", wantStatus: http.StatusNotFound},
+		{method: http.MethodGet, path: "/projects/proj-1/tasks", wantStatus: http.StatusNotFound},
+		{method: http.MethodGet, path: "/api/api/projects/proj-1/tasks", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader("{}"))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if tt.wantHandler == "" {
+				if w.Code != tt.wantStatus {
+					t.Fatalf("expected status %d, got %d (handler=%q)", tt.wantStatus, w.Code, w.Header().Get("X-Handler"))
+				}
+				return
+			}
+			if got := w.Header().Get("X-Handler"); got != tt.wantHandler {
+				t.Fatalf("expected handler %q, got %q (status %d)", tt.wantHandler, got, w.Code)
+			}
+			if got := w.Header().Get("X-Path"); got != tt.wantPath {
+				t.Errorf("expected path %q, got %q", tt.wantPath, got)
+			}
+		})
+	}
+}
+
+func TestRouter_CreateInProjectSetsProjectID(t *testing.T) {
+	router := newStubRouter()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/projects/proj-1/tasks", strings.NewReader(`{"title":"画面設計","projectId":"proj-2"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var got map[string]string
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode forwarded body: %v", err)
+	}
+	if got["projectId"] != "proj-1" || got["title"] != "画面設計" {
+		t.Errorf("expected projectId from path and title preserved, got %v", got)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/projects/proj-1/tasks", strings.NewReader(`{invalid`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid json, got %d", w.Code)
+	}
+}
+
+// TestRouter_Versioned は正式な /api/v1 配下のパスと、レスポンスの X-API-Version を固定する。
+func TestRouter_Versioned(t *testing.T) {
+	router := newStubRouter()
+
+	tests := []struct {
+		method      string
+		path        string
+		wantHandler string
+		wantPath    string
+	}{
+		{method: http.MethodGet, path: "/api/v1/tasks", wantHandler: "list", wantPath: "/tasks"},
+		{method: http.MethodPatch, path: "/api/v1/projects/proj-1/tasks/t-1", wantHandler: "update", wantPath: "/projects/proj-1/tasks/t-1"},
+		{method: http.MethodPost, path: "/api/v1/tasks:stats", wantHandler: "batchStats", wantPath: "/tasks:stats"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if got := w.Header().Get("X-Handler"); got != tt.wantHandler {
+				t.Fatalf("expected handler %q, got %q (status %d)", tt.wantHandler, got, w.Code)
+			}
+			if got := w.Header().Get("X-Path"); got != tt.wantPath {
+				t.Errorf("expected path %q, got %q", tt.wantPath, got)
+			}
+			if got := w.Header().Get("X-API-Version"); got != "v1" {
+				t.Errorf("expected X-API-Version v1, got %q", got)
+			}
+		})
+	}
+}
+
+func TestRouteLabel(t *testing.T) {
+	for _, tt := range []struct {
+		path string
+		want string
+	}{
+		{path: "/api/tasks", want: "/api/tasks"},
+		{path: "/api/tasks/t-1", want: "/api/tasks/{id}"},
+		{path: "/api/projects/p-1/tasks:batch", want: "/api/projects/{id}/tasks:batch"},
+		{path: "/api/projects/p-1/tasks/stats/milestones", want: "/api/projects/{id}/tasks/stats/milestones"},
+		{path: "/api/projects/p-1/tasks/number/3", want: "/api/projects/{id}/tasks/number/{id}"},
+		{path: "/api/v1/projects/p-1/tasks", want: "/api/v1/projects/{id}/tasks"},
+		{path: "/api/v1/projects/p-1/tasks.ics", want: "/api/v1/projects/{id}/tasks.ics"},
+		{path: "/api/projects/p-1/sync", want: "/api/projects/{id}/sync"},
+		{path: "/api/v1/projects/p-1/tasks/number/3", want: "/api/v1/projects/{id}/tasks/number/{id}"},
+		{path: "/api/v1/admin/cursor/inspect", want: "/api/v1/admin/cursor/inspect"},
+		{path: "/healthz", want: "/healthz"},
+		{path: "/a/b/c/d/e/f/g", want: "other"},
+	} {
+		if got := httpiface.RouteLabel(tt.path); got != tt.want {
+			t.Errorf("RouteLabel(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/clock"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// SyncTasksHandler は GET /api/projects/{projectId}/sync を処理する HTTP ハンドラ。
+//
+// オフライン対応のクライアント向けに、since（前回の syncToken）以降に作成・更新されたタスク（upserts）と
+// 削除・アーカイブされたタスク（deleted）を変更の順序で返す。since を付けない場合はすべてのタスクを返す。
+// hasMore が true の場合は、返した syncToken ですぐに続きを取得する。
+type SyncTasksHandler struct {
+	syncUC *usecase.SyncTasksUsecase
+	clock  clock.Clock
+}
+
+// NewSyncTasksHandler は SyncTasksHandler を生成する。
+func NewSyncTasksHandler(syncUC *usecase.SyncTasksUsecase, clk clock.Clock) http.Handler {
+	return &SyncTasksHandler{syncUC: syncUC, clock: clk}
+}
+
+type syncDeletionResponse struct {
+	ID        string    `json:"id"`
+	DeletedAt time.Time `json:"deletedAt"`
+	Reason    string    `json:"reason"` // deleted または archived
+}
+
+type syncTasksResponse struct {
+	Upserts   []taskResponse         `json:"upserts"`
+	Deleted   []syncDeletionResponse `json:"deleted"`
+	SyncToken string                 `json:"syncToken"`
+	HasMore   bool                   `json:"hasMore"`
+}
+
+func (h *SyncTasksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	// /projects/{projectId}/sync（/api を除いたパス）から projectId を抽出
+	projectID, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/projects/"), "/sync")
+	if !ok || projectID == "" || strings.Contains(projectID, "/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	out, err := h.syncUC.Execute(r.Context(), usecase.SyncTasksInput{
+		ProjectID: projectID,
+		Since:     r.URL.Query().Get("since"),
+		ActorID:   actorID(r),
+		Now:       h.clock.Now(),
+	})
+	if err != nil {
+		if writeAuthzError(w, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, domain.ErrSyncTokenInvalid), errors.Is(err, domain.ErrSyncTokenExpired):
+			apierror.Write(w, http.StatusBadRequest, NewValidationErrorResponse(toValidationIssue(err)))
+		case errors.Is(err, usecase.ErrTimeout):
+			writeTimeoutResponse(w)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	resp := syncTasksResponse{
+		Upserts:   make([]taskResponse, 0, len(out.Upserts)),
+		Deleted:   make([]syncDeletionResponse, 0, len(out.Deletions)),
+		SyncToken: out.SyncToken,
+		HasMore:   out.HasMore,
+	}
+	for _, t := range out.Upserts {
+		resp.Upserts = append(resp.Upserts, toTaskResponse(t))
+	}
+	for _, d := range out.Deletions {
+		resp.Deleted = append(resp.Deleted, syncDeletionResponse{ID: d.TaskID, DeletedAt: d.DeletedAt, Reason: d.Reason})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "private, no-store")
+	_ = json.NewEncoder(w).Encode(resp)
+}
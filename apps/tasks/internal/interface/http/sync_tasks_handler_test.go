@@ -0,0 +1,126 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	domain "teamflow-tasks/internal/domain/task"
+	taskinfra "teamflow-tasks/internal/infrastructure/task"
+	httpiface "teamflow-tasks/internal/interface/http"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+type syncResponse struct {
+	Upserts []struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	} `json:"upserts"`
+	Deleted []struct {
+		ID        string    `json:"id"`
+		DeletedAt time.Time `json:"deletedAt"`
+		Reason    string    `json:"reason"`
+	} `json:"deleted"`
+	SyncToken string `json:"syncToken"`
+	HasMore   bool   `json:"hasMore"`
+}
+
+func TestSyncTasksHandler(t *testing.T) {
+	ctx := context.Background()
+	now := fixedNow()
+	repo := taskinfra.NewMemoryTaskRepository()
+	handler := httpiface.NewSyncTasksHandler(&usecase.SyncTasksUsecase{
+		Feed:   taskinfra.NewMemoryTaskChanges(repo),
+		Access: privateProjectAccess{private: "proj-1", member: "user-1"},
+		Secret: []byte("test-secret"),
+	}, fixedClock)
+	sync := func(since string) syncResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/projects/proj-1/sync?since="+url.QueryEscape(since), nil)
+		req.Header.Set("X-User-ID", "user-1")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+		}
+		var resp syncResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	t1 := &domain.Task{ID: "t1", ProjectID: "proj-1", Title: "設計", Status: domain.StatusTodo, Priority: domain.PriorityLow, CreatedAt: now, UpdatedAt: now}
+	if err := repo.Save(ctx, t1); err != nil {
+		t.Fatalf("failed to save task: %v", err)
+	}
+	first := sync("")
+	if len(first.Upserts) != 1 || first.Upserts[0].ID != "t1" || len(first.Deleted) != 0 || first.SyncToken == "" {
+		t.Fatalf("unexpected first sync: %+v", first)
+	}
+
+	// 変更が無ければ空を返す
+	if empty := sync(first.SyncToken); len(empty.Upserts) != 0 || len(empty.Deleted) != 0 {
+		t.Errorf("expected no changes, got %+v", empty)
+	}
+
+	t1.Title = "設計レビュー"
+	t1.RecordChange("title")
+	if err := repo.Update(ctx, t1); err != nil {
+		t.Fatalf("failed to update task: %v", err)
+	}
+	second := sync(first.SyncToken)
+	if len(second.Upserts) != 1 || second.Upserts[0].Title != "設計レビュー" {
+		t.Fatalf("expected the updated task, got %+v", second)
+	}
+
+	if _, err := repo.DeleteByProject(ctx, "proj-1"); err != nil {
+		t.Fatalf("failed to delete tasks: %v", err)
+	}
+	third := sync(second.SyncToken)
+	if len(third.Upserts) != 0 || len(third.Deleted) != 1 || third.Deleted[0].ID != "t1" || third.Deleted[0].Reason != "deleted" {
+		t.Fatalf("expected a tombstone of t1, got %+v", third)
+	}
+}
+
+func TestSyncTasksHandler_Errors(t *testing.T) {
+	secret := []byte("test-secret")
+	handler := httpiface.NewSyncTasksHandler(&usecase.SyncTasksUsecase{
+		Feed:   taskinfra.NewMemoryTaskChanges(taskinfra.NewMemoryTaskRepository()),
+		Access: privateProjectAccess{private: "proj-1", member: "user-1"},
+		Secret: secret,
+	}, fixedClock)
+	expired, err := domain.EncodeSyncToken(domain.SyncTokenPayload{
+		V: domain.SyncTokenVersion, ProjectID: "proj-1", Seq: 1, IssuedAt: fixedNow().Add(-usecase.DefaultTombstoneRetention - time.Hour).Unix(),
+	}, secret)
+	if err != nil {
+		t.Fatalf("failed to encode sync token: %v", err)
+	}
+
+	for _, tt := range []struct {
+		name, method, path, actor string
+		want                      int
+	}{
+		{name: "no actor", method: http.MethodGet, path: "/projects/proj-1/sync", want: http.StatusUnauthorized},
+		{name: "not a member", method: http.MethodGet, path: "/projects/proj-1/sync", actor: "user-2", want: http.StatusForbidden},
+		{name: "invalid since", method: http.MethodGet, path: "/projects/proj-1/sync?since=garbage", actor: "user-1", want: http.StatusBadRequest},
+		{name: "expired since", method: http.MethodGet, path: "/projects/proj-1/sync?since=" + expired, actor: "user-1", want: http.StatusBadRequest},
+		{name: "post", method: http.MethodPost, path: "/projects/proj-1/sync", actor: "user-1", want: http.StatusMethodNotAllowed},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.actor != "" {
+				req.Header.Set("X-User-ID", tt.actor)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, w.Code, w.Body)
+			}
+		})
+	}
+}
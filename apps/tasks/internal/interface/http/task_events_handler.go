@@ -0,0 +1,144 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	"teamflow-shared/workspace"
+
+	"teamflow-tasks/internal/broadcast"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// FlagTaskEvents はタスク変更イベントの配信（SSE）を有効にするフィーチャーフラグ。
+// 無効にすると GET /api/projects/{projectId}/tasks/events は 404 になる（クライアントはポーリングに戻る）。
+const FlagTaskEvents = "task-events"
+
+// sseHeartbeatInterval はプロキシによる無通信切断を防ぐためのコメント送信間隔。
+const sseHeartbeatInterval = 25 * time.Second
+
+// sseReconnectDelay はサーバーの停止時にクライアント（EventSource）へ伝える再接続までの最短の待ち時間（retry）。
+// デプロイで全クライアントが同時に再接続しないよう、実際には最大で 2 倍までばらつかせる。
+const sseReconnectDelay = 2 * time.Second
+
+// eventsSuffix はタスク変更イベント（SSE）のパスの末尾。
+const eventsSuffix = "/tasks/events"
+
+// IsEventsPath は path（/api または /api/v1 を含むパス）がタスク変更イベントの SSE かどうかを返す。
+// 接続を保ち続けるため、処理中のリクエスト数の上限（loadshed）に数えないパスの判定に使う。
+func IsEventsPath(path string) bool {
+	return strings.HasPrefix(path, APIPrefix+"/") && strings.HasSuffix(path, eventsSuffix)
+}
+
+// TaskEventsHandler は GET /api/projects/{projectId}/tasks/events を処理する SSE ハンドラ。
+//
+// 責務:
+//   - access が設定されていれば、購読の前に操作者がプロジェクトを閲覧できるか確認する
+//   - 操作者のワークスペースにあるプロジェクトのタスク変更イベントを broadcast.Broker から購読する
+//   - イベントを text/event-stream（event: <type> / data: <JSON>）で送信する
+//   - クライアントが切断したら購読を解除する
+//   - サーバーの停止時（WithEventsShutdown）は再接続までの待ち時間（retry）を送ってからストリームを閉じる
+type TaskEventsHandler struct {
+	broker    *broadcast.Broker
+	access    usecase.ProjectAccessChecker
+	heartbeat time.Duration
+	shutdown  <-chan struct{}
+}
+
+// TaskEventsHandlerOption は TaskEventsHandler の任意の設定。
+type TaskEventsHandlerOption func(*TaskEventsHandler)
+
+// WithEventsShutdown はサーバーの停止（graceful shutdown の開始）で閉じるチャネルを設定する（server.Drain の Done）。
+// 閉じると、接続中のクライアントに再接続を促してからストリームを閉じる。省略時は切断されるまで送り続ける。
+func WithEventsShutdown(done <-chan struct{}) TaskEventsHandlerOption {
+	return func(h *TaskEventsHandler) {
+		h.shutdown = done
+	}
+}
+
+// NewTaskEventsHandler は TaskEventsHandler を生成する。access が nil の場合は閲覧権限を確認しない。
+func NewTaskEventsHandler(broker *broadcast.Broker, access usecase.ProjectAccessChecker, opts ...TaskEventsHandlerOption) http.Handler {
+	h := &TaskEventsHandler{
+		broker:    broker,
+		access:    access,
+		heartbeat: sseHeartbeatInterval,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *TaskEventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	// /projects/{projectId}/tasks/events（/api を除いたパス）から projectId を抽出
+	projectID, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/projects/"), "/tasks/events")
+	if !ok || projectID == "" || strings.Contains(projectID, "/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if h.access != nil {
+		if err := h.access.AuthorizeRead(r.Context(), projectID, actorID(r)); err != nil {
+			if !writeAuthzError(w, err) {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+			return
+		}
+	}
+
+	events, cancel := h.broker.Subscribe(workspace.FromContext(r.Context()), projectID)
+	defer cancel()
+
+	// SSE は長時間の接続になるため、サーバーの WriteTimeout を解除する
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprint(w, ": connected\n\n"); err != nil {
+		return
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		var err error
+		select {
+		case <-r.Context().Done():
+			return
+		case <-h.shutdown:
+			// 停止するサーバーへの接続を閉じ、EventSource には retry の後に別のインスタンスへ再接続させる
+			delay := sseReconnectDelay + rand.N(sseReconnectDelay)
+			if _, err := fmt.Fprintf(w, ": server shutting down\nretry: %d\n\n", delay.Milliseconds()); err == nil {
+				_ = rc.Flush()
+			}
+			return
+		case e := <-events:
+			data, _ := json.Marshal(e)
+			_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+		case <-ticker.C:
+			_, err = fmt.Fprint(w, ": ping\n\n")
+		}
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package http_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"teamflow-tasks/internal/broadcast"
+	httpiface "teamflow-tasks/internal/interface/http"
+)
+
+func TestTaskEventsHandler_StreamsProjectEvents(t *testing.T) {
+	broker := broadcast.NewBroker()
+	srv := httptest.NewServer(httpiface.NewTaskEventsHandler(broker, nil))
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/projects/proj-1/tasks/events")
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", res.StatusCode)
+	}
+	if ct := res.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected Content-Type text/event-stream, got %q", ct)
+	}
+
+	reader := bufio.NewReader(res.Body)
+	readEvent := func() string {
+		var lines []string
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatalf("failed to read stream: %v", err)
+			}
+			if line == "\n" {
+				return strings.Join(lines, "")
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	// 購読開始の確認（以降の Publish は必ず届く）
+	if got := readEvent(); got != ": connected\n" {
+		t.Fatalf("expected connected comment, got %q", got)
+	}
+
+	// 別のプロジェクトと、別のワークスペースの同じ ID のプロジェクトのイベントは届かない
+	broker.Publish(broadcast.Event{Type: broadcast.EventTaskUpdated, WorkspaceID: "default", ProjectID: "proj-2", TaskID: "other"})
+	broker.Publish(broadcast.Event{Type: broadcast.EventTaskUpdated, WorkspaceID: "ws-2", ProjectID: "proj-1", TaskID: "other"})
+	broker.Publish(broadcast.Event{Type: broadcast.EventTaskUpdated, WorkspaceID: "default", ProjectID: "proj-1", TaskID: "task-1"})
+
+	want := "event: task.updated\ndata: {\"type\":\"task.updated\",\"workspaceId\":\"default\",\"projectId\":\"proj-1\",\"taskId\":\"task-1\"}\n"
+	if got := readEvent(); got != want {
+		t.Errorf("unexpected event:\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestTaskEventsHandler_ClosesOnShutdown(t *testing.T) {
+	shutdown := make(chan struct{})
+	srv := httptest.NewServer(httpiface.NewTaskEventsHandler(broadcast.NewBroker(), nil, httpiface.WithEventsShutdown(shutdown)))
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/projects/proj-1/tasks/events")
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer res.Body.Close()
+	reader := bufio.NewReader(res.Body)
+	if line, err := reader.ReadString('\n'); err != nil || line != ": connected\n" {
+		t.Fatalf("expected connected comment, got %q (%v)", line, err)
+	}
+
+	close(shutdown)
+	rest, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("expected the stream to be closed, got %v", err)
+	}
+	// retry は 2〜4 秒（ミリ秒）の範囲でばらつかせる
+	var delay int
+	if _, err := fmt.Sscanf(string(rest), "\n: server shutting down\nretry: %d\n\n", &delay); err != nil || delay < 2000 || delay >= 4000 {
+		t.Errorf("expected a retry hint before closing, got %q", rest)
+	}
+}
+
+func TestTaskEventsHandler_Errors(t *testing.T) {
+	handler := httpiface.NewTaskEventsHandler(broadcast.NewBroker(), nil)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{name: "method not allowed", method: http.MethodPost, path: "/projects/proj-1/tasks/events", wantStatus: http.StatusMethodNotAllowed},
+		{name: "missing projectId", method: http.MethodGet, path: "/projects//tasks/events", wantStatus: http.StatusNotFound},
+		{name: "unknown path", method: http.MethodGet, path: "/projects/proj-1/tasks/events/x", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestIsEventsPath(t *testing.T) {
+	for path, want := range map[string]bool{
+		"/api/projects/proj-1/tasks/events":    true,
+		"/api/v1/projects/proj-1/tasks/events": true,
+		"/api/projects/proj-1/tasks":           false,
+		"/projects/proj-1/tasks/events":        false,
+	} {
+		if got := httpiface.IsEventsPath(path); got != want {
+			t.Errorf("IsEventsPath(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
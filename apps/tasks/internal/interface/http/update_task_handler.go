@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 	"strings"
-	"time"
+
+	"teamflow-shared/uuidpolicy"
 
 	domain "teamflow-tasks/internal/domain/task"
 	usecase "teamflow-tasks/internal/usecase/task"
@@ -14,12 +16,17 @@ import (
 // UpdateTaskHandler は PATCH /tasks/{id} を処理する HTTP ハンドラ。
 //
 // 責務:
-//   - PATCH /api/tasks/{id} エンドポイントのリクエストを受け付ける
-//   - パスパラメータからタスクIDを抽出する
+//   - PATCH /api/tasks/{id} および PATCH /api/projects/{projectId}/tasks/{id} エンドポイントのリクエストを受け付ける
+//   - パスパラメータからタスクID（とプロジェクトID）を抽出する
 //   - リクエストボディのJSONをパースし、部分更新用のPatch型に変換する
-//   - 各フィールドのバリデーションを行う（titleの空文字チェック、assigneeIdのUUID形式チェック、dueDateのRFC3339形式チェックなど）
+//   - 各フィールドのバリデーションを行う（titleの空文字チェック、assigneeIdのUUID形式チェック、dueDateのYYYY-MM-DD・RFC3339形式チェックなど）
+//   - If-Match（タスクの版数）と merge クエリを、更新の前提にした版数とフィールド単位のマージの指定に変換する
 //   - UpdateTaskUsecaseを呼び出してタスクを更新する
-//   - 更新されたタスクをJSONレスポンスとして返す
+//   - 更新されたタスクをJSONレスポンスとして返す（ETag は更新後の版数）
+//
+// If-Match を付けた場合、その版数より後にタスクが変更されていれば 409 CONFLICT を返す。merge=true の場合は、
+// リクエストで指定したフィールドがその版数より後に変更されていた場合だけ 409 にし、それ以外は現在のタスクに適用する。
+// 409 の details.issues には変更されていたフィールドを、ETag には現在の版数を返す。
 type UpdateTaskHandler struct {
 	updateUC *usecase.UpdateTaskUsecase
 }
@@ -33,136 +40,175 @@ func NewUpdateTaskHandler(
 	}
 }
 
-// PatchTaskRequest は PATCH /api/tasks/{id} のリクエストボディ。
-type PatchTaskRequest struct {
-	Title       *string        `json:"title"`
-	Description nullableString `json:"description"`
-	Status      *string        `json:"status"`
-	Priority    *string        `json:"priority"`
-	AssigneeID  OptionalString `json:"assigneeId"`
-	DueDate     nullableString `json:"dueDate"`
-}
-
 func (h *UpdateTaskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPatch {
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
 
-	// /api/tasks/{id} または /tasks/{id} から id を抽出
-	var path string
-	if strings.HasPrefix(r.URL.Path, "/api/tasks/") {
-		path = strings.TrimPrefix(r.URL.Path, "/api/tasks/")
-	} else if strings.HasPrefix(r.URL.Path, "/tasks/") {
-		path = strings.TrimPrefix(r.URL.Path, "/tasks/")
-	} else {
+	projectID, id, ok := parseTaskPath(r.URL.Path)
+	if !ok {
 		writeErrorResponse(w, http.StatusBadRequest, "validation error", "invalid task id")
 		return
 	}
 
-	if path == "" || strings.Contains(path, "/") {
-		writeErrorResponse(w, http.StatusBadRequest, "validation error", "invalid task id")
-		return
+	h.handleUpdate(w, r, projectID, id)
+}
+
+// parseTaskPath はパス（Router で /api を除いたもの）から projectId（任意）と taskId を抽出する。
+//
+// 対応するパス:
+//   - /projects/{projectId}/tasks/{taskId}（プロジェクトスコープ）
+//   - /tasks/{taskId}（projectId は空文字）
+func parseTaskPath(p string) (projectID, taskID string, ok bool) {
+	switch {
+	case strings.HasPrefix(p, "/projects/"):
+		parts := strings.Split(strings.TrimPrefix(p, "/projects/"), "/")
+		if len(parts) != 3 || parts[0] == "" || parts[1] != "tasks" || parts[2] == "" {
+			return "", "", false
+		}
+		return parts[0], parts[2], true
+	case strings.HasPrefix(p, "/tasks/"):
+		taskID = strings.TrimPrefix(p, "/tasks/")
+	default:
+		return "", "", false
 	}
 
-	h.handleUpdate(w, r, path)
+	if taskID == "" || strings.Contains(taskID, "/") {
+		return "", "", false
+	}
+	return "", taskID, true
 }
 
-func (h *UpdateTaskHandler) handleUpdate(w http.ResponseWriter, r *http.Request, id string) {
+func (h *UpdateTaskHandler) handleUpdate(w http.ResponseWriter, r *http.Request, projectID, id string) {
 	if h.updateUC == nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
+	baseVersion, ok := parseIfMatch(r.Header.Get("If-Match"))
+	if !ok {
+		writeErrorResponse(w, http.StatusBadRequest, "validation error", `If-Match must be a task version (e.g. "3")`)
+		return
+	}
+	var merge bool
+	switch r.URL.Query().Get("merge") {
+	case "", "false":
+	case "true":
+		merge = true
+	default:
+		writeErrorResponse(w, http.StatusBadRequest, "validation error", "merge must be true or false")
+		return
+	}
+	if merge && baseVersion == 0 {
+		writeErrorResponse(w, http.StatusBadRequest, "validation error", "merge=true requires If-Match")
+		return
+	}
+
 	var req PatchTaskRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeErrorResponse(w, http.StatusBadRequest, "invalid json", err.Error())
 		return
 	}
 
-	// 全部 nil チェック
-	if req.Title == nil &&
-		req.Status == nil &&
-		req.Priority == nil &&
-		!req.Description.present &&
-		!req.AssigneeID.IsSet &&
-		!req.DueDate.present {
+	// 全部未指定チェック
+	if req.isEmpty() {
 		writeErrorResponse(w, http.StatusBadRequest, "validation error", "at least one field must be provided")
 		return
 	}
 
-	// Title
-	var titlePatch domain.Patch[string]
-	if req.Title != nil {
-		trimmed := strings.TrimSpace(*req.Title)
+	// Title（前後の空白は除去し、空になる場合は 400）
+	titlePatch, err := domain.MapPatch(toPatch(req.Title), func(v string) (string, error) {
+		trimmed := strings.TrimSpace(v)
 		if trimmed == "" {
-			writeErrorResponse(w, http.StatusBadRequest, "validation error", "task title must not be empty")
-			return
+			return "", errors.New("task title must not be empty")
 		}
-		titlePatch = domain.Set(trimmed)
+		return trimmed, nil
+	})
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "validation error", err.Error())
+		return
 	}
 
-	// Description
-	var descriptionPatch domain.Patch[string]
-	if req.Description.present {
-		if req.Description.isNull {
-			descriptionPatch = domain.Null[string]()
-		} else {
-			descriptionPatch = domain.Set(*req.Description.value)
+	// AssigneeID（UUID 形式のバリデーション）
+	assigneeIDPatch, err := domain.MapPatch(toPatch(req.AssigneeID), func(v string) (string, error) {
+		if !uuidpolicy.Any.Valid(v) {
+			return "", errors.New("assigneeId must be a valid UUID")
 		}
+		return v, nil
+	})
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "validation error", err.Error())
+		return
 	}
 
-	// Status (Usecase 層で Parse するため、文字列のまま渡す)
-	var statusStr *string
-	if req.Status != nil {
-		statusStr = req.Status
+	// MilestoneID（空文字は不可。外す場合は null を指定する）
+	milestoneIDPatch, err := domain.MapPatch(toPatch(req.MilestoneID), func(v string) (string, error) {
+		if strings.TrimSpace(v) == "" {
+			return "", errors.New("milestoneId must not be empty (use null to clear)")
+		}
+		return v, nil
+	})
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "validation error", err.Error())
+		return
 	}
 
-	// Priority (Usecase 層で Parse するため、文字列のまま渡す)
-	var priorityStr *string
-	if req.Priority != nil {
-		priorityStr = req.Priority
+	// SprintID（空文字は不可。バックログに戻す場合は null を指定する）
+	sprintIDPatch, err := domain.MapPatch(toPatch(req.SprintID), func(v string) (string, error) {
+		if strings.TrimSpace(v) == "" {
+			return "", errors.New("sprintId must not be empty (use null to clear)")
+		}
+		return v, nil
+	})
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "validation error", err.Error())
+		return
 	}
 
-	// AssigneeID
-	var assigneeIDPatch domain.Patch[string]
-	if req.AssigneeID.IsSet {
-		if req.AssigneeID.Value != nil {
-			// UUID 形式のバリデーション
-			uuidStr := *req.AssigneeID.Value
-			if !isValidUUID(uuidStr) {
-				writeErrorResponse(w, http.StatusBadRequest, "validation error", "assigneeId must be a valid UUID")
-				return
-			}
-			assigneeIDPatch = domain.Set(uuidStr)
-		} else {
-			assigneeIDPatch = domain.Null[string]()
+	// EpicID（空文字は不可。エピックから外す場合は null を指定する）
+	epicIDPatch, err := domain.MapPatch(toPatch(req.EpicID), func(v string) (string, error) {
+		if strings.TrimSpace(v) == "" {
+			return "", errors.New("epicId must not be empty (use null to clear)")
 		}
+		return v, nil
+	})
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "validation error", err.Error())
+		return
 	}
 
-	// DueDate
-	var dueDatePatch domain.Patch[time.Time]
-	if req.DueDate.present {
-		if req.DueDate.isNull {
-			dueDatePatch = domain.Null[time.Time]()
-		} else {
-			parsed, err := time.Parse(time.RFC3339, *req.DueDate.value)
-			if err != nil {
-				writeErrorResponse(w, http.StatusBadRequest, "validation error", "dueDate must be RFC3339")
-				return
-			}
-			dueDatePatch = domain.Set(parsed)
-		}
+	// DueDate（YYYY-MM-DD か RFC3339、日付に正規化する）/ StartDate（RFC3339）
+	dueDatePatch, err := domain.MapPatch(toPatch(req.DueDate), domain.ParseDueDate)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "validation error", err.Error())
+		return
+	}
+	startDatePatch, err := domain.MapPatch(toPatch(req.StartDate), parseRFC3339("startDate"))
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "validation error", err.Error())
+		return
 	}
 
+	// Status / Priority は Usecase 層で Parse するため、文字列のまま渡す
 	in := usecase.UpdateTaskInput{
 		ID:          id,
+		ProjectID:   projectID,
 		Title:       titlePatch,
-		Description: descriptionPatch,
-		StatusStr:   statusStr,
-		PriorityStr: priorityStr,
+		Description: toPatch(req.Description),
+		Status:      toPatch(req.Status),
+		Priority:    toPatch(req.Priority),
 		AssigneeID:  assigneeIDPatch,
 		DueDate:     dueDatePatch,
+		StartDate:   startDatePatch,
+		Estimate:    toPatch(req.Estimate),
+		MilestoneID: milestoneIDPatch,
+		SprintID:    sprintIDPatch,
+		EpicID:      epicIDPatch,
+		LabelIDs:    toPatch(req.LabelIDs),
+		ActorID:     actorID(r),
+		BaseVersion: baseVersion,
+		MergeFields: merge,
 	}
 
 	t, err := h.updateUC.Execute(r.Context(), in)
@@ -175,24 +221,45 @@ func (h *UpdateTaskHandler) handleUpdate(w http.ResponseWriter, r *http.Request,
 			writeErrorResponse(w, http.StatusBadRequest, "validation error", err.Error())
 			return
 		}
+		if writeFieldForbidden(w, err) {
+			return
+		}
+		if writeAuthzError(w, err) {
+			return
+		}
+		if writeVersionConflict(w, err) {
+			return
+		}
+		if errors.Is(err, usecase.ErrTimeout) {
+			writeTimeoutResponse(w)
+			return
+		}
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	resp := taskResponse{
-		ID:          t.ID,
-		ProjectID:   t.ProjectID,
-		Title:       t.Title,
-		Description: t.Description,
-		Status:      string(t.Status),
-		Priority:    string(t.Priority),
-		AssigneeID:  t.AssigneeID,
-		DueDate:     t.DueDate,
-		CreatedAt:   t.CreatedAt,
-		UpdatedAt:   t.UpdatedAt,
-	}
-
+	w.Header().Set("ETag", taskETag(t.Version))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	_ = json.NewEncoder(w).Encode(resp)
+	_ = json.NewEncoder(w).Encode(toTaskResponse(t))
+}
+
+// taskETag はタスクの版数の ETag（"3" など）を返す。
+func taskETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// parseIfMatch は If-Match からタスクの版数を取り出す。未指定の場合は 0。
+// 弱い ETag（W/"3"）や引用符の無い値（3）も受け付ける。1 以上の整数でない場合は ok=false。
+func parseIfMatch(ifMatch string) (version int, ok bool) {
+	v := strings.TrimSpace(ifMatch)
+	if v == "" {
+		return 0, true
+	}
+	v = strings.Trim(strings.TrimPrefix(v, "W/"), `"`)
+	version, err := strconv.Atoi(v)
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
 }
@@ -6,9 +6,14 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"teamflow-shared/authz"
+	"teamflow-shared/i18n"
+
 	domain "teamflow-tasks/internal/domain/task"
 	taskinfra "teamflow-tasks/internal/infrastructure/task"
 	httpiface "teamflow-tasks/internal/interface/http"
@@ -200,6 +205,223 @@ func TestPatchTaskHandler_TaskNotFound(t *testing.T) {
 	}
 }
 
+func TestPatchTaskHandler_Membership(t *testing.T) {
+	repo := taskinfra.NewMemoryTaskRepository()
+	createUC := &usecase.CreateTaskUsecase{Repo: repo}
+	if _, err := createUC.Execute(context.Background(), usecase.CreateTaskInput{
+		ID: "task-1", ProjectID: "proj-1", Title: "initial title",
+		Status: domain.StatusTodo, Priority: domain.PriorityMedium, Now: fixedNow(),
+	}); err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	updateUC := &usecase.UpdateTaskUsecase{
+		Repo:       repo,
+		Authorizer: &usecase.MembershipPolicy{Members: staticMembers{"proj-1/user-1": true}},
+	}
+	handler := httpiface.NewUpdateTaskHandler(updateUC)
+
+	tests := []struct {
+		name       string
+		path       string
+		actorID    string
+		wantStatus int
+	}{
+		{name: "member", path: "/tasks/task-1", actorID: "user-1", wantStatus: http.StatusOK},
+		{name: "no actor", path: "/tasks/task-1", actorID: "", wantStatus: http.StatusUnauthorized},
+		// メンバーでないプロジェクトのタスクは、存在しないタスクと同じ 404 にする
+		{name: "not a member", path: "/tasks/task-1", actorID: "user-2", wantStatus: http.StatusNotFound},
+		{name: "missing task", path: "/tasks/missing", actorID: "user-2", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, _ := json.Marshal(map[string]string{"title": "updated title"})
+			req := httptest.NewRequest(http.MethodPatch, tt.path, bytes.NewReader(b))
+			if tt.actorID != "" {
+				req.Header.Set(authz.ActorHeader, tt.actorID)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// staticFieldLocks は FieldLockPolicy のテスト用実装（priority を admin だけに許可する）。
+type staticFieldLocks map[string]string // userID → role
+
+func (r staticFieldLocks) FieldLocks(context.Context, string) (map[string][]string, error) {
+	return map[string][]string{"priority": {"admin"}}, nil
+}
+
+func (r staticFieldLocks) MemberRole(_ context.Context, _, userID string) (string, error) {
+	return r[userID], nil
+}
+
+func TestPatchTaskHandler_FieldLocks(t *testing.T) {
+	repo := taskinfra.NewMemoryTaskRepository()
+	createUC := &usecase.CreateTaskUsecase{Repo: repo}
+	if _, err := createUC.Execute(context.Background(), usecase.CreateTaskInput{
+		ID: "task-1", ProjectID: "proj-1", Title: "initial title",
+		Status: domain.StatusTodo, Priority: domain.PriorityMedium, Now: fixedNow(),
+	}); err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	updateUC := &usecase.UpdateTaskUsecase{Repo: repo, FieldLocks: staticFieldLocks{"admin-1": "admin", "member-1": "member"}}
+	handler := i18n.Middleware(httpiface.Messages, httpiface.NewUpdateTaskHandler(updateUC))
+
+	patchIn := func(acceptLanguage, actorID string, body map[string]string) *httptest.ResponseRecorder {
+		b, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/task-1", bytes.NewReader(b))
+		req.Header.Set(authz.ActorHeader, actorID)
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+	patch := func(actorID string, body map[string]string) *httptest.ResponseRecorder {
+		return patchIn("", actorID, body)
+	}
+
+	w := patch("member-1", map[string]string{"priority": "high"})
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp httpiface.ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Error != "FORBIDDEN" || resp.Details == nil || len(resp.Details.Issues) != 1 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if issue := resp.Details.Issues[0]; issue.Location != "body" || issue.Field != "priority" || issue.Code != "FIELD_FORBIDDEN" {
+		t.Errorf("unexpected issue: %+v", issue)
+	}
+
+	// Accept-Language: en の場合は 403 の message も英語にする
+	w = patchIn("en", "member-1", map[string]string{"priority": "high"})
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d: %s", w.Code, w.Body.String())
+	}
+	resp = httpiface.ErrorResponse{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Details == nil || len(resp.Details.Issues) != 1 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if got, want := resp.Details.Issues[0].Message, "priority is locked by the project settings and cannot be changed with your role."; got != want {
+		t.Errorf("message = %q, want %q", got, want)
+	}
+
+	if w := patch("member-1", map[string]string{"title": "updated title"}); w.Code != http.StatusOK {
+		t.Errorf("expected unlocked field to be updated, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := patch("admin-1", map[string]string{"priority": "high"}); w.Code != http.StatusOK {
+		t.Errorf("expected admin to change priority, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestPatchTaskHandler_IfMatch(t *testing.T) {
+	repo := taskinfra.NewMemoryTaskRepository()
+	createUC := &usecase.CreateTaskUsecase{Repo: repo}
+	if _, err := createUC.Execute(context.Background(), usecase.CreateTaskInput{
+		ID: "task-1", ProjectID: "proj-1", Title: "initial title",
+		Status: domain.StatusTodo, Priority: domain.PriorityMedium, Now: fixedNow(),
+	}); err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	handler := i18n.Middleware(httpiface.Messages, httpiface.NewUpdateTaskHandler(&usecase.UpdateTaskUsecase{Repo: repo}))
+
+	// 順に実行する（前のステップで進んだ版数に依存する）
+	steps := []struct {
+		name           string
+		query          string
+		ifMatch        string
+		acceptLanguage string
+		body           string
+		wantStatus     int
+		wantETag       string
+		wantChanged    string // 409 の details.issues のフィールド（カンマ区切り）
+		wantMessage    string // 任意。空でない場合は 409 の最初の issue の message
+	}{
+		{name: "invalid If-Match", ifMatch: `"abc"`, body: `{"title":"x"}`, wantStatus: http.StatusBadRequest},
+		{name: "zero version", ifMatch: `"0"`, body: `{"title":"x"}`, wantStatus: http.StatusBadRequest},
+		{name: "merge without If-Match", query: "?merge=true", body: `{"title":"x"}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid merge", query: "?merge=yes", ifMatch: `"1"`, body: `{"title":"x"}`, wantStatus: http.StatusBadRequest},
+		{name: "without If-Match", body: `{"title":"v2"}`, wantStatus: http.StatusOK, wantETag: `"2"`},
+		{name: "current version", ifMatch: `"2"`, body: `{"status":"in_progress"}`, wantStatus: http.StatusOK, wantETag: `"3"`},
+		{name: "stale version", ifMatch: `"2"`, body: `{"priority":"high"}`, wantStatus: http.StatusConflict, wantETag: `"3"`},
+		{name: "merge unrelated field", query: "?merge=true", ifMatch: `W/"2"`, body: `{"priority":"high"}`, wantStatus: http.StatusOK, wantETag: `"4"`},
+		{
+			name: "merge field changed later", query: "?merge=true", ifMatch: `"2"`, body: `{"status":"done","priority":"low","title":"v3"}`,
+			wantStatus: http.StatusConflict, wantETag: `"4"`, wantChanged: "status,priority",
+		},
+		{
+			name: "merge conflict in english", query: "?merge=true", ifMatch: `"2"`, acceptLanguage: "en", body: `{"status":"done","priority":"low"}`,
+			wantStatus: http.StatusConflict, wantETag: `"4"`, wantChanged: "status,priority",
+			wantMessage: "status was changed after the version in If-Match. Fetch the task again before changing it.",
+		},
+	}
+	for _, step := range steps {
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/task-1"+step.query, bytes.NewReader([]byte(step.body)))
+		if step.ifMatch != "" {
+			req.Header.Set("If-Match", step.ifMatch)
+		}
+		if step.acceptLanguage != "" {
+			req.Header.Set("Accept-Language", step.acceptLanguage)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != step.wantStatus {
+			t.Fatalf("%s: expected status %d, got %d: %s", step.name, step.wantStatus, w.Code, w.Body.String())
+		}
+		if got := w.Header().Get("ETag"); got != step.wantETag {
+			t.Errorf("%s: expected ETag %s, got %q", step.name, step.wantETag, got)
+		}
+		switch w.Code {
+		case http.StatusOK:
+			var got struct {
+				Version int `json:"version"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil || `"`+strconv.Itoa(got.Version)+`"` != step.wantETag {
+				t.Errorf("%s: expected version %s in the response, got %d (err=%v)", step.name, step.wantETag, got.Version, err)
+			}
+		case http.StatusConflict:
+			var resp httpiface.ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil || resp.Error != "CONFLICT" {
+				t.Fatalf("%s: unexpected response: %+v (err=%v)", step.name, resp, err)
+			}
+			var fields []string
+			if resp.Details != nil {
+				for _, issue := range resp.Details.Issues {
+					if issue.Code != "CHANGED" {
+						t.Errorf("%s: unexpected issue: %+v", step.name, issue)
+					}
+					fields = append(fields, issue.Field)
+				}
+			}
+			if strings.Join(fields, ",") != step.wantChanged {
+				t.Errorf("%s: expected changed fields %q, got %v", step.name, step.wantChanged, fields)
+			}
+			if step.wantMessage != "" && resp.Details.Issues[0].Message != step.wantMessage {
+				t.Errorf("%s: expected message %q, got %q", step.name, step.wantMessage, resp.Details.Issues[0].Message)
+			}
+		}
+	}
+
+	// 409 の場合はどのフィールドも変更しない
+	got, err := repo.FindByID(context.Background(), "task-1")
+	if err != nil || got.Title != "v2" || got.Status != domain.StatusInProgress || got.Priority != domain.PriorityHigh {
+		t.Errorf("unexpected task: %+v (err=%v)", got, err)
+	}
+}
+
 func TestPatchTaskHandler_UpdateStatus(t *testing.T) {
 	repo := taskinfra.NewMemoryTaskRepository()
 	createUC := &usecase.CreateTaskUsecase{Repo: repo}
@@ -869,68 +1091,65 @@ func TestPatchTaskHandler_InvalidAssigneeID(t *testing.T) {
 }
 
 func TestPatchTaskHandler_UpdateDueDate(t *testing.T) {
-	repo := taskinfra.NewMemoryTaskRepository()
-	createUC := &usecase.CreateTaskUsecase{Repo: repo}
-	updateUC := &usecase.UpdateTaskUsecase{Repo: repo}
-
-	now := fixedNow()
-	ctx := context.Background()
-
-	// 事前にタスク作成
-	_, err := createUC.Execute(ctx, usecase.CreateTaskInput{
-		ID:          "task-1",
-		ProjectID:   "proj-1",
-		Title:       "initial title",
-		Description: "desc",
-		Status:      domain.StatusTodo,
-		Priority:    domain.PriorityMedium,
-		Now:         now,
-	})
-	if err != nil {
-		t.Fatalf("failed to create task: %v", err)
-	}
-
-	handler := httpiface.NewUpdateTaskHandler(updateUC)
-
-	// dueDate のみを更新
-	body := map[string]interface{}{
-		"dueDate": "2025-01-01T00:00:00Z",
-	}
-	b, _ := json.Marshal(body)
-
-	req := httptest.NewRequest(http.MethodPatch, "/tasks/task-1", bytes.NewReader(b))
-	req.Header.Set("Content-Type", "application/json")
-	w := httptest.NewRecorder()
-
-	handler.ServeHTTP(w, req)
-
-	res := w.Result()
-	defer res.Body.Close()
-
-	if res.StatusCode != http.StatusOK {
-		var errorResp struct {
-			Error  string `json:"error"`
-			Detail string `json:"detail"`
-		}
-		if err := json.NewDecoder(res.Body).Decode(&errorResp); err == nil {
-			t.Fatalf("expected status 200, got %d: error=%s, detail=%s", res.StatusCode, errorResp.Error, errorResp.Detail)
-		} else {
-			t.Fatalf("expected status 200, got %d", res.StatusCode)
-		}
-	}
-
-	var respBody struct {
-		DueDate *time.Time `json:"dueDate"`
-	}
-	if err := json.NewDecoder(res.Body).Decode(&respBody); err != nil {
-		t.Fatalf("failed to decode response: %v", err)
-	}
-
-	expectedDueDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
-	if respBody.DueDate == nil {
-		t.Errorf("expected dueDate to be set, got nil")
-	} else if !respBody.DueDate.Equal(expectedDueDate) {
-		t.Errorf("expected dueDate '%s', got '%s'", expectedDueDate.Format(time.RFC3339), respBody.DueDate.Format(time.RFC3339))
+	tests := []struct {
+		name       string
+		dueDate    string
+		wantStatus int
+		want       string
+	}{
+		{name: "date only", dueDate: "2025-01-01", wantStatus: http.StatusOK, want: "2025-01-01"},
+		{name: "rfc3339 utc", dueDate: "2025-01-01T00:00:00Z", wantStatus: http.StatusOK, want: "2025-01-01"},
+		// オフセットでの日付を使う（UTC に変換すると 2024-12-31 になる）
+		{name: "rfc3339 with offset", dueDate: "2025-01-01T00:30:00+09:00", wantStatus: http.StatusOK, want: "2025-01-01"},
+		{name: "invalid format", dueDate: "2025/01/01", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := taskinfra.NewMemoryTaskRepository()
+			createUC := &usecase.CreateTaskUsecase{Repo: repo}
+			updateUC := &usecase.UpdateTaskUsecase{Repo: repo}
+
+			// 事前にタスク作成
+			_, err := createUC.Execute(context.Background(), usecase.CreateTaskInput{
+				ID:          "task-1",
+				ProjectID:   "proj-1",
+				Title:       "initial title",
+				Description: "desc",
+				Status:      domain.StatusTodo,
+				Priority:    domain.PriorityMedium,
+				Now:         fixedNow(),
+			})
+			if err != nil {
+				t.Fatalf("failed to create task: %v", err)
+			}
+
+			handler := httpiface.NewUpdateTaskHandler(updateUC)
+
+			// dueDate のみを更新
+			b, _ := json.Marshal(map[string]interface{}{"dueDate": tt.dueDate})
+			req := httptest.NewRequest(http.MethodPatch, "/tasks/task-1", bytes.NewReader(b))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var respBody struct {
+				DueDate *string `json:"dueDate"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&respBody); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if respBody.DueDate == nil || *respBody.DueDate != tt.want {
+				t.Errorf("expected dueDate %q, got %v", tt.want, respBody.DueDate)
+			}
+		})
 	}
 }
 
@@ -999,13 +1218,442 @@ func TestPatchTaskHandler_UpdateDueDateToNull(t *testing.T) {
 	}
 
 	var respBody struct {
-		DueDate *time.Time `json:"dueDate"`
+		DueDate *string `json:"dueDate"`
 	}
 	if err := json.NewDecoder(res.Body).Decode(&respBody); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
 
 	if respBody.DueDate != nil {
-		t.Errorf("expected dueDate to be nil, got '%s'", respBody.DueDate.Format(time.RFC3339))
+		t.Errorf("expected dueDate to be nil, got %q", *respBody.DueDate)
+	}
+}
+
+func TestPatchTaskHandler_StartDateAndEstimate(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		wantStatus    int
+		wantStartDate *string
+		wantEstimate  *int
+	}{
+		{
+			name:          "set startDate",
+			body:          `{"startDate":"2025-02-01T00:00:00Z"}`,
+			wantStatus:    http.StatusOK,
+			wantStartDate: strPtr("2025-02-01T00:00:00Z"),
+		},
+		{
+			name:         "set estimate",
+			body:         `{"estimate":5}`,
+			wantStatus:   http.StatusOK,
+			wantEstimate: intPtr(5),
+		},
+		{
+			name:       "null clears startDate and estimate",
+			body:       `{"startDate":null,"estimate":null}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid startDate",
+			body:       `{"startDate":"2025/02/01"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "negative estimate",
+			body:       `{"estimate":-1}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "estimate with wrong type",
+			body:       `{"estimate":"5"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "title null",
+			body:       `{"title":null}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := taskinfra.NewMemoryTaskRepository()
+			createUC := &usecase.CreateTaskUsecase{Repo: repo}
+			updateUC := &usecase.UpdateTaskUsecase{Repo: repo}
+
+			if _, err := createUC.Execute(context.Background(), usecase.CreateTaskInput{
+				ID:        "task-1",
+				ProjectID: "proj-1",
+				Title:     "initial title",
+				Status:    domain.StatusTodo,
+				Priority:  domain.PriorityMedium,
+				Now:       fixedNow(),
+			}); err != nil {
+				t.Fatalf("failed to create task: %v", err)
+			}
+
+			handler := httpiface.NewUpdateTaskHandler(updateUC)
+			req := httptest.NewRequest(http.MethodPatch, "/tasks/task-1", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, res.StatusCode)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var respBody struct {
+				StartDate *time.Time `json:"startDate"`
+				Estimate  *int       `json:"estimate"`
+			}
+			if err := json.NewDecoder(res.Body).Decode(&respBody); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			if tt.wantStartDate == nil {
+				if respBody.StartDate != nil {
+					t.Errorf("expected startDate to be nil, got %v", respBody.StartDate)
+				}
+			} else if respBody.StartDate == nil || respBody.StartDate.Format(time.RFC3339) != *tt.wantStartDate {
+				t.Errorf("expected startDate %s, got %v", *tt.wantStartDate, respBody.StartDate)
+			}
+
+			if tt.wantEstimate == nil {
+				if respBody.Estimate != nil {
+					t.Errorf("expected estimate to be nil, got %d", *respBody.Estimate)
+				}
+			} else if respBody.Estimate == nil || *respBody.Estimate != *tt.wantEstimate {
+				t.Errorf("expected estimate %d, got %v", *tt.wantEstimate, respBody.Estimate)
+			}
+		})
+	}
+}
+
+func TestPatchTaskHandler_MilestoneID(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		wantStatus    int
+		wantMilestone *string
+	}{
+		{name: "set milestoneId", body: `{"milestoneId":"m-1"}`, wantStatus: http.StatusOK, wantMilestone: strPtr("m-1")},
+		{name: "null clears milestoneId", body: `{"milestoneId":null}`, wantStatus: http.StatusOK},
+		{name: "empty milestoneId", body: `{"milestoneId":""}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := taskinfra.NewMemoryTaskRepository()
+			createUC := &usecase.CreateTaskUsecase{Repo: repo}
+			updateUC := &usecase.UpdateTaskUsecase{Repo: repo}
+
+			if _, err := createUC.Execute(context.Background(), usecase.CreateTaskInput{
+				ID:          "task-1",
+				ProjectID:   "proj-1",
+				Title:       "initial title",
+				Status:      domain.StatusTodo,
+				Priority:    domain.PriorityMedium,
+				MilestoneID: "m-0",
+				Now:         fixedNow(),
+			}); err != nil {
+				t.Fatalf("failed to create task: %v", err)
+			}
+
+			handler := httpiface.NewUpdateTaskHandler(updateUC)
+			req := httptest.NewRequest(http.MethodPatch, "/tasks/task-1", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var respBody struct {
+				MilestoneID *string `json:"milestoneId"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&respBody); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if tt.wantMilestone == nil {
+				if respBody.MilestoneID != nil {
+					t.Errorf("expected milestoneId to be nil, got %v", *respBody.MilestoneID)
+				}
+			} else if respBody.MilestoneID == nil || *respBody.MilestoneID != *tt.wantMilestone {
+				t.Errorf("expected milestoneId %s, got %v", *tt.wantMilestone, respBody.MilestoneID)
+			}
+		})
+	}
+}
+
+func TestPatchTaskHandler_SprintID(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantSprint *string
+	}{
+		{name: "set sprintId", body: `{"sprintId":"s-1"}`, wantStatus: http.StatusOK, wantSprint: strPtr("s-1")},
+		{name: "null clears sprintId", body: `{"sprintId":null}`, wantStatus: http.StatusOK},
+		{name: "empty sprintId", body: `{"sprintId":""}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := taskinfra.NewMemoryTaskRepository()
+			createUC := &usecase.CreateTaskUsecase{Repo: repo}
+			updateUC := &usecase.UpdateTaskUsecase{Repo: repo}
+
+			if _, err := createUC.Execute(context.Background(), usecase.CreateTaskInput{
+				ID:        "task-1",
+				ProjectID: "proj-1",
+				Title:     "initial title",
+				Status:    domain.StatusTodo,
+				Priority:  domain.PriorityMedium,
+				SprintID:  "s-0",
+				Now:       fixedNow(),
+			}); err != nil {
+				t.Fatalf("failed to create task: %v", err)
+			}
+
+			handler := httpiface.NewUpdateTaskHandler(updateUC)
+			req := httptest.NewRequest(http.MethodPatch, "/tasks/task-1", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var respBody struct {
+				SprintID *string `json:"sprintId"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&respBody); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if tt.wantSprint == nil {
+				if respBody.SprintID != nil {
+					t.Errorf("expected sprintId to be nil, got %v", *respBody.SprintID)
+				}
+			} else if respBody.SprintID == nil || *respBody.SprintID != *tt.wantSprint {
+				t.Errorf("expected sprintId %s, got %v", *tt.wantSprint, respBody.SprintID)
+			}
+		})
+	}
+}
+
+func TestPatchTaskHandler_EpicID(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantEpic   *string
+	}{
+		{name: "set epicId", body: `{"epicId":"e-1"}`, wantStatus: http.StatusOK, wantEpic: strPtr("e-1")},
+		{name: "null clears epicId", body: `{"epicId":null}`, wantStatus: http.StatusOK},
+		{name: "empty epicId", body: `{"epicId":""}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := taskinfra.NewMemoryTaskRepository()
+			createUC := &usecase.CreateTaskUsecase{Repo: repo}
+			updateUC := &usecase.UpdateTaskUsecase{Repo: repo}
+
+			if _, err := createUC.Execute(context.Background(), usecase.CreateTaskInput{
+				ID:        "task-1",
+				ProjectID: "proj-1",
+				Title:     "initial title",
+				Status:    domain.StatusTodo,
+				Priority:  domain.PriorityMedium,
+				EpicID:    "e-0",
+				Now:       fixedNow(),
+			}); err != nil {
+				t.Fatalf("failed to create task: %v", err)
+			}
+
+			handler := httpiface.NewUpdateTaskHandler(updateUC)
+			req := httptest.NewRequest(http.MethodPatch, "/tasks/task-1", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var respBody struct {
+				EpicID *string `json:"epicId"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&respBody); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if tt.wantEpic == nil {
+				if respBody.EpicID != nil {
+					t.Errorf("expected epicId to be nil, got %v", *respBody.EpicID)
+				}
+			} else if respBody.EpicID == nil || *respBody.EpicID != *tt.wantEpic {
+				t.Errorf("expected epicId %s, got %v", *tt.wantEpic, respBody.EpicID)
+			}
+		})
+	}
+}
+
+func TestPatchTaskHandler_LabelIDs(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantLabels string // レスポンスの labelIds をそのまま JSON で比較する
+	}{
+		{name: "replace labelIds", body: `{"labelIds":["l-2","l-1","l-2"]}`, wantStatus: http.StatusOK, wantLabels: `["l-2","l-1"]`},
+		{name: "null clears labelIds", body: `{"labelIds":null}`, wantStatus: http.StatusOK, wantLabels: `[]`},
+		{name: "empty array clears labelIds", body: `{"labelIds":[]}`, wantStatus: http.StatusOK, wantLabels: `[]`},
+		{name: "empty label id", body: `{"labelIds":[""]}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := taskinfra.NewMemoryTaskRepository()
+			createUC := &usecase.CreateTaskUsecase{Repo: repo}
+			updateUC := &usecase.UpdateTaskUsecase{Repo: repo}
+
+			if _, err := createUC.Execute(context.Background(), usecase.CreateTaskInput{
+				ID:        "task-1",
+				ProjectID: "proj-1",
+				Title:     "initial title",
+				Status:    domain.StatusTodo,
+				Priority:  domain.PriorityMedium,
+				LabelIDs:  []string{"l-0"},
+				Now:       fixedNow(),
+			}); err != nil {
+				t.Fatalf("failed to create task: %v", err)
+			}
+
+			handler := httpiface.NewUpdateTaskHandler(updateUC)
+			req := httptest.NewRequest(http.MethodPatch, "/tasks/task-1", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var respBody struct {
+				LabelIDs json.RawMessage `json:"labelIds"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&respBody); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if string(respBody.LabelIDs) != tt.wantLabels {
+				t.Errorf("expected labelIds %s, got %s", tt.wantLabels, respBody.LabelIDs)
+			}
+		})
+	}
+}
+
+func TestPatchTaskHandler_ProjectScoped(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{
+			name:       "same project",
+			path:       "/projects/proj-1/tasks/task-1",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "other project returns 404",
+			path:       "/projects/proj-2/tasks/task-1",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "task not found",
+			path:       "/projects/proj-1/tasks/non-existent",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "missing task id",
+			path:       "/projects/proj-1/tasks/",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "extra path segment",
+			path:       "/projects/proj-1/tasks/task-1/move",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := taskinfra.NewMemoryTaskRepository()
+			createUC := &usecase.CreateTaskUsecase{Repo: repo}
+			updateUC := &usecase.UpdateTaskUsecase{Repo: repo}
+
+			if _, err := createUC.Execute(context.Background(), usecase.CreateTaskInput{
+				ID:        "task-1",
+				ProjectID: "proj-1",
+				Title:     "initial title",
+				Status:    domain.StatusTodo,
+				Priority:  domain.PriorityMedium,
+				Now:       fixedNow(),
+			}); err != nil {
+				t.Fatalf("failed to create task: %v", err)
+			}
+
+			handler := httpiface.NewUpdateTaskHandler(updateUC)
+			req := httptest.NewRequest(http.MethodPatch, tt.path, bytes.NewReader([]byte(`{"title":"updated title"}`)))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, res.StatusCode)
+			}
+
+			// 他プロジェクトからの更新は反映されないこと
+			stored, err := repo.FindByID(context.Background(), "task-1")
+			if err != nil {
+				t.Fatalf("failed to find task: %v", err)
+			}
+			wantTitle := "initial title"
+			if tt.wantStatus == http.StatusOK {
+				wantTitle = "updated title"
+			}
+			if stored.Title != wantTitle {
+				t.Errorf("expected title %q, got %q", wantTitle, stored.Title)
+			}
+		})
 	}
 }
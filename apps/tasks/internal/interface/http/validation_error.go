@@ -2,41 +2,25 @@ package http
 
 import (
 	"errors"
-	"log"
+	"fmt"
+	"log/slog"
 	"strconv"
 
+	"teamflow-shared/apierror"
+
 	domain "teamflow-tasks/internal/domain/task"
 )
 
-// ValidationIssue: OpenAPIの schema（ValidationIssue）と対応する構造体
-type ValidationIssue struct {
-	Location      string  `json:"location"`                // "query" | "path" | "body"
-	Field         string  `json:"field"`                   // 例: status, priority, sort, dueDateFrom
-	Code          string  `json:"code"`                    // 例: INVALID_ENUM
-	Message       string  `json:"message"`                 // フロントが直すべき内容がわかる文言
-	RejectedValue *string `json:"rejectedValue,omitempty"` // 出せる場合のみ
-}
-
-type ErrorResponse struct {
-	Error   string        `json:"error"`
-	Message string        `json:"message"`
-	Details *ErrorDetails `json:"details,omitempty"`
-}
-
-type ErrorDetails struct {
-	Issues []ValidationIssue `json:"issues,omitempty"`
-}
+// ValidationIssue / ErrorResponse / ErrorDetails は projects サービスと共通（teamflow-shared/apierror）。
+type (
+	ValidationIssue = apierror.ValidationIssue
+	ErrorResponse   = apierror.ErrorResponse
+	ErrorDetails    = apierror.ErrorDetails
+)
 
 // NewValidationErrorResponse: 400用の統一レスポンス生成
 func NewValidationErrorResponse(issues ...ValidationIssue) ErrorResponse {
-	resp := ErrorResponse{
-		Error:   "VALIDATION_ERROR",
-		Message: "Invalid query parameters",
-	}
-	if len(issues) > 0 {
-		resp.Details = &ErrorDetails{Issues: issues}
-	}
-	return resp
+	return apierror.New(apierror.CodeValidation, "Invalid query parameters", issues...)
 }
 
 // toValidationIssue: domain のエラーを ValidationIssue に変換する。
@@ -92,7 +76,7 @@ func toValidationIssue(err error) ValidationIssue {
 			Location: "query",
 			Field:    "limit",
 			Code:     "INVALID_RANGE",
-			Message:  "limit は 1〜200 の整数で指定してください（未指定または 1 未満は 200 に正規化されます）。",
+			Message:  "limit は 1 以上、1 ページの上限（既定 200）以下の整数で指定してください（未指定または 1 未満は既定値、上限を超える値は上限に正規化されます）。",
 		}
 
 	case errors.Is(err, domain.ErrSortIncompatibleWithCursor):
@@ -134,10 +118,26 @@ func toValidationIssue(err error) ValidationIssue {
 			Code:     "QUERY_MISMATCH",
 			Message:  "cursor のクエリ条件が一致しません。フィルタ等が変更された可能性があります。",
 		}
+
+	case errors.Is(err, domain.ErrSyncTokenInvalid):
+		return ValidationIssue{
+			Location: "query",
+			Field:    "since",
+			Code:     "INVALID_FORMAT",
+			Message:  "since は前回の同期で返された syncToken を指定してください。",
+		}
+
+	case errors.Is(err, domain.ErrSyncTokenExpired):
+		return ValidationIssue{
+			Location: "query",
+			Field:    "since",
+			Code:     "EXPIRED",
+			Message:  "since の有効期限が切れています。since を付けずに全件を同期し直してください。",
+		}
 	}
 
 	// fallback: 想定外でも 400 の形式は崩さない（ログ出力してデバッグ可能に）
-	log.Printf("WARNING: unmapped validation error: %T %v", err, err)
+	slog.Warn("unmapped validation error", "type", fmt.Sprintf("%T", err), "error", err)
 	return ValidationIssue{
 		Location: "query",
 		Field:    "unknown",
@@ -151,6 +151,10 @@ func toValidationIssue(err error) ValidationIssue {
 func getMessageForFieldAndCode(field, code string) string {
 	// field + code による固定 mapping（互換維持）
 	switch field {
+	case "title":
+		if code == "REQUIRED" {
+			return "title は必須です。空文字や空白のみは指定できません。"
+		}
 	case "status":
 		if code == "INVALID_ENUM" {
 			return "status は 'todo','doing','in_progress','done' のいずれかをカンマ区切りで指定してください（例: status=todo,in_progress）。"
@@ -200,7 +204,7 @@ func (e *InvalidLimitError) Unwrap() error {
 // 失敗したら InvalidLimitError を返し、toValidationIssue で errors.As で判定できる。
 func ParseLimit(raw string) (int, error) {
 	if raw == "" {
-		// 未指定は上位で default を入れる運用にする（例: 200）
+		// 未指定は 0 を返し、NewTaskQuery で既定値に正規化する
 		return 0, nil
 	}
 	v, err := strconv.Atoi(raw)
@@ -39,6 +39,12 @@ func TestGetMessageForFieldAndCode(t *testing.T) {
 			code:     "INVALID_ENUM",
 			expected: "sort は 'sortOrder','createdAt','updatedAt','dueDate','priority' のみ指定できます（例: sort=-priority,createdAt）。",
 		},
+		{
+			name:     "title REQUIRED",
+			field:    "title",
+			code:     "REQUIRED",
+			expected: "title は必須です。空文字や空白のみは指定できません。",
+		},
 		{
 			name:     "unknown field fallback",
 			field:    "unknown",
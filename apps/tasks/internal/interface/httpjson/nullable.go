@@ -2,12 +2,15 @@ package httpjson
 
 import "encoding/json"
 
+// Nullable は JSON の「未指定 / null / 値あり」を区別して受け取るための型。
+// PATCH のリクエストボディで使い、HTTP 層で domain.Patch[T] に変換する。
 type Nullable[T any] struct {
 	Set   bool // JSONにフィールドが存在したか（未指定=false）
 	Valid bool // nullでないか（値あり=true、null=false）
 	Val   T
 }
 
+// UnmarshalJSON はフィールドが存在した場合のみ呼ばれるため、Set=true を立てる。
 func (n *Nullable[T]) UnmarshalJSON(b []byte) error {
 	n.Set = true
 	if string(b) == "null" {
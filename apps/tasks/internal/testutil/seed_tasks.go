@@ -9,37 +9,26 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
-)
 
-// SeedTask represents a task to be inserted for testing.
-type SeedTask struct {
-	ID         string
-	ProjectID  string
-	Title      string
-	Desc       *string
-	Status     string
-	Priority   string
-	AssigneeID *string
-	DueDate    *time.Time // DATE in DB: pass time at midnight; nil for NULL
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-}
+	domain "teamflow-tasks/internal/domain/task"
+)
 
-// InsertTasks inserts tasks into the database for testing.
-func InsertTasks(t *testing.T, db *pgxpool.Pool, tasks []SeedTask) {
+// InsertMany inserts tasks (typically built with TaskBuilder) into the database for testing.
+func InsertMany(t *testing.T, db *pgxpool.Pool, tasks ...*domain.Task) {
 	t.Helper()
 	ctx := context.Background()
 
 	const q = `
 		INSERT INTO tasks (
-			id, project_id, title, description, status, priority, assignee_id, due_date, created_at, updated_at
+			id, project_id, title, description, status, priority, assignee_id, due_date, start_date, estimate, created_at, updated_at
 		) VALUES (
-			$1,$2,$3,$4,$5,$6,$7,$8,$9,$10
+			$1,$2,$3,NULLIF($4, ''),$5,$6,$7,$8,$9,$10,$11,$12
 		)
 	`
 	for _, tt := range tasks {
 		_, err := db.Exec(ctx, q,
-			tt.ID, tt.ProjectID, tt.Title, tt.Desc, tt.Status, tt.Priority, tt.AssigneeID, tt.DueDate, tt.CreatedAt, tt.UpdatedAt,
+			tt.ID, tt.ProjectID, tt.Title, tt.Description, string(tt.Status), string(tt.Priority),
+			tt.AssigneeID, tt.DueDate, tt.StartDate, tt.Estimate, tt.CreatedAt, tt.UpdatedAt,
 		)
 		if err != nil {
 			t.Fatalf("failed to insert seed task id=%s: %v", tt.ID, err)
@@ -0,0 +1,144 @@
+package testutil
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// DefaultTaskTime is the default CreatedAt / UpdatedAt of TaskBuilder.
+var DefaultTaskTime = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// TaskBuilder builds domain.Task values for tests (unit, repository and HTTP integration tests).
+//
+// Defaults: ID is a random UUID, ProjectID is "proj-1", Title is the ID, Status is todo,
+// Priority is medium and CreatedAt is DefaultTaskTime. UpdatedAt follows CreatedAt unless set.
+//
+// Methods use value receivers, so a partially configured builder can be shared safely.
+//
+//	b := testutil.NewTaskBuilder().WithProjectID("proj-1")
+//	high := b.WithID("task-high").WithPriority(domain.PriorityHigh).Build()
+//	low := b.WithID("task-low").WithPriority(domain.PriorityLow).Build()
+type TaskBuilder struct {
+	task       domain.Task
+	updatedSet bool
+}
+
+// NewTaskBuilder returns a TaskBuilder with the defaults above.
+func NewTaskBuilder() TaskBuilder {
+	return TaskBuilder{task: domain.Task{
+		ProjectID: "proj-1",
+		Status:    domain.StatusTodo,
+		Priority:  domain.PriorityMedium,
+		CreatedAt: DefaultTaskTime,
+	}}
+}
+
+func (b TaskBuilder) WithID(id string) TaskBuilder {
+	b.task.ID = id
+	return b
+}
+
+func (b TaskBuilder) WithProjectID(projectID string) TaskBuilder {
+	b.task.ProjectID = projectID
+	return b
+}
+
+func (b TaskBuilder) WithTitle(title string) TaskBuilder {
+	b.task.Title = title
+	return b
+}
+
+func (b TaskBuilder) WithDescription(description string) TaskBuilder {
+	b.task.Description = description
+	return b
+}
+
+func (b TaskBuilder) WithStatus(status domain.TaskStatus) TaskBuilder {
+	b.task.Status = status
+	return b
+}
+
+func (b TaskBuilder) WithPriority(priority domain.TaskPriority) TaskBuilder {
+	b.task.Priority = priority
+	return b
+}
+
+func (b TaskBuilder) WithAssigneeID(assigneeID string) TaskBuilder {
+	b.task.AssigneeID = &assigneeID
+	return b
+}
+
+// WithDueDate sets the due date, truncated to midnight UTC to match the DATE column.
+func (b TaskBuilder) WithDueDate(dueDate time.Time) TaskBuilder {
+	d := truncateToDate(dueDate)
+	b.task.DueDate = &d
+	return b
+}
+
+// WithStartDate sets the start date, truncated to midnight UTC to match the DATE column.
+func (b TaskBuilder) WithStartDate(startDate time.Time) TaskBuilder {
+	d := truncateToDate(startDate)
+	b.task.StartDate = &d
+	return b
+}
+
+func (b TaskBuilder) WithEstimate(estimate int) TaskBuilder {
+	b.task.Estimate = &estimate
+	return b
+}
+
+func (b TaskBuilder) WithSprintID(sprintID string) TaskBuilder {
+	b.task.SprintID = &sprintID
+	return b
+}
+
+func (b TaskBuilder) WithEpicID(epicID string) TaskBuilder {
+	b.task.EpicID = &epicID
+	return b
+}
+
+func (b TaskBuilder) WithLabelIDs(labelIDs ...string) TaskBuilder {
+	b.task.LabelIDs = labelIDs
+	return b
+}
+
+// WithCreatedBy sets CreatedBy and UpdatedBy.
+func (b TaskBuilder) WithCreatedBy(actorID string) TaskBuilder {
+	b.task.CreatedBy = actorID
+	b.task.UpdatedBy = actorID
+	return b
+}
+
+// WithCreatedAt sets CreatedAt (and UpdatedAt, unless WithUpdatedAt is used).
+func (b TaskBuilder) WithCreatedAt(createdAt time.Time) TaskBuilder {
+	b.task.CreatedAt = createdAt
+	return b
+}
+
+func (b TaskBuilder) WithUpdatedAt(updatedAt time.Time) TaskBuilder {
+	b.task.UpdatedAt = updatedAt
+	b.updatedSet = true
+	return b
+}
+
+// Build returns a new domain.Task. Each call returns an independent instance.
+func (b TaskBuilder) Build() *domain.Task {
+	t := b.task
+	if t.ID == "" {
+		t.ID = uuid.NewString()
+	}
+	if t.Title == "" {
+		t.Title = t.ID
+	}
+	if !b.updatedSet {
+		t.UpdatedAt = t.CreatedAt
+	}
+	return &t
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
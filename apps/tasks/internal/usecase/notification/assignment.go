@@ -0,0 +1,89 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"teamflow-shared/logging"
+	"teamflow-shared/mail"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// Notifier はタスクの担当者になったユーザーにメールを送る（usecase.AssignmentNotifier）。
+//
+// タスクの操作のレスポンスを遅らせないよう、メールは別の goroutine で送る。
+// 停止する前に Wait で送信中のメールを待つこと。
+type Notifier struct {
+	Recipients RecipientDirectory
+	Sender     mail.Sender
+	// Timeout は 1 件の通知のタイムアウト。任意。0 の場合は DefaultTimeout
+	Timeout time.Duration
+
+	wg sync.WaitGroup
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.AssignmentNotifier = (*Notifier)(nil)
+
+// NotifyAssigned は t の担当者が通知を受け取る設定であればメールを送る。
+// ctx のキャンセル（リクエストの終了）では止めず、ctx の値（ワークスペース・リクエスト ID）は引き継ぐ。
+func (n *Notifier) NotifyAssigned(ctx context.Context, t *domain.Task, actorID string) {
+	if t.AssigneeID == nil {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		ctx, cancel := context.WithTimeout(ctx, orDefault(n.Timeout, DefaultTimeout))
+		defer cancel()
+		if err := n.notify(ctx, t, actorID); err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "failed to send assignment email",
+				"task_id", t.ID, "assignee_id", *t.AssigneeID, "error", err)
+		}
+	}()
+}
+
+// Wait は送信中のメールがすべて終わるまで待つ。
+func (n *Notifier) Wait() {
+	n.wg.Wait()
+}
+
+func (n *Notifier) notify(ctx context.Context, t *domain.Task, actorID string) error {
+	assigneeID := *t.AssigneeID
+	ids := []string{assigneeID}
+	if actorID != "" {
+		ids = append(ids, actorID)
+	}
+	recipients, err := n.Recipients.LookupRecipients(ctx, ids)
+	if err != nil {
+		return err
+	}
+	to, ok := recipients[assigneeID]
+	if !ok || !to.EmailOnAssignment || to.Email == "" {
+		return nil
+	}
+
+	// 担当者にした操作者の名前（分からない場合は載せない）
+	by := ""
+	if actor, ok := recipients[actorID]; ok && actor.Name != "" {
+		by = actor.Name + " さんが"
+	}
+	return n.Sender.Send(ctx, mail.Message{
+		To:      to.Email,
+		Subject: fmt.Sprintf("%s%sの担当者になりました", subjectPrefix, taskLabel(t)),
+		Body: fmt.Sprintf("%s さん\n\n%sあなたを%sの担当者にしました。\n\n%s",
+			to.Name, by, taskLabel(t), taskDetails(t)),
+	})
+}
+
+func orDefault(d, def time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return def
+}
@@ -0,0 +1,190 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/mail"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// DueReminder の既定値。
+const (
+	// DefaultDueSoonDays は期日の何日前から通知するか（1 は期日の前日と当日）。
+	DefaultDueSoonDays = 1
+	// DefaultDueReminderInterval は期日が近いタスクを確認する間隔。
+	DefaultDueReminderInterval = time.Hour
+	// dueReminderBatchSize は 1 回に取り出すタスクの最大数（残りは次の確認で取り出す）。
+	dueReminderBatchSize = 100
+)
+
+// DueTaskClaimer は期日が近いタスクの取り出しを担当する抽象。
+type DueTaskClaimer interface {
+	// DueTasks は期日が from から to（日付。両端を含む）の、完了していない・アーカイブされていない・担当者のいるタスクのうち、
+	// まだ通知していないものを期日・ID の順で最大 limit 件返す（すべてのワークスペースが対象。通知済みとしては記録しない）。
+	// after が nil でない場合は、期日・ID の順で after より後のタスクから返す。
+	DueTasks(ctx context.Context, from, to time.Time, after *domain.Task, limit int) ([]*domain.Task, error)
+	// ClaimDueTask は t を今の期日で通知済みとして記録し、記録できた場合に true を返す。
+	// 同じタスクの同じ期日は、複数のレプリカから呼び出しても 1 度だけ true を返す。期日を変更したタスクは新しい期日で再び記録できる。
+	ClaimDueTask(ctx context.Context, t *domain.Task, now time.Time) (bool, error)
+}
+
+// DueReminder は担当しているタスクの期日が近づいたユーザーにメールを送る。
+//
+// 期日が近いかどうかはタスクのプロジェクトのタイムゾーンでの日付で判定する。
+// 通知済みの記録は送信の前に行う（送信に失敗したメールは再送しない。同じメールを 2 度送らないことを優先する）。
+type DueReminder struct {
+	Tasks      DueTaskClaimer
+	Recipients RecipientDirectory
+	Sender     mail.Sender
+	// Timezones はプロジェクトのタイムゾーンの取得に使う。任意。nil の場合はすべてのプロジェクトを UTC で判定する
+	Timezones usecase.ProjectTimezoneProvider
+	// Days は期日の何日前から通知するか。任意。0 の場合は DefaultDueSoonDays
+	Days int
+	// Interval は期日が近いタスクを確認する間隔。任意。0 の場合は DefaultDueReminderInterval
+	Interval time.Duration
+	// Clock は期日の判定に使う現在時刻。任意。nil の場合は clock.System
+	Clock clock.Clock
+}
+
+// dueTask は通知するタスクと、そのプロジェクトのタイムゾーンでの今日の日付。
+type dueTask struct {
+	task  *domain.Task
+	today time.Time
+}
+
+// Run は ctx がキャンセルされるまで Interval ごとに RunOnce を呼ぶ。起動した直後にも 1 度確認する。
+func (r *DueReminder) Run(ctx context.Context) {
+	ticker := time.NewTicker(orDefault(r.Interval, DefaultDueReminderInterval))
+	defer ticker.Stop()
+	for {
+		if _, err := r.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "failed to send due date reminders", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce は期日が近いタスクを取り出し、担当者が通知を受け取る設定であればメールを送る。戻り値は送ったメールの数。
+// 取り出せるタスクが無くなるまで繰り返す。個々のメールの送信の失敗はログに記録して続ける。
+//
+// タイムゾーンは UTC-12 から UTC+14 まであるため、UTC の日付の前後 1 日まで広げてタスクを取り出し、
+// プロジェクトのタイムゾーンでの今日から Days 日後までが期日のタスクだけを通知済みとして記録する。
+func (r *DueReminder) RunOnce(ctx context.Context) (int, error) {
+	now := clock.OrSystem(r.Clock).Now()
+	days := r.Days
+	if days <= 0 {
+		days = DefaultDueSoonDays
+	}
+	today := domain.DueDateOf(now.UTC())
+	from, to := today.AddDate(0, 0, -1), today.AddDate(0, 0, days+1)
+
+	locations := make(map[string]*time.Location)
+	sent := 0
+	var after *domain.Task
+	for {
+		tasks, err := r.Tasks.DueTasks(ctx, from, to, after, dueReminderBatchSize)
+		if err != nil {
+			return sent, err
+		}
+		if len(tasks) == 0 {
+			return sent, nil
+		}
+		after = tasks[len(tasks)-1]
+
+		var due []dueTask
+		for _, t := range tasks {
+			loc, ok := locations[t.ProjectID]
+			if !ok {
+				if loc, err = usecase.ProjectLocation(ctx, r.Timezones, t.ProjectID); err != nil {
+					return sent, err
+				}
+				locations[t.ProjectID] = loc
+			}
+			localToday := domain.DueDateOf(now.In(loc))
+			if t.DueDate.Before(localToday) || t.DueDate.After(localToday.AddDate(0, 0, days)) {
+				continue
+			}
+			claimed, err := r.Tasks.ClaimDueTask(ctx, t, now)
+			if err != nil {
+				return sent, err
+			}
+			if claimed {
+				due = append(due, dueTask{task: t, today: localToday})
+			}
+		}
+		n, err := r.remind(ctx, due)
+		sent += n
+		if err != nil {
+			return sent, err
+		}
+		if len(tasks) < dueReminderBatchSize {
+			return sent, nil
+		}
+	}
+}
+
+// remind は tasks の担当者にメールを送る。宛先はまとめて取得する。
+func (r *DueReminder) remind(ctx context.Context, tasks []dueTask) (int, error) {
+	if len(tasks) == 0 {
+		return 0, nil
+	}
+	seen := make(map[string]bool, len(tasks))
+	ids := make([]string, 0, len(tasks))
+	for _, d := range tasks {
+		if id := *d.task.AssigneeID; !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	recipients, err := r.Recipients.LookupRecipients(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, d := range tasks {
+		t := d.task
+		to, ok := recipients[*t.AssigneeID]
+		if !ok || !to.EmailOnDueSoon || to.Email == "" {
+			continue
+		}
+		when := dueLabel(*t.DueDate, d.today)
+		err := r.Sender.Send(ctx, mail.Message{
+			To:      to.Email,
+			Subject: fmt.Sprintf("%s%sの期日は%sです", subjectPrefix, taskLabel(t), when),
+			Body: fmt.Sprintf("%s さん\n\nあなたが担当している%sの期日は%s（%s）です。\n\n%s",
+				to.Name, taskLabel(t), when, t.DueDate.Format(time.DateOnly), taskDetails(t)),
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to send due date reminder",
+				"task_id", t.ID, "workspace_id", t.WorkspaceID, "assignee_id", to.ID, "error", err)
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// dueLabel は期日を today からの日数で表す（今日・明日・N 日後）。
+func dueLabel(due, today time.Time) string {
+	y, m, d := due.Date()
+	days := int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Sub(today).Hours() / 24)
+	switch days {
+	case 0:
+		return "今日"
+	case 1:
+		return "明日"
+	default:
+		return fmt.Sprintf("%d 日後", days)
+	}
+}
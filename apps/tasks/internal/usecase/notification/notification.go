@@ -0,0 +1,54 @@
+// Package notification はタスクの担当者へのメールの通知を扱うユースケース。
+//
+// 担当者になったとき（Notifier）と、担当しているタスクの期日が近づいたとき（DueReminder）にメールを送る。
+// 宛先のメールアドレスと通知の設定は users サービスから取得し、設定で受け取らないとしたユーザーには送らない。
+// 通知の失敗はタスクの操作を失敗にせず、ログに記録するだけで再送しない。
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// Recipient は通知の宛先（users サービスのユーザーと通知の設定）。
+type Recipient struct {
+	ID                string
+	Name              string
+	Email             string
+	EmailOnAssignment bool // 担当者になったときにメールを受け取る
+	EmailOnDueSoon    bool // 担当しているタスクの期日が近づいたときにメールを受け取る
+}
+
+// RecipientDirectory は通知の宛先の取得を担当する抽象（users サービスの POST /users:lookup）。
+type RecipientDirectory interface {
+	// LookupRecipients は userIDs の宛先をユーザーの ID ごとに返す。存在しないユーザーは含めない。
+	LookupRecipients(ctx context.Context, userIDs []string) (map[string]Recipient, error)
+}
+
+// DefaultTimeout は 1 件の通知（宛先の取得とメールの送信）のタイムアウト。
+const DefaultTimeout = 30 * time.Second
+
+// subjectPrefix はメールの件名の接頭辞。
+const subjectPrefix = "[TeamFlow] "
+
+// taskLabel はメールに載せるタスクの表示（例: タスク #12「ログイン画面の修正」）。
+func taskLabel(t *domain.Task) string {
+	return fmt.Sprintf("タスク #%d「%s」", t.Number, t.Title)
+}
+
+// taskDetails はメールの本文に載せるタスクの詳細。
+func taskDetails(t *domain.Task) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "タイトル: %s\n", t.Title)
+	fmt.Fprintf(&b, "番号: #%d\n", t.Number)
+	fmt.Fprintf(&b, "ステータス: %s\n", t.Status)
+	fmt.Fprintf(&b, "優先度: %s\n", t.Priority)
+	if t.DueDate != nil {
+		fmt.Fprintf(&b, "期日: %s\n", t.DueDate.Format(time.DateOnly))
+	}
+	return b.String()
+}
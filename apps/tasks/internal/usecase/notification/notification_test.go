@@ -0,0 +1,236 @@
+package notification_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/mail"
+	"teamflow-shared/workspace"
+
+	domain "teamflow-tasks/internal/domain/task"
+	taskinfra "teamflow-tasks/internal/infrastructure/task"
+	"teamflow-tasks/internal/usecase/notification"
+)
+
+// recordingSender は送ったメールを記録する mail.Sender。
+type recordingSender struct {
+	mu   sync.Mutex
+	sent []mail.Message
+	err  error
+}
+
+func (s *recordingSender) Send(_ context.Context, m mail.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+// fakeRecipients は RecipientDirectory のテスト用フェイク実装。
+type fakeRecipients map[string]notification.Recipient
+
+func (f fakeRecipients) LookupRecipients(_ context.Context, ids []string) (map[string]notification.Recipient, error) {
+	out := make(map[string]notification.Recipient)
+	for _, id := range ids {
+		if r, ok := f[id]; ok {
+			out[id] = r
+		}
+	}
+	return out, nil
+}
+
+var recipients = fakeRecipients{
+	"taro": {ID: "taro", Name: "Taro", Email: "taro@example.com", EmailOnAssignment: true, EmailOnDueSoon: true},
+	"jiro": {ID: "jiro", Name: "Jiro", Email: "jiro@example.com", EmailOnAssignment: false, EmailOnDueSoon: false},
+}
+
+func newTask(t *testing.T, id, assignee string, due *time.Time) *domain.Task {
+	t.Helper()
+	task, err := domain.NewTask(id, "proj-1", "ログイン画面の修正", "", domain.StatusTodo, domain.PriorityHigh, due, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	task.Number = 12
+	if assignee != "" {
+		task.AssigneeID = &assignee
+	}
+	return task
+}
+
+func TestNotifier_NotifyAssigned(t *testing.T) {
+	sender := &recordingSender{}
+	n := &notification.Notifier{Recipients: recipients, Sender: sender}
+
+	n.NotifyAssigned(context.Background(), newTask(t, "task-1", "taro", nil), "jiro")
+	// 担当者になったときの通知を受け取らない設定のユーザーには送らない
+	n.NotifyAssigned(context.Background(), newTask(t, "task-2", "jiro", nil), "taro")
+	// 存在しないユーザーには送らない
+	n.NotifyAssigned(context.Background(), newTask(t, "task-3", "missing", nil), "taro")
+	n.Wait()
+
+	if len(sender.sent) != 1 {
+		t.Fatalf("expected 1 email, got %+v", sender.sent)
+	}
+	m := sender.sent[0]
+	if m.To != "taro@example.com" || m.Subject != "[TeamFlow] タスク #12「ログイン画面の修正」の担当者になりました" {
+		t.Errorf("unexpected email: %+v", m)
+	}
+	if !strings.Contains(m.Body, "Jiro さんがあなたを") {
+		t.Errorf("body does not mention the actor: %s", m.Body)
+	}
+}
+
+func TestNotifier_IgnoresRequestCancellation(t *testing.T) {
+	sender := &recordingSender{}
+	n := &notification.Notifier{Recipients: recipients, Sender: sender}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	n.NotifyAssigned(ctx, newTask(t, "task-1", "taro", nil), "")
+	cancel()
+	n.Wait()
+	if len(sender.sent) != 1 {
+		t.Errorf("expected the email to be sent after the request ended, got %+v", sender.sent)
+	}
+}
+
+func TestDueReminder_RunOnce(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	day := func(offset int) *time.Time {
+		d := time.Date(2025, 3, 10+offset, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	repo := taskinfra.NewMemoryTaskRepository()
+	ctx := workspace.NewContext(context.Background(), "acme")
+	done := newTask(t, "done", "taro", day(0))
+	done.Status = domain.StatusDone
+	for _, task := range []*domain.Task{
+		newTask(t, "today", "taro", day(0)),
+		newTask(t, "tomorrow", "taro", day(1)),
+		newTask(t, "later", "taro", day(2)),
+		newTask(t, "overdue", "taro", day(-1)),
+		newTask(t, "opted-out", "jiro", day(0)),
+		newTask(t, "unassigned", "", day(0)),
+		done,
+	} {
+		if err := repo.Save(ctx, task); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+	}
+
+	sender := &recordingSender{}
+	r := &notification.DueReminder{
+		Tasks:      taskinfra.NewMemoryDueReminders(repo),
+		Recipients: recipients,
+		Sender:     sender,
+		Clock:      clock.Fixed(now),
+	}
+	sent, err := r.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce failed: %v", err)
+	}
+	if sent != 2 || len(sender.sent) != 2 {
+		t.Fatalf("expected 2 emails, got %d: %+v", sent, sender.sent)
+	}
+	if !strings.Contains(sender.sent[0].Subject, "の期日は今日です") || !strings.Contains(sender.sent[1].Subject, "の期日は明日です") {
+		t.Errorf("unexpected subjects: %q, %q", sender.sent[0].Subject, sender.sent[1].Subject)
+	}
+
+	// 同じ期日のタスクは 2 度通知しない
+	if sent, err := r.RunOnce(context.Background()); err != nil || sent != 0 {
+		t.Errorf("expected no emails on the second run, got %d, %v", sent, err)
+	}
+
+	// 期日を変更したタスクは新しい期日で再び通知する
+	task, err := repo.FindByID(ctx, "today")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task.DueDate = day(1)
+	task.RecordChange("dueDate")
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+	if sent, err := r.RunOnce(context.Background()); err != nil || sent != 1 {
+		t.Errorf("expected 1 email after the due date changed, got %d, %v", sent, err)
+	}
+}
+
+func TestDueReminder_SendFailureIsNotRetried(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	repo := taskinfra.NewMemoryTaskRepository()
+	due := now
+	if err := repo.Save(context.Background(), newTask(t, "task-1", "taro", &due)); err != nil {
+		t.Fatal(err)
+	}
+
+	sender := &recordingSender{err: errors.New("smtp unavailable")}
+	r := &notification.DueReminder{
+		Tasks:      taskinfra.NewMemoryDueReminders(repo),
+		Recipients: recipients,
+		Sender:     sender,
+		Clock:      clock.Fixed(now),
+	}
+	if sent, err := r.RunOnce(context.Background()); err != nil || sent != 0 {
+		t.Errorf("expected the failure to be logged, got %d, %v", sent, err)
+	}
+	sender.err = nil
+	if sent, err := r.RunOnce(context.Background()); err != nil || sent != 0 {
+		t.Errorf("expected no resend, got %d, %v", sent, err)
+	}
+}
+
+// fakeTimezones は ProjectTimezoneProvider のテスト用フェイク実装。無いプロジェクトは UTC を返す。
+type fakeTimezones map[string]*time.Location
+
+func (f fakeTimezones) ProjectLocation(_ context.Context, projectID string) (*time.Location, error) {
+	if loc, ok := f[projectID]; ok {
+		return loc, nil
+	}
+	return time.UTC, nil
+}
+
+func TestDueReminder_ProjectTimezone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// UTC では 3/10、Asia/Tokyo では 3/11 の朝
+	now := time.Date(2025, 3, 10, 20, 0, 0, 0, time.UTC)
+	day := func(d int) *time.Time {
+		due := time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC)
+		return &due
+	}
+	repo := taskinfra.NewMemoryTaskRepository()
+	for _, task := range []*domain.Task{
+		newTask(t, "yesterday-in-tokyo", "taro", day(10)),
+		newTask(t, "tomorrow-in-tokyo", "taro", day(12)),
+	} {
+		if err := repo.Save(context.Background(), task); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+	}
+
+	sender := &recordingSender{}
+	r := &notification.DueReminder{
+		Tasks:      taskinfra.NewMemoryDueReminders(repo),
+		Recipients: recipients,
+		Sender:     sender,
+		Timezones:  fakeTimezones{"proj-1": tokyo},
+		Clock:      clock.Fixed(now),
+	}
+	sent, err := r.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce failed: %v", err)
+	}
+	if sent != 1 || !strings.Contains(sender.sent[0].Subject, "の期日は明日です") {
+		t.Fatalf("expected only the task due tomorrow in Asia/Tokyo, got %d: %+v", sent, sender.sent)
+	}
+}
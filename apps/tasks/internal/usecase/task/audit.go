@@ -0,0 +1,57 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"teamflow-shared/audit"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// auditService / auditEntityTask は監査ログに記録する tasks サービスのタスクの識別子。
+const (
+	auditService    = "tasks"
+	auditEntityTask = "task"
+)
+
+// recordAudit は rec が設定されていれば監査ログを記録する。
+// 監査ログは任意のため、rec が nil の構成（テスト等）では何もしない。
+func recordAudit(ctx context.Context, rec audit.Recorder, entries ...audit.Entry) error {
+	if rec == nil || len(entries) == 0 {
+		return nil
+	}
+	return rec.Record(ctx, entries...)
+}
+
+// taskAuditEntry はタスク taskID への action の監査ログを返す。
+func taskAuditEntry(projectID, taskID string, action audit.Action, actorID string, fields []string, now time.Time) audit.Entry {
+	return audit.Entry{
+		Service:    auditService,
+		EntityType: auditEntityTask,
+		EntityID:   taskID,
+		ProjectID:  projectID,
+		Action:     action,
+		ActorID:    actorID,
+		Fields:     fields,
+		OccurredAt: now,
+	}
+}
+
+// createdAuditEntries は作成したタスクの監査ログを返す（操作者は CreatedBy）。
+func createdAuditEntries(tasks ...*domain.Task) []audit.Entry {
+	entries := make([]audit.Entry, len(tasks))
+	for i, t := range tasks {
+		entries[i] = taskAuditEntry(t.ProjectID, t.ID, audit.ActionCreate, t.CreatedBy, nil, t.CreatedAt)
+	}
+	return entries
+}
+
+// idsAuditEntries は一括操作の対象になったタスク ids の監査ログを返す。
+func idsAuditEntries(projectID string, ids []string, action audit.Action, actorID string, fields []string, now time.Time) []audit.Entry {
+	entries := make([]audit.Entry, len(ids))
+	for i, id := range ids {
+		entries[i] = taskAuditEntry(projectID, id, action, actorID, fields, now)
+	}
+	return entries
+}
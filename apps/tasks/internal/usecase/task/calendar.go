@@ -0,0 +1,54 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// カレンダーのフィードに含めるタスクの範囲。
+const (
+	// CalendarPastDays は期日が何日前までのタスクを含めるか（それより前の期日のタスクは含めない）
+	CalendarPastDays = 90
+	// MaxCalendarTasks はフィードに含めるタスクの最大数（期日の昇順に数える）
+	MaxCalendarTasks = 200
+)
+
+// ListCalendarTasksUsecase はカレンダーのフィード（iCalendar）に載せる、期日のあるタスクの取得ユースケース。
+type ListCalendarTasksUsecase struct {
+	Repo TaskRepository
+	// Access が設定されていれば、操作者がプロジェクトを閲覧できるか確認する
+	Access ProjectAccessChecker
+	// Timezones は表示を始める日付（CalendarPastDays 日前）の判定に使うプロジェクトのタイムゾーンの取得に使う。
+	// 任意。nil の場合は UTC で判定する
+	Timezones ProjectTimezoneProvider
+}
+
+type ListCalendarTasksInput struct {
+	ProjectID string
+	ActorID   string
+	Now       time.Time
+}
+
+// Execute は期日が CalendarPastDays 日前以降のアーカイブされていないタスクを、期日の昇順に最大 MaxCalendarTasks 件返す。
+// 完了したタスクも含める（カレンダーから消えないように）。
+func (uc *ListCalendarTasksUsecase) Execute(ctx context.Context, in ListCalendarTasksInput) ([]*domain.Task, error) {
+	if err := checkReadAccess(ctx, uc.Access, in.ProjectID, in.ActorID); err != nil {
+		return nil, err
+	}
+	loc, err := ProjectLocation(ctx, uc.Timezones, in.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	from := in.Now.In(loc).AddDate(0, 0, -CalendarPastDays).Format(time.DateOnly)
+	query, err := domain.NewTaskQuery(
+		domain.WithDueDateRangeFilter(from, ""),
+		domain.WithSort("dueDate,createdAt"),
+		domain.WithLimit(MaxCalendarTasks),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return uc.Repo.FindByProjectID(ctx, in.ProjectID, query)
+}
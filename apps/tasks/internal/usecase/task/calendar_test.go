@@ -0,0 +1,52 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// queryRecordingRepo は FindByProjectID に渡された Query Object を記録する。
+type queryRecordingRepo struct {
+	*fakeTaskRepo
+	query *domain.TaskQuery
+}
+
+func (r *queryRecordingRepo) FindByProjectID(ctx context.Context, projectID string, query *domain.TaskQuery) ([]*domain.Task, error) {
+	r.query = query
+	return r.fakeTaskRepo.FindByProjectID(ctx, projectID, query)
+}
+
+func TestListCalendarTasks(t *testing.T) {
+	repo := &queryRecordingRepo{fakeTaskRepo: &fakeTaskRepo{listOut: []*domain.Task{{ID: "t1", ProjectID: "proj-1"}}}}
+	uc := &usecase.ListCalendarTasksUsecase{
+		Repo:   repo,
+		Access: &fakeAccess{privateProjects: map[string]bool{"proj-1": true}, members: map[string]bool{"user-1": true}},
+	}
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	tasks, err := uc.Execute(context.Background(), usecase.ListCalendarTasksInput{ProjectID: "proj-1", ActorID: "user-1", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("expected the repository result, got %d tasks", len(tasks))
+	}
+	q := repo.query
+	if q.DueDateFrom == nil || !q.DueDateFrom.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) || q.DueDateTo != nil {
+		t.Errorf("expected due dates from 90 days ago, got %v - %v", q.DueDateFrom, q.DueDateTo)
+	}
+	if q.Limit != usecase.MaxCalendarTasks || len(q.SortOrders) == 0 || q.SortOrders[0].Key != "dueDate" {
+		t.Errorf("expected up to %d tasks ordered by due date, got limit %d sort %+v", usecase.MaxCalendarTasks, q.Limit, q.SortOrders)
+	}
+
+	repo.query = nil
+	_, err = uc.Execute(context.Background(), usecase.ListCalendarTasksInput{ProjectID: "proj-1", ActorID: "user-2", Now: now})
+	if !errors.Is(err, usecase.ErrForbidden) || repo.query != nil {
+		t.Errorf("expected ErrForbidden before querying, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"teamflow-shared/audit"
+	"teamflow-shared/events"
+	"teamflow-shared/outbox"
+)
+
+// CarryOverSprintTasksInput はスプリントの未完了タスクの持ち越しの入力。
+type CarryOverSprintTasksInput struct {
+	ProjectID    string
+	FromSprintID string // 完了するスプリント
+	ToSprintID   string // 持ち越し先のスプリント。空の場合はバックログ（sprintId なし）に戻す
+	ActorID      string // 操作者（UpdatedBy）。空の場合は不明
+	Now          time.Time
+}
+
+// CarryOverSprintTasksUsecase はスプリントの完了に伴って、未完了のタスクを次のスプリントへ移すユースケース。
+// projects サービスのスプリントの完了から呼ばれる。
+//
+// 移したタスクは FromSprintID に属さなくなるため、再試行されても二重に移すことはない（2 回目は 0 件）。
+// 持ち越し先のスプリントの存在は projects サービス側で確認済みとして扱う。
+type CarryOverSprintTasksUsecase struct {
+	Repo TaskRepository
+	Tx   TxManager // 任意。nil の場合はトランザクション無しで実行する
+	// Audit は移したタスクを監査ログに記録するために使う。任意。nil の場合は記録しない
+	Audit audit.Recorder
+	// Events は移したタスクの task.updated を outbox に記録するために使う。任意。nil の場合は記録しない
+	Events outbox.Writer
+}
+
+// Execute は FromSprintID の未完了（done 以外）でアーカイブされていないタスクを ToSprintID に移し、件数を返す。
+func (uc *CarryOverSprintTasksUsecase) Execute(ctx context.Context, in CarryOverSprintTasksInput) (int, error) {
+	if in.ProjectID == "" {
+		return 0, fmt.Errorf("%w: projectId is required", ErrInvalidInput)
+	}
+	if in.FromSprintID == "" {
+		return 0, fmt.Errorf("%w: fromSprintId is required", ErrInvalidInput)
+	}
+	if in.FromSprintID == in.ToSprintID {
+		return 0, fmt.Errorf("%w: toSprintId must differ from fromSprintId", ErrInvalidInput)
+	}
+
+	var to *string
+	if in.ToSprintID != "" {
+		toSprintID := in.ToSprintID
+		to = &toSprintID
+	}
+	var n int
+	err := withinTx(ctx, uc.Tx, func(ctx context.Context) error {
+		ids, err := uc.Repo.MoveIncompleteSprintTasks(ctx, in.ProjectID, in.FromSprintID, to, in.ActorID, in.Now)
+		if err != nil {
+			return err
+		}
+		n = len(ids)
+		fields := []string{"sprintId"}
+		if err := recordAudit(ctx, uc.Audit, idsAuditEntries(in.ProjectID, ids, audit.ActionUpdate, in.ActorID, fields, in.Now)...); err != nil {
+			return err
+		}
+		return recordEvents(ctx, uc.Events, idsEvents(ctx, events.TaskUpdated, in.ProjectID, ids, in.ActorID, fields, in.Now)...)
+	})
+	return n, err
+}
@@ -0,0 +1,84 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"teamflow-shared/audit"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+func TestCarryOverSprintTasks(t *testing.T) {
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	archivedAt := now.Add(-time.Hour)
+	s1, s2, other := "s-1", "s-2", "s-9"
+	repo := &fakeTaskRepo{listOut: []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", Status: domain.StatusTodo, SprintID: &s1},
+		{ID: "t2", ProjectID: "proj-1", Status: domain.StatusInProgress, SprintID: &s1},
+		{ID: "t3", ProjectID: "proj-1", Status: domain.StatusDone, SprintID: &s1},
+		{ID: "t4", ProjectID: "proj-1", Status: domain.StatusTodo, SprintID: &s1, ArchivedAt: &archivedAt},
+		{ID: "t5", ProjectID: "proj-1", Status: domain.StatusTodo, SprintID: &other},
+		{ID: "t6", ProjectID: "proj-1", Status: domain.StatusTodo},
+	}}
+	rec := audit.NewMemoryRecorder()
+	uc := &usecase.CarryOverSprintTasksUsecase{Repo: repo, Audit: rec}
+
+	got, err := uc.Execute(context.Background(), usecase.CarryOverSprintTasksInput{ProjectID: "proj-1", FromSprintID: s1, ToSprintID: s2, ActorID: "user-1", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 2 {
+		t.Errorf("expected 2 tasks, got %d", got)
+	}
+	for _, task := range repo.listOut {
+		want := map[string]string{"t1": s2, "t2": s2, "t3": s1, "t4": s1, "t5": other, "t6": ""}[task.ID]
+		gotID := ""
+		if task.SprintID != nil {
+			gotID = *task.SprintID
+		}
+		if gotID != want {
+			t.Errorf("%s: expected sprint %q, got %q", task.ID, want, gotID)
+		}
+	}
+	if repo.listOut[0].UpdatedBy != "user-1" || repo.listOut[2].UpdatedBy != "" {
+		t.Errorf("expected only moved tasks to be updated by user-1, got %q / %q", repo.listOut[0].UpdatedBy, repo.listOut[2].UpdatedBy)
+	}
+	entries := rec.Entries()
+	if len(entries) != 2 || entries[0].EntityID != "t1" || entries[1].EntityID != "t2" {
+		t.Fatalf("expected audit entries for t1 and t2, got %+v", entries)
+	}
+	if e := entries[0]; e.Action != audit.ActionUpdate || e.ActorID != "user-1" || len(e.Fields) != 1 || e.Fields[0] != "sprintId" {
+		t.Errorf("unexpected audit entry: %+v", e)
+	}
+
+	// 再試行では 0 件
+	if got, err := uc.Execute(context.Background(), usecase.CarryOverSprintTasksInput{ProjectID: "proj-1", FromSprintID: s1, ToSprintID: s2, Now: now}); err != nil || got != 0 {
+		t.Errorf("expected 0 tasks on retry, got %d (err=%v)", got, err)
+	}
+
+	// 持ち越し先を省略した場合はバックログに戻す
+	if got, err := uc.Execute(context.Background(), usecase.CarryOverSprintTasksInput{ProjectID: "proj-1", FromSprintID: s2, Now: now}); err != nil || got != 2 {
+		t.Fatalf("expected 2 tasks moved to backlog, got %d (err=%v)", got, err)
+	}
+	if repo.listOut[0].SprintID != nil || repo.listOut[1].SprintID != nil {
+		t.Errorf("expected tasks to be moved to backlog, got %v, %v", repo.listOut[0].SprintID, repo.listOut[1].SprintID)
+	}
+}
+
+func TestCarryOverSprintTasks_InvalidInput(t *testing.T) {
+	uc := &usecase.CarryOverSprintTasksUsecase{Repo: &fakeTaskRepo{}}
+
+	for _, in := range []usecase.CarryOverSprintTasksInput{
+		{ProjectID: "", FromSprintID: "s-1"},
+		{ProjectID: "proj-1", FromSprintID: ""},
+		{ProjectID: "proj-1", FromSprintID: "s-1", ToSprintID: "s-1"},
+	} {
+		if _, err := uc.Execute(context.Background(), in); !errors.Is(err, usecase.ErrInvalidInput) {
+			t.Errorf("%+v: expected ErrInvalidInput, got %v", in, err)
+		}
+	}
+}
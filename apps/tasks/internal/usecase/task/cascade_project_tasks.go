@@ -0,0 +1,93 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"teamflow-shared/audit"
+	"teamflow-shared/events"
+	"teamflow-shared/outbox"
+)
+
+// CascadeAction はプロジェクトの削除・復元に伴うタスクの一括操作の種類。
+type CascadeAction string
+
+const (
+	CascadeArchive   CascadeAction = "archive"   // タスクをアーカイブする（cascade=archive_tasks での削除）
+	CascadeUnarchive CascadeAction = "unarchive" // アーカイブしたタスクを戻す（archive_tasks で削除したプロジェクトの復元）
+	CascadeDelete    CascadeAction = "delete"    // タスクを物理削除する（cascade=delete_tasks での削除。戻せない）
+)
+
+// CascadeProjectTasksInput はタスクの一括操作の入力。
+type CascadeProjectTasksInput struct {
+	ProjectID string
+	Action    CascadeAction
+	ActorID   string    // 操作者（監査ログに記録する）。空の場合は不明
+	Now       time.Time // アーカイブ日時（CascadeArchive のみ使う）。監査ログの日時にも使う
+}
+
+// CascadeProjectTasksUsecase はプロジェクトの削除・復元に伴ってプロジェクトのタスクを一括で操作するユースケース。
+// projects サービスの削除（saga）から呼ばれる。
+//
+// 再試行されても結果が変わらないよう、対象になるタスクが無い場合も成功として 0 件を返す
+// （アーカイブ済みのタスクは再度アーカイブしない）。
+type CascadeProjectTasksUsecase struct {
+	Repo TaskRepository
+	Tx   TxManager // 任意。nil の場合はトランザクション無しで実行する
+	// Audit は対象になったタスクを監査ログに記録するために使う。任意。nil の場合は記録しない
+	Audit audit.Recorder
+	// Events は対象になったタスクの task.updated（アーカイブ・アーカイブ解除）・task.deleted を outbox に記録するために使う。
+	// 任意。nil の場合は記録しない
+	Events outbox.Writer
+}
+
+// Execute は in.Action を実行し、対象になったタスクの件数を返す。
+func (uc *CascadeProjectTasksUsecase) Execute(ctx context.Context, in CascadeProjectTasksInput) (int, error) {
+	if in.ProjectID == "" {
+		return 0, fmt.Errorf("%w: projectId is required", ErrInvalidInput)
+	}
+
+	var n int
+	err := withinTx(ctx, uc.Tx, func(ctx context.Context) error {
+		var err error
+		n, err = uc.execute(ctx, in)
+		return err
+	})
+	return n, err
+}
+
+// execute は一括操作と監査ログ・イベントの記録を行い、対象になったタスクの件数を返す。
+func (uc *CascadeProjectTasksUsecase) execute(ctx context.Context, in CascadeProjectTasksInput) (int, error) {
+	var (
+		ids       []string
+		action    audit.Action
+		eventType = events.TaskUpdated
+		fields    = []string{"archivedAt"}
+		err       error
+	)
+	switch in.Action {
+	case CascadeArchive:
+		ids, err = uc.Repo.ArchiveByProject(ctx, in.ProjectID, in.Now)
+		action = audit.ActionArchive
+	case CascadeUnarchive:
+		ids, err = uc.Repo.UnarchiveByProject(ctx, in.ProjectID)
+		action = audit.ActionUnarchive
+	case CascadeDelete:
+		ids, err = uc.Repo.DeleteByProject(ctx, in.ProjectID)
+		action = audit.ActionDelete
+		eventType, fields = events.TaskDeleted, nil
+	default:
+		return 0, fmt.Errorf("%w: unknown action %q", ErrInvalidInput, in.Action)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := recordAudit(ctx, uc.Audit, idsAuditEntries(in.ProjectID, ids, action, in.ActorID, nil, in.Now)...); err != nil {
+		return len(ids), err
+	}
+	if err := recordEvents(ctx, uc.Events, idsEvents(ctx, eventType, in.ProjectID, ids, in.ActorID, fields, in.Now)...); err != nil {
+		return len(ids), err
+	}
+	return len(ids), nil
+}
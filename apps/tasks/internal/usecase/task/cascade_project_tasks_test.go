@@ -0,0 +1,95 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"teamflow-shared/audit"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+func TestCascadeProjectTasks(t *testing.T) {
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	repo := &fakeTaskRepo{listOut: []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1"},
+		{ID: "t2", ProjectID: "proj-1"},
+		{ID: "t3", ProjectID: "proj-2"},
+	}}
+	rec := audit.NewMemoryRecorder()
+	uc := &usecase.CascadeProjectTasksUsecase{Repo: repo, Audit: rec}
+
+	steps := []struct {
+		name      string
+		in        usecase.CascadeProjectTasksInput
+		wantCount int
+	}{
+		{name: "archive", in: usecase.CascadeProjectTasksInput{ProjectID: "proj-1", Action: usecase.CascadeArchive, ActorID: "user-1", Now: now}, wantCount: 2},
+		// 再試行（saga のリトライ）ではアーカイブ済みのタスクを対象にしない
+		{name: "archive again", in: usecase.CascadeProjectTasksInput{ProjectID: "proj-1", Action: usecase.CascadeArchive, Now: now.Add(time.Hour)}, wantCount: 0},
+		{name: "unarchive", in: usecase.CascadeProjectTasksInput{ProjectID: "proj-1", Action: usecase.CascadeUnarchive}, wantCount: 2},
+		{name: "delete", in: usecase.CascadeProjectTasksInput{ProjectID: "proj-1", Action: usecase.CascadeDelete}, wantCount: 2},
+		{name: "delete again", in: usecase.CascadeProjectTasksInput{ProjectID: "proj-1", Action: usecase.CascadeDelete}, wantCount: 0},
+	}
+
+	for _, step := range steps {
+		got, err := uc.Execute(context.Background(), step.in)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", step.name, err)
+		}
+		if got != step.wantCount {
+			t.Errorf("%s: expected %d tasks, got %d", step.name, step.wantCount, got)
+		}
+		if step.name == "archive" {
+			for _, task := range repo.listOut[:2] {
+				if task.ArchivedAt == nil || !task.ArchivedAt.Equal(now) {
+					t.Errorf("expected %s archived at %v, got %v", task.ID, now, task.ArchivedAt)
+				}
+			}
+		}
+	}
+
+	// 対象になったタスクごとに記録する（0 件の再試行は記録しない）
+	var actions []string
+	for _, e := range rec.Entries() {
+		actions = append(actions, e.EntityID+":"+string(e.Action))
+	}
+	if got := strings.Join(actions, ","); got != "t1:archive,t2:archive,t1:unarchive,t2:unarchive,t1:delete,t2:delete" {
+		t.Errorf("unexpected audit entries: %s", got)
+	}
+	if e := rec.Entries()[0]; e.ActorID != "user-1" || !e.OccurredAt.Equal(now) {
+		t.Errorf("unexpected audit entry: %+v", e)
+	}
+
+	// 他のプロジェクトのタスクは変更しない
+	if len(repo.listOut) != 1 || repo.listOut[0].ID != "t3" || repo.listOut[0].ArchivedAt != nil {
+		t.Errorf("expected only t3 to remain untouched, got %+v", repo.listOut)
+	}
+}
+
+func TestCascadeProjectTasks_InvalidInput(t *testing.T) {
+	uc := &usecase.CascadeProjectTasksUsecase{Repo: &fakeTaskRepo{}}
+
+	for _, in := range []usecase.CascadeProjectTasksInput{
+		{ProjectID: "", Action: usecase.CascadeArchive},
+		{ProjectID: "proj-1", Action: "purge"},
+	} {
+		if _, err := uc.Execute(context.Background(), in); !errors.Is(err, usecase.ErrInvalidInput) {
+			t.Errorf("%+v: expected ErrInvalidInput, got %v", in, err)
+		}
+	}
+}
+
+func TestCascadeProjectTasks_RepositoryError(t *testing.T) {
+	repoErr := errors.New("db down")
+	uc := &usecase.CascadeProjectTasksUsecase{Repo: &fakeTaskRepo{err: repoErr}}
+
+	_, err := uc.Execute(context.Background(), usecase.CascadeProjectTasksInput{ProjectID: "proj-1", Action: usecase.CascadeDelete})
+	if !errors.Is(err, repoErr) {
+		t.Errorf("expected repository error, got %v", err)
+	}
+}
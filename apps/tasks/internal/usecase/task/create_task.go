@@ -2,18 +2,36 @@ package task
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"teamflow-shared/audit"
+	"teamflow-shared/outbox"
+	"teamflow-shared/uuidpolicy"
+
 	domain "teamflow-tasks/internal/domain/task"
 )
 
 // TaskRepository はタスクの永続化・取得を担当する抽象。
 type TaskRepository interface {
 	Save(ctx context.Context, t *domain.Task) error
+	// Update は t を保存する。保存されている版数が t.Version-1 でない場合（読み取った後に他で更新された）は
+	// *domain.VersionConflictError を返す
 	Update(ctx context.Context, t *domain.Task) error
 	FindByID(ctx context.Context, id string) (*domain.Task, error)
-	ListByProject(ctx context.Context, projectID string) ([]*domain.Task, error) // 後方互換性のため残す
+	FindByNumber(ctx context.Context, projectID string, number int) (*domain.Task, error) // プロジェクト内のタスク番号で取得する
+	ListByProject(ctx context.Context, projectID string) ([]*domain.Task, error)          // 後方互換性のため残す
 	FindByProjectID(ctx context.Context, projectID string, query *domain.TaskQuery) ([]*domain.Task, error)
+	ProjectVersion(ctx context.Context, projectID string) (domain.ProjectVersion, error) // 一覧のバージョン（件数と最新の updated_at）
+
+	// 以下はプロジェクトの削除・復元（projects サービス）に伴う一括操作。戻り値は対象になったタスクの ID
+	ArchiveByProject(ctx context.Context, projectID string, archivedAt time.Time) ([]string, error) // アーカイブされていないタスクをアーカイブする
+	UnarchiveByProject(ctx context.Context, projectID string) ([]string, error)                     // アーカイブされたタスクを戻す
+	DeleteByProject(ctx context.Context, projectID string) ([]string, error)                        // タスクを物理削除する
+
+	// MoveIncompleteSprintTasks はスプリントの完了（projects サービス）に伴い、fromSprintID の未完了でアーカイブされていない
+	// タスクを toSprintID（nil はバックログ）に移して updated_at を now、updated_by を actorID にする。戻り値は対象になったタスクの ID
+	MoveIncompleteSprintTasks(ctx context.Context, projectID, fromSprintID string, toSprintID *string, actorID string, now time.Time) ([]string, error)
 }
 
 // CreateTaskInput はタスク作成ユースケースの入力。
@@ -23,19 +41,123 @@ type CreateTaskInput struct {
 	Title       string
 	Description string
 	Status      domain.TaskStatus
-	Priority    domain.TaskPriority
+	Priority    domain.TaskPriority // 空の場合はプロジェクト設定の既定値を使う
+	AssigneeID  string              // 空の場合はプロジェクト設定の既定値を使う
+	DueDate     *time.Time          // 期日（日付に正規化する）。nil の場合は期日なし
+	MilestoneID string              // 空の場合はマイルストーンなし
+	SprintID    string              // 空の場合はバックログ
+	EpicID      string              // 空の場合はエピックなし
+	LabelIDs    []string            // 重複は取り除く。空の場合はラベルなし
+	ActorID     string              // 作成者（CreatedBy / UpdatedBy）。空の場合は不明
 	Now         time.Time
 }
 
 // CreateTaskUsecase はタスク作成ユースケースを表す。
 type CreateTaskUsecase struct {
 	Repo TaskRepository
+	Tx   TxManager // 任意。nil の場合はトランザクション無しで実行する
+	// Authorizer は操作者がプロジェクトにタスクを作成できるかの確認に使う。任意。nil の場合は確認しない
+	Authorizer ProjectWriteAuthorizer
+	// Defaults は省略されたフィールドの既定値の取得に使う。任意。nil の場合は既定値を適用しない
+	Defaults ProjectDefaultsProvider
+	// Members は担当者のメンバーチェックに使う。任意。nil の場合はチェックしない
+	Members MembershipChecker
+	// Users は担当者のユーザーの存在チェックに使う。任意。nil の場合はチェックしない
+	Users UserDirectory
+	// Milestones はマイルストーンの存在チェックに使う。任意。nil の場合はチェックしない
+	Milestones MilestoneChecker
+	// Sprints はスプリントの存在チェックに使う。任意。nil の場合はチェックしない
+	Sprints SprintChecker
+	// Epics はエピックの存在チェックに使う。任意。nil の場合はチェックしない
+	Epics EpicChecker
+	// Labels はラベルが定義済みかのチェックに使う。任意。nil の場合はチェックしない
+	Labels LabelChecker
+	// Audit は作成を監査ログに記録するために使う。任意。nil の場合は記録しない
+	Audit audit.Recorder
+	// Events は task.created を outbox に記録するために使う。任意。nil の場合は記録しない
+	Events outbox.Writer
+	// Notifier は担当者への通知に使う（保存した後に呼ぶ）。任意。nil の場合は通知しない
+	Notifier AssignmentNotifier
+	// UUIDPolicy は作成するタスクの ID と担当者 ID として受け付ける UUID の形式。任意。空の場合は形式を確認しない
+	UUIDPolicy uuidpolicy.Policy
 }
 
 // Execute は新しいタスクを作成し、リポジトリに保存する。
+// Authorizer が設定されていれば、先に操作者がプロジェクトにタスクを作成できるか確認する。
+// UUIDPolicy で受け付けない ID の場合は ErrInvalidInput を返す。
 func (uc *CreateTaskUsecase) Execute(ctx context.Context, in CreateTaskInput) (*domain.Task, error) {
-	// いまは dueDate 未対応なので nil 固定
-	var dueDate *time.Time = nil
+	if err := checkUUID(uc.UUIDPolicy, "id", in.ID); err != nil {
+		return nil, err
+	}
+	if err := checkUUID(uc.UUIDPolicy, "assigneeId", in.AssigneeID); err != nil {
+		return nil, err
+	}
+	if err := checkWriteAccess(ctx, uc.Authorizer, in.ProjectID, in.ActorID); err != nil {
+		return nil, err
+	}
+
+	var defaults *ProjectDefaults
+	if in.Priority == "" || in.AssigneeID == "" {
+		d, err := uc.loadDefaults(ctx, in.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		defaults = d
+	}
+
+	t, err := uc.build(ctx, in, defaults)
+	if err != nil {
+		return nil, err
+	}
+
+	// 保存と監査ログ・イベントの記録は 1 トランザクションで行う
+	err = withinTx(ctx, uc.Tx, func(ctx context.Context) error {
+		return uc.save(ctx, t)
+	})
+	if err != nil {
+		return t, err
+	}
+	notifyAssigned(ctx, uc.Notifier, in.ActorID, t)
+	return t, nil
+}
+
+// save はタスクを保存し、監査ログと task.created を記録する。
+func (uc *CreateTaskUsecase) save(ctx context.Context, tasks ...*domain.Task) error {
+	for _, t := range tasks {
+		if err := uc.Repo.Save(ctx, t); err != nil {
+			return err
+		}
+	}
+	if err := recordAudit(ctx, uc.Audit, createdAuditEntries(tasks...)...); err != nil {
+		return err
+	}
+	return recordEvents(ctx, uc.Events, createdEvents(ctx, tasks...)...)
+}
+
+// loadDefaults はプロジェクト設定の既定値を取得する。Defaults が nil の場合は nil を返す。
+func (uc *CreateTaskUsecase) loadDefaults(ctx context.Context, projectID string) (*ProjectDefaults, error) {
+	if uc.Defaults == nil {
+		return nil, nil
+	}
+	defaults, err := uc.Defaults.ProjectDefaults(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project defaults: %w", err)
+	}
+	return &defaults, nil
+}
+
+// build は省略されたフィールドに defaults（nil 可）を適用してタスクを生成し、担当者のメンバーチェックと
+// マイルストーンの存在チェックを行う。
+func (uc *CreateTaskUsecase) build(ctx context.Context, in CreateTaskInput, defaults *ProjectDefaults) (*domain.Task, error) {
+	priority, assigneeID := in.Priority, in.AssigneeID
+	if defaults != nil {
+		if priority == "" {
+			priority = defaults.Priority
+		}
+		if assigneeID == "" {
+			assigneeID = defaults.AssigneeID
+		}
+	}
 
 	t, err := domain.NewTask(
 		in.ID,
@@ -43,16 +165,66 @@ func (uc *CreateTaskUsecase) Execute(ctx context.Context, in CreateTaskInput) (*
 		in.Title,
 		in.Description,
 		in.Status,
-		in.Priority,
-		dueDate,
+		priority,
+		in.DueDate,
 		in.Now,
 	)
 	if err != nil {
 		return nil, err
 	}
+	t.CreatedBy = in.ActorID
+	t.UpdatedBy = in.ActorID
 
-	if err := uc.Repo.Save(ctx, t); err != nil {
-		return t, err
+	if assigneeID != "" {
+		// 既定の担当者も設定後にメンバーから外れている可能性があるためチェックする
+		if uc.Members != nil {
+			ok, err := uc.Members.IsMember(ctx, in.ProjectID, assigneeID)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidInput, ErrAssigneeNotMember)
+			}
+		}
+		if err := checkAssignee(ctx, uc.Users, assigneeID); err != nil {
+			return nil, err
+		}
+		t.AssigneeID = &assigneeID
+	}
+
+	if in.MilestoneID != "" {
+		if err := checkMilestone(ctx, uc.Milestones, in.ProjectID, in.MilestoneID); err != nil {
+			return nil, err
+		}
+		milestoneID := in.MilestoneID
+		t.MilestoneID = &milestoneID
+	}
+
+	if in.SprintID != "" {
+		if err := checkSprint(ctx, uc.Sprints, in.ProjectID, in.SprintID); err != nil {
+			return nil, err
+		}
+		sprintID := in.SprintID
+		t.SprintID = &sprintID
+	}
+
+	if in.EpicID != "" {
+		if err := checkEpic(ctx, uc.Epics, in.ProjectID, in.EpicID); err != nil {
+			return nil, err
+		}
+		epicID := in.EpicID
+		t.EpicID = &epicID
+	}
+
+	if len(in.LabelIDs) > 0 {
+		labelIDs, err := domain.NormalizeLabelIDs(in.LabelIDs)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkLabels(ctx, uc.Labels, in.ProjectID, labelIDs); err != nil {
+			return nil, err
+		}
+		t.LabelIDs = labelIDs
 	}
 
 	return t, nil
@@ -6,6 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"teamflow-shared/audit"
+	"teamflow-shared/uuidpolicy"
+
 	domain "teamflow-tasks/internal/domain/task"
 	usecase "teamflow-tasks/internal/usecase/task"
 )
@@ -40,6 +43,15 @@ func (r *fakeTaskRepo) FindByID(_ context.Context, id string) (*domain.Task, err
 	return nil, errors.New("not found")
 }
 
+func (r *fakeTaskRepo) FindByNumber(_ context.Context, projectID string, number int) (*domain.Task, error) {
+	for _, t := range r.listOut {
+		if t.ProjectID == projectID && t.Number == number {
+			return t, nil
+		}
+	}
+	return nil, usecase.ErrTaskNotFound
+}
+
 func (r *fakeTaskRepo) ListByProject(_ context.Context, projectID string) ([]*domain.Task, error) {
 	return r.listOut, nil
 }
@@ -49,6 +61,72 @@ func (r *fakeTaskRepo) FindByProjectID(_ context.Context, projectID string, quer
 	return r.listOut, nil
 }
 
+func (r *fakeTaskRepo) ProjectVersion(_ context.Context, projectID string) (domain.ProjectVersion, error) {
+	return domain.ProjectVersion{}, nil
+}
+
+func (r *fakeTaskRepo) ArchiveByProject(_ context.Context, projectID string, archivedAt time.Time) ([]string, error) {
+	return r.eachInProject(projectID, func(t *domain.Task) bool {
+		if t.ArchivedAt != nil {
+			return false
+		}
+		at := archivedAt
+		t.ArchivedAt = &at
+		return true
+	})
+}
+
+func (r *fakeTaskRepo) UnarchiveByProject(_ context.Context, projectID string) ([]string, error) {
+	return r.eachInProject(projectID, func(t *domain.Task) bool {
+		if t.ArchivedAt == nil {
+			return false
+		}
+		t.ArchivedAt = nil
+		return true
+	})
+}
+
+func (r *fakeTaskRepo) DeleteByProject(_ context.Context, projectID string) ([]string, error) {
+	ids, err := r.eachInProject(projectID, func(*domain.Task) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	kept := r.listOut[:0]
+	for _, t := range r.listOut {
+		if t.ProjectID != projectID {
+			kept = append(kept, t)
+		}
+	}
+	r.listOut = kept
+	return ids, nil
+}
+
+func (r *fakeTaskRepo) MoveIncompleteSprintTasks(_ context.Context, projectID, fromSprintID string, toSprintID *string, actorID string, now time.Time) ([]string, error) {
+	return r.eachInProject(projectID, func(t *domain.Task) bool {
+		if t.ArchivedAt != nil || t.Status == domain.StatusDone || t.SprintID == nil || *t.SprintID != fromSprintID {
+			return false
+		}
+		t.SprintID = toSprintID
+		t.UpdatedAt = now
+		t.UpdatedBy = actorID
+		return true
+	})
+}
+
+// eachInProject は projectID のタスクに fn を適用し、fn が true を返したタスクの ID を返す。
+func (r *fakeTaskRepo) eachInProject(projectID string, fn func(t *domain.Task) bool) ([]string, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	var ids []string
+	for _, t := range r.listOut {
+		if t.ProjectID == projectID && fn(t) {
+			ids = append(ids, t.ID)
+		}
+	}
+	return ids, nil
+}
+
 func TestNewTask_Success(t *testing.T) {
 	now := time.Now()
 
@@ -131,6 +209,33 @@ func TestCreateTask_Success(t *testing.T) {
 	}
 }
 
+func TestCreateTask_RecordsActor(t *testing.T) {
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	rec := audit.NewMemoryRecorder()
+	uc := &usecase.CreateTaskUsecase{Repo: &fakeTaskRepo{}, Audit: rec}
+
+	task, err := uc.Execute(context.Background(), usecase.CreateTaskInput{
+		ID: "task-1", ProjectID: "proj-1", Title: "t", Status: domain.StatusTodo, Priority: domain.PriorityMedium,
+		ActorID: "user-1", Now: now,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.CreatedBy != "user-1" || task.UpdatedBy != "user-1" {
+		t.Errorf("expected createdBy/updatedBy=user-1, got %q/%q", task.CreatedBy, task.UpdatedBy)
+	}
+
+	entries := rec.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %+v", entries)
+	}
+	want := audit.Entry{Service: "tasks", EntityType: "task", EntityID: "task-1", ProjectID: "proj-1", Action: audit.ActionCreate, ActorID: "user-1", OccurredAt: now}
+	if e := entries[0]; e.Service != want.Service || e.EntityType != want.EntityType || e.EntityID != want.EntityID ||
+		e.ProjectID != want.ProjectID || e.Action != want.Action || e.ActorID != want.ActorID || !e.OccurredAt.Equal(now) || e.Fields != nil {
+		t.Errorf("unexpected audit entry: %+v", e)
+	}
+}
+
 func TestCreateTask_RepositoryError(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
@@ -167,3 +272,309 @@ func TestCreateTask_RepositoryError(t *testing.T) {
 		t.Fatalf("expected task to be non-nil when repo error")
 	}
 }
+
+// fakeDefaultsProvider は ProjectDefaultsProvider のテスト用フェイク実装。
+type fakeDefaultsProvider struct {
+	defaults usecase.ProjectDefaults
+	err      error
+	calls    int
+}
+
+func (p *fakeDefaultsProvider) ProjectDefaults(_ context.Context, _ string) (usecase.ProjectDefaults, error) {
+	p.calls++
+	return p.defaults, p.err
+}
+
+func TestCreateTask_ProjectDefaults(t *testing.T) {
+	defaults := usecase.ProjectDefaults{Priority: domain.PriorityHigh, AssigneeID: "user-1"}
+
+	tests := []struct {
+		name         string
+		priority     domain.TaskPriority
+		assignee     string
+		wantPriority domain.TaskPriority
+		wantAssignee string
+		wantCalls    int
+	}{
+		{name: "both omitted", wantPriority: domain.PriorityHigh, wantAssignee: "user-1", wantCalls: 1},
+		{name: "priority given", priority: domain.PriorityLow, wantPriority: domain.PriorityLow, wantAssignee: "user-1", wantCalls: 1},
+		{name: "both given", priority: domain.PriorityLow, assignee: "user-2", wantPriority: domain.PriorityLow, wantAssignee: "user-2", wantCalls: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeDefaultsProvider{defaults: defaults}
+			uc := &usecase.CreateTaskUsecase{Repo: &fakeTaskRepo{}, Defaults: provider}
+
+			task, err := uc.Execute(context.Background(), usecase.CreateTaskInput{
+				ID:         "task-1",
+				ProjectID:  "proj-1",
+				Title:      "画面設計",
+				Status:     domain.StatusTodo,
+				Priority:   tt.priority,
+				AssigneeID: tt.assignee,
+				Now:        time.Now(),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if task.Priority != tt.wantPriority {
+				t.Errorf("expected Priority=%s, got=%s", tt.wantPriority, task.Priority)
+			}
+			if task.AssigneeID == nil || *task.AssigneeID != tt.wantAssignee {
+				t.Errorf("expected AssigneeID=%s, got=%v", tt.wantAssignee, task.AssigneeID)
+			}
+			if provider.calls != tt.wantCalls {
+				t.Errorf("expected %d ProjectDefaults calls, got %d", tt.wantCalls, provider.calls)
+			}
+		})
+	}
+}
+
+func TestCreateTask_NoDefaultPriority(t *testing.T) {
+	uc := &usecase.CreateTaskUsecase{Repo: &fakeTaskRepo{}, Defaults: &fakeDefaultsProvider{}}
+
+	_, err := uc.Execute(context.Background(), usecase.CreateTaskInput{
+		ID: "task-1", ProjectID: "proj-1", Title: "画面設計", Status: domain.StatusTodo, Now: time.Now(),
+	})
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+}
+
+func TestCreateTask_DefaultsError(t *testing.T) {
+	providerErr := errors.New("projects service unavailable")
+	repo := &fakeTaskRepo{}
+	uc := &usecase.CreateTaskUsecase{Repo: repo, Defaults: &fakeDefaultsProvider{err: providerErr}}
+
+	_, err := uc.Execute(context.Background(), usecase.CreateTaskInput{
+		ID: "task-1", ProjectID: "proj-1", Title: "画面設計", Status: domain.StatusTodo, Now: time.Now(),
+	})
+	if !errors.Is(err, providerErr) {
+		t.Fatalf("expected %v, got %v", providerErr, err)
+	}
+	if repo.saved != nil {
+		t.Fatalf("expected task not to be saved")
+	}
+}
+
+func TestCreateTask_AssigneeMembership(t *testing.T) {
+	members := &fakeMembershipChecker{members: map[string]bool{"proj-1/user-1": true}}
+	repo := &fakeTaskRepo{}
+	uc := &usecase.CreateTaskUsecase{
+		Repo:     repo,
+		Defaults: &fakeDefaultsProvider{defaults: usecase.ProjectDefaults{Priority: domain.PriorityMedium, AssigneeID: "user-2"}},
+		Members:  members,
+	}
+
+	_, err := uc.Execute(context.Background(), usecase.CreateTaskInput{
+		ID: "task-1", ProjectID: "proj-1", Title: "画面設計", Status: domain.StatusTodo, Now: time.Now(),
+	})
+	if !errors.Is(err, usecase.ErrAssigneeNotMember) || !errors.Is(err, usecase.ErrInvalidInput) {
+		t.Fatalf("expected ErrAssigneeNotMember wrapped in ErrInvalidInput, got %v", err)
+	}
+	if repo.saved != nil {
+		t.Fatalf("expected task not to be saved")
+	}
+}
+
+func TestCreateTask_UUIDPolicy(t *testing.T) {
+	const (
+		v4 = "3f1c2b9e-8d4a-4c1e-9f2a-6b7d8e9f0a1b"
+		v1 = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	)
+	tests := []struct {
+		name     string
+		policy   uuidpolicy.Policy
+		id       string
+		assignee string
+		wantErr  bool
+	}{
+		{name: "not configured", id: "task-1", assignee: "user-1"},
+		{name: "any accepts v1", policy: uuidpolicy.Any, id: v1, assignee: v1},
+		{name: "any rejects free form id", policy: uuidpolicy.Any, id: "task-1", wantErr: true},
+		{name: "v4v7 accepts v4", policy: uuidpolicy.V4V7, id: v4, assignee: v4},
+		{name: "v4v7 rejects v1 id", policy: uuidpolicy.V4V7, id: v1, wantErr: true},
+		{name: "v4v7 rejects v1 assignee", policy: uuidpolicy.V4V7, id: v4, assignee: v1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeTaskRepo{}
+			uc := &usecase.CreateTaskUsecase{Repo: repo, UUIDPolicy: tt.policy}
+
+			_, err := uc.Execute(context.Background(), usecase.CreateTaskInput{
+				ID: tt.id, ProjectID: "proj-1", Title: "画面設計", Status: domain.StatusTodo, Priority: domain.PriorityMedium,
+				AssigneeID: tt.assignee, Now: time.Now(),
+			})
+			if tt.wantErr {
+				if !errors.Is(err, usecase.ErrInvalidInput) || !errors.Is(err, uuidpolicy.ErrInvalid) {
+					t.Fatalf("expected ErrInvalid wrapped in ErrInvalidInput, got %v", err)
+				}
+				if repo.saved != nil {
+					t.Fatal("expected task not to be saved")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateTask_Authorizer(t *testing.T) {
+	policy := &usecase.MembershipPolicy{Members: &fakeMembershipChecker{members: map[string]bool{"proj-1/user-1": true}}}
+
+	tests := []struct {
+		name    string
+		actorID string
+		wantErr error
+	}{
+		{name: "member", actorID: "user-1"},
+		{name: "no actor", actorID: "", wantErr: usecase.ErrActorRequired},
+		{name: "not a member", actorID: "user-2", wantErr: usecase.ErrProjectNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeTaskRepo{}
+			uc := &usecase.CreateTaskUsecase{Repo: repo, Authorizer: policy}
+
+			_, err := uc.Execute(context.Background(), usecase.CreateTaskInput{
+				ID: "task-1", ProjectID: "proj-1", Title: "画面設計", Status: domain.StatusTodo, Priority: domain.PriorityMedium,
+				ActorID: tt.actorID, Now: time.Now(),
+			})
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if repo.saved != nil {
+				t.Fatalf("expected task not to be saved")
+			}
+		})
+	}
+}
+
+func TestCreateTask_Milestone(t *testing.T) {
+	milestones := &fakeMilestoneChecker{milestones: map[string]bool{"proj-1/m-1": true}}
+	repo := &fakeTaskRepo{}
+	uc := &usecase.CreateTaskUsecase{Repo: repo, Milestones: milestones}
+
+	created, err := uc.Execute(context.Background(), usecase.CreateTaskInput{
+		ID: "task-1", ProjectID: "proj-1", Title: "画面設計", Status: domain.StatusTodo, Priority: domain.PriorityLow,
+		MilestoneID: "m-1", Now: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.MilestoneID == nil || *created.MilestoneID != "m-1" {
+		t.Errorf("expected milestoneId m-1, got %v", created.MilestoneID)
+	}
+
+	repo.saved = nil
+	_, err = uc.Execute(context.Background(), usecase.CreateTaskInput{
+		ID: "task-2", ProjectID: "proj-1", Title: "画面設計", Status: domain.StatusTodo, Priority: domain.PriorityLow,
+		MilestoneID: "m-2", Now: time.Now(),
+	})
+	if !errors.Is(err, usecase.ErrMilestoneNotFound) || !errors.Is(err, usecase.ErrInvalidInput) {
+		t.Fatalf("expected ErrMilestoneNotFound wrapped in ErrInvalidInput, got %v", err)
+	}
+	if repo.saved != nil {
+		t.Fatalf("expected task not to be saved")
+	}
+}
+
+func TestCreateTask_Sprint(t *testing.T) {
+	sprints := &fakeSprintChecker{sprints: map[string]bool{"proj-1/s-1": true}}
+	repo := &fakeTaskRepo{}
+	uc := &usecase.CreateTaskUsecase{Repo: repo, Sprints: sprints}
+
+	created, err := uc.Execute(context.Background(), usecase.CreateTaskInput{
+		ID: "task-1", ProjectID: "proj-1", Title: "画面設計", Status: domain.StatusTodo, Priority: domain.PriorityLow,
+		SprintID: "s-1", Now: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.SprintID == nil || *created.SprintID != "s-1" {
+		t.Errorf("expected sprintId s-1, got %v", created.SprintID)
+	}
+
+	repo.saved = nil
+	_, err = uc.Execute(context.Background(), usecase.CreateTaskInput{
+		ID: "task-2", ProjectID: "proj-1", Title: "画面設計", Status: domain.StatusTodo, Priority: domain.PriorityLow,
+		SprintID: "s-2", Now: time.Now(),
+	})
+	if !errors.Is(err, usecase.ErrSprintNotFound) || !errors.Is(err, usecase.ErrInvalidInput) {
+		t.Fatalf("expected ErrSprintNotFound wrapped in ErrInvalidInput, got %v", err)
+	}
+	if repo.saved != nil {
+		t.Fatalf("expected task not to be saved")
+	}
+}
+
+func TestCreateTask_Epic(t *testing.T) {
+	epics := &fakeEpicChecker{epics: map[string]bool{"proj-1/e-1": true}}
+	repo := &fakeTaskRepo{}
+	uc := &usecase.CreateTaskUsecase{Repo: repo, Epics: epics}
+
+	created, err := uc.Execute(context.Background(), usecase.CreateTaskInput{
+		ID: "task-1", ProjectID: "proj-1", Title: "画面設計", Status: domain.StatusTodo, Priority: domain.PriorityLow,
+		EpicID: "e-1", Now: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.EpicID == nil || *created.EpicID != "e-1" {
+		t.Errorf("expected epicId e-1, got %v", created.EpicID)
+	}
+
+	repo.saved = nil
+	_, err = uc.Execute(context.Background(), usecase.CreateTaskInput{
+		ID: "task-2", ProjectID: "proj-1", Title: "画面設計", Status: domain.StatusTodo, Priority: domain.PriorityLow,
+		EpicID: "e-2", Now: time.Now(),
+	})
+	if !errors.Is(err, usecase.ErrEpicNotFound) || !errors.Is(err, usecase.ErrInvalidInput) {
+		t.Fatalf("expected ErrEpicNotFound wrapped in ErrInvalidInput, got %v", err)
+	}
+	if repo.saved != nil {
+		t.Fatalf("expected task not to be saved")
+	}
+}
+
+func TestCreateTask_Labels(t *testing.T) {
+	labels := &fakeLabelChecker{labels: map[string]bool{"proj-1/l-1": true, "proj-1/l-2": true}}
+	repo := &fakeTaskRepo{}
+	uc := &usecase.CreateTaskUsecase{Repo: repo, Labels: labels}
+
+	created, err := uc.Execute(context.Background(), usecase.CreateTaskInput{
+		ID: "task-1", ProjectID: "proj-1", Title: "画面設計", Status: domain.StatusTodo, Priority: domain.PriorityLow,
+		LabelIDs: []string{"l-2", "l-1", "l-2"}, Now: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(created.LabelIDs) != 2 || created.LabelIDs[0] != "l-2" || created.LabelIDs[1] != "l-1" {
+		t.Errorf("expected labelIds [l-2 l-1], got %v", created.LabelIDs)
+	}
+	if labels.calls != 2 {
+		t.Errorf("expected 2 LabelExists calls, got %d", labels.calls)
+	}
+
+	repo.saved = nil
+	_, err = uc.Execute(context.Background(), usecase.CreateTaskInput{
+		ID: "task-2", ProjectID: "proj-1", Title: "画面設計", Status: domain.StatusTodo, Priority: domain.PriorityLow,
+		LabelIDs: []string{"l-1", "l-3"}, Now: time.Now(),
+	})
+	if !errors.Is(err, usecase.ErrLabelNotFound) || !errors.Is(err, usecase.ErrInvalidInput) {
+		t.Fatalf("expected ErrLabelNotFound wrapped in ErrInvalidInput, got %v", err)
+	}
+	if repo.saved != nil {
+		t.Fatalf("expected task not to be saved")
+	}
+}
@@ -0,0 +1,54 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// MaxBatchTasks は一括作成できるタスクの最大数。
+const MaxBatchTasks = 200
+
+// CreateTasksUsecase は同じプロジェクトのタスクを一括作成するユースケース。
+// プロジェクトテンプレートからの作成など、サービス間の呼び出しで使う。
+type CreateTasksUsecase struct {
+	Create *CreateTaskUsecase
+	Tx     TxManager
+}
+
+// Execute はすべてのタスクを検証してから 1 トランザクションで保存する（すべて作成されるか、1 件も作成されない）。
+// 入力の ProjectID はすべて projectID で上書きする。
+// 不正な入力がある場合は何も保存せず、何件目かを含めたエラーを返す。
+func (uc *CreateTasksUsecase) Execute(ctx context.Context, projectID string, inputs []CreateTaskInput) ([]*domain.Task, error) {
+	if len(inputs) > MaxBatchTasks {
+		return nil, fmt.Errorf("%w: tasks must not exceed %d", ErrInvalidInput, MaxBatchTasks)
+	}
+
+	// 既定値はバッチ全体で 1 回だけ取得する
+	defaults, err := uc.Create.loadDefaults(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*domain.Task, len(inputs))
+	for i, in := range inputs {
+		in.ProjectID = projectID
+		t, err := uc.Create.build(ctx, in, defaults)
+		if err != nil {
+			return nil, fmt.Errorf("tasks[%d]: %w", i, err)
+		}
+		tasks[i] = t
+	}
+
+	err = withinTx(ctx, uc.Tx, func(ctx context.Context) error {
+		return uc.Create.save(ctx, tasks...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, t := range tasks {
+		notifyAssigned(ctx, uc.Create.Notifier, inputs[i].ActorID, t)
+	}
+	return tasks, nil
+}
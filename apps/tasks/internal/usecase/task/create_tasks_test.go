@@ -0,0 +1,80 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// recordingTaskRepo は保存されたタスクをすべて記録する。
+type recordingTaskRepo struct {
+	fakeTaskRepo
+	all []*domain.Task
+}
+
+func (r *recordingTaskRepo) Save(ctx context.Context, t *domain.Task) error {
+	r.all = append(r.all, t)
+	return r.fakeTaskRepo.Save(ctx, t)
+}
+
+func TestCreateTasksUsecase_Success(t *testing.T) {
+	repo := &recordingTaskRepo{}
+	provider := &fakeDefaultsProvider{defaults: usecase.ProjectDefaults{Priority: domain.PriorityHigh}}
+	tx := &fakeTxManager{}
+	uc := &usecase.CreateTasksUsecase{
+		Create: &usecase.CreateTaskUsecase{Repo: repo, Defaults: provider},
+		Tx:     tx,
+	}
+
+	now := time.Now()
+	tasks, err := uc.Execute(context.Background(), "proj-1", []usecase.CreateTaskInput{
+		{ID: "task-1", ProjectID: "ignored", Title: "計画", Status: domain.StatusTodo, Now: now},
+		{ID: "task-2", Title: "振り返り", Status: domain.StatusTodo, Priority: domain.PriorityLow, Now: now},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != 2 || len(repo.all) != 2 {
+		t.Fatalf("expected 2 tasks to be saved, got %d", len(repo.all))
+	}
+	if tasks[0].ProjectID != "proj-1" || tasks[0].Priority != domain.PriorityHigh || tasks[1].Priority != domain.PriorityLow {
+		t.Errorf("unexpected tasks: %+v, %+v", tasks[0], tasks[1])
+	}
+	if provider.calls != 1 {
+		t.Errorf("expected defaults to be loaded once, got %d calls", provider.calls)
+	}
+	if tx.calls != 1 {
+		t.Errorf("expected saves to run within a transaction, got %d calls", tx.calls)
+	}
+}
+
+func TestCreateTasksUsecase_InvalidTaskSavesNothing(t *testing.T) {
+	repo := &recordingTaskRepo{}
+	tx := &fakeTxManager{}
+	uc := &usecase.CreateTasksUsecase{Create: &usecase.CreateTaskUsecase{Repo: repo}, Tx: tx}
+
+	_, err := uc.Execute(context.Background(), "proj-1", []usecase.CreateTaskInput{
+		{ID: "task-1", Title: "計画", Status: domain.StatusTodo, Priority: domain.PriorityMedium},
+		{ID: "task-2", Title: "", Status: domain.StatusTodo, Priority: domain.PriorityMedium},
+	})
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if len(repo.all) != 0 || tx.calls != 0 {
+		t.Errorf("expected nothing to be saved, got %d saves and %d tx calls", len(repo.all), tx.calls)
+	}
+}
+
+func TestCreateTasksUsecase_TooMany(t *testing.T) {
+	uc := &usecase.CreateTasksUsecase{Create: &usecase.CreateTaskUsecase{Repo: &recordingTaskRepo{}}}
+
+	_, err := uc.Execute(context.Background(), "proj-1", make([]usecase.CreateTaskInput, usecase.MaxBatchTasks+1))
+	if !errors.Is(err, usecase.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+}
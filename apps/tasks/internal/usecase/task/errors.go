@@ -1,9 +1,34 @@
 package task
 
-import "errors"
+import (
+	"errors"
+
+	"teamflow-shared/authz"
+)
 
 // Sentinel errors used by task usecases.
 var (
 	ErrInvalidInput = errors.New("invalid input")
 	ErrTaskNotFound = errors.New("task not found")
+	// ErrAssigneeNotMember は担当者がプロジェクトのメンバーでない場合に返す（ErrInvalidInput でラップする）。
+	ErrAssigneeNotMember = errors.New("assignee is not a project member")
+	// ErrAssigneeNotFound は担当者のユーザーが users サービスに存在しない場合に返す（ErrInvalidInput でラップする）。
+	ErrAssigneeNotFound = errors.New("assignee user not found")
+	// ErrMilestoneNotFound はマイルストーンがプロジェクトに存在しない場合に返す（ErrInvalidInput でラップする）。
+	ErrMilestoneNotFound = errors.New("milestone not found in project")
+	// ErrSprintNotFound はスプリントがプロジェクトに存在しない場合に返す（ErrInvalidInput でラップする）。
+	ErrSprintNotFound = errors.New("sprint not found in project")
+	// ErrEpicNotFound はエピックがプロジェクトに存在しない場合に返す（ErrInvalidInput でラップする）。
+	ErrEpicNotFound = errors.New("epic not found in project")
+	// ErrLabelNotFound はラベルがプロジェクトに定義されていない場合に返す（ErrInvalidInput でラップする）。
+	ErrLabelNotFound = errors.New("label not defined in project")
+	// ErrTimeout はリポジトリへの問い合わせがタイムアウトした場合に返す。
+	ErrTimeout = errors.New("timeout")
+	// ErrActorRequired は非公開プロジェクトの閲覧で操作者が特定できない場合に返す（HTTP 層で 401）。
+	ErrActorRequired = authz.ErrActorRequired
+	// ErrForbidden は操作者が非公開プロジェクトのメンバーでない場合に返す（HTTP 層で 403）。
+	ErrForbidden = authz.ErrForbidden
+	// ErrProjectNotFound はメンバーシップを確認する設定で、操作者がプロジェクトのメンバーでない場合に返す（HTTP 層で 404）。
+	// プロジェクトが存在しない場合と区別しない（非メンバーにプロジェクトの有無を明かさないため）。
+	ErrProjectNotFound = errors.New("project not found")
 )
@@ -0,0 +1,56 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"teamflow-shared/events"
+	"teamflow-shared/outbox"
+	"teamflow-shared/workspace"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// recordEvents は w が設定されていればドメインイベントを outbox に記録する。
+// イベントの配信は任意のため、w が nil の構成（テスト等）では何もしない。
+func recordEvents(ctx context.Context, w outbox.Writer, evs ...events.Event) error {
+	if w == nil || len(evs) == 0 {
+		return nil
+	}
+	return w.Append(ctx, evs...)
+}
+
+// taskEventData はタスク t のイベントの内容を返す。
+func taskEventData(t *domain.Task, actorID string) events.TaskData {
+	data := events.TaskData{
+		ID:        t.ID,
+		ProjectID: t.ProjectID,
+		Number:    t.Number,
+		Title:     t.Title,
+		Status:    string(t.Status),
+		ActorID:   actorID,
+	}
+	if t.AssigneeID != nil {
+		data.AssigneeID = *t.AssigneeID
+	}
+	return data
+}
+
+// createdEvents は作成したタスクの task.created を返す（操作者は CreatedBy）。
+func createdEvents(ctx context.Context, tasks ...*domain.Task) []events.Event {
+	evs := make([]events.Event, len(tasks))
+	for i, t := range tasks {
+		evs[i] = events.NewTaskEvent(events.TaskCreated, workspace.FromContext(ctx), taskEventData(t, t.CreatedBy), t.CreatedAt)
+	}
+	return evs
+}
+
+// idsEvents は一括操作の対象になったタスク ids のイベントを返す（タスクを読み込まないため ID 以外は分からない）。
+func idsEvents(ctx context.Context, typ events.Type, projectID string, ids []string, actorID string, fields []string, now time.Time) []events.Event {
+	evs := make([]events.Event, len(ids))
+	for i, id := range ids {
+		data := events.TaskData{ID: id, ProjectID: projectID, Fields: fields, ActorID: actorID}
+		evs[i] = events.NewTaskEvent(typ, workspace.FromContext(ctx), data, now)
+	}
+	return evs
+}
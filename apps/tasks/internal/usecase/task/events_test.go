@@ -0,0 +1,135 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/events"
+	"teamflow-shared/outbox"
+	"teamflow-shared/workspace"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// failingWriter は outbox への記録に常に失敗する。
+type failingWriter struct{}
+
+func (failingWriter) Append(context.Context, ...events.Event) error {
+	return errors.New("outbox unavailable")
+}
+
+func decodeTask(t *testing.T, e events.Event) events.TaskData {
+	t.Helper()
+	var data events.TaskData
+	if err := e.Decode(&data); err != nil {
+		t.Fatalf("failed to decode %s: %v", e.Type, err)
+	}
+	return data
+}
+
+func TestCreateTask_RecordsEvent(t *testing.T) {
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	store := outbox.NewMemoryStore()
+	tx := &fakeTxManager{}
+	uc := &usecase.CreateTaskUsecase{Repo: &fakeTaskRepo{}, Tx: tx, Events: store}
+	ctx := workspace.NewContext(context.Background(), "acme")
+
+	if _, err := uc.Execute(ctx, usecase.CreateTaskInput{
+		ID: "task-1", ProjectID: "proj-1", Title: "t", Status: domain.StatusTodo, Priority: domain.PriorityMedium,
+		ActorID: "user-1", Now: now,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tx.calls != 1 {
+		t.Errorf("expected the save and the event to share a transaction, got %d calls", tx.calls)
+	}
+
+	evs := store.Events()
+	if len(evs) != 1 {
+		t.Fatalf("expected 1 event, got %+v", evs)
+	}
+	e := evs[0]
+	if e.Type != events.TaskCreated || e.AggregateID != "task-1" || e.ProjectID != "proj-1" || e.WorkspaceID != "acme" || !e.OccurredAt.Equal(now) {
+		t.Errorf("unexpected event %+v", e)
+	}
+	if data := decodeTask(t, e); data.Status != "todo" || data.Title != "t" || data.ActorID != "user-1" {
+		t.Errorf("unexpected data %+v", data)
+	}
+}
+
+func TestCreateTask_EventErrorFailsTheWrite(t *testing.T) {
+	tx := &fakeTxManager{}
+	uc := &usecase.CreateTaskUsecase{Repo: &fakeTaskRepo{}, Tx: tx, Events: failingWriter{}}
+
+	_, err := uc.Execute(context.Background(), usecase.CreateTaskInput{
+		ID: "task-1", ProjectID: "proj-1", Title: "t", Status: domain.StatusTodo, Priority: domain.PriorityMedium,
+		Now: time.Now(),
+	})
+	// ロールバックさせるため、エラーは TxManager に返されること
+	if err == nil || tx.gotErr == nil {
+		t.Fatalf("expected the outbox error to fail the transaction, got %v / %v", err, tx.gotErr)
+	}
+}
+
+func TestUpdateTaskUsecase_RecordsEvent(t *testing.T) {
+	repo := newUpdateTestRepo(t)
+	now := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	store := outbox.NewMemoryStore()
+	uc := &usecase.UpdateTaskUsecase{Repo: repo, Clock: clock.Fixed(now), Events: store}
+
+	for _, in := range []usecase.UpdateTaskInput{
+		{ID: "task-1", Status: domain.Set("done"), ActorID: "user-2"},
+		{ID: "task-1", Title: domain.Set("renamed")},
+		{ID: "task-1", AssigneeID: domain.Set("user-3")},
+	} {
+		if _, err := uc.Execute(context.Background(), in); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	evs := store.Events()
+	if len(evs) != 3 || evs[0].Type != events.TaskUpdated {
+		t.Fatalf("expected 3 task.updated events, got %+v", evs)
+	}
+	if data := decodeTask(t, evs[0]); data.Status != "done" || data.PreviousStatus != "todo" ||
+		strings.Join(data.Fields, ",") != "status" || data.ActorID != "user-2" {
+		t.Errorf("unexpected data %+v", data)
+	}
+	// ステータスを変更しない更新では previousStatus を含めない
+	if data := decodeTask(t, evs[1]); data.Status != "done" || data.PreviousStatus != "" || strings.Join(data.Fields, ",") != "title" || data.AssigneeChanged {
+		t.Errorf("unexpected data %+v", data)
+	}
+	if data := decodeTask(t, evs[2]); data.AssigneeID != "user-3" || !data.AssigneeChanged {
+		t.Errorf("expected the assignee change, got %+v", data)
+	}
+}
+
+func TestCascadeProjectTasks_RecordsEvents(t *testing.T) {
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	repo := &fakeTaskRepo{listOut: []*domain.Task{{ID: "t1", ProjectID: "proj-1"}}}
+	store := outbox.NewMemoryStore()
+	tx := &fakeTxManager{}
+	uc := &usecase.CascadeProjectTasksUsecase{Repo: repo, Tx: tx, Events: store}
+
+	for _, action := range []usecase.CascadeAction{usecase.CascadeArchive, usecase.CascadeDelete} {
+		if _, err := uc.Execute(context.Background(), usecase.CascadeProjectTasksInput{ProjectID: "proj-1", Action: action, Now: now}); err != nil {
+			t.Fatalf("%s: unexpected error: %v", action, err)
+		}
+	}
+	if tx.calls != 2 {
+		t.Errorf("expected each action to run in a transaction, got %d calls", tx.calls)
+	}
+
+	var got []string
+	for _, e := range store.Events() {
+		got = append(got, e.AggregateID+":"+string(e.Type)+":"+strings.Join(decodeTask(t, e).Fields, ","))
+	}
+	if strings.Join(got, " ") != "t1:task.updated:archivedAt t1:task.deleted:" {
+		t.Errorf("unexpected events %v", got)
+	}
+}
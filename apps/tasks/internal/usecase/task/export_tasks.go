@@ -0,0 +1,78 @@
+package task
+
+import (
+	"context"
+	"iter"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// ExportPageSize は ExportTasksUsecase が 1 回の問い合わせで取得するタスクの件数。
+const ExportPageSize = 200
+
+// ExportTasksUsecase はプロジェクトのタスクをすべて書き出すユースケース（大きなプロジェクトのエクスポート用）。
+// 一覧と違って件数の上限は無く、作成日時の昇順に ExportPageSize 件ずつ keyset で取得して 1 件ずつ返す
+// （すべてのタスクをメモリに保持しない）。
+type ExportTasksUsecase struct {
+	Repo TaskRepository
+	// Access が設定されていれば、操作者がプロジェクトを閲覧できるか確認する
+	Access ProjectAccessChecker
+}
+
+type ExportTasksInput struct {
+	ProjectID string
+	// Query は一覧と同じフィルタ（status など）。sort / cursor / limit は使わない。nil の場合はすべてのタスクを返す
+	Query   *domain.TaskQuery
+	ActorID string
+}
+
+// Execute は閲覧権限を確認し、タスクを作成日時の昇順に返すイテレータを返す。
+// イテレータは ctx が取り消された場合と問い合わせに失敗した場合に、エラーを 1 回返して終了する。
+func (uc *ExportTasksUsecase) Execute(ctx context.Context, in ExportTasksInput) (iter.Seq2[*domain.Task, error], error) {
+	if err := checkReadAccess(ctx, uc.Access, in.ProjectID, in.ActorID); err != nil {
+		return nil, err
+	}
+	// in.Query を変更しないよう、フィルタだけを写して keyset（created_at, id の昇順）で ExportPageSize 件ずつ取得する
+	query := &domain.TaskQuery{}
+	if in.Query != nil {
+		*query = *in.Query
+	}
+	query.SortOrders = nil
+	query.Cursor = nil
+	query.Limit = ExportPageSize
+
+	return func(yield func(*domain.Task, error) bool) {
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			page, err := uc.Repo.FindByProjectID(ctx, in.ProjectID, query)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			// FindByProjectID は nextCursor 判定のため limit + 1 件まで返す
+			hasMore := len(page) > query.Limit
+			if hasMore {
+				page = page[:query.Limit]
+			}
+			for _, t := range page {
+				if !yield(t, nil) {
+					return
+				}
+			}
+			if !hasMore {
+				return
+			}
+
+			last := page[len(page)-1]
+			query.Cursor = &domain.TaskCursor{
+				CreatedAt: last.CreatedAt,
+				ID:        last.ID,
+				ProjectID: in.ProjectID,
+			}
+		}
+	}, nil
+}
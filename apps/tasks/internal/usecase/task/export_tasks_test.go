@@ -0,0 +1,63 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+func TestExportTasks(t *testing.T) {
+	repo := &queryRecordingRepo{fakeTaskRepo: &fakeTaskRepo{listOut: []*domain.Task{{ID: "t1", ProjectID: "proj-1"}}}}
+	uc := &usecase.ExportTasksUsecase{
+		Repo:   repo,
+		Access: &fakeAccess{privateProjects: map[string]bool{"proj-1": true}, members: map[string]bool{"user-1": true}},
+	}
+	query, err := domain.NewTaskQuery(domain.WithStatusFilter("todo"), domain.WithSort("-updatedAt"), domain.WithLimit(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := usecase.ExportTasksInput{ProjectID: "proj-1", ActorID: "user-1", Query: query}
+
+	tasks, err := uc.Execute(context.Background(), in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var ids []string
+	for task, err := range tasks {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, task.ID)
+	}
+	if len(ids) != 1 || ids[0] != "t1" {
+		t.Errorf("expected the repository result, got %v", ids)
+	}
+	if q := repo.query; q == query || q.Limit != usecase.ExportPageSize || len(q.Statuses) != 1 || len(q.SortOrders) != 0 {
+		t.Errorf("expected pages of %d filtered tasks in keyset order, got limit %d statuses %v sort %+v", usecase.ExportPageSize, q.Limit, q.Statuses, q.SortOrders)
+	}
+
+	// 取り消された ctx では問い合わせずにエラーを返す
+	repo.query = nil
+	ctx, cancel := context.WithCancel(context.Background())
+	tasks, err = uc.Execute(ctx, in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cancel()
+	for _, err := range tasks {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	}
+	if repo.query != nil {
+		t.Error("expected no query after cancellation")
+	}
+
+	in.ActorID = "user-2"
+	if _, err := uc.Execute(context.Background(), in); !errors.Is(err, usecase.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
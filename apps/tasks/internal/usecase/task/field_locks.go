@@ -0,0 +1,53 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// FieldLockPolicy はプロジェクト設定のフィールドのロック（変更できるロール）と、操作者のロールを取得する。
+// projects サービスの GET /projects/{id}/settings と GET /projects/{id}/members/{userId} を呼ぶクライアントなどで実装する。
+type FieldLockPolicy interface {
+	// FieldLocks はフィールド（PATCH のフィールド名）ごとに変更できるロールを返す。ロックが無い場合は空 map
+	FieldLocks(ctx context.Context, projectID string) (map[string][]string, error)
+	// MemberRole は userID のプロジェクトでのロール（owner / admin / member）を返す。メンバーでない場合は空文字
+	MemberRole(ctx context.Context, projectID, userID string) (string, error)
+}
+
+// checkFieldLocks は policy が設定されていれば、fields のうちロックされたフィールドを actorID が変更できるか確認する。
+// 操作者が無い場合は ErrActorRequired、ロールが許可されていない場合は ErrForbidden でラップした
+// *domain.ValidationError（FIELD_FORBIDDEN）を返す。ロックされたフィールドを含まない場合はロールを問い合わせない。
+func checkFieldLocks(ctx context.Context, policy FieldLockPolicy, projectID, actorID string, fields []string) error {
+	if policy == nil || len(fields) == 0 {
+		return nil
+	}
+	locks, err := policy.FieldLocks(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	var role string
+	var roleLoaded bool
+	for _, field := range fields {
+		allowed, locked := locks[field]
+		if !locked {
+			continue
+		}
+		if actorID == "" {
+			return fmt.Errorf("%w: %s is locked by the project settings", ErrActorRequired, field)
+		}
+		if !roleLoaded {
+			if role, err = policy.MemberRole(ctx, projectID, actorID); err != nil {
+				return err
+			}
+			roleLoaded = true
+		}
+		if role == "" || !slices.Contains(allowed, role) {
+			return fmt.Errorf("%w: %w", ErrForbidden, domain.NewFieldForbidden(field, nil))
+		}
+	}
+	return nil
+}
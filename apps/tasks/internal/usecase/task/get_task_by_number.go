@@ -0,0 +1,28 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// GetTaskByNumberUsecase はプロジェクト内のタスク番号（TFLOW-123 の 123）でタスクを取得するユースケース。
+type GetTaskByNumberUsecase struct {
+	Repo TaskRepository
+	// Access が設定されていれば、操作者がプロジェクトを閲覧できるか確認する
+	Access ProjectAccessChecker
+}
+
+// Execute は projectID のタスク番号 number のタスクを返す。
+// number が 1 未満の場合は ErrInvalidInput、存在しない場合は ErrTaskNotFound を返す。
+// actorID がプロジェクトを閲覧できない場合は ErrActorRequired / ErrForbidden を返す。
+func (uc *GetTaskByNumberUsecase) Execute(ctx context.Context, projectID string, number int, actorID string) (*domain.Task, error) {
+	if number < 1 {
+		return nil, fmt.Errorf("%w: number must be a positive integer", ErrInvalidInput)
+	}
+	if err := checkReadAccess(ctx, uc.Access, projectID, actorID); err != nil {
+		return nil, err
+	}
+	return uc.Repo.FindByNumber(ctx, projectID, number)
+}
@@ -0,0 +1,68 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+func TestGetTaskByNumber(t *testing.T) {
+	repo := &fakeTaskRepo{listOut: []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", Number: 1},
+		{ID: "t2", ProjectID: "proj-1", Number: 2},
+		{ID: "t3", ProjectID: "proj-2", Number: 1},
+	}}
+	uc := &usecase.GetTaskByNumberUsecase{Repo: repo}
+
+	tests := []struct {
+		name      string
+		projectID string
+		number    int
+		wantID    string
+		wantErr   error
+	}{
+		{name: "found", projectID: "proj-1", number: 2, wantID: "t2"},
+		{name: "same number in other project", projectID: "proj-2", number: 1, wantID: "t3"},
+		{name: "not found", projectID: "proj-1", number: 3, wantErr: usecase.ErrTaskNotFound},
+		{name: "zero", projectID: "proj-1", number: 0, wantErr: usecase.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.Execute(context.Background(), tt.projectID, tt.number, "")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ID != tt.wantID {
+				t.Errorf("expected %s, got %s", tt.wantID, got.ID)
+			}
+		})
+	}
+}
+
+func TestGetTaskByNumber_Access(t *testing.T) {
+	repo := &fakeTaskRepo{listOut: []*domain.Task{{ID: "t1", ProjectID: "proj-1", Number: 1}}}
+	uc := &usecase.GetTaskByNumberUsecase{
+		Repo:   repo,
+		Access: &fakeAccess{privateProjects: map[string]bool{"proj-1": true}, members: map[string]bool{"user-1": true}},
+	}
+
+	if got, err := uc.Execute(context.Background(), "proj-1", 1, "user-1"); err != nil || got.ID != "t1" {
+		t.Fatalf("expected t1, got %v (err=%v)", got, err)
+	}
+	if _, err := uc.Execute(context.Background(), "proj-1", 1, "user-2"); !errors.Is(err, usecase.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+	if _, err := uc.Execute(context.Background(), "proj-1", 1, ""); !errors.Is(err, usecase.ErrActorRequired) {
+		t.Errorf("expected ErrActorRequired, got %v", err)
+	}
+}
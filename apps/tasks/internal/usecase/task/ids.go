@@ -0,0 +1,19 @@
+package task
+
+import (
+	"fmt"
+
+	"teamflow-shared/uuidpolicy"
+)
+
+// checkUUID は policy が設定されていれば、field（id・assigneeId）の値が policy で受け付ける UUID か確認する。
+// 空の値（省略して自動生成・既定値に任せるもの）は確認しない。受け付けない場合は ErrInvalidInput でラップしたエラーを返す。
+func checkUUID(policy uuidpolicy.Policy, field, value string) error {
+	if policy == "" || value == "" {
+		return nil
+	}
+	if err := policy.Validate(value); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidInput, field, err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"teamflow-shared/authz"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// InspectCursorUsecase は運用者がページネーションの不具合を調査するための、一覧の cursor の調査ユースケース。
+// cursor の中身は信頼せずに表示するだけで、タスクは取得しない。
+type InspectCursorUsecase struct {
+	// Operators は調査できる運用者（ADMIN_USER_IDS）。空の場合は誰も調査できない
+	Operators authz.Operators
+	// Secret は一覧の cursor の署名に使う CURSOR_SECRET
+	Secret []byte
+}
+
+type InspectCursorInput struct {
+	Cursor string
+	// ProjectID は cursor を使ったプロジェクト。任意。空の場合はクエリとの比較をしない
+	ProjectID string
+	// Query は cursor を使った一覧のクエリ条件（ProjectID を指定した場合に比較する）
+	Query   *domain.TaskQuery
+	ActorID string
+	Now     time.Time
+}
+
+type InspectCursorOutput struct {
+	Cursor *domain.CursorInspection
+	// Query はクエリとの比較結果。ProjectID を指定しなかった場合は nil
+	Query *domain.CursorQueryMatch
+}
+
+// Execute は操作者が運用者であることを確認し、cursor の payload・署名・有効期限と、指定したクエリとの一致を返す。
+// cursor の形式が不正な場合は domain.ErrCursorInvalidFormat を返す。
+func (uc *InspectCursorUsecase) Execute(_ context.Context, in InspectCursorInput) (*InspectCursorOutput, error) {
+	if err := uc.Operators.Authorize(in.ActorID); err != nil {
+		return nil, err
+	}
+	inspection, err := domain.InspectCursor(in.Cursor, uc.Secret, in.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &InspectCursorOutput{Cursor: inspection}
+	if in.ProjectID != "" && in.Query != nil {
+		m := in.Query.MatchCursor(inspection.Payload, in.ProjectID)
+		out.Query = &m
+	}
+	return out, nil
+}
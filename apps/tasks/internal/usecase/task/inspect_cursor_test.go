@@ -0,0 +1,66 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"teamflow-shared/authz"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+func TestInspectCursor(t *testing.T) {
+	secret := []byte("test-secret")
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	query, err := domain.NewTaskQuery(domain.WithWorkspace("default"), domain.WithStatusFilter("todo"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cursor, err := domain.EncodeCursor(domain.CursorPayload{
+		V: 1, ID: "task-1", ProjectID: "proj-1", QHash: query.ComputeQHash("proj-1"), QV: domain.QHashVersion, IssuedAt: now.Unix(),
+	}, secret)
+	if err != nil {
+		t.Fatalf("failed to encode cursor: %v", err)
+	}
+	uc := &usecase.InspectCursorUsecase{Operators: authz.ParseOperators("ops-1"), Secret: secret}
+
+	out, err := uc.Execute(context.Background(), usecase.InspectCursorInput{Cursor: cursor, ProjectID: "proj-1", Query: query, ActorID: "ops-1", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Cursor.Payload.ID != "task-1" || !out.Cursor.SignatureValid || out.Cursor.Expired {
+		t.Errorf("unexpected inspection: %+v", out.Cursor)
+	}
+	if out.Query == nil || !out.Query.Matches() {
+		t.Errorf("expected the query to match, got %+v", out.Query)
+	}
+
+	// プロジェクトを指定しない場合はクエリと比較しない
+	out, err = uc.Execute(context.Background(), usecase.InspectCursorInput{Cursor: cursor, ActorID: "ops-1", Now: now})
+	if err != nil || out.Query != nil {
+		t.Errorf("expected no query comparison, got %+v, %v", out, err)
+	}
+}
+
+func TestInspectCursor_Errors(t *testing.T) {
+	uc := &usecase.InspectCursorUsecase{Operators: authz.ParseOperators("ops-1"), Secret: []byte("s")}
+	for _, tt := range []struct {
+		name  string
+		actor string
+		want  error
+	}{
+		{name: "no actor", want: usecase.ErrActorRequired},
+		{name: "not an operator", actor: "user-1", want: usecase.ErrForbidden},
+		{name: "invalid cursor", actor: "ops-1", want: domain.ErrCursorInvalidFormat},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.Execute(context.Background(), usecase.InspectCursorInput{Cursor: "not-a-cursor", ActorID: tt.actor, Now: time.Now()})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -9,22 +9,37 @@ import (
 // ListTasksByProjectUsecase は projectID ごとのタスク一覧取得ユースケース。
 type ListTasksByProjectUsecase struct {
 	Repo TaskRepository
+	// Access が設定されていれば、操作者がプロジェクトを閲覧できるか確認する
+	Access ProjectAccessChecker
+	// Users は担当者名の取得（AssigneeNames）に使う。任意。nil の場合は名前を返さない
+	Users UserDirectory
 }
 
 type ListTasksByProjectInput struct {
 	ProjectID  string
 	Status     string
 	AssigneeID string
+	ActorID    string // 操作者（Access が設定されている場合に閲覧権限を確認する）
 	// 後方互換性のため残す。Queryが指定されていない場合はこちらを使用
 }
 
 type ListTasksByProjectWithQueryInput struct {
 	ProjectID string
 	Query     *domain.TaskQuery
+	ActorID   string // 操作者（Access が設定されている場合に閲覧権限を確認する）
+}
+
+// ProjectVersionInput はプロジェクトのタスク一覧のバージョン取得の入力。
+type ProjectVersionInput struct {
+	ProjectID string
+	ActorID   string // 操作者（Access が設定されている場合に閲覧権限を確認する）
 }
 
 // Execute は既存のAPI向け（後方互換性のため残す）。
 func (uc *ListTasksByProjectUsecase) Execute(ctx context.Context, in ListTasksByProjectInput) ([]*domain.Task, error) {
+	if err := checkReadAccess(ctx, uc.Access, in.ProjectID, in.ActorID); err != nil {
+		return nil, err
+	}
 	tasks, err := uc.Repo.ListByProject(ctx, in.ProjectID)
 	if err != nil {
 		return nil, err
@@ -36,7 +51,11 @@ func (uc *ListTasksByProjectUsecase) Execute(ctx context.Context, in ListTasksBy
 }
 
 // ExecuteWithQuery はQuery Objectを受け取り、フィルタ/ソート/リミットを適用する。
+// Access が設定されていれば、先に操作者がプロジェクトを閲覧できるか確認する。
 func (uc *ListTasksByProjectUsecase) ExecuteWithQuery(ctx context.Context, in ListTasksByProjectWithQueryInput) ([]*domain.Task, error) {
+	if err := checkReadAccess(ctx, uc.Access, in.ProjectID, in.ActorID); err != nil {
+		return nil, err
+	}
 	if in.Query == nil {
 		// Queryがnilの場合は空のQueryを作成（全件取得、デフォルトソート）
 		var err error
@@ -53,3 +72,12 @@ func (uc *ListTasksByProjectUsecase) ExecuteWithQuery(ctx context.Context, in Li
 
 	return tasks, nil
 }
+
+// ProjectVersion はプロジェクトのタスク一覧のバージョンを返す（一覧の ETag に使う）。
+// 一覧を取得せずに変更の有無を判定できるよう、ExecuteWithQuery と同じ閲覧権限の確認だけを行う。
+func (uc *ListTasksByProjectUsecase) ProjectVersion(ctx context.Context, in ProjectVersionInput) (domain.ProjectVersion, error) {
+	if err := checkReadAccess(ctx, uc.Access, in.ProjectID, in.ActorID); err != nil {
+		return domain.ProjectVersion{}, err
+	}
+	return uc.Repo.ProjectVersion(ctx, in.ProjectID)
+}
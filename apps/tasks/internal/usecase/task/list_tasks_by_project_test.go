@@ -25,6 +25,9 @@ func (r *listRepo) FindByID(_ context.Context, id string) (*domain.Task, error)
 	}
 	return nil, errors.New("not found")
 }
+func (r *listRepo) FindByNumber(context.Context, string, int) (*domain.Task, error) {
+	return nil, usecase.ErrTaskNotFound
+}
 func (r *listRepo) ListByProject(context.Context, string) ([]*domain.Task, error) {
 	// memory repositoryと同様にcreatedAt ASCでソート
 	result := make([]*domain.Task, len(r.out))
@@ -40,6 +43,25 @@ func (r *listRepo) FindByProjectID(context.Context, string, *domain.TaskQuery) (
 	return r.out, nil
 }
 
+func (r *listRepo) ProjectVersion(context.Context, string) (domain.ProjectVersion, error) {
+	v := domain.ProjectVersion{Count: len(r.out)}
+	for _, t := range r.out {
+		if t.UpdatedAt.After(v.UpdatedAt) {
+			v.UpdatedAt = t.UpdatedAt
+		}
+	}
+	return v, nil
+}
+
+func (r *listRepo) ArchiveByProject(context.Context, string, time.Time) ([]string, error) {
+	return nil, nil
+}
+func (r *listRepo) UnarchiveByProject(context.Context, string) ([]string, error) { return nil, nil }
+func (r *listRepo) DeleteByProject(context.Context, string) ([]string, error)    { return nil, nil }
+func (r *listRepo) MoveIncompleteSprintTasks(context.Context, string, string, *string, string, time.Time) ([]string, error) {
+	return nil, nil
+}
+
 func TestListTasksByProject_Success(t *testing.T) {
 	now := time.Now()
 
@@ -87,3 +109,66 @@ func TestListTasksByProject_Success(t *testing.T) {
 		t.Fatalf("tasks are not sorted by CreatedAt ascending: %v then %v", got[0].CreatedAt, got[1].CreatedAt)
 	}
 }
+
+// fakeAccess は privateProjects のプロジェクトを members だけが閲覧できるとみなす ProjectAccessChecker。
+type fakeAccess struct {
+	privateProjects map[string]bool
+	members         map[string]bool
+}
+
+func (a *fakeAccess) AuthorizeRead(_ context.Context, projectID, actorID string) error {
+	if !a.privateProjects[projectID] {
+		return nil
+	}
+	if actorID == "" {
+		return usecase.ErrActorRequired
+	}
+	if !a.members[actorID] {
+		return usecase.ErrForbidden
+	}
+	return nil
+}
+
+func TestListTasksByProject_Access(t *testing.T) {
+	uc := &usecase.ListTasksByProjectUsecase{
+		Repo:   &listRepo{},
+		Access: &fakeAccess{privateProjects: map[string]bool{"proj-1": true}, members: map[string]bool{"user-1": true}},
+	}
+
+	tests := []struct {
+		name      string
+		projectID string
+		actorID   string
+		wantErr   error
+	}{
+		{name: "member", projectID: "proj-1", actorID: "user-1"},
+		{name: "non-member", projectID: "proj-1", actorID: "user-2", wantErr: usecase.ErrForbidden},
+		{name: "anonymous", projectID: "proj-1", wantErr: usecase.ErrActorRequired},
+		{name: "public project", projectID: "proj-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.ExecuteWithQuery(context.Background(), usecase.ListTasksByProjectWithQueryInput{
+				ProjectID: tt.projectID,
+				ActorID:   tt.actorID,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			_, err = uc.Execute(context.Background(), usecase.ListTasksByProjectInput{
+				ProjectID: tt.projectID,
+				ActorID:   tt.actorID,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute: expected %v, got %v", tt.wantErr, err)
+			}
+			_, err = uc.ProjectVersion(context.Background(), usecase.ProjectVersionInput{
+				ProjectID: tt.projectID,
+				ActorID:   tt.actorID,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ProjectVersion: expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package task
+
+import (
+	"context"
+	"fmt"
+)
+
+// MembershipChecker はユーザーがプロジェクトのメンバーかどうかを判定する。
+// projects サービスの GET /projects/{id}/members/{userId} を呼ぶクライアントなどで実装する。
+type MembershipChecker interface {
+	IsMember(ctx context.Context, projectID, userID string) (bool, error)
+}
+
+// ProjectAccessChecker は操作者がプロジェクトを閲覧できるかどうかを判定する。
+// projects サービスの GET /projects/{id} を操作者を引き継いで呼ぶクライアントなどで実装する。
+// 閲覧できない場合は ErrActorRequired または ErrForbidden を返す。
+type ProjectAccessChecker interface {
+	AuthorizeRead(ctx context.Context, projectID, actorID string) error
+}
+
+// ProjectWriteAuthorizer は操作者がプロジェクトのタスクを作成・変更できるかどうかを判定する。
+// 変更できない場合は ErrActorRequired または ErrProjectNotFound を返す。
+type ProjectWriteAuthorizer interface {
+	AuthorizeWrite(ctx context.Context, projectID, actorID string) error
+}
+
+// MembershipPolicy は操作者がプロジェクトのメンバーであることを閲覧・変更の条件にする。
+// ProjectAccessChecker と ProjectWriteAuthorizer を実装する。
+//
+// 操作者が無い場合は ErrActorRequired、メンバーでない場合は ErrProjectNotFound を返す。
+// 公開・非公開やプロジェクトの有無によらず同じエラーにし、非メンバーにプロジェクトの存在を明かさない。
+type MembershipPolicy struct {
+	Members MembershipChecker
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var (
+	_ ProjectAccessChecker   = (*MembershipPolicy)(nil)
+	_ ProjectWriteAuthorizer = (*MembershipPolicy)(nil)
+)
+
+// AuthorizeRead は actorID がプロジェクトのメンバーであれば nil を返す。
+func (p *MembershipPolicy) AuthorizeRead(ctx context.Context, projectID, actorID string) error {
+	return p.authorize(ctx, projectID, actorID)
+}
+
+// AuthorizeWrite は actorID がプロジェクトのメンバーであれば nil を返す。
+func (p *MembershipPolicy) AuthorizeWrite(ctx context.Context, projectID, actorID string) error {
+	return p.authorize(ctx, projectID, actorID)
+}
+
+func (p *MembershipPolicy) authorize(ctx context.Context, projectID, actorID string) error {
+	if actorID == "" {
+		return ErrActorRequired
+	}
+	ok, err := p.Members.IsMember(ctx, projectID, actorID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrProjectNotFound, projectID)
+	}
+	return nil
+}
+
+// checkWriteAccess は authorizer が設定されていれば、actorID が projectID のプロジェクトのタスクを変更できるか確認する。
+func checkWriteAccess(ctx context.Context, authorizer ProjectWriteAuthorizer, projectID, actorID string) error {
+	if authorizer == nil {
+		return nil
+	}
+	return authorizer.AuthorizeWrite(ctx, projectID, actorID)
+}
+
+// checkReadAccess は checker が設定されていれば、actorID が projectID のプロジェクトを閲覧できるか確認する。
+func checkReadAccess(ctx context.Context, checker ProjectAccessChecker, projectID, actorID string) error {
+	if checker == nil {
+		return nil
+	}
+	return checker.AuthorizeRead(ctx, projectID, actorID)
+}
+
+// MilestoneChecker はマイルストーンがプロジェクトに存在するかどうかを判定する。
+// projects サービスの GET /projects/{id}/milestones/{milestoneId} を呼ぶクライアントなどで実装する。
+type MilestoneChecker interface {
+	MilestoneExists(ctx context.Context, projectID, milestoneID string) (bool, error)
+}
+
+// checkMilestone は checker が設定されていれば、マイルストーンがプロジェクトに存在するか確認する。
+// 存在しない場合は ErrInvalidInput でラップした ErrMilestoneNotFound を返す。
+func checkMilestone(ctx context.Context, checker MilestoneChecker, projectID, milestoneID string) error {
+	if checker == nil {
+		return nil
+	}
+	ok, err := checker.MilestoneExists(ctx, projectID, milestoneID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: %w", ErrInvalidInput, ErrMilestoneNotFound)
+	}
+	return nil
+}
+
+// SprintChecker はスプリントがプロジェクトに存在するかどうかを判定する。
+// projects サービスの GET /projects/{id}/sprints/{sprintId} を呼ぶクライアントなどで実装する。
+type SprintChecker interface {
+	SprintExists(ctx context.Context, projectID, sprintID string) (bool, error)
+}
+
+// checkSprint は checker が設定されていれば、スプリントがプロジェクトに存在するか確認する。
+// 存在しない場合は ErrInvalidInput でラップした ErrSprintNotFound を返す。
+func checkSprint(ctx context.Context, checker SprintChecker, projectID, sprintID string) error {
+	if checker == nil {
+		return nil
+	}
+	ok, err := checker.SprintExists(ctx, projectID, sprintID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: %w", ErrInvalidInput, ErrSprintNotFound)
+	}
+	return nil
+}
+
+// EpicChecker はエピックがプロジェクトに存在するかどうかを判定する。
+// projects サービスの GET /projects/{id}/epics/{epicId} を呼ぶクライアントなどで実装する。
+type EpicChecker interface {
+	EpicExists(ctx context.Context, projectID, epicID string) (bool, error)
+}
+
+// checkEpic は checker が設定されていれば、エピックがプロジェクトに存在するか確認する。
+// 存在しない場合は ErrInvalidInput でラップした ErrEpicNotFound を返す。
+func checkEpic(ctx context.Context, checker EpicChecker, projectID, epicID string) error {
+	if checker == nil {
+		return nil
+	}
+	ok, err := checker.EpicExists(ctx, projectID, epicID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: %w", ErrInvalidInput, ErrEpicNotFound)
+	}
+	return nil
+}
+
+// LabelChecker はラベルがプロジェクトに定義されているかどうかを判定する。
+// projects サービスの GET /projects/{id}/labels/{labelId} を呼ぶクライアントなどで実装する。
+type LabelChecker interface {
+	LabelExists(ctx context.Context, projectID, labelID string) (bool, error)
+}
+
+// checkLabels は checker が設定されていれば、labelIDs がすべてプロジェクトに定義されているか確認する。
+// 空の ID はドメインの検証に任せ、重複した ID は 1 回だけ確認する。
+// 定義されていないラベルがある場合は ErrInvalidInput でラップした ErrLabelNotFound を返す。
+func checkLabels(ctx context.Context, checker LabelChecker, projectID string, labelIDs []string) error {
+	if checker == nil {
+		return nil
+	}
+	checked := make(map[string]bool, len(labelIDs))
+	for _, id := range labelIDs {
+		if id == "" || checked[id] {
+			continue
+		}
+		checked[id] = true
+		ok, err := checker.LabelExists(ctx, projectID, id)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%w: %w: %s", ErrInvalidInput, ErrLabelNotFound, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+func TestMembershipPolicy(t *testing.T) {
+	policy := &usecase.MembershipPolicy{
+		Members: &fakeMembershipChecker{members: map[string]bool{"proj-1/user-1": true}},
+	}
+
+	tests := []struct {
+		name      string
+		projectID string
+		actorID   string
+		wantErr   error
+	}{
+		{name: "member", projectID: "proj-1", actorID: "user-1"},
+		{name: "no actor", projectID: "proj-1", actorID: "", wantErr: usecase.ErrActorRequired},
+		{name: "not a member", projectID: "proj-1", actorID: "user-2", wantErr: usecase.ErrProjectNotFound},
+		{name: "missing project is indistinguishable", projectID: "missing", actorID: "user-1", wantErr: usecase.ErrProjectNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			for op, authorize := range map[string]func(context.Context, string, string) error{
+				"read":  policy.AuthorizeRead,
+				"write": policy.AuthorizeWrite,
+			} {
+				err := authorize(ctx, tt.projectID, tt.actorID)
+				if tt.wantErr == nil && err != nil {
+					t.Errorf("%s: unexpected error: %v", op, err)
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("%s: expected %v, got %v", op, tt.wantErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestMembershipPolicy_CheckerError(t *testing.T) {
+	wantErr := errors.New("projects service unavailable")
+	policy := &usecase.MembershipPolicy{Members: failingMembershipChecker{err: wantErr}}
+
+	err := policy.AuthorizeRead(context.Background(), "proj-1", "user-1")
+	if !errors.Is(err, wantErr) || errors.Is(err, usecase.ErrProjectNotFound) {
+		t.Fatalf("expected the checker error as is, got %v", err)
+	}
+}
+
+// failingMembershipChecker は常に err を返す MembershipChecker。
+type failingMembershipChecker struct {
+	err error
+}
+
+func (c failingMembershipChecker) IsMember(context.Context, string, string) (bool, error) {
+	return false, c.err
+}
@@ -0,0 +1,28 @@
+package task
+
+import (
+	"context"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// AssignmentNotifier はタスクの担当者になったユーザーへの通知を担当する抽象。
+type AssignmentNotifier interface {
+	// NotifyAssigned は t の担当者（t.AssigneeID）に、actorID の操作で担当者になったことを通知する。
+	// 保存（コミット）した後に呼ぶ。通知の失敗でタスクの操作を失敗にしないため、エラーは返さない（実装がログに記録する）。
+	NotifyAssigned(ctx context.Context, t *domain.Task, actorID string)
+}
+
+// notifyAssigned は tasks の担当者に通知する。n が nil の場合は何もしない。
+// 担当者がいないタスクと、操作者が自分を担当者にしたタスクは通知しない。
+func notifyAssigned(ctx context.Context, n AssignmentNotifier, actorID string, tasks ...*domain.Task) {
+	if n == nil {
+		return
+	}
+	for _, t := range tasks {
+		if t.AssigneeID == nil || *t.AssigneeID == actorID {
+			continue
+		}
+		n.NotifyAssigned(ctx, t, actorID)
+	}
+}
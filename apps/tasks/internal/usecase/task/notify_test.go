@@ -0,0 +1,92 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// recordingNotifier は AssignmentNotifier のテスト用フェイク実装（通知したタスクの ID と担当者を記録する）。
+type recordingNotifier struct {
+	notified []string
+}
+
+func (n *recordingNotifier) NotifyAssigned(_ context.Context, t *domain.Task, actorID string) {
+	n.notified = append(n.notified, t.ID+":"+*t.AssigneeID+":"+actorID)
+}
+
+func TestCreateTaskUsecase_NotifiesAssignee(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		name     string
+		assignee string
+		actor    string
+		want     int
+	}{
+		{name: "assigned by another user", assignee: "user-2", actor: "user-1", want: 1},
+		{name: "self assignment", assignee: "user-1", actor: "user-1", want: 0},
+		{name: "no assignee", actor: "user-1", want: 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &recordingNotifier{}
+			uc := &usecase.CreateTaskUsecase{Repo: &fakeTaskRepo{}, Notifier: n}
+			_, err := uc.Execute(context.Background(), usecase.CreateTaskInput{
+				ID: "task-1", ProjectID: "proj-1", Title: "title", Status: domain.StatusTodo, Priority: domain.PriorityMedium,
+				AssigneeID: tt.assignee, ActorID: tt.actor, Now: now,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(n.notified) != tt.want {
+				t.Errorf("expected %d notifications, got %v", tt.want, n.notified)
+			}
+		})
+	}
+}
+
+func TestUpdateTaskUsecase_NotifiesNewAssignee(t *testing.T) {
+	repo := newUpdateTestRepo(t)
+	n := &recordingNotifier{}
+	uc := &usecase.UpdateTaskUsecase{Repo: repo, Notifier: n}
+	ctx := context.Background()
+
+	if _, err := uc.Execute(ctx, usecase.UpdateTaskInput{ID: "task-1", AssigneeID: domain.Set("user-2"), ActorID: "user-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 担当者が変わらない更新は通知しない
+	if _, err := uc.Execute(ctx, usecase.UpdateTaskInput{ID: "task-1", AssigneeID: domain.Set("user-2"), Title: domain.Set("new"), ActorID: "user-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.Execute(ctx, usecase.UpdateTaskInput{ID: "task-1", Title: domain.Set("newer"), ActorID: "user-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(n.notified) != 1 || n.notified[0] != "task-1:user-2:user-1" {
+		t.Errorf("unexpected notifications: %v", n.notified)
+	}
+
+	// ロールバックした更新は通知しない
+	tx := &failingTxManager{err: errors.New("commit failed")}
+	uc.Tx = tx
+	if _, err := uc.Execute(ctx, usecase.UpdateTaskInput{ID: "task-1", AssigneeID: domain.Set("user-3"), ActorID: "user-1"}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(n.notified) != 1 {
+		t.Errorf("expected no notification for a failed update, got %v", n.notified)
+	}
+}
+
+// failingTxManager は fn を実行した後にコミットに失敗する TxManager。
+type failingTxManager struct {
+	err error
+}
+
+func (m *failingTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	return m.err
+}
@@ -0,0 +1,19 @@
+package task
+
+import (
+	"context"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// ProjectDefaults は新規タスクに適用するプロジェクトの既定値。空文字は未設定を表す。
+type ProjectDefaults struct {
+	Priority   domain.TaskPriority
+	AssigneeID string
+}
+
+// ProjectDefaultsProvider はプロジェクト設定から新規タスクの既定値を取得する。
+// projects サービスの GET /projects/{id}/settings を呼ぶクライアントなどで実装する。
+type ProjectDefaultsProvider interface {
+	ProjectDefaults(ctx context.Context, projectID string) (ProjectDefaults, error)
+}
@@ -0,0 +1,95 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// GetProjectStatsUsecase はプロジェクトのタスクを集計するユースケース。
+// projects サービスのプロジェクトカード（GET /projects/{id}/stats）から呼ばれる。
+type GetProjectStatsUsecase struct {
+	Repo TaskRepository
+	// Timezones は期限切れの判定に使うプロジェクトのタイムゾーンの取得に使う。任意。nil の場合は UTC で判定する
+	Timezones ProjectTimezoneProvider
+}
+
+// Execute は projectID のタスクを now 時点で集計する。期限切れはプロジェクトのタイムゾーンでの日付で判定する。
+// タスクが無いプロジェクトはすべて 0 を返す。
+func (uc *GetProjectStatsUsecase) Execute(ctx context.Context, projectID string, now time.Time) (domain.ProjectStats, error) {
+	loc, err := ProjectLocation(ctx, uc.Timezones, projectID)
+	if err != nil {
+		return domain.ProjectStats{}, err
+	}
+	tasks, err := uc.Repo.ListByProject(ctx, projectID)
+	if err != nil {
+		return domain.ProjectStats{}, err
+	}
+	return domain.ComputeProjectStats(tasks, now.In(loc)), nil
+}
+
+// ExecuteByMilestone は projectID のタスクをマイルストーンごとに集計する。
+// projects サービスのマイルストーンの進捗（GET /projects/{id}/milestones:progress）から呼ばれる。
+// タスクが 1 件も無いマイルストーンは含まない。
+func (uc *GetProjectStatsUsecase) ExecuteByMilestone(ctx context.Context, projectID string) ([]domain.MilestoneStats, error) {
+	tasks, err := uc.Repo.ListByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.ComputeMilestoneStats(tasks), nil
+}
+
+// ExecuteByEpic は projectID のタスクをエピックごとに集計する（件数と見積もりの合計）。
+// projects サービスのエピックの進捗（GET /projects/{id}/epics?expand=progress）から呼ばれる。
+// タスクが 1 件も無いエピックは含まない。
+func (uc *GetProjectStatsUsecase) ExecuteByEpic(ctx context.Context, projectID string) ([]domain.EpicStats, error) {
+	tasks, err := uc.Repo.ListByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.ComputeEpicStats(tasks), nil
+}
+
+// ExecuteByLabel は projectID のタスクをラベルごとに数える。
+// projects サービスのラベル一覧（GET /projects/{id}/labels）の使用数から呼ばれる。
+// どのタスクにも付いていないラベルは含まない。
+func (uc *GetProjectStatsUsecase) ExecuteByLabel(ctx context.Context, projectID string) ([]domain.LabelStats, error) {
+	tasks, err := uc.Repo.ListByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.ComputeLabelStats(tasks), nil
+}
+
+// MaxBatchStatsProjects は一度に集計できるプロジェクトの最大数（projects サービスの一覧の最大件数）。
+const MaxBatchStatsProjects = 200
+
+// ExecuteBatch は projectIDs のプロジェクトごとのタスクを now 時点で集計する。
+// projects サービスのプロジェクト一覧（expand=taskCounts）から 1 回の呼び出しで取得するために使う。
+// 重複した ID は 1 つにまとめ、タスクが無いプロジェクトはすべて 0 を返す。
+func (uc *GetProjectStatsUsecase) ExecuteBatch(ctx context.Context, projectIDs []string, now time.Time) (map[string]domain.ProjectStats, error) {
+	if len(projectIDs) == 0 {
+		return nil, fmt.Errorf("%w: projectIds is required", ErrInvalidInput)
+	}
+	if len(projectIDs) > MaxBatchStatsProjects {
+		return nil, fmt.Errorf("%w: projectIds must not exceed %d", ErrInvalidInput, MaxBatchStatsProjects)
+	}
+
+	stats := make(map[string]domain.ProjectStats, len(projectIDs))
+	for _, id := range projectIDs {
+		if id == "" {
+			return nil, fmt.Errorf("%w: projectIds must not contain empty values", ErrInvalidInput)
+		}
+		if _, ok := stats[id]; ok {
+			continue
+		}
+		s, err := uc.Execute(ctx, id, now)
+		if err != nil {
+			return nil, err
+		}
+		stats[id] = s
+	}
+	return stats, nil
+}
@@ -0,0 +1,161 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+func TestGetProjectStats(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	yesterday := now.Add(-24 * time.Hour)
+	repo := &listRepo{out: []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", Status: domain.StatusTodo, DueDate: &yesterday, UpdatedAt: yesterday},
+		{ID: "t2", ProjectID: "proj-1", Status: domain.StatusDone, UpdatedAt: now},
+	}}
+	uc := &usecase.GetProjectStatsUsecase{Repo: repo}
+
+	stats, err := uc.Execute(context.Background(), "proj-1", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Open != 1 || stats.Done != 1 || stats.Overdue != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if stats.LastActivityAt == nil || !stats.LastActivityAt.Equal(now) {
+		t.Errorf("expected lastActivityAt=%v, got %v", now, stats.LastActivityAt)
+	}
+}
+
+// fixedTimezone は ProjectTimezoneProvider のテスト用フェイク実装。すべてのプロジェクトで loc を返す。
+type fixedTimezone struct {
+	loc *time.Location
+	err error
+}
+
+func (f fixedTimezone) ProjectLocation(context.Context, string) (*time.Location, error) {
+	return f.loc, f.err
+}
+
+func TestGetProjectStats_ProjectTimezone(t *testing.T) {
+	// UTC では 5/10 の夜、JST では 5/11
+	now := time.Date(2024, 5, 10, 20, 0, 0, 0, time.UTC)
+	due := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	repo := &listRepo{out: []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", Status: domain.StatusTodo, DueDate: &due, UpdatedAt: due},
+	}}
+
+	uc := &usecase.GetProjectStatsUsecase{Repo: repo}
+	if stats, err := uc.Execute(context.Background(), "proj-1", now); err != nil || stats.Overdue != 0 {
+		t.Errorf("expected the task due today in UTC not to be overdue, got %+v, %v", stats, err)
+	}
+
+	uc.Timezones = fixedTimezone{loc: time.FixedZone("JST", 9*60*60)}
+	if stats, err := uc.Execute(context.Background(), "proj-1", now); err != nil || stats.Overdue != 1 {
+		t.Errorf("expected the task to be overdue in JST, got %+v, %v", stats, err)
+	}
+
+	uc.Timezones = fixedTimezone{err: errors.New("projects unavailable")}
+	if _, err := uc.Execute(context.Background(), "proj-1", now); err == nil {
+		t.Error("expected the timezone lookup error")
+	}
+}
+
+func TestGetProjectStats_ExecuteByMilestone(t *testing.T) {
+	m1 := "m-1"
+	repo := &listRepo{out: []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", Status: domain.StatusTodo, MilestoneID: &m1},
+		{ID: "t2", ProjectID: "proj-1", Status: domain.StatusDone, MilestoneID: &m1},
+		{ID: "t3", ProjectID: "proj-1", Status: domain.StatusDone},
+	}}
+	uc := &usecase.GetProjectStatsUsecase{Repo: repo}
+
+	stats, err := uc.ExecuteByMilestone(context.Background(), "proj-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stats) != 1 || stats[0] != (domain.MilestoneStats{MilestoneID: "m-1", Open: 1, Done: 1}) {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestGetProjectStats_ExecuteByEpic(t *testing.T) {
+	e1 := "e-1"
+	two := 2
+	repo := &listRepo{out: []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", Status: domain.StatusTodo, EpicID: &e1, Estimate: &two},
+		{ID: "t2", ProjectID: "proj-1", Status: domain.StatusDone, EpicID: &e1, Estimate: &two},
+		{ID: "t3", ProjectID: "proj-1", Status: domain.StatusDone},
+	}}
+	uc := &usecase.GetProjectStatsUsecase{Repo: repo}
+
+	stats, err := uc.ExecuteByEpic(context.Background(), "proj-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := domain.EpicStats{EpicID: "e-1", Total: 2, Done: 1, EstimateTotal: 4, EstimateDone: 2}
+	if len(stats) != 1 || stats[0] != want {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestGetProjectStats_ExecuteByLabel(t *testing.T) {
+	repo := &listRepo{out: []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", Status: domain.StatusTodo, LabelIDs: []string{"l-1", "l-2"}},
+		{ID: "t2", ProjectID: "proj-1", Status: domain.StatusDone, LabelIDs: []string{"l-1"}},
+		{ID: "t3", ProjectID: "proj-1", Status: domain.StatusDone},
+	}}
+	uc := &usecase.GetProjectStatsUsecase{Repo: repo}
+
+	stats, err := uc.ExecuteByLabel(context.Background(), "proj-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []domain.LabelStats{{LabelID: "l-1", Count: 2}, {LabelID: "l-2", Count: 1}}
+	if len(stats) != len(want) || stats[0] != want[0] || stats[1] != want[1] {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestGetProjectStats_ExecuteBatch(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	repo := &listRepo{out: []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", Status: domain.StatusTodo, UpdatedAt: now},
+		{ID: "t2", ProjectID: "proj-1", Status: domain.StatusDone, UpdatedAt: now},
+	}}
+	uc := &usecase.GetProjectStatsUsecase{Repo: repo}
+
+	stats, err := uc.ExecuteBatch(context.Background(), []string{"proj-1", "proj-2", "proj-1"}, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("expected stats for 2 projects, got %+v", stats)
+	}
+	if s := stats["proj-1"]; s.Open != 1 || s.Done != 1 {
+		t.Errorf("unexpected stats: %+v", s)
+	}
+}
+
+func TestGetProjectStats_ExecuteBatch_InvalidInput(t *testing.T) {
+	uc := &usecase.GetProjectStatsUsecase{Repo: &listRepo{}}
+	tooMany := make([]string, usecase.MaxBatchStatsProjects+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("proj-%d", i)
+	}
+
+	for name, ids := range map[string][]string{
+		"empty":      nil,
+		"blank id":   {"proj-1", ""},
+		"over limit": tooMany,
+	} {
+		if _, err := uc.ExecuteBatch(context.Background(), ids, time.Now()); !errors.Is(err, usecase.ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ProjectTimezoneProvider はプロジェクトの期日の判定（期限切れ・期日が近いタスク）に使うタイムゾーンを取得する。
+// projects サービスの GET /projects/{id}/settings を呼ぶクライアントなどで実装する。
+type ProjectTimezoneProvider interface {
+	// ProjectLocation は projectID のタイムゾーンを返す。設定されていない場合は time.UTC を返す。
+	ProjectLocation(ctx context.Context, projectID string) (*time.Location, error)
+}
+
+// ProjectLocation は p から projectID のタイムゾーンを取得する。p が nil の場合は time.UTC を返す。
+func ProjectLocation(ctx context.Context, p ProjectTimezoneProvider, projectID string) (*time.Location, error) {
+	if p == nil {
+		return time.UTC, nil
+	}
+	loc, err := p.ProjectLocation(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project timezone: %w", err)
+	}
+	return loc, nil
+}
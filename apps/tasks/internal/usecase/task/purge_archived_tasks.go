@@ -0,0 +1,121 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"teamflow-shared/audit"
+	"teamflow-shared/authz"
+	"teamflow-shared/events"
+	"teamflow-shared/outbox"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// ArchivedTaskPurger はアーカイブしてから保持期間を過ぎたタスクを取り出し、物理削除する。
+// いずれもすべてのワークスペースのタスクを対象にする（運用者・保持期間のジョブがまとめて扱うため）。
+type ArchivedTaskPurger interface {
+	// ArchivedBefore は before より前にアーカイブされたタスクを、アーカイブ日時・ID の順に最大 limit 件返す。
+	ArchivedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Task, error)
+	// DeleteArchivedBefore は before より前にアーカイブされたタスクを、アーカイブ日時・ID の順に最大 limit 件物理削除し、
+	// 削除したタスクを返す。
+	DeleteArchivedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Task, error)
+}
+
+// DefaultPurgeBatchSize は 1 回の呼び出しで物理削除するタスクの最大数の既定値。
+const DefaultPurgeBatchSize = 500
+
+// PurgeArchivedTasksInput はアーカイブしたタスクの物理削除の入力。
+type PurgeArchivedTasksInput struct {
+	// OlderThanDays はアーカイブしてからの日数。これより前にアーカイブされたタスクを対象にする（1 以上）
+	OlderThanDays int
+	// DryRun が true の場合は削除せずに対象のタスクを返す
+	DryRun  bool
+	ActorID string // 操作者（監査ログに記録する）。保持期間のジョブの場合は空
+	Now     time.Time
+}
+
+// PurgeArchivedTasksOutput はアーカイブしたタスクの物理削除の結果。
+type PurgeArchivedTasksOutput struct {
+	// Before はこの日時より前にアーカイブされたタスクを対象にしたことを表す
+	Before time.Time
+	// Tasks は削除したタスク（DryRun の場合は削除するタスク）
+	Tasks []*domain.Task
+	// HasMore は対象のタスクが BatchSize を超えて残っていることを表す（続きはもう一度呼び出す）
+	HasMore bool
+}
+
+// PurgeArchivedTasksUsecase はアーカイブしてから指定した日数を過ぎたタスクを物理削除するユースケース。
+// 削除したタスクは戻せない。差分同期には削除（Tombstone）として返る。
+type PurgeArchivedTasksUsecase struct {
+	Purger ArchivedTaskPurger
+	Tx     TxManager // 任意。nil の場合はトランザクション無しで実行する
+	// Operators は Execute（管理用 API）を呼び出せる運用者（ADMIN_USER_IDS）。空の場合は誰も呼び出せない
+	Operators authz.Operators
+	// Audit は削除したタスクを監査ログに記録するために使う。任意。nil の場合は記録しない
+	Audit audit.Recorder
+	// Events は削除したタスクの task.deleted を outbox に記録するために使う。任意。nil の場合は記録しない
+	Events outbox.Writer
+	// BatchSize は 1 回の呼び出しで削除する最大数。任意。0 の場合は DefaultPurgeBatchSize
+	BatchSize int
+}
+
+// Execute は操作者が運用者であることを確認してから Purge を実行する（管理用 API）。
+func (uc *PurgeArchivedTasksUsecase) Execute(ctx context.Context, in PurgeArchivedTasksInput) (*PurgeArchivedTasksOutput, error) {
+	if err := uc.Operators.Authorize(in.ActorID); err != nil {
+		return nil, err
+	}
+	return uc.Purge(ctx, in)
+}
+
+// Purge は in.OlderThanDays 日より前にアーカイブされたタスクを最大 BatchSize 件物理削除し、削除したタスクを監査ログと
+// task.deleted に記録する。DryRun の場合は削除・記録をせずに対象のタスクを返す。
+// OlderThanDays が 1 未満の場合は ErrInvalidInput を返す。
+func (uc *PurgeArchivedTasksUsecase) Purge(ctx context.Context, in PurgeArchivedTasksInput) (*PurgeArchivedTasksOutput, error) {
+	if in.OlderThanDays < 1 {
+		return nil, fmt.Errorf("%w: olderThanDays must be at least 1", ErrInvalidInput)
+	}
+	out := &PurgeArchivedTasksOutput{Before: in.Now.AddDate(0, 0, -in.OlderThanDays)}
+	limit := uc.BatchSize
+	if limit <= 0 {
+		limit = DefaultPurgeBatchSize
+	}
+
+	if in.DryRun {
+		tasks, err := uc.Purger.ArchivedBefore(ctx, out.Before, limit+1)
+		if err != nil {
+			return nil, err
+		}
+		out.HasMore = len(tasks) > limit
+		out.Tasks = tasks[:min(len(tasks), limit)]
+		return out, nil
+	}
+
+	// 削除と監査ログ・イベントの記録は 1 トランザクションで行う
+	err := withinTx(ctx, uc.Tx, func(ctx context.Context) error {
+		tasks, err := uc.Purger.DeleteArchivedBefore(ctx, out.Before, limit)
+		if err != nil {
+			return err
+		}
+		entries := make([]audit.Entry, len(tasks))
+		evs := make([]events.Event, len(tasks))
+		for i, t := range tasks {
+			entries[i] = taskAuditEntry(t.ProjectID, t.ID, audit.ActionPurge, in.ActorID, nil, in.Now)
+			evs[i] = events.NewTaskEvent(events.TaskDeleted, t.WorkspaceID, taskEventData(t, in.ActorID), in.Now)
+		}
+		if err := recordAudit(ctx, uc.Audit, entries...); err != nil {
+			return err
+		}
+		if err := recordEvents(ctx, uc.Events, evs...); err != nil {
+			return err
+		}
+		out.Tasks = tasks
+		out.HasMore = len(tasks) == limit
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
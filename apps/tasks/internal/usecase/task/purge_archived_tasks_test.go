@@ -0,0 +1,161 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"teamflow-shared/audit"
+	"teamflow-shared/authz"
+	"teamflow-shared/events"
+	"teamflow-shared/outbox"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// fakeArchivedTasks はアーカイブ日時を持つタスクをメモリに保持する usecase.ArchivedTaskPurger。
+type fakeArchivedTasks struct {
+	tasks []*domain.Task
+}
+
+func (f *fakeArchivedTasks) ArchivedBefore(_ context.Context, before time.Time, limit int) ([]*domain.Task, error) {
+	var out []*domain.Task
+	for _, t := range f.tasks {
+		if t.ArchivedAt != nil && t.ArchivedAt.Before(before) {
+			out = append(out, t)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ArchivedAt.Before(*out[j].ArchivedAt) })
+	return out[:min(len(out), limit)], nil
+}
+
+func (f *fakeArchivedTasks) DeleteArchivedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Task, error) {
+	deleted, _ := f.ArchivedBefore(ctx, before, limit)
+	kept := f.tasks[:0]
+	for _, t := range f.tasks {
+		if !containsTask(deleted, t.ID) {
+			kept = append(kept, t)
+		}
+	}
+	f.tasks = kept
+	return deleted, nil
+}
+
+func containsTask(tasks []*domain.Task, id string) bool {
+	for _, t := range tasks {
+		if t.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func taskIDs(tasks []*domain.Task) string {
+	ids := make([]string, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestPurgeArchivedTasks(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	archived := func(daysAgo int) *time.Time {
+		at := now.AddDate(0, 0, -daysAgo)
+		return &at
+	}
+	purger := &fakeArchivedTasks{tasks: []*domain.Task{
+		{ID: "t1", ProjectID: "proj-1", WorkspaceID: "ws-1", Title: "old", ArchivedAt: archived(120)},
+		{ID: "t2", ProjectID: "proj-1", WorkspaceID: "ws-1", Title: "older", ArchivedAt: archived(200)},
+		{ID: "t3", ProjectID: "proj-2", WorkspaceID: "ws-2", Title: "old in another workspace", ArchivedAt: archived(91)},
+		{ID: "t4", ProjectID: "proj-1", WorkspaceID: "ws-1", Title: "recent", ArchivedAt: archived(30)},
+		{ID: "t5", ProjectID: "proj-1", WorkspaceID: "ws-1", Title: "not archived"},
+	}}
+	rec := audit.NewMemoryRecorder()
+	store := outbox.NewMemoryStore()
+	uc := &usecase.PurgeArchivedTasksUsecase{
+		Purger:    purger,
+		Operators: authz.ParseOperators("admin-1"),
+		Audit:     rec,
+		Events:    store,
+		BatchSize: 2,
+	}
+	ctx := context.Background()
+
+	// dry-run は削除・記録をしない
+	out, err := uc.Execute(ctx, usecase.PurgeArchivedTasksInput{OlderThanDays: 90, DryRun: true, ActorID: "admin-1", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := taskIDs(out.Tasks); got != "t2,t1" || !out.HasMore || !out.Before.Equal(now.AddDate(0, 0, -90)) {
+		t.Errorf("unexpected dry-run result: tasks=%s hasMore=%v before=%s", got, out.HasMore, out.Before)
+	}
+	if len(purger.tasks) != 5 || len(rec.Entries()) != 0 || len(store.Events()) != 0 {
+		t.Fatalf("dry-run must not delete or record anything")
+	}
+
+	// BatchSize ずつ削除する
+	out, err = uc.Execute(ctx, usecase.PurgeArchivedTasksInput{OlderThanDays: 90, ActorID: "admin-1", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := taskIDs(out.Tasks); got != "t2,t1" || !out.HasMore {
+		t.Errorf("unexpected first batch: tasks=%s hasMore=%v", got, out.HasMore)
+	}
+	out, err = uc.Execute(ctx, usecase.PurgeArchivedTasksInput{OlderThanDays: 90, ActorID: "admin-1", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := taskIDs(out.Tasks); got != "t3" || out.HasMore {
+		t.Errorf("unexpected second batch: tasks=%s hasMore=%v", got, out.HasMore)
+	}
+	if got := taskIDs(purger.tasks); got != "t4,t5" {
+		t.Errorf("expected recent and unarchived tasks to remain, got %s", got)
+	}
+
+	entries := rec.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 audit entries, got %+v", entries)
+	}
+	for _, e := range entries {
+		if e.Action != audit.ActionPurge || e.ActorID != "admin-1" || !e.OccurredAt.Equal(now) {
+			t.Errorf("unexpected audit entry: %+v", e)
+		}
+	}
+	evs := store.Events()
+	if len(evs) != 3 || evs[2].Type != events.TaskDeleted || evs[2].WorkspaceID != "ws-2" || evs[2].ProjectID != "proj-2" {
+		t.Errorf("expected task.deleted in each task's workspace, got %+v", evs)
+	}
+}
+
+func TestPurgeArchivedTasks_Errors(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	uc := &usecase.PurgeArchivedTasksUsecase{Purger: &fakeArchivedTasks{}, Operators: authz.ParseOperators("admin-1")}
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		in   usecase.PurgeArchivedTasksInput
+		want error
+	}{
+		{name: "no actor", in: usecase.PurgeArchivedTasksInput{OlderThanDays: 90, Now: now}, want: usecase.ErrActorRequired},
+		{name: "not an operator", in: usecase.PurgeArchivedTasksInput{OlderThanDays: 90, ActorID: "user-1", Now: now}, want: usecase.ErrForbidden},
+		{name: "zero days", in: usecase.PurgeArchivedTasksInput{ActorID: "admin-1", Now: now}, want: usecase.ErrInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := uc.Execute(ctx, tt.in); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	// 保持期間のジョブ（Purge）は運用者を確認しない
+	if _, err := uc.Purge(ctx, usecase.PurgeArchivedTasksInput{OlderThanDays: 90, Now: now}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
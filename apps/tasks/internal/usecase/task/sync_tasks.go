@@ -0,0 +1,144 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// TaskChangeFeed はプロジェクトのタスクの変更（作成・更新・アーカイブ・削除）を変更の順序で取り出す（差分同期用）。
+type TaskChangeFeed interface {
+	// Changes は context のワークスペースの projectID のタスクの変更のうち、(Seq, TaskID) が after より後で
+	// Seq が until 未満のものを (Seq, TaskID) の順に最大 limit 件返す。同じタスクの変更は最新の 1 件だけを返す。
+	// until が 0 の場合は現在の上限を使う。戻り値の until は使った上限で、上限未満の変更が後から増えることはない
+	// （実行中の書き込みの変更は上限以上になる）。
+	Changes(ctx context.Context, projectID string, after domain.SyncPosition, until int64, limit int) (changes []domain.TaskChange, usedUntil int64, err error)
+	// PurgeTombstones はすべてのワークスペースで before より前に削除されたタスクの記録を消し、消した件数を返す。
+	PurgeTombstones(ctx context.Context, before time.Time) (int64, error)
+}
+
+const (
+	// MaxSyncChanges は 1 回の同期で返す変更の最大数。超える場合は hasMore を返し、続きは syncToken で取得する
+	MaxSyncChanges = 500
+	// DefaultTombstoneRetention は削除したタスクの記録を残す既定の期間
+	DefaultTombstoneRetention = 30 * 24 * time.Hour
+)
+
+// 削除として返す理由。
+const (
+	SyncDeletionDeleted  = "deleted"  // タスクが削除された
+	SyncDeletionArchived = "archived" // タスクがアーカイブされた（プロジェクトの削除に伴う。一覧に表示されない）
+)
+
+// SyncTasksUsecase はオフライン対応のクライアント向けに、前回の同期以降のタスクの変更を返すユースケース。
+type SyncTasksUsecase struct {
+	Feed TaskChangeFeed
+	// Access が設定されていれば、操作者がプロジェクトを閲覧できるか確認する
+	Access ProjectAccessChecker
+	// Secret は同期トークンの署名に使う（cursor と同じ CURSOR_SECRET）
+	Secret []byte
+	// TombstoneRetention は削除の記録を残す期間。これより古い同期トークンは ErrSyncTokenExpired にする。
+	// 0 の場合は DefaultTombstoneRetention
+	TombstoneRetention time.Duration
+}
+
+type SyncTasksInput struct {
+	ProjectID string
+	// Since は前回の同期で返した同期トークン。任意。空の場合はすべてのタスクを返す
+	Since   string
+	ActorID string // 操作者（Access が設定されている場合に閲覧権限を確認する）
+	Now     time.Time
+}
+
+// SyncDeletion は同期で返す削除（Tombstone）。
+type SyncDeletion struct {
+	TaskID    string
+	DeletedAt time.Time
+	Reason    string // SyncDeletionDeleted または SyncDeletionArchived
+}
+
+type SyncTasksOutput struct {
+	// Upserts は作成・更新されたタスク（クライアントは ID で置き換える）
+	Upserts   []*domain.Task
+	Deletions []SyncDeletion
+	// SyncToken は次の同期の since に渡すトークン。トークンの位置は同期のたびに単調に進む
+	SyncToken string
+	// HasMore は変更が MaxSyncChanges を超えて残っているかどうか（true の場合は SyncToken ですぐに続きを取得する）
+	HasMore bool
+}
+
+// Execute は in.Since 以降の変更を変更の順序で最大 MaxSyncChanges 件返す。
+// アーカイブされたタスクは一覧に表示されないため、削除（SyncDeletionArchived）として返す。
+func (uc *SyncTasksUsecase) Execute(ctx context.Context, in SyncTasksInput) (*SyncTasksOutput, error) {
+	if err := checkReadAccess(ctx, uc.Access, in.ProjectID, in.ActorID); err != nil {
+		return nil, err
+	}
+
+	var after domain.SyncPosition
+	var until int64
+	issuedAt := in.Now.Unix()
+	if in.Since != "" {
+		token, err := domain.DecodeSyncToken(in.Since, uc.Secret)
+		if err != nil {
+			return nil, err
+		}
+		if token.ProjectID != in.ProjectID {
+			return nil, fmt.Errorf("%w: token was issued for another project", domain.ErrSyncTokenInvalid)
+		}
+		if in.Now.Unix()-token.IssuedAt > int64(uc.retention()/time.Second) {
+			return nil, domain.ErrSyncTokenExpired
+		}
+		after, until = token.Position(), token.Until
+		if until != 0 {
+			// 変更の途中の続きは、最初のトークンの発行日時で有効期限を数える
+			issuedAt = token.IssuedAt
+		}
+	}
+
+	changes, until, err := uc.Feed.Changes(ctx, in.ProjectID, after, until, MaxSyncChanges+1)
+	if err != nil {
+		return nil, err
+	}
+	next := domain.SyncTokenPayload{V: domain.SyncTokenVersion, ProjectID: in.ProjectID, Seq: until, IssuedAt: in.Now.Unix()}
+	hasMore := len(changes) > MaxSyncChanges
+	if hasMore {
+		changes = changes[:MaxSyncChanges]
+		last := changes[len(changes)-1]
+		next = domain.SyncTokenPayload{
+			V: domain.SyncTokenVersion, ProjectID: in.ProjectID,
+			Seq: last.Seq, TaskID: last.TaskID, Until: until, IssuedAt: issuedAt,
+		}
+	}
+
+	out := &SyncTasksOutput{Upserts: []*domain.Task{}, Deletions: []SyncDeletion{}, HasMore: hasMore}
+	for _, c := range changes {
+		switch {
+		case c.Task == nil:
+			out.Deletions = append(out.Deletions, SyncDeletion{TaskID: c.TaskID, DeletedAt: c.DeletedAt, Reason: SyncDeletionDeleted})
+		case c.Task.ArchivedAt != nil:
+			out.Deletions = append(out.Deletions, SyncDeletion{TaskID: c.TaskID, DeletedAt: *c.Task.ArchivedAt, Reason: SyncDeletionArchived})
+		default:
+			out.Upserts = append(out.Upserts, c.Task)
+		}
+	}
+	out.SyncToken, err = domain.EncodeSyncToken(next, uc.Secret)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PurgeTombstones は保持期間（TombstoneRetention）を過ぎた削除の記録を消し、消した件数を返す。
+// 定期的に呼び出す（保持期間より古い同期トークンは Execute が拒否するため、消した記録が必要になることはない）。
+func (uc *SyncTasksUsecase) PurgeTombstones(ctx context.Context, now time.Time) (int64, error) {
+	return uc.Feed.PurgeTombstones(ctx, now.Add(-uc.retention()))
+}
+
+func (uc *SyncTasksUsecase) retention() time.Duration {
+	if uc.TombstoneRetention > 0 {
+		return uc.TombstoneRetention
+	}
+	return DefaultTombstoneRetention
+}
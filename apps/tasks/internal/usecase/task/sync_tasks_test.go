@@ -0,0 +1,178 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// fakeChangeFeed は changes（(Seq, TaskID) の順）から位置と上限で取り出す。上限の既定値は horizon。
+type fakeChangeFeed struct {
+	changes []domain.TaskChange
+	horizon int64
+	purged  time.Time
+}
+
+func (f *fakeChangeFeed) Changes(_ context.Context, _ string, after domain.SyncPosition, until int64, limit int) ([]domain.TaskChange, int64, error) {
+	if until == 0 {
+		until = f.horizon
+	}
+	out := []domain.TaskChange{}
+	for _, c := range f.changes {
+		if c.Seq < until && (c.Seq > after.Seq || (c.Seq == after.Seq && c.TaskID > after.TaskID)) && len(out) < limit {
+			out = append(out, c)
+		}
+	}
+	return out, until, nil
+}
+
+func (f *fakeChangeFeed) PurgeTombstones(_ context.Context, before time.Time) (int64, error) {
+	f.purged = before
+	return 0, nil
+}
+
+func TestSyncTasks(t *testing.T) {
+	secret := []byte("test-secret")
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	archivedAt := now.Add(-time.Hour)
+	feed := &fakeChangeFeed{horizon: 10, changes: []domain.TaskChange{
+		{Seq: 3, TaskID: "t1", Task: &domain.Task{ID: "t1"}},
+		{Seq: 4, TaskID: "t2", Task: &domain.Task{ID: "t2", ArchivedAt: &archivedAt}},
+		{Seq: 5, TaskID: "t3", DeletedAt: now.Add(-2 * time.Hour)},
+	}}
+	uc := &usecase.SyncTasksUsecase{Feed: feed, Secret: secret}
+
+	out, err := uc.Execute(context.Background(), usecase.SyncTasksInput{ProjectID: "proj-1", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Upserts) != 1 || out.Upserts[0].ID != "t1" || out.HasMore {
+		t.Errorf("unexpected upserts: %+v hasMore=%v", out.Upserts, out.HasMore)
+	}
+	want := []usecase.SyncDeletion{
+		{TaskID: "t2", DeletedAt: archivedAt, Reason: usecase.SyncDeletionArchived},
+		{TaskID: "t3", DeletedAt: now.Add(-2 * time.Hour), Reason: usecase.SyncDeletionDeleted},
+	}
+	if len(out.Deletions) != len(want) || out.Deletions[0] != want[0] || out.Deletions[1] != want[1] {
+		t.Errorf("expected deletions %+v, got %+v", want, out.Deletions)
+	}
+	token, err := domain.DecodeSyncToken(out.SyncToken, secret)
+	if err != nil {
+		t.Fatalf("failed to decode sync token: %v", err)
+	}
+	if token.Seq != 10 || token.Until != 0 || token.ProjectID != "proj-1" || token.IssuedAt != now.Unix() {
+		t.Errorf("unexpected sync token: %+v", token)
+	}
+
+	// 前回の同期以降の変更だけを返し、トークンの位置は進む
+	feed.changes = append(feed.changes, domain.TaskChange{Seq: 12, TaskID: "t1", Task: &domain.Task{ID: "t1", Title: "updated"}})
+	feed.horizon = 13
+	out, err = uc.Execute(context.Background(), usecase.SyncTasksInput{ProjectID: "proj-1", Since: out.SyncToken, Now: now.Add(time.Minute)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Upserts) != 1 || out.Upserts[0].Title != "updated" || len(out.Deletions) != 0 {
+		t.Errorf("expected only the updated t1, got %+v %+v", out.Upserts, out.Deletions)
+	}
+	next, _ := domain.DecodeSyncToken(out.SyncToken, secret)
+	if next == nil || next.Seq != 13 {
+		t.Errorf("expected the sync token to advance to 13, got %+v", next)
+	}
+}
+
+func TestSyncTasks_HasMore(t *testing.T) {
+	secret := []byte("test-secret")
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	feed := &fakeChangeFeed{horizon: 100}
+	for i := 0; i < usecase.MaxSyncChanges+1; i++ {
+		id := fmt.Sprintf("t%04d", i)
+		feed.changes = append(feed.changes, domain.TaskChange{Seq: 50, TaskID: id, Task: &domain.Task{ID: id}})
+	}
+	uc := &usecase.SyncTasksUsecase{Feed: feed, Secret: secret}
+
+	first, err := uc.Execute(context.Background(), usecase.SyncTasksInput{ProjectID: "proj-1", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !first.HasMore || len(first.Upserts) != usecase.MaxSyncChanges {
+		t.Fatalf("expected a full page with hasMore, got %d hasMore=%v", len(first.Upserts), first.HasMore)
+	}
+
+	// 続きは同じ上限で取り出す（途中で増えた上限以降の変更は次の同期で返す）
+	feed.horizon = 200
+	second, err := uc.Execute(context.Background(), usecase.SyncTasksInput{ProjectID: "proj-1", Since: first.SyncToken, Now: now.Add(time.Minute)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.HasMore || len(second.Upserts) != 1 || second.Upserts[0].ID != fmt.Sprintf("t%04d", usecase.MaxSyncChanges) {
+		t.Fatalf("expected the last task, got %d hasMore=%v", len(second.Upserts), second.HasMore)
+	}
+	token, _ := domain.DecodeSyncToken(second.SyncToken, secret)
+	if token == nil || token.Seq != 100 || token.Until != 0 {
+		t.Errorf("expected the sync token to end at the first horizon, got %+v", token)
+	}
+}
+
+func TestSyncTasks_InvalidSince(t *testing.T) {
+	secret := []byte("test-secret")
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	encode := func(p domain.SyncTokenPayload) string {
+		p.V = domain.SyncTokenVersion
+		token, err := domain.EncodeSyncToken(p, secret)
+		if err != nil {
+			t.Fatalf("failed to encode: %v", err)
+		}
+		return token
+	}
+	uc := &usecase.SyncTasksUsecase{Feed: &fakeChangeFeed{horizon: 10}, Secret: secret, TombstoneRetention: 24 * time.Hour}
+
+	tests := []struct {
+		name    string
+		since   string
+		wantErr error
+	}{
+		{name: "garbage", since: "garbage", wantErr: domain.ErrSyncTokenInvalid},
+		{name: "other project", since: encode(domain.SyncTokenPayload{ProjectID: "proj-2", Seq: 1, IssuedAt: now.Unix()}), wantErr: domain.ErrSyncTokenInvalid},
+		{name: "older than retention", since: encode(domain.SyncTokenPayload{ProjectID: "proj-1", Seq: 1, IssuedAt: now.Add(-25 * time.Hour).Unix()}), wantErr: domain.ErrSyncTokenExpired},
+		{name: "within retention", since: encode(domain.SyncTokenPayload{ProjectID: "proj-1", Seq: 1, IssuedAt: now.Add(-23 * time.Hour).Unix()})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.Execute(context.Background(), usecase.SyncTasksInput{ProjectID: "proj-1", Since: tt.since, Now: now})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSyncTasks_Access(t *testing.T) {
+	uc := &usecase.SyncTasksUsecase{
+		Feed:   &fakeChangeFeed{horizon: 1},
+		Secret: []byte("test-secret"),
+		Access: &fakeAccess{privateProjects: map[string]bool{"proj-1": true}, members: map[string]bool{"user-1": true}},
+	}
+	if _, err := uc.Execute(context.Background(), usecase.SyncTasksInput{ProjectID: "proj-1", ActorID: "user-2"}); !errors.Is(err, usecase.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+	if _, err := uc.Execute(context.Background(), usecase.SyncTasksInput{ProjectID: "proj-1", ActorID: "user-1"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSyncTasks_PurgeTombstones(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	feed := &fakeChangeFeed{}
+	uc := &usecase.SyncTasksUsecase{Feed: feed}
+	if _, err := uc.PurgeTombstones(context.Background(), now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := now.Add(-usecase.DefaultTombstoneRetention); !feed.purged.Equal(want) {
+		t.Errorf("expected tombstones before %v to be purged, got %v", want, feed.purged)
+	}
+}
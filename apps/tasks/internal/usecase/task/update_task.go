@@ -6,6 +6,13 @@ import (
 	"fmt"
 	"time"
 
+	"teamflow-shared/audit"
+	"teamflow-shared/clock"
+	"teamflow-shared/events"
+	"teamflow-shared/outbox"
+	"teamflow-shared/uuidpolicy"
+	"teamflow-shared/workspace"
+
 	domain "teamflow-tasks/internal/domain/task"
 )
 
@@ -13,72 +20,232 @@ import (
 // HTTP 層から受け取った情報を TaskPatch に変換する。
 type UpdateTaskInput struct {
 	ID          string
+	ProjectID   string // 指定時はタスクの所属プロジェクトと一致しなければ ErrTaskNotFound
 	Title       domain.Patch[string]
 	Description domain.Patch[string]
-	StatusStr   *string
-	PriorityStr *string
+	Status      domain.Patch[string] // Usecase 層で ParseStatus する
+	Priority    domain.Patch[string] // Usecase 層で ParsePriority する
 	AssigneeID  domain.Patch[string]
 	DueDate     domain.Patch[time.Time]
+	StartDate   domain.Patch[time.Time]
+	Estimate    domain.Patch[int]
+	MilestoneID domain.Patch[string]
+	SprintID    domain.Patch[string]
+	EpicID      domain.Patch[string]
+	LabelIDs    domain.Patch[[]string] // 指定した一覧で置き換える。null はすべて外す
+	ActorID     string                 // 操作者（UpdatedBy）。空の場合は不明
+	// BaseVersion は更新の前提にしたタスクの版数（If-Match）。任意。0 の場合は版数を確認しない
+	BaseVersion int
+	// MergeFields が true の場合は BaseVersion と現在の版数が異なっても、指定したフィールドが BaseVersion より後に
+	// 変更されていなければ更新する（フィールド単位のマージ）。BaseVersion が 0 の場合は使わない
+	MergeFields bool
 }
 
+// maxUpdateAttempts は読み取ってから保存するまでの間に他の更新があった場合に、読み取りからやり直す回数の上限（初回を含む）。
+const maxUpdateAttempts = 3
+
+// errUpdateRaced は読み取ってから保存するまでの間に、他の更新でタスクの版数が進んだことを表す（読み取りからやり直す）。
+var errUpdateRaced = errors.New("task was updated concurrently")
+
 // UpdateTaskUsecase はタスク更新ユースケースを表す。
 type UpdateTaskUsecase struct {
 	Repo TaskRepository
+	Tx   TxManager // 任意。nil の場合はトランザクション無しで実行する
+	// Authorizer は操作者がタスクのプロジェクトのタスクを変更できるかの確認に使う。任意。nil の場合は確認しない
+	Authorizer ProjectWriteAuthorizer
+	// Members は担当者のメンバーチェックに使う。任意。nil の場合はチェックしない
+	Members MembershipChecker
+	// Users は担当者のユーザーの存在チェックに使う。任意。nil の場合はチェックしない
+	Users UserDirectory
+	// Milestones はマイルストーンの存在チェックに使う。任意。nil の場合はチェックしない
+	Milestones MilestoneChecker
+	// Sprints はスプリントの存在チェックに使う。任意。nil の場合はチェックしない
+	Sprints SprintChecker
+	// Epics はエピックの存在チェックに使う。任意。nil の場合はチェックしない
+	Epics EpicChecker
+	// Labels はラベルが定義済みかのチェックに使う。任意。nil の場合はチェックしない
+	Labels LabelChecker
+	// FieldLocks はプロジェクト設定でロックされたフィールドを操作者のロールで変更できるかの確認に使う。任意。nil の場合は確認しない
+	FieldLocks FieldLockPolicy
+	// Clock は UpdatedAt に使う現在時刻。任意。nil の場合は clock.System
+	Clock clock.Clock
+	// Audit は更新を監査ログに記録するために使う。任意。nil の場合は記録しない
+	Audit audit.Recorder
+	// Events は task.updated を outbox に記録するために使う。任意。nil の場合は記録しない
+	Events outbox.Writer
+	// Notifier は担当者が変わった場合の新しい担当者への通知に使う（コミットした後に呼ぶ）。任意。nil の場合は通知しない
+	Notifier AssignmentNotifier
+	// UUIDPolicy は担当者 ID として受け付ける UUID の形式。任意。空の場合は形式を確認しない
+	UUIDPolicy uuidpolicy.Policy
 }
 
 // Execute は既存タスクを取得し、指定されたフィールドを更新する。
+// 取得と更新は 1 トランザクション内で行う。担当者が変わった場合はコミットした後に新しい担当者に通知する。
+//
+// BaseVersion を指定した場合は、その版数より後にタスクが変更されていれば *domain.VersionConflictError を返す
+// （MergeFields の場合は、指定したフィールドが変更されていた場合のみ）。
+// 取得してから保存するまでの間に他の更新があった場合は、取得からやり直す（版数の確認もやり直す）。
 func (uc *UpdateTaskUsecase) Execute(ctx context.Context, in UpdateTaskInput) (*domain.Task, error) {
+	var (
+		updated  *domain.Task
+		assigned bool
+		err      error
+	)
+	for attempt := 1; ; attempt++ {
+		err = withinTx(ctx, uc.Tx, func(ctx context.Context) error {
+			var err error
+			updated, assigned, err = uc.execute(ctx, in)
+			return err
+		})
+		if !errors.Is(err, errUpdateRaced) || attempt >= maxUpdateAttempts {
+			break
+		}
+	}
+	if err == nil && assigned {
+		notifyAssigned(ctx, uc.Notifier, in.ActorID, updated)
+	}
+	return updated, err
+}
+
+// execute はタスクを更新する。assigned は担当者が変わった（新しい担当者が設定された）かどうか。
+func (uc *UpdateTaskUsecase) execute(ctx context.Context, in UpdateTaskInput) (*domain.Task, bool, error) {
 	existing, err := uc.Repo.FindByID(ctx, in.ID)
 	if err != nil {
 		if errors.Is(err, ErrTaskNotFound) {
-			return nil, fmt.Errorf("%w: %v", ErrTaskNotFound, err)
+			return nil, false, fmt.Errorf("%w: %v", ErrTaskNotFound, err)
 		}
-		return nil, err
+		return nil, false, err
 	}
 
-	// TaskPatch を組み立てる
-	patch := domain.TaskPatch{}
+	// プロジェクトスコープの更新では、他プロジェクトのタスクは存在しないものとして扱う
+	if in.ProjectID != "" && existing.ProjectID != in.ProjectID {
+		return nil, false, fmt.Errorf("%w: task %s does not belong to project %s", ErrTaskNotFound, in.ID, in.ProjectID)
+	}
 
-	// Title
-	patch.Title = in.Title
+	// 変更できないプロジェクトのタスクも、存在しないタスクと区別しない
+	if err := checkWriteAccess(ctx, uc.Authorizer, existing.ProjectID, in.ActorID); err != nil {
+		if errors.Is(err, ErrProjectNotFound) {
+			return nil, false, fmt.Errorf("%w: %w", ErrTaskNotFound, err)
+		}
+		return nil, false, err
+	}
 
-	// Description
-	patch.Description = in.Description
+	// Status / Priority は文字列で受け取り、Usecase 層で Parse する
+	status, err := domain.MapPatch(in.Status, domain.ParseStatus)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+	priority, err := domain.MapPatch(in.Priority, domain.ParsePriority)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
 
-	// Status (Usecase 層で Parse)
-	if in.StatusStr != nil {
-		parsed, err := domain.ParseStatus(*in.StatusStr)
-		if err != nil {
-			return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	patch := domain.TaskPatch{
+		Title:       in.Title,
+		Description: in.Description,
+		Status:      status,
+		Priority:    priority,
+		AssigneeID:  in.AssigneeID,
+		DueDate:     in.DueDate,
+		StartDate:   in.StartDate,
+		Estimate:    in.Estimate,
+		MilestoneID: in.MilestoneID,
+		SprintID:    in.SprintID,
+		EpicID:      in.EpicID,
+		LabelIDs:    in.LabelIDs,
+	}
+
+	// ロックされたフィールドは、プロジェクト設定で許可されたロールの操作者だけが変更できる
+	if err := checkFieldLocks(ctx, uc.FieldLocks, existing.ProjectID, in.ActorID, patch.Fields()); err != nil {
+		return nil, false, err
+	}
+
+	if in.BaseVersion > 0 {
+		if err := existing.CheckVersion(in.BaseVersion, patch.Fields(), in.MergeFields); err != nil {
+			return nil, false, err
 		}
-		patch.Status = domain.Set(parsed)
 	}
 
-	// Priority (Usecase 層で Parse)
-	if in.PriorityStr != nil {
-		parsed, err := domain.ParsePriority(*in.PriorityStr)
+	if in.AssigneeID.HasValue() {
+		if err := checkUUID(uc.UUIDPolicy, "assigneeId", in.AssigneeID.Value); err != nil {
+			return nil, false, err
+		}
+	}
+	if uc.Members != nil && in.AssigneeID.HasValue() {
+		ok, err := uc.Members.IsMember(ctx, existing.ProjectID, in.AssigneeID.Value)
 		if err != nil {
-			return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+			return nil, false, err
+		}
+		if !ok {
+			return nil, false, fmt.Errorf("%w: %w", ErrInvalidInput, ErrAssigneeNotMember)
+		}
+	}
+	if in.AssigneeID.HasValue() && in.AssigneeID.Value != "" {
+		if err := checkAssignee(ctx, uc.Users, in.AssigneeID.Value); err != nil {
+			return nil, false, err
 		}
-		patch.Priority = domain.Set(parsed)
 	}
 
-	// AssigneeID
-	patch.AssigneeID = in.AssigneeID
+	if in.MilestoneID.HasValue() && in.MilestoneID.Value != "" {
+		if err := checkMilestone(ctx, uc.Milestones, existing.ProjectID, in.MilestoneID.Value); err != nil {
+			return nil, false, err
+		}
+	}
 
-	// DueDate
-	patch.DueDate = in.DueDate
+	if in.SprintID.HasValue() && in.SprintID.Value != "" {
+		if err := checkSprint(ctx, uc.Sprints, existing.ProjectID, in.SprintID.Value); err != nil {
+			return nil, false, err
+		}
+	}
 
-	if err := existing.ApplyPatch(patch); err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	if in.EpicID.HasValue() && in.EpicID.Value != "" {
+		if err := checkEpic(ctx, uc.Epics, existing.ProjectID, in.EpicID.Value); err != nil {
+			return nil, false, err
+		}
 	}
 
+	if in.LabelIDs.HasValue() {
+		if err := checkLabels(ctx, uc.Labels, existing.ProjectID, in.LabelIDs.Value); err != nil {
+			return nil, false, err
+		}
+	}
+
+	previousStatus := existing.Status
+	previousAssignee := ""
+	if existing.AssigneeID != nil {
+		previousAssignee = *existing.AssigneeID
+	}
+	if err := existing.ApplyPatch(patch, clock.OrSystem(uc.Clock).Now()); err != nil {
+		return nil, false, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+	existing.UpdatedBy = in.ActorID
+
 	if err := uc.Repo.Update(ctx, existing); err != nil {
 		if errors.Is(err, ErrTaskNotFound) {
-			return existing, fmt.Errorf("%w: %v", ErrTaskNotFound, err)
+			return existing, false, fmt.Errorf("%w: %v", ErrTaskNotFound, err)
+		}
+		if errors.Is(err, domain.ErrVersionConflict) {
+			return existing, false, fmt.Errorf("%w: %w", errUpdateRaced, err)
 		}
-		return existing, err
+		return existing, false, err
+	}
+
+	entry := taskAuditEntry(existing.ProjectID, existing.ID, audit.ActionUpdate, in.ActorID, patch.Fields(), existing.UpdatedAt)
+	if err := recordAudit(ctx, uc.Audit, entry); err != nil {
+		return existing, false, err
+	}
+
+	data := taskEventData(existing, in.ActorID)
+	data.Fields = patch.Fields()
+	if existing.Status != previousStatus {
+		data.PreviousStatus = string(previousStatus)
+	}
+	data.AssigneeChanged = data.AssigneeID != previousAssignee
+	event := events.NewTaskEvent(events.TaskUpdated, workspace.FromContext(ctx), data, existing.UpdatedAt)
+	if err := recordEvents(ctx, uc.Events, event); err != nil {
+		return existing, false, err
 	}
 
-	return existing, nil
+	assigned := data.AssigneeChanged && data.AssigneeID != ""
+	return existing, assigned, nil
 }
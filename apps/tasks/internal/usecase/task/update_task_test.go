@@ -0,0 +1,599 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"teamflow-shared/audit"
+	"teamflow-shared/clock"
+	"teamflow-shared/uuidpolicy"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// fakeTxManager は TxManager のテスト用フェイク実装。
+type fakeTxManager struct {
+	calls  int
+	gotErr error
+}
+
+func (m *fakeTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.calls++
+	m.gotErr = fn(ctx)
+	return m.gotErr
+}
+
+func newUpdateTestRepo(t *testing.T) *fakeTaskRepo {
+	t.Helper()
+	task, err := domain.NewTask("task-1", "proj-1", "title", "", domain.StatusTodo, domain.PriorityMedium, nil, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	return &fakeTaskRepo{listOut: []*domain.Task{task}}
+}
+
+func TestUpdateTaskUsecase_RunsWithinTx(t *testing.T) {
+	repo := newUpdateTestRepo(t)
+	tx := &fakeTxManager{}
+	now := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	uc := &usecase.UpdateTaskUsecase{Repo: repo, Tx: tx, Clock: clock.Fixed(now)}
+
+	got, err := uc.Execute(context.Background(), usecase.UpdateTaskInput{
+		ID:    "task-1",
+		Title: domain.Set("updated"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if tx.calls != 1 {
+		t.Errorf("expected WithinTx to be called once, got %d", tx.calls)
+	}
+	if got.Title != "updated" {
+		t.Errorf("expected Title=updated, got=%s", got.Title)
+	}
+	if !got.UpdatedAt.Equal(now) {
+		t.Errorf("expected UpdatedAt=%s (from Clock), got=%s", now, got.UpdatedAt)
+	}
+}
+
+func TestUpdateTaskUsecase_RecordsActor(t *testing.T) {
+	repo := newUpdateTestRepo(t)
+	now := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	rec := audit.NewMemoryRecorder()
+	uc := &usecase.UpdateTaskUsecase{Repo: repo, Clock: clock.Fixed(now), Audit: rec}
+
+	got, err := uc.Execute(context.Background(), usecase.UpdateTaskInput{
+		ID:       "task-1",
+		Title:    domain.Set("updated"),
+		Priority: domain.Set("high"),
+		ActorID:  "user-2",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.UpdatedBy != "user-2" {
+		t.Errorf("expected UpdatedBy=user-2, got %q", got.UpdatedBy)
+	}
+
+	entries := rec.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %+v", entries)
+	}
+	if e := entries[0]; e.Action != audit.ActionUpdate || e.ActorID != "user-2" || e.EntityID != "task-1" ||
+		strings.Join(e.Fields, ",") != "title,priority" || !e.OccurredAt.Equal(now) {
+		t.Errorf("unexpected audit entry: %+v", e)
+	}
+}
+
+func TestUpdateTaskUsecase_TxReceivesError(t *testing.T) {
+	repo := newUpdateTestRepo(t)
+	tx := &fakeTxManager{}
+	uc := &usecase.UpdateTaskUsecase{Repo: repo, Tx: tx}
+
+	_, err := uc.Execute(context.Background(), usecase.UpdateTaskInput{
+		ID:       "task-1",
+		Estimate: domain.Set(-1),
+	})
+	if !errors.Is(err, usecase.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+
+	// ロールバックさせるため、エラーは TxManager に返されること
+	if !errors.Is(tx.gotErr, usecase.ErrInvalidInput) {
+		t.Errorf("expected TxManager to receive ErrInvalidInput, got %v", tx.gotErr)
+	}
+}
+
+func TestUpdateTaskUsecase_ProjectMismatch(t *testing.T) {
+	repo := newUpdateTestRepo(t)
+	uc := &usecase.UpdateTaskUsecase{Repo: repo}
+
+	_, err := uc.Execute(context.Background(), usecase.UpdateTaskInput{
+		ID:        "task-1",
+		ProjectID: "proj-2",
+		Title:     domain.Set("updated"),
+	})
+	if !errors.Is(err, usecase.ErrTaskNotFound) {
+		t.Fatalf("expected ErrTaskNotFound, got %v", err)
+	}
+}
+
+func TestUpdateTaskUsecase_Authorizer(t *testing.T) {
+	policy := &usecase.MembershipPolicy{Members: &fakeMembershipChecker{members: map[string]bool{"proj-1/user-1": true}}}
+
+	tests := []struct {
+		name    string
+		actorID string
+		wantErr error
+	}{
+		{name: "member", actorID: "user-1"},
+		{name: "no actor", actorID: "", wantErr: usecase.ErrActorRequired},
+		// 変更できないプロジェクトのタスクは、存在しないタスクと区別しない
+		{name: "not a member", actorID: "user-2", wantErr: usecase.ErrTaskNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &usecase.UpdateTaskUsecase{Repo: newUpdateTestRepo(t), Authorizer: policy}
+
+			_, err := uc.Execute(context.Background(), usecase.UpdateTaskInput{
+				ID:      "task-1",
+				Title:   domain.Set("updated"),
+				ActorID: tt.actorID,
+			})
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// fakeMembershipChecker は MembershipChecker のテスト用フェイク実装。
+type fakeMembershipChecker struct {
+	members map[string]bool // "projectID/userID"
+	calls   int
+}
+
+func (c *fakeMembershipChecker) IsMember(_ context.Context, projectID, userID string) (bool, error) {
+	c.calls++
+	return c.members[projectID+"/"+userID], nil
+}
+
+func TestUpdateTaskUsecase_AssigneeMembership(t *testing.T) {
+	tests := []struct {
+		name      string
+		assignee  domain.Patch[string]
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "member", assignee: domain.Set("user-1"), wantCalls: 1},
+		{name: "not a member", assignee: domain.Set("user-2"), wantErr: usecase.ErrAssigneeNotMember, wantCalls: 1},
+		{name: "unassign is not checked", assignee: domain.Null[string](), wantCalls: 0},
+		{name: "unset is not checked", assignee: domain.Unset[string](), wantCalls: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			members := &fakeMembershipChecker{members: map[string]bool{"proj-1/user-1": true}}
+			uc := &usecase.UpdateTaskUsecase{Repo: newUpdateTestRepo(t), Members: members}
+
+			_, err := uc.Execute(context.Background(), usecase.UpdateTaskInput{
+				ID:         "task-1",
+				AssigneeID: tt.assignee,
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || !errors.Is(err, usecase.ErrInvalidInput) {
+					t.Fatalf("expected %v wrapped in ErrInvalidInput, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if members.calls != tt.wantCalls {
+				t.Errorf("expected %d IsMember calls, got %d", tt.wantCalls, members.calls)
+			}
+		})
+	}
+}
+
+func TestUpdateTaskUsecase_AssigneeUUIDPolicy(t *testing.T) {
+	uc := &usecase.UpdateTaskUsecase{Repo: newUpdateTestRepo(t), UUIDPolicy: uuidpolicy.V4V7}
+
+	_, err := uc.Execute(context.Background(), usecase.UpdateTaskInput{
+		ID:         "task-1",
+		AssigneeID: domain.Set("6ba7b810-9dad-11d1-80b4-00c04fd430c8"),
+	})
+	if !errors.Is(err, usecase.ErrInvalidInput) || !errors.Is(err, uuidpolicy.ErrInvalid) {
+		t.Fatalf("expected ErrInvalid wrapped in ErrInvalidInput, got %v", err)
+	}
+
+	// 担当者を外す（null）のは確認しない
+	if _, err := uc.Execute(context.Background(), usecase.UpdateTaskInput{ID: "task-1", AssigneeID: domain.Null[string]()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.Execute(context.Background(), usecase.UpdateTaskInput{
+		ID:         "task-1",
+		AssigneeID: domain.Set("01890a5d-ac96-774b-bcce-b302099a8057"),
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// fakeMilestoneChecker は MilestoneChecker のテスト用フェイク実装。
+type fakeMilestoneChecker struct {
+	milestones map[string]bool // "projectID/milestoneID"
+	calls      int
+}
+
+func (c *fakeMilestoneChecker) MilestoneExists(_ context.Context, projectID, milestoneID string) (bool, error) {
+	c.calls++
+	return c.milestones[projectID+"/"+milestoneID], nil
+}
+
+func TestUpdateTaskUsecase_Milestone(t *testing.T) {
+	tests := []struct {
+		name      string
+		milestone domain.Patch[string]
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "existing milestone", milestone: domain.Set("m-1"), wantCalls: 1},
+		{name: "unknown milestone", milestone: domain.Set("m-2"), wantErr: usecase.ErrMilestoneNotFound, wantCalls: 1},
+		{name: "clear is not checked", milestone: domain.Null[string](), wantCalls: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			milestones := &fakeMilestoneChecker{milestones: map[string]bool{"proj-1/m-1": true}}
+			uc := &usecase.UpdateTaskUsecase{Repo: newUpdateTestRepo(t), Milestones: milestones}
+
+			updated, err := uc.Execute(context.Background(), usecase.UpdateTaskInput{
+				ID:          "task-1",
+				MilestoneID: tt.milestone,
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || !errors.Is(err, usecase.ErrInvalidInput) {
+					t.Fatalf("expected %v wrapped in ErrInvalidInput, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if tt.milestone.HasValue() && (updated.MilestoneID == nil || *updated.MilestoneID != tt.milestone.Value) {
+				t.Errorf("expected milestoneId %q, got %v", tt.milestone.Value, updated.MilestoneID)
+			}
+			if milestones.calls != tt.wantCalls {
+				t.Errorf("expected %d MilestoneExists calls, got %d", tt.wantCalls, milestones.calls)
+			}
+		})
+	}
+}
+
+// fakeSprintChecker は SprintChecker のテスト用フェイク実装。
+type fakeSprintChecker struct {
+	sprints map[string]bool // "projectID/sprintID"
+	calls   int
+}
+
+func (c *fakeSprintChecker) SprintExists(_ context.Context, projectID, sprintID string) (bool, error) {
+	c.calls++
+	return c.sprints[projectID+"/"+sprintID], nil
+}
+
+func TestUpdateTaskUsecase_Sprint(t *testing.T) {
+	tests := []struct {
+		name      string
+		sprint    domain.Patch[string]
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "existing sprint", sprint: domain.Set("s-1"), wantCalls: 1},
+		{name: "unknown sprint", sprint: domain.Set("s-2"), wantErr: usecase.ErrSprintNotFound, wantCalls: 1},
+		{name: "clear is not checked", sprint: domain.Null[string](), wantCalls: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sprints := &fakeSprintChecker{sprints: map[string]bool{"proj-1/s-1": true}}
+			uc := &usecase.UpdateTaskUsecase{Repo: newUpdateTestRepo(t), Sprints: sprints}
+
+			updated, err := uc.Execute(context.Background(), usecase.UpdateTaskInput{
+				ID:       "task-1",
+				SprintID: tt.sprint,
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || !errors.Is(err, usecase.ErrInvalidInput) {
+					t.Fatalf("expected %v wrapped in ErrInvalidInput, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if tt.sprint.HasValue() && (updated.SprintID == nil || *updated.SprintID != tt.sprint.Value) {
+				t.Errorf("expected sprintId %q, got %v", tt.sprint.Value, updated.SprintID)
+			}
+			if sprints.calls != tt.wantCalls {
+				t.Errorf("expected %d SprintExists calls, got %d", tt.wantCalls, sprints.calls)
+			}
+		})
+	}
+}
+
+// fakeEpicChecker は EpicChecker のテスト用フェイク実装。
+type fakeEpicChecker struct {
+	epics map[string]bool // "projectID/epicID"
+	calls int
+}
+
+func (c *fakeEpicChecker) EpicExists(_ context.Context, projectID, epicID string) (bool, error) {
+	c.calls++
+	return c.epics[projectID+"/"+epicID], nil
+}
+
+func TestUpdateTaskUsecase_Epic(t *testing.T) {
+	tests := []struct {
+		name      string
+		epic      domain.Patch[string]
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "existing epic", epic: domain.Set("e-1"), wantCalls: 1},
+		{name: "unknown epic", epic: domain.Set("e-2"), wantErr: usecase.ErrEpicNotFound, wantCalls: 1},
+		{name: "clear is not checked", epic: domain.Null[string](), wantCalls: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			epics := &fakeEpicChecker{epics: map[string]bool{"proj-1/e-1": true}}
+			uc := &usecase.UpdateTaskUsecase{Repo: newUpdateTestRepo(t), Epics: epics}
+
+			updated, err := uc.Execute(context.Background(), usecase.UpdateTaskInput{
+				ID:     "task-1",
+				EpicID: tt.epic,
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || !errors.Is(err, usecase.ErrInvalidInput) {
+					t.Fatalf("expected %v wrapped in ErrInvalidInput, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if tt.epic.HasValue() && (updated.EpicID == nil || *updated.EpicID != tt.epic.Value) {
+				t.Errorf("expected epicId %q, got %v", tt.epic.Value, updated.EpicID)
+			}
+			if epics.calls != tt.wantCalls {
+				t.Errorf("expected %d EpicExists calls, got %d", tt.wantCalls, epics.calls)
+			}
+		})
+	}
+}
+
+// fakeLabelChecker は LabelChecker のテスト用フェイク実装。
+type fakeLabelChecker struct {
+	labels map[string]bool // "projectID/labelID"
+	calls  int
+}
+
+func (c *fakeLabelChecker) LabelExists(_ context.Context, projectID, labelID string) (bool, error) {
+	c.calls++
+	return c.labels[projectID+"/"+labelID], nil
+}
+
+func TestUpdateTaskUsecase_Labels(t *testing.T) {
+	tests := []struct {
+		name      string
+		labels    domain.Patch[[]string]
+		want      []string
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "defined labels", labels: domain.Set([]string{"l-1", "l-1"}), want: []string{"l-1"}, wantCalls: 1},
+		{name: "undefined label", labels: domain.Set([]string{"l-1", "l-2"}), wantErr: usecase.ErrLabelNotFound, wantCalls: 2},
+		{name: "clear is not checked", labels: domain.Null[[]string](), wantCalls: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels := &fakeLabelChecker{labels: map[string]bool{"proj-1/l-1": true}}
+			uc := &usecase.UpdateTaskUsecase{Repo: newUpdateTestRepo(t), Labels: labels}
+
+			updated, err := uc.Execute(context.Background(), usecase.UpdateTaskInput{
+				ID:       "task-1",
+				LabelIDs: tt.labels,
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || !errors.Is(err, usecase.ErrInvalidInput) {
+					t.Fatalf("expected %v wrapped in ErrInvalidInput, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if strings.Join(updated.LabelIDs, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected labelIds %v, got %v", tt.want, updated.LabelIDs)
+			}
+			if labels.calls != tt.wantCalls {
+				t.Errorf("expected %d LabelExists calls, got %d", tt.wantCalls, labels.calls)
+			}
+		})
+	}
+}
+
+// fakeFieldLockPolicy は FieldLockPolicy のテスト用フェイク実装。
+type fakeFieldLockPolicy struct {
+	locks     map[string][]string
+	roles     map[string]string // userID → role
+	roleCalls int
+}
+
+func (p *fakeFieldLockPolicy) FieldLocks(_ context.Context, _ string) (map[string][]string, error) {
+	return p.locks, nil
+}
+
+func (p *fakeFieldLockPolicy) MemberRole(_ context.Context, _, userID string) (string, error) {
+	p.roleCalls++
+	return p.roles[userID], nil
+}
+
+func TestUpdateTaskUsecase_FieldLocks(t *testing.T) {
+	tests := []struct {
+		name          string
+		in            usecase.UpdateTaskInput
+		wantErr       error
+		wantField     string
+		wantRoleCalls int
+	}{
+		{name: "unlocked field", in: usecase.UpdateTaskInput{Title: domain.Set("updated"), ActorID: "member-1"}},
+		{name: "allowed role", in: usecase.UpdateTaskInput{Priority: domain.Set("high"), ActorID: "admin-1"}, wantRoleCalls: 1},
+		{
+			name:    "role not allowed",
+			in:      usecase.UpdateTaskInput{Title: domain.Set("updated"), Priority: domain.Set("high"), ActorID: "member-1"},
+			wantErr: usecase.ErrForbidden, wantField: "priority", wantRoleCalls: 1,
+		},
+		{
+			name:    "clearing a locked field",
+			in:      usecase.UpdateTaskInput{DueDate: domain.Null[time.Time](), ActorID: "member-1"},
+			wantErr: usecase.ErrForbidden, wantField: "dueDate", wantRoleCalls: 1,
+		},
+		{
+			name:    "not a member",
+			in:      usecase.UpdateTaskInput{Priority: domain.Set("high"), ActorID: "stranger"},
+			wantErr: usecase.ErrForbidden, wantField: "priority", wantRoleCalls: 1,
+		},
+		{name: "no actor", in: usecase.UpdateTaskInput{Priority: domain.Set("high")}, wantErr: usecase.ErrActorRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newUpdateTestRepo(t)
+			policy := &fakeFieldLockPolicy{
+				locks: map[string][]string{"priority": {"owner", "admin"}, "dueDate": {"owner", "admin"}},
+				roles: map[string]string{"admin-1": "admin", "member-1": "member"},
+			}
+			uc := &usecase.UpdateTaskUsecase{Repo: repo, FieldLocks: policy}
+
+			in := tt.in
+			in.ID = "task-1"
+			_, err := uc.Execute(context.Background(), in)
+			if policy.roleCalls != tt.wantRoleCalls {
+				t.Errorf("expected %d MemberRole calls, got %d", tt.wantRoleCalls, policy.roleCalls)
+			}
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if repo.saved != nil {
+				t.Error("expected the task not to be updated")
+			}
+			if tt.wantField == "" {
+				return
+			}
+			var ve *domain.ValidationError
+			if !errors.As(err, &ve) || ve.Field != tt.wantField || ve.Code != "FIELD_FORBIDDEN" {
+				t.Errorf("expected FIELD_FORBIDDEN on %s, got %v", tt.wantField, err)
+			}
+		})
+	}
+}
+
+func TestUpdateTaskUsecase_BaseVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         usecase.UpdateTaskInput
+		wantFields []string // 衝突したフィールド（nil は衝突なし）
+		wantErr    bool
+	}{
+		{name: "no base version", in: usecase.UpdateTaskInput{Title: domain.Set("updated")}},
+		{name: "current version", in: usecase.UpdateTaskInput{Title: domain.Set("updated"), BaseVersion: 3}},
+		{name: "stale version", in: usecase.UpdateTaskInput{Priority: domain.Set("high"), BaseVersion: 2}, wantErr: true},
+		{name: "merge unrelated field", in: usecase.UpdateTaskInput{Priority: domain.Set("high"), BaseVersion: 1, MergeFields: true}},
+		{
+			name:    "merge field changed later",
+			in:      usecase.UpdateTaskInput{Priority: domain.Set("high"), Status: domain.Set("todo"), BaseVersion: 2, MergeFields: true},
+			wantErr: true, wantFields: []string{"status"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newUpdateTestRepo(t)
+			// 版数 2 で title、版数 3 で status を変更した
+			task := repo.listOut[0]
+			_ = task.ApplyPatch(domain.TaskPatch{Title: domain.Set("renamed")}, task.CreatedAt)
+			_ = task.ApplyPatch(domain.TaskPatch{Status: domain.Set(domain.StatusInProgress)}, task.CreatedAt)
+			uc := &usecase.UpdateTaskUsecase{Repo: repo}
+
+			in := tt.in
+			in.ID = "task-1"
+			got, err := uc.Execute(context.Background(), in)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got.Version != 4 {
+					t.Errorf("expected version 4, got %d", got.Version)
+				}
+				return
+			}
+			var conflict *domain.VersionConflictError
+			if !errors.As(err, &conflict) || conflict.Current != 3 || strings.Join(conflict.Fields, ",") != strings.Join(tt.wantFields, ",") {
+				t.Fatalf("expected VersionConflictError (current 3, fields %v), got %v", tt.wantFields, err)
+			}
+			if repo.saved != nil {
+				t.Error("expected the task not to be updated")
+			}
+		})
+	}
+}
+
+// racingTaskRepo は保存する前に他の更新があった（版数が進んだ）ものとして、Update を conflicts 回失敗させる。
+type racingTaskRepo struct {
+	*fakeTaskRepo
+	conflicts int
+	updates   int
+}
+
+func (r *racingTaskRepo) Update(ctx context.Context, t *domain.Task) error {
+	r.updates++
+	if r.conflicts > 0 {
+		r.conflicts--
+		return &domain.VersionConflictError{Current: t.Version}
+	}
+	return r.fakeTaskRepo.Update(ctx, t)
+}
+
+func TestUpdateTaskUsecase_RetriesRacedUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		conflicts   int
+		wantUpdates int
+		wantErr     bool
+	}{
+		{name: "no race", conflicts: 0, wantUpdates: 1},
+		{name: "raced once", conflicts: 1, wantUpdates: 2},
+		{name: "raced every attempt", conflicts: 5, wantUpdates: 3, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &racingTaskRepo{fakeTaskRepo: newUpdateTestRepo(t), conflicts: tt.conflicts}
+			tx := &fakeTxManager{}
+			uc := &usecase.UpdateTaskUsecase{Repo: repo, Tx: tx}
+
+			_, err := uc.Execute(context.Background(), usecase.UpdateTaskInput{ID: "task-1", Title: domain.Set("updated")})
+			if repo.updates != tt.wantUpdates || tx.calls != tt.wantUpdates {
+				t.Errorf("expected %d attempts, got %d updates in %d transactions", tt.wantUpdates, repo.updates, tx.calls)
+			}
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, domain.ErrVersionConflict) {
+				t.Errorf("expected ErrVersionConflict, got %v", err)
+			}
+		})
+	}
+}
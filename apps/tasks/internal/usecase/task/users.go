@@ -0,0 +1,71 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	domain "teamflow-tasks/internal/domain/task"
+)
+
+// UserProfile は users サービスのユーザーのうち、tasks で使う項目。
+type UserProfile struct {
+	ID        string
+	Name      string
+	AvatarURL string // 未設定は空文字
+}
+
+// UserDirectory はユーザーをまとめて取得する。
+// users サービスの POST /users:lookup を呼ぶクライアントなどで実装する。
+// 存在しない ID は戻り値の map に含めない（エラーにしない）。
+type UserDirectory interface {
+	LookupUsers(ctx context.Context, ids []string) (map[string]UserProfile, error)
+}
+
+// checkAssignee は directory が設定されていれば、担当者のユーザーが存在するか確認する。
+// 存在しない場合は ErrInvalidInput でラップした ErrAssigneeNotFound を返す。
+func checkAssignee(ctx context.Context, directory UserDirectory, assigneeID string) error {
+	if directory == nil {
+		return nil
+	}
+	users, err := directory.LookupUsers(ctx, []string{assigneeID})
+	if err != nil {
+		return err
+	}
+	if _, ok := users[assigneeID]; !ok {
+		return fmt.Errorf("%w: %w", ErrInvalidInput, ErrAssigneeNotFound)
+	}
+	return nil
+}
+
+// AssigneeNames は tasks の担当者の表示名（担当者 ID → 名前）を返す。
+// Users が設定されていない場合や担当者がいない場合は nil。users サービスに存在しない担当者は含めない。
+func (uc *ListTasksByProjectUsecase) AssigneeNames(ctx context.Context, tasks []*domain.Task) (map[string]string, error) {
+	if uc.Users == nil {
+		return nil, nil
+	}
+	seen := make(map[string]struct{})
+	var ids []string
+	for _, t := range tasks {
+		if t.AssigneeID == nil || *t.AssigneeID == "" {
+			continue
+		}
+		if _, ok := seen[*t.AssigneeID]; ok {
+			continue
+		}
+		seen[*t.AssigneeID] = struct{}{}
+		ids = append(ids, *t.AssigneeID)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	users, err := uc.Users.LookupUsers(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(users))
+	for id, u := range users {
+		names[id] = u.Name
+	}
+	return names, nil
+}
@@ -0,0 +1,151 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-tasks/internal/domain/task"
+	usecase "teamflow-tasks/internal/usecase/task"
+)
+
+// fakeUserDirectory は UserDirectory のテスト用フェイク実装。
+type fakeUserDirectory struct {
+	users map[string]string // ID → 名前
+	err   error
+	calls [][]string
+}
+
+func (d *fakeUserDirectory) LookupUsers(_ context.Context, ids []string) (map[string]usecase.UserProfile, error) {
+	d.calls = append(d.calls, ids)
+	if d.err != nil {
+		return nil, d.err
+	}
+	found := make(map[string]usecase.UserProfile)
+	for _, id := range ids {
+		if name, ok := d.users[id]; ok {
+			found[id] = usecase.UserProfile{ID: id, Name: name}
+		}
+	}
+	return found, nil
+}
+
+func TestCreateTask_AssigneeUser(t *testing.T) {
+	users := &fakeUserDirectory{users: map[string]string{"user-1": "Taro"}}
+
+	tests := []struct {
+		name     string
+		assignee string
+		wantErr  error
+	}{
+		{name: "existing user", assignee: "user-1"},
+		{name: "unknown user", assignee: "user-9", wantErr: usecase.ErrAssigneeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeTaskRepo{}
+			uc := &usecase.CreateTaskUsecase{Repo: repo, Users: users}
+
+			_, err := uc.Execute(context.Background(), usecase.CreateTaskInput{
+				ID: "task-1", ProjectID: "proj-1", Title: "画面設計", Status: domain.StatusTodo, Priority: domain.PriorityLow,
+				AssigneeID: tt.assignee, Now: time.Now(),
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || !errors.Is(err, usecase.ErrInvalidInput) {
+					t.Fatalf("expected %v wrapped in ErrInvalidInput, got %v", tt.wantErr, err)
+				}
+				if repo.saved != nil {
+					t.Fatal("expected task not to be saved")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateTask_AssigneeUserLookupError(t *testing.T) {
+	lookupErr := errors.New("users service unavailable")
+	uc := &usecase.CreateTaskUsecase{Repo: &fakeTaskRepo{}, Users: &fakeUserDirectory{err: lookupErr}}
+
+	_, err := uc.Execute(context.Background(), usecase.CreateTaskInput{
+		ID: "task-1", ProjectID: "proj-1", Title: "画面設計", Status: domain.StatusTodo, Priority: domain.PriorityLow,
+		AssigneeID: "user-1", Now: time.Now(),
+	})
+	if !errors.Is(err, lookupErr) || errors.Is(err, usecase.ErrInvalidInput) {
+		t.Fatalf("expected %v, got %v", lookupErr, err)
+	}
+}
+
+func TestUpdateTaskUsecase_AssigneeUser(t *testing.T) {
+	tests := []struct {
+		name      string
+		assignee  domain.Patch[string]
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "existing user", assignee: domain.Set("user-1"), wantCalls: 1},
+		{name: "unknown user", assignee: domain.Set("user-9"), wantErr: usecase.ErrAssigneeNotFound, wantCalls: 1},
+		{name: "unassign is not checked", assignee: domain.Null[string](), wantCalls: 0},
+		{name: "unset is not checked", assignee: domain.Unset[string](), wantCalls: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &fakeUserDirectory{users: map[string]string{"user-1": "Taro"}}
+			uc := &usecase.UpdateTaskUsecase{Repo: newUpdateTestRepo(t), Users: users}
+
+			_, err := uc.Execute(context.Background(), usecase.UpdateTaskInput{
+				ID:         "task-1",
+				AssigneeID: tt.assignee,
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || !errors.Is(err, usecase.ErrInvalidInput) {
+					t.Fatalf("expected %v wrapped in ErrInvalidInput, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(users.calls) != tt.wantCalls {
+				t.Errorf("expected %d LookupUsers calls, got %d", tt.wantCalls, len(users.calls))
+			}
+		})
+	}
+}
+
+func TestListTasksByProject_AssigneeNames(t *testing.T) {
+	assignee := func(id string) *string { return &id }
+	tasks := []*domain.Task{
+		{ID: "t1", AssigneeID: assignee("user-1")},
+		{ID: "t2", AssigneeID: assignee("user-2")},
+		{ID: "t3", AssigneeID: assignee("user-1")},
+		{ID: "t4"},
+	}
+
+	users := &fakeUserDirectory{users: map[string]string{"user-1": "Taro"}}
+	uc := &usecase.ListTasksByProjectUsecase{Users: users}
+	names, err := uc.AssigneeNames(context.Background(), tasks)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(names) != 1 || names["user-1"] != "Taro" {
+		t.Errorf("unexpected names: %v", names)
+	}
+	if len(users.calls) != 1 || len(users.calls[0]) != 2 {
+		t.Errorf("expected one lookup of the distinct assignees, got %v", users.calls)
+	}
+
+	// 担当者がいなければ問い合わせない
+	if _, err := uc.AssigneeNames(context.Background(), tasks[3:]); err != nil || len(users.calls) != 1 {
+		t.Errorf("expected no lookup without assignees, got err=%v calls=%d", err, len(users.calls))
+	}
+
+	// Users が無ければ名前を返さない
+	names, err = (&usecase.ListTasksByProjectUsecase{}).AssigneeNames(context.Background(), tasks)
+	if err != nil || names != nil {
+		t.Errorf("expected no names without Users, got %v, %v", names, err)
+	}
+}
@@ -0,0 +1,30 @@
+// Package schema は tasks サービスのデータベースのマイグレーションを、サービスの外（teamflowctl）から実行できるように公開する。
+// マイグレーション自体は internal/infrastructure/migration に埋め込んである。
+package schema
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"teamflow-tasks/internal/infrastructure/migration"
+)
+
+// Migrator はスキーマのマイグレーションの適用・巻き戻しを行う。
+type Migrator interface {
+	// Up は未適用のマイグレーションをすべて適用する。
+	Up(ctx context.Context) error
+	// Down は適用済みのマイグレーションを新しい順に steps 件巻き戻す（0 はすべて）。
+	Down(ctx context.Context, steps int) error
+	// Version は適用済みの最新のバージョンを返す（未適用の場合は 0）。
+	Version(ctx context.Context) (int64, error)
+}
+
+// NewMigrator は db のスキーマを管理する Migrator を生成する。
+func NewMigrator(db *pgxpool.Pool) (Migrator, error) {
+	m, err := migration.New(db)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"teamflow-shared/client"
+	sharedconfig "teamflow-shared/config"
+	"teamflow-shared/workspace"
+)
+
+const (
+	// defaultProjectsURL / defaultTasksURL はローカルで起動したサービス（各サービスの PORT の既定値）。
+	defaultProjectsURL = "http://localhost:8080"
+	defaultTasksURL    = "http://localhost:8081"
+)
+
+// services は DB を持つ（migrate の対象の）サービス。migrate all はこの順に実行する。
+var services = []string{"users", "projects", "tasks"}
+
+// config は環境変数から読み込んだ teamflowctl の設定。
+type config struct {
+	// API の呼び出し先
+	ProjectsURL string
+	TasksURL    string
+	// Token は個人用アクセストークン（tfp_...）。操作者とワークスペースはサーバーがトークンから決める
+	Token string
+	// UserID は操作者（X-User-ID）。認証の無いローカルのサービスを呼び出す場合に使う
+	UserID string
+	// Workspace は X-Workspace-ID
+	Workspace string
+	// Timeout は API の 1 回の呼び出しのタイムアウト
+	Timeout time.Duration
+
+	// DBDSNs はサービスごとの PostgreSQL の接続文字列（migrate で使う、未設定のサービスは含まない）
+	DBDSNs map[string]string
+	// CursorSecret は decode-cursor で署名を検証する鍵（サービスの CURSOR_SECRET）。空の場合は検証しない
+	CursorSecret string
+}
+
+// loadConfig は環境変数（と CONFIG_FILE の設定ファイル）から設定を読み込み、検証する。
+// 不正な値はまとめて 1 つのエラーとして返す。
+//
+//	CONFIG_FILE             KEY=VALUE 形式の設定ファイル（環境変数に無い値を補う、default: 無し）
+//	TEAMFLOW_PROJECTS_URL   projects サービスのベース URL（default: http://localhost:8080）
+//	TEAMFLOW_TASKS_URL      tasks サービスのベース URL（default: http://localhost:8081）
+//	TEAMFLOW_TOKEN          個人用アクセストークン（tfp_...、default: 無し）
+//	TEAMFLOW_USER_ID        操作者（X-User-ID）。認証の無いローカルのサービス向け。TEAMFLOW_TOKEN と同時には指定できない（default: 無し）
+//	TEAMFLOW_WORKSPACE      ワークスペース（X-Workspace-ID、default: default）
+//	TEAMFLOW_TIMEOUT        API の 1 回の呼び出しのタイムアウト（default: 10s）
+//	USERS_DB_DSN            users サービスの DB（migrate 用、default: 無し）
+//	PROJECTS_DB_DSN         projects サービスの DB（migrate 用、default: 無し）
+//	TASKS_DB_DSN            tasks サービスの DB（migrate 用、default: 無し）
+//	CURSOR_SECRET           decode-cursor で署名を検証する鍵（default: 無し＝検証しない）
+func loadConfig(getenv func(string) string) (config, error) {
+	getenv, err := sharedconfig.Load(getenv)
+	if err != nil {
+		return config{}, fmt.Errorf("invalid configuration: %w", err)
+	}
+	p := sharedconfig.NewParser(getenv)
+
+	cfg := config{
+		ProjectsURL:  p.URL("TEAMFLOW_PROJECTS_URL"),
+		TasksURL:     p.URL("TEAMFLOW_TASKS_URL"),
+		Token:        p.Get("TEAMFLOW_TOKEN"),
+		UserID:       p.Get("TEAMFLOW_USER_ID"),
+		Timeout:      p.Duration("TEAMFLOW_TIMEOUT", client.DefaultTimeout),
+		DBDSNs:       map[string]string{},
+		CursorSecret: p.Get("CURSOR_SECRET"),
+	}
+	if cfg.ProjectsURL == "" {
+		cfg.ProjectsURL = defaultProjectsURL
+	}
+	if cfg.TasksURL == "" {
+		cfg.TasksURL = defaultTasksURL
+	}
+	if cfg.Token != "" && cfg.UserID != "" {
+		p.Errorf("TEAMFLOW_TOKEN and TEAMFLOW_USER_ID must not be set together (the token determines the user)")
+	}
+
+	ws, err := workspace.Parse(p.Get("TEAMFLOW_WORKSPACE"))
+	if err != nil {
+		p.Errorf("TEAMFLOW_WORKSPACE is invalid: %w", err)
+	}
+	cfg.Workspace = ws
+
+	for _, svc := range services {
+		key := strings.ToUpper(svc) + "_DB_DSN"
+		dsn := p.Get(key)
+		if dsn == "" {
+			continue
+		}
+		if _, err := pgxpool.ParseConfig(dsn); err != nil {
+			p.Errorf("%s is invalid: %w", key, err)
+			continue
+		}
+		cfg.DBDSNs[svc] = dsn
+	}
+
+	if err := p.Err(); err != nil {
+		return config{}, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func mapEnv(env map[string]string) func(string) string {
+	return func(key string) string { return env[key] }
+}
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	cfg, err := loadConfig(mapEnv(nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ProjectsURL != "http://localhost:8080" || cfg.TasksURL != "http://localhost:8081" {
+		t.Errorf("unexpected urls: projects=%q tasks=%q", cfg.ProjectsURL, cfg.TasksURL)
+	}
+	if cfg.Workspace != "default" || cfg.Timeout != 10*time.Second || len(cfg.DBDSNs) != 0 || cfg.CursorSecret != "" {
+		t.Errorf("unexpected defaults: %+v", cfg)
+	}
+}
+
+func TestLoadConfig_Overrides(t *testing.T) {
+	cfg, err := loadConfig(mapEnv(map[string]string{
+		"TEAMFLOW_PROJECTS_URL": "https://projects.example.com",
+		"TEAMFLOW_TASKS_URL":    "https://tasks.example.com",
+		"TEAMFLOW_TOKEN":        "tfp_abc",
+		"TEAMFLOW_WORKSPACE":    "acme",
+		"TEAMFLOW_TIMEOUT":      "30s",
+		"TASKS_DB_DSN":          "postgres://localhost:5432/tasks",
+		"CURSOR_SECRET":         "s3cr3t",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ProjectsURL != "https://projects.example.com" || cfg.TasksURL != "https://tasks.example.com" {
+		t.Errorf("unexpected urls: projects=%q tasks=%q", cfg.ProjectsURL, cfg.TasksURL)
+	}
+	if cfg.Token != "tfp_abc" || cfg.Workspace != "acme" || cfg.Timeout != 30*time.Second || cfg.CursorSecret != "s3cr3t" {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if len(cfg.DBDSNs) != 1 || cfg.DBDSNs["tasks"] != "postgres://localhost:5432/tasks" {
+		t.Errorf("DBDSNs = %v", cfg.DBDSNs)
+	}
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"token and user", map[string]string{"TEAMFLOW_TOKEN": "tfp_abc", "TEAMFLOW_USER_ID": "user-1"}, "must not be set together"},
+		{"invalid url", map[string]string{"TEAMFLOW_TASKS_URL": "tasks:8081"}, "TEAMFLOW_TASKS_URL"},
+		{"invalid workspace", map[string]string{"TEAMFLOW_WORKSPACE": "-acme"}, "TEAMFLOW_WORKSPACE"},
+		{"invalid timeout", map[string]string{"TEAMFLOW_TIMEOUT": "0"}, "TEAMFLOW_TIMEOUT"},
+		{"invalid dsn", map[string]string{"USERS_DB_DSN": "postgres://%zz"}, "USERS_DB_DSN"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadConfig(mapEnv(tt.env))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// signCursor はサービスの EncodeCursor と同じ形式の cursor を作る。
+func signCursor(t *testing.T, payload map[string]any, secret string) string {
+	t.Helper()
+	b, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(b)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(encoded))
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestInspectCursor(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	fresh := signCursor(t, map[string]any{"v": 1, "id": "task-1", "projectId": "proj-1", "iat": now.Add(-time.Hour).Unix()}, "s3cr3t")
+	stale := signCursor(t, map[string]any{"v": 1, "sort": "name", "key": "demo", "id": "proj-1", "iat": now.Add(-25 * time.Hour).Unix()}, "s3cr3t")
+
+	tests := []struct {
+		name          string
+		cursor        string
+		secret        string
+		wantExpired   bool
+		wantSignature string
+	}{
+		{name: "valid", cursor: fresh, secret: "s3cr3t", wantSignature: signatureValid},
+		{name: "other secret", cursor: fresh, secret: "other", wantSignature: signatureInvalid},
+		{name: "no secret", cursor: fresh, wantSignature: signatureUnverified},
+		{name: "tampered signature", cursor: fresh[:len(fresh)-2] + "!!", secret: "s3cr3t", wantSignature: signatureInvalid},
+		{name: "expired", cursor: stale, secret: "s3cr3t", wantExpired: true, wantSignature: signatureValid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := inspectCursor(tt.cursor, []byte(tt.secret), now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if report.Expired != tt.wantExpired || report.Signature != tt.wantSignature {
+				t.Errorf("expired=%v signature=%s, want expired=%v signature=%s", report.Expired, report.Signature, tt.wantExpired, tt.wantSignature)
+			}
+			if report.IssuedAt == nil || report.ExpiresAt.Sub(*report.IssuedAt) != 24*time.Hour {
+				t.Errorf("unexpected issuedAt=%v expiresAt=%v", report.IssuedAt, report.ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestInspectCursor_Invalid(t *testing.T) {
+	for name, cursor := range map[string]string{
+		"no signature":   "eyJ2IjoxfQ",
+		"empty payload":  ".sig",
+		"too many parts": "a.b.c",
+		"not base64":     "!!!.sig",
+		"not an object":  base64.RawURLEncoding.EncodeToString([]byte(`[1]`)) + ".sig",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := inspectCursor(cursor, nil, time.Now()); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"teamflow-shared/client"
+)
+
+// exportPageSize は export で 1 回に取得するタスクの件数（一覧 API の上限）。
+const exportPageSize = 200
+
+// exportFlushRows は export -format ndjson で書き出しをフラッシュする間隔（行数）。
+const exportFlushRows = 100
+
+// csvHeader は export -format csv の列。
+var csvHeader = []string{
+	"id", "number", "title", "description", "status", "priority", "assigneeId",
+	"dueDate", "startDate", "estimate", "milestoneId", "sprintId", "epicId", "labelIds",
+	"createdAt", "updatedAt",
+}
+
+// runExport は export サブコマンドを実行する。プロジェクトのタスクをすべてのページから取得して書き出す。
+// json / csv はすべて取得してから書き出し、ndjson は取得したタスクから 1 行ずつ書き出す（大きなプロジェクト向け）。
+//
+//	teamflowctl export -project <projectId> [-format json|csv|ndjson] [-status todo,in_progress] [-o file]
+func runExport(ctx context.Context, e *env, args []string) (err error) {
+	fs := e.newFlagSet("export", "")
+	projectID := fs.String("project", "", "書き出すプロジェクトの ID（必須）")
+	format := fs.String("format", "json", "出力形式（json, csv, ndjson）")
+	status := fs.String("status", "", "ステータスで絞り込む（カンマ区切りで複数指定できる）")
+	output := fs.String("o", "", "出力先のファイル（既定: 標準出力）")
+	if err := noArgs(fs, args); err != nil {
+		return err
+	}
+	if *projectID == "" {
+		return usagef("export: -project is required")
+	}
+	var write func(context.Context, io.Writer, iter.Seq2[client.Task, error]) (int, error)
+	switch *format {
+	case "json":
+		write = collected(writeTasksJSON)
+	case "csv":
+		write = collected(writeTasksCSV)
+	case "ndjson":
+		write = writeTasksNDJSON
+	default:
+		return usagef("export: unknown format %q (must be json, csv or ndjson)", *format)
+	}
+	tasks := e.tasks().ProjectTasks(ctx, *projectID, client.ListTasksOptions{
+		Status: *status,
+		Limit:  exportPageSize,
+	})
+
+	// 途中で失敗した場合は不完全なファイルを残さない
+	w := e.stdout
+	if *output != "" {
+		f, cerr := os.Create(*output)
+		if cerr != nil {
+			return cerr
+		}
+		defer func() {
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				_ = os.Remove(*output)
+			}
+		}()
+		w = f
+	}
+	n, err := write(ctx, w, tasks)
+	if err != nil {
+		return err
+	}
+	if *output != "" {
+		fmt.Fprintf(e.stderr, "exported %d tasks to %s\n", n, *output)
+	}
+	return nil
+}
+
+// collected は write をすべてのタスクを取得してから書き出す関数にする。取得に失敗した場合は何も書き出さない。
+func collected(write func(io.Writer, []client.Task) error) func(context.Context, io.Writer, iter.Seq2[client.Task, error]) (int, error) {
+	return func(_ context.Context, w io.Writer, it iter.Seq2[client.Task, error]) (int, error) {
+		tasks, err := client.Collect(it)
+		if err != nil {
+			return 0, err
+		}
+		return len(tasks), write(w, tasks)
+	}
+}
+
+// writeTasksNDJSON はタスクを取得した順に 1 行 1 件の JSON で書き出し、書き出した件数を返す。
+// すべてをメモリに保持せず、exportFlushRows 行ごとにフラッシュする。ctx が取り消された場合は途中で終了する。
+func writeTasksNDJSON(ctx context.Context, w io.Writer, tasks iter.Seq2[client.Task, error]) (n int, err error) {
+	bw := bufio.NewWriter(w)
+	defer func() {
+		if ferr := bw.Flush(); err == nil {
+			err = ferr
+		}
+	}()
+	enc := json.NewEncoder(bw)
+	for t, err := range tasks {
+		if err != nil {
+			return n, err
+		}
+		if err := ctx.Err(); err != nil {
+			return n, err
+		}
+		if err := enc.Encode(t); err != nil {
+			return n, err
+		}
+		n++
+		if n%exportFlushRows == 0 {
+			if err := bw.Flush(); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// writeTasksJSON はタスクを JSON の配列（API のレスポンスと同じ形式）で書き出す。
+func writeTasksJSON(w io.Writer, tasks []client.Task) error {
+	if tasks == nil {
+		tasks = []client.Task{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(tasks)
+}
+
+// writeTasksCSV はタスクを CSV で書き出す。期日は YYYY-MM-DD、日時は RFC3339、ラベルはセミコロン区切り、未設定の項目は空にする。
+func writeTasksCSV(w io.Writer, tasks []client.Task) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, t := range tasks {
+		record := []string{
+			t.ID,
+			strconv.Itoa(t.Number),
+			t.Title,
+			t.Description,
+			t.Status,
+			t.Priority,
+			deref(t.AssigneeID),
+			deref(t.DueDate),
+			formatTime(t.StartDate),
+			"",
+			deref(t.MilestoneID),
+			deref(t.SprintID),
+			deref(t.EpicID),
+			strings.Join(t.LabelIDs, ";"),
+			t.CreatedAt.Format(time.RFC3339),
+			t.UpdatedAt.Format(time.RFC3339),
+		}
+		if t.Estimate != nil {
+			record[9] = strconv.Itoa(*t.Estimate)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
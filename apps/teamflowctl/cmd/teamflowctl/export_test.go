@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"teamflow-shared/client"
+)
+
+func TestWriteTasksNDJSON(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// 最初のフラッシュの後、途中のタスクを取得したところで取り消す
+	tasks := func(yield func(client.Task, error) bool) {
+		for i := 1; i <= exportFlushRows*2; i++ {
+			if i == exportFlushRows+3 {
+				cancel()
+			}
+			if !yield(client.Task{ID: fmt.Sprintf("task-%d", i)}, nil) {
+				return
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	n, err := writeTasksNDJSON(ctx, &buf, tasks)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	// 取り消す前に取得した分はすべて行として書き出す
+	if want := exportFlushRows + 2; n != want || strings.Count(buf.String(), "\n") != want {
+		t.Errorf("expected %d lines, got n=%d:\n%s", want, n, buf.String())
+	}
+}
+
+func TestWriteTasksNDJSON_FetchError(t *testing.T) {
+	tasks := func(yield func(client.Task, error) bool) {
+		if !yield(client.Task{ID: "task-1"}, nil) {
+			return
+		}
+		yield(client.Task{}, errors.New("boom"))
+	}
+	var buf bytes.Buffer
+	n, err := writeTasksNDJSON(context.Background(), &buf, tasks)
+	if err == nil || err.Error() != "boom" || n != 1 || !strings.HasPrefix(buf.String(), `{"id":"task-1"`) {
+		t.Errorf("unexpected result: n=%d err=%v\n%s", n, err, buf.String())
+	}
+}
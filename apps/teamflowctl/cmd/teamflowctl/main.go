@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"teamflow-shared/client"
+	"teamflow-shared/requestid"
+	"teamflow-shared/workspace"
+)
+
+// command は teamflowctl のサブコマンド。
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, e *env, args []string) error
+}
+
+// commands は usage に表示する順のサブコマンド。
+var commands = []command{
+	{name: "migrate", summary: "サービスの DB のマイグレーションを適用・巻き戻す", run: runMigrate},
+	{name: "seed", summary: "デモ用のプロジェクトとタスクを作成する", run: runSeed},
+	{name: "list-projects", summary: "プロジェクトの一覧を表示する", run: runListProjects},
+	{name: "create-task", summary: "タスクを作成する", run: runCreateTask},
+	{name: "decode-cursor", summary: "一覧 API の cursor の中身を表示する（デバッグ用）", run: runDecodeCursor},
+	{name: "export", summary: "プロジェクトのタスクを JSON / CSV / NDJSON に書き出す", run: runExport},
+}
+
+// env はサブコマンドの実行環境。
+type env struct {
+	cfg    config
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// projects / tasks は API を呼び出す Client を返す。認証は TEAMFLOW_TOKEN（または TEAMFLOW_USER_ID）を使う。
+func (e *env) projects() *client.Client { return e.client(e.cfg.ProjectsURL) }
+func (e *env) tasks() *client.Client    { return e.client(e.cfg.TasksURL) }
+
+func (e *env) client(baseURL string) *client.Client {
+	httpClient := &http.Client{Timeout: e.cfg.Timeout, Transport: &requestid.Transport{}}
+	return client.New(baseURL, httpClient).WithToken(e.cfg.Token).WithActor(e.cfg.UserID)
+}
+
+// usageError は引数の誤り（終了コード 2）。
+type usageError struct{ msg string }
+
+func (e *usageError) Error() string { return e.msg }
+
+func usagef(format string, args ...any) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// errFlagReported はフラグの誤りを FlagSet が表示済みであることを表す（終了コード 2）。
+var errFlagReported = errors.New("invalid flags")
+
+// newFlagSet はサブコマンドのフラグを定義する FlagSet を生成する（エラーと -h は stderr に表示する）。
+func (e *env) newFlagSet(name, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: teamflowctl %s [flags]%s\n", name, args)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags は args をパースし、フラグ以外の引数を返す。
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, err
+		}
+		return nil, errFlagReported
+	}
+	return fs.Args(), nil
+}
+
+// noArgs はフラグ以外の引数を受け付けないサブコマンドで、余分な引数をエラーにする。
+func noArgs(fs *flag.FlagSet, args []string) error {
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return usagef("%s: unexpected arguments: %s", fs.Name(), strings.Join(rest, " "))
+	}
+	return nil
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Getenv, os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run は teamflowctl を実行し、終了コード（0: 成功、1: 失敗、2: 使い方の誤り）を返す。
+func run(ctx context.Context, args []string, getenv func(string) string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(stderr)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == args[0] {
+			cmd = &commands[i]
+			break
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(stderr, "teamflowctl: unknown command %q\n\n", args[0])
+		printUsage(stderr)
+		return 2
+	}
+
+	cfg, err := loadConfig(getenv)
+	if err != nil {
+		fmt.Fprintf(stderr, "teamflowctl: %v\n", err)
+		return 1
+	}
+	ctx = workspace.NewContext(ctx, cfg.Workspace)
+
+	err = cmd.run(ctx, &env{cfg: cfg, stdout: stdout, stderr: stderr}, args[1:])
+	var usageErr *usageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errFlagReported):
+		return 2
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(stderr, "teamflowctl: %v\n", err)
+		return 2
+	default:
+		fmt.Fprintf(stderr, "teamflowctl %s: %v\n", cmd.name, err)
+		return 1
+	}
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: teamflowctl <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-14s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "設定は環境変数（TEAMFLOW_PROJECTS_URL, TEAMFLOW_TASKS_URL, TEAMFLOW_TOKEN, *_DB_DSN など）で指定する。")
+	fmt.Fprintln(w, "各コマンドのフラグは teamflowctl <command> -h で表示する。")
+}
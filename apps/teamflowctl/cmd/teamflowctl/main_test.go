@@ -0,0 +1,257 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeAPI は projects / tasks サービスの代わりに、受け取ったリクエストを記録して固定のレスポンスを返す。
+type fakeAPI struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   []string
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body := new(bytes.Buffer)
+	_, _ = body.ReadFrom(r.Body)
+	f.mu.Lock()
+	f.requests = append(f.requests, r)
+	f.bodies = append(f.bodies, body.String())
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v1/projects":
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"proj-1","key":"DEMO","name":"デモ","status":"active","visibility":"private"}`))
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/projects":
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"projects":[{"id":"proj-1","key":"DEMO","name":"デモ","status":"active","taskCounts":{"open":3,"done":1}}],"page":{"nextCursor":"c2","limit":1}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"projects":[{"id":"proj-2","key":"OPS","name":"運用","status":"on_hold"}],"page":{"nextCursor":null,"limit":1}}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v1/projects/proj-1/tasks:batch":
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"tasks":[{"id":"task-1"},{"id":"task-2"}]}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v1/projects/proj-1/tasks":
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"task-9","projectId":"proj-1","number":9,"title":"新しいタスク","status":"todo","priority":"medium"}`))
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/projects/proj-1/tasks":
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"tasks":[{"id":"task-1","number":1,"title":"設計, レビュー","status":"done","priority":"high","estimate":3,"labelIds":["l1","l2"],"createdAt":"2026-10-01T09:00:00Z","updatedAt":"2026-10-02T09:00:00Z"}],"page":{"nextCursor":"c2","limit":200}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"tasks":[{"id":"task-2","number":2,"title":"実装","status":"todo","priority":"medium","assigneeId":"user-1","createdAt":"2026-10-03T09:00:00Z","updatedAt":"2026-10-03T09:00:00Z"}],"page":{"nextCursor":null,"limit":200}}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":"NOT_FOUND","message":"not found"}`))
+	}
+}
+
+// runCLI は fakeAPI を projects / tasks サービスとして teamflowctl を実行する。
+func runCLI(t *testing.T, api *fakeAPI, extraEnv map[string]string, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	env := map[string]string{
+		"TEAMFLOW_PROJECTS_URL": srv.URL,
+		"TEAMFLOW_TASKS_URL":    srv.URL,
+		"TEAMFLOW_TOKEN":        "tfp_test",
+		"TEAMFLOW_WORKSPACE":    "acme",
+	}
+	for k, v := range extraEnv {
+		env[k] = v
+	}
+	var out, errOut bytes.Buffer
+	code = run(context.Background(), args, mapEnv(env), &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+func TestRun_Seed(t *testing.T) {
+	api := &fakeAPI{}
+	code, stdout, stderr := runCLI(t, api, nil, "seed", "-assignee", "user-1")
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "created project proj-1 (DEMO) with 2 tasks") {
+		t.Errorf("unexpected output: %q", stdout)
+	}
+	if len(api.requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(api.requests))
+	}
+	for _, r := range api.requests {
+		if r.Header.Get("Authorization") != "Bearer tfp_test" || r.Header.Get("X-Workspace-ID") != "acme" {
+			t.Errorf("unexpected headers: %v", r.Header)
+		}
+	}
+	var batch struct {
+		Tasks []struct {
+			Status     string `json:"status"`
+			AssigneeID string `json:"assigneeId"`
+		} `json:"tasks"`
+	}
+	if err := json.Unmarshal([]byte(api.bodies[1]), &batch); err != nil {
+		t.Fatal(err)
+	}
+	if len(batch.Tasks) != len(demoTasks) {
+		t.Fatalf("expected %d tasks, got %d", len(demoTasks), len(batch.Tasks))
+	}
+	for _, task := range batch.Tasks {
+		if (task.Status == "in_progress") != (task.AssigneeID == "user-1") {
+			t.Errorf("unexpected assignee for %s task: %q", task.Status, task.AssigneeID)
+		}
+	}
+}
+
+func TestRun_ListProjects(t *testing.T) {
+	api := &fakeAPI{}
+	code, stdout, stderr := runCLI(t, api, nil, "list-projects", "-status", "active")
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "proj-1") || !strings.Contains(lines[2], "OPS") {
+		t.Fatalf("unexpected output:\n%s", stdout)
+	}
+	if fields := strings.Fields(lines[1]); fields[len(fields)-2] != "3" || fields[len(fields)-1] != "1" {
+		t.Errorf("expected task counts in %q", lines[1])
+	}
+	if q := api.requests[0].URL.Query(); q.Get("status") != "active" || q.Get("expand") != "taskCounts" {
+		t.Errorf("unexpected query: %v", q)
+	}
+
+	code, stdout, _ = runCLI(t, &fakeAPI{}, nil, "list-projects", "-json")
+	if code != 0 || strings.Count(stdout, "\n") != 2 || !strings.HasPrefix(stdout, `{"id":"proj-1"`) {
+		t.Errorf("unexpected json output (code %d):\n%s", code, stdout)
+	}
+}
+
+func TestRun_CreateTask(t *testing.T) {
+	api := &fakeAPI{}
+	code, stdout, stderr := runCLI(t, api, map[string]string{"TEAMFLOW_TOKEN": "", "TEAMFLOW_USER_ID": "user-1"},
+		"create-task", "-project", "proj-1", "-title", "新しいタスク", "-priority", "medium")
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	if stdout != "created task #9 task-9\n" {
+		t.Errorf("unexpected output: %q", stdout)
+	}
+	if api.requests[0].Header.Get("X-User-ID") != "user-1" || api.requests[0].Header.Get("Authorization") != "" {
+		t.Errorf("unexpected headers: %v", api.requests[0].Header)
+	}
+	if api.bodies[0] != `{"title":"新しいタスク","priority":"medium"}` {
+		t.Errorf("unexpected body: %s", api.bodies[0])
+	}
+}
+
+func TestRun_Export(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.csv")
+	api := &fakeAPI{}
+	code, _, stderr := runCLI(t, api, nil, "export", "-project", "proj-1", "-format", "csv", "-o", path)
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	if q := api.requests[0].URL.Query(); q.Get("limit") != "200" {
+		t.Errorf("unexpected query: %v", q)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 || records[0][0] != "id" {
+		t.Fatalf("unexpected records: %v", records)
+	}
+	if got := records[1]; got[2] != "設計, レビュー" || got[9] != "3" || got[13] != "l1;l2" || got[14] != "2026-10-01T09:00:00Z" {
+		t.Errorf("unexpected first row: %v", got)
+	}
+	if got := records[2]; got[6] != "user-1" || got[9] != "" {
+		t.Errorf("unexpected second row: %v", got)
+	}
+
+	code, stdout, _ := runCLI(t, &fakeAPI{}, nil, "export", "-project", "proj-1")
+	var tasks []map[string]any
+	if err := json.Unmarshal([]byte(stdout), &tasks); code != 0 || err != nil || len(tasks) != 2 {
+		t.Errorf("unexpected json export (code %d, err %v):\n%s", code, err, stdout)
+	}
+
+	code, stdout, _ = runCLI(t, &fakeAPI{}, nil, "export", "-project", "proj-1", "-format", "ndjson")
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if code != 0 || len(lines) != 2 || !strings.HasPrefix(lines[0], `{"id":"task-1"`) || !strings.HasPrefix(lines[1], `{"id":"task-2"`) {
+		t.Errorf("unexpected ndjson export (code %d):\n%s", code, stdout)
+	}
+}
+
+func TestRun_Export_APIError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	code, _, stderr := runCLI(t, &fakeAPI{}, nil, "export", "-project", "missing", "-o", path)
+	if code != 1 || !strings.Contains(stderr, "teamflowctl export:") {
+		t.Errorf("expected exit code 1 with the error, got %d: %s", code, stderr)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected no file to be written, got %v", err)
+	}
+}
+
+func TestRun_DecodeCursor(t *testing.T) {
+	cursor := signCursor(t, map[string]any{"v": 1, "id": "task-1", "iat": 1}, "s3cr3t")
+	code, stdout, stderr := runCLI(t, &fakeAPI{}, map[string]string{"CURSOR_SECRET": "s3cr3t"}, "decode-cursor", cursor)
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	var report struct {
+		Payload   map[string]any `json:"payload"`
+		Expired   bool           `json:"expired"`
+		Signature string         `json:"signature"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("failed to decode output: %v\n%s", err, stdout)
+	}
+	if report.Payload["id"] != "task-1" || !report.Expired || report.Signature != signatureValid {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
+
+func TestRun_Usage(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		env      map[string]string
+		wantCode int
+		wantErr  string
+	}{
+		{name: "no command", wantCode: 2, wantErr: "usage: teamflowctl"},
+		{name: "help", args: []string{"help"}, wantCode: 0, wantErr: "commands:"},
+		{name: "unknown command", args: []string{"deploy"}, wantCode: 2, wantErr: `unknown command "deploy"`},
+		{name: "unknown flag", args: []string{"list-projects", "-verbose"}, wantCode: 2, wantErr: "flag provided but not defined"},
+		{name: "command help", args: []string{"export", "-h"}, wantCode: 0, wantErr: "usage: teamflowctl export"},
+		{name: "missing required flag", args: []string{"create-task", "-title", "x"}, wantCode: 2, wantErr: "-project and -title are required"},
+		{name: "extra args", args: []string{"seed", "now"}, wantCode: 2, wantErr: "unexpected arguments: now"},
+		{name: "unknown format", args: []string{"export", "-project", "p", "-format", "xml"}, wantCode: 2, wantErr: `unknown format "xml"`},
+		{name: "invalid config", args: []string{"list-projects"}, env: map[string]string{"TEAMFLOW_USER_ID": "user-1"}, wantCode: 1, wantErr: "must not be set together"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := runCLI(t, &fakeAPI{}, tt.env, tt.args...)
+			if code != tt.wantCode || !strings.Contains(stderr, tt.wantErr) {
+				t.Errorf("got code %d, stderr %q; want code %d containing %q", code, stderr, tt.wantCode, tt.wantErr)
+			}
+		})
+	}
+}
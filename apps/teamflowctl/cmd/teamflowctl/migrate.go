@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	projectsschema "teamflow-projects/schema"
+	tasksschema "teamflow-tasks/schema"
+	usersschema "teamflow-users/schema"
+)
+
+// migrator は各サービスの schema.Migrator。
+type migrator interface {
+	Up(ctx context.Context) error
+	Down(ctx context.Context, steps int) error
+	Version(ctx context.Context) (int64, error)
+}
+
+// migrators はサービスごとの Migrator の生成。
+var migrators = map[string]func(*pgxpool.Pool) (migrator, error){
+	"users":    func(db *pgxpool.Pool) (migrator, error) { return usersschema.NewMigrator(db) },
+	"projects": func(db *pgxpool.Pool) (migrator, error) { return projectsschema.NewMigrator(db) },
+	"tasks":    func(db *pgxpool.Pool) (migrator, error) { return tasksschema.NewMigrator(db) },
+}
+
+const migrateUsage = "usage: teamflowctl migrate <users|projects|tasks|all> <up|down [steps]|version>"
+
+// migrateCommand は migrate サブコマンドの引数をパースした結果。
+type migrateCommand struct {
+	services []string
+	action   string // up, down, version
+	steps    int    // down のみ。0 はすべて巻き戻す
+	all      bool
+}
+
+// parseMigrateArgs は "migrate" 以降の引数をパースする。
+//
+//	teamflowctl migrate tasks up
+//	teamflowctl migrate tasks down [steps]   （steps 省略時は 1）
+//	teamflowctl migrate all up               （users → projects → tasks の順）
+//	teamflowctl migrate all version
+//
+// 誤って全サービスを巻き戻さないよう、all と down は組み合わせられない。
+func parseMigrateArgs(args []string) (migrateCommand, error) {
+	if len(args) < 2 {
+		return migrateCommand{}, usagef(migrateUsage)
+	}
+
+	var cmd migrateCommand
+	switch target := args[0]; {
+	case target == "all":
+		cmd.services = services
+		cmd.all = true
+	case slices.Contains(services, target):
+		cmd.services = []string{target}
+	default:
+		return migrateCommand{}, usagef("unknown service %q (must be one of %s, all)", target, strings.Join(services, ", "))
+	}
+
+	cmd.action = args[1]
+	switch cmd.action {
+	case "up", "version":
+		if len(args) > 2 {
+			return migrateCommand{}, usagef("migrate %s takes no arguments", cmd.action)
+		}
+	case "down":
+		if cmd.all {
+			return migrateCommand{}, usagef("migrate all down is not supported; roll back each service explicitly")
+		}
+		cmd.steps = 1
+		if len(args) > 3 {
+			return migrateCommand{}, usagef("migrate down takes at most one argument")
+		}
+		if len(args) == 3 {
+			steps, err := strconv.Atoi(args[2])
+			if err != nil || steps < 0 {
+				return migrateCommand{}, usagef("invalid steps %q: must be a non-negative integer", args[2])
+			}
+			cmd.steps = steps
+		}
+	default:
+		return migrateCommand{}, usagef("unknown migrate action %q", cmd.action)
+	}
+	return cmd, nil
+}
+
+// runMigrate は migrate サブコマンドを実行する。
+// all の場合は <SERVICE>_DB_DSN が設定されたサービスのみを対象にし、設定されていないサービスはスキップする。
+func runMigrate(ctx context.Context, e *env, args []string) error {
+	cmd, err := parseMigrateArgs(args)
+	if err != nil {
+		return err
+	}
+
+	ran := 0
+	for _, svc := range cmd.services {
+		dsn := e.cfg.DBDSNs[svc]
+		if dsn == "" {
+			if cmd.all {
+				fmt.Fprintf(e.stdout, "%s: skipped (%s_DB_DSN is not set)\n", svc, strings.ToUpper(svc))
+				continue
+			}
+			return fmt.Errorf("%s_DB_DSN must be set to run migrations", strings.ToUpper(svc))
+		}
+		version, err := migrate(ctx, svc, dsn, cmd)
+		if err != nil {
+			return fmt.Errorf("%s: %w", svc, err)
+		}
+		fmt.Fprintf(e.stdout, "%s: schema version %d\n", svc, version)
+		ran++
+	}
+	if ran == 0 {
+		return fmt.Errorf("no database is configured (set %s)", strings.Join(dsnKeys(), ", "))
+	}
+	return nil
+}
+
+// migrate は 1 つのサービスの DB にマイグレーションを実行し、実行後のスキーマのバージョンを返す。
+func migrate(ctx context.Context, svc, dsn string, cmd migrateCommand) (int64, error) {
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect database: %w", err)
+	}
+	defer pool.Close()
+
+	m, err := migrators[svc](pool)
+	if err != nil {
+		return 0, err
+	}
+
+	switch cmd.action {
+	case "up":
+		err = m.Up(ctx)
+	case "down":
+		err = m.Down(ctx, cmd.steps)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return m.Version(ctx)
+}
+
+func dsnKeys() []string {
+	keys := make([]string, len(services))
+	for i, svc := range services {
+		keys[i] = strings.ToUpper(svc) + "_DB_DSN"
+	}
+	return keys
+}
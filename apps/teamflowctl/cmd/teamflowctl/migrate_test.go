@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMigrateArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    migrateCommand
+		wantErr bool
+	}{
+		{name: "up", args: []string{"tasks", "up"}, want: migrateCommand{services: []string{"tasks"}, action: "up"}},
+		{name: "version", args: []string{"users", "version"}, want: migrateCommand{services: []string{"users"}, action: "version"}},
+		{name: "all up", args: []string{"all", "up"}, want: migrateCommand{services: []string{"users", "projects", "tasks"}, action: "up", all: true}},
+		{name: "down defaults to 1 step", args: []string{"projects", "down"}, want: migrateCommand{services: []string{"projects"}, action: "down", steps: 1}},
+		{name: "down with steps", args: []string{"projects", "down", "3"}, want: migrateCommand{services: []string{"projects"}, action: "down", steps: 3}},
+		{name: "down all steps", args: []string{"projects", "down", "0"}, want: migrateCommand{services: []string{"projects"}, action: "down", steps: 0}},
+		{name: "no args", args: nil, wantErr: true},
+		{name: "no action", args: []string{"tasks"}, wantErr: true},
+		{name: "unknown service", args: []string{"auth", "up"}, wantErr: true},
+		{name: "unknown action", args: []string{"tasks", "redo"}, wantErr: true},
+		{name: "up with extra args", args: []string{"tasks", "up", "1"}, wantErr: true},
+		{name: "all down", args: []string{"all", "down"}, wantErr: true},
+		{name: "down with negative steps", args: []string{"tasks", "down", "-1"}, wantErr: true},
+		{name: "down with invalid steps", args: []string{"tasks", "down", "x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMigrateArgs(tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunMigrate_MissingDSN(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantErr    string
+		wantOutput string
+	}{
+		{name: "single service", args: []string{"tasks", "up"}, wantErr: "TASKS_DB_DSN must be set"},
+		{name: "all", args: []string{"all", "version"}, wantErr: "no database is configured", wantOutput: "users: skipped (USERS_DB_DSN is not set)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			e := &env{cfg: config{DBDSNs: map[string]string{}}, stdout: &stdout, stderr: &bytes.Buffer{}}
+			err := runMigrate(context.Background(), e, tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if !strings.Contains(stdout.String(), tt.wantOutput) {
+				t.Errorf("expected output to contain %q, got %q", tt.wantOutput, stdout.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+
+	"teamflow-shared/client"
+)
+
+// runListProjects は list-projects サブコマンドを実行する。すべてのページをたどって表示する。
+//
+//	teamflowctl list-projects [-q text] [-status active] [-archived] [-json]
+func runListProjects(ctx context.Context, e *env, args []string) error {
+	fs := e.newFlagSet("list-projects", "")
+	query := fs.String("q", "", "名前・キーで絞り込む")
+	status := fs.String("status", "", "ステータスで絞り込む（active, on_hold, completed）")
+	archived := fs.Bool("archived", false, "アーカイブ済みのプロジェクトを表示する")
+	asJSON := fs.Bool("json", false, "JSON（1 行 1 プロジェクト）で出力する")
+	if err := noArgs(fs, args); err != nil {
+		return err
+	}
+
+	projects, err := client.Collect(e.projects().Projects(ctx, client.ListProjectsOptions{
+		Query:            *query,
+		Status:           *status,
+		Archived:         archived,
+		ExpandTaskCounts: true,
+	}))
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(e.stdout)
+		for _, p := range projects {
+			if err := enc.Encode(p); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(e.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tKEY\tNAME\tSTATUS\tOPEN\tDONE")
+	for _, p := range projects {
+		open, done := "-", "-"
+		if p.TaskCounts != nil {
+			open, done = strconv.Itoa(p.TaskCounts.Open), strconv.Itoa(p.TaskCounts.Done)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", p.ID, p.Key, p.Name, p.Status, open, done)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"teamflow-shared/client"
+)
+
+// demoTasks は seed で作成するタスク。ボード・フィルタ・集計を試せるよう、ステータスと優先度を散らしてある。
+var demoTasks = []client.CreateTaskRequest{
+	{Title: "要件を整理する", Description: "デモ用のタスク。関係者へのヒアリング結果をまとめる。", Status: "done", Priority: "high"},
+	{Title: "画面設計をレビューする", Status: "done", Priority: "medium"},
+	{Title: "API のスキーマを決める", Description: "OpenAPI に一覧・作成・更新のエンドポイントを定義する。", Status: "in_progress", Priority: "high"},
+	{Title: "ログイン画面を実装する", Status: "in_progress", Priority: "medium"},
+	{Title: "タスクの一覧を実装する", Status: "todo", Priority: "high"},
+	{Title: "通知メールの文面を考える", Status: "todo", Priority: "low"},
+	{Title: "E2E テストを書く", Status: "todo", Priority: "medium"},
+	{Title: "リリースノートを書く", Status: "todo", Priority: "low"},
+}
+
+// runSeed は seed サブコマンドを実行する。projects サービスにデモ用のプロジェクトを作成し、
+// tasks サービスにそのタスクを一括作成する（ローカル環境の動作確認用）。
+//
+//	teamflowctl seed [-key DEMO] [-name "デモプロジェクト"] [-assignee userId]
+func runSeed(ctx context.Context, e *env, args []string) error {
+	fs := e.newFlagSet("seed", "")
+	key := fs.String("key", "DEMO", "作成するプロジェクトのキー")
+	name := fs.String("name", "デモプロジェクト", "作成するプロジェクトの名前")
+	assignee := fs.String("assignee", "", "進行中のタスクの担当者（ユーザー ID、既定: 担当者なし）")
+	if err := noArgs(fs, args); err != nil {
+		return err
+	}
+
+	project, err := e.projects().CreateProject(ctx, client.CreateProjectRequest{
+		Key:         *key,
+		Name:        *name,
+		Description: "teamflowctl seed で作成したデモ用のプロジェクト。",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	reqs := make([]client.CreateTaskRequest, len(demoTasks))
+	for i, t := range demoTasks {
+		if t.Status == "in_progress" {
+			t.AssigneeID = *assignee
+		}
+		reqs[i] = t
+	}
+	tasks, err := e.tasks().BatchCreateTasks(ctx, project.ID, reqs)
+	if err != nil {
+		return fmt.Errorf("created project %s but failed to create tasks: %w", project.ID, err)
+	}
+
+	fmt.Fprintf(e.stdout, "created project %s (%s) with %d tasks\n", project.ID, project.Key, len(tasks))
+	return nil
+}
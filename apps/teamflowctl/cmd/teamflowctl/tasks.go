@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"teamflow-shared/client"
+)
+
+// runCreateTask は create-task サブコマンドを実行する。省略した項目はサービスの既定値になる。
+//
+//	teamflowctl create-task -project <projectId> -title "..." [-description ...] [-status todo] [-priority high] [-assignee userId]
+func runCreateTask(ctx context.Context, e *env, args []string) error {
+	fs := e.newFlagSet("create-task", "")
+	projectID := fs.String("project", "", "タスクを作成するプロジェクトの ID（必須）")
+	var req client.CreateTaskRequest
+	fs.StringVar(&req.Title, "title", "", "タイトル（必須）")
+	fs.StringVar(&req.Description, "description", "", "説明")
+	fs.StringVar(&req.Status, "status", "", "ステータス（todo, in_progress, done）")
+	fs.StringVar(&req.Priority, "priority", "", "優先度（low, medium, high）")
+	fs.StringVar(&req.AssigneeID, "assignee", "", "担当者のユーザー ID")
+	if err := noArgs(fs, args); err != nil {
+		return err
+	}
+	if *projectID == "" || req.Title == "" {
+		return usagef("create-task: -project and -title are required")
+	}
+
+	task, err := e.tasks().CreateTask(ctx, *projectID, req)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stdout, "created task #%d %s\n", task.Number, task.ID)
+	return nil
+}
@@ -0,0 +1,27 @@
+module teamflowctl
+
+go 1.23.0
+
+require (
+	github.com/jackc/pgx/v5 v5.7.5
+	teamflow-projects v0.0.0
+	teamflow-shared v0.0.0
+	teamflow-tasks v0.0.0
+	teamflow-users v0.0.0
+)
+
+require (
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
+	github.com/jackc/puddle/v2 v2.2.2 // indirect
+	golang.org/x/crypto v0.41.0 // indirect
+	golang.org/x/sync v0.16.0 // indirect
+	golang.org/x/text v0.28.0 // indirect
+)
+
+replace (
+	teamflow-projects => ../projects
+	teamflow-shared => ../../shared
+	teamflow-tasks => ../tasks
+	teamflow-users => ../users
+)
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"teamflow-shared/apiversion"
+	"teamflow-shared/authz"
+	sharedconfig "teamflow-shared/config"
+	"teamflow-shared/cors"
+	"teamflow-shared/logging"
+	"teamflow-shared/openapi"
+	"teamflow-shared/ratelimit"
+	"teamflow-shared/requestid"
+	"teamflow-shared/server"
+	"teamflow-shared/serviceauth"
+	"teamflow-shared/tracing"
+)
+
+const (
+	// defaultPort は API の listen ポートの既定値。
+	defaultPort = 8082
+	// defaultAdminPort はメトリクスなどの運用エンドポイントの listen ポートの既定値。
+	defaultAdminPort = 9092
+	// defaultJWTIssuer / defaultJWTAudience は受け付ける JWT の iss / aud の既定値（auth サービスの AUTH_ISSUER / AUTH_AUDIENCE と合わせる）。
+	defaultJWTIssuer   = "teamflow-auth"
+	defaultJWTAudience = "teamflow"
+)
+
+// config は環境変数から読み込んだ users サービスの設定。
+type config struct {
+	AppEnv string
+	// LogLevel はログの出力レベル（既定は info）
+	LogLevel slog.Level
+	// Port は API の listen ポート
+	Port int
+	// AdminPort はメトリクス（/metrics）を公開する listen ポート（API とは別）
+	AdminPort int
+	// ShutdownTimeout は SIGINT / SIGTERM を受けてから処理中のリクエストを待つ時間
+	ShutdownTimeout time.Duration
+
+	// TLS はクライアントとの接続の TLS の設定（未設定の場合は平文で待ち受ける）
+	TLS server.TLSOptions
+	// H2C は平文の接続で HTTP/2（h2c）を受け付けるか（TLS を終端したロードバランサー・サービス間の呼び出し用）
+	H2C bool
+
+	// ServiceAPIKeys はサービス間専用のエンドポイント（POST /users:lookup）で受け付けるキー（空の場合は認証しない）
+	ServiceAPIKeys []serviceauth.Key
+
+	// レート制限（RATE_LIMIT_TIERS。RateLimitTiers が空の場合は制限しない）
+	RateLimitTiers     map[string]int
+	RateLimitUserTiers map[string]string
+	// MaxInFlightRequests は同時に処理する API のリクエスト数の上限。超えた分は 503 で断る（0 の場合は制限しない）
+	MaxInFlightRequests int
+
+	// JWT（auth サービスが OIDC のログインで発行する）の検証（JWKSURL が空の場合は検証しない）
+	JWKSURL     string
+	JWTIssuer   string
+	JWTAudience string
+
+	// CORS はブラウザからの別オリジンのリクエストの許可設定
+	CORS cors.Options
+
+	// トレーシング（OTLPEndpoint が空の場合は記録しない）
+	OTLPEndpoint string
+	// ServiceName はトレースの service.name
+	ServiceName string
+	// TraceSampleRatio は新しいトレースを記録する割合（0〜1）
+	TraceSampleRatio float64
+
+	// OpenAPIValidation はリクエスト・レスポンスを OpenAPI の仕様で検証するかどうか（off / log / strict）
+	OpenAPIValidation openapi.Mode
+
+	// DB（DBDSN が空の場合はインメモリリポジトリを使う）
+	DBDSN              string
+	DBMaxConns         int32
+	DBMinConns         int32
+	DBStatementTimeout time.Duration
+}
+
+// useSQL は SQL リポジトリを使うかどうかを返す。
+func (c config) useSQL() bool {
+	return c.DBDSN != ""
+}
+
+// isProduction は本番環境かどうかを返す。
+func (c config) isProduction() bool {
+	return c.AppEnv == "production"
+}
+
+// addr は API の listen アドレスを返す。
+func (c config) addr() string {
+	return fmt.Sprintf(":%d", c.Port)
+}
+
+// adminAddr は運用エンドポイントの listen アドレスを返す。
+func (c config) adminAddr() string {
+	return fmt.Sprintf(":%d", c.AdminPort)
+}
+
+// loadConfig は環境変数（と CONFIG_FILE の設定ファイル）から設定を読み込み、検証する。
+// 不正な値はまとめて 1 つのエラーとして返す（起動時にすべて把握できるように）。
+//
+//	CONFIG_FILE             KEY=VALUE 形式の設定ファイル（環境変数に無い値を補う、default: 無し）
+//	APP_ENV                 production の場合は DB_DSN が必須
+//	LOG_LEVEL               ログレベル（debug / info / warn / error、default: info）
+//	PORT                    API の listen ポート（default: 8082）
+//	ADMIN_PORT              メトリクス（/metrics）の listen ポート（default: 9092、PORT と別にする）
+//	SHUTDOWN_TIMEOUT        停止時に処理中のリクエストを待つ時間（default: 20s）
+//	TLS_* / H2C             クライアントとの接続の TLS・h2c（server.ParseTLSOptions を参照、default: 平文の HTTP/1.1）
+//	SERVICE_API_KEYS        サービス間専用のエンドポイントで受け付けるキー（カンマ区切りの name:key[:rpm]、例: tasks:s3cr3t:600、default: 無し＝認証しない）
+//	RATE_LIMIT_TIERS        ティアごとの 1 分あたりのリクエスト数の上限（カンマ区切りの tier:rpm、例: anonymous:60,user:600,token:300、0 で無制限、default: 無し＝制限しない）
+//	RATE_LIMIT_USER_TIERS   既定と異なるティアを使う操作者（カンマ区切りの userId:tier、例: ci-bot:premium、default: 無し）
+//	MAX_IN_FLIGHT_REQUESTS  同時に処理するリクエスト数の上限。超えた分は 503 と Retry-After で断る（DB_MAX_CONNS の数倍を目安にする、0 で無制限、default: 0）
+//	JWKS_URL                JWT を検証する公開鍵（auth サービスの /.well-known/jwks.json、例: http://auth:8083/.well-known/jwks.json、default: 無し＝検証しない）
+//	JWT_ISSUER              受け付ける JWT の iss（default: teamflow-auth）
+//	JWT_AUDIENCE            受け付ける JWT の aud（default: teamflow）
+//	CORS_ALLOWED_ORIGINS    ブラウザから呼び出せるオリジン（カンマ区切り、* ですべて、default: http://localhost:3000,http://127.0.0.1:3000）
+//	CORS_ALLOWED_METHODS    プリフライトで許可するメソッド（カンマ区切り、default: GET,POST,PUT,PATCH,DELETE）
+//	CORS_ALLOWED_HEADERS    プリフライトで許可するヘッダ（カンマ区切り、default: Content-Type,Authorization,X-Request-ID,X-User-ID,traceparent）
+//	CORS_ALLOW_CREDENTIALS  Cookie・Authorization 付きのリクエストを許可するか（default: true、* のオリジンとは併用不可）
+//	CORS_MAX_AGE            プリフライトの結果のキャッシュ期間（default: 10m）
+//	OTEL_EXPORTER_OTLP_ENDPOINT  トレースの送信先（OTLP/HTTP、例: http://otel-collector:4318、default: 無し＝記録しない）
+//	OTEL_SERVICE_NAME       トレースの service.name（default: users）
+//	OTEL_TRACES_SAMPLER_ARG 新しいトレースを記録する割合（0〜1、default: 1）
+//	OPENAPI_VALIDATION      OpenAPI の仕様とのずれの検証（off / log / strict、default: production は off、それ以外は strict）
+//	DB_DSN                  PostgreSQL の接続文字列。未設定ならインメモリ（production では必須）
+//	DB_MAX_CONNS            プールの最大接続数（default: pgxpool の既定値）
+//	DB_MIN_CONNS            プールの最小接続数（default: 0）
+//	DB_STATEMENT_TIMEOUT    ステートメントタイムアウト（例: 5s、default: 無し）
+func loadConfig(getenv func(string) string) (config, error) {
+	getenv, err := sharedconfig.Load(getenv)
+	if err != nil {
+		return config{}, fmt.Errorf("invalid configuration: %w", err)
+	}
+	p := sharedconfig.NewParser(getenv)
+
+	cfg := config{
+		AppEnv:             p.Get("APP_ENV"),
+		Port:               p.Port("PORT", defaultPort),
+		AdminPort:          p.Port("ADMIN_PORT", defaultAdminPort),
+		ShutdownTimeout:    p.Duration("SHUTDOWN_TIMEOUT", server.DefaultShutdownTimeout),
+		CORS:               parseCORS(p),
+		OTLPEndpoint:       p.URL("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		JWKSURL:            p.URL("JWKS_URL"),
+		JWTIssuer:          p.String("JWT_ISSUER", defaultJWTIssuer),
+		JWTAudience:        p.String("JWT_AUDIENCE", defaultJWTAudience),
+		ServiceName:        p.String("OTEL_SERVICE_NAME", "users"),
+		TraceSampleRatio:   p.Ratio("OTEL_TRACES_SAMPLER_ARG", 1),
+		DBDSN:              p.Get("DB_DSN"),
+		DBMaxConns:         p.PositiveInt32("DB_MAX_CONNS"),
+		DBMinConns:         p.PositiveInt32("DB_MIN_CONNS"),
+		DBStatementTimeout: p.Duration("DB_STATEMENT_TIMEOUT", 0),
+	}
+
+	tlsOpts, h2c, err := server.ParseTLSOptions(p.Get)
+	p.Add(err)
+	cfg.TLS, cfg.H2C = tlsOpts, h2c
+
+	cfg.RateLimitTiers, cfg.RateLimitUserTiers = parseRateLimits(p)
+	cfg.MaxInFlightRequests = p.NonNegativeInt("MAX_IN_FLIGHT_REQUESTS", 0)
+
+	keys, err := serviceauth.ParseKeys(p.Get("SERVICE_API_KEYS"))
+	if err != nil {
+		p.Errorf("SERVICE_API_KEYS is invalid: %w", err)
+	}
+	cfg.ServiceAPIKeys = keys
+
+	level, err := logging.ParseLevel(p.Get("LOG_LEVEL"))
+	if err != nil {
+		p.Errorf("LOG_LEVEL is invalid: %w", err)
+	}
+	cfg.LogLevel = level
+
+	// 仕様とのずれは開発・テストで早く見つける。本番はレスポンスをバッファしないよう検証しない
+	cfg.OpenAPIValidation = openapi.ModeStrict
+	if cfg.isProduction() {
+		cfg.OpenAPIValidation = openapi.ModeOff
+	}
+	if v := p.Get("OPENAPI_VALIDATION"); v != "" {
+		mode, err := openapi.ParseMode(v)
+		if err != nil {
+			p.Errorf("OPENAPI_VALIDATION %w", err)
+		} else {
+			cfg.OpenAPIValidation = mode
+		}
+	}
+
+	if cfg.AdminPort == cfg.Port {
+		p.Errorf("ADMIN_PORT must differ from PORT (%d)", cfg.Port)
+	}
+
+	if cfg.DBMaxConns > 0 && cfg.DBMinConns > cfg.DBMaxConns {
+		p.Errorf("DB_MIN_CONNS (%d) must not exceed DB_MAX_CONNS (%d)", cfg.DBMinConns, cfg.DBMaxConns)
+	}
+
+	switch {
+	case cfg.DBDSN != "":
+		if _, err := pgxpool.ParseConfig(cfg.DBDSN); err != nil {
+			p.Errorf("DB_DSN is invalid: %w", err)
+		}
+	case cfg.isProduction():
+		// インメモリのリポジトリは再起動でデータが消えるため、本番では使わない
+		p.Required("DB_DSN", "in-memory repositories lose data on restart; set a PostgreSQL connection string in production")
+	}
+
+	if err := p.Err(); err != nil {
+		return config{}, err
+	}
+	return cfg, nil
+}
+
+// CORS の既定値（ローカルのフロントエンドの開発サーバーを許可する）。
+var (
+	defaultCORSOrigins = []string{"http://localhost:3000", "http://127.0.0.1:3000"}
+	defaultCORSMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+	defaultCORSHeaders = []string{"Content-Type", "Authorization", requestid.Header, authz.ActorHeader, tracing.TraceparentHeader}
+)
+
+// defaultCORSMaxAge はプリフライトの結果をブラウザがキャッシュする時間の既定値。
+const defaultCORSMaxAge = 10 * time.Minute
+
+// parseRateLimits は RATE_LIMIT_TIERS / RATE_LIMIT_USER_TIERS を読み込む。
+func parseRateLimits(p *sharedconfig.Parser) (map[string]int, map[string]string) {
+	tiers, err := ratelimit.ParseTiers(p.Get("RATE_LIMIT_TIERS"))
+	if err != nil {
+		p.Errorf("RATE_LIMIT_TIERS is invalid: %w", err)
+		return nil, nil
+	}
+	users, err := ratelimit.ParseUserTiers(p.Get("RATE_LIMIT_USER_TIERS"), tiers)
+	if err != nil {
+		p.Errorf("RATE_LIMIT_USER_TIERS is invalid: %w", err)
+	}
+	return tiers, users
+}
+
+// parseCORS は CORS_* の環境変数から CORS の設定を読み込む。
+func parseCORS(p *sharedconfig.Parser) cors.Options {
+	opts := cors.Options{
+		AllowedOrigins:   defaultCORSOrigins,
+		AllowedMethods:   defaultCORSMethods,
+		AllowedHeaders:   defaultCORSHeaders,
+		ExposedHeaders:   []string{requestid.Header, apiversion.Header, ratelimit.HeaderLimit, ratelimit.HeaderRemaining, ratelimit.HeaderReset, "Retry-After"},
+		AllowCredentials: p.Bool("CORS_ALLOW_CREDENTIALS", true),
+		MaxAge:           p.NonNegativeDuration("CORS_MAX_AGE", defaultCORSMaxAge),
+	}
+	if v := p.Get("CORS_ALLOWED_ORIGINS"); v != "" {
+		opts.AllowedOrigins = cors.SplitList(v)
+	}
+	if v := p.Get("CORS_ALLOWED_METHODS"); v != "" {
+		opts.AllowedMethods = cors.SplitList(v)
+	}
+	if v := p.Get("CORS_ALLOWED_HEADERS"); v != "" {
+		opts.AllowedHeaders = cors.SplitList(v)
+	}
+	if err := opts.Validate(); err != nil {
+		p.Errorf("CORS_ALLOWED_ORIGINS is invalid: %w", err)
+	}
+	return opts
+}
+
+// poolConfig は DB 設定を反映した pgxpool.Config を返す。
+func (c config) poolConfig() (*pgxpool.Config, error) {
+	pc, err := pgxpool.ParseConfig(c.DBDSN)
+	if err != nil {
+		return nil, err
+	}
+	if c.DBMaxConns > 0 {
+		pc.MaxConns = c.DBMaxConns
+	}
+	if c.DBMinConns > 0 {
+		pc.MinConns = c.DBMinConns
+	}
+	if c.DBStatementTimeout > 0 {
+		pc.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(c.DBStatementTimeout.Milliseconds(), 10)
+	}
+	return pc, nil
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/health"
+	"teamflow-shared/jwt"
+	"teamflow-shared/loadshed"
+	"teamflow-shared/logging"
+	"teamflow-shared/metrics"
+	"teamflow-shared/migrate"
+	"teamflow-shared/openapi"
+	"teamflow-shared/pat"
+	"teamflow-shared/ratelimit"
+	"teamflow-shared/server"
+	"teamflow-shared/serviceauth"
+	"teamflow-shared/tracing"
+	"teamflow-shared/tracing/pgxtrace"
+	"teamflow-shared/workspace"
+
+	"teamflow-users/internal/infrastructure/migration"
+	infra "teamflow-users/internal/infrastructure/user"
+	httphandler "teamflow-users/internal/interface/http"
+	usecase "teamflow-users/internal/usecase/user"
+)
+
+func main() {
+	// ログは JSON 形式で標準出力に出す。レベルは設定を読み込んでから LOG_LEVEL に合わせる
+	var logLevel slog.LevelVar
+	slog.SetDefault(logging.New(os.Stdout, &logLevel))
+
+	// users migrate <up|down [steps]|version>
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrate.Run(context.Background(), os.Getenv("DB_DSN"), migration.Config, "users", os.Args[2:]); err != nil {
+			fatal("migration failed", err)
+		}
+		return
+	}
+
+	cfg, err := loadConfig(os.Getenv)
+	if err != nil {
+		fatal("failed to load configuration", err)
+	}
+	logLevel.Set(cfg.LogLevel)
+
+	// トレーシング（OTEL_EXPORTER_OTLP_ENDPOINT が無ければ記録しない）
+	tracer, err := newTracer(cfg)
+	if err != nil {
+		fatal("failed to initialize tracing", err)
+	}
+	defer shutdownTracer(tracer)
+
+	// readiness で確認する依存先（リポジトリの生成時に登録する）
+	checks := health.NewChecker(health.DefaultTimeout)
+
+	// リポジトリ（DB_DSN があれば PostgreSQL、無ければインメモリ）
+	repo, tokenRepo, prefsRepo, closeRepo, err := newRepository(context.Background(), cfg, tracer, checks)
+	if err != nil {
+		fatal("failed to initialize repository", err)
+	}
+	defer closeRepo()
+
+	// ユースケース
+	createUC := &usecase.CreateUserUsecase{Repo: repo}
+	updateUC := &usecase.UpdateUserUsecase{Repo: repo}
+	getUC := &usecase.GetUserUsecase{Repo: repo}
+	lookupUC := &usecase.LookupUsersUsecase{Repo: repo, Preferences: prefsRepo}
+	issueTokenUC := &usecase.IssueTokenUsecase{Users: repo, Tokens: tokenRepo}
+	listTokensUC := &usecase.ListTokensUsecase{Tokens: tokenRepo}
+	revokeTokenUC := &usecase.RevokeTokenUsecase{Tokens: tokenRepo}
+	verifyTokenUC := &usecase.VerifyTokenUsecase{Tokens: tokenRepo, Clock: clock.System}
+	getPrefsUC := &usecase.GetPreferencesUsecase{Users: repo, Preferences: prefsRepo}
+	updatePrefsUC := &usecase.UpdatePreferencesUsecase{Users: repo, Preferences: prefsRepo}
+
+	// 一括取得・トークンの検証はサービス間専用（tasks サービスの担当者の確認・名前の表示・通知の宛先、PAT の検証）。
+	// SERVICE_API_KEYS があれば X-Service-Key で認証する
+	serviceAuth := serviceauth.NewAuthenticator(cfg.ServiceAPIKeys, clock.System)
+	if serviceAuth.Enabled() {
+		slog.Info("requiring service API keys for service endpoints", "keys", len(cfg.ServiceAPIKeys))
+	}
+
+	// HTTP ハンドラ（API はすべて /api 配下。パスの振り分けは Router が行う）。
+	// アクセストークンは発行したワークスペース（X-Workspace-ID）に限って使えるようにする（PAT は常に検証する）
+	router := httphandler.NewRouter(httphandler.Handlers{
+		Users:       httphandler.NewUsersHandler(createUC, updateUC, getUC, clock.System),
+		Lookup:      serviceAuth.Require(httphandler.NewLookupHandler(lookupUC)),
+		Tokens:      workspace.Middleware(true, serviceAuth.Authenticated, httphandler.NewTokensHandler(issueTokenUC, listTokensUC, revokeTokenUC, clock.System)),
+		Preferences: httphandler.NewPreferencesHandler(getPrefsUC, updatePrefsUC, clock.System),
+		Introspect:  serviceAuth.Require(httphandler.NewIntrospectHandler(verifyTokenUC)),
+	})
+
+	// ヘルスチェック（/livez, /healthz, /readyz）は server.NewMux が登録する
+	mux := server.NewMux(checks)
+	mux.Handle(httphandler.APIPrefix+"/", router)
+
+	// OpenAPI の仕様（/api/openapi.json）。OPENAPI_VALIDATION が off 以外なら API のリクエスト・レスポンスを仕様で検証する
+	handler, err := withOpenAPI(mux, cfg.OpenAPIValidation)
+	if err != nil {
+		fatal("failed to load OpenAPI spec", err)
+	}
+	if cfg.OpenAPIValidation != openapi.ModeOff {
+		slog.Info("validating requests and responses against the OpenAPI spec", "mode", cfg.OpenAPIValidation)
+	}
+	// 操作者・個人用アクセストークンごとのレート制限（RATE_LIMIT_TIERS）。サービス API キーでの呼び出しはキーごとに制限するため外す
+	handler = ratelimit.Middleware(ratelimit.Policy{
+		Tiers:     cfg.RateLimitTiers,
+		UserTiers: cfg.RateLimitUserTiers,
+		Skip:      serviceAuth.Authenticated,
+		Clock:     clock.System,
+	}, handler)
+	// Authorization: Bearer の個人用アクセストークンを検証し、持ち主を操作者にする（レート制限・仕様の検証より前に認証する）。
+	// トークンの無いリクエストの X-User-ID / X-Workspace-ID は、JWT を検証した場合とサービス API キーで認証した呼び出しの場合だけ使う
+	handler = pat.Middleware(verifyTokenUC, serviceAuth.Authenticated, handler)
+	// Authorization: Bearer の JWT（auth サービスが OIDC のログインで発行する）を JWKS_URL の公開鍵で検証し、sub を操作者にする。
+	// トークンの無いリクエストの X-User-ID / X-Workspace-ID は、サービス API キーで認証した呼び出しの場合だけ使う
+	handler = jwt.Middleware(jwt.NewRemoteVerifier(cfg.JWKSURL, cfg.JWTIssuer, cfg.JWTAudience), serviceAuth.Authenticated, handler)
+	// 処理中のリクエスト数の上限（MAX_IN_FLIGHT_REQUESTS）。DB のプールが埋まる前に超えた分を 503 で断る（認証よりも前に断る）
+	shedder := loadshed.NewLimiter(loadshed.Policy{MaxInFlight: cfg.MaxInFlightRequests})
+	handler = shedder.Middleware(handler)
+	metrics.RegisterLoadShedMetrics(prometheus.DefaultRegisterer, "users", shedder)
+	if cfg.MaxInFlightRequests > 0 {
+		slog.Info("shedding requests above the in-flight limit", "max_in_flight", cfg.MaxInFlightRequests)
+	}
+
+	// メトリクス（Prometheus テキスト形式）は API と別の管理用ポートで公開する
+	adminMux := http.NewServeMux()
+	adminMux.Handle("/metrics", promhttp.Handler())
+	stopAdmin, err := server.StartAdmin(cfg.adminAddr(), adminMux)
+	if err != nil {
+		fatal("failed to start admin server", err)
+	}
+	defer stopAdmin()
+
+	// TLS_CERT_FILE・TLS_AUTOCERT_HOSTS を設定した場合は TLS で待ち受ける（証明書のファイルは起動時に読み込む）
+	tlsConfig, err := cfg.TLS.Config()
+	if err != nil {
+		fatal("failed to configure TLS (check TLS_CERT_FILE / TLS_KEY_FILE)", err)
+	}
+
+	// リクエスト ID・トレース・ログ・メトリクス・セキュリティヘッダ・panic の回復・CORS は server.New が順に適用する
+	srv := server.New(handler, server.Options{
+		Addr:      cfg.addr(),
+		Tracer:    tracer,
+		Metrics:   metrics.NewHTTPMetrics(prometheus.DefaultRegisterer, "users", httphandler.RouteLabel).Middleware,
+		CORS:      cfg.CORS,
+		Messages:  httphandler.Messages,
+		TLSConfig: tlsConfig,
+		H2C:       cfg.H2C,
+	})
+	slog.Info("users service listening", "addr", srv.Addr, "tls", tlsConfig != nil, "h2c", cfg.H2C)
+
+	// SIGINT / SIGTERM で graceful shutdown し、処理中のリクエストが終わってからプールを閉じる
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := server.Run(ctx, srv, cfg.ShutdownTimeout); err != nil {
+		fatal("http server stopped", err)
+	}
+	slog.Info("users service stopped")
+}
+
+// newRepository は設定に応じてユーザー・アクセストークン・通知の設定のリポジトリを生成する。
+// SQL の場合は起動時に疎通確認を行い、戻り値の close でプールを閉じる。
+// tracer が nil でなければ問い合わせごとのスパンを記録する。プールへの疎通確認を checks に登録する。
+func newRepository(ctx context.Context, cfg config, tracer *tracing.Tracer, checks *health.Checker) (usecase.UserRepository, usecase.TokenRepository, usecase.PreferencesRepository, func(), error) {
+	if !cfg.useSQL() {
+		slog.Info("using in-memory user repository")
+		return infra.NewMemoryUserRepository(), infra.NewMemoryTokenRepository(), infra.NewMemoryPreferencesRepository(), func() {}, nil
+	}
+
+	poolCfg, err := cfg.poolConfig()
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("DB_DSN is invalid: %w", err)
+	}
+	if tracer != nil {
+		poolCfg.ConnConfig.Tracer = pgxtrace.NewQueryTracer(tracer.Provider())
+	}
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to create database pool: %w", err)
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := pool.Ping(pingCtx); err != nil {
+		pool.Close()
+		return nil, nil, nil, nil, fmt.Errorf("failed to connect database (check DB_DSN): %w", err)
+	}
+
+	slog.Info("using postgres user repository", "max_conns", poolCfg.MaxConns)
+	metrics.RegisterPoolMetrics(prometheus.DefaultRegisterer, "users", pool)
+	checks.Add("postgres", pool.Ping)
+	return infra.NewSQLUserRepository(pool), infra.NewSQLTokenRepository(pool), infra.NewSQLPreferencesRepository(pool), pool.Close, nil
+}
+
+// withOpenAPI は mux に仕様を返す /api/openapi.json を登録し、mode に応じて仕様で検証するハンドラを返す。
+func withOpenAPI(mux *http.ServeMux, mode openapi.Mode) (http.Handler, error) {
+	spec, err := openapi.Load()
+	if err != nil {
+		return nil, err
+	}
+	specHandler, err := openapi.SpecHandler(spec)
+	if err != nil {
+		return nil, err
+	}
+	mux.Handle(openapi.Path, specHandler)
+	return openapi.Middleware(spec, mode, mux)
+}
+
+// newTracer は OTLP の送信先が設定されていれば Tracer を生成する。設定されていなければ nil（記録しない）。
+func newTracer(cfg config) (*tracing.Tracer, error) {
+	if cfg.OTLPEndpoint == "" {
+		return nil, nil
+	}
+	slog.Info("exporting traces", "endpoint", cfg.OTLPEndpoint, "service", cfg.ServiceName, "sample_ratio", cfg.TraceSampleRatio)
+	return tracing.NewTracer(tracing.Options{
+		Endpoint:    cfg.OTLPEndpoint,
+		ServiceName: cfg.ServiceName,
+		SampleRatio: cfg.TraceSampleRatio,
+	})
+}
+
+// shutdownTracer は送信待ちのスパンを送ってから Tracer を停止する。
+func shutdownTracer(tracer *tracing.Tracer) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := tracer.Shutdown(ctx); err != nil {
+		slog.Warn("failed to flush traces", "error", err)
+	}
+}
+
+// fatal はエラーをログに出力して終了する。
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
@@ -0,0 +1,45 @@
+package user
+
+import "errors"
+
+// --- Sentinel Errors ---
+// これらは errors.Is で判定可能。HTTP 層で 400 に変換される。
+
+// User validation errors
+var (
+	// ErrInvalidID は id が空、または '/' を含む場合のエラー。
+	ErrInvalidID = errors.New("user id must not be empty or contain '/'")
+
+	// ErrNameRequired は name が空の場合のエラー。
+	ErrNameRequired = errors.New("user name must not be empty")
+
+	// ErrNameTooLong は name が MaxNameLength 文字を超える場合のエラー。
+	ErrNameTooLong = errors.New("user name is too long")
+
+	// ErrInvalidEmail は email がメールアドレスの形式でない場合のエラー。
+	ErrInvalidEmail = errors.New("email must be a valid email address")
+
+	// ErrInvalidAvatarURL は avatarUrl が http(s) の絶対 URL でない場合のエラー。
+	ErrInvalidAvatarURL = errors.New("avatarUrl must be an absolute http(s) URL")
+)
+
+// Lookup validation errors
+var (
+	// ErrInvalidLookup は一括取得の ids が空・件数超過・空の ID を含む場合のエラー。
+	ErrInvalidLookup = errors.New("invalid user lookup")
+)
+
+// AccessToken validation errors
+var (
+	// ErrTokenNameRequired はアクセストークンの name が空の場合のエラー。
+	ErrTokenNameRequired = errors.New("token name must not be empty")
+
+	// ErrTokenNameTooLong はアクセストークンの name が MaxTokenNameLength 文字を超える場合のエラー。
+	ErrTokenNameTooLong = errors.New("token name is too long")
+
+	// ErrInvalidScopes は scopes が空、または read / write 以外を含む場合のエラー。
+	ErrInvalidScopes = errors.New("scopes must be one or more of read, write")
+
+	// ErrInvalidExpiresAt は expiresAt が現在以前の場合のエラー。
+	ErrInvalidExpiresAt = errors.New("expiresAt must be in the future")
+)
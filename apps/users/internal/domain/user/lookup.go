@@ -0,0 +1,35 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxLookupIDs は一括取得（POST /users:lookup）で 1 回に指定できる ID の上限。
+// tasks サービスの一覧 1 ページ分（limit の上限 200）の担当者をまとめて引けるようにする。
+const MaxLookupIDs = 200
+
+// NormalizeLookupIDs は一括取得の ids から前後の空白を除き、重複を取り除いて指定順に返す。
+// 空、MaxLookupIDs 件を超える、空の ID を含む場合は ErrInvalidLookup を返す。
+func NormalizeLookupIDs(ids []string) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: ids must not be empty", ErrInvalidLookup)
+	}
+	if len(ids) > MaxLookupIDs {
+		return nil, fmt.Errorf("%w: ids must contain at most %d items", ErrInvalidLookup, MaxLookupIDs)
+	}
+	seen := make(map[string]bool, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			return nil, fmt.Errorf("%w: ids must not contain empty values", ErrInvalidLookup)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	return out, nil
+}
@@ -0,0 +1,34 @@
+package user
+
+import "time"
+
+// NotificationPreferences はユーザーごとの通知の設定を表す。
+// tasks サービスが担当者にメールを送る前に確認する。保存されていないユーザーは DefaultNotificationPreferences に従う。
+type NotificationPreferences struct {
+	UserID string
+	// EmailOnAssignment はタスクの担当者になったときにメールを受け取るかどうか
+	EmailOnAssignment bool
+	// EmailOnDueSoon は担当しているタスクの期日が近づいたときにメールを受け取るかどうか
+	EmailOnDueSoon bool
+	UpdatedAt      time.Time // 保存されていない場合はゼロ値
+}
+
+// DefaultNotificationPreferences は設定を保存していないユーザーの通知の設定（すべて受け取る）を返す。
+func DefaultNotificationPreferences(userID string) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:            userID,
+		EmailOnAssignment: true,
+		EmailOnDueSoon:    true,
+	}
+}
+
+// Update は指定された設定を置き換える。nil の項目は変更しない。
+func (p *NotificationPreferences) Update(emailOnAssignment, emailOnDueSoon *bool, now time.Time) {
+	if emailOnAssignment != nil {
+		p.EmailOnAssignment = *emailOnAssignment
+	}
+	if emailOnDueSoon != nil {
+		p.EmailOnDueSoon = *emailOnDueSoon
+	}
+	p.UpdatedAt = now
+}
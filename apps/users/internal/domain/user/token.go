@@ -0,0 +1,78 @@
+package user
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"teamflow-shared/pat"
+)
+
+// MaxTokenNameLength はアクセストークンの名前の最大文字数。
+const MaxTokenNameLength = 100
+
+// AccessToken は CLI・CI から API を呼び出すための個人用アクセストークン（PAT）を表す。
+// 平文のトークンは保存せず、照合用のハッシュと一覧で見分けるための先頭（Prefix）だけを持つ。
+type AccessToken struct {
+	ID          string
+	UserID      string
+	WorkspaceID string // 発行したワークスペース。トークンでの操作はこのワークスペースに限られる
+	Name        string // 用途を見分けるための名前（例: "GitHub Actions"）
+	Prefix      string // トークンの先頭（pat.DisplayPrefix）
+	Hash        string // トークンの SHA-256（pat.Hash）
+	Scopes      []string
+	CreatedAt   time.Time
+	ExpiresAt   *time.Time // nil の場合は無期限
+	LastUsedAt  *time.Time // nil の場合は未使用
+	RevokedAt   *time.Time // nil の場合は有効
+}
+
+// NewAccessToken は token（平文）の新しいアクセストークンを生成する。平文は呼び出し側が 1 度だけ利用者に返す。
+//
+//	ErrTokenNameRequired  name が空
+//	ErrTokenNameTooLong   name が MaxTokenNameLength 文字を超える
+//	ErrInvalidScopes      scopes が空・read / write 以外を含む
+//	ErrInvalidExpiresAt   expiresAt が now 以前
+func NewAccessToken(id, userID, workspaceID, name, token string, scopes []string, expiresAt *time.Time, now time.Time) (*AccessToken, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrTokenNameRequired
+	}
+	if utf8.RuneCountInString(name) > MaxTokenNameLength {
+		return nil, ErrTokenNameTooLong
+	}
+	scopes, err := pat.ParseScopes(scopes)
+	if err != nil {
+		return nil, ErrInvalidScopes
+	}
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, ErrInvalidExpiresAt
+	}
+
+	return &AccessToken{
+		ID:          id,
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Name:        name,
+		Prefix:      pat.DisplayPrefix(token),
+		Hash:        pat.Hash(token),
+		Scopes:      scopes,
+		CreatedAt:   now,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// Active は now の時点でトークンを使えるかどうか（失効しておらず期限内か）を返す。
+func (t *AccessToken) Active(now time.Time) bool {
+	if t.RevokedAt != nil {
+		return false
+	}
+	return t.ExpiresAt == nil || now.Before(*t.ExpiresAt)
+}
+
+// Revoke はトークンを失効させる。失効済みの場合は失効した日時を変更しない。
+func (t *AccessToken) Revoke(now time.Time) {
+	if t.RevokedAt == nil {
+		t.RevokedAt = &now
+	}
+}
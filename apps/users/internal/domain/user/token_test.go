@@ -0,0 +1,73 @@
+package user
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"teamflow-shared/pat"
+)
+
+func TestNewAccessToken(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	token := pat.TokenPrefix + strings.Repeat("a", 40)
+	expiresAt := now.Add(24 * time.Hour)
+
+	tok, err := NewAccessToken("t-1", "user-1", "acme", " CI ", token, []string{"write", "read"}, &expiresAt, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tok.Name != "CI" || tok.Prefix != "tfp_aaaaaaaa" || tok.Hash != pat.Hash(token) || tok.WorkspaceID != "acme" {
+		t.Errorf("unexpected token: %+v", tok)
+	}
+	if !reflect.DeepEqual(tok.Scopes, []string{"read", "write"}) {
+		t.Errorf("Scopes = %v", tok.Scopes)
+	}
+
+	past := now.Add(-time.Second)
+	tests := []struct {
+		name      string
+		tname     string
+		scopes    []string
+		expiresAt *time.Time
+		wantErr   error
+	}{
+		{name: "empty name", tname: " ", scopes: []string{"read"}, wantErr: ErrTokenNameRequired},
+		{name: "name too long", tname: strings.Repeat("あ", MaxTokenNameLength+1), scopes: []string{"read"}, wantErr: ErrTokenNameTooLong},
+		{name: "no scopes", tname: "ci", wantErr: ErrInvalidScopes},
+		{name: "unknown scope", tname: "ci", scopes: []string{"admin"}, wantErr: ErrInvalidScopes},
+		{name: "expires in the past", tname: "ci", scopes: []string{"read"}, expiresAt: &past, wantErr: ErrInvalidExpiresAt},
+		{name: "expires now", tname: "ci", scopes: []string{"read"}, expiresAt: &now, wantErr: ErrInvalidExpiresAt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewAccessToken("t-1", "user-1", "acme", tt.tname, token, tt.scopes, tt.expiresAt, now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAccessToken_Active(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(time.Hour)
+	tok := &AccessToken{CreatedAt: now, ExpiresAt: &expiresAt}
+
+	if !tok.Active(now) {
+		t.Error("expected the token to be active before it expires")
+	}
+	if tok.Active(expiresAt) {
+		t.Error("expected the token to be inactive once it expires")
+	}
+
+	tok.Revoke(now.Add(time.Minute))
+	tok.Revoke(now.Add(2 * time.Minute))
+	if tok.Active(now.Add(time.Minute)) {
+		t.Error("expected a revoked token to be inactive")
+	}
+	if !tok.RevokedAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("RevokedAt = %v, want the first revocation", tok.RevokedAt)
+	}
+}
@@ -0,0 +1,101 @@
+package user
+
+import (
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxNameLength は表示名の最大文字数。
+const MaxNameLength = 100
+
+// MaxEmailLength はメールアドレスの最大文字数（RFC 5321 のパスの上限）。
+const MaxEmailLength = 254
+
+// User はユーザーのプロフィールを表す。
+// tasks サービスの担当者（assigneeId）、projects サービスのメンバー（userId）は ID で参照する。
+type User struct {
+	ID        string
+	Name      string
+	Email     string // 一意（大文字・小文字は区別しない）
+	AvatarURL string // 空の場合は未設定
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewUser は新しいユーザーを生成する。
+// ID が空・'/' を含む場合は ErrInvalidID、その他の値が不正な場合は Update と同じエラーを返す。
+func NewUser(id, name, email, avatarURL string, now time.Time) (*User, error) {
+	id = strings.TrimSpace(id)
+	if id == "" || strings.Contains(id, "/") {
+		return nil, ErrInvalidID
+	}
+
+	u := &User{
+		ID:        id,
+		CreatedAt: now,
+	}
+	if err := u.Update(name, email, avatarURL, now); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Update は表示名・メールアドレス・アバターの URL を置き換える。不正な値の場合は u を変更しない。
+//
+//	ErrNameRequired      name が空
+//	ErrNameTooLong       name が MaxNameLength 文字を超える
+//	ErrInvalidEmail      email がメールアドレスの形式でない・長すぎる
+//	ErrInvalidAvatarURL  avatarURL が空でも http(s) の絶対 URL でもない
+func (u *User) Update(name, email, avatarURL string, now time.Time) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrNameRequired
+	}
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return ErrNameTooLong
+	}
+	email, err := ParseEmail(email)
+	if err != nil {
+		return err
+	}
+	avatarURL, err = ParseAvatarURL(avatarURL)
+	if err != nil {
+		return err
+	}
+
+	u.Name = name
+	u.Email = email
+	u.AvatarURL = avatarURL
+	u.UpdatedAt = now
+	return nil
+}
+
+// ParseEmail はメールアドレス（表示名や <> を含まない addr-spec）か検証し、前後の空白を除いて返す。
+func ParseEmail(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || len(s) > MaxEmailLength {
+		return "", ErrInvalidEmail
+	}
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Address != s || addr.Name != "" {
+		return "", ErrInvalidEmail
+	}
+	return s, nil
+}
+
+// ParseAvatarURL はアバターの URL が http(s) の絶対 URL か検証し、前後の空白を除いて返す。
+// 空文字は未設定として許可する。
+func ParseAvatarURL(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", nil
+	}
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", ErrInvalidAvatarURL
+	}
+	return s, nil
+}
@@ -0,0 +1,98 @@
+package user
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewUser(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	u, err := NewUser(" user-1 ", " 山田 太郎 ", " Taro@Example.com ", " https://example.com/a.png ", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u.ID != "user-1" || u.Name != "山田 太郎" || u.Email != "Taro@Example.com" || u.AvatarURL != "https://example.com/a.png" {
+		t.Errorf("unexpected user: %+v", u)
+	}
+	if !u.CreatedAt.Equal(now) || !u.UpdatedAt.Equal(now) {
+		t.Errorf("unexpected timestamps: %+v", u)
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		uname   string
+		email   string
+		avatar  string
+		wantErr error
+	}{
+		{name: "empty id", id: " ", uname: "taro", email: "taro@example.com", wantErr: ErrInvalidID},
+		{name: "id with slash", id: "a/b", uname: "taro", email: "taro@example.com", wantErr: ErrInvalidID},
+		{name: "empty name", id: "u1", uname: "  ", email: "taro@example.com", wantErr: ErrNameRequired},
+		{name: "name too long", id: "u1", uname: strings.Repeat("あ", MaxNameLength+1), email: "taro@example.com", wantErr: ErrNameTooLong},
+		{name: "empty email", id: "u1", uname: "taro", email: "", wantErr: ErrInvalidEmail},
+		{name: "email without domain", id: "u1", uname: "taro", email: "taro", wantErr: ErrInvalidEmail},
+		{name: "email with display name", id: "u1", uname: "taro", email: "Taro <taro@example.com>", wantErr: ErrInvalidEmail},
+		{name: "email too long", id: "u1", uname: "taro", email: strings.Repeat("a", MaxEmailLength) + "@example.com", wantErr: ErrInvalidEmail},
+		{name: "relative avatar", id: "u1", uname: "taro", email: "taro@example.com", avatar: "/a.png", wantErr: ErrInvalidAvatarURL},
+		{name: "non-http avatar", id: "u1", uname: "taro", email: "taro@example.com", avatar: "javascript:alert(1)", wantErr: ErrInvalidAvatarURL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewUser(tt.id, tt.uname, tt.email, tt.avatar, now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestUser_Update(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	u, err := NewUser("u1", "taro", "taro@example.com", "https://example.com/a.png", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	later := now.Add(time.Hour)
+	if err := u.Update("Taro", "taro@example.org", "", later); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u.Name != "Taro" || u.Email != "taro@example.org" || u.AvatarURL != "" {
+		t.Errorf("unexpected user: %+v", u)
+	}
+	if !u.CreatedAt.Equal(now) || !u.UpdatedAt.Equal(later) {
+		t.Errorf("unexpected timestamps: %+v", u)
+	}
+
+	// 不正な値の場合は変更しない
+	if err := u.Update("Jiro", "invalid", "", later.Add(time.Hour)); !errors.Is(err, ErrInvalidEmail) {
+		t.Fatalf("expected ErrInvalidEmail, got %v", err)
+	}
+	if u.Name != "Taro" || !u.UpdatedAt.Equal(later) {
+		t.Errorf("expected user to be unchanged, got %+v", u)
+	}
+}
+
+func TestNormalizeLookupIDs(t *testing.T) {
+	got, err := NormalizeLookupIDs([]string{" u2 ", "u1", "u2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"u2", "u1"}) {
+		t.Errorf("NormalizeLookupIDs = %v", got)
+	}
+
+	tooMany := make([]string, MaxLookupIDs+1)
+	for i := range tooMany {
+		tooMany[i] = "u"
+	}
+	for _, ids := range [][]string{nil, {"u1", " "}, tooMany} {
+		if _, err := NormalizeLookupIDs(ids); !errors.Is(err, ErrInvalidLookup) {
+			t.Errorf("expected ErrInvalidLookup for %d ids, got %v", len(ids), err)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+-- ユーザーのプロフィール。tasks サービスの担当者（assignee_id）、projects サービスのメンバー（user_id）はこの id を参照する
+CREATE TABLE users (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    email TEXT NOT NULL,
+    -- アバター画像の URL（未設定は NULL）
+    avatar_url TEXT,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- メールアドレスは一意（大文字・小文字は区別しない）
+CREATE UNIQUE INDEX idx_users_email ON users(lower(email));
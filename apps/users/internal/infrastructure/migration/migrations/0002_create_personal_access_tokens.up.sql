@@ -0,0 +1,22 @@
+-- 個人用アクセストークン（CLI・CI 向け）。平文は保存せず、照合用の SHA-256 と一覧に表示する先頭だけを持つ
+CREATE TABLE personal_access_tokens (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- 発行したワークスペース。トークンでの操作はこのワークスペースに限られる
+    workspace_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    prefix TEXT NOT NULL,
+    token_hash TEXT NOT NULL,
+    scopes TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    -- 無期限・未使用・有効の場合は NULL
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+
+-- 認証のたびにハッシュで引く
+CREATE UNIQUE INDEX idx_personal_access_tokens_hash ON personal_access_tokens(token_hash);
+
+-- ユーザーごとの一覧（発行の新しい順）
+CREATE INDEX idx_personal_access_tokens_user ON personal_access_tokens(user_id, created_at DESC);
@@ -0,0 +1,7 @@
+-- ユーザーごとの通知の設定。行が無いユーザーはすべての通知を受け取る
+CREATE TABLE notification_preferences (
+    user_id TEXT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    email_on_assignment BOOLEAN NOT NULL,
+    email_on_due_soon BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,27 @@
+//go:build integration
+// +build integration
+
+package userinfra
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"teamflow-shared/dbtest"
+
+	"teamflow-users/internal/infrastructure/migration"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(dbtest.Main(m, migration.New))
+}
+
+// setupUsersDB returns the integration-test pool with the users tables emptied.
+func setupUsersDB(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	db := dbtest.Setup(t)
+	dbtest.Truncate(t, db, "users, personal_access_tokens, notification_preferences")
+	return db
+}
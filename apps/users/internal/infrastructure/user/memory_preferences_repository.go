@@ -0,0 +1,47 @@
+package userinfra
+
+import (
+	"context"
+	"sync"
+
+	domain "teamflow-users/internal/domain/user"
+	usecase "teamflow-users/internal/usecase/user"
+)
+
+// MemoryPreferencesRepository はメモリ上に通知の設定を保持する PreferencesRepository 実装。
+// 並行に呼び出してよい。保存時と取得時にコピーする。
+type MemoryPreferencesRepository struct {
+	mu    sync.RWMutex
+	prefs map[string]domain.NotificationPreferences
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.PreferencesRepository = (*MemoryPreferencesRepository)(nil)
+
+// NewMemoryPreferencesRepository は空のインメモリリポジトリを生成する。
+func NewMemoryPreferencesRepository() *MemoryPreferencesRepository {
+	return &MemoryPreferencesRepository{
+		prefs: make(map[string]domain.NotificationPreferences),
+	}
+}
+
+// Save は通知の設定を保存する（既にある場合は置き換える）。
+func (r *MemoryPreferencesRepository) Save(_ context.Context, p *domain.NotificationPreferences) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prefs[p.UserID] = *p
+	return nil
+}
+
+// FindByUserIDs は userIDs のうち設定を保存しているユーザーの設定を返す。
+func (r *MemoryPreferencesRepository) FindByUserIDs(_ context.Context, userIDs []string) ([]*domain.NotificationPreferences, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := []*domain.NotificationPreferences{}
+	for _, id := range userIDs {
+		if p, ok := r.prefs[id]; ok {
+			out = append(out, &p)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,30 @@
+package userinfra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "teamflow-users/internal/domain/user"
+)
+
+func TestMemoryPreferencesRepository(t *testing.T) {
+	repo := NewMemoryPreferencesRepository()
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	p := &domain.NotificationPreferences{UserID: "u1", EmailOnAssignment: true, UpdatedAt: now}
+	if err := repo.Save(ctx, p); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	// 保存後に呼び出し側の値を変更しても影響しない
+	p.EmailOnAssignment = false
+
+	got, err := repo.FindByUserIDs(ctx, []string{"u1", "u2"})
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if len(got) != 1 || got[0].UserID != "u1" || !got[0].EmailOnAssignment || got[0].EmailOnDueSoon {
+		t.Errorf("unexpected preferences: %+v", got)
+	}
+}
@@ -0,0 +1,107 @@
+package userinfra
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	domain "teamflow-users/internal/domain/user"
+	usecase "teamflow-users/internal/usecase/user"
+)
+
+// ErrUserNotFound はユーザーが存在しない場合のエラー。
+var ErrUserNotFound = usecase.ErrUserNotFound
+
+// ErrUserAlreadyExists は同じ ID のユーザーが既に存在する場合のエラー。
+var ErrUserAlreadyExists = usecase.ErrUserAlreadyExists
+
+// ErrEmailAlreadyExists は同じメールアドレスのユーザーが既に存在する場合のエラー。
+var ErrEmailAlreadyExists = usecase.ErrEmailAlreadyExists
+
+// MemoryUserRepository はメモリ上にユーザーを保持する UserRepository 実装。
+// 並行に呼び出してよい。保存時と取得時にコピーする。
+type MemoryUserRepository struct {
+	mu    sync.RWMutex
+	users map[string]*domain.User
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.UserRepository = (*MemoryUserRepository)(nil)
+
+// NewMemoryUserRepository は空のインメモリリポジトリを生成する。
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{
+		users: make(map[string]*domain.User),
+	}
+}
+
+// Save はユーザーを保存する。
+// 同じ ID がある場合は ErrUserAlreadyExists、同じメールアドレスがある場合は ErrEmailAlreadyExists を返す。
+func (r *MemoryUserRepository) Save(_ context.Context, u *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.users[u.ID]; ok {
+		return ErrUserAlreadyExists
+	}
+	if r.emailTaken(u) {
+		return ErrEmailAlreadyExists
+	}
+	r.users[u.ID] = cloneUser(u)
+	return nil
+}
+
+// Update はユーザーを更新する。
+// 存在しない場合は ErrUserNotFound、メールアドレスが他のユーザーと重複する場合は ErrEmailAlreadyExists を返す。
+func (r *MemoryUserRepository) Update(_ context.Context, u *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.users[u.ID]; !ok {
+		return ErrUserNotFound
+	}
+	if r.emailTaken(u) {
+		return ErrEmailAlreadyExists
+	}
+	r.users[u.ID] = cloneUser(u)
+	return nil
+}
+
+// emailTaken は u 以外で同じメールアドレス（大文字・小文字は区別しない）のユーザーがあるかどうかを返す。
+// 呼び出し側でロックを取得していること。
+func (r *MemoryUserRepository) emailTaken(u *domain.User) bool {
+	for id, other := range r.users {
+		if id != u.ID && strings.EqualFold(other.Email, u.Email) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindByID はユーザーを取得する。存在しない場合は ErrUserNotFound を返す。
+func (r *MemoryUserRepository) FindByID(_ context.Context, id string) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	u, ok := r.users[id]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	return cloneUser(u), nil
+}
+
+// FindByIDs は ids のうち存在するユーザーを ids の順で返す。
+func (r *MemoryUserRepository) FindByIDs(_ context.Context, ids []string) ([]*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]*domain.User, 0, len(ids))
+	for _, id := range ids {
+		if u, ok := r.users[id]; ok {
+			out = append(out, cloneUser(u))
+		}
+	}
+	return out, nil
+}
+
+// cloneUser は u のコピーを返す。
+func cloneUser(u *domain.User) *domain.User {
+	c := *u
+	return &c
+}
@@ -0,0 +1,63 @@
+package userinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-users/internal/domain/user"
+)
+
+func TestMemoryUserRepository(t *testing.T) {
+	repo := NewMemoryUserRepository()
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	taro := &domain.User{ID: "u1", Name: "Taro", Email: "taro@example.com", CreatedAt: now, UpdatedAt: now}
+	if err := repo.Save(ctx, taro); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	if err := repo.Save(ctx, &domain.User{ID: "u2", Name: "Jiro", Email: "jiro@example.com", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	if err := repo.Save(ctx, &domain.User{ID: "u1", Name: "dup", Email: "dup@example.com"}); !errors.Is(err, ErrUserAlreadyExists) {
+		t.Errorf("expected ErrUserAlreadyExists, got %v", err)
+	}
+	if err := repo.Save(ctx, &domain.User{ID: "u3", Name: "dup", Email: "TARO@example.com"}); !errors.Is(err, ErrEmailAlreadyExists) {
+		t.Errorf("expected ErrEmailAlreadyExists, got %v", err)
+	}
+
+	// 保存後に呼び出し側の値を変更しても影響しない
+	taro.Name = "changed"
+	got, err := repo.FindByID(ctx, "u1")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.Name != "Taro" {
+		t.Errorf("expected stored copy, got %+v", got)
+	}
+	if _, err := repo.FindByID(ctx, "missing"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+
+	got.Email = "Jiro@example.com"
+	if err := repo.Update(ctx, got); !errors.Is(err, ErrEmailAlreadyExists) {
+		t.Errorf("expected ErrEmailAlreadyExists, got %v", err)
+	}
+	got.Email = "taro@example.org"
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	if err := repo.Update(ctx, &domain.User{ID: "missing"}); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+
+	users, err := repo.FindByIDs(ctx, []string{"u2", "missing", "u1"})
+	if err != nil {
+		t.Fatalf("failed to find by ids: %v", err)
+	}
+	if len(users) != 2 || users[0].ID != "u2" || users[1].Email != "taro@example.org" {
+		t.Errorf("unexpected users: %+v", users)
+	}
+}
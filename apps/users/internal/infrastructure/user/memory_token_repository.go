@@ -0,0 +1,111 @@
+package userinfra
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	domain "teamflow-users/internal/domain/user"
+	usecase "teamflow-users/internal/usecase/user"
+)
+
+// ErrTokenNotFound はアクセストークンが存在しない場合のエラー。
+var ErrTokenNotFound = usecase.ErrTokenNotFound
+
+// MemoryTokenRepository はメモリ上にアクセストークンを保持する TokenRepository 実装。
+// 並行に呼び出してよい。保存時と取得時にコピーする。
+type MemoryTokenRepository struct {
+	mu     sync.RWMutex
+	tokens map[string]*domain.AccessToken
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.TokenRepository = (*MemoryTokenRepository)(nil)
+
+// NewMemoryTokenRepository は空のインメモリリポジトリを生成する。
+func NewMemoryTokenRepository() *MemoryTokenRepository {
+	return &MemoryTokenRepository{
+		tokens: make(map[string]*domain.AccessToken),
+	}
+}
+
+// Save はトークンを保存する。
+func (r *MemoryTokenRepository) Save(_ context.Context, t *domain.AccessToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens[t.ID] = cloneToken(t)
+	return nil
+}
+
+// Update はトークンを更新する。存在しない場合は ErrTokenNotFound を返す。
+func (r *MemoryTokenRepository) Update(_ context.Context, t *domain.AccessToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.tokens[t.ID]; !ok {
+		return ErrTokenNotFound
+	}
+	r.tokens[t.ID] = cloneToken(t)
+	return nil
+}
+
+// FindByID はトークンを取得する。存在しない場合は ErrTokenNotFound を返す。
+func (r *MemoryTokenRepository) FindByID(_ context.Context, id string) (*domain.AccessToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.tokens[id]
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+	return cloneToken(t), nil
+}
+
+// FindByHash はハッシュが一致するトークンを取得する。存在しない場合は ErrTokenNotFound を返す。
+func (r *MemoryTokenRepository) FindByHash(_ context.Context, hash string) (*domain.AccessToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, t := range r.tokens {
+		if t.Hash == hash {
+			return cloneToken(t), nil
+		}
+	}
+	return nil, ErrTokenNotFound
+}
+
+// ListByUser は userID のトークンを発行の新しい順（同時刻は ID の昇順）に返す。
+func (r *MemoryTokenRepository) ListByUser(_ context.Context, userID string) ([]*domain.AccessToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := []*domain.AccessToken{}
+	for _, t := range r.tokens {
+		if t.UserID == userID {
+			out = append(out, cloneToken(t))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.After(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// cloneToken は t のコピーを返す（スコープと日時のポインタも複製する）。
+func cloneToken(t *domain.AccessToken) *domain.AccessToken {
+	c := *t
+	c.Scopes = slices.Clone(t.Scopes)
+	c.ExpiresAt = cloneTime(t.ExpiresAt)
+	c.LastUsedAt = cloneTime(t.LastUsedAt)
+	c.RevokedAt = cloneTime(t.RevokedAt)
+	return &c
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
@@ -0,0 +1,61 @@
+package userinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-users/internal/domain/user"
+)
+
+func TestMemoryTokenRepository(t *testing.T) {
+	repo := NewMemoryTokenRepository()
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	older := &domain.AccessToken{ID: "t1", UserID: "u1", Name: "laptop", Hash: "h1", Scopes: []string{"read"}, CreatedAt: now}
+	newer := &domain.AccessToken{ID: "t2", UserID: "u1", Name: "ci", Hash: "h2", Scopes: []string{"write"}, CreatedAt: now.Add(time.Hour)}
+	other := &domain.AccessToken{ID: "t3", UserID: "u2", Name: "ci", Hash: "h3", Scopes: []string{"read"}, CreatedAt: now}
+	for _, tok := range []*domain.AccessToken{older, newer, other} {
+		if err := repo.Save(ctx, tok); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+	}
+
+	// 保存後に呼び出し側の値を変更しても影響しない
+	older.Scopes[0] = "changed"
+	got, err := repo.FindByHash(ctx, "h1")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.ID != "t1" || got.Scopes[0] != "read" {
+		t.Errorf("expected stored copy, got %+v", got)
+	}
+	if _, err := repo.FindByHash(ctx, "missing"); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("expected ErrTokenNotFound, got %v", err)
+	}
+
+	got.Revoke(now.Add(2 * time.Hour))
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	got, err = repo.FindByID(ctx, "t1")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.RevokedAt == nil || !got.RevokedAt.Equal(now.Add(2*time.Hour)) {
+		t.Errorf("unexpected token after revoke: %+v", got)
+	}
+	if err := repo.Update(ctx, &domain.AccessToken{ID: "missing"}); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("expected ErrTokenNotFound, got %v", err)
+	}
+
+	list, err := repo.ListByUser(ctx, "u1")
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != "t2" || list[1].ID != "t1" {
+		t.Errorf("expected newest first, got %+v", list)
+	}
+}
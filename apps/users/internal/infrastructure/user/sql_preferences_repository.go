@@ -0,0 +1,67 @@
+package userinfra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "teamflow-users/internal/domain/user"
+	usecase "teamflow-users/internal/usecase/user"
+)
+
+// SQLPreferencesRepository はPostgreSQLを使用したPreferencesRepository実装。
+type SQLPreferencesRepository struct {
+	db *pgxpool.Pool
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.PreferencesRepository = (*SQLPreferencesRepository)(nil)
+
+// NewSQLPreferencesRepository は新しいSQLPreferencesRepositoryを生成する。
+func NewSQLPreferencesRepository(db *pgxpool.Pool) *SQLPreferencesRepository {
+	return &SQLPreferencesRepository{
+		db: db,
+	}
+}
+
+// Save は通知の設定を保存する（既にある場合は置き換える）。
+func (r *SQLPreferencesRepository) Save(ctx context.Context, p *domain.NotificationPreferences) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO notification_preferences (user_id, email_on_assignment, email_on_due_soon, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE SET
+			email_on_assignment = EXCLUDED.email_on_assignment,
+			email_on_due_soon = EXCLUDED.email_on_due_soon,
+			updated_at = EXCLUDED.updated_at
+	`, p.UserID, p.EmailOnAssignment, p.EmailOnDueSoon, p.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+	return nil
+}
+
+// FindByUserIDs は userIDs のうち設定を保存しているユーザーの設定をユーザーの ID の昇順で返す。
+func (r *SQLPreferencesRepository) FindByUserIDs(ctx context.Context, userIDs []string) ([]*domain.NotificationPreferences, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT user_id, email_on_assignment, email_on_due_soon, updated_at
+		FROM notification_preferences WHERE user_id = ANY($1) ORDER BY user_id ASC
+	`, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification preferences: %w", err)
+	}
+	defer rows.Close()
+
+	prefs := []*domain.NotificationPreferences{}
+	for rows.Next() {
+		var p domain.NotificationPreferences
+		if err := rows.Scan(&p.UserID, &p.EmailOnAssignment, &p.EmailOnDueSoon, &p.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan notification preferences: %w", err)
+		}
+		prefs = append(prefs, &p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notification preferences: %w", err)
+	}
+	return prefs, nil
+}
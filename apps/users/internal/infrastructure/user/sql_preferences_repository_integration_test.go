@@ -0,0 +1,45 @@
+//go:build integration
+// +build integration
+
+package userinfra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "teamflow-users/internal/domain/user"
+)
+
+func TestSQLPreferencesRepository(t *testing.T) {
+	db := setupUsersDB(t)
+	users := NewSQLUserRepository(db)
+	repo := NewSQLPreferencesRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	for _, id := range []string{"u1", "u2"} {
+		if err := users.Save(ctx, &domain.User{ID: id, Name: id, Email: id + "@example.com", CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatalf("failed to save user: %v", err)
+		}
+	}
+
+	p := &domain.NotificationPreferences{UserID: "u1", EmailOnAssignment: false, EmailOnDueSoon: true, UpdatedAt: now}
+	if err := repo.Save(ctx, p); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	// 2 回目の保存は置き換える
+	p.EmailOnDueSoon = false
+	p.UpdatedAt = now.Add(time.Hour)
+	if err := repo.Save(ctx, p); err != nil {
+		t.Fatalf("failed to save again: %v", err)
+	}
+
+	got, err := repo.FindByUserIDs(ctx, []string{"u1", "u2", "missing"})
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if len(got) != 1 || got[0].UserID != "u1" || got[0].EmailOnAssignment || got[0].EmailOnDueSoon || !got[0].UpdatedAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("unexpected preferences: %+v", got)
+	}
+}
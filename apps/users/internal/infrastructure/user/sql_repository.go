@@ -0,0 +1,141 @@
+package userinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "teamflow-users/internal/domain/user"
+	usecase "teamflow-users/internal/usecase/user"
+)
+
+// pgUniqueViolation は PostgreSQL の一意制約違反のエラーコード。
+const pgUniqueViolation = "23505"
+
+// userColumns は SELECT 時のカラム順。scanUser の Scan 順と一致させる。
+const userColumns = "id, name, email, avatar_url, created_at, updated_at"
+
+// userEmailIndex はメールアドレスの一意インデックス名（0001_create_users）。
+const userEmailIndex = "idx_users_email"
+
+// SQLUserRepository はPostgreSQLを使用したUserRepository実装。
+type SQLUserRepository struct {
+	db *pgxpool.Pool
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.UserRepository = (*SQLUserRepository)(nil)
+
+// NewSQLUserRepository は新しいSQLUserRepositoryを生成する。
+func NewSQLUserRepository(db *pgxpool.Pool) *SQLUserRepository {
+	return &SQLUserRepository{
+		db: db,
+	}
+}
+
+// Save はユーザーを保存する。
+// 同じ ID がある場合は ErrUserAlreadyExists、同じメールアドレスがある場合は ErrEmailAlreadyExists を返す。
+func (r *SQLUserRepository) Save(ctx context.Context, u *domain.User) error {
+	_, err := r.db.Exec(ctx,
+		"INSERT INTO users ("+userColumns+") VALUES ($1, $2, $3, $4, $5, $6)",
+		u.ID, u.Name, u.Email, nullString(u.AvatarURL), u.CreatedAt, u.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			if pgErr.ConstraintName == userEmailIndex {
+				return ErrEmailAlreadyExists
+			}
+			return ErrUserAlreadyExists
+		}
+		return fmt.Errorf("failed to insert user: %w", err)
+	}
+	return nil
+}
+
+// Update はユーザーを更新する。
+// 存在しない場合は ErrUserNotFound、メールアドレスが他のユーザーと重複する場合は ErrEmailAlreadyExists を返す。
+func (r *SQLUserRepository) Update(ctx context.Context, u *domain.User) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE users SET
+			name = $2,
+			email = $3,
+			avatar_url = $4,
+			updated_at = $5
+		WHERE id = $1
+	`, u.ID, u.Name, u.Email, nullString(u.AvatarURL), u.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == userEmailIndex {
+			return ErrEmailAlreadyExists
+		}
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// FindByID はユーザーを取得する。存在しない場合は ErrUserNotFound を返す。
+func (r *SQLUserRepository) FindByID(ctx context.Context, id string) (*domain.User, error) {
+	row := r.db.QueryRow(ctx, "SELECT "+userColumns+" FROM users WHERE id = $1", id)
+	u, err := scanUser(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	return u, nil
+}
+
+// FindByIDs は ids のうち存在するユーザーを ID の昇順で返す。
+func (r *SQLUserRepository) FindByIDs(ctx context.Context, ids []string) ([]*domain.User, error) {
+	rows, err := r.db.Query(ctx,
+		"SELECT "+userColumns+" FROM users WHERE id = ANY($1) ORDER BY id ASC",
+		ids,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []*domain.User{}
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate users: %w", err)
+	}
+	return users, nil
+}
+
+// scanUser は userColumns の順で 1 行を読み取る。
+func scanUser(row pgx.Row) (*domain.User, error) {
+	var u domain.User
+	var avatarURL *string
+	if err := row.Scan(&u.ID, &u.Name, &u.Email, &avatarURL, &u.CreatedAt, &u.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if avatarURL != nil {
+		u.AvatarURL = *avatarURL
+	}
+	return &u, nil
+}
+
+// nullString は空文字を NULL として書き込む。
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
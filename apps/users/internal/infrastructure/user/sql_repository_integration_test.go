@@ -0,0 +1,76 @@
+//go:build integration
+// +build integration
+
+package userinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "teamflow-users/internal/domain/user"
+)
+
+func TestSQLUserRepository(t *testing.T) {
+	db := setupUsersDB(t)
+	repo := NewSQLUserRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	for _, u := range []*domain.User{
+		{ID: "u2", Name: "Jiro", Email: "jiro@example.com", CreatedAt: now, UpdatedAt: now},
+		{ID: "u1", Name: "Taro", Email: "Taro@example.com", AvatarURL: "https://example.com/a.png", CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := repo.Save(ctx, u); err != nil {
+			t.Fatalf("failed to save %s: %v", u.ID, err)
+		}
+	}
+	if err := repo.Save(ctx, &domain.User{ID: "u1", Name: "dup", Email: "dup@example.com", CreatedAt: now, UpdatedAt: now}); !errors.Is(err, ErrUserAlreadyExists) {
+		t.Errorf("expected ErrUserAlreadyExists, got %v", err)
+	}
+	if err := repo.Save(ctx, &domain.User{ID: "u3", Name: "dup", Email: "taro@EXAMPLE.com", CreatedAt: now, UpdatedAt: now}); !errors.Is(err, ErrEmailAlreadyExists) {
+		t.Errorf("expected ErrEmailAlreadyExists, got %v", err)
+	}
+
+	got, err := repo.FindByID(ctx, "u1")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.Name != "Taro" || got.AvatarURL != "https://example.com/a.png" || !got.CreatedAt.Equal(now) {
+		t.Errorf("unexpected user: %+v", got)
+	}
+	if _, err := repo.FindByID(ctx, "missing"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+
+	// アバターを外すと NULL になり、空文字で読み戻せる
+	got.AvatarURL = ""
+	got.UpdatedAt = now.Add(time.Hour)
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	got, err = repo.FindByID(ctx, "u1")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.AvatarURL != "" || !got.UpdatedAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("unexpected user after update: %+v", got)
+	}
+
+	got.Email = "JIRO@example.com"
+	if err := repo.Update(ctx, got); !errors.Is(err, ErrEmailAlreadyExists) {
+		t.Errorf("expected ErrEmailAlreadyExists, got %v", err)
+	}
+	if err := repo.Update(ctx, &domain.User{ID: "missing", Name: "x", Email: "x@example.com", UpdatedAt: now}); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+
+	users, err := repo.FindByIDs(ctx, []string{"u2", "missing", "u1"})
+	if err != nil {
+		t.Fatalf("failed to find by ids: %v", err)
+	}
+	if len(users) != 2 || users[0].ID != "u1" || users[1].ID != "u2" {
+		t.Errorf("unexpected users: %+v", users)
+	}
+}
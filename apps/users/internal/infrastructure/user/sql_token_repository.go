@@ -0,0 +1,118 @@
+package userinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "teamflow-users/internal/domain/user"
+	usecase "teamflow-users/internal/usecase/user"
+)
+
+// tokenColumns は SELECT 時のカラム順。scanToken の Scan 順と一致させる。
+const tokenColumns = "id, user_id, workspace_id, name, prefix, token_hash, scopes, created_at, expires_at, last_used_at, revoked_at"
+
+// SQLTokenRepository はPostgreSQLを使用したTokenRepository実装。
+type SQLTokenRepository struct {
+	db *pgxpool.Pool
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ usecase.TokenRepository = (*SQLTokenRepository)(nil)
+
+// NewSQLTokenRepository は新しいSQLTokenRepositoryを生成する。
+func NewSQLTokenRepository(db *pgxpool.Pool) *SQLTokenRepository {
+	return &SQLTokenRepository{
+		db: db,
+	}
+}
+
+// Save はトークンを保存する。
+func (r *SQLTokenRepository) Save(ctx context.Context, t *domain.AccessToken) error {
+	_, err := r.db.Exec(ctx,
+		"INSERT INTO personal_access_tokens ("+tokenColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
+		t.ID, t.UserID, t.WorkspaceID, t.Name, t.Prefix, t.Hash, t.Scopes, t.CreatedAt, t.ExpiresAt, t.LastUsedAt, t.RevokedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert access token: %w", err)
+	}
+	return nil
+}
+
+// Update はトークンの最終使用日時・失効日時を更新する。存在しない場合は ErrTokenNotFound を返す。
+func (r *SQLTokenRepository) Update(ctx context.Context, t *domain.AccessToken) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE personal_access_tokens SET
+			last_used_at = $2,
+			revoked_at = $3
+		WHERE id = $1
+	`, t.ID, t.LastUsedAt, t.RevokedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update access token: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTokenNotFound
+	}
+	return nil
+}
+
+// FindByID はトークンを取得する。存在しない場合は ErrTokenNotFound を返す。
+func (r *SQLTokenRepository) FindByID(ctx context.Context, id string) (*domain.AccessToken, error) {
+	return r.findOne(ctx, "id", id)
+}
+
+// FindByHash はハッシュが一致するトークンを取得する。存在しない場合は ErrTokenNotFound を返す。
+func (r *SQLTokenRepository) FindByHash(ctx context.Context, hash string) (*domain.AccessToken, error) {
+	return r.findOne(ctx, "token_hash", hash)
+}
+
+// findOne は column = value のトークンを 1 件取得する。column は固定の値だけを渡すこと。
+func (r *SQLTokenRepository) findOne(ctx context.Context, column, value string) (*domain.AccessToken, error) {
+	row := r.db.QueryRow(ctx, "SELECT "+tokenColumns+" FROM personal_access_tokens WHERE "+column+" = $1", value)
+	t, err := scanToken(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTokenNotFound
+		}
+		return nil, fmt.Errorf("failed to find access token: %w", err)
+	}
+	return t, nil
+}
+
+// ListByUser は userID のトークンを発行の新しい順（同時刻は ID の昇順）に返す。
+func (r *SQLTokenRepository) ListByUser(ctx context.Context, userID string) ([]*domain.AccessToken, error) {
+	rows, err := r.db.Query(ctx,
+		"SELECT "+tokenColumns+" FROM personal_access_tokens WHERE user_id = $1 ORDER BY created_at DESC, id ASC",
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list access tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []*domain.AccessToken{}
+	for rows.Next() {
+		t, err := scanToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan access token: %w", err)
+		}
+		tokens = append(tokens, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate access tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// scanToken は tokenColumns の順で 1 行を読み取る。
+func scanToken(row pgx.Row) (*domain.AccessToken, error) {
+	var t domain.AccessToken
+	if err := row.Scan(&t.ID, &t.UserID, &t.WorkspaceID, &t.Name, &t.Prefix, &t.Hash, &t.Scopes,
+		&t.CreatedAt, &t.ExpiresAt, &t.LastUsedAt, &t.RevokedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,74 @@
+//go:build integration
+// +build integration
+
+package userinfra
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	domain "teamflow-users/internal/domain/user"
+)
+
+func TestSQLTokenRepository(t *testing.T) {
+	db := setupUsersDB(t)
+	users := NewSQLUserRepository(db)
+	repo := NewSQLTokenRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	if err := users.Save(ctx, &domain.User{ID: "u1", Name: "Taro", Email: "taro@example.com", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("failed to save user: %v", err)
+	}
+
+	expiresAt := now.Add(24 * time.Hour)
+	for _, tok := range []*domain.AccessToken{
+		{ID: "t1", UserID: "u1", WorkspaceID: "acme", Name: "laptop", Prefix: "tfp_aaaaaaaa", Hash: "h1", Scopes: []string{"read"}, CreatedAt: now},
+		{ID: "t2", UserID: "u1", WorkspaceID: "acme", Name: "ci", Prefix: "tfp_bbbbbbbb", Hash: "h2", Scopes: []string{"read", "write"}, CreatedAt: now.Add(time.Hour), ExpiresAt: &expiresAt},
+	} {
+		if err := repo.Save(ctx, tok); err != nil {
+			t.Fatalf("failed to save %s: %v", tok.ID, err)
+		}
+	}
+
+	got, err := repo.FindByHash(ctx, "h2")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.ID != "t2" || !reflect.DeepEqual(got.Scopes, []string{"read", "write"}) || got.ExpiresAt == nil || !got.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("unexpected token: %+v", got)
+	}
+	if got.LastUsedAt != nil || got.RevokedAt != nil {
+		t.Errorf("expected NULL timestamps, got %+v", got)
+	}
+	if _, err := repo.FindByHash(ctx, "missing"); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("expected ErrTokenNotFound, got %v", err)
+	}
+
+	used, revoked := now.Add(2*time.Hour), now.Add(3*time.Hour)
+	got.LastUsedAt, got.RevokedAt = &used, &revoked
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	got, err = repo.FindByID(ctx, "t2")
+	if err != nil {
+		t.Fatalf("failed to find: %v", err)
+	}
+	if got.LastUsedAt == nil || !got.LastUsedAt.Equal(used) || got.RevokedAt == nil || !got.RevokedAt.Equal(revoked) {
+		t.Errorf("unexpected token after update: %+v", got)
+	}
+	if err := repo.Update(ctx, &domain.AccessToken{ID: "missing"}); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("expected ErrTokenNotFound, got %v", err)
+	}
+
+	list, err := repo.ListByUser(ctx, "u1")
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != "t2" || list[1].ID != "t1" {
+		t.Errorf("expected newest first, got %+v", list)
+	}
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/requestid"
+
+	domain "teamflow-users/internal/domain/user"
+	usecase "teamflow-users/internal/usecase/user"
+)
+
+// エラーレスポンスは tasks / projects サービスと同じ ErrorResponse / ValidationIssue 形式（teamflow-shared/apierror）で返す。
+
+// writeError は status と ErrorResponse を書き込む。
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	apierror.Write(w, status, apierror.New(code, message))
+}
+
+// writeValidationError は 400 + VALIDATION_ERROR を issues 付きで書き込む。
+func writeValidationError(w http.ResponseWriter, issues ...apierror.ValidationIssue) {
+	apierror.Write(w, http.StatusBadRequest, apierror.New(apierror.CodeValidation, "Invalid request", issues...))
+}
+
+// writeInvalidJSON はリクエストボディを JSON としてデコードできない場合の 400 を書き込む。
+func writeInvalidJSON(w http.ResponseWriter) {
+	writeValidationError(w, apierror.ValidationIssue{
+		Location: apierror.LocationBody,
+		Field:    "body",
+		Code:     "INVALID_FORMAT",
+		Message:  "リクエストボディは JSON で指定してください。",
+	})
+}
+
+// writeNotFound は 404 + NOT_FOUND を書き込む。
+func writeNotFound(w http.ResponseWriter, message string) {
+	writeError(w, http.StatusNotFound, apierror.CodeNotFound, message)
+}
+
+// writeMethodNotAllowed は 405 + METHOD_NOT_ALLOWED を書き込む。
+func writeMethodNotAllowed(w http.ResponseWriter) {
+	writeError(w, http.StatusMethodNotAllowed, apierror.CodeMethodNotAllowed, "method not allowed")
+}
+
+// writeInternalError は 500 + INTERNAL_ERROR を書き込む。内部エラーの詳細はレスポンスに含めない。
+func writeInternalError(w http.ResponseWriter) {
+	writeError(w, http.StatusInternalServerError, apierror.CodeInternal, "internal server error")
+}
+
+// writeUsecaseError はユースケース・リポジトリのエラーをステータスコードに変換して書き込む。
+//
+//	400  ドメインのバリデーションエラー（ValidationIssue 付き）
+//	401  操作者（X-User-ID）が無い
+//	403  本人以外のアクセストークン・通知の設定の操作
+//	404  ユーザー・アクセストークンが存在しない
+//	409  ID・メールアドレスの重複
+//	500  その他（タイムアウトを含む）
+func writeUsecaseError(w http.ResponseWriter, err error) {
+	if issue, ok := toValidationIssue(err); ok {
+		writeValidationError(w, issue)
+		return
+	}
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound),
+		errors.Is(err, usecase.ErrTokenNotFound):
+		writeNotFound(w, err.Error())
+	case errors.Is(err, usecase.ErrActorRequired):
+		writeError(w, http.StatusUnauthorized, apierror.CodeUnauthorized, "X-User-ID header is required")
+	case errors.Is(err, usecase.ErrForbidden):
+		writeError(w, http.StatusForbidden, apierror.CodeForbidden, "access tokens and notification preferences can only be managed by their owner")
+	case errors.Is(err, usecase.ErrUserAlreadyExists),
+		errors.Is(err, usecase.ErrEmailAlreadyExists):
+		writeError(w, http.StatusConflict, apierror.CodeConflict, err.Error())
+	default:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			slog.Error("unhandled error", "error", err, "request_id", w.Header().Get(requestid.Header))
+		}
+		writeInternalError(w)
+	}
+}
+
+// toValidationIssue はドメインのバリデーションエラーを ValidationIssue に変換する。
+// バリデーションエラーでない場合は false を返す。
+func toValidationIssue(err error) (apierror.ValidationIssue, bool) {
+	issue := func(field, code, message string) (apierror.ValidationIssue, bool) {
+		return apierror.ValidationIssue{Location: apierror.LocationBody, Field: field, Code: code, Message: message}, true
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrInvalidID):
+		return issue("id", "INVALID_FORMAT", "id は '/' を含まない空でない文字列で指定してください。")
+	case errors.Is(err, domain.ErrNameRequired):
+		return issue("name", "REQUIRED", "name は必須です。")
+	case errors.Is(err, domain.ErrNameTooLong):
+		return issue("name", "TOO_LONG", "name は 100 文字以内で指定してください。")
+	case errors.Is(err, domain.ErrInvalidEmail):
+		return issue("email", "INVALID_FORMAT", "email はメールアドレスの形式で指定してください（例: taro@example.com）。")
+	case errors.Is(err, domain.ErrInvalidAvatarURL):
+		return issue("avatarUrl", "INVALID_FORMAT", "avatarUrl は http または https の URL で指定してください。")
+	case errors.Is(err, domain.ErrTokenNameRequired):
+		return issue("name", "REQUIRED", "name は必須です。")
+	case errors.Is(err, domain.ErrTokenNameTooLong):
+		return issue("name", "TOO_LONG", "name は 100 文字以内で指定してください。")
+	case errors.Is(err, domain.ErrInvalidScopes):
+		return issue("scopes", "INVALID_VALUE", "scopes は read, write から 1 つ以上指定してください。")
+	case errors.Is(err, domain.ErrInvalidExpiresAt):
+		return issue("expiresAt", "INVALID_VALUE", "expiresAt は現在より後の日時を指定してください。")
+	case errors.Is(err, domain.ErrInvalidLookup):
+		return issue("ids", "INVALID_VALUE", err.Error())
+	}
+	return apierror.ValidationIssue{}, false
+}
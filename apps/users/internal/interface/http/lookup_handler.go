@@ -0,0 +1,109 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"teamflow-shared/apierror"
+
+	usecase "teamflow-users/internal/usecase/user"
+)
+
+// LookupHandler は POST /users:lookup（ユーザーの一括取得）を処理する HTTP ハンドラ。
+// tasks サービスが担当者の存在確認と、一覧に表示する名前の取得、通知の宛先の取得に使う。
+type LookupHandler struct {
+	lookupUC *usecase.LookupUsersUsecase
+}
+
+// NewLookupHandler は LookupHandler を生成する。
+func NewLookupHandler(lookupUC *usecase.LookupUsersUsecase) http.Handler {
+	return &LookupHandler{lookupUC: lookupUC}
+}
+
+// lookupUsersRequest は POST /users:lookup のリクエスト。
+type lookupUsersRequest struct {
+	IDs []string `json:"ids"` // 最大 200 件。重複は 1 件として扱う
+}
+
+// lookupUsersResponse は POST /users:lookup のレスポンス。
+type lookupUsersResponse struct {
+	Users      []lookupUserResponse `json:"users"`      // 見つかったユーザー（指定順）
+	MissingIDs []string             `json:"missingIds"` // 存在しなかった ID（指定順）
+}
+
+// lookupUserResponse は一括取得のユーザー。
+type lookupUserResponse struct {
+	userResponse
+	// NotificationPreferences は expand=notificationPreferences を指定した場合のみ設定する
+	NotificationPreferences *preferencesResponse `json:"notificationPreferences,omitempty"`
+}
+
+// ServeHTTP は ids のユーザーをまとめて返す。存在しない ID は 404 にせず missingIds で返す。
+// expand=notificationPreferences を指定した場合は各ユーザーに通知の設定を含める。
+func (h *LookupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+	withPreferences, ok := parseLookupExpand(r.URL.Query().Get("expand"))
+	if !ok {
+		writeValidationError(w, apierror.ValidationIssue{
+			Location: apierror.LocationQuery,
+			Field:    "expand",
+			Code:     "INVALID_ENUM",
+			Message:  "expand は 'notificationPreferences' を指定してください。",
+		})
+		return
+	}
+
+	var req lookupUsersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	res, err := h.lookupUC.Execute(r.Context(), req.IDs)
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := lookupUsersResponse{
+		Users:      make([]lookupUserResponse, 0, len(res.Users)),
+		MissingIDs: res.MissingIDs,
+	}
+	for _, u := range res.Users {
+		resp.Users = append(resp.Users, lookupUserResponse{userResponse: toUserResponse(u)})
+	}
+	if withPreferences {
+		prefs, err := h.lookupUC.PreferencesOf(r.Context(), res.Users)
+		if err != nil {
+			writeUsecaseError(w, err)
+			return
+		}
+		for i, u := range res.Users {
+			p := toPreferencesResponse(prefs[u.ID])
+			resp.Users[i].NotificationPreferences = &p
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// parseLookupExpand は一括取得の expand（カンマ区切り）を解析し、notificationPreferences が指定されているかどうかを返す。
+// それ以外の値を含む場合は ok が false。
+func parseLookupExpand(v string) (preferences, ok bool) {
+	for _, e := range strings.Split(v, ",") {
+		switch strings.TrimSpace(e) {
+		case "":
+		case "notificationPreferences":
+			preferences = true
+		default:
+			return false, false
+		}
+	}
+	return preferences, true
+}
@@ -0,0 +1,15 @@
+package http
+
+import "teamflow-shared/i18n"
+
+// Messages は ValidationIssue の message の翻訳（field.code ごと）。日本語は toValidationIssue の文言をそのまま使う。
+// server.Options.Messages に渡し、Accept-Language で英語を求められた場合に使う。
+// INVALID_VALUE はドメインのエラー（英語）をそのまま message にしているため翻訳しない。
+var Messages = i18n.Merge(i18n.Common, i18n.Catalog{
+	"id.INVALID_FORMAT":        {i18n.English: "id must be a non-empty string without '/'."},
+	"name.REQUIRED":            {i18n.English: "name is required."},
+	"name.TOO_LONG":            {i18n.English: "name must be at most 100 characters."},
+	"email.INVALID_FORMAT":     {i18n.English: "email must be a valid email address (e.g. taro@example.com)."},
+	"avatarUrl.INVALID_FORMAT": {i18n.English: "avatarUrl must be an http or https URL."},
+	"expand.INVALID_ENUM":      {i18n.English: "expand must be 'notificationPreferences'."},
+})
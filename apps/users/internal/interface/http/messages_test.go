@@ -0,0 +1,29 @@
+package http
+
+import (
+	"testing"
+
+	"teamflow-shared/i18n"
+
+	domain "teamflow-users/internal/domain/user"
+)
+
+// toValidationIssue が返す field・code には英語の文言があること（INVALID_VALUE はドメインのエラーをそのまま使う）。
+func TestMessages_CoverValidationIssues(t *testing.T) {
+	errs := []error{
+		domain.ErrInvalidID,
+		domain.ErrNameRequired,
+		domain.ErrNameTooLong,
+		domain.ErrInvalidEmail,
+		domain.ErrInvalidAvatarURL,
+	}
+	for _, err := range errs {
+		issue, ok := toValidationIssue(err)
+		if !ok {
+			t.Fatalf("%v is not a validation error", err)
+		}
+		if msg, ok := Messages.Message(i18n.English, issue.Field, issue.Code); !ok || msg == "" {
+			t.Errorf("no English message for %s.%s", issue.Field, issue.Code)
+		}
+	}
+}
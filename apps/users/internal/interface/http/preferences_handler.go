@@ -0,0 +1,111 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"teamflow-shared/clock"
+
+	domain "teamflow-users/internal/domain/user"
+	usecase "teamflow-users/internal/usecase/user"
+)
+
+// PreferencesHandler は /users/{id}/notification-preferences（通知の設定）を処理する HTTP ハンドラ。
+// 操作できるのは本人（X-User-ID が {id} と一致する）だけ。
+type PreferencesHandler struct {
+	getUC    *usecase.GetPreferencesUsecase
+	updateUC *usecase.UpdatePreferencesUsecase
+	clock    clock.Clock
+}
+
+// NewPreferencesHandler は PreferencesHandler を生成する。
+func NewPreferencesHandler(
+	getUC *usecase.GetPreferencesUsecase,
+	updateUC *usecase.UpdatePreferencesUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &PreferencesHandler{
+		getUC:    getUC,
+		updateUC: updateUC,
+		clock:    clk,
+	}
+}
+
+// updatePreferencesRequest は PATCH のリクエスト。省略した項目は変更しない。
+type updatePreferencesRequest struct {
+	EmailOnAssignment *bool `json:"emailOnAssignment"`
+	EmailOnDueSoon    *bool `json:"emailOnDueSoon"`
+}
+
+type preferencesResponse struct {
+	EmailOnAssignment bool       `json:"emailOnAssignment"`
+	EmailOnDueSoon    bool       `json:"emailOnDueSoon"`
+	UpdatedAt         *time.Time `json:"updatedAt"` // 保存していない（既定の設定の）場合は null
+}
+
+func toPreferencesResponse(p *domain.NotificationPreferences) preferencesResponse {
+	resp := preferencesResponse{
+		EmailOnAssignment: p.EmailOnAssignment,
+		EmailOnDueSoon:    p.EmailOnDueSoon,
+	}
+	if !p.UpdatedAt.IsZero() {
+		updatedAt := p.UpdatedAt
+		resp.UpdatedAt = &updatedAt
+	}
+	return resp
+}
+
+// parsePreferencesPath は /users/{id}/notification-preferences から userID を取り出す。
+func parsePreferencesPath(path string) (userID string, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/users/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "notification-preferences" {
+		return "", false
+	}
+	return parts[0], true
+}
+
+// ServeHTTP は以下を処理する。
+// - GET   /users/{id}/notification-preferences : 通知の設定の取得（保存していない場合は既定の設定）
+// - PATCH /users/{id}/notification-preferences : 通知の設定の更新
+func (h *PreferencesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parsePreferencesPath(r.URL.Path)
+	if !ok {
+		writeNotFound(w, "not found")
+		return
+	}
+
+	var (
+		prefs *domain.NotificationPreferences
+		err   error
+	)
+	switch r.Method {
+	case http.MethodGet:
+		prefs, err = h.getUC.Execute(r.Context(), userID, actorID(r))
+	case http.MethodPatch:
+		var req updatePreferencesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeInvalidJSON(w)
+			return
+		}
+		prefs, err = h.updateUC.Execute(r.Context(), usecase.UpdatePreferencesInput{
+			UserID:            userID,
+			ActorID:           actorID(r),
+			EmailOnAssignment: req.EmailOnAssignment,
+			EmailOnDueSoon:    req.EmailOnDueSoon,
+			Now:               h.clock.Now(),
+		})
+	default:
+		writeMethodNotAllowed(w)
+		return
+	}
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toPreferencesResponse(prefs))
+}
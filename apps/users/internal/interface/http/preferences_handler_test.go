@@ -0,0 +1,134 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	domain "teamflow-users/internal/domain/user"
+	infra "teamflow-users/internal/infrastructure/user"
+	httpiface "teamflow-users/internal/interface/http"
+	usecase "teamflow-users/internal/usecase/user"
+)
+
+// newPreferencesHandlers は user-1, user-2 を登録したリポジトリを共有する PreferencesHandler と LookupHandler を返す。
+func newPreferencesHandlers(t *testing.T) (prefs, lookup http.Handler) {
+	t.Helper()
+	users := infra.NewMemoryUserRepository()
+	for _, id := range []string{"user-1", "user-2"} {
+		u, err := domain.NewUser(id, id, id+"@example.com", "", fixedClock.Now())
+		if err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+		if err := users.Save(context.Background(), u); err != nil {
+			t.Fatalf("failed to save user: %v", err)
+		}
+	}
+
+	repo := infra.NewMemoryPreferencesRepository()
+	prefs = httpiface.NewPreferencesHandler(
+		&usecase.GetPreferencesUsecase{Users: users, Preferences: repo},
+		&usecase.UpdatePreferencesUsecase{Users: users, Preferences: repo},
+		fixedClock,
+	)
+	return prefs, httpiface.NewLookupHandler(&usecase.LookupUsersUsecase{Repo: users, Preferences: repo})
+}
+
+type preferencesBody struct {
+	EmailOnAssignment bool    `json:"emailOnAssignment"`
+	EmailOnDueSoon    bool    `json:"emailOnDueSoon"`
+	UpdatedAt         *string `json:"updatedAt"`
+}
+
+func TestPreferencesHandler(t *testing.T) {
+	handler, _ := newPreferencesHandlers(t)
+	path := "/users/user-1/notification-preferences"
+
+	// 保存していない場合は既定の設定（すべて受け取る）
+	w := doActorRequest(handler, http.MethodGet, path, "user-1", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var got preferencesBody
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !got.EmailOnAssignment || !got.EmailOnDueSoon || got.UpdatedAt != nil {
+		t.Errorf("expected default preferences, got %+v", got)
+	}
+
+	// 省略した項目は変更しない
+	w = doActorRequest(handler, http.MethodPatch, path, "user-1", map[string]bool{"emailOnDueSoon": false})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doActorRequest(handler, http.MethodGet, path, "user-1", nil)
+	got = preferencesBody{}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !got.EmailOnAssignment || got.EmailOnDueSoon || got.UpdatedAt == nil {
+		t.Errorf("unexpected preferences: %+v", got)
+	}
+
+	for _, tt := range []struct {
+		name, method, path, actor string
+		want                      int
+	}{
+		{name: "no actor", method: http.MethodGet, path: path, want: http.StatusUnauthorized},
+		{name: "other user", method: http.MethodPatch, path: path, actor: "user-2", want: http.StatusForbidden},
+		{name: "missing user", method: http.MethodGet, path: "/users/missing/notification-preferences", actor: "missing", want: http.StatusNotFound},
+		{name: "method", method: http.MethodDelete, path: path, actor: "user-1", want: http.StatusMethodNotAllowed},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := doActorRequest(handler, tt.method, tt.path, tt.actor, map[string]bool{}); w.Code != tt.want {
+				t.Errorf("expected status %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestLookupHandler_ExpandNotificationPreferences(t *testing.T) {
+	prefs, lookup := newPreferencesHandlers(t)
+	if w := doActorRequest(prefs, http.MethodPatch, "/users/user-2/notification-preferences", "user-2", map[string]bool{"emailOnAssignment": false}); w.Code != http.StatusOK {
+		t.Fatalf("failed to update preferences: %d %s", w.Code, w.Body.String())
+	}
+
+	w := doRequest(lookup, http.MethodPost, "/users:lookup?expand=notificationPreferences", map[string][]string{"ids": {"user-1", "user-2"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Users []struct {
+			ID                      string           `json:"id"`
+			NotificationPreferences *preferencesBody `json:"notificationPreferences"`
+		} `json:"users"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Users) != 2 || resp.Users[0].NotificationPreferences == nil || resp.Users[1].NotificationPreferences == nil {
+		t.Fatalf("expected preferences for each user, got %+v", resp.Users)
+	}
+	if p := resp.Users[0].NotificationPreferences; !p.EmailOnAssignment || !p.EmailOnDueSoon {
+		t.Errorf("expected default preferences for user-1, got %+v", p)
+	}
+	if p := resp.Users[1].NotificationPreferences; p.EmailOnAssignment || !p.EmailOnDueSoon {
+		t.Errorf("unexpected preferences for user-2: %+v", p)
+	}
+
+	// expand を指定しない場合は含めない
+	w = doRequest(lookup, http.MethodPost, "/users:lookup", map[string][]string{"ids": {"user-1"}})
+	resp.Users = nil
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Users) != 1 || resp.Users[0].NotificationPreferences != nil {
+		t.Errorf("expected no preferences without expand, got %+v", resp.Users)
+	}
+
+	if w := doRequest(lookup, http.MethodPost, "/users:lookup?expand=tokens", map[string][]string{"ids": {"user-1"}}); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for unknown expand, got %d", w.Code)
+	}
+}
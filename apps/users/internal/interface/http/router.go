@@ -0,0 +1,91 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"teamflow-shared/apiversion"
+)
+
+// APIPrefix は users サービスの API を配置するパス（tasks / projects サービスと同じ）。
+const APIPrefix = "/api"
+
+// Handlers は Router に登録する各エンドポイントのハンドラ。
+type Handlers struct {
+	Users       http.Handler // POST /api/users, GET|PATCH /api/users/{id}
+	Lookup      http.Handler // POST /api/users:lookup
+	Tokens      http.Handler // POST|GET /api/users/{id}/tokens, DELETE /api/users/{id}/tokens/{tokenId}
+	Preferences http.Handler // GET|PATCH /api/users/{id}/notification-preferences
+	Introspect  http.Handler // POST /api/tokens:introspect
+}
+
+// NewRouter は users サービスの API のルーティングを行うハンドラを返す。
+//
+// API はすべて APIPrefix 配下（/api/v1 と、その別名の /api）に置き、プレフィックスはここで一度だけ取り除く。
+// 各ハンドラは /api（/api/v1）を除いたパス（/users, /users/{id}...）を扱う。
+// /healthz, /metrics などの運用エンドポイントは含まない。
+func NewRouter(h Handlers) http.Handler {
+	api := http.NewServeMux()
+	api.Handle("/users", h.Users)
+	api.HandleFunc("/users/", h.serveUsers)
+	api.Handle("/users:lookup", h.Lookup)
+	api.Handle("/tokens:introspect", h.Introspect)
+
+	// 正式なパスは /api/v1 配下。バージョン無しの /api 配下は互換のため v1 の別名として扱う
+	return apiversion.Handler(APIPrefix, api)
+}
+
+// serveUsers は /users/{id} 配下を振り分ける。/users/{id}/tokens 配下はアクセストークンのハンドラ、
+// /users/{id}/notification-preferences は通知の設定のハンドラに渡す。
+func (h Handlers) serveUsers(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/users/"), "/")
+	if len(parts) >= 2 && parts[1] == "tokens" {
+		h.Tokens.ServeHTTP(w, r)
+		return
+	}
+	if len(parts) >= 2 && parts[1] == "notification-preferences" {
+		h.Preferences.ServeHTTP(w, r)
+		return
+	}
+	h.Users.ServeHTTP(w, r)
+}
+
+// routeSegments は RouteLabel でそのまま残すパスの要素。それ以外（ID など）は {id} に置き換える。
+var routeSegments = map[string]bool{
+	"livez":                    true,
+	"readyz":                   true,
+	"healthz":                  true,
+	"openapi.json":             true,
+	"api":                      true,
+	"users":                    true,
+	"users:lookup":             true,
+	"tokens":                   true,
+	"notification-preferences": true,
+	"tokens:introspect":        true,
+}
+
+// maxRouteSegments は RouteLabel で扱うパスの要素数の上限（/api/v1 の v1 を除く。これより深いパスは存在しない）。
+const maxRouteSegments = 5
+
+// RouteLabel はメトリクスの route ラベル用に、パスの ID を {id} に置き換えたルートを返す。
+// 例: /api/users/u-1 → /api/users/{id}、/api/users/u-1/tokens/t-1 → /api/users/{id}/tokens/{id}
+// ラベルの種類が増え続けないよう、ルートに無い深さのパスは "other" にまとめる。
+func RouteLabel(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	// /api/v1 のバージョンはそのまま残す（別名の /api と分けて計測する）
+	versioned := len(parts) > 1 && parts[0] == "api" && parts[1] == apiversion.Current
+	limit := maxRouteSegments
+	if versioned {
+		limit++
+	}
+	if len(parts) > limit {
+		return "other"
+	}
+	for i, p := range parts {
+		if routeSegments[p] || p == "" || (versioned && i == 1) {
+			continue
+		}
+		parts[i] = "{id}"
+	}
+	return "/" + strings.Join(parts, "/")
+}
@@ -0,0 +1,90 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpiface "teamflow-users/internal/interface/http"
+)
+
+// stubHandler は呼ばれたハンドラ名とハンドラが受け取ったパスをヘッダで返す。
+func stubHandler(name string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Handler", name)
+		w.Header().Set("X-Path", r.URL.Path)
+	})
+}
+
+// TestRouter は公開している URL と、各ハンドラが受け取るパス（/api を除いたもの）を固定する。
+func TestRouter(t *testing.T) {
+	router := httpiface.NewRouter(httpiface.Handlers{
+		Users:       stubHandler("users"),
+		Lookup:      stubHandler("lookup"),
+		Tokens:      stubHandler("tokens"),
+		Preferences: stubHandler("preferences"),
+		Introspect:  stubHandler("introspect"),
+	})
+
+	tests := []struct {
+		method      string
+		path        string
+		wantHandler string
+		wantPath    string
+	}{
+		{method: http.MethodPost, path: "/api/users", wantHandler: "users", wantPath: "/users"},
+		{method: http.MethodGet, path: "/api/users/user-1", wantHandler: "users", wantPath: "/users/user-1"},
+		{method: http.MethodPatch, path: "/api/v1/users/user-1", wantHandler: "users", wantPath: "/users/user-1"},
+		{method: http.MethodPost, path: "/api/users:lookup", wantHandler: "lookup", wantPath: "/users:lookup"},
+		{method: http.MethodPost, path: "/api/users/user-1/tokens", wantHandler: "tokens", wantPath: "/users/user-1/tokens"},
+		{method: http.MethodDelete, path: "/api/v1/users/user-1/tokens/t-1", wantHandler: "tokens", wantPath: "/users/user-1/tokens/t-1"},
+		{method: http.MethodPatch, path: "/api/users/user-1/notification-preferences", wantHandler: "preferences", wantPath: "/users/user-1/notification-preferences"},
+		{method: http.MethodPost, path: "/api/tokens:introspect", wantHandler: "introspect", wantPath: "/tokens:introspect"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if got := w.Header().Get("X-Handler"); got != tt.wantHandler {
+				t.Fatalf("expected handler %q, got %q (status %d)", tt.wantHandler, got, w.Code)
+			}
+			if got := w.Header().Get("X-Path"); got != tt.wantPath {
+				t.Errorf("expected path %q, got %q", tt.wantPath, got)
+			}
+		})
+	}
+
+	// /api を付けないパスは公開しない
+	for _, path := range []string{"/users", "/users/user-1", "/api/api/users"} {
+		t.Run("not found "+path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+			if w.Code != http.StatusNotFound {
+				t.Errorf("expected status 404, got %d (handler=%q)", w.Code, w.Header().Get("X-Handler"))
+			}
+		})
+	}
+}
+
+func TestRouteLabel(t *testing.T) {
+	for _, tt := range []struct {
+		path string
+		want string
+	}{
+		{path: "/api/users", want: "/api/users"},
+		{path: "/api/users/u-1", want: "/api/users/{id}"},
+		{path: "/api/users:lookup", want: "/api/users:lookup"},
+		{path: "/api/v1/users/u-1", want: "/api/v1/users/{id}"},
+		{path: "/healthz", want: "/healthz"},
+		{path: "/api/users/u-1/tokens", want: "/api/users/{id}/tokens"},
+		{path: "/api/v1/users/u-1/tokens/t-1", want: "/api/v1/users/{id}/tokens/{id}"},
+		{path: "/api/tokens:introspect", want: "/api/tokens:introspect"},
+		{path: "/api/v1/users/u-1/notification-preferences", want: "/api/v1/users/{id}/notification-preferences"},
+		{path: "/api/users/u-1/tokens/t-1/x", want: "other"},
+	} {
+		if got := httpiface.RouteLabel(tt.path); got != tt.want {
+			t.Errorf("RouteLabel(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
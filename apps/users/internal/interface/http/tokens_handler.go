@@ -0,0 +1,247 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"teamflow-shared/authz"
+	"teamflow-shared/clock"
+	"teamflow-shared/pat"
+	"teamflow-shared/workspace"
+
+	domain "teamflow-users/internal/domain/user"
+	usecase "teamflow-users/internal/usecase/user"
+)
+
+// TokensHandler は /users/{id}/tokens と /users/{id}/tokens/{tokenId}（個人用アクセストークン）を処理する HTTP ハンドラ。
+// 操作できるのは本人（X-User-ID が {id} と一致する）だけ。
+type TokensHandler struct {
+	issueUC  *usecase.IssueTokenUsecase
+	listUC   *usecase.ListTokensUsecase
+	revokeUC *usecase.RevokeTokenUsecase
+	clock    clock.Clock
+}
+
+// NewTokensHandler は TokensHandler を生成する。
+func NewTokensHandler(
+	issueUC *usecase.IssueTokenUsecase,
+	listUC *usecase.ListTokensUsecase,
+	revokeUC *usecase.RevokeTokenUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &TokensHandler{
+		issueUC:  issueUC,
+		listUC:   listUC,
+		revokeUC: revokeUC,
+		clock:    clk,
+	}
+}
+
+// createTokenRequest は POST のリクエスト。
+type createTokenRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expiresAt"` // 省略・null の場合は無期限
+}
+
+type tokenResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	Token      string     `json:"token,omitempty"` // 平文。発行時のレスポンスにだけ含める
+}
+
+type listTokensResponse struct {
+	Tokens []tokenResponse `json:"tokens"`
+}
+
+func toTokenResponse(t *domain.AccessToken) tokenResponse {
+	return tokenResponse{
+		ID:         t.ID,
+		Name:       t.Name,
+		Prefix:     t.Prefix,
+		Scopes:     t.Scopes,
+		CreatedAt:  t.CreatedAt,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		RevokedAt:  t.RevokedAt,
+	}
+}
+
+// parseTokensPath は /users/{id}/tokens[/{tokenId}] から userID と tokenID を取り出す。/tokens の場合 tokenID は空文字。
+func parseTokensPath(path string) (userID, tokenID string, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/users/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] != "tokens" {
+		return "", "", false
+	}
+	if len(parts) == 3 {
+		if parts[2] == "" {
+			return "", "", false
+		}
+		return parts[0], parts[2], true
+	}
+	return parts[0], "", true
+}
+
+// ServeHTTP は以下を処理する。
+// - POST   /users/{id}/tokens           : トークンの発行（平文はこのレスポンスでしか返さない）
+// - GET    /users/{id}/tokens           : トークンの一覧（失効したものを含む。発行の新しい順）
+// - DELETE /users/{id}/tokens/{tokenId} : トークンの失効
+func (h *TokensHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	userID, tokenID, ok := parseTokensPath(r.URL.Path)
+	if !ok {
+		writeNotFound(w, "not found")
+		return
+	}
+
+	if tokenID == "" {
+		switch r.Method {
+		case http.MethodPost:
+			h.handleIssue(w, r, userID)
+		case http.MethodGet:
+			h.handleList(w, r, userID)
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodDelete:
+		h.handleRevoke(w, r, userID, tokenID)
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+func (h *TokensHandler) handleIssue(w http.ResponseWriter, r *http.Request, userID string) {
+	var req createTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	issued, err := h.issueUC.Execute(r.Context(), usecase.IssueTokenInput{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		ActorID:     actorID(r),
+		WorkspaceID: workspace.FromContext(r.Context()),
+		Name:        req.Name,
+		Scopes:      req.Scopes,
+		ExpiresAt:   req.ExpiresAt,
+		Now:         h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := toTokenResponse(issued.Token)
+	resp.Token = issued.Plaintext
+	// 平文のトークンを含むため、途中のキャッシュに残さない
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *TokensHandler) handleList(w http.ResponseWriter, r *http.Request, userID string) {
+	tokens, err := h.listUC.Execute(r.Context(), userID, actorID(r))
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	resp := listTokensResponse{Tokens: make([]tokenResponse, 0, len(tokens))}
+	for _, t := range tokens {
+		resp.Tokens = append(resp.Tokens, toTokenResponse(t))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *TokensHandler) handleRevoke(w http.ResponseWriter, r *http.Request, userID, tokenID string) {
+	err := h.revokeUC.Execute(r.Context(), usecase.RevokeTokenInput{
+		UserID:  userID,
+		TokenID: tokenID,
+		ActorID: actorID(r),
+		Now:     h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// IntrospectHandler は POST /tokens:introspect（個人用アクセストークンの検証）を処理する HTTP ハンドラ。
+// tasks / projects サービスが Authorization: Bearer のトークンを検証するのに使う（サービス間専用）。
+type IntrospectHandler struct {
+	verifyUC *usecase.VerifyTokenUsecase
+}
+
+// NewIntrospectHandler は IntrospectHandler を生成する。
+func NewIntrospectHandler(verifyUC *usecase.VerifyTokenUsecase) http.Handler {
+	return &IntrospectHandler{verifyUC: verifyUC}
+}
+
+// introspectRequest は POST /tokens:introspect のリクエスト。
+type introspectRequest struct {
+	Token string `json:"token"`
+}
+
+// introspectResponse は POST /tokens:introspect のレスポンス。active が false の場合、ほかのフィールドは含めない。
+type introspectResponse struct {
+	Active      bool       `json:"active"`
+	UserID      string     `json:"userId,omitempty"`
+	WorkspaceID string     `json:"workspaceId,omitempty"`
+	Scopes      []string   `json:"scopes,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+}
+
+// ServeHTTP はトークンが有効かどうかと持ち主を返す。
+// 存在しない・失効した・期限切れのトークンは 404 にせず active: false で返す。
+func (h *IntrospectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req introspectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	var resp introspectResponse
+	t, err := h.verifyUC.Execute(r.Context(), req.Token)
+	switch {
+	case errors.Is(err, pat.ErrInvalidToken):
+	case err != nil:
+		writeUsecaseError(w, err)
+		return
+	default:
+		resp = introspectResponse{Active: true, UserID: t.UserID, WorkspaceID: t.WorkspaceID, Scopes: t.Scopes, ExpiresAt: t.ExpiresAt}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// actorID はリクエストの操作者（X-User-ID）を返す。
+func actorID(r *http.Request) string {
+	return strings.TrimSpace(r.Header.Get(authz.ActorHeader))
+}
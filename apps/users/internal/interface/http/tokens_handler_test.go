@@ -0,0 +1,179 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"teamflow-shared/authz"
+	"teamflow-shared/pat"
+	"teamflow-shared/workspace"
+
+	domain "teamflow-users/internal/domain/user"
+	infra "teamflow-users/internal/infrastructure/user"
+	httpiface "teamflow-users/internal/interface/http"
+	usecase "teamflow-users/internal/usecase/user"
+)
+
+// newTokenHandlers は user-1 を登録したリポジトリを共有する TokensHandler と IntrospectHandler を返す。
+func newTokenHandlers(t *testing.T) (tokens, introspect http.Handler) {
+	t.Helper()
+	users := infra.NewMemoryUserRepository()
+	u, err := domain.NewUser("user-1", "Taro", "taro@example.com", "", fixedClock.Now())
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	if err := users.Save(context.Background(), u); err != nil {
+		t.Fatalf("failed to save user: %v", err)
+	}
+
+	repo := infra.NewMemoryTokenRepository()
+	tokens = httpiface.NewTokensHandler(
+		&usecase.IssueTokenUsecase{Users: users, Tokens: repo},
+		&usecase.ListTokensUsecase{Tokens: repo},
+		&usecase.RevokeTokenUsecase{Tokens: repo},
+		fixedClock,
+	)
+	return tokens, httpiface.NewIntrospectHandler(&usecase.VerifyTokenUsecase{Tokens: repo, Clock: fixedClock})
+}
+
+// doActorRequest は X-User-ID（actor が空の場合は付けない）と X-Workspace-ID: acme を付けて、トークンを検証したリクエストとして送る。
+func doActorRequest(handler http.Handler, method, path, actor string, body any) *httptest.ResponseRecorder {
+	var b []byte
+	if body != nil {
+		b, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(b))
+	if actor != "" {
+		req.Header.Set(authz.ActorHeader, actor)
+	}
+	req.Header.Set(workspace.Header, "acme")
+	// 操作者・ワークスペースは jwt / pat の Middleware がトークンを検証して設定したものとして扱う
+	req = req.WithContext(authz.ContextWithVerifiedIdentity(req.Context()))
+	w := httptest.NewRecorder()
+	workspace.Middleware(true, nil, handler).ServeHTTP(w, req)
+	return w
+}
+
+type tokenBody struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Prefix    string   `json:"prefix"`
+	Scopes    []string `json:"scopes"`
+	RevokedAt *string  `json:"revokedAt"`
+	Token     string   `json:"token"`
+}
+
+type introspectBody struct {
+	Active      bool     `json:"active"`
+	UserID      string   `json:"userId"`
+	WorkspaceID string   `json:"workspaceId"`
+	Scopes      []string `json:"scopes"`
+}
+
+func introspect(t *testing.T, handler http.Handler, token string) introspectBody {
+	t.Helper()
+	w := doRequest(handler, http.MethodPost, "/tokens:introspect", map[string]string{"token": token})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var got introspectBody
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return got
+}
+
+func TestTokensHandler_Lifecycle(t *testing.T) {
+	handler, introspectHandler := newTokenHandlers(t)
+
+	w := doActorRequest(handler, http.MethodPost, "/users/user-1/tokens", "user-1", map[string]any{"name": "ci", "scopes": []string{"read"}})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Cache-Control"); got != "no-store" {
+		t.Errorf("Cache-Control = %q, want no-store", got)
+	}
+	var created tokenBody
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !pat.IsToken(created.Token) || created.Prefix != pat.DisplayPrefix(created.Token) || created.ID == "" {
+		t.Errorf("unexpected token: %+v", created)
+	}
+
+	// 一覧に平文は含めない
+	w = doActorRequest(handler, http.MethodGet, "/users/user-1/tokens", "user-1", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var list struct {
+		Tokens []tokenBody `json:"tokens"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(list.Tokens) != 1 || list.Tokens[0].Token != "" || list.Tokens[0].Prefix != created.Prefix {
+		t.Errorf("unexpected tokens: %+v", list.Tokens)
+	}
+
+	got := introspect(t, introspectHandler, created.Token)
+	if !got.Active || got.UserID != "user-1" || got.WorkspaceID != "acme" || len(got.Scopes) != 1 || got.Scopes[0] != "read" {
+		t.Errorf("unexpected introspection: %+v", got)
+	}
+
+	if w := doActorRequest(handler, http.MethodDelete, "/users/user-1/tokens/"+created.ID, "user-1", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := introspect(t, introspectHandler, created.Token); got.Active || got.UserID != "" {
+		t.Errorf("expected a revoked token to be inactive, got %+v", got)
+	}
+	if w := doActorRequest(handler, http.MethodDelete, "/users/user-1/tokens/missing", "user-1", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
+
+func TestTokensHandler_Errors(t *testing.T) {
+	handler, _ := newTokenHandlers(t)
+	valid := map[string]any{"name": "ci", "scopes": []string{"read"}}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		actor      string
+		body       any
+		wantStatus int
+	}{
+		{name: "no actor", method: http.MethodPost, path: "/users/user-1/tokens", body: valid, wantStatus: http.StatusUnauthorized},
+		{name: "another user", method: http.MethodGet, path: "/users/user-1/tokens", actor: "user-2", wantStatus: http.StatusForbidden},
+		{name: "unknown user", method: http.MethodPost, path: "/users/user-9/tokens", actor: "user-9", body: valid, wantStatus: http.StatusNotFound},
+		{name: "invalid scope", method: http.MethodPost, path: "/users/user-1/tokens", actor: "user-1", body: map[string]any{"name": "ci", "scopes": []string{"admin"}}, wantStatus: http.StatusBadRequest},
+		{name: "expired", method: http.MethodPost, path: "/users/user-1/tokens", actor: "user-1", body: map[string]any{"name": "ci", "scopes": []string{"read"}, "expiresAt": "2024-01-01T00:00:00Z"}, wantStatus: http.StatusBadRequest},
+		{name: "method not allowed", method: http.MethodPatch, path: "/users/user-1/tokens/t-1", actor: "user-1", wantStatus: http.StatusMethodNotAllowed},
+		{name: "too deep", method: http.MethodGet, path: "/users/user-1/tokens/t-1/x", actor: "user-1", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := doActorRequest(handler, tt.method, tt.path, tt.actor, tt.body); w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestIntrospectHandler_UnknownToken(t *testing.T) {
+	_, handler := newTokenHandlers(t)
+
+	for _, token := range []string{"", "not-a-token", pat.TokenPrefix + "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"} {
+		if got := introspect(t, handler, token); got.Active {
+			t.Errorf("expected %q to be inactive, got %+v", token, got)
+		}
+	}
+	if w := doRequest(handler, http.MethodGet, "/tokens:introspect", nil); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", w.Code)
+	}
+}
@@ -0,0 +1,180 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"teamflow-shared/clock"
+
+	domain "teamflow-users/internal/domain/user"
+	usecase "teamflow-users/internal/usecase/user"
+)
+
+// UsersHandler は /users と /users/{id} を処理する HTTP ハンドラ。
+type UsersHandler struct {
+	createUC *usecase.CreateUserUsecase
+	updateUC *usecase.UpdateUserUsecase
+	getUC    *usecase.GetUserUsecase
+	clock    clock.Clock
+}
+
+// NewUsersHandler は UsersHandler を生成する。
+func NewUsersHandler(
+	createUC *usecase.CreateUserUsecase,
+	updateUC *usecase.UpdateUserUsecase,
+	getUC *usecase.GetUserUsecase,
+	clk clock.Clock,
+) http.Handler {
+	return &UsersHandler{
+		createUC: createUC,
+		updateUC: updateUC,
+		getUC:    getUC,
+		clock:    clk,
+	}
+}
+
+// createUserRequest は POST のリクエスト。
+type createUserRequest struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatarUrl"`
+}
+
+// updateUserRequest は PATCH のリクエスト。省略したフィールドは変更しない。avatarUrl に空文字を指定するとアバターを外す。
+type updateUserRequest struct {
+	Name      *string `json:"name"`
+	Email     *string `json:"email"`
+	AvatarURL *string `json:"avatarUrl"`
+}
+
+type userResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	AvatarURL *string   `json:"avatarUrl"` // 未設定は null
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func toUserResponse(u *domain.User) userResponse {
+	resp := userResponse{
+		ID:        u.ID,
+		Name:      u.Name,
+		Email:     u.Email,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	}
+	if u.AvatarURL != "" {
+		avatarURL := u.AvatarURL
+		resp.AvatarURL = &avatarURL
+	}
+	return resp
+}
+
+// parseUsersPath は /users[/{id}] から userID を取り出す。/users の場合は空文字。
+func parseUsersPath(path string) (userID string, ok bool) {
+	if path == "/users" {
+		return "", true
+	}
+	id := strings.TrimPrefix(path, "/users/")
+	if id == path || id == "" || strings.Contains(id, "/") {
+		return "", false
+	}
+	return id, true
+}
+
+// ServeHTTP は以下を処理する。
+// - POST  /users      : ユーザー作成
+// - GET   /users/{id} : ユーザー取得
+// - PATCH /users/{id} : ユーザー更新（名前・メールアドレス・アバター）
+//
+// 複数のユーザーの取得は POST /users:lookup（LookupHandler）で行う。
+func (h *UsersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parseUsersPath(r.URL.Path)
+	if !ok {
+		writeNotFound(w, "not found")
+		return
+	}
+
+	if userID == "" {
+		switch r.Method {
+		case http.MethodPost:
+			h.handleCreate(w, r)
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGet(w, r, userID)
+	case http.MethodPatch:
+		h.handleUpdate(w, r, userID)
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+func (h *UsersHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req createUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	u, err := h.createUC.Execute(r.Context(), usecase.CreateUserInput{
+		ID:        req.ID,
+		Name:      req.Name,
+		Email:     req.Email,
+		AvatarURL: req.AvatarURL,
+		Now:       h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(toUserResponse(u))
+}
+
+func (h *UsersHandler) handleGet(w http.ResponseWriter, r *http.Request, userID string) {
+	u, err := h.getUC.Execute(r.Context(), userID)
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toUserResponse(u))
+}
+
+func (h *UsersHandler) handleUpdate(w http.ResponseWriter, r *http.Request, userID string) {
+	var req updateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInvalidJSON(w)
+		return
+	}
+
+	u, err := h.updateUC.Execute(r.Context(), usecase.UpdateUserInput{
+		ID:        userID,
+		Name:      req.Name,
+		Email:     req.Email,
+		AvatarURL: req.AvatarURL,
+		Now:       h.clock.Now(),
+	})
+	if err != nil {
+		writeUsecaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toUserResponse(u))
+}
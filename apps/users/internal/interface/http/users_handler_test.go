@@ -0,0 +1,163 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/clock"
+
+	infra "teamflow-users/internal/infrastructure/user"
+	httpiface "teamflow-users/internal/interface/http"
+	usecase "teamflow-users/internal/usecase/user"
+)
+
+var fixedClock = clock.Fixed(time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC))
+
+// newHandlers はインメモリリポジトリを共有する UsersHandler と LookupHandler を返す。
+func newHandlers(t *testing.T) (users, lookup http.Handler) {
+	t.Helper()
+	repo := infra.NewMemoryUserRepository()
+	users = httpiface.NewUsersHandler(
+		&usecase.CreateUserUsecase{Repo: repo},
+		&usecase.UpdateUserUsecase{Repo: repo},
+		&usecase.GetUserUsecase{Repo: repo},
+		fixedClock,
+	)
+	return users, httpiface.NewLookupHandler(&usecase.LookupUsersUsecase{Repo: repo})
+}
+
+func doRequest(handler http.Handler, method, path string, body any) *httptest.ResponseRecorder {
+	var b []byte
+	if body != nil {
+		b, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(b))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+type userBody struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Email     string  `json:"email"`
+	AvatarURL *string `json:"avatarUrl"`
+}
+
+func TestUsersHandler_Lifecycle(t *testing.T) {
+	handler, _ := newHandlers(t)
+
+	w := doRequest(handler, http.MethodPost, "/users", map[string]string{
+		"id": "user-1", "name": "Taro", "email": "taro@example.com", "avatarUrl": "https://example.com/a.png",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created userBody
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.ID != "user-1" || created.Name != "Taro" || created.AvatarURL == nil || *created.AvatarURL != "https://example.com/a.png" {
+		t.Errorf("unexpected user: %+v", created)
+	}
+
+	if w := doRequest(handler, http.MethodPost, "/users", map[string]string{"id": "user-2", "name": "Taro", "email": "TARO@example.com"}); w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for duplicate email, got %d", w.Code)
+	}
+
+	w = doRequest(handler, http.MethodPatch, "/users/user-1", map[string]string{"name": "Taro Yamada", "avatarUrl": ""})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(handler, http.MethodGet, "/users/user-1", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var got userBody
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.Name != "Taro Yamada" || got.Email != "taro@example.com" || got.AvatarURL != nil {
+		t.Errorf("unexpected user after update: %+v", got)
+	}
+
+	if w := doRequest(handler, http.MethodGet, "/users/missing", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+	if w := doRequest(handler, http.MethodDelete, "/users/user-1", nil); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", w.Code)
+	}
+}
+
+func TestUsersHandler_ValidationErrors(t *testing.T) {
+	handler, _ := newHandlers(t)
+
+	tests := []struct {
+		name      string
+		body      map[string]string
+		wantField string
+	}{
+		{name: "empty name", body: map[string]string{"id": "u1", "name": "", "email": "a@example.com"}, wantField: "name"},
+		{name: "invalid email", body: map[string]string{"id": "u1", "name": "Taro", "email": "taro"}, wantField: "email"},
+		{name: "invalid avatar", body: map[string]string{"id": "u1", "name": "Taro", "email": "a@example.com", "avatarUrl": "ftp://x"}, wantField: "avatarUrl"},
+		{name: "empty id", body: map[string]string{"id": "", "name": "Taro", "email": "a@example.com"}, wantField: "id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doRequest(handler, http.MethodPost, "/users", tt.body)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+			var resp apierror.ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Details == nil || len(resp.Details.Issues) != 1 || resp.Details.Issues[0].Field != tt.wantField {
+				t.Errorf("expected issue for %s, got %+v", tt.wantField, resp.Details)
+			}
+		})
+	}
+}
+
+func TestLookupHandler(t *testing.T) {
+	users, lookup := newHandlers(t)
+	for _, body := range []map[string]string{
+		{"id": "user-1", "name": "Taro", "email": "taro@example.com"},
+		{"id": "user-2", "name": "Jiro", "email": "jiro@example.com"},
+	} {
+		if w := doRequest(users, http.MethodPost, "/users", body); w.Code != http.StatusCreated {
+			t.Fatalf("failed to create user: %d %s", w.Code, w.Body.String())
+		}
+	}
+
+	w := doRequest(lookup, http.MethodPost, "/users:lookup", map[string][]string{"ids": {"user-2", "missing", "user-1"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Users      []userBody `json:"users"`
+		MissingIDs []string   `json:"missingIds"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Users) != 2 || resp.Users[0].Name != "Jiro" || resp.Users[1].Name != "Taro" {
+		t.Errorf("unexpected users: %+v", resp.Users)
+	}
+	if len(resp.MissingIDs) != 1 || resp.MissingIDs[0] != "missing" {
+		t.Errorf("unexpected missingIds: %v", resp.MissingIDs)
+	}
+
+	if w := doRequest(lookup, http.MethodPost, "/users:lookup", map[string][]string{"ids": {}}); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for empty ids, got %d", w.Code)
+	}
+	if w := doRequest(lookup, http.MethodGet, "/users:lookup", nil); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", w.Code)
+	}
+}
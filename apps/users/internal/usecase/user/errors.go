@@ -0,0 +1,23 @@
+package user
+
+import (
+	"errors"
+
+	"teamflow-shared/authz"
+)
+
+// Sentinel errors used by user usecases.
+// リポジトリ実装はこれらを返す（infrastructure 層では同じ値を別名で公開している）。
+var (
+	ErrUserNotFound       = errors.New("user not found")
+	ErrUserAlreadyExists  = errors.New("user already exists")
+	ErrEmailAlreadyExists = errors.New("email already exists")
+	ErrTokenNotFound      = errors.New("access token not found")
+)
+
+var (
+	// ErrActorRequired はアクセストークンの操作で操作者が特定できない場合に返す（HTTP 層で 401）。
+	ErrActorRequired = authz.ErrActorRequired
+	// ErrForbidden は操作者が本人以外のアクセストークンを操作しようとした場合に返す（HTTP 層で 403）。
+	ErrForbidden = authz.ErrForbidden
+)
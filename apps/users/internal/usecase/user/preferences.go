@@ -0,0 +1,89 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	domain "teamflow-users/internal/domain/user"
+)
+
+// PreferencesRepository は通知の設定の永続化・取得を担当する抽象。
+type PreferencesRepository interface {
+	// Save は通知の設定を保存する（既にある場合は置き換える）。
+	Save(ctx context.Context, p *domain.NotificationPreferences) error
+	// FindByUserIDs は userIDs のうち設定を保存しているユーザーの設定を返す（順序は問わない。保存していないユーザーは含めない）。
+	FindByUserIDs(ctx context.Context, userIDs []string) ([]*domain.NotificationPreferences, error)
+}
+
+// preferencesOf は userIDs の通知の設定を返す。設定を保存していないユーザーは既定の設定にする。
+func preferencesOf(ctx context.Context, repo PreferencesRepository, userIDs []string) (map[string]*domain.NotificationPreferences, error) {
+	found, err := repo.FindByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	prefs := make(map[string]*domain.NotificationPreferences, len(userIDs))
+	for _, id := range userIDs {
+		prefs[id] = domain.DefaultNotificationPreferences(id)
+	}
+	for _, p := range found {
+		prefs[p.UserID] = p
+	}
+	return prefs, nil
+}
+
+// GetPreferencesUsecase は通知の設定の取得ユースケース。
+type GetPreferencesUsecase struct {
+	Users       UserRepository
+	Preferences PreferencesRepository
+}
+
+// Execute は本人（actorID == userID）の通知の設定を返す。保存していない場合は既定の設定を返す。
+// 操作者が無い場合は ErrActorRequired、本人以外は ErrForbidden、ユーザーが存在しない場合は ErrUserNotFound を返す。
+func (uc *GetPreferencesUsecase) Execute(ctx context.Context, userID, actorID string) (*domain.NotificationPreferences, error) {
+	if err := authorizeOwner(actorID, userID); err != nil {
+		return nil, err
+	}
+	if _, err := uc.Users.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	prefs, err := preferencesOf(ctx, uc.Preferences, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	return prefs[userID], nil
+}
+
+// UpdatePreferencesInput は通知の設定の更新ユースケースの入力。nil のフィールドは変更しない（PATCH）。
+type UpdatePreferencesInput struct {
+	UserID            string
+	ActorID           string
+	EmailOnAssignment *bool
+	EmailOnDueSoon    *bool
+	Now               time.Time
+}
+
+// UpdatePreferencesUsecase は通知の設定の更新ユースケース。
+type UpdatePreferencesUsecase struct {
+	Users       UserRepository
+	Preferences PreferencesRepository
+}
+
+// Execute は本人の通知の設定を更新して保存する。エラーは GetPreferencesUsecase と同じ。
+func (uc *UpdatePreferencesUsecase) Execute(ctx context.Context, in UpdatePreferencesInput) (*domain.NotificationPreferences, error) {
+	if err := authorizeOwner(in.ActorID, in.UserID); err != nil {
+		return nil, err
+	}
+	if _, err := uc.Users.FindByID(ctx, in.UserID); err != nil {
+		return nil, err
+	}
+	prefs, err := preferencesOf(ctx, uc.Preferences, []string{in.UserID})
+	if err != nil {
+		return nil, err
+	}
+	p := prefs[in.UserID]
+	p.Update(in.EmailOnAssignment, in.EmailOnDueSoon, in.Now)
+	if err := uc.Preferences.Save(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
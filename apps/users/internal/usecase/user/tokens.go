@@ -0,0 +1,185 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/pat"
+
+	domain "teamflow-users/internal/domain/user"
+)
+
+// lastUsedInterval は LastUsedAt を更新する最小の間隔。リクエストのたびに書き込まないよう間引く。
+const lastUsedInterval = time.Minute
+
+// TokenRepository は個人用アクセストークンの永続化・取得を担当する抽象。
+type TokenRepository interface {
+	// Save はトークンを保存する。
+	Save(ctx context.Context, t *domain.AccessToken) error
+	// Update はトークンの LastUsedAt / RevokedAt を更新する。存在しない場合は ErrTokenNotFound を返す。
+	Update(ctx context.Context, t *domain.AccessToken) error
+	// FindByID はトークンを 1 件取得する。存在しない場合は ErrTokenNotFound を返す。
+	FindByID(ctx context.Context, id string) (*domain.AccessToken, error)
+	// FindByHash はハッシュ（pat.Hash）が一致するトークンを取得する。存在しない場合は ErrTokenNotFound を返す。
+	FindByHash(ctx context.Context, hash string) (*domain.AccessToken, error)
+	// ListByUser は userID のトークン（失効したものを含む）を発行の新しい順に返す。
+	ListByUser(ctx context.Context, userID string) ([]*domain.AccessToken, error)
+}
+
+// authorizeOwner はアクセストークンを操作できるのが本人だけであることを確認する。
+func authorizeOwner(actorID, userID string) error {
+	if actorID == "" {
+		return ErrActorRequired
+	}
+	if actorID != userID {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// IssueTokenInput はアクセストークン発行ユースケースの入力。
+type IssueTokenInput struct {
+	ID          string // トークンの ID（HTTP 層で生成する）
+	UserID      string
+	ActorID     string
+	WorkspaceID string
+	Name        string
+	Scopes      []string
+	ExpiresAt   *time.Time // nil の場合は無期限
+	Now         time.Time
+}
+
+// IssuedToken は発行したトークンと平文。平文はこのレスポンスでしか返さない。
+type IssuedToken struct {
+	Token     *domain.AccessToken
+	Plaintext string
+}
+
+// IssueTokenUsecase はアクセストークン発行ユースケース。
+type IssueTokenUsecase struct {
+	Users  UserRepository
+	Tokens TokenRepository
+}
+
+// Execute は本人（ActorID == UserID）のアクセストークンを発行する。
+// 操作者が無い場合は ErrActorRequired、本人以外は ErrForbidden、ユーザーが存在しない場合は ErrUserNotFound、
+// 不正な値の場合は domain のバリデーションエラーを返す。
+func (uc *IssueTokenUsecase) Execute(ctx context.Context, in IssueTokenInput) (*IssuedToken, error) {
+	if err := authorizeOwner(in.ActorID, in.UserID); err != nil {
+		return nil, err
+	}
+	if _, err := uc.Users.FindByID(ctx, in.UserID); err != nil {
+		return nil, err
+	}
+
+	plaintext, err := pat.Generate()
+	if err != nil {
+		return nil, err
+	}
+	t, err := domain.NewAccessToken(in.ID, in.UserID, in.WorkspaceID, in.Name, plaintext, in.Scopes, in.ExpiresAt, in.Now)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.Tokens.Save(ctx, t); err != nil {
+		return nil, err
+	}
+	return &IssuedToken{Token: t, Plaintext: plaintext}, nil
+}
+
+// ListTokensUsecase はアクセストークン一覧ユースケース。
+type ListTokensUsecase struct {
+	Tokens TokenRepository
+}
+
+// Execute は本人のアクセストークン（失効したものを含む）を発行の新しい順に返す。
+func (uc *ListTokensUsecase) Execute(ctx context.Context, userID, actorID string) ([]*domain.AccessToken, error) {
+	if err := authorizeOwner(actorID, userID); err != nil {
+		return nil, err
+	}
+	return uc.Tokens.ListByUser(ctx, userID)
+}
+
+// RevokeTokenInput はアクセストークン失効ユースケースの入力。
+type RevokeTokenInput struct {
+	UserID  string
+	TokenID string
+	ActorID string
+	Now     time.Time
+}
+
+// RevokeTokenUsecase はアクセストークン失効ユースケース。
+type RevokeTokenUsecase struct {
+	Tokens TokenRepository
+}
+
+// Execute は本人のアクセストークンを失効させる。失効済みの場合も成功する。
+// トークンが存在しない・UserID のものでない場合は ErrTokenNotFound を返す。
+func (uc *RevokeTokenUsecase) Execute(ctx context.Context, in RevokeTokenInput) error {
+	if err := authorizeOwner(in.ActorID, in.UserID); err != nil {
+		return err
+	}
+	t, err := uc.Tokens.FindByID(ctx, in.TokenID)
+	if err != nil {
+		return err
+	}
+	if t.UserID != in.UserID {
+		return ErrTokenNotFound
+	}
+	if t.RevokedAt != nil {
+		return nil
+	}
+	t.Revoke(in.Now)
+	return uc.Tokens.Update(ctx, t)
+}
+
+// VerifyTokenUsecase はアクセストークンを検証する（pat.Verifier）。
+// users サービス自身の認証と、他のサービスからの問い合わせ（POST /tokens:introspect）で使う。
+type VerifyTokenUsecase struct {
+	Tokens TokenRepository
+	// Clock は有効期限の判定と LastUsedAt に使う。任意。nil の場合は clock.System を使う。
+	Clock clock.Clock
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ pat.Verifier = (*VerifyTokenUsecase)(nil)
+
+// Execute は有効な token を返す。存在しない・失効した・期限切れの場合は pat.ErrInvalidToken を返す。
+// LastUsedAt は lastUsedInterval ごとに更新する（更新の失敗は検証の結果に影響しない）。
+func (uc *VerifyTokenUsecase) Execute(ctx context.Context, token string) (*domain.AccessToken, error) {
+	if !pat.IsToken(token) {
+		return nil, pat.ErrInvalidToken
+	}
+	t, err := uc.Tokens.FindByHash(ctx, pat.Hash(token))
+	if errors.Is(err, ErrTokenNotFound) {
+		return nil, pat.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := uc.now()
+	if !t.Active(now) {
+		return nil, pat.ErrInvalidToken
+	}
+
+	if t.LastUsedAt == nil || now.Sub(*t.LastUsedAt) >= lastUsedInterval {
+		t.LastUsedAt = &now
+		_ = uc.Tokens.Update(ctx, t)
+	}
+	return t, nil
+}
+
+// Verify は token の持ち主を返す（pat.Verifier）。エラーは Execute と同じ。
+func (uc *VerifyTokenUsecase) Verify(ctx context.Context, token string) (pat.Identity, error) {
+	t, err := uc.Execute(ctx, token)
+	if err != nil {
+		return pat.Identity{}, err
+	}
+	return pat.Identity{UserID: t.UserID, WorkspaceID: t.WorkspaceID, Scopes: slices.Clone(t.Scopes)}, nil
+}
+
+func (uc *VerifyTokenUsecase) now() time.Time {
+	return clock.OrSystem(uc.Clock).Now()
+}
@@ -0,0 +1,199 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/pat"
+
+	domain "teamflow-users/internal/domain/user"
+	usecase "teamflow-users/internal/usecase/user"
+)
+
+// fakeTokenRepo は TokenRepository のテスト用フェイク実装（登録順に返す）。
+type fakeTokenRepo struct {
+	tokens  []*domain.AccessToken
+	updates int
+}
+
+func (r *fakeTokenRepo) Save(_ context.Context, t *domain.AccessToken) error {
+	r.tokens = append(r.tokens, t)
+	return nil
+}
+
+func (r *fakeTokenRepo) Update(_ context.Context, t *domain.AccessToken) error {
+	for i, existing := range r.tokens {
+		if existing.ID == t.ID {
+			r.tokens[i] = t
+			r.updates++
+			return nil
+		}
+	}
+	return usecase.ErrTokenNotFound
+}
+
+func (r *fakeTokenRepo) find(match func(*domain.AccessToken) bool) (*domain.AccessToken, error) {
+	for _, t := range r.tokens {
+		if match(t) {
+			c := *t
+			return &c, nil
+		}
+	}
+	return nil, usecase.ErrTokenNotFound
+}
+
+func (r *fakeTokenRepo) FindByID(_ context.Context, id string) (*domain.AccessToken, error) {
+	return r.find(func(t *domain.AccessToken) bool { return t.ID == id })
+}
+
+func (r *fakeTokenRepo) FindByHash(_ context.Context, hash string) (*domain.AccessToken, error) {
+	return r.find(func(t *domain.AccessToken) bool { return t.Hash == hash })
+}
+
+func (r *fakeTokenRepo) ListByUser(_ context.Context, userID string) ([]*domain.AccessToken, error) {
+	var out []*domain.AccessToken
+	for _, t := range r.tokens {
+		if t.UserID == userID {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func TestIssueToken(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	users := &fakeUserRepo{users: []*domain.User{{ID: "u1", Name: "Taro", Email: "taro@example.com"}}}
+	tokens := &fakeTokenRepo{}
+	uc := &usecase.IssueTokenUsecase{Users: users, Tokens: tokens}
+	ctx := context.Background()
+
+	in := usecase.IssueTokenInput{ID: "t1", UserID: "u1", ActorID: "u1", WorkspaceID: "acme", Name: "ci", Scopes: []string{"read"}, Now: now}
+	issued, err := uc.Execute(ctx, in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !pat.IsToken(issued.Plaintext) || issued.Token.Hash != pat.Hash(issued.Plaintext) || issued.Token.WorkspaceID != "acme" {
+		t.Errorf("unexpected issued token: %+v", issued)
+	}
+	if len(tokens.tokens) != 1 {
+		t.Fatalf("expected the token to be saved, got %d", len(tokens.tokens))
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*usecase.IssueTokenInput)
+		wantErr error
+	}{
+		{name: "no actor", modify: func(in *usecase.IssueTokenInput) { in.ActorID = "" }, wantErr: usecase.ErrActorRequired},
+		{name: "another user", modify: func(in *usecase.IssueTokenInput) { in.ActorID = "u2" }, wantErr: usecase.ErrForbidden},
+		{name: "unknown user", modify: func(in *usecase.IssueTokenInput) { in.UserID, in.ActorID = "u9", "u9" }, wantErr: usecase.ErrUserNotFound},
+		{name: "invalid scope", modify: func(in *usecase.IssueTokenInput) { in.Scopes = []string{"admin"} }, wantErr: domain.ErrInvalidScopes},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := in
+			tt.modify(&in)
+			if _, err := uc.Execute(ctx, in); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+	if len(tokens.tokens) != 1 {
+		t.Errorf("expected no more tokens to be saved, got %d", len(tokens.tokens))
+	}
+}
+
+func TestListTokens(t *testing.T) {
+	tokens := &fakeTokenRepo{tokens: []*domain.AccessToken{{ID: "t1", UserID: "u1"}, {ID: "t2", UserID: "u2"}}}
+	uc := &usecase.ListTokensUsecase{Tokens: tokens}
+	ctx := context.Background()
+
+	got, err := uc.Execute(ctx, "u1", "u1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "t1" {
+		t.Errorf("unexpected tokens: %+v", got)
+	}
+	if _, err := uc.Execute(ctx, "u1", "u2"); !errors.Is(err, usecase.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestRevokeToken(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	tokens := &fakeTokenRepo{tokens: []*domain.AccessToken{{ID: "t1", UserID: "u1"}, {ID: "t2", UserID: "u2"}}}
+	uc := &usecase.RevokeTokenUsecase{Tokens: tokens}
+	ctx := context.Background()
+
+	if err := uc.Execute(ctx, usecase.RevokeTokenInput{UserID: "u1", TokenID: "t1", ActorID: "u1", Now: now}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tokens.tokens[0].RevokedAt == nil || !tokens.tokens[0].RevokedAt.Equal(now) {
+		t.Errorf("expected the token to be revoked, got %+v", tokens.tokens[0])
+	}
+	// 失効済みのトークンは変更しない
+	if err := uc.Execute(ctx, usecase.RevokeTokenInput{UserID: "u1", TokenID: "t1", ActorID: "u1", Now: now.Add(time.Hour)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tokens.updates != 1 {
+		t.Errorf("expected one update, got %d", tokens.updates)
+	}
+
+	// 他のユーザーのトークンは存在しないものとして扱う
+	if err := uc.Execute(ctx, usecase.RevokeTokenInput{UserID: "u1", TokenID: "t2", ActorID: "u1", Now: now}); !errors.Is(err, usecase.ErrTokenNotFound) {
+		t.Errorf("expected ErrTokenNotFound, got %v", err)
+	}
+	if err := uc.Execute(ctx, usecase.RevokeTokenInput{UserID: "u2", TokenID: "t2", ActorID: "u1", Now: now}); !errors.Is(err, usecase.ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestVerifyToken(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(now)
+	active := pat.TokenPrefix + "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	expired := pat.TokenPrefix + "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	revoked := pat.TokenPrefix + "cccccccccccccccccccccccccccccccccccccccc"
+	past := now.Add(-time.Minute)
+	tokens := &fakeTokenRepo{tokens: []*domain.AccessToken{
+		{ID: "t1", UserID: "u1", WorkspaceID: "acme", Hash: pat.Hash(active), Scopes: []string{"read"}},
+		{ID: "t2", UserID: "u1", Hash: pat.Hash(expired), Scopes: []string{"read"}, ExpiresAt: &past},
+		{ID: "t3", UserID: "u1", Hash: pat.Hash(revoked), Scopes: []string{"read"}, RevokedAt: &past},
+	}}
+	uc := &usecase.VerifyTokenUsecase{Tokens: tokens, Clock: clk}
+	ctx := context.Background()
+
+	id, err := uc.Verify(ctx, active)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id.UserID != "u1" || id.WorkspaceID != "acme" || len(id.Scopes) != 1 {
+		t.Errorf("unexpected identity: %+v", id)
+	}
+	if got := tokens.tokens[0].LastUsedAt; got == nil || !got.Equal(now) {
+		t.Errorf("LastUsedAt = %v, want %v", got, now)
+	}
+
+	// 最終使用日時の更新は間引く
+	clk.Advance(30 * time.Second)
+	if _, err := uc.Verify(ctx, active); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clk.Advance(30 * time.Second)
+	if _, err := uc.Verify(ctx, active); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tokens.updates != 2 {
+		t.Errorf("expected 2 updates of LastUsedAt, got %d", tokens.updates)
+	}
+
+	for _, token := range []string{expired, revoked, pat.TokenPrefix + "dddddddddddddddddddddddddddddddddddddddd", "not-a-token"} {
+		if _, err := uc.Verify(ctx, token); !errors.Is(err, pat.ErrInvalidToken) {
+			t.Errorf("Verify(%q): expected ErrInvalidToken, got %v", token, err)
+		}
+	}
+}
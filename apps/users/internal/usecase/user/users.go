@@ -0,0 +1,161 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	domain "teamflow-users/internal/domain/user"
+)
+
+// UserRepository はユーザーの永続化・取得を担当する抽象。
+type UserRepository interface {
+	// Save はユーザーを保存する。同じ ID が既にある場合は ErrUserAlreadyExists、
+	// 同じメールアドレス（大文字・小文字は区別しない）が既にある場合は ErrEmailAlreadyExists 相当のエラーを返す。
+	Save(ctx context.Context, u *domain.User) error
+	// Update はユーザーを更新する。存在しない場合は ErrUserNotFound、
+	// メールアドレスが他のユーザーと重複する場合は ErrEmailAlreadyExists 相当のエラーを返す。
+	Update(ctx context.Context, u *domain.User) error
+	// FindByID はユーザーを 1 件取得する。存在しない場合は ErrUserNotFound 相当のエラーを返す。
+	FindByID(ctx context.Context, id string) (*domain.User, error)
+	// FindByIDs は ids のうち存在するユーザーを返す（順序は問わない。存在しない ID は含めない）。
+	FindByIDs(ctx context.Context, ids []string) ([]*domain.User, error)
+}
+
+// CreateUserInput はユーザー作成ユースケースの入力。
+type CreateUserInput struct {
+	ID        string
+	Name      string
+	Email     string
+	AvatarURL string // 空の場合は未設定
+	Now       time.Time
+}
+
+// CreateUserUsecase はユーザー作成ユースケース。
+type CreateUserUsecase struct {
+	Repo UserRepository
+}
+
+// Execute は値を検証してからユーザーを保存する。
+// 不正な値の場合は domain のバリデーションエラー、ID・メールアドレスの重複は
+// ErrUserAlreadyExists / ErrEmailAlreadyExists を返す。
+func (uc *CreateUserUsecase) Execute(ctx context.Context, in CreateUserInput) (*domain.User, error) {
+	u, err := domain.NewUser(in.ID, in.Name, in.Email, in.AvatarURL, in.Now)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.Repo.Save(ctx, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// UpdateUserInput はユーザー更新ユースケースの入力。
+// nil のフィールドは変更しない（PATCH）。AvatarURL に空文字を指定するとアバターを外す。
+type UpdateUserInput struct {
+	ID        string
+	Name      *string
+	Email     *string
+	AvatarURL *string
+	Now       time.Time
+}
+
+// UpdateUserUsecase はユーザー更新ユースケース。
+// ID は変わらないため、タスクの担当者・プロジェクトのメンバーはそのまま新しい名前で表示される。
+type UpdateUserUsecase struct {
+	Repo UserRepository
+}
+
+// Execute は既存のユーザーを取得し、指定されたフィールドを更新して保存する。
+func (uc *UpdateUserUsecase) Execute(ctx context.Context, in UpdateUserInput) (*domain.User, error) {
+	u, err := uc.Repo.FindByID(ctx, in.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, email, avatarURL := u.Name, u.Email, u.AvatarURL
+	if in.Name != nil {
+		name = *in.Name
+	}
+	if in.Email != nil {
+		email = *in.Email
+	}
+	if in.AvatarURL != nil {
+		avatarURL = *in.AvatarURL
+	}
+	if err := u.Update(name, email, avatarURL, in.Now); err != nil {
+		return nil, err
+	}
+	if err := uc.Repo.Update(ctx, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// GetUserUsecase はユーザー取得ユースケース。
+type GetUserUsecase struct {
+	Repo UserRepository
+}
+
+// Execute はユーザーを返す。存在しない場合は ErrUserNotFound を返す。
+func (uc *GetUserUsecase) Execute(ctx context.Context, id string) (*domain.User, error) {
+	return uc.Repo.FindByID(ctx, id)
+}
+
+// LookupResult は一括取得の結果。
+type LookupResult struct {
+	// Users は見つかったユーザー（指定された ids の順）
+	Users []*domain.User
+	// MissingIDs は存在しなかった ID（指定された ids の順）
+	MissingIDs []string
+}
+
+// LookupUsersUsecase はユーザーの一括取得ユースケース。
+// tasks サービスが担当者の存在確認と、一覧に表示する名前の取得、通知の宛先の取得に使う。
+type LookupUsersUsecase struct {
+	Repo UserRepository
+	// Preferences は通知の設定の取得に使う。任意。nil の場合はすべてのユーザーを既定の設定として扱う
+	Preferences PreferencesRepository
+}
+
+// Execute は ids のユーザーをまとめて取得する。存在しない ID はエラーにせず MissingIDs に含める。
+// ids が空・多すぎる場合は domain.ErrInvalidLookup を返す。
+func (uc *LookupUsersUsecase) Execute(ctx context.Context, ids []string) (*LookupResult, error) {
+	ids, err := domain.NormalizeLookupIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	found, err := uc.Repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*domain.User, len(found))
+	for _, u := range found {
+		byID[u.ID] = u
+	}
+	res := &LookupResult{Users: make([]*domain.User, 0, len(found)), MissingIDs: []string{}}
+	for _, id := range ids {
+		if u, ok := byID[id]; ok {
+			res.Users = append(res.Users, u)
+		} else {
+			res.MissingIDs = append(res.MissingIDs, id)
+		}
+	}
+	return res, nil
+}
+
+// PreferencesOf は users（Execute の結果）の通知の設定をユーザーの ID ごとに返す。保存していないユーザーは既定の設定にする。
+func (uc *LookupUsersUsecase) PreferencesOf(ctx context.Context, users []*domain.User) (map[string]*domain.NotificationPreferences, error) {
+	ids := make([]string, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+	if uc.Preferences == nil || len(ids) == 0 {
+		prefs := make(map[string]*domain.NotificationPreferences, len(ids))
+		for _, id := range ids {
+			prefs[id] = domain.DefaultNotificationPreferences(id)
+		}
+		return prefs, nil
+	}
+	return preferencesOf(ctx, uc.Preferences, ids)
+}
@@ -0,0 +1,158 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domain "teamflow-users/internal/domain/user"
+	usecase "teamflow-users/internal/usecase/user"
+)
+
+// fakeUserRepo は UserRepository のテスト用フェイク実装（登録順に返す）。
+type fakeUserRepo struct {
+	users []*domain.User
+}
+
+func (r *fakeUserRepo) index(id string) int {
+	for i, u := range r.users {
+		if u.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// emailTaken は u 以外で同じメールアドレスのユーザーがあるかどうかを返す。
+func (r *fakeUserRepo) emailTaken(u *domain.User) bool {
+	for _, other := range r.users {
+		if other.ID != u.ID && strings.EqualFold(other.Email, u.Email) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *fakeUserRepo) Save(_ context.Context, u *domain.User) error {
+	if r.index(u.ID) >= 0 {
+		return usecase.ErrUserAlreadyExists
+	}
+	if r.emailTaken(u) {
+		return usecase.ErrEmailAlreadyExists
+	}
+	r.users = append(r.users, u)
+	return nil
+}
+
+func (r *fakeUserRepo) Update(_ context.Context, u *domain.User) error {
+	i := r.index(u.ID)
+	if i < 0 {
+		return usecase.ErrUserNotFound
+	}
+	if r.emailTaken(u) {
+		return usecase.ErrEmailAlreadyExists
+	}
+	r.users[i] = u
+	return nil
+}
+
+func (r *fakeUserRepo) FindByID(_ context.Context, id string) (*domain.User, error) {
+	i := r.index(id)
+	if i < 0 {
+		return nil, usecase.ErrUserNotFound
+	}
+	c := *r.users[i]
+	return &c, nil
+}
+
+func (r *fakeUserRepo) FindByIDs(_ context.Context, ids []string) ([]*domain.User, error) {
+	var out []*domain.User
+	for _, u := range r.users {
+		for _, id := range ids {
+			if u.ID == id {
+				out = append(out, u)
+			}
+		}
+	}
+	return out, nil
+}
+
+func TestCreateUser(t *testing.T) {
+	repo := &fakeUserRepo{}
+	uc := &usecase.CreateUserUsecase{Repo: repo}
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	u, err := uc.Execute(ctx, usecase.CreateUserInput{ID: "u1", Name: "Taro", Email: "taro@example.com", Now: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u.ID != "u1" || u.Name != "Taro" || len(repo.users) != 1 {
+		t.Errorf("unexpected user: %+v", u)
+	}
+
+	if _, err := uc.Execute(ctx, usecase.CreateUserInput{ID: "u2", Name: "Taro", Email: "TARO@example.com", Now: now}); !errors.Is(err, usecase.ErrEmailAlreadyExists) {
+		t.Errorf("expected ErrEmailAlreadyExists, got %v", err)
+	}
+	if _, err := uc.Execute(ctx, usecase.CreateUserInput{ID: "u1", Name: "Jiro", Email: "jiro@example.com", Now: now}); !errors.Is(err, usecase.ErrUserAlreadyExists) {
+		t.Errorf("expected ErrUserAlreadyExists, got %v", err)
+	}
+	if _, err := uc.Execute(ctx, usecase.CreateUserInput{ID: "u3", Name: "", Email: "x@example.com", Now: now}); !errors.Is(err, domain.ErrNameRequired) {
+		t.Errorf("expected ErrNameRequired, got %v", err)
+	}
+}
+
+func TestUpdateUser(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	repo := &fakeUserRepo{users: []*domain.User{
+		{ID: "u1", Name: "Taro", Email: "taro@example.com", AvatarURL: "https://example.com/a.png", CreatedAt: now, UpdatedAt: now},
+		{ID: "u2", Name: "Jiro", Email: "jiro@example.com", CreatedAt: now, UpdatedAt: now},
+	}}
+	uc := &usecase.UpdateUserUsecase{Repo: repo}
+	ctx := context.Background()
+	later := now.Add(time.Hour)
+
+	name, empty := "Taro Yamada", ""
+	u, err := uc.Execute(ctx, usecase.UpdateUserInput{ID: "u1", Name: &name, AvatarURL: &empty, Now: later})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u.Name != "Taro Yamada" || u.Email != "taro@example.com" || u.AvatarURL != "" || !u.UpdatedAt.Equal(later) {
+		t.Errorf("unexpected user: %+v", u)
+	}
+
+	email := "JIRO@example.com"
+	if _, err := uc.Execute(ctx, usecase.UpdateUserInput{ID: "u1", Email: &email, Now: later}); !errors.Is(err, usecase.ErrEmailAlreadyExists) {
+		t.Errorf("expected ErrEmailAlreadyExists, got %v", err)
+	}
+	if _, err := uc.Execute(ctx, usecase.UpdateUserInput{ID: "missing", Name: &name, Now: later}); !errors.Is(err, usecase.ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestLookupUsers(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	repo := &fakeUserRepo{users: []*domain.User{
+		{ID: "u1", Name: "Taro", Email: "taro@example.com", CreatedAt: now, UpdatedAt: now},
+		{ID: "u2", Name: "Jiro", Email: "jiro@example.com", CreatedAt: now, UpdatedAt: now},
+	}}
+	uc := &usecase.LookupUsersUsecase{Repo: repo}
+	ctx := context.Background()
+
+	res, err := uc.Execute(ctx, []string{"u2", "missing", "u1", "u2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.Users) != 2 || res.Users[0].ID != "u2" || res.Users[1].ID != "u1" {
+		t.Errorf("expected users in the requested order, got %+v", res.Users)
+	}
+	if len(res.MissingIDs) != 1 || res.MissingIDs[0] != "missing" {
+		t.Errorf("unexpected missing ids: %v", res.MissingIDs)
+	}
+
+	if _, err := uc.Execute(ctx, nil); !errors.Is(err, domain.ErrInvalidLookup) {
+		t.Errorf("expected ErrInvalidLookup, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+// Package apierror は tasks / projects サービスで共通のエラーレスポンス（OpenAPI の ErrorResponse / ValidationIssue）を提供する。
+//
+// クライアント（apps/frontend の apiFetch）はどちらのサービスのエラーも同じ形式で扱う。
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"teamflow-shared/requestid"
+)
+
+// エラー種別コード（ErrorResponse.error）。
+const (
+	CodeValidation         = "VALIDATION_ERROR"
+	CodeUnauthorized       = "UNAUTHORIZED"
+	CodeForbidden          = "FORBIDDEN"
+	CodeNotFound           = "NOT_FOUND"
+	CodeMethodNotAllowed   = "METHOD_NOT_ALLOWED"
+	CodeConflict           = "CONFLICT"
+	CodeTooManyRequests    = "TOO_MANY_REQUESTS"
+	CodeInternal           = "INTERNAL_ERROR"
+	CodeBadGateway         = "BAD_GATEWAY"
+	CodeServiceUnavailable = "SERVICE_UNAVAILABLE"
+	CodeTimeout            = "TIMEOUT"
+)
+
+// ValidationIssue の location。
+const (
+	LocationQuery = "query"
+	LocationPath  = "path"
+	LocationBody  = "body"
+)
+
+// ValidationIssue: OpenAPIの schema（ValidationIssue）と対応する構造体
+type ValidationIssue struct {
+	Location      string  `json:"location"`                // "query" | "path" | "body"
+	Field         string  `json:"field"`                   // 例: status, priority, sort, dueDateFrom
+	Code          string  `json:"code"`                    // 例: INVALID_ENUM
+	Message       string  `json:"message"`                 // フロントが直すべき内容がわかる文言
+	RejectedValue *string `json:"rejectedValue,omitempty"` // 出せる場合のみ
+}
+
+// ErrorResponse: OpenAPIの schema（ErrorResponse）と対応する構造体
+type ErrorResponse struct {
+	Error   string        `json:"error"`
+	Message string        `json:"message"`
+	Details *ErrorDetails `json:"details,omitempty"`
+	// RequestID はリクエストの X-Request-ID（Write がレスポンスヘッダから設定する）
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// setRequestID は RequestID が未設定の場合に id を設定する。ErrorResponse を埋め込んだ構造体にも昇格する。
+func (e *ErrorResponse) setRequestID(id string) {
+	if e.RequestID == "" {
+		e.RequestID = id
+	}
+}
+
+// ErrorDetails は ErrorResponse.details。
+type ErrorDetails struct {
+	Issues []ValidationIssue `json:"issues,omitempty"`
+}
+
+// New は ErrorResponse を生成する。issues が無い場合は details を省略する。
+func New(code, message string, issues ...ValidationIssue) ErrorResponse {
+	resp := ErrorResponse{
+		Error:   code,
+		Message: message,
+	}
+	if len(issues) > 0 {
+		resp.Details = &ErrorDetails{Issues: issues}
+	}
+	return resp
+}
+
+// Write は body を JSON で書き込む。body は ErrorResponse か、ErrorResponse を埋め込んだ構造体のポインタを渡す。
+// レスポンスヘッダに X-Request-ID（requestid.Middleware が設定する）がある場合は requestId に設定する。
+func Write(w http.ResponseWriter, status int, body any) {
+	if id := w.Header().Get(requestid.Header); id != "" {
+		switch b := body.(type) {
+		case ErrorResponse:
+			b.setRequestID(id)
+			body = b
+		case interface{ setRequestID(string) }:
+			b.setRequestID(id)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
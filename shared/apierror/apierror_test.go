@@ -0,0 +1,91 @@
+package apierror_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/requestid"
+)
+
+func TestWrite(t *testing.T) {
+	rejected := "urgent"
+	tests := []struct {
+		name        string
+		resp        apierror.ErrorResponse
+		wantDetails bool
+	}{
+		{name: "without issues", resp: apierror.New(apierror.CodeNotFound, "project not found")},
+		{
+			name: "with issues",
+			resp: apierror.New(apierror.CodeValidation, "Invalid request body", apierror.ValidationIssue{
+				Location: apierror.LocationBody, Field: "status", Code: "INVALID_ENUM", Message: "status is invalid", RejectedValue: &rejected,
+			}),
+			wantDetails: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			apierror.Write(w, http.StatusBadRequest, tt.resp)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("expected application/json, got %q", ct)
+			}
+			var got map[string]any
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got["error"] != tt.resp.Error || got["message"] != tt.resp.Message {
+				t.Errorf("unexpected response: %v", got)
+			}
+			if _, ok := got["details"]; ok != tt.wantDetails {
+				t.Errorf("expected details present=%v, got %v", tt.wantDetails, got)
+			}
+		})
+	}
+}
+
+func TestWrite_RequestID(t *testing.T) {
+	type conflictResponse struct {
+		apierror.ErrorResponse
+		ExistingID string `json:"existingId"`
+	}
+
+	tests := []struct {
+		name string
+		body any
+	}{
+		{name: "error response", body: apierror.New(apierror.CodeNotFound, "project not found")},
+		{name: "embedded pointer", body: &conflictResponse{ErrorResponse: apierror.New(apierror.CodeConflict, "conflict"), ExistingID: "p1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			w.Header().Set(requestid.Header, "req-1")
+			apierror.Write(w, http.StatusNotFound, tt.body)
+
+			var got map[string]any
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got["requestId"] != "req-1" {
+				t.Errorf("expected requestId req-1, got %v", got)
+			}
+		})
+	}
+
+	// X-Request-ID が無い場合は requestId を省略する
+	w := httptest.NewRecorder()
+	apierror.Write(w, http.StatusNotFound, apierror.New(apierror.CodeNotFound, "project not found"))
+	if strings.Contains(w.Body.String(), "requestId") {
+		t.Errorf("expected no requestId, got %s", w.Body.String())
+	}
+}
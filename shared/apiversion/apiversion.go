@@ -0,0 +1,32 @@
+// Package apiversion は tasks / projects サービスで共通の API のバージョン（/api/v1）を提供する。
+//
+// 正式なパスは /api/v1/...。バージョン無しの /api/... は互換のため v1 の別名として扱う。
+// 互換を壊す変更（エラー形式の変更など）は /api/v2 を追加して行い、/api/... の別名は v1 のまま残す。
+package apiversion
+
+import "net/http"
+
+// Header はレスポンスの API のバージョンを返すヘッダ。
+const Header = "X-API-Version"
+
+// Current は現在の API のバージョン。
+const Current = "v1"
+
+// Handler は api（prefix を除いたパスを扱うハンドラ）を prefix/v1 と、互換のための prefix に配置する。
+// どちらのパスでもレスポンスに X-API-Version（v1）を付ける。
+func Handler(prefix string, api http.Handler) http.Handler {
+	versioned := prefix + "/" + Current
+	mux := http.NewServeMux()
+	mux.Handle(versioned+"/", http.StripPrefix(versioned, api))
+	mux.Handle(prefix+"/", http.StripPrefix(prefix, api))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(Header, Current)
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// Path は API のパス（/tasks など、/api を除いたもの）に正式なプレフィックス（/api/v1）を付けて返す。
+// クライアントは別名の /api ではなくこのパスを使う。
+func Path(path string) string {
+	return "/api/" + Current + path
+}
@@ -0,0 +1,46 @@
+package apiversion_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"teamflow-shared/apiversion"
+)
+
+func TestHandler(t *testing.T) {
+	api := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Path", r.URL.Path)
+	})
+	h := apiversion.Handler("/api", api)
+
+	tests := []struct {
+		path     string
+		wantPath string
+	}{
+		{path: "/api/v1/tasks", wantPath: "/tasks"},
+		{path: "/api/v1/projects/p-1/tasks", wantPath: "/projects/p-1/tasks"},
+		// バージョン無しのパスは v1 の別名
+		{path: "/api/tasks", wantPath: "/tasks"},
+		{path: "/api/projects/p-1/tasks", wantPath: "/projects/p-1/tasks"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if got := w.Header().Get("X-Path"); got != tt.wantPath {
+				t.Errorf("path = %q, want %q", got, tt.wantPath)
+			}
+			if got := w.Header().Get(apiversion.Header); got != "v1" {
+				t.Errorf("%s = %q, want v1", apiversion.Header, got)
+			}
+		})
+	}
+}
+
+func TestPath(t *testing.T) {
+	if got := apiversion.Path("/projects/p-1/tasks:batch"); got != "/api/v1/projects/p-1/tasks:batch" {
+		t.Errorf("Path() = %q", got)
+	}
+}
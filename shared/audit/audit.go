@@ -0,0 +1,87 @@
+// Package audit は projects / tasks サービスで共通の監査ログを定義する。
+//
+// 監査ログは追記専用の audit_log テーブル（各サービスのマイグレーションで同じ定義を作成する）に記録する。
+// 1 件の Entry は「誰が（ActorID）いつ（OccurredAt）どのサービスのどのエンティティに何をしたか」を表す。
+// 記録はユースケースから Recorder を通して行い、書き込みと同じトランザクションで保存する実装を想定する。
+package audit
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Action は監査ログに記録する操作の種類。
+type Action string
+
+const (
+	ActionCreate    Action = "create"
+	ActionUpdate    Action = "update"
+	ActionArchive   Action = "archive"
+	ActionUnarchive Action = "unarchive"
+	ActionDelete    Action = "delete"
+	ActionRestore   Action = "restore"
+	// ActionPurge は保持期間を過ぎたアーカイブ・削除済みのデータの物理削除（管理用 API・保持期間のジョブ）
+	ActionPurge Action = "purge"
+)
+
+// Entry は監査ログの 1 件。
+type Entry struct {
+	Service    string // 記録したサービス（projects / tasks）
+	EntityType string // 操作の対象（project / task）
+	EntityID   string
+	ProjectID  string // 対象が属するプロジェクト（プロジェクト自体の場合は EntityID と同じ）
+	Action     Action
+	ActorID    string // 操作者。空は不明（X-User-ID の無いサービス間の呼び出しなど）
+	// Fields は変更したフィールド（API の JSON 名）。update 以外は nil
+	Fields     []string
+	OccurredAt time.Time
+}
+
+// Recorder は監査ログを記録する。記録に失敗した場合は書き込み自体を失敗として扱えるよう、エラーを返す。
+type Recorder interface {
+	Record(ctx context.Context, entries ...Entry) error
+}
+
+// InsertSQL は audit_log に 1 件追加する SQL。引数は Entry.Args の順。
+const InsertSQL = `INSERT INTO audit_log (service, entity_type, entity_id, project_id, action, actor_id, fields, occurred_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+// Args は InsertSQL の引数を返す。Fields が nil の場合は空配列にする（fields カラムは NOT NULL）。
+func (e Entry) Args() []any {
+	fields := e.Fields
+	if fields == nil {
+		fields = []string{}
+	}
+	return []any{e.Service, e.EntityType, e.EntityID, e.ProjectID, string(e.Action), e.ActorID, fields, e.OccurredAt}
+}
+
+// MemoryRecorder はメモリ上に記録する Recorder。テストと DATABASE_URL 未設定時の開発用。
+type MemoryRecorder struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// NewMemoryRecorder は空の MemoryRecorder を生成する。
+func NewMemoryRecorder() *MemoryRecorder {
+	return &MemoryRecorder{}
+}
+
+// Record は entries を追加する。
+func (r *MemoryRecorder) Record(_ context.Context, entries ...Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range entries {
+		e.Fields = slices.Clone(e.Fields)
+		r.entries = append(r.entries, e)
+	}
+	return nil
+}
+
+// Entries は記録された順に Entry のコピーを返す。
+func (r *MemoryRecorder) Entries() []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.entries)
+}
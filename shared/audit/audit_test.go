@@ -0,0 +1,48 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"teamflow-shared/audit"
+)
+
+func TestEntry_Args(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	e := audit.Entry{
+		Service: "tasks", EntityType: "task", EntityID: "t-1", ProjectID: "p-1",
+		Action: audit.ActionCreate, ActorID: "u-1", OccurredAt: now,
+	}
+	args := e.Args()
+	if len(args) != 8 {
+		t.Fatalf("expected 8 args, got %d", len(args))
+	}
+	if args[4] != "create" || args[5] != "u-1" || args[7] != now {
+		t.Errorf("unexpected args: %v", args)
+	}
+	// fields カラムは NOT NULL のため nil は空配列にする
+	if fields, ok := args[6].([]string); !ok || fields == nil || len(fields) != 0 {
+		t.Errorf("expected empty fields, got %#v", args[6])
+	}
+}
+
+func TestMemoryRecorder(t *testing.T) {
+	r := audit.NewMemoryRecorder()
+	fields := []string{"title"}
+	if err := r.Record(context.Background(),
+		audit.Entry{EntityID: "t-1", Action: audit.ActionCreate},
+		audit.Entry{EntityID: "t-1", Action: audit.ActionUpdate, Fields: fields},
+	); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	fields[0] = "mutated"
+
+	got := r.Entries()
+	if len(got) != 2 || got[0].Action != audit.ActionCreate || got[1].Action != audit.ActionUpdate {
+		t.Fatalf("unexpected entries: %+v", got)
+	}
+	if got[1].Fields[0] != "title" {
+		t.Errorf("expected recorded fields to be copied, got %v", got[1].Fields)
+	}
+}
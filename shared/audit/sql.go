@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SQLRecorder は PostgreSQL の audit_log テーブルに記録する Recorder 実装。
+// txFromContext で ctx からトランザクションを取り出せれば、その中で記録する（サービスの書き込みと一緒にロールバックされる）。
+type SQLRecorder struct {
+	db            *pgxpool.Pool
+	txFromContext func(ctx context.Context) (pgx.Tx, bool)
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ Recorder = (*SQLRecorder)(nil)
+
+// NewSQLRecorder は新しいSQLRecorderを生成する。txFromContext は各サービスのトランザクションの取り出し方。
+func NewSQLRecorder(db *pgxpool.Pool, txFromContext func(ctx context.Context) (pgx.Tx, bool)) *SQLRecorder {
+	return &SQLRecorder{db: db, txFromContext: txFromContext}
+}
+
+// Record は entries を 1 回のバッチで追加する。
+func (r *SQLRecorder) Record(ctx context.Context, entries ...Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	b := &pgx.Batch{}
+	for _, e := range entries {
+		b.Queue(InsertSQL, e.Args()...)
+	}
+
+	var results pgx.BatchResults
+	if tx, ok := r.txFromContext(ctx); ok {
+		results = tx.SendBatch(ctx, b)
+	} else {
+		results = r.db.SendBatch(ctx, b)
+	}
+	if err := results.Close(); err != nil {
+		return fmt.Errorf("failed to insert audit log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Package authz は tasks / projects サービスで共通のアクセス制御（操作者の受け渡し・プロジェクトの閲覧権限・管理用 API の運用者）を提供する。
+//
+// projects サービスはプロジェクトの公開範囲とメンバーから閲覧できるかを判定し、
+// tasks サービスは操作者を引き継いで projects サービスに判定を委ねる。どちらも同じエラーで 401 / 403 を返す。
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ActorHeader は操作者のユーザー ID を受け取るヘッダ（認証済みのゲートウェイが設定する）。
+// サービス間の呼び出しでも、元のリクエストの操作者をこのヘッダで引き継ぐ。
+const ActorHeader = "X-User-ID"
+
+// ErrForbidden は権限が不足している場合のエラー。errors.Is で判定し、HTTP 層で 403 に変換する。
+var ErrForbidden = errors.New("forbidden")
+
+// ErrActorRequired はロールの確認が必要な操作で、操作者が特定できない場合のエラー。HTTP 層で 401 に変換する。
+var ErrActorRequired = errors.New("actor is required")
+
+// ErrInvalidVisibility は公開範囲が private / public 以外の場合のエラー。
+var ErrInvalidVisibility = errors.New("visibility must be private or public")
+
+// Visibility はプロジェクトの公開範囲。
+type Visibility string
+
+const (
+	// VisibilityPrivate はメンバーだけが閲覧できる（既定）。
+	VisibilityPrivate Visibility = "private"
+	// VisibilityPublic はメンバーでなくても（操作者が不明でも）閲覧できる。
+	VisibilityPublic Visibility = "public"
+)
+
+// ParseVisibility は文字列から Visibility を生成する。前後の空白と大文字小文字は無視する。
+// 未知の値の場合は ErrInvalidVisibility を返す。
+func ParseVisibility(s string) (Visibility, error) {
+	switch v := Visibility(strings.ToLower(strings.TrimSpace(s))); v {
+	case VisibilityPrivate, VisibilityPublic:
+		return v, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidVisibility, s)
+	}
+}
+
+// AuthorizeRead は公開範囲 v のプロジェクトを actorID が閲覧できるか確認する。
+// 公開プロジェクトは誰でも閲覧できる。非公開プロジェクトは actorID が空の場合は ErrActorRequired、
+// isMember が false を返す場合は ErrForbidden を返す。isMember は非公開で actorID がある場合だけ呼ぶ。
+func AuthorizeRead(v Visibility, actorID string, isMember func() (bool, error)) error {
+	if v == VisibilityPublic {
+		return nil
+	}
+	if actorID == "" {
+		return ErrActorRequired
+	}
+	ok, err := isMember()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: reading a private project requires membership", ErrForbidden)
+	}
+	return nil
+}
+
+type actorKey struct{}
+
+// ContextWithActor は操作者のユーザー ID を ctx に設定する。
+// サービス間のクライアントは ActorFromContext で取り出して ActorHeader に設定する。
+func ContextWithActor(ctx context.Context, actorID string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actorID)
+}
+
+// ActorFromContext は ContextWithActor で設定した操作者のユーザー ID を返す。未設定の場合は空文字。
+func ActorFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(actorKey{}).(string)
+	return id
+}
+
+type verifiedKey struct{}
+
+// ContextWithVerifiedIdentity は、トークン（JWT・個人用アクセストークン）を検証して ActorHeader と X-Workspace-ID を
+// 設定したことを ctx に記録する（jwt / pat の Middleware が設定する）。
+func ContextWithVerifiedIdentity(ctx context.Context) context.Context {
+	return context.WithValue(ctx, verifiedKey{}, true)
+}
+
+// IdentityVerified は ContextWithVerifiedIdentity で記録したかどうか（ヘッダの操作者・ワークスペースがトークンから設定されたか）を返す。
+func IdentityVerified(ctx context.Context) bool {
+	ok, _ := ctx.Value(verifiedKey{}).(bool)
+	return ok
+}
+
+// Operators は管理用の API（/api/admin 配下）を呼び出せる運用者のユーザー ID の集合。
+// プロジェクトのロールとは別に、サービスの設定（ADMIN_USER_IDS）で決める。
+type Operators map[string]bool
+
+// ParseOperators はカンマ区切りのユーザー ID から Operators を生成する。前後の空白と空の要素は無視する。
+func ParseOperators(s string) Operators {
+	ops := Operators{}
+	for _, id := range strings.Split(s, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ops[id] = true
+		}
+	}
+	return ops
+}
+
+// Authorize は actorID が運用者か確認する。actorID が空の場合は ErrActorRequired、
+// 運用者でない場合（運用者が 1 人も設定されていない場合を含む）は ErrForbidden を返す。
+func (o Operators) Authorize(actorID string) error {
+	if actorID == "" {
+		return ErrActorRequired
+	}
+	if !o[actorID] {
+		return fmt.Errorf("%w: the actor is not an operator", ErrForbidden)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package authz_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"teamflow-shared/authz"
+)
+
+func TestParseVisibility(t *testing.T) {
+	for _, tt := range []struct {
+		in      string
+		want    authz.Visibility
+		wantErr bool
+	}{
+		{in: "private", want: authz.VisibilityPrivate},
+		{in: " Public ", want: authz.VisibilityPublic},
+		{in: "", wantErr: true},
+		{in: "internal", wantErr: true},
+	} {
+		got, err := authz.ParseVisibility(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, authz.ErrInvalidVisibility) {
+				t.Errorf("%q: expected ErrInvalidVisibility, got %v", tt.in, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%q: expected %q, got %q (err=%v)", tt.in, tt.want, got, err)
+		}
+	}
+}
+
+func TestAuthorizeRead(t *testing.T) {
+	boom := errors.New("boom")
+	member := func() (bool, error) { return true, nil }
+	nonMember := func() (bool, error) { return false, nil }
+	failing := func() (bool, error) { return false, boom }
+	mustNotCall := func() (bool, error) {
+		t.Error("isMember must not be called")
+		return false, nil
+	}
+
+	tests := []struct {
+		name       string
+		visibility authz.Visibility
+		actorID    string
+		isMember   func() (bool, error)
+		wantErr    error
+	}{
+		{name: "public without actor", visibility: authz.VisibilityPublic, isMember: mustNotCall},
+		{name: "public non-member", visibility: authz.VisibilityPublic, actorID: "u1", isMember: mustNotCall},
+		{name: "private without actor", visibility: authz.VisibilityPrivate, isMember: mustNotCall, wantErr: authz.ErrActorRequired},
+		{name: "private member", visibility: authz.VisibilityPrivate, actorID: "u1", isMember: member},
+		{name: "private non-member", visibility: authz.VisibilityPrivate, actorID: "u1", isMember: nonMember, wantErr: authz.ErrForbidden},
+		{name: "membership lookup fails", visibility: authz.VisibilityPrivate, actorID: "u1", isMember: failing, wantErr: boom},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authz.AuthorizeRead(tt.visibility, tt.actorID, tt.isMember)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestActorContext(t *testing.T) {
+	ctx := context.Background()
+	if got := authz.ActorFromContext(ctx); got != "" {
+		t.Errorf("expected empty actor, got %q", got)
+	}
+	if got := authz.ActorFromContext(authz.ContextWithActor(ctx, "u1")); got != "u1" {
+		t.Errorf("expected u1, got %q", got)
+	}
+}
+
+func TestOperators(t *testing.T) {
+	ops := authz.ParseOperators(" ops-1, ,ops-2 ")
+	if len(ops) != 2 {
+		t.Fatalf("expected 2 operators, got %v", ops)
+	}
+	for _, tt := range []struct {
+		ops   authz.Operators
+		actor string
+		want  error
+	}{
+		{ops: ops, actor: "ops-2"},
+		{ops: ops, actor: "", want: authz.ErrActorRequired},
+		{ops: ops, actor: "user-1", want: authz.ErrForbidden},
+		{ops: authz.ParseOperators(""), actor: "ops-1", want: authz.ErrForbidden},
+	} {
+		if err := tt.ops.Authorize(tt.actor); !errors.Is(err, tt.want) {
+			t.Errorf("Authorize(%q) = %v, want %v", tt.actor, err, tt.want)
+		}
+	}
+}
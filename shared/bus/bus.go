@@ -0,0 +1,125 @@
+// Package bus は outbox のリレーがドメインイベントを配信するイベントバスの Publisher を提供する。
+//
+// 配信先は Config.Driver で選ぶ（log / nats / kafka）。イベントは events.Marshal の JSON（version 付きの Envelope）で送る。
+//   - nats: NATS に（nats.go のクライアントで）<SubjectPrefix>.<イベントの種類>（例: teamflow.events.task.created）の subject で publish する
+//   - kafka: Kafka REST Proxy（v2 API）で Topic に、対象の ID をキーにして produce する（同じタスク・プロジェクトのイベントは同じパーティションに入る）
+//
+// 一時的な失敗は Retries 回まで待ち時間を倍にしながら再試行し、それでも失敗した場合は outbox のリレーが後で再試行する。
+//
+// 他のサービスのイベントは NewConsumer の Consumer で購読する（nats / kafka のみ）。
+package bus
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"teamflow-shared/outbox"
+)
+
+// Driver はイベントバスの種類。
+type Driver string
+
+const (
+	DriverLog   Driver = "log"   // ログに出力するだけ（イベントバスを使わない開発用）
+	DriverNATS  Driver = "nats"  // NATS
+	DriverKafka Driver = "kafka" // Kafka（REST Proxy 経由）
+)
+
+// 設定の既定値。
+const (
+	DefaultSubjectPrefix = "teamflow.events"
+	DefaultTopic         = "teamflow.events"
+	DefaultRetries       = 3
+	// DefaultTimeout は 1 回の publish（接続を含む）のタイムアウト。
+	DefaultTimeout = 5 * time.Second
+)
+
+// ParseDriver は EVENT_BUS の値を Driver に変換する。空の場合は DriverLog。
+func ParseDriver(s string) (Driver, error) {
+	switch d := Driver(s); d {
+	case "":
+		return DriverLog, nil
+	case DriverLog, DriverNATS, DriverKafka:
+		return d, nil
+	default:
+		return "", fmt.Errorf("must be one of log, nats or kafka, got %q", s)
+	}
+}
+
+// Config はイベントバスの設定。
+type Config struct {
+	Driver Driver
+	// NATSURL は NATS サーバーの URL（nats://[user:pass@|token@]host:4222、TLS は tls://）。DriverNATS では必須
+	NATSURL string
+	// SubjectPrefix は NATS の subject の接頭辞。空の場合は DefaultSubjectPrefix
+	SubjectPrefix string
+	// KafkaRESTURL は Kafka REST Proxy のベース URL。DriverKafka では必須
+	KafkaRESTURL string
+	// Topic は Kafka のトピック。空の場合は DefaultTopic
+	Topic string
+	// Retries は 1 回の配信で失敗した場合に再試行する回数（0 は再試行しない）
+	Retries int
+	// ClientName は NATS の接続名（サーバーの監視で接続を見分けるために使う）
+	ClientName string
+}
+
+// Validate は Driver に必要な設定があるか、URL が正しいかを検証する。
+func (c Config) Validate() error {
+	switch c.Driver {
+	case DriverLog, "":
+		return nil
+	case DriverNATS:
+		if c.NATSURL == "" {
+			return errors.New("NATS URL is required")
+		}
+		return validateNATSURL(c.NATSURL)
+	case DriverKafka:
+		if c.KafkaRESTURL == "" {
+			return errors.New("kafka: REST Proxy URL is required")
+		}
+		if u, err := url.Parse(c.KafkaRESTURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid Kafka REST Proxy URL %q: must be an absolute http(s) URL", c.KafkaRESTURL)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown event bus %q", c.Driver)
+	}
+}
+
+// New は cfg の Publisher を生成する。戻り値の close で接続を閉じる。
+// NATS には最初の Publish で接続するため、New の時点ではサーバーに接続できなくてもエラーにしない。
+func New(cfg Config) (outbox.Publisher, func(), error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	var (
+		pub     outbox.Publisher
+		closeFn = func() {}
+	)
+	switch cfg.Driver {
+	case DriverLog, "":
+		return outbox.LogPublisher{}, closeFn, nil
+	case DriverNATS:
+		n, err := NewNATSPublisher(cfg.NATSURL, orDefault(cfg.SubjectPrefix, DefaultSubjectPrefix), cfg.ClientName)
+		if err != nil {
+			return nil, nil, err
+		}
+		pub, closeFn = n, n.Close
+	case DriverKafka:
+		pub = NewKafkaRESTPublisher(cfg.KafkaRESTURL, orDefault(cfg.Topic, DefaultTopic), &http.Client{Timeout: DefaultTimeout})
+	}
+	if cfg.Retries > 0 {
+		pub = &RetryingPublisher{Next: pub, Retries: cfg.Retries}
+	}
+	return pub, closeFn, nil
+}
+
+func orDefault(s, def string) string {
+	if s != "" {
+		return s
+	}
+	return def
+}
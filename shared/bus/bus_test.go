@@ -0,0 +1,515 @@
+package bus_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"teamflow-shared/bus"
+	"teamflow-shared/events"
+	"teamflow-shared/outbox"
+)
+
+func testEvent() events.Event {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	return events.NewTaskEvent(events.TaskCreated, "acme", events.TaskData{ID: "t-1", ProjectID: "p-1", Status: "todo"}, now)
+}
+
+func TestParseDriver(t *testing.T) {
+	for in, want := range map[string]bus.Driver{"": bus.DriverLog, "log": bus.DriverLog, "nats": bus.DriverNATS, "kafka": bus.DriverKafka} {
+		if got, err := bus.ParseDriver(in); err != nil || got != want {
+			t.Errorf("ParseDriver(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := bus.ParseDriver("rabbitmq"); err == nil {
+		t.Error("expected an error for unknown drivers")
+	}
+}
+
+func TestNew(t *testing.T) {
+	pub, closeFn, err := bus.New(bus.Config{Driver: bus.DriverLog, Retries: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeFn()
+	if _, ok := pub.(outbox.LogPublisher); !ok {
+		t.Errorf("expected LogPublisher, got %T", pub)
+	}
+
+	pub, _, err = bus.New(bus.Config{Driver: bus.DriverKafka, KafkaRESTURL: "http://kafka-rest:8082", Retries: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := pub.(*bus.RetryingPublisher); !ok || r.Retries != 2 {
+		t.Errorf("expected RetryingPublisher, got %T", pub)
+	}
+
+	for _, cfg := range []bus.Config{
+		{Driver: bus.DriverNATS},
+		{Driver: bus.DriverNATS, NATSURL: "http://nats:4222"},
+		{Driver: bus.DriverKafka},
+		{Driver: bus.DriverKafka, KafkaRESTURL: "kafka-rest:8082"},
+		{Driver: "rabbitmq"},
+	} {
+		if _, _, err := bus.New(cfg); err == nil {
+			t.Errorf("expected an error for %+v", cfg)
+		}
+	}
+}
+
+type flakyPublisher struct {
+	failures int
+	calls    int
+}
+
+func (p *flakyPublisher) Publish(context.Context, events.Event) error {
+	p.calls++
+	if p.calls <= p.failures {
+		return errors.New("temporary failure")
+	}
+	return nil
+}
+
+func TestRetryingPublisher(t *testing.T) {
+	next := &flakyPublisher{failures: 2}
+	p := &bus.RetryingPublisher{Next: next, Retries: 2, Delay: time.Millisecond}
+	if err := p.Publish(context.Background(), testEvent()); err != nil || next.calls != 3 {
+		t.Errorf("Publish() = %v after %d calls, want success after 3", err, next.calls)
+	}
+
+	next = &flakyPublisher{failures: 5}
+	p = &bus.RetryingPublisher{Next: next, Retries: 2, Delay: time.Millisecond}
+	if err := p.Publish(context.Background(), testEvent()); err == nil || next.calls != 3 {
+		t.Errorf("Publish() = %v after %d calls, want failure after 3", err, next.calls)
+	}
+}
+
+func TestKafkaRESTPublisher(t *testing.T) {
+	var got struct {
+		Records []struct {
+			Key   string          `json:"key"`
+			Value json.RawMessage `json:"value"`
+		} `json:"records"`
+	}
+	var fail bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/topics/teamflow.events" || r.Header.Get("Content-Type") != "application/vnd.kafka.json.v2+json" {
+			t.Errorf("unexpected request %s %s (%s)", r.Method, r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		if fail {
+			_, _ = io.WriteString(w, `{"offsets":[{"partition":null,"offset":null,"error_code":50003,"error":"leader not available"}]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"offsets":[{"partition":0,"offset":42,"error_code":null,"error":null}]}`)
+	}))
+	defer srv.Close()
+
+	p := bus.NewKafkaRESTPublisher(srv.URL+"/", "teamflow.events", srv.Client())
+	e := testEvent()
+	if err := p.Publish(context.Background(), e); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if len(got.Records) != 1 || got.Records[0].Key != "t-1" {
+		t.Fatalf("unexpected records %+v", got.Records)
+	}
+	decoded, err := events.Unmarshal(got.Records[0].Value)
+	if err != nil || decoded.ID != e.ID || decoded.Type != events.TaskCreated {
+		t.Errorf("unexpected value %s (%v)", got.Records[0].Value, err)
+	}
+
+	fail = true
+	if err := p.Publish(context.Background(), e); err == nil || !strings.Contains(err.Error(), "leader not available") {
+		t.Errorf("expected the record error, got %v", err)
+	}
+}
+
+// fakeNATS は NATS のクライアントプロトコルの一部（CONNECT / PUB / HPUB / PING）を話すテスト用のサーバー。
+type fakeNATS struct {
+	ln       net.Listener
+	headers  bool
+	mu       sync.Mutex
+	connects []string
+	msgs     []string // subject + " " + ヘッダ + ペイロード
+	subs     []fakeSub
+}
+
+// fakeSub は SUB で登録された購読。
+type fakeSub struct {
+	subject, queue, sid string
+	conn                net.Conn
+}
+
+func newFakeNATS(t *testing.T, headers bool) *fakeNATS {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeNATS{ln: ln, headers: headers}
+	go s.serve()
+	t.Cleanup(func() { _ = ln.Close() })
+	return s
+}
+
+func (s *fakeNATS) url(userinfo string) string {
+	return "nats://" + userinfo + s.ln.Addr().String()
+}
+
+func (s *fakeNATS) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeNATS) handle(conn net.Conn) {
+	defer conn.Close()
+	fmt.Fprintf(conn, "INFO {\"server_id\":\"fake\",\"max_payload\":1048576,\"proto\":1,\"headers\":%t}\r\n", s.headers)
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "CONNECT":
+			s.mu.Lock()
+			s.connects = append(s.connects, strings.TrimSpace(strings.TrimPrefix(line, "CONNECT ")))
+			s.mu.Unlock()
+			if strings.Contains(line, `"auth_token":"bad"`) {
+				_, _ = io.WriteString(conn, "-ERR 'Authorization Violation'\r\n")
+				return
+			}
+		case "PUB", "HPUB":
+			n, _ := strconv.Atoi(fields[len(fields)-1])
+			buf := make([]byte, n+2)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.msgs = append(s.msgs, fields[1]+" "+string(buf[:n]))
+			s.mu.Unlock()
+		case "SUB":
+			if len(fields) == 4 {
+				s.mu.Lock()
+				s.subs = append(s.subs, fakeSub{subject: fields[1], queue: fields[2], sid: fields[3], conn: conn})
+				s.mu.Unlock()
+			}
+		case "PING":
+			_, _ = io.WriteString(conn, "PONG\r\n")
+		}
+	}
+}
+
+// deliver は subject の購読者に MSG（header が空でない場合は HMSG）を送る。
+func (s *fakeNATS) deliver(subject, header string, payload []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sub := range s.subs {
+		if sub.subject != subject {
+			continue
+		}
+		if header == "" {
+			fmt.Fprintf(sub.conn, "MSG %s %s %d\r\n%s\r\n", subject, sub.sid, len(payload), payload)
+		} else {
+			fmt.Fprintf(sub.conn, "HMSG %s %s %d %d\r\n%s%s\r\n", subject, sub.sid, len(header), len(header)+len(payload), header, payload)
+		}
+	}
+}
+
+func (s *fakeNATS) subscriptions() []fakeSub {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]fakeSub(nil), s.subs...)
+}
+
+func (s *fakeNATS) snapshot() ([]string, []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.connects...), append([]string(nil), s.msgs...)
+}
+
+func TestNATSPublisher(t *testing.T) {
+	srv := newFakeNATS(t, false)
+	p, err := bus.NewNATSPublisher(srv.url("alice:secret@"), "teamflow.events", "teamflow-tasks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	e := testEvent()
+	for range 2 {
+		if err := p.Publish(context.Background(), e); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+
+	connects, msgs := srv.snapshot()
+	if len(connects) != 1 || !strings.Contains(connects[0], `"user":"alice"`) || !strings.Contains(connects[0], `"name":"teamflow-tasks"`) {
+		t.Errorf("expected a single authenticated connection, got %v", connects)
+	}
+	if len(msgs) != 2 {
+		t.Fatalf("expected 2 messages, got %v", msgs)
+	}
+	subject, payload, _ := strings.Cut(msgs[0], " ")
+	if subject != "teamflow.events.task.created" {
+		t.Errorf("subject = %q", subject)
+	}
+	if decoded, err := events.Unmarshal([]byte(payload)); err != nil || decoded.ID != e.ID {
+		t.Errorf("unexpected payload %s (%v)", payload, err)
+	}
+}
+
+func TestNATSPublisher_Headers(t *testing.T) {
+	srv := newFakeNATS(t, true)
+	p, err := bus.NewNATSPublisher(srv.url(""), "teamflow.events", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	e := testEvent()
+	if err := p.Publish(context.Background(), e); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	// JetStream で重複を排除できるよう、イベント ID を Nats-Msg-Id に付ける
+	if _, msgs := srv.snapshot(); len(msgs) != 1 || !strings.Contains(msgs[0], "Nats-Msg-Id: "+e.ID+"\r\n") {
+		t.Errorf("expected Nats-Msg-Id header, got %q", msgs)
+	}
+}
+
+func TestNATSPublisher_Errors(t *testing.T) {
+	srv := newFakeNATS(t, false)
+	p, err := bus.NewNATSPublisher(srv.url("bad@"), "teamflow.events", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	if err := p.Publish(context.Background(), testEvent()); !errors.Is(err, nats.ErrAuthorization) {
+		t.Errorf("expected the authorization error, got %v", err)
+	}
+
+	// 接続できないサーバーはエラーにする（outbox のリレーが後で再試行する）
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+	p, err = bus.NewNATSPublisher("nats://"+addr, "teamflow.events", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Publish(context.Background(), testEvent()); err == nil {
+		t.Error("expected an error for an unreachable server")
+	}
+}
+
+func TestNewConsumer(t *testing.T) {
+	if _, err := bus.NewConsumer(bus.Config{Driver: bus.DriverLog}, "projects"); err == nil {
+		t.Error("expected an error for the log driver")
+	}
+	if _, err := bus.NewConsumer(bus.Config{Driver: bus.DriverNATS}, "projects"); err == nil {
+		t.Error("expected an error for a missing NATS URL")
+	}
+	c, err := bus.NewConsumer(bus.Config{Driver: bus.DriverKafka, KafkaRESTURL: "http://kafka-rest:8082"}, "projects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.(*bus.KafkaRESTConsumer); !ok {
+		t.Errorf("expected KafkaRESTConsumer, got %T", c)
+	}
+}
+
+// collect は受け取ったイベントを ch に送る Handler を返す。最初の failures 回は失敗する。
+func collect(ch chan<- events.Event, failures int) bus.Handler {
+	var mu sync.Mutex
+	return func(_ context.Context, e events.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if failures > 0 {
+			failures--
+			return errors.New("temporary failure")
+		}
+		ch <- e
+		return nil
+	}
+}
+
+func receive(t *testing.T, ch <-chan events.Event) events.Event {
+	t.Helper()
+	select {
+	case e := <-ch:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+		return events.Event{}
+	}
+}
+
+func TestNATSConsumer(t *testing.T) {
+	srv := newFakeNATS(t, true)
+	c, err := bus.NewConsumer(bus.Config{Driver: bus.DriverNATS, NATSURL: srv.url("")}, "projects")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	got := make(chan events.Event, 10)
+	go func() {
+		defer close(done)
+		c.Consume(ctx, []events.Type{events.TaskCreated, events.TaskUpdated}, collect(got, 1))
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(srv.subscriptions()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for subscriptions")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	for _, sub := range srv.subscriptions() {
+		if sub.queue != "projects" || (sub.subject != "teamflow.events.task.created" && sub.subject != "teamflow.events.task.updated") {
+			t.Errorf("unexpected subscription %+v", sub)
+		}
+	}
+
+	created := testEvent()
+	updated := events.NewTaskEvent(events.TaskUpdated, "acme", events.TaskData{ID: "t-1", ProjectID: "p-1", Status: "done", PreviousStatus: "todo"}, time.Now())
+	payload, _ := events.Marshal(created)
+	srv.deliver("teamflow.events.task.created", "", payload)
+	srv.deliver("teamflow.events.task.created", "", []byte("not an event")) // 読み飛ばす
+	payload, _ = events.Marshal(updated)
+	srv.deliver("teamflow.events.task.updated", "NATS/1.0\r\nNats-Msg-Id: "+updated.ID+"\r\n\r\n", payload)
+
+	// 最初の失敗は再試行する
+	if e := receive(t, got); e.ID != created.ID {
+		t.Errorf("first event = %+v, want %s", e, created.ID)
+	}
+	if e := receive(t, got); e.ID != updated.ID || e.Type != events.TaskUpdated {
+		t.Errorf("second event = %+v, want %s", e, updated.ID)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Consume did not return after cancel")
+	}
+}
+
+func TestKafkaRESTConsumer(t *testing.T) {
+	created := testEvent()
+	other := events.NewProjectEvent(events.ProjectCreated, "acme", events.ProjectData{ID: "p-1"}, time.Now())
+	value1, _ := events.Marshal(created)
+	value2, _ := events.Marshal(other)
+
+	var (
+		mu      sync.Mutex
+		polls   int
+		commits int
+		deleted bool
+		base    string
+		topics  []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/consumers/projects":
+			fmt.Fprintf(w, `{"instance_id":"i-1","base_uri":%q}`, base+"/consumers/projects/instances/i-1")
+		case r.Method == http.MethodPost && r.URL.Path == "/consumers/projects/instances/i-1/subscription":
+			var body struct {
+				Topics []string `json:"topics"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			topics = body.Topics
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/consumers/projects/instances/i-1/records":
+			polls++
+			if polls == 1 {
+				fmt.Fprintf(w, `[{"topic":"teamflow.events","key":"t-1","value":%s,"partition":0,"offset":1},{"topic":"teamflow.events","key":"p-1","value":%s,"partition":0,"offset":2}]`, value1, value2)
+				return
+			}
+			_, _ = io.WriteString(w, "[]")
+		case r.Method == http.MethodPost && r.URL.Path == "/consumers/projects/instances/i-1/offsets":
+			commits++
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && r.URL.Path == "/consumers/projects/instances/i-1":
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	base = srv.URL
+
+	c := bus.NewKafkaRESTConsumer(srv.URL, "teamflow.events", "projects", srv.Client())
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	got := make(chan events.Event, 10)
+	go func() {
+		defer close(done)
+		c.Consume(ctx, []events.Type{events.TaskCreated}, collect(got, 0))
+	}()
+
+	if e := receive(t, got); e.ID != created.ID {
+		t.Errorf("event = %+v, want %s", e, created.ID)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		n := commits
+		mu.Unlock()
+		if n > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the offset commit")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(topics) != 1 || topics[0] != "teamflow.events" {
+		t.Errorf("subscribed topics = %v", topics)
+	}
+	if !deleted {
+		t.Error("expected the consumer instance to be deleted")
+	}
+	// project.created は購読していない種類のため Handler に渡さない
+	select {
+	case e := <-got:
+		t.Errorf("unexpected event %+v", e)
+	default:
+	}
+}
@@ -0,0 +1,96 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"teamflow-shared/events"
+)
+
+// Handler は購読したイベントを処理する。同じイベントが複数回届くことがあるため、イベント ID で重複を排除する。
+type Handler func(ctx context.Context, e events.Event) error
+
+// Consumer はイベントバスからイベントを受け取る。
+type Consumer interface {
+	// Consume は ctx がキャンセルされるまで types のイベントを受け取って h を呼ぶ。
+	// 接続が切れた場合は待ち時間を倍にしながら接続し直す。
+	Consume(ctx context.Context, types []events.Type, h Handler)
+}
+
+// 購読の既定値。
+const (
+	// handleRetries は Handler が失敗した場合に再試行する回数。それでも失敗したイベントはログに出力して読み飛ばす
+	handleRetries = DefaultRetries
+	// maxReconnectDelay は接続し直すまでの待ち時間の上限（最初は 1 秒）。
+	maxReconnectDelay = 30 * time.Second
+)
+
+// NewConsumer は cfg のイベントバスを group で購読する Consumer を生成する。
+// 同じ group の Consumer（同じサービスの複数のレプリカ）にはイベントを 1 回だけ配る。
+// DriverLog はイベントを配信しないため購読できない（エラーを返す）。
+func NewConsumer(cfg Config, group string) (Consumer, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	switch cfg.Driver {
+	case DriverNATS:
+		return &NATSConsumer{url: cfg.NATSURL, clientName: cfg.ClientName, subjectPrefix: orDefault(cfg.SubjectPrefix, DefaultSubjectPrefix), group: group}, nil
+	case DriverKafka:
+		// 長いポーリング（kafkaPollTimeout）より長くする
+		client := &http.Client{Timeout: DefaultTimeout + kafkaPollTimeout}
+		return NewKafkaRESTConsumer(cfg.KafkaRESTURL, orDefault(cfg.Topic, DefaultTopic), group, client), nil
+	default:
+		return nil, fmt.Errorf("event bus %q cannot be consumed (use nats or kafka)", orDefault(string(cfg.Driver), string(DriverLog)))
+	}
+}
+
+// consumeLoop は session を ctx がキャンセルされるまで繰り返す。session が失敗した場合は待ってから接続し直す。
+// session は接続できたら connected を呼ぶ（待ち時間を最初に戻す）。
+func consumeLoop(ctx context.Context, driver Driver, session func(ctx context.Context, connected func()) error) {
+	delay := time.Second
+	for ctx.Err() == nil {
+		err := session(ctx, func() { delay = time.Second })
+		if ctx.Err() != nil {
+			return
+		}
+		slog.Warn("event bus subscription failed; reconnecting", "driver", driver, "error", err, "retry_in", delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+// deliver は types に含まれるイベント e を h に渡す。h が失敗した場合は handleRetries 回まで再試行し、
+// それでも失敗した場合はログに出力して読み飛ばす（購読を止めない）。
+func deliver(ctx context.Context, types []events.Type, h Handler, e events.Event) {
+	if !slices.Contains(types, e.Type) {
+		return
+	}
+	delay := defaultRetryDelay
+	var err error
+	for attempt := 0; ; attempt++ {
+		if err = h(ctx, e); err == nil {
+			return
+		}
+		if attempt >= handleRetries || ctx.Err() != nil {
+			break
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+	slog.Error("failed to handle event", "event_id", e.ID, "type", e.Type, "error", err)
+}
@@ -0,0 +1,234 @@
+package bus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"teamflow-shared/events"
+	"teamflow-shared/outbox"
+)
+
+// kafkaJSONContentType は Kafka REST Proxy（v2 API）に JSON のレコードを送る Content-Type。
+const kafkaJSONContentType = "application/vnd.kafka.json.v2+json"
+
+// KafkaRESTPublisher は Kafka REST Proxy でトピックにイベントを produce する Publisher。
+// レコードのキーは対象の ID（AggregateID）、値は events.Marshal の JSON。
+type KafkaRESTPublisher struct {
+	endpoint string
+	client   *http.Client
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ outbox.Publisher = (*KafkaRESTPublisher)(nil)
+
+// NewKafkaRESTPublisher は baseURL の REST Proxy で topic に produce する KafkaRESTPublisher を生成する。
+func NewKafkaRESTPublisher(baseURL, topic string, client *http.Client) *KafkaRESTPublisher {
+	return &KafkaRESTPublisher{
+		endpoint: strings.TrimRight(baseURL, "/") + "/topics/" + url.PathEscape(topic),
+		client:   client,
+	}
+}
+
+type kafkaRecord struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+type kafkaProduceRequest struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		ErrorCode *int    `json:"error_code"`
+		Error     *string `json:"error"`
+	} `json:"offsets"`
+}
+
+// Publish は e を 1 件のレコードとして produce する。
+// 2xx 以外の応答や、レコードごとのエラー（offsets[].error_code）がある場合はエラーを返す。
+func (p *KafkaRESTPublisher) Publish(ctx context.Context, e events.Event) error {
+	value, err := events.Marshal(e)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(kafkaProduceRequest{Records: []kafkaRecord{{Key: e.AggregateID, Value: value}}})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaJSONContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json, application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to produce to kafka: %w", err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to produce to kafka: status %d: %s", resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+
+	var out kafkaProduceResponse
+	if err := json.Unmarshal(b, &out); err != nil {
+		return fmt.Errorf("failed to produce to kafka: invalid response: %w", err)
+	}
+	for _, o := range out.Offsets {
+		if o.ErrorCode != nil && *o.ErrorCode != 0 {
+			msg := ""
+			if o.Error != nil {
+				msg = *o.Error
+			}
+			return fmt.Errorf("failed to produce to kafka: error code %d: %s", *o.ErrorCode, msg)
+		}
+	}
+	return nil
+}
+
+// kafkaV2ContentType は Kafka REST Proxy（v2 API）の JSON 以外のリクエスト（コンシューマーの作成・購読・コミット）の Content-Type。
+const kafkaV2ContentType = "application/vnd.kafka.v2+json"
+
+// kafkaPollTimeout は 1 回のポーリングでレコードを待つ時間。
+const kafkaPollTimeout = 5 * time.Second
+
+// KafkaRESTConsumer は Kafka REST Proxy のコンシューマーグループでトピックを購読する Consumer。
+//
+// レコードは処理してからオフセットをコミットする（at-least-once）。コミットする前に停止した場合は、
+// 次に起動したときに同じレコードを受け取る。
+type KafkaRESTConsumer struct {
+	baseURL string
+	topic   string
+	group   string
+	client  *http.Client
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ Consumer = (*KafkaRESTConsumer)(nil)
+
+// NewKafkaRESTConsumer は baseURL の REST Proxy で topic をコンシューマーグループ group で購読する KafkaRESTConsumer を生成する。
+func NewKafkaRESTConsumer(baseURL, topic, group string, client *http.Client) *KafkaRESTConsumer {
+	return &KafkaRESTConsumer{baseURL: strings.TrimRight(baseURL, "/"), topic: topic, group: group, client: client}
+}
+
+type kafkaConsumerInstance struct {
+	InstanceID string `json:"instance_id"`
+	BaseURI    string `json:"base_uri"`
+}
+
+type kafkaConsumedRecord struct {
+	Value     json.RawMessage `json:"value"`
+	Partition int             `json:"partition"`
+	Offset    int64           `json:"offset"`
+}
+
+// Consume は ctx がキャンセルされるまで types のイベントを受け取って h を呼ぶ。
+func (c *KafkaRESTConsumer) Consume(ctx context.Context, types []events.Type, h Handler) {
+	consumeLoop(ctx, DriverKafka, func(ctx context.Context, connected func()) error {
+		return c.session(ctx, types, h, connected)
+	})
+}
+
+// session はコンシューマーのインスタンスを作って購読し、失敗するか ctx がキャンセルされるまでレコードを読む。
+// 終了時にインスタンスを削除する（パーティションをすぐに他のレプリカに割り当て直せるように）。
+func (c *KafkaRESTConsumer) session(ctx context.Context, types []events.Type, h Handler, connected func()) error {
+	var inst kafkaConsumerInstance
+	err := c.do(ctx, http.MethodPost, c.baseURL+"/consumers/"+url.PathEscape(c.group), map[string]string{
+		"format":             "json",
+		"auto.offset.reset":  "earliest",
+		"auto.commit.enable": "false",
+	}, "", &inst)
+	if err != nil {
+		return err
+	}
+	if inst.BaseURI == "" {
+		return errors.New("kafka: consumer instance has no base_uri")
+	}
+	defer func() {
+		dctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), DefaultTimeout)
+		defer cancel()
+		_ = c.do(dctx, http.MethodDelete, inst.BaseURI, nil, "", nil)
+	}()
+
+	if err := c.do(ctx, http.MethodPost, inst.BaseURI+"/subscription", map[string][]string{"topics": {c.topic}}, "", nil); err != nil {
+		return err
+	}
+	connected()
+
+	for {
+		var records []kafkaConsumedRecord
+		pollURL := inst.BaseURI + "/records?timeout=" + strconv.FormatInt(kafkaPollTimeout.Milliseconds(), 10)
+		if err := c.do(ctx, http.MethodGet, pollURL, nil, kafkaJSONContentType, &records); err != nil {
+			return err
+		}
+		for _, rec := range records {
+			e, err := events.Unmarshal(rec.Value)
+			if err != nil {
+				slog.Warn("skipping invalid event", "driver", DriverKafka, "partition", rec.Partition, "offset", rec.Offset, "error", err)
+				continue
+			}
+			deliver(ctx, types, h, e)
+		}
+		if ctx.Err() != nil {
+			// 処理し終えていないレコードはコミットしない
+			return ctx.Err()
+		}
+		if len(records) > 0 {
+			// 本文が空のコミットは、このインスタンスが読み出したすべてのレコードのオフセットをコミットする
+			if err := c.do(ctx, http.MethodPost, inst.BaseURI+"/offsets", nil, "", nil); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// do は REST Proxy に body（nil の場合は本文無し）を送り、2xx 以外の応答はエラーにする。out が nil でなければ応答を読み込む。
+func (c *KafkaRESTConsumer) do(ctx context.Context, method, endpoint string, body any, accept string, out any) error {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaV2ContentType)
+	if accept == "" {
+		accept = kafkaV2ContentType
+	}
+	req.Header.Set("Accept", accept+", application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("kafka: %s %s: %w", method, endpoint, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return fmt.Errorf("kafka: %s %s: status %d: %s", method, endpoint, resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("kafka: %s %s: invalid response: %w", method, endpoint, err)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+
+	"teamflow-shared/events"
+	"teamflow-shared/outbox"
+)
+
+// natsMsgIDHeader は JetStream がメッセージの重複を排除するヘッダ。
+const natsMsgIDHeader = "Nats-Msg-Id"
+
+// NATSPublisher は NATS にイベントを publish する Publisher（nats.go のクライアントを使う）。
+//
+// 接続は最初の Publish で張る。切れた接続は nats.go が張り直し、閉じた場合は次の Publish で張り直す。
+// publish の後に Flush で PONG を待つため、Publish が成功すればサーバーはメッセージを受け付けている。
+// サーバーがヘッダに対応していれば Nats-Msg-Id にイベント ID を付ける（JetStream のストリームで重複を排除できる）。
+type NATSPublisher struct {
+	url           string
+	subjectPrefix string
+	clientName    string
+
+	mu   sync.Mutex
+	conn *nats.Conn
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ outbox.Publisher = (*NATSPublisher)(nil)
+
+// NewNATSPublisher は rawURL（nats://[user:pass@|token@]host[:port]、TLS は tls://）の NATS サーバーに
+// <subjectPrefix>.<イベントの種類> の subject で publish する NATSPublisher を生成する。接続は最初の Publish で張る。
+func NewNATSPublisher(rawURL, subjectPrefix, clientName string) (*NATSPublisher, error) {
+	if err := validateNATSURL(rawURL); err != nil {
+		return nil, err
+	}
+	return &NATSPublisher{url: rawURL, subjectPrefix: subjectPrefix, clientName: clientName}, nil
+}
+
+// Subject は e を publish する subject を返す。
+func (p *NATSPublisher) Subject(e events.Event) string {
+	return p.subjectPrefix + "." + string(e.Type)
+}
+
+// Publish は e を publish し、サーバーが受け付けるまで待つ。
+func (p *NATSPublisher) Publish(ctx context.Context, e events.Event) error {
+	payload, err := events.Marshal(e)
+	if err != nil {
+		return err
+	}
+	nc, err := p.connection()
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+
+	msg := &nats.Msg{Subject: p.Subject(e), Data: payload}
+	if nc.HeadersSupported() {
+		msg.Header = nats.Header{natsMsgIDHeader: []string{e.ID}}
+	}
+	if err := nc.PublishMsg(msg); err != nil {
+		return fmt.Errorf("failed to publish to NATS: %w", err)
+	}
+	// FlushWithContext は期限の無い ctx を受け付けない
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+	if err := nc.FlushWithContext(ctx); err != nil {
+		return fmt.Errorf("failed to publish to NATS: %w", err)
+	}
+	return nil
+}
+
+// connection は接続を返す。まだ接続していないか、閉じている場合は接続する。
+func (p *NATSPublisher) connection() (*nats.Conn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn == nil || p.conn.IsClosed() {
+		nc, err := nats.Connect(p.url, natsOptions(p.clientName)...)
+		if err != nil {
+			return nil, err
+		}
+		p.conn = nc
+	}
+	return p.conn, nil
+}
+
+// Close は接続を閉じる。
+func (p *NATSPublisher) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn != nil {
+		p.conn.Close()
+		p.conn = nil
+	}
+}
+
+// validateNATSURL は rawURL（nats://[user:pass@|token@]host[:port]、TLS は tls://）を検証する。
+// 認証情報・ポートの既定値（4222）は nats.go が URL から読む。
+func validateNATSURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid NATS URL: %w", err)
+	}
+	if u.Scheme != "nats" && u.Scheme != "tls" {
+		return fmt.Errorf("invalid NATS URL %q: scheme must be nats or tls", rawURL)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("invalid NATS URL %q: host is required", rawURL)
+	}
+	return nil
+}
+
+// natsOptions は Publisher・Consumer に共通の接続のオプションを返す。
+func natsOptions(clientName string, opts ...nats.Option) []nats.Option {
+	return append([]nats.Option{
+		nats.Name(clientName),
+		nats.Timeout(DefaultTimeout),
+		nats.ErrorHandler(func(_ *nats.Conn, sub *nats.Subscription, err error) {
+			attrs := []any{"driver", DriverNATS, "error", err}
+			if sub != nil {
+				attrs = append(attrs, "subject", sub.Subject)
+			}
+			slog.Warn("NATS connection error", attrs...)
+		}),
+	}, opts...)
+}
+
+// NATSConsumer は NATS の subject（<subjectPrefix>.<イベントの種類>）をキューグループで購読する Consumer。
+//
+// 切れた接続は nats.go が張り直し、購読も張り直す。
+// コアの NATS は購読していない間のメッセージを保持しないため、停止中に publish されたイベントは受け取れない。
+// 取りこぼしを避ける場合は JetStream のストリームを用意し、そこから配る構成にする。
+type NATSConsumer struct {
+	url           string
+	clientName    string
+	subjectPrefix string
+	group         string
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ Consumer = (*NATSConsumer)(nil)
+
+// Consume は ctx がキャンセルされるまで types のイベントを受け取って h を呼ぶ。
+func (c *NATSConsumer) Consume(ctx context.Context, types []events.Type, h Handler) {
+	consumeLoop(ctx, DriverNATS, func(ctx context.Context, connected func()) error {
+		return c.session(ctx, types, h, connected)
+	})
+}
+
+// session は接続して types を購読し、接続が閉じるか ctx がキャンセルされるまでメッセージを処理する。
+func (c *NATSConsumer) session(ctx context.Context, types []events.Type, h Handler, connected func()) error {
+	closed := make(chan struct{})
+	nc, err := nats.Connect(c.url, natsOptions(c.clientName,
+		nats.MaxReconnects(-1),
+		nats.ClosedHandler(func(*nats.Conn) { close(closed) }),
+	)...)
+	if err != nil {
+		return err
+	}
+	defer nc.Close()
+
+	// 種類ごとの購読を 1 つのチャネルで受け取り、届いた順に 1 件ずつ処理する（作成より先に更新を処理しない）
+	msgs := make(chan *nats.Msg, nats.DefaultMaxChanLen)
+	for _, t := range types {
+		if _, err := nc.ChanQueueSubscribe(c.subjectPrefix+"."+string(t), c.group, msgs); err != nil {
+			return err
+		}
+	}
+	if err := nc.Flush(); err != nil {
+		return err
+	}
+	connected()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-closed:
+			if err := nc.LastError(); err != nil {
+				return err
+			}
+			return nats.ErrConnectionClosed
+		case msg := <-msgs:
+			e, err := events.Unmarshal(msg.Data)
+			if err != nil {
+				slog.Warn("skipping invalid event", "driver", DriverNATS, "error", err)
+				continue
+			}
+			deliver(ctx, types, h, e)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package bus
+
+import (
+	"context"
+	"time"
+
+	"teamflow-shared/events"
+	"teamflow-shared/outbox"
+)
+
+// defaultRetryDelay は RetryingPublisher の最初の再試行までの待ち時間。
+const defaultRetryDelay = 100 * time.Millisecond
+
+// RetryingPublisher は Next の失敗を Retries 回まで、待ち時間を倍にしながら再試行する Publisher。
+type RetryingPublisher struct {
+	Next    outbox.Publisher
+	Retries int
+	// Delay は最初の再試行までの待ち時間。任意。0 の場合は 100ms
+	Delay time.Duration
+}
+
+// Publish は e を配信する。すべて失敗した場合は最後のエラーを返す。
+// ctx がキャンセルされた場合は待機を打ち切り、直前のエラーを返す。
+func (p *RetryingPublisher) Publish(ctx context.Context, e events.Event) error {
+	delay := p.Delay
+	if delay <= 0 {
+		delay = defaultRetryDelay
+	}
+	var err error
+	for attempt := 0; ; attempt++ {
+		if err = p.Next.Publish(ctx, e); err == nil || attempt >= p.Retries {
+			return err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
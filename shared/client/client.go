@@ -0,0 +1,240 @@
+// Package client は TeamFlow の API（projects / tasks / users サービス）の Go クライアント。
+//
+// サービス間の呼び出しと CLI で使う。パスは正式な /api/v1 配下を使い、
+// 2xx 以外のレスポンスは ErrorResponse をデコードした *APIError として返す。
+// ワークスペース（X-Workspace-ID）は ctx の workspace.FromContext を常に送る。
+// 一覧は cursor をたどってすべてのページを返すイテレータ（Projects / ProjectTasks）も提供する。
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/apiversion"
+	"teamflow-shared/authz"
+	"teamflow-shared/requestid"
+	"teamflow-shared/workspace"
+)
+
+// DefaultTimeout は httpClient を指定しない場合の 1 回のリクエストのタイムアウト。
+const DefaultTimeout = 10 * time.Second
+
+// Client は TeamFlow の API クライアント。projects / tasks / users のどのサービスにも使える
+// （baseURL のサービスに無いメソッドを呼ぶと 404 になる）。
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	actorID    string
+	token      string
+}
+
+// New は baseURL（例: http://tasks:8081）のサービスに接続する Client を生成する。
+// httpClient が nil の場合はタイムアウト付きで、リクエスト ID（X-Request-ID）を引き継ぐ既定のクライアントを使う。
+func New(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultTimeout, Transport: &requestid.Transport{}}
+	}
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// WithActor は操作者（X-User-ID）を actorID にした Client を返す。空の場合は操作者を送らない。
+// サービス間の呼び出しでは元のリクエストの操作者（authz.ActorFromContext）を引き継ぐのに使う。
+func (c *Client) WithActor(actorID string) *Client {
+	cp := *c
+	cp.actorID = actorID
+	return &cp
+}
+
+// WithToken は個人用アクセストークン（Authorization: Bearer）を token にした Client を返す。空の場合は送らない。
+// CLI や CI から呼び出すのに使う。操作者とワークスペースはサーバーがトークンから決める。
+func (c *Client) WithToken(token string) *Client {
+	cp := *c
+	cp.token = token
+	return &cp
+}
+
+// APIError は 2xx 以外のレスポンス。本文が ErrorResponse の場合は Code / Message などを設定する。
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	// Code は ErrorResponse.error（例: NOT_FOUND）。本文が ErrorResponse でない場合は空
+	Code      string
+	Message   string
+	RequestID string
+	Issues    []apierror.ValidationIssue
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("teamflow: %s %s: status %d", e.Method, e.Path, e.StatusCode)
+	if e.Code != "" {
+		msg += " " + e.Code
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// StatusCode は err が *APIError の場合にそのステータスコードを返す。それ以外は 0。
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound は err が 404 の *APIError かどうかを返す。
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// do は method で path に in（nil の場合は本文なし）を JSON で送り、2xx の場合は out（nil の場合は読み捨てる）にデコードする。
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	path = apiversion.Path(path)
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if in != nil {
+		encoded, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("teamflow: failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return fmt.Errorf("teamflow: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.actorID != "" {
+		req.Header.Set(authz.ActorHeader, c.actorID)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	// ワークスペースは呼び出し元の context から常に引き継ぐ（別のワークスペースのデータを扱わないため）
+	req.Header.Set(workspace.Header, workspace.FromContext(ctx))
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("teamflow: %s %s: %w", method, path, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return decodeError(res, method, path)
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, res.Body)
+		return nil
+	}
+	// 本文が空の場合（204 など）は out をゼロ値のままにする
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("teamflow: %s %s: failed to decode response: %w", method, path, err)
+	}
+	return nil
+}
+
+// decodeError は 2xx 以外のレスポンスを *APIError にする。
+func decodeError(res *http.Response, method, path string) error {
+	apiErr := &APIError{Method: method, Path: path, StatusCode: res.StatusCode, RequestID: res.Header.Get(requestid.Header)}
+	raw, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+	var body apierror.ErrorResponse
+	if json.Unmarshal(raw, &body) == nil && body.Error != "" {
+		apiErr.Code = body.Error
+		apiErr.Message = body.Message
+		if body.RequestID != "" {
+			apiErr.RequestID = body.RequestID
+		}
+		if body.Details != nil {
+			apiErr.Issues = body.Details.Issues
+		}
+	} else {
+		apiErr.Message = strings.TrimSpace(string(raw))
+	}
+	return apiErr
+}
+
+// PageInfo は一覧のレスポンスの page。
+type PageInfo struct {
+	// NextCursor は次のページの cursor（最後のページの場合は nil）
+	NextCursor *string `json:"nextCursor"`
+	Limit      int     `json:"limit"`
+}
+
+// next は次のページの cursor を返す。最後のページの場合は空文字。
+func (p PageInfo) next() string {
+	if p.NextCursor == nil {
+		return ""
+	}
+	return *p.NextCursor
+}
+
+// paginate は fetch で cursor をたどり、すべてのページの要素を順に返すイテレータを返す。
+// エラーの場合はエラーを 1 回返して終了する。
+func paginate[T any](fetch func(cursor string) ([]T, string, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		cursor := ""
+		for {
+			items, next, err := fetch(cursor)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if next == "" {
+				return
+			}
+			cursor = next
+		}
+	}
+}
+
+// Collect は it の要素をすべてスライスにまとめる。エラーの場合はそれまでの要素とエラーを返す。
+func Collect[T any](it iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range it {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// setIfNotEmpty は v が空でなければ query に key を設定する。
+func setIfNotEmpty(query url.Values, key, v string) {
+	if v != "" {
+		query.Set(key, v)
+	}
+}
+
+func escape(id string) string {
+	return url.PathEscape(id)
+}
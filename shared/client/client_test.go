@@ -0,0 +1,295 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/authz"
+	"teamflow-shared/client"
+	"teamflow-shared/workspace"
+)
+
+func TestProjectTasks_FollowsCursor(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/projects/p-1/tasks" {
+			t.Errorf("path = %q, want /api/v1/projects/p-1/tasks", r.URL.Path)
+		}
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			_, _ = w.Write([]byte(`{"tasks":[{"id":"t-1"},{"id":"t-2"}],"page":{"nextCursor":"c-2","limit":2}}`))
+		case "c-2":
+			_, _ = w.Write([]byte(`{"tasks":[{"id":"t-3"}],"page":{"limit":2}}`))
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL, srv.Client())
+	tasks, err := client.Collect(c.ProjectTasks(context.Background(), "p-1", client.ListTasksOptions{Status: "todo,in_progress", Limit: 2}))
+	if err != nil {
+		t.Fatalf("ProjectTasks: %v", err)
+	}
+	var ids []string
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	if want := []string{"t-1", "t-2", "t-3"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+	want := []string{"limit=2&status=todo%2Cin_progress", "cursor=c-2&limit=2&status=todo%2Cin_progress"}
+	if !reflect.DeepEqual(queries, want) {
+		t.Errorf("queries = %v, want %v", queries, want)
+	}
+}
+
+func TestProjectTasks_StopsOnError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apierror.Write(w, http.StatusForbidden, apierror.ErrorResponse{Error: "FORBIDDEN", Message: "not allowed"})
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL, srv.Client())
+	n := 0
+	for _, err := range c.ProjectTasks(context.Background(), "p-1", client.ListTasksOptions{}) {
+		n++
+		if client.StatusCode(err) != http.StatusForbidden {
+			t.Errorf("err = %v, want 403 *APIError", err)
+		}
+	}
+	if n != 1 {
+		t.Errorf("yielded %d times, want 1", n)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(apierror.ErrorResponse{Error: "NOT_FOUND", Message: "project not found", RequestID: "req-1"})
+	}))
+	defer srv.Close()
+
+	_, err := client.New(srv.URL, srv.Client()).GetProject(context.Background(), "p-404")
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Code != "NOT_FOUND" || apiErr.RequestID != "req-1" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+	if !client.IsNotFound(err) {
+		t.Errorf("IsNotFound(%v) = false, want true", err)
+	}
+	if got, want := err.Error(), "teamflow: GET /api/v1/projects/p-404: status 404 NOT_FOUND: project not found"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestAPIError_PlainTextBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream unavailable", http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	_, err := client.New(srv.URL, srv.Client()).ProjectTaskStats(context.Background(), "p-1")
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want *APIError", err)
+	}
+	if apiErr.Code != "" || apiErr.Message != "upstream unavailable" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+	if client.IsNotFound(err) {
+		t.Error("IsNotFound = true, want false")
+	}
+}
+
+func TestWithActor(t *testing.T) {
+	var actors []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actors = append(actors, r.Header.Get(authz.ActorHeader))
+		_, _ = w.Write([]byte(`{"id":"p-1"}`))
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL, srv.Client())
+	ctx := context.Background()
+	if _, err := c.WithActor("u-1").GetProject(ctx, "p-1"); err != nil {
+		t.Fatalf("GetProject: %v", err)
+	}
+	// WithActor は元の Client を変更しない
+	if _, err := c.GetProject(ctx, "p-1"); err != nil {
+		t.Fatalf("GetProject: %v", err)
+	}
+	if want := []string{"u-1", ""}; !reflect.DeepEqual(actors, want) {
+		t.Errorf("actors = %q, want %q", actors, want)
+	}
+}
+
+func TestWorkspaceFromContext(t *testing.T) {
+	var workspaces []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		workspaces = append(workspaces, r.Header.Get(workspace.Header))
+		_, _ = w.Write([]byte(`{"id":"p-1"}`))
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL, srv.Client())
+	if _, err := c.GetProject(workspace.NewContext(context.Background(), "acme"), "p-1"); err != nil {
+		t.Fatalf("GetProject: %v", err)
+	}
+	if _, err := c.GetProject(context.Background(), "p-1"); err != nil {
+		t.Fatalf("GetProject: %v", err)
+	}
+	if want := []string{"acme", workspace.DefaultID}; !reflect.DeepEqual(workspaces, want) {
+		t.Errorf("workspaces = %q, want %q", workspaces, want)
+	}
+}
+
+func TestCarryOverSprintTasks(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/projects/p-1/tasks:carry-over" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"projectId":"p-1","count":3}`))
+	}))
+	defer srv.Close()
+
+	n, err := client.New(srv.URL, srv.Client()).CarryOverSprintTasks(context.Background(), "p-1", "s-1", "")
+	if err != nil {
+		t.Fatalf("CarryOverSprintTasks: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("count = %d, want 3", n)
+	}
+	// toSprintId の省略はバックログへの移動として null で送る
+	if want := map[string]any{"fromSprintId": "s-1", "toSprintId": nil}; !reflect.DeepEqual(body, want) {
+		t.Errorf("body = %v, want %v", body, want)
+	}
+}
+
+func TestLookupUsers(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/users:lookup" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"users":[{"id":"u-1","name":"Taro","email":"taro@example.com","avatarUrl":null}],"missingIds":["u-2"]}`))
+	}))
+	defer srv.Close()
+
+	got, err := client.New(srv.URL, srv.Client()).LookupUsers(context.Background(), []string{"u-1", "u-2"})
+	if err != nil {
+		t.Fatalf("LookupUsers: %v", err)
+	}
+	if len(got.Users) != 1 || got.Users[0].Name != "Taro" || got.Users[0].AvatarURL != nil {
+		t.Errorf("unexpected users: %+v", got.Users)
+	}
+	if !reflect.DeepEqual(got.MissingIDs, []string{"u-2"}) {
+		t.Errorf("missingIds = %v", got.MissingIDs)
+	}
+	if want := map[string]any{"ids": []any{"u-1", "u-2"}}; !reflect.DeepEqual(body, want) {
+		t.Errorf("request body = %v, want %v", body, want)
+	}
+}
+
+func TestLookupUsersWithPreferences(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/users:lookup" || r.URL.Query().Get("expand") != "notificationPreferences" {
+			t.Errorf("request = %s %s", r.Method, r.URL)
+		}
+		_, _ = w.Write([]byte(`{"users":[{"id":"u-1","name":"Taro","email":"taro@example.com","avatarUrl":null,` +
+			`"notificationPreferences":{"emailOnAssignment":false,"emailOnDueSoon":true,"updatedAt":null}}],"missingIds":[]}`))
+	}))
+	defer srv.Close()
+
+	got, err := client.New(srv.URL, srv.Client()).LookupUsersWithPreferences(context.Background(), []string{"u-1"})
+	if err != nil {
+		t.Fatalf("LookupUsersWithPreferences: %v", err)
+	}
+	if len(got.Users) != 1 || got.Users[0].NotificationPreferences == nil {
+		t.Fatalf("unexpected users: %+v", got.Users)
+	}
+	if p := got.Users[0].NotificationPreferences; p.EmailOnAssignment || !p.EmailOnDueSoon || p.UpdatedAt != nil {
+		t.Errorf("unexpected preferences: %+v", p)
+	}
+}
+
+func TestWithToken(t *testing.T) {
+	var auths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auths = append(auths, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"id":"p-1"}`))
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL, srv.Client())
+	ctx := context.Background()
+	if _, err := c.WithToken("tfp_secret").GetProject(ctx, "p-1"); err != nil {
+		t.Fatalf("GetProject: %v", err)
+	}
+	if _, err := c.GetProject(ctx, "p-1"); err != nil {
+		t.Fatalf("GetProject: %v", err)
+	}
+	if want := []string{"Bearer tfp_secret", ""}; !reflect.DeepEqual(auths, want) {
+		t.Errorf("Authorization = %q, want %q", auths, want)
+	}
+}
+
+func TestAccessTokens(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"t-1","name":"ci","prefix":"tfp_abcdefgh","scopes":["read"],"createdAt":"2025-01-01T10:00:00Z","expiresAt":null,"lastUsedAt":null,"revokedAt":null,"token":"tfp_abcdefgh"}`))
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"tokens":[{"id":"t-1","name":"ci","prefix":"tfp_abcdefgh","scopes":["read"],"createdAt":"2025-01-01T10:00:00Z","expiresAt":null,"lastUsedAt":null,"revokedAt":null}]}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL, srv.Client()).WithActor("u-1")
+	ctx := context.Background()
+	created, err := c.CreateAccessToken(ctx, "u-1", client.CreateAccessTokenRequest{Name: "ci", Scopes: []string{"read"}})
+	if err != nil {
+		t.Fatalf("CreateAccessToken: %v", err)
+	}
+	if created.ID != "t-1" || created.Token == "" {
+		t.Errorf("unexpected token: %+v", created)
+	}
+	tokens, err := c.ListAccessTokens(ctx, "u-1")
+	if err != nil {
+		t.Fatalf("ListAccessTokens: %v", err)
+	}
+	if len(tokens) != 1 || tokens[0].Token != "" || tokens[0].Prefix != "tfp_abcdefgh" {
+		t.Errorf("unexpected tokens: %+v", tokens)
+	}
+	if err := c.RevokeAccessToken(ctx, "u-1", "t-1"); err != nil {
+		t.Fatalf("RevokeAccessToken: %v", err)
+	}
+
+	want := []string{"POST /api/v1/users/u-1/tokens", "GET /api/v1/users/u-1/tokens", "DELETE /api/v1/users/u-1/tokens/t-1"}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+}
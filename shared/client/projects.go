@@ -0,0 +1,240 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Project は OpenAPI の Project。
+type Project struct {
+	ID          string      `json:"id"`
+	Key         string      `json:"key,omitempty"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Status      string      `json:"status"`
+	Visibility  string      `json:"visibility"`
+	CreatedAt   time.Time   `json:"createdAt"`
+	UpdatedAt   time.Time   `json:"updatedAt"`
+	ArchivedAt  *time.Time  `json:"archivedAt,omitempty"`
+	CreatedBy   string      `json:"createdBy,omitempty"`
+	UpdatedBy   string      `json:"updatedBy,omitempty"`
+	TaskCounts  *TaskCounts `json:"taskCounts,omitempty"`
+}
+
+// TaskCounts はプロジェクトの一覧で expand=taskCounts を指定した場合のタスク数。
+type TaskCounts struct {
+	Open int `json:"open"`
+	Done int `json:"done"`
+}
+
+// CreateProjectRequest は OpenAPI の ProjectCreateRequest。
+type CreateProjectRequest struct {
+	Key         string `json:"key,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Visibility  string `json:"visibility,omitempty"`
+}
+
+// ListProjectsOptions は GET /api/v1/projects のクエリ。ゼロ値の項目は送らない。
+type ListProjectsOptions struct {
+	Query    string
+	Archived *bool
+	Status   string
+	Sort     string
+	// Limit は 1 ページの件数（0 の場合はサービスの既定値）
+	Limit int
+	// ExpandTaskCounts は各プロジェクトに taskCounts を含めるかどうか
+	ExpandTaskCounts bool
+}
+
+func (o ListProjectsOptions) query(cursor string) url.Values {
+	q := url.Values{}
+	setIfNotEmpty(q, "q", o.Query)
+	if o.Archived != nil {
+		q.Set("archived", strconv.FormatBool(*o.Archived))
+	}
+	setIfNotEmpty(q, "status", o.Status)
+	// ソート順は cursor に含まれるため、2 ページ目以降は送らない
+	if cursor == "" {
+		setIfNotEmpty(q, "sort", o.Sort)
+	}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.ExpandTaskCounts {
+		q.Set("expand", "taskCounts")
+	}
+	setIfNotEmpty(q, "cursor", cursor)
+	return q
+}
+
+// ProjectPage は GET /api/v1/projects のレスポンス。
+type ProjectPage struct {
+	Projects []Project `json:"projects"`
+	Page     PageInfo  `json:"page"`
+}
+
+// ListProjects はプロジェクトの一覧を 1 ページ取得する（cursor が空の場合は最初のページ）。
+func (c *Client) ListProjects(ctx context.Context, opts ListProjectsOptions, cursor string) (*ProjectPage, error) {
+	var page ProjectPage
+	if err := c.do(ctx, http.MethodGet, "/projects", opts.query(cursor), nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// Projects はプロジェクトの一覧を、cursor をたどってすべてのページから順に返す。
+func (c *Client) Projects(ctx context.Context, opts ListProjectsOptions) iter.Seq2[Project, error] {
+	return paginate(func(cursor string) ([]Project, string, error) {
+		page, err := c.ListProjects(ctx, opts, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		return page.Projects, page.Page.next(), nil
+	})
+}
+
+// GetProject はプロジェクトを取得する。
+func (c *Client) GetProject(ctx context.Context, projectID string) (*Project, error) {
+	var p Project
+	if err := c.do(ctx, http.MethodGet, "/projects/"+escape(projectID), nil, nil, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// CreateProject はプロジェクトを作成する。
+func (c *Client) CreateProject(ctx context.Context, req CreateProjectRequest) (*Project, error) {
+	var p Project
+	if err := c.do(ctx, http.MethodPost, "/projects", nil, req, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ProjectSettings は OpenAPI の ProjectSettings。
+type ProjectSettings struct {
+	ProjectID         string         `json:"projectId"`
+	DefaultPriority   *string        `json:"defaultPriority"`
+	DefaultAssigneeID *string        `json:"defaultAssigneeId"`
+	DefaultSort       *string        `json:"defaultSort"`
+	WIPLimits         map[string]int `json:"wipLimits"`
+	// FieldLocks はタスクのフィールドごとに変更できるロール（例: {"priority": ["admin", "owner"]}）
+	FieldLocks map[string][]string `json:"fieldLocks"`
+	// Timezone は期日の判定に使うタイムゾーン（IANA のタイムゾーン名、例: Asia/Tokyo）。未設定の場合は nil（UTC）
+	Timezone  *string    `json:"timezone"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+}
+
+// GetProjectSettings はプロジェクト設定を取得する。
+func (c *Client) GetProjectSettings(ctx context.Context, projectID string) (*ProjectSettings, error) {
+	var s ProjectSettings
+	if err := c.do(ctx, http.MethodGet, "/projects/"+escape(projectID)+"/settings", nil, nil, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// ProjectMember は OpenAPI の ProjectMember。
+type ProjectMember struct {
+	ProjectID string    `json:"projectId"`
+	UserID    string    `json:"userId"`
+	Role      string    `json:"role"`
+	JoinedAt  time.Time `json:"joinedAt"`
+}
+
+// GetMember はプロジェクトのメンバーを取得する。メンバーでない場合は 404 の *APIError を返す。
+func (c *Client) GetMember(ctx context.Context, projectID, userID string) (*ProjectMember, error) {
+	var m ProjectMember
+	if err := c.do(ctx, http.MethodGet, "/projects/"+escape(projectID)+"/members/"+escape(userID), nil, nil, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Milestone は OpenAPI の Milestone。
+type Milestone struct {
+	ID        string     `json:"id"`
+	ProjectID string     `json:"projectId"`
+	Name      string     `json:"name"`
+	DueDate   *time.Time `json:"dueDate"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+// GetMilestone はマイルストーンを取得する。
+func (c *Client) GetMilestone(ctx context.Context, projectID, milestoneID string) (*Milestone, error) {
+	var m Milestone
+	if err := c.do(ctx, http.MethodGet, "/projects/"+escape(projectID)+"/milestones/"+escape(milestoneID), nil, nil, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Sprint は OpenAPI の Sprint。
+type Sprint struct {
+	ID          string     `json:"id"`
+	ProjectID   string     `json:"projectId"`
+	Name        string     `json:"name"`
+	Goal        string     `json:"goal"`
+	StartDate   time.Time  `json:"startDate"`
+	EndDate     time.Time  `json:"endDate"`
+	State       string     `json:"state"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	CompletedAt *time.Time `json:"completedAt"`
+}
+
+// GetSprint はスプリントを取得する。
+func (c *Client) GetSprint(ctx context.Context, projectID, sprintID string) (*Sprint, error) {
+	var s Sprint
+	if err := c.do(ctx, http.MethodGet, "/projects/"+escape(projectID)+"/sprints/"+escape(sprintID), nil, nil, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Epic は OpenAPI の Epic。
+type Epic struct {
+	ID          string    `json:"id"`
+	ProjectID   string    `json:"projectId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// GetEpic はエピックを取得する。
+func (c *Client) GetEpic(ctx context.Context, projectID, epicID string) (*Epic, error) {
+	var e Epic
+	if err := c.do(ctx, http.MethodGet, "/projects/"+escape(projectID)+"/epics/"+escape(epicID), nil, nil, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// Label は OpenAPI の TaskLabel。
+type Label struct {
+	ID        string    `json:"id"`
+	ProjectID string    `json:"projectId"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// GetLabel はラベルを取得する。
+func (c *Client) GetLabel(ctx context.Context, projectID, labelID string) (*Label, error) {
+	var l Label
+	if err := c.do(ctx, http.MethodGet, "/projects/"+escape(projectID)+"/labels/"+escape(labelID), nil, nil, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
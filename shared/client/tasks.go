@@ -0,0 +1,276 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Task は OpenAPI の Task。
+type Task struct {
+	ID          string     `json:"id"`
+	ProjectID   string     `json:"projectId"`
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Priority    string     `json:"priority"`
+	AssigneeID  *string    `json:"assigneeId"`
+	DueDate     *string    `json:"dueDate"` // YYYY-MM-DD
+	StartDate   *time.Time `json:"startDate"`
+	Estimate    *int       `json:"estimate"`
+	MilestoneID *string    `json:"milestoneId"`
+	SprintID    *string    `json:"sprintId"`
+	EpicID      *string    `json:"epicId"`
+	LabelIDs    []string   `json:"labelIds"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	ArchivedAt  *time.Time `json:"archivedAt,omitempty"`
+	CreatedBy   string     `json:"createdBy,omitempty"`
+	UpdatedBy   string     `json:"updatedBy,omitempty"`
+}
+
+// CreateTaskRequest は OpenAPI の TaskCreateRequest。ゼロ値の項目は送らない（サービスの既定値になる）。
+type CreateTaskRequest struct {
+	ID          string   `json:"id,omitempty"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Status      string   `json:"status,omitempty"`
+	Priority    string   `json:"priority,omitempty"`
+	AssigneeID  string   `json:"assigneeId,omitempty"`
+	MilestoneID string   `json:"milestoneId,omitempty"`
+	SprintID    string   `json:"sprintId,omitempty"`
+	EpicID      string   `json:"epicId,omitempty"`
+	LabelIDs    []string `json:"labelIds,omitempty"`
+}
+
+// TaskPatch は PATCH /api/v1/projects/{projectId}/tasks/{id} の本文（JSON Merge Patch）。
+// キーは Task の JSON 名で、値を nil にするとその項目をクリアする。
+type TaskPatch map[string]any
+
+// ListTasksOptions は GET /api/v1/projects/{projectId}/tasks のクエリ。ゼロ値の項目は送らない。
+type ListTasksOptions struct {
+	// Status はカンマ区切りで複数指定できる（例: todo,in_progress）
+	Status      string
+	Priority    string
+	AssigneeID  string
+	MilestoneID string
+	SprintID    string
+	EpicID      string
+	Query       string
+	Sort        string
+	// Limit は 1 ページの件数（0 の場合はサービスの既定値）
+	Limit int
+}
+
+func (o ListTasksOptions) query(cursor string) url.Values {
+	q := url.Values{}
+	setIfNotEmpty(q, "status", o.Status)
+	setIfNotEmpty(q, "priority", o.Priority)
+	setIfNotEmpty(q, "assigneeId", o.AssigneeID)
+	setIfNotEmpty(q, "milestoneId", o.MilestoneID)
+	setIfNotEmpty(q, "sprintId", o.SprintID)
+	setIfNotEmpty(q, "epicId", o.EpicID)
+	setIfNotEmpty(q, "q", o.Query)
+	setIfNotEmpty(q, "sort", o.Sort)
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	setIfNotEmpty(q, "cursor", cursor)
+	return q
+}
+
+// TaskPage は GET /api/v1/projects/{projectId}/tasks のレスポンス。
+type TaskPage struct {
+	Tasks []Task    `json:"tasks"`
+	Page  *PageInfo `json:"page"`
+}
+
+// ListProjectTasks はプロジェクトのタスクを 1 ページ取得する（cursor が空の場合は最初のページ）。
+func (c *Client) ListProjectTasks(ctx context.Context, projectID string, opts ListTasksOptions, cursor string) (*TaskPage, error) {
+	var page TaskPage
+	if err := c.do(ctx, http.MethodGet, "/projects/"+escape(projectID)+"/tasks", opts.query(cursor), nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// ProjectTasks はプロジェクトのタスクを、cursor をたどってすべてのページから順に返す。
+func (c *Client) ProjectTasks(ctx context.Context, projectID string, opts ListTasksOptions) iter.Seq2[Task, error] {
+	return paginate(func(cursor string) ([]Task, string, error) {
+		page, err := c.ListProjectTasks(ctx, projectID, opts, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		if page.Page == nil {
+			return page.Tasks, "", nil
+		}
+		return page.Tasks, page.Page.next(), nil
+	})
+}
+
+// CreateTask はプロジェクトにタスクを作成する。
+func (c *Client) CreateTask(ctx context.Context, projectID string, req CreateTaskRequest) (*Task, error) {
+	var t Task
+	if err := c.do(ctx, http.MethodPost, "/projects/"+escape(projectID)+"/tasks", nil, req, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// UpdateTask はタスクを部分更新する。
+func (c *Client) UpdateTask(ctx context.Context, projectID, taskID string, patch TaskPatch) (*Task, error) {
+	var t Task
+	if err := c.do(ctx, http.MethodPatch, "/projects/"+escape(projectID)+"/tasks/"+escape(taskID), nil, patch, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// BatchCreateTasks はプロジェクトにタスクを一括作成する。tasks サービス側で 1 トランザクションで作成される。
+func (c *Client) BatchCreateTasks(ctx context.Context, projectID string, tasks []CreateTaskRequest) ([]Task, error) {
+	in := struct {
+		Tasks []CreateTaskRequest `json:"tasks"`
+	}{Tasks: tasks}
+	var out struct {
+		Tasks []Task `json:"tasks"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/projects/"+escape(projectID)+"/tasks:batch", nil, in, &out); err != nil {
+		return nil, err
+	}
+	return out.Tasks, nil
+}
+
+// ProjectStats は OpenAPI の ProjectTaskStats。
+type ProjectStats struct {
+	ProjectID      string     `json:"projectId"`
+	Open           int        `json:"open"`
+	Done           int        `json:"done"`
+	Overdue        int        `json:"overdue"`
+	LastActivityAt *time.Time `json:"lastActivityAt"`
+}
+
+// ProjectTaskStats はプロジェクトのタスクの集計を取得する。
+func (c *Client) ProjectTaskStats(ctx context.Context, projectID string) (*ProjectStats, error) {
+	var s ProjectStats
+	if err := c.do(ctx, http.MethodGet, "/projects/"+escape(projectID)+"/tasks/stats", nil, nil, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// BatchProjectStats は複数プロジェクトのタスクの集計を 1 回のリクエストで取得する（重複を除いた projectIDs の順）。
+func (c *Client) BatchProjectStats(ctx context.Context, projectIDs []string) ([]ProjectStats, error) {
+	in := struct {
+		ProjectIDs []string `json:"projectIds"`
+	}{ProjectIDs: projectIDs}
+	var out struct {
+		Stats []ProjectStats `json:"stats"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/tasks:stats", nil, in, &out); err != nil {
+		return nil, err
+	}
+	return out.Stats, nil
+}
+
+// MilestoneTaskStats はマイルストーンごとのタスク数。
+type MilestoneTaskStats struct {
+	MilestoneID string `json:"milestoneId"`
+	Open        int    `json:"open"`
+	Done        int    `json:"done"`
+}
+
+// MilestoneTaskStats はプロジェクトのタスクをマイルストーンごとに集計する（タスクのあるマイルストーンのみ）。
+func (c *Client) MilestoneTaskStats(ctx context.Context, projectID string) ([]MilestoneTaskStats, error) {
+	var out struct {
+		Milestones []MilestoneTaskStats `json:"milestones"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/projects/"+escape(projectID)+"/tasks/stats/milestones", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Milestones, nil
+}
+
+// EpicTaskStats はエピックごとのタスク数と見積もりの合計。
+type EpicTaskStats struct {
+	EpicID        string `json:"epicId"`
+	Total         int    `json:"total"`
+	Done          int    `json:"done"`
+	EstimateTotal int    `json:"estimateTotal"`
+	EstimateDone  int    `json:"estimateDone"`
+}
+
+// EpicTaskStats はプロジェクトのタスクをエピックごとに集計する（タスクのあるエピックのみ）。
+func (c *Client) EpicTaskStats(ctx context.Context, projectID string) ([]EpicTaskStats, error) {
+	var out struct {
+		Epics []EpicTaskStats `json:"epics"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/projects/"+escape(projectID)+"/tasks/stats/epics", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Epics, nil
+}
+
+// LabelTaskStats はラベルごとのタスク数。
+type LabelTaskStats struct {
+	LabelID string `json:"labelId"`
+	Count   int    `json:"count"`
+}
+
+// LabelTaskStats はプロジェクトのタスクをラベルごとに数える（タスクに付いているラベルのみ）。
+func (c *Client) LabelTaskStats(ctx context.Context, projectID string) ([]LabelTaskStats, error) {
+	var out struct {
+		Labels []LabelTaskStats `json:"labels"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/projects/"+escape(projectID)+"/tasks/stats/labels", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Labels, nil
+}
+
+// ArchiveTasks はプロジェクトのタスクをアーカイブし、対象になった件数を返す。
+func (c *Client) ArchiveTasks(ctx context.Context, projectID string) (int, error) {
+	return c.cascadeTasks(ctx, projectID, "archive")
+}
+
+// UnarchiveTasks はプロジェクトのアーカイブしたタスクを戻し、対象になった件数を返す。
+func (c *Client) UnarchiveTasks(ctx context.Context, projectID string) (int, error) {
+	return c.cascadeTasks(ctx, projectID, "unarchive")
+}
+
+// DeleteTasks はプロジェクトのタスクを削除し、対象になった件数を返す。
+func (c *Client) DeleteTasks(ctx context.Context, projectID string) (int, error) {
+	return c.cascadeTasks(ctx, projectID, "delete")
+}
+
+// cascadeTasks は POST /api/v1/projects/{id}/tasks:{action} を呼ぶ。対象のタスクが無い場合も成功する。
+func (c *Client) cascadeTasks(ctx context.Context, projectID, action string) (int, error) {
+	var out struct {
+		Count int `json:"count"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/projects/"+escape(projectID)+"/tasks:"+action, nil, nil, &out); err != nil {
+		return 0, err
+	}
+	return out.Count, nil
+}
+
+// CarryOverSprintTasks はスプリントの未完了タスクを toSprintID（空の場合はバックログ）へ移し、移した件数を返す。
+func (c *Client) CarryOverSprintTasks(ctx context.Context, projectID, fromSprintID, toSprintID string) (int, error) {
+	in := struct {
+		FromSprintID string  `json:"fromSprintId"`
+		ToSprintID   *string `json:"toSprintId"` // null はバックログ
+	}{FromSprintID: fromSprintID}
+	if toSprintID != "" {
+		in.ToSprintID = &toSprintID
+	}
+	var out struct {
+		Count int `json:"count"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/projects/"+escape(projectID)+"/tasks:carry-over", nil, in, &out); err != nil {
+		return 0, err
+	}
+	return out.Count, nil
+}
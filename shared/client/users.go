@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// User は OpenAPI の User。
+type User struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	AvatarURL *string   `json:"avatarUrl"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	// NotificationPreferences は LookupUsersWithPreferences の結果にだけ含まれる
+	NotificationPreferences *NotificationPreferences `json:"notificationPreferences,omitempty"`
+}
+
+// NotificationPreferences は OpenAPI の NotificationPreferences（通知の設定）。UpdatedAt は保存していない（既定の設定の）場合は nil。
+type NotificationPreferences struct {
+	EmailOnAssignment bool       `json:"emailOnAssignment"`
+	EmailOnDueSoon    bool       `json:"emailOnDueSoon"`
+	UpdatedAt         *time.Time `json:"updatedAt"`
+}
+
+// GetUser はユーザーを取得する。存在しない場合は 404 の *APIError を返す。
+func (c *Client) GetUser(ctx context.Context, userID string) (*User, error) {
+	var u User
+	if err := c.do(ctx, http.MethodGet, "/users/"+escape(userID), nil, nil, &u); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// UserLookup は LookupUsers の結果。Users・MissingIDs はどちらも指定した ids の順。
+type UserLookup struct {
+	Users      []User   `json:"users"`
+	MissingIDs []string `json:"missingIds"`
+}
+
+// LookupUsers は複数のユーザーを 1 回のリクエストで取得する（users サービスのサービス間専用のエンドポイント）。
+// 存在しない ID はエラーにせず MissingIDs で返す。
+func (c *Client) LookupUsers(ctx context.Context, ids []string) (*UserLookup, error) {
+	return c.lookupUsers(ctx, ids, nil)
+}
+
+// LookupUsersWithPreferences は LookupUsers と同じく複数のユーザーを取得し、各ユーザーの NotificationPreferences も含める。
+// 通知のメールを送る前に、宛先と通知を受け取るかどうかをまとめて確認するのに使う。
+func (c *Client) LookupUsersWithPreferences(ctx context.Context, ids []string) (*UserLookup, error) {
+	return c.lookupUsers(ctx, ids, url.Values{"expand": {"notificationPreferences"}})
+}
+
+func (c *Client) lookupUsers(ctx context.Context, ids []string, query url.Values) (*UserLookup, error) {
+	in := struct {
+		IDs []string `json:"ids"`
+	}{IDs: ids}
+	var out UserLookup
+	if err := c.do(ctx, http.MethodPost, "/users:lookup", query, in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetNotificationPreferences は userID の通知の設定を取得する。
+// 操作者（WithActor）が userID 本人でない場合は 403 の *APIError を返す。
+func (c *Client) GetNotificationPreferences(ctx context.Context, userID string) (*NotificationPreferences, error) {
+	var out NotificationPreferences
+	if err := c.do(ctx, http.MethodGet, "/users/"+escape(userID)+"/notification-preferences", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateNotificationPreferencesRequest は OpenAPI の NotificationPreferencesUpdateRequest。nil の項目は変更しない。
+type UpdateNotificationPreferencesRequest struct {
+	EmailOnAssignment *bool `json:"emailOnAssignment,omitempty"`
+	EmailOnDueSoon    *bool `json:"emailOnDueSoon,omitempty"`
+}
+
+// UpdateNotificationPreferences は userID の通知の設定を更新し、更新後の設定を返す。
+func (c *Client) UpdateNotificationPreferences(ctx context.Context, userID string, req UpdateNotificationPreferencesRequest) (*NotificationPreferences, error) {
+	var out NotificationPreferences
+	if err := c.do(ctx, http.MethodPatch, "/users/"+escape(userID)+"/notification-preferences", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AccessToken は OpenAPI の AccessToken（個人用アクセストークン）。Token は発行時のレスポンスにだけ含まれる。
+type AccessToken struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	Token      string     `json:"token,omitempty"`
+}
+
+// CreateAccessTokenRequest は OpenAPI の AccessTokenCreateRequest。
+type CreateAccessTokenRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// CreateAccessToken は userID の個人用アクセストークンを発行する。返り値の Token（平文）は再取得できない。
+// 操作者（WithActor）が userID 本人でない場合は 403 の *APIError を返す。
+func (c *Client) CreateAccessToken(ctx context.Context, userID string, req CreateAccessTokenRequest) (*AccessToken, error) {
+	var out AccessToken
+	if err := c.do(ctx, http.MethodPost, "/users/"+escape(userID)+"/tokens", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListAccessTokens は userID の個人用アクセストークン（失効したものを含む）を発行の新しい順に返す。
+func (c *Client) ListAccessTokens(ctx context.Context, userID string) ([]AccessToken, error) {
+	var out struct {
+		Tokens []AccessToken `json:"tokens"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/users/"+escape(userID)+"/tokens", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Tokens, nil
+}
+
+// RevokeAccessToken は userID の個人用アクセストークンを失効させる。失効済みの場合も成功する。
+func (c *Client) RevokeAccessToken(ctx context.Context, userID, tokenID string) error {
+	return c.do(ctx, http.MethodDelete, "/users/"+escape(userID)+"/tokens/"+escape(tokenID), nil, nil, nil)
+}
+
+// TokenIntrospection は IntrospectToken の結果。Active が false の場合、ほかのフィールドは空。
+type TokenIntrospection struct {
+	Active      bool       `json:"active"`
+	UserID      string     `json:"userId,omitempty"`
+	WorkspaceID string     `json:"workspaceId,omitempty"`
+	Scopes      []string   `json:"scopes,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+}
+
+// IntrospectToken は個人用アクセストークンが有効かどうかと持ち主を問い合わせる（users サービスのサービス間専用のエンドポイント）。
+// 存在しない・失効した・期限切れのトークンはエラーにせず Active=false で返す。
+func (c *Client) IntrospectToken(ctx context.Context, token string) (*TokenIntrospection, error) {
+	in := struct {
+		Token string `json:"token"`
+	}{Token: token}
+	var out TokenIntrospection
+	if err := c.do(ctx, http.MethodPost, "/tokens:introspect", nil, in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,73 @@
+// Package clock は現在時刻の取得を抽象化する。
+//
+// ハンドラ・ユースケース・キャッシュは time.Now を直接呼ばずに Clock を受け取り、
+// テストでは Fixed / Fake を渡して時刻を固定する。
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock は現在時刻を返す。
+type Clock interface {
+	Now() time.Time
+}
+
+// Func は関数を Clock として使うためのアダプタ。
+type Func func() time.Time
+
+// Now は f() を返す。
+func (f Func) Now() time.Time { return f() }
+
+// System は time.Now を返す Clock。
+var System Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// OrSystem は c が nil の場合に System を返す。任意の Clock を受け取る構造体の既定値に使う。
+func OrSystem(c Clock) Clock {
+	if c == nil {
+		return System
+	}
+	return c
+}
+
+// Fixed は常に t を返す Clock。
+func Fixed(t time.Time) Clock {
+	return Func(func() time.Time { return t })
+}
+
+// Fake はテストで時刻を進められる Clock。並行に使ってよい。
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFake は t を現在時刻とする Fake を生成する。
+func NewFake(t time.Time) *Fake {
+	return &Fake{now: t}
+}
+
+// Now は現在時刻を返す。
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Set は現在時刻を t にする。
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = t
+}
+
+// Advance は現在時刻を d だけ進める。
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
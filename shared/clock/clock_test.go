@@ -0,0 +1,39 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"teamflow-shared/clock"
+)
+
+func TestFixed(t *testing.T) {
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	c := clock.Fixed(now)
+	if !c.Now().Equal(now) || !c.Now().Equal(now) {
+		t.Errorf("Fixed().Now() = %s, want %s", c.Now(), now)
+	}
+}
+
+func TestFake(t *testing.T) {
+	start := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	c := clock.NewFake(start)
+	c.Advance(90 * time.Minute)
+	if want := start.Add(90 * time.Minute); !c.Now().Equal(want) {
+		t.Errorf("after Advance: %s, want %s", c.Now(), want)
+	}
+	c.Set(start)
+	if !c.Now().Equal(start) {
+		t.Errorf("after Set: %s, want %s", c.Now(), start)
+	}
+}
+
+func TestOrSystem(t *testing.T) {
+	if clock.OrSystem(nil) != clock.System {
+		t.Error("OrSystem(nil) should return System")
+	}
+	fixed := clock.Fixed(time.Unix(0, 0))
+	if got := clock.OrSystem(fixed).Now(); !got.Equal(time.Unix(0, 0)) {
+		t.Errorf("OrSystem(fixed).Now() = %s", got)
+	}
+}
@@ -0,0 +1,260 @@
+// Package config は tasks / projects サービスで共通の設定の読み込みと検証を提供する。
+//
+// 値は環境変数から読み込む。CONFIG_FILE で KEY=VALUE 形式のファイルを指定すると、
+// 環境変数に無い値をファイルから補う（環境変数が優先）。
+//
+// 各サービスは Parser で型付きの値を読み込み、不正な値はすべてまとめて 1 つのエラーとして返す。
+// 起動時に設定の誤りをすべて把握して、すぐに終了できるようにするため。
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FileKey は設定ファイルのパスを指定する環境変数。
+const FileKey = "CONFIG_FILE"
+
+// MinSecretLength は本番環境で署名用のシークレットに求める最小の長さ（バイト）。
+// HMAC-SHA256 の鍵として十分な長さにするため（例: openssl rand -base64 32 で生成する）。
+const MinSecretLength = 32
+
+// Load は getenv に CONFIG_FILE のファイルの値を補った読み込み関数を返す。
+// CONFIG_FILE が未設定の場合は getenv をそのまま返す。
+func Load(getenv func(string) string) (func(string) string, error) {
+	path := getenv(FileKey)
+	if path == "" {
+		return getenv, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", FileKey, err)
+	}
+	defer f.Close()
+
+	values, err := ParseFile(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", FileKey, path, err)
+	}
+	return func(key string) string {
+		if v := getenv(key); v != "" {
+			return v
+		}
+		return values[key]
+	}, nil
+}
+
+// ParseFile は KEY=VALUE 形式（.env と同じ）の設定を読み込む。
+// 空行と # で始まる行は無視し、値を囲む " または ' は取り除く。export KEY=VALUE も受け付ける。
+func ParseFile(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", n)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// Parser は設定値を型付きで読み込み、不正な値のエラーを集める。
+// 各メソッドは不正な値の場合にエラーを記録して既定値を返す。最後に Err でまとめて確認する。
+type Parser struct {
+	getenv func(string) string
+	errs   []error
+}
+
+// NewParser は getenv から読み込む Parser を生成する。
+func NewParser(getenv func(string) string) *Parser {
+	return &Parser{getenv: getenv}
+}
+
+// Get は key の値をそのまま返す。
+func (p *Parser) Get(key string) string {
+	return p.getenv(key)
+}
+
+// Add はエラーを記録する（サービス固有の検証用）。nil は無視する。
+func (p *Parser) Add(err error) {
+	if err != nil {
+		p.errs = append(p.errs, err)
+	}
+}
+
+// Errorf は書式付きのエラーを記録する。
+func (p *Parser) Errorf(format string, args ...any) {
+	p.errs = append(p.errs, fmt.Errorf(format, args...))
+}
+
+// Err は記録したエラーをまとめて返す。無ければ nil。
+func (p *Parser) Err() error {
+	if len(p.errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid configuration: %w", errors.Join(p.errs...))
+}
+
+// String は key の値を返す。未設定の場合は def。
+func (p *Parser) String(key, def string) string {
+	if v := p.getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// Required は key の値を返す。未設定の場合は why（必要な理由）を含むエラーを記録する。
+func (p *Parser) Required(key, why string) string {
+	v := p.getenv(key)
+	if v == "" {
+		p.Errorf("%s must be set (%s)", key, why)
+	}
+	return v
+}
+
+// Bool は key の値を真偽値として返す。
+func (p *Parser) Bool(key string, def bool) bool {
+	v := p.getenv(key)
+	if v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		p.Errorf("%s must be true or false, got %q", key, v)
+		return def
+	}
+	return b
+}
+
+// Port は key の値を listen ポート（1〜65535）として返す。
+func (p *Parser) Port(key string, def int) int {
+	v := p.getenv(key)
+	if v == "" {
+		return def
+	}
+	port, err := strconv.Atoi(v)
+	if err != nil || port < 1 || port > 65535 {
+		p.Errorf("%s must be an integer between 1 and 65535, got %q", key, v)
+		return def
+	}
+	return port
+}
+
+// NonNegativeInt は key の値を 0 以上の整数として返す。
+func (p *Parser) NonNegativeInt(key string, def int) int {
+	v := p.getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		p.Errorf("%s must be a non-negative integer, got %q", key, v)
+		return def
+	}
+	return n
+}
+
+// PositiveInt32 は key の値を正の int32 として返す。未設定の場合は 0。
+func (p *Parser) PositiveInt32(key string) int32 {
+	v := p.getenv(key)
+	if v == "" {
+		return 0
+	}
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil || n < 1 {
+		p.Errorf("%s must be a positive integer, got %q", key, v)
+		return 0
+	}
+	return int32(n)
+}
+
+// Ratio は key の値を 0〜1 の数値として返す。
+func (p *Parser) Ratio(key string, def float64) float64 {
+	v := p.getenv(key)
+	if v == "" {
+		return def
+	}
+	r, err := strconv.ParseFloat(v, 64)
+	if err != nil || r < 0 || r > 1 {
+		p.Errorf("%s must be a number between 0 and 1, got %q", key, v)
+		return def
+	}
+	return r
+}
+
+// Duration は key の値を正の時間（例: 30s）として返す。
+func (p *Parser) Duration(key string, def time.Duration) time.Duration {
+	v := p.getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		p.Errorf("%s must be a positive duration (e.g. %s), got %q", key, example(def), v)
+		return def
+	}
+	return d
+}
+
+// NonNegativeDuration は key の値を 0 以上の時間として返す（0 は無効化などに使う）。
+func (p *Parser) NonNegativeDuration(key string, def time.Duration) time.Duration {
+	v := p.getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		p.Errorf("%s must be a non-negative duration (e.g. %s), got %q", key, example(def), v)
+		return def
+	}
+	return d
+}
+
+// URL は key の値を絶対 URL（http / https）として返す。未設定の場合は空文字。
+func (p *Parser) URL(key string) string {
+	v := p.getenv(key)
+	if v == "" {
+		return ""
+	}
+	u, err := url.Parse(v)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		p.Errorf("%s must be an absolute http(s) URL, got %q", key, v)
+	}
+	return v
+}
+
+// example はエラーメッセージの例に使う時間（720h0m0s は 720h）を返す。
+func example(def time.Duration) string {
+	if def <= 0 {
+		return "5s"
+	}
+	s := def.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
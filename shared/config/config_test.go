@@ -0,0 +1,135 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"teamflow-shared/config"
+)
+
+func mapEnv(env map[string]string) func(string) string {
+	return func(key string) string { return env[key] }
+}
+
+func TestParseFile(t *testing.T) {
+	values, err := config.ParseFile(strings.NewReader(`
+# コメント
+DB_DSN=postgres://localhost:5432/app
+export LOG_LEVEL = debug
+CURSOR_SECRET="quoted value"
+EMPTY=
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"DB_DSN":        "postgres://localhost:5432/app",
+		"LOG_LEVEL":     "debug",
+		"CURSOR_SECRET": "quoted value",
+		"EMPTY":         "",
+	}
+	if len(values) != len(want) {
+		t.Fatalf("expected %d values, got %v", len(want), values)
+	}
+	for k, v := range want {
+		if values[k] != v {
+			t.Errorf("%s = %q, want %q", k, values[k], v)
+		}
+	}
+
+	if _, err := config.ParseFile(strings.NewReader("A=1\nnot a pair\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected an error with the line number, got %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.env")
+	if err := os.WriteFile(path, []byte("PORT=9000\nLOG_LEVEL=debug\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	getenv, err := config.Load(mapEnv(map[string]string{config.FileKey: path, "PORT": "8000"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := getenv("PORT"); got != "8000" {
+		t.Errorf("expected the environment to take precedence, got %q", got)
+	}
+	if got := getenv("LOG_LEVEL"); got != "debug" {
+		t.Errorf("expected the value from the file, got %q", got)
+	}
+
+	if _, err := config.Load(mapEnv(map[string]string{config.FileKey: filepath.Join(t.TempDir(), "missing.env")})); err == nil || !strings.Contains(err.Error(), config.FileKey) {
+		t.Errorf("expected an error for a missing file, got %v", err)
+	}
+}
+
+func TestParser(t *testing.T) {
+	p := config.NewParser(mapEnv(map[string]string{
+		"NAME":     "tasks",
+		"ENABLED":  "true",
+		"PORT":     "9000",
+		"SIZE":     "10",
+		"CONNS":    "4",
+		"RATIO":    "0.5",
+		"TIMEOUT":  "3s",
+		"TTL":      "0",
+		"BASE_URL": "http://projects:8080",
+	}))
+	if got := p.String("NAME", "x"); got != "tasks" {
+		t.Errorf("String = %q", got)
+	}
+	if got := p.String("MISSING", "x"); got != "x" {
+		t.Errorf("String default = %q", got)
+	}
+	if !p.Bool("ENABLED", false) || p.Port("PORT", 1) != 9000 || p.NonNegativeInt("SIZE", 0) != 10 ||
+		p.PositiveInt32("CONNS") != 4 || p.Ratio("RATIO", 1) != 0.5 || p.Duration("TIMEOUT", time.Second) != 3*time.Second ||
+		p.NonNegativeDuration("TTL", time.Minute) != 0 || p.URL("BASE_URL") != "http://projects:8080" {
+		t.Error("unexpected parsed values")
+	}
+	if err := p.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestParser_CollectsAllErrors(t *testing.T) {
+	p := config.NewParser(mapEnv(map[string]string{
+		"ENABLED":  "maybe",
+		"PORT":     "70000",
+		"SIZE":     "-1",
+		"CONNS":    "0",
+		"RATIO":    "2",
+		"TIMEOUT":  "0s",
+		"TTL":      "-1m",
+		"BASE_URL": "projects:8080",
+	}))
+	if got := p.Duration("TIMEOUT", 720*time.Hour); got != 720*time.Hour {
+		t.Errorf("expected the default on error, got %v", got)
+	}
+	p.Bool("ENABLED", false)
+	p.Port("PORT", 8080)
+	p.NonNegativeInt("SIZE", 0)
+	p.PositiveInt32("CONNS")
+	p.Ratio("RATIO", 1)
+	p.NonNegativeDuration("TTL", 0)
+	p.URL("BASE_URL")
+	p.Required("DB_DSN", "required in production")
+
+	err := p.Err()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{
+		"invalid configuration",
+		"ENABLED", "PORT", "SIZE", "CONNS", "RATIO", "TTL", "BASE_URL",
+		"TIMEOUT must be a positive duration (e.g. 720h)",
+		"DB_DSN must be set (required in production)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got %q", want, err.Error())
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RepoBackend はリポジトリの実装（REPO_BACKEND）。
+type RepoBackend string
+
+const (
+	// BackendMemory はこのプロセスのメモリに保存する（再起動でデータが消えるため、開発・テスト用）
+	BackendMemory RepoBackend = "memory"
+	// BackendPostgres は DB_DSN の PostgreSQL に保存する
+	BackendPostgres RepoBackend = "postgres"
+)
+
+// RepoBackend は REPO_BACKEND と dsn（DB_DSN）からリポジトリの実装を選び、組み合わせを検証する。
+// REPO_BACKEND が未設定の場合は dsn があれば BackendPostgres、無ければ BackendMemory。
+// production では再起動でデータが消えるインメモリを使えない。
+func (p *Parser) RepoBackend(dsn string, production bool) RepoBackend {
+	backend := BackendMemory
+	if dsn != "" {
+		backend = BackendPostgres
+	}
+	switch v := RepoBackend(strings.ToLower(strings.TrimSpace(p.getenv("REPO_BACKEND")))); v {
+	case "":
+	case BackendMemory, BackendPostgres:
+		backend = v
+	default:
+		p.Errorf("REPO_BACKEND must be memory or postgres, got %q", p.getenv("REPO_BACKEND"))
+		return backend
+	}
+
+	switch {
+	case backend == BackendPostgres && dsn == "":
+		p.Required("DB_DSN", "REPO_BACKEND=postgres stores data in PostgreSQL")
+	case backend == BackendPostgres:
+		if _, err := pgxpool.ParseConfig(dsn); err != nil {
+			p.Errorf("DB_DSN is invalid: %w", err)
+		}
+	case dsn != "":
+		// DB_DSN を設定したのにインメモリで起動すると、保存したつもりのデータが再起動で消える
+		p.Errorf("DB_DSN is set but REPO_BACKEND=memory ignores it; unset DB_DSN or use REPO_BACKEND=postgres")
+	case production && p.getenv("REPO_BACKEND") != "":
+		p.Errorf("REPO_BACKEND=memory cannot be used in production (in-memory repositories lose data on restart)")
+	case production:
+		// インメモリのリポジトリは再起動でデータが消えるため、本番では使わない
+		p.Required("DB_DSN", "in-memory repositories lose data on restart; set a PostgreSQL connection string in production")
+	}
+	return backend
+}
+
+// DBTarget は接続先（host:port/database）を返す。パスワードを含めずにログ・エラーに出すために使う。
+func DBTarget(pc *pgxpool.Config) string {
+	return fmt.Sprintf("%s:%d/%s", pc.ConnConfig.Host, pc.ConnConfig.Port, pc.ConnConfig.Database)
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// placeholderCursorSecret は設定例に載せているプレースホルダ。本番環境では使えない。
+const placeholderCursorSecret = "default-secret-change-in-production"
+
+// #nosec G101 -- これは本物のクレデンシャルではなく、開発環境のみで使用されるプレースホルダー
+const devCursorSecret = "dev-only-secret-change-me"
+
+// ResolveCursorSecret は APP_ENV（appEnv）に応じて CURSOR_SECRET（raw）を検証する。
+// 本番環境（production）では空・プレースホルダ・MinSecretLength 未満の値をエラーにする。
+// 開発・テスト環境では、未設定の場合に警告を出して開発用の値を使う。
+func ResolveCursorSecret(appEnv, raw string) ([]byte, error) {
+	if appEnv == "production" {
+		if raw == "" {
+			return nil, errors.New("CURSOR_SECRET must be set in production")
+		}
+		if raw == placeholderCursorSecret {
+			return nil, errors.New("CURSOR_SECRET must not be the placeholder value in production")
+		}
+		if len(raw) < MinSecretLength {
+			return nil, fmt.Errorf("CURSOR_SECRET must be at least %d bytes in production, got %d (generate one with: openssl rand -base64 32)",
+				MinSecretLength, len(raw))
+		}
+		return []byte(raw), nil
+	}
+
+	if raw == "" {
+		slog.Warn("CURSOR_SECRET is not set, using dev default secret (not for production)")
+		return []byte(devCursorSecret), nil
+	}
+	return []byte(raw), nil
+}
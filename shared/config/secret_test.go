@@ -1,8 +1,10 @@
-package main
+package config_test
 
 import (
 	"bytes"
 	"testing"
+
+	"teamflow-shared/config"
 )
 
 func TestResolveCursorSecret(t *testing.T) {
@@ -29,10 +31,17 @@ func TestResolveCursorSecret(t *testing.T) {
 			wantErr:    true,
 		},
 		{
-			name:       "production with valid secret should succeed",
+			name:       "production with short secret should fail",
 			appEnv:     "production",
 			rawSecret:  "valid-secret",
-			wantSecret: []byte("valid-secret"),
+			wantSecret: nil,
+			wantErr:    true,
+		},
+		{
+			name:       "production with valid secret should succeed",
+			appEnv:     "production",
+			rawSecret:  "0123456789abcdef0123456789abcdef",
+			wantSecret: []byte("0123456789abcdef0123456789abcdef"),
 			wantErr:    false,
 		},
 
@@ -41,21 +50,21 @@ func TestResolveCursorSecret(t *testing.T) {
 			name:       "empty APP_ENV with empty secret should use dev default",
 			appEnv:     "",
 			rawSecret:  "",
-			wantSecret: []byte(devDefaultSecret),
+			wantSecret: []byte("dev-only-secret-change-me"),
 			wantErr:    false,
 		},
 		{
 			name:       "development with empty secret should use dev default",
 			appEnv:     "development",
 			rawSecret:  "",
-			wantSecret: []byte(devDefaultSecret),
+			wantSecret: []byte("dev-only-secret-change-me"),
 			wantErr:    false,
 		},
 		{
 			name:       "test with empty secret should use dev default",
 			appEnv:     "test",
 			rawSecret:  "",
-			wantSecret: []byte(devDefaultSecret),
+			wantSecret: []byte("dev-only-secret-change-me"),
 			wantErr:    false,
 		},
 		{
@@ -69,7 +78,7 @@ func TestResolveCursorSecret(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotSecret, err := resolveCursorSecret(tt.appEnv, tt.rawSecret)
+			gotSecret, err := config.ResolveCursorSecret(tt.appEnv, tt.rawSecret)
 
 			if tt.wantErr {
 				if err == nil {
@@ -0,0 +1,149 @@
+// Package cors はブラウザからの別オリジンのリクエスト（CORS）を許可するミドルウェアを提供する。
+//
+// 許可するオリジン・メソッド・ヘッダは Options で指定する（各サービスは環境変数から読み込む）。
+// プリフライト（OPTIONS + Access-Control-Request-Method）はこのミドルウェアで応答し、後続のハンドラには渡さない。
+package cors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Options は許可する CORS の設定。
+type Options struct {
+	// AllowedOrigins は許可するオリジン（例: https://app.example.com）。"*" はすべてのオリジン
+	AllowedOrigins []string
+	// AllowedMethods はプリフライトで許可するメソッド
+	AllowedMethods []string
+	// AllowedHeaders はプリフライトで許可するリクエストヘッダ（大文字小文字は区別しない）
+	AllowedHeaders []string
+	// ExposedHeaders はブラウザのスクリプトから読めるレスポンスヘッダ
+	ExposedHeaders []string
+	// AllowCredentials は Cookie・Authorization 付きのリクエストを許可するかどうか
+	AllowCredentials bool
+	// MaxAge はプリフライトの結果をブラウザがキャッシュする時間（0 の場合はヘッダを付けない）
+	MaxAge time.Duration
+}
+
+// Validate は設定を検証する。
+// オリジンは "*" か、パスを含まない http(s) の URL であること。
+// 資格情報付きのリクエストでは "*" を使えない（ブラウザが拒否する）ため、AllowCredentials との併用はエラーにする。
+func (o Options) Validate() error {
+	var errs []error
+	for _, origin := range o.AllowedOrigins {
+		if origin == "*" {
+			if o.AllowCredentials {
+				errs = append(errs, errors.New(`origin "*" cannot be used with credentials`))
+			}
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			(u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+			errs = append(errs, fmt.Errorf("origin must be a scheme://host[:port] URL, got %q", origin))
+		}
+	}
+	if o.MaxAge < 0 {
+		errs = append(errs, fmt.Errorf("max age must not be negative, got %s", o.MaxAge))
+	}
+	return errors.Join(errs...)
+}
+
+// SplitList はカンマ区切りの値を分割する。前後の空白と空の要素は取り除く。
+func SplitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Middleware は opts に従って CORS のヘッダを付ける。
+//
+// 許可していないオリジンからのリクエストには CORS のヘッダを付けない（ブラウザがレスポンスを読ませない）。
+// プリフライトは許可している場合は 204、許可していないオリジン・メソッドの場合は 403 を返す。
+func Middleware(opts Options, next http.Handler) http.Handler {
+	allowAll := false
+	origins := make(map[string]bool, len(opts.AllowedOrigins))
+	for _, o := range opts.AllowedOrigins {
+		if o == "*" {
+			allowAll = true
+		}
+		origins[strings.TrimSuffix(o, "/")] = true
+	}
+	methods := make(map[string]bool, len(opts.AllowedMethods))
+	for _, m := range opts.AllowedMethods {
+		methods[strings.ToUpper(m)] = true
+	}
+	allowMethods := strings.Join(opts.AllowedMethods, ", ")
+	allowHeaders := strings.Join(opts.AllowedHeaders, ", ")
+	exposeHeaders := strings.Join(opts.ExposedHeaders, ", ")
+	maxAge := ""
+	if opts.MaxAge > 0 {
+		maxAge = strconv.Itoa(int(opts.MaxAge.Seconds()))
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		origin := r.Header.Get("Origin")
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+		// レスポンスがオリジンによって変わるため、キャッシュに区別させる
+		h.Add("Vary", "Origin")
+		if preflight {
+			h.Add("Vary", "Access-Control-Request-Method")
+			h.Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		allowed := allowAll || origins[origin]
+		if !allowed {
+			if preflight {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if allowAll && !opts.AllowCredentials {
+			h.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			h.Set("Access-Control-Allow-Origin", origin)
+		}
+		if opts.AllowCredentials {
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if exposeHeaders != "" {
+				h.Set("Access-Control-Expose-Headers", exposeHeaders)
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !methods[strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))] {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		h.Set("Access-Control-Allow-Methods", allowMethods)
+		if allowHeaders != "" {
+			h.Set("Access-Control-Allow-Headers", allowHeaders)
+		}
+		if maxAge != "" {
+			h.Set("Access-Control-Max-Age", maxAge)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,166 @@
+package cors_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"teamflow-shared/cors"
+)
+
+func testOptions() cors.Options {
+	return cors.Options{
+		AllowedOrigins:   []string{"https://app.example.com"},
+		AllowedMethods:   []string{"GET", "POST", "PATCH"},
+		AllowedHeaders:   []string{"Content-Type", "X-User-ID"},
+		ExposedHeaders:   []string{"X-Request-ID"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+}
+
+// nextHandler は後続のハンドラが呼ばれたことを 200 で返す。
+var nextHandler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func TestMiddleware_CredentialedRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/projects", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Cookie", "session=abc")
+	w := httptest.NewRecorder()
+	cors.Middleware(testOptions(), nextHandler).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected the next handler to run, got %d", w.Code)
+	}
+	for key, want := range map[string]string{
+		"Access-Control-Allow-Origin":      "https://app.example.com",
+		"Access-Control-Allow-Credentials": "true",
+		"Access-Control-Expose-Headers":    "X-Request-ID",
+		"Vary":                             "Origin",
+	} {
+		if got := w.Header().Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+	if got := w.Header().Get("Access-Control-Allow-Methods"); got != "" {
+		t.Errorf("expected no Allow-Methods on a simple request, got %q", got)
+	}
+}
+
+func TestMiddleware_Preflight(t *testing.T) {
+	tests := []struct {
+		name       string
+		origin     string
+		method     string
+		wantStatus int
+		wantOrigin string
+	}{
+		{name: "allowed", origin: "https://app.example.com", method: "PATCH", wantStatus: http.StatusNoContent, wantOrigin: "https://app.example.com"},
+		{name: "method not allowed", origin: "https://app.example.com", method: "DELETE", wantStatus: http.StatusForbidden, wantOrigin: "https://app.example.com"},
+		{name: "origin not allowed", origin: "https://evil.example.com", method: "PATCH", wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			next := http.HandlerFunc(func(http.ResponseWriter, *http.Request) { called = true })
+
+			req := httptest.NewRequest(http.MethodOptions, "/api/tasks/t-1", nil)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", tt.method)
+			req.Header.Set("Access-Control-Request-Headers", "content-type,x-user-id")
+			w := httptest.NewRecorder()
+			cors.Middleware(testOptions(), next).ServeHTTP(w, req)
+
+			if called {
+				t.Fatal("expected the preflight not to reach the next handler")
+			}
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+			if tt.wantStatus != http.StatusNoContent {
+				return
+			}
+			for key, want := range map[string]string{
+				"Access-Control-Allow-Methods":     "GET, POST, PATCH",
+				"Access-Control-Allow-Headers":     "Content-Type, X-User-ID",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Max-Age":           "600",
+			} {
+				if got := w.Header().Get(key); got != want {
+					t.Errorf("%s = %q, want %q", key, got, want)
+				}
+			}
+			if got := w.Header().Values("Vary"); len(got) != 3 {
+				t.Errorf("expected Vary for origin and request method/headers, got %v", got)
+			}
+		})
+	}
+}
+
+func TestMiddleware_DisallowedOriginPassesThrough(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/projects", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	w := httptest.NewRecorder()
+	cors.Middleware(testOptions(), nextHandler).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected the next handler to run, got %d", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected no Allow-Origin, got %q", got)
+	}
+}
+
+func TestMiddleware_Wildcard(t *testing.T) {
+	opts := cors.Options{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET"}}
+	req := httptest.NewRequest(http.MethodGet, "/api/projects", nil)
+	req.Header.Set("Origin", "https://any.example.com")
+	w := httptest.NewRecorder()
+	cors.Middleware(opts, nextHandler).ServeHTTP(w, req)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Allow-Origin = %q, want *", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("expected no Allow-Credentials, got %q", got)
+	}
+}
+
+func TestOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    cors.Options
+		wantErr bool
+	}{
+		{name: "valid", opts: testOptions()},
+		{name: "wildcard without credentials", opts: cors.Options{AllowedOrigins: []string{"*"}}},
+		{name: "wildcard with credentials", opts: cors.Options{AllowedOrigins: []string{"*"}, AllowCredentials: true}, wantErr: true},
+		{name: "origin with path", opts: cors.Options{AllowedOrigins: []string{"https://app.example.com/app"}}, wantErr: true},
+		{name: "origin without scheme", opts: cors.Options{AllowedOrigins: []string{"app.example.com"}}, wantErr: true},
+		{name: "negative max age", opts: cors.Options{MaxAge: -time.Second}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	got := cors.SplitList(" GET, POST ,,PATCH ")
+	if want := []string{"GET", "POST", "PATCH"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitList() = %v, want %v", got, want)
+	}
+	if got := cors.SplitList(""); got != nil {
+		t.Errorf("SplitList(\"\") = %v, want nil", got)
+	}
+}
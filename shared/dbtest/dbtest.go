@@ -0,0 +1,89 @@
+//go:build integration
+// +build integration
+
+// Package dbtest は各サービスの統合テスト（-tags=integration）が共有する DB の接続を提供する。
+//
+// テストパッケージの TestMain から Main を呼び、DB_TEST_DSN の DB に接続してマイグレーションを適用する。
+package dbtest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"teamflow-shared/migrate"
+)
+
+// TestPool は Main が初期化するテスト用の DB のプール（パッケージ内の統合テストで共有する）。
+var TestPool *pgxpool.Pool
+
+// Main は DB_TEST_DSN の DB に接続し、newMigrator のマイグレーションを適用してから m を実行する。
+// TestMain から os.Exit(dbtest.Main(m, migration.New)) のように呼ぶ。
+func Main(m *testing.M, newMigrator func(*pgxpool.Pool) (*migrate.Migrator, error)) int {
+	dsn := os.Getenv("DB_TEST_DSN")
+	if dsn == "" {
+		fmt.Fprintln(os.Stderr, "DB_TEST_DSN is required")
+		return 2
+	}
+
+	ctx := context.Background()
+	pool, err := WaitForDB(ctx, dsn, 30*time.Second)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "db not ready:", err)
+		return 1
+	}
+	TestPool = pool
+	defer TestPool.Close()
+
+	// 複数のテストパッケージが同じDBを使う場合も、適用済みのマイグレーションはスキップされる
+	migrator, err := newMigrator(pool)
+	if err == nil {
+		err = migrator.Up(ctx)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "apply schema failed:", err)
+		return 1
+	}
+	return m.Run()
+}
+
+// Setup は Main で初期化したプールを返す。Main を呼んでいない場合はテストを失敗させる。
+func Setup(t testing.TB) *pgxpool.Pool {
+	t.Helper()
+	if TestPool == nil {
+		t.Fatalf("TestPool is nil: ensure TestMain calls dbtest.Main (go test -tags=integration ./... with DB_TEST_DSN)")
+	}
+	return TestPool
+}
+
+// Truncate は tables を空にする（テストの前にデータを消す）。
+func Truncate(t testing.TB, db *pgxpool.Pool, tables string) {
+	t.Helper()
+	if _, err := db.Exec(context.Background(), "TRUNCATE TABLE "+tables); err != nil {
+		t.Fatalf("failed to truncate %s: %v", tables, err)
+	}
+}
+
+// WaitForDB は DB に接続できるまで timeout の間待つ。
+func WaitForDB(ctx context.Context, dsn string, timeout time.Duration) (*pgxpool.Pool, error) {
+	deadline := time.Now().Add(timeout)
+
+	for time.Now().Before(deadline) {
+		pool, err := pgxpool.New(ctx, dsn)
+		if err == nil {
+			pctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+			err = pool.Ping(pctx)
+			cancel()
+			if err == nil {
+				return pool, nil
+			}
+			pool.Close()
+		}
+		time.Sleep(300 * time.Millisecond)
+	}
+	return nil, fmt.Errorf("timeout waiting for db")
+}
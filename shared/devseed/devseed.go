@@ -0,0 +1,69 @@
+// Package devseed はローカル開発用のデモデータ（DEV_SEED=true で起動時に作成するプロジェクトとタスク）を定義する。
+//
+// projects サービスがプロジェクトを、tasks サービスがタスクを、それぞれのユースケースで作成する。
+// 2 つのサービスで同じデータになるよう ID は固定にしてあり、作成済みの ID は作成しない（再起動しても重複しない）。
+// ID はバージョン 4 の UUID のため、UUID_POLICY=v4v7 でも受け付ける。
+package devseed
+
+import "time"
+
+// OwnerID はデモのプロジェクトの作成者（owner）のユーザー ID。
+// ENFORCE_PROJECT_ROLES / ENFORCE_MEMBERSHIP を有効にしている場合は、この ID を X-User-ID に指定して操作する。
+const OwnerID = "0d3c6a52-7a4e-4f0b-9b1e-5f8a2c4d6e01"
+
+// Project はデモのプロジェクト。
+type Project struct {
+	ID          string
+	Key         string
+	Name        string
+	Description string
+}
+
+// Task はデモのタスク。
+type Task struct {
+	ID          string
+	ProjectID   string
+	Title       string
+	Description string
+	Status      string
+	Priority    string
+	// DueInDays は作成日からの期日の日数（負の値は期限切れ）。nil の場合は期日なし
+	DueInDays *int
+}
+
+// DueDate は now を基準にした期日を返す。期日なしの場合は nil。
+func (t Task) DueDate(now time.Time) *time.Time {
+	if t.DueInDays == nil {
+		return nil
+	}
+	d := now.AddDate(0, 0, *t.DueInDays)
+	return &d
+}
+
+const (
+	demoProjectID      = "6f1d2b7c-3e4a-4c5b-8d9e-0a1b2c3d4e01"
+	marketingProjectID = "6f1d2b7c-3e4a-4c5b-8d9e-0a1b2c3d4e02"
+)
+
+// Projects はデモのプロジェクト。
+var Projects = []Project{
+	{ID: demoProjectID, Key: "DEMO", Name: "デモプロジェクト", Description: "DEV_SEED で作成したデモ用のプロジェクト。"},
+	{ID: marketingProjectID, Key: "MKT", Name: "新製品のプロモーション", Description: "DEV_SEED で作成したデモ用のプロジェクト。"},
+}
+
+// Tasks はデモのタスク。ボード・フィルタ・集計・期日の表示を試せるよう、ステータス・優先度・期日（期限切れ・当日・近日・期日なし）を散らしてある。
+var Tasks = []Task{
+	{ID: "8a4e6c1f-2b3d-4e5f-9a0b-1c2d3e4f5a01", ProjectID: demoProjectID, Title: "要件を整理する", Description: "関係者へのヒアリング結果をまとめる。", Status: "done", Priority: "high", DueInDays: days(-7)},
+	{ID: "8a4e6c1f-2b3d-4e5f-9a0b-1c2d3e4f5a02", ProjectID: demoProjectID, Title: "画面設計をレビューする", Status: "done", Priority: "medium"},
+	{ID: "8a4e6c1f-2b3d-4e5f-9a0b-1c2d3e4f5a03", ProjectID: demoProjectID, Title: "API のスキーマを決める", Description: "OpenAPI に一覧・作成・更新のエンドポイントを定義する。", Status: "in_progress", Priority: "high", DueInDays: days(-1)},
+	{ID: "8a4e6c1f-2b3d-4e5f-9a0b-1c2d3e4f5a04", ProjectID: demoProjectID, Title: "ログイン画面を実装する", Status: "in_progress", Priority: "medium", DueInDays: days(0)},
+	{ID: "8a4e6c1f-2b3d-4e5f-9a0b-1c2d3e4f5a05", ProjectID: demoProjectID, Title: "タスクの一覧を実装する", Status: "todo", Priority: "high", DueInDays: days(2)},
+	{ID: "8a4e6c1f-2b3d-4e5f-9a0b-1c2d3e4f5a06", ProjectID: demoProjectID, Title: "通知メールの文面を考える", Status: "todo", Priority: "low"},
+	{ID: "8a4e6c1f-2b3d-4e5f-9a0b-1c2d3e4f5a07", ProjectID: demoProjectID, Title: "E2E テストを書く", Status: "todo", Priority: "medium", DueInDays: days(14)},
+	{ID: "8a4e6c1f-2b3d-4e5f-9a0b-1c2d3e4f5a08", ProjectID: marketingProjectID, Title: "ターゲットを決める", Status: "done", Priority: "high", DueInDays: days(-3)},
+	{ID: "8a4e6c1f-2b3d-4e5f-9a0b-1c2d3e4f5a09", ProjectID: marketingProjectID, Title: "ランディングページの原稿を書く", Status: "in_progress", Priority: "medium", DueInDays: days(5)},
+	{ID: "8a4e6c1f-2b3d-4e5f-9a0b-1c2d3e4f5a0a", ProjectID: marketingProjectID, Title: "SNS の投稿を予約する", Status: "todo", Priority: "low", DueInDays: days(1)},
+	{ID: "8a4e6c1f-2b3d-4e5f-9a0b-1c2d3e4f5a0b", ProjectID: marketingProjectID, Title: "広告の予算を確認する", Status: "todo", Priority: "high", DueInDays: days(-2)},
+}
+
+func days(n int) *int { return &n }
@@ -0,0 +1,56 @@
+package devseed_test
+
+import (
+	"testing"
+	"time"
+
+	"teamflow-shared/devseed"
+	"teamflow-shared/uuidpolicy"
+)
+
+func TestDataset(t *testing.T) {
+	ids := map[string]bool{}
+	checkID := func(id string) {
+		t.Helper()
+		if !uuidpolicy.V4V7.Valid(id) {
+			t.Errorf("%q is not accepted by the v4v7 policy", id)
+		}
+		if ids[id] {
+			t.Errorf("duplicate id %q", id)
+		}
+		ids[id] = true
+	}
+
+	checkID(devseed.OwnerID)
+	projects := map[string]bool{}
+	for _, p := range devseed.Projects {
+		checkID(p.ID)
+		projects[p.ID] = true
+	}
+	statuses := map[string]bool{}
+	for _, task := range devseed.Tasks {
+		checkID(task.ID)
+		if !projects[task.ProjectID] {
+			t.Errorf("task %q belongs to an unknown project %q", task.Title, task.ProjectID)
+		}
+		statuses[task.Status] = true
+	}
+	for _, s := range []string{"todo", "in_progress", "done"} {
+		if !statuses[s] {
+			t.Errorf("expected a task with status %q", s)
+		}
+	}
+}
+
+func TestTask_DueDate(t *testing.T) {
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	for _, task := range devseed.Tasks {
+		got := task.DueDate(now)
+		if (got == nil) != (task.DueInDays == nil) {
+			t.Fatalf("%q: DueDate = %v, DueInDays = %v", task.Title, got, task.DueInDays)
+		}
+		if got != nil && !got.Equal(now.AddDate(0, 0, *task.DueInDays)) {
+			t.Errorf("%q: DueDate = %s, want %d days from %s", task.Title, got, *task.DueInDays, now)
+		}
+	}
+}
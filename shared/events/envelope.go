@@ -0,0 +1,72 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SchemaVersion はイベントバスに配信する JSON（Envelope）の形式のバージョン。
+// 項目の追加では上げず、既存の項目の意味・型を変える場合に上げる。
+const SchemaVersion = 1
+
+// ErrUnsupportedVersion は Envelope の version が SchemaVersion より新しい場合のエラー。
+var ErrUnsupportedVersion = errors.New("unsupported event schema version")
+
+// Envelope はイベントバスに配信するイベントの JSON 形式。
+type Envelope struct {
+	Version     int             `json:"version"`
+	ID          string          `json:"id"`
+	Type        Type            `json:"type"`
+	Source      string          `json:"source"`
+	AggregateID string          `json:"aggregateId"`
+	ProjectID   string          `json:"projectId"`
+	WorkspaceID string          `json:"workspaceId"`
+	OccurredAt  time.Time       `json:"occurredAt"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// Marshal は e を SchemaVersion の Envelope の JSON にする。
+func Marshal(e Event) ([]byte, error) {
+	data := e.Data
+	if data == nil {
+		data = json.RawMessage("{}")
+	}
+	return json.Marshal(Envelope{
+		Version:     SchemaVersion,
+		ID:          e.ID,
+		Type:        e.Type,
+		Source:      e.Source,
+		AggregateID: e.AggregateID,
+		ProjectID:   e.ProjectID,
+		WorkspaceID: e.WorkspaceID,
+		OccurredAt:  e.OccurredAt.UTC(),
+		Data:        data,
+	})
+}
+
+// Unmarshal は Envelope の JSON を Event に読み込む。
+// version が無い・SchemaVersion より新しい場合は ErrUnsupportedVersion、id か type が無い場合はエラーを返す。
+func Unmarshal(b []byte) (Event, error) {
+	var env Envelope
+	if err := json.Unmarshal(b, &env); err != nil {
+		return Event{}, fmt.Errorf("invalid event: %w", err)
+	}
+	if env.Version < 1 || env.Version > SchemaVersion {
+		return Event{}, fmt.Errorf("%w: %d", ErrUnsupportedVersion, env.Version)
+	}
+	if env.ID == "" || env.Type == "" {
+		return Event{}, errors.New("invalid event: id and type are required")
+	}
+	return Event{
+		ID:          env.ID,
+		Type:        env.Type,
+		Source:      env.Source,
+		AggregateID: env.AggregateID,
+		ProjectID:   env.ProjectID,
+		WorkspaceID: env.WorkspaceID,
+		OccurredAt:  env.OccurredAt,
+		Data:        env.Data,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package events_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"teamflow-shared/events"
+)
+
+func TestMarshalUnmarshal(t *testing.T) {
+	at := time.Date(2026, 4, 1, 18, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	e := events.NewTaskEvent(events.TaskCreated, "acme", events.TaskData{ID: "t-1", ProjectID: "p-1", Status: "todo"}, at)
+
+	b, err := events.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(b, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw["version"] != float64(events.SchemaVersion) || raw["occurredAt"] != "2026-04-01T09:00:00Z" || raw["aggregateId"] != "t-1" {
+		t.Errorf("unexpected envelope %s", b)
+	}
+
+	got, err := events.Unmarshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != e.ID || got.Type != e.Type || got.Source != e.Source || got.WorkspaceID != "acme" ||
+		!got.OccurredAt.Equal(at) || string(got.Data) != string(e.Data) {
+		t.Errorf("Unmarshal() = %+v, want %+v", got, e)
+	}
+
+	// Data が無いイベントは {} で送る
+	if b, _ := events.Marshal(events.Event{ID: "e-1", Type: events.TaskDeleted}); !json.Valid(b) {
+		t.Errorf("invalid JSON %s", b)
+	}
+}
+
+func TestUnmarshal_Invalid(t *testing.T) {
+	for _, in := range []string{
+		`{"version":2,"id":"e-1","type":"task.created"}`,
+		`{"id":"e-1","type":"task.created"}`,
+	} {
+		if _, err := events.Unmarshal([]byte(in)); !errors.Is(err, events.ErrUnsupportedVersion) {
+			t.Errorf("Unmarshal(%s) = %v, want ErrUnsupportedVersion", in, err)
+		}
+	}
+	for _, in := range []string{`{"version":1,"type":"task.created"}`, `{"version":1,"id":"e-1"}`, `not json`} {
+		if _, err := events.Unmarshal([]byte(in)); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded, want an error", in)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Package events は tasks / projects サービスがサービス間に配信するドメインイベントを定義する。
+//
+// イベントは書き込みと同じトランザクションで outbox テーブルに記録し（teamflow-shared/outbox）、
+// リレーがイベントバスに配信する。配信は at-least-once のため、購読側は Event.ID で重複を排除する。
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// Type はイベントの種類。
+type Type string
+
+const (
+	TaskCreated    Type = "task.created"
+	TaskUpdated    Type = "task.updated"
+	TaskDeleted    Type = "task.deleted"
+	ProjectCreated Type = "project.created"
+)
+
+// SourceTasks / SourceProjects はイベントを発行したサービス。
+const (
+	SourceTasks    = "tasks"
+	SourceProjects = "projects"
+)
+
+// Event はドメインイベントの 1 件。
+type Event struct {
+	ID          string // イベント ID（128 bit の乱数の 16 進文字列）。購読側は重複の排除に使う
+	Type        Type
+	Source      string // 発行したサービス（tasks / projects）
+	AggregateID string // 対象のタスク・プロジェクトの ID
+	ProjectID   string // 対象が属するプロジェクト（プロジェクト自体の場合は AggregateID と同じ）
+	WorkspaceID string
+	OccurredAt  time.Time
+	// Data は Type ごとの内容（task.* は TaskData、project.* は ProjectData）の JSON
+	Data json.RawMessage
+}
+
+// TaskData は task.created / task.updated / task.deleted の内容。
+// 一括操作（プロジェクトの削除に伴うアーカイブ等）ではタスクを読み込まないため、ID と ProjectID 以外は空になる。
+type TaskData struct {
+	ID        string `json:"id"`
+	ProjectID string `json:"projectId"`
+	Number    int    `json:"number,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Status    string `json:"status,omitempty"`
+	// PreviousStatus は task.updated でステータスを変更した場合の変更前のステータス
+	PreviousStatus string `json:"previousStatus,omitempty"`
+	// AssigneeID はイベントの時点の担当者（担当者がいない場合は空）
+	AssigneeID string `json:"assigneeId,omitempty"`
+	// AssigneeChanged は task.updated で担当者を変更した（外した場合を含む）場合に true
+	AssigneeChanged bool `json:"assigneeChanged,omitempty"`
+	// Fields は task.updated で変更したフィールド（API の JSON 名）
+	Fields  []string `json:"fields,omitempty"`
+	ActorID string   `json:"actorId,omitempty"`
+}
+
+// ProjectData は project.created の内容。
+type ProjectData struct {
+	ID         string `json:"id"`
+	Key        string `json:"key,omitempty"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Visibility string `json:"visibility"`
+	ActorID    string `json:"actorId,omitempty"`
+}
+
+// NewID はイベント ID を生成する。
+func NewID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:]) // crypto/rand.Read は失敗しない
+	return hex.EncodeToString(b[:])
+}
+
+// NewTaskEvent はタスクのイベントを生成する。
+func NewTaskEvent(typ Type, workspaceID string, data TaskData, at time.Time) Event {
+	return newEvent(typ, SourceTasks, data.ID, data.ProjectID, workspaceID, data, at)
+}
+
+// NewProjectEvent はプロジェクトのイベントを生成する。
+func NewProjectEvent(typ Type, workspaceID string, data ProjectData, at time.Time) Event {
+	return newEvent(typ, SourceProjects, data.ID, data.ID, workspaceID, data, at)
+}
+
+func newEvent(typ Type, source, aggregateID, projectID, workspaceID string, data any, at time.Time) Event {
+	// TaskData / ProjectData は文字列とスライスのみのため、Marshal は失敗しない
+	b, _ := json.Marshal(data)
+	return Event{
+		ID:          NewID(),
+		Type:        typ,
+		Source:      source,
+		AggregateID: aggregateID,
+		ProjectID:   projectID,
+		WorkspaceID: workspaceID,
+		OccurredAt:  at,
+		Data:        b,
+	}
+}
+
+// Decode は Data を v（*TaskData / *ProjectData）に読み込む。
+func (e Event) Decode(v any) error {
+	return json.Unmarshal(e.Data, v)
+}
@@ -0,0 +1,48 @@
+package events_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"teamflow-shared/events"
+)
+
+func TestNewTaskEvent(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	data := events.TaskData{ID: "t-1", ProjectID: "p-1", Status: "done", PreviousStatus: "todo", Fields: []string{"status"}}
+	e := events.NewTaskEvent(events.TaskUpdated, "acme", data, now)
+
+	if e.ID == "" || e.Type != events.TaskUpdated || e.Source != events.SourceTasks ||
+		e.AggregateID != "t-1" || e.ProjectID != "p-1" || e.WorkspaceID != "acme" || !e.OccurredAt.Equal(now) {
+		t.Errorf("unexpected event %+v", e)
+	}
+	if other := events.NewTaskEvent(events.TaskUpdated, "acme", data, now); other.ID == e.ID {
+		t.Error("expected a unique event ID")
+	}
+
+	var got events.TaskData
+	if err := e.Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != "done" || got.PreviousStatus != "todo" || !slices.Equal(got.Fields, []string{"status"}) {
+		t.Errorf("Decode() = %+v", got)
+	}
+	// 分からない項目は JSON に含めない
+	if string(events.NewTaskEvent(events.TaskDeleted, "acme", events.TaskData{ID: "t-1", ProjectID: "p-1"}, now).Data) !=
+		`{"id":"t-1","projectId":"p-1"}` {
+		t.Error("expected empty fields to be omitted")
+	}
+}
+
+func TestNewProjectEvent(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	e := events.NewProjectEvent(events.ProjectCreated, "acme", events.ProjectData{ID: "p-1", Name: "Alpha", Status: "active"}, now)
+	if e.Source != events.SourceProjects || e.AggregateID != "p-1" || e.ProjectID != "p-1" {
+		t.Errorf("unexpected event %+v", e)
+	}
+	var got events.ProjectData
+	if err := e.Decode(&got); err != nil || got.Name != "Alpha" {
+		t.Errorf("Decode() = %+v, %v", got, err)
+	}
+}
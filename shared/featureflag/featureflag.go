@@ -0,0 +1,124 @@
+// Package featureflag は tasks / projects サービスで共通のフィーチャーフラグを提供する。
+//
+// 機能をデプロイごとに段階的に有効化・無効化するために、ハンドラ・ユースケースは Provider に問い合わせる。
+// 今は環境変数と JSON ファイルから読み込む Set のみだが、リモートの配信サービスは Provider を実装すれば差し替えられる。
+//
+//	FEATURE_FLAGS       カンマ区切りのフラグ（name で有効、name=false で無効。例: task-events=false,cursor-v2）
+//	FEATURE_FLAGS_FILE  {"name": true} 形式の JSON ファイル（FEATURE_FLAGS が優先）
+package featureflag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"teamflow-shared/apierror"
+)
+
+// 設定の環境変数。
+const (
+	EnvKey     = "FEATURE_FLAGS"
+	FileEnvKey = "FEATURE_FLAGS_FILE"
+)
+
+// Provider はフラグが有効かどうかを返す。ctx はリクエスト単位の判定（リモートの配信サービス等）のために渡す。
+// 未知のフラグは無効として扱う。
+type Provider interface {
+	Enabled(ctx context.Context, name string) bool
+}
+
+// Set は固定のフラグの集合。
+type Set map[string]bool
+
+// コンパイル時にインターフェース実装を保証する。
+var _ Provider = Set(nil)
+
+// Enabled は name が有効かどうかを返す。
+func (s Set) Enabled(_ context.Context, name string) bool {
+	return s[name]
+}
+
+// Names は有効なフラグの名前を昇順で返す（起動時のログ用）。
+func (s Set) Names() []string {
+	var names []string
+	for name, on := range s {
+		if on {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load は defaults に FEATURE_FLAGS_FILE、FEATURE_FLAGS の順で上書きした Set を返す。
+// defaults は各サービスが認識するフラグの既定値。変更しない。
+func Load(getenv func(string) string, defaults Set) (Set, error) {
+	s := make(Set, len(defaults))
+	for name, on := range defaults {
+		s[name] = on
+	}
+	if path := getenv(FileEnvKey); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", FileEnvKey, err)
+		}
+		var file map[string]bool
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", FileEnvKey, path, err)
+		}
+		for name, on := range file {
+			s[name] = on
+		}
+	}
+	list, err := Parse(getenv(EnvKey))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", EnvKey, err)
+	}
+	for name, on := range list {
+		s[name] = on
+	}
+	return s, nil
+}
+
+// Parse は FEATURE_FLAGS 形式（カンマ区切りの name または name=bool）を読み込む。
+func Parse(list string) (Set, error) {
+	s := make(Set)
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, value, hasValue := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("empty flag name in %q", item)
+		}
+		on := true
+		if hasValue {
+			v, err := strconv.ParseBool(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("flag %s: expected true or false, got %q", name, value)
+			}
+			on = v
+		}
+		s[name] = on
+	}
+	return s, nil
+}
+
+// Require は name が無効な場合に 404（NOT_FOUND）を返し、有効な場合は next に渡すハンドラを返す。
+// 無効な機能はエンドポイントごと存在しないものとして扱う。
+func Require(p Provider, name string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !p.Enabled(r.Context(), name) {
+			apierror.Write(w, http.StatusNotFound, apierror.New(apierror.CodeNotFound, "not found"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,96 @@
+package featureflag_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"teamflow-shared/featureflag"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		list    string
+		want    featureflag.Set
+		wantErr bool
+	}{
+		{list: "", want: featureflag.Set{}},
+		{list: "a, b=false ,c=true", want: featureflag.Set{"a": true, "b": false, "c": true}},
+		{list: "a,,", want: featureflag.Set{"a": true}},
+		{list: "=true", wantErr: true},
+		{list: "a=maybe", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.list, func(t *testing.T) {
+			got, err := featureflag.Parse(tt.list)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.list, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flags.json")
+	if err := os.WriteFile(path, []byte(`{"from-file": true, "overridden": true}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	env := map[string]string{
+		featureflag.FileEnvKey: path,
+		featureflag.EnvKey:     "overridden=false,from-env",
+	}
+	defaults := featureflag.Set{"default-on": true, "from-file": false}
+
+	got, err := featureflag.Load(func(key string) string { return env[key] }, defaults)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := featureflag.Set{"default-on": true, "from-file": true, "overridden": false, "from-env": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Load = %v, want %v", got, want)
+	}
+	if defaults["from-file"] {
+		t.Error("expected defaults not to be modified")
+	}
+	if names := got.Names(); !reflect.DeepEqual(names, []string{"default-on", "from-env", "from-file"}) {
+		t.Errorf("Names = %v", names)
+	}
+
+	env[featureflag.FileEnvKey] = filepath.Join(t.TempDir(), "missing.json")
+	if _, err := featureflag.Load(func(key string) string { return env[key] }, nil); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestRequire(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) })
+
+	for _, tt := range []struct {
+		flags featureflag.Set
+		want  int
+	}{
+		{flags: featureflag.Set{"beta": true}, want: http.StatusNoContent},
+		{flags: featureflag.Set{"beta": false}, want: http.StatusNotFound},
+		{flags: nil, want: http.StatusNotFound},
+	} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(context.Background())
+		featureflag.Require(tt.flags, "beta", next).ServeHTTP(w, r)
+		if w.Code != tt.want {
+			t.Errorf("flags %v: expected %d, got %d", tt.flags, tt.want, w.Code)
+		}
+	}
+}
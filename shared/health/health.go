@@ -0,0 +1,122 @@
+// Package health は tasks / projects サービスで共通のヘルスチェックのエンドポイントを提供する。
+//
+//	/livez   プロセスが応答できるか（依存先は確認しない。失敗したら再起動する）
+//	/readyz  依存先（PostgreSQL など）に接続できるか（失敗したらリクエストを振り分けない）
+//
+// liveness で依存先を確認すると、DB の障害時に全インスタンスが再起動を繰り返すため分けている。
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Check は依存先の確認。接続できない場合はエラーを返す。
+type Check func(ctx context.Context) error
+
+// DefaultTimeout は readiness の確認全体のタイムアウトの既定値。
+const DefaultTimeout = 2 * time.Second
+
+// Checker は readiness の確認に使う依存先の一覧。
+type Checker struct {
+	timeout time.Duration
+
+	mu     sync.Mutex
+	names  []string
+	checks map[string]Check
+}
+
+// NewChecker は timeout（0 以下の場合は DefaultTimeout）で確認する Checker を生成する。
+func NewChecker(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Checker{timeout: timeout, checks: make(map[string]Check)}
+}
+
+// Add は name（postgres など）の確認を追加する。同じ名前の場合は置き換える。
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.checks[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.checks[name] = check
+}
+
+// Response は /readyz のレスポンス。
+type Response struct {
+	// Status は ok または unavailable
+	Status string `json:"status"`
+	// Checks は依存先ごとの結果（ok または failed）。エラーの内容は接続先などを含むためログにのみ出す
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Run はすべての確認を並行して実行し、結果を返す。1 つでも失敗した場合は ok を false で返す。
+func (c *Checker) Run(ctx context.Context) (Response, bool) {
+	c.mu.Lock()
+	names := append([]string(nil), c.names...)
+	checks := make([]Check, len(names))
+	for i, name := range names {
+		checks[i] = c.checks[name]
+	}
+	c.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	errs := make([]error, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = check(ctx)
+		}()
+	}
+	wg.Wait()
+
+	res := Response{Status: "ok", Checks: make(map[string]string, len(names))}
+	ok := true
+	for i, name := range names {
+		if errs[i] != nil {
+			slog.WarnContext(ctx, "readiness check failed", "check", name, "error", errs[i])
+			res.Checks[name] = "failed"
+			ok = false
+			continue
+		}
+		res.Checks[name] = "ok"
+	}
+	if !ok {
+		res.Status = "unavailable"
+	}
+	return res, ok
+}
+
+// LiveHandler は常に 200 を返す liveness のハンドラ。
+func LiveHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+}
+
+// ReadyHandler は c の確認の結果を返す readiness のハンドラ。すべて成功した場合は 200、それ以外は 503。
+func (c *Checker) ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, ok := c.Run(r.Context())
+		status := http.StatusOK
+		if !ok {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(res)
+	})
+}
@@ -0,0 +1,86 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"teamflow-shared/health"
+)
+
+func TestReadyHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		postgres   health.Check
+		wantStatus int
+		wantBody   health.Response
+	}{
+		{
+			name:       "all checks pass",
+			postgres:   func(context.Context) error { return nil },
+			wantStatus: http.StatusOK,
+			wantBody:   health.Response{Status: "ok", Checks: map[string]string{"postgres": "ok", "queue": "ok"}},
+		},
+		{
+			name:       "a check fails",
+			postgres:   func(context.Context) error { return errors.New("connection refused") },
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   health.Response{Status: "unavailable", Checks: map[string]string{"postgres": "failed", "queue": "ok"}},
+		},
+		{
+			name: "a check times out",
+			postgres: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   health.Response{Status: "unavailable", Checks: map[string]string{"postgres": "failed", "queue": "ok"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := health.NewChecker(50 * time.Millisecond)
+			c.Add("postgres", tt.postgres)
+			c.Add("queue", func(context.Context) error { return nil })
+
+			w := httptest.NewRecorder()
+			c.ReadyHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			var got health.Response
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode: %v", err)
+			}
+			if got.Status != tt.wantBody.Status || len(got.Checks) != len(tt.wantBody.Checks) {
+				t.Fatalf("expected %+v, got %+v", tt.wantBody, got)
+			}
+			for name, want := range tt.wantBody.Checks {
+				if got.Checks[name] != want {
+					t.Errorf("checks[%s] = %q, want %q", name, got.Checks[name], want)
+				}
+			}
+		})
+	}
+}
+
+func TestReadyHandler_NoChecks(t *testing.T) {
+	w := httptest.NewRecorder()
+	health.NewChecker(0).ReadyHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 without dependencies, got %d", w.Code)
+	}
+}
+
+func TestLiveHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	health.LiveHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "ok" {
+		t.Fatalf("expected 200 ok, got %d %q", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,192 @@
+// Package i18n は ValidationIssue の message を Accept-Language に応じて翻訳する。
+//
+// 各ハンドラは日本語（既定の言語）で message を組み立てる。英語などを求められた場合は、
+// Middleware が 4xx のエラーレスポンスの details.issues[].message を、field と code をキーにした
+// Catalog の文言に置き換える。field・code は変えないため、クライアントが自分で翻訳することもできる。
+package i18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Lang は言語タグ（BCP 47 の主言語）。
+type Lang string
+
+// 対応する言語。
+const (
+	Japanese Lang = "ja"
+	English  Lang = "en"
+)
+
+// Default は Accept-Language が無い・対応していない場合の言語（ハンドラの文言の言語）。
+const Default = Japanese
+
+// Supported は対応する言語（Accept-Language の q 値が同じ場合はこの順で選ぶ）。
+var Supported = []Lang{Japanese, English}
+
+// Negotiate は Accept-Language（例: en-US,en;q=0.9,ja;q=0.8）から応答する言語を選ぶ。
+// 地域のサブタグ（en-US の US）は無視し、q=0 は拒否として扱う。
+func Negotiate(acceptLanguage string) Lang {
+	type candidate struct {
+		lang Lang
+		q    float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		for _, lang := range Supported {
+			if primary == string(lang) && q > 0 {
+				candidates = append(candidates, candidate{lang: lang, q: q})
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return Default
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].lang
+}
+
+// Catalog は "field.code" をキーにした言語ごとの文言。
+// field を問わない文言は "*.code" に置き、文言中の {field} はフィールド名に置き換える。
+type Catalog map[string]map[Lang]string
+
+// Message は field と code の文言を返す。"field.code"、"*.code" の順に探し、無い場合は false を返す。
+func (c Catalog) Message(lang Lang, field, code string) (string, bool) {
+	for _, key := range []string{field + "." + code, "*." + code} {
+		if msg, ok := c[key][lang]; ok {
+			return strings.ReplaceAll(msg, "{field}", field), true
+		}
+	}
+	return "", false
+}
+
+// Merge は catalogs を 1 つにまとめる。同じキーの文言は後の Catalog が優先される。
+func Merge(catalogs ...Catalog) Catalog {
+	merged := Catalog{}
+	for _, c := range catalogs {
+		for key, msgs := range c {
+			if merged[key] == nil {
+				merged[key] = map[Lang]string{}
+			}
+			for lang, msg := range msgs {
+				merged[key][lang] = msg
+			}
+		}
+	}
+	return merged
+}
+
+// Middleware は Accept-Language から言語を選び、Content-Language に設定する。
+// 既定の言語以外の場合は、4xx のエラーレスポンス（400 の検証エラー、403 の FIELD_FORBIDDEN など）の
+// issues の message を catalog の文言に置き換える（catalog に無い field・code の message はそのまま）。
+func Middleware(catalog Catalog, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang := Negotiate(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Language", string(lang))
+		w.Header().Add("Vary", "Accept-Language")
+		if lang == Default {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		tw := &translatingWriter{ResponseWriter: w}
+		next.ServeHTTP(tw, r)
+		if tw.buf != nil {
+			w.Header().Del("Content-Length")
+			w.WriteHeader(tw.status)
+			_, _ = w.Write(translate(tw.buf.Bytes(), catalog, lang))
+		}
+	})
+}
+
+// translatingWriter は 4xx のレスポンスの本文だけをバッファし、それ以外はそのまま書き込む。
+type translatingWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	buf         *bytes.Buffer
+}
+
+func (w *translatingWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+	if status >= http.StatusBadRequest && status < http.StatusInternalServerError {
+		w.buf = &bytes.Buffer{}
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *translatingWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.buf != nil {
+		return w.buf.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush は SSE などのストリーミングのために、バッファしていないレスポンスをフラッシュする。
+func (w *translatingWriter) Flush() {
+	if w.buf != nil {
+		return
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap は http.ResponseController が元の ResponseWriter を使えるようにする。
+func (w *translatingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// translate は ErrorResponse の details.issues[].message を翻訳する。
+// ErrorResponse を埋め込んだ本文の他のフィールドは残すため、map としてデコードする。
+// JSON でない・issues が無い場合は body をそのまま返す。
+func translate(body []byte, catalog Catalog, lang Lang) []byte {
+	var resp map[string]any
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return body
+	}
+	details, _ := resp["details"].(map[string]any)
+	issues, _ := details["issues"].([]any)
+	if len(issues) == 0 {
+		return body
+	}
+	for _, v := range issues {
+		issue, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		field, _ := issue["field"].(string)
+		code, _ := issue["code"].(string)
+		if msg, ok := catalog.Message(lang, field, code); ok {
+			issue["message"] = msg
+		}
+	}
+	out, err := json.Marshal(resp)
+	if err != nil {
+		return body
+	}
+	return append(out, '\n')
+}
@@ -0,0 +1,138 @@
+package i18n_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/i18n"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   i18n.Lang
+	}{
+		{header: "", want: i18n.Japanese},
+		{header: "en", want: i18n.English},
+		{header: "en-US,en;q=0.9", want: i18n.English},
+		{header: "ja-JP,en;q=0.5", want: i18n.Japanese},
+		{header: "fr-FR,en;q=0.8,ja;q=0.7", want: i18n.English},
+		{header: "en;q=0.3,ja;q=0.7", want: i18n.Japanese},
+		{header: "en;q=0,fr", want: i18n.Japanese},
+		{header: "de", want: i18n.Japanese},
+	}
+	for _, tt := range tests {
+		if got := i18n.Negotiate(tt.header); got != tt.want {
+			t.Errorf("Negotiate(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestCatalog_Message(t *testing.T) {
+	c := i18n.Merge(i18n.Common, i18n.Catalog{
+		"title.REQUIRED": {i18n.English: "title is required and must not be blank."},
+	})
+
+	if got, _ := c.Message(i18n.English, "title", "REQUIRED"); got != "title is required and must not be blank." {
+		t.Errorf("field-specific message = %q", got)
+	}
+	// field 固有の文言が無い場合は code の文言に field を埋め込む
+	if got, _ := c.Message(i18n.English, "projectId", "REQUIRED"); got != "projectId is required." {
+		t.Errorf("fallback message = %q", got)
+	}
+	if _, ok := c.Message(i18n.English, "settings", "INVALID_VALUE"); ok {
+		t.Error("expected no message for an unknown code")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	catalog := i18n.Merge(i18n.Common, i18n.Catalog{
+		"name.REQUIRED":     {i18n.English: "name is required."},
+		"*.FIELD_FORBIDDEN": {i18n.English: "{field} is locked."},
+	})
+	h := i18n.Middleware(catalog, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			_, _ = w.Write([]byte(`{"id":"p-1"}`))
+			return
+		case "/locked":
+			apierror.Write(w, http.StatusForbidden, apierror.New(apierror.CodeForbidden, "Forbidden",
+				apierror.ValidationIssue{Location: apierror.LocationBody, Field: "priority", Code: "FIELD_FORBIDDEN", Message: "priority は変更できません。"},
+			))
+			return
+		}
+		apierror.Write(w, http.StatusBadRequest, apierror.New(apierror.CodeValidation, "Invalid request",
+			apierror.ValidationIssue{Location: apierror.LocationBody, Field: "name", Code: "REQUIRED", Message: "name は必須です。"},
+			apierror.ValidationIssue{Location: apierror.LocationBody, Field: "settings", Code: "INVALID_VALUE", Message: "invalid settings: wipLimits"},
+		))
+	}))
+
+	serve := func(path, acceptLanguage string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+	messages := func(w *httptest.ResponseRecorder) []string {
+		var body apierror.ErrorResponse
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode: %v", err)
+		}
+		var msgs []string
+		for _, issue := range body.Details.Issues {
+			msgs = append(msgs, issue.Message)
+		}
+		return msgs
+	}
+
+	t.Run("default language keeps the handler's message", func(t *testing.T) {
+		w := serve("/projects", "")
+		if got := w.Header().Get("Content-Language"); got != "ja" {
+			t.Errorf("Content-Language = %q, want ja", got)
+		}
+		if got := messages(w); got[0] != "name は必須です。" {
+			t.Errorf("messages = %q", got)
+		}
+	})
+
+	t.Run("english", func(t *testing.T) {
+		w := serve("/projects", "en-US,en;q=0.9")
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want 400", w.Code)
+		}
+		if got := w.Header().Get("Content-Language"); got != "en" {
+			t.Errorf("Content-Language = %q, want en", got)
+		}
+		got := messages(w)
+		if got[0] != "name is required." {
+			t.Errorf("translated message = %q", got[0])
+		}
+		// カタログに無い code の message はそのまま
+		if got[1] != "invalid settings: wipLimits" {
+			t.Errorf("untranslated message = %q", got[1])
+		}
+	})
+
+	t.Run("other 4xx responses are translated too", func(t *testing.T) {
+		w := serve("/locked", "en")
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("status = %d, want 403", w.Code)
+		}
+		if got := messages(w); got[0] != "priority is locked." {
+			t.Errorf("translated message = %q", got[0])
+		}
+	})
+
+	t.Run("non-error responses pass through", func(t *testing.T) {
+		w := serve("/ok", "en")
+		if w.Code != http.StatusOK || w.Body.String() != `{"id":"p-1"}` {
+			t.Errorf("unexpected response: %d %s", w.Code, w.Body.String())
+		}
+	})
+}
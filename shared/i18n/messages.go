@@ -0,0 +1,19 @@
+package i18n
+
+// Common は両サービスで共通の field・code の文言（一覧の cursor、リクエストボディの形式など）。
+// 各サービスはこれに自分の field・code の文言を Merge して使う。
+var Common = Catalog{
+	// field を問わない文言
+	"*.REQUIRED":       {English: "{field} is required."},
+	"*.INVALID_FORMAT": {English: "{field} has an invalid format."},
+	"*.INVALID_ENUM":   {English: "{field} has an unsupported value."},
+	"*.INVALID_RANGE":  {English: "{field} is out of range."},
+	"*.UNKNOWN":        {English: "The request parameters are invalid. Check your input."},
+
+	"body.INVALID_FORMAT":           {English: "The request body must be JSON."},
+	"sort.INCOMPATIBLE_WITH_CURSOR": {English: "sort cannot be specified together with cursor."},
+	"cursor.INVALID_FORMAT":         {English: "cursor has an invalid format."},
+	"cursor.INVALID_SIGNATURE":      {English: "cursor has an invalid signature."},
+	"cursor.EXPIRED":                {English: "cursor has expired."},
+	"cursor.QUERY_MISMATCH":         {English: "cursor does not match the query. Filters may have changed since it was issued."},
+}
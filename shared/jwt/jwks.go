@@ -0,0 +1,205 @@
+package jwt
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"teamflow-shared/clock"
+)
+
+// JWK は RSA 公開鍵の JSON Web Key。
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// JWKS は公開鍵の一覧（/.well-known/jwks.json の本文）。
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewJWK は pub の JWK（署名用・RS256）を返す。
+func NewJWK(kid string, pub *rsa.PublicKey) JWK {
+	return JWK{
+		Kty: "RSA",
+		Kid: kid,
+		Use: "sig",
+		Alg: Algorithm,
+		N:   encoding.EncodeToString(pub.N.Bytes()),
+		E:   encoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+	}
+}
+
+// KeyID は pub の JWK Thumbprint（RFC 7638、SHA-256）を返す。鍵から決まるため、鍵を入れ替えると kid も変わる。
+func KeyID(pub *rsa.PublicKey) string {
+	k := NewJWK("", pub)
+	// メンバーは辞書順で、空白を含めない
+	b, _ := json.Marshal(struct {
+		E   string `json:"e"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+	}{E: k.E, Kty: k.Kty, N: k.N})
+	sum := sha256.Sum256(b)
+	return encoding.EncodeToString(sum[:])
+}
+
+// PublicKey は k の RSA 公開鍵を返す。RSA 以外や値が不正な場合はエラーを返す。
+func (k JWK) PublicKey() (*rsa.PublicKey, error) {
+	if k.Kty != "RSA" {
+		return nil, fmt.Errorf("jwk %s: unsupported kty %q", k.Kid, k.Kty)
+	}
+	n, err := encoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("jwk %s: invalid n: %w", k.Kid, err)
+	}
+	e, err := encoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("jwk %s: invalid e: %w", k.Kid, err)
+	}
+	exp := new(big.Int).SetBytes(e)
+	if len(n) == 0 || !exp.IsInt64() || exp.Int64() < 3 || exp.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("jwk %s: invalid rsa public key", k.Kid)
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+}
+
+// DefaultJWKSCacheTTL は RemoteKeys が取得した JWKS を使い続ける時間。
+const DefaultJWKSCacheTTL = 10 * time.Minute
+
+// minRefreshInterval は未知の kid で JWKS を取得し直す最短の間隔（不正なトークンで取得先に負荷をかけないため）。
+const minRefreshInterval = 10 * time.Second
+
+// RemoteKeys は URL の JWKS から公開鍵を返す KeySource。取得した JWKS は TTL の間キャッシュし、
+// 未知の kid のトークンを受け取った場合は（鍵の入れ替えに追従するため）取得し直す。並行に呼び出してよい。
+type RemoteKeys struct {
+	url        string
+	httpClient *http.Client
+	clock      clock.Clock
+	ttl        time.Duration
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+// NewRemoteKeys は url の JWKS を使う RemoteKeys を生成する。
+// httpClient が nil の場合は http.DefaultClient、clk が nil の場合は clock.System、ttl が 0 の場合は DefaultJWKSCacheTTL。
+func NewRemoteKeys(url string, httpClient *http.Client, clk clock.Clock, ttl time.Duration) *RemoteKeys {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if ttl == 0 {
+		ttl = DefaultJWKSCacheTTL
+	}
+	return &RemoteKeys{url: url, httpClient: httpClient, clock: clock.OrSystem(clk), ttl: ttl}
+}
+
+// jwksFetchTimeout は NewRemoteVerifier が JWKS を取得する際のタイムアウト。
+const jwksFetchTimeout = 5 * time.Second
+
+// NewRemoteVerifier は jwksURL の JWKS で検証する Verifier を生成する（各サービスの JWKS_URL / JWT_ISSUER / JWT_AUDIENCE）。
+// jwksURL が空の場合は nil（Middleware は検証しない）を返す。
+func NewRemoteVerifier(jwksURL, issuer, audience string) *Verifier {
+	if jwksURL == "" {
+		return nil
+	}
+	slog.Info("verifying jwts", "jwks_url", jwksURL, "issuer", issuer, "audience", audience)
+	return &Verifier{
+		Keys:     NewRemoteKeys(jwksURL, &http.Client{Timeout: jwksFetchTimeout}, clock.System, 0),
+		Issuer:   issuer,
+		Audience: audience,
+		Clock:    clock.System,
+	}
+}
+
+// PublicKey は kid の公開鍵を返す。キャッシュが古い・kid が無い場合は JWKS を取得し直す。
+// 取得後も見つからない場合は ErrUnknownKey、取得できない場合はそれ以外のエラーを返す。
+func (k *RemoteKeys) PublicKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := k.clock.Now()
+	age := now.Sub(k.fetchedAt)
+	key, ok := k.keys[kid]
+	if k.keys != nil && (ok && age < k.ttl || !ok && age < minRefreshInterval) {
+		if !ok {
+			return nil, ErrUnknownKey
+		}
+		return key, nil
+	}
+
+	keys, err := k.fetch(ctx)
+	if err != nil {
+		if ok {
+			// 取得先に問い合わせられない間は、キャッシュ済みの鍵で検証を続ける
+			return key, nil
+		}
+		return nil, err
+	}
+	k.keys, k.fetchedAt = keys, now
+	if key, ok = keys[kid]; !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+func (k *RemoteKeys) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := k.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch jwks: %s returned %d", k.url, res.StatusCode)
+	}
+	var set JWKS
+	if err := json.NewDecoder(res.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("failed to decode jwks: %w", err)
+	}
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	var errs []error
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		pub, err := jwk.PublicKey()
+		if err != nil {
+			// 未対応の鍵（EC など）は無視し、RSA の鍵だけで検証する
+			errs = append(errs, err)
+			continue
+		}
+		keys[jwk.Kid] = pub
+	}
+	if len(keys) == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return keys, nil
+}
+
+// Handler は set を JSON で返すハンドラ（/.well-known/jwks.json）。検証側がキャッシュできるよう Cache-Control を付ける。
+func Handler(set JWKS) http.Handler {
+	body, _ := json.Marshal(set)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		_, _ = w.Write(body)
+	})
+}
@@ -0,0 +1,229 @@
+// Package jwt は TeamFlow の JWT（RS256）の発行・検証と、公開鍵の配布（JWKS）を提供する。
+//
+// auth サービスは OIDC プロバイダでログインしたユーザーに JWT を発行し、公開鍵を /.well-known/jwks.json で配布する。
+// 各サービスは Middleware で Authorization: Bearer <JWT> を JWKS の公開鍵で検証し、
+// sub を操作者（X-User-ID）、workspace_id を X-Workspace-ID に設定する。
+// 署名アルゴリズムは RS256 のみ受け付ける（alg: none や HS256 への差し替えは拒否する）。
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"teamflow-shared/clock"
+)
+
+// Algorithm は発行・検証する署名アルゴリズム。
+const Algorithm = "RS256"
+
+// DefaultLeeway は有効期限・有効開始時刻の確認で許容する時計のずれ。
+const DefaultLeeway = time.Minute
+
+var (
+	// ErrInvalidToken は形式・署名・有効期限・発行者・対象者のいずれかが不正な場合のエラー（HTTP 層で 401）。
+	ErrInvalidToken = errors.New("jwt is invalid")
+	// ErrUnknownKey は kid の公開鍵が見つからない場合のエラー。ErrInvalidToken として扱う。
+	ErrUnknownKey = fmt.Errorf("%w: unknown signing key", ErrInvalidToken)
+)
+
+// Audience は aud クレーム。JSON では 1 件の場合は文字列、複数の場合は配列で表す。
+type Audience []string
+
+// MarshalJSON は 1 件の場合は文字列、それ以外は配列で返す。
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+// UnmarshalJSON は文字列・配列のどちらも受け付ける。
+func (a *Audience) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*a = Audience{s}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+// Contains は aud に s が含まれるかどうかを返す。
+func (a Audience) Contains(s string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Claims は JWT のクレーム。時刻は Unix 秒。
+type Claims struct {
+	Issuer    string   `json:"iss,omitempty"`
+	Subject   string   `json:"sub,omitempty"`
+	Audience  Audience `json:"aud,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+	ID        string   `json:"jti,omitempty"`
+	// Nonce は OIDC の ID トークンで、認可リクエストと応答を結び付ける値
+	Nonce string `json:"nonce,omitempty"`
+	Email string `json:"email,omitempty"`
+	Name  string `json:"name,omitempty"`
+	// WorkspaceID は TeamFlow の JWT で、操作するワークスペース。空の場合は既定のワークスペース
+	WorkspaceID string `json:"workspace_id,omitempty"`
+
+	// Raw は検証したペイロードのすべてのクレーム（プロバイダ独自のクレームを読むために使う）。発行時は使わない
+	Raw map[string]any `json:"-"`
+}
+
+// header は JOSE ヘッダ。
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+	Kid string `json:"kid,omitempty"`
+}
+
+var encoding = base64.RawURLEncoding
+
+// Sign は claims を key で署名した JWT を返す。kid は検証側が JWKS から公開鍵を選ぶための鍵 ID。
+func Sign(claims Claims, key *rsa.PrivateKey, kid string) (string, error) {
+	h, err := json.Marshal(header{Alg: Algorithm, Typ: "JWT", Kid: kid})
+	if err != nil {
+		return "", err
+	}
+	p, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := encoding.EncodeToString(h) + "." + encoding.EncodeToString(p)
+	digest := sha256.Sum256([]byte(signingInput))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign jwt: %w", err)
+	}
+	return signingInput + "." + encoding.EncodeToString(sig), nil
+}
+
+// LooksLikeToken は s が JWT の形式（ドット区切りの 3 つの部分）かどうかを返す（署名は確認しない）。
+func LooksLikeToken(s string) bool {
+	return strings.Count(s, ".") == 2 && !strings.ContainsAny(s, " \t")
+}
+
+// KeySource は kid から署名の検証に使う公開鍵を返す。
+type KeySource interface {
+	// PublicKey は kid の公開鍵を返す。見つからない場合は ErrUnknownKey を返す。
+	// 取得先（JWKS）に問い合わせられない場合はそれ以外のエラーを返す。
+	PublicKey(ctx context.Context, kid string) (*rsa.PublicKey, error)
+}
+
+// StaticKeys は固定の公開鍵（kid → 公開鍵）の KeySource。auth サービス自身やテストで使う。
+type StaticKeys map[string]*rsa.PublicKey
+
+// PublicKey は kid の公開鍵を返す。
+func (k StaticKeys) PublicKey(_ context.Context, kid string) (*rsa.PublicKey, error) {
+	if key, ok := k[kid]; ok {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+// Verifier は JWT の署名とクレームを検証する。
+type Verifier struct {
+	// Keys は署名の検証に使う公開鍵
+	Keys KeySource
+	// Issuer は期待する iss。空の場合は確認しない
+	Issuer string
+	// Audience は aud に含まれるべき値。空の場合は確認しない
+	Audience string
+	// Clock は任意。nil の場合は clock.System
+	Clock clock.Clock
+	// Leeway は有効期限・有効開始時刻で許容する時計のずれ。0 の場合は DefaultLeeway
+	Leeway time.Duration
+}
+
+// Verify は token の署名・有効期限・有効開始時刻・発行者・対象者を検証してクレームを返す。
+// exp の無いトークンは受け付けない。不正な場合は ErrInvalidToken を返す（errors.Is で判定する）。
+func (v *Verifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("%w: malformed header", ErrInvalidToken)
+	}
+	if h.Alg != Algorithm {
+		return nil, fmt.Errorf("%w: unsupported alg %q", ErrInvalidToken, h.Alg)
+	}
+	sig, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+	key, err := v.Keys.PublicKey(ctx, h.Kid)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims", ErrInvalidToken)
+	}
+	if err := decodeSegment(parts[1], &claims.Raw); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims", ErrInvalidToken)
+	}
+	if err := v.validate(&claims); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
+
+func (v *Verifier) validate(c *Claims) error {
+	now := clock.OrSystem(v.Clock).Now()
+	leeway := v.Leeway
+	if leeway == 0 {
+		leeway = DefaultLeeway
+	}
+	if c.ExpiresAt == 0 {
+		return fmt.Errorf("%w: exp is required", ErrInvalidToken)
+	}
+	if now.Add(-leeway).After(time.Unix(c.ExpiresAt, 0)) {
+		return fmt.Errorf("%w: token is expired", ErrInvalidToken)
+	}
+	if c.NotBefore != 0 && now.Add(leeway).Before(time.Unix(c.NotBefore, 0)) {
+		return fmt.Errorf("%w: token is not valid yet", ErrInvalidToken)
+	}
+	if v.Issuer != "" && c.Issuer != v.Issuer {
+		return fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, c.Issuer)
+	}
+	if v.Audience != "" && !c.Audience.Contains(v.Audience) {
+		return fmt.Errorf("%w: audience does not include %q", ErrInvalidToken, v.Audience)
+	}
+	return nil
+}
+
+func decodeSegment(seg string, v any) error {
+	b, err := encoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,293 @@
+package jwt_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"teamflow-shared/authz"
+	"teamflow-shared/clock"
+	"teamflow-shared/jwt"
+	"teamflow-shared/workspace"
+)
+
+var now = time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	return key
+}
+
+func validClaims() jwt.Claims {
+	return jwt.Claims{
+		Issuer:      "https://auth.teamflow.test",
+		Subject:     "user-1",
+		Audience:    jwt.Audience{"teamflow"},
+		IssuedAt:    now.Unix(),
+		ExpiresAt:   now.Add(time.Hour).Unix(),
+		WorkspaceID: "acme",
+	}
+}
+
+func sign(t *testing.T, c jwt.Claims, key *rsa.PrivateKey, kid string) string {
+	t.Helper()
+	token, err := jwt.Sign(c, key, kid)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	return token
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	key, other := generateKey(t), generateKey(t)
+	v := &jwt.Verifier{
+		Keys:     jwt.StaticKeys{"k1": &key.PublicKey},
+		Issuer:   "https://auth.teamflow.test",
+		Audience: "teamflow",
+		Clock:    clock.Fixed(now),
+	}
+
+	got, err := v.Verify(context.Background(), sign(t, validClaims(), key, "k1"))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if got.Subject != "user-1" || got.WorkspaceID != "acme" || got.Raw["sub"] != "user-1" {
+		t.Errorf("unexpected claims %+v", got)
+	}
+
+	noneHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","kid":"k1"}`))
+	valid := sign(t, validClaims(), key, "k1")
+	tampered := noneHeader + valid[strings.Index(valid, "."):]
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "malformed", token: "not-a-jwt"},
+		{name: "alg none", token: tampered},
+		{name: "unknown kid", token: sign(t, validClaims(), key, "k2")},
+		{name: "signed by another key", token: sign(t, validClaims(), other, "k1")},
+		{name: "expired", token: sign(t, func() jwt.Claims {
+			c := validClaims()
+			c.ExpiresAt = now.Add(-2 * time.Minute).Unix()
+			return c
+		}(), key, "k1")},
+		{name: "no exp", token: sign(t, func() jwt.Claims {
+			c := validClaims()
+			c.ExpiresAt = 0
+			return c
+		}(), key, "k1")},
+		{name: "not valid yet", token: sign(t, func() jwt.Claims {
+			c := validClaims()
+			c.NotBefore = now.Add(5 * time.Minute).Unix()
+			return c
+		}(), key, "k1")},
+		{name: "other issuer", token: sign(t, func() jwt.Claims {
+			c := validClaims()
+			c.Issuer = "https://evil.test"
+			return c
+		}(), key, "k1")},
+		{name: "other audience", token: sign(t, func() jwt.Claims {
+			c := validClaims()
+			c.Audience = jwt.Audience{"other", "another"}
+			return c
+		}(), key, "k1")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := v.Verify(context.Background(), tt.token); !errors.Is(err, jwt.ErrInvalidToken) {
+				t.Errorf("expected ErrInvalidToken, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifier_Leeway(t *testing.T) {
+	key := generateKey(t)
+	v := &jwt.Verifier{Keys: jwt.StaticKeys{"k1": &key.PublicKey}, Clock: clock.Fixed(now)}
+	c := validClaims()
+	c.ExpiresAt = now.Add(-30 * time.Second).Unix()
+	if _, err := v.Verify(context.Background(), sign(t, c, key, "k1")); err != nil {
+		t.Errorf("expected the token within the leeway to be accepted, got %v", err)
+	}
+}
+
+func TestAudience_JSON(t *testing.T) {
+	var c jwt.Claims
+	if err := json.Unmarshal([]byte(`{"aud":"a"}`), &c); err != nil || !c.Audience.Contains("a") {
+		t.Errorf("string aud: %v %v", c.Audience, err)
+	}
+	if err := json.Unmarshal([]byte(`{"aud":["a","b"]}`), &c); err != nil || !c.Audience.Contains("b") {
+		t.Errorf("array aud: %v %v", c.Audience, err)
+	}
+	b, _ := json.Marshal(jwt.Claims{Audience: jwt.Audience{"a"}})
+	if string(b) != `{"aud":"a"}` {
+		t.Errorf("Marshal() = %s", b)
+	}
+}
+
+func TestJWK_RoundTrip(t *testing.T) {
+	key := generateKey(t)
+	kid := jwt.KeyID(&key.PublicKey)
+	if kid == "" || kid == jwt.KeyID(&generateKey(t).PublicKey) {
+		t.Errorf("unexpected kid %q", kid)
+	}
+	got, err := jwt.NewJWK(kid, &key.PublicKey).PublicKey()
+	if err != nil {
+		t.Fatalf("PublicKey: %v", err)
+	}
+	if !got.Equal(&key.PublicKey) {
+		t.Error("public key changed through JWK")
+	}
+	if _, err := (jwt.JWK{Kty: "EC", Kid: "x"}).PublicKey(); err == nil {
+		t.Error("expected an error for EC keys")
+	}
+}
+
+func TestRemoteKeys(t *testing.T) {
+	key, rotated := generateKey(t), generateKey(t)
+	var fetches atomic.Int32
+	var set atomic.Value
+	set.Store(jwt.JWKS{Keys: []jwt.JWK{jwt.NewJWK("k1", &key.PublicKey)}})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		jwt.Handler(set.Load().(jwt.JWKS)).ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	clk := clock.NewFake(now)
+	keys := jwt.NewRemoteKeys(srv.URL, srv.Client(), clk, time.Hour)
+	ctx := context.Background()
+
+	if _, err := keys.PublicKey(ctx, "k1"); err != nil {
+		t.Fatalf("PublicKey: %v", err)
+	}
+	if _, err := keys.PublicKey(ctx, "k1"); err != nil || fetches.Load() != 1 {
+		t.Fatalf("expected the cached jwks to be used: fetches=%d err=%v", fetches.Load(), err)
+	}
+
+	// 鍵を入れ替えた直後は、未知の kid でも短時間は取得し直さない
+	set.Store(jwt.JWKS{Keys: []jwt.JWK{jwt.NewJWK("k2", &rotated.PublicKey)}})
+	if _, err := keys.PublicKey(ctx, "k2"); !errors.Is(err, jwt.ErrUnknownKey) || fetches.Load() != 1 {
+		t.Fatalf("expected ErrUnknownKey without refetch: fetches=%d err=%v", fetches.Load(), err)
+	}
+	clk.Advance(time.Minute)
+	got, err := keys.PublicKey(ctx, "k2")
+	if err != nil || !got.Equal(&rotated.PublicKey) || fetches.Load() != 2 {
+		t.Fatalf("expected the rotated key after refetch: fetches=%d err=%v", fetches.Load(), err)
+	}
+
+	srv.Close()
+	if _, err := jwt.NewRemoteKeys(srv.URL, nil, clk, 0).PublicKey(ctx, "k1"); err == nil || errors.Is(err, jwt.ErrInvalidToken) {
+		t.Errorf("expected a fetch error, got %v", err)
+	}
+}
+
+func TestNewRemoteVerifier(t *testing.T) {
+	if v := jwt.NewRemoteVerifier("", "teamflow", "teamflow-api"); v != nil {
+		t.Errorf("expected no verifier without a JWKS URL, got %+v", v)
+	}
+
+	key := generateKey(t)
+	srv := httptest.NewServer(jwt.Handler(jwt.JWKS{Keys: []jwt.JWK{jwt.NewJWK("k1", &key.PublicKey)}}))
+	defer srv.Close()
+	v := jwt.NewRemoteVerifier(srv.URL, "teamflow", "teamflow-api")
+	if v == nil || v.Issuer != "teamflow" || v.Audience != "teamflow-api" {
+		t.Fatalf("unexpected verifier %+v", v)
+	}
+	if _, err := v.Keys.PublicKey(context.Background(), "k1"); err != nil {
+		t.Errorf("expected the key from the JWKS URL, got %v", err)
+	}
+}
+
+// serviceCall はサービス API キー（serviceauth.Authenticator.Authenticated）の代わりに、X-Service-Key があれば信頼する。
+func serviceCall(r *http.Request) bool {
+	return r.Header.Get("X-Service-Key") == "s3cr3t"
+}
+
+func TestMiddleware(t *testing.T) {
+	key := generateKey(t)
+	v := &jwt.Verifier{Keys: jwt.StaticKeys{"k1": &key.PublicKey}, Audience: "teamflow", Clock: clock.Fixed(now)}
+	broken := &jwt.Verifier{Keys: jwt.NewRemoteKeys("http://127.0.0.1:1/jwks.json", nil, nil, 0), Clock: clock.Fixed(now)}
+
+	var actor, ws string
+	var authenticated bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor, ws = r.Header.Get(authz.ActorHeader), r.Header.Get(workspace.Header)
+		_, authenticated = jwt.FromContext(r.Context())
+		if authenticated != authz.IdentityVerified(r.Context()) {
+			t.Errorf("expected the verified identity to be recorded with the claims")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	noWorkspace := validClaims()
+	noWorkspace.WorkspaceID = ""
+	noSubject := validClaims()
+	noSubject.Subject = ""
+
+	tests := []struct {
+		name          string
+		verifier      *jwt.Verifier
+		authorization string
+		serviceKey    string
+		wantStatus    int
+		wantActor     string
+		wantWorkspace string
+		wantAuth      bool
+	}{
+		{name: "token identifies the actor", verifier: v, authorization: "Bearer " + sign(t, validClaims(), key, "k1"),
+			wantStatus: http.StatusNoContent, wantActor: "user-1", wantWorkspace: "acme", wantAuth: true},
+		{name: "spoofed workspace is dropped", verifier: v, authorization: "Bearer " + sign(t, noWorkspace, key, "k1"),
+			wantStatus: http.StatusNoContent, wantActor: "user-1", wantAuth: true},
+		{name: "no subject", verifier: v, authorization: "Bearer " + sign(t, noSubject, key, "k1"), wantStatus: http.StatusUnauthorized},
+		{name: "invalid signature", verifier: v, authorization: "Bearer " + sign(t, validClaims(), generateKey(t), "k1"),
+			wantStatus: http.StatusUnauthorized},
+		{name: "jwks unavailable", verifier: broken, authorization: "Bearer " + sign(t, validClaims(), key, "k1"),
+			wantStatus: http.StatusBadGateway},
+		// JWT の無いリクエストはクライアントが送った操作者・ワークスペースを使わない（PAT は pat.Middleware が設定し直す）
+		{name: "personal access tokens pass through", verifier: v, authorization: "Bearer tfp_" + strings.Repeat("a", 40),
+			wantStatus: http.StatusNoContent},
+		{name: "no authorization", verifier: v, wantStatus: http.StatusNoContent},
+		{name: "service call keeps the headers", verifier: v, serviceKey: "s3cr3t",
+			wantStatus: http.StatusNoContent, wantActor: "spoofed", wantWorkspace: "spoofed"},
+		{name: "no verifier", wantStatus: http.StatusNoContent, wantActor: "spoofed", wantWorkspace: "spoofed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actor, ws, authenticated = "", "", false
+			req := httptest.NewRequest(http.MethodGet, "/api/projects", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			req.Header.Set(authz.ActorHeader, "spoofed")
+			req.Header.Set(workspace.Header, "spoofed")
+			if tt.serviceKey != "" {
+				req.Header.Set("X-Service-Key", tt.serviceKey)
+			}
+			w := httptest.NewRecorder()
+			jwt.Middleware(tt.verifier, serviceCall, next).ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if actor != tt.wantActor || ws != tt.wantWorkspace || authenticated != tt.wantAuth {
+				t.Errorf("actor, workspace, authenticated = %q, %q, %v, want %q, %q, %v",
+					actor, ws, authenticated, tt.wantActor, tt.wantWorkspace, tt.wantAuth)
+			}
+		})
+	}
+}
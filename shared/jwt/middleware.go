@@ -0,0 +1,80 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/authz"
+	"teamflow-shared/logging"
+	"teamflow-shared/pat"
+	"teamflow-shared/workspace"
+)
+
+type claimsKey struct{}
+
+// NewContext は Middleware が検証したクレームを ctx に設定する。
+func NewContext(ctx context.Context, c *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, c)
+}
+
+// FromContext は Middleware が検証したクレームを返す。JWT で認証していない場合は false。
+func FromContext(ctx context.Context) (*Claims, bool) {
+	c, ok := ctx.Value(claimsKey{}).(*Claims)
+	return c, ok
+}
+
+// Middleware は Authorization: Bearer <JWT> のリクエストを v で検証し、
+// sub を X-User-ID、workspace_id を X-Workspace-ID（無い場合は既定のワークスペース）に設定して next を呼ぶ
+// （クライアントが送った値は使わない）。
+//
+// JWT の無いリクエスト（PAT の Bearer トークンや Authorization の無いリクエスト）は、trusted が true を返す場合
+// （サービス API キーで認証したサービス間の呼び出し）を除き、X-User-ID と X-Workspace-ID を取り除いてから next に渡す。
+// PAT は後段の pat.Middleware が検証して設定し直す。trusted は nil でもよい（すべて取り除く）。
+//
+//	401  署名・有効期限・発行者・対象者が不正、sub が無い（UNAUTHORIZED）
+//	502  公開鍵（JWKS）を取得できない（BAD_GATEWAY）
+//
+// v が nil の場合は next をそのまま返す（ヘッダは認証済みのゲートウェイが設定したものとして扱う）。
+func Middleware(v *Verifier, trusted func(r *http.Request) bool, next http.Handler) http.Handler {
+	if v == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := pat.BearerToken(r)
+		if !LooksLikeToken(token) {
+			if trusted == nil || !trusted(r) {
+				r = pat.StripIdentity(r)
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		claims, err := v.Verify(r.Context(), token)
+		switch {
+		case errors.Is(err, ErrInvalidToken):
+			logging.FromContext(r.Context()).InfoContext(r.Context(), "rejected jwt", "error", err)
+			apierror.Write(w, http.StatusUnauthorized, apierror.New(apierror.CodeUnauthorized, ErrInvalidToken.Error()))
+			return
+		case err != nil:
+			logging.FromContext(r.Context()).ErrorContext(r.Context(), "failed to verify jwt", "error", err)
+			apierror.Write(w, http.StatusBadGateway, apierror.New(apierror.CodeBadGateway, "failed to fetch the signing keys"))
+			return
+		}
+		if claims.Subject == "" {
+			apierror.Write(w, http.StatusUnauthorized, apierror.New(apierror.CodeUnauthorized, "jwt has no subject"))
+			return
+		}
+
+		// ハンドラは従来どおりヘッダから操作者・ワークスペースを読むため、トークンのクレームで置き換える
+		r = r.Clone(authz.ContextWithVerifiedIdentity(NewContext(r.Context(), claims)))
+		r.Header.Set(authz.ActorHeader, claims.Subject)
+		if claims.WorkspaceID != "" {
+			r.Header.Set(workspace.Header, claims.WorkspaceID)
+		} else {
+			r.Header.Del(workspace.Header)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,95 @@
+// Package loadshed は処理中のリクエスト数に上限を設け、超えた分をすぐに断るミドルウェアを提供する。
+//
+// DB のプールが埋まると、後から来たリクエストは接続の取得を待って積み上がり、すべてのリクエストが遅くなる。
+// 上限を超えたリクエストは待たせずに 503（SERVICE_UNAVAILABLE）と Retry-After を返し、受け付けたリクエストの応答時間を保つ。
+//
+// ヘルスチェック（/livez・/healthz・/readyz）は断らない。過負荷のインスタンスが liveness の失敗で再起動されたり、
+// readiness の失敗で振り分けから外れて残りのインスタンスに負荷が移ったりしないようにするため。
+package loadshed
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"teamflow-shared/apierror"
+)
+
+// DefaultRetryAfter は断ったレスポンスの Retry-After の既定値。
+const DefaultRetryAfter = time.Second
+
+// Policy は Limiter の設定。
+type Policy struct {
+	// MaxInFlight は同時に処理するリクエスト数の上限。0 の場合は制限しない
+	MaxInFlight int
+	// RetryAfter は断ったレスポンスの Retry-After。任意。0 の場合は DefaultRetryAfter
+	RetryAfter time.Duration
+	// Skip は上限に数えないリクエスト（SSE のように長く接続を保つものなど）を判定する。任意。
+	// ヘルスチェック（IsHealthPath）は Skip に関わらず数えない
+	Skip func(r *http.Request) bool
+}
+
+// Limiter は処理中のリクエスト数を数え、上限を超えたリクエストを断る。
+type Limiter struct {
+	policy   Policy
+	inFlight atomic.Int64
+	shed     atomic.Uint64
+}
+
+// NewLimiter は p の上限で制限する Limiter を生成する。
+func NewLimiter(p Policy) *Limiter {
+	if p.RetryAfter <= 0 {
+		p.RetryAfter = DefaultRetryAfter
+	}
+	return &Limiter{policy: p}
+}
+
+// Limit は処理中のリクエスト数の上限を返す。0 は制限しないことを表す。
+func (l *Limiter) Limit() int {
+	return l.policy.MaxInFlight
+}
+
+// InFlight は上限に数えている処理中のリクエスト数を返す。
+func (l *Limiter) InFlight() int {
+	return int(l.inFlight.Load())
+}
+
+// Shed は起動してから上限を超えて断ったリクエスト数を返す。
+func (l *Limiter) Shed() uint64 {
+	return l.shed.Load()
+}
+
+// Middleware は処理中のリクエスト数が上限に達している場合に、next を呼ばずに 503（SERVICE_UNAVAILABLE）と Retry-After を返す。
+// 上限が 0 の場合は next をそのまま返す。
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	if l.policy.MaxInFlight <= 0 {
+		return next
+	}
+	retryAfter := strconv.Itoa(int(math.Ceil(l.policy.RetryAfter.Seconds())))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if IsHealthPath(r.URL.Path) || (l.policy.Skip != nil && l.policy.Skip(r)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if l.inFlight.Add(1) > int64(l.policy.MaxInFlight) {
+			l.inFlight.Add(-1)
+			l.shed.Add(1)
+			w.Header().Set("Retry-After", retryAfter)
+			apierror.Write(w, http.StatusServiceUnavailable, apierror.New(apierror.CodeServiceUnavailable, "server is overloaded, retry later"))
+			return
+		}
+		defer l.inFlight.Add(-1)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// IsHealthPath は path がヘルスチェックのエンドポイント（server.NewMux が登録する /livez・/healthz・/readyz）かどうかを返す。
+func IsHealthPath(path string) bool {
+	switch path {
+	case "/livez", "/healthz", "/readyz":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package loadshed_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"teamflow-shared/loadshed"
+)
+
+// blockingHandler は release が閉じられるまで応答しないハンドラと、処理を始めたことを知らせる started を返す。
+func blockingHandler() (http.Handler, chan struct{}, chan struct{}) {
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	h := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+	return h, started, release
+}
+
+func TestLimiter_Middleware(t *testing.T) {
+	next, started, release := blockingHandler()
+	l := loadshed.NewLimiter(loadshed.Policy{
+		MaxInFlight: 2,
+		RetryAfter:  1500 * time.Millisecond,
+		Skip:        func(r *http.Request) bool { return r.URL.Path == "/api/events" },
+	})
+	h := l.Middleware(next)
+
+	done := make(chan int, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/projects", nil))
+			done <- w.Code
+		}()
+		<-started
+	}
+	if got := l.InFlight(); got != 2 {
+		t.Fatalf("expected 2 requests in flight, got %d", got)
+	}
+
+	// 上限を超えたリクエストは待たずに断る
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/projects", nil))
+	if w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") != "2" {
+		t.Fatalf("expected 503 with Retry-After: 2, got %d %q", w.Code, w.Header().Get("Retry-After"))
+	}
+	var body struct {
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil || body.Error != "SERVICE_UNAVAILABLE" {
+		t.Errorf("expected SERVICE_UNAVAILABLE, got %+v (%v)", body, err)
+	}
+	if got := l.Shed(); got != 1 {
+		t.Errorf("expected 1 shed request, got %d", got)
+	}
+
+	// ヘルスチェックと Skip のリクエストは上限に数えない
+	for _, path := range []string{"/readyz", "/livez", "/api/events"} {
+		go func() {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+			done <- w.Code
+		}()
+		<-started
+	}
+	if got := l.InFlight(); got != 2 {
+		t.Errorf("expected skipped requests not to be counted, got %d in flight", got)
+	}
+
+	close(release)
+	for i := 0; i < 5; i++ {
+		if code := <-done; code != http.StatusOK {
+			t.Errorf("expected accepted requests to succeed, got %d", code)
+		}
+	}
+	if got := l.InFlight(); got != 0 {
+		t.Errorf("expected no requests in flight, got %d", got)
+	}
+	// 処理中のリクエストが終われば再び受け付ける
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	w = httptest.NewRecorder()
+	l.Middleware(ok).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/projects", nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected the request to be accepted after the load drops, got %d", w.Code)
+	}
+}
+
+func TestLimiter_Disabled(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	l := loadshed.NewLimiter(loadshed.Policy{})
+	if l.Limit() != 0 {
+		t.Errorf("expected no limit, got %d", l.Limit())
+	}
+	w := httptest.NewRecorder()
+	l.Middleware(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/projects", nil))
+	if w.Code != http.StatusOK || l.Shed() != 0 {
+		t.Errorf("expected the request to pass through, got %d (shed %d)", w.Code, l.Shed())
+	}
+}
@@ -0,0 +1,57 @@
+// Package logging は tasks / projects サービスで共通の構造化ログ（log/slog の JSON 形式）を提供する。
+//
+// Middleware はリクエストごとに request_id（teamflow-shared/requestid の X-Request-ID）を付けたロガーを context に入れ、
+// 処理の終了時に method / path / status / latency_ms を 1 行で出力する。
+// ハンドラやユースケースは FromContext でロガーを取り出すと、同じ request_id でログを出力できる。
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// ErrInvalidLevel はログレベルが debug / info / warn / error 以外の場合のエラー。
+var ErrInvalidLevel = errors.New("log level must be debug, info, warn or error")
+
+// ParseLevel は文字列からログレベルを返す。空の場合は info とする。前後の空白と大文字小文字は無視する。
+// 未知の値の場合は ErrInvalidLevel を返す。
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrInvalidLevel, s)
+	}
+}
+
+// New は w に JSON 形式で出力するロガーを生成する。level 未満のログは出力しない。
+// 起動後にレベルを変えられるよう、level には *slog.LevelVar も渡せる。
+func New(w io.Writer, level slog.Leveler) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+type contextKey struct{}
+
+// NewContext は l を持つ context を返す。
+func NewContext(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext は context のロガー（Middleware が付けた request_id 付きのもの）を返す。
+// 無い場合は slog.Default() を返す。
+func FromContext(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return l
+	}
+	return slog.Default()
+}
@@ -0,0 +1,151 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"teamflow-shared/logging"
+	"teamflow-shared/requestid"
+)
+
+func TestParseLevel(t *testing.T) {
+	for _, tt := range []struct {
+		in      string
+		want    slog.Level
+		wantErr bool
+	}{
+		{in: "", want: slog.LevelInfo},
+		{in: "debug", want: slog.LevelDebug},
+		{in: " WARN ", want: slog.LevelWarn},
+		{in: "error", want: slog.LevelError},
+		{in: "verbose", wantErr: true},
+	} {
+		got, err := logging.ParseLevel(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, logging.ErrInvalidLevel) {
+				t.Errorf("%q: expected ErrInvalidLevel, got %v", tt.in, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%q: expected %v, got %v (err=%v)", tt.in, tt.want, got, err)
+		}
+	}
+}
+
+// decodeLines は JSON 形式のログを 1 行ずつデコードする。
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var line map[string]any
+		if err := dec.Decode(&line); err != nil {
+			t.Fatalf("failed to decode log line: %v", err)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, slog.LevelInfo)
+
+	handler := logging.Middleware(logger, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logging.FromContext(r.Context()).Info("handling")
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/projects?limit=1", nil))
+
+	lines := decodeLines(t, &buf)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d", len(lines))
+	}
+	inner, req := lines[0], lines[1]
+	if inner["request_id"] == nil || inner["request_id"] != req["request_id"] {
+		t.Errorf("expected the same request_id, got %v and %v", inner["request_id"], req["request_id"])
+	}
+	if req["msg"] != "request" || req["level"] != "INFO" || req["method"] != "POST" || req["path"] != "/api/projects" || req["status"] != float64(http.StatusTeapot) {
+		t.Errorf("unexpected request log: %v", req)
+	}
+	if _, ok := req["latency_ms"].(float64); !ok {
+		t.Errorf("expected latency_ms, got %v", req["latency_ms"])
+	}
+}
+
+func TestMiddleware_RequestID(t *testing.T) {
+	var buf bytes.Buffer
+	handler := requestid.Middleware(logging.Middleware(logging.New(&buf, slog.LevelInfo), http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(requestid.Header, "req-1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if lines := decodeLines(t, &buf); len(lines) != 1 || lines[0]["request_id"] != "req-1" {
+		t.Errorf("expected request_id req-1, got %v", lines)
+	}
+}
+
+func TestMiddleware_StatusAndLevel(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantStatus float64
+		wantLevel  string
+	}{
+		{name: "implicit 200", handler: func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte("ok")) }, wantStatus: 200, wantLevel: "INFO"},
+		{name: "nothing written", handler: func(http.ResponseWriter, *http.Request) {}, wantStatus: 200, wantLevel: "INFO"},
+		{name: "server error", handler: func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusBadGateway) }, wantStatus: 502, wantLevel: "ERROR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logging.Middleware(logging.New(&buf, slog.LevelInfo), tt.handler).
+				ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+			lines := decodeLines(t, &buf)
+			if len(lines) != 1 || lines[0]["status"] != tt.wantStatus || lines[0]["level"] != tt.wantLevel {
+				t.Errorf("unexpected log: %v", lines)
+			}
+		})
+	}
+}
+
+func TestMiddleware_Flush(t *testing.T) {
+	var buf bytes.Buffer
+	handler := logging.Middleware(logging.New(&buf, slog.LevelInfo), http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// SSE ハンドラは http.Flusher を前提にする
+		f, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("expected ResponseWriter to implement http.Flusher")
+		}
+		_, _ = w.Write([]byte("data: x\n\n"))
+		f.Flush()
+	}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if !w.Flushed {
+		t.Error("expected the underlying writer to be flushed")
+	}
+}
+
+func TestMiddleware_Level(t *testing.T) {
+	var buf bytes.Buffer
+	logging.Middleware(logging.New(&buf, slog.LevelWarn), http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})).
+		ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if buf.Len() != 0 {
+		t.Errorf("expected no log below the level, got %s", buf.String())
+	}
+}
+
+func TestFromContext_Default(t *testing.T) {
+	if logging.FromContext(httptest.NewRequest(http.MethodGet, "/", nil).Context()) != slog.Default() {
+		t.Error("expected slog.Default() without a request logger")
+	}
+}
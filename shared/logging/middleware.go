@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"teamflow-shared/requestid"
+)
+
+// Middleware はリクエストごとに request_id を付けたロガーを context に入れて next を呼び、
+// 終了時にリクエストのログを 1 行出力する。5xx は error、それ以外は info で出力する。
+// request_id は requestid.Middleware が設定した ID を使う（内側に置いた場合など、無い場合はここで生成する）。
+func Middleware(logger *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		id := requestid.FromContext(r.Context())
+		if id == "" {
+			id = requestid.New()
+		}
+		l := logger.With(slog.String("request_id", id))
+		rec := &statusRecorder{ResponseWriter: w}
+
+		next.ServeHTTP(rec, r.WithContext(NewContext(r.Context(), l)))
+
+		level := slog.LevelInfo
+		if rec.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		l.LogAttrs(r.Context(), level, "request",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", rec.statusCode()),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+		)
+	})
+}
+
+// statusRecorder はレスポンスのステータスコードを記録する ResponseWriter。
+// SSE のストリーミングが動くよう、Flush と Unwrap（http.ResponseController 用）を引き継ぐ。
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if r.status == 0 {
+		r.status = code
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *statusRecorder) Flush() {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// statusCode は記録したステータスコードを返す。何も書き込まれなかった場合は 200（net/http の既定）。
+func (r *statusRecorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
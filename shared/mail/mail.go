@@ -0,0 +1,158 @@
+// Package mail は通知メールを送る Sender を提供する。
+//
+// 送信方法は Config.Driver で選ぶ（log / smtp / sendgrid）。
+//   - smtp: SMTP サーバーに送る（サーバーが対応していれば STARTTLS で暗号化し、ユーザー名があれば PLAIN 認証する）
+//   - sendgrid: SendGrid の Web API（v3 /mail/send）で送る
+//
+// 本文はプレーンテキスト（UTF-8）。送信に失敗した場合はエラーを返すだけで再試行しない。
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	netmail "net/mail"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Message は送信するメール。
+type Message struct {
+	// To は宛先のメールアドレス（1 件）
+	To      string
+	Subject string
+	// Body はプレーンテキストの本文
+	Body string
+}
+
+// Sender はメールを送る。
+type Sender interface {
+	Send(ctx context.Context, m Message) error
+}
+
+// Driver はメールの送信方法。
+type Driver string
+
+const (
+	DriverLog      Driver = "log"      // ログに出力するだけ（メールを送らない開発用）
+	DriverSMTP     Driver = "smtp"     // SMTP サーバー
+	DriverSendGrid Driver = "sendgrid" // SendGrid の Web API
+)
+
+// 設定の既定値。
+const (
+	DefaultSendGridURL = "https://api.sendgrid.com"
+	// DefaultTimeout は 1 通の送信（接続を含む）のタイムアウト。
+	DefaultTimeout = 10 * time.Second
+)
+
+// ParseDriver は NOTIFY_EMAIL の値を Driver に変換する。空の場合は DriverLog。
+func ParseDriver(s string) (Driver, error) {
+	switch d := Driver(s); d {
+	case "":
+		return DriverLog, nil
+	case DriverLog, DriverSMTP, DriverSendGrid:
+		return d, nil
+	default:
+		return "", fmt.Errorf("must be one of log, smtp or sendgrid, got %q", s)
+	}
+}
+
+// Config はメールの送信の設定。
+type Config struct {
+	Driver Driver
+	// From は送信元のアドレス（例: TeamFlow <noreply@example.com>）。DriverSMTP / DriverSendGrid では必須
+	From string
+	// SMTPAddr は SMTP サーバーの host:port。DriverSMTP では必須
+	SMTPAddr string
+	// SMTPUsername / SMTPPassword は SMTP の認証情報。任意。空の場合は認証しない
+	SMTPUsername string
+	SMTPPassword string
+	// SendGridAPIKey は SendGrid の API キー。DriverSendGrid では必須
+	SendGridAPIKey string
+	// SendGridURL は SendGrid の API のベース URL。任意。空の場合は DefaultSendGridURL
+	SendGridURL string
+}
+
+// Validate は Driver に必要な設定があるか、アドレスや URL が正しいかを検証する。
+func (c Config) Validate() error {
+	switch c.Driver {
+	case DriverLog, "":
+		return nil
+	case DriverSMTP, DriverSendGrid:
+	default:
+		return fmt.Errorf("unknown email driver %q", c.Driver)
+	}
+	if c.From == "" {
+		return errors.New("from address is required")
+	}
+	if _, err := netmail.ParseAddress(c.From); err != nil {
+		return fmt.Errorf("invalid from address %q: %w", c.From, err)
+	}
+	if c.Driver == DriverSMTP {
+		if c.SMTPAddr == "" {
+			return errors.New("SMTP address is required")
+		}
+		if _, port, err := net.SplitHostPort(c.SMTPAddr); err != nil || port == "" {
+			return fmt.Errorf("invalid SMTP address %q: must be host:port", c.SMTPAddr)
+		}
+		return nil
+	}
+	if c.SendGridAPIKey == "" {
+		return errors.New("SendGrid API key is required")
+	}
+	if c.SendGridURL != "" {
+		if u, err := url.Parse(c.SendGridURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid SendGrid URL %q: must be an absolute http(s) URL", c.SendGridURL)
+		}
+	}
+	return nil
+}
+
+// New は cfg の Sender を生成する。サーバーには送信のたびに接続するため、New の時点では接続しない。
+func New(cfg Config) (Sender, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	switch cfg.Driver {
+	case DriverSMTP:
+		return NewSMTPSender(cfg.SMTPAddr, cfg.From, cfg.SMTPUsername, cfg.SMTPPassword), nil
+	case DriverSendGrid:
+		return NewSendGridSender(orDefault(cfg.SendGridURL, DefaultSendGridURL), cfg.SendGridAPIKey, cfg.From,
+			&http.Client{Timeout: DefaultTimeout}), nil
+	default:
+		return LogSender{}, nil
+	}
+}
+
+// LogSender はメールをログに出力するだけの Sender。送信方法を設定していない構成（開発用）で使う。
+type LogSender struct{}
+
+// Send は宛先と件名を Info レベルでログに出力する（本文は出力しない）。
+func (LogSender) Send(ctx context.Context, m Message) error {
+	slog.InfoContext(ctx, "email", "to", m.To, "subject", m.Subject)
+	return nil
+}
+
+// validate は宛先のアドレスと、ヘッダに入れる件名に改行が無いかを検証する（ヘッダインジェクションを防ぐ）。
+func (m Message) validate() (*netmail.Address, error) {
+	to, err := netmail.ParseAddress(m.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient %q: %w", m.To, err)
+	}
+	if strings.ContainsAny(m.Subject, "\r\n") {
+		return nil, errors.New("subject must not contain line breaks")
+	}
+	return to, nil
+}
+
+func orDefault(s, def string) string {
+	if s != "" {
+		return s
+	}
+	return def
+}
@@ -0,0 +1,209 @@
+package mail_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"teamflow-shared/mail"
+)
+
+func TestParseDriver(t *testing.T) {
+	for in, want := range map[string]mail.Driver{"": mail.DriverLog, "log": mail.DriverLog, "smtp": mail.DriverSMTP, "sendgrid": mail.DriverSendGrid} {
+		if got, err := mail.ParseDriver(in); err != nil || got != want {
+			t.Errorf("ParseDriver(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := mail.ParseDriver("ses"); err == nil {
+		t.Error("expected an error for unknown drivers")
+	}
+}
+
+func TestNew(t *testing.T) {
+	s, err := mail.New(mail.Config{Driver: mail.DriverLog})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.(mail.LogSender); !ok {
+		t.Errorf("expected LogSender, got %T", s)
+	}
+	s, err = mail.New(mail.Config{Driver: mail.DriverSMTP, From: "TeamFlow <noreply@example.com>", SMTPAddr: "smtp.example.com:587"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.(*mail.SMTPSender); !ok {
+		t.Errorf("expected SMTPSender, got %T", s)
+	}
+	s, err = mail.New(mail.Config{Driver: mail.DriverSendGrid, From: "noreply@example.com", SendGridAPIKey: "key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.(*mail.SendGridSender); !ok {
+		t.Errorf("expected SendGridSender, got %T", s)
+	}
+
+	for _, cfg := range []mail.Config{
+		{Driver: mail.DriverSMTP, SMTPAddr: "smtp.example.com:587"},
+		{Driver: mail.DriverSMTP, From: "not an address", SMTPAddr: "smtp.example.com:587"},
+		{Driver: mail.DriverSMTP, From: "noreply@example.com"},
+		{Driver: mail.DriverSMTP, From: "noreply@example.com", SMTPAddr: "smtp.example.com"},
+		{Driver: mail.DriverSendGrid, From: "noreply@example.com"},
+		{Driver: mail.DriverSendGrid, From: "noreply@example.com", SendGridAPIKey: "key", SendGridURL: "api.sendgrid.com"},
+		{Driver: "ses"},
+	} {
+		if _, err := mail.New(cfg); err == nil {
+			t.Errorf("expected an error for %+v", cfg)
+		}
+	}
+}
+
+func TestSenders_RejectInvalidMessages(t *testing.T) {
+	s := mail.NewSendGridSender("http://127.0.0.1:1", "key", "noreply@example.com", http.DefaultClient)
+	for _, m := range []mail.Message{
+		{To: "not an address", Subject: "hi"},
+		{To: "a@example.com", Subject: "hi\r\nBcc: b@example.com"},
+	} {
+		if err := s.Send(context.Background(), m); err == nil || strings.Contains(err.Error(), "sendgrid") {
+			t.Errorf("Send(%+v) = %v, want a validation error", m, err)
+		}
+	}
+}
+
+func TestSendGridSender_Send(t *testing.T) {
+	var (
+		gotPath, gotAuth string
+		gotBody          map[string]any
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAuth = r.URL.Path, r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	s := mail.NewSendGridSender(srv.URL+"/", "sg-key", "TeamFlow <noreply@example.com>", srv.Client())
+	if err := s.Send(context.Background(), mail.Message{To: "alice@example.com", Subject: "件名", Body: "本文"}); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if gotPath != "/v3/mail/send" || gotAuth != "Bearer sg-key" {
+		t.Errorf("path = %q, auth = %q", gotPath, gotAuth)
+	}
+	b, _ := json.Marshal(gotBody)
+	for _, want := range []string{`"email":"alice@example.com"`, `"from":{"email":"noreply@example.com","name":"TeamFlow"}`, `"subject":"件名"`, `"value":"本文"`} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("request body %s does not contain %s", b, want)
+		}
+	}
+}
+
+func TestSendGridSender_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errors":[{"message":"forbidden"}]}`, http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	s := mail.NewSendGridSender(srv.URL, "bad", "noreply@example.com", srv.Client())
+	err := s.Send(context.Background(), mail.Message{To: "alice@example.com", Subject: "s", Body: "b"})
+	if err == nil || !strings.Contains(err.Error(), "status 403") {
+		t.Errorf("Send = %v, want status 403", err)
+	}
+}
+
+// fakeSMTP は 1 通だけ受け取る最小限の SMTP サーバー（STARTTLS・認証には対応しない）。
+func fakeSMTP(t *testing.T) (addr string, received <-chan []string) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = lis.Close() })
+	ch := make(chan []string, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(s string) { _, _ = io.WriteString(conn, s+"\r\n") }
+		var lines []string
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			lines = append(lines, line)
+			switch cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); cmd {
+			case "EHLO":
+				reply("250 localhost")
+			case "DATA":
+				reply("354 go ahead")
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					l = strings.TrimRight(l, "\r\n")
+					if l == "." {
+						break
+					}
+					lines = append(lines, l)
+				}
+				reply("250 queued")
+			case "QUIT":
+				reply("221 bye")
+				ch <- lines
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+	return lis.Addr().String(), ch
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	addr, received := fakeSMTP(t)
+	s := mail.NewSMTPSender(addr, "TeamFlow <noreply@example.com>", "", "")
+	if err := s.Send(context.Background(), mail.Message{To: "alice@example.com", Subject: "タスクの担当者になりました", Body: "本文です"}); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	lines := <-received
+	text := strings.Join(lines, "\n")
+	for _, want := range []string{
+		"MAIL FROM:<noreply@example.com>",
+		"RCPT TO:<alice@example.com>",
+		`From: "TeamFlow" <noreply@example.com>`,
+		"To: <alice@example.com>",
+		"Subject: =?UTF-8?b?",
+		"Content-Type: text/plain; charset=UTF-8",
+		base64.StdEncoding.EncodeToString([]byte("本文です")),
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("session does not contain %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestSMTPSender_ConnectionError(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := lis.Addr().String()
+	_ = lis.Close()
+
+	s := mail.NewSMTPSender(addr, "noreply@example.com", "", "")
+	if err := s.Send(context.Background(), mail.Message{To: "alice@example.com", Subject: "s", Body: "b"}); err == nil {
+		t.Error("expected an error when the server is unavailable")
+	}
+}
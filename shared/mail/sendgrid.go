@@ -0,0 +1,94 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	netmail "net/mail"
+	"strings"
+)
+
+// SendGridSender は SendGrid の Web API（v3 /mail/send）でメールを送る Sender。
+type SendGridSender struct {
+	endpoint string
+	apiKey   string
+	from     *netmail.Address
+	client   *http.Client
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ Sender = (*SendGridSender)(nil)
+
+// NewSendGridSender は baseURL の SendGrid の API で from から送る SendGridSender を生成する。
+// from は Config.Validate で検証済みのアドレスにする。
+func NewSendGridSender(baseURL, apiKey, from string, client *http.Client) *SendGridSender {
+	fromAddr, err := netmail.ParseAddress(from)
+	if err != nil {
+		fromAddr = &netmail.Address{Address: from}
+	}
+	return &SendGridSender{
+		endpoint: strings.TrimRight(baseURL, "/") + "/v3/mail/send",
+		apiKey:   apiKey,
+		from:     fromAddr,
+		client:   client,
+	}
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+}
+
+// Send は m を送る。2xx 以外の応答はエラーを返す（SendGrid は受け付けると 202 を返す）。
+func (s *SendGridSender) Send(ctx context.Context, m Message) error {
+	to, err := m.validate()
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(sendGridRequest{
+		Personalizations: []sendGridPersonalization{{To: []sendGridAddress{{Email: to.Address, Name: to.Name}}}},
+		From:             sendGridAddress{Email: s.from.Address, Name: s.from.Name},
+		Subject:          m.Subject,
+		Content:          []sendGridContent{{Type: "text/plain", Value: m.Body}},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send email via sendgrid: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return fmt.Errorf("failed to send email via sendgrid: status %d: %s", resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+	return nil
+}
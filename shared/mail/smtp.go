@@ -0,0 +1,118 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"time"
+)
+
+// SMTPSender は SMTP サーバーにメールを送る Sender。
+// サーバーが STARTTLS に対応していれば暗号化してから認証・送信する。
+type SMTPSender struct {
+	addr     string
+	host     string
+	from     *netmail.Address
+	username string
+	password string
+	// TLSConfig は STARTTLS の設定。任意。nil の場合は addr のホスト名で証明書を検証する
+	TLSConfig *tls.Config
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ Sender = (*SMTPSender)(nil)
+
+// NewSMTPSender は addr（host:port）の SMTP サーバーに from から送る SMTPSender を生成する。
+// username が空の場合は認証しない。from は Config.Validate で検証済みのアドレスにする。
+func NewSMTPSender(addr, from, username, password string) *SMTPSender {
+	host, _, _ := net.SplitHostPort(addr)
+	fromAddr, err := netmail.ParseAddress(from)
+	if err != nil {
+		fromAddr = &netmail.Address{Address: from}
+	}
+	return &SMTPSender{addr: addr, host: host, from: fromAddr, username: username, password: password}
+}
+
+// Send は m を送る。ctx の期限（無い場合は DefaultTimeout）を接続全体のタイムアウトにする。
+func (s *SMTPSender) Send(ctx context.Context, m Message) error {
+	to, err := m.validate()
+	if err != nil {
+		return err
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(DefaultTimeout)
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect SMTP server: %w", err)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		cfg := s.TLSConfig
+		if cfg == nil {
+			cfg = &tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12}
+		}
+		if err := c.StartTLS(cfg); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if s.username != "" {
+		// smtp.PlainAuth は TLS でない接続では localhost 以外への認証を拒否する
+		if err := c.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return fmt.Errorf("failed to authenticate SMTP: %w", err)
+		}
+	}
+	if err := c.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM failed: %w", err)
+	}
+	if err := c.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("SMTP RCPT TO failed: %w", err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA failed: %w", err)
+	}
+	if _, err := w.Write(s.compose(to, m, time.Now())); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("SMTP DATA failed: %w", err)
+	}
+	return c.Quit()
+}
+
+// compose は m を RFC 5322 のメッセージにする。件名は MIME のエンコード、本文は base64 で UTF-8 をそのまま送れるようにする。
+func (s *SMTPSender) compose(to *netmail.Address, m Message, now time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", s.from.String())
+	fmt.Fprintf(&b, "To: %s\r\n", to.String())
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", m.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+	body := base64.StdEncoding.EncodeToString([]byte(m.Body))
+	for len(body) > 76 {
+		b.WriteString(body[:76] + "\r\n")
+		body = body[76:]
+	}
+	b.WriteString(body + "\r\n")
+	return b.Bytes()
+}
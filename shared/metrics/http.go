@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// HTTPMetrics は HTTP サーバーのリクエスト数・処理時間・処理中のリクエスト数。
+type HTTPMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight prometheus.Gauge
+	route    func(path string) string
+}
+
+// NewHTTPMetrics は namespace（サービス名）を接頭辞にした HTTP のメトリクスを reg に登録する。
+// route はパスを route ラベルの値（/api/projects/{id} など）に変換する。ID をそのままラベルにしないため。
+func NewHTTPMetrics(reg prometheus.Registerer, namespace string, route func(path string) string) *HTTPMetrics {
+	f := promauto.With(reg)
+	return &HTTPMetrics{
+		requests: f.NewCounterVec(prometheus.CounterOpts{
+			Name: namespace + "_http_requests_total",
+			Help: "Number of HTTP requests.",
+		}, []string{"route", "method", "status"}),
+		duration: f.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    namespace + "_http_request_duration_seconds",
+			Help:    "Duration of HTTP requests.",
+			Buckets: DefaultBuckets,
+		}, []string{"route", "method", "status"}),
+		inFlight: f.NewGauge(prometheus.GaugeOpts{
+			Name: namespace + "_http_requests_in_flight",
+			Help: "Number of HTTP requests being served.",
+		}),
+		route: route,
+	}
+}
+
+// Middleware は next のリクエストを計測する。
+func (m *HTTPMetrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		m.inFlight.Inc()
+		defer m.inFlight.Dec()
+
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+
+		labels := []string{m.route(r.URL.Path), r.Method, strconv.Itoa(rec.statusCode())}
+		m.requests.WithLabelValues(labels...).Inc()
+		m.duration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+	})
+}
+
+// LoadShedder は処理中のリクエスト数の上限と、上限を超えて断ったリクエスト数を返す（loadshed.Limiter）。
+type LoadShedder interface {
+	Limit() int
+	Shed() uint64
+}
+
+// RegisterLoadShedMetrics は namespace（サービス名）を接頭辞にした l の上限と断ったリクエスト数を reg に登録する。
+// 処理中のリクエスト数は NewHTTPMetrics の *_http_requests_in_flight と比べる。
+func RegisterLoadShedMetrics(reg prometheus.Registerer, namespace string, l LoadShedder) {
+	f := promauto.With(reg)
+	f.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: namespace + "_http_max_in_flight_requests",
+		Help: "Maximum number of HTTP requests served concurrently (0 means unlimited).",
+	}, func() float64 { return float64(l.Limit()) })
+	f.NewCounterFunc(prometheus.CounterOpts{
+		Name: namespace + "_http_requests_shed_total",
+		Help: "Number of HTTP requests rejected with 503 because too many requests were in flight.",
+	}, func() float64 { return float64(l.Shed()) })
+}
+
+// statusRecorder はレスポンスのステータスコードを記録する ResponseWriter。
+// SSE のストリーミングが動くよう、Flush と Unwrap（http.ResponseController 用）を引き継ぐ。
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if r.status == 0 {
+		r.status = code
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *statusRecorder) Flush() {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// statusCode は記録したステータスコードを返す。何も書き込まれなかった場合は 200（net/http の既定）。
+func (r *statusRecorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
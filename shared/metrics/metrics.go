@@ -0,0 +1,9 @@
+// Package metrics は各サービスで共通の Prometheus のメトリクスを prometheus/client_golang で登録する。
+//
+// 各サービスのメトリクスは promauto（prometheus.DefaultRegisterer）で定義し、管理用ポートの /metrics で
+// promhttp.Handler が Go ランタイム・プロセスのメトリクスと合わせて出力する。
+// このパッケージは HTTP・負荷制限・DB のプールのように、どのサービスでも同じ形のメトリクスだけを持つ。
+package metrics
+
+// DefaultBuckets はレイテンシ（秒）用の既定のバケット（DB の問い合わせを測るため prometheus.DefBuckets より細かい 1ms から）。
+var DefaultBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// sampleCount はヒストグラムの観測回数を返す。
+func sampleCount(t *testing.T, o prometheus.Observer) uint64 {
+	t.Helper()
+	var m dto.Metric
+	if err := o.(prometheus.Metric).Write(&m); err != nil {
+		t.Fatalf("failed to read histogram: %v", err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestHTTPMetrics_Middleware(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewHTTPMetrics(reg, "test", func(string) string { return "/items/{id}" })
+
+	var inFlight float64
+	handler := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inFlight = testutil.ToFloat64(m.inFlight)
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/1", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/2", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/items/3", nil))
+
+	if inFlight != 1 {
+		t.Errorf("expected 1 request in flight while serving, got %v", inFlight)
+	}
+	if v := testutil.ToFloat64(m.inFlight); v != 0 {
+		t.Errorf("expected no requests in flight, got %v", v)
+	}
+	if v := testutil.ToFloat64(m.requests.WithLabelValues("/items/{id}", http.MethodGet, "200")); v != 2 {
+		t.Errorf("expected 2 GET requests, got %v", v)
+	}
+	if n := sampleCount(t, m.duration.WithLabelValues("/items/{id}", http.MethodDelete, "404")); n != 1 {
+		t.Errorf("expected 1 DELETE observation, got %d", n)
+	}
+}
+
+func TestHTTPMetrics_DuplicateNamePanics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	NewHTTPMetrics(reg, "dup", func(p string) string { return p })
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on duplicate metric name")
+		}
+	}()
+	NewHTTPMetrics(reg, "dup", func(p string) string { return p })
+}
+
+type fakeShedder struct{}
+
+func (fakeShedder) Limit() int   { return 64 }
+func (fakeShedder) Shed() uint64 { return 3 }
+
+func TestRegisterLoadShedMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	RegisterLoadShedMetrics(reg, "test", fakeShedder{})
+
+	want := `# HELP test_http_max_in_flight_requests Maximum number of HTTP requests served concurrently (0 means unlimited).
+# TYPE test_http_max_in_flight_requests gauge
+test_http_max_in_flight_requests 64
+# HELP test_http_requests_shed_total Number of HTTP requests rejected with 503 because too many requests were in flight.
+# TYPE test_http_requests_shed_total counter
+test_http_requests_shed_total 3
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRegisterPoolMetrics(t *testing.T) {
+	// pgxpool は最初の Acquire まで接続しないため、DB 無しで統計を確認できる
+	pool, err := pgxpool.New(context.Background(), "postgres://localhost:5432/teamflow?pool_max_conns=4")
+	if err != nil {
+		t.Fatalf("failed to create pool: %v", err)
+	}
+	defer pool.Close()
+
+	reg := prometheus.NewRegistry()
+	RegisterPoolMetrics(reg, "test", pool)
+
+	want := `# HELP test_db_pool_acquired_conns Number of connections currently in use.
+# TYPE test_db_pool_acquired_conns gauge
+test_db_pool_acquired_conns 0
+# HELP test_db_pool_acquires_total Number of successful connection acquires.
+# TYPE test_db_pool_acquires_total counter
+test_db_pool_acquires_total 0
+# HELP test_db_pool_max_conns Maximum number of connections in the database pool.
+# TYPE test_db_pool_max_conns gauge
+test_db_pool_max_conns 4
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want),
+		"test_db_pool_max_conns", "test_db_pool_acquired_conns", "test_db_pool_acquires_total"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// RegisterPoolMetrics は namespace（サービス名）を接頭辞にした pool の統計（接続数・接続の取得回数と待ち時間）を
+// reg に登録する。値は出力のたびに読み取る。
+func RegisterPoolMetrics(reg prometheus.Registerer, namespace string, pool *pgxpool.Pool) {
+	f := promauto.With(reg)
+	gauge := func(name, help string, v func(*pgxpool.Stat) float64) {
+		f.NewGaugeFunc(prometheus.GaugeOpts{Name: namespace + "_db_pool_" + name, Help: help},
+			func() float64 { return v(pool.Stat()) })
+	}
+	counter := func(name, help string, v func(*pgxpool.Stat) float64) {
+		f.NewCounterFunc(prometheus.CounterOpts{Name: namespace + "_db_pool_" + name, Help: help},
+			func() float64 { return v(pool.Stat()) })
+	}
+	gauge("max_conns", "Maximum number of connections in the database pool.",
+		func(s *pgxpool.Stat) float64 { return float64(s.MaxConns()) })
+	gauge("total_conns", "Number of open connections in the database pool.",
+		func(s *pgxpool.Stat) float64 { return float64(s.TotalConns()) })
+	gauge("acquired_conns", "Number of connections currently in use.",
+		func(s *pgxpool.Stat) float64 { return float64(s.AcquiredConns()) })
+	gauge("idle_conns", "Number of idle connections in the database pool.",
+		func(s *pgxpool.Stat) float64 { return float64(s.IdleConns()) })
+	counter("acquires_total", "Number of successful connection acquires.",
+		func(s *pgxpool.Stat) float64 { return float64(s.AcquireCount()) })
+	counter("empty_acquires_total", "Number of acquires that waited because the pool was empty.",
+		func(s *pgxpool.Stat) float64 { return float64(s.EmptyAcquireCount()) })
+	counter("acquire_wait_seconds_total", "Total time spent waiting for a connection.",
+		func(s *pgxpool.Stat) float64 { return s.AcquireDuration().Seconds() })
+}
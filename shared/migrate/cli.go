@@ -0,0 +1,90 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Command は migrate サブコマンドの引数をパースした結果。
+type Command struct {
+	Action string // up, down, version
+	Steps  int    // down のみ。0 はすべて巻き戻す
+}
+
+// ParseArgs は "<program> migrate" 以降の引数をパースする。program は使い方の表示に使う。
+//
+//	<program> migrate up
+//	<program> migrate down [steps]   （steps 省略時は 1）
+//	<program> migrate version
+func ParseArgs(program string, args []string) (Command, error) {
+	if len(args) == 0 {
+		return Command{}, fmt.Errorf("usage: %s migrate <up|down [steps]|version>", program)
+	}
+
+	cmd := Command{Action: args[0]}
+	switch cmd.Action {
+	case "up", "version":
+		if len(args) > 1 {
+			return Command{}, fmt.Errorf("migrate %s takes no arguments", cmd.Action)
+		}
+	case "down":
+		cmd.Steps = 1
+		if len(args) > 2 {
+			return Command{}, errors.New("migrate down takes at most one argument")
+		}
+		if len(args) == 2 {
+			steps, err := strconv.Atoi(args[1])
+			if err != nil || steps < 0 {
+				return Command{}, fmt.Errorf("invalid steps %q: must be a non-negative integer", args[1])
+			}
+			cmd.Steps = steps
+		}
+	default:
+		return Command{}, fmt.Errorf("unknown migrate action %q", cmd.Action)
+	}
+	return cmd, nil
+}
+
+// Run は各サービスの migrate サブコマンド（"<program> migrate ..."）を dsn の DB に対して実行する。
+func Run(ctx context.Context, dsn string, cfg Config, program string, args []string) error {
+	cmd, err := ParseArgs(program, args)
+	if err != nil {
+		return err
+	}
+	if dsn == "" {
+		return errors.New("DB_DSN must be set to run migrations")
+	}
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return fmt.Errorf("failed to connect database: %w", err)
+	}
+	defer pool.Close()
+
+	m, err := New(pool, cfg)
+	if err != nil {
+		return err
+	}
+
+	switch cmd.Action {
+	case "up":
+		err = m.Up(ctx)
+	case "down":
+		err = m.Down(ctx, cmd.Steps)
+	}
+	if err != nil {
+		return err
+	}
+
+	version, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	slog.Info("schema version", "version", version)
+	return nil
+}
@@ -0,0 +1,248 @@
+// Package migrate は各サービスに埋め込んだ SQL マイグレーションを適用・巻き戻す。
+//
+// マイグレーションは "<version>_<name>.up.sql" / "<version>_<name>.down.sql" の組で置く。
+// 適用済みバージョンはサービスごとのテーブル（Config.Table）で管理し、同じ DB を複数のサービスで使っても衝突しないようにする。
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Config はサービスのマイグレーションの設定。
+type Config struct {
+	// FS と Dir はマイグレーションのファイルを置いたディレクトリ（go:embed の embed.FS など）
+	FS  fs.FS
+	Dir string
+	// Table は適用済みバージョンを記録するテーブル（例: schema_migrations, projects_schema_migrations）
+	Table string
+	// LockKey は複数プロセス（テストパッケージの並列実行など）からの同時適用を直列化する advisory lock のキー
+	LockKey int64
+}
+
+// Migration は 1 バージョン分のマイグレーション。
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Migrator はマイグレーションの適用・巻き戻しを行う。
+type Migrator struct {
+	db         *pgxpool.Pool
+	name       string // バージョンのテーブル名（エラーメッセージ用）
+	table      string // 識別子としてクォートした name
+	lockKey    int64
+	migrations []Migration
+}
+
+// New は cfg のマイグレーションを読み込んだ Migrator を生成する。
+func New(db *pgxpool.Pool, cfg Config) (*Migrator, error) {
+	if cfg.Table == "" {
+		return nil, errors.New("migrate: table is required")
+	}
+	migrations, err := Load(cfg.FS, cfg.Dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		db:         db,
+		name:       cfg.Table,
+		table:      pgx.Identifier{cfg.Table}.Sanitize(),
+		lockKey:    cfg.LockKey,
+		migrations: migrations,
+	}, nil
+}
+
+// Load は fsys の dir 配下からマイグレーションを読み込み、バージョン昇順で返す。
+// up / down の片方しか無い場合や、バージョンが重複する場合はエラーを返す。
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		version, name, direction, err := parseFilename(e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", e.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if m.Name != name {
+			return nil, fmt.Errorf("migration version %d has conflicting names: %s, %s", version, m.Name, name)
+		}
+
+		switch direction {
+		case "up":
+			m.Up = string(b)
+		case "down":
+			m.Down = string(b)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// parseFilename は "0001_create_tasks.up.sql" を (1, "create_tasks", "up") に分解する。
+func parseFilename(filename string) (int64, string, string, error) {
+	base, ok := strings.CutSuffix(filename, ".sql")
+	if !ok {
+		return 0, "", "", fmt.Errorf("invalid migration filename %q: must end with .sql", filename)
+	}
+
+	var direction string
+	switch {
+	case strings.HasSuffix(base, ".up"):
+		direction = "up"
+	case strings.HasSuffix(base, ".down"):
+		direction = "down"
+	default:
+		return 0, "", "", fmt.Errorf("invalid migration filename %q: must end with .up.sql or .down.sql", filename)
+	}
+	base = strings.TrimSuffix(base, "."+direction)
+
+	versionStr, name, ok := strings.Cut(base, "_")
+	if !ok || name == "" {
+		return 0, "", "", fmt.Errorf("invalid migration filename %q: must be <version>_<name>", filename)
+	}
+	version, err := strconv.ParseInt(versionStr, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, "", "", fmt.Errorf("invalid migration filename %q: version must be a positive integer", filename)
+	}
+	return version, name, direction, nil
+}
+
+// Up は未適用のマイグレーションをバージョン昇順にすべて適用する。
+// 各マイグレーションは個別のトランザクションで適用する。
+func (m *Migrator) Up(ctx context.Context) error {
+	for _, mig := range m.migrations {
+		err := m.withLock(ctx, func(tx pgx.Tx) error {
+			applied, err := m.isApplied(ctx, tx, mig.Version)
+			if err != nil || applied {
+				return err
+			}
+			if _, err := tx.Exec(ctx, mig.Up); err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", mig.Version, mig.Name, err)
+			}
+			_, err = tx.Exec(ctx, "INSERT INTO "+m.table+" (version, name) VALUES ($1, $2)", mig.Version, mig.Name)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down は適用済みのマイグレーションを新しい順に steps 件巻き戻す。steps <= 0 の場合はすべて巻き戻す。
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	rolledBack := 0
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		if steps > 0 && rolledBack >= steps {
+			return nil
+		}
+		mig := m.migrations[i]
+		var done bool
+		err := m.withLock(ctx, func(tx pgx.Tx) error {
+			applied, err := m.isApplied(ctx, tx, mig.Version)
+			if err != nil || !applied {
+				return err
+			}
+			if _, err := tx.Exec(ctx, mig.Down); err != nil {
+				return fmt.Errorf("failed to roll back migration %d_%s: %w", mig.Version, mig.Name, err)
+			}
+			if _, err := tx.Exec(ctx, "DELETE FROM "+m.table+" WHERE version = $1", mig.Version); err != nil {
+				return err
+			}
+			done = true
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if done {
+			rolledBack++
+		}
+	}
+	return nil
+}
+
+// Version は適用済みの最新バージョンを返す。未適用の場合は 0 を返す。
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	var version int64
+	err := m.withLock(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx, "SELECT COALESCE(MAX(version), 0) FROM "+m.table).Scan(&version)
+	})
+	return version, err
+}
+
+// withLock はトランザクションを開始して advisory lock を取得し、バージョンのテーブルを用意してから fn を実行する。
+func (m *Migrator) withLock(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", m.lockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS `+m.table+` (
+			version BIGINT PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)`); err != nil {
+		return fmt.Errorf("failed to create %s: %w", m.name, err)
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit migration: %w", err)
+	}
+	return nil
+}
+
+func (m *Migrator) isApplied(ctx context.Context, tx pgx.Tx, version int64) (bool, error) {
+	var exists bool
+	err := tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM "+m.table+" WHERE version = $1)", version).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", m.name, err)
+	}
+	return exists, nil
+}
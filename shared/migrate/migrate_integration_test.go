@@ -0,0 +1,90 @@
+//go:build integration
+// +build integration
+
+package migrate_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"teamflow-shared/dbtest"
+	"teamflow-shared/migrate"
+)
+
+// fixture は統合テスト用のマイグレーション（サービスのテーブルとは別のテーブルを使う）。
+var fixture = migrate.Config{
+	FS: fstest.MapFS{
+		"m/0001_create_items.up.sql":    {Data: []byte("CREATE TABLE migrate_test_items (id TEXT PRIMARY KEY)")},
+		"m/0001_create_items.down.sql":  {Data: []byte("DROP TABLE migrate_test_items")},
+		"m/0002_add_item_name.up.sql":   {Data: []byte("ALTER TABLE migrate_test_items ADD COLUMN name TEXT")},
+		"m/0002_add_item_name.down.sql": {Data: []byte("ALTER TABLE migrate_test_items DROP COLUMN name")},
+	},
+	Dir:     "m",
+	Table:   "migrate_test_schema_migrations",
+	LockKey: 0x6d696772617465, // "migrate"
+}
+
+func newFixtureMigrator(db *pgxpool.Pool) (*migrate.Migrator, error) {
+	return migrate.New(db, fixture)
+}
+
+func TestMain(m *testing.M) {
+	os.Exit(dbtest.Main(m, newFixtureMigrator))
+}
+
+// TestMigrator_UpDown は Down / Up の往復でスキーマとバージョンが戻ることを検証する。
+func TestMigrator_UpDown(t *testing.T) {
+	db := dbtest.Setup(t)
+	ctx := context.Background()
+
+	m, err := newFixtureMigrator(db)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := m.Down(ctx, 0); err != nil {
+			t.Errorf("failed to roll back migrations: %v", err)
+		}
+	})
+
+	if v, err := m.Version(ctx); err != nil || v != 2 {
+		t.Fatalf("expected version 2 after TestMain, got %d (err=%v)", v, err)
+	}
+
+	// 1 件だけ巻き戻す
+	if err := m.Down(ctx, 1); err != nil {
+		t.Fatalf("failed to roll back: %v", err)
+	}
+	if v, _ := m.Version(ctx); v != 1 {
+		t.Errorf("expected version 1 after Down(1), got %d", v)
+	}
+
+	// すべて巻き戻すとテーブルが無くなる
+	if err := m.Down(ctx, 0); err != nil {
+		t.Fatalf("failed to roll back all: %v", err)
+	}
+	if v, _ := m.Version(ctx); v != 0 {
+		t.Errorf("expected version 0 after Down(0), got %d", v)
+	}
+	var exists bool
+	if err := db.QueryRow(ctx, "SELECT to_regclass('public.migrate_test_items') IS NOT NULL").Scan(&exists); err != nil {
+		t.Fatalf("failed to check the table: %v", err)
+	}
+	if exists {
+		t.Error("expected migrate_test_items to be dropped")
+	}
+
+	// 再適用で最新に戻る（Up は冪等）
+	for i := 0; i < 2; i++ {
+		if err := m.Up(ctx); err != nil {
+			t.Fatalf("failed to apply migrations: %v", err)
+		}
+	}
+	if v, _ := m.Version(ctx); v != 2 {
+		t.Errorf("expected version 2 after Up, got %d", v)
+	}
+}
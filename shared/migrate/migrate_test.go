@@ -0,0 +1,162 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        fstest.MapFS
+		wantErr      bool
+		wantVersions []int64
+	}{
+		{
+			name: "sorted by version",
+			files: fstest.MapFS{
+				"m/0010_b.up.sql":   {Data: []byte("b up")},
+				"m/0010_b.down.sql": {Data: []byte("b down")},
+				"m/0002_a.up.sql":   {Data: []byte("a up")},
+				"m/0002_a.down.sql": {Data: []byte("a down")},
+			},
+			wantVersions: []int64{2, 10},
+		},
+		{
+			name: "missing down",
+			files: fstest.MapFS{
+				"m/0001_a.up.sql": {Data: []byte("a up")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "conflicting names",
+			files: fstest.MapFS{
+				"m/0001_a.up.sql":   {Data: []byte("a up")},
+				"m/0001_b.down.sql": {Data: []byte("b down")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid filename",
+			files: fstest.MapFS{
+				"m/create_tasks.sql": {Data: []byte("x")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.files, "m")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.wantVersions) {
+				t.Fatalf("expected %d migrations, got %d", len(tt.wantVersions), len(got))
+			}
+			for i, v := range tt.wantVersions {
+				if got[i].Version != v {
+					t.Errorf("index %d: expected version %d, got %d", i, v, got[i].Version)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFilename(t *testing.T) {
+	tests := []struct {
+		filename      string
+		wantVersion   int64
+		wantName      string
+		wantDirection string
+		wantErr       bool
+	}{
+		{filename: "0001_create_tasks.up.sql", wantVersion: 1, wantName: "create_tasks", wantDirection: "up"},
+		{filename: "0002_add_index.down.sql", wantVersion: 2, wantName: "add_index", wantDirection: "down"},
+		{filename: "0001_create_tasks.sql", wantErr: true},
+		{filename: "0001.up.sql", wantErr: true},
+		{filename: "abc_create.up.sql", wantErr: true},
+		{filename: "0000_zero.up.sql", wantErr: true},
+		{filename: "0001_create.up.txt", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			version, name, direction, err := parseFilename(tt.filename)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if version != tt.wantVersion || name != tt.wantName || direction != tt.wantDirection {
+				t.Errorf("got (%d, %q, %q), want (%d, %q, %q)", version, name, direction, tt.wantVersion, tt.wantName, tt.wantDirection)
+			}
+		})
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    Command
+		wantErr bool
+	}{
+		{name: "up", args: []string{"up"}, want: Command{Action: "up"}},
+		{name: "version", args: []string{"version"}, want: Command{Action: "version"}},
+		{name: "down defaults to 1 step", args: []string{"down"}, want: Command{Action: "down", Steps: 1}},
+		{name: "down with steps", args: []string{"down", "3"}, want: Command{Action: "down", Steps: 3}},
+		{name: "down all", args: []string{"down", "0"}, want: Command{Action: "down", Steps: 0}},
+		{name: "no action", args: nil, wantErr: true},
+		{name: "unknown action", args: []string{"redo"}, wantErr: true},
+		{name: "up with extra args", args: []string{"up", "1"}, wantErr: true},
+		{name: "down with negative steps", args: []string{"down", "-1"}, wantErr: true},
+		{name: "down with invalid steps", args: []string{"down", "x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseArgs("tasks", tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseArgs_Usage(t *testing.T) {
+	if _, err := ParseArgs("projects", nil); err == nil || !strings.Contains(err.Error(), "usage: projects migrate") {
+		t.Errorf("expected the usage for projects, got %v", err)
+	}
+}
+
+func TestNew_RequiresTable(t *testing.T) {
+	files := fstest.MapFS{
+		"m/0001_a.up.sql":   {Data: []byte("a up")},
+		"m/0001_a.down.sql": {Data: []byte("a down")},
+	}
+	if _, err := New(nil, Config{FS: files, Dir: "m"}); err == nil {
+		t.Error("expected an error for a missing table")
+	}
+}
@@ -0,0 +1,54 @@
+// Package openapi は tasks / projects サービスで共通の OpenAPI 仕様（docs/api/teamflow-openapi.yaml）の
+// 配信と、リクエスト・レスポンスが仕様どおりかの実行時の検証を提供する。
+//
+// 仕様はバイナリに埋め込む。teamflow-openapi.yaml は docs/api のコピーで、go generate で更新する
+// （TestSpecInSync が差分を検出する）。
+package openapi
+
+//go:generate cp ../../docs/api/teamflow-openapi.yaml teamflow-openapi.yaml
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+//go:embed teamflow-openapi.yaml
+var spec []byte
+
+// Path は仕様を JSON で返すパス。
+const Path = "/api/openapi.json"
+
+// Load は埋め込んだ仕様を読み込み、仕様として正しいかを検証する。
+func Load() (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI spec: %w", err)
+	}
+	return doc, nil
+}
+
+// SpecHandler は doc を JSON で返すハンドラ（GET /api/openapi.json 用）。
+func SpecHandler(doc *openapi3.T) (http.Handler, error) {
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI spec: %w", err)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		_, _ = w.Write(body)
+	}), nil
+}
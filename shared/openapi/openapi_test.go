@@ -0,0 +1,251 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"teamflow-shared/openapi"
+)
+
+func TestSpecInSync(t *testing.T) {
+	want, err := os.ReadFile("../../docs/api/teamflow-openapi.yaml")
+	if err != nil {
+		t.Fatalf("failed to read docs/api/teamflow-openapi.yaml: %v", err)
+	}
+	got, err := os.ReadFile("teamflow-openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal("teamflow-openapi.yaml is out of date; run go generate ./openapi in shared")
+	}
+}
+
+func TestSpecHandler(t *testing.T) {
+	doc, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	h, err := openapi.SpecHandler(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, openapi.Path, nil))
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("expected 200 JSON, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	var body struct {
+		OpenAPI string                     `json:"openapi"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	if body.OpenAPI == "" || body.Paths["/api/projects/{projectId}/tasks/stats"] == nil {
+		t.Errorf("unexpected spec: openapi=%q paths=%d", body.OpenAPI, len(body.Paths))
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, openapi.Path, nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for POST, got %d", w.Code)
+	}
+}
+
+const statsPath = "/api/v1/projects/7f0c6a4e-3c1b-4b8e-9a55-0a8f0f1e2d3c/tasks/stats"
+
+// jsonHandler は status と body を JSON で返す。
+func jsonHandler(status int, body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	doc, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	validStats := `{"projectId":"7f0c6a4e-3c1b-4b8e-9a55-0a8f0f1e2d3c","open":1,"done":2,"overdue":0,"lastActivityAt":null}`
+
+	tests := []struct {
+		name       string
+		mode       openapi.Mode
+		method     string
+		path       string
+		handler    http.Handler
+		wantStatus int
+	}{
+		{
+			name: "valid response", mode: openapi.ModeStrict, method: http.MethodGet, path: statsPath,
+			handler: jsonHandler(http.StatusOK, validStats), wantStatus: http.StatusOK,
+		},
+		{
+			name: "unversioned alias", mode: openapi.ModeStrict, method: http.MethodGet,
+			path:    strings.Replace(statsPath, "/api/v1/", "/api/", 1),
+			handler: jsonHandler(http.StatusOK, validStats), wantStatus: http.StatusOK,
+		},
+		{
+			name: "response drift in strict mode", mode: openapi.ModeStrict, method: http.MethodGet, path: statsPath,
+			handler: jsonHandler(http.StatusOK, `{"projectId":"7f0c6a4e-3c1b-4b8e-9a55-0a8f0f1e2d3c","open":"1"}`), wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "response drift in log mode", mode: openapi.ModeLog, method: http.MethodGet, path: statsPath,
+			handler: jsonHandler(http.StatusOK, `{"open":"1"}`), wantStatus: http.StatusOK,
+		},
+		{
+			name: "undocumented route", mode: openapi.ModeStrict, method: http.MethodGet, path: "/api/v1/undocumented",
+			handler: jsonHandler(http.StatusOK, `{"anything":true}`), wantStatus: http.StatusOK,
+		},
+		{
+			name: "outside the API", mode: openapi.ModeStrict, method: http.MethodGet, path: "/livez",
+			handler: jsonHandler(http.StatusOK, `ok`), wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := openapi.Middleware(doc, tt.mode, tt.handler)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestMiddleware_AcceptedInvalidRequest(t *testing.T) {
+	doc, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	// 仕様では不正な本文を、ハンドラが 201 で受け付けた（ハンドラの検証が仕様より緩い）
+	var gotBody string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var buf bytes.Buffer
+		_, _ = buf.ReadFrom(r.Body)
+		gotBody = buf.String()
+		w.WriteHeader(http.StatusCreated)
+	})
+	h, err := openapi.Middleware(doc, openapi.ModeStrict, next)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/projects", strings.NewReader(`{"name":123}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500 for the contract violation, got %d", w.Code)
+	}
+	if gotBody != `{"name":123}` {
+		t.Errorf("expected the handler to read the original body, got %q", gotBody)
+	}
+
+	// ハンドラも 400 で拒否した場合はずれではない
+	h, err = openapi.Middleware(doc, openapi.ModeStrict,
+		jsonHandler(http.StatusBadRequest, `{"error":"VALIDATION_ERROR","message":"name must be a string"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/projects", strings.NewReader(`{"name":123}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected the handler's 400, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestMiddleware_StreamsEventStream(t *testing.T) {
+	doc, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	flushed := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("event: ping\n\n"))
+		flushed = http.NewResponseController(w).Flush() == nil
+	})
+	h, err := openapi.Middleware(doc, openapi.ModeStrict, next)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/projects/7f0c6a4e-3c1b-4b8e-9a55-0a8f0f1e2d3c/tasks/events", nil))
+	if !flushed || !w.Flushed || w.Body.String() != "event: ping\n\n" {
+		t.Errorf("expected the event to be streamed, flushed=%v body=%q", w.Flushed, w.Body.String())
+	}
+}
+
+func TestMiddleware_StreamsNDJSON(t *testing.T) {
+	doc, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"t1"}` + "\n"))
+		_ = http.NewResponseController(w).Flush()
+	})
+	h, err := openapi.Middleware(doc, openapi.ModeStrict, next)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/projects/7f0c6a4e-3c1b-4b8e-9a55-0a8f0f1e2d3c/tasks:export?format=ndjson", nil))
+	if !w.Flushed || w.Body.String() != `{"id":"t1"}`+"\n" {
+		t.Errorf("expected the rows to be streamed, flushed=%v body=%q", w.Flushed, w.Body.String())
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for _, s := range []string{"off", "log", "STRICT"} {
+		if _, err := openapi.ParseMode(s); err != nil {
+			t.Errorf("ParseMode(%q) error: %v", s, err)
+		}
+	}
+	if _, err := openapi.ParseMode("on"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
+
+func TestMiddleware_ValidatesCalendar(t *testing.T) {
+	doc, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		_, _ = w.Write([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"))
+	})
+	h, err := openapi.Middleware(doc, openapi.ModeStrict, next)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/projects/7f0c6a4e-3c1b-4b8e-9a55-0a8f0f1e2d3c/tasks.ics", nil))
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Body.String(), "BEGIN:VCALENDAR") {
+		t.Errorf("expected the calendar to pass validation, got %d: %s", w.Code, w.Body.String())
+	}
+}
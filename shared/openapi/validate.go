@@ -0,0 +1,219 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/apiversion"
+	"teamflow-shared/logging"
+)
+
+// Mode は実行時の検証の動作。
+type Mode string
+
+const (
+	// ModeOff は検証しない
+	ModeOff Mode = "off"
+	// ModeLog は仕様とのずれをログに出し、レスポンスはそのまま返す
+	ModeLog Mode = "log"
+	// ModeStrict は仕様とのずれをログに出し、レスポンスを 500 にする（テスト・CI でずれを早く見つけるため）
+	ModeStrict Mode = "strict"
+)
+
+func init() {
+	// kin-openapi が本文の形式を知らないレスポンス（iCalendar のフィード）は文字列として検証する
+	openapi3filter.RegisterBodyDecoder("text/calendar", openapi3filter.PlainBodyDecoder)
+}
+
+// ParseMode は off / log / strict を Mode に変換する。
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(strings.ToLower(strings.TrimSpace(s))); m {
+	case ModeOff, ModeLog, ModeStrict:
+		return m, nil
+	}
+	return "", fmt.Errorf("must be off, log or strict, got %q", s)
+}
+
+// Middleware は next のリクエストとレスポンスが doc の仕様どおりかを検証する。
+//
+// /api 配下のみを対象にし、/api/v1 配下は /api の仕様で検証する。次を仕様とのずれとして扱う。
+//
+//   - レスポンス（ステータスに対応する本文・ヘッダ）が仕様と異なる
+//   - 仕様では不正なリクエストを 4xx / 5xx 以外で受け付けた
+//
+// 仕様に無いルートとストリーミングのレスポンス（text/event-stream・application/x-ndjson）は検証しない。
+// レスポンスは検証のためにバッファするので、本番（ModeOff）では使わない。
+func Middleware(doc *openapi3.T, mode Mode, next http.Handler) (http.Handler, error) {
+	if mode == ModeOff {
+		return next, nil
+	}
+	// servers（本番のホストなど）に関係なく、パスだけでルートを探す
+	routed := *doc
+	routed.Servers = openapi3.Servers{{URL: "/"}}
+	router, err := gorillamux.NewRouter(&routed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OpenAPI router: %w", err)
+	}
+	return &validator{router: router, mode: mode, next: next}, nil
+}
+
+type validator struct {
+	router routers.Router
+	mode   Mode
+	next   http.Handler
+}
+
+// versionedPrefix は正式なパスのプレフィックス（/api/v1）。
+var versionedPrefix = "/api/" + apiversion.Current
+
+func (v *validator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, "/api/") {
+		v.next.ServeHTTP(w, r)
+		return
+	}
+
+	// 仕様のパスはバージョン無しの /api 配下で書いている
+	lookup := r.Clone(r.Context())
+	if rest, ok := strings.CutPrefix(r.URL.Path, versionedPrefix); ok && strings.HasPrefix(rest, "/") {
+		lookup.URL.Path = "/api" + rest
+		lookup.URL.RawPath = ""
+	}
+	route, params, err := v.router.FindRoute(lookup)
+	if err != nil {
+		// 仕様に無いルート・メソッドは検証しない（ハンドラが 404 / 405 を返す）
+		v.next.ServeHTTP(w, r)
+		return
+	}
+
+	in := &openapi3filter.RequestValidationInput{
+		Request:    lookup,
+		PathParams: params,
+		Route:      route,
+		Options:    &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+	}
+	reqErr := openapi3filter.ValidateRequest(r.Context(), in)
+	// 検証で読んだ本文を読み直せるようにしたものをハンドラに渡す
+	r.Body = lookup.Body
+
+	rec := &responseBuffer{w: w, header: w.Header().Clone()}
+	v.next.ServeHTTP(rec, r)
+	if rec.streaming {
+		return
+	}
+
+	var violation error
+	if reqErr != nil && rec.statusCode() < http.StatusBadRequest {
+		violation = fmt.Errorf("accepted a request that does not match the spec: %w", reqErr)
+	} else if err := openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: in,
+		Status:                 rec.statusCode(),
+		Header:                 rec.header,
+		Body:                   io.NopCloser(bytes.NewReader(rec.body.Bytes())),
+	}); err != nil {
+		violation = fmt.Errorf("response does not match the spec: %w", err)
+	}
+
+	if violation != nil {
+		logging.FromContext(r.Context()).ErrorContext(r.Context(), "openapi contract violation",
+			"method", r.Method,
+			"route", route.Path,
+			"status", rec.statusCode(),
+			"error", violation,
+		)
+		if v.mode == ModeStrict {
+			apierror.Write(w, http.StatusInternalServerError,
+				apierror.New(apierror.CodeInternal, "the response does not match the OpenAPI spec"))
+			return
+		}
+	}
+	rec.writeTo(w)
+}
+
+// responseBuffer は検証のためにレスポンスをバッファする ResponseWriter。
+// ストリーミングのレスポンス（SSE・NDJSON のエクスポート）はバッファせずにそのまま書き込む（少しずつ届くように）。
+type responseBuffer struct {
+	w         http.ResponseWriter
+	header    http.Header
+	status    int
+	body      bytes.Buffer
+	streaming bool
+}
+
+func (b *responseBuffer) Header() http.Header {
+	return b.header
+}
+
+func (b *responseBuffer) WriteHeader(code int) {
+	if b.status != 0 {
+		return
+	}
+	b.status = code
+	if isStreaming(b.header.Get("Content-Type")) {
+		b.streaming = true
+		copyHeader(b.w.Header(), b.header)
+		b.w.WriteHeader(code)
+	}
+}
+
+// isStreaming は contentType が少しずつ書き出すレスポンスかを返す。
+func isStreaming(contentType string) bool {
+	return strings.HasPrefix(contentType, "text/event-stream") || strings.HasPrefix(contentType, "application/x-ndjson")
+}
+
+func (b *responseBuffer) Write(p []byte) (int, error) {
+	if b.status == 0 {
+		b.WriteHeader(http.StatusOK)
+	}
+	if b.streaming {
+		return b.w.Write(p)
+	}
+	return b.body.Write(p)
+}
+
+// FlushError は http.ResponseController.Flush 用。バッファしている間は何もしない。
+func (b *responseBuffer) FlushError() error {
+	if b.status == 0 {
+		b.WriteHeader(http.StatusOK)
+	}
+	if !b.streaming {
+		return nil
+	}
+	return http.NewResponseController(b.w).Flush()
+}
+
+func (b *responseBuffer) Flush() {
+	_ = b.FlushError()
+}
+
+func (b *responseBuffer) Unwrap() http.ResponseWriter {
+	return b.w
+}
+
+func (b *responseBuffer) statusCode() int {
+	if b.status == 0 {
+		return http.StatusOK
+	}
+	return b.status
+}
+
+// writeTo はバッファしたレスポンスを w に書き込む。
+func (b *responseBuffer) writeTo(w http.ResponseWriter) {
+	copyHeader(w.Header(), b.header)
+	w.WriteHeader(b.statusCode())
+	_, _ = w.Write(b.body.Bytes())
+}
+
+func copyHeader(dst, src http.Header) {
+	for k, v := range src {
+		dst[k] = v
+	}
+}
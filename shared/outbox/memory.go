@@ -0,0 +1,131 @@
+package outbox
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"teamflow-shared/events"
+)
+
+// MemoryStore はメモリ上の outbox。テストと DB_DSN 未設定時の開発用。
+// トランザクションが無いため、記録したイベントは書き込みが失敗しても残る。
+type MemoryStore struct {
+	mu       sync.Mutex
+	nextSeq  int64
+	messages []*memoryMessage
+}
+
+type memoryMessage struct {
+	Message
+	nextAttemptAt time.Time
+	publishedAt   *time.Time
+	lastErr       string
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var (
+	_ Writer = (*MemoryStore)(nil)
+	_ Store  = (*MemoryStore)(nil)
+)
+
+// NewMemoryStore は空の MemoryStore を生成する。
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// Append はイベントを追加する。
+func (s *MemoryStore) Append(_ context.Context, evs ...events.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range evs {
+		s.nextSeq++
+		e.Data = slices.Clone(e.Data)
+		s.messages = append(s.messages, &memoryMessage{
+			Message:       Message{Seq: s.nextSeq, Event: e},
+			nextAttemptAt: e.OccurredAt,
+		})
+	}
+	return nil
+}
+
+// Claim は配信できる未配信のイベントを記録順に最大 limit 件返す。
+func (s *MemoryStore) Claim(_ context.Context, now time.Time, lease time.Duration, limit int) ([]Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var claimed []Message
+	for _, m := range s.messages {
+		if len(claimed) >= limit {
+			break
+		}
+		if m.publishedAt != nil || m.nextAttemptAt.After(now) {
+			continue
+		}
+		m.nextAttemptAt = now.Add(lease)
+		claimed = append(claimed, m.Message)
+	}
+	return claimed, nil
+}
+
+// MarkPublished は seqs のイベントを配信済みにする。
+func (s *MemoryStore) MarkPublished(_ context.Context, seqs []int64, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, m := range s.messages {
+		if slices.Contains(seqs, m.Seq) {
+			m.publishedAt = &at
+			m.lastErr = ""
+		}
+	}
+	return nil
+}
+
+// MarkFailed は seq のイベントの配信の失敗を記録する。
+func (s *MemoryStore) MarkFailed(_ context.Context, seq int64, attempts int, nextAttemptAt time.Time, lastErr string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, m := range s.messages {
+		if m.Seq == seq {
+			m.Attempts = attempts
+			m.nextAttemptAt = nextAttemptAt
+			m.lastErr = lastErr
+		}
+	}
+	return nil
+}
+
+// DeletePublished は before より前に配信済みになったイベントを削除する。
+func (s *MemoryStore) DeletePublished(_ context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := len(s.messages)
+	s.messages = slices.DeleteFunc(s.messages, func(m *memoryMessage) bool {
+		return m.publishedAt != nil && m.publishedAt.Before(before)
+	})
+	return int64(n - len(s.messages)), nil
+}
+
+// Events は記録された順に、未配信のものも含めたすべてのイベントを返す。
+func (s *MemoryStore) Events() []events.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	evs := make([]events.Event, len(s.messages))
+	for i, m := range s.messages {
+		evs[i] = m.Event
+	}
+	return evs
+}
+
+// Pending は未配信のイベントの件数を返す。
+func (s *MemoryStore) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, m := range s.messages {
+		if m.publishedAt == nil {
+			n++
+		}
+	}
+	return n
+}
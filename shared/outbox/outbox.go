@@ -0,0 +1,94 @@
+// Package outbox は tasks / projects サービスで共通の transactional outbox を提供する。
+//
+// ユースケースはドメインイベント（teamflow-shared/events）を Writer で outbox テーブル
+// （各サービスのマイグレーションで同じ定義を作成する）に書き込みと同じトランザクションで記録する。
+// Relay が未配信のイベントを読み出して Publisher でイベントバスに配信し、配信済みにする。
+// 書き込みがロールバックされればイベントも記録されず、記録されたイベントは配信に成功するまで再試行される（at-least-once）。
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"teamflow-shared/events"
+)
+
+// Writer はイベントを outbox に記録する。記録に失敗した場合は書き込み自体を失敗として扱えるよう、エラーを返す。
+type Writer interface {
+	Append(ctx context.Context, evs ...events.Event) error
+}
+
+// Message は outbox に記録された未配信のイベント。
+type Message struct {
+	Seq      int64 // 記録順の連番
+	Event    events.Event
+	Attempts int // これまでに配信に失敗した回数
+}
+
+// Store は Relay が使う outbox の読み出し・更新。
+type Store interface {
+	// Claim は now の時点で配信できる未配信のイベントを記録順に最大 limit 件返し、
+	// lease の間は他の Relay（別のレプリカ）に返さないようにする。
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Message, error)
+	// MarkPublished は seqs のイベントを配信済みにする。
+	MarkPublished(ctx context.Context, seqs []int64, at time.Time) error
+	// MarkFailed は seq のイベントの配信の失敗を記録し、nextAttemptAt まで再試行しないようにする。
+	MarkFailed(ctx context.Context, seq int64, attempts int, nextAttemptAt time.Time, lastErr string) error
+	// DeletePublished は before より前に配信済みになったイベントを削除し、件数を返す。
+	DeletePublished(ctx context.Context, before time.Time) (int64, error)
+}
+
+// InsertSQL は outbox に 1 件追加する SQL。引数は Args の順。
+const InsertSQL = `INSERT INTO outbox (event_id, event_type, source, aggregate_id, project_id, workspace_id, data, occurred_at, next_attempt_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)`
+
+// ClaimSQL は配信できる未配信のイベントを取得し、next_attempt_at を延ばして他の Relay から隠す SQL。
+// 引数は now, now + lease, limit。戻り値の列は ScanMessage の順（順序は保証されないため呼び出し側で Seq 順に並べる）。
+const ClaimSQL = `UPDATE outbox SET next_attempt_at = $2
+WHERE seq IN (
+    SELECT seq FROM outbox
+    WHERE published_at IS NULL AND next_attempt_at <= $1
+    ORDER BY seq
+    LIMIT $3
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING seq, event_id, event_type, source, aggregate_id, project_id, workspace_id, data, occurred_at, attempts`
+
+// MarkPublishedSQL は配信済みにする SQL。引数は seqs, at。
+const MarkPublishedSQL = `UPDATE outbox SET published_at = $2, last_error = '' WHERE seq = ANY($1)`
+
+// MarkFailedSQL は配信の失敗を記録する SQL。引数は seq, attempts, nextAttemptAt, lastErr。
+const MarkFailedSQL = `UPDATE outbox SET attempts = $2, next_attempt_at = $3, last_error = $4 WHERE seq = $1`
+
+// DeletePublishedSQL は配信済みのイベントを削除する SQL。引数は before。
+const DeletePublishedSQL = `DELETE FROM outbox WHERE published_at < $1`
+
+// Args は InsertSQL の引数を返す。
+func Args(e events.Event) []any {
+	data := e.Data
+	if data == nil {
+		data = []byte("{}")
+	}
+	return []any{e.ID, string(e.Type), e.Source, e.AggregateID, e.ProjectID, e.WorkspaceID, []byte(data), e.OccurredAt}
+}
+
+// Scanner は pgx.Row / pgx.Rows の Scan。
+type Scanner interface {
+	Scan(dest ...any) error
+}
+
+// ScanMessage は ClaimSQL の 1 行を Message に読み込む。
+func ScanMessage(row Scanner) (Message, error) {
+	var (
+		m    Message
+		typ  string
+		data []byte
+	)
+	if err := row.Scan(&m.Seq, &m.Event.ID, &typ, &m.Event.Source, &m.Event.AggregateID, &m.Event.ProjectID,
+		&m.Event.WorkspaceID, &data, &m.Event.OccurredAt, &m.Attempts); err != nil {
+		return Message{}, err
+	}
+	m.Event.Type = events.Type(typ)
+	m.Event.Data = data
+	return m, nil
+}
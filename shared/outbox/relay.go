@@ -0,0 +1,162 @@
+package outbox
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/events"
+)
+
+// Relay の既定値。
+const (
+	DefaultInterval  = time.Second
+	DefaultBatchSize = 100
+	DefaultLease     = 30 * time.Second
+	DefaultRetention = 24 * time.Hour
+
+	// retryBaseDelay / retryMaxDelay は配信に失敗したイベントを再試行するまでの待ち時間（失敗ごとに倍にする）。
+	retryBaseDelay = time.Second
+	retryMaxDelay  = 5 * time.Minute
+	// cleanupInterval は配信済みのイベントを削除する間隔。
+	cleanupInterval = time.Hour
+)
+
+// Publisher はイベントをイベントバスに配信する。
+type Publisher interface {
+	Publish(ctx context.Context, e events.Event) error
+}
+
+// LogPublisher はイベントをログに出力するだけの Publisher。イベントバスを設定していない構成（開発用）で使う。
+type LogPublisher struct{}
+
+// Publish はイベントを Info レベルでログに出力する。
+func (LogPublisher) Publish(ctx context.Context, e events.Event) error {
+	slog.InfoContext(ctx, "domain event", "event_id", e.ID, "event_type", string(e.Type),
+		"aggregate_id", e.AggregateID, "project_id", e.ProjectID, "workspace_id", e.WorkspaceID)
+	return nil
+}
+
+// Relay は outbox の未配信のイベントを Publisher で配信する。
+//
+// 複数のレプリカで動かしてもよい（Store.Claim のリースで同じイベントを同時に配信しない）。
+// 配信に失敗したイベントは待ち時間を倍にしながら成功するまで再試行するため、
+// 失敗したイベントより後のイベントが先に配信されることがある。
+type Relay struct {
+	Store     Store
+	Publisher Publisher
+	// Interval は未配信のイベントを確認する間隔。任意。0 の場合は DefaultInterval
+	Interval time.Duration
+	// BatchSize は 1 回に読み出すイベントの件数。任意。0 の場合は DefaultBatchSize
+	BatchSize int
+	// Lease は読み出したイベントを他のレプリカに渡さない期間（配信にかかる時間より長くする）。任意。0 の場合は DefaultLease
+	Lease time.Duration
+	// Retention は配信済みのイベントを残す期間。任意。0 の場合は DefaultRetention
+	Retention time.Duration
+	// Clock は任意。nil の場合は clock.System
+	Clock clock.Clock
+}
+
+// Run は ctx がキャンセルされるまで Interval ごとに未配信のイベントを配信する。
+// 読み出したイベントが BatchSize 件あれば、待たずに続きを配信する。
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(orDefault(r.Interval, DefaultInterval))
+	defer ticker.Stop()
+	var lastCleanup time.Time
+	for {
+		for {
+			n, err := r.RelayOnce(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					slog.Warn("outbox relay failed", "error", err)
+				}
+				break
+			}
+			if n < r.batchSize() {
+				break
+			}
+		}
+		if now := clock.OrSystem(r.Clock).Now(); now.Sub(lastCleanup) >= cleanupInterval {
+			lastCleanup = now
+			if n, err := r.Cleanup(ctx); err != nil {
+				slog.Warn("outbox cleanup failed", "error", err)
+			} else if n > 0 {
+				slog.Info("deleted published outbox events", "count", n)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce は未配信のイベントを最大 BatchSize 件読み出して配信し、読み出した件数を返す。
+// 配信に失敗したイベントは失敗を記録して次回以降に再試行する（エラーとしては返さない）。
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	now := clock.OrSystem(r.Clock).Now()
+	msgs, err := r.Store.Claim(ctx, now, orDefault(r.Lease, DefaultLease), r.batchSize())
+	if err != nil {
+		return 0, err
+	}
+	slices.SortFunc(msgs, func(a, b Message) int { return cmp.Compare(a.Seq, b.Seq) })
+
+	var (
+		published []int64
+		errs      []error
+	)
+	for _, m := range msgs {
+		if err := r.Publisher.Publish(ctx, m.Event); err != nil {
+			attempts := m.Attempts + 1
+			delay := RetryDelay(attempts)
+			slog.Warn("failed to publish outbox event", "event_id", m.Event.ID, "event_type", string(m.Event.Type),
+				"attempts", attempts, "retry_in", delay.String(), "error", err)
+			if err := r.Store.MarkFailed(ctx, m.Seq, attempts, now.Add(delay), err.Error()); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		published = append(published, m.Seq)
+	}
+	if len(published) > 0 {
+		if err := r.Store.MarkPublished(ctx, published, clock.OrSystem(r.Clock).Now()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return len(msgs), errors.Join(errs...)
+}
+
+// Cleanup は Retention より前に配信済みになったイベントを削除し、件数を返す。
+func (r *Relay) Cleanup(ctx context.Context) (int64, error) {
+	before := clock.OrSystem(r.Clock).Now().Add(-orDefault(r.Retention, DefaultRetention))
+	return r.Store.DeletePublished(ctx, before)
+}
+
+// RetryDelay は attempts 回目の失敗の後、再試行するまでの待ち時間を返す（1 秒から倍にして最大 5 分）。
+func RetryDelay(attempts int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempts && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, retryMaxDelay)
+}
+
+func (r *Relay) batchSize() int {
+	if r.BatchSize > 0 {
+		return r.BatchSize
+	}
+	return DefaultBatchSize
+}
+
+func orDefault(d, def time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return def
+}
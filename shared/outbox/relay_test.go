@@ -0,0 +1,149 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/events"
+	"teamflow-shared/outbox"
+)
+
+// recordingPublisher は配信したイベントを記録する。fail が true の間は配信に失敗する。
+type recordingPublisher struct {
+	fail      bool
+	published []events.Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, e events.Event) error {
+	if p.fail {
+		return errors.New("bus unavailable")
+	}
+	p.published = append(p.published, e)
+	return nil
+}
+
+func taskEvent(id string, at time.Time) events.Event {
+	return events.NewTaskEvent(events.TaskCreated, "default", events.TaskData{ID: id, ProjectID: "p-1"}, at)
+}
+
+func TestRelay_RelayOnce(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(now)
+	store := outbox.NewMemoryStore()
+	pub := &recordingPublisher{}
+	relay := &outbox.Relay{Store: store, Publisher: pub, BatchSize: 2, Clock: clk}
+
+	if err := store.Append(ctx, taskEvent("t-1", now), taskEvent("t-2", now), taskEvent("t-3", now)); err != nil {
+		t.Fatal(err)
+	}
+
+	// BatchSize 件ずつ記録順に配信する
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 2 {
+		t.Fatalf("RelayOnce() = %d, %v", n, err)
+	}
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 1 {
+		t.Fatalf("RelayOnce() = %d, %v", n, err)
+	}
+	if len(pub.published) != 3 || pub.published[0].AggregateID != "t-1" || pub.published[2].AggregateID != "t-3" {
+		t.Fatalf("unexpected published events %+v", pub.published)
+	}
+	if store.Pending() != 0 {
+		t.Errorf("Pending() = %d, want 0", store.Pending())
+	}
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 0 {
+		t.Errorf("expected nothing to relay, got %d, %v", n, err)
+	}
+
+	// 配信済みのイベントは Retention を過ぎてから削除する
+	clk.Advance(outbox.DefaultRetention + time.Minute)
+	if n, err := relay.Cleanup(ctx); err != nil || n != 3 {
+		t.Errorf("Cleanup() = %d, %v", n, err)
+	}
+}
+
+func TestRelay_RetriesFailedEvents(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(now)
+	store := outbox.NewMemoryStore()
+	pub := &recordingPublisher{fail: true}
+	relay := &outbox.Relay{Store: store, Publisher: pub, Clock: clk}
+
+	if err := store.Append(ctx, taskEvent("t-1", now)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := relay.RelayOnce(ctx); err != nil {
+		t.Fatalf("RelayOnce: %v", err)
+	}
+	if store.Pending() != 1 {
+		t.Fatalf("failed event must stay pending")
+	}
+
+	// バスが回復しても、待ち時間が過ぎるまでは再試行しない
+	pub.fail = false
+	if n, _ := relay.RelayOnce(ctx); n != 0 {
+		t.Errorf("expected no retry before the backoff, got %d", n)
+	}
+	clk.Advance(outbox.RetryDelay(1))
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 1 {
+		t.Fatalf("RelayOnce() = %d, %v", n, err)
+	}
+	if len(pub.published) != 1 || store.Pending() != 0 {
+		t.Errorf("expected the event to be published once, got %d (pending %d)", len(pub.published), store.Pending())
+	}
+}
+
+func TestMemoryStore_ClaimLease(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	store := outbox.NewMemoryStore()
+	if err := store.Append(ctx, taskEvent("t-1", now)); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs, err := store.Claim(ctx, now, time.Minute, 10)
+	if err != nil || len(msgs) != 1 || msgs[0].Seq != 1 {
+		t.Fatalf("Claim() = %+v, %v", msgs, err)
+	}
+	// リースの間は他の Relay に返さない
+	if msgs, _ := store.Claim(ctx, now.Add(30*time.Second), time.Minute, 10); len(msgs) != 0 {
+		t.Errorf("expected the claimed event to be hidden, got %+v", msgs)
+	}
+	// 配信されないままリースが切れたら再び返す
+	if msgs, _ := store.Claim(ctx, now.Add(time.Minute), time.Minute, 10); len(msgs) != 1 {
+		t.Errorf("expected the event to be claimable after the lease, got %+v", msgs)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	for attempts, want := range map[int]time.Duration{
+		1:  time.Second,
+		2:  2 * time.Second,
+		5:  16 * time.Second,
+		20: 5 * time.Minute,
+	} {
+		if got := outbox.RetryDelay(attempts); got != want {
+			t.Errorf("RetryDelay(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
+
+func TestArgs(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	e := taskEvent("t-1", now)
+	args := outbox.Args(e)
+	if len(args) != 8 {
+		t.Fatalf("expected 8 args, got %d", len(args))
+	}
+	if args[1] != "task.created" || args[2] != "tasks" || args[3] != "t-1" || args[7] != now {
+		t.Errorf("unexpected args %v", args)
+	}
+	// data カラムは NOT NULL のため、Data が無いイベントは空のオブジェクトにする
+	if data := outbox.Args(events.Event{})[6].([]byte); string(data) != "{}" {
+		t.Errorf("expected empty object, got %s", data)
+	}
+}
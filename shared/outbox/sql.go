@@ -0,0 +1,102 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"teamflow-shared/events"
+)
+
+// TxFromContext は ctx に紐づく pgx.Tx を返す（各サービスの TxManager が設定したもの）。
+type TxFromContext func(ctx context.Context) (pgx.Tx, bool)
+
+// SQLOutbox は PostgreSQL の outbox テーブルを使う Writer / Store 実装。
+// Append は txFromContext で ctx からトランザクションを取り出せれば、その中で記録する（サービスの書き込みと一緒にロールバックされる）。
+type SQLOutbox struct {
+	db            *pgxpool.Pool
+	txFromContext TxFromContext
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var (
+	_ Writer = (*SQLOutbox)(nil)
+	_ Store  = (*SQLOutbox)(nil)
+)
+
+// NewSQLOutbox は新しいSQLOutboxを生成する。txFromContext は各サービスのトランザクションの取り出し方。
+func NewSQLOutbox(db *pgxpool.Pool, txFromContext TxFromContext) *SQLOutbox {
+	return &SQLOutbox{db: db, txFromContext: txFromContext}
+}
+
+// Append は evs を 1 回のバッチで追加する。
+func (o *SQLOutbox) Append(ctx context.Context, evs ...events.Event) error {
+	if len(evs) == 0 {
+		return nil
+	}
+	b := &pgx.Batch{}
+	for _, e := range evs {
+		b.Queue(InsertSQL, Args(e)...)
+	}
+
+	var results pgx.BatchResults
+	if tx, ok := o.txFromContext(ctx); ok {
+		results = tx.SendBatch(ctx, b)
+	} else {
+		results = o.db.SendBatch(ctx, b)
+	}
+	if err := results.Close(); err != nil {
+		return fmt.Errorf("failed to insert outbox events: %w", err)
+	}
+	return nil
+}
+
+// Claim は配信できる未配信のイベントを最大 limit 件読み出し、lease の間は他のレプリカに返さないようにする。
+func (o *SQLOutbox) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Message, error) {
+	rows, err := o.db.Query(ctx, ClaimSQL, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var msgs []Message
+	for rows.Next() {
+		m, err := ScanMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		msgs = append(msgs, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	return msgs, nil
+}
+
+// MarkPublished は seqs のイベントを配信済みにする。
+func (o *SQLOutbox) MarkPublished(ctx context.Context, seqs []int64, at time.Time) error {
+	if _, err := o.db.Exec(ctx, MarkPublishedSQL, seqs, at); err != nil {
+		return fmt.Errorf("failed to mark outbox events as published: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed は seq のイベントの配信の失敗を記録する。
+func (o *SQLOutbox) MarkFailed(ctx context.Context, seq int64, attempts int, nextAttemptAt time.Time, lastErr string) error {
+	if _, err := o.db.Exec(ctx, MarkFailedSQL, seq, attempts, nextAttemptAt, lastErr); err != nil {
+		return fmt.Errorf("failed to record outbox publish failure: %w", err)
+	}
+	return nil
+}
+
+// DeletePublished は before より前に配信済みになったイベントを削除する。
+func (o *SQLOutbox) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := o.db.Exec(ctx, DeletePublishedSQL, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete published outbox events: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,273 @@
+// Package pat は個人用アクセストークン（PAT: Personal Access Token）の形式と検証を提供する。
+//
+// CLI や CI から API を呼び出すためのトークンで、users サービスが発行・失効を管理する。
+// トークンは TokenPrefix に続く 40 文字のランダムな文字列で、サーバーは SHA-256 のハッシュだけを保存する
+// （平文は発行時に 1 度だけ返す）。一覧では先頭の DisplayPrefix（例: tfp_a1b2c3d4）で見分ける。
+//
+// 各サービスは Middleware で Authorization: Bearer <PAT> を検証し、トークンの持ち主を操作者（X-User-ID）、
+// 発行したワークスペースを X-Workspace-ID に設定する。PAT 以外の Authorization（jwt.Middleware かゲートウェイが検証する JWT）は
+// そのまま通す。
+package pat
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/authz"
+	"teamflow-shared/client"
+	"teamflow-shared/logging"
+	"teamflow-shared/workspace"
+)
+
+// TokenPrefix はトークンの先頭の固定文字列（ログやシークレットスキャンで PAT と分かるようにする）。
+const TokenPrefix = "tfp_"
+
+// secretBytes はトークンのランダム部分のバイト数（base32 で 40 文字）。
+const secretBytes = 25
+
+// tokenLength はトークン全体の長さ。
+const tokenLength = len(TokenPrefix) + secretBytes*8/5
+
+// displayPrefixLength は一覧に表示するトークンの先頭の長さ（TokenPrefix + 8 文字）。
+const displayPrefixLength = len(TokenPrefix) + 8
+
+// encoding はトークンのランダム部分の符号化（小文字の base32、パディングなし）。
+var encoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// トークンで許可する操作の範囲（スコープ）。
+const (
+	// ScopeRead は参照（GET / HEAD / OPTIONS）を許可する。
+	ScopeRead = "read"
+	// ScopeWrite は作成・更新・削除を許可する（参照も含む）。
+	ScopeWrite = "write"
+)
+
+var (
+	// ErrInvalidToken はトークンが存在しない・失効した・期限切れの場合のエラー（HTTP 層で 401）。
+	ErrInvalidToken = errors.New("personal access token is invalid, expired or revoked")
+	// ErrInvalidScope はスコープが空・未知の値を含む場合のエラー。
+	ErrInvalidScope = errors.New("scopes must be one or more of read, write")
+)
+
+// Generate は新しいトークンの平文を生成する。
+func Generate() (string, error) {
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate personal access token: %w", err)
+	}
+	return TokenPrefix + encoding.EncodeToString(b), nil
+}
+
+// IsToken は s が PAT の形式かどうかを返す（存在するかどうかは確認しない）。
+func IsToken(s string) bool {
+	if len(s) != tokenLength || !strings.HasPrefix(s, TokenPrefix) {
+		return false
+	}
+	_, err := encoding.DecodeString(s[len(TokenPrefix):])
+	return err == nil
+}
+
+// Hash は保存・照合に使うトークンの SHA-256（16 進数）を返す。
+// トークンは十分なエントロピーを持つランダムな値のため、パスワードのようなストレッチングはしない。
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// DisplayPrefix は一覧に表示するトークンの先頭（例: tfp_a1b2c3d4）を返す。
+func DisplayPrefix(token string) string {
+	if len(token) < displayPrefixLength {
+		return token
+	}
+	return token[:displayPrefixLength]
+}
+
+// ParseScopes はスコープの一覧を検証し、重複を除いて昇順で返す。
+// 空・未知の値を含む場合は ErrInvalidScope を返す。
+func ParseScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, ErrInvalidScope
+	}
+	out := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s != ScopeRead && s != ScopeWrite {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidScope, s)
+		}
+		if !slices.Contains(out, s) {
+			out = append(out, s)
+		}
+	}
+	slices.Sort(out)
+	return out, nil
+}
+
+// Allows は scopes で method のリクエストを許可するかどうかを返す。
+// 参照（GET / HEAD / OPTIONS）は read か write、それ以外は write が必要。
+func Allows(scopes []string, method string) bool {
+	if slices.Contains(scopes, ScopeWrite) {
+		return true
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return slices.Contains(scopes, ScopeRead)
+	}
+	return false
+}
+
+// Identity はトークンの持ち主と許可された範囲。
+type Identity struct {
+	UserID      string
+	WorkspaceID string
+	Scopes      []string
+}
+
+// Verifier はトークンを検証する。users サービスへの問い合わせ（POST /tokens:introspect）などで実装する。
+type Verifier interface {
+	// Verify は token の持ち主を返す。存在しない・失効した・期限切れの場合は ErrInvalidToken を返す。
+	Verify(ctx context.Context, token string) (Identity, error)
+}
+
+// VerifierFunc は関数を Verifier として使うためのアダプタ。
+type VerifierFunc func(ctx context.Context, token string) (Identity, error)
+
+// Verify は f(ctx, token) を返す。
+func (f VerifierFunc) Verify(ctx context.Context, token string) (Identity, error) {
+	return f(ctx, token)
+}
+
+type identityKey struct{}
+
+// NewContext は Middleware が認証したトークンの持ち主を ctx に設定する。
+func NewContext(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext は Middleware が認証したトークンの持ち主を返す。トークンで認証していない場合は false。
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
+
+// BearerToken は Authorization ヘッダの Bearer トークンを返す。Bearer でない場合は空文字。
+func BearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// StripIdentity は、クライアントが送った操作者（X-User-ID）とワークスペース（X-Workspace-ID）を取り除いた r の複製を返す。
+// トークンを検証していないリクエストが、ヘッダで他のユーザー・ワークスペースになりすませないようにする。
+func StripIdentity(r *http.Request) *http.Request {
+	r = r.Clone(r.Context())
+	r.Header.Del(authz.ActorHeader)
+	r.Header.Del(workspace.Header)
+	return r
+}
+
+// QueryParam は QueryToken がトークンを読むクエリパラメータ。
+const QueryParam = "token"
+
+// QueryToken は Authorization ヘッダを送れないクライアント（カレンダーアプリの購読など）のために、
+// match がパスを許可したリクエストのクエリの token=<PAT> を Authorization: Bearer に移してから next を呼ぶ。
+// Middleware より外側に置く。Authorization が既にあるリクエストと、PAT の形式でない値はそのまま渡す
+// （操作者は Middleware が決めるため、トークンの無いリクエストの X-User-ID / X-Workspace-ID は Middleware が取り除く）。
+// トークンを後段（ハンドラ・ログ）に残さないよう、クエリからは取り除く。
+func QueryToken(match func(path string) bool, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" || !match(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		q := r.URL.Query()
+		token := q.Get(QueryParam)
+		if !IsToken(token) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		q.Del(QueryParam)
+		r = r.Clone(r.Context())
+		r.URL.RawQuery = q.Encode()
+		r.RequestURI = r.URL.RequestURI()
+		r.Header.Set("Authorization", "Bearer "+token)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Middleware は Authorization: Bearer <PAT> のリクエストを v で検証し、
+// トークンの持ち主を X-User-ID、発行したワークスペースを X-Workspace-ID に設定して next を呼ぶ
+// （クライアントが送った値は使わない）。
+//
+// PAT の無いリクエストは、前段の jwt.Middleware が JWT を検証した場合（authz.IdentityVerified）と
+// trusted が true を返す場合（サービス API キーで認証したサービス間の呼び出し）を除き、X-User-ID と X-Workspace-ID を
+// 取り除いてから next に渡す。trusted は nil でもよい（JWT を検証したリクエスト以外はすべて取り除く）。
+//
+//	401  トークンが存在しない・失効した・期限切れ（UNAUTHORIZED）
+//	403  スコープがリクエストのメソッドを許可しない（FORBIDDEN）
+//	502  検証先（users サービス）に問い合わせられない（BAD_GATEWAY）
+//
+// v が nil の場合は next をそのまま返す。
+func Middleware(v Verifier, trusted func(r *http.Request) bool, next http.Handler) http.Handler {
+	if v == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := BearerToken(r)
+		if !IsToken(token) {
+			if !authz.IdentityVerified(r.Context()) && (trusted == nil || !trusted(r)) {
+				r = StripIdentity(r)
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		id, err := v.Verify(r.Context(), token)
+		switch {
+		case errors.Is(err, ErrInvalidToken):
+			apierror.Write(w, http.StatusUnauthorized, apierror.New(apierror.CodeUnauthorized, ErrInvalidToken.Error()))
+			return
+		case err != nil:
+			logging.FromContext(r.Context()).ErrorContext(r.Context(), "failed to verify personal access token",
+				"token_prefix", DisplayPrefix(token), "error", err)
+			apierror.Write(w, http.StatusBadGateway, apierror.New(apierror.CodeBadGateway, "failed to verify the personal access token"))
+			return
+		}
+		if !Allows(id.Scopes, r.Method) {
+			apierror.Write(w, http.StatusForbidden, apierror.New(apierror.CodeForbidden,
+				"the personal access token does not have the scope for "+r.Method))
+			return
+		}
+
+		// ハンドラは従来どおりヘッダから操作者・ワークスペースを読むため、トークンの持ち主で置き換える
+		r = r.Clone(authz.ContextWithVerifiedIdentity(NewContext(r.Context(), id)))
+		r.Header.Set(authz.ActorHeader, id.UserID)
+		r.Header.Set(workspace.Header, id.WorkspaceID)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// NewIntrospectionVerifier は users サービスの POST /tokens:introspect でトークンを検証する Verifier を返す。
+// c はサービス間専用のエンドポイントを呼ぶため、X-Service-Key を付けるクライアントにする。
+func NewIntrospectionVerifier(c *client.Client) Verifier {
+	return VerifierFunc(func(ctx context.Context, token string) (Identity, error) {
+		res, err := c.IntrospectToken(ctx, token)
+		if err != nil {
+			return Identity{}, err
+		}
+		if !res.Active {
+			return Identity{}, ErrInvalidToken
+		}
+		return Identity{UserID: res.UserID, WorkspaceID: res.WorkspaceID, Scopes: res.Scopes}, nil
+	})
+}
@@ -0,0 +1,1167 @@
+// tasks サービスの gRPC API（サービス間の呼び出し用）。
+//
+// HTTP の API（/api/v1/tasks...）と同じユースケースを呼び出す。
+// 呼び出し元は次のメタデータを送る（HTTP のヘッダと同じ意味）。
+//
+//	x-service-key   サービス API キー（tasks サービスの SERVICE_API_KEYS が設定されている場合は必須）
+//	x-workspace-id  ワークスペース ID（省略時は default）
+//	x-user-id       操作者のユーザー ID（閲覧権限の確認・作成者 / 更新者の記録に使う）
+//
+// Go のコードは make proto-generate で生成する（tasks.pb.go / tasks_grpc.pb.go）。
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v5.29.3
+// source: proto/tasks/v1/tasks.proto
+
+package tasksv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Task はタスク。
+type Task struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// プロジェクト内のタスク番号（TFLOW-123 の 123）
+	Number      int32  `protobuf:"varint,3,opt,name=number,proto3" json:"number,omitempty"`
+	Title       string `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// todo / in_progress / done
+	Status string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	// low / medium / high
+	Priority   string  `protobuf:"bytes,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	AssigneeId *string `protobuf:"bytes,8,opt,name=assignee_id,json=assigneeId,proto3,oneof" json:"assignee_id,omitempty"`
+	// 担当者の表示名（ListTasks のみ。users サービスを使わない場合や取得できない場合は空）
+	AssigneeName string                 `protobuf:"bytes,9,opt,name=assignee_name,json=assigneeName,proto3" json:"assignee_name,omitempty"`
+	DueDate      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	StartDate    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	Estimate     *int32                 `protobuf:"varint,12,opt,name=estimate,proto3,oneof" json:"estimate,omitempty"`
+	MilestoneId  *string                `protobuf:"bytes,13,opt,name=milestone_id,json=milestoneId,proto3,oneof" json:"milestone_id,omitempty"`
+	SprintId     *string                `protobuf:"bytes,14,opt,name=sprint_id,json=sprintId,proto3,oneof" json:"sprint_id,omitempty"`
+	EpicId       *string                `protobuf:"bytes,15,opt,name=epic_id,json=epicId,proto3,oneof" json:"epic_id,omitempty"`
+	LabelIds     []string               `protobuf:"bytes,16,rep,name=label_ids,json=labelIds,proto3" json:"label_ids,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt    *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// 作成者・最終更新者（x-user-id）。不明な場合は空
+	CreatedBy string `protobuf:"bytes,19,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	UpdatedBy string `protobuf:"bytes,20,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	// プロジェクトの削除に伴ってアーカイブされた日時
+	ArchivedAt    *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_v1_tasks_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Task) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Task) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *Task) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Task) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Task) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Task) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Task) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *Task) GetAssigneeId() string {
+	if x != nil && x.AssigneeId != nil {
+		return *x.AssigneeId
+	}
+	return ""
+}
+
+func (x *Task) GetAssigneeName() string {
+	if x != nil {
+		return x.AssigneeName
+	}
+	return ""
+}
+
+func (x *Task) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *Task) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *Task) GetEstimate() int32 {
+	if x != nil && x.Estimate != nil {
+		return *x.Estimate
+	}
+	return 0
+}
+
+func (x *Task) GetMilestoneId() string {
+	if x != nil && x.MilestoneId != nil {
+		return *x.MilestoneId
+	}
+	return ""
+}
+
+func (x *Task) GetSprintId() string {
+	if x != nil && x.SprintId != nil {
+		return *x.SprintId
+	}
+	return ""
+}
+
+func (x *Task) GetEpicId() string {
+	if x != nil && x.EpicId != nil {
+		return *x.EpicId
+	}
+	return ""
+}
+
+func (x *Task) GetLabelIds() []string {
+	if x != nil {
+		return x.LabelIds
+	}
+	return nil
+}
+
+func (x *Task) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Task) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Task) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Task) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *Task) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+// CreateTaskRequest は CreateTask のリクエスト。
+type CreateTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 空の場合は UUID を生成する
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId   string `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Title       string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// todo / in_progress（doing）/ done
+	Status string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	// 空の場合はプロジェクト設定の既定値を使う
+	Priority string `protobuf:"bytes,6,opt,name=priority,proto3" json:"priority,omitempty"`
+	// 空の場合はプロジェクト設定の既定値を使う
+	AssigneeId    string   `protobuf:"bytes,7,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	MilestoneId   string   `protobuf:"bytes,8,opt,name=milestone_id,json=milestoneId,proto3" json:"milestone_id,omitempty"`
+	SprintId      string   `protobuf:"bytes,9,opt,name=sprint_id,json=sprintId,proto3" json:"sprint_id,omitempty"`
+	EpicId        string   `protobuf:"bytes,10,opt,name=epic_id,json=epicId,proto3" json:"epic_id,omitempty"`
+	LabelIds      []string `protobuf:"bytes,11,rep,name=label_ids,json=labelIds,proto3" json:"label_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_v1_tasks_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetAssigneeId() string {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetMilestoneId() string {
+	if x != nil {
+		return x.MilestoneId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetSprintId() string {
+	if x != nil {
+		return x.SprintId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetEpicId() string {
+	if x != nil {
+		return x.EpicId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetLabelIds() []string {
+	if x != nil {
+		return x.LabelIds
+	}
+	return nil
+}
+
+// GetTaskByNumberRequest は GetTaskByNumber のリクエスト。
+type GetTaskByNumberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Number        int32                  `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskByNumberRequest) Reset() {
+	*x = GetTaskByNumberRequest{}
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskByNumberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskByNumberRequest) ProtoMessage() {}
+
+func (x *GetTaskByNumberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskByNumberRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskByNumberRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_v1_tasks_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetTaskByNumberRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *GetTaskByNumberRequest) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+// ListTasksRequest は ListTasks のリクエスト。フィルタは HTTP のクエリパラメータと同じ。
+type ListTasksRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// いずれかに一致するタスク（doing は in_progress として扱う）
+	Statuses    []string `protobuf:"bytes,2,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	Priorities  []string `protobuf:"bytes,3,rep,name=priorities,proto3" json:"priorities,omitempty"`
+	AssigneeId  string   `protobuf:"bytes,4,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	MilestoneId string   `protobuf:"bytes,5,opt,name=milestone_id,json=milestoneId,proto3" json:"milestone_id,omitempty"`
+	SprintId    string   `protobuf:"bytes,6,opt,name=sprint_id,json=sprintId,proto3" json:"sprint_id,omitempty"`
+	EpicId      string   `protobuf:"bytes,7,opt,name=epic_id,json=epicId,proto3" json:"epic_id,omitempty"`
+	// 期限の範囲（YYYY-MM-DD）
+	DueDateFrom string `protobuf:"bytes,8,opt,name=due_date_from,json=dueDateFrom,proto3" json:"due_date_from,omitempty"`
+	DueDateTo   string `protobuf:"bytes,9,opt,name=due_date_to,json=dueDateTo,proto3" json:"due_date_to,omitempty"`
+	// タイトルの検索
+	Query string `protobuf:"bytes,10,opt,name=query,proto3" json:"query,omitempty"`
+	// 並び順（例: -priority,createdAt）。page_token と併用できない
+	Sort string `protobuf:"bytes,11,opt,name=sort,proto3" json:"sort,omitempty"`
+	// 1〜上限（既定 200）。0 の場合は既定値（既定 200）、上限を超える値は上限にする
+	PageSize int32 `protobuf:"varint,12,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// 前のレスポンスの next_page_token
+	PageToken     string `protobuf:"bytes,13,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_v1_tasks_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListTasksRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetPriorities() []string {
+	if x != nil {
+		return x.Priorities
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetAssigneeId() string {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetMilestoneId() string {
+	if x != nil {
+		return x.MilestoneId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetSprintId() string {
+	if x != nil {
+		return x.SprintId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetEpicId() string {
+	if x != nil {
+		return x.EpicId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetDueDateFrom() string {
+	if x != nil {
+		return x.DueDateFrom
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetDueDateTo() string {
+	if x != nil {
+		return x.DueDateTo
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ListTasksResponse は ListTasks のレスポンス。
+type ListTasksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tasks []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// 次のページがある場合のみ設定する
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_v1_tasks_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListTasksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// BatchListTasksRequest は BatchListTasks のリクエスト。
+type BatchListTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 対象のプロジェクト（1〜200 件。重複は 1 つにまとめる）
+	ProjectIds []string `protobuf:"bytes,1,rep,name=project_ids,json=projectIds,proto3" json:"project_ids,omitempty"`
+	// 各プロジェクトに適用するフィルタ・並び順・件数。project_id と page_token は使わない
+	Filter        *ListTasksRequest `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchListTasksRequest) Reset() {
+	*x = BatchListTasksRequest{}
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchListTasksRequest) ProtoMessage() {}
+
+func (x *BatchListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchListTasksRequest.ProtoReflect.Descriptor instead.
+func (*BatchListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_v1_tasks_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchListTasksRequest) GetProjectIds() []string {
+	if x != nil {
+		return x.ProjectIds
+	}
+	return nil
+}
+
+func (x *BatchListTasksRequest) GetFilter() *ListTasksRequest {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// BatchListTasksResponse は BatchListTasks のレスポンス。
+type BatchListTasksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// project_ids の順。存在しない・操作者が閲覧できないプロジェクトは含めない
+	Projects      []*ProjectTasks `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchListTasksResponse) Reset() {
+	*x = BatchListTasksResponse{}
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchListTasksResponse) ProtoMessage() {}
+
+func (x *BatchListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchListTasksResponse.ProtoReflect.Descriptor instead.
+func (*BatchListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_v1_tasks_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchListTasksResponse) GetProjects() []*ProjectTasks {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+// ProjectTasks は 1 つのプロジェクトのタスク。
+type ProjectTasks struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Tasks     []*Task                `protobuf:"bytes,2,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// 次のページがある場合のみ設定する（ListTasks の page_token に使う）
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectTasks) Reset() {
+	*x = ProjectTasks{}
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectTasks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectTasks) ProtoMessage() {}
+
+func (x *ProjectTasks) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectTasks.ProtoReflect.Descriptor instead.
+func (*ProjectTasks) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_v1_tasks_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ProjectTasks) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ProjectTasks) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ProjectTasks) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// UpdateTaskRequest は UpdateTask のリクエスト。
+type UpdateTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 更新するタスク。id は必須。project_id を指定した場合はタスクの所属プロジェクトと一致しなければ NOT_FOUND
+	Task *Task `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// 更新するフィールド（title, description, status, priority, assignee_id, due_date, start_date, estimate,
+	// milestone_id, sprint_id, epic_id, label_ids）。値を設定していない optional のフィールドは外す（null にする）
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_v1_tasks_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateTaskRequest) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+// WatchTasksRequest は WatchTasks のリクエスト。
+type WatchTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTasksRequest) Reset() {
+	*x = WatchTasksRequest{}
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTasksRequest) ProtoMessage() {}
+
+func (x *WatchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTasksRequest.ProtoReflect.Descriptor instead.
+func (*WatchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_v1_tasks_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchTasksRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+// TaskEvent はタスクの変更イベント。受け取った側は対象のタスクを取得し直す。
+type TaskEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// task.created / task.updated / task.deleted
+	Type          string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	WorkspaceId   string `protobuf:"bytes,2,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	ProjectId     string `protobuf:"bytes,3,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	TaskId        string `protobuf:"bytes,4,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskEvent) Reset() {
+	*x = TaskEvent{}
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEvent) ProtoMessage() {}
+
+func (x *TaskEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_v1_tasks_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEvent.ProtoReflect.Descriptor instead.
+func (*TaskEvent) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_v1_tasks_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TaskEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+var File_proto_tasks_v1_tasks_proto protoreflect.FileDescriptor
+
+const file_proto_tasks_v1_tasks_proto_rawDesc = "" +
+	"\n" +
+	"\x1aproto/tasks/v1/tasks.proto\x12\x11teamflow.tasks.v1\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd5\x06\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tR\tprojectId\x12\x16\n" +
+	"\x06number\x18\x03 \x01(\x05R\x06number\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1a\n" +
+	"\bpriority\x18\a \x01(\tR\bpriority\x12$\n" +
+	"\vassignee_id\x18\b \x01(\tH\x00R\n" +
+	"assigneeId\x88\x01\x01\x12#\n" +
+	"\rassignee_name\x18\t \x01(\tR\fassigneeName\x125\n" +
+	"\bdue_date\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x129\n" +
+	"\n" +
+	"start_date\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x12\x1f\n" +
+	"\bestimate\x18\f \x01(\x05H\x01R\bestimate\x88\x01\x01\x12&\n" +
+	"\fmilestone_id\x18\r \x01(\tH\x02R\vmilestoneId\x88\x01\x01\x12 \n" +
+	"\tsprint_id\x18\x0e \x01(\tH\x03R\bsprintId\x88\x01\x01\x12\x1c\n" +
+	"\aepic_id\x18\x0f \x01(\tH\x04R\x06epicId\x88\x01\x01\x12\x1b\n" +
+	"\tlabel_ids\x18\x10 \x03(\tR\blabelIds\x129\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x13 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x14 \x01(\tR\tupdatedBy\x12;\n" +
+	"\varchived_at\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"archivedAtB\x0e\n" +
+	"\f_assignee_idB\v\n" +
+	"\t_estimateB\x0f\n" +
+	"\r_milestone_idB\f\n" +
+	"\n" +
+	"_sprint_idB\n" +
+	"\n" +
+	"\b_epic_id\"\xc5\x02\n" +
+	"\x11CreateTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tR\tprojectId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1a\n" +
+	"\bpriority\x18\x06 \x01(\tR\bpriority\x12\x1f\n" +
+	"\vassignee_id\x18\a \x01(\tR\n" +
+	"assigneeId\x12!\n" +
+	"\fmilestone_id\x18\b \x01(\tR\vmilestoneId\x12\x1b\n" +
+	"\tsprint_id\x18\t \x01(\tR\bsprintId\x12\x17\n" +
+	"\aepic_id\x18\n" +
+	" \x01(\tR\x06epicId\x12\x1b\n" +
+	"\tlabel_ids\x18\v \x03(\tR\blabelIds\"O\n" +
+	"\x16GetTaskByNumberRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\x05R\x06number\"\x91\x03\n" +
+	"\x10ListTasksRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x1a\n" +
+	"\bstatuses\x18\x02 \x03(\tR\bstatuses\x12\x1e\n" +
+	"\n" +
+	"priorities\x18\x03 \x03(\tR\n" +
+	"priorities\x12\x1f\n" +
+	"\vassignee_id\x18\x04 \x01(\tR\n" +
+	"assigneeId\x12!\n" +
+	"\fmilestone_id\x18\x05 \x01(\tR\vmilestoneId\x12\x1b\n" +
+	"\tsprint_id\x18\x06 \x01(\tR\bsprintId\x12\x17\n" +
+	"\aepic_id\x18\a \x01(\tR\x06epicId\x12\"\n" +
+	"\rdue_date_from\x18\b \x01(\tR\vdueDateFrom\x12\x1e\n" +
+	"\vdue_date_to\x18\t \x01(\tR\tdueDateTo\x12\x14\n" +
+	"\x05query\x18\n" +
+	" \x01(\tR\x05query\x12\x12\n" +
+	"\x04sort\x18\v \x01(\tR\x04sort\x12\x1b\n" +
+	"\tpage_size\x18\f \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\r \x01(\tR\tpageToken\"j\n" +
+	"\x11ListTasksResponse\x12-\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x17.teamflow.tasks.v1.TaskR\x05tasks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"u\n" +
+	"\x15BatchListTasksRequest\x12\x1f\n" +
+	"\vproject_ids\x18\x01 \x03(\tR\n" +
+	"projectIds\x12;\n" +
+	"\x06filter\x18\x02 \x01(\v2#.teamflow.tasks.v1.ListTasksRequestR\x06filter\"U\n" +
+	"\x16BatchListTasksResponse\x12;\n" +
+	"\bprojects\x18\x01 \x03(\v2\x1f.teamflow.tasks.v1.ProjectTasksR\bprojects\"\x84\x01\n" +
+	"\fProjectTasks\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12-\n" +
+	"\x05tasks\x18\x02 \x03(\v2\x17.teamflow.tasks.v1.TaskR\x05tasks\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"}\n" +
+	"\x11UpdateTaskRequest\x12+\n" +
+	"\x04task\x18\x01 \x01(\v2\x17.teamflow.tasks.v1.TaskR\x04task\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"2\n" +
+	"\x11WatchTasksRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\"z\n" +
+	"\tTaskEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12!\n" +
+	"\fworkspace_id\x18\x02 \x01(\tR\vworkspaceId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x03 \x01(\tR\tprojectId\x12\x17\n" +
+	"\atask_id\x18\x04 \x01(\tR\x06taskId2\x91\x04\n" +
+	"\vTaskService\x12K\n" +
+	"\n" +
+	"CreateTask\x12$.teamflow.tasks.v1.CreateTaskRequest\x1a\x17.teamflow.tasks.v1.Task\x12U\n" +
+	"\x0fGetTaskByNumber\x12).teamflow.tasks.v1.GetTaskByNumberRequest\x1a\x17.teamflow.tasks.v1.Task\x12V\n" +
+	"\tListTasks\x12#.teamflow.tasks.v1.ListTasksRequest\x1a$.teamflow.tasks.v1.ListTasksResponse\x12e\n" +
+	"\x0eBatchListTasks\x12(.teamflow.tasks.v1.BatchListTasksRequest\x1a).teamflow.tasks.v1.BatchListTasksResponse\x12K\n" +
+	"\n" +
+	"UpdateTask\x12$.teamflow.tasks.v1.UpdateTaskRequest\x1a\x17.teamflow.tasks.v1.Task\x12R\n" +
+	"\n" +
+	"WatchTasks\x12$.teamflow.tasks.v1.WatchTasksRequest\x1a\x1c.teamflow.tasks.v1.TaskEvent0\x01B(Z&teamflow-shared/proto/tasks/v1;tasksv1b\x06proto3"
+
+var (
+	file_proto_tasks_v1_tasks_proto_rawDescOnce sync.Once
+	file_proto_tasks_v1_tasks_proto_rawDescData []byte
+)
+
+func file_proto_tasks_v1_tasks_proto_rawDescGZIP() []byte {
+	file_proto_tasks_v1_tasks_proto_rawDescOnce.Do(func() {
+		file_proto_tasks_v1_tasks_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_tasks_v1_tasks_proto_rawDesc), len(file_proto_tasks_v1_tasks_proto_rawDesc)))
+	})
+	return file_proto_tasks_v1_tasks_proto_rawDescData
+}
+
+var file_proto_tasks_v1_tasks_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_tasks_v1_tasks_proto_goTypes = []any{
+	(*Task)(nil),                   // 0: teamflow.tasks.v1.Task
+	(*CreateTaskRequest)(nil),      // 1: teamflow.tasks.v1.CreateTaskRequest
+	(*GetTaskByNumberRequest)(nil), // 2: teamflow.tasks.v1.GetTaskByNumberRequest
+	(*ListTasksRequest)(nil),       // 3: teamflow.tasks.v1.ListTasksRequest
+	(*ListTasksResponse)(nil),      // 4: teamflow.tasks.v1.ListTasksResponse
+	(*BatchListTasksRequest)(nil),  // 5: teamflow.tasks.v1.BatchListTasksRequest
+	(*BatchListTasksResponse)(nil), // 6: teamflow.tasks.v1.BatchListTasksResponse
+	(*ProjectTasks)(nil),           // 7: teamflow.tasks.v1.ProjectTasks
+	(*UpdateTaskRequest)(nil),      // 8: teamflow.tasks.v1.UpdateTaskRequest
+	(*WatchTasksRequest)(nil),      // 9: teamflow.tasks.v1.WatchTasksRequest
+	(*TaskEvent)(nil),              // 10: teamflow.tasks.v1.TaskEvent
+	(*timestamppb.Timestamp)(nil),  // 11: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),  // 12: google.protobuf.FieldMask
+}
+var file_proto_tasks_v1_tasks_proto_depIdxs = []int32{
+	11, // 0: teamflow.tasks.v1.Task.due_date:type_name -> google.protobuf.Timestamp
+	11, // 1: teamflow.tasks.v1.Task.start_date:type_name -> google.protobuf.Timestamp
+	11, // 2: teamflow.tasks.v1.Task.created_at:type_name -> google.protobuf.Timestamp
+	11, // 3: teamflow.tasks.v1.Task.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 4: teamflow.tasks.v1.Task.archived_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: teamflow.tasks.v1.ListTasksResponse.tasks:type_name -> teamflow.tasks.v1.Task
+	3,  // 6: teamflow.tasks.v1.BatchListTasksRequest.filter:type_name -> teamflow.tasks.v1.ListTasksRequest
+	7,  // 7: teamflow.tasks.v1.BatchListTasksResponse.projects:type_name -> teamflow.tasks.v1.ProjectTasks
+	0,  // 8: teamflow.tasks.v1.ProjectTasks.tasks:type_name -> teamflow.tasks.v1.Task
+	0,  // 9: teamflow.tasks.v1.UpdateTaskRequest.task:type_name -> teamflow.tasks.v1.Task
+	12, // 10: teamflow.tasks.v1.UpdateTaskRequest.update_mask:type_name -> google.protobuf.FieldMask
+	1,  // 11: teamflow.tasks.v1.TaskService.CreateTask:input_type -> teamflow.tasks.v1.CreateTaskRequest
+	2,  // 12: teamflow.tasks.v1.TaskService.GetTaskByNumber:input_type -> teamflow.tasks.v1.GetTaskByNumberRequest
+	3,  // 13: teamflow.tasks.v1.TaskService.ListTasks:input_type -> teamflow.tasks.v1.ListTasksRequest
+	5,  // 14: teamflow.tasks.v1.TaskService.BatchListTasks:input_type -> teamflow.tasks.v1.BatchListTasksRequest
+	8,  // 15: teamflow.tasks.v1.TaskService.UpdateTask:input_type -> teamflow.tasks.v1.UpdateTaskRequest
+	9,  // 16: teamflow.tasks.v1.TaskService.WatchTasks:input_type -> teamflow.tasks.v1.WatchTasksRequest
+	0,  // 17: teamflow.tasks.v1.TaskService.CreateTask:output_type -> teamflow.tasks.v1.Task
+	0,  // 18: teamflow.tasks.v1.TaskService.GetTaskByNumber:output_type -> teamflow.tasks.v1.Task
+	4,  // 19: teamflow.tasks.v1.TaskService.ListTasks:output_type -> teamflow.tasks.v1.ListTasksResponse
+	6,  // 20: teamflow.tasks.v1.TaskService.BatchListTasks:output_type -> teamflow.tasks.v1.BatchListTasksResponse
+	0,  // 21: teamflow.tasks.v1.TaskService.UpdateTask:output_type -> teamflow.tasks.v1.Task
+	10, // 22: teamflow.tasks.v1.TaskService.WatchTasks:output_type -> teamflow.tasks.v1.TaskEvent
+	17, // [17:23] is the sub-list for method output_type
+	11, // [11:17] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_proto_tasks_v1_tasks_proto_init() }
+func file_proto_tasks_v1_tasks_proto_init() {
+	if File_proto_tasks_v1_tasks_proto != nil {
+		return
+	}
+	file_proto_tasks_v1_tasks_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_tasks_v1_tasks_proto_rawDesc), len(file_proto_tasks_v1_tasks_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_tasks_v1_tasks_proto_goTypes,
+		DependencyIndexes: file_proto_tasks_v1_tasks_proto_depIdxs,
+		MessageInfos:      file_proto_tasks_v1_tasks_proto_msgTypes,
+	}.Build()
+	File_proto_tasks_v1_tasks_proto = out.File
+	file_proto_tasks_v1_tasks_proto_goTypes = nil
+	file_proto_tasks_v1_tasks_proto_depIdxs = nil
+}
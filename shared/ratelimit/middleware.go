@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/authz"
+	"teamflow-shared/clock"
+	"teamflow-shared/pat"
+)
+
+// 既定のティア。RATE_LIMIT_TIERS に無いティアは制限しない。
+const (
+	// TierAnonymous は操作者（X-User-ID）の無いリクエスト。クライアントの IP アドレスごとに数える
+	TierAnonymous = "anonymous"
+	// TierUser は認証ゲートウェイが操作者を設定したリクエスト。操作者ごとに数える
+	TierUser = "user"
+	// TierToken は個人用アクセストークンで認証したリクエスト。トークンの持ち主ごとに数える
+	TierToken = "token"
+)
+
+// Policy は Middleware の制限の設定。
+type Policy struct {
+	// Tiers はティアごとの 1 分あたりの上限。無いティア・0 のティアは制限しない
+	Tiers map[string]int
+	// UserTiers は既定（user / token）の代わりに使うティアを操作者ごとに指定する。任意。
+	UserTiers map[string]string
+	// Skip は制限しないリクエスト（サービス API キーで認証したサービス間の呼び出しなど）を判定する。任意。nil の場合はすべて制限する。
+	Skip func(r *http.Request) bool
+	// Clock は補充の計算に使う。任意。nil の場合は clock.System を使う。
+	Clock clock.Clock
+}
+
+// Middleware は p のティアの上限でリクエストを制限する。
+// 操作者（pat.Middleware の後であればトークンの持ち主）ごとに数え、X-RateLimit-* ヘッダを付ける。
+// 上限を超えた場合は 429（TOO_MANY_REQUESTS）と Retry-After を返す。
+// ティアが 1 つも設定されていない場合は next をそのまま返す。
+func Middleware(p Policy, next http.Handler) http.Handler {
+	if len(p.Tiers) == 0 {
+		return next
+	}
+	limiter := NewLimiter(p.Clock)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if p.Skip != nil && p.Skip(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		tier, subject := p.classify(r)
+		limit := p.Tiers[tier]
+		if limit <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		res := limiter.Take(tier+":"+subject, limit)
+		WriteHeaders(w, res)
+		if !res.Allowed {
+			apierror.Write(w, http.StatusTooManyRequests, apierror.New(apierror.CodeTooManyRequests, "rate limit exceeded for tier "+tier))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// classify はリクエストのティアと、回数を数える単位（操作者の ID・IP アドレス）を返す。
+func (p Policy) classify(r *http.Request) (tier, subject string) {
+	actor := strings.TrimSpace(r.Header.Get(authz.ActorHeader))
+	if actor == "" {
+		return TierAnonymous, clientIP(r)
+	}
+	if t, ok := p.UserTiers[actor]; ok {
+		return t, actor
+	}
+	if _, ok := pat.FromContext(r.Context()); ok {
+		return TierToken, actor
+	}
+	return TierUser, actor
+}
+
+// clientIP は接続元の IP アドレスを返す（X-Forwarded-For は偽装できるため使わない）。
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,168 @@
+// Package ratelimit は 1 分あたりのリクエスト数の制限（トークンバケット）と、残りの回数を伝えるヘッダを提供する。
+//
+// 制限はキー（操作者・トークン・サービス API キーなど）ごとに数え、上限はティア（anonymous / user / token など）ごとに設定する。
+// どのレスポンスにも残りの回数を付け、上限を超えた場合は 429（TOO_MANY_REQUESTS）と Retry-After を返す。
+//
+//	X-RateLimit-Limit      1 分あたりの上限
+//	X-RateLimit-Remaining  すぐに送れる残りの回数
+//	X-RateLimit-Reset      残りの回数が上限まで回復するまでの秒数
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"teamflow-shared/clock"
+)
+
+// レスポンスに付けるヘッダ。
+const (
+	HeaderLimit     = "X-RateLimit-Limit"
+	HeaderRemaining = "X-RateLimit-Remaining"
+	HeaderReset     = "X-RateLimit-Reset"
+)
+
+// Result は Take の結果。
+type Result struct {
+	// Allowed はリクエストを受け付けるかどうか
+	Allowed bool
+	// Limit は 1 分あたりの上限
+	Limit int
+	// Remaining はすぐに送れる残りの回数
+	Remaining int
+	// Reset は残りの回数が上限まで回復するまでの時間
+	Reset time.Duration
+	// RetryAfter は Allowed が false の場合に、次に受け付けられるまでの時間
+	RetryAfter time.Duration
+}
+
+// WriteHeaders は res の X-RateLimit-* ヘッダ（受け付けない場合は Retry-After も）を w に設定する。
+func WriteHeaders(w http.ResponseWriter, res Result) {
+	h := w.Header()
+	h.Set(HeaderLimit, strconv.Itoa(res.Limit))
+	h.Set(HeaderRemaining, strconv.Itoa(res.Remaining))
+	h.Set(HeaderReset, strconv.Itoa(ceilSeconds(res.Reset)))
+	if !res.Allowed {
+		h.Set("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// Limiter はキーごとのトークンバケットを保持する。並行に呼び出してよい。
+type Limiter struct {
+	clock clock.Clock
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewLimiter は Limiter を生成する。clk が nil の場合は clock.System。
+func NewLimiter(clk clock.Clock) *Limiter {
+	return &Limiter{clock: clock.OrSystem(clk), buckets: make(map[string]*bucket)}
+}
+
+// Take は key の回数を 1 つ消費する。perMinute は 1 分あたりの上限（バーストも同じ回数まで）。
+// 同じ key の上限が変わった場合（設定の変更など）は新しい上限でバケットを作り直す。
+func (l *Limiter) Take(key string, perMinute int) Result {
+	now := l.clock.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.buckets[key]
+	if !ok || b.limit != perMinute {
+		b = newBucket(perMinute, now)
+		l.buckets[key] = b
+	}
+	return b.take(now)
+}
+
+// bucket は 1 分あたり limit 回を上限とするトークンバケット（バーストも limit 回まで）。
+type bucket struct {
+	limit  int
+	rate   float64 // 1 秒あたりに補充するトークン数
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(perMinute int, now time.Time) *bucket {
+	return &bucket{limit: perMinute, rate: float64(perMinute) / 60, tokens: float64(perMinute), last: now}
+}
+
+// take はトークンを 1 つ消費する。足りない場合は 1 つ補充されるまでの時間を RetryAfter で返す。
+func (b *bucket) take(now time.Time) Result {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit), b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+	res := Result{Limit: b.limit}
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = b.duration(1 - b.tokens)
+	}
+	res.Remaining = int(b.tokens)
+	res.Reset = b.duration(float64(b.limit) - b.tokens)
+	return res
+}
+
+// duration は n 個のトークンが補充されるまでの時間を返す。
+func (b *bucket) duration(n float64) time.Duration {
+	return time.Duration(n / b.rate * float64(time.Second))
+}
+
+// ParseTiers は RATE_LIMIT_TIERS 形式（カンマ区切りの tier:rpm。例: anonymous:60,user:600）を読み込む。
+// rpm が 0 のティアは制限しない。ティア名の重複はエラーにする。
+func ParseTiers(list string) (map[string]int, error) {
+	tiers := make(map[string]int)
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, rpm, ok := strings.Cut(item, ":")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("expected tier:rpm, got %q", item)
+		}
+		n, err := strconv.Atoi(rpm)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("tier %s: rpm must be a non-negative integer, got %q", name, rpm)
+		}
+		if _, dup := tiers[name]; dup {
+			return nil, fmt.Errorf("duplicate tier %s", name)
+		}
+		tiers[name] = n
+	}
+	return tiers, nil
+}
+
+// ParseUserTiers は RATE_LIMIT_USER_TIERS 形式（カンマ区切りの userId:tier。例: u-1:premium）を読み込む。
+// tiers に無いティアを指定した場合はエラーにする。
+func ParseUserTiers(list string, tiers map[string]int) (map[string]string, error) {
+	users := make(map[string]string)
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		userID, tier, ok := strings.Cut(item, ":")
+		if !ok || userID == "" || tier == "" {
+			return nil, fmt.Errorf("expected userId:tier, got %q", item)
+		}
+		if _, known := tiers[tier]; !known {
+			return nil, fmt.Errorf("user %s: unknown tier %s", userID, tier)
+		}
+		if _, dup := users[userID]; dup {
+			return nil, fmt.Errorf("duplicate user %s", userID)
+		}
+		users[userID] = tier
+	}
+	return users, nil
+}
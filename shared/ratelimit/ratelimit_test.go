@@ -0,0 +1,164 @@
+package ratelimit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"teamflow-shared/authz"
+	"teamflow-shared/clock"
+	"teamflow-shared/pat"
+	"teamflow-shared/ratelimit"
+)
+
+func TestLimiter_Take(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC))
+	l := ratelimit.NewLimiter(fake)
+
+	for i, want := range []int{1, 0} {
+		res := l.Take("u-1", 2)
+		if !res.Allowed || res.Limit != 2 || res.Remaining != want {
+			t.Fatalf("request %d: unexpected result %+v", i, res)
+		}
+	}
+	res := l.Take("u-1", 2)
+	if res.Allowed || res.RetryAfter != 30*time.Second || res.Reset != time.Minute {
+		t.Errorf("expected the third request to be rejected, got %+v", res)
+	}
+	// 他のキーには影響しない
+	if res := l.Take("u-2", 2); !res.Allowed {
+		t.Errorf("expected another key to be allowed, got %+v", res)
+	}
+
+	fake.Advance(30 * time.Second)
+	if res := l.Take("u-1", 2); !res.Allowed || res.Remaining != 0 {
+		t.Errorf("expected one request after refill, got %+v", res)
+	}
+	// 上限が変わった場合は新しい上限で数え直す
+	if res := l.Take("u-1", 10); !res.Allowed || res.Limit != 10 || res.Remaining != 9 {
+		t.Errorf("expected the new limit to apply, got %+v", res)
+	}
+}
+
+func TestParseTiers(t *testing.T) {
+	got, err := ratelimit.ParseTiers(" anonymous:60, user:600,,token:0 ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]int{"anonymous": 60, "user": 600, "token": 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseTiers() = %v, want %v", got, want)
+	}
+
+	for _, in := range []string{"user", ":60", "user:-1", "user:many", "user:1,user:2"} {
+		if _, err := ratelimit.ParseTiers(in); err == nil {
+			t.Errorf("ParseTiers(%q): expected error", in)
+		}
+	}
+}
+
+func TestParseUserTiers(t *testing.T) {
+	tiers := map[string]int{"user": 600, "premium": 6000}
+	got, err := ratelimit.ParseUserTiers("u-1:premium, u-2:user", tiers)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]string{"u-1": "premium", "u-2": "user"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseUserTiers() = %v, want %v", got, want)
+	}
+
+	for _, in := range []string{"u-1", "u-1:", "u-1:gold", "u-1:user,u-1:premium"} {
+		if _, err := ratelimit.ParseUserTiers(in, tiers); err == nil {
+			t.Errorf("ParseUserTiers(%q): expected error", in)
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC))
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	h := ratelimit.Middleware(ratelimit.Policy{
+		Tiers:     map[string]int{ratelimit.TierAnonymous: 1, ratelimit.TierUser: 2, ratelimit.TierToken: 3, "premium": 0},
+		UserTiers: map[string]string{"vip": "premium"},
+		Skip:      func(r *http.Request) bool { return r.Header.Get("X-Service-Key") == "s3cr3t" },
+		Clock:     fake,
+	}, next)
+
+	type request struct {
+		actor      string
+		token      bool
+		remoteAddr string
+		service    bool
+	}
+	call := func(req request) *httptest.ResponseRecorder {
+		ctx := context.Background()
+		if req.token {
+			ctx = pat.NewContext(ctx, pat.Identity{UserID: req.actor})
+		}
+		r := httptest.NewRequest(http.MethodGet, "/api/projects", nil).WithContext(ctx)
+		if req.actor != "" {
+			r.Header.Set(authz.ActorHeader, req.actor)
+		}
+		if req.remoteAddr != "" {
+			r.RemoteAddr = req.remoteAddr
+		}
+		if req.service {
+			r.Header.Set("X-Service-Key", "s3cr3t")
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+	// allowed は req を limit+1 回送り、limit 回だけ受け付けることを確認する
+	allowed := func(name string, req request, limit int) {
+		t.Helper()
+		for i := 0; i < limit; i++ {
+			w := call(req)
+			if w.Code != http.StatusNoContent {
+				t.Fatalf("%s: request %d: expected 204, got %d", name, i, w.Code)
+			}
+			if got := w.Header().Get(ratelimit.HeaderLimit); got != strconv.Itoa(limit) {
+				t.Errorf("%s: X-RateLimit-Limit = %q, want %d", name, got, limit)
+			}
+		}
+		w := call(req)
+		if w.Code != http.StatusTooManyRequests {
+			t.Fatalf("%s: expected 429 after %d requests, got %d", name, limit, w.Code)
+		}
+		if w.Header().Get("Retry-After") == "" || w.Header().Get(ratelimit.HeaderRemaining) != "0" {
+			t.Errorf("%s: unexpected headers %v", name, w.Header())
+		}
+	}
+
+	allowed("user", request{actor: "u-1"}, 2)
+	allowed("token", request{actor: "u-2", token: true}, 3)
+	allowed("anonymous", request{remoteAddr: "192.0.2.1:1234"}, 1)
+	// 別の IP アドレス・操作者は別に数える
+	allowed("another address", request{remoteAddr: "192.0.2.2:1234"}, 1)
+	allowed("another user", request{actor: "u-3"}, 2)
+
+	// 上限の無いティア・サービス間の呼び出しは制限しない（ヘッダも付けない）
+	for i := 0; i < 10; i++ {
+		for _, req := range []request{{actor: "vip"}, {actor: "u-1", service: true}} {
+			w := call(req)
+			if w.Code != http.StatusNoContent || w.Header().Get(ratelimit.HeaderLimit) != "" {
+				t.Fatalf("%+v: expected no limit, got %d %v", req, w.Code, w.Header())
+			}
+		}
+	}
+
+	fake.Advance(30 * time.Second)
+	if w := call(request{actor: "u-1"}); w.Code != http.StatusNoContent {
+		t.Errorf("expected 204 after refill, got %d", w.Code)
+	}
+}
+
+func TestMiddleware_NoTiers(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {})
+	if got := ratelimit.Middleware(ratelimit.Policy{}, next); reflect.ValueOf(got).Pointer() != reflect.ValueOf(next).Pointer() {
+		t.Error("expected next to be returned as is")
+	}
+}
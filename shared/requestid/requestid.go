@@ -0,0 +1,87 @@
+// Package requestid は tasks / projects サービスで共通のリクエスト ID（X-Request-ID）の受け渡しを提供する。
+//
+// Middleware は受け取った X-Request-ID（無い・不正な場合は新しく生成したもの）を context とレスポンスヘッダに設定する。
+// ログ（teamflow-shared/logging）とエラーレスポンス（teamflow-shared/apierror）はこの ID を含み、
+// サービス間の呼び出しは Transport で同じ ID を引き継ぐため、1 つの操作を両サービスのログで追跡できる。
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// Header はリクエスト ID を受け渡すヘッダ。
+const Header = "X-Request-ID"
+
+// MaxLength は受け付けるリクエスト ID の最大長。これを超える値は使わずに新しく生成する。
+const MaxLength = 128
+
+// New はリクエスト ID（128 bit の乱数の 16 進文字列）を生成する。
+func New() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:]) // crypto/rand.Read は失敗しない
+	return hex.EncodeToString(b[:])
+}
+
+// Valid は id をリクエスト ID として受け付けるかどうかを返す。
+// ログやヘッダに埋め込むため、1〜MaxLength 文字の表示可能な ASCII（空白を除く）のみ受け付ける。
+func Valid(id string) bool {
+	if id == "" || len(id) > MaxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+type contextKey struct{}
+
+// NewContext はリクエスト ID を持つ context を返す。
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext は context のリクエスト ID を返す。未設定の場合は空文字。
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Middleware はリクエストの X-Request-ID を context とレスポンスヘッダに設定して next を呼ぶ。
+// ヘッダが無い、または Valid でない場合は New で生成する。
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(Header)
+		if !Valid(id) {
+			id = New()
+		}
+		w.Header().Set(Header, id)
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
+
+// Transport はリクエストの context のリクエスト ID を X-Request-ID に設定する http.RoundTripper。
+// サービス間のクライアントで使い、呼び出し先のログに同じ ID を残す。
+type Transport struct {
+	// Base は実際に送信する RoundTripper。nil の場合は http.DefaultTransport
+	Base http.RoundTripper
+}
+
+// RoundTrip は X-Request-ID を設定したリクエストを Base で送信する。既にヘッダがある場合はそのまま送る。
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if id := FromContext(req.Context()); id != "" && req.Header.Get(Header) == "" {
+		// RoundTripper は受け取ったリクエストを変更してはいけないため複製する
+		req = req.Clone(req.Context())
+		req.Header.Set(Header, id)
+	}
+	return base.RoundTrip(req)
+}
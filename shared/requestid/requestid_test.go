@@ -0,0 +1,98 @@
+package requestid_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"teamflow-shared/requestid"
+)
+
+func TestValid(t *testing.T) {
+	for _, tt := range []struct {
+		id   string
+		want bool
+	}{
+		{id: "abc-123", want: true},
+		{id: "3f2b6c1e-8d7a-4b7e-9a55-0f1c2d3e4f5a", want: true},
+		{id: strings.Repeat("a", requestid.MaxLength), want: true},
+		{id: "", want: false},
+		{id: strings.Repeat("a", requestid.MaxLength+1), want: false},
+		{id: "has space", want: false},
+		{id: "line\nbreak", want: false},
+		{id: "日本語", want: false},
+	} {
+		if got := requestid.Valid(tt.id); got != tt.want {
+			t.Errorf("Valid(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	var gotID string
+	handler := requestid.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		gotID = requestid.FromContext(r.Context())
+	}))
+
+	tests := []struct {
+		name     string
+		incoming string
+		wantSame bool
+	}{
+		{name: "accepts incoming id", incoming: "req-1", wantSame: true},
+		{name: "generates when missing"},
+		{name: "replaces invalid id", incoming: "bad id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(requestid.Header, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if gotID == "" || w.Header().Get(requestid.Header) != gotID {
+				t.Fatalf("expected the same id in context and response, got %q and %q", gotID, w.Header().Get(requestid.Header))
+			}
+			if (gotID == tt.incoming) != tt.wantSame {
+				t.Errorf("unexpected id %q for incoming %q", gotID, tt.incoming)
+			}
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	var received string
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(requestid.Header)
+	}))
+	defer srv.Close()
+	client := &http.Client{Transport: &requestid.Transport{}}
+
+	req, _ := http.NewRequestWithContext(requestid.NewContext(context.Background(), "req-1"), http.MethodGet, srv.URL, nil)
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+	if received != "req-1" {
+		t.Errorf("expected forwarded id req-1, got %q", received)
+	}
+	if req.Header.Get(requestid.Header) != "" {
+		t.Error("the original request must not be modified")
+	}
+
+	// context に無い場合は付けない
+	req, _ = http.NewRequest(http.MethodGet, srv.URL, nil)
+	res, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+	if received != "" {
+		t.Errorf("expected no id, got %q", received)
+	}
+}
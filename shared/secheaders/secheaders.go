@@ -0,0 +1,88 @@
+// Package secheaders は API のレスポンスにセキュリティ関連のヘッダを付けるミドルウェアを提供する。
+//
+// ヘッダはハンドラを呼ぶ前に設定するため、ハンドラが同じヘッダを設定した場合はハンドラの値が優先される
+// （例: SSE の Cache-Control: no-cache、仕様の配信の Cache-Control: public, max-age=300）。
+package secheaders
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Omit を Headers の項目に指定すると、そのヘッダを付けない。
+const Omit = "-"
+
+// Headers は付けるヘッダの値。空の項目は既定値（Routes の場合は Options.Headers の値）を使う。
+type Headers struct {
+	ContentTypeOptions string // X-Content-Type-Options
+	FrameOptions       string // X-Frame-Options
+	ReferrerPolicy     string // Referrer-Policy
+	CacheControl       string // Cache-Control
+}
+
+// Defaults は API のレスポンスの既定値を返す。
+// API は HTML を返さずキャッシュもさせないため、最も厳しい値にしている。
+func Defaults() Headers {
+	return Headers{
+		ContentTypeOptions: "nosniff",
+		FrameOptions:       "DENY",
+		ReferrerPolicy:     "no-referrer",
+		CacheControl:       "no-store",
+	}
+}
+
+// Options はミドルウェアの設定。
+type Options struct {
+	// Headers はすべてのレスポンスに付けるヘッダ（空の項目は Defaults の値）
+	Headers Headers
+	// Routes はパスの接頭辞ごとの上書き（最も長い接頭辞を使う）。空の項目は Headers の値のまま
+	Routes map[string]Headers
+}
+
+// merge は h の空の項目を base の値で埋める。
+func (h Headers) merge(base Headers) Headers {
+	or := func(v, def string) string {
+		if v == "" {
+			return def
+		}
+		return v
+	}
+	return Headers{
+		ContentTypeOptions: or(h.ContentTypeOptions, base.ContentTypeOptions),
+		FrameOptions:       or(h.FrameOptions, base.FrameOptions),
+		ReferrerPolicy:     or(h.ReferrerPolicy, base.ReferrerPolicy),
+		CacheControl:       or(h.CacheControl, base.CacheControl),
+	}
+}
+
+func (h Headers) apply(header http.Header) {
+	set := func(key, v string) {
+		if v != "" && v != Omit {
+			header.Set(key, v)
+		}
+	}
+	set("X-Content-Type-Options", h.ContentTypeOptions)
+	set("X-Frame-Options", h.FrameOptions)
+	set("Referrer-Policy", h.ReferrerPolicy)
+	set("Cache-Control", h.CacheControl)
+}
+
+// Middleware は next のレスポンスに opts のヘッダを付ける。
+func Middleware(opts Options, next http.Handler) http.Handler {
+	base := opts.Headers.merge(Defaults())
+	routes := make(map[string]Headers, len(opts.Routes))
+	for prefix, h := range opts.Routes {
+		routes[prefix] = h.merge(base)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h, best := base, -1
+		for prefix, rh := range routes {
+			if len(prefix) > best && strings.HasPrefix(r.URL.Path, prefix) {
+				h, best = rh, len(prefix)
+			}
+		}
+		h.apply(w.Header())
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,81 @@
+package secheaders_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"teamflow-shared/secheaders"
+)
+
+func TestMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/tasks/events" {
+			w.Header().Set("Cache-Control", "no-cache")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	h := secheaders.Middleware(secheaders.Options{
+		Routes: map[string]secheaders.Headers{
+			"/api/":      {ReferrerPolicy: "same-origin"},
+			"/api/docs/": {FrameOptions: "SAMEORIGIN", CacheControl: secheaders.Omit},
+		},
+	}, next)
+
+	tests := []struct {
+		path string
+		want map[string]string
+	}{
+		{path: "/livez", want: map[string]string{
+			"X-Content-Type-Options": "nosniff",
+			"X-Frame-Options":        "DENY",
+			"Referrer-Policy":        "no-referrer",
+			"Cache-Control":          "no-store",
+		}},
+		{path: "/api/projects", want: map[string]string{
+			"X-Frame-Options": "DENY",
+			"Referrer-Policy": "same-origin",
+			"Cache-Control":   "no-store",
+		}},
+		// 最も長い接頭辞の設定を使い、Omit のヘッダは付けない
+		{path: "/api/docs/index.html", want: map[string]string{
+			"X-Frame-Options": "SAMEORIGIN",
+			"Referrer-Policy": "no-referrer",
+			"Cache-Control":   "",
+		}},
+		// ハンドラが設定した値が優先される
+		{path: "/api/tasks/events", want: map[string]string{
+			"X-Content-Type-Options": "nosniff",
+			"Cache-Control":          "no-cache",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			for key, want := range tt.want {
+				if got := w.Header().Get(key); got != want {
+					t.Errorf("%s = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMiddleware_OverrideDefaults(t *testing.T) {
+	h := secheaders.Middleware(secheaders.Options{
+		Headers: secheaders.Headers{CacheControl: "private, max-age=60", FrameOptions: secheaders.Omit},
+	}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/projects", nil))
+	if got := w.Header().Get("Cache-Control"); got != "private, max-age=60" {
+		t.Errorf("Cache-Control = %q", got)
+	}
+	if _, ok := w.Header()["X-Frame-Options"]; ok {
+		t.Error("X-Frame-Options should be omitted")
+	}
+	if got := w.Header().Get("X-Content-Type-Options"); got != "nosniff" {
+		t.Errorf("X-Content-Type-Options = %q", got)
+	}
+}
@@ -0,0 +1,31 @@
+package server
+
+import "sync"
+
+// Drain は graceful shutdown の開始を、SSE・gRPC のストリーミングのようにクライアントが切断するまで続くハンドラに知らせる。
+//
+// http.Server.Shutdown は処理中のハンドラが終わるのを待つが、終わらないハンドラの接続は drain の期間を過ぎると強制的に閉じられ、
+// クライアントは書き込みの途中で切断される。ハンドラは Done で停止を知り、再接続を促してから自ら終わる。
+// Options.Drain に設定すると、Shutdown の開始時（新規の接続の受け付けを止めた後）に閉じる。
+type Drain struct {
+	once sync.Once
+	done chan struct{}
+}
+
+// NewDrain は新しいDrainを生成する。
+func NewDrain() *Drain {
+	return &Drain{done: make(chan struct{})}
+}
+
+// Done は graceful shutdown が始まると閉じるチャネルを返す。d が nil の場合は閉じないチャネル（nil）を返す。
+func (d *Drain) Done() <-chan struct{} {
+	if d == nil {
+		return nil
+	}
+	return d.done
+}
+
+// Close は Done を閉じる。複数回呼んでもよい。
+func (d *Drain) Close() {
+	d.once.Do(func() { close(d.done) })
+}
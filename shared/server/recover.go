@@ -0,0 +1,32 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"runtime/debug"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/logging"
+)
+
+// Recover はハンドラの panic を 500（INTERNAL_ERROR）に変換し、スタックトレースをログに出力する。
+// http.ErrAbortHandler は net/http が接続を切るための panic なので、そのまま再送出する。
+func Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			p := recover()
+			if p == nil {
+				return
+			}
+			if err, ok := p.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(p)
+			}
+			logging.FromContext(r.Context()).ErrorContext(r.Context(), "panic recovered",
+				"panic", p,
+				"stack", string(debug.Stack()),
+			)
+			apierror.Write(w, http.StatusInternalServerError, apierror.New(apierror.CodeInternal, "internal server error"))
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Run は srv.Addr で listen して Serve する。
+func Run(ctx context.Context, srv *http.Server, drain time.Duration) error {
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	return Serve(ctx, srv, ln, drain)
+}
+
+// Serve は ln で srv を起動し、ctx が終了したら graceful shutdown する。srv.TLSConfig が設定されている場合は TLS で待ち受ける。
+// 新規の接続の受け付けを止め、処理中のリクエストは drain まで完了を待つ。
+// SSE などの長時間の接続は、srv に登録した Drain（Options.Drain）で停止を知らせ、ハンドラが自ら終わるのを待つ。
+// drain を過ぎても残っている接続は強制的に閉じる。
+func Serve(ctx context.Context, srv *http.Server, ln net.Listener, drain time.Duration) error {
+	errCh := make(chan error, 1)
+	go func() {
+		if srv.TLSConfig != nil {
+			// 証明書は TLSConfig（Certificates・GetCertificate）から取得する
+			errCh <- srv.ServeTLS(ln, "", "")
+			return
+		}
+		errCh <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-errCh:
+		// Shutdown 前に Serve が終了するのは listener の異常のみ
+		return fmt.Errorf("http server stopped: %w", err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down", "drain", drain.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), drain)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		_ = srv.Close()
+		if errors.Is(err, context.DeadlineExceeded) {
+			slog.Warn("drain period exceeded; closed remaining connections")
+		} else {
+			return fmt.Errorf("http server shutdown: %w", err)
+		}
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("http server stopped: %w", err)
+	}
+	return nil
+}
+
+// StartAdmin は addr で管理用のエンドポイント（/metrics など）を返すサーバーを起動する。
+// API と同じミドルウェアは適用しない。戻り値の stop で停止する。
+func StartAdmin(addr string, h http.Handler) (stop func(), err error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen admin port: %w", err)
+	}
+	srv := &http.Server{
+		Handler:      h,
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("admin server stopped", "error", err)
+		}
+	}()
+	slog.Info("admin server listening", "addr", ln.Addr().String())
+	return func() { _ = srv.Close() }, nil
+}
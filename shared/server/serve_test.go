@@ -0,0 +1,152 @@
+package server_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"teamflow-shared/server"
+)
+
+// startServe は handler を server.Serve で起動し、URL と serve の戻り値を受け取るチャネルを返す。
+func startServe(t *testing.T, ctx context.Context, handler http.Handler, drain time.Duration) (string, <-chan error) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Serve(ctx, &http.Server{Handler: handler}, ln, drain)
+	}()
+	return "http://" + ln.Addr().String(), done
+}
+
+func TestServe_DrainsInFlightRequests(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		_, _ = w.Write([]byte("done"))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	url, done := startServe(t, ctx, handler, 5*time.Second)
+
+	type result struct {
+		body string
+		err  error
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		res, err := http.Get(url)
+		if err != nil {
+			resCh <- result{err: err}
+			return
+		}
+		defer res.Body.Close()
+		b, err := io.ReadAll(res.Body)
+		resCh <- result{body: string(b), err: err}
+	}()
+
+	<-started
+	cancel()
+
+	// 処理中のリクエストが残っている間は終了しない
+	select {
+	case err := <-done:
+		t.Fatalf("serve returned before in-flight request finished: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	if r := <-resCh; r.err != nil || r.body != "done" {
+		t.Fatalf("expected in-flight request to complete, got body=%q err=%v", r.body, r.err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestServe_ClosesConnectionsAfterDrain(t *testing.T) {
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done() // SSE のように終わらないリクエスト
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	url, done := startServe(t, ctx, handler, 50*time.Millisecond)
+
+	go func() {
+		if res, err := http.Get(url); err == nil {
+			res.Body.Close()
+		}
+	}()
+	<-started
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve did not return after drain period")
+	}
+}
+
+func TestServe_SignalsDrainToStreams(t *testing.T) {
+	drain := server.NewDrain()
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		select {
+		case <-r.Context().Done():
+		case <-drain.Done():
+			// SSE のハンドラのように再接続を促してから終わる
+			_, _ = w.Write([]byte("retry: 1000\n\n"))
+		}
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Serve(ctx, server.New(handler, server.Options{Drain: drain}), ln, 5*time.Second)
+	}()
+
+	bodyCh := make(chan string, 1)
+	go func() {
+		res, err := http.Get("http://" + ln.Addr().String())
+		if err != nil {
+			bodyCh <- err.Error()
+			return
+		}
+		defer res.Body.Close()
+		b, _ := io.ReadAll(res.Body)
+		bodyCh <- string(b)
+	}()
+	<-started
+	cancel()
+
+	// drain の期間（5 秒）を待たずに、ストリームを閉じてから終了する
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("serve did not return after the stream finished")
+	}
+	if body := <-bodyCh; body != "retry: 1000\n\n" {
+		t.Errorf("expected the stream to end with a retry hint, got %q", body)
+	}
+}
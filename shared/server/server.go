@@ -0,0 +1,122 @@
+// Package server は tasks / projects サービスで共通の HTTP サーバーの構成（タイムアウト・ミドルウェアの順序・停止処理）を提供する。
+//
+// ミドルウェアは外側から次の順に適用する。
+//
+//	requestid → tracing → logging → metrics → secheaders → i18n → recover → cors → ハンドラ
+//
+// panic や CORS で拒否したリクエストもログ・メトリクス・トレースに残るよう、recover と cors を内側に置く。
+// セキュリティヘッダは recover の外側で付けるため、panic による 500 にも付く。
+// ValidationIssue の翻訳（i18n）はハンドラが書いたエラーレスポンスを書き換えるため、recover・cors より外側に置く。
+package server
+
+import (
+	"crypto/tls"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"teamflow-shared/cors"
+	"teamflow-shared/health"
+	"teamflow-shared/i18n"
+	"teamflow-shared/logging"
+	"teamflow-shared/requestid"
+	"teamflow-shared/secheaders"
+	"teamflow-shared/tracing"
+)
+
+// タイムアウトの既定値。
+const (
+	DefaultReadTimeout     = 15 * time.Second
+	DefaultWriteTimeout    = 15 * time.Second
+	DefaultIdleTimeout     = 60 * time.Second
+	DefaultShutdownTimeout = 20 * time.Second
+)
+
+// Options は API サーバーの設定。
+type Options struct {
+	// Addr は listen アドレス（例: :8080）
+	Addr string
+	// Logger はリクエストログの出力先（nil の場合は slog.Default()）
+	Logger *slog.Logger
+	// Tracer はリクエストのスパンを記録する Tracer（nil の場合は記録しない）
+	Tracer *tracing.Tracer
+	// Metrics はリクエストのメトリクスを計測するミドルウェア（nil の場合は計測しない）
+	Metrics func(http.Handler) http.Handler
+	// CORS はブラウザからの別オリジンのリクエストの許可設定
+	CORS cors.Options
+	// SecurityHeaders は API のレスポンスに付けるセキュリティヘッダ（ゼロ値の場合は secheaders.Defaults）
+	SecurityHeaders secheaders.Options
+	// Messages は ValidationIssue の message の翻訳（nil の場合は i18n.Common）
+	Messages i18n.Catalog
+	// Drain は graceful shutdown の開始を SSE などの長時間の接続に知らせる。任意。nil の場合は知らせない
+	Drain *Drain
+	// TLSConfig はクライアントとの接続の TLS の設定（TLSOptions.Config）。任意。nil の場合は平文で待ち受ける。
+	// 設定した場合は Serve が TLS で待ち受け、ALPN で HTTP/2 も受け付ける
+	TLSConfig *tls.Config
+	// H2C は平文の接続で HTTP/2（h2c、prior knowledge と Upgrade の両方）を受け付けるか。
+	// TLS を終端したロードバランサー・サービス間の呼び出しなど、内部の通信で多重化を使うためのもの
+	H2C bool
+
+	// 0 の場合は Default* を使う
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+}
+
+// Handler は h に共通のミドルウェアを適用したハンドラを返す。
+func Handler(h http.Handler, opts Options) http.Handler {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	messages := opts.Messages
+	if messages == nil {
+		messages = i18n.Common
+	}
+	h = Recover(cors.Middleware(opts.CORS, h))
+	h = secheaders.Middleware(opts.SecurityHeaders, i18n.Middleware(messages, h))
+	if opts.Metrics != nil {
+		h = opts.Metrics(h)
+	}
+	return requestid.Middleware(tracing.Middleware(opts.Tracer, logging.Middleware(logger, h)))
+}
+
+// New は h に共通のミドルウェアを適用した http.Server を生成する。
+func New(h http.Handler, opts Options) *http.Server {
+	handler := Handler(h, opts)
+	if opts.H2C && opts.TLSConfig == nil {
+		handler = h2c.NewHandler(handler, &http2.Server{IdleTimeout: orDefault(opts.IdleTimeout, DefaultIdleTimeout)})
+	}
+	srv := &http.Server{
+		Addr:         opts.Addr,
+		Handler:      handler,
+		TLSConfig:    opts.TLSConfig,
+		ReadTimeout:  orDefault(opts.ReadTimeout, DefaultReadTimeout),
+		WriteTimeout: orDefault(opts.WriteTimeout, DefaultWriteTimeout),
+		IdleTimeout:  orDefault(opts.IdleTimeout, DefaultIdleTimeout),
+	}
+	if opts.Drain != nil {
+		srv.RegisterOnShutdown(opts.Drain.Close)
+	}
+	return srv
+}
+
+// NewMux はヘルスチェックのエンドポイントを登録した ServeMux を生成する。
+// /livez はプロセスのみ、/readyz は checks の依存先も確認する。/healthz は /livez と同じ。
+func NewMux(checks *health.Checker) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/livez", health.LiveHandler())
+	mux.Handle("/healthz", health.LiveHandler())
+	mux.Handle("/readyz", checks.ReadyHandler())
+	return mux
+}
+
+func orDefault(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
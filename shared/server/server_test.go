@@ -0,0 +1,111 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/cors"
+	"teamflow-shared/health"
+	"teamflow-shared/requestid"
+	"teamflow-shared/server"
+)
+
+func TestHandler_RecoversPanic(t *testing.T) {
+	// metrics のミドルウェアが panic を 500 として観測できることも確認する
+	var observed int
+	metrics := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+			observed = rec.status
+		})
+	}
+	h := server.Handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}), server.Options{Metrics: metrics})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/tasks", nil)
+	req.Header.Set(requestid.Header, "req-1")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError || observed != http.StatusInternalServerError {
+		t.Fatalf("expected 500 (observed by metrics), got %d (observed %d)", w.Code, observed)
+	}
+	var body apierror.ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	if body.Error != apierror.CodeInternal || body.RequestID != "req-1" {
+		t.Errorf("unexpected body: %+v", body)
+	}
+	if got := w.Header().Get("X-Content-Type-Options"); got != "nosniff" {
+		t.Errorf("expected security headers on the 500 response, got X-Content-Type-Options=%q", got)
+	}
+}
+
+func TestRecover_ReraisesAbortHandler(t *testing.T) {
+	h := server.Recover(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	defer func() {
+		if p := recover(); p != http.ErrAbortHandler {
+			t.Fatalf("expected ErrAbortHandler to be re-raised, got %v", p)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestHandler_AppliesCORS(t *testing.T) {
+	opts := server.Options{CORS: cors.Options{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{"GET"},
+	}}
+	h := server.Handler(http.NotFoundHandler(), opts)
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/tasks", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected preflight 204, got %d", w.Code)
+	}
+	if w.Header().Get(requestid.Header) == "" {
+		t.Error("expected X-Request-ID on the preflight response")
+	}
+}
+
+func TestNew_DefaultTimeouts(t *testing.T) {
+	srv := server.New(http.NotFoundHandler(), server.Options{Addr: ":8080", WriteTimeout: 30 * time.Second})
+	if srv.Addr != ":8080" || srv.ReadTimeout != server.DefaultReadTimeout ||
+		srv.WriteTimeout != 30*time.Second || srv.IdleTimeout != server.DefaultIdleTimeout {
+		t.Errorf("unexpected server: addr=%s read=%s write=%s idle=%s", srv.Addr, srv.ReadTimeout, srv.WriteTimeout, srv.IdleTimeout)
+	}
+}
+
+func TestNewMux_HealthEndpoints(t *testing.T) {
+	mux := server.NewMux(health.NewChecker(0))
+	for _, path := range []string{"/livez", "/healthz", "/readyz"} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected 200, got %d", path, w.Code)
+		}
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
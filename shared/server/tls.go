@@ -0,0 +1,106 @@
+package server
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/crypto/acme/autocert"
+
+	"teamflow-shared/cors"
+)
+
+// TLSOptions はクライアントとの接続の TLS の設定。
+// 証明書のファイル（CertFile・KeyFile）か ACME での自動取得（AutocertHosts）のいずれかを設定すると TLS で待ち受け、
+// ALPN で HTTP/2 も受け付ける。どちらも設定しない場合は平文で待ち受ける（TLS はロードバランサーで終端する）。
+type TLSOptions struct {
+	// CertFile・KeyFile は PEM 形式の証明書（中間証明書を含む）と秘密鍵のパス。証明書を更新した場合は再起動する
+	CertFile string
+	KeyFile  string
+	// AutocertHosts は ACME（Let's Encrypt など）で証明書を取得・更新するホスト名。
+	// 検証は TLS-ALPN-01 で行うため、インターネットから 443 番ポートでこのサーバーに到達できること
+	AutocertHosts []string
+	// AutocertCacheDir は取得した証明書と ACME のアカウントの鍵を保存するディレクトリ。
+	// 再起動のたびに証明書を取得し直して発行数の制限にかからないよう、AutocertHosts を設定する場合は必須
+	AutocertCacheDir string
+}
+
+// Enabled は TLS で待ち受けるかどうかを返す。
+func (o TLSOptions) Enabled() bool {
+	return o.CertFile != "" || o.KeyFile != "" || len(o.AutocertHosts) > 0
+}
+
+// Validate は設定の組み合わせを確認する。
+func (o TLSOptions) Validate() error {
+	if (o.CertFile == "") != (o.KeyFile == "") {
+		return errors.New("both the certificate and the key file are required")
+	}
+	if o.CertFile != "" && len(o.AutocertHosts) > 0 {
+		return errors.New("the certificate files and autocert cannot be combined")
+	}
+	if len(o.AutocertHosts) > 0 && o.AutocertCacheDir == "" {
+		return errors.New("autocert requires a cache directory")
+	}
+	return nil
+}
+
+// Config は Options.TLSConfig に設定する tls.Config を返す。TLS を設定していない場合は nil を返す。
+// 証明書のファイルは起動時に読み込み、読み込めない場合はエラーを返す。
+func (o TLSOptions) Config() (*tls.Config, error) {
+	if !o.Enabled() {
+		return nil, nil
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	if len(o.AutocertHosts) > 0 {
+		m := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(o.AutocertHosts...),
+			Cache:      autocert.DirCache(o.AutocertCacheDir),
+		}
+		cfg := m.TLSConfig()
+		cfg.MinVersion = tls.VersionTLS12
+		return cfg, nil
+	}
+	cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate: %w", err)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// ParseTLSOptions は getenv からクライアントとの接続の TLS の設定と h2c を読み込み、組み合わせを確認する。
+// エラーがあった場合も読み込めた値を返す（呼び出し側で他の設定のエラーとまとめて報告できる）。
+//
+//	TLS_CERT_FILE           TLS の証明書（PEM、中間証明書を含む）のパス（TLS_KEY_FILE と併せて設定すると TLS で待ち受け、HTTP/2 も受け付ける、default: 無し＝平文）
+//	TLS_KEY_FILE            TLS の秘密鍵（PEM）のパス（default: 無し）
+//	TLS_AUTOCERT_HOSTS      ACME（Let's Encrypt など）で証明書を自動で取得するホスト名（カンマ区切り、TLS_CERT_FILE と併用不可、default: 無し）
+//	TLS_AUTOCERT_CACHE_DIR  取得した証明書を保存するディレクトリ（TLS_AUTOCERT_HOSTS を設定する場合は必須）
+//	H2C                     平文の接続で HTTP/2（h2c）を受け付けるか（内部の通信用、TLS と併用不可、default: false）
+func ParseTLSOptions(getenv func(string) string) (opts TLSOptions, h2c bool, err error) {
+	opts = TLSOptions{
+		CertFile:         getenv("TLS_CERT_FILE"),
+		KeyFile:          getenv("TLS_KEY_FILE"),
+		AutocertHosts:    cors.SplitList(getenv("TLS_AUTOCERT_HOSTS")),
+		AutocertCacheDir: getenv("TLS_AUTOCERT_CACHE_DIR"),
+	}
+	var errs []error
+	if err := opts.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("TLS_CERT_FILE / TLS_KEY_FILE / TLS_AUTOCERT_* are invalid: %w", err))
+	}
+	if v := getenv("H2C"); v != "" {
+		if h2c, err = strconv.ParseBool(v); err != nil {
+			errs = append(errs, fmt.Errorf("H2C must be true or false, got %q", v))
+		}
+	}
+	// h2c は平文の接続でのみ使う（TLS では ALPN で HTTP/2 を選ぶ）
+	if h2c && opts.Enabled() {
+		errs = append(errs, errors.New("H2C cannot be combined with TLS_CERT_FILE / TLS_AUTOCERT_HOSTS"))
+	}
+	return opts, h2c, errors.Join(errs...)
+}
@@ -0,0 +1,181 @@
+package server_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"golang.org/x/net/http2"
+
+	"teamflow-shared/server"
+)
+
+// writeSelfSignedCert は 127.0.0.1 の自己署名の証明書と秘密鍵を dir に書き出し、そのパスを返す。
+func writeSelfSignedCert(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "teamflow-test"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	certFile = filepath.Join(dir, "tls.crt")
+	keyFile = filepath.Join(dir, "tls.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	return certFile, keyFile
+}
+
+// serveProto は opts で server.New したサーバーを起動し、リクエストのプロトコル（HTTP/1.1・HTTP/2.0）を返すサーバーのアドレスを返す。
+func serveProto(t *testing.T, opts server.Options) string {
+	t.Helper()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	})
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Serve(ctx, server.New(handler, opts), ln, time.Second)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("serve: %v", err)
+		}
+	})
+	return ln.Addr().String()
+}
+
+func readProto(t *testing.T, client *http.Client, url string) string {
+	t.Helper()
+	res, err := client.Get(url)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer res.Body.Close()
+	buf := make([]byte, 16)
+	n, _ := res.Body.Read(buf)
+	return string(buf[:n])
+}
+
+func TestServe_TLSWithHTTP2(t *testing.T) {
+	certFile, keyFile := writeSelfSignedCert(t, t.TempDir())
+	tlsConfig, err := server.TLSOptions{CertFile: certFile, KeyFile: keyFile}.Config()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	addr := serveProto(t, server.Options{TLSConfig: tlsConfig})
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, // テスト用の自己署名の証明書
+		ForceAttemptHTTP2: true,
+	}}
+	if got := readProto(t, client, "https://"+addr); got != "HTTP/2.0" {
+		t.Errorf("expected HTTP/2.0 over TLS, got %q", got)
+	}
+}
+
+func TestServe_H2C(t *testing.T) {
+	addr := serveProto(t, server.Options{H2C: true})
+
+	// prior knowledge の h2c（平文の HTTP/2）
+	h2cClient := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+	if got := readProto(t, h2cClient, "http://"+addr); got != "HTTP/2.0" {
+		t.Errorf("expected HTTP/2.0 over h2c, got %q", got)
+	}
+	// HTTP/1.1 のクライアントもそのまま受け付ける
+	if got := readProto(t, http.DefaultClient, "http://"+addr); got != "HTTP/1.1" {
+		t.Errorf("expected HTTP/1.1, got %q", got)
+	}
+}
+
+func TestTLSOptions(t *testing.T) {
+	if cfg, err := (server.TLSOptions{}).Config(); cfg != nil || err != nil {
+		t.Errorf("expected no TLS by default, got %v, %v", cfg, err)
+	}
+
+	for name, o := range map[string]server.TLSOptions{
+		"cert without key":     {CertFile: "tls.crt"},
+		"files and autocert":   {CertFile: "tls.crt", KeyFile: "tls.key", AutocertHosts: []string{"api.example.com"}},
+		"autocert without dir": {AutocertHosts: []string{"api.example.com"}},
+		"missing certificate":  {CertFile: "missing.crt", KeyFile: "missing.key"},
+	} {
+		if _, err := o.Config(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	cfg, err := server.TLSOptions{AutocertHosts: []string{"api.example.com"}, AutocertCacheDir: t.TempDir()}.Config()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.GetCertificate == nil || !slices.Contains(cfg.NextProtos, "acme-tls/1") || !slices.Contains(cfg.NextProtos, "h2") {
+		t.Errorf("expected an autocert config answering TLS-ALPN-01 and h2, got %+v", cfg.NextProtos)
+	}
+}
+
+func TestParseTLSOptions(t *testing.T) {
+	env := func(m map[string]string) func(string) string {
+		return func(k string) string { return m[k] }
+	}
+
+	opts, h2c, err := server.ParseTLSOptions(env(map[string]string{
+		"TLS_AUTOCERT_HOSTS":     "api.example.com, www.example.com",
+		"TLS_AUTOCERT_CACHE_DIR": "/var/cache/autocert",
+	}))
+	if err != nil || h2c || !opts.Enabled() || !slices.Equal(opts.AutocertHosts, []string{"api.example.com", "www.example.com"}) {
+		t.Errorf("unexpected result %+v, %v, %v", opts, h2c, err)
+	}
+	if opts, h2c, err := server.ParseTLSOptions(env(map[string]string{"H2C": "true"})); err != nil || !h2c || opts.Enabled() {
+		t.Errorf("expected plaintext h2c, got %+v, %v, %v", opts, h2c, err)
+	}
+
+	for name, m := range map[string]map[string]string{
+		"cert without key": {"TLS_CERT_FILE": "tls.crt"},
+		"invalid h2c":      {"H2C": "yes please"},
+		"h2c with tls":     {"TLS_CERT_FILE": "tls.crt", "TLS_KEY_FILE": "tls.key", "H2C": "true"},
+	} {
+		if _, _, err := server.ParseTLSOptions(env(m)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// Package serviceauth は projects / tasks サービス間の呼び出しの認証（サービス API キー）を提供する。
+//
+// エンドユーザーの認証（X-User-ID / Cookie）とは別に、サービス間専用のエンドポイント
+// （タスクの集計、プロジェクトの削除に伴う一括操作、テンプレートからのタスクの作成など）は
+// 呼び出し元のサービスが X-Service-Key ヘッダで送るキーで認証する。キーごとに 1 分あたりのリクエスト数を制限できる。
+//
+//	受け付ける側: SERVICE_API_KEYS  カンマ区切りの name:key[:rpm]（例: projects:s3cr3t:600。rpm 省略・0 は無制限）
+//	呼び出す側:   SERVICE_API_KEY   送信するキー
+//
+// キーが 1 つも設定されていなければ認証しない（ローカル開発・既存の構成との互換のため）。
+package serviceauth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/clock"
+	"teamflow-shared/ratelimit"
+)
+
+// Header はサービス API キーを送るヘッダ。
+const Header = "X-Service-Key"
+
+// Key は受け付けるサービス API キー。
+type Key struct {
+	// Name は呼び出し元のサービス名（ログ・ServiceFromContext 用。例: projects）
+	Name string
+	// Secret はヘッダで送られるキーの値
+	Secret string
+	// RatePerMinute は 1 分あたりに受け付けるリクエスト数。0 の場合は制限しない
+	RatePerMinute int
+}
+
+// ParseKeys は SERVICE_API_KEYS 形式（カンマ区切りの name:key[:rpm]）を読み込む。
+// 名前・キーの重複はエラーにする。
+func ParseKeys(list string) ([]Key, error) {
+	var keys []Key
+	names := make(map[string]bool)
+	secrets := make(map[string]bool)
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("expected name:key[:rpm], got %q", redact(parts[0]))
+		}
+		k := Key{Name: parts[0], Secret: parts[1]}
+		if len(parts) == 3 {
+			rpm, err := strconv.Atoi(parts[2])
+			if err != nil || rpm < 0 {
+				return nil, fmt.Errorf("key %s: rpm must be a non-negative integer, got %q", k.Name, parts[2])
+			}
+			k.RatePerMinute = rpm
+		}
+		if names[k.Name] {
+			return nil, fmt.Errorf("duplicate key name %s", k.Name)
+		}
+		if secrets[k.Secret] {
+			return nil, fmt.Errorf("key %s: duplicate key value", k.Name)
+		}
+		names[k.Name] = true
+		secrets[k.Secret] = true
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// redact はエラーメッセージにキーの値を含めないよう、name:key の key 以降を取り除く。
+func redact(name string) string {
+	if name == "" {
+		return "(empty)"
+	}
+	return name + ":..."
+}
+
+// Authenticate のエラー。
+var (
+	// ErrInvalidKey はキーが無い・一致しない場合のエラー。
+	ErrInvalidKey = errors.New("service API key is missing or invalid")
+	// ErrRateLimited はキーのレート制限を超えた場合のエラー。
+	ErrRateLimited = errors.New("rate limit exceeded for service key")
+)
+
+type serviceKey struct{}
+
+// ContextWithService は認証した呼び出し元のサービス名を ctx に設定する。
+func ContextWithService(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, serviceKey{}, name)
+}
+
+// ServiceFromContext は認証した呼び出し元のサービス名を返す。認証していない場合は空文字。
+func ServiceFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(serviceKey{}).(string)
+	return name
+}
+
+// Authenticator はサービス API キーを検証し、キーごとのレート制限を行う。
+type Authenticator struct {
+	keys    []Key
+	limiter *ratelimit.Limiter
+}
+
+// NewAuthenticator は keys を受け付ける Authenticator を生成する。clk が nil の場合は clock.System。
+func NewAuthenticator(keys []Key, clk clock.Clock) *Authenticator {
+	return &Authenticator{keys: keys, limiter: ratelimit.NewLimiter(clk)}
+}
+
+// Enabled はキーが設定されていて認証を行うかどうかを返す。
+func (a *Authenticator) Enabled() bool {
+	return a != nil && len(a.keys) > 0
+}
+
+// Authenticated は r が有効なサービス API キーを送っているかどうかを返す（レート制限は数えない）。
+// キーが設定されていない場合は false。ratelimit.Policy.Skip でサービス間の呼び出しを操作者の制限から外すのに使う。
+func (a *Authenticator) Authenticated(r *http.Request) bool {
+	if !a.Enabled() {
+		return false
+	}
+	_, ok := a.lookup(r.Header.Get(Header))
+	return ok
+}
+
+// Require はサービス API キーを検証してから next に渡すハンドラを返す。
+// キーが無い・一致しない場合は 401（UNAUTHORIZED）、レート制限を超えた場合は 429（TOO_MANY_REQUESTS）と Retry-After を返す。
+// レート制限のあるキーには X-RateLimit-* ヘッダ（teamflow-shared/ratelimit）を付ける。
+// キーが設定されていない場合は検証せずに next に渡す。
+func (a *Authenticator) Require(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.Enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		key, ok := a.lookup(r.Header.Get(Header))
+		if !ok {
+			apierror.Write(w, http.StatusUnauthorized, apierror.New(apierror.CodeUnauthorized, Header+" header is missing or invalid"))
+			return
+		}
+		if key.RatePerMinute > 0 {
+			res := a.limiter.Take(key.Name, key.RatePerMinute)
+			ratelimit.WriteHeaders(w, res)
+			if !res.Allowed {
+				apierror.Write(w, http.StatusTooManyRequests, apierror.New(apierror.CodeTooManyRequests, "rate limit exceeded for service key "+key.Name))
+				return
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(ContextWithService(r.Context(), key.Name)))
+	})
+}
+
+// Authenticate は secret のキーを検証してレート制限を数え、呼び出し元のサービス名を返す。
+// HTTP 以外（gRPC のメタデータ）で送られたキーの検証に使う（HTTP は Require を使う）。
+// キーが無い・一致しない場合は ErrInvalidKey、レート制限を超えた場合は ErrRateLimited を返す。
+// キーが設定されていない場合は検証せずに空文字を返す。
+func (a *Authenticator) Authenticate(secret string) (string, error) {
+	if !a.Enabled() {
+		return "", nil
+	}
+	key, ok := a.lookup(secret)
+	if !ok {
+		return "", ErrInvalidKey
+	}
+	if key.RatePerMinute > 0 && !a.limiter.Take(key.Name, key.RatePerMinute).Allowed {
+		return "", fmt.Errorf("%w %s", ErrRateLimited, key.Name)
+	}
+	return key.Name, nil
+}
+
+// lookup は secret に一致するキーを返す。比較は定数時間で行う（すべてのキーと比較する）。
+func (a *Authenticator) lookup(secret string) (Key, bool) {
+	if secret == "" {
+		return Key{}, false
+	}
+	var found Key
+	ok := false
+	for _, k := range a.keys {
+		if subtle.ConstantTimeCompare([]byte(k.Secret), []byte(secret)) == 1 {
+			found, ok = k, true
+		}
+	}
+	return found, ok
+}
+
+// Transport は X-Service-Key にキーを設定する http.RoundTripper。
+// サービス間のクライアントで使う。Key が空の場合は何も設定しない。
+type Transport struct {
+	// Key は送信するサービス API キー
+	Key string
+	// Base は実際に送信する RoundTripper。nil の場合は http.DefaultTransport
+	Base http.RoundTripper
+}
+
+// RoundTrip は X-Service-Key を設定したリクエストを Base で送信する。
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if t.Key != "" {
+		// RoundTripper は受け取ったリクエストを変更してはいけないため複製する
+		req = req.Clone(req.Context())
+		req.Header.Set(Header, t.Key)
+	}
+	return base.RoundTrip(req)
+}
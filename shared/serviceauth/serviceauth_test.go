@@ -0,0 +1,205 @@
+package serviceauth_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"teamflow-shared/clock"
+	"teamflow-shared/serviceauth"
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		list    string
+		want    []serviceauth.Key
+		wantErr bool
+	}{
+		{list: "", want: nil},
+		{list: "projects:s3cr3t", want: []serviceauth.Key{{Name: "projects", Secret: "s3cr3t"}}},
+		{list: "projects:a:600, batch:b:0", want: []serviceauth.Key{{Name: "projects", Secret: "a", RatePerMinute: 600}, {Name: "batch", Secret: "b"}}},
+		{list: "projects", wantErr: true},
+		{list: "projects:", wantErr: true},
+		{list: "projects:a:fast", wantErr: true},
+		{list: "projects:a:-1", wantErr: true},
+		{list: "projects:a,projects:b", wantErr: true},
+		{list: "projects:a,batch:a", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.list, func(t *testing.T) {
+			got, err := serviceauth.ParseKeys(tt.list)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseKeys(%q) = %+v, want %+v", tt.list, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticator_Require(t *testing.T) {
+	var service string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		service = serviceauth.ServiceFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})
+	fake := clock.NewFake(time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC))
+	auth := serviceauth.NewAuthenticator([]serviceauth.Key{
+		{Name: "projects", Secret: "s3cr3t", RatePerMinute: 2},
+		{Name: "admin", Secret: "unlimited"},
+	}, fake)
+	h := auth.Require(next)
+
+	call := func(key string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(context.Background())
+		if key != "" {
+			r.Header.Set(serviceauth.Header, key)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	if w := call(""); w.Code != http.StatusUnauthorized {
+		t.Errorf("missing key: expected 401, got %d", w.Code)
+	}
+	if w := call("wrong"); w.Code != http.StatusUnauthorized {
+		t.Errorf("wrong key: expected 401, got %d", w.Code)
+	}
+
+	for i := 0; i < 2; i++ {
+		if w := call("s3cr3t"); w.Code != http.StatusNoContent {
+			t.Fatalf("request %d: expected 204, got %d", i, w.Code)
+		}
+	}
+	if service != "projects" {
+		t.Errorf("expected service projects in context, got %q", service)
+	}
+	w := call("s3cr3t")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 after the limit, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("expected Retry-After 30, got %q", got)
+	}
+	if got := w.Header().Get("X-RateLimit-Remaining"); got != "0" {
+		t.Errorf("expected X-RateLimit-Remaining 0, got %q", got)
+	}
+	// 他のキーには影響しない（制限の無いキーには X-RateLimit-* を付けない）
+	for i := 0; i < 5; i++ {
+		w := call("unlimited")
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("unlimited key: expected 204, got %d", w.Code)
+		}
+		if got := w.Header().Get("X-RateLimit-Limit"); got != "" {
+			t.Fatalf("unlimited key: expected no X-RateLimit-Limit, got %q", got)
+		}
+	}
+
+	fake.Advance(30 * time.Second)
+	if w := call("s3cr3t"); w.Code != http.StatusNoContent {
+		t.Errorf("expected 204 after refill, got %d", w.Code)
+	}
+}
+
+func TestAuthenticator_Authenticated(t *testing.T) {
+	auth := serviceauth.NewAuthenticator([]serviceauth.Key{{Name: "projects", Secret: "s3cr3t", RatePerMinute: 1}}, nil)
+	for _, tt := range []struct {
+		key  string
+		want bool
+	}{
+		{key: "s3cr3t", want: true},
+		{key: "wrong", want: false},
+		{key: "", want: false},
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.key != "" {
+			r.Header.Set(serviceauth.Header, tt.key)
+		}
+		// レート制限の回数は数えない
+		for i := 0; i < 3; i++ {
+			if got := auth.Authenticated(r); got != tt.want {
+				t.Errorf("Authenticated(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		}
+	}
+	if serviceauth.NewAuthenticator(nil, nil).Authenticated(httptest.NewRequest(http.MethodGet, "/", nil)) {
+		t.Error("expected an authenticator without keys to authenticate nothing")
+	}
+}
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC))
+	auth := serviceauth.NewAuthenticator([]serviceauth.Key{{Name: "projects", Secret: "s3cr3t", RatePerMinute: 1}}, fake)
+
+	for _, key := range []string{"", "wrong"} {
+		if _, err := auth.Authenticate(key); !errors.Is(err, serviceauth.ErrInvalidKey) {
+			t.Errorf("Authenticate(%q): expected ErrInvalidKey, got %v", key, err)
+		}
+	}
+	name, err := auth.Authenticate("s3cr3t")
+	if err != nil || name != "projects" {
+		t.Fatalf("expected projects, got %q, %v", name, err)
+	}
+	if _, err := auth.Authenticate("s3cr3t"); !errors.Is(err, serviceauth.ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited after the limit, got %v", err)
+	}
+	fake.Advance(time.Minute)
+	if _, err := auth.Authenticate("s3cr3t"); err != nil {
+		t.Errorf("expected the key to be accepted after refill, got %v", err)
+	}
+
+	// キーが設定されていない場合は検証しない
+	if name, err := serviceauth.NewAuthenticator(nil, nil).Authenticate(""); err != nil || name != "" {
+		t.Errorf("expected no authentication without keys, got %q, %v", name, err)
+	}
+}
+
+func TestAuthenticator_NoKeys(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	auth := serviceauth.NewAuthenticator(nil, nil)
+	if auth.Enabled() {
+		t.Fatal("expected authenticator without keys to be disabled")
+	}
+	w := httptest.NewRecorder()
+	auth.Require(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(context.Background()))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected requests to pass without keys, got %d", w.Code)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(serviceauth.Header)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &serviceauth.Transport{Key: "s3cr3t"}}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if got != "s3cr3t" {
+		t.Errorf("expected %s header s3cr3t, got %q", serviceauth.Header, got)
+	}
+	if req.Header.Get(serviceauth.Header) != "" {
+		t.Error("expected the original request not to be modified")
+	}
+}
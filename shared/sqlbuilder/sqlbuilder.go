@@ -0,0 +1,88 @@
+// Package sqlbuilder は tasks / projects の SQL リポジトリが検索の SELECT 文を組み立てる小さなビルダーを提供する。
+//
+// プレースホルダ番号は Arg で払い出すため、条件の追加・並べ替えで番号がずれることはない。
+// 値は必ず Arg 経由で渡し、SQL 文字列に直接埋め込まない（カラム名・ソート式はホワイトリストから渡す）。
+package sqlbuilder
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Select は SELECT 文を組み立てる。
+type Select struct {
+	columns string
+	from    string
+	where   []string
+	orderBy []string
+	limit   string
+	args    []interface{}
+}
+
+// NewSelect は from から columns を取り出す SELECT 文のビルダーを生成する。
+func NewSelect(columns, from string) *Select {
+	return &Select{columns: columns, from: from}
+}
+
+// Arg は値をパラメータに追加し、そのプレースホルダ（$n）を返す。
+func (b *Select) Arg(v interface{}) string {
+	b.args = append(b.args, v)
+	return "$" + strconv.Itoa(len(b.args))
+}
+
+// ArgList は複数の値をパラメータに追加し、"$n, $m, ..." を返す（IN 句用）。
+func (b *Select) ArgList(vs ...interface{}) string {
+	placeholders := make([]string, len(vs))
+	for i, v := range vs {
+		placeholders[i] = b.Arg(v)
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+// Where は条件を AND で追加する。OR を含む条件は呼び出し側で括弧で囲むこと。
+func (b *Select) Where(cond string) *Select {
+	b.where = append(b.where, cond)
+	return b
+}
+
+// OrderBy はソート式を追加する。
+func (b *Select) OrderBy(exprs ...string) *Select {
+	b.orderBy = append(b.orderBy, exprs...)
+	return b
+}
+
+// Limit は LIMIT 句を設定する。
+func (b *Select) Limit(n int) *Select {
+	b.limit = b.Arg(n)
+	return b
+}
+
+// Build は SQL 文字列とパラメータを返す。
+func (b *Select) Build() (string, []interface{}) {
+	var sb strings.Builder
+	sb.WriteString("SELECT ")
+	sb.WriteString(b.columns)
+	sb.WriteString(" FROM ")
+	sb.WriteString(b.from)
+	if len(b.where) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(b.where, " AND "))
+	}
+	if len(b.orderBy) > 0 {
+		sb.WriteString(" ORDER BY ")
+		sb.WriteString(strings.Join(b.orderBy, ", "))
+	}
+	if b.limit != "" {
+		sb.WriteString(" LIMIT ")
+		sb.WriteString(b.limit)
+	}
+	return sb.String(), b.args
+}
+
+// likeEscaper は LIKE パターンの特殊文字をエスケープする（PostgreSQL の既定のエスケープ文字は \）。
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// EscapeLike は s を LIKE パターン内でリテラルとして扱えるようにエスケープする。
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
@@ -0,0 +1,46 @@
+package sqlbuilder_test
+
+import (
+	"reflect"
+	"testing"
+
+	"teamflow-shared/sqlbuilder"
+)
+
+func TestSelect(t *testing.T) {
+	b := sqlbuilder.NewSelect("id", "tasks")
+	b.Where("project_id = " + b.Arg("proj-1"))
+	b.Where("status IN (" + b.ArgList("todo", "done") + ")")
+	b.OrderBy("created_at ASC").OrderBy("id ASC")
+	b.Limit(10)
+
+	gotSQL, gotArgs := b.Build()
+
+	wantSQL := "SELECT id FROM tasks WHERE project_id = $1 AND status IN ($2, $3) ORDER BY created_at ASC, id ASC LIMIT $4"
+	if gotSQL != wantSQL {
+		t.Errorf("SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+	wantArgs := []interface{}{"proj-1", "todo", "done", 10}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Errorf("args mismatch: got %v, want %v", gotArgs, wantArgs)
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "bug", want: "bug"},
+		{in: "100%", want: `100\%`},
+		{in: "snake_case", want: `snake\_case`},
+		{in: `C:\path`, want: `C:\\path`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := sqlbuilder.EscapeLike(tt.in); got != tt.want {
+				t.Errorf("EscapeLike(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
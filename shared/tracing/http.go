@@ -0,0 +1,31 @@
+package tracing
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// Middleware はリクエストごとにサーバーのスパンを記録する（5xx はエラーとして記録する）。
+// traceparent ヘッダがある場合はそのトレースに続けて記録する。t が nil の場合は next をそのまま返す。
+func Middleware(t *Tracer, next http.Handler) http.Handler {
+	if t == nil {
+		return next
+	}
+	return otelhttp.NewHandler(next, "",
+		otelhttp.WithTracerProvider(t.provider),
+		otelhttp.WithPropagators(propagator),
+		otelhttp.WithSpanNameFormatter(spanName),
+	)
+}
+
+// NewTransport はクライアントのスパンを記録し、traceparent ヘッダでトレースを引き継ぐ http.RoundTripper を返す。
+// サービス間のクライアントで使う。t が nil の場合は記録せず、context のトレースの伝搬のみ行う。
+// base が nil の場合は http.DefaultTransport で送信する。
+func NewTransport(t *Tracer, base http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(base,
+		otelhttp.WithTracerProvider(t.Provider()),
+		otelhttp.WithPropagators(propagator),
+		otelhttp.WithSpanNameFormatter(spanName),
+	)
+}
@@ -0,0 +1,72 @@
+// Package pgxtrace は pgx の問い合わせを OpenTelemetry のスパンとして記録する。
+// 各サービスの pgxpool.Config の ConnConfig.Tracer に設定する。
+package pgxtrace
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName はスパンを記録する計装の名前（OTLP の scope）。
+const instrumentationName = "teamflow-shared/tracing/pgxtrace"
+
+// QueryTracer は pgx の問い合わせごとにスパンを記録する pgx.QueryTracer。
+// リクエストのトレースの中（context に有効な SpanContext がある場合）の問い合わせのみ記録する。
+type QueryTracer struct {
+	tracer trace.Tracer
+}
+
+// コンパイル時にインターフェース実装を保証する。
+var _ pgx.QueryTracer = (*QueryTracer)(nil)
+
+// NewQueryTracer は tp でスパンを記録する QueryTracer を生成する（tracing.Tracer の Provider を渡す）。
+func NewQueryTracer(tp trace.TracerProvider) *QueryTracer {
+	return &QueryTracer{tracer: tp.Tracer(instrumentationName)}
+}
+
+// spanKey は TraceQueryStart で開始したスパンを TraceQueryEnd に渡す context のキー。
+// trace.SpanFromContext では、記録しなかった場合に親（HTTP のスパンなど）を終了してしまうため使わない。
+type spanKey struct{}
+
+// TraceQueryStart は問い合わせのスパンを開始する。
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	ctx, span := t.tracer.Start(ctx, "db "+Operation(data.SQL),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.statement", data.SQL),
+		),
+	)
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// TraceQueryEnd は問い合わせのスパンを終了する。
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span, ok := ctx.Value(spanKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	}
+	span.End()
+}
+
+// Operation は SQL の先頭のキーワード（SELECT, INSERT など）を返す（スパン名・メトリクスのラベル用）。
+func Operation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "query"
+	}
+	return strings.ToUpper(fields[0])
+}
@@ -0,0 +1,59 @@
+package pgxtrace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestQueryTracer(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	qt := NewQueryTracer(tp)
+
+	// リクエストのトレースの外の問い合わせは記録しない
+	ctx := qt.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	qt.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+
+	reqCtx, root := tp.Tracer("test").Start(context.Background(), "HTTP GET")
+	ctx = qt.TraceQueryStart(reqCtx, nil, pgx.TraceQueryStartData{SQL: "\n\tupdate tasks SET name = $1"})
+	qt.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{
+		CommandTag: pgconn.NewCommandTag("UPDATE 2"),
+		Err:        errors.New("deadlock detected"),
+	})
+	// 問い合わせの終了で親のスパンを終了しない
+	if !root.IsRecording() {
+		t.Fatal("expected the request span to be still recording")
+	}
+	root.End()
+
+	spans := rec.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	q := spans[0]
+	if q.Name() != "db UPDATE" || q.Parent().SpanID() != root.SpanContext().SpanID() || q.SpanContext().TraceID() != root.SpanContext().TraceID() {
+		t.Fatalf("unexpected query span: %s parent=%s", q.Name(), q.Parent().SpanID())
+	}
+	if q.Status().Code != codes.Error {
+		t.Errorf("expected the query error to be recorded, got %+v", q.Status())
+	}
+}
+
+func TestOperation(t *testing.T) {
+	for sql, want := range map[string]string{
+		"select * from tasks":   "SELECT",
+		"\n\tINSERT INTO tasks": "INSERT",
+		"":                      "query",
+	} {
+		if got := Operation(sql); got != want {
+			t.Errorf("Operation(%q) = %q, want %q", sql, got, want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Package tracing は tasks / projects / users サービスで共通の分散トレーシングを OpenTelemetry で提供する。
+//
+// スパンは OpenTelemetry の SDK で記録し、OTLP/HTTP（otlptracehttp）で送る。送信先は OTLP/HTTP を受け付ける
+// コレクタ（OpenTelemetry Collector, Jaeger など）であればよい。サービス間は W3C Trace Context（traceparent）で
+// トレースを引き継ぐ。
+//
+// HTTP ハンドラは Middleware、サービス間のクライアントは NewTransport（いずれも otelhttp）、DB の問い合わせは
+// pgxtrace の QueryTracer でスパンを記録し、1 リクエストの中でハンドラと DB の時間を分けて確認できる。
+//
+// *Tracer が nil の場合はスパンを記録しない（Middleware は next をそのまま返し、NewTransport は traceparent の伝搬のみ行う）。
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// TraceparentHeader は W3C Trace Context のヘッダ。
+const TraceparentHeader = "traceparent"
+
+// propagator はサービス間でトレースを引き継ぐ形式（W3C Trace Context）。
+var propagator = propagation.TraceContext{}
+
+// Options は Tracer の設定。
+type Options struct {
+	// Endpoint は OTLP/HTTP の送信先（例: http://otel-collector:4318）。/v1/traces で終わらない場合は付け足す
+	Endpoint string
+	// ServiceName はトレースの service.name
+	ServiceName string
+	// SampleRatio は新しいトレースを記録する割合（0〜1）。親がある場合は親の sampled に従う
+	SampleRatio float64
+}
+
+// Tracer はスパンを記録し、まとめて OTLP/HTTP で送る OpenTelemetry の TracerProvider。
+type Tracer struct {
+	provider *sdktrace.TracerProvider
+}
+
+// NewTracer は opts.Endpoint に送る Tracer を生成する。送信はバッチで行い、Shutdown で送信待ちのスパンを送って停止する。
+func NewTracer(opts Options) (*Tracer, error) {
+	endpoint := strings.TrimRight(opts.Endpoint, "/")
+	if !strings.HasSuffix(endpoint, "/v1/traces") {
+		endpoint += "/v1/traces"
+	}
+	exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("tracing: failed to create OTLP exporter: %w", err)
+	}
+	return newTracer(sdktrace.WithBatcher(exporter), opts), nil
+}
+
+// newTracer は processor にスパンを渡す Tracer を生成する（テストではスパンを記録するだけの processor を渡す）。
+func newTracer(processor sdktrace.TracerProviderOption, opts Options) *Tracer {
+	return &Tracer{provider: sdktrace.NewTracerProvider(
+		processor,
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", opts.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)}
+}
+
+// Provider はスパンを記録する TracerProvider を返す。t が nil の場合は何も記録しない TracerProvider を返す。
+func (t *Tracer) Provider() trace.TracerProvider {
+	if t == nil {
+		return noop.NewTracerProvider()
+	}
+	return t.provider
+}
+
+// Shutdown は送信待ちのスパンを送ってから停止する。ctx が終了した場合は残りを捨てる。
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	return t.provider.Shutdown(ctx)
+}
+
+// spanName は HTTP のスパン名（HTTP GET など）。ID を含むパスは名前に入れない。
+func spanName(_ string, r *http.Request) string {
+	return "HTTP " + r.Method
+}
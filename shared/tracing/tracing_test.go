@@ -0,0 +1,197 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const incomingTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// newTestTracer は終了したスパンを recorder に記録する Tracer を返す。
+func newTestTracer(t *testing.T, ratio float64) (*Tracer, *tracetest.SpanRecorder) {
+	t.Helper()
+	rec := tracetest.NewSpanRecorder()
+	tracer := newTracer(sdktrace.WithSpanProcessor(rec), Options{ServiceName: "test", SampleRatio: ratio})
+	t.Cleanup(func() { _ = tracer.Shutdown(context.Background()) })
+	return tracer, rec
+}
+
+func TestMiddleware_ContinuesIncomingTrace(t *testing.T) {
+	tracer, rec := newTestTracer(t, 1)
+
+	var inner trace.SpanContext
+	handler := Middleware(tracer, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inner = trace.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/projects", nil)
+	req.Header.Set(TraceparentHeader, incomingTraceparent)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := rec.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	s := spans[0]
+	if s.SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" || s.Parent().SpanID().String() != "00f067aa0ba902b7" {
+		t.Fatalf("expected the span to continue the incoming trace, got trace=%s parent=%s", s.SpanContext().TraceID(), s.Parent().SpanID())
+	}
+	if !inner.Equal(s.SpanContext()) {
+		t.Fatalf("expected the handler to see the server span, got %+v", inner)
+	}
+	if s.Name() != "HTTP GET" || s.SpanKind() != trace.SpanKindServer {
+		t.Fatalf("unexpected span: %s kind=%s", s.Name(), s.SpanKind())
+	}
+	if s.Status().Code != codes.Error {
+		t.Fatalf("expected 5xx to be recorded as an error, got %+v", s.Status())
+	}
+}
+
+func TestMiddleware_NilTracer(t *testing.T) {
+	called := false
+	next := http.HandlerFunc(func(http.ResponseWriter, *http.Request) { called = true })
+	Middleware(nil, next).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if !called {
+		t.Fatal("expected the handler to be called")
+	}
+}
+
+func TestTracer_SampleRatio(t *testing.T) {
+	tracer, rec := newTestTracer(t, 0)
+	tr := tracer.Provider().Tracer("test")
+
+	ctx, span := tr.Start(context.Background(), "root")
+	if span.IsRecording() {
+		t.Fatal("expected no recording with ratio 0")
+	}
+	// 記録しない場合も SpanContext は伝搬する
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		t.Fatal("expected a span context to propagate")
+	}
+	span.End()
+
+	// 記録対象の親がある場合は割合に関係なく記録する
+	parent := propagator.Extract(context.Background(), headerCarrier(TraceparentHeader, incomingTraceparent))
+	_, child := tr.Start(parent, "child")
+	if !child.IsRecording() {
+		t.Fatal("expected a span for a sampled parent")
+	}
+	child.End()
+
+	if n := len(rec.Ended()); n != 1 {
+		t.Fatalf("expected 1 span, got %d", n)
+	}
+}
+
+func TestTransport_InjectsTraceparent(t *testing.T) {
+	tracer, rec := newTestTracer(t, 1)
+
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(TraceparentHeader)
+	}))
+	defer srv.Close()
+
+	ctx, root := tracer.Provider().Tracer("test").Start(context.Background(), "root")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	client := &http.Client{Transport: NewTransport(tracer, nil)}
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	root.End()
+
+	if req.Header.Get(TraceparentHeader) != "" {
+		t.Fatal("expected the original request to be left unchanged")
+	}
+	rootSC := root.SpanContext()
+	if !strings.HasPrefix(got, "00-"+rootSC.TraceID().String()+"-") || strings.Contains(got, rootSC.SpanID().String()) {
+		t.Fatalf("expected traceparent with the client span as parent, got %q", got)
+	}
+	if n := len(rec.Ended()); n != 2 {
+		t.Fatalf("expected 2 spans, got %d", n)
+	}
+}
+
+func TestTransport_NilTracerPropagates(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(TraceparentHeader)
+	}))
+	defer srv.Close()
+
+	ctx := propagator.Extract(context.Background(), headerCarrier(TraceparentHeader, incomingTraceparent))
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	res, err := (&http.Client{Transport: NewTransport(nil, nil)}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if got != incomingTraceparent {
+		t.Fatalf("expected the incoming traceparent to propagate, got %q", got)
+	}
+}
+
+func TestNewTracer_ExportsOTLP(t *testing.T) {
+	var (
+		mu          sync.Mutex
+		paths       []string
+		contentType string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		paths = append(paths, r.URL.Path)
+		contentType = r.Header.Get("Content-Type")
+	}))
+	defer srv.Close()
+
+	tracer, err := NewTracer(Options{Endpoint: srv.URL + "/", ServiceName: "tasks", SampleRatio: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := tracer.Provider().Tracer("test").Start(context.Background(), "root")
+	span.End()
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(paths) != 1 || paths[0] != "/v1/traces" {
+		t.Fatalf("expected 1 export to /v1/traces, got %v", paths)
+	}
+	if contentType != "application/x-protobuf" {
+		t.Errorf("expected OTLP/HTTP protobuf, got %q", contentType)
+	}
+}
+
+func TestTracer_Nil(t *testing.T) {
+	var tracer *Tracer
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, span := tracer.Provider().Tracer("test").Start(context.Background(), "root"); span.IsRecording() {
+		t.Fatal("expected a nil tracer not to record")
+	}
+}
+
+// headerCarrier は 1 つのヘッダを持つ TextMapCarrier を返す。
+func headerCarrier(key, value string) propagation.HeaderCarrier {
+	h := http.Header{}
+	h.Set(key, value)
+	return propagation.HeaderCarrier(h)
+}
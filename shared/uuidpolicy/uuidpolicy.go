@@ -0,0 +1,82 @@
+// Package uuidpolicy は ID（タスク・プロジェクトの ID、担当者のユーザー ID）として受け付ける UUID の形式の方針を提供する。
+//
+// UUID は 8-4-4-4-12 桁の 16 進数（ハイフン区切り、36 文字、大文字・小文字を問わない）の表記のみを受け付ける。
+// 波括弧や urn:uuid: の付いた表記、ハイフンの無い表記は受け付けない。
+// どのバージョンまで受け付けるかは Policy で選ぶ。サーバーが生成する ID（uuid.New）はバージョン 4 なので、どちらの方針でも受け付ける。
+package uuidpolicy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Policy は受け付ける UUID の厳しさ。
+type Policy string
+
+const (
+	// Any は RFC 4122 の表記であればバージョン・バリアントを問わず受け付ける（Nil UUID を含む）
+	Any Policy = "any"
+	// V4V7 はバージョン 4（ランダム）と 7（時刻順）で、RFC 4122 のバリアントの UUID のみを受け付ける。
+	// 推測しやすい ID（MAC アドレス・時刻から作るバージョン 1 など）や手で作った ID を弾く
+	V4V7 Policy = "v4v7"
+)
+
+// ErrInvalid は方針に合わない UUID のエラー。
+var ErrInvalid = errors.New("invalid UUID")
+
+// Parse は any / v4v7 を Policy に変換する。空の場合は Any を返す。
+func Parse(s string) (Policy, error) {
+	switch p := Policy(strings.ToLower(strings.TrimSpace(s))); p {
+	case "":
+		return Any, nil
+	case Any, V4V7:
+		return p, nil
+	}
+	return "", fmt.Errorf("must be any or v4v7, got %q", s)
+}
+
+// Validate は s が p で受け付ける UUID かどうかを確認し、受け付けない場合は ErrInvalid を返す。
+// p が空（ゼロ値）の場合は Any として扱う。
+func (p Policy) Validate(s string) error {
+	if !wellFormed(s) {
+		return fmt.Errorf("%w: must be formatted as xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx", ErrInvalid)
+	}
+	if p != V4V7 {
+		return nil
+	}
+	// バージョンは 3 つ目のグループの先頭、バリアントは 4 つ目のグループの先頭の上位 2 ビット（10xx = RFC 4122）
+	if v := s[14]; v != '4' && v != '7' {
+		return fmt.Errorf("%w: must be a version 4 or 7 UUID, got version %c", ErrInvalid, v)
+	}
+	if !strings.ContainsRune("89abAB", rune(s[19])) {
+		return fmt.Errorf("%w: must be an RFC 4122 variant UUID", ErrInvalid)
+	}
+	return nil
+}
+
+// Valid は s が p で受け付ける UUID かどうかを返す。
+func (p Policy) Valid(s string) bool {
+	return p.Validate(s) == nil
+}
+
+// wellFormed は s が 8-4-4-4-12 桁の 16 進数の表記かどうかを返す。
+func wellFormed(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package uuidpolicy_test
+
+import (
+	"errors"
+	"testing"
+
+	"teamflow-shared/uuidpolicy"
+)
+
+func TestPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		wantAny bool
+		wantV47 bool
+	}{
+		{name: "v4", in: "3f1c2b9e-8d4a-4c1e-9f2a-6b7d8e9f0a1b", wantAny: true, wantV47: true},
+		{name: "v7", in: "01890a5d-ac96-774b-bcce-b302099a8057", wantAny: true, wantV47: true},
+		{name: "uppercase v4", in: "3F1C2B9E-8D4A-4C1E-AF2A-6B7D8E9F0A1B", wantAny: true, wantV47: true},
+		{name: "v1", in: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", wantAny: true},
+		{name: "nil", in: "00000000-0000-0000-0000-000000000000", wantAny: true},
+		{name: "v4 with non RFC 4122 variant", in: "3f1c2b9e-8d4a-4c1e-cf2a-6b7d8e9f0a1b", wantAny: true},
+		{name: "not hex", in: "3f1c2b9e-8d4a-4c1e-9f2a-6b7d8e9f0a1g"},
+		{name: "misplaced hyphen", in: "3f1c2b9e8-d4a-4c1e-9f2a-6b7d8e9f0a1b"},
+		{name: "no hyphens", in: "3f1c2b9e8d4a4c1e9f2a6b7d8e9f0a1b"},
+		{name: "braces", in: "{3f1c2b9e-8d4a-4c1e-9f2a-6b7d8e9f0a1b}"},
+		{name: "free form", in: "task-1"},
+		{name: "empty", in: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := uuidpolicy.Any.Valid(tt.in); got != tt.wantAny {
+				t.Errorf("Any.Valid(%q) = %v, want %v", tt.in, got, tt.wantAny)
+			}
+			if got := uuidpolicy.V4V7.Valid(tt.in); got != tt.wantV47 {
+				t.Errorf("V4V7.Valid(%q) = %v, want %v", tt.in, got, tt.wantV47)
+			}
+		})
+	}
+
+	// ゼロ値は Any として扱う
+	if !uuidpolicy.Policy("").Valid("6ba7b810-9dad-11d1-80b4-00c04fd430c8") {
+		t.Error("expected the zero policy to accept any version")
+	}
+	if err := uuidpolicy.V4V7.Validate("6ba7b810-9dad-11d1-80b4-00c04fd430c8"); !errors.Is(err, uuidpolicy.ErrInvalid) {
+		t.Errorf("expected ErrInvalid, got %v", err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	for in, want := range map[string]uuidpolicy.Policy{"": uuidpolicy.Any, "any": uuidpolicy.Any, " V4V7 ": uuidpolicy.V4V7} {
+		got, err := uuidpolicy.Parse(in)
+		if err != nil || got != want {
+			t.Errorf("Parse(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := uuidpolicy.Parse("v4"); err == nil {
+		t.Error("expected error for unknown policy")
+	}
+}
@@ -0,0 +1,90 @@
+// Package workspace は tasks / projects サービスで共通のワークスペース（テナント）の受け渡しを提供する。
+//
+// jwt / pat の Middleware が検証したトークンのワークスペースを X-Workspace-ID に設定し、
+// Middleware がそれを context に設定する。リポジトリは FromContext のワークスペースで
+// すべての問い合わせを絞り込むため、別のワークスペースのプロジェクト・タスクは存在しないものとして扱われる。
+// トークンを検証していないリクエストのヘッダはクライアントが送った値のため使わない（サービス API キーで認証した
+// サービス間の呼び出しは、元のリクエストのワークスペースをヘッダで引き継ぐ）。認証を設定していない場合は
+// X-User-ID と同じくヘッダをそのまま使う。
+// ヘッダが無い場合は DefaultID（単一テナントの既存データ）として扱う。
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"teamflow-shared/apierror"
+	"teamflow-shared/authz"
+)
+
+// Header はワークスペース ID を受け取るヘッダ（jwt / pat の Middleware が検証したトークンから設定する）。
+// サービス間の呼び出しでも、元のリクエストのワークスペースをこのヘッダで引き継ぐ。
+const Header = "X-Workspace-ID"
+
+// DefaultID はヘッダが無い場合のワークスペース（ワークスペースの導入前のデータもここに属する）。
+const DefaultID = "default"
+
+// MaxLength はワークスペース ID の最大長。
+const MaxLength = 64
+
+// ErrInvalidID はワークスペース ID の形式が不正な場合のエラー。
+var ErrInvalidID = errors.New("invalid workspace id")
+
+// Parse はヘッダの値からワークスペース ID を返す。空の場合は DefaultID。
+// 1〜MaxLength 文字の英数字・ハイフン・アンダースコア（先頭は英数字）のみ受け付け、それ以外は ErrInvalidID を返す。
+func Parse(s string) (string, error) {
+	if s == "" {
+		return DefaultID, nil
+	}
+	if len(s) > MaxLength || !isAlnum(s[0]) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidID, s)
+	}
+	for i := 1; i < len(s); i++ {
+		if c := s[i]; !isAlnum(c) && c != '-' && c != '_' {
+			return "", fmt.Errorf("%w: %q", ErrInvalidID, s)
+		}
+	}
+	return s, nil
+}
+
+func isAlnum(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+type contextKey struct{}
+
+// NewContext はワークスペース ID を持つ context を返す。
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext は context のワークスペース ID を返す。未設定の場合は DefaultID。
+func FromContext(ctx context.Context) string {
+	if id, _ := ctx.Value(contextKey{}).(string); id != "" {
+		return id
+	}
+	return DefaultID
+}
+
+// Middleware はリクエストの X-Workspace-ID を context に設定して next を呼ぶ。
+// verifying（jwt / pat の Middleware がトークンを検証する）場合にヘッダを使うのは、トークンを検証したリクエスト
+// （authz.IdentityVerified）と、trusted が true を返すリクエスト（サービス API キーで認証したサービス間の呼び出し）だけで、
+// それ以外は DefaultID として扱う。verifying が false（認証を設定していない）の場合は、X-User-ID と同じく
+// ヘッダを認証済みのゲートウェイが設定したものとしてそのまま使う。
+// trusted は nil でもよい。形式が不正な場合は 400（VALIDATION_ERROR）を返す。
+func Middleware(verifying bool, trusted func(r *http.Request) bool, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get(Header)
+		if verifying && !authz.IdentityVerified(r.Context()) && (trusted == nil || !trusted(r)) {
+			header = ""
+		}
+		id, err := Parse(header)
+		if err != nil {
+			apierror.Write(w, http.StatusBadRequest, apierror.New(apierror.CodeValidation, Header+" header is invalid"))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
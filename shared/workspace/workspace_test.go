@@ -0,0 +1,136 @@
+package workspace_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"teamflow-shared/authz"
+	"teamflow-shared/workspace"
+)
+
+func TestParse(t *testing.T) {
+	for _, tt := range []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "", want: workspace.DefaultID},
+		{in: "acme", want: "acme"},
+		{in: "3f2b6c1e-8d7a-4b7e-9a55-0f1c2d3e4f5a", want: "3f2b6c1e-8d7a-4b7e-9a55-0f1c2d3e4f5a"},
+		{in: "team_1", want: "team_1"},
+		{in: strings.Repeat("a", workspace.MaxLength), want: strings.Repeat("a", workspace.MaxLength)},
+		{in: strings.Repeat("a", workspace.MaxLength+1), wantErr: true},
+		{in: "-acme", wantErr: true},
+		{in: "has space", wantErr: true},
+		{in: "a/b", wantErr: true},
+		{in: "日本語", wantErr: true},
+	} {
+		got, err := workspace.Parse(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, workspace.ErrInvalidID) {
+				t.Errorf("Parse(%q) error = %v, want ErrInvalidID", tt.in, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if got := workspace.FromContext(context.Background()); got != workspace.DefaultID {
+		t.Errorf("FromContext() = %q, want %q", got, workspace.DefaultID)
+	}
+	if got := workspace.FromContext(workspace.NewContext(context.Background(), "acme")); got != "acme" {
+		t.Errorf("FromContext() = %q, want acme", got)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	var got string
+	handler := workspace.Middleware(true, func(r *http.Request) bool { return r.Header.Get("X-Service-Key") == "s3cr3t" },
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = workspace.FromContext(r.Context())
+		}))
+
+	tests := []struct {
+		name       string
+		header     string
+		verified   bool   // jwt / pat の Middleware がトークンを検証した
+		serviceKey string // X-Service-Key
+		wantStatus int
+		want       string
+	}{
+		{name: "verified token", header: "acme", verified: true, wantStatus: http.StatusOK, want: "acme"},
+		{name: "service call", header: "acme", serviceKey: "s3cr3t", wantStatus: http.StatusOK, want: "acme"},
+		// トークンを検証していないリクエストは、クライアントが送ったワークスペースを使わない
+		{name: "unverified header", header: "acme", wantStatus: http.StatusOK, want: workspace.DefaultID},
+		{name: "invalid service key", header: "acme", serviceKey: "wrong", wantStatus: http.StatusOK, want: workspace.DefaultID},
+		{name: "no header", verified: true, wantStatus: http.StatusOK, want: workspace.DefaultID},
+		{name: "invalid header", header: "a b", verified: true, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = ""
+			req := httptest.NewRequest(http.MethodGet, "/api/projects", nil)
+			if tt.header != "" {
+				req.Header.Set(workspace.Header, tt.header)
+			}
+			if tt.serviceKey != "" {
+				req.Header.Set("X-Service-Key", tt.serviceKey)
+			}
+			if tt.verified {
+				req = req.WithContext(authz.ContextWithVerifiedIdentity(req.Context()))
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got != tt.want {
+				t.Errorf("workspace = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddleware_AuthNotConfigured(t *testing.T) {
+	var got string
+	handler := workspace.Middleware(false, nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = workspace.FromContext(r.Context())
+	}))
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+		want       string
+	}{
+		// 認証を設定していない場合は、X-User-ID と同じくヘッダをそのまま使う
+		{name: "header", header: "acme", wantStatus: http.StatusOK, want: "acme"},
+		{name: "no header", wantStatus: http.StatusOK, want: workspace.DefaultID},
+		{name: "invalid header", header: "a b", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = ""
+			req := httptest.NewRequest(http.MethodGet, "/api/projects", nil)
+			if tt.header != "" {
+				req.Header.Set(workspace.Header, tt.header)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got != tt.want {
+				t.Errorf("workspace = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}